@@ -2,6 +2,7 @@
 package main
 
 import (
+	"errors"
 	"io"
 	"log"
 	"net/http"
@@ -9,12 +10,18 @@ import (
 	"strings"
 	"time"
 
+	"github.com/fastly/cli/pkg/api"
+	"github.com/fastly/cli/pkg/api/recorder"
 	"github.com/fastly/cli/pkg/app"
 	"github.com/fastly/cli/pkg/commands/update"
 	"github.com/fastly/cli/pkg/config"
 	fsterr "github.com/fastly/cli/pkg/errors"
+	"github.com/fastly/cli/pkg/interrupt"
 	"github.com/fastly/cli/pkg/revision"
 	"github.com/fastly/cli/pkg/sync"
+	"github.com/fastly/cli/pkg/text"
+	"github.com/fastly/cli/pkg/tools"
+	"github.com/fastly/go-fastly/v6/fastly"
 	"github.com/fatih/color"
 	"github.com/getsentry/sentry-go"
 )
@@ -50,8 +57,16 @@ func main() {
 	defer sentry.Flush(sentryTimeout)
 
 	// Some configuration options can come from env vars.
+	environ := parseEnv(os.Environ())
 	var env config.Environment
-	env.Read(parseEnv(os.Environ()))
+	env.Read(environ)
+
+	// API interactions can be recorded to, or replayed from, a fixture file.
+	rec, err := recorder.FromEnv(environ)
+	if err != nil {
+		fsterr.Deduce(err).Print(color.Error)
+		os.Exit(1)
+	}
 
 	// All of the work of building the set of commands and subcommands, wiring
 	// them together, picking which one to call, and executing it, occurs in a
@@ -61,8 +76,8 @@ func main() {
 	// user's real environment, etc.
 	var (
 		args                    = os.Args[1:]
-		clientFactory           = app.FastlyAPIClient
-		httpClient              = &http.Client{Timeout: time.Second * 5}
+		clientFactory           = recordedAPIClient(rec)
+		httpClient              = &http.Client{Timeout: time.Second * 5, Transport: rec.Transport(nil)}
 		in            io.Reader = os.Stdin
 		out           io.Writer = sync.NewWriter(color.Output)
 		versionerCLI            = update.NewGitHub(update.GitHubOpts{
@@ -75,6 +90,8 @@ func main() {
 			Repo:   "viceroy",
 			Binary: "viceroy",
 		})
+		versionerJSComputeRuntime = tools.JSComputeRuntimeVersioner()
+		versionerWasmOpt          = tools.WasmOptVersioner()
 	)
 
 	// We have to manually handle the inclusion of the verbose flag here because
@@ -91,26 +108,41 @@ func main() {
 	// Similarly for the --auto-yes/--non-interactive flags, we need access to
 	// these for handling interactive error prompts to the user, in case the CLI
 	// is being run in a CI environment.
-	var autoYes, nonInteractive bool
+	var autoYes, interactive, nonInteractive bool
 	for _, seg := range args {
 		if seg == "-y" || seg == "--auto-yes" {
 			autoYes = true
 		}
+		if seg == "--interactive" {
+			interactive = true
+		}
 		if seg == "-i" || seg == "--non-interactive" {
 			nonInteractive = true
 		}
 	}
 
+	// The terminal determines whether the user is able to answer prompts, and
+	// whether the output is coloured (until the --color flag is parsed).
+	terminal := text.DetectTerminal(in)
+	configIn := in
+	if !interactive && !terminal.Interactive() {
+		configIn = text.NonInteractiveReader{}
+	}
+	text.SetColor(text.ColorOptions{
+		NoColor:    env.NoColor,
+		ForceColor: env.ForceColor,
+	}.Enabled(terminal))
+
 	// Extract a subset of configuration options from the local application directory.
 	var file config.File
 	file.SetAutoYes(autoYes)
 	file.SetNonInteractive(nonInteractive)
 
 	// The CLI relies on a valid configuration, otherwise we can't continue.
-	err = file.Read(config.FilePath, in, out, fsterr.Log, verboseOutput)
+	err = file.Read(config.FilePath, configIn, out, fsterr.Log, verboseOutput)
 	if err != nil {
 		fsterr.Deduce(err).Print(color.Error)
-		os.Exit(1)
+		os.Exit(int(fsterr.Exit(err)))
 	}
 
 	// Main is basically just a shim to call Run, so we do that here.
@@ -125,12 +157,18 @@ func main() {
 		Stdin:      in,
 		Stdout:     out,
 		Versioners: app.Versioners{
-			CLI:     versionerCLI,
-			Viceroy: versionerViceroy,
+			CLI:              versionerCLI,
+			JSComputeRuntime: versionerJSComputeRuntime,
+			Viceroy:          versionerViceroy,
+			WasmOpt:          versionerWasmOpt,
 		},
 	}
 	err = app.Run(opts)
 
+	if recErr := rec.Save(); recErr != nil {
+		fsterr.Deduce(recErr).Print(color.Error)
+	}
+
 	// NOTE: We persist any error log entries to disk before attempting to handle
 	// a possible error response from app.Run as there could be errors recorded
 	// during the execution flow but were otherwise handled without bubbling an
@@ -148,7 +186,26 @@ func main() {
 		// flush the Sentry buffer here (as well as the deferred call at the top of
 		// the main function).
 		sentry.Flush(sentryTimeout)
-		os.Exit(1)
+		if errors.Is(err, interrupt.ErrInterrupted) {
+			os.Exit(interrupt.ExitCode)
+		}
+		os.Exit(int(fsterr.Exit(err)))
+	}
+}
+
+// recordedAPIClient returns an app.APIClientFactory whose requests are
+// recorded or replayed by rec. A nil rec returns app.FastlyAPIClient.
+func recordedAPIClient(rec *recorder.Recorder) app.APIClientFactory {
+	if rec == nil {
+		return app.FastlyAPIClient
+	}
+	return func(token, endpoint string) (api.Interface, error) {
+		client, err := fastly.NewClientForEndpoint(token, endpoint)
+		if err != nil {
+			return nil, err
+		}
+		client.HTTPClient.Transport = rec.Transport(client.HTTPClient.Transport)
+		return client, nil
 	}
 }
 
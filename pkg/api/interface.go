@@ -233,7 +233,9 @@ type Interface interface {
 	CreateVCL(*fastly.CreateVCLInput) (*fastly.VCL, error)
 	ListVCLs(*fastly.ListVCLsInput) ([]*fastly.VCL, error)
 	GetVCL(*fastly.GetVCLInput) (*fastly.VCL, error)
+	GetGeneratedVCL(*fastly.GetGeneratedVCLInput) (*fastly.VCL, error)
 	UpdateVCL(*fastly.UpdateVCLInput) (*fastly.VCL, error)
+	ActivateVCL(*fastly.ActivateVCLInput) (*fastly.VCL, error)
 	DeleteVCL(*fastly.DeleteVCLInput) error
 
 	CreateSnippet(i *fastly.CreateSnippetInput) (*fastly.Snippet, error)
@@ -276,6 +278,8 @@ type Interface interface {
 	UpdateUser(i *fastly.UpdateUserInput) (*fastly.User, error)
 	ResetUserPassword(i *fastly.ResetUserPasswordInput) error
 
+	CreateServiceAuthorization(i *fastly.CreateServiceAuthorizationInput) (*fastly.ServiceAuthorization, error)
+
 	BatchDeleteTokens(i *fastly.BatchDeleteTokensInput) error
 	CreateToken(i *fastly.CreateTokenInput) (*fastly.Token, error)
 	DeleteToken(i *fastly.DeleteTokenInput) error
@@ -321,6 +325,25 @@ type Interface interface {
 	GetTLSSubscription(i *fastly.GetTLSSubscriptionInput) (*fastly.TLSSubscription, error)
 	ListTLSSubscriptions(i *fastly.ListTLSSubscriptionsInput) ([]*fastly.TLSSubscription, error)
 	UpdateTLSSubscription(i *fastly.UpdateTLSSubscriptionInput) (*fastly.TLSSubscription, error)
+
+	GetAPIEvent(i *fastly.GetAPIEventInput) (*fastly.Event, error)
+	GetAPIEvents(i *fastly.GetAPIEventsFilterInput) (fastly.GetAPIEventsResponse, error)
+
+	CreateERL(i *fastly.CreateERLInput) (*fastly.ERL, error)
+	DeleteERL(i *fastly.DeleteERLInput) error
+	GetERL(i *fastly.GetERLInput) (*fastly.ERL, error)
+	ListERLs(i *fastly.ListERLsInput) ([]*fastly.ERL, error)
+	UpdateERL(i *fastly.UpdateERLInput) (*fastly.ERL, error)
+
+	CreateDirector(i *fastly.CreateDirectorInput) (*fastly.Director, error)
+	DeleteDirector(i *fastly.DeleteDirectorInput) error
+	GetDirector(i *fastly.GetDirectorInput) (*fastly.Director, error)
+	ListDirectors(i *fastly.ListDirectorsInput) ([]*fastly.Director, error)
+	UpdateDirector(i *fastly.UpdateDirectorInput) (*fastly.Director, error)
+	CreateDirectorBackend(i *fastly.CreateDirectorBackendInput) (*fastly.DirectorBackend, error)
+	DeleteDirectorBackend(i *fastly.DeleteDirectorBackendInput) error
+
+	GetOriginMetricsForService(i *fastly.GetOriginMetricsInput) (*fastly.OriginInspector, error)
 }
 
 // RealtimeStatsInterface is the subset of go-fastly's realtime stats API used here.
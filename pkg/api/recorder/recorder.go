@@ -0,0 +1,284 @@
+// Package recorder captures API interactions into fixture files (cassettes)
+// and replays them, so that tests can exercise commands against real API
+// responses without a hand-written mock of every API call.
+package recorder
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/fastly/cli/pkg/env"
+	fsterr "github.com/fastly/cli/pkg/errors"
+)
+
+// Mode determines whether API interactions are recorded or replayed.
+type Mode string
+
+const (
+	// ModeOff sends requests to the API as normal.
+	ModeOff Mode = ""
+	// ModeRecord sends requests to the API and records the interactions.
+	ModeRecord Mode = "record"
+	// ModeReplay answers requests from previously recorded interactions.
+	ModeReplay Mode = "replay"
+)
+
+// Redacted replaces sensitive values in recorded interactions.
+const Redacted = "REDACTED"
+
+// sensitiveHeaders are response headers never written to a cassette.
+var sensitiveHeaders = []string{"Authorization", "Fastly-Key", "Set-Cookie"}
+
+// Interaction is a recorded request and its response.
+type Interaction struct {
+	Request  Request  `json:"request"`
+	Response Response `json:"response"`
+}
+
+// Request is a recorded request. The URL excludes the scheme and host so that
+// a cassette can be replayed against any API endpoint.
+type Request struct {
+	Method string `json:"method"`
+	URL    string `json:"url"`
+	Body   string `json:"body,omitempty"`
+}
+
+// Response is a recorded response.
+type Response struct {
+	StatusCode int         `json:"status_code"`
+	Header     http.Header `json:"header,omitempty"`
+	Body       string      `json:"body,omitempty"`
+}
+
+// Cassette is a list of recorded interactions.
+type Cassette struct {
+	Interactions []Interaction `json:"interactions"`
+}
+
+// Load reads a cassette from disk.
+func Load(path string) (*Cassette, error) {
+	// gosec flagged this:
+	// G304 (CWE-22): Potential file inclusion via variable
+	// Disabling as the path is provided by the user or the test suite.
+	/* #nosec */
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading cassette: %w", err)
+	}
+	var c Cassette
+	if err := json.Unmarshal(data, &c); err != nil {
+		return nil, fmt.Errorf("error parsing cassette %s: %w", path, err)
+	}
+	return &c, nil
+}
+
+// Save writes the cassette to disk, creating any missing directories.
+func (c *Cassette) Save(path string) error {
+	data, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return fmt.Errorf("error encoding cassette: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return fmt.Errorf("error creating cassette directory: %w", err)
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0o600); err != nil {
+		return fmt.Errorf("error writing cassette: %w", err)
+	}
+	return nil
+}
+
+// Recorder records or replays the requests sent via its transports.
+type Recorder struct {
+	mode Mode
+	path string
+
+	mu       sync.Mutex
+	cassette *Cassette
+	used     []bool
+}
+
+// New returns a Recorder for the cassette at path. In replay mode the
+// cassette must already exist.
+func New(mode Mode, path string) (*Recorder, error) {
+	if path == "" {
+		return nil, fmt.Errorf("%s is required when %s is set", env.Cassette, env.Recorder)
+	}
+	r := &Recorder{mode: mode, path: path, cassette: &Cassette{}}
+	switch mode {
+	case ModeRecord:
+	case ModeReplay:
+		c, err := Load(path)
+		if err != nil {
+			return nil, err
+		}
+		r.cassette = c
+		r.used = make([]bool, len(c.Interactions))
+	default:
+		return nil, fmt.Errorf("invalid %s value '%s': must be '%s' or '%s'", env.Recorder, mode, ModeRecord, ModeReplay)
+	}
+	return r, nil
+}
+
+// FromEnv returns the Recorder configured by the given environment, or nil if
+// recording is disabled.
+func FromEnv(state map[string]string) (*Recorder, error) {
+	mode := Mode(state[env.Recorder])
+	if mode == ModeOff {
+		return nil, nil
+	}
+	return New(mode, state[env.Cassette])
+}
+
+// Transport wraps rt so that its requests are recorded or replayed. A nil rt
+// wraps http.DefaultTransport.
+func (r *Recorder) Transport(rt http.RoundTripper) http.RoundTripper {
+	if r == nil {
+		return rt
+	}
+	if rt == nil {
+		rt = http.DefaultTransport
+	}
+	return &transport{r: r, rt: rt}
+}
+
+// Save writes the recorded interactions to the cassette. It's a no-op when
+// replaying.
+func (r *Recorder) Save() error {
+	if r == nil || r.mode != ModeRecord {
+		return nil
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.cassette.Save(r.path)
+}
+
+// Unused returns the recorded interactions that were never replayed, which
+// usually means a command no longer makes a request the fixture expects.
+func (r *Recorder) Unused() []Interaction {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var unused []Interaction
+	for i, used := range r.used {
+		if !used {
+			unused = append(unused, r.cassette.Interactions[i])
+		}
+	}
+	return unused
+}
+
+// record appends a sanitized interaction to the cassette.
+func (r *Recorder) record(req Request, res Response, token string) {
+	req.URL = sanitize(req.URL, token)
+	req.Body = sanitize(req.Body, token)
+	res.Body = sanitize(res.Body, token)
+	for _, h := range sensitiveHeaders {
+		res.Header.Del(h)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.cassette.Interactions = append(r.cassette.Interactions, Interaction{Request: req, Response: res})
+}
+
+// replay returns the first unused interaction matching the request.
+func (r *Recorder) replay(req Request, token string) (Response, bool) {
+	req.URL = sanitize(req.URL, token)
+	req.Body = sanitize(req.Body, token)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for i, in := range r.cassette.Interactions {
+		if r.used[i] || in.Request != req {
+			continue
+		}
+		r.used[i] = true
+		return in.Response, true
+	}
+	return Response{}, false
+}
+
+// transport is a http.RoundTripper that records or replays requests.
+type transport struct {
+	r  *Recorder
+	rt http.RoundTripper
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	body, err := readBody(&req.Body)
+	if err != nil {
+		return nil, err
+	}
+	rec := Request{
+		Method: req.Method,
+		URL:    req.URL.RequestURI(),
+		Body:   body,
+	}
+	token := req.Header.Get("Fastly-Key")
+
+	if t.r.mode == ModeReplay {
+		res, ok := t.r.replay(rec, token)
+		if !ok {
+			return nil, fsterr.RemediationError{
+				Inner:       fmt.Errorf("no recorded interaction for %s %s", rec.Method, rec.URL),
+				Remediation: fmt.Sprintf("Re-record the fixture by running the command with %s=%s.", env.Recorder, ModeRecord),
+			}
+		}
+		return &http.Response{
+			Status:        fmt.Sprintf("%d %s", res.StatusCode, http.StatusText(res.StatusCode)),
+			StatusCode:    res.StatusCode,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        res.Header.Clone(),
+			Body:          io.NopCloser(strings.NewReader(res.Body)),
+			ContentLength: int64(len(res.Body)),
+			Request:       req,
+		}, nil
+	}
+
+	res, err := t.rt.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	resBody, err := readBody(&res.Body)
+	if err != nil {
+		return nil, err
+	}
+	t.r.record(rec, Response{
+		StatusCode: res.StatusCode,
+		Header:     res.Header.Clone(),
+		Body:       resBody,
+	}, token)
+	return res, nil
+}
+
+// readBody reads the body and replaces it with an unread copy.
+func readBody(body *io.ReadCloser) (string, error) {
+	if *body == nil || *body == http.NoBody {
+		return "", nil
+	}
+	data, err := io.ReadAll(*body)
+	_ = (*body).Close()
+	if err != nil {
+		return "", err
+	}
+	*body = io.NopCloser(bytes.NewReader(data))
+	return string(data), nil
+}
+
+// sanitize removes the token, and anything else that looks like a credential,
+// from s.
+func sanitize(s, token string) string {
+	if token != "" {
+		s = strings.ReplaceAll(s, token, Redacted)
+	}
+	return fsterr.FilterToken(s)
+}
@@ -0,0 +1,83 @@
+package recorder_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/fastly/cli/pkg/api/recorder"
+	"github.com/fastly/cli/pkg/testutil"
+)
+
+func TestRecordReplay(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Set-Cookie", "session=secret")
+		_, _ = io.WriteString(w, `{"access_token":"`+r.Header.Get("Fastly-Key")+`","name":"example"}`)
+	}))
+	defer srv.Close()
+
+	path := filepath.Join(t.TempDir(), "fixtures", "cassette.json")
+
+	rec, err := recorder.New(recorder.ModeRecord, path)
+	testutil.AssertNoError(t, err)
+	body := get(t, &http.Client{Transport: rec.Transport(nil)}, srv.URL+"/service?page=1")
+	testutil.AssertString(t, `{"access_token":"abc123","name":"example"}`, body)
+	testutil.AssertNoError(t, rec.Save())
+
+	c, err := recorder.Load(path)
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, 1, len(c.Interactions))
+	in := c.Interactions[0]
+	testutil.AssertString(t, "/service?page=1", in.Request.URL)
+	testutil.AssertString(t, `{"access_token":"REDACTED","name":"example"}`, in.Response.Body)
+	testutil.AssertString(t, "", in.Response.Header.Get("Set-Cookie"))
+
+	rec, err = recorder.New(recorder.ModeReplay, path)
+	testutil.AssertNoError(t, err)
+	client := &http.Client{Transport: rec.Transport(nil)}
+	testutil.AssertEqual(t, 1, len(rec.Unused()))
+	body = get(t, client, "https://api.example.com/service?page=1")
+	testutil.AssertString(t, `{"access_token":"REDACTED","name":"example"}`, body)
+	testutil.AssertEqual(t, 0, len(rec.Unused()))
+
+	// Each interaction is only replayed once.
+	req, err := http.NewRequest(http.MethodGet, "https://api.example.com/service?page=1", nil)
+	testutil.AssertNoError(t, err)
+	_, err = client.Do(req)
+	testutil.AssertErrorContains(t, err, "no recorded interaction for GET /service?page=1")
+}
+
+func TestNew(t *testing.T) {
+	_, err := recorder.New(recorder.ModeRecord, "")
+	testutil.AssertErrorContains(t, err, "FASTLY_RECORDER_CASSETTE is required")
+
+	_, err = recorder.New("rewind", "cassette.json")
+	testutil.AssertErrorContains(t, err, "invalid FASTLY_RECORDER value 'rewind'")
+
+	_, err = recorder.New(recorder.ModeReplay, filepath.Join(t.TempDir(), "missing.json"))
+	testutil.AssertErrorContains(t, err, "error reading cassette")
+
+	rec, err := recorder.FromEnv(map[string]string{})
+	testutil.AssertNoError(t, err)
+	if rec != nil {
+		t.Fatal("want nil recorder when FASTLY_RECORDER is unset")
+	}
+}
+
+func get(t *testing.T, c *http.Client, url string) string {
+	t.Helper()
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	testutil.AssertNoError(t, err)
+	req.Header.Set("Fastly-Key", "abc123")
+	res, err := c.Do(req)
+	testutil.AssertNoError(t, err)
+	defer res.Body.Close()
+	var b strings.Builder
+	_, err = io.Copy(&b, res.Body)
+	testutil.AssertNoError(t, err)
+	return b.String()
+}
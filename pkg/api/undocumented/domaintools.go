@@ -0,0 +1,97 @@
+package undocumented
+
+import (
+	"net/url"
+	"strings"
+)
+
+// DomainToolsSuggest is the API endpoint for domain name suggestions.
+//
+// NOTE: go-fastly doesn't support the domain tools API so we call it directly.
+const DomainToolsSuggest = "/domain-management/v1/tools/suggest"
+
+// DomainToolsStatus is the API endpoint for the status of a domain name.
+const DomainToolsStatus = "/domain-management/v1/tools/status"
+
+// DomainSuggestion is a domain name suggested for a query.
+type DomainSuggestion struct {
+	Domain    string `json:"domain"`
+	Subdomain string `json:"subdomain"`
+	Zone      string `json:"zone"`
+}
+
+// DomainStatus is the registration status of a domain name.
+//
+// The status is a space separated list of values, e.g. 'undelegated inactive'
+// for a domain that's available.
+type DomainStatus struct {
+	Domain string `json:"domain"`
+	Status string `json:"status"`
+	Tags   string `json:"tags"`
+	Zone   string `json:"zone"`
+}
+
+// Available indicates whether the domain name is free to use.
+func (s DomainStatus) Available() bool {
+	var inactive bool
+	for _, v := range strings.Fields(s.Status) {
+		switch v {
+		case "inactive":
+			inactive = true
+		case "active", "claimed", "invalid", "reserved":
+			return false
+		}
+	}
+	return inactive
+}
+
+// InUse indicates whether the domain name is registered or otherwise in use.
+func (s DomainStatus) InUse() bool {
+	for _, v := range strings.Fields(s.Status) {
+		if v == "active" {
+			return true
+		}
+	}
+	return false
+}
+
+// DomainSuggestInput is the query to suggest domain names for.
+type DomainSuggestInput struct {
+	// Defaults are the zones to always include suggestions for, e.g. com.
+	Defaults []string
+	// Keywords are used to suggest related domain names.
+	Keywords []string
+	Query    string
+}
+
+// SuggestDomains returns domain names suggested for the query.
+func (c *Client) SuggestDomains(i DomainSuggestInput) ([]*DomainSuggestion, error) {
+	params := url.Values{}
+	params.Set("query", i.Query)
+	if len(i.Defaults) > 0 {
+		params.Set("defaults", strings.Join(i.Defaults, ","))
+	}
+	if len(i.Keywords) > 0 {
+		params.Set("keywords", strings.Join(i.Keywords, ","))
+	}
+
+	var r struct {
+		Results []*DomainSuggestion `json:"results"`
+	}
+	if err := c.Get(DomainToolsSuggest, params, &r); err != nil {
+		return nil, err
+	}
+	return r.Results, nil
+}
+
+// GetDomainStatus returns the registration status of the domain name.
+func (c *Client) GetDomainStatus(domain string) (*DomainStatus, error) {
+	params := url.Values{}
+	params.Set("domain", domain)
+
+	var s DomainStatus
+	if err := c.Get(DomainToolsStatus, params, &s); err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
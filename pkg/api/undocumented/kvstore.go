@@ -0,0 +1,220 @@
+package undocumented
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// KVStores is the API endpoint for KV stores.
+//
+// NOTE: go-fastly doesn't yet support KV stores so we call the API directly.
+const KVStores = "/resources/stores/kv"
+
+// KVStoreKeys is the API endpoint for the keys of a KV store.
+const KVStoreKeys = KVStores + "/%s/keys"
+
+// KVStore is a store of key/value pairs that Compute@Edge services can read,
+// once it's linked to the service (see CreateResourceLink).
+type KVStore struct {
+	CreatedAt *time.Time `json:"created_at"`
+	ID        string     `json:"id"`
+	Name      string     `json:"name"`
+	UpdatedAt *time.Time `json:"updated_at"`
+}
+
+// kvStoreMeta describes the pagination of a list response.
+type kvStoreMeta struct {
+	NextCursor string `json:"next_cursor"`
+}
+
+// ListKVStores returns every KV store of the account.
+func (c *Client) ListKVStores() ([]*KVStore, error) {
+	var (
+		stores []*KVStore
+		cursor string
+	)
+	for {
+		params := url.Values{}
+		if cursor != "" {
+			params.Set("cursor", cursor)
+		}
+		var r struct {
+			Data []*KVStore  `json:"data"`
+			Meta kvStoreMeta `json:"meta"`
+		}
+		if err := c.Get(KVStores, params, &r); err != nil {
+			return nil, err
+		}
+		stores = append(stores, r.Data...)
+		if r.Meta.NextCursor == "" || r.Meta.NextCursor == cursor {
+			return stores, nil
+		}
+		cursor = r.Meta.NextCursor
+	}
+}
+
+// CreateKVStore creates a KV store with the given name.
+func (c *Client) CreateKVStore(name string) (*KVStore, error) {
+	r := KVStore{Name: name}
+	err := c.Do(Request{
+		Method: http.MethodPost,
+		Path:   KVStores,
+		JSON:   map[string]string{"name": name},
+	}, &r)
+	if err != nil {
+		return nil, err
+	}
+	return &r, nil
+}
+
+// ListKVStoreKeys returns every key of the KV store with the given prefix, or
+// every key when the prefix is empty.
+func (c *Client) ListKVStoreKeys(storeID, prefix string) ([]string, error) {
+	var (
+		keys   []string
+		cursor string
+	)
+	for {
+		params := url.Values{}
+		if cursor != "" {
+			params.Set("cursor", cursor)
+		}
+		if prefix != "" {
+			params.Set("prefix", prefix)
+		}
+		var r struct {
+			Data []string    `json:"data"`
+			Meta kvStoreMeta `json:"meta"`
+		}
+		if err := c.Get(fmt.Sprintf(KVStoreKeys, url.PathEscape(storeID)), params, &r); err != nil {
+			return nil, err
+		}
+		keys = append(keys, r.Data...)
+		if r.Meta.NextCursor == "" || r.Meta.NextCursor == cursor {
+			return keys, nil
+		}
+		cursor = r.Meta.NextCursor
+	}
+}
+
+// GetKVStoreKey returns the value of the key.
+func (c *Client) GetKVStoreKey(storeID, key string) ([]byte, error) {
+	var value []byte
+	if err := c.Get(kvStoreKeyPath(storeID, key), nil, &value); err != nil {
+		return nil, err
+	}
+	return value, nil
+}
+
+// ByteRange is a range of the bytes of a value, from First to Last inclusive.
+// A Last of -1 is the end of the value, and a negative First is the last
+// -First bytes of the value (e.g. {First: -100, Last: -1}).
+type ByteRange struct {
+	First int64
+	Last  int64
+}
+
+// String returns the range as the value of a Range header.
+func (r ByteRange) String() string {
+	switch {
+	case r.First < 0:
+		return fmt.Sprintf("bytes=%d", r.First)
+	case r.Last < 0:
+		return fmt.Sprintf("bytes=%d-", r.First)
+	default:
+		return fmt.Sprintf("bytes=%d-%d", r.First, r.Last)
+	}
+}
+
+// KVStoreValue is the value of a key being read, which the caller must close.
+type KVStoreValue struct {
+	io.ReadCloser
+	// Size is the length of the value (or of the range of it) being read, or
+	// -1 when it's unknown.
+	Size int64
+}
+
+// OpenKVStoreKey opens the value of the key for reading, so that a large value
+// can be streamed rather than read into memory. When rng isn't nil only the
+// bytes of the value in the range are read.
+func (c *Client) OpenKVStoreKey(storeID, key string, rng *ByteRange) (*KVStoreValue, error) {
+	r := Request{Method: http.MethodGet, Path: kvStoreKeyPath(storeID, key)}
+	if rng != nil {
+		r.Header = http.Header{"Range": {rng.String()}}
+	}
+	res, err := c.Send(r)
+	if err != nil {
+		return nil, err
+	}
+	v := &KVStoreValue{ReadCloser: res.Body, Size: res.ContentLength}
+	if rng == nil || res.StatusCode == http.StatusPartialContent {
+		return v, nil
+	}
+
+	// NOTE: The API returns the whole value when it doesn't support ranges, in
+	// which case the bytes outside of the range are skipped.
+	first, size := rng.First, res.ContentLength
+	if first < 0 {
+		if size < 0 {
+			res.Body.Close()
+			return nil, fmt.Errorf("error reading the range %s: the length of the value is unknown", rng)
+		}
+		first = size + first
+		if first < 0 {
+			first = 0
+		}
+	}
+	if _, err := io.CopyN(io.Discard, res.Body, first); err != nil {
+		res.Body.Close()
+		if errors.Is(err, io.EOF) {
+			return nil, fmt.Errorf("error reading the range %s: the value is shorter than the range", rng)
+		}
+		return nil, err
+	}
+	if size >= 0 {
+		size -= first
+	}
+	if rng.First >= 0 && rng.Last >= 0 {
+		n := rng.Last - rng.First + 1
+		v.ReadCloser = struct {
+			io.Reader
+			io.Closer
+		}{io.LimitReader(res.Body, n), res.Body}
+		if size < 0 || n < size {
+			size = n
+		}
+	}
+	v.Size = size
+	return v, nil
+}
+
+// InsertKVStoreKey sets the value of the key, replacing any existing value and
+// metadata. The metadata is an arbitrary string (e.g. JSON) stored alongside
+// the value, which isn't set when empty.
+func (c *Client) InsertKVStoreKey(storeID, key string, value io.Reader, metadata string) error {
+	var header http.Header
+	if metadata != "" {
+		header = http.Header{"Metadata": {metadata}}
+	}
+	return c.Do(Request{
+		Method:      http.MethodPut,
+		Path:        kvStoreKeyPath(storeID, key),
+		Body:        value,
+		ContentType: "application/octet-stream",
+		Header:      header,
+	}, nil)
+}
+
+// DeleteKVStoreKey deletes the key.
+func (c *Client) DeleteKVStoreKey(storeID, key string) error {
+	return c.Delete(kvStoreKeyPath(storeID, key))
+}
+
+// kvStoreKeyPath returns the API endpoint for the key of a KV store.
+func kvStoreKeyPath(storeID, key string) string {
+	return fmt.Sprintf(KVStoreKeys+"/%s", url.PathEscape(storeID), url.PathEscape(key))
+}
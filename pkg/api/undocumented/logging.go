@@ -0,0 +1,82 @@
+package undocumented
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// LoggingEndpoints is the API endpoint for the logging endpoints of a provider
+// on a service version.
+//
+// NOTE: go-fastly only lists the endpoints of one provider at a time, and
+// only as provider specific types, so we call the API directly.
+const LoggingEndpoints = "/service/%s/version/%d/logging/%s"
+
+// LoggingProviders maps the names of the logging commands to the API path of
+// their provider.
+var LoggingProviders = map[string]string{
+	"azureblob":     "azureblob",
+	"bigquery":      "bigquery",
+	"cloudfiles":    "cloudfiles",
+	"datadog":       "datadog",
+	"digitalocean":  "digitalocean",
+	"elasticsearch": "elasticsearch",
+	"ftp":           "ftp",
+	"gcs":           "gcs",
+	"googlepubsub":  "pubsub",
+	"heroku":        "heroku",
+	"honeycomb":     "honeycomb",
+	"https":         "https",
+	"kafka":         "kafka",
+	"kinesis":       "kinesis",
+	"logentries":    "logentries",
+	"loggly":        "loggly",
+	"logshuttle":    "logshuttle",
+	"newrelic":      "newrelic",
+	"openstack":     "openstack",
+	"papertrail":    "papertrail",
+	"s3":            "s3",
+	"scalyr":        "scalyr",
+	"sftp":          "sftp",
+	"splunk":        "splunk",
+	"sumologic":     "sumologic",
+	"syslog":        "syslog",
+}
+
+// LoggingEndpoint is the configuration common to the logging endpoints of all
+// providers.
+type LoggingEndpoint struct {
+	Name              string      `json:"name"`
+	Type              string      `json:"type"`
+	Placement         string      `json:"placement"`
+	ResponseCondition string      `json:"response_condition"`
+	FormatVersion     json.Number `json:"format_version,omitempty"`
+	CreatedAt         *time.Time  `json:"created_at"`
+	UpdatedAt         *time.Time  `json:"updated_at"`
+}
+
+// LoggingEndpointsInput identifies the service version the logging endpoints
+// belong to.
+type LoggingEndpointsInput struct {
+	ServiceID      string
+	ServiceVersion int
+}
+
+// ListLoggingEndpoints returns the logging endpoints of the given provider,
+// named as per LoggingProviders, on the service version.
+func (c *Client) ListLoggingEndpoints(i LoggingEndpointsInput, provider string) ([]*LoggingEndpoint, error) {
+	segment, ok := LoggingProviders[provider]
+	if !ok {
+		return nil, fmt.Errorf("unrecognised logging provider: %s", provider)
+	}
+
+	var es []*LoggingEndpoint
+	if err := c.Get(fmt.Sprintf(LoggingEndpoints, i.ServiceID, i.ServiceVersion, segment), nil, &es); err != nil {
+		return nil, err
+	}
+	for _, e := range es {
+		e.Type = provider
+	}
+	return es, nil
+}
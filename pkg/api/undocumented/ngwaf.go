@@ -0,0 +1,182 @@
+package undocumented
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// WAFWorkspaces is the API endpoint for Next-Gen WAF workspaces.
+//
+// NOTE: go-fastly only supports the legacy WAF API so we call the Next-Gen WAF
+// API directly.
+const WAFWorkspaces = "/ngwaf/v1/workspaces"
+
+// WAFEvents is the API endpoint for the events recorded by a Next-Gen WAF
+// workspace.
+const WAFEvents = WAFWorkspaces + "/%s/events"
+
+// WAFExclusions is the API endpoint for the rule exclusions of a Next-Gen WAF
+// workspace.
+const WAFExclusions = WAFWorkspaces + "/%s/exclusions"
+
+// WAFServiceConfiguration is the API endpoint for the Next-Gen WAF
+// configuration of a service, which names the workspace protecting it.
+const WAFServiceConfiguration = "/enabled-products/v1/ngwaf/services/%s/configuration"
+
+// WAFWorkspaceModes are the modes a Next-Gen WAF workspace can be switched
+// between.
+var WAFWorkspaceModes = []string{"block", "log", "off"}
+
+// WAFWorkspace is a Next-Gen WAF workspace, which protects the services
+// configured to use it.
+type WAFWorkspace struct {
+	CreatedAt   *time.Time `json:"created_at"`
+	Description string     `json:"description"`
+	ID          string     `json:"id"`
+	Mode        string     `json:"mode"`
+	Name        string     `json:"name"`
+}
+
+// WAFEvent is a group of requests from a single source that the Next-Gen WAF
+// flagged or acted upon.
+type WAFEvent struct {
+	Action       string         `json:"action"`
+	Country      string         `json:"remote_country_code"`
+	ID           string         `json:"id"`
+	Reasons      map[string]int `json:"reasons"`
+	RequestCount int            `json:"request_count"`
+	Source       string         `json:"source"`
+	Timestamp    *time.Time     `json:"timestamp"`
+	Type         string         `json:"type"`
+}
+
+// WAFEventsInput identifies the workspace to fetch events for.
+type WAFEventsInput struct {
+	From        time.Time
+	Limit       int
+	WorkspaceID string
+}
+
+// WAFExclusion stops the Next-Gen WAF from tagging requests to a path with
+// the given signals (e.g. SQLI), to suppress false positives.
+type WAFExclusion struct {
+	CreatedAt   *time.Time `json:"created_at"`
+	Description string     `json:"description"`
+	ID          string     `json:"id"`
+	Path        string     `json:"path"`
+	Signals     []string   `json:"signals"`
+	UpdatedAt   *time.Time `json:"updated_at"`
+}
+
+// WAFExclusionInput describes the fields of a rule exclusion to create or
+// update. Fields that are nil (or empty) are left unchanged by an update.
+type WAFExclusionInput struct {
+	Description *string  `json:"description,omitempty"`
+	Path        *string  `json:"path,omitempty"`
+	Signals     []string `json:"signals,omitempty"`
+}
+
+// ListWAFWorkspaces returns every Next-Gen WAF workspace of the account.
+func (c *Client) ListWAFWorkspaces() ([]*WAFWorkspace, error) {
+	var r struct {
+		Data []*WAFWorkspace `json:"data"`
+	}
+	if err := c.Get(WAFWorkspaces, nil, &r); err != nil {
+		return nil, err
+	}
+	return r.Data, nil
+}
+
+// UpdateWAFWorkspaceMode switches the workspace to the mode, i.e. whether
+// requests are blocked, only logged or not inspected at all.
+func (c *Client) UpdateWAFWorkspaceMode(workspaceID, mode string) (*WAFWorkspace, error) {
+	// NOTE: If the API doesn't echo the workspace back, report what was
+	// requested.
+	r := WAFWorkspace{ID: workspaceID, Mode: mode}
+	err := c.Do(Request{
+		Method: http.MethodPatch,
+		Path:   fmt.Sprintf(WAFWorkspaces+"/%s", url.PathEscape(workspaceID)),
+		JSON:   map[string]string{"mode": mode},
+	}, &r)
+	if err != nil {
+		return nil, err
+	}
+	return &r, nil
+}
+
+// ServiceWAFWorkspace returns the ID of the workspace protecting the service.
+func (c *Client) ServiceWAFWorkspace(serviceID string) (string, error) {
+	var r struct {
+		WorkspaceID string `json:"workspace_id"`
+	}
+	if err := c.Get(fmt.Sprintf(WAFServiceConfiguration, url.PathEscape(serviceID)), nil, &r); err != nil {
+		return "", err
+	}
+	return r.WorkspaceID, nil
+}
+
+// ListWAFEvents returns the events recorded by the workspace since From.
+func (c *Client) ListWAFEvents(i WAFEventsInput) ([]*WAFEvent, error) {
+	params := url.Values{}
+	if !i.From.IsZero() {
+		params.Set("from", i.From.UTC().Format(time.RFC3339))
+	}
+	if i.Limit > 0 {
+		params.Set("limit", strconv.Itoa(i.Limit))
+	}
+
+	var r struct {
+		Data []*WAFEvent `json:"data"`
+	}
+	if err := c.Get(fmt.Sprintf(WAFEvents, url.PathEscape(i.WorkspaceID)), params, &r); err != nil {
+		return nil, err
+	}
+	return r.Data, nil
+}
+
+// ListWAFExclusions returns the rule exclusions of the workspace.
+func (c *Client) ListWAFExclusions(workspaceID string) ([]*WAFExclusion, error) {
+	var r struct {
+		Data []*WAFExclusion `json:"data"`
+	}
+	if err := c.Get(fmt.Sprintf(WAFExclusions, url.PathEscape(workspaceID)), nil, &r); err != nil {
+		return nil, err
+	}
+	return r.Data, nil
+}
+
+// CreateWAFExclusion adds a rule exclusion to the workspace.
+func (c *Client) CreateWAFExclusion(workspaceID string, i WAFExclusionInput) (*WAFExclusion, error) {
+	var r WAFExclusion
+	err := c.Do(Request{
+		Method: http.MethodPost,
+		Path:   fmt.Sprintf(WAFExclusions, url.PathEscape(workspaceID)),
+		JSON:   i,
+	}, &r)
+	if err != nil {
+		return nil, err
+	}
+	return &r, nil
+}
+
+// UpdateWAFExclusion modifies a rule exclusion of the workspace.
+func (c *Client) UpdateWAFExclusion(workspaceID, id string, i WAFExclusionInput) (*WAFExclusion, error) {
+	r := WAFExclusion{ID: id}
+	err := c.Do(Request{
+		Method: http.MethodPatch,
+		Path:   fmt.Sprintf(WAFExclusions+"/%s", url.PathEscape(workspaceID), url.PathEscape(id)),
+		JSON:   i,
+	}, &r)
+	if err != nil {
+		return nil, err
+	}
+	return &r, nil
+}
+
+// DeleteWAFExclusion removes a rule exclusion from the workspace.
+func (c *Client) DeleteWAFExclusion(workspaceID, id string) error {
+	return c.Delete(fmt.Sprintf(WAFExclusions+"/%s", url.PathEscape(workspaceID), url.PathEscape(id)))
+}
@@ -0,0 +1,70 @@
+package undocumented
+
+import (
+	"fmt"
+	"net/url"
+	"time"
+)
+
+// ResourceLinks is the API endpoint for the resources linked to a service
+// version.
+//
+// NOTE: go-fastly doesn't yet support resource links so we call the API
+// directly.
+const ResourceLinks = "/service/%s/version/%d/resource"
+
+// ResourceLink links a store (e.g. a KV, config or secret store) to a service
+// version.
+type ResourceLink struct {
+	CreatedAt      *time.Time `json:"created_at"`
+	DeletedAt      *time.Time `json:"deleted_at"`
+	ID             string     `json:"id"`
+	Name           string     `json:"name"`
+	ResourceID     string     `json:"resource_id"`
+	ResourceType   string     `json:"resource_type"`
+	ServiceID      string     `json:"service_id"`
+	ServiceVersion int        `json:"version"`
+	UpdatedAt      *time.Time `json:"updated_at"`
+}
+
+// ResourceLinkInput identifies the service version a resource link belongs to.
+type ResourceLinkInput struct {
+	ServiceID      string
+	ServiceVersion int
+}
+
+// CreateResourceLink links the resource to the service version under the
+// given name, which is how the resource is referenced from code.
+func (c *Client) CreateResourceLink(i ResourceLinkInput, resourceID, name string) (*ResourceLink, error) {
+	body := url.Values{}
+	body.Set("resource_id", resourceID)
+	if name != "" {
+		body.Set("name", name)
+	}
+
+	// NOTE: If the API doesn't echo the link back, report what was requested.
+	r := ResourceLink{
+		Name:           name,
+		ResourceID:     resourceID,
+		ServiceID:      i.ServiceID,
+		ServiceVersion: i.ServiceVersion,
+	}
+	if err := c.Post(fmt.Sprintf(ResourceLinks, i.ServiceID, i.ServiceVersion), body, &r); err != nil {
+		return nil, err
+	}
+	return &r, nil
+}
+
+// ListResourceLinks returns the resources linked to the service version.
+func (c *Client) ListResourceLinks(i ResourceLinkInput) ([]*ResourceLink, error) {
+	var rs []*ResourceLink
+	if err := c.Get(fmt.Sprintf(ResourceLinks, i.ServiceID, i.ServiceVersion), nil, &rs); err != nil {
+		return nil, err
+	}
+	return rs, nil
+}
+
+// DeleteResourceLink removes the resource link from the service version.
+func (c *Client) DeleteResourceLink(i ResourceLinkInput, id string) error {
+	return c.Delete(fmt.Sprintf(ResourceLinks+"/%s", i.ServiceID, i.ServiceVersion, id))
+}
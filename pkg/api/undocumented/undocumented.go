@@ -3,6 +3,8 @@
 package undocumented
 
 import (
+	"bytes"
+	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
@@ -22,14 +24,35 @@ const EdgeComputeTrial = "/customer/%s/edge-compute-trial"
 const RequestTimeout = 5 * time.Second
 
 // APIError models a custom error for undocumented API calls.
+//
+// The StatusCode is zero when the request failed before a response was
+// received, and Message is the error message of the response body, if any.
+// Response is the non-2xx response, whose body has already been read but can
+// be read again (e.g. to display it).
 type APIError struct {
 	Err        error
+	Message    string
+	Method     string
+	Path       string
+	Response   *http.Response
 	StatusCode int
 }
 
 // Error implements the error interface.
 func (e APIError) Error() string {
-	return e.Err.Error()
+	if e.StatusCode == 0 {
+		return e.Err.Error()
+	}
+	msg := fmt.Sprintf("%s: %d %s", e.Err, e.StatusCode, http.StatusText(e.StatusCode))
+	if e.Message != "" {
+		msg += ": " + e.Message
+	}
+	return msg
+}
+
+// Unwrap returns the underlying error.
+func (e APIError) Unwrap() error {
+	return e.Err
 }
 
 // NewError returns an APIError
@@ -40,39 +63,185 @@ func NewError(err error, statusCode int) APIError {
 	}
 }
 
-// Get calls the given API endpoint and returns its response data.
-func Get(host, path, token string, c api.HTTPClient) (data []byte, err error) {
-	host = strings.TrimSuffix(host, "/")
-	endpoint := fmt.Sprintf("%s%s", host, path)
+// Client calls undocumented API endpoints with a user token.
+type Client struct {
+	// Host is the API endpoint, e.g. https://api.fastly.com.
+	Host  string
+	HTTP  api.HTTPClient
+	Token string
+}
 
-	req, err := http.NewRequest(http.MethodPost, endpoint, nil)
+// NewClient returns a client for the API endpoint.
+func NewClient(host, token string, c api.HTTPClient) *Client {
+	return &Client{
+		Host:  host,
+		HTTP:  c,
+		Token: token,
+	}
+}
+
+// Request is a request to an API endpoint.
+//
+// A request has either a Form, a JSON or a raw Body (sent with the
+// ContentType), or none of them. The Header is sent in addition to the headers
+// set by the client, apart from an Accept header which replaces the default.
+type Request struct {
+	Method      string
+	Path        string
+	Query       url.Values
+	Form        url.Values
+	JSON        any
+	Body        io.Reader
+	ContentType string
+	Header      http.Header
+}
+
+// Do sends the request and decodes the JSON response into v, unless v is nil
+// or the response has no body (e.g. a 204 No Content), in which case v is left
+// untouched. When v is a *[]byte the response body is stored undecoded.
+//
+// Errors are returned as an APIError, apart from network timeouts which are
+// returned as a RemediationError.
+func (c *Client) Do(r Request, v any) error {
+	res, err := c.Send(r)
 	if err != nil {
-		return data, NewError(err, 0)
+		return err
 	}
+	defer res.Body.Close()
 
-	req.Header.Set("Fastly-Key", token)
+	data, err := io.ReadAll(res.Body)
+	if err != nil {
+		return APIError{Err: err, Method: r.Method, Path: r.Path, StatusCode: res.StatusCode}
+	}
+
+	if raw, ok := v.(*[]byte); ok {
+		*raw = data
+		return nil
+	}
+	if v == nil || len(bytes.TrimSpace(data)) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return APIError{Err: fmt.Errorf("error decoding response: %w", err), Method: r.Method, Path: r.Path}
+	}
+	return nil
+}
+
+// Send sends the request and returns the response, whose body the caller must
+// close, so that a large body can be streamed rather than read into memory.
+//
+// Errors, including non-2xx responses, are returned as for Do.
+func (c *Client) Send(r Request) (*http.Response, error) {
+	path := r.Path
+	if len(r.Query) > 0 {
+		path += "?" + r.Query.Encode()
+	}
+	endpoint := strings.TrimSuffix(c.Host, "/") + path
+
+	apiErr := func(err error, statusCode int) APIError {
+		return APIError{Err: err, Method: r.Method, Path: r.Path, StatusCode: statusCode}
+	}
+
+	var body io.Reader
+	var contentType string
+	switch {
+	case r.Form != nil:
+		body = strings.NewReader(r.Form.Encode())
+		contentType = "application/x-www-form-urlencoded"
+	case r.JSON != nil:
+		data, err := json.Marshal(r.JSON)
+		if err != nil {
+			return nil, apiErr(err, 0)
+		}
+		body = bytes.NewReader(data)
+		contentType = "application/json"
+	case r.Body != nil:
+		body = r.Body
+		contentType = r.ContentType
+	}
+
+	req, err := http.NewRequest(r.Method, endpoint, body)
+	if err != nil {
+		return nil, apiErr(err, 0)
+	}
+
+	for k, vs := range r.Header {
+		for _, v := range vs {
+			req.Header.Add(k, v)
+		}
+	}
+	if req.Header.Get("Accept") == "" {
+		req.Header.Set("Accept", "application/json")
+	}
+	req.Header.Set("Fastly-Key", c.Token)
 	req.Header.Set("User-Agent", useragent.Name)
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
 
-	res, err := c.Do(req)
+	res, err := c.HTTP.Do(req)
 	if err != nil {
 		if urlErr, ok := err.(*url.Error); ok && urlErr.Timeout() {
-			return data, fsterr.RemediationError{
+			return nil, fsterr.RemediationError{
 				Inner:       err,
 				Remediation: fsterr.NetworkRemediation,
 			}
 		}
-		return data, NewError(err, 0)
+		return nil, apiErr(err, 0)
 	}
-	defer res.Body.Close()
 
-	if res.StatusCode != http.StatusOK {
-		return data, NewError(fmt.Errorf("non-2xx response"), res.StatusCode)
+	if res.StatusCode < http.StatusOK || res.StatusCode >= http.StatusMultipleChoices {
+		defer res.Body.Close()
+		data, err := io.ReadAll(res.Body)
+		if err != nil {
+			return nil, apiErr(err, res.StatusCode)
+		}
+		res.Body = io.NopCloser(bytes.NewReader(data))
+		e := apiErr(fmt.Errorf("non-2xx response"), res.StatusCode)
+		e.Message = errorMessage(data)
+		e.Response = res
+		return nil, e
 	}
+	return res, nil
+}
 
-	data, err = io.ReadAll(res.Body)
-	if err != nil {
-		return data, NewError(err, res.StatusCode)
-	}
+// Get sends a GET request, decoding the response into v.
+func (c *Client) Get(path string, query url.Values, v any) error {
+	return c.Do(Request{Method: http.MethodGet, Path: path, Query: query}, v)
+}
+
+// Post sends a POST request with a form body, decoding the response into v.
+func (c *Client) Post(path string, form url.Values, v any) error {
+	return c.Do(Request{Method: http.MethodPost, Path: path, Form: form}, v)
+}
+
+// Put sends a PUT request with a form body, decoding the response into v.
+func (c *Client) Put(path string, form url.Values, v any) error {
+	return c.Do(Request{Method: http.MethodPut, Path: path, Form: form}, v)
+}
 
-	return data, nil
+// Delete sends a DELETE request.
+func (c *Client) Delete(path string) error {
+	return c.Do(Request{Method: http.MethodDelete, Path: path}, nil)
+}
+
+// errorMessage returns the error message of an API error response body, which
+// is either of the form {"msg": "...", "detail": "..."} or, for newer APIs,
+// {"title": "...", "detail": "..."}.
+func errorMessage(data []byte) string {
+	var r struct {
+		Detail string `json:"detail"`
+		Msg    string `json:"msg"`
+		Title  string `json:"title"`
+	}
+	if err := json.Unmarshal(data, &r); err != nil {
+		return ""
+	}
+	var parts []string
+	for _, s := range []string{r.Msg, r.Title, r.Detail} {
+		if s != "" {
+			parts = append(parts, s)
+		}
+	}
+	return strings.Join(parts, ": ")
 }
@@ -0,0 +1,147 @@
+package undocumented_test
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/fastly/cli/pkg/api/undocumented"
+)
+
+func TestClientDo(t *testing.T) {
+	scenarios := []struct {
+		name            string
+		request         undocumented.Request
+		code            int
+		body            string
+		wantURL         string
+		wantBody        string
+		wantContentType string
+		wantHeader      http.Header
+		wantError       string
+		wantStatus      int
+		wantValue       string
+	}{
+		{
+			name:      "GET with query params",
+			request:   undocumented.Request{Method: http.MethodGet, Path: "/example", Query: url.Values{"q": {"a b"}}},
+			body:      `{"name":"example"}`,
+			wantURL:   "https://api.example.com/example?q=a+b",
+			wantValue: "example",
+		},
+		{
+			name:            "POST with a form body",
+			request:         undocumented.Request{Method: http.MethodPost, Path: "/example", Form: url.Values{"name": {"example"}}},
+			code:            http.StatusNoContent,
+			wantURL:         "https://api.example.com/example",
+			wantBody:        "name=example",
+			wantContentType: "application/x-www-form-urlencoded",
+		},
+		{
+			name:            "PUT with a JSON body",
+			request:         undocumented.Request{Method: http.MethodPut, Path: "/example", JSON: map[string]string{"name": "example"}},
+			body:            `{"name":"updated"}`,
+			wantURL:         "https://api.example.com/example",
+			wantBody:        `{"name":"example"}`,
+			wantContentType: "application/json",
+			wantValue:       "updated",
+		},
+		{
+			name:            "PUT with a raw body and header",
+			request:         undocumented.Request{Method: http.MethodPut, Path: "/example", Body: strings.NewReader("<html>"), ContentType: "text/html", Header: http.Header{"Metadata": {"abc"}}},
+			body:            `{"name":"raw"}`,
+			wantURL:         "https://api.example.com/example",
+			wantBody:        "<html>",
+			wantContentType: "text/html",
+			wantHeader:      http.Header{"Metadata": {"abc"}},
+			wantValue:       "raw",
+		},
+		{
+			name:       "GET with an Accept header",
+			request:    undocumented.Request{Method: http.MethodGet, Path: "/example", Header: http.Header{"Accept": {"text/plain"}}},
+			code:       http.StatusNoContent,
+			wantURL:    "https://api.example.com/example",
+			wantHeader: http.Header{"Accept": {"text/plain"}},
+		},
+		{
+			name:       "error response",
+			request:    undocumented.Request{Method: http.MethodDelete, Path: "/example"},
+			code:       http.StatusNotFound,
+			body:       `{"msg":"Record not found","detail":"Cannot find example"}`,
+			wantURL:    "https://api.example.com/example",
+			wantError:  "non-2xx response: 404 Not Found: Record not found: Cannot find example",
+			wantStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, testcase := range scenarios {
+		t.Run(testcase.name, func(t *testing.T) {
+			var req *http.Request
+			var reqBody string
+			c := undocumented.NewClient("https://api.example.com/", "123", doFunc(func(r *http.Request) (*http.Response, error) {
+				req = r
+				if r.Body != nil {
+					b, _ := io.ReadAll(r.Body)
+					reqBody = string(b)
+				}
+				rec := httptest.NewRecorder()
+				if testcase.code != 0 {
+					rec.WriteHeader(testcase.code)
+				}
+				rec.WriteString(testcase.body)
+				return rec.Result(), nil
+			}))
+
+			var v struct {
+				Name string `json:"name"`
+			}
+			err := c.Do(testcase.request, &v)
+
+			if testcase.wantError == "" && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if testcase.wantError != "" {
+				if err == nil || err.Error() != testcase.wantError {
+					t.Fatalf("want error %q, have %v", testcase.wantError, err)
+				}
+				var apiErr undocumented.APIError
+				if !errors.As(err, &apiErr) || apiErr.StatusCode != testcase.wantStatus {
+					t.Fatalf("want an APIError with status %d, have %#v", testcase.wantStatus, err)
+				}
+				if b, _ := io.ReadAll(apiErr.Response.Body); string(b) != testcase.body {
+					t.Errorf("want the error response body %q, have %q", testcase.body, b)
+				}
+			}
+			if have := req.URL.String(); have != testcase.wantURL {
+				t.Errorf("want URL %q, have %q", testcase.wantURL, have)
+			}
+			if have := req.Header.Get("Fastly-Key"); have != "123" {
+				t.Errorf("want Fastly-Key %q, have %q", "123", have)
+			}
+			if reqBody != testcase.wantBody {
+				t.Errorf("want body %q, have %q", testcase.wantBody, reqBody)
+			}
+			if have := req.Header.Get("Content-Type"); have != testcase.wantContentType {
+				t.Errorf("want Content-Type %q, have %q", testcase.wantContentType, have)
+			}
+			for k := range testcase.wantHeader {
+				if have, want := req.Header.Get(k), testcase.wantHeader.Get(k); have != want {
+					t.Errorf("want %s header %q, have %q", k, want, have)
+				}
+			}
+			if v.Name != testcase.wantValue {
+				t.Errorf("want decoded name %q, have %q", testcase.wantValue, v.Name)
+			}
+		})
+	}
+}
+
+type doFunc func(*http.Request) (*http.Response, error)
+
+func (f doFunc) Do(r *http.Request) (*http.Response, error) {
+	return f(r)
+}
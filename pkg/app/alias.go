@@ -0,0 +1,92 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/fastly/cli/pkg/cmd"
+	"github.com/fastly/kingpin"
+)
+
+// expandAlias replaces the command name in args with the command line it is
+// an alias for, as defined in the [alias] section of the application config.
+//
+// Aliases can refer to other aliases but can't override the built-in
+// commands, so a user's config can't change the behaviour of scripts that
+// call e.g. `fastly compute publish`.
+func expandAlias(args []string, aliases map[string]string, app *kingpin.Application) ([]string, error) {
+	if len(aliases) == 0 {
+		return args, nil
+	}
+
+	flags := app.Model().Flags
+	seen := make(map[string]bool)
+	for {
+		i := commandIndex(args, flags)
+		if i < 0 || isCommand(app, args[i]) {
+			return args, nil
+		}
+		name := args[i]
+		expansion, ok := aliases[name]
+		if !ok {
+			return args, nil
+		}
+		if seen[name] {
+			return args, fmt.Errorf("alias '%s' refers to itself", name)
+		}
+		seen[name] = true
+
+		fields, err := cmd.SplitArgs(expansion)
+		if err != nil {
+			return args, fmt.Errorf("error expanding alias '%s': %w", name, err)
+		}
+		expanded := make([]string, 0, len(args)+len(fields))
+		expanded = append(expanded, args[:i]...)
+		expanded = append(expanded, fields...)
+		args = append(expanded, args[i+1:]...)
+	}
+}
+
+// isCommand reports whether name is a top-level command.
+func isCommand(app *kingpin.Application, name string) bool {
+	// NOTE: The help command is added by kingpin as a side-effect of parsing.
+	return name == "help" || app.GetCommand(name) != nil
+}
+
+// commandIndex returns the index of the first argument that isn't a global
+// flag or the value of one, or -1 if there isn't one.
+func commandIndex(args []string, flags []*kingpin.ClauseModel) int {
+	long := make(map[string]*kingpin.ClauseModel)
+	short := make(map[string]*kingpin.ClauseModel)
+	for _, f := range flags {
+		long[f.Name] = f
+		if f.Short != 0 {
+			short[string(f.Short)] = f
+		}
+	}
+
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		var f *kingpin.ClauseModel
+		switch {
+		case arg == "--":
+			return -1
+		case strings.HasPrefix(arg, "--"):
+			if strings.Contains(arg, "=") {
+				continue
+			}
+			f = long[strings.TrimPrefix(arg, "--")]
+		case strings.HasPrefix(arg, "-") && len(arg) > 1:
+			if len(arg) > 2 {
+				continue // e.g. -ofoo
+			}
+			f = short[arg[1:]]
+		default:
+			return i
+		}
+		if f != nil && !f.IsBoolFlag() {
+			i++ // skip the flag value
+		}
+	}
+	return -1
+}
@@ -0,0 +1,239 @@
+package app
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/fastly/cli/pkg/cmd"
+	fsterr "github.com/fastly/cli/pkg/errors"
+	"github.com/fastly/cli/pkg/text"
+	"github.com/fastly/kingpin"
+)
+
+// DefaultConcurrency is the number of services a command runs against at once
+// when given a --service-id-file.
+const DefaultConcurrency = 4
+
+// bulkFlags are the global flags that control a bulk run, which are removed
+// from the arguments used to run the command against each service.
+//
+// NOTE: --dir is also removed as the working directory has already been
+// changed, and changing it again would be relative to the new directory.
+var bulkFlags = []string{"C", "command-timeout", "concurrency", "dir", "service-id-file"}
+
+// bulkResult is the outcome of running a command against a single service.
+type bulkResult struct {
+	err       error
+	output    bytes.Buffer
+	serviceID string
+}
+
+// runBulk runs the selected command once for each service ID in the
+// --service-id-file, at most --concurrency at a time, then displays each
+// service's output followed by a summary of which services failed.
+//
+// Each run is a separate invocation of Run with --service-id appended to the
+// arguments, so every command that accepts --service-id supports bulk runs
+// without needing to be aware of them.
+//
+// NOTE: Prompts can't be answered for many services at once, so each run is
+// non-interactive and confirmations must be accepted with --auto-yes.
+//
+// NOTE: The runs share the process-wide state that the bulk run has already
+// set up (the working directory, logger and output settings, which are the
+// same for every service), as changing it from concurrent runs would race.
+func runBulk(opts RunOpts, app *kingpin.Application, name string, concurrency int, path string) error {
+	if !commandHasFlag(app, name, cmd.FlagServiceIDName) {
+		return fsterr.RemediationError{
+			Inner:       fmt.Errorf("the '%s' command doesn't accept a --%s flag", name, cmd.FlagServiceIDName),
+			Remediation: "Remove the --service-id-file flag.",
+		}
+	}
+	if hasServiceFlag(opts.Args) {
+		return fsterr.RemediationError{
+			Inner:       fmt.Errorf("invalid flag combination, --service-id-file and --%s or --%s", cmd.FlagServiceIDName, cmd.FlagServiceName),
+			Remediation: "Use either --service-id-file or a single service, not both.",
+		}
+	}
+	if concurrency < 1 {
+		return fsterr.RemediationError{
+			Inner:       fmt.Errorf("invalid --concurrency value: %d", concurrency),
+			Remediation: "Set --concurrency to 1 or more.",
+		}
+	}
+
+	ids, err := readServiceIDs(path)
+	if err != nil {
+		opts.ErrLog.Add(err)
+		return err
+	}
+
+	args := removeFlags(opts.Args, bulkFlags)
+	results := make([]*bulkResult, len(ids))
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+
+	for i, id := range ids {
+		r := &bulkResult{serviceID: id}
+		results[i] = r
+
+		wg.Add(1)
+		sem <- struct{}{}
+		go func() {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+
+			o := opts
+			o.Args = append(append([]string{}, args...), "--"+cmd.FlagServiceIDName, r.serviceID)
+			o.Stdin = text.NonInteractiveReader{}
+			o.Stdout = &r.output
+			o.Versioners = Versioners{}
+			o.shared = true
+			r.err = nonInteractiveErr(Run(o))
+		}()
+	}
+	wg.Wait()
+
+	var failed int
+	for _, r := range results {
+		text.Output(opts.Stdout, "%s %s", text.Bold("Service ID:"), r.serviceID)
+		if r.output.Len() > 0 {
+			_, _ = opts.Stdout.Write(r.output.Bytes())
+			text.Break(opts.Stdout)
+		}
+		if r.err != nil {
+			failed++
+		}
+	}
+
+	text.Break(opts.Stdout)
+	t := text.NewTable(opts.Stdout)
+	t.AddHeader("SERVICE ID", "RESULT")
+	for _, r := range results {
+		result := "OK"
+		if r.err != nil {
+			result = "ERROR: " + firstLine(r.err.Error())
+		}
+		t.AddLine(r.serviceID, result)
+	}
+	t.Print()
+
+	if failed > 0 {
+		return fmt.Errorf("the command failed for %d of %d services", failed, len(results))
+	}
+	text.Success(opts.Stdout, "The command succeeded for all %d services", len(results))
+	return nil
+}
+
+// readServiceIDs reads the service IDs from a file, one per line. Blank lines
+// and lines starting with a # are ignored.
+func readServiceIDs(path string) ([]string, error) {
+	f, err := os.Open(filepath.Clean(path))
+	if err != nil {
+		return nil, fmt.Errorf("error reading --service-id-file: %w", err)
+	}
+	defer f.Close() // #nosec G307
+
+	var ids []string
+	seen := make(map[string]bool)
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		id := strings.TrimSpace(scanner.Text())
+		if id == "" || strings.HasPrefix(id, "#") || seen[id] {
+			continue
+		}
+		seen[id] = true
+		ids = append(ids, id)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("error reading --service-id-file: %w", err)
+	}
+	if len(ids) == 0 {
+		return nil, fsterr.RemediationError{
+			Inner:       fmt.Errorf("no service IDs found in %s", path),
+			Remediation: "Add one service ID per line to the --service-id-file.",
+		}
+	}
+	return ids, nil
+}
+
+// commandHasFlag reports whether the named command, e.g. "logging s3 update",
+// defines the flag.
+func commandHasFlag(app *kingpin.Application, name, flag string) bool {
+	segs := strings.Fields(name)
+	if len(segs) == 0 {
+		return false
+	}
+	c := app.GetCommand(segs[0])
+	for _, seg := range segs[1:] {
+		if c == nil {
+			return false
+		}
+		c = c.GetCommand(seg)
+	}
+	return c != nil && c.GetFlag(flag) != nil
+}
+
+// hasServiceFlag reports whether the arguments select a specific service.
+func hasServiceFlag(args []string) bool {
+	for _, arg := range args {
+		if arg == "--" {
+			return false
+		}
+		if arg == "-s" || strings.HasPrefix(arg, "-s=") {
+			return true
+		}
+		for _, flag := range []string{cmd.FlagServiceIDName, cmd.FlagServiceName} {
+			if arg == "--"+flag || strings.HasPrefix(arg, "--"+flag+"=") {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// removeFlags returns the arguments without the given flags and their values,
+// which may be given as either --flag=value or --flag value. A single letter
+// flag is a short flag, given as either -fvalue or -f value.
+func removeFlags(args []string, flags []string) []string {
+	var out []string
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if arg == "--" {
+			return append(out, args[i:]...)
+		}
+		var matched bool
+		for _, flag := range flags {
+			name := "--" + flag
+			if len(flag) == 1 {
+				name = "-" + flag
+			}
+			if arg == name {
+				matched = true
+				i++ // skip the value
+				break
+			}
+			if len(flag) == 1 && strings.HasPrefix(arg, name) || strings.HasPrefix(arg, name+"=") {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			out = append(out, arg)
+		}
+	}
+	return out
+}
+
+// firstLine returns the first line of s.
+func firstLine(s string) string {
+	line, _, _ := strings.Cut(s, "\n")
+	return line
+}
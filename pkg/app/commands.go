@@ -1,18 +1,27 @@
 package app
 
 import (
+	"path/filepath"
+
 	"github.com/fastly/cli/pkg/cmd"
 	"github.com/fastly/cli/pkg/commands/acl"
 	"github.com/fastly/cli/pkg/commands/aclentry"
+	"github.com/fastly/cli/pkg/commands/alias"
+	"github.com/fastly/cli/pkg/commands/apicall"
 	"github.com/fastly/cli/pkg/commands/authtoken"
 	"github.com/fastly/cli/pkg/commands/backend"
+	"github.com/fastly/cli/pkg/commands/billing"
 	"github.com/fastly/cli/pkg/commands/compute"
 	"github.com/fastly/cli/pkg/commands/config"
 	"github.com/fastly/cli/pkg/commands/dictionary"
 	"github.com/fastly/cli/pkg/commands/dictionaryitem"
+	"github.com/fastly/cli/pkg/commands/director"
 	"github.com/fastly/cli/pkg/commands/domain"
+	"github.com/fastly/cli/pkg/commands/errorlog"
+	"github.com/fastly/cli/pkg/commands/events"
 	"github.com/fastly/cli/pkg/commands/healthcheck"
 	"github.com/fastly/cli/pkg/commands/ip"
+	"github.com/fastly/cli/pkg/commands/kvstore"
 	"github.com/fastly/cli/pkg/commands/logging"
 	"github.com/fastly/cli/pkg/commands/logging/azureblob"
 	"github.com/fastly/cli/pkg/commands/logging/bigquery"
@@ -41,9 +50,15 @@ import (
 	"github.com/fastly/cli/pkg/commands/logging/sumologic"
 	"github.com/fastly/cli/pkg/commands/logging/syslog"
 	"github.com/fastly/cli/pkg/commands/logtail"
+	"github.com/fastly/cli/pkg/commands/mcp"
+	"github.com/fastly/cli/pkg/commands/origins"
+	"github.com/fastly/cli/pkg/commands/plugin"
 	"github.com/fastly/cli/pkg/commands/pop"
 	"github.com/fastly/cli/pkg/commands/profile"
 	"github.com/fastly/cli/pkg/commands/purge"
+	"github.com/fastly/cli/pkg/commands/ratelimit"
+	"github.com/fastly/cli/pkg/commands/resourcelink"
+	"github.com/fastly/cli/pkg/commands/serveapi"
 	"github.com/fastly/cli/pkg/commands/service"
 	"github.com/fastly/cli/pkg/commands/serviceversion"
 	"github.com/fastly/cli/pkg/commands/shellcomplete"
@@ -57,15 +72,21 @@ import (
 	tlsCustomPrivateKey "github.com/fastly/cli/pkg/commands/tls/custom/privatekey"
 	tlsPlatform "github.com/fastly/cli/pkg/commands/tls/platform"
 	tlsSubscription "github.com/fastly/cli/pkg/commands/tls/subscription"
+	"github.com/fastly/cli/pkg/commands/tools"
 	"github.com/fastly/cli/pkg/commands/update"
 	"github.com/fastly/cli/pkg/commands/user"
 	"github.com/fastly/cli/pkg/commands/vcl"
 	"github.com/fastly/cli/pkg/commands/vcl/custom"
+	"github.com/fastly/cli/pkg/commands/vcl/generated"
 	"github.com/fastly/cli/pkg/commands/vcl/snippet"
 	"github.com/fastly/cli/pkg/commands/version"
+	"github.com/fastly/cli/pkg/commands/waf"
+	wafExclusion "github.com/fastly/cli/pkg/commands/waf/exclusion"
 	"github.com/fastly/cli/pkg/commands/whoami"
 	cfg "github.com/fastly/cli/pkg/config"
+	fsterr "github.com/fastly/cli/pkg/errors"
 	"github.com/fastly/cli/pkg/manifest"
+	fsttools "github.com/fastly/cli/pkg/tools"
 	"github.com/fastly/kingpin"
 )
 
@@ -88,31 +109,48 @@ func defineCommands(
 	aclEntryDescribe := aclentry.NewDescribeCommand(aclEntryCmdRoot.CmdClause, globals, data)
 	aclEntryList := aclentry.NewListCommand(aclEntryCmdRoot.CmdClause, globals, data)
 	aclEntryUpdate := aclentry.NewUpdateCommand(aclEntryCmdRoot.CmdClause, globals, data)
+	aliasCmdRoot := alias.NewRootCommand(app, globals)
+	aliasList := alias.NewListCommand(aliasCmdRoot.CmdClause, globals)
+	aliasSet := alias.NewSetCommand(aliasCmdRoot.CmdClause, globals, func(name string) bool { return isCommand(app, name) })
+	aliasUnset := alias.NewUnsetCommand(aliasCmdRoot.CmdClause, globals)
+	apiCmdRoot := apicall.NewRootCommand(app, globals, data)
 	authtokenCmdRoot := authtoken.NewRootCommand(app, globals)
 	authtokenCreate := authtoken.NewCreateCommand(authtokenCmdRoot.CmdClause, globals, data)
 	authtokenDelete := authtoken.NewDeleteCommand(authtokenCmdRoot.CmdClause, globals, data)
 	authtokenDescribe := authtoken.NewDescribeCommand(authtokenCmdRoot.CmdClause, globals, data)
 	authtokenList := authtoken.NewListCommand(authtokenCmdRoot.CmdClause, globals, data)
+	authtokenMint := authtoken.NewMintCommand(authtokenCmdRoot.CmdClause, globals)
+	authtokenRotate := authtoken.NewRotateCommand(authtokenCmdRoot.CmdClause, authtoken.APIClientFactory(opts.APIClient), globals)
 	backendCmdRoot := backend.NewRootCommand(app, globals)
 	backendCreate := backend.NewCreateCommand(backendCmdRoot.CmdClause, globals, data)
 	backendDelete := backend.NewDeleteCommand(backendCmdRoot.CmdClause, globals, data)
 	backendDescribe := backend.NewDescribeCommand(backendCmdRoot.CmdClause, globals, data)
 	backendList := backend.NewListCommand(backendCmdRoot.CmdClause, globals, data)
 	backendUpdate := backend.NewUpdateCommand(backendCmdRoot.CmdClause, globals, data)
+	billingCmdRoot := billing.NewRootCommand(app, globals)
+	billingUsage := billing.NewUsageCommand(billingCmdRoot.CmdClause, globals)
 	computeCmdRoot := compute.NewRootCommand(app, globals)
 	computeBuild := compute.NewBuildCommand(computeCmdRoot.CmdClause, globals, data)
 	computeDeploy := compute.NewDeployCommand(computeCmdRoot.CmdClause, globals, data)
 	computeInit := compute.NewInitCommand(computeCmdRoot.CmdClause, globals, data)
+	computeManifestCmdRoot := compute.NewManifestRootCommand(computeCmdRoot.CmdClause, globals)
+	computeManifestMigrate := compute.NewManifestMigrateCommand(computeManifestCmdRoot.CmdClause, globals)
+	computeManifestSchema := compute.NewManifestSchemaCommand(computeCmdRoot.CmdClause, globals)
 	computePack := compute.NewPackCommand(computeCmdRoot.CmdClause, globals, data)
 	computePublish := compute.NewPublishCommand(computeCmdRoot.CmdClause, globals, computeBuild, computeDeploy, data)
 	computeServe := compute.NewServeCommand(computeCmdRoot.CmdClause, globals, computeBuild, opts.Versioners.Viceroy, data)
+	computeStatus := compute.NewStatusCommand(computeCmdRoot.CmdClause, globals, data)
 	computeUpdate := compute.NewUpdateCommand(computeCmdRoot.CmdClause, globals, data)
 	computeValidate := compute.NewValidateCommand(computeCmdRoot.CmdClause, globals)
 	configCmdRoot := config.NewRootCommand(app, globals)
+	configPin := config.NewPinCommand(configCmdRoot.CmdClause, globals)
+	configShow := config.NewShowCommand(configCmdRoot.CmdClause, globals)
+	configUnpin := config.NewUnpinCommand(configCmdRoot.CmdClause, globals)
 	dictionaryCmdRoot := dictionary.NewRootCommand(app, globals)
 	dictionaryCreate := dictionary.NewCreateCommand(dictionaryCmdRoot.CmdClause, globals, data)
 	dictionaryDelete := dictionary.NewDeleteCommand(dictionaryCmdRoot.CmdClause, globals, data)
 	dictionaryDescribe := dictionary.NewDescribeCommand(dictionaryCmdRoot.CmdClause, globals, data)
+	dictionaryDiff := dictionary.NewDiffCommand(dictionaryCmdRoot.CmdClause, globals, data)
 	dictionaryItemCmdRoot := dictionaryitem.NewRootCommand(app, globals)
 	dictionaryItemCreate := dictionaryitem.NewCreateCommand(dictionaryItemCmdRoot.CmdClause, globals, data)
 	dictionaryItemDelete := dictionaryitem.NewDeleteCommand(dictionaryItemCmdRoot.CmdClause, globals, data)
@@ -121,13 +159,29 @@ func defineCommands(
 	dictionaryItemUpdate := dictionaryitem.NewUpdateCommand(dictionaryItemCmdRoot.CmdClause, globals, data)
 	dictionaryList := dictionary.NewListCommand(dictionaryCmdRoot.CmdClause, globals, data)
 	dictionaryUpdate := dictionary.NewUpdateCommand(dictionaryCmdRoot.CmdClause, globals, data)
+	directorCmdRoot := director.NewRootCommand(app, globals)
+	directorAddBackend := director.NewAddBackendCommand(directorCmdRoot.CmdClause, globals, data)
+	directorCreate := director.NewCreateCommand(directorCmdRoot.CmdClause, globals, data)
+	directorDelete := director.NewDeleteCommand(directorCmdRoot.CmdClause, globals, data)
+	directorDescribe := director.NewDescribeCommand(directorCmdRoot.CmdClause, globals, data)
+	directorList := director.NewListCommand(directorCmdRoot.CmdClause, globals, data)
+	directorRebalance := director.NewRebalanceCommand(directorCmdRoot.CmdClause, globals, data)
+	directorRemoveBackend := director.NewRemoveBackendCommand(directorCmdRoot.CmdClause, globals, data)
+	directorUpdate := director.NewUpdateCommand(directorCmdRoot.CmdClause, globals, data)
 	domainCmdRoot := domain.NewRootCommand(app, globals)
 	domainCreate := domain.NewCreateCommand(domainCmdRoot.CmdClause, globals, data)
 	domainDelete := domain.NewDeleteCommand(domainCmdRoot.CmdClause, globals, data)
 	domainDescribe := domain.NewDescribeCommand(domainCmdRoot.CmdClause, globals, data)
 	domainList := domain.NewListCommand(domainCmdRoot.CmdClause, globals, data)
+	domainSuggest := domain.NewSuggestCommand(domainCmdRoot.CmdClause, globals)
 	domainUpdate := domain.NewUpdateCommand(domainCmdRoot.CmdClause, globals, data)
 	domainValidate := domain.NewValidateCommand(domainCmdRoot.CmdClause, globals, data)
+	errorsCmdRoot := errorlog.NewRootCommand(app, globals)
+	errorsClear := errorlog.NewClearCommand(errorsCmdRoot.CmdClause, globals, fsterr.LogPath)
+	errorsShow := errorlog.NewShowCommand(errorsCmdRoot.CmdClause, globals, fsterr.LogPath)
+	eventsCmdRoot := events.NewRootCommand(app, globals)
+	eventsDescribe := events.NewDescribeCommand(eventsCmdRoot.CmdClause, globals)
+	eventsList := events.NewListCommand(eventsCmdRoot.CmdClause, globals)
 	healthcheckCmdRoot := healthcheck.NewRootCommand(app, globals)
 	healthcheckCreate := healthcheck.NewCreateCommand(healthcheckCmdRoot.CmdClause, globals, data)
 	healthcheckDelete := healthcheck.NewDeleteCommand(healthcheckCmdRoot.CmdClause, globals, data)
@@ -135,12 +189,17 @@ func defineCommands(
 	healthcheckList := healthcheck.NewListCommand(healthcheckCmdRoot.CmdClause, globals, data)
 	healthcheckUpdate := healthcheck.NewUpdateCommand(healthcheckCmdRoot.CmdClause, globals, data)
 	ipCmdRoot := ip.NewRootCommand(app, globals)
+	kvStoreCmdRoot := kvstore.NewRootCommand(app, globals)
+	kvStoreGet := kvstore.NewGetCommand(kvStoreCmdRoot.CmdClause, globals)
+	kvStoreInsert := kvstore.NewInsertCommand(kvStoreCmdRoot.CmdClause, globals)
+	kvStoreSync := kvstore.NewSyncCommand(kvStoreCmdRoot.CmdClause, globals)
 	logtailCmdRoot := logtail.NewRootCommand(app, globals, data)
 	loggingCmdRoot := logging.NewRootCommand(app, globals)
 	loggingAzureblobCmdRoot := azureblob.NewRootCommand(loggingCmdRoot.CmdClause, globals)
 	loggingAzureblobCreate := azureblob.NewCreateCommand(loggingAzureblobCmdRoot.CmdClause, globals, data)
 	loggingAzureblobDelete := azureblob.NewDeleteCommand(loggingAzureblobCmdRoot.CmdClause, globals, data)
 	loggingAzureblobDescribe := azureblob.NewDescribeCommand(loggingAzureblobCmdRoot.CmdClause, globals, data)
+	loggingAzureblobGenerateSAS := azureblob.NewGenerateSASCommand(loggingAzureblobCmdRoot.CmdClause, globals)
 	loggingAzureblobList := azureblob.NewListCommand(loggingAzureblobCmdRoot.CmdClause, globals, data)
 	loggingAzureblobUpdate := azureblob.NewUpdateCommand(loggingAzureblobCmdRoot.CmdClause, globals, data)
 	loggingBigQueryCmdRoot := bigquery.NewRootCommand(loggingCmdRoot.CmdClause, globals)
@@ -257,11 +316,13 @@ func defineCommands(
 	loggingPapertrailDescribe := papertrail.NewDescribeCommand(loggingPapertrailCmdRoot.CmdClause, globals, data)
 	loggingPapertrailList := papertrail.NewListCommand(loggingPapertrailCmdRoot.CmdClause, globals, data)
 	loggingPapertrailUpdate := papertrail.NewUpdateCommand(loggingPapertrailCmdRoot.CmdClause, globals, data)
+	loggingPresets := logging.NewPresetsCommand(loggingCmdRoot.CmdClause, globals)
 	loggingS3CmdRoot := s3.NewRootCommand(loggingCmdRoot.CmdClause, globals)
 	loggingS3Create := s3.NewCreateCommand(loggingS3CmdRoot.CmdClause, globals, data)
 	loggingS3Delete := s3.NewDeleteCommand(loggingS3CmdRoot.CmdClause, globals, data)
 	loggingS3Describe := s3.NewDescribeCommand(loggingS3CmdRoot.CmdClause, globals, data)
 	loggingS3List := s3.NewListCommand(loggingS3CmdRoot.CmdClause, globals, data)
+	loggingS3Policy := s3.NewPolicyCommand(loggingS3CmdRoot.CmdClause, globals)
 	loggingS3Update := s3.NewUpdateCommand(loggingS3CmdRoot.CmdClause, globals, data)
 	loggingScalyrCmdRoot := scalyr.NewRootCommand(loggingCmdRoot.CmdClause, globals)
 	loggingScalyrCreate := scalyr.NewCreateCommand(loggingScalyrCmdRoot.CmdClause, globals, data)
@@ -281,6 +342,7 @@ func defineCommands(
 	loggingSplunkDescribe := splunk.NewDescribeCommand(loggingSplunkCmdRoot.CmdClause, globals, data)
 	loggingSplunkList := splunk.NewListCommand(loggingSplunkCmdRoot.CmdClause, globals, data)
 	loggingSplunkUpdate := splunk.NewUpdateCommand(loggingSplunkCmdRoot.CmdClause, globals, data)
+	loggingStatus := logging.NewStatusCommand(loggingCmdRoot.CmdClause, globals, data)
 	loggingSumologicCmdRoot := sumologic.NewRootCommand(loggingCmdRoot.CmdClause, globals)
 	loggingSumologicCreate := sumologic.NewCreateCommand(loggingSumologicCmdRoot.CmdClause, globals, data)
 	loggingSumologicDelete := sumologic.NewDeleteCommand(loggingSumologicCmdRoot.CmdClause, globals, data)
@@ -293,6 +355,12 @@ func defineCommands(
 	loggingSyslogDescribe := syslog.NewDescribeCommand(loggingSyslogCmdRoot.CmdClause, globals, data)
 	loggingSyslogList := syslog.NewListCommand(loggingSyslogCmdRoot.CmdClause, globals, data)
 	loggingSyslogUpdate := syslog.NewUpdateCommand(loggingSyslogCmdRoot.CmdClause, globals, data)
+	mcpCmdRoot := mcp.NewRootCommand(app, globals, nestedRunner(opts))
+	originsCmdRoot := origins.NewRootCommand(app, globals)
+	originsHealth := origins.NewHealthCommand(originsCmdRoot.CmdClause, globals, data)
+	pluginCmdRoot := plugin.NewRootCommand(app, globals)
+	pluginExec := plugin.NewExecCommand(pluginCmdRoot.CmdClause, globals)
+	pluginList := plugin.NewListCommand(pluginCmdRoot.CmdClause, globals)
 	popCmdRoot := pop.NewRootCommand(app, globals)
 	profileCmdRoot := profile.NewRootCommand(app, globals)
 	profileCreate := profile.NewCreateCommand(profileCmdRoot.CmdClause, profile.APIClientFactory(opts.APIClient), globals)
@@ -302,6 +370,17 @@ func defineCommands(
 	profileToken := profile.NewTokenCommand(profileCmdRoot.CmdClause, globals)
 	profileUpdate := profile.NewUpdateCommand(profileCmdRoot.CmdClause, profile.APIClientFactory(opts.APIClient), globals)
 	purgeCmdRoot := purge.NewRootCommand(app, globals, data)
+	rateLimitCmdRoot := ratelimit.NewRootCommand(app, globals)
+	rateLimitCreate := ratelimit.NewCreateCommand(rateLimitCmdRoot.CmdClause, globals, data)
+	rateLimitDelete := ratelimit.NewDeleteCommand(rateLimitCmdRoot.CmdClause, globals, data)
+	rateLimitDescribe := ratelimit.NewDescribeCommand(rateLimitCmdRoot.CmdClause, globals, data)
+	rateLimitList := ratelimit.NewListCommand(rateLimitCmdRoot.CmdClause, globals, data)
+	rateLimitUpdate := ratelimit.NewUpdateCommand(rateLimitCmdRoot.CmdClause, globals, data)
+	resourceLinkCmdRoot := resourcelink.NewRootCommand(app, globals)
+	resourceLinkCreate := resourcelink.NewCreateCommand(resourceLinkCmdRoot.CmdClause, globals, data)
+	resourceLinkDelete := resourcelink.NewDeleteCommand(resourceLinkCmdRoot.CmdClause, globals, data)
+	resourceLinkList := resourcelink.NewListCommand(resourceLinkCmdRoot.CmdClause, globals, data)
+	serveAPICmdRoot := serveapi.NewRootCommand(app, globals, nestedRunner(opts))
 	serviceCmdRoot := service.NewRootCommand(app, globals)
 	serviceCreate := service.NewCreateCommand(serviceCmdRoot.CmdClause, globals)
 	serviceDelete := service.NewDeleteCommand(serviceCmdRoot.CmdClause, globals, data)
@@ -309,12 +388,15 @@ func defineCommands(
 	serviceList := service.NewListCommand(serviceCmdRoot.CmdClause, globals)
 	serviceSearch := service.NewSearchCommand(serviceCmdRoot.CmdClause, globals, data)
 	serviceUpdate := service.NewUpdateCommand(serviceCmdRoot.CmdClause, globals, data)
+	serviceWatch := service.NewWatchCommand(serviceCmdRoot.CmdClause, globals, data)
 	serviceVersionCmdRoot := serviceversion.NewRootCommand(app, globals)
 	serviceVersionActivate := serviceversion.NewActivateCommand(serviceVersionCmdRoot.CmdClause, globals, data)
 	serviceVersionClone := serviceversion.NewCloneCommand(serviceVersionCmdRoot.CmdClause, globals, data)
 	serviceVersionDeactivate := serviceversion.NewDeactivateCommand(serviceVersionCmdRoot.CmdClause, globals, data)
+	serviceVersionDescribe := serviceversion.NewDescribeCommand(serviceVersionCmdRoot.CmdClause, globals, data)
 	serviceVersionList := serviceversion.NewListCommand(serviceVersionCmdRoot.CmdClause, globals, data)
 	serviceVersionLock := serviceversion.NewLockCommand(serviceVersionCmdRoot.CmdClause, globals, data)
+	serviceVersionTag := serviceversion.NewTagCommand(serviceVersionCmdRoot.CmdClause, globals, data)
 	serviceVersionUpdate := serviceversion.NewUpdateCommand(serviceVersionCmdRoot.CmdClause, globals, data)
 	statsCmdRoot := stats.NewRootCommand(app, globals)
 	statsHistorical := stats.NewHistoricalCommand(statsCmdRoot.CmdClause, globals, data)
@@ -356,27 +438,49 @@ func defineCommands(
 	tlsSubscriptionDescribe := tlsSubscription.NewDescribeCommand(tlsSubscriptionCmdRoot.CmdClause, globals, data)
 	tlsSubscriptionList := tlsSubscription.NewListCommand(tlsSubscriptionCmdRoot.CmdClause, globals, data)
 	tlsSubscriptionUpdate := tlsSubscription.NewUpdateCommand(tlsSubscriptionCmdRoot.CmdClause, globals, data)
+	toolsDir := filepath.Join(compute.InstallDir, fsttools.DirName)
+	toolsAll := managedTools(opts.Versioners)
+	toolsCmdRoot := tools.NewRootCommand(app, globals)
+	toolsInstall := tools.NewInstallCommand(toolsCmdRoot.CmdClause, globals, data, toolsDir, toolsAll)
+	toolsList := tools.NewListCommand(toolsCmdRoot.CmdClause, globals, data, toolsDir, toolsAll)
+	toolsPin := tools.NewPinCommand(toolsCmdRoot.CmdClause, globals, data, toolsDir, toolsAll)
+	toolsUpgrade := tools.NewUpgradeCommand(toolsCmdRoot.CmdClause, globals, data, toolsDir, toolsAll)
 	updateRoot := update.NewRootCommand(app, opts.ConfigPath, opts.Versioners.CLI, globals)
 	userCmdRoot := user.NewRootCommand(app, globals)
 	userCreate := user.NewCreateCommand(userCmdRoot.CmdClause, globals, data)
 	userDelete := user.NewDeleteCommand(userCmdRoot.CmdClause, globals, data)
 	userDescribe := user.NewDescribeCommand(userCmdRoot.CmdClause, globals, data)
+	userInvite := user.NewInviteCommand(userCmdRoot.CmdClause, globals, data)
 	userList := user.NewListCommand(userCmdRoot.CmdClause, globals, data)
 	userUpdate := user.NewUpdateCommand(userCmdRoot.CmdClause, globals, data)
 	vclCmdRoot := vcl.NewRootCommand(app, globals)
+	vclSync := vcl.NewSyncCommand(vclCmdRoot.CmdClause, globals, data)
 	vclCustomCmdRoot := custom.NewRootCommand(vclCmdRoot.CmdClause, globals)
 	vclCustomCreate := custom.NewCreateCommand(vclCustomCmdRoot.CmdClause, globals, data)
 	vclCustomDelete := custom.NewDeleteCommand(vclCustomCmdRoot.CmdClause, globals, data)
 	vclCustomDescribe := custom.NewDescribeCommand(vclCustomCmdRoot.CmdClause, globals, data)
 	vclCustomList := custom.NewListCommand(vclCustomCmdRoot.CmdClause, globals, data)
 	vclCustomUpdate := custom.NewUpdateCommand(vclCustomCmdRoot.CmdClause, globals, data)
+	vclGeneratedCmdRoot := generated.NewRootCommand(vclCmdRoot.CmdClause, globals)
+	vclGeneratedDiff := generated.NewDiffCommand(vclGeneratedCmdRoot.CmdClause, globals, data)
+	vclGeneratedDownload := generated.NewDownloadCommand(vclGeneratedCmdRoot.CmdClause, globals, data)
 	vclSnippetCmdRoot := snippet.NewRootCommand(vclCmdRoot.CmdClause, globals)
 	vclSnippetCreate := snippet.NewCreateCommand(vclSnippetCmdRoot.CmdClause, globals, data)
 	vclSnippetDelete := snippet.NewDeleteCommand(vclSnippetCmdRoot.CmdClause, globals, data)
 	vclSnippetDescribe := snippet.NewDescribeCommand(vclSnippetCmdRoot.CmdClause, globals, data)
 	vclSnippetList := snippet.NewListCommand(vclSnippetCmdRoot.CmdClause, globals, data)
+	vclSnippetRender := snippet.NewRenderCommand(vclSnippetCmdRoot.CmdClause, globals)
 	vclSnippetUpdate := snippet.NewUpdateCommand(vclSnippetCmdRoot.CmdClause, globals, data)
 	versionCmdRoot := version.NewRootCommand(app, opts.Versioners.Viceroy)
+	wafCmdRoot := waf.NewRootCommand(app, globals)
+	wafEvents := waf.NewEventsCommand(wafCmdRoot.CmdClause, globals, data)
+	wafList := waf.NewListCommand(wafCmdRoot.CmdClause, globals)
+	wafMode := waf.NewModeCommand(wafCmdRoot.CmdClause, globals)
+	wafExclusionCmdRoot := wafExclusion.NewRootCommand(wafCmdRoot.CmdClause, globals)
+	wafExclusionCreate := wafExclusion.NewCreateCommand(wafExclusionCmdRoot.CmdClause, globals)
+	wafExclusionDelete := wafExclusion.NewDeleteCommand(wafExclusionCmdRoot.CmdClause, globals)
+	wafExclusionList := wafExclusion.NewListCommand(wafExclusionCmdRoot.CmdClause, globals)
+	wafExclusionUpdate := wafExclusion.NewUpdateCommand(wafExclusionCmdRoot.CmdClause, globals)
 	whoamiCmdRoot := whoami.NewRootCommand(app, globals)
 
 	return []cmd.Command{
@@ -393,11 +497,18 @@ func defineCommands(
 		aclEntryDescribe,
 		aclEntryList,
 		aclEntryUpdate,
+		aliasCmdRoot,
+		aliasList,
+		aliasSet,
+		aliasUnset,
+		apiCmdRoot,
 		authtokenCmdRoot,
 		authtokenCreate,
 		authtokenDelete,
 		authtokenDescribe,
 		authtokenList,
+		authtokenMint,
+		authtokenRotate,
 		backendCmdRoot,
 		backendCreate,
 		backendDelete,
@@ -405,19 +516,29 @@ func defineCommands(
 		backendList,
 		backendUpdate,
 		computeBuild,
+		billingCmdRoot,
+		billingUsage,
 		computeCmdRoot,
 		computeDeploy,
 		computeInit,
+		computeManifestCmdRoot,
+		computeManifestMigrate,
+		computeManifestSchema,
 		computePack,
 		computePublish,
 		computeServe,
+		computeStatus,
 		computeUpdate,
 		computeValidate,
 		configCmdRoot,
+		configPin,
+		configShow,
+		configUnpin,
 		dictionaryCmdRoot,
 		dictionaryCreate,
 		dictionaryDelete,
 		dictionaryDescribe,
+		dictionaryDiff,
 		dictionaryItemCmdRoot,
 		dictionaryItemCreate,
 		dictionaryItemDelete,
@@ -426,13 +547,29 @@ func defineCommands(
 		dictionaryItemUpdate,
 		dictionaryList,
 		dictionaryUpdate,
+		directorAddBackend,
+		directorCmdRoot,
+		directorCreate,
+		directorDelete,
+		directorDescribe,
+		directorList,
+		directorRebalance,
+		directorRemoveBackend,
+		directorUpdate,
 		domainCmdRoot,
 		domainCreate,
 		domainDelete,
 		domainDescribe,
 		domainList,
+		domainSuggest,
 		domainUpdate,
 		domainValidate,
+		errorsCmdRoot,
+		errorsClear,
+		errorsShow,
+		eventsCmdRoot,
+		eventsDescribe,
+		eventsList,
 		healthcheckCmdRoot,
 		healthcheckCreate,
 		healthcheckDelete,
@@ -440,11 +577,16 @@ func defineCommands(
 		healthcheckList,
 		healthcheckUpdate,
 		ipCmdRoot,
+		kvStoreCmdRoot,
+		kvStoreGet,
+		kvStoreInsert,
+		kvStoreSync,
 		logtailCmdRoot,
 		loggingAzureblobCmdRoot,
 		loggingAzureblobCreate,
 		loggingAzureblobDelete,
 		loggingAzureblobDescribe,
+		loggingAzureblobGenerateSAS,
 		loggingAzureblobList,
 		loggingAzureblobUpdate,
 		loggingBigQueryCmdRoot,
@@ -562,11 +704,13 @@ func defineCommands(
 		loggingPapertrailDescribe,
 		loggingPapertrailList,
 		loggingPapertrailUpdate,
+		loggingPresets,
 		loggingS3CmdRoot,
 		loggingS3Create,
 		loggingS3Delete,
 		loggingS3Describe,
 		loggingS3List,
+		loggingS3Policy,
 		loggingS3Update,
 		loggingScalyrCmdRoot,
 		loggingScalyrCreate,
@@ -586,6 +730,7 @@ func defineCommands(
 		loggingSplunkDescribe,
 		loggingSplunkList,
 		loggingSplunkUpdate,
+		loggingStatus,
 		loggingSumologicCmdRoot,
 		loggingSumologicCreate,
 		loggingSumologicDelete,
@@ -598,6 +743,12 @@ func defineCommands(
 		loggingSyslogDescribe,
 		loggingSyslogList,
 		loggingSyslogUpdate,
+		mcpCmdRoot,
+		originsCmdRoot,
+		originsHealth,
+		pluginCmdRoot,
+		pluginExec,
+		pluginList,
 		popCmdRoot,
 		profileCmdRoot,
 		profileCreate,
@@ -607,6 +758,17 @@ func defineCommands(
 		profileToken,
 		profileUpdate,
 		purgeCmdRoot,
+		rateLimitCmdRoot,
+		rateLimitCreate,
+		rateLimitDelete,
+		rateLimitDescribe,
+		rateLimitList,
+		rateLimitUpdate,
+		resourceLinkCmdRoot,
+		resourceLinkCreate,
+		resourceLinkDelete,
+		resourceLinkList,
+		serveAPICmdRoot,
 		serviceCmdRoot,
 		serviceCreate,
 		serviceDelete,
@@ -614,12 +776,15 @@ func defineCommands(
 		serviceList,
 		serviceSearch,
 		serviceUpdate,
+		serviceWatch,
 		serviceVersionActivate,
 		serviceVersionClone,
 		serviceVersionCmdRoot,
 		serviceVersionDeactivate,
+		serviceVersionDescribe,
 		serviceVersionList,
 		serviceVersionLock,
+		serviceVersionTag,
 		serviceVersionUpdate,
 		statsCmdRoot,
 		statsHistorical,
@@ -661,11 +826,17 @@ func defineCommands(
 		tlsSubscriptionDescribe,
 		tlsSubscriptionList,
 		tlsSubscriptionUpdate,
+		toolsCmdRoot,
+		toolsInstall,
+		toolsList,
+		toolsPin,
+		toolsUpgrade,
 		updateRoot,
 		userCmdRoot,
 		userCreate,
 		userDelete,
 		userDescribe,
+		userInvite,
 		userList,
 		userUpdate,
 		vclCmdRoot,
@@ -675,13 +846,43 @@ func defineCommands(
 		vclCustomDescribe,
 		vclCustomList,
 		vclCustomUpdate,
+		vclGeneratedCmdRoot,
+		vclGeneratedDiff,
+		vclGeneratedDownload,
 		vclSnippetCmdRoot,
 		vclSnippetCreate,
 		vclSnippetDelete,
 		vclSnippetDescribe,
 		vclSnippetList,
+		vclSnippetRender,
 		vclSnippetUpdate,
+		vclSync,
 		versionCmdRoot,
+		wafCmdRoot,
+		wafEvents,
+		wafList,
+		wafMode,
+		wafExclusionCmdRoot,
+		wafExclusionCreate,
+		wafExclusionDelete,
+		wafExclusionList,
+		wafExclusionUpdate,
 		whoamiCmdRoot,
 	}
 }
+
+// managedTools returns the tools managed by `fastly tools`, omitting those
+// without a versioner (e.g. when the checks for new releases are disabled).
+func managedTools(v Versioners) []fsttools.Tool {
+	var tools []fsttools.Tool
+	if v.JSComputeRuntime != nil {
+		tools = append(tools, fsttools.JSComputeRuntime(v.JSComputeRuntime))
+	}
+	if v.Viceroy != nil {
+		tools = append(tools, fsttools.Viceroy(v.Viceroy))
+	}
+	if v.WasmOpt != nil {
+		tools = append(tools, fsttools.WasmOpt(v.WasmOpt))
+	}
+	return tools
+}
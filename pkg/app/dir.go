@@ -0,0 +1,97 @@
+package app
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	fsterr "github.com/fastly/cli/pkg/errors"
+	"github.com/fastly/cli/pkg/manifest"
+)
+
+// projectDir returns the directory the command runs in, which is determined
+// before the manifest is read and the arguments are parsed, which is why the
+// --dir flag is extracted by hand.
+//
+// The directory is the --dir directory, if given, resolved against base, then
+// the nearest parent directory with a fastly.toml manifest when that directory
+// doesn't have one, so commands work from a project's sub-folders.
+//
+// NOTE: `compute init` doesn't search parent directories, as it creates a new
+// project, which in a monorepo may well be nested within another.
+func projectDir(base string, args []string) (string, error) {
+	dir := base
+	if d := dirFromArgs(args); d != "" {
+		if !filepath.IsAbs(d) {
+			d = filepath.Join(base, d)
+		}
+		if fi, err := os.Stat(d); err != nil || !fi.IsDir() {
+			if err == nil {
+				err = fmt.Errorf("%s is not a directory", d)
+			}
+			return base, fsterr.RemediationError{
+				Inner:       fmt.Errorf("error changing to --dir directory: %w", err),
+				Remediation: "Check the --dir flag is set to an existing directory.",
+			}
+		}
+		dir = d
+	}
+
+	if isComputeInit(args) {
+		return dir, nil
+	}
+	root, err := manifest.Find(dir)
+	if err != nil || root == "" {
+		return dir, nil
+	}
+	return root, nil
+}
+
+// changeDir changes the working directory to dir. The returned function
+// restores the original working directory.
+func changeDir(wd, dir string) (restore func(), err error) {
+	restore = func() {}
+	if dir == wd {
+		return restore, nil
+	}
+	if err := os.Chdir(dir); err != nil {
+		return restore, fmt.Errorf("error changing to project directory: %w", err)
+	}
+	return func() { _ = os.Chdir(wd) }, nil
+}
+
+// dirFromArgs returns the value of the --dir (-C) flag.
+func dirFromArgs(args []string) string {
+	var dir string
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		switch {
+		case arg == "--":
+			return dir
+		case arg == "--dir" || arg == "-C":
+			if i+1 < len(args) {
+				dir = args[i+1]
+				i++
+			}
+		case strings.HasPrefix(arg, "--dir="):
+			dir = strings.TrimPrefix(arg, "--dir=")
+		case strings.HasPrefix(arg, "-C") && !strings.HasPrefix(arg, "--"):
+			dir = strings.TrimPrefix(strings.TrimPrefix(arg, "-C"), "=")
+		}
+	}
+	return dir
+}
+
+// isComputeInit reports whether the arguments run `compute init`.
+func isComputeInit(args []string) bool {
+	for i := 0; i+1 < len(args); i++ {
+		if args[i] == "--" {
+			return false
+		}
+		if args[i] == "compute" && args[i+1] == "init" {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,352 @@
+package app
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	fsterr "github.com/fastly/cli/pkg/errors"
+	"github.com/fastly/cli/pkg/revision"
+	"github.com/fastly/cli/pkg/text"
+	"github.com/fastly/kingpin"
+)
+
+// HelpFormats are the formats `help generate` renders the help in.
+var HelpFormats = []string{"man", "markdown"}
+
+// helpPage is the help for a single command, or the application itself when
+// the command is empty.
+type helpPage struct {
+	Command     string
+	Description string
+	Synopsis    string
+	Args        []*kingpin.ClauseModel
+	Flags       []flagJSON
+	Examples    []Example
+	APIs        []string
+	Related     []string
+}
+
+// name returns the page name, e.g. fastly-compute-build.
+func (p helpPage) name() string {
+	return helpPageName(p.Command)
+}
+
+// helpPageName returns the page name of the command.
+func helpPageName(command string) string {
+	if command == "" {
+		return "fastly"
+	}
+	return "fastly-" + strings.ReplaceAll(command, " ", "-")
+}
+
+// generateHelp implements `fastly help generate`, which renders a man page or
+// markdown document for every command from the kingpin command tree and the
+// metadata.json examples, so the manuals are generated from the same source
+// as the --help output.
+//
+// NOTE: As with `help --format json`, the kingpin help command can't have
+// subcommands, so the args are parsed here rather than by the application.
+func generateHelp(app *kingpin.Application, args []string, out io.Writer) error {
+	var format, dir string
+	g := kingpin.New("fastly help generate", "")
+	g.Writers(io.Discard, io.Discard)
+	g.Terminate(nil)
+	g.Flag("format", "The help format").Default("man").EnumVar(&format, HelpFormats...)
+	g.Flag("output", "The directory the help is written to").Short('o').Default(".").StringVar(&dir)
+	if _, err := g.Parse(args); err != nil {
+		return fsterr.RemediationError{
+			Inner:       fmt.Errorf("error parsing arguments: %w", err),
+			Remediation: fmt.Sprintf("Run `fastly help generate [--format %s] [--output DIR]`.", strings.Join(HelpFormats, "|")),
+		}
+	}
+
+	pages, err := helpPages(app)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return fmt.Errorf("error creating help directory: %w", err)
+	}
+	for _, p := range pages {
+		var filename, content string
+		switch format {
+		case "man":
+			filename, content = p.name()+".1", manPage(p)
+		case "markdown":
+			filename, content = p.name()+".md", markdownPage(p)
+		}
+		if err := os.WriteFile(filepath.Join(dir, filename), []byte(content), 0o644); err != nil {
+			return fmt.Errorf("error writing help: %w", err)
+		}
+	}
+
+	text.Success(out, "Generated %d %s pages in %s", len(pages), format, dir)
+	return nil
+}
+
+// exitCodesHelp implements `fastly help exit-codes`, which documents the exit
+// codes scripts can branch on.
+func exitCodesHelp(out io.Writer) {
+	fmt.Fprintln(out, text.Bold("EXIT CODES"))
+	for _, c := range fsterr.ExitCodes {
+		fmt.Fprintf(out, "  %-5d%s\n", c.Code, c.Description)
+	}
+	fmt.Fprintln(out)
+	fmt.Fprintln(out, text.Wrap("Codes other than 0 and 1 are only returned for the failures described, so scripts can distinguish e.g. an expired API token from an API outage.", text.DefaultTextWidth))
+}
+
+// helpPages returns the help for the application and each visible command.
+func helpPages(app *kingpin.Application) ([]helpPage, error) {
+	var data commandsMetadata
+	if err := json.Unmarshal(metadata, &data); err != nil {
+		return nil, err
+	}
+
+	model := app.Model()
+	root := helpPage{
+		Description: model.Help,
+		Synopsis:    "fastly [<flags>] <command> [<args> ...]",
+		Flags:       getGlobalFlagJSON(model.Flags),
+	}
+	for _, c := range visibleCommands(model.Commands) {
+		root.Related = append(root.Related, c.FullCommand())
+	}
+
+	pages := []helpPage{root}
+	var walk func(cmds []*kingpin.CmdModel, siblings []*kingpin.CmdModel)
+	walk = func(cmds []*kingpin.CmdModel, siblings []*kingpin.CmdModel) {
+		for _, c := range cmds {
+			if c.Hidden {
+				continue
+			}
+			pages = append(pages, commandHelpPage(c, siblings, data))
+			walk(c.Commands, visibleCommands(c.Commands))
+		}
+	}
+	walk(model.Commands, visibleCommands(model.Commands))
+	return pages, nil
+}
+
+// commandHelpPage returns the help for the command. The related commands are
+// the parent, the subcommands and the siblings of the command.
+func commandHelpPage(c *kingpin.CmdModel, siblings []*kingpin.CmdModel, data commandsMetadata) helpPage {
+	p := helpPage{
+		Command:     c.FullCommand(),
+		Description: c.Help,
+		Synopsis:    "fastly " + c.FullCommand(),
+		Flags:       getFlagJSON(c.Flags),
+	}
+	if s := c.FlagSummary(); s != "" {
+		p.Synopsis += " " + s
+	}
+	if s := c.ArgSummary(); s != "" {
+		p.Synopsis += " " + s
+	}
+	if len(visibleCommands(c.Commands)) > 0 {
+		p.Synopsis += " <command> [<args> ...]"
+	}
+	for _, a := range c.Args {
+		if !a.Hidden {
+			p.Args = append(p.Args, a)
+		}
+	}
+
+	// NOTE: getCommandJSON resolves the metadata.json content of the command,
+	// which we reuse so the examples and APIs are validated the same way.
+	if cj := getCommandJSON([]*kingpin.CmdModel{c}, data); len(cj) > 0 {
+		p.Examples = cj[0].Examples
+		p.APIs = cj[0].APIs
+	}
+
+	if c.Parent != nil {
+		p.Related = append(p.Related, c.Parent.FullCommand())
+	} else {
+		p.Related = append(p.Related, "")
+	}
+	for _, sub := range visibleCommands(c.Commands) {
+		p.Related = append(p.Related, sub.FullCommand())
+	}
+	for _, s := range siblings {
+		if s != c {
+			p.Related = append(p.Related, s.FullCommand())
+		}
+	}
+	return p
+}
+
+// visibleCommands returns the commands that aren't hidden.
+func visibleCommands(cmds []*kingpin.CmdModel) []*kingpin.CmdModel {
+	var visible []*kingpin.CmdModel
+	for _, c := range cmds {
+		if !c.Hidden {
+			visible = append(visible, c)
+		}
+	}
+	return visible
+}
+
+// flagUsage returns the usage of the flag, e.g. --name=NAME.
+func flagUsage(f flagJSON) string {
+	if f.IsBool {
+		return "--" + f.Name
+	}
+	placeholder := f.Placeholder
+	if placeholder == "" {
+		placeholder = strings.ToUpper(f.Name)
+	}
+	return fmt.Sprintf("--%s=%s", f.Name, placeholder)
+}
+
+// manPage renders the help page in the roff format used by man(1).
+func manPage(p helpPage) string {
+	var b strings.Builder
+	title := strings.ToUpper(p.name())
+	fmt.Fprintf(&b, ".TH %s 1 \"\" \"fastly %s\" \"Fastly CLI Manual\"\n", roff(title), roff(revision.AppVersion))
+
+	b.WriteString(".SH NAME\n")
+	fmt.Fprintf(&b, "%s \\- %s\n", roff(p.name()), roff(p.Description))
+
+	b.WriteString(".SH SYNOPSIS\n")
+	fmt.Fprintf(&b, "\\fB%s\\fR\n", roff(p.Synopsis))
+
+	b.WriteString(".SH DESCRIPTION\n")
+	fmt.Fprintf(&b, "%s\n", roff(p.Description))
+
+	if len(p.Args) > 0 {
+		b.WriteString(".SH ARGUMENTS\n")
+		for _, a := range p.Args {
+			fmt.Fprintf(&b, ".TP\n\\fI%s\\fR\n%s\n", roff(a.Name), roff(a.Help))
+		}
+	}
+
+	if len(p.Flags) > 0 {
+		b.WriteString(".SH OPTIONS\n")
+		for _, f := range p.Flags {
+			fmt.Fprintf(&b, ".TP\n\\fB%s\\fR\n%s", roff(flagUsage(f)), roff(f.Description))
+			if f.Required {
+				b.WriteString(" (required)")
+			}
+			if f.Default != "" {
+				fmt.Fprintf(&b, " (default: %s)", roff(f.Default))
+			}
+			b.WriteString("\n")
+		}
+	}
+
+	if len(p.Examples) > 0 {
+		b.WriteString(".SH EXAMPLES\n")
+		for _, e := range p.Examples {
+			fmt.Fprintf(&b, ".PP\n%s\n", roff(e.Title))
+			if e.Description != "" {
+				fmt.Fprintf(&b, ".PP\n%s\n", roff(e.Description))
+			}
+			fmt.Fprintf(&b, ".PP\n.RS 4\n.nf\n%s\n.fi\n.RE\n", roff(e.Cmd))
+		}
+	}
+
+	if len(p.APIs) > 0 {
+		b.WriteString(".SH API REFERENCE\n")
+		for _, api := range p.APIs {
+			fmt.Fprintf(&b, ".IP \\(bu 2\n%s\n", roff(api))
+		}
+	}
+
+	if len(p.Related) > 0 {
+		b.WriteString(".SH SEE ALSO\n")
+		related := make([]string, 0, len(p.Related))
+		for _, r := range p.Related {
+			related = append(related, fmt.Sprintf("\\fB%s\\fR(1)", roff(helpPageName(r))))
+		}
+		fmt.Fprintf(&b, "%s\n", strings.Join(related, ", "))
+	}
+	return b.String()
+}
+
+// roffEscaper escapes the characters roff treats specially.
+var roffEscaper = strings.NewReplacer(`\`, `\e`, "-", `\-`)
+
+// roff escapes s for use in a roff document, including lines that would be
+// mistaken for roff requests.
+func roff(s string) string {
+	lines := strings.Split(roffEscaper.Replace(s), "\n")
+	for i, l := range lines {
+		if strings.HasPrefix(l, ".") || strings.HasPrefix(l, "'") {
+			lines[i] = `\&` + l
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// markdownPage renders the help page as markdown, linking the related commands
+// to their own pages.
+func markdownPage(p helpPage) string {
+	var b strings.Builder
+	heading := "fastly"
+	if p.Command != "" {
+		heading += " " + p.Command
+	}
+	fmt.Fprintf(&b, "# %s\n\n%s\n\n", heading, p.Description)
+	fmt.Fprintf(&b, "## Usage\n\n```\n%s\n```\n", p.Synopsis)
+
+	if len(p.Args) > 0 {
+		b.WriteString("\n## Arguments\n\n| Argument | Description |\n| --- | --- |\n")
+		for _, a := range p.Args {
+			fmt.Fprintf(&b, "| `%s` | %s |\n", a.Name, markdownCell(a.Help))
+		}
+	}
+
+	if len(p.Flags) > 0 {
+		title := "Flags"
+		if p.Command == "" {
+			title = "Global flags"
+		}
+		fmt.Fprintf(&b, "\n## %s\n\n| Flag | Description | Default |\n| --- | --- | --- |\n", title)
+		for _, f := range p.Flags {
+			desc := markdownCell(f.Description)
+			if f.Required {
+				desc += " (required)"
+			}
+			fmt.Fprintf(&b, "| `%s` | %s | %s |\n", flagUsage(f), desc, markdownCell(f.Default))
+		}
+	}
+
+	if len(p.Examples) > 0 {
+		b.WriteString("\n## Examples\n")
+		for _, e := range p.Examples {
+			fmt.Fprintf(&b, "\n### %s\n\n", e.Title)
+			if e.Description != "" {
+				fmt.Fprintf(&b, "%s\n\n", e.Description)
+			}
+			fmt.Fprintf(&b, "```sh\n%s\n```\n", e.Cmd)
+		}
+	}
+
+	if len(p.APIs) > 0 {
+		b.WriteString("\n## API reference\n\n")
+		for _, api := range p.APIs {
+			fmt.Fprintf(&b, "- <%s>\n", api)
+		}
+	}
+
+	if len(p.Related) > 0 {
+		b.WriteString("\n## Related commands\n\n")
+		for _, r := range p.Related {
+			name := "fastly"
+			if r != "" {
+				name += " " + r
+			}
+			fmt.Fprintf(&b, "- [%s](%s.md)\n", name, helpPageName(r))
+		}
+	}
+	return b.String()
+}
+
+// markdownCell escapes s for use in a markdown table cell.
+func markdownCell(s string) string {
+	return strings.NewReplacer("|", `\|`, "\n", " ").Replace(s)
+}
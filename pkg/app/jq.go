@@ -0,0 +1,78 @@
+package app
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/fastly/cli/pkg/cmd"
+	fsterr "github.com/fastly/cli/pkg/errors"
+	"github.com/itchyny/gojq"
+)
+
+// compileJQ compiles the expression given by the global --jq flag.
+func compileJQ(expr string) (*gojq.Code, error) {
+	query, err := gojq.Parse(expr)
+	if err != nil {
+		return nil, fsterr.RemediationError{
+			Inner:       fmt.Errorf("invalid --jq expression: %w", err),
+			Remediation: "See https://stedolan.github.io/jq/manual/ for the expression syntax.",
+		}
+	}
+	code, err := gojq.Compile(query)
+	if err != nil {
+		return nil, fmt.Errorf("invalid --jq expression: %w", err)
+	}
+	return code, nil
+}
+
+// execJQ executes the command, then writes the result of applying the
+// compiled --jq expression to each JSON value in its output.
+//
+// NOTE: Like `gh --jq`, string results are written without quotes so they can
+// be used directly in shell scripts.
+func execJQ(command cmd.Command, code *gojq.Code, in io.Reader, out io.Writer) error {
+	var buf bytes.Buffer
+	if err := command.Exec(in, &buf); err != nil {
+		// The output may explain the error, so it isn't discarded.
+		_, _ = out.Write(buf.Bytes())
+		return err
+	}
+
+	dec := json.NewDecoder(&buf)
+	for {
+		var v any
+		err := dec.Decode(&v)
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return fsterr.RemediationError{
+				Inner:       fmt.Errorf("the command output isn't JSON: %w", err),
+				Remediation: fsterr.JQRemediation,
+			}
+		}
+
+		iter := code.Run(v)
+		for {
+			result, ok := iter.Next()
+			if !ok {
+				break
+			}
+			if err, ok := result.(error); ok {
+				return fmt.Errorf("error evaluating --jq expression: %w", err)
+			}
+			if s, ok := result.(string); ok {
+				fmt.Fprintln(out, s)
+				continue
+			}
+			data, err := json.Marshal(result)
+			if err != nil {
+				return err
+			}
+			fmt.Fprintln(out, string(data))
+		}
+	}
+}
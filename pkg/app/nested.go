@@ -0,0 +1,25 @@
+package app
+
+import (
+	"io"
+
+	"github.com/fastly/cli/pkg/commands/serveapi"
+	"github.com/fastly/cli/pkg/text"
+)
+
+// nestedRunner returns a runner that executes commands in-process, with the
+// same configuration as the current invocation, on behalf of the serve-api
+// and mcp commands.
+//
+// Prompts fail rather than block, as there's no user to answer them, and the
+// CLI update check is skipped as it was already made for the server itself.
+func nestedRunner(opts RunOpts) serveapi.Runner {
+	return func(args []string, out io.Writer) error {
+		o := opts
+		o.Args = args
+		o.Stdin = text.NonInteractiveReader{}
+		o.Stdout = out
+		o.Versioners.CLI = nil
+		return Run(o)
+	}
+}
@@ -0,0 +1,23 @@
+package app
+
+import (
+	"github.com/fastly/cli/pkg/commands/plugin"
+	"github.com/fastly/kingpin"
+)
+
+// expandPlugin rewrites `fastly <name> <args>` as `fastly plugin exec <name>
+// -- <args>` when <name> isn't a command but a plugin executable exists for
+// it, so the global flags preceding the plugin name are processed as usual.
+func expandPlugin(args []string, app *kingpin.Application) []string {
+	i := commandIndex(args, app.Model().Flags)
+	if i < 0 || isCommand(app, args[i]) {
+		return args
+	}
+	if _, ok := plugin.Find(args[i]); !ok {
+		return args
+	}
+	expanded := make([]string, 0, len(args)+3)
+	expanded = append(expanded, args[:i]...)
+	expanded = append(expanded, "plugin", "exec", args[i], "--")
+	return append(expanded, args[i+1:]...)
+}
@@ -2,9 +2,12 @@ package app
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"os"
+	"path/filepath"
+	"strconv"
 	"strings"
 	"time"
 
@@ -14,32 +17,67 @@ import (
 	"github.com/fastly/cli/pkg/config"
 	"github.com/fastly/cli/pkg/env"
 	fsterr "github.com/fastly/cli/pkg/errors"
+	"github.com/fastly/cli/pkg/interrupt"
+	"github.com/fastly/cli/pkg/logger"
 	"github.com/fastly/cli/pkg/manifest"
 	"github.com/fastly/cli/pkg/profile"
 	"github.com/fastly/cli/pkg/revision"
 	"github.com/fastly/cli/pkg/text"
 	"github.com/fastly/go-fastly/v6/fastly"
 	"github.com/fastly/kingpin"
+	"github.com/itchyny/gojq"
 )
 
 // Versioners represents all supported versioner types.
 type Versioners struct {
-	CLI     update.Versioner
-	Viceroy update.Versioner
+	CLI              update.Versioner
+	JSComputeRuntime update.Versioner
+	Viceroy          update.Versioner
+	WasmOpt          update.Versioner
 }
 
 // RunOpts represent arguments to Run()
+//
+// NOTE: Programs embedding the CLI should prefer the sdk package, which
+// provides defaults for these options.
 type RunOpts struct {
-	APIClient  APIClientFactory
-	Args       []string
+	// APIClient creates the Fastly API client from the resolved token and
+	// endpoint.
+	APIClient APIClientFactory
+	// Args are the command line arguments, excluding the program name.
+	Args []string
+	// ConfigFile is the application configuration, already read from disk.
 	ConfigFile config.File
+	// ConfigPath is where commands persist changes to the ConfigFile.
 	ConfigPath string
-	Env        config.Environment
-	ErrLog     fsterr.LogInterface
+	// Dir is the directory the command runs in, as if the CLI was started
+	// there. When set, the process working directory is neither used nor
+	// changed, which allows concurrent runs (e.g. in parallel tests).
+	//
+	// NOTE: Only commands that resolve local files against config.Data.Dir
+	// (e.g. `compute build`) support running in a directory other than the
+	// process working directory.
+	Dir string
+	// Env holds the configuration provided by environment variables.
+	Env config.Environment
+	// ErrLog records errors for later diagnosis.
+	ErrLog fsterr.LogInterface
+	// HTTPClient is used for requests that don't go via the APIClient.
 	HTTPClient api.HTTPClient
-	Stdin      io.Reader
-	Stdout     io.Writer
+	// Interrupt handles the user interrupting the CLI. If nil, Run installs a
+	// handler for SIGINT and SIGTERM.
+	Interrupt *interrupt.Handler
+	// Stdin provides input to interactive prompts.
+	Stdin io.Reader
+	// Stdout receives all command output.
+	Stdout io.Writer
+	// Versioners check for new releases, a nil Versioner disables the check.
 	Versioners Versioners
+
+	// shared marks one of the concurrent runs of a bulk run, which uses the
+	// process-wide state (the working directory, logger and output settings)
+	// set up by the bulk run rather than changing it.
+	shared bool
 }
 
 // Run constructs the application including all of the subcommands, parses the
@@ -53,28 +91,64 @@ type RunOpts struct {
 // io.Writer. All error-related information should be encoded into an error type
 // and returned to the caller. This includes usage text.
 func Run(opts RunOpts) error {
+	// NOTE: Nested and bulk runs share the handler of the run that invoked them,
+	// which is also responsible for the --command-timeout.
+	ownInterrupt := opts.Interrupt == nil
+	if ownInterrupt {
+		opts.Interrupt = interrupt.New()
+		stop := opts.Interrupt.Notify(opts.Stdout)
+		defer stop()
+		opts.HTTPClient = opts.Interrupt.Client(opts.HTTPClient)
+		opts.Stdin = opts.Interrupt.Reader(opts.Stdin)
+	}
+
+	dir, err := runDir(opts)
+	if err != nil {
+		opts.ErrLog.Add(err)
+		return err
+	}
+	if opts.Dir == "" && !opts.shared {
+		wd, _ := os.Getwd()
+		restoreDir, err := changeDir(wd, dir)
+		defer restoreDir()
+		if err != nil {
+			opts.ErrLog.Add(err)
+			return err
+		}
+	}
+
 	var md manifest.Data
 	md.File.SetErrLog(opts.ErrLog)
 	md.File.SetOutput(opts.Stdout)
-	md.File.Read(manifest.Filename)
+	md.File.Read(filepath.Join(dir, manifest.Filename))
+
+	settings, err := manifest.ReadSettings(dir, md.File)
+	if err != nil {
+		opts.ErrLog.Add(err)
+		return err
+	}
 
 	// The globals will hold generally-applicable configuration parameters
 	// from a variety of sources, and is provided to each concrete command.
 	globals := config.Data{
+		Dir:        dir,
 		Env:        opts.Env,
 		ErrLog:     opts.ErrLog,
 		File:       opts.ConfigFile,
 		HTTPClient: opts.HTTPClient,
+		Interrupt:  opts.Interrupt,
 		Manifest:   md,
 		Output:     opts.Stdout,
 		Path:       opts.ConfigPath,
+		Settings:   settings,
 	}
 
 	// Set up the main application root, including global flags, and then each
 	// of the subcommands. Note that we deliberately don't use some of the more
 	// advanced features of the kingpin.Application flags, like env var
 	// bindings, because we need to do things like track where a config
-	// parameter came from.
+	// parameter came from. Flag values from FASTLY_ env vars are instead
+	// provided as arguments (see cmd.EnvFlagArgs).
 	app := kingpin.New("fastly", "A tool to interact with the Fastly API")
 	app.Writers(opts.Stdout, io.Discard) // don't let kingpin write error output
 	app.UsageContext(&kingpin.UsageContext{
@@ -98,32 +172,145 @@ func Run(opts RunOpts) error {
 	// NOTE: Short flags CAN be safely reused across commands.
 	tokenHelp := fmt.Sprintf("Fastly API token (or via %s)", env.Token)
 	app.Flag("accept-defaults", "Accept default options for all interactive prompts apart from Yes/No confirmations").Short('d').BoolVar(&globals.Flag.AcceptDefaults)
+	app.Flag("answer", "Answer an interactive prompt ahead of time as name=value (e.g. init.name=my-app), can be repeated").StringMapVar(&globals.Flag.Answers)
+	app.Flag("answers-file", "Path to a JSON file of interactive prompt answers (e.g. {\"init.name\": \"my-app\"}), overridden by --answer").StringVar(&globals.Flag.AnswersFile)
 	app.Flag("auto-yes", "Answer yes automatically to all Yes/No confirmations. This may suppress security warnings").Short('y').BoolVar(&globals.Flag.AutoYes)
+	app.Flag("command-timeout", "Cancel the command if it runs for longer than this duration (e.g. 30s, 5m), 0 disables the timeout").PlaceHolder("DURATION").DurationVar(&globals.Flag.CommandTimeout)
+	app.Flag("color", "Colour the output: auto (when writing to a terminal, unless NO_COLOR is set), always or never").Default(text.ColorAuto).EnumVar(&globals.Flag.Color, text.ColorModes...)
+	app.Flag("columns", "Comma-separated list of table columns to display (e.g. name,updated_at)").StringsVar(&globals.Flag.Columns, kingpin.Separator(","))
+	app.Flag("concurrency", "Number of services to run the command against at once with --service-id-file").Default(strconv.Itoa(DefaultConcurrency)).IntVar(&globals.Flag.Concurrency)
+	app.Flag("dir", "Change to this directory before running the command (like git -C)").Short('C').PlaceHolder("DIR").StringVar(&globals.Flag.Dir)
 	app.Flag("endpoint", "Fastly API endpoint").Hidden().StringVar(&globals.Flag.Endpoint)
+	app.Flag("interactive", "Prompt for user input even when stdin isn't a terminal or the project settings disable prompts").BoolVar(&globals.Flag.Interactive)
+	app.Flag("jq", "Filter a command's JSON output with a jq expression (e.g. '.[0].Name')").StringVar(&globals.Flag.JQ)
+	app.Flag("log-file", "Write diagnostic logs to this file rather than stderr (see --log-level)").PlaceHolder("FILE").StringVar(&globals.Flag.LogFile)
+	app.Flag("log-level", fmt.Sprintf("Log diagnostic messages at or above this level, separately from the command output: %s", strings.Join(logger.LevelNames, ", "))).Default("off").EnumVar(&globals.Flag.LogLevel, logger.LevelNames...)
 	app.Flag("non-interactive", "Do not prompt for user input - suitable for CI processes. Equivalent to --accept-defaults and --auto-yes").Short('i').BoolVar(&globals.Flag.NonInteractive)
 	app.Flag("profile", "Switch account profile for single command execution (see also: 'fastly profile switch')").Short('o').StringVar(&globals.Flag.Profile)
+	app.Flag("quiet", "Suppress informational and progress output, only errors and requested data are displayed").Short('q').BoolVar(&globals.Flag.Quiet)
+	app.Flag("service-id-file", "Run the command against each service ID in the file (one per line) and summarise the results").PlaceHolder("FILE").StringVar(&globals.Flag.ServiceIDFile)
+	app.Flag("show-secrets", "Display secrets such as tokens, passwords and keys in command output rather than masking them").BoolVar(&globals.Flag.ShowSecrets)
+	app.Flag("sort-by", "Table column to sort rows by, prefix with '-' for descending order (e.g. --sort-by=-updated_at)").StringVar(&globals.Flag.SortBy)
+	app.Flag("strict-deprecations", "Fail, rather than warn, when a deprecated command or flag is used").BoolVar(&globals.Flag.StrictDeprecations)
+	app.Flag("theme", fmt.Sprintf("The colour theme of the output: %s", strings.Join(text.ThemeNames, ", "))).Default(text.ThemeNames[0]).EnumVar(&globals.Flag.Theme, text.ThemeNames...)
 	app.Flag("token", tokenHelp).Short('t').StringVar(&globals.Flag.Token)
 	app.Flag("verbose", "Verbose logging").Short('v').BoolVar(&globals.Flag.Verbose)
 
 	commands := defineCommands(app, &globals, md, opts)
-	command, name, err := processCommandInput(opts, app, &globals, commands)
+
+	args, err := expandAlias(opts.Args, globals.File.Aliases, app)
+	if err != nil {
+		globals.ErrLog.Add(err)
+		return err
+	}
+	opts.Args = expandPlugin(args, app)
+
+	command, name, deprecated, err := processCommandInput(opts, app, &globals, commands)
 	if err != nil {
 		return err
 	}
 	// We short-circuit the execution for specific cases:
 	//
 	// - cmd.ArgsIsHelpJSON() == true
+	// - cmd.ArgsIsHelpExitCodes() == true
+	// - cmd.ArgsIsHelpGenerate() == true
 	// - shell autocompletion flag provided
 	switch name {
 	case "help--format=json":
 		fallthrough
 	case "help--formatjson":
 		fallthrough
+	case "help exit-codes":
+		fallthrough
+	case "help generate":
+		fallthrough
 	case "shell-autocomplete":
 		return nil
 	}
 
+	level, err := logger.ParseLevel(globals.Flag.LogLevel)
+	if err != nil {
+		return err
+	}
+	lg := logger.Default()
+	if !opts.shared {
+		lg, err = logger.Open(globals.Flag.LogFile, level)
+		if err != nil {
+			globals.ErrLog.Add(err)
+			return err
+		}
+		defer lg.Close()
+		defer logger.SetDefault(logger.SetDefault(lg))
+	}
+	logger.Debug("running command", "command", name, "version", revision.AppVersion)
+
+	if globals.Verbose() && globals.Flag.Quiet {
+		return fsterr.ErrInvalidVerboseQuietCombo
+	}
+	if globals.Verbose() && globals.Flag.JQ != "" {
+		return fsterr.ErrInvalidVerboseJQCombo
+	}
+	if ownInterrupt {
+		stop := opts.Interrupt.Timeout(globals.Flag.CommandTimeout, opts.Stdout)
+		defer stop()
+	}
+
+	if !opts.shared {
+		text.SetQuiet(globals.Flag.Quiet)
+		text.SetColor(text.ColorOptions{
+			Mode:       globals.Flag.Color,
+			NoColor:    globals.Env.NoColor,
+			ForceColor: globals.Env.ForceColor,
+		}.Enabled(text.DetectTerminal(opts.Stdin)))
+		text.SetTheme(text.Themes[globals.Flag.Theme])
+		text.SetShowSecrets(globals.Flag.ShowSecrets)
+		text.SetTableOptions(text.TableOptions{
+			Columns: globals.Flag.Columns,
+			SortBy:  globals.Flag.SortBy,
+		})
+	}
+
+	if globals.Flag.ServiceIDFile != "" {
+		return timeoutErr(runBulk(opts, app, name, globals.Flag.Concurrency, globals.Flag.ServiceIDFile), opts.Interrupt, globals.Flag.CommandTimeout)
+	}
+
+	for _, d := range deprecated {
+		text.Warning(opts.Stdout, "%s", d.Warning())
+	}
+
+	var jq *gojq.Code
+	if globals.Flag.JQ != "" {
+		jq, err = compileJQ(globals.Flag.JQ)
+		if err != nil {
+			return err
+		}
+	}
+
+	if globals.Flag.AnswersFile != "" {
+		globals.Answers, err = text.ReadAnswers(globals.Flag.AnswersFile)
+		if err != nil {
+			globals.ErrLog.Add(err)
+			return err
+		}
+	}
+	globals.Answers = globals.Answers.Merge(globals.Flag.Answers)
+
+	// Prompts must fail rather than wait on stdin when --non-interactive is set,
+	// or when stdin isn't a terminal (e.g. in a CI environment) or the project
+	// settings disable prompts, unless --interactive is set.
+	//
+	// NOTE: Only the explicit flag implies the answers to prompts (see
+	// --auto-yes), as a confirmation that can't be answered must not be
+	// approved. The mcp command and `api --data @-` read their input from
+	// stdin, which is only withheld from them when --non-interactive is set.
+	in := opts.Stdin
+	noPrompts := !text.DetectTerminal(opts.Stdin).Interactive() || (settings.NonInteractive != nil && *settings.NonInteractive)
+	if globals.Flag.NonInteractive || (noPrompts && !globals.Flag.Interactive && !readsStdin(name)) {
+		in = text.NonInteractiveReader{}
+	}
+
 	token, source := globals.Token()
+	logger.Debug("resolved token", "source", source)
 
 	if globals.Verbose() {
 		displayTokenSource(
@@ -134,16 +321,19 @@ func Run(opts RunOpts) error {
 		)
 	}
 
-	token, err = profile.Init(token, &md, &globals, opts.Stdin, opts.Stdout)
+	token, err = profile.Init(token, &md, &globals, in, opts.Stdout)
 	if err != nil {
-		return err
+		return nonInteractiveErr(err)
 	}
 
+	// The mcp command's stdout is reserved for protocol messages.
+	reservedStdout := name == "mcp"
+
 	// If we are using the token from config file, check the files permissions
 	// to assert if they are not too open or have been altered outside of the
 	// application and warn if so.
 	segs := strings.Split(name, " ")
-	if source == config.SourceFile && (len(segs) > 0 && segs[0] != "profile") {
+	if source == config.SourceFile && (len(segs) > 0 && segs[0] != "profile") && !reservedStdout {
 		if fi, err := os.Stat(config.FilePath); err == nil {
 			if mode := fi.Mode().Perm(); mode > config.FilePermissions {
 				text.Warning(opts.Stdout, "Unprotected configuration file.")
@@ -155,6 +345,7 @@ func Run(opts RunOpts) error {
 	}
 
 	endpoint, source := globals.Endpoint()
+	logger.Debug("resolved api endpoint", "endpoint", endpoint, "source", source)
 	if globals.Verbose() {
 		switch source {
 		case config.SourceEnvironment:
@@ -171,6 +362,9 @@ func Run(opts RunOpts) error {
 		globals.ErrLog.Add(err)
 		return fmt.Errorf("error constructing Fastly API client: %w", err)
 	}
+	if c, ok := globals.APIClient.(*fastly.Client); ok && c.HTTPClient != nil {
+		c.HTTPClient.Transport = opts.Interrupt.Transport(lg.Transport(c.HTTPClient.Transport))
+	}
 
 	globals.RTSClient, err = fastly.NewRealtimeStatsClientForEndpoint(token, fastly.DefaultRealtimeStatsEndpoint)
 	if err != nil {
@@ -178,7 +372,7 @@ func Run(opts RunOpts) error {
 		return fmt.Errorf("error constructing Fastly realtime stats client: %w", err)
 	}
 
-	if opts.Versioners.CLI != nil && name != "update" && !version.IsPreRelease(revision.AppVersion) {
+	if opts.Versioners.CLI != nil && name != "update" && !reservedStdout && !version.IsPreRelease(revision.AppVersion) {
 		ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
 		defer cancel() // push cancel on the defer stack first...
 		f := update.CheckAsync(
@@ -189,7 +383,66 @@ func Run(opts RunOpts) error {
 		defer f(opts.Stdout) // ...and the printing function second, so we hit the timeout
 	}
 
-	return command.Exec(opts.Stdin, opts.Stdout)
+	if jq != nil {
+		err = execJQ(command, jq, in, opts.Stdout)
+	} else {
+		err = command.Exec(in, opts.Stdout)
+	}
+	if err != nil {
+		logger.Warn("command failed", "command", name, "error", err)
+	}
+	return nonInteractiveErr(timeoutErr(err, opts.Interrupt, globals.Flag.CommandTimeout))
+}
+
+// readsStdin reports whether the named command reads its input, rather than
+// answers to prompts, from stdin.
+func readsStdin(name string) bool {
+	return name == "mcp" || name == "api"
+}
+
+// timeoutErr explains an error caused by the command being cancelled when the
+// --command-timeout elapsed.
+func timeoutErr(err error, h *interrupt.Handler, timeout time.Duration) error {
+	if err == nil || !errors.Is(h.Err(), interrupt.ErrTimeout) {
+		return err
+	}
+	inner := fmt.Errorf("command timed out after %s: %w", timeout, err)
+	if err == interrupt.ErrTimeout {
+		inner = fmt.Errorf("command timed out after %s", timeout)
+	}
+	return fsterr.RemediationError{
+		Inner:       inner,
+		Remediation: "Increase the --command-timeout value, or set it to 0 to disable the timeout.",
+	}
+}
+
+// nonInteractiveErr explains how to avoid a prompt that failed because user
+// input was disabled by the --non-interactive flag.
+func nonInteractiveErr(err error) error {
+	if errors.Is(err, text.ErrNonInteractive) {
+		return fsterr.RemediationError{
+			Inner:       err,
+			Remediation: fsterr.NonInteractiveRemediation,
+		}
+	}
+	return err
+}
+
+// runDir returns the absolute path of the directory the command runs in.
+func runDir(opts RunOpts) (string, error) {
+	base := opts.Dir
+	if base == "" {
+		wd, err := os.Getwd()
+		if err != nil {
+			return "", fmt.Errorf("error determining current directory: %w", err)
+		}
+		base = wd
+	}
+	base, err := filepath.Abs(base)
+	if err != nil {
+		return "", fmt.Errorf("error determining directory: %w", err)
+	}
+	return projectDir(base, opts.Args)
 }
 
 // APIClientFactory creates a Fastly API client (modeled as an api.Interface)
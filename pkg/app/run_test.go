@@ -3,14 +3,20 @@ package app_test
 import (
 	"bufio"
 	"bytes"
+	"encoding/json"
+	"fmt"
 	"io"
 	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
 	"github.com/fastly/cli/pkg/app"
+	"github.com/fastly/cli/pkg/config"
 	"github.com/fastly/cli/pkg/errors"
+	"github.com/fastly/cli/pkg/mock"
 	"github.com/fastly/cli/pkg/testutil"
+	"github.com/fastly/go-fastly/v6/fastly"
 )
 
 func TestApplication(t *testing.T) {
@@ -60,6 +66,581 @@ func TestApplication(t *testing.T) {
 	}
 }
 
+func TestJQ(t *testing.T) {
+	args := testutil.Args
+	scenarios := []testutil.TestScenario{
+		{
+			Name:       "filter JSON output",
+			Args:       args("alias list --json --jq .cdp"),
+			WantOutput: "compute publish -i\n",
+		},
+		{
+			Name:       "filter JSON output to JSON",
+			Args:       args("alias list --json --jq keys"),
+			WantOutput: "[\"cdp\",\"sl\"]\n",
+		},
+		{
+			Name:       "multiple results",
+			Args:       args("alias list --json --jq .[]"),
+			WantOutput: "compute publish -i\nservice list\n",
+		},
+		{
+			Name:      "output isn't JSON",
+			Args:      args("alias list --jq .cdp"),
+			WantError: "the command output isn't JSON",
+		},
+		{
+			Name:      "invalid expression",
+			Args:      args("alias list --json --jq .["),
+			WantError: "invalid --jq expression",
+		},
+		{
+			Name:      "verbose",
+			Args:      args("alias list --json --jq .cdp --verbose"),
+			WantError: "invalid flag combination, --verbose and --jq",
+		},
+	}
+	for testcaseIdx := range scenarios {
+		testcase := &scenarios[testcaseIdx]
+		t.Run(testcase.Name, func(t *testing.T) {
+			var stdout bytes.Buffer
+			opts := testutil.NewRunOpts(testcase.Args, &stdout)
+			opts.ConfigFile = config.File{
+				Aliases: config.Aliases{"cdp": "compute publish -i", "sl": "service list"},
+			}
+			err := app.Run(opts)
+			testutil.AssertErrorContains(t, err, testcase.WantError)
+			if testcase.WantError == "" {
+				testutil.AssertString(t, testcase.WantOutput, stdout.String())
+			}
+		})
+	}
+}
+
+func TestBulk(t *testing.T) {
+	dir := t.TempDir()
+	ids := filepath.Join(dir, "ids.txt")
+	if err := os.WriteFile(ids, []byte("# fleet\n123\n\n456\n123\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	bad := filepath.Join(dir, "bad.txt")
+	if err := os.WriteFile(bad, []byte("123\nbad\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	empty := filepath.Join(dir, "empty.txt")
+	if err := os.WriteFile(empty, []byte("# none\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	args := testutil.Args
+	scenarios := []struct {
+		testutil.TestScenario
+		wantOutput []string
+	}{
+		{
+			TestScenario: testutil.TestScenario{
+				Name:       "all succeed",
+				Args:       args("backend describe --version 1 --name www.test.com --json --service-id-file " + ids),
+				WantOutput: "The command succeeded for all 2 services",
+			},
+			wantOutput: []string{`"ServiceID":"123"`, `"ServiceID":"456"`, "123         OK", "456         OK"},
+		},
+		{
+			TestScenario: testutil.TestScenario{
+				Name:       "one fails",
+				Args:       args("backend describe --version 1 --name www.test.com --service-id-file " + bad + " --concurrency 1"),
+				WantOutput: "ERROR: test error",
+				WantError:  "the command failed for 1 of 2 services",
+			},
+			wantOutput: []string{"Service ID: 123", "123         OK"},
+		},
+		{
+			TestScenario: testutil.TestScenario{
+				Name:      "no service IDs",
+				Args:      args("backend describe --version 1 --name www.test.com --service-id-file " + empty),
+				WantError: "no service IDs found",
+			},
+		},
+		{
+			TestScenario: testutil.TestScenario{
+				Name:      "with --service-id",
+				Args:      args("backend describe --version 1 --name www.test.com --service-id 123 --service-id-file " + ids),
+				WantError: "invalid flag combination, --service-id-file and --service-id",
+			},
+		},
+		{
+			TestScenario: testutil.TestScenario{
+				Name:      "command without --service-id",
+				Args:      args("alias list --service-id-file " + ids),
+				WantError: "the 'alias list' command doesn't accept a --service-id flag",
+			},
+		},
+		{
+			TestScenario: testutil.TestScenario{
+				Name:      "invalid concurrency",
+				Args:      args("backend describe --version 1 --name www.test.com --service-id-file " + ids + " --concurrency 0"),
+				WantError: "invalid --concurrency value: 0",
+			},
+		},
+	}
+	for testcaseIdx := range scenarios {
+		testcase := &scenarios[testcaseIdx]
+		t.Run(testcase.Name, func(t *testing.T) {
+			var stdout bytes.Buffer
+			opts := testutil.NewRunOpts(testcase.Args, &stdout)
+			opts.APIClient = mock.APIClient(mock.API{
+				ListVersionsFn: testutil.ListVersions,
+				GetBackendFn: func(i *fastly.GetBackendInput) (*fastly.Backend, error) {
+					if i.ServiceID == "bad" {
+						return nil, testutil.Err
+					}
+					return &fastly.Backend{ServiceID: i.ServiceID, ServiceVersion: i.ServiceVersion, Name: i.Name}, nil
+				},
+			})
+			err := app.Run(opts)
+			testutil.AssertErrorContains(t, err, testcase.WantError)
+			testutil.AssertStringContains(t, stdout.String(), testcase.WantOutput)
+			for _, s := range testcase.wantOutput {
+				testutil.AssertStringContains(t, stdout.String(), s)
+			}
+		})
+	}
+}
+
+// TestBulkConcurrency runs many services at once, which should be run with
+// -race as the runs share the process-wide settings of the bulk run.
+func TestBulkConcurrency(t *testing.T) {
+	var ids []string
+	for i := 0; i < 40; i++ {
+		ids = append(ids, fmt.Sprintf("svc%02d", i))
+	}
+	path := filepath.Join(t.TempDir(), "ids.txt")
+	if err := os.WriteFile(path, []byte(strings.Join(ids, "\n")), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var stdout bytes.Buffer
+	opts := testutil.NewRunOpts(testutil.Args("backend describe --version 1 --name www.test.com --color never --concurrency 8 --service-id-file "+path), &stdout)
+	opts.APIClient = mock.APIClient(mock.API{
+		ListVersionsFn: testutil.ListVersions,
+		GetBackendFn: func(i *fastly.GetBackendInput) (*fastly.Backend, error) {
+			return &fastly.Backend{ServiceID: i.ServiceID, ServiceVersion: i.ServiceVersion, Name: i.Name}, nil
+		},
+	})
+	err = app.Run(opts)
+	testutil.AssertNoError(t, err)
+	for _, id := range ids {
+		testutil.AssertStringContains(t, stdout.String(), "Service ID: "+id)
+	}
+	testutil.AssertStringContains(t, stdout.String(), "The command succeeded for all 40 services")
+
+	have, err := os.Getwd()
+	testutil.AssertNoError(t, err)
+	testutil.AssertString(t, wd, have)
+}
+
+func TestCommandTimeout(t *testing.T) {
+	// NOTE: Nothing is written to stdin, so the prompt for a token blocks until
+	// the command times out.
+	stdin, w := io.Pipe()
+	defer w.Close()
+
+	var stdout bytes.Buffer
+	opts := testutil.NewRunOpts(testutil.Args("profile create example --command-timeout 100ms"), &stdout)
+	opts.Stdin = stdin
+	err := app.Run(opts)
+	testutil.AssertErrorContains(t, err, "command timed out after 100ms")
+	testutil.AssertRemediationErrorContains(t, err, "Increase the --command-timeout value")
+	testutil.AssertStringContains(t, stdout.String(), "Timed out after 100ms, cleaning up.")
+}
+
+func TestNonInteractiveStdin(t *testing.T) {
+	args := testutil.Args
+
+	// NOTE: The custom build script leaves a file behind if it's run.
+	project := t.TempDir()
+	fastlyManifest := `
+	manifest_version = 2
+	name = "test"
+	language = "other"
+	[scripts]
+	build = "touch script-ran"`
+	if err := os.WriteFile(filepath.Join(project, "fastly.toml"), []byte(fastlyManifest), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, testcase := range []struct {
+		name      string
+		args      []string
+		dir       string
+		wantError string
+	}{
+		{
+			name:      "non-terminal stdin disables prompts",
+			args:      args("profile create example"),
+			wantError: "user input required but prompts are disabled",
+		},
+		{
+			name:      "--interactive reads from stdin",
+			args:      args("profile create example --interactive"),
+			wantError: "error validating token: test error",
+		},
+		{
+			name:      "non-terminal stdin doesn't confirm a custom build script",
+			args:      args("compute build"),
+			dir:       project,
+			wantError: "user input required but prompts are disabled",
+		},
+	} {
+		t.Run(testcase.name, func(t *testing.T) {
+			// NOTE: A file isn't a terminal, and so is detected as stdin that
+			// can't answer prompts.
+			stdin, err := os.CreateTemp(t.TempDir(), "stdin")
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer stdin.Close()
+			if _, err := stdin.WriteString("y\n"); err != nil {
+				t.Fatal(err)
+			}
+			if _, err := stdin.Seek(0, io.SeekStart); err != nil {
+				t.Fatal(err)
+			}
+
+			var stdout bytes.Buffer
+			opts := testutil.NewRunOpts(testcase.args, &stdout)
+			opts.APIClient = mock.APIClient(mock.API{
+				GetTokenSelfFn: func() (*fastly.Token, error) {
+					return nil, testutil.Err
+				},
+			})
+			opts.Dir = testcase.dir
+			opts.Stdin = stdin
+			err = app.Run(opts)
+			testutil.AssertErrorContains(t, err, testcase.wantError)
+			if testcase.dir != "" {
+				if _, err := os.Stat(filepath.Join(testcase.dir, "script-ran")); err == nil {
+					t.Fatal("expected the custom build script not to run")
+				}
+			}
+		})
+	}
+}
+
+func TestLogFile(t *testing.T) {
+	args := testutil.Args
+	for _, testcase := range []struct {
+		name           string
+		args           string
+		wantLogs       []string
+		dontWantLogs   []string
+		dontWantOutput string
+	}{
+		{
+			name: "debug",
+			args: "service describe --service-id 123 --token 123 --log-level debug",
+			wantLogs: []string{
+				`level=debug msg="running command" command="service describe"`,
+				`level=debug msg="resolved token" source=flag`,
+				`level=warn msg="command failed" command="service describe" error="test error"`,
+			},
+		},
+		{
+			name: "warn",
+			args: "service describe --service-id 123 --token 123 --log-level warn",
+			wantLogs: []string{
+				`level=warn msg="command failed"`,
+			},
+			dontWantLogs: []string{"level=debug"},
+		},
+		{
+			name:         "off",
+			args:         "service describe --service-id 123 --token 123",
+			dontWantLogs: []string{"level="},
+		},
+	} {
+		t.Run(testcase.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "fastly.log")
+
+			var stdout bytes.Buffer
+			opts := testutil.NewRunOpts(args(testcase.args+" --log-file "+path), &stdout)
+			opts.APIClient = mock.APIClient(mock.API{
+				GetServiceDetailsFn: func(_ *fastly.GetServiceInput) (*fastly.ServiceDetail, error) {
+					return nil, testutil.Err
+				},
+			})
+			err := app.Run(opts)
+			testutil.AssertErrorContains(t, err, "test error")
+
+			// The logs are written separately from the command output.
+			testutil.AssertStringDoesntContain(t, stdout.String(), "level=")
+
+			b, err := os.ReadFile(path) // #nosec G304 (CWE-22)
+			if err != nil && !os.IsNotExist(err) {
+				t.Fatal(err)
+			}
+			for _, s := range testcase.wantLogs {
+				testutil.AssertStringContains(t, string(b), s)
+			}
+			for _, s := range testcase.dontWantLogs {
+				testutil.AssertStringDoesntContain(t, string(b), s)
+			}
+		})
+	}
+}
+
+func TestDir(t *testing.T) {
+	root := t.TempDir()
+	src := filepath.Join(root, "src", "handlers")
+	if err := os.MkdirAll(src, 0o750); err != nil {
+		t.Fatal(err)
+	}
+	manifest := "manifest_version = 2\nname = \"app\"\nservice_id = \"123\"\n"
+	if err := os.WriteFile(filepath.Join(root, "fastly.toml"), []byte(manifest), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	elsewhere := t.TempDir()
+
+	args := testutil.Args
+	scenarios := []struct {
+		testutil.TestScenario
+		wd string
+	}{
+		{
+			TestScenario: testutil.TestScenario{
+				Name:       "manifest found in a parent directory",
+				Args:       args("service describe"),
+				WantOutput: "ID: 123",
+			},
+			wd: src,
+		},
+		{
+			TestScenario: testutil.TestScenario{
+				Name:       "--dir",
+				Args:       args("--dir " + root + " service describe"),
+				WantOutput: "ID: 123",
+			},
+			wd: elsewhere,
+		},
+		{
+			TestScenario: testutil.TestScenario{
+				Name:       "-C with a sub-folder",
+				Args:       args("-C " + src + " service describe"),
+				WantOutput: "ID: 123",
+			},
+			wd: elsewhere,
+		},
+		{
+			TestScenario: testutil.TestScenario{
+				Name:      "missing --dir",
+				Args:      args("--dir " + filepath.Join(root, "missing") + " service describe"),
+				WantError: "error changing to --dir directory",
+			},
+			wd: elsewhere,
+		},
+	}
+	for testcaseIdx := range scenarios {
+		testcase := &scenarios[testcaseIdx]
+		t.Run(testcase.Name, func(t *testing.T) {
+			pwd, err := os.Getwd()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := os.Chdir(testcase.wd); err != nil {
+				t.Fatal(err)
+			}
+			defer os.Chdir(pwd)
+
+			var stdout bytes.Buffer
+			opts := testutil.NewRunOpts(testcase.Args, &stdout)
+			opts.APIClient = mock.APIClient(mock.API{
+				GetServiceDetailsFn: func(i *fastly.GetServiceInput) (*fastly.ServiceDetail, error) {
+					return &fastly.ServiceDetail{ID: i.ID}, nil
+				},
+			})
+			err = app.Run(opts)
+			testutil.AssertErrorContains(t, err, testcase.WantError)
+			testutil.AssertStringContains(t, stdout.String(), testcase.WantOutput)
+
+			// The working directory is restored once the command has run.
+			wd, err := os.Getwd()
+			if err != nil {
+				t.Fatal(err)
+			}
+			testutil.AssertString(t, testcase.wd, wd)
+		})
+	}
+}
+
+func TestHelpGenerate(t *testing.T) {
+	args := testutil.Args
+	scenarios := []struct {
+		testutil.TestScenario
+		wantFile     string
+		wantContents []string
+	}{
+		{
+			TestScenario: testutil.TestScenario{
+				Name:       "man pages",
+				Args:       args("help generate --output %s"),
+				WantOutput: "Generated",
+			},
+			wantFile:     "fastly-acl-create.1",
+			wantContents: []string{".SH SYNOPSIS", ".SH EXAMPLES", ".SH SEE ALSO", `\fBfastly\-acl\fR(1)`},
+		},
+		{
+			TestScenario: testutil.TestScenario{
+				Name:       "markdown",
+				Args:       args("help generate --format markdown -o %s"),
+				WantOutput: "markdown pages",
+			},
+			wantFile:     "fastly-acl-create.md",
+			wantContents: []string{"# fastly acl create", "## Examples", "fastly acl create --name robots", "[fastly acl](fastly-acl.md)"},
+		},
+		{
+			TestScenario: testutil.TestScenario{
+				Name:      "invalid format",
+				Args:      args("help generate --format html --output %s"),
+				WantError: "error parsing arguments",
+			},
+		},
+	}
+	for testcaseIdx := range scenarios {
+		testcase := &scenarios[testcaseIdx]
+		t.Run(testcase.Name, func(t *testing.T) {
+			dir := t.TempDir()
+			for i, arg := range testcase.Args {
+				if arg == "%s" {
+					testcase.Args[i] = dir
+				}
+			}
+
+			var stdout bytes.Buffer
+			err := app.Run(testutil.NewRunOpts(testcase.Args, &stdout))
+			testutil.AssertErrorContains(t, err, testcase.WantError)
+			testutil.AssertStringContains(t, stdout.String(), testcase.WantOutput)
+			if testcase.wantFile == "" {
+				return
+			}
+
+			if _, err := os.Stat(filepath.Join(dir, "fastly"+filepath.Ext(testcase.wantFile))); err != nil {
+				t.Fatalf("the application page wasn't generated: %v", err)
+			}
+			content, err := os.ReadFile(filepath.Join(dir, testcase.wantFile))
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, want := range testcase.wantContents {
+				testutil.AssertStringContains(t, string(content), want)
+			}
+		})
+	}
+}
+
+func TestHelpExitCodes(t *testing.T) {
+	var stdout bytes.Buffer
+	err := app.Run(testutil.NewRunOpts(testutil.Args("help exit-codes"), &stdout))
+	testutil.AssertNoError(t, err)
+	testutil.AssertStringContains(t, stdout.String(), "EXIT CODES")
+	testutil.AssertStringContains(t, stdout.String(), "3    The API token is missing, invalid or lacks permission")
+	testutil.AssertStringContains(t, stdout.String(), "130  The command was interrupted")
+}
+
+// TestExamples validates// TestExamples validates that the example of every command, whether from
+// metadata.json or registered by the command, parses as a valid invocation of
+// that command.
+func TestExamples(t *testing.T) {
+	var stdout bytes.Buffer
+	if err := app.Run(testutil.NewRunOpts(testutil.Args("help --format json"), &stdout)); err != nil {
+		t.Fatal(err)
+	}
+
+	type command struct {
+		Name     string        `json:"name"`
+		Children []command     `json:"children"`
+		Examples []app.Example `json:"examples"`
+	}
+	var usage struct {
+		Commands []command `json:"commands"`
+	}
+	if err := json.Unmarshal(stdout.Bytes(), &usage); err != nil {
+		t.Fatal(err)
+	}
+
+	var n int
+	var walk func(cmds []command, parent string)
+	walk = func(cmds []command, parent string) {
+		for _, c := range cmds {
+			name := strings.TrimSpace(parent + " " + c.Name)
+			for _, e := range c.Examples {
+				n++
+				t.Run(e.Cmd, func(t *testing.T) {
+					args := splitExample(e.Cmd)
+					if len(args) == 0 || args[0] != "fastly" {
+						t.Fatalf("want example to start with 'fastly', have %q", e.Cmd)
+					}
+					if !strings.HasPrefix(strings.Join(args, " ")+" ", "fastly "+name+" ") {
+						t.Fatalf("want example of '%s', have %q", name, e.Cmd)
+					}
+
+					// NOTE: The --help flag stops the command from running once
+					// the arguments have been parsed.
+					var stdout bytes.Buffer
+					err := app.Run(testutil.NewRunOpts(append(args[1:], "--help"), &stdout))
+					re, ok := err.(errors.RemediationError)
+					if !ok {
+						t.Fatalf("want help output, have %v", err)
+					}
+					if re.Inner != nil {
+						t.Fatalf("invalid example: %v", re.Inner)
+					}
+				})
+			}
+			walk(c.Children, name)
+		}
+	}
+	walk(usage.Commands, "")
+
+	if n == 0 {
+		t.Fatal("no examples found")
+	}
+}
+
+// splitExample splits an example command line into its arguments, treating
+// text within double quotes as a single argument.
+func splitExample(s string) []string {
+	var (
+		args   []string
+		arg    strings.Builder
+		quoted bool
+		inArg  bool
+	)
+	for _, r := range s {
+		switch {
+		case r == '"':
+			quoted = !quoted
+			inArg = true
+		case r == ' ' && !quoted:
+			if inArg {
+				args = append(args, arg.String())
+				arg.Reset()
+				inArg = false
+			}
+		default:
+			arg.WriteRune(r)
+			inArg = true
+		}
+	}
+	if inArg {
+		args = append(args, arg.String())
+	}
+	return args
+}
+
 func TestShellCompletion(t *testing.T) {
 	args := testutil.Args
 	scenarios := []testutil.TestScenario{
@@ -106,20 +687,33 @@ complete -F _fastly_bash_autocomplete fastly
 			WantOutput: `help
 acl
 acl-entry
+alias
+api
 auth-token
 backend
+billing
 compute
 config
 dictionary
 dictionary-item
+director
 domain
+errors
+events
 healthcheck
 ip-list
+kv-store
 log-tail
 logging
+mcp
+origins
+plugin
 pops
 profile
 purge
+rate-limit
+resource-link
+serve-api
 service
 service-version
 stats
@@ -127,10 +721,12 @@ tls-config
 tls-custom
 tls-platform
 tls-subscription
+tools
 update
 user
 vcl
 version
+waf
 whoami
 `,
 		},
@@ -192,37 +788,93 @@ USAGE
 A tool to interact with the Fastly API
 
 GLOBAL FLAGS
-      --help             Show context-sensitive help.
-  -d, --accept-defaults  Accept default options for all interactive prompts
-                         apart from Yes/No confirmations
-  -y, --auto-yes         Answer yes automatically to all Yes/No confirmations.
-                         This may suppress security warnings
-  -i, --non-interactive  Do not prompt for user input - suitable for CI
-                         processes. Equivalent to --accept-defaults and
-                         --auto-yes
-  -o, --profile=PROFILE  Switch account profile for single command execution
-                         (see also: 'fastly profile switch')
-  -t, --token=TOKEN      Fastly API token (or via FASTLY_API_TOKEN)
-  -v, --verbose          Verbose logging
+      --help                  Show context-sensitive help.
+  -d, --accept-defaults       Accept default options for all interactive prompts
+                              apart from Yes/No confirmations
+      --answer=ANSWER ...     Answer an interactive prompt ahead of time
+                              as name=value (e.g. init.name=my-app), can be
+                              repeated
+      --answers-file=ANSWERS-FILE
+                              Path to a JSON file of interactive prompt answers
+                              (e.g. {"init.name": "my-app"}), overridden by
+                              --answer
+  -y, --auto-yes              Answer yes automatically to all Yes/No
+                              confirmations. This may suppress security warnings
+      --command-timeout=DURATION
+                              Cancel the command if it runs for longer than this
+                              duration (e.g. 30s, 5m), 0 disables the timeout
+      --color=auto            Colour the output: auto (when writing to a
+                              terminal, unless NO_COLOR is set), always or never
+      --columns=COLUMNS ...   Comma-separated list of table columns to display
+                              (e.g. name,updated_at)
+      --concurrency=4         Number of services to run the command against at
+                              once with --service-id-file
+  -C, --dir=DIR               Change to this directory before running the
+                              command (like git -C)
+      --interactive           Prompt for user input even when stdin isn't a
+                              terminal or the project settings disable prompts
+      --jq=JQ                 Filter a command's JSON output with a jq
+                              expression (e.g. '.[0].Name')
+      --log-file=FILE         Write diagnostic logs to this file rather than
+                              stderr (see --log-level)
+      --log-level=off         Log diagnostic messages at or above this level,
+                              separately from the command output: debug, info,
+                              warn, off
+  -i, --non-interactive       Do not prompt for user input - suitable for CI
+                              processes. Equivalent to --accept-defaults and
+                              --auto-yes
+  -o, --profile=PROFILE       Switch account profile for single command
+                              execution (see also: 'fastly profile switch')
+  -q, --quiet                 Suppress informational and progress output,
+                              only errors and requested data are displayed
+      --service-id-file=FILE  Run the command against each service ID in the
+                              file (one per line) and summarise the results
+      --show-secrets          Display secrets such as tokens, passwords and keys
+                              in command output rather than masking them
+      --sort-by=SORT-BY       Table column to sort rows by, prefix with '-' for
+                              descending order (e.g. --sort-by=-updated_at)
+      --strict-deprecations   Fail, rather than warn, when a deprecated command
+                              or flag is used
+      --theme=default         The colour theme of the output: default,
+                              high-contrast
+  -t, --token=TOKEN           Fastly API token (or via FASTLY_API_TOKEN)
+  -v, --verbose               Verbose logging
 
 COMMANDS
   help              Show help.
   acl               Manipulate Fastly ACLs (Access Control Lists)
   acl-entry         Manipulate Fastly ACL (Access Control List) entries
+  alias             Manage command aliases
+  api               Send an authenticated request to the Fastly API, e.g.
+                    fastly api GET /service/%s/version
   auth-token        Manage API tokens for Fastly service users
   backend           Manipulate Fastly service version backends
+  billing           Report on Fastly account usage for billing purposes
   compute           Manage Compute@Edge packages
-  config            Display the Fastly CLI configuration
+  config            Display and manage the Fastly CLI configuration
   dictionary        Manipulate Fastly edge dictionaries
   dictionary-item   Manipulate Fastly edge dictionary items
+  director          Manipulate Fastly service version directors
   domain            Manipulate Fastly service version domains
+  errors            Inspect the log of errors recorded by the CLI
+  events            Inspect the audit log of changes made to your Fastly account
   healthcheck       Manipulate Fastly service version healthchecks
   ip-list           List Fastly's public IPs
+  kv-store          Manipulate Fastly KV stores
   log-tail          Tail Compute@Edge logs
   logging           Manipulate Fastly service version logging endpoints
+  mcp               Serve Fastly operations as Model Context Protocol (MCP)
+                    tools over stdio, for AI assistants
+  origins           Inspect the origins of a Fastly service
+  plugin            Manage CLI plugins ('fastly-<name>' executables on your PATH
+                    run as 'fastly <name>')
   pops              List Fastly datacenters
   profile           Manage user profiles
   purge             Invalidate objects in the Fastly cache
+  rate-limit        Manipulate Fastly service version edge rate limiters
+  resource-link     Manipulate the resources linked to a Fastly service version
+  serve-api         Serve CLI operations (build, deploy, purge, service
+                    describe) over a local JSON-RPC socket
   service           Manipulate Fastly services
   service-version   Manipulate Fastly service versions
   stats             View historical and realtime statistics for a Fastly service
@@ -230,10 +882,12 @@ COMMANDS
   tls-custom        Manage custom keys and certs used to enable TLS
   tls-platform      Manage large numbers of TLS certificates
   tls-subscription  Generate TLS certificates procured and renewed by Fastly
+  tools             Manage the auxiliary binaries (e.g. Viceroy) used by the CLI
   update            Update the CLI to the latest version
   user              Manipulate users of the Fastly API and web interface
   vcl               Manipulate Fastly service version VCL
   version           Display version information for the Fastly CLI
+  waf               Manipulate Fastly Next-Gen WAF workspaces
   whoami            Get information about the currently authenticated account
 
 SEE ALSO
@@ -245,18 +899,57 @@ USAGE
   fastly [<flags>] service
 
 GLOBAL FLAGS
-      --help             Show context-sensitive help.
-  -d, --accept-defaults  Accept default options for all interactive prompts
-                         apart from Yes/No confirmations
-  -y, --auto-yes         Answer yes automatically to all Yes/No confirmations.
-                         This may suppress security warnings
-  -i, --non-interactive  Do not prompt for user input - suitable for CI
-                         processes. Equivalent to --accept-defaults and
-                         --auto-yes
-  -o, --profile=PROFILE  Switch account profile for single command execution
-                         (see also: 'fastly profile switch')
-  -t, --token=TOKEN      Fastly API token (or via FASTLY_API_TOKEN)
-  -v, --verbose          Verbose logging
+      --help                  Show context-sensitive help.
+  -d, --accept-defaults       Accept default options for all interactive prompts
+                              apart from Yes/No confirmations
+      --answer=ANSWER ...     Answer an interactive prompt ahead of time
+                              as name=value (e.g. init.name=my-app), can be
+                              repeated
+      --answers-file=ANSWERS-FILE
+                              Path to a JSON file of interactive prompt answers
+                              (e.g. {"init.name": "my-app"}), overridden by
+                              --answer
+  -y, --auto-yes              Answer yes automatically to all Yes/No
+                              confirmations. This may suppress security warnings
+      --command-timeout=DURATION
+                              Cancel the command if it runs for longer than this
+                              duration (e.g. 30s, 5m), 0 disables the timeout
+      --color=auto            Colour the output: auto (when writing to a
+                              terminal, unless NO_COLOR is set), always or never
+      --columns=COLUMNS ...   Comma-separated list of table columns to display
+                              (e.g. name,updated_at)
+      --concurrency=4         Number of services to run the command against at
+                              once with --service-id-file
+  -C, --dir=DIR               Change to this directory before running the
+                              command (like git -C)
+      --interactive           Prompt for user input even when stdin isn't a
+                              terminal or the project settings disable prompts
+      --jq=JQ                 Filter a command's JSON output with a jq
+                              expression (e.g. '.[0].Name')
+      --log-file=FILE         Write diagnostic logs to this file rather than
+                              stderr (see --log-level)
+      --log-level=off         Log diagnostic messages at or above this level,
+                              separately from the command output: debug, info,
+                              warn, off
+  -i, --non-interactive       Do not prompt for user input - suitable for CI
+                              processes. Equivalent to --accept-defaults and
+                              --auto-yes
+  -o, --profile=PROFILE       Switch account profile for single command
+                              execution (see also: 'fastly profile switch')
+  -q, --quiet                 Suppress informational and progress output,
+                              only errors and requested data are displayed
+      --service-id-file=FILE  Run the command against each service ID in the
+                              file (one per line) and summarise the results
+      --show-secrets          Display secrets such as tokens, passwords and keys
+                              in command output rather than masking them
+      --sort-by=SORT-BY       Table column to sort rows by, prefix with '-' for
+                              descending order (e.g. --sort-by=-updated_at)
+      --strict-deprecations   Fail, rather than warn, when a deprecated command
+                              or flag is used
+      --theme=default         The colour theme of the output: default,
+                              high-contrast
+  -t, --token=TOKEN           Fastly API token (or via FASTLY_API_TOKEN)
+  -v, --verbose               Verbose logging
 
 SUBCOMMANDS
 
@@ -281,6 +974,8 @@ SUBCOMMANDS
     Show detailed information about a Fastly service
 
     -j, --json                   Render output as JSON
+        --format=text            Output format (text, json, env)
+        --output=OUTPUT          Write the output to a file instead of stdout
     -s, --service-id=SERVICE-ID  Service ID (falls back to FASTLY_SERVICE_ID,
                                  then fastly.toml)
         --service-name=SERVICE-NAME
@@ -289,9 +984,12 @@ SUBCOMMANDS
   service list [<flags>]
     List Fastly services
 
+        --all                Fetch every page of the data set (the default
+                             unless --page is set)
         --direction=ascend   Direction in which to sort results
     -j, --json               Render output as JSON
-        --page=PAGE          Page number of data set to fetch
+        --page=PAGE          Page number of data set to fetch (only that page is
+                             returned)
         --per-page=PER-PAGE  Number of records per page
         --sort="created"     Field on which to sort
 
@@ -310,9 +1008,18 @@ SUBCOMMANDS
     -n, --name=NAME              Service name
         --comment=COMMENT        Human-readable comment
 
+  service watch [<flags>]
+    Poll a Fastly service and report its new versions, activations, domain
+    changes and package changes until interrupted
+
+        --interval=10s           How often to poll the service
+    -s, --service-id=SERVICE-ID  Service ID (falls back to FASTLY_SERVICE_ID,
+                                 then fastly.toml)
+        --service-name=SERVICE-NAME
+                                 The name of the service
+
 SEE ALSO
   https://developer.fastly.com/reference/cli/service/
-
 `) + "\n\n"
 
 var fullFatHelpDefault = strings.TrimSpace(`
@@ -322,18 +1029,57 @@ USAGE
 A tool to interact with the Fastly API
 
 GLOBAL FLAGS
-      --help             Show context-sensitive help.
-  -d, --accept-defaults  Accept default options for all interactive prompts
-                         apart from Yes/No confirmations
-  -y, --auto-yes         Answer yes automatically to all Yes/No confirmations.
-                         This may suppress security warnings
-  -i, --non-interactive  Do not prompt for user input - suitable for CI
-                         processes. Equivalent to --accept-defaults and
-                         --auto-yes
-  -o, --profile=PROFILE  Switch account profile for single command execution
-                         (see also: 'fastly profile switch')
-  -t, --token=TOKEN      Fastly API token (or via FASTLY_API_TOKEN)
-  -v, --verbose          Verbose logging
+      --help                  Show context-sensitive help.
+  -d, --accept-defaults       Accept default options for all interactive prompts
+                              apart from Yes/No confirmations
+      --answer=ANSWER ...     Answer an interactive prompt ahead of time
+                              as name=value (e.g. init.name=my-app), can be
+                              repeated
+      --answers-file=ANSWERS-FILE
+                              Path to a JSON file of interactive prompt answers
+                              (e.g. {"init.name": "my-app"}), overridden by
+                              --answer
+  -y, --auto-yes              Answer yes automatically to all Yes/No
+                              confirmations. This may suppress security warnings
+      --command-timeout=DURATION
+                              Cancel the command if it runs for longer than this
+                              duration (e.g. 30s, 5m), 0 disables the timeout
+      --color=auto            Colour the output: auto (when writing to a
+                              terminal, unless NO_COLOR is set), always or never
+      --columns=COLUMNS ...   Comma-separated list of table columns to display
+                              (e.g. name,updated_at)
+      --concurrency=4         Number of services to run the command against at
+                              once with --service-id-file
+  -C, --dir=DIR               Change to this directory before running the
+                              command (like git -C)
+      --interactive           Prompt for user input even when stdin isn't a
+                              terminal or the project settings disable prompts
+      --jq=JQ                 Filter a command's JSON output with a jq
+                              expression (e.g. '.[0].Name')
+      --log-file=FILE         Write diagnostic logs to this file rather than
+                              stderr (see --log-level)
+      --log-level=off         Log diagnostic messages at or above this level,
+                              separately from the command output: debug, info,
+                              warn, off
+  -i, --non-interactive       Do not prompt for user input - suitable for CI
+                              processes. Equivalent to --accept-defaults and
+                              --auto-yes
+  -o, --profile=PROFILE       Switch account profile for single command
+                              execution (see also: 'fastly profile switch')
+  -q, --quiet                 Suppress informational and progress output,
+                              only errors and requested data are displayed
+      --service-id-file=FILE  Run the command against each service ID in the
+                              file (one per line) and summarise the results
+      --show-secrets          Display secrets such as tokens, passwords and keys
+                              in command output rather than masking them
+      --sort-by=SORT-BY       Table column to sort rows by, prefix with '-' for
+                              descending order (e.g. --sort-by=-updated_at)
+      --strict-deprecations   Fail, rather than warn, when a deprecated command
+                              or flag is used
+      --theme=default         The colour theme of the output: default,
+                              high-contrast
+  -t, --token=TOKEN           Fastly API token (or via FASTLY_API_TOKEN)
+  -v, --verbose               Verbose logging
 
 COMMANDS
   help [<command> ...]
@@ -347,9 +1093,10 @@ COMMANDS
                                  alphanumeric character and contain only
                                  alphanumeric characters, underscores,
                                  and whitespace
-        --version=VERSION        'latest', 'active', or the number of a specific
-                                 version
-        --autoclone              If the selected service version is not
+        --version=VERSION        'latest', 'active', 'draft', relative to latest
+                                 or active (e.g. 'latest-1'), 'tag:NAME',
+                                 or the number of a specific version
+        --[no-]autoclone         If the selected service version is not
                                  editable, clone it and use the clone.
     -s, --service-id=SERVICE-ID  Service ID (falls back to FASTLY_SERVICE_ID,
                                  then fastly.toml)
@@ -360,9 +1107,10 @@ COMMANDS
     Delete an ACL from the specified service version
 
         --name=NAME              The name of the ACL to delete
-        --version=VERSION        'latest', 'active', or the number of a specific
-                                 version
-        --autoclone              If the selected service version is not
+        --version=VERSION        'latest', 'active', 'draft', relative to latest
+                                 or active (e.g. 'latest-1'), 'tag:NAME',
+                                 or the number of a specific version
+        --[no-]autoclone         If the selected service version is not
                                  editable, clone it and use the clone.
     -s, --service-id=SERVICE-ID  Service ID (falls back to FASTLY_SERVICE_ID,
                                  then fastly.toml)
@@ -373,9 +1121,12 @@ COMMANDS
     Retrieve a single ACL by name for the version and service
 
         --name=NAME              The name of the ACL
-        --version=VERSION        'latest', 'active', or the number of a specific
-                                 version
+        --version=VERSION        'latest', 'active', 'draft', relative to latest
+                                 or active (e.g. 'latest-1'), 'tag:NAME',
+                                 or the number of a specific version
     -j, --json                   Render output as JSON
+        --format=text            Output format (text, json, env)
+        --output=OUTPUT          Write the output to a file instead of stdout
     -s, --service-id=SERVICE-ID  Service ID (falls back to FASTLY_SERVICE_ID,
                                  then fastly.toml)
         --service-name=SERVICE-NAME
@@ -384,8 +1135,9 @@ COMMANDS
   acl list --version=VERSION [<flags>]
     List ACLs
 
-        --version=VERSION        'latest', 'active', or the number of a specific
-                                 version
+        --version=VERSION        'latest', 'active', 'draft', relative to latest
+                                 or active (e.g. 'latest-1'), 'tag:NAME',
+                                 or the number of a specific version
     -j, --json                   Render output as JSON
     -s, --service-id=SERVICE-ID  Service ID (falls back to FASTLY_SERVICE_ID,
                                  then fastly.toml)
@@ -397,9 +1149,10 @@ COMMANDS
 
         --name=NAME              The name of the ACL to update
         --new-name=NEW-NAME      The new name of the ACL
-        --version=VERSION        'latest', 'active', or the number of a specific
-                                 version
-        --autoclone              If the selected service version is not
+        --version=VERSION        'latest', 'active', 'draft', relative to latest
+                                 or active (e.g. 'latest-1'), 'tag:NAME',
+                                 or the number of a specific version
+        --[no-]autoclone         If the selected service version is not
                                  editable, clone it and use the clone.
     -s, --service-id=SERVICE-ID  Service ID (falls back to FASTLY_SERVICE_ID,
                                  then fastly.toml)
@@ -436,6 +1189,8 @@ COMMANDS
         --acl-id=ACL-ID          Alphanumeric string identifying a ACL
         --id=ID                  Alphanumeric string identifying an ACL Entry
     -j, --json                   Render output as JSON
+        --format=text            Output format (text, json, env)
+        --output=OUTPUT          Write the output to a file instead of stdout
     -s, --service-id=SERVICE-ID  Service ID (falls back to FASTLY_SERVICE_ID,
                                  then fastly.toml)
         --service-name=SERVICE-NAME
@@ -445,9 +1200,12 @@ COMMANDS
     List ACLs
 
         --acl-id=ACL-ID          Alphanumeric string identifying a ACL
+        --all                    Fetch every page of the data set (the default
+                                 unless --page is set)
         --direction=ascend       Direction in which to sort results
     -j, --json                   Render output as JSON
-        --page=PAGE              Page number of data set to fetch
+        --page=PAGE              Page number of data set to fetch (only that
+                                 page is returned)
         --per-page=PER-PAGE      Number of records per page
     -s, --service-id=SERVICE-ID  Service ID (falls back to FASTLY_SERVICE_ID,
                                  then fastly.toml)
@@ -472,6 +1230,39 @@ COMMANDS
         --subnet=SUBNET          Number of bits for the subnet mask applied to
                                  the IP address
 
+  alias list [<flags>]
+    List command aliases
+
+    -j, --json  Render output as JSON
+
+  alias set <name> <command>
+    Create or update a command alias (e.g. fastly alias set cdp "compute publish
+    -i")
+
+
+  alias unset <name>
+    Delete a command alias
+
+
+  api [<flags>] <method> <path>
+    Send an authenticated request to the Fastly API, e.g. fastly api GET
+    /service/%s/version
+
+        --data=DATA              The request body, or @FILE to read it from a
+                                 file (@- for stdin). JSON bodies are sent as
+                                 application/json, and others as form data
+    -H, --header=HEADER ...      A request header in the form 'Name: value',
+                                 which can be repeated
+        --include                Print the response status and headers
+        --max-retries=3          How many times to retry a rate limited (429)
+                                 request
+        --raw                    Print the response body as is, rather than
+                                 pretty-printing JSON
+    -s, --service-id=SERVICE-ID  Service ID (falls back to FASTLY_SERVICE_ID,
+                                 then fastly.toml)
+        --service-name=SERVICE-NAME
+                                 The name of the service
+
   auth-token create --password=PASSWORD [<flags>]
     Create an API token
 
@@ -494,7 +1285,9 @@ COMMANDS
   auth-token describe [<flags>]
     Get the current API token
 
-    -j, --json  Render output as JSON
+    -j, --json           Render output as JSON
+        --format=text    Output format (text, json, env)
+        --output=OUTPUT  Write the output to a file instead of stdout
 
   auth-token list [<flags>]
     List API tokens
@@ -503,6 +1296,31 @@ COMMANDS
                                    (falls back to FASTLY_CUSTOMER_ID)
     -j, --json                     Render output as JSON
 
+  auth-token mint --password=PASSWORD --services=SERVICES [<flags>]
+    Create a short-lived API token limited to specific services, e.g. for a CI
+    job, and print it
+
+    --password=PASSWORD      User password corresponding with --token or
+                             $FASTLY_API_TOKEN
+    --services=SERVICES ...  A comma-separated list of alphanumeric strings
+                             identifying the services the token can access
+    --name=NAME              Name of the token (default: a name including its
+                             expiry time)
+    --output=OUTPUT          Write the token to a file, readable only by the
+                             current user, instead of stdout
+    --scope=global ...       Authorization scope (repeat flag per scope)
+    --ttl=1h                 How long the token is valid for (e.g. 30m, 1h)
+
+  auth-token rotate --password=PASSWORD [<flags>]
+    Replace the active profile's API token with a new token of the same scope,
+    then revoke the old token
+
+    --password=PASSWORD  User password corresponding with the active profile's
+                         token
+    --expires=EXPIRES    Time-stamp (UTC) of when the new token will expire
+    --name=NAME          Name of the new token (default: the name of the old
+                         token)
+
   backend create --version=VERSION --name=NAME --address=ADDRESS [<flags>]
     Create a backend on a Fastly service version
 
@@ -510,9 +1328,11 @@ COMMANDS
                                    then fastly.toml)
         --service-name=SERVICE-NAME
                                    The name of the service
-        --version=VERSION          'latest', 'active', or the number of a
-                                   specific version
-        --autoclone                If the selected service version is not
+        --version=VERSION          'latest', 'active', 'draft', relative
+                                   to latest or active (e.g. 'latest-1'),
+                                   'tag:NAME', or the number of a specific
+                                   version
+        --[no-]autoclone           If the selected service version is not
                                    editable, clone it and use the clone.
     -n, --name=NAME                Backend name
         --address=ADDRESS          A hostname, IPv4, or IPv6 address for the
@@ -574,9 +1394,10 @@ COMMANDS
                                  then fastly.toml)
         --service-name=SERVICE-NAME
                                  The name of the service
-        --version=VERSION        'latest', 'active', or the number of a specific
-                                 version
-        --autoclone              If the selected service version is not
+        --version=VERSION        'latest', 'active', 'draft', relative to latest
+                                 or active (e.g. 'latest-1'), 'tag:NAME',
+                                 or the number of a specific version
+        --[no-]autoclone         If the selected service version is not
                                  editable, clone it and use the clone.
     -n, --name=NAME              Backend name
 
@@ -584,12 +1405,15 @@ COMMANDS
     Show detailed information about a backend on a Fastly service version
 
     -j, --json                   Render output as JSON
+        --format=text            Output format (text, json, env)
+        --output=OUTPUT          Write the output to a file instead of stdout
     -s, --service-id=SERVICE-ID  Service ID (falls back to FASTLY_SERVICE_ID,
                                  then fastly.toml)
         --service-name=SERVICE-NAME
                                  The name of the service
-        --version=VERSION        'latest', 'active', or the number of a specific
-                                 version
+        --version=VERSION        'latest', 'active', 'draft', relative to latest
+                                 or active (e.g. 'latest-1'), 'tag:NAME',
+                                 or the number of a specific version
     -n, --name=NAME              Name of backend
 
   backend list --version=VERSION [<flags>]
@@ -600,8 +1424,9 @@ COMMANDS
                                  then fastly.toml)
         --service-name=SERVICE-NAME
                                  The name of the service
-        --version=VERSION        'latest', 'active', or the number of a specific
-                                 version
+        --version=VERSION        'latest', 'active', 'draft', relative to latest
+                                 or active (e.g. 'latest-1'), 'tag:NAME',
+                                 or the number of a specific version
 
   backend update --version=VERSION --name=NAME [<flags>]
     Update a backend on a Fastly service version
@@ -610,9 +1435,11 @@ COMMANDS
                                    then fastly.toml)
         --service-name=SERVICE-NAME
                                    The name of the service
-        --version=VERSION          'latest', 'active', or the number of a
-                                   specific version
-        --autoclone                If the selected service version is not
+        --version=VERSION          'latest', 'active', 'draft', relative
+                                   to latest or active (e.g. 'latest-1'),
+                                   'tag:NAME', or the number of a specific
+                                   version
+        --[no-]autoclone           If the selected service version is not
                                    editable, clone it and use the clone.
     -n, --name=NAME                backend name
         --new-name=NEW-NAME        New backend name
@@ -668,14 +1495,39 @@ COMMANDS
         --ssl-ciphers=SSL-CIPHERS  List of OpenSSL ciphers
                                    (https://www.openssl.org/docs/man1.0.2/man1/ciphers)
 
+  billing usage [<flags>]
+    Summarise bandwidth, request and Compute@Edge usage per service for a
+    calendar month
+
+    --format=FORMAT  Output format (csv, json)
+    --month=MONTH    Month to report on in YYYY-MM format (defaults to the
+                     current month)
+
   compute build [<flags>]
     Build a Compute@Edge package locally
 
-    --include-source     Include source code in built package
-    --language=LANGUAGE  Language type
-    --name=NAME          Package name
-    --skip-verification  Skip verification steps and force build
-    --timeout=TIMEOUT    Timeout, in seconds, for the build compilation step
+    --audit                    Check the dependencies for security advisories
+                               before packaging (Rust only, requires
+                               cargo-audit)
+    --build-arg=BUILD-ARG ...  An argument appended to the built-in build
+                               command, which can be repeated (ignored when
+                               [scripts.build] is set)
+    --build-log=BUILD-LOG      Also emit build events (stages, durations,
+                               artifact sizes) as JSON lines (json)
+    --env-var=ENV-VAR ...      An environment variable (KEY=value) set for
+                               the build, in addition to [scripts.env_vars],
+                               which can be repeated
+    --include-source           Include source code in built package
+    --language=LANGUAGE        Language type
+    --name=NAME                Package name
+    --native-test              Also compile a native test binary, in parallel
+                               with the Wasm binary (Go and Rust only)
+    --[no-]sandbox             Run custom build scripts in a sandbox,
+                               as configured in the [sandbox] config section
+                               (--no-sandbox to disable)
+    --skip-verification        Skip verification steps and force build
+    --timeout=TIMEOUT          Timeout, in seconds, for the build compilation
+                               step
 
   compute deploy [<flags>]
     Deploy a package to a Fastly Compute@Edge service
@@ -684,13 +1536,41 @@ COMMANDS
                                  then fastly.toml)
         --service-name=SERVICE-NAME
                                  The name of the service
-        --version=VERSION        'latest', 'active', or the number of a specific
-                                 version
+        --version=VERSION        'latest', 'active', 'draft', relative to latest
+                                 or active (e.g. 'latest-1'), 'tag:NAME',
+                                 or the number of a specific version
         --comment=COMMENT        Human-readable comment
+        --comment-template=TEMPLATE
+                                 Template for the version comment when
+                                 --comment isn't set, populated from git and
+                                 the environment, e.g. '{{.GitShortSHA}} by
+                                 {{.GitAuthor}} via CLI {{.Version}}'
         --domain=DOMAIN          The name of the domain associated to the
                                  package
+        --git-remote="origin"    The git remote that --git-tag pushes the tag to
+        --git-tag                Create an annotated git tag
+                                 (fastly-deploy/<service>/<version>) of the
+                                 deployed commit and push it to --git-remote
+        --lock=LOCK              Name of a dictionary that holds an advisory
+                                 lock during the deploy, so a concurrent deploy
+                                 to the service fails rather than tramples this
+                                 one
+        --lock-ttl=30m0s         How long a --lock is held before it's presumed
+                                 abandoned and replaced
+        --metadata=METADATA ...  Metadata recorded in the version comment, e.g.
+                                 --metadata git_sha=abc123 (can be repeated)
         --name=NAME              Package name
+        --[no-]notify            Notify the endpoints in the [notify] config
+                                 section (--no-notify to skip)
     -p, --package=PACKAGE        Path to a package tar.gz
+        --policy=FILE            Path to a policy file whose rules the deploy
+                                 plan must satisfy before the service is changed
+                                 (default: fastly.policy.toml if it exists)
+        --remote-build           Upload the package source to the [remote_build]
+                                 endpoint to be built, instead of deploying a
+                                 locally built package
+        --require-clean-git      Fail, rather than warn, when the git working
+                                 tree has uncommitted changes
 
   compute init [<flags>]
     Initialize a new Compute@Edge package locally
@@ -704,8 +1584,27 @@ COMMANDS
     -f, --from=FROM                Local project directory, or Git repository
                                    URL, or URL referencing a .zip/.tar.gz file,
                                    containing a package template
+        --commit=COMMIT            Git commit to use from the package template
+                                   repository (overrides the commit pinned in
+                                   the starter kit configuration)
+        --answers=ANSWERS          Path to a JSON file of answers for the init
+                                   prompts, e.g. {"init": {"name": "my-app",
+                                   "author": ["me@example.com"]}}
         --force                    Skip non-empty directory verification step
                                    and force new project creation
+        --sync-template            Merge updates from the package template into
+                                   an existing project, instead of creating a
+                                   new project
+
+  compute manifest migrate [<flags>]
+    Upgrade the fastly.toml to the latest manifest_version (2)
+
+    --dry-run  Display the changes without modifying the manifest
+
+  compute manifest-schema
+    Display the JSON Schema for the fastly.toml manifest file (e.g. for IDE
+    autocomplete and validation)
+
 
   compute pack --wasm-binary=WASM-BINARY
     Package a pre-compiled Wasm binary for a Fastly Compute@Edge service
@@ -715,37 +1614,132 @@ COMMANDS
   compute publish [<flags>]
     Build and deploy a Compute@Edge package to a Fastly service
 
-        --comment=COMMENT        Human-readable comment
-        --domain=DOMAIN          The name of the domain associated to the
-                                 package
-        --include-source         Include source code in built package
-        --language=LANGUAGE      Language type
-        --name=NAME              Package name
-    -p, --package=PACKAGE        Path to a package tar.gz
-    -s, --service-id=SERVICE-ID  Service ID (falls back to FASTLY_SERVICE_ID,
-                                 then fastly.toml)
+        --audit                    Check the dependencies for security
+                                   advisories before packaging (Rust only,
+                                   requires cargo-audit)
+        --build-arg=BUILD-ARG ...  An argument appended to the built-in build
+                                   command, which can be repeated (ignored when
+                                   [scripts.build] is set)
+        --build-log=BUILD-LOG      Also emit build events (stages, durations,
+                                   artifact sizes) as JSON lines (json)
+        --comment=COMMENT          Human-readable comment
+        --comment-template=TEMPLATE
+                                   Template for the version comment when
+                                   --comment isn't set, populated from git and
+                                   the environment, e.g. '{{.GitShortSHA}} by
+                                   {{.GitAuthor}} via CLI {{.Version}}'
+        --debounce=1s              How long to wait for further file changes
+                                   before publishing when using --watch
+        --domain=DOMAIN            The name of the domain associated to the
+                                   package
+        --env-var=ENV-VAR ...      An environment variable (KEY=value) set for
+                                   the build, in addition to [scripts.env_vars],
+                                   which can be repeated
+        --git-remote="origin"      The git remote that --git-tag pushes the tag
+                                   to
+        --git-tag                  Create an annotated git tag
+                                   (fastly-deploy/<service>/<version>) of the
+                                   deployed commit and push it to --git-remote
+        --include-source           Include source code in built package
+        --language=LANGUAGE        Language type
+        --lock=LOCK                Name of a dictionary that holds an advisory
+                                   lock during the deploy, so a concurrent
+                                   deploy to the service fails rather than
+                                   tramples this one
+        --lock-ttl=30m0s           How long a --lock is held before it's
+                                   presumed abandoned and replaced
+        --metadata=METADATA ...    Metadata recorded in the version comment,
+                                   e.g. --metadata git_sha=abc123 (can be
+                                   repeated)
+        --name=NAME                Package name
+        --native-test              Also compile a native test binary,
+                                   in parallel with the Wasm binary (Go and Rust
+                                   only)
+        --[no-]notify              Notify the endpoints in the [notify] config
+                                   section (--no-notify to skip)
+    -p, --package=PACKAGE          Path to a package tar.gz
+        --policy=FILE              Path to a policy file whose rules the deploy
+                                   plan must satisfy before the service is
+                                   changed (default: fastly.policy.toml if it
+                                   exists)
+        --remote-build             Upload the package source to the
+                                   [remote_build] endpoint to be built, instead
+                                   of building locally
+        --require-clean-git        Fail, rather than warn, when the git working
+                                   tree has uncommitted changes
+    -s, --service-id=SERVICE-ID    Service ID (falls back to FASTLY_SERVICE_ID,
+                                   then fastly.toml)
         --service-name=SERVICE-NAME
-                                 The name of the service
-        --version=VERSION        'latest', 'active', or the number of a specific
-                                 version
-        --skip-verification      Skip verification steps and force build
-        --timeout=TIMEOUT        Timeout, in seconds, for the build compilation
-                                 step
+                                   The name of the service
+        --version=VERSION          'latest', 'active', 'draft', relative
+                                   to latest or active (e.g. 'latest-1'),
+                                   'tag:NAME', or the number of a specific
+                                   version
+        --[no-]sandbox             Run custom build scripts in a sandbox,
+                                   as configured in the [sandbox] config section
+                                   (--no-sandbox to disable)
+        --skip-verification        Skip verification steps and force build
+        --static-dir=DIR           Upload the files of a directory (e.g.
+                                   ./public) to a KV store linked to the
+                                   service, skipping the files that haven't
+                                   changed
+        --static-store=STATIC-STORE
+                                   Name of the KV store for --static-dir, which
+                                   is created if it doesn't exist (default:
+                                   <package name>-static)
+        --timeout=TIMEOUT          Timeout, in seconds, for the build
+                                   compilation step
+        --watch                    Watch for file changes, then rebuild and
+                                   deploy the project
 
   compute serve [<flags>]
     Build and run a Compute@Edge package locally
 
-    --addr="127.0.0.1:7676"  The IPv4 address and port to listen on
-    --env=ENV                The environment configuration to use (e.g. stage)
-    --file="bin/main.wasm"   The Wasm file to run
-    --include-source         Include source code in built package
-    --language=LANGUAGE      Language type
-    --name=NAME              Package name
-    --skip-build             Skip the build step
-    --skip-verification      Skip verification steps and force build
-    --timeout=TIMEOUT        Timeout, in seconds, for the build compilation step
-    --watch                  Watch for file changes, then rebuild project and
-                             restart local server
+    --addr="127.0.0.1:7676"    The IPv4 address and port to listen on
+    --arch=ARCH                The architecture of the Viceroy binary to
+                               download, instead of the detected one (amd64,
+                               arm64)
+    --audit                    Check the dependencies for security advisories
+                               before packaging (Rust only, requires
+                               cargo-audit)
+    --build-arg=BUILD-ARG ...  An argument appended to the built-in build
+                               command, which can be repeated (ignored when
+                               [scripts.build] is set)
+    --build-log=BUILD-LOG      Also emit build events (stages, durations,
+                               artifact sizes) as JSON lines (json)
+    --env=ENV                  The environment configuration to use (e.g. stage)
+    --file="bin/main.wasm"     The Wasm file to run
+    --env-var=ENV-VAR ...      An environment variable (KEY=value) set for
+                               the build and the local server, in addition to
+                               [scripts.env_vars], which can be repeated
+    --include-source           Include source code in built package
+    --language=LANGUAGE        Language type
+    --name=NAME                Package name
+    --native-test              Also compile a native test binary, in parallel
+                               with the Wasm binary (Go and Rust only)
+    --skip-build               Skip the build step
+    --[no-]sandbox             Run custom build scripts in a sandbox,
+                               as configured in the [sandbox] config section
+                               (--no-sandbox to disable)
+    --skip-verification        Skip verification steps and force build
+    --timeout=TIMEOUT          Timeout, in seconds, for the build compilation
+                               step
+    --watch                    Watch for file changes, then rebuild project and
+                               restart local server
+
+  compute status [<flags>]
+    Show the Fastly service a Compute@Edge project is linked to, and whether its
+    local package has been deployed
+
+        --exit-code              Exit with status 7 if the local package isn't
+                                 deployed to the active version (see `+"`"+`fastly
+                                 help exit-codes`+"`"+`)
+    -j, --json                   Render output as JSON
+    -p, --package=PACKAGE        Path to a package tar.gz
+    -s, --service-id=SERVICE-ID  Service ID (falls back to FASTLY_SERVICE_ID,
+                                 then fastly.toml)
+        --service-name=SERVICE-NAME
+                                 The name of the service
 
   compute update --version=VERSION --package=PACKAGE [<flags>]
     Update a package on a Fastly Compute@Edge service version
@@ -754,9 +1748,10 @@ COMMANDS
                                  then fastly.toml)
         --service-name=SERVICE-NAME
                                  The name of the service
-        --version=VERSION        'latest', 'active', or the number of a specific
-                                 version
-        --autoclone              If the selected service version is not
+        --version=VERSION        'latest', 'active', 'draft', relative to latest
+                                 or active (e.g. 'latest-1'), 'tag:NAME',
+                                 or the number of a specific version
+        --[no-]autoclone         If the selected service version is not
                                  editable, clone it and use the clone.
     -p, --package=PACKAGE        Path to a package tar.gz
 
@@ -765,11 +1760,20 @@ COMMANDS
 
     -p, --package=PACKAGE  Path to a package tar.gz
 
-  config [<flags>]
+  config pin
+    Keep the current language constraints and starter kits when the CLI is
+    updated
+
+
+  config *show [<flags>]
     Display the Fastly CLI configuration
 
     -l, --location  Print the location of the CLI configuration file
 
+  config unpin
+    Use the language constraints and starter kits shipped with the CLI
+
+
   dictionary create --version=VERSION --name=NAME [<flags>]
     Create a Fastly edge dictionary on a Fastly service version
 
@@ -777,9 +1781,10 @@ COMMANDS
                                  then fastly.toml)
         --service-name=SERVICE-NAME
                                  The name of the service
-        --version=VERSION        'latest', 'active', or the number of a specific
-                                 version
-        --autoclone              If the selected service version is not
+        --version=VERSION        'latest', 'active', 'draft', relative to latest
+                                 or active (e.g. 'latest-1'), 'tag:NAME',
+                                 or the number of a specific version
+        --[no-]autoclone         If the selected service version is not
                                  editable, clone it and use the clone.
     -n, --name=NAME              Name of Dictionary
         --write-only=WRITE-ONLY  Whether to mark this dictionary as write-only.
@@ -792,9 +1797,10 @@ COMMANDS
                                  then fastly.toml)
         --service-name=SERVICE-NAME
                                  The name of the service
-        --version=VERSION        'latest', 'active', or the number of a specific
-                                 version
-        --autoclone              If the selected service version is not
+        --version=VERSION        'latest', 'active', 'draft', relative to latest
+                                 or active (e.g. 'latest-1'), 'tag:NAME',
+                                 or the number of a specific version
+        --[no-]autoclone         If the selected service version is not
                                  editable, clone it and use the clone.
     -n, --name=NAME              Name of Dictionary
 
@@ -802,14 +1808,36 @@ COMMANDS
     Show detailed information about a Fastly edge dictionary
 
     -j, --json                   Render output as JSON
+        --format=text            Output format (text, json, env)
+        --output=OUTPUT          Write the output to a file instead of stdout
     -s, --service-id=SERVICE-ID  Service ID (falls back to FASTLY_SERVICE_ID,
                                  then fastly.toml)
         --service-name=SERVICE-NAME
                                  The name of the service
-        --version=VERSION        'latest', 'active', or the number of a specific
-                                 version
+        --version=VERSION        'latest', 'active', 'draft', relative to latest
+                                 or active (e.g. 'latest-1'), 'tag:NAME',
+                                 or the number of a specific version
     -n, --name=NAME              Name of Dictionary
 
+  dictionary diff --file=FILE --name=NAME --version=VERSION [<flags>]
+    Compare a local file of items with the items of a Fastly edge dictionary
+
+        --apply                  Change the items of the dictionary to match the
+                                 file
+        --file=FILE              Path to a JSON file of the items' keys and
+                                 values, e.g. {"key": "value"}
+    -n, --name=NAME              Name of Dictionary
+        --revert-on-failure      When a chunk of operations fails, revert the
+                                 chunks already applied so the dictionary is
+                                 left unchanged (with --apply)
+    -s, --service-id=SERVICE-ID  Service ID (falls back to FASTLY_SERVICE_ID,
+                                 then fastly.toml)
+        --service-name=SERVICE-NAME
+                                 The name of the service
+        --version=VERSION        'latest', 'active', 'draft', relative to latest
+                                 or active (e.g. 'latest-1'), 'tag:NAME',
+                                 or the number of a specific version
+
   dictionary list --version=VERSION [<flags>]
     List all dictionaries on a Fastly service version
 
@@ -818,8 +1846,9 @@ COMMANDS
                                  then fastly.toml)
         --service-name=SERVICE-NAME
                                  The name of the service
-        --version=VERSION        'latest', 'active', or the number of a specific
-                                 version
+        --version=VERSION        'latest', 'active', 'draft', relative to latest
+                                 or active (e.g. 'latest-1'), 'tag:NAME',
+                                 or the number of a specific version
 
   dictionary update --version=VERSION --name=NAME [<flags>]
     Update name of dictionary on a Fastly service version
@@ -828,9 +1857,10 @@ COMMANDS
                                  then fastly.toml)
         --service-name=SERVICE-NAME
                                  The name of the service
-        --version=VERSION        'latest', 'active', or the number of a specific
-                                 version
-        --autoclone              If the selected service version is not
+        --version=VERSION        'latest', 'active', 'draft', relative to latest
+                                 or active (e.g. 'latest-1'), 'tag:NAME',
+                                 or the number of a specific version
+        --[no-]autoclone         If the selected service version is not
                                  editable, clone it and use the clone.
     -n, --name=NAME              Old name of Dictionary
         --new-name=NEW-NAME      New name of Dictionary
@@ -864,6 +1894,8 @@ COMMANDS
     Show detailed information about a Fastly edge dictionary item
 
     -j, --json                   Render output as JSON
+        --format=text            Output format (text, json, env)
+        --output=OUTPUT          Write the output to a file instead of stdout
     -s, --service-id=SERVICE-ID  Service ID (falls back to FASTLY_SERVICE_ID,
                                  then fastly.toml)
         --service-name=SERVICE-NAME
@@ -875,11 +1907,14 @@ COMMANDS
   dictionary-item list --dictionary-id=DICTIONARY-ID [<flags>]
     List items in a Fastly edge dictionary
 
+        --all                    Fetch every page of the data set (the default
+                                 unless --page is set)
         --dictionary-id=DICTIONARY-ID
                                  Dictionary ID
         --direction=ascend       Direction in which to sort results
     -j, --json                   Render output as JSON
-        --page=PAGE              Page number of data set to fetch
+        --page=PAGE              Page number of data set to fetch (only that
+                                 page is returned)
         --per-page=PER-PAGE      Number of records per page
     -s, --service-id=SERVICE-ID  Service ID (falls back to FASTLY_SERVICE_ID,
                                  then fastly.toml)
@@ -894,12 +1929,150 @@ COMMANDS
                                  Dictionary ID
         --file=FILE              Batch update json file
         --key=KEY                Dictionary item key
+        --revert-on-failure      When a chunk of operations fails, revert the
+                                 chunks already applied so the dictionary is
+                                 left unchanged (with --file)
     -s, --service-id=SERVICE-ID  Service ID (falls back to FASTLY_SERVICE_ID,
                                  then fastly.toml)
         --service-name=SERVICE-NAME
                                  The name of the service
         --value=VALUE            Dictionary item value
 
+  director add-backend --backend=BACKEND --name=NAME --version=VERSION [<flags>]
+    Add a backend to a director on a Fastly service version
+
+        --backend=BACKEND        Name of the backend to add
+    -n, --name=NAME              Name of the director
+        --version=VERSION        'latest', 'active', 'draft', relative to latest
+                                 or active (e.g. 'latest-1'), 'tag:NAME',
+                                 or the number of a specific version
+        --[no-]autoclone         If the selected service version is not
+                                 editable, clone it and use the clone.
+    -s, --service-id=SERVICE-ID  Service ID (falls back to FASTLY_SERVICE_ID,
+                                 then fastly.toml)
+        --service-name=SERVICE-NAME
+                                 The name of the service
+        --weight=WEIGHT          Weight used by a random director to load
+                                 balance this backend against others
+
+  director create --name=NAME --version=VERSION [<flags>]
+    Create a director on a Fastly service version
+
+    -n, --name=NAME              Name of the director
+        --version=VERSION        'latest', 'active', 'draft', relative to latest
+                                 or active (e.g. 'latest-1'), 'tag:NAME',
+                                 or the number of a specific version
+        --[no-]autoclone         If the selected service version is not
+                                 editable, clone it and use the clone.
+        --comment=COMMENT        A descriptive note
+        --quorum=QUORUM          The percentage of capacity that needs to be up
+                                 for the director to be considered up (0-100)
+        --retries=RETRIES        How many backends to search if it fails
+    -s, --service-id=SERVICE-ID  Service ID (falls back to FASTLY_SERVICE_ID,
+                                 then fastly.toml)
+        --service-name=SERVICE-NAME
+                                 The name of the service
+        --shield=SHIELD          Selected POP to serve as a shield for the
+                                 backends
+        --type=random            How the director selects a backend (only
+                                 'random' honours backend weights)
+
+  director delete --name=NAME --version=VERSION [<flags>]
+    Delete a director on a Fastly service version
+
+    -n, --name=NAME              Name of the director
+        --version=VERSION        'latest', 'active', 'draft', relative to latest
+                                 or active (e.g. 'latest-1'), 'tag:NAME',
+                                 or the number of a specific version
+        --[no-]autoclone         If the selected service version is not
+                                 editable, clone it and use the clone.
+    -s, --service-id=SERVICE-ID  Service ID (falls back to FASTLY_SERVICE_ID,
+                                 then fastly.toml)
+        --service-name=SERVICE-NAME
+                                 The name of the service
+
+  director describe --name=NAME --version=VERSION [<flags>]
+    Show detailed information about a director on a Fastly service version
+
+    -n, --name=NAME              Name of the director
+        --version=VERSION        'latest', 'active', 'draft', relative to latest
+                                 or active (e.g. 'latest-1'), 'tag:NAME',
+                                 or the number of a specific version
+    -j, --json                   Render output as JSON
+        --format=text            Output format (text, json, env)
+        --output=OUTPUT          Write the output to a file instead of stdout
+    -s, --service-id=SERVICE-ID  Service ID (falls back to FASTLY_SERVICE_ID,
+                                 then fastly.toml)
+        --service-name=SERVICE-NAME
+                                 The name of the service
+
+  director list --version=VERSION [<flags>]
+    List directors on a Fastly service version
+
+        --version=VERSION        'latest', 'active', 'draft', relative to latest
+                                 or active (e.g. 'latest-1'), 'tag:NAME',
+                                 or the number of a specific version
+    -j, --json                   Render output as JSON
+    -s, --service-id=SERVICE-ID  Service ID (falls back to FASTLY_SERVICE_ID,
+                                 then fastly.toml)
+        --service-name=SERVICE-NAME
+                                 The name of the service
+
+  director rebalance --name=NAME --version=VERSION --weights=WEIGHTS [<flags>]
+    Set the weights of a director's backends in one call (backends not listed
+    keep their current weight)
+
+    -n, --name=NAME              Name of the director
+        --version=VERSION        'latest', 'active', 'draft', relative to latest
+                                 or active (e.g. 'latest-1'), 'tag:NAME',
+                                 or the number of a specific version
+        --weights=WEIGHTS ...    A comma-separated list of backend=weight pairs
+                                 (e.g. origin-a=70,origin-b=30)
+        --[no-]autoclone         If the selected service version is not
+                                 editable, clone it and use the clone.
+    -s, --service-id=SERVICE-ID  Service ID (falls back to FASTLY_SERVICE_ID,
+                                 then fastly.toml)
+        --service-name=SERVICE-NAME
+                                 The name of the service
+
+  director remove-backend --backend=BACKEND --name=NAME --version=VERSION [<flags>]
+    Remove a backend from a director on a Fastly service version
+
+        --backend=BACKEND        Name of the backend to remove
+    -n, --name=NAME              Name of the director
+        --version=VERSION        'latest', 'active', 'draft', relative to latest
+                                 or active (e.g. 'latest-1'), 'tag:NAME',
+                                 or the number of a specific version
+        --[no-]autoclone         If the selected service version is not
+                                 editable, clone it and use the clone.
+    -s, --service-id=SERVICE-ID  Service ID (falls back to FASTLY_SERVICE_ID,
+                                 then fastly.toml)
+        --service-name=SERVICE-NAME
+                                 The name of the service
+
+  director update --name=NAME --version=VERSION [<flags>]
+    Update a director on a Fastly service version
+
+    -n, --name=NAME              Name of the director
+        --version=VERSION        'latest', 'active', 'draft', relative to latest
+                                 or active (e.g. 'latest-1'), 'tag:NAME',
+                                 or the number of a specific version
+        --[no-]autoclone         If the selected service version is not
+                                 editable, clone it and use the clone.
+        --comment=COMMENT        A descriptive note
+        --new-name=NEW-NAME      New name for the director
+        --quorum=QUORUM          The percentage of capacity that needs to be up
+                                 for the director to be considered up (0-100)
+        --retries=RETRIES        How many backends to search if it fails
+    -s, --service-id=SERVICE-ID  Service ID (falls back to FASTLY_SERVICE_ID,
+                                 then fastly.toml)
+        --service-name=SERVICE-NAME
+                                 The name of the service
+        --shield=SHIELD          Selected POP to serve as a shield for the
+                                 backends
+        --type=TYPE              How the director selects a backend (only
+                                 'random' honours backend weights)
+
   domain create --name=NAME --version=VERSION [<flags>]
     Create a domain on a Fastly service version
 
@@ -909,9 +2082,10 @@ COMMANDS
                                  then fastly.toml)
         --service-name=SERVICE-NAME
                                  The name of the service
-        --version=VERSION        'latest', 'active', or the number of a specific
-                                 version
-        --autoclone              If the selected service version is not
+        --version=VERSION        'latest', 'active', 'draft', relative to latest
+                                 or active (e.g. 'latest-1'), 'tag:NAME',
+                                 or the number of a specific version
+        --[no-]autoclone         If the selected service version is not
                                  editable, clone it and use the clone.
 
   domain delete --name=NAME --version=VERSION [<flags>]
@@ -922,21 +2096,25 @@ COMMANDS
                                  then fastly.toml)
         --service-name=SERVICE-NAME
                                  The name of the service
-        --version=VERSION        'latest', 'active', or the number of a specific
-                                 version
-        --autoclone              If the selected service version is not
+        --version=VERSION        'latest', 'active', 'draft', relative to latest
+                                 or active (e.g. 'latest-1'), 'tag:NAME',
+                                 or the number of a specific version
+        --[no-]autoclone         If the selected service version is not
                                  editable, clone it and use the clone.
 
   domain describe --version=VERSION --name=NAME [<flags>]
     Show detailed information about a domain on a Fastly service version
 
     -j, --json                   Render output as JSON
+        --format=text            Output format (text, json, env)
+        --output=OUTPUT          Write the output to a file instead of stdout
     -s, --service-id=SERVICE-ID  Service ID (falls back to FASTLY_SERVICE_ID,
                                  then fastly.toml)
         --service-name=SERVICE-NAME
                                  The name of the service
-        --version=VERSION        'latest', 'active', or the number of a specific
-                                 version
+        --version=VERSION        'latest', 'active', 'draft', relative to latest
+                                 or active (e.g. 'latest-1'), 'tag:NAME',
+                                 or the number of a specific version
     -n, --name=NAME              Name of domain
 
   domain list --version=VERSION [<flags>]
@@ -947,8 +2125,18 @@ COMMANDS
                                  then fastly.toml)
         --service-name=SERVICE-NAME
                                  The name of the service
-        --version=VERSION        'latest', 'active', or the number of a specific
-                                 version
+        --version=VERSION        'latest', 'active', 'draft', relative to latest
+                                 or active (e.g. 'latest-1'), 'tag:NAME',
+                                 or the number of a specific version
+
+  domain suggest [<flags>] <query>
+    Suggest domain names for a query, along with whether they're available
+
+        --defaults=DEFAULTS ...  Zone to always suggest a domain name in, e.g.
+                                 com (can be repeated)
+    -j, --json                   Render output as JSON
+        --keyword=KEYWORD ...    Keyword to suggest related domain names for
+                                 (can be repeated)
 
   domain update --version=VERSION --name=NAME [<flags>]
     Update a domain on a Fastly service version
@@ -957,9 +2145,10 @@ COMMANDS
                                  then fastly.toml)
         --service-name=SERVICE-NAME
                                  The name of the service
-        --version=VERSION        'latest', 'active', or the number of a specific
-                                 version
-        --autoclone              If the selected service version is not
+        --version=VERSION        'latest', 'active', 'draft', relative to latest
+                                 or active (e.g. 'latest-1'), 'tag:NAME',
+                                 or the number of a specific version
+        --[no-]autoclone         If the selected service version is not
                                  editable, clone it and use the clone.
     -n, --name=NAME              Domain name
         --new-name=NEW-NAME      New domain name
@@ -968,8 +2157,9 @@ COMMANDS
   domain validate --version=VERSION [<flags>]
     Checks the status of a specific domain's DNS record for a Service Version
 
-        --version=VERSION        'latest', 'active', or the number of a specific
-                                 version
+        --version=VERSION        'latest', 'active', 'draft', relative to latest
+                                 or active (e.g. 'latest-1'), 'tag:NAME',
+                                 or the number of a specific version
     -a, --all                    Checks the status of all domains' DNS records
                                  for a Service Version
     -n, --name=NAME              The name of the domain associated with this
@@ -979,6 +2169,44 @@ COMMANDS
         --service-name=SERVICE-NAME
                                  The name of the service
 
+  errors clear
+    Delete all entries in the error log
+
+
+  errors show [<flags>]
+    Show the most recent entries in the error log
+
+    -n, --limit=5  The number of most recent CLI invocations to show
+
+  events describe --id=ID [<flags>]
+    Show detailed information about an event
+
+        --id=ID          Alphanumeric string identifying an event
+    -j, --json           Render output as JSON
+        --format=text    Output format (text, json, env)
+        --output=OUTPUT  Write the output to a file instead of stdout
+
+  events list [<flags>]
+    List events from the audit log of your Fastly account
+
+        --all                    Fetch every page of the data set (the default
+                                 unless --page is set)
+        --event-type=EVENT-TYPE  Limit the returned events to a specific event
+                                 type (e.g. version.activate)
+    -f, --follow                 Poll for new events until interrupted
+        --interval=10s           How often to poll for new events when using
+                                 --follow
+    -j, --json                   Render output as JSON
+        --page=PAGE              Page number of data set to fetch (only that
+                                 page is returned)
+        --per-page=PER-PAGE      Number of records per page
+    -s, --service-id=SERVICE-ID  Limit the returned events to a specific service
+        --since=SINCE            Only show events created after this point in
+                                 time (a duration such as 24h, or an RFC3339
+                                 timestamp)
+        --user=USER              Limit the returned events to those made by a
+                                 specific user ID
+
   healthcheck create --version=VERSION --name=NAME [<flags>]
     Create a healthcheck on a Fastly service version
 
@@ -986,9 +2214,10 @@ COMMANDS
                                  then fastly.toml)
         --service-name=SERVICE-NAME
                                  The name of the service
-        --version=VERSION        'latest', 'active', or the number of a specific
-                                 version
-        --autoclone              If the selected service version is not
+        --version=VERSION        'latest', 'active', 'draft', relative to latest
+                                 or active (e.g. 'latest-1'), 'tag:NAME',
+                                 or the number of a specific version
+        --[no-]autoclone         If the selected service version is not
                                  editable, clone it and use the clone.
     -n, --name=NAME              Healthcheck name
         --comment=COMMENT        A descriptive note
@@ -1017,9 +2246,10 @@ COMMANDS
                                  then fastly.toml)
         --service-name=SERVICE-NAME
                                  The name of the service
-        --version=VERSION        'latest', 'active', or the number of a specific
-                                 version
-        --autoclone              If the selected service version is not
+        --version=VERSION        'latest', 'active', 'draft', relative to latest
+                                 or active (e.g. 'latest-1'), 'tag:NAME',
+                                 or the number of a specific version
+        --[no-]autoclone         If the selected service version is not
                                  editable, clone it and use the clone.
     -n, --name=NAME              Healthcheck name
 
@@ -1027,12 +2257,15 @@ COMMANDS
     Show detailed information about a healthcheck on a Fastly service version
 
     -j, --json                   Render output as JSON
+        --format=text            Output format (text, json, env)
+        --output=OUTPUT          Write the output to a file instead of stdout
     -s, --service-id=SERVICE-ID  Service ID (falls back to FASTLY_SERVICE_ID,
                                  then fastly.toml)
         --service-name=SERVICE-NAME
                                  The name of the service
-        --version=VERSION        'latest', 'active', or the number of a specific
-                                 version
+        --version=VERSION        'latest', 'active', 'draft', relative to latest
+                                 or active (e.g. 'latest-1'), 'tag:NAME',
+                                 or the number of a specific version
     -n, --name=NAME              Name of healthcheck
 
   healthcheck list --version=VERSION [<flags>]
@@ -1043,8 +2276,9 @@ COMMANDS
                                  then fastly.toml)
         --service-name=SERVICE-NAME
                                  The name of the service
-        --version=VERSION        'latest', 'active', or the number of a specific
-                                 version
+        --version=VERSION        'latest', 'active', 'draft', relative to latest
+                                 or active (e.g. 'latest-1'), 'tag:NAME',
+                                 or the number of a specific version
 
   healthcheck update --version=VERSION --name=NAME [<flags>]
     Update a healthcheck on a Fastly service version
@@ -1053,9 +2287,10 @@ COMMANDS
                                  then fastly.toml)
         --service-name=SERVICE-NAME
                                  The name of the service
-        --version=VERSION        'latest', 'active', or the number of a specific
-                                 version
-        --autoclone              If the selected service version is not
+        --version=VERSION        'latest', 'active', 'draft', relative to latest
+                                 or active (e.g. 'latest-1'), 'tag:NAME',
+                                 or the number of a specific version
+        --[no-]autoclone         If the selected service version is not
                                  editable, clone it and use the clone.
     -n, --name=NAME              Healthcheck name
         --new-name=NEW-NAME      Healthcheck name
@@ -1082,6 +2317,36 @@ COMMANDS
     List Fastly's public IPs
 
 
+  kv-store get --key=KEY --store=STORE [<flags>]
+    Stream the value of a key of a KV store to stdout or a file
+
+    --key=KEY      Key to read
+    --store=STORE  Name of the KV store
+    --file=PATH    Path to write the value to, reporting progress (default:
+                   stdout)
+    --range=RANGE  Only read the bytes in the range: FIRST-LAST, FIRST- (to the
+                   end) or -N (the last N bytes)
+
+  kv-store insert --key=KEY --store=STORE [<flags>]
+    Set the value of a key of a KV store, streaming it from a file or stdin
+
+    --key=KEY            Key to set
+    --store=STORE        Name of the KV store
+    --file=PATH          Path to a file whose content is the value (default:
+                         stdin)
+    --metadata=METADATA  Metadata (e.g. JSON) to store alongside the value
+
+  kv-store sync --store=STORE [<flags>] <dir>
+    Mirror a local directory into a KV store, uploading only the files that
+    changed
+
+    --store=STORE        Name of the KV store, which is created if it doesn't
+                         exist
+    --metadata-from-ext  Set the content-type and cache-control metadata of each
+                         key from its file extension
+    --prune              Delete the keys of the store that aren't files of the
+                         directory
+
   log-tail [<flags>]
     Tail Compute@Edge logs
 
@@ -1100,9 +2365,10 @@ COMMANDS
 
     -n, --name=NAME              The name of the Azure Blob Storage logging
                                  object. Used as a primary key for API access
-        --version=VERSION        'latest', 'active', or the number of a specific
-                                 version
-        --autoclone              If the selected service version is not
+        --version=VERSION        'latest', 'active', 'draft', relative to latest
+                                 or active (e.g. 'latest-1'), 'tag:NAME',
+                                 or the number of a specific version
+        --[no-]autoclone         If the selected service version is not
                                  editable, clone it and use the clone.
         --container=CONTAINER    The name of the Azure Blob Storage container in
                                  which to store logs
@@ -1159,9 +2425,10 @@ COMMANDS
   logging azureblob delete --version=VERSION --name=NAME [<flags>]
     Delete an Azure Blob Storage logging endpoint on a Fastly service version
 
-        --version=VERSION        'latest', 'active', or the number of a specific
-                                 version
-        --autoclone              If the selected service version is not
+        --version=VERSION        'latest', 'active', 'draft', relative to latest
+                                 or active (e.g. 'latest-1'), 'tag:NAME',
+                                 or the number of a specific version
+        --[no-]autoclone         If the selected service version is not
                                  editable, clone it and use the clone.
     -n, --name=NAME              The name of the Azure Blob Storage logging
                                  object
@@ -1175,15 +2442,32 @@ COMMANDS
     Fastly service version
 
     -j, --json                   Render output as JSON
+        --format=text            Output format (text, json, env)
+        --output=OUTPUT          Write the output to a file instead of stdout
     -s, --service-id=SERVICE-ID  Service ID (falls back to FASTLY_SERVICE_ID,
                                  then fastly.toml)
         --service-name=SERVICE-NAME
                                  The name of the service
-        --version=VERSION        'latest', 'active', or the number of a specific
-                                 version
+        --version=VERSION        'latest', 'active', 'draft', relative to latest
+                                 or active (e.g. 'latest-1'), 'tag:NAME',
+                                 or the number of a specific version
     -n, --name=NAME              The name of the Azure Blob Storage logging
                                  object
 
+  logging azureblob generate-sas --account-name=ACCOUNT-NAME --container=CONTAINER [<flags>]
+    Generate a SAS token scoped to writing logs to an Azure Blob Storage
+    container
+
+        --account-name=ACCOUNT-NAME
+                                   The unique Azure Blob Storage namespace in
+                                   which your data objects are stored
+        --container=CONTAINER      The name of the Azure Blob Storage container
+                                   in which to store logs
+        --account-key=ACCOUNT-KEY  The storage account access key used to sign
+                                   the token (falls back to AZURE_STORAGE_KEY)
+        --expires-in=2160h         How long the token is valid for, e.g. 720h
+    -j, --json                     Render output as JSON
+
   logging azureblob list --version=VERSION [<flags>]
     List Azure Blob Storage logging endpoints on a Fastly service version
 
@@ -1192,15 +2476,17 @@ COMMANDS
                                  then fastly.toml)
         --service-name=SERVICE-NAME
                                  The name of the service
-        --version=VERSION        'latest', 'active', or the number of a specific
-                                 version
+        --version=VERSION        'latest', 'active', 'draft', relative to latest
+                                 or active (e.g. 'latest-1'), 'tag:NAME',
+                                 or the number of a specific version
 
   logging azureblob update --version=VERSION --name=NAME [<flags>]
     Update an Azure Blob Storage logging endpoint on a Fastly service version
 
-        --version=VERSION        'latest', 'active', or the number of a specific
-                                 version
-        --autoclone              If the selected service version is not
+        --version=VERSION        'latest', 'active', 'draft', relative to latest
+                                 or active (e.g. 'latest-1'), 'tag:NAME',
+                                 or the number of a specific version
+        --[no-]autoclone         If the selected service version is not
                                  editable, clone it and use the clone.
     -n, --name=NAME              The name of the Azure Blob Storage logging
                                  object
@@ -1258,14 +2544,15 @@ COMMANDS
                                  compression_codec and gzip_level in the same
                                  API request will result in an error.
 
-  logging bigquery create --name=NAME --version=VERSION --project-id=PROJECT-ID --dataset=DATASET --table=TABLE --user=USER --secret-key=SECRET-KEY [<flags>]
+  logging bigquery create --name=NAME --version=VERSION --dataset=DATASET --table=TABLE [<flags>]
     Create a BigQuery logging endpoint on a Fastly service version
 
     -n, --name=NAME              The name of the BigQuery logging object.
                                  Used as a primary key for API access
-        --version=VERSION        'latest', 'active', or the number of a specific
-                                 version
-        --autoclone              If the selected service version is not
+        --version=VERSION        'latest', 'active', 'draft', relative to latest
+                                 or active (e.g. 'latest-1'), 'tag:NAME',
+                                 or the number of a specific version
+        --[no-]autoclone         If the selected service version is not
                                  editable, clone it and use the clone.
         --project-id=PROJECT-ID  Your Google Cloud Platform project ID
         --dataset=DATASET        Your BigQuery dataset
@@ -1276,6 +2563,11 @@ COMMANDS
         --secret-key=SECRET-KEY  Your Google Cloud Platform account secret key.
                                  The private_key field in your service account
                                  authentication JSON.
+        --credentials-file=CREDENTIALS-FILE
+                                 Path to the JSON key file of a Google Cloud
+                                 service account. Its client_email, private_key
+                                 and project_id fields are used unless --user,
+                                 --secret-key and --project-id are set
     -s, --service-id=SERVICE-ID  Service ID (falls back to FASTLY_SERVICE_ID,
                                  then fastly.toml)
         --service-name=SERVICE-NAME
@@ -1304,9 +2596,10 @@ COMMANDS
   logging bigquery delete --version=VERSION --name=NAME [<flags>]
     Delete a BigQuery logging endpoint on a Fastly service version
 
-        --version=VERSION        'latest', 'active', or the number of a specific
-                                 version
-        --autoclone              If the selected service version is not
+        --version=VERSION        'latest', 'active', 'draft', relative to latest
+                                 or active (e.g. 'latest-1'), 'tag:NAME',
+                                 or the number of a specific version
+        --[no-]autoclone         If the selected service version is not
                                  editable, clone it and use the clone.
     -n, --name=NAME              The name of the BigQuery logging object
     -s, --service-id=SERVICE-ID  Service ID (falls back to FASTLY_SERVICE_ID,
@@ -1319,12 +2612,15 @@ COMMANDS
     service version
 
     -j, --json                   Render output as JSON
+        --format=text            Output format (text, json, env)
+        --output=OUTPUT          Write the output to a file instead of stdout
     -s, --service-id=SERVICE-ID  Service ID (falls back to FASTLY_SERVICE_ID,
                                  then fastly.toml)
         --service-name=SERVICE-NAME
                                  The name of the service
-        --version=VERSION        'latest', 'active', or the number of a specific
-                                 version
+        --version=VERSION        'latest', 'active', 'draft', relative to latest
+                                 or active (e.g. 'latest-1'), 'tag:NAME',
+                                 or the number of a specific version
     -n, --name=NAME              The name of the BigQuery logging object
 
   logging bigquery list --version=VERSION [<flags>]
@@ -1335,15 +2631,17 @@ COMMANDS
                                  then fastly.toml)
         --service-name=SERVICE-NAME
                                  The name of the service
-        --version=VERSION        'latest', 'active', or the number of a specific
-                                 version
+        --version=VERSION        'latest', 'active', 'draft', relative to latest
+                                 or active (e.g. 'latest-1'), 'tag:NAME',
+                                 or the number of a specific version
 
   logging bigquery update --version=VERSION --name=NAME [<flags>]
     Update a BigQuery logging endpoint on a Fastly service version
 
-        --version=VERSION        'latest', 'active', or the number of a specific
-                                 version
-        --autoclone              If the selected service version is not
+        --version=VERSION        'latest', 'active', 'draft', relative to latest
+                                 or active (e.g. 'latest-1'), 'tag:NAME',
+                                 or the number of a specific version
+        --[no-]autoclone         If the selected service version is not
                                  editable, clone it and use the clone.
     -n, --name=NAME              The name of the BigQuery logging object
     -s, --service-id=SERVICE-ID  Service ID (falls back to FASTLY_SERVICE_ID,
@@ -1360,6 +2658,11 @@ COMMANDS
         --secret-key=SECRET-KEY  Your Google Cloud Platform account secret key.
                                  The private_key field in your service account
                                  authentication JSON.
+        --credentials-file=CREDENTIALS-FILE
+                                 Path to the JSON key file of a Google
+                                 Cloud service account. Its client_email and
+                                 private_key fields are used unless --user and
+                                 --secret-key are set
         --template-suffix=TEMPLATE-SUFFIX
                                  BigQuery table name suffix template
         --format=FORMAT          Apache style log formatting. Must produce JSON
@@ -1386,9 +2689,10 @@ COMMANDS
 
     -n, --name=NAME              The name of the Cloudfiles logging object.
                                  Used as a primary key for API access
-        --version=VERSION        'latest', 'active', or the number of a specific
-                                 version
-        --autoclone              If the selected service version is not
+        --version=VERSION        'latest', 'active', 'draft', relative to latest
+                                 or active (e.g. 'latest-1'), 'tag:NAME',
+                                 or the number of a specific version
+        --[no-]autoclone         If the selected service version is not
                                  editable, clone it and use the clone.
         --user=USER              The username for your Cloudfile account
         --access-key=ACCESS-KEY  Your Cloudfile account access key
@@ -1440,9 +2744,10 @@ COMMANDS
   logging cloudfiles delete --version=VERSION --name=NAME [<flags>]
     Delete a Cloudfiles logging endpoint on a Fastly service version
 
-        --version=VERSION        'latest', 'active', or the number of a specific
-                                 version
-        --autoclone              If the selected service version is not
+        --version=VERSION        'latest', 'active', 'draft', relative to latest
+                                 or active (e.g. 'latest-1'), 'tag:NAME',
+                                 or the number of a specific version
+        --[no-]autoclone         If the selected service version is not
                                  editable, clone it and use the clone.
     -n, --name=NAME              The name of the Cloudfiles logging object
     -s, --service-id=SERVICE-ID  Service ID (falls back to FASTLY_SERVICE_ID,
@@ -1455,12 +2760,15 @@ COMMANDS
     service version
 
     -j, --json                   Render output as JSON
+        --format=text            Output format (text, json, env)
+        --output=OUTPUT          Write the output to a file instead of stdout
     -s, --service-id=SERVICE-ID  Service ID (falls back to FASTLY_SERVICE_ID,
                                  then fastly.toml)
         --service-name=SERVICE-NAME
                                  The name of the service
-        --version=VERSION        'latest', 'active', or the number of a specific
-                                 version
+        --version=VERSION        'latest', 'active', 'draft', relative to latest
+                                 or active (e.g. 'latest-1'), 'tag:NAME',
+                                 or the number of a specific version
     -n, --name=NAME              The name of the Cloudfiles logging object
 
   logging cloudfiles list --version=VERSION [<flags>]
@@ -1471,15 +2779,17 @@ COMMANDS
                                  then fastly.toml)
         --service-name=SERVICE-NAME
                                  The name of the service
-        --version=VERSION        'latest', 'active', or the number of a specific
-                                 version
+        --version=VERSION        'latest', 'active', 'draft', relative to latest
+                                 or active (e.g. 'latest-1'), 'tag:NAME',
+                                 or the number of a specific version
 
   logging cloudfiles update --version=VERSION --name=NAME [<flags>]
     Update a Cloudfiles logging endpoint on a Fastly service version
 
-        --version=VERSION        'latest', 'active', or the number of a specific
-                                 version
-        --autoclone              If the selected service version is not
+        --version=VERSION        'latest', 'active', 'draft', relative to latest
+                                 or active (e.g. 'latest-1'), 'tag:NAME',
+                                 or the number of a specific version
+        --[no-]autoclone         If the selected service version is not
                                  editable, clone it and use the clone.
     -n, --name=NAME              The name of the Cloudfiles logging object
     -s, --service-id=SERVICE-ID  Service ID (falls back to FASTLY_SERVICE_ID,
@@ -1535,9 +2845,10 @@ COMMANDS
 
     -n, --name=NAME              The name of the Datadog logging object.
                                  Used as a primary key for API access
-        --version=VERSION        'latest', 'active', or the number of a specific
-                                 version
-        --autoclone              If the selected service version is not
+        --version=VERSION        'latest', 'active', 'draft', relative to latest
+                                 or active (e.g. 'latest-1'), 'tag:NAME',
+                                 or the number of a specific version
+        --[no-]autoclone         If the selected service version is not
                                  editable, clone it and use the clone.
         --auth-token=AUTH-TOKEN  The API key from your Datadog account
     -s, --service-id=SERVICE-ID  Service ID (falls back to FASTLY_SERVICE_ID,
@@ -1560,13 +2871,19 @@ COMMANDS
         --placement=PLACEMENT    Where in the generated VCL the logging call
                                  should be placed, overriding any format_version
                                  default. Can be none or waf_debug
+        --preset=PRESET          Apply the recommended log format of an
+                                 observability vendor (e.g. datadog, honeycomb,
+                                 newrelic, splunk) as listed by the logging
+                                 presets command. Other flags override the
+                                 preset
 
   logging datadog delete --version=VERSION --name=NAME [<flags>]
     Delete a Datadog logging endpoint on a Fastly service version
 
-        --version=VERSION        'latest', 'active', or the number of a specific
-                                 version
-        --autoclone              If the selected service version is not
+        --version=VERSION        'latest', 'active', 'draft', relative to latest
+                                 or active (e.g. 'latest-1'), 'tag:NAME',
+                                 or the number of a specific version
+        --[no-]autoclone         If the selected service version is not
                                  editable, clone it and use the clone.
     -n, --name=NAME              The name of the Datadog logging object
     -s, --service-id=SERVICE-ID  Service ID (falls back to FASTLY_SERVICE_ID,
@@ -1579,12 +2896,15 @@ COMMANDS
     service version
 
     -j, --json                   Render output as JSON
+        --format=text            Output format (text, json, env)
+        --output=OUTPUT          Write the output to a file instead of stdout
     -s, --service-id=SERVICE-ID  Service ID (falls back to FASTLY_SERVICE_ID,
                                  then fastly.toml)
         --service-name=SERVICE-NAME
                                  The name of the service
-        --version=VERSION        'latest', 'active', or the number of a specific
-                                 version
+        --version=VERSION        'latest', 'active', 'draft', relative to latest
+                                 or active (e.g. 'latest-1'), 'tag:NAME',
+                                 or the number of a specific version
     -n, --name=NAME              The name of the Datadog logging object
 
   logging datadog list --version=VERSION [<flags>]
@@ -1595,15 +2915,17 @@ COMMANDS
                                  then fastly.toml)
         --service-name=SERVICE-NAME
                                  The name of the service
-        --version=VERSION        'latest', 'active', or the number of a specific
-                                 version
+        --version=VERSION        'latest', 'active', 'draft', relative to latest
+                                 or active (e.g. 'latest-1'), 'tag:NAME',
+                                 or the number of a specific version
 
   logging datadog update --version=VERSION --name=NAME [<flags>]
     Update a Datadog logging endpoint on a Fastly service version
 
-        --version=VERSION        'latest', 'active', or the number of a specific
-                                 version
-        --autoclone              If the selected service version is not
+        --version=VERSION        'latest', 'active', 'draft', relative to latest
+                                 or active (e.g. 'latest-1'), 'tag:NAME',
+                                 or the number of a specific version
+        --[no-]autoclone         If the selected service version is not
                                  editable, clone it and use the clone.
     -n, --name=NAME              The name of the Datadog logging object
     -s, --service-id=SERVICE-ID  Service ID (falls back to FASTLY_SERVICE_ID,
@@ -1634,9 +2956,10 @@ COMMANDS
 
     -n, --name=NAME              The name of the DigitalOcean Spaces logging
                                  object. Used as a primary key for API access
-        --version=VERSION        'latest', 'active', or the number of a specific
-                                 version
-        --autoclone              If the selected service version is not
+        --version=VERSION        'latest', 'active', 'draft', relative to latest
+                                 or active (e.g. 'latest-1'), 'tag:NAME',
+                                 or the number of a specific version
+        --[no-]autoclone         If the selected service version is not
                                  editable, clone it and use the clone.
         --bucket=BUCKET          The name of the DigitalOcean Space
         --access-key=ACCESS-KEY  Your DigitalOcean Spaces account access key
@@ -1687,9 +3010,10 @@ COMMANDS
   logging digitalocean delete --version=VERSION --name=NAME [<flags>]
     Delete a DigitalOcean Spaces logging endpoint on a Fastly service version
 
-        --version=VERSION        'latest', 'active', or the number of a specific
-                                 version
-        --autoclone              If the selected service version is not
+        --version=VERSION        'latest', 'active', 'draft', relative to latest
+                                 or active (e.g. 'latest-1'), 'tag:NAME',
+                                 or the number of a specific version
+        --[no-]autoclone         If the selected service version is not
                                  editable, clone it and use the clone.
     -n, --name=NAME              The name of the DigitalOcean Spaces logging
                                  object
@@ -1703,12 +3027,15 @@ COMMANDS
     Fastly service version
 
     -j, --json                   Render output as JSON
+        --format=text            Output format (text, json, env)
+        --output=OUTPUT          Write the output to a file instead of stdout
     -s, --service-id=SERVICE-ID  Service ID (falls back to FASTLY_SERVICE_ID,
                                  then fastly.toml)
         --service-name=SERVICE-NAME
                                  The name of the service
-        --version=VERSION        'latest', 'active', or the number of a specific
-                                 version
+        --version=VERSION        'latest', 'active', 'draft', relative to latest
+                                 or active (e.g. 'latest-1'), 'tag:NAME',
+                                 or the number of a specific version
     -n, --name=NAME              The name of the DigitalOcean Spaces logging
                                  object
 
@@ -1720,15 +3047,17 @@ COMMANDS
                                  then fastly.toml)
         --service-name=SERVICE-NAME
                                  The name of the service
-        --version=VERSION        'latest', 'active', or the number of a specific
-                                 version
+        --version=VERSION        'latest', 'active', 'draft', relative to latest
+                                 or active (e.g. 'latest-1'), 'tag:NAME',
+                                 or the number of a specific version
 
   logging digitalocean update --version=VERSION --name=NAME [<flags>]
     Update a DigitalOcean Spaces logging endpoint on a Fastly service version
 
-        --version=VERSION        'latest', 'active', or the number of a specific
-                                 version
-        --autoclone              If the selected service version is not
+        --version=VERSION        'latest', 'active', 'draft', relative to latest
+                                 or active (e.g. 'latest-1'), 'tag:NAME',
+                                 or the number of a specific version
+        --[no-]autoclone         If the selected service version is not
                                  editable, clone it and use the clone.
     -n, --name=NAME              The name of the DigitalOcean Spaces logging
                                  object
@@ -1785,9 +3114,11 @@ COMMANDS
 
     -n, --name=NAME                The name of the Elasticsearch logging object.
                                    Used as a primary key for API access
-        --version=VERSION          'latest', 'active', or the number of a
-                                   specific version
-        --autoclone                If the selected service version is not
+        --version=VERSION          'latest', 'active', 'draft', relative
+                                   to latest or active (e.g. 'latest-1'),
+                                   'tag:NAME', or the number of a specific
+                                   version
+        --[no-]autoclone           If the selected service version is not
                                    editable, clone it and use the clone.
         --index=INDEX              The name of the Elasticsearch index to
                                    send documents (logs) to. The index must
@@ -1846,9 +3177,10 @@ COMMANDS
   logging elasticsearch delete --version=VERSION --name=NAME [<flags>]
     Delete an Elasticsearch logging endpoint on a Fastly service version
 
-        --version=VERSION        'latest', 'active', or the number of a specific
-                                 version
-        --autoclone              If the selected service version is not
+        --version=VERSION        'latest', 'active', 'draft', relative to latest
+                                 or active (e.g. 'latest-1'), 'tag:NAME',
+                                 or the number of a specific version
+        --[no-]autoclone         If the selected service version is not
                                  editable, clone it and use the clone.
     -n, --name=NAME              The name of the Elasticsearch logging object
     -s, --service-id=SERVICE-ID  Service ID (falls back to FASTLY_SERVICE_ID,
@@ -1861,12 +3193,15 @@ COMMANDS
     Fastly service version
 
     -j, --json                   Render output as JSON
+        --format=text            Output format (text, json, env)
+        --output=OUTPUT          Write the output to a file instead of stdout
     -s, --service-id=SERVICE-ID  Service ID (falls back to FASTLY_SERVICE_ID,
                                  then fastly.toml)
         --service-name=SERVICE-NAME
                                  The name of the service
-        --version=VERSION        'latest', 'active', or the number of a specific
-                                 version
+        --version=VERSION        'latest', 'active', 'draft', relative to latest
+                                 or active (e.g. 'latest-1'), 'tag:NAME',
+                                 or the number of a specific version
     -n, --name=NAME              The name of the Elasticsearch logging object
 
   logging elasticsearch list --version=VERSION [<flags>]
@@ -1877,15 +3212,18 @@ COMMANDS
                                  then fastly.toml)
         --service-name=SERVICE-NAME
                                  The name of the service
-        --version=VERSION        'latest', 'active', or the number of a specific
-                                 version
+        --version=VERSION        'latest', 'active', 'draft', relative to latest
+                                 or active (e.g. 'latest-1'), 'tag:NAME',
+                                 or the number of a specific version
 
   logging elasticsearch update --version=VERSION --name=NAME [<flags>]
     Update an Elasticsearch logging endpoint on a Fastly service version
 
-        --version=VERSION          'latest', 'active', or the number of a
-                                   specific version
-        --autoclone                If the selected service version is not
+        --version=VERSION          'latest', 'active', 'draft', relative
+                                   to latest or active (e.g. 'latest-1'),
+                                   'tag:NAME', or the number of a specific
+                                   version
+        --[no-]autoclone           If the selected service version is not
                                    editable, clone it and use the clone.
     -n, --name=NAME                The name of the Elasticsearch logging object
     -s, --service-id=SERVICE-ID    Service ID (falls back to FASTLY_SERVICE_ID,
@@ -1948,9 +3286,10 @@ COMMANDS
 
     -n, --name=NAME              The name of the FTP logging object. Used as a
                                  primary key for API access
-        --version=VERSION        'latest', 'active', or the number of a specific
-                                 version
-        --autoclone              If the selected service version is not
+        --version=VERSION        'latest', 'active', 'draft', relative to latest
+                                 or active (e.g. 'latest-1'), 'tag:NAME',
+                                 or the number of a specific version
+        --[no-]autoclone         If the selected service version is not
                                  editable, clone it and use the clone.
         --address=ADDRESS        An hostname or IPv4 address
         --user=USER              The username for the server (can be anonymous)
@@ -1996,9 +3335,10 @@ COMMANDS
   logging ftp delete --version=VERSION --name=NAME [<flags>]
     Delete an FTP logging endpoint on a Fastly service version
 
-        --version=VERSION        'latest', 'active', or the number of a specific
-                                 version
-        --autoclone              If the selected service version is not
+        --version=VERSION        'latest', 'active', 'draft', relative to latest
+                                 or active (e.g. 'latest-1'), 'tag:NAME',
+                                 or the number of a specific version
+        --[no-]autoclone         If the selected service version is not
                                  editable, clone it and use the clone.
     -n, --name=NAME              The name of the FTP logging object
     -s, --service-id=SERVICE-ID  Service ID (falls back to FASTLY_SERVICE_ID,
@@ -2011,12 +3351,15 @@ COMMANDS
     version
 
     -j, --json                   Render output as JSON
+        --format=text            Output format (text, json, env)
+        --output=OUTPUT          Write the output to a file instead of stdout
     -s, --service-id=SERVICE-ID  Service ID (falls back to FASTLY_SERVICE_ID,
                                  then fastly.toml)
         --service-name=SERVICE-NAME
                                  The name of the service
-        --version=VERSION        'latest', 'active', or the number of a specific
-                                 version
+        --version=VERSION        'latest', 'active', 'draft', relative to latest
+                                 or active (e.g. 'latest-1'), 'tag:NAME',
+                                 or the number of a specific version
     -n, --name=NAME              The name of the FTP logging object
 
   logging ftp list --version=VERSION [<flags>]
@@ -2027,15 +3370,17 @@ COMMANDS
                                  then fastly.toml)
         --service-name=SERVICE-NAME
                                  The name of the service
-        --version=VERSION        'latest', 'active', or the number of a specific
-                                 version
+        --version=VERSION        'latest', 'active', 'draft', relative to latest
+                                 or active (e.g. 'latest-1'), 'tag:NAME',
+                                 or the number of a specific version
 
   logging ftp update --version=VERSION --name=NAME [<flags>]
     Update an FTP logging endpoint on a Fastly service version
 
-        --version=VERSION        'latest', 'active', or the number of a specific
-                                 version
-        --autoclone              If the selected service version is not
+        --version=VERSION        'latest', 'active', 'draft', relative to latest
+                                 or active (e.g. 'latest-1'), 'tag:NAME',
+                                 or the number of a specific version
+        --[no-]autoclone         If the selected service version is not
                                  editable, clone it and use the clone.
     -n, --name=NAME              The name of the FTP logging object
     -s, --service-id=SERVICE-ID  Service ID (falls back to FASTLY_SERVICE_ID,
@@ -2087,14 +3432,15 @@ COMMANDS
                                  compression_codec and gzip_level in the same
                                  API request will result in an error.
 
-  logging gcs create --name=NAME --version=VERSION --user=USER --bucket=BUCKET --secret-key=SECRET-KEY [<flags>]
+  logging gcs create --name=NAME --version=VERSION --bucket=BUCKET [<flags>]
     Create a GCS logging endpoint on a Fastly service version
 
     -n, --name=NAME              The name of the GCS logging object. Used as a
                                  primary key for API access
-        --version=VERSION        'latest', 'active', or the number of a specific
-                                 version
-        --autoclone              If the selected service version is not
+        --version=VERSION        'latest', 'active', 'draft', relative to latest
+                                 or active (e.g. 'latest-1'), 'tag:NAME',
+                                 or the number of a specific version
+        --[no-]autoclone         If the selected service version is not
                                  editable, clone it and use the clone.
         --user=USER              Your GCS service account email address.
                                  The client_email field in your service account
@@ -2103,6 +3449,11 @@ COMMANDS
         --secret-key=SECRET-KEY  Your GCS account secret key. The private_key
                                  field in your service account authentication
                                  JSON
+        --credentials-file=CREDENTIALS-FILE
+                                 Path to the JSON key file of a Google
+                                 Cloud service account. Its client_email and
+                                 private_key fields are used unless --user and
+                                 --secret-key are set
     -s, --service-id=SERVICE-ID  Service ID (falls back to FASTLY_SERVICE_ID,
                                  then fastly.toml)
         --service-name=SERVICE-NAME
@@ -2148,9 +3499,10 @@ COMMANDS
   logging gcs delete --version=VERSION --name=NAME [<flags>]
     Delete a GCS logging endpoint on a Fastly service version
 
-        --version=VERSION        'latest', 'active', or the number of a specific
-                                 version
-        --autoclone              If the selected service version is not
+        --version=VERSION        'latest', 'active', 'draft', relative to latest
+                                 or active (e.g. 'latest-1'), 'tag:NAME',
+                                 or the number of a specific version
+        --[no-]autoclone         If the selected service version is not
                                  editable, clone it and use the clone.
     -n, --name=NAME              The name of the GCS logging object
     -s, --service-id=SERVICE-ID  Service ID (falls back to FASTLY_SERVICE_ID,
@@ -2163,12 +3515,15 @@ COMMANDS
     version
 
     -j, --json                   Render output as JSON
+        --format=text            Output format (text, json, env)
+        --output=OUTPUT          Write the output to a file instead of stdout
     -s, --service-id=SERVICE-ID  Service ID (falls back to FASTLY_SERVICE_ID,
                                  then fastly.toml)
         --service-name=SERVICE-NAME
                                  The name of the service
-        --version=VERSION        'latest', 'active', or the number of a specific
-                                 version
+        --version=VERSION        'latest', 'active', 'draft', relative to latest
+                                 or active (e.g. 'latest-1'), 'tag:NAME',
+                                 or the number of a specific version
     -n, --name=NAME              The name of the GCS logging object
 
   logging gcs list --version=VERSION [<flags>]
@@ -2179,15 +3534,17 @@ COMMANDS
                                  then fastly.toml)
         --service-name=SERVICE-NAME
                                  The name of the service
-        --version=VERSION        'latest', 'active', or the number of a specific
-                                 version
+        --version=VERSION        'latest', 'active', 'draft', relative to latest
+                                 or active (e.g. 'latest-1'), 'tag:NAME',
+                                 or the number of a specific version
 
   logging gcs update --version=VERSION --name=NAME [<flags>]
     Update a GCS logging endpoint on a Fastly service version
 
-        --version=VERSION        'latest', 'active', or the number of a specific
-                                 version
-        --autoclone              If the selected service version is not
+        --version=VERSION        'latest', 'active', 'draft', relative to latest
+                                 or active (e.g. 'latest-1'), 'tag:NAME',
+                                 or the number of a specific version
+        --[no-]autoclone         If the selected service version is not
                                  editable, clone it and use the clone.
     -n, --name=NAME              The name of the GCS logging object
     -s, --service-id=SERVICE-ID  Service ID (falls back to FASTLY_SERVICE_ID,
@@ -2202,6 +3559,11 @@ COMMANDS
         --secret-key=SECRET-KEY  Your GCS account secret key. The private_key
                                  field in your service account authentication
                                  JSON
+        --credentials-file=CREDENTIALS-FILE
+                                 Path to the JSON key file of a Google
+                                 Cloud service account. Its client_email and
+                                 private_key fields are used unless --user and
+                                 --secret-key are set
         --path=PATH              The path to upload logs to (default '/')
         --period=PERIOD          How frequently log files are finalized so they
                                  can be available for reading (in seconds,
@@ -2240,14 +3602,15 @@ COMMANDS
                                  compression_codec and gzip_level in the same
                                  API request will result in an error.
 
-  logging googlepubsub create --name=NAME --version=VERSION --user=USER --secret-key=SECRET-KEY --topic=TOPIC --project-id=PROJECT-ID [<flags>]
+  logging googlepubsub create --name=NAME --version=VERSION --topic=TOPIC [<flags>]
     Create a Google Cloud Pub/Sub logging endpoint on a Fastly service version
 
     -n, --name=NAME              The name of the Google Cloud Pub/Sub logging
                                  object. Used as a primary key for API access
-        --version=VERSION        'latest', 'active', or the number of a specific
-                                 version
-        --autoclone              If the selected service version is not
+        --version=VERSION        'latest', 'active', 'draft', relative to latest
+                                 or active (e.g. 'latest-1'), 'tag:NAME',
+                                 or the number of a specific version
+        --[no-]autoclone         If the selected service version is not
                                  editable, clone it and use the clone.
         --user=USER              Your Google Cloud Platform service account
                                  email address. The client_email field in your
@@ -2255,6 +3618,11 @@ COMMANDS
         --secret-key=SECRET-KEY  Your Google Cloud Platform account secret key.
                                  The private_key field in your service account
                                  authentication JSON
+        --credentials-file=CREDENTIALS-FILE
+                                 Path to the JSON key file of a Google Cloud
+                                 service account. Its client_email, private_key
+                                 and project_id fields are used unless --user,
+                                 --secret-key and --project-id are set
         --topic=TOPIC            The Google Cloud Pub/Sub topic to which logs
                                  will be published
         --project-id=PROJECT-ID  The ID of your Google Cloud Platform project
@@ -2279,9 +3647,10 @@ COMMANDS
   logging googlepubsub delete --version=VERSION --name=NAME [<flags>]
     Delete a Google Cloud Pub/Sub logging endpoint on a Fastly service version
 
-        --version=VERSION        'latest', 'active', or the number of a specific
-                                 version
-        --autoclone              If the selected service version is not
+        --version=VERSION        'latest', 'active', 'draft', relative to latest
+                                 or active (e.g. 'latest-1'), 'tag:NAME',
+                                 or the number of a specific version
+        --[no-]autoclone         If the selected service version is not
                                  editable, clone it and use the clone.
     -n, --name=NAME              The name of the Google Cloud Pub/Sub logging
                                  object
@@ -2295,12 +3664,15 @@ COMMANDS
     Fastly service version
 
     -j, --json                   Render output as JSON
+        --format=text            Output format (text, json, env)
+        --output=OUTPUT          Write the output to a file instead of stdout
     -s, --service-id=SERVICE-ID  Service ID (falls back to FASTLY_SERVICE_ID,
                                  then fastly.toml)
         --service-name=SERVICE-NAME
                                  The name of the service
-        --version=VERSION        'latest', 'active', or the number of a specific
-                                 version
+        --version=VERSION        'latest', 'active', 'draft', relative to latest
+                                 or active (e.g. 'latest-1'), 'tag:NAME',
+                                 or the number of a specific version
     -n, --name=NAME              The name of the Google Cloud Pub/Sub logging
                                  object
 
@@ -2312,15 +3684,17 @@ COMMANDS
                                  then fastly.toml)
         --service-name=SERVICE-NAME
                                  The name of the service
-        --version=VERSION        'latest', 'active', or the number of a specific
-                                 version
+        --version=VERSION        'latest', 'active', 'draft', relative to latest
+                                 or active (e.g. 'latest-1'), 'tag:NAME',
+                                 or the number of a specific version
 
   logging googlepubsub update --version=VERSION --name=NAME [<flags>]
     Update a Google Cloud Pub/Sub logging endpoint on a Fastly service version
 
-        --version=VERSION        'latest', 'active', or the number of a specific
-                                 version
-        --autoclone              If the selected service version is not
+        --version=VERSION        'latest', 'active', 'draft', relative to latest
+                                 or active (e.g. 'latest-1'), 'tag:NAME',
+                                 or the number of a specific version
+        --[no-]autoclone         If the selected service version is not
                                  editable, clone it and use the clone.
     -n, --name=NAME              The name of the Google Cloud Pub/Sub logging
                                  object
@@ -2336,6 +3710,11 @@ COMMANDS
         --secret-key=SECRET-KEY  Your Google Cloud Platform account secret key.
                                  The private_key field in your service account
                                  authentication JSON
+        --credentials-file=CREDENTIALS-FILE
+                                 Path to the JSON key file of a Google
+                                 Cloud service account. Its client_email and
+                                 private_key fields are used unless --user and
+                                 --secret-key are set
         --topic=TOPIC            The Google Cloud Pub/Sub topic to which logs
                                  will be published
         --project-id=PROJECT-ID  The ID of your Google Cloud Platform project
@@ -2358,9 +3737,10 @@ COMMANDS
 
     -n, --name=NAME              The name of the Heroku logging object. Used as
                                  a primary key for API access
-        --version=VERSION        'latest', 'active', or the number of a specific
-                                 version
-        --autoclone              If the selected service version is not
+        --version=VERSION        'latest', 'active', 'draft', relative to latest
+                                 or active (e.g. 'latest-1'), 'tag:NAME',
+                                 or the number of a specific version
+        --[no-]autoclone         If the selected service version is not
                                  editable, clone it and use the clone.
         --url=URL                The url to stream logs to
         --auth-token=AUTH-TOKEN  The token to use for authentication
@@ -2385,9 +3765,10 @@ COMMANDS
   logging heroku delete --version=VERSION --name=NAME [<flags>]
     Delete a Heroku logging endpoint on a Fastly service version
 
-        --version=VERSION        'latest', 'active', or the number of a specific
-                                 version
-        --autoclone              If the selected service version is not
+        --version=VERSION        'latest', 'active', 'draft', relative to latest
+                                 or active (e.g. 'latest-1'), 'tag:NAME',
+                                 or the number of a specific version
+        --[no-]autoclone         If the selected service version is not
                                  editable, clone it and use the clone.
     -n, --name=NAME              The name of the Heroku logging object
     -s, --service-id=SERVICE-ID  Service ID (falls back to FASTLY_SERVICE_ID,
@@ -2400,12 +3781,15 @@ COMMANDS
     service version
 
     -j, --json                   Render output as JSON
+        --format=text            Output format (text, json, env)
+        --output=OUTPUT          Write the output to a file instead of stdout
     -s, --service-id=SERVICE-ID  Service ID (falls back to FASTLY_SERVICE_ID,
                                  then fastly.toml)
         --service-name=SERVICE-NAME
                                  The name of the service
-        --version=VERSION        'latest', 'active', or the number of a specific
-                                 version
+        --version=VERSION        'latest', 'active', 'draft', relative to latest
+                                 or active (e.g. 'latest-1'), 'tag:NAME',
+                                 or the number of a specific version
     -n, --name=NAME              The name of the Heroku logging object
 
   logging heroku list --version=VERSION [<flags>]
@@ -2416,15 +3800,17 @@ COMMANDS
                                  then fastly.toml)
         --service-name=SERVICE-NAME
                                  The name of the service
-        --version=VERSION        'latest', 'active', or the number of a specific
-                                 version
+        --version=VERSION        'latest', 'active', 'draft', relative to latest
+                                 or active (e.g. 'latest-1'), 'tag:NAME',
+                                 or the number of a specific version
 
   logging heroku update --version=VERSION --name=NAME [<flags>]
     Update a Heroku logging endpoint on a Fastly service version
 
-        --version=VERSION        'latest', 'active', or the number of a specific
-                                 version
-        --autoclone              If the selected service version is not
+        --version=VERSION        'latest', 'active', 'draft', relative to latest
+                                 or active (e.g. 'latest-1'), 'tag:NAME',
+                                 or the number of a specific version
+        --[no-]autoclone         If the selected service version is not
                                  editable, clone it and use the clone.
     -n, --name=NAME              The name of the Heroku logging object
     -s, --service-id=SERVICE-ID  Service ID (falls back to FASTLY_SERVICE_ID,
@@ -2453,9 +3839,10 @@ COMMANDS
 
     -n, --name=NAME              The name of the Honeycomb logging object.
                                  Used as a primary key for API access
-        --version=VERSION        'latest', 'active', or the number of a specific
-                                 version
-        --autoclone              If the selected service version is not
+        --version=VERSION        'latest', 'active', 'draft', relative to latest
+                                 or active (e.g. 'latest-1'), 'tag:NAME',
+                                 or the number of a specific version
+        --[no-]autoclone         If the selected service version is not
                                  editable, clone it and use the clone.
         --dataset=DATASET        The Honeycomb Dataset you want to log to
         --auth-token=AUTH-TOKEN  The Write Key from the Account page of your
@@ -2477,13 +3864,19 @@ COMMANDS
         --placement=PLACEMENT    Where in the generated VCL the logging call
                                  should be placed, overriding any format_version
                                  default. Can be none or waf_debug
+        --preset=PRESET          Apply the recommended log format of an
+                                 observability vendor (e.g. datadog, honeycomb,
+                                 newrelic, splunk) as listed by the logging
+                                 presets command. Other flags override the
+                                 preset
 
   logging honeycomb delete --version=VERSION --name=NAME [<flags>]
     Delete a Honeycomb logging endpoint on a Fastly service version
 
-        --version=VERSION        'latest', 'active', or the number of a specific
-                                 version
-        --autoclone              If the selected service version is not
+        --version=VERSION        'latest', 'active', 'draft', relative to latest
+                                 or active (e.g. 'latest-1'), 'tag:NAME',
+                                 or the number of a specific version
+        --[no-]autoclone         If the selected service version is not
                                  editable, clone it and use the clone.
     -n, --name=NAME              The name of the Honeycomb logging object
     -s, --service-id=SERVICE-ID  Service ID (falls back to FASTLY_SERVICE_ID,
@@ -2496,12 +3889,15 @@ COMMANDS
     service version
 
     -j, --json                   Render output as JSON
+        --format=text            Output format (text, json, env)
+        --output=OUTPUT          Write the output to a file instead of stdout
     -s, --service-id=SERVICE-ID  Service ID (falls back to FASTLY_SERVICE_ID,
                                  then fastly.toml)
         --service-name=SERVICE-NAME
                                  The name of the service
-        --version=VERSION        'latest', 'active', or the number of a specific
-                                 version
+        --version=VERSION        'latest', 'active', 'draft', relative to latest
+                                 or active (e.g. 'latest-1'), 'tag:NAME',
+                                 or the number of a specific version
     -n, --name=NAME              The name of the Honeycomb logging object
 
   logging honeycomb list --version=VERSION [<flags>]
@@ -2512,15 +3908,17 @@ COMMANDS
                                  then fastly.toml)
         --service-name=SERVICE-NAME
                                  The name of the service
-        --version=VERSION        'latest', 'active', or the number of a specific
-                                 version
+        --version=VERSION        'latest', 'active', 'draft', relative to latest
+                                 or active (e.g. 'latest-1'), 'tag:NAME',
+                                 or the number of a specific version
 
   logging honeycomb update --version=VERSION --name=NAME [<flags>]
     Update a Honeycomb logging endpoint on a Fastly service version
 
-        --version=VERSION        'latest', 'active', or the number of a specific
-                                 version
-        --autoclone              If the selected service version is not
+        --version=VERSION        'latest', 'active', 'draft', relative to latest
+                                 or active (e.g. 'latest-1'), 'tag:NAME',
+                                 or the number of a specific version
+        --[no-]autoclone         If the selected service version is not
                                  editable, clone it and use the clone.
     -n, --name=NAME              The name of the Honeycomb logging object
     -s, --service-id=SERVICE-ID  Service ID (falls back to FASTLY_SERVICE_ID,
@@ -2550,9 +3948,11 @@ COMMANDS
 
     -n, --name=NAME                The name of the HTTPS logging object.
                                    Used as a primary key for API access
-        --version=VERSION          'latest', 'active', or the number of a
-                                   specific version
-        --autoclone                If the selected service version is not
+        --version=VERSION          'latest', 'active', 'draft', relative
+                                   to latest or active (e.g. 'latest-1'),
+                                   'tag:NAME', or the number of a specific
+                                   version
+        --[no-]autoclone           If the selected service version is not
                                    editable, clone it and use the clone.
         --url=URL                  URL that log data will be sent to. Must use
                                    the https protocol
@@ -2600,6 +4000,14 @@ COMMANDS
                                    call should be placed, overriding any
                                    format_version default. Can be none or
                                    waf_debug
+        --preset=PRESET            Apply the recommended log format of an
+                                   observability vendor (e.g. datadog,
+                                   honeycomb, newrelic, splunk) as listed by the
+                                   logging presets command. Other flags override
+                                   the preset
+        --preview                  Print an example of the requests the endpoint
+                                   would send, with the format rendered using a
+                                   sample request, rather than creating it
         --response-condition=RESPONSE-CONDITION
                                    The name of an existing condition in the
                                    configured endpoint, or leave blank to always
@@ -2614,9 +4022,10 @@ COMMANDS
   logging https delete --version=VERSION --name=NAME [<flags>]
     Delete an HTTPS logging endpoint on a Fastly service version
 
-        --version=VERSION        'latest', 'active', or the number of a specific
-                                 version
-        --autoclone              If the selected service version is not
+        --version=VERSION        'latest', 'active', 'draft', relative to latest
+                                 or active (e.g. 'latest-1'), 'tag:NAME',
+                                 or the number of a specific version
+        --[no-]autoclone         If the selected service version is not
                                  editable, clone it and use the clone.
     -n, --name=NAME              The name of the HTTPS logging object
     -s, --service-id=SERVICE-ID  Service ID (falls back to FASTLY_SERVICE_ID,
@@ -2629,12 +4038,15 @@ COMMANDS
     service version
 
     -j, --json                   Render output as JSON
+        --format=text            Output format (text, json, env)
+        --output=OUTPUT          Write the output to a file instead of stdout
     -s, --service-id=SERVICE-ID  Service ID (falls back to FASTLY_SERVICE_ID,
                                  then fastly.toml)
         --service-name=SERVICE-NAME
                                  The name of the service
-        --version=VERSION        'latest', 'active', or the number of a specific
-                                 version
+        --version=VERSION        'latest', 'active', 'draft', relative to latest
+                                 or active (e.g. 'latest-1'), 'tag:NAME',
+                                 or the number of a specific version
     -n, --name=NAME              The name of the HTTPS logging object
 
   logging https list --version=VERSION [<flags>]
@@ -2645,15 +4057,18 @@ COMMANDS
                                  then fastly.toml)
         --service-name=SERVICE-NAME
                                  The name of the service
-        --version=VERSION        'latest', 'active', or the number of a specific
-                                 version
+        --version=VERSION        'latest', 'active', 'draft', relative to latest
+                                 or active (e.g. 'latest-1'), 'tag:NAME',
+                                 or the number of a specific version
 
   logging https update --version=VERSION --name=NAME [<flags>]
     Update an HTTPS logging endpoint on a Fastly service version
 
-        --version=VERSION          'latest', 'active', or the number of a
-                                   specific version
-        --autoclone                If the selected service version is not
+        --version=VERSION          'latest', 'active', 'draft', relative
+                                   to latest or active (e.g. 'latest-1'),
+                                   'tag:NAME', or the number of a specific
+                                   version
+        --[no-]autoclone           If the selected service version is not
                                    editable, clone it and use the clone.
     -n, --name=NAME                The name of the HTTPS logging object
     -s, --service-id=SERVICE-ID    Service ID (falls back to FASTLY_SERVICE_ID,
@@ -2719,9 +4134,11 @@ COMMANDS
 
     -n, --name=NAME                The name of the Kafka logging object.
                                    Used as a primary key for API access
-        --version=VERSION          'latest', 'active', or the number of a
-                                   specific version
-        --autoclone                If the selected service version is not
+        --version=VERSION          'latest', 'active', 'draft', relative
+                                   to latest or active (e.g. 'latest-1'),
+                                   'tag:NAME', or the number of a specific
+                                   version
+        --[no-]autoclone           If the selected service version is not
                                    editable, clone it and use the clone.
         --topic=TOPIC              The Kafka topic to send logs to
         --brokers=BROKERS          A comma-separated list of IP addresses or
@@ -2775,18 +4192,43 @@ COMMANDS
                                    --auth-method, --username, and --password to
                                    be specified
         --auth-method=AUTH-METHOD  SASL authentication method. Valid values are:
-                                   plain, scram-sha-256, scram-sha-512
+                                   plain, scram-sha-256, scram-sha-512,
+                                   oauthbearer
         --username=USERNAME        SASL authentication username. Required if
-                                   --auth-method is specified
-        --password=PASSWORD        SASL authentication password. Required if
-                                   --auth-method is specified
+                                   --auth-method is specified, unless it's
+                                   oauthbearer
+        --password=PASSWORD        SASL authentication password, or the
+                                   OAuth bearer token when --auth-method is
+                                   oauthbearer. Required if --auth-method is
+                                   specified
+        --schema-registry-url=SCHEMA-REGISTRY-URL
+                                   The URL of a Confluent schema registry to
+                                   register the schema of the logs with before
+                                   creating the endpoint. Requires --schema-file
+        --schema-registry-username=SCHEMA-REGISTRY-USERNAME
+                                   The username (or API key) used to
+                                   authenticate with the schema registry
+        --schema-registry-password=SCHEMA-REGISTRY-PASSWORD
+                                   The password (or API secret) used to
+                                   authenticate with the schema registry
+        --schema-file=SCHEMA-FILE  Path to the schema of the logs produced by
+                                   --format. Requires --schema-registry-url
+        --schema-type=AVRO         The type of the schema. Valid values are:
+                                   AVRO (default), JSON, PROTOBUF
+        --schema-subject=SCHEMA-SUBJECT
+                                   The subject the schema is registered under
+                                   (default: <topic>-value)
+        --schema-validate-only     Only validate the schema is compatible with
+                                   the latest version registered under the
+                                   subject, rather than registering it
 
   logging kafka delete --version=VERSION --name=NAME [<flags>]
     Delete a Kafka logging endpoint on a Fastly service version
 
-        --version=VERSION        'latest', 'active', or the number of a specific
-                                 version
-        --autoclone              If the selected service version is not
+        --version=VERSION        'latest', 'active', 'draft', relative to latest
+                                 or active (e.g. 'latest-1'), 'tag:NAME',
+                                 or the number of a specific version
+        --[no-]autoclone         If the selected service version is not
                                  editable, clone it and use the clone.
     -n, --name=NAME              The name of the Kafka logging object
     -s, --service-id=SERVICE-ID  Service ID (falls back to FASTLY_SERVICE_ID,
@@ -2799,12 +4241,15 @@ COMMANDS
     version
 
     -j, --json                   Render output as JSON
+        --format=text            Output format (text, json, env)
+        --output=OUTPUT          Write the output to a file instead of stdout
     -s, --service-id=SERVICE-ID  Service ID (falls back to FASTLY_SERVICE_ID,
                                  then fastly.toml)
         --service-name=SERVICE-NAME
                                  The name of the service
-        --version=VERSION        'latest', 'active', or the number of a specific
-                                 version
+        --version=VERSION        'latest', 'active', 'draft', relative to latest
+                                 or active (e.g. 'latest-1'), 'tag:NAME',
+                                 or the number of a specific version
     -n, --name=NAME              The name of the Kafka logging object
 
   logging kafka list --version=VERSION [<flags>]
@@ -2815,15 +4260,18 @@ COMMANDS
                                  then fastly.toml)
         --service-name=SERVICE-NAME
                                  The name of the service
-        --version=VERSION        'latest', 'active', or the number of a specific
-                                 version
+        --version=VERSION        'latest', 'active', 'draft', relative to latest
+                                 or active (e.g. 'latest-1'), 'tag:NAME',
+                                 or the number of a specific version
 
   logging kafka update --version=VERSION --name=NAME [<flags>]
     Update a Kafka logging endpoint on a Fastly service version
 
-        --version=VERSION          'latest', 'active', or the number of a
-                                   specific version
-        --autoclone                If the selected service version is not
+        --version=VERSION          'latest', 'active', 'draft', relative
+                                   to latest or active (e.g. 'latest-1'),
+                                   'tag:NAME', or the number of a specific
+                                   version
+        --[no-]autoclone           If the selected service version is not
                                    editable, clone it and use the clone.
     -n, --name=NAME                The name of the Kafka logging object
     -s, --service-id=SERVICE-ID    Service ID (falls back to FASTLY_SERVICE_ID,
@@ -2879,19 +4327,25 @@ COMMANDS
                                    --auth-method, --username, and --password to
                                    be specified
         --auth-method=AUTH-METHOD  SASL authentication method. Valid values are:
-                                   plain, scram-sha-256, scram-sha-512
+                                   plain, scram-sha-256, scram-sha-512,
+                                   oauthbearer
         --username=USERNAME        SASL authentication username. Required if
-                                   --auth-method is specified
-        --password=PASSWORD        SASL authentication password. Required if
-                                   --auth-method is specified
+                                   --auth-method is specified, unless it's
+                                   oauthbearer
+        --password=PASSWORD        SASL authentication password, or the
+                                   OAuth bearer token when --auth-method is
+                                   oauthbearer. Required if --auth-method is
+                                   specified
 
   logging kinesis create --name=NAME --version=VERSION --stream-name=STREAM-NAME --region=REGION [<flags>]
     Create an Amazon Kinesis logging endpoint on a Fastly service version
 
     -n, --name=NAME                The name of the Kinesis logging object.
                                    Used as a primary key for API access
-        --version=VERSION          'latest', 'active', or the number of a
-                                   specific version
+        --version=VERSION          'latest', 'active', 'draft', relative
+                                   to latest or active (e.g. 'latest-1'),
+                                   'tag:NAME', or the number of a specific
+                                   version
         --stream-name=STREAM-NAME  The Amazon Kinesis stream to send logs to
         --region=REGION            The AWS region where the Kinesis stream
                                    exists
@@ -2900,7 +4354,7 @@ COMMANDS
         --secret-key=SECRET-KEY    The secret key associated with the target
                                    Amazon Kinesis stream
         --iam-role=IAM-ROLE        The IAM role ARN for logging
-        --autoclone                If the selected service version is not
+        --[no-]autoclone           If the selected service version is not
                                    editable, clone it and use the clone.
     -s, --service-id=SERVICE-ID    Service ID (falls back to FASTLY_SERVICE_ID,
                                    then fastly.toml)
@@ -2923,9 +4377,10 @@ COMMANDS
   logging kinesis delete --version=VERSION --name=NAME [<flags>]
     Delete a Kinesis logging endpoint on a Fastly service version
 
-        --version=VERSION        'latest', 'active', or the number of a specific
-                                 version
-        --autoclone              If the selected service version is not
+        --version=VERSION        'latest', 'active', 'draft', relative to latest
+                                 or active (e.g. 'latest-1'), 'tag:NAME',
+                                 or the number of a specific version
+        --[no-]autoclone         If the selected service version is not
                                  editable, clone it and use the clone.
     -n, --name=NAME              The name of the Kinesis logging object
     -s, --service-id=SERVICE-ID  Service ID (falls back to FASTLY_SERVICE_ID,
@@ -2938,12 +4393,15 @@ COMMANDS
     service version
 
     -j, --json                   Render output as JSON
+        --format=text            Output format (text, json, env)
+        --output=OUTPUT          Write the output to a file instead of stdout
     -s, --service-id=SERVICE-ID  Service ID (falls back to FASTLY_SERVICE_ID,
                                  then fastly.toml)
         --service-name=SERVICE-NAME
                                  The name of the service
-        --version=VERSION        'latest', 'active', or the number of a specific
-                                 version
+        --version=VERSION        'latest', 'active', 'draft', relative to latest
+                                 or active (e.g. 'latest-1'), 'tag:NAME',
+                                 or the number of a specific version
     -n, --name=NAME              The name of the Kinesis logging object
 
   logging kinesis list --version=VERSION [<flags>]
@@ -2954,15 +4412,18 @@ COMMANDS
                                  then fastly.toml)
         --service-name=SERVICE-NAME
                                  The name of the service
-        --version=VERSION        'latest', 'active', or the number of a specific
-                                 version
+        --version=VERSION        'latest', 'active', 'draft', relative to latest
+                                 or active (e.g. 'latest-1'), 'tag:NAME',
+                                 or the number of a specific version
 
   logging kinesis update --version=VERSION --name=NAME [<flags>]
     Update a Kinesis logging endpoint on a Fastly service version
 
-        --version=VERSION          'latest', 'active', or the number of a
-                                   specific version
-        --autoclone                If the selected service version is not
+        --version=VERSION          'latest', 'active', 'draft', relative
+                                   to latest or active (e.g. 'latest-1'),
+                                   'tag:NAME', or the number of a specific
+                                   version
+        --[no-]autoclone           If the selected service version is not
                                    editable, clone it and use the clone.
     -n, --name=NAME                The name of the Kinesis logging object
     -s, --service-id=SERVICE-ID    Service ID (falls back to FASTLY_SERVICE_ID,
@@ -3022,17 +4483,19 @@ COMMANDS
                                  default. Can be none or waf_debug. This field
                                  is not required and has no default value
         --region=REGION          The region to which to stream logs
-        --version=VERSION        'latest', 'active', or the number of a specific
-                                 version
-        --autoclone              If the selected service version is not
+        --version=VERSION        'latest', 'active', 'draft', relative to latest
+                                 or active (e.g. 'latest-1'), 'tag:NAME',
+                                 or the number of a specific version
+        --[no-]autoclone         If the selected service version is not
                                  editable, clone it and use the clone.
 
   logging logentries delete --version=VERSION --name=NAME [<flags>]
     Delete a Logentries logging endpoint on a Fastly service version
 
-        --version=VERSION        'latest', 'active', or the number of a specific
-                                 version
-        --autoclone              If the selected service version is not
+        --version=VERSION        'latest', 'active', 'draft', relative to latest
+                                 or active (e.g. 'latest-1'), 'tag:NAME',
+                                 or the number of a specific version
+        --[no-]autoclone         If the selected service version is not
                                  editable, clone it and use the clone.
     -n, --name=NAME              The name of the Logentries logging object
     -s, --service-id=SERVICE-ID  Service ID (falls back to FASTLY_SERVICE_ID,
@@ -3045,12 +4508,15 @@ COMMANDS
     service version
 
     -j, --json                   Render output as JSON
+        --format=text            Output format (text, json, env)
+        --output=OUTPUT          Write the output to a file instead of stdout
     -s, --service-id=SERVICE-ID  Service ID (falls back to FASTLY_SERVICE_ID,
                                  then fastly.toml)
         --service-name=SERVICE-NAME
                                  The name of the service
-        --version=VERSION        'latest', 'active', or the number of a specific
-                                 version
+        --version=VERSION        'latest', 'active', 'draft', relative to latest
+                                 or active (e.g. 'latest-1'), 'tag:NAME',
+                                 or the number of a specific version
     -n, --name=NAME              The name of the Logentries logging object
 
   logging logentries list --version=VERSION [<flags>]
@@ -3061,15 +4527,17 @@ COMMANDS
                                  then fastly.toml)
         --service-name=SERVICE-NAME
                                  The name of the service
-        --version=VERSION        'latest', 'active', or the number of a specific
-                                 version
+        --version=VERSION        'latest', 'active', 'draft', relative to latest
+                                 or active (e.g. 'latest-1'), 'tag:NAME',
+                                 or the number of a specific version
 
   logging logentries update --version=VERSION --name=NAME [<flags>]
     Update a Logentries logging endpoint on a Fastly service version
 
-        --version=VERSION        'latest', 'active', or the number of a specific
-                                 version
-        --autoclone              If the selected service version is not
+        --version=VERSION        'latest', 'active', 'draft', relative to latest
+                                 or active (e.g. 'latest-1'), 'tag:NAME',
+                                 or the number of a specific version
+        --[no-]autoclone         If the selected service version is not
                                  editable, clone it and use the clone.
     -n, --name=NAME              The name of the Logentries logging object
     -s, --service-id=SERVICE-ID  Service ID (falls back to FASTLY_SERVICE_ID,
@@ -3106,9 +4574,10 @@ COMMANDS
 
     -n, --name=NAME              The name of the Loggly logging object. Used as
                                  a primary key for API access
-        --version=VERSION        'latest', 'active', or the number of a specific
-                                 version
-        --autoclone              If the selected service version is not
+        --version=VERSION        'latest', 'active', 'draft', relative to latest
+                                 or active (e.g. 'latest-1'), 'tag:NAME',
+                                 or the number of a specific version
+        --[no-]autoclone         If the selected service version is not
                                  editable, clone it and use the clone.
         --auth-token=AUTH-TOKEN  The token to use for authentication
                                  (https://www.loggly.com/docs/customer-token-authentication-token/)
@@ -3132,9 +4601,10 @@ COMMANDS
   logging loggly delete --version=VERSION --name=NAME [<flags>]
     Delete a Loggly logging endpoint on a Fastly service version
 
-        --version=VERSION        'latest', 'active', or the number of a specific
-                                 version
-        --autoclone              If the selected service version is not
+        --version=VERSION        'latest', 'active', 'draft', relative to latest
+                                 or active (e.g. 'latest-1'), 'tag:NAME',
+                                 or the number of a specific version
+        --[no-]autoclone         If the selected service version is not
                                  editable, clone it and use the clone.
     -n, --name=NAME              The name of the Loggly logging object
     -s, --service-id=SERVICE-ID  Service ID (falls back to FASTLY_SERVICE_ID,
@@ -3147,12 +4617,15 @@ COMMANDS
     service version
 
     -j, --json                   Render output as JSON
+        --format=text            Output format (text, json, env)
+        --output=OUTPUT          Write the output to a file instead of stdout
     -s, --service-id=SERVICE-ID  Service ID (falls back to FASTLY_SERVICE_ID,
                                  then fastly.toml)
         --service-name=SERVICE-NAME
                                  The name of the service
-        --version=VERSION        'latest', 'active', or the number of a specific
-                                 version
+        --version=VERSION        'latest', 'active', 'draft', relative to latest
+                                 or active (e.g. 'latest-1'), 'tag:NAME',
+                                 or the number of a specific version
     -n, --name=NAME              The name of the Loggly logging object
 
   logging loggly list --version=VERSION [<flags>]
@@ -3163,15 +4636,17 @@ COMMANDS
                                  then fastly.toml)
         --service-name=SERVICE-NAME
                                  The name of the service
-        --version=VERSION        'latest', 'active', or the number of a specific
-                                 version
+        --version=VERSION        'latest', 'active', 'draft', relative to latest
+                                 or active (e.g. 'latest-1'), 'tag:NAME',
+                                 or the number of a specific version
 
   logging loggly update --version=VERSION --name=NAME [<flags>]
     Update a Loggly logging endpoint on a Fastly service version
 
-        --version=VERSION        'latest', 'active', or the number of a specific
-                                 version
-        --autoclone              If the selected service version is not
+        --version=VERSION        'latest', 'active', 'draft', relative to latest
+                                 or active (e.g. 'latest-1'), 'tag:NAME',
+                                 or the number of a specific version
+        --[no-]autoclone         If the selected service version is not
                                  editable, clone it and use the clone.
     -n, --name=NAME              The name of the Loggly logging object
     -s, --service-id=SERVICE-ID  Service ID (falls back to FASTLY_SERVICE_ID,
@@ -3199,9 +4674,10 @@ COMMANDS
 
     -n, --name=NAME              The name of the Logshuttle logging object.
                                  Used as a primary key for API access
-        --version=VERSION        'latest', 'active', or the number of a specific
-                                 version
-        --autoclone              If the selected service version is not
+        --version=VERSION        'latest', 'active', 'draft', relative to latest
+                                 or active (e.g. 'latest-1'), 'tag:NAME',
+                                 or the number of a specific version
+        --[no-]autoclone         If the selected service version is not
                                  editable, clone it and use the clone.
         --url=URL                Your Log Shuttle endpoint url
         --auth-token=AUTH-TOKEN  The data authentication token associated with
@@ -3226,9 +4702,10 @@ COMMANDS
   logging logshuttle delete --version=VERSION --name=NAME [<flags>]
     Delete a Logshuttle logging endpoint on a Fastly service version
 
-        --version=VERSION        'latest', 'active', or the number of a specific
-                                 version
-        --autoclone              If the selected service version is not
+        --version=VERSION        'latest', 'active', 'draft', relative to latest
+                                 or active (e.g. 'latest-1'), 'tag:NAME',
+                                 or the number of a specific version
+        --[no-]autoclone         If the selected service version is not
                                  editable, clone it and use the clone.
     -n, --name=NAME              The name of the Logshuttle logging object
     -s, --service-id=SERVICE-ID  Service ID (falls back to FASTLY_SERVICE_ID,
@@ -3241,12 +4718,15 @@ COMMANDS
     service version
 
     -j, --json                   Render output as JSON
+        --format=text            Output format (text, json, env)
+        --output=OUTPUT          Write the output to a file instead of stdout
     -s, --service-id=SERVICE-ID  Service ID (falls back to FASTLY_SERVICE_ID,
                                  then fastly.toml)
         --service-name=SERVICE-NAME
                                  The name of the service
-        --version=VERSION        'latest', 'active', or the number of a specific
-                                 version
+        --version=VERSION        'latest', 'active', 'draft', relative to latest
+                                 or active (e.g. 'latest-1'), 'tag:NAME',
+                                 or the number of a specific version
     -n, --name=NAME              The name of the Logshuttle logging object
 
   logging logshuttle list --version=VERSION [<flags>]
@@ -3257,15 +4737,17 @@ COMMANDS
                                  then fastly.toml)
         --service-name=SERVICE-NAME
                                  The name of the service
-        --version=VERSION        'latest', 'active', or the number of a specific
-                                 version
+        --version=VERSION        'latest', 'active', 'draft', relative to latest
+                                 or active (e.g. 'latest-1'), 'tag:NAME',
+                                 or the number of a specific version
 
   logging logshuttle update --version=VERSION --name=NAME [<flags>]
     Update a Logshuttle logging endpoint on a Fastly service version
 
-        --version=VERSION        'latest', 'active', or the number of a specific
-                                 version
-        --autoclone              If the selected service version is not
+        --version=VERSION        'latest', 'active', 'draft', relative to latest
+                                 or active (e.g. 'latest-1'), 'tag:NAME',
+                                 or the number of a specific version
+        --[no-]autoclone         If the selected service version is not
                                  editable, clone it and use the clone.
     -n, --name=NAME              The name of the Logshuttle logging object
     -s, --service-id=SERVICE-ID  Service ID (falls back to FASTLY_SERVICE_ID,
@@ -3297,9 +4779,10 @@ COMMANDS
                                  your New Relic account
         --name=NAME              The name for the real-time logging
                                  configuration
-        --version=VERSION        'latest', 'active', or the number of a specific
-                                 version
-        --autoclone              If the selected service version is not
+        --version=VERSION        'latest', 'active', 'draft', relative to latest
+                                 or active (e.g. 'latest-1'), 'tag:NAME',
+                                 or the number of a specific version
+        --[no-]autoclone         If the selected service version is not
                                  editable, clone it and use the clone.
         --format=FORMAT          A Fastly log format string. Must produce valid
                                  JSON that New Relic Logs can ingest
@@ -3308,6 +4791,11 @@ COMMANDS
                                  for the configured endpoint
         --placement=PLACEMENT    Where in the generated VCL the logging call
                                  should be placed
+        --preset=PRESET          Apply the recommended log format of an
+                                 observability vendor (e.g. datadog, honeycomb,
+                                 newrelic, splunk) as listed by the logging
+                                 presets command. Other flags override the
+                                 preset
         --region=REGION          The region to which to stream logs
         --response-condition=RESPONSE-CONDITION
                                  The name of an existing condition in the
@@ -3323,9 +4811,10 @@ COMMANDS
 
         --name=NAME              The name for the real-time logging
                                  configuration to delete
-        --version=VERSION        'latest', 'active', or the number of a specific
-                                 version
-        --autoclone              If the selected service version is not
+        --version=VERSION        'latest', 'active', 'draft', relative to latest
+                                 or active (e.g. 'latest-1'), 'tag:NAME',
+                                 or the number of a specific version
+        --[no-]autoclone         If the selected service version is not
                                  editable, clone it and use the clone.
     -s, --service-id=SERVICE-ID  Service ID (falls back to FASTLY_SERVICE_ID,
                                  then fastly.toml)
@@ -3338,9 +4827,12 @@ COMMANDS
 
         --name=NAME              The name for the real-time logging
                                  configuration
-        --version=VERSION        'latest', 'active', or the number of a specific
-                                 version
+        --version=VERSION        'latest', 'active', 'draft', relative to latest
+                                 or active (e.g. 'latest-1'), 'tag:NAME',
+                                 or the number of a specific version
     -j, --json                   Render output as JSON
+        --format=text            Output format (text, json, env)
+        --output=OUTPUT          Write the output to a file instead of stdout
     -s, --service-id=SERVICE-ID  Service ID (falls back to FASTLY_SERVICE_ID,
                                  then fastly.toml)
         --service-name=SERVICE-NAME
@@ -3350,8 +4842,9 @@ COMMANDS
     List all of the New Relic Logs logging objects for a particular service and
     version
 
-        --version=VERSION        'latest', 'active', or the number of a specific
-                                 version
+        --version=VERSION        'latest', 'active', 'draft', relative to latest
+                                 or active (e.g. 'latest-1'), 'tag:NAME',
+                                 or the number of a specific version
     -j, --json                   Render output as JSON
     -s, --service-id=SERVICE-ID  Service ID (falls back to FASTLY_SERVICE_ID,
                                  then fastly.toml)
@@ -3363,9 +4856,10 @@ COMMANDS
 
         --name=NAME              The name for the real-time logging
                                  configuration to update
-        --version=VERSION        'latest', 'active', or the number of a specific
-                                 version
-        --autoclone              If the selected service version is not
+        --version=VERSION        'latest', 'active', 'draft', relative to latest
+                                 or active (e.g. 'latest-1'), 'tag:NAME',
+                                 or the number of a specific version
+        --[no-]autoclone         If the selected service version is not
                                  editable, clone it and use the clone.
         --format=FORMAT          A Fastly log format string. Must produce valid
                                  JSON that New Relic Logs can ingest
@@ -3392,9 +4886,10 @@ COMMANDS
 
     -n, --name=NAME              The name of the OpenStack logging object.
                                  Used as a primary key for API access
-        --version=VERSION        'latest', 'active', or the number of a specific
-                                 version
-        --autoclone              If the selected service version is not
+        --version=VERSION        'latest', 'active', 'draft', relative to latest
+                                 or active (e.g. 'latest-1'), 'tag:NAME',
+                                 or the number of a specific version
+        --[no-]autoclone         If the selected service version is not
                                  editable, clone it and use the clone.
         --bucket=BUCKET          The name of your OpenStack container
         --access-key=ACCESS-KEY  Your OpenStack account access key
@@ -3444,9 +4939,10 @@ COMMANDS
   logging openstack delete --version=VERSION --name=NAME [<flags>]
     Delete an OpenStack logging endpoint on a Fastly service version
 
-        --version=VERSION        'latest', 'active', or the number of a specific
-                                 version
-        --autoclone              If the selected service version is not
+        --version=VERSION        'latest', 'active', 'draft', relative to latest
+                                 or active (e.g. 'latest-1'), 'tag:NAME',
+                                 or the number of a specific version
+        --[no-]autoclone         If the selected service version is not
                                  editable, clone it and use the clone.
     -n, --name=NAME              The name of the OpenStack logging object
     -s, --service-id=SERVICE-ID  Service ID (falls back to FASTLY_SERVICE_ID,
@@ -3459,12 +4955,15 @@ COMMANDS
     service version
 
     -j, --json                   Render output as JSON
+        --format=text            Output format (text, json, env)
+        --output=OUTPUT          Write the output to a file instead of stdout
     -s, --service-id=SERVICE-ID  Service ID (falls back to FASTLY_SERVICE_ID,
                                  then fastly.toml)
         --service-name=SERVICE-NAME
                                  The name of the service
-        --version=VERSION        'latest', 'active', or the number of a specific
-                                 version
+        --version=VERSION        'latest', 'active', 'draft', relative to latest
+                                 or active (e.g. 'latest-1'), 'tag:NAME',
+                                 or the number of a specific version
     -n, --name=NAME              The name of the OpenStack logging object
 
   logging openstack list --version=VERSION [<flags>]
@@ -3475,15 +4974,17 @@ COMMANDS
                                  then fastly.toml)
         --service-name=SERVICE-NAME
                                  The name of the service
-        --version=VERSION        'latest', 'active', or the number of a specific
-                                 version
+        --version=VERSION        'latest', 'active', 'draft', relative to latest
+                                 or active (e.g. 'latest-1'), 'tag:NAME',
+                                 or the number of a specific version
 
   logging openstack update --version=VERSION --name=NAME [<flags>]
     Update an OpenStack logging endpoint on a Fastly service version
 
-        --version=VERSION        'latest', 'active', or the number of a specific
-                                 version
-        --autoclone              If the selected service version is not
+        --version=VERSION        'latest', 'active', 'draft', relative to latest
+                                 or active (e.g. 'latest-1'), 'tag:NAME',
+                                 or the number of a specific version
+        --[no-]autoclone         If the selected service version is not
                                  editable, clone it and use the clone.
     -n, --name=NAME              The name of the OpenStack logging object
     -s, --service-id=SERVICE-ID  Service ID (falls back to FASTLY_SERVICE_ID,
@@ -3537,9 +5038,10 @@ COMMANDS
 
     -n, --name=NAME              The name of the Papertrail logging object.
                                  Used as a primary key for API access
-        --version=VERSION        'latest', 'active', or the number of a specific
-                                 version
-        --autoclone              If the selected service version is not
+        --version=VERSION        'latest', 'active', 'draft', relative to latest
+                                 or active (e.g. 'latest-1'), 'tag:NAME',
+                                 or the number of a specific version
+        --[no-]autoclone         If the selected service version is not
                                  editable, clone it and use the clone.
         --address=ADDRESS        A hostname or IPv4 address
     -s, --service-id=SERVICE-ID  Service ID (falls back to FASTLY_SERVICE_ID,
@@ -3568,9 +5070,10 @@ COMMANDS
   logging papertrail delete --version=VERSION --name=NAME [<flags>]
     Delete a Papertrail logging endpoint on a Fastly service version
 
-        --version=VERSION        'latest', 'active', or the number of a specific
-                                 version
-        --autoclone              If the selected service version is not
+        --version=VERSION        'latest', 'active', 'draft', relative to latest
+                                 or active (e.g. 'latest-1'), 'tag:NAME',
+                                 or the number of a specific version
+        --[no-]autoclone         If the selected service version is not
                                  editable, clone it and use the clone.
     -n, --name=NAME              The name of the Papertrail logging object
     -s, --service-id=SERVICE-ID  Service ID (falls back to FASTLY_SERVICE_ID,
@@ -3583,12 +5086,15 @@ COMMANDS
     service version
 
     -j, --json                   Render output as JSON
+        --format=text            Output format (text, json, env)
+        --output=OUTPUT          Write the output to a file instead of stdout
     -s, --service-id=SERVICE-ID  Service ID (falls back to FASTLY_SERVICE_ID,
                                  then fastly.toml)
         --service-name=SERVICE-NAME
                                  The name of the service
-        --version=VERSION        'latest', 'active', or the number of a specific
-                                 version
+        --version=VERSION        'latest', 'active', 'draft', relative to latest
+                                 or active (e.g. 'latest-1'), 'tag:NAME',
+                                 or the number of a specific version
     -n, --name=NAME              The name of the Papertrail logging object
 
   logging papertrail list --version=VERSION [<flags>]
@@ -3599,15 +5105,17 @@ COMMANDS
                                  then fastly.toml)
         --service-name=SERVICE-NAME
                                  The name of the service
-        --version=VERSION        'latest', 'active', or the number of a specific
-                                 version
+        --version=VERSION        'latest', 'active', 'draft', relative to latest
+                                 or active (e.g. 'latest-1'), 'tag:NAME',
+                                 or the number of a specific version
 
   logging papertrail update --version=VERSION --name=NAME [<flags>]
     Update a Papertrail logging endpoint on a Fastly service version
 
-        --version=VERSION        'latest', 'active', or the number of a specific
-                                 version
-        --autoclone              If the selected service version is not
+        --version=VERSION        'latest', 'active', 'draft', relative to latest
+                                 or active (e.g. 'latest-1'), 'tag:NAME',
+                                 or the number of a specific version
+        --[no-]autoclone         If the selected service version is not
                                  editable, clone it and use the clone.
     -n, --name=NAME              The name of the Papertrail logging object
     -s, --service-id=SERVICE-ID  Service ID (falls back to FASTLY_SERVICE_ID,
@@ -3635,14 +5143,21 @@ COMMANDS
                                  default. Can be none or waf_debug. This field
                                  is not required and has no default value
 
+  logging presets [<flags>]
+    List the log format presets applied by the --preset flag of the create
+    commands
+
+    -j, --json  Render output as JSON
+
   logging s3 create --name=NAME --version=VERSION --bucket=BUCKET [<flags>]
     Create an Amazon S3 logging endpoint on a Fastly service version
 
     -n, --name=NAME              The name of the S3 logging object. Used as a
                                  primary key for API access
-        --version=VERSION        'latest', 'active', or the number of a specific
-                                 version
-        --autoclone              If the selected service version is not
+        --version=VERSION        'latest', 'active', 'draft', relative to latest
+                                 or active (e.g. 'latest-1'), 'tag:NAME',
+                                 or the number of a specific version
+        --[no-]autoclone         If the selected service version is not
                                  editable, clone it and use the clone.
         --bucket=BUCKET          Your S3 bucket name
         --access-key=ACCESS-KEY  Your S3 account access key
@@ -3697,13 +5212,18 @@ COMMANDS
                                  set the level using gzip_level. Specifying both
                                  compression_codec and gzip_level in the same
                                  API request will result in an error.
+        --validate               Validate the access key can write to the bucket
+                                 by uploading a small object before creating
+                                 the endpoint. With --iam-role, print the IAM
+                                 policies the role needs instead
 
   logging s3 delete --version=VERSION --name=NAME [<flags>]
     Delete a S3 logging endpoint on a Fastly service version
 
-        --version=VERSION        'latest', 'active', or the number of a specific
-                                 version
-        --autoclone              If the selected service version is not
+        --version=VERSION        'latest', 'active', 'draft', relative to latest
+                                 or active (e.g. 'latest-1'), 'tag:NAME',
+                                 or the number of a specific version
+        --[no-]autoclone         If the selected service version is not
                                  editable, clone it and use the clone.
     -n, --name=NAME              The name of the S3 logging object
     -s, --service-id=SERVICE-ID  Service ID (falls back to FASTLY_SERVICE_ID,
@@ -3716,12 +5236,15 @@ COMMANDS
     version
 
     -j, --json                   Render output as JSON
+        --format=text            Output format (text, json, env)
+        --output=OUTPUT          Write the output to a file instead of stdout
     -s, --service-id=SERVICE-ID  Service ID (falls back to FASTLY_SERVICE_ID,
                                  then fastly.toml)
         --service-name=SERVICE-NAME
                                  The name of the service
-        --version=VERSION        'latest', 'active', or the number of a specific
-                                 version
+        --version=VERSION        'latest', 'active', 'draft', relative to latest
+                                 or active (e.g. 'latest-1'), 'tag:NAME',
+                                 or the number of a specific version
     -n, --name=NAME              The name of the S3 logging object
 
   logging s3 list --version=VERSION [<flags>]
@@ -3732,15 +5255,32 @@ COMMANDS
                                  then fastly.toml)
         --service-name=SERVICE-NAME
                                  The name of the service
-        --version=VERSION        'latest', 'active', or the number of a specific
-                                 version
+        --version=VERSION        'latest', 'active', 'draft', relative to latest
+                                 or active (e.g. 'latest-1'), 'tag:NAME',
+                                 or the number of a specific version
+
+  logging s3 policy --bucket=BUCKET [<flags>]
+    Print the IAM policies an S3 logging endpoint needs, including the trust
+    policy of a role assumed with --iam-role
+
+        --bucket=BUCKET            Your S3 bucket name
+        --path=PATH                The path to upload logs to
+        --server-side-encryption-kms-key-id=SERVER-SIDE-ENCRYPTION-KMS-KEY-ID
+                                   Server-side KMS Key ID, if the logs are
+                                   encrypted with aws:kms
+        --customer-id=CUSTOMER-ID  Alphanumeric string identifying the customer,
+                                   used as the external ID of the trust policy
+                                   (falls back to FASTLY_CUSTOMER_ID, then the
+                                   customer of the current user)
+    -j, --json                     Render output as JSON
 
   logging s3 update --version=VERSION --name=NAME [<flags>]
     Update a S3 logging endpoint on a Fastly service version
 
-        --version=VERSION        'latest', 'active', or the number of a specific
-                                 version
-        --autoclone              If the selected service version is not
+        --version=VERSION        'latest', 'active', 'draft', relative to latest
+                                 or active (e.g. 'latest-1'), 'tag:NAME',
+                                 or the number of a specific version
+        --[no-]autoclone         If the selected service version is not
                                  editable, clone it and use the clone.
     -n, --name=NAME              The name of the S3 logging object
     -s, --service-id=SERVICE-ID  Service ID (falls back to FASTLY_SERVICE_ID,
@@ -3803,9 +5343,10 @@ COMMANDS
 
     -n, --name=NAME              The name of the Scalyr logging object. Used as
                                  a primary key for API access
-        --version=VERSION        'latest', 'active', or the number of a specific
-                                 version
-        --autoclone              If the selected service version is not
+        --version=VERSION        'latest', 'active', 'draft', relative to latest
+                                 or active (e.g. 'latest-1'), 'tag:NAME',
+                                 or the number of a specific version
+        --[no-]autoclone         If the selected service version is not
                                  editable, clone it and use the clone.
         --auth-token=AUTH-TOKEN  The token to use for authentication
                                  (https://www.scalyr.com/keys)
@@ -3831,9 +5372,10 @@ COMMANDS
   logging scalyr delete --version=VERSION --name=NAME [<flags>]
     Delete a Scalyr logging endpoint on a Fastly service version
 
-        --version=VERSION        'latest', 'active', or the number of a specific
-                                 version
-        --autoclone              If the selected service version is not
+        --version=VERSION        'latest', 'active', 'draft', relative to latest
+                                 or active (e.g. 'latest-1'), 'tag:NAME',
+                                 or the number of a specific version
+        --[no-]autoclone         If the selected service version is not
                                  editable, clone it and use the clone.
     -n, --name=NAME              The name of the Scalyr logging object
     -s, --service-id=SERVICE-ID  Service ID (falls back to FASTLY_SERVICE_ID,
@@ -3846,12 +5388,15 @@ COMMANDS
     service version
 
     -j, --json                   Render output as JSON
+        --format=text            Output format (text, json, env)
+        --output=OUTPUT          Write the output to a file instead of stdout
     -s, --service-id=SERVICE-ID  Service ID (falls back to FASTLY_SERVICE_ID,
                                  then fastly.toml)
         --service-name=SERVICE-NAME
                                  The name of the service
-        --version=VERSION        'latest', 'active', or the number of a specific
-                                 version
+        --version=VERSION        'latest', 'active', 'draft', relative to latest
+                                 or active (e.g. 'latest-1'), 'tag:NAME',
+                                 or the number of a specific version
     -n, --name=NAME              The name of the Scalyr logging object
 
   logging scalyr list --version=VERSION [<flags>]
@@ -3862,15 +5407,17 @@ COMMANDS
                                  then fastly.toml)
         --service-name=SERVICE-NAME
                                  The name of the service
-        --version=VERSION        'latest', 'active', or the number of a specific
-                                 version
+        --version=VERSION        'latest', 'active', 'draft', relative to latest
+                                 or active (e.g. 'latest-1'), 'tag:NAME',
+                                 or the number of a specific version
 
   logging scalyr update --version=VERSION --name=NAME [<flags>]
     Update a Scalyr logging endpoint on a Fastly service version
 
-        --version=VERSION        'latest', 'active', or the number of a specific
-                                 version
-        --autoclone              If the selected service version is not
+        --version=VERSION        'latest', 'active', 'draft', relative to latest
+                                 or active (e.g. 'latest-1'), 'tag:NAME',
+                                 or the number of a specific version
+        --[no-]autoclone         If the selected service version is not
                                  editable, clone it and use the clone.
     -n, --name=NAME              The name of the Scalyr logging object
     -s, --service-id=SERVICE-ID  Service ID (falls back to FASTLY_SERVICE_ID,
@@ -3900,9 +5447,10 @@ COMMANDS
 
     -n, --name=NAME              The name of the SFTP logging object. Used as a
                                  primary key for API access
-        --version=VERSION        'latest', 'active', or the number of a specific
-                                 version
-        --autoclone              If the selected service version is not
+        --version=VERSION        'latest', 'active', 'draft', relative to latest
+                                 or active (e.g. 'latest-1'), 'tag:NAME',
+                                 or the number of a specific version
+        --[no-]autoclone         If the selected service version is not
                                  editable, clone it and use the clone.
         --address=ADDRESS        The hostname or IPv4 address
         --user=USER              The username for the server
@@ -3962,9 +5510,10 @@ COMMANDS
   logging sftp delete --version=VERSION --name=NAME [<flags>]
     Delete an SFTP logging endpoint on a Fastly service version
 
-        --version=VERSION        'latest', 'active', or the number of a specific
-                                 version
-        --autoclone              If the selected service version is not
+        --version=VERSION        'latest', 'active', 'draft', relative to latest
+                                 or active (e.g. 'latest-1'), 'tag:NAME',
+                                 or the number of a specific version
+        --[no-]autoclone         If the selected service version is not
                                  editable, clone it and use the clone.
     -n, --name=NAME              The name of the SFTP logging object
     -s, --service-id=SERVICE-ID  Service ID (falls back to FASTLY_SERVICE_ID,
@@ -3977,12 +5526,15 @@ COMMANDS
     version
 
     -j, --json                   Render output as JSON
+        --format=text            Output format (text, json, env)
+        --output=OUTPUT          Write the output to a file instead of stdout
     -s, --service-id=SERVICE-ID  Service ID (falls back to FASTLY_SERVICE_ID,
                                  then fastly.toml)
         --service-name=SERVICE-NAME
                                  The name of the service
-        --version=VERSION        'latest', 'active', or the number of a specific
-                                 version
+        --version=VERSION        'latest', 'active', 'draft', relative to latest
+                                 or active (e.g. 'latest-1'), 'tag:NAME',
+                                 or the number of a specific version
     -n, --name=NAME              The name of the SFTP logging object
 
   logging sftp list --version=VERSION [<flags>]
@@ -3993,15 +5545,17 @@ COMMANDS
                                  then fastly.toml)
         --service-name=SERVICE-NAME
                                  The name of the service
-        --version=VERSION        'latest', 'active', or the number of a specific
-                                 version
+        --version=VERSION        'latest', 'active', 'draft', relative to latest
+                                 or active (e.g. 'latest-1'), 'tag:NAME',
+                                 or the number of a specific version
 
   logging sftp update --version=VERSION --name=NAME [<flags>]
     Update an SFTP logging endpoint on a Fastly service version
 
-        --version=VERSION        'latest', 'active', or the number of a specific
-                                 version
-        --autoclone              If the selected service version is not
+        --version=VERSION        'latest', 'active', 'draft', relative to latest
+                                 or active (e.g. 'latest-1'), 'tag:NAME',
+                                 or the number of a specific version
+        --[no-]autoclone         If the selected service version is not
                                  editable, clone it and use the clone.
     -n, --name=NAME              The name of the SFTP logging object
     -s, --service-id=SERVICE-ID  Service ID (falls back to FASTLY_SERVICE_ID,
@@ -4065,9 +5619,11 @@ COMMANDS
 
     -n, --name=NAME                The name of the Splunk logging object.
                                    Used as a primary key for API access
-        --version=VERSION          'latest', 'active', or the number of a
-                                   specific version
-        --autoclone                If the selected service version is not
+        --version=VERSION          'latest', 'active', 'draft', relative
+                                   to latest or active (e.g. 'latest-1'),
+                                   'tag:NAME', or the number of a specific
+                                   version
+        --[no-]autoclone           If the selected service version is not
                                    editable, clone it and use the clone.
         --url=URL                  The URL to POST to
     -s, --service-id=SERVICE-ID    Service ID (falls back to FASTLY_SERVICE_ID,
@@ -4099,15 +5655,21 @@ COMMANDS
                                    call should be placed, overriding any
                                    format_version default. Can be none or
                                    waf_debug
+        --preset=PRESET            Apply the recommended log format of an
+                                   observability vendor (e.g. datadog,
+                                   honeycomb, newrelic, splunk) as listed by the
+                                   logging presets command. Other flags override
+                                   the preset
         --auth-token=AUTH-TOKEN    A Splunk token for use in posting logs over
                                    HTTP to your collector
 
   logging splunk delete --version=VERSION --name=NAME [<flags>]
     Delete a Splunk logging endpoint on a Fastly service version
 
-        --version=VERSION        'latest', 'active', or the number of a specific
-                                 version
-        --autoclone              If the selected service version is not
+        --version=VERSION        'latest', 'active', 'draft', relative to latest
+                                 or active (e.g. 'latest-1'), 'tag:NAME',
+                                 or the number of a specific version
+        --[no-]autoclone         If the selected service version is not
                                  editable, clone it and use the clone.
     -n, --name=NAME              The name of the Splunk logging object
     -s, --service-id=SERVICE-ID  Service ID (falls back to FASTLY_SERVICE_ID,
@@ -4120,12 +5682,15 @@ COMMANDS
     service version
 
     -j, --json                   Render output as JSON
+        --format=text            Output format (text, json, env)
+        --output=OUTPUT          Write the output to a file instead of stdout
     -s, --service-id=SERVICE-ID  Service ID (falls back to FASTLY_SERVICE_ID,
                                  then fastly.toml)
         --service-name=SERVICE-NAME
                                  The name of the service
-        --version=VERSION        'latest', 'active', or the number of a specific
-                                 version
+        --version=VERSION        'latest', 'active', 'draft', relative to latest
+                                 or active (e.g. 'latest-1'), 'tag:NAME',
+                                 or the number of a specific version
     -n, --name=NAME              The name of the Splunk logging object
 
   logging splunk list --version=VERSION [<flags>]
@@ -4136,15 +5701,18 @@ COMMANDS
                                  then fastly.toml)
         --service-name=SERVICE-NAME
                                  The name of the service
-        --version=VERSION        'latest', 'active', or the number of a specific
-                                 version
+        --version=VERSION        'latest', 'active', 'draft', relative to latest
+                                 or active (e.g. 'latest-1'), 'tag:NAME',
+                                 or the number of a specific version
 
   logging splunk update --version=VERSION --name=NAME [<flags>]
     Update a Splunk logging endpoint on a Fastly service version
 
-        --version=VERSION          'latest', 'active', or the number of a
-                                   specific version
-        --autoclone                If the selected service version is not
+        --version=VERSION          'latest', 'active', 'draft', relative
+                                   to latest or active (e.g. 'latest-1'),
+                                   'tag:NAME', or the number of a specific
+                                   version
+        --[no-]autoclone           If the selected service version is not
                                    editable, clone it and use the clone.
     -n, --name=NAME                The name of the Splunk logging object
     -s, --service-id=SERVICE-ID    Service ID (falls back to FASTLY_SERVICE_ID,
@@ -4181,14 +5749,32 @@ COMMANDS
                                    no default value
         --auth-token=AUTH-TOKEN
 
+  logging status [<flags>]
+    Show the logging endpoints of a service version and how many log lines the
+    service has recently sent
+
+        --from="1 day ago"       Count the log lines sent since
+                                 this time, accepted formats at
+                                 https://fastly.dev/reference/api/metrics-stats/historical-stats
+    -j, --json                   Render output as JSON
+    -s, --service-id=SERVICE-ID  Service ID (falls back to FASTLY_SERVICE_ID,
+                                 then fastly.toml)
+        --service-name=SERVICE-NAME
+                                 The name of the service
+        --version=VERSION        'latest', 'active', 'draft', relative to latest
+                                 or active (e.g. 'latest-1'), 'tag:NAME',
+                                 or the number of a specific version (defaults
+                                 to the active version)
+
   logging sumologic create --name=NAME --version=VERSION --url=URL [<flags>]
     Create a Sumologic logging endpoint on a Fastly service version
 
     -n, --name=NAME              The name of the Sumologic logging object.
                                  Used as a primary key for API access
-        --version=VERSION        'latest', 'active', or the number of a specific
-                                 version
-        --autoclone              If the selected service version is not
+        --version=VERSION        'latest', 'active', 'draft', relative to latest
+                                 or active (e.g. 'latest-1'), 'tag:NAME',
+                                 or the number of a specific version
+        --[no-]autoclone         If the selected service version is not
                                  editable, clone it and use the clone.
         --url=URL                The URL to POST to
     -s, --service-id=SERVICE-ID  Service ID (falls back to FASTLY_SERVICE_ID,
@@ -4219,9 +5805,10 @@ COMMANDS
   logging sumologic delete --version=VERSION --name=NAME [<flags>]
     Delete a Sumologic logging endpoint on a Fastly service version
 
-        --version=VERSION        'latest', 'active', or the number of a specific
-                                 version
-        --autoclone              If the selected service version is not
+        --version=VERSION        'latest', 'active', 'draft', relative to latest
+                                 or active (e.g. 'latest-1'), 'tag:NAME',
+                                 or the number of a specific version
+        --[no-]autoclone         If the selected service version is not
                                  editable, clone it and use the clone.
     -n, --name=NAME              The name of the Sumologic logging object
     -s, --service-id=SERVICE-ID  Service ID (falls back to FASTLY_SERVICE_ID,
@@ -4234,12 +5821,15 @@ COMMANDS
     service version
 
     -j, --json                   Render output as JSON
+        --format=text            Output format (text, json, env)
+        --output=OUTPUT          Write the output to a file instead of stdout
     -s, --service-id=SERVICE-ID  Service ID (falls back to FASTLY_SERVICE_ID,
                                  then fastly.toml)
         --service-name=SERVICE-NAME
                                  The name of the service
-        --version=VERSION        'latest', 'active', or the number of a specific
-                                 version
+        --version=VERSION        'latest', 'active', 'draft', relative to latest
+                                 or active (e.g. 'latest-1'), 'tag:NAME',
+                                 or the number of a specific version
     -n, --name=NAME              The name of the Sumologic logging object
 
   logging sumologic list --version=VERSION [<flags>]
@@ -4250,15 +5840,17 @@ COMMANDS
                                  then fastly.toml)
         --service-name=SERVICE-NAME
                                  The name of the service
-        --version=VERSION        'latest', 'active', or the number of a specific
-                                 version
+        --version=VERSION        'latest', 'active', 'draft', relative to latest
+                                 or active (e.g. 'latest-1'), 'tag:NAME',
+                                 or the number of a specific version
 
   logging sumologic update --version=VERSION --name=NAME [<flags>]
     Update a Sumologic logging endpoint on a Fastly service version
 
-        --version=VERSION        'latest', 'active', or the number of a specific
-                                 version
-        --autoclone              If the selected service version is not
+        --version=VERSION        'latest', 'active', 'draft', relative to latest
+                                 or active (e.g. 'latest-1'), 'tag:NAME',
+                                 or the number of a specific version
+        --[no-]autoclone         If the selected service version is not
                                  editable, clone it and use the clone.
     -n, --name=NAME              The name of the Sumologic logging object
     -s, --service-id=SERVICE-ID  Service ID (falls back to FASTLY_SERVICE_ID,
@@ -4293,9 +5885,11 @@ COMMANDS
 
     -n, --name=NAME                The name of the Syslog logging object.
                                    Used as a primary key for API access
-        --version=VERSION          'latest', 'active', or the number of a
-                                   specific version
-        --autoclone                If the selected service version is not
+        --version=VERSION          'latest', 'active', 'draft', relative
+                                   to latest or active (e.g. 'latest-1'),
+                                   'tag:NAME', or the number of a specific
+                                   version
+        --[no-]autoclone           If the selected service version is not
                                    editable, clone it and use the clone.
         --address=ADDRESS          A hostname or IPv4 address
     -s, --service-id=SERVICE-ID    Service ID (falls back to FASTLY_SERVICE_ID,
@@ -4338,9 +5932,10 @@ COMMANDS
   logging syslog delete --version=VERSION --name=NAME [<flags>]
     Delete a Syslog logging endpoint on a Fastly service version
 
-        --version=VERSION        'latest', 'active', or the number of a specific
-                                 version
-        --autoclone              If the selected service version is not
+        --version=VERSION        'latest', 'active', 'draft', relative to latest
+                                 or active (e.g. 'latest-1'), 'tag:NAME',
+                                 or the number of a specific version
+        --[no-]autoclone         If the selected service version is not
                                  editable, clone it and use the clone.
     -n, --name=NAME              The name of the Syslog logging object
     -s, --service-id=SERVICE-ID  Service ID (falls back to FASTLY_SERVICE_ID,
@@ -4353,12 +5948,15 @@ COMMANDS
     service version
 
     -j, --json                   Render output as JSON
+        --format=text            Output format (text, json, env)
+        --output=OUTPUT          Write the output to a file instead of stdout
     -s, --service-id=SERVICE-ID  Service ID (falls back to FASTLY_SERVICE_ID,
                                  then fastly.toml)
         --service-name=SERVICE-NAME
                                  The name of the service
-        --version=VERSION        'latest', 'active', or the number of a specific
-                                 version
+        --version=VERSION        'latest', 'active', 'draft', relative to latest
+                                 or active (e.g. 'latest-1'), 'tag:NAME',
+                                 or the number of a specific version
     -n, --name=NAME              The name of the Syslog logging object
 
   logging syslog list --version=VERSION [<flags>]
@@ -4369,15 +5967,18 @@ COMMANDS
                                  then fastly.toml)
         --service-name=SERVICE-NAME
                                  The name of the service
-        --version=VERSION        'latest', 'active', or the number of a specific
-                                 version
+        --version=VERSION        'latest', 'active', 'draft', relative to latest
+                                 or active (e.g. 'latest-1'), 'tag:NAME',
+                                 or the number of a specific version
 
   logging syslog update --version=VERSION --name=NAME [<flags>]
     Update a Syslog logging endpoint on a Fastly service version
 
-        --version=VERSION          'latest', 'active', or the number of a
-                                   specific version
-        --autoclone                If the selected service version is not
+        --version=VERSION          'latest', 'active', 'draft', relative
+                                   to latest or active (e.g. 'latest-1'),
+                                   'tag:NAME', or the number of a specific
+                                   version
+        --[no-]autoclone           If the selected service version is not
                                    editable, clone it and use the clone.
     -n, --name=NAME                The name of the Syslog logging object
     -s, --service-id=SERVICE-ID    Service ID (falls back to FASTLY_SERVICE_ID,
@@ -4419,6 +6020,35 @@ COMMANDS
                                    format_version default. Can be none or
                                    waf_debug
 
+  mcp [<flags>]
+    Serve Fastly operations as Model Context Protocol (MCP) tools over stdio,
+    for AI assistants
+
+    --allow=ALLOW ...  Expose a tool that changes state, may be repeated
+                       (compute_build, compute_deploy, purge)
+
+  origins health --version=VERSION [<flags>]
+    Show the healthchecks and recent error rates of a service's origins
+
+        --version=VERSION        'latest', 'active', 'draft', relative to latest
+                                 or active (e.g. 'latest-1'), 'tag:NAME',
+                                 or the number of a specific version
+        --error-threshold=5      Percentage of 5xx origin responses at which an
+                                 origin is reported as unhealthy
+        --interval=10s           How often to refresh when using --watch
+    -j, --json                   Render output as JSON
+    -s, --service-id=SERVICE-ID  Service ID (falls back to FASTLY_SERVICE_ID,
+                                 then fastly.toml)
+        --service-name=SERVICE-NAME
+                                 The name of the service
+    -w, --watch                  Refresh the report until interrupted
+        --window=5m              How far back to look for origin responses
+
+  plugin list [<flags>]
+    List the plugins found on your PATH
+
+    -j, --json  Render output as JSON
+
   pops
     List Fastly datacenters
 
@@ -4457,6 +6087,8 @@ COMMANDS
                                  Surrogate Keys
         --key=KEY                Purge a service of objects tagged with a
                                  Surrogate Key
+        --[no-]notify            Notify the endpoints in the [notify] config
+                                 section (--no-notify to skip) when using --all
     -s, --service-id=SERVICE-ID  Service ID (falls back to FASTLY_SERVICE_ID,
                                  then fastly.toml)
         --service-name=SERVICE-NAME
@@ -4465,6 +6097,191 @@ COMMANDS
                                  rather than making them inaccessible
         --url=URL                Purge an individual URL
 
+  rate-limit create --action=ACTION --client-key=CLIENT-KEY --http-methods=HTTP-METHODS --name=NAME --penalty-box-duration=PENALTY-BOX-DURATION --rps-limit=RPS-LIMIT --version=VERSION --window-size=WINDOW-SIZE [<flags>]
+    Create a rate limiter on a Fastly service version
+
+        --action=ACTION            The action to take when a rate limiter
+                                   violation is detected
+        --client-key=CLIENT-KEY ...
+                                   A comma-separated list of VCL variables
+                                   used to generate a counter key to identify a
+                                   client (e.g. req.http.Fastly-Client-IP)
+        --http-methods=HTTP-METHODS ...
+                                   A comma-separated list of HTTP methods to
+                                   apply rate limiting to (e.g. GET,POST)
+    -n, --name=NAME                A human readable name for the rate limiting
+                                   rule
+        --penalty-box-duration=PENALTY-BOX-DURATION
+                                   Length of time in minutes that the rate
+                                   limiter is in effect after the initial
+                                   violation is detected (1-60)
+        --rps-limit=RPS-LIMIT      Upper limit of requests per second allowed by
+                                   the rate limiter (10-10000)
+        --version=VERSION          'latest', 'active', 'draft', relative
+                                   to latest or active (e.g. 'latest-1'),
+                                   'tag:NAME', or the number of a specific
+                                   version
+        --window-size=WINDOW-SIZE  Number of seconds during which the RPS
+                                   limit must be exceeded in order to trigger a
+                                   violation
+        --[no-]autoclone           If the selected service version is not
+                                   editable, clone it and use the clone.
+        --response-content=RESPONSE-CONTENT
+                                   HTTP response body to send when
+                                   --action=response
+        --response-content-type=RESPONSE-CONTENT-TYPE
+                                   HTTP Content-Type to send when
+                                   --action=response (e.g. application/json)
+        --response-status=RESPONSE-STATUS
+                                   HTTP status code to send when
+                                   --action=response (e.g. 429)
+    -s, --service-id=SERVICE-ID    Service ID (falls back to FASTLY_SERVICE_ID,
+                                   then fastly.toml)
+        --service-name=SERVICE-NAME
+                                   The name of the service
+
+  rate-limit delete --id=ID --version=VERSION [<flags>]
+    Delete a rate limiter on a Fastly service version
+
+        --id=ID                  Alphanumeric string identifying the rate
+                                 limiter
+        --version=VERSION        'latest', 'active', 'draft', relative to latest
+                                 or active (e.g. 'latest-1'), 'tag:NAME',
+                                 or the number of a specific version
+        --[no-]autoclone         If the selected service version is not
+                                 editable, clone it and use the clone.
+    -s, --service-id=SERVICE-ID  Service ID (falls back to FASTLY_SERVICE_ID,
+                                 then fastly.toml)
+        --service-name=SERVICE-NAME
+                                 The name of the service
+
+  rate-limit describe --id=ID --version=VERSION [<flags>]
+    Show detailed information about a rate limiter on a Fastly service version
+
+        --id=ID                  Alphanumeric string identifying the rate
+                                 limiter
+        --version=VERSION        'latest', 'active', 'draft', relative to latest
+                                 or active (e.g. 'latest-1'), 'tag:NAME',
+                                 or the number of a specific version
+    -j, --json                   Render output as JSON
+        --format=text            Output format (text, json, env)
+        --output=OUTPUT          Write the output to a file instead of stdout
+    -s, --service-id=SERVICE-ID  Service ID (falls back to FASTLY_SERVICE_ID,
+                                 then fastly.toml)
+        --service-name=SERVICE-NAME
+                                 The name of the service
+
+  rate-limit list --version=VERSION [<flags>]
+    List rate limiters on a Fastly service version
+
+        --version=VERSION        'latest', 'active', 'draft', relative to latest
+                                 or active (e.g. 'latest-1'), 'tag:NAME',
+                                 or the number of a specific version
+    -j, --json                   Render output as JSON
+    -s, --service-id=SERVICE-ID  Service ID (falls back to FASTLY_SERVICE_ID,
+                                 then fastly.toml)
+        --service-name=SERVICE-NAME
+                                 The name of the service
+
+  rate-limit update --id=ID --version=VERSION [<flags>]
+    Update a rate limiter on a Fastly service version
+
+        --id=ID                    Alphanumeric string identifying the rate
+                                   limiter
+        --version=VERSION          'latest', 'active', 'draft', relative
+                                   to latest or active (e.g. 'latest-1'),
+                                   'tag:NAME', or the number of a specific
+                                   version
+        --action=ACTION            The action to take when a rate limiter
+                                   violation is detected
+        --[no-]autoclone           If the selected service version is not
+                                   editable, clone it and use the clone.
+        --client-key=CLIENT-KEY ...
+                                   A comma-separated list of VCL variables
+                                   used to generate a counter key to identify a
+                                   client (e.g. req.http.Fastly-Client-IP)
+        --http-methods=HTTP-METHODS ...
+                                   A comma-separated list of HTTP methods to
+                                   apply rate limiting to (e.g. GET,POST)
+    -n, --name=NAME                A human readable name for the rate limiting
+                                   rule
+        --penalty-box-duration=PENALTY-BOX-DURATION
+                                   Length of time in minutes that the rate
+                                   limiter is in effect after the initial
+                                   violation is detected (1-60)
+        --response-content=RESPONSE-CONTENT
+                                   HTTP response body to send when
+                                   --action=response
+        --response-content-type=RESPONSE-CONTENT-TYPE
+                                   HTTP Content-Type to send when
+                                   --action=response (e.g. application/json)
+        --response-status=RESPONSE-STATUS
+                                   HTTP status code to send when
+                                   --action=response (e.g. 429)
+        --rps-limit=RPS-LIMIT      Upper limit of requests per second allowed by
+                                   the rate limiter (10-10000)
+    -s, --service-id=SERVICE-ID    Service ID (falls back to FASTLY_SERVICE_ID,
+                                   then fastly.toml)
+        --service-name=SERVICE-NAME
+                                   The name of the service
+        --window-size=WINDOW-SIZE  Number of seconds during which the RPS
+                                   limit must be exceeded in order to trigger a
+                                   violation
+
+  resource-link create --resource-id=RESOURCE-ID --version=VERSION [<flags>]
+    Link a resource (e.g. a KV, config or secret store) to a Fastly service
+    version
+
+        --resource-id=RESOURCE-ID  ID of the resource (e.g. the store ID) to
+                                   link
+        --version=VERSION          'latest', 'active', 'draft', relative
+                                   to latest or active (e.g. 'latest-1'),
+                                   'tag:NAME', or the number of a specific
+                                   version
+        --[no-]autoclone           If the selected service version is not
+                                   editable, clone it and use the clone.
+    -n, --name=NAME                Name used to reference the resource from the
+                                   service (defaults to the resource's own name)
+    -s, --service-id=SERVICE-ID    Service ID (falls back to FASTLY_SERVICE_ID,
+                                   then fastly.toml)
+        --service-name=SERVICE-NAME
+                                   The name of the service
+
+  resource-link delete --id=ID --version=VERSION [<flags>]
+    Unlink a resource from a Fastly service version
+
+        --id=ID                  ID of the resource link (not the resource
+                                 itself)
+        --version=VERSION        'latest', 'active', 'draft', relative to latest
+                                 or active (e.g. 'latest-1'), 'tag:NAME',
+                                 or the number of a specific version
+        --[no-]autoclone         If the selected service version is not
+                                 editable, clone it and use the clone.
+    -s, --service-id=SERVICE-ID  Service ID (falls back to FASTLY_SERVICE_ID,
+                                 then fastly.toml)
+        --service-name=SERVICE-NAME
+                                 The name of the service
+
+  resource-link list --version=VERSION [<flags>]
+    List the resources linked to a Fastly service version
+
+        --version=VERSION        'latest', 'active', 'draft', relative to latest
+                                 or active (e.g. 'latest-1'), 'tag:NAME',
+                                 or the number of a specific version
+    -j, --json                   Render output as JSON
+    -s, --service-id=SERVICE-ID  Service ID (falls back to FASTLY_SERVICE_ID,
+                                 then fastly.toml)
+        --service-name=SERVICE-NAME
+                                 The name of the service
+
+  serve-api [<flags>]
+    Serve CLI operations (build, deploy, purge, service describe) over a local
+    JSON-RPC socket
+
+    --socket=SOCKET  Path of the unix socket to listen on (defaults to
+                     fastly-api.sock in $XDG_RUNTIME_DIR, or in a private
+                     directory next to the CLI config file)
+
   service create --name=NAME [<flags>]
     Create a Fastly service
 
@@ -4486,6 +6303,8 @@ COMMANDS
     Show detailed information about a Fastly service
 
     -j, --json                   Render output as JSON
+        --format=text            Output format (text, json, env)
+        --output=OUTPUT          Write the output to a file instead of stdout
     -s, --service-id=SERVICE-ID  Service ID (falls back to FASTLY_SERVICE_ID,
                                  then fastly.toml)
         --service-name=SERVICE-NAME
@@ -4494,9 +6313,12 @@ COMMANDS
   service list [<flags>]
     List Fastly services
 
+        --all                Fetch every page of the data set (the default
+                             unless --page is set)
         --direction=ascend   Direction in which to sort results
     -j, --json               Render output as JSON
-        --page=PAGE          Page number of data set to fetch
+        --page=PAGE          Page number of data set to fetch (only that page is
+                             returned)
         --per-page=PER-PAGE  Number of records per page
         --sort="created"     Field on which to sort
 
@@ -4515,6 +6337,16 @@ COMMANDS
     -n, --name=NAME              Service name
         --comment=COMMENT        Human-readable comment
 
+  service watch [<flags>]
+    Poll a Fastly service and report its new versions, activations, domain
+    changes and package changes until interrupted
+
+        --interval=10s           How often to poll the service
+    -s, --service-id=SERVICE-ID  Service ID (falls back to FASTLY_SERVICE_ID,
+                                 then fastly.toml)
+        --service-name=SERVICE-NAME
+                                 The name of the service
+
   service-version activate --version=VERSION [<flags>]
     Activate a Fastly service version
 
@@ -4522,10 +6354,13 @@ COMMANDS
                                  then fastly.toml)
         --service-name=SERVICE-NAME
                                  The name of the service
-        --version=VERSION        'latest', 'active', or the number of a specific
-                                 version
-        --autoclone              If the selected service version is not
+        --version=VERSION        'latest', 'active', 'draft', relative to latest
+                                 or active (e.g. 'latest-1'), 'tag:NAME',
+                                 or the number of a specific version
+        --[no-]autoclone         If the selected service version is not
                                  editable, clone it and use the clone.
+        --[no-]notify            Notify the endpoints in the [notify] config
+                                 section (--no-notify to skip)
 
   service-version clone --version=VERSION [<flags>]
     Clone a Fastly service version
@@ -4534,8 +6369,9 @@ COMMANDS
                                  then fastly.toml)
         --service-name=SERVICE-NAME
                                  The name of the service
-        --version=VERSION        'latest', 'active', or the number of a specific
-                                 version
+        --version=VERSION        'latest', 'active', 'draft', relative to latest
+                                 or active (e.g. 'latest-1'), 'tag:NAME',
+                                 or the number of a specific version
 
   service-version deactivate --version=VERSION [<flags>]
     Deactivate a Fastly service version
@@ -4544,8 +6380,24 @@ COMMANDS
                                  then fastly.toml)
         --service-name=SERVICE-NAME
                                  The name of the service
-        --version=VERSION        'latest', 'active', or the number of a specific
-                                 version
+        --version=VERSION        'latest', 'active', 'draft', relative to latest
+                                 or active (e.g. 'latest-1'), 'tag:NAME',
+                                 or the number of a specific version
+
+  service-version describe --version=VERSION [<flags>]
+    Show detailed information about a Fastly service version, including its tags
+    and deploy metadata
+
+    -j, --json                   Render output as JSON
+        --format=text            Output format (text, json, env)
+        --output=OUTPUT          Write the output to a file instead of stdout
+    -s, --service-id=SERVICE-ID  Service ID (falls back to FASTLY_SERVICE_ID,
+                                 then fastly.toml)
+        --service-name=SERVICE-NAME
+                                 The name of the service
+        --version=VERSION        'latest', 'active', 'draft', relative to latest
+                                 or active (e.g. 'latest-1'), 'tag:NAME',
+                                 or the number of a specific version
 
   service-version list [<flags>]
     List Fastly service versions
@@ -4563,8 +6415,24 @@ COMMANDS
                                  then fastly.toml)
         --service-name=SERVICE-NAME
                                  The name of the service
-        --version=VERSION        'latest', 'active', or the number of a specific
-                                 version
+        --version=VERSION        'latest', 'active', 'draft', relative to latest
+                                 or active (e.g. 'latest-1'), 'tag:NAME',
+                                 or the number of a specific version
+
+  service-version tag --version=VERSION --name=NAME [<flags>]
+    Tag a Fastly service version, so it can be referred to with --version
+    tag:NAME
+
+    -s, --service-id=SERVICE-ID  Service ID (falls back to FASTLY_SERVICE_ID,
+                                 then fastly.toml)
+        --service-name=SERVICE-NAME
+                                 The name of the service
+        --version=VERSION        'latest', 'active', 'draft', relative to latest
+                                 or active (e.g. 'latest-1'), 'tag:NAME',
+                                 or the number of a specific version
+        --delete                 Remove the tag from the version
+        --name=NAME              Name of the tag (letters, digits, '.', '_' and
+                                 '-')
 
   service-version update --version=VERSION [<flags>]
     Update a Fastly service version
@@ -4573,9 +6441,10 @@ COMMANDS
                                  then fastly.toml)
         --service-name=SERVICE-NAME
                                  The name of the service
-        --version=VERSION        'latest', 'active', or the number of a specific
-                                 version
-        --autoclone              If the selected service version is not
+        --version=VERSION        'latest', 'active', 'draft', relative to latest
+                                 or active (e.g. 'latest-1'), 'tag:NAME',
+                                 or the number of a specific version
+        --[no-]autoclone         If the selected service version is not
                                  editable, clone it and use the clone.
         --comment=COMMENT        Human-readable comment
 
@@ -4592,6 +6461,12 @@ COMMANDS
         --by=BY                  Aggregation period (minute/hour/day)
         --region=REGION          Filter by region ('stats regions' to list)
         --format=FORMAT          Output format (json)
+        --baseline=BASELINE      Compare the totals with the same period this
+                                 long before (e.g. 7d, 2w, 12h), exiting with
+                                 an error if a metric changed by more than
+                                 --threshold
+        --threshold=20           The percentage change from the --baseline
+                                 period considered an anomaly
 
   stats realtime [<flags>]
     View realtime stats for a Fastly service
@@ -4601,6 +6476,13 @@ COMMANDS
         --service-name=SERVICE-NAME
                                  The name of the service
         --format=FORMAT          Output format (json)
+        --aggregate              Show a live table of several services, with a
+                                 row per service and a row of their totals
+        --services=SERVICES      Comma-separated IDs of the services to
+                                 aggregate (requires --aggregate)
+        --filter=FILTER          Aggregate the services whose name matches
+                                 this glob pattern, e.g. 'prod-*' (requires
+                                 --aggregate)
 
   stats regions
     List stats regions
@@ -4612,14 +6494,19 @@ COMMANDS
         --id=ID            Alphanumeric string identifying a TLS configuration
         --include=INCLUDE  Include related objects (comma-separated values)
     -j, --json             Render output as JSON
+        --format=text      Output format (text, json, env)
+        --output=OUTPUT    Write the output to a file instead of stdout
 
   tls-config list [<flags>]
     List all TLS configurations
 
+        --all                Fetch every page of the data set (the default
+                             unless --page is set)
         --filter-bulk        Optionally filter by the bulk attribute
         --include=INCLUDE    Include related objects (comma-separated values)
     -j, --json               Render output as JSON
-        --page=PAGE          Page number of data set to fetch
+        --page=PAGE          Page number of data set to fetch (only that page is
+                             returned)
         --per-page=PER-PAGE  Number of records per page
 
   tls-config update --id=ID --name=NAME
@@ -4645,10 +6532,14 @@ COMMANDS
         --id=ID            Alphanumeric string identifying a TLS activation
         --include=INCLUDE  Include related objects (comma-separated values)
     -j, --json             Render output as JSON
+        --format=text      Output format (text, json, env)
+        --output=OUTPUT    Write the output to a file instead of stdout
 
   tls-custom activation list [<flags>]
     List all TLS activations
 
+        --all                      Fetch every page of the data set (the default
+                                   unless --page is set)
         --filter-cert=FILTER-CERT  Limit the returned activations to a specific
                                    certificate
         --filter-config=FILTER-CONFIG
@@ -4660,7 +6551,8 @@ COMMANDS
         --include=INCLUDE          Include related objects (comma-separated
                                    values)
     -j, --json                     Render output as JSON
-        --page=PAGE                Page number of data set to fetch
+        --page=PAGE                Page number of data set to fetch (only that
+                                   page is returned)
         --per-page=PER-PAGE        Number of records per page
 
   tls-custom activation update --cert-id=CERT-ID --id=ID
@@ -4688,12 +6580,16 @@ COMMANDS
   tls-custom certificate describe --id=ID [<flags>]
     Show a TLS certificate
 
-        --id=ID  Alphanumeric string identifying a TLS certificate
-    -j, --json   Render output as JSON
+        --id=ID          Alphanumeric string identifying a TLS certificate
+    -j, --json           Render output as JSON
+        --format=text    Output format (text, json, env)
+        --output=OUTPUT  Write the output to a file instead of stdout
 
   tls-custom certificate list [<flags>]
     List all TLS certificates
 
+        --all                Fetch every page of the data set (the default
+                             unless --page is set)
         --filter-not-after=FILTER-NOT-AFTER
                              Limit the returned certificates to those that
                              expire prior to the specified date in UTC
@@ -4702,7 +6598,8 @@ COMMANDS
                              include the specific domain
         --include=INCLUDE    Include related objects (comma-separated values)
     -j, --json               Render output as JSON
-        --page=PAGE          Page number of data set to fetch
+        --page=PAGE          Page number of data set to fetch (only that page is
+                             returned)
         --per-page=PER-PAGE  Number of records per page
         --sort=SORT          The order in which to list the results by creation
                              date
@@ -4720,6 +6617,8 @@ COMMANDS
   tls-custom domain list [<flags>]
     List all TLS domains
 
+        --all                      Fetch every page of the data set (the default
+                                   unless --page is set)
         --filter-cert=FILTER-CERT  Limit the returned domains to those listed in
                                    the given TLS certificate's SAN list
         --filter-in-use            Limit the returned domains to those currently
@@ -4730,7 +6629,8 @@ COMMANDS
         --include=INCLUDE          Include related objects (comma-separated
                                    values)
     -j, --json                     Render output as JSON
-        --page=PAGE                Page number of data set to fetch
+        --page=PAGE                Page number of data set to fetch (only that
+                                   page is returned)
         --per-page=PER-PAGE        Number of records per page
         --sort=SORT                The order in which to list the results by
                                    creation date
@@ -4750,17 +6650,22 @@ COMMANDS
   tls-custom private-key describe --id=ID [<flags>]
     Show a TLS private key
 
-        --id=ID  Alphanumeric string identifying a private Key
-    -j, --json   Render output as JSON
+        --id=ID          Alphanumeric string identifying a private Key
+    -j, --json           Render output as JSON
+        --format=text    Output format (text, json, env)
+        --output=OUTPUT  Write the output to a file instead of stdout
 
   tls-custom private-key list [<flags>]
     List all TLS private keys
 
+        --all                Fetch every page of the data set (the default
+                             unless --page is set)
         --filter-in-use=FILTER-IN-USE
                              Limit the returned keys to those without any
                              matching TLS certificates
     -j, --json               Render output as JSON
-        --page=PAGE          Page number of data set to fetch
+        --page=PAGE          Page number of data set to fetch (only that page is
+                             returned)
         --per-page=PER-PAGE  Number of records per page
 
   tls-platform upload --cert-blob=CERT-BLOB --intermediates-blob=INTERMEDIATES-BLOB [<flags>]
@@ -4782,16 +6687,21 @@ COMMANDS
   tls-platform describe --id=ID [<flags>]
     Retrieve a single certificate
 
-        --id=ID  Alphanumeric string identifying a TLS bulk certificate
-    -j, --json   Render output as JSON
+        --id=ID          Alphanumeric string identifying a TLS bulk certificate
+    -j, --json           Render output as JSON
+        --format=text    Output format (text, json, env)
+        --output=OUTPUT  Write the output to a file instead of stdout
 
   tls-platform list [<flags>]
     List all certificates
 
+        --all                Fetch every page of the data set (the default
+                             unless --page is set)
         --filter-domain=FILTER-DOMAIN
                              Optionally filter by the bulk attribute
     -j, --json               Render output as JSON
-        --page=PAGE          Page number of data set to fetch
+        --page=PAGE          Page number of data set to fetch (only that page is
+                             returned)
         --per-page=PER-PAGE  Number of records per page
         --sort=SORT          The order in which to list the results by creation
                              date
@@ -4809,16 +6719,29 @@ COMMANDS
   tls-subscription create --domain=DOMAIN [<flags>]
     Create a new TLS subscription
 
-    --domain=DOMAIN ...        Domain(s) to add to the TLS certificates
-                               generated for the subscription (set flag once per
-                               domain)
-    --cert-auth=CERT-AUTH      The entity that issues and certifies the TLS
-                               certificates for your subscription. Valid values
-                               are lets-encrypt or globalsign
-    --common-name=COMMON-NAME  The domain name associated with the subscription.
-                               Default to the first domain specified by --domain
-    --config=CONFIG            Alphanumeric string identifying a TLS
-                               configuration
+        --domain=DOMAIN ...        Domain(s) to add to the TLS certificates
+                                   generated for the subscription (set flag once
+                                   per domain)
+        --cert-auth=CERT-AUTH      The entity that issues and certifies the
+                                   TLS certificates for your subscription.
+                                   Valid values are lets-encrypt or globalsign
+        --common-name=COMMON-NAME  The domain name associated with the
+                                   subscription. Default to the first domain
+                                   specified by --domain
+        --config=CONFIG            Alphanumeric string identifying a TLS
+                                   configuration
+        --dns-provider=DNS-PROVIDER
+                                   Create the ACME challenge DNS records with a
+                                   DNS provider, then wait for the certificate
+                                   to be issued (credentials are read from the
+                                   provider's usual env vars)
+        --interval=30s             How often to check the subscription state
+                                   when using --wait
+    -j, --json                     Render output as JSON
+        --wait                     Wait until the domains are validated and the
+                                   certificate issued
+        --wait-timeout=1h          How long to wait for the certificate to be
+                                   issued when using --wait
 
   tls-subscription delete --id=ID [<flags>]
     Destroy a TLS subscription. A subscription cannot be destroyed if there are
@@ -4834,10 +6757,14 @@ COMMANDS
         --id=ID            Alphanumeric string identifying a TLS subscription
         --include=INCLUDE  Include related objects (comma-separated values)
     -j, --json             Render output as JSON
+        --format=text      Output format (text, json, env)
+        --output=OUTPUT    Write the output to a file instead of stdout
 
   tls-subscription list [<flags>]
     List all TLS subscriptions
 
+        --all                Fetch every page of the data set (the default
+                             unless --page is set)
         --filter-active      Limit the returned subscriptions to those that have
                              currently active orders
         --filter-domain=FILTER-DOMAIN
@@ -4847,7 +6774,8 @@ COMMANDS
                              Limit the returned subscriptions by state
         --include=INCLUDE    Include related objects (comma-separated values)
     -j, --json               Render output as JSON
-        --page=PAGE          Page number of data set to fetch
+        --page=PAGE          Page number of data set to fetch (only that page is
+                             returned)
         --per-page=PER-PAGE  Number of records per page
         --sort=SORT          The order in which to list the results by creation
                              date
@@ -4867,9 +6795,40 @@ COMMANDS
     --force                    A flag that allows you to edit and delete a
                                subscription with active domains
 
-  update
+  tools install [<flags>] <name>
+    Install a tool, at the version pinned by the project or otherwise the latest
+    version
+
+    --arch=ARCH        The architecture of the binary to download, instead of
+                       the detected one (amd64, arm64)
+    --version=VERSION  The version to install, instead of the pinned or latest
+                       version
+
+  tools list [<flags>]
+    List the tools along with their installed versions and the version pinned by
+    the project
+
+    -j, --json  Render output as JSON
+
+  tools pin [<flags>] <name>
+    Pin a version of a tool, along with the checksum of its binary, in the
+    fastly.toml [tools] section
+
+    --arch=ARCH        The architecture of the binary to download, instead of
+                       the detected one (amd64, arm64)
+    --version=VERSION  The version to pin, instead of the latest version
+
+  tools upgrade [<flags>] [<name>]
+    Install the latest version of a tool, otherwise of every installed tool
+
+    --arch=ARCH  The architecture of the binary to download, instead of the
+                 detected one (amd64, arm64)
+
+  update [<flags>]
     Update the CLI to the latest version
 
+    --arch=ARCH  The architecture of the binary to download, instead of the
+                 detected one (amd64, arm64)
 
   user create --login=LOGIN --name=NAME [<flags>]
     Create a user of the Fastly API and web interface
@@ -4888,9 +6847,22 @@ COMMANDS
   user describe [<flags>]
     Get a specific user of the Fastly API and web interface
 
-        --current  Get the logged in user
-        --id=ID    Alphanumeric string identifying the user
-    -j, --json     Render output as JSON
+        --current        Get the logged in user
+        --id=ID          Alphanumeric string identifying the user
+    -j, --json           Render output as JSON
+        --format=text    Output format (text, json, env)
+        --output=OUTPUT  Write the output to a file instead of stdout
+
+  user invite --file=FILE [<flags>]
+    Invite users in bulk from a CSV file, and authorize them to access services
+
+    -f, --file=FILE        Path to a CSV file with a header row and the columns
+                           login, name, and optionally role and services (e.g.
+                           SERVICE_ID:read_only;SERVICE_ID)
+        --permission=full  The permission granted for services listed without
+                           one: full, read_only, purge_select or purge_all
+        --role=ROLE        The permissions role assigned to users without one:
+                           user, billing, engineer or superuser
 
   user list [<flags>]
     List all users from a specified customer id
@@ -4910,15 +6882,42 @@ COMMANDS
     --role=ROLE       The permissions role assigned to the user. Can be user,
                       billing, engineer, or superuser
 
+  vcl sync --version=VERSION [<flags>]
+    Make the custom VCL of a service version match a local directory of VCL
+    files
+
+        --version=VERSION        'latest', 'active', 'draft', relative to latest
+                                 or active (e.g. 'latest-1'), 'tag:NAME',
+                                 or the number of a specific version
+        --activate               Activate the service version once the VCL is
+                                 synced
+        --[no-]autoclone         If the selected service version is not
+                                 editable, clone it and use the clone.
+        --dry-run                Display the changes without modifying the
+                                 service version
+        --exit-code              With --dry-run, exit with status 7 if there are
+                                 changes to sync (see `+"`"+`fastly help exit-codes`+"`"+`)
+        --main=MAIN              The name of the main VCL (defaults to the only
+                                 file, or 'main' if there are several)
+        --[no-]notify            Notify the endpoints in the [notify] config
+                                 section (--no-notify to skip)
+        --path="vcl"             Directory of .vcl files, each uploaded as the
+                                 VCL named after the file
+    -s, --service-id=SERVICE-ID  Service ID (falls back to FASTLY_SERVICE_ID,
+                                 then fastly.toml)
+        --service-name=SERVICE-NAME
+                                 The name of the service
+
   vcl custom create --content=CONTENT --name=NAME --version=VERSION [<flags>]
     Upload a VCL for a particular service and version
 
         --content=CONTENT        VCL passed as file path or content, e.g.
                                  $(< main.vcl)
         --name=NAME              The name of the VCL
-        --version=VERSION        'latest', 'active', or the number of a specific
-                                 version
-        --autoclone              If the selected service version is not
+        --version=VERSION        'latest', 'active', 'draft', relative to latest
+                                 or active (e.g. 'latest-1'), 'tag:NAME',
+                                 or the number of a specific version
+        --[no-]autoclone         If the selected service version is not
                                  editable, clone it and use the clone.
         --main                   Whether the VCL is the 'main' entrypoint
     -s, --service-id=SERVICE-ID  Service ID (falls back to FASTLY_SERVICE_ID,
@@ -4930,9 +6929,10 @@ COMMANDS
     Delete the uploaded VCL for a particular service and version
 
         --name=NAME              The name of the VCL to delete
-        --version=VERSION        'latest', 'active', or the number of a specific
-                                 version
-        --autoclone              If the selected service version is not
+        --version=VERSION        'latest', 'active', 'draft', relative to latest
+                                 or active (e.g. 'latest-1'), 'tag:NAME',
+                                 or the number of a specific version
+        --[no-]autoclone         If the selected service version is not
                                  editable, clone it and use the clone.
     -s, --service-id=SERVICE-ID  Service ID (falls back to FASTLY_SERVICE_ID,
                                  then fastly.toml)
@@ -4943,9 +6943,12 @@ COMMANDS
     Get the uploaded VCL for a particular service and version
 
         --name=NAME              The name of the VCL
-        --version=VERSION        'latest', 'active', or the number of a specific
-                                 version
+        --version=VERSION        'latest', 'active', 'draft', relative to latest
+                                 or active (e.g. 'latest-1'), 'tag:NAME',
+                                 or the number of a specific version
     -j, --json                   Render output as JSON
+        --format=text            Output format (text, json, env)
+        --output=OUTPUT          Write the output to a file instead of stdout
     -s, --service-id=SERVICE-ID  Service ID (falls back to FASTLY_SERVICE_ID,
                                  then fastly.toml)
         --service-name=SERVICE-NAME
@@ -4954,8 +6957,9 @@ COMMANDS
   vcl custom list --version=VERSION [<flags>]
     List the uploaded VCLs for a particular service and version
 
-        --version=VERSION        'latest', 'active', or the number of a specific
-                                 version
+        --version=VERSION        'latest', 'active', 'draft', relative to latest
+                                 or active (e.g. 'latest-1'), 'tag:NAME',
+                                 or the number of a specific version
     -j, --json                   Render output as JSON
     -s, --service-id=SERVICE-ID  Service ID (falls back to FASTLY_SERVICE_ID,
                                  then fastly.toml)
@@ -4966,9 +6970,10 @@ COMMANDS
     Update the uploaded VCL for a particular service and version
 
         --name=NAME              The name of the VCL to update
-        --version=VERSION        'latest', 'active', or the number of a specific
-                                 version
-        --autoclone              If the selected service version is not
+        --version=VERSION        'latest', 'active', 'draft', relative to latest
+                                 or active (e.g. 'latest-1'), 'tag:NAME',
+                                 or the number of a specific version
+        --[no-]autoclone         If the selected service version is not
                                  editable, clone it and use the clone.
         --new-name=NEW-NAME      New name for the VCL
         --content=CONTENT        VCL passed as file path or content, e.g.
@@ -4978,21 +6983,60 @@ COMMANDS
         --service-name=SERVICE-NAME
                                  The name of the service
 
+  vcl generated diff --from=FROM --to=TO [<flags>]
+    Compare the VCL generated by Fastly for two versions of a service
+
+        --from=FROM              The version to compare from: 'latest',
+                                 'active', 'draft', relative to latest or active
+                                 (e.g. 'latest-1'), 'tag:NAME', or the number of
+                                 a specific version
+        --to=TO                  The version to compare to: 'latest', 'active',
+                                 'draft', relative to latest or active (e.g.
+                                 'latest-1'), 'tag:NAME', or the number of a
+                                 specific version
+    -s, --service-id=SERVICE-ID  Service ID (falls back to FASTLY_SERVICE_ID,
+                                 then fastly.toml)
+        --service-name=SERVICE-NAME
+                                 The name of the service
+
+  vcl generated download --version=VERSION [<flags>]
+    Download the VCL generated by Fastly for a particular service and version
+
+        --version=VERSION        'latest', 'active', 'draft', relative to latest
+                                 or active (e.g. 'latest-1'), 'tag:NAME',
+                                 or the number of a specific version
+        --output=OUTPUT          Path of the file to write the VCL to (otherwise
+                                 the VCL is written to stdout)
+    -s, --service-id=SERVICE-ID  Service ID (falls back to FASTLY_SERVICE_ID,
+                                 then fastly.toml)
+        --service-name=SERVICE-NAME
+                                 The name of the service
+
   vcl snippet create --content=CONTENT --name=NAME --version=VERSION --type=TYPE [<flags>]
     Create a snippet for a particular service and version
 
         --content=CONTENT        VCL snippet passed as file path or content,
                                  e.g. $(< snippet.vcl)
         --name=NAME              The name of the VCL snippet
-        --version=VERSION        'latest', 'active', or the number of a specific
-                                 version
+        --version=VERSION        'latest', 'active', 'draft', relative to latest
+                                 or active (e.g. 'latest-1'), 'tag:NAME',
+                                 or the number of a specific version
         --type=TYPE              The location in generated VCL where the snippet
                                  should be placed
-        --autoclone              If the selected service version is not
+        --[no-]autoclone         If the selected service version is not
                                  editable, clone it and use the clone.
         --dynamic                Whether the VCL snippet is dynamic or versioned
     -p, --priority=PRIORITY      Priority determines execution order. Lower
                                  numbers execute first
+        --include=INCLUDE ...    Path of a template the snippet can include
+                                 by file name, e.g. {{ template "acl.vcl" .
+                                 }} (can be repeated)
+        --set=SET ...            Set a template variable, overriding the
+                                 --values file, e.g. --set origin=example.com
+                                 (can be repeated)
+        --values=VALUES          Path of a TOML (or .json) file of template
+                                 variables, rendering the content as a Go
+                                 template
     -s, --service-id=SERVICE-ID  Service ID (falls back to FASTLY_SERVICE_ID,
                                  then fastly.toml)
         --service-name=SERVICE-NAME
@@ -5002,9 +7046,10 @@ COMMANDS
     Delete a specific snippet for a particular service and version
 
         --name=NAME              The name of the VCL snippet to delete
-        --version=VERSION        'latest', 'active', or the number of a specific
-                                 version
-        --autoclone              If the selected service version is not
+        --version=VERSION        'latest', 'active', 'draft', relative to latest
+                                 or active (e.g. 'latest-1'), 'tag:NAME',
+                                 or the number of a specific version
+        --[no-]autoclone         If the selected service version is not
                                  editable, clone it and use the clone.
     -s, --service-id=SERVICE-ID  Service ID (falls back to FASTLY_SERVICE_ID,
                                  then fastly.toml)
@@ -5014,10 +7059,13 @@ COMMANDS
   vcl snippet describe --version=VERSION [<flags>]
     Get the uploaded VCL snippet for a particular service and version
 
-        --version=VERSION        'latest', 'active', or the number of a specific
-                                 version
+        --version=VERSION        'latest', 'active', 'draft', relative to latest
+                                 or active (e.g. 'latest-1'), 'tag:NAME',
+                                 or the number of a specific version
         --dynamic                Whether the VCL snippet is dynamic or versioned
     -j, --json                   Render output as JSON
+        --format=text            Output format (text, json, env)
+        --output=OUTPUT          Write the output to a file instead of stdout
         --name=NAME              The name of the VCL snippet
     -s, --service-id=SERVICE-ID  Service ID (falls back to FASTLY_SERVICE_ID,
                                  then fastly.toml)
@@ -5028,20 +7076,36 @@ COMMANDS
   vcl snippet list --version=VERSION [<flags>]
     List the uploaded VCL snippets for a particular service and version
 
-        --version=VERSION        'latest', 'active', or the number of a specific
-                                 version
+        --version=VERSION        'latest', 'active', 'draft', relative to latest
+                                 or active (e.g. 'latest-1'), 'tag:NAME',
+                                 or the number of a specific version
     -j, --json                   Render output as JSON
     -s, --service-id=SERVICE-ID  Service ID (falls back to FASTLY_SERVICE_ID,
                                  then fastly.toml)
         --service-name=SERVICE-NAME
                                  The name of the service
 
+  vcl snippet render --content=CONTENT [<flags>]
+    Render a VCL snippet template, as the create and update commands do when
+    given template variables
+
+    --content=CONTENT      VCL snippet template passed as file path or content,
+                           e.g. $(< snippet.vcl)
+    --include=INCLUDE ...  Path of a template the snippet can include by
+                           file name, e.g. {{ template "acl.vcl" . }} (can be
+                           repeated)
+    --set=SET ...          Set a template variable, overriding the --values
+                           file, e.g. --set origin=example.com (can be repeated)
+    --values=VALUES        Path of a TOML (or .json) file of template variables,
+                           rendering the content as a Go template
+
   vcl snippet update --version=VERSION [<flags>]
     Update a VCL snippet for a particular service and version
 
-        --version=VERSION        'latest', 'active', or the number of a specific
-                                 version
-        --autoclone              If the selected service version is not
+        --version=VERSION        'latest', 'active', 'draft', relative to latest
+                                 or active (e.g. 'latest-1'), 'tag:NAME',
+                                 or the number of a specific version
+        --[no-]autoclone         If the selected service version is not
                                  editable, clone it and use the clone.
         --content=CONTENT        VCL snippet passed as file path or content,
                                  e.g. $(< snippet.vcl)
@@ -5055,6 +7119,15 @@ COMMANDS
         --service-name=SERVICE-NAME
                                  The name of the service
         --snippet-id=SNIPPET-ID  Alphanumeric string identifying a VCL Snippet
+        --include=INCLUDE ...    Path of a template the snippet can include
+                                 by file name, e.g. {{ template "acl.vcl" .
+                                 }} (can be repeated)
+        --set=SET ...            Set a template variable, overriding the
+                                 --values file, e.g. --set origin=example.com
+                                 (can be repeated)
+        --values=VALUES          Path of a TOML (or .json) file of template
+                                 variables, rendering the content as a Go
+                                 template
         --type=TYPE              The location in generated VCL where the snippet
                                  should be placed
 
@@ -5062,6 +7135,71 @@ COMMANDS
     Display version information for the Fastly CLI
 
 
+  waf events [<flags>]
+    List recent events recorded by the Next-Gen WAF workspace protecting a
+    service
+
+    -j, --json                   Render output as JSON
+        --limit=20               Maximum number of events to return
+    -s, --service-id=SERVICE-ID  Service ID (falls back to FASTLY_SERVICE_ID,
+                                 then fastly.toml)
+        --service-name=SERVICE-NAME
+                                 The name of the service
+        --since=1h               Only show events from within this duration
+                                 (e.g. 30m, 24h)
+
+  waf list [<flags>]
+    List the Next-Gen WAF workspaces of the account
+
+    -j, --json  Render output as JSON
+
+  waf mode --mode=MODE --workspace-id=WORKSPACE-ID
+    Switch a Next-Gen WAF workspace between blocking, logging and being disabled
+
+    --mode=MODE                  Whether the workspace blocks attacks, only logs
+                                 them, or is disabled
+    --workspace-id=WORKSPACE-ID  Alphanumeric string identifying the Next-Gen
+                                 WAF workspace
+
+  waf rule-exclusion create --path=PATH --signal=SIGNAL --workspace-id=WORKSPACE-ID [<flags>]
+    Create a rule exclusion on a Next-Gen WAF workspace
+
+    --path=PATH                  Request path the exclusion applies to (e.g.
+                                 /search)
+    --signal=SIGNAL ...          A comma-separated list of signals to exclude
+                                 (e.g. SQLI,XSS)
+    --workspace-id=WORKSPACE-ID  Alphanumeric string identifying the Next-Gen
+                                 WAF workspace
+    --description=DESCRIPTION    Description of the rule exclusion
+
+  waf rule-exclusion delete --id=ID --workspace-id=WORKSPACE-ID
+    Delete a rule exclusion from a Next-Gen WAF workspace
+
+    --id=ID                      Alphanumeric string identifying the rule
+                                 exclusion
+    --workspace-id=WORKSPACE-ID  Alphanumeric string identifying the Next-Gen
+                                 WAF workspace
+
+  waf rule-exclusion list --workspace-id=WORKSPACE-ID [<flags>]
+    List the rule exclusions on a Next-Gen WAF workspace
+
+        --workspace-id=WORKSPACE-ID
+                Alphanumeric string identifying the Next-Gen WAF workspace
+    -j, --json  Render output as JSON
+
+  waf rule-exclusion update --id=ID --workspace-id=WORKSPACE-ID [<flags>]
+    Update a rule exclusion on a Next-Gen WAF workspace
+
+    --id=ID                      Alphanumeric string identifying the rule
+                                 exclusion
+    --workspace-id=WORKSPACE-ID  Alphanumeric string identifying the Next-Gen
+                                 WAF workspace
+    --description=DESCRIPTION    Description of the rule exclusion
+    --path=PATH                  Request path the exclusion applies to (e.g.
+                                 /search)
+    --signal=SIGNAL ...          A comma-separated list of signals to exclude
+                                 (e.g. SQLI,XSS)
+
   whoami
     Get information about the currently authenticated account
 
@@ -7,12 +7,14 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"strconv"
 	"strings"
 	"text/template"
 
 	"github.com/fastly/cli/pkg/cmd"
 	"github.com/fastly/cli/pkg/config"
 	fsterr "github.com/fastly/cli/pkg/errors"
+	"github.com/fastly/cli/pkg/profile"
 	"github.com/fastly/cli/pkg/text"
 	"github.com/fastly/kingpin"
 )
@@ -90,6 +92,10 @@ var CompactUsageTemplate = `{{define "FormatCommand" -}}
 {{T "COMMANDS"|Bold}}
 {{.App.Commands|CommandsToTwoColumns|FormatTwoColumns}}
 {{end -}}
+{{with .Context.SelectedCommand|Examples -}}
+{{T "EXAMPLES"|Bold}}
+{{.}}
+{{end -}}
 {{T "SEE ALSO"|Bold}}
 {{.Context.SelectedCommand|SeeAlso}}
 `
@@ -127,6 +133,24 @@ var UsageTemplateFuncs = template.FuncMap{
 	"Bold": func(s string) string {
 		return text.Bold(s)
 	},
+	"Examples": func(cm *kingpin.CmdModel) string {
+		if cm == nil {
+			return ""
+		}
+		var data commandsMetadata
+		if err := json.Unmarshal(metadata, &data); err != nil {
+			return ""
+		}
+		segs := strings.Split(cm.FullCommand(), " ")
+		var b strings.Builder
+		for i, e := range commandExamples(cm.FullCommand(), recurse(len(segs), segs, data)) {
+			if i > 0 {
+				b.WriteString("\n")
+			}
+			fmt.Fprintf(&b, "  # %s\n  $ %s\n", e.Title, e.Cmd)
+		}
+		return b.String()
+	},
 	"SeeAlso": func(cm *kingpin.CmdModel) string {
 		cmd := cm.FullCommand()
 		url := "https://developer.fastly.com/reference/cli/"
@@ -144,13 +168,30 @@ var UsageTemplateFuncs = template.FuncMap{
 //
 // NOTE: This map is used to help populate the CLI 'usage' template renderer.
 var globalFlags = map[string]bool{
-	"accept-defaults": true,
-	"auto-yes":        true,
-	"help":            true,
-	"non-interactive": true,
-	"profile":         true,
-	"token":           true,
-	"verbose":         true,
+	"accept-defaults":     true,
+	"answer":              true,
+	"answers-file":        true,
+	"auto-yes":            true,
+	"color":               true,
+	"columns":             true,
+	"command-timeout":     true,
+	"dir":                 true,
+	"concurrency":         true,
+	"help":                true,
+	"interactive":         true,
+	"jq":                  true,
+	"log-file":            true,
+	"log-level":           true,
+	"non-interactive":     true,
+	"profile":             true,
+	"quiet":               true,
+	"service-id-file":     true,
+	"show-secrets":        true,
+	"sort-by":             true,
+	"strict-deprecations": true,
+	"theme":               true,
+	"token":               true,
+	"verbose":             true,
 }
 
 // VerboseUsageTemplate is the full-fat usage template, rendered when users type
@@ -208,7 +249,7 @@ func processCommandInput(
 	app *kingpin.Application,
 	globals *config.Data,
 	commands []cmd.Command,
-) (command cmd.Command, cmdName string, err error) {
+) (command cmd.Command, cmdName string, deprecated []cmd.Deprecation, err error) {
 	// As the `help` command model gets privately added as a side-effect of
 	// kingpin.Parse, we cannot add the `--format json` flag to the model.
 	// Therefore, we have to manually parse the args slice here to check for the
@@ -218,10 +259,21 @@ func processCommandInput(
 		j, err := UsageJSON(app)
 		if err != nil {
 			globals.ErrLog.Add(err)
-			return command, cmdName, err
+			return command, cmdName, deprecated, err
 		}
 		fmt.Fprintf(opts.Stdout, "%s", j)
-		return command, strings.Join(opts.Args, ""), nil
+		return command, strings.Join(opts.Args, ""), deprecated, nil
+	}
+	if cmd.ArgsIsHelpExitCodes(opts.Args) {
+		exitCodesHelp(opts.Stdout)
+		return command, "help exit-codes", deprecated, nil
+	}
+	if cmd.ArgsIsHelpGenerate(opts.Args) {
+		if err := generateHelp(app, opts.Args[2:], opts.Stdout); err != nil {
+			globals.ErrLog.Add(err)
+			return command, cmdName, deprecated, err
+		}
+		return command, "help generate", deprecated, nil
 	}
 
 	// Use partial application to generate help output function.
@@ -273,7 +325,7 @@ func processCommandInput(
 		if noargs || globalFlagsOnly {
 			err = fmt.Errorf("command not specified")
 		}
-		return command, cmdName, help(vars, err)
+		return command, cmdName, deprecated, help(vars, err)
 	}
 
 	// NOTE: The `fastly help` and `fastly --help` behaviours need to avoid
@@ -290,12 +342,52 @@ func processCommandInput(
 	if !cmd.IsHelpOnly(opts.Args) && !cmd.IsHelpFlagOnly(opts.Args) && !cmd.IsCompletion(opts.Args) && !cmd.IsCompletionScript(opts.Args) {
 		command, found = cmd.Select(ctx.SelectedCommand.FullCommand(), commands)
 		if !found {
-			return command, cmdName, help(vars, err)
+			return command, cmdName, deprecated, help(vars, err)
+		}
+
+		// Deprecated command names and flags are replaced before the args are
+		// inspected any further, e.g. for the FASTLY_ env vars.
+		opts.Args, deprecated = cmd.ReplaceDeprecated(ctx, opts.Args)
+		if len(deprecated) > 0 {
+			if strictDeprecations(ctx, globals) {
+				err := deprecated[0].Err()
+				globals.ErrLog.Add(err)
+				return command, cmdName, deprecated, err
+			}
+			ctx, err = app.ParseContext(opts.Args)
+			if err != nil {
+				return command, cmdName, deprecated, help(vars, err)
+			}
 		}
+
+		// Flags not set on the command line can be set via FASTLY_ env vars.
+		envArgs, err := cmd.EnvFlagArgs(ctx, app.Model().Flags, globals.Env.Flags)
+		if err != nil {
+			globals.ErrLog.Add(err)
+			return command, cmdName, deprecated, err
+		}
+		opts.Args = insertArgs(opts.Args, envArgs)
+
+		// Flags not set on the command line or via env vars can be set by the
+		// project settings.
+		opts.Args = insertArgs(opts.Args, cmd.SettingsArgs(ctx, envArgs, globals.Settings))
+
+		// --autoclone can be enabled by default for the project or the profile.
+		_, p := activeProfile(ctx, globals)
+		autoClone := p.AutoClone
+		if globals.Settings.AutoClone != nil {
+			autoClone = *globals.Settings.AutoClone
+		}
+		autoCloneArgs, err := cmd.AutoCloneArgs(ctx, envArgs, globals.Env.Flags, autoClone)
+		if err != nil {
+			globals.ErrLog.Add(err)
+			return command, cmdName, deprecated, err
+		}
+		opts.Args = insertArgs(opts.Args, autoCloneArgs)
 	}
 
 	if cmd.ContextHasHelpFlag(ctx) && !cmd.IsHelpFlagOnly(opts.Args) {
-		return command, cmdName, help(vars, nil)
+		return command, cmdName, deprecated, help(vars, nil)
 	}
 
 	// NOTE: app.Parse() resets the default values for app.Writers() from
@@ -324,7 +416,7 @@ func processCommandInput(
 
 	cmdName, err = app.Parse(opts.Args)
 	if err != nil {
-		return command, cmdName, help(vars, err)
+		return command, cmdName, deprecated, help(vars, err)
 	}
 
 	// Restore output writers
@@ -334,7 +426,7 @@ func processCommandInput(
 	// we allow it to call os.Exit, only if a completion flag is present.
 	if cmd.IsCompletion(opts.Args) || cmd.IsCompletionScript(opts.Args) {
 		app.Terminate(os.Exit)
-		return command, "shell-autocomplete", nil
+		return command, "shell-autocomplete", deprecated, nil
 	}
 
 	// A side-effect of suppressing app.Parse from writing output is the usage
@@ -358,16 +450,16 @@ func processCommandInput(
 			fmt.Fprintln(&buf, "")
 		}
 
-		return command, cmdName, fsterr.RemediationError{Prefix: buf.String()}
+		return command, cmdName, deprecated, fsterr.RemediationError{Prefix: buf.String()}
 	}
 
 	// Catch scenario where user wants to view help with the following format:
 	// fastly --help <command>
 	if cmd.IsHelpFlagOnly(opts.Args) {
-		return command, cmdName, help(vars, nil)
+		return command, cmdName, deprecated, help(vars, nil)
 	}
 
-	return command, cmdName, nil
+	return command, cmdName, deprecated, nil
 }
 
 //go:embed metadata.json
@@ -413,12 +505,9 @@ type flagJSON struct {
 	IsBool      bool   `json:"isBool"`
 }
 
-// Example represents a metadata.json command example.
-type Example struct {
-	Cmd         string `json:"cmd"`
-	Description string `json:"description,omitempty"`
-	Title       string `json:"title"`
-}
+// Example represents a command example, from either metadata.json or the
+// command itself.
+type Example = cmd.Example
 
 type commandJSON struct {
 	Name        string        `json:"name"`
@@ -468,28 +557,37 @@ func getCommandJSON(models []*kingpin.CmdModel, data commandsMetadata) []command
 			}
 		}
 
-		examples, ok := data["examples"]
+		cj.Examples = append(cj.Examples, commandExamples(m.FullCommand(), data)...)
+
+		cmds = append(cmds, cj)
+	}
+	return cmds
+}
+
+// commandExamples returns the examples of the command from its metadata.json
+// content, followed by those the command registered (see
+// cmd.Base.RegisterExamples).
+func commandExamples(command string, data commandsMetadata) []Example {
+	var cmdExamples []Example
+	examples, ok := data["examples"]
+	if ok {
+		examples, ok := examples.([]any)
 		if ok {
-			examples, ok := examples.([]any)
-			if ok {
-				for _, example := range examples {
-					c := resolveToString(example, "cmd")
-					d := resolveToString(example, "description")
-					t := resolveToString(example, "title")
-					if c != "" && t != "" {
-						cj.Examples = append(cj.Examples, Example{
-							Cmd:         c,
-							Description: d,
-							Title:       t,
-						})
-					}
+			for _, example := range examples {
+				c := resolveToString(example, "cmd")
+				d := resolveToString(example, "description")
+				t := resolveToString(example, "title")
+				if c != "" && t != "" {
+					cmdExamples = append(cmdExamples, Example{
+						Cmd:         c,
+						Description: d,
+						Title:       t,
+					})
 				}
 			}
 		}
-
-		cmds = append(cmds, cj)
 	}
-	return cmds
+	return append(cmdExamples, cmd.Examples(command)...)
 }
 
 // recurse simplifies the tree style traversal of a complex map.
@@ -565,7 +663,59 @@ func displayHelp(
 		if err != nil {
 			errLog.Add(err)
 			remediation.Inner = fmt.Errorf("error parsing arguments: %w", err)
+			remediation.Code = fsterr.ExitValidation
 		}
 		return remediation
 	}
 }
+
+// activeProfile returns the profile the command will use (see
+// config.Data.Profile), with the --profile flag (or its env var) falling back
+// to the project settings.
+//
+// NOTE: The flags have yet to be parsed, and so the --profile flag is read from
+// the parse context and resolved against a copy of the globals.
+func activeProfile(ctx *kingpin.ParseContext, globals *config.Data) (string, *config.Profile) {
+	d := *globals
+	if e := ctx.Elements.FlagMap()["profile"]; e != nil && e.Value != nil {
+		d.Flag.Profile = *e.Value
+	} else {
+		d.Flag.Profile = globals.Env.Flags[cmd.EnvFlagName("", "profile")]
+	}
+	if d.Flag.Profile == "" {
+		d.Flag.Profile = globals.Settings.Profile
+	}
+	return profile.Get(d.Profile(), globals.File.Profiles)
+}
+
+// strictDeprecations reports whether using a deprecated command or flag is an
+// error, via the --strict-deprecations flag (or its env var).
+//
+// NOTE: As with activeProfile, the flags have yet to be parsed, and so the
+// flag is read from the parse context.
+func strictDeprecations(ctx *kingpin.ParseContext, globals *config.Data) bool {
+	if e := ctx.Elements.FlagMap()["strict-deprecations"]; e != nil {
+		return e.Value == nil || *e.Value != "false"
+	}
+	strict, _ := strconv.ParseBool(globals.Env.Flags[cmd.EnvFlagName("", "strict-deprecations")])
+	return strict
+}
+
+// insertArgs inserts the flag arguments ahead of any `--` terminator, so they
+// aren't mistaken for positional arguments.
+func insertArgs(args, flags []string) []string {
+	if len(flags) == 0 {
+		return args
+	}
+	i := len(args)
+	for j, arg := range args {
+		if arg == "--" {
+			i = j
+			break
+		}
+	}
+	out := make([]string, 0, len(args)+len(flags))
+	out = append(out, args[:i]...)
+	out = append(out, flags...)
+	return append(out, args[i:]...)
+}
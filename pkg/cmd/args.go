@@ -0,0 +1,54 @@
+package cmd
+
+import (
+	"errors"
+	"strings"
+)
+
+// SplitArgs splits a command line into arguments on whitespace, treating
+// single or double quoted text as a single argument (e.g. for a
+// `--comment "from CI"` flag).
+func SplitArgs(s string) ([]string, error) {
+	var (
+		args    []string
+		b       strings.Builder
+		inArg   bool
+		quote   rune
+		escaped bool
+	)
+	for _, r := range s {
+		switch {
+		case escaped:
+			b.WriteRune(r)
+			escaped = false
+		case r == '\\' && quote != '\'':
+			escaped = true
+			inArg = true
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			} else {
+				b.WriteRune(r)
+			}
+		case r == '"' || r == '\'':
+			quote = r
+			inArg = true
+		case r == ' ' || r == '\t' || r == '\n':
+			if inArg {
+				args = append(args, b.String())
+				b.Reset()
+				inArg = false
+			}
+		default:
+			b.WriteRune(r)
+			inArg = true
+		}
+	}
+	if quote != 0 || escaped {
+		return nil, errors.New("unterminated quote or escape")
+	}
+	if inArg {
+		args = append(args, b.String())
+	}
+	return args, nil
+}
@@ -0,0 +1,44 @@
+package cmd_test
+
+import (
+	"testing"
+
+	"github.com/fastly/cli/pkg/cmd"
+	"github.com/fastly/cli/pkg/testutil"
+)
+
+func TestSplitArgs(t *testing.T) {
+	for _, testcase := range []struct {
+		input     string
+		want      []string
+		wantError string
+	}{
+		{
+			input: "",
+		},
+		{
+			input: "compute publish --non-interactive",
+			want:  []string{"compute", "publish", "--non-interactive"},
+		},
+		{
+			input: `  compute   deploy  --comment "from CI" `,
+			want:  []string{"compute", "deploy", "--comment", "from CI"},
+		},
+		{
+			input: `--comment "say \"hi\""`,
+			want:  []string{"--comment", `say "hi"`},
+		},
+		{
+			input: `--comment ''`,
+			want:  []string{"--comment", ""},
+		},
+		{
+			input:     `--comment 'it\'s'`,
+			wantError: "unterminated quote or escape",
+		},
+	} {
+		got, err := cmd.SplitArgs(testcase.input)
+		testutil.AssertErrorContains(t, err, testcase.wantError)
+		testutil.AssertEqual(t, testcase.want, got)
+	}
+}
@@ -202,6 +202,18 @@ func DisplayServiceID(sid, flag string, s manifest.Source, out io.Writer) {
 	text.Break(out)
 }
 
+// ArgsIsHelpExitCodes determines whether the supplied command arguments are
+// exactly `help exit-codes`.
+func ArgsIsHelpExitCodes(args []string) bool {
+	return len(args) == 2 && args[0] == "help" && args[1] == "exit-codes"
+}
+
+// ArgsIsHelpGenerate determines whether the supplied command arguments are
+// `help generate`, followed by its flags.
+func ArgsIsHelpGenerate(args []string) bool {
+	return len(args) >= 2 && args[0] == "help" && args[1] == "generate"
+}
+
 // ArgsIsHelpJSON determines whether the supplied command arguments are exactly
 // `help --format=json` or `help --format json`.
 func ArgsIsHelpJSON(args []string) bool {
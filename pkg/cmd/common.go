@@ -20,7 +20,7 @@ var (
 	// FlagVersionName is the flag name.
 	FlagVersionName = "version"
 	// FlagVersionDesc is the flag description.
-	FlagVersionDesc = "'latest', 'active', or the number of a specific version"
+	FlagVersionDesc = "'latest', 'active', 'draft', relative to latest or active (e.g. 'latest-1'), 'tag:NAME', or the number of a specific version"
 )
 
 // PaginationDirection is a list of directions the page results can be displayed.
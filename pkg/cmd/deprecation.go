@@ -0,0 +1,220 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	fsterr "github.com/fastly/cli/pkg/errors"
+	"github.com/fastly/kingpin"
+)
+
+// Deprecation describes a deprecated command name or flag, and its
+// replacement.
+type Deprecation struct {
+	// Command is the full name of the command, e.g. `service-version clone`.
+	Command string
+	// Flag reports whether Name is a flag of the command, rather than a
+	// deprecated name of the command itself.
+	Flag bool
+	// Name is the deprecated command name or flag (without the `--` prefix).
+	Name string
+	// Removal is the release the deprecated name will be removed in, if known.
+	Removal string
+	// Replacement is the flag (without the `--` prefix) or command name to use
+	// instead.
+	Replacement string
+}
+
+// Usage returns how the deprecated name is used on the command line, e.g.
+// `fastly service-version clone --old` or `fastly old-name`.
+func (d Deprecation) Usage() string {
+	if d.Flag {
+		return fmt.Sprintf("`fastly %s --%s`", d.Command, d.Name)
+	}
+	segs := strings.Split(d.Command, " ")
+	segs[len(segs)-1] = d.Name
+	return fmt.Sprintf("`fastly %s`", strings.Join(segs, " "))
+}
+
+// ReplacementUsage returns how the replacement is used on the command line.
+func (d Deprecation) ReplacementUsage() string {
+	if d.Flag {
+		return fmt.Sprintf("`fastly %s --%s`", d.Command, d.Replacement)
+	}
+	return fmt.Sprintf("`fastly %s`", d.Command)
+}
+
+// Warning returns the warning displayed when the deprecated name is used.
+func (d Deprecation) Warning() string {
+	msg := fmt.Sprintf("%s is deprecated, use %s instead.", d.Usage(), d.ReplacementUsage())
+	if d.Removal != "" {
+		msg += fmt.Sprintf(" It will be removed in %s.", d.Removal)
+	}
+	return msg
+}
+
+// Err returns the error returned when the deprecated name is used with
+// --strict-deprecations.
+func (d Deprecation) Err() error {
+	return fsterr.RemediationError{
+		Inner:       fmt.Errorf("%s is deprecated", d.Usage()),
+		Remediation: fmt.Sprintf("Use %s instead, or remove the --strict-deprecations flag.", d.ReplacementUsage()),
+		Code:        fsterr.ExitValidation,
+	}
+}
+
+// deprecations are the registered deprecations, keyed by the full command
+// name.
+//
+// NOTE: As with the examples, the registry is shared by every (possibly
+// concurrent) run of the application, and so it's guarded by a mutex.
+var deprecations = struct {
+	sync.RWMutex
+	m map[string][]Deprecation
+}{m: make(map[string][]Deprecation)}
+
+// register records the deprecation, replacing any previously registered for
+// the same command and name.
+func (d Deprecation) register() {
+	deprecations.Lock()
+	defer deprecations.Unlock()
+	ds := deprecations.m[d.Command]
+	for i, existing := range ds {
+		if existing.Flag == d.Flag && existing.Name == d.Name {
+			ds[i] = d
+			return
+		}
+	}
+	deprecations.m[d.Command] = append(ds, d)
+}
+
+// Deprecations returns the deprecations registered for the named command.
+func Deprecations(command string) []Deprecation {
+	deprecations.RLock()
+	defer deprecations.RUnlock()
+	return deprecations.m[command]
+}
+
+// RegisterDeprecatedName registers name as a deprecated name of the command,
+// e.g. after the command has been renamed. The deprecated name is accepted as
+// an alias of the command. Removal is the release the name will be removed in,
+// and can be empty.
+func (b Base) RegisterDeprecatedName(name, removal string) {
+	b.CmdClause.Alias(name)
+	command := commandName(b.CmdClause.FullCommand())
+	segs := strings.Split(command, " ")
+	Deprecation{
+		Command:     command,
+		Name:        name,
+		Removal:     removal,
+		Replacement: segs[len(segs)-1],
+	}.register()
+}
+
+// RegisterDeprecatedFlag registers name as a deprecated flag of the command,
+// which is replaced by the replacement flag. The deprecated flag is hidden
+// from the help output, and its value is passed to the replacement. Removal is
+// the release the flag will be removed in, and can be empty.
+//
+// NOTE: The replacement flag must be registered first, so that the deprecated
+// flag accepts the same kind of value.
+func (b Base) RegisterDeprecatedFlag(name, replacement, removal string) {
+	flag := b.CmdClause.Flag(name, fmt.Sprintf("Deprecated, use --%s", replacement)).Hidden()
+	var m *kingpin.ClauseModel
+	if f := b.CmdClause.GetFlag(replacement); f != nil {
+		m = f.Model()
+	}
+	switch {
+	case m != nil && m.IsNegatable():
+		flag.NegatableBool()
+	case m != nil && m.IsBoolFlag():
+		flag.Bool()
+	default:
+		flag.Strings()
+	}
+	Deprecation{
+		Command:     commandName(b.CmdClause.FullCommand()),
+		Flag:        true,
+		Name:        name,
+		Removal:     removal,
+		Replacement: replacement,
+	}.register()
+}
+
+// ReplaceDeprecated replaces the deprecated command names and flags of the
+// selected command in args with their replacements, and returns the
+// deprecations that were used.
+//
+// The deprecated names are accepted by the parser (as a command alias or a
+// hidden flag), so ctx tells us which were used. Replacing them in the args,
+// rather than mapping their values after parsing, ensures the replacement
+// behaves exactly as if the user had typed it, e.g. for required flags.
+func ReplaceDeprecated(ctx *kingpin.ParseContext, args []string) ([]string, []Deprecation) {
+	if ctx.SelectedCommand == nil {
+		return args, nil
+	}
+
+	var cmds []*kingpin.CmdClause
+	for _, e := range ctx.Elements {
+		if e.OneOf.Cmd != nil {
+			cmds = append(cmds, e.OneOf.Cmd)
+		}
+	}
+	set := ctx.Elements.FlagMap()
+
+	replaced := make([]string, len(args))
+	copy(replaced, args)
+	var used []Deprecation
+
+	// The command names are matched in order, and so a flag value can't be
+	// mistaken for a deprecated command name once its command has been found.
+	next := 0
+	for i, arg := range replaced {
+		if arg == "--" {
+			break
+		}
+		if strings.HasPrefix(arg, "-") {
+			name, value, hasValue := strings.Cut(strings.TrimPrefix(arg, "--"), "=")
+			negated := strings.HasPrefix(name, "no-")
+			for _, c := range cmds {
+				for _, d := range Deprecations(commandName(c.FullCommand())) {
+					if !d.Flag || set[d.Name] == nil {
+						continue
+					}
+					switch {
+					case name == d.Name:
+						replaced[i] = "--" + d.Replacement
+					case negated && strings.TrimPrefix(name, "no-") == d.Name:
+						replaced[i] = "--no-" + d.Replacement
+					default:
+						continue
+					}
+					if hasValue {
+						replaced[i] += "=" + value
+					}
+					used = append(used, d)
+				}
+			}
+			continue
+		}
+		if next >= len(cmds) {
+			continue
+		}
+		command := commandName(cmds[next].FullCommand())
+		segs := strings.Split(command, " ")
+		if arg == segs[len(segs)-1] {
+			next++
+			continue
+		}
+		for _, d := range Deprecations(command) {
+			if !d.Flag && arg == d.Name {
+				replaced[i] = d.Replacement
+				used = append(used, d)
+				next++
+				break
+			}
+		}
+	}
+	return replaced, used
+}
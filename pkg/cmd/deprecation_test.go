@@ -0,0 +1,86 @@
+package cmd_test
+
+import (
+	"testing"
+
+	"github.com/fastly/cli/pkg/cmd"
+	"github.com/fastly/cli/pkg/testutil"
+	"github.com/fastly/kingpin"
+)
+
+func TestReplaceDeprecated(t *testing.T) {
+	for _, testcase := range []struct {
+		name         string
+		args         []string
+		wantArgs     []string
+		wantWarnings []string
+	}{
+		{
+			name:     "no deprecations",
+			args:     []string{"widget", "create", "--name", "example", "--force"},
+			wantArgs: []string{"widget", "create", "--name", "example", "--force"},
+		},
+		{
+			name:         "deprecated flag",
+			args:         []string{"widget", "create", "--title", "example"},
+			wantArgs:     []string{"widget", "create", "--name", "example"},
+			wantWarnings: []string{"`fastly widget create --title` is deprecated, use `fastly widget create --name` instead. It will be removed in v11.0.0."},
+		},
+		{
+			name:         "deprecated flag with value",
+			args:         []string{"widget", "create", "--title=example"},
+			wantArgs:     []string{"widget", "create", "--name=example"},
+			wantWarnings: []string{"`fastly widget create --title` is deprecated, use `fastly widget create --name` instead. It will be removed in v11.0.0."},
+		},
+		{
+			name:         "deprecated negated boolean flag",
+			args:         []string{"widget", "create", "--name", "example", "--no-overwrite"},
+			wantArgs:     []string{"widget", "create", "--name", "example", "--no-force"},
+			wantWarnings: []string{"`fastly widget create --overwrite` is deprecated, use `fastly widget create --force` instead."},
+		},
+		{
+			name:     "deprecated command name",
+			args:     []string{"--verbose", "widgets", "create", "--title", "example"},
+			wantArgs: []string{"--verbose", "widget", "create", "--name", "example"},
+			wantWarnings: []string{
+				"`fastly widgets` is deprecated, use `fastly widget` instead.",
+				"`fastly widget create --title` is deprecated, use `fastly widget create --name` instead. It will be removed in v11.0.0.",
+			},
+		},
+		{
+			name:     "flag value matching a deprecated name",
+			args:     []string{"widget", "create", "--name", "widgets"},
+			wantArgs: []string{"widget", "create", "--name", "widgets"},
+		},
+	} {
+		t.Run(testcase.name, func(t *testing.T) {
+			app := kingpin.New("fastly", "")
+			app.Flag("verbose", "").Bool()
+			root := cmd.Base{CmdClause: app.Command("widget", "")}
+			root.RegisterDeprecatedName("widgets", "")
+			create := cmd.Base{CmdClause: root.CmdClause.Command("create", "")}
+			create.CmdClause.Flag("force", "").NegatableBool()
+			create.CmdClause.Flag("name", "").Required().String()
+			create.RegisterDeprecatedFlag("overwrite", "force", "")
+			create.RegisterDeprecatedFlag("title", "name", "v11.0.0")
+
+			ctx, err := app.ParseContext(testcase.args)
+			if err != nil {
+				t.Fatal(err)
+			}
+			args, used := cmd.ReplaceDeprecated(ctx, testcase.args)
+			testutil.AssertEqual(t, testcase.wantArgs, args)
+
+			var warnings []string
+			for _, d := range used {
+				warnings = append(warnings, d.Warning())
+			}
+			testutil.AssertEqual(t, testcase.wantWarnings, warnings)
+
+			// The replaced args must parse, e.g. satisfying the required flags.
+			if _, err := app.Parse(args); err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
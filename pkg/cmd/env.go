@@ -0,0 +1,164 @@
+package cmd
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/fastly/cli/pkg/env"
+	"github.com/fastly/cli/pkg/manifest"
+	"github.com/fastly/kingpin"
+)
+
+var envFlagRegExp = regexp.MustCompile(`[^A-Z0-9]+`)
+
+// envFlagExclusions are the global flags that already have a dedicated env
+// var (whose source is tracked separately) or are only meaningful on the
+// command line.
+//
+// NOTE: --service-id-file is excluded as a bulk run invokes the command again
+// for each service, which would otherwise pick up the env var and recurse.
+// --dir is excluded as it's applied before the env vars are read.
+var envFlagExclusions = map[string]bool{
+	"dir":             true,
+	"endpoint":        true,
+	"help":            true,
+	"service-id-file": true,
+	"token":           true,
+}
+
+// EnvFlagName returns the env var that provides a value for the flag of the
+// given command, e.g. FASTLY_COMPUTE_DEPLOY_COMMENT for `compute deploy
+// --comment`. An empty command returns the env var for a global flag, e.g.
+// FASTLY_NON_INTERACTIVE for `--non-interactive`.
+func EnvFlagName(command, flag string) string {
+	name := strings.ToUpper(strings.TrimSpace(command + " " + flag))
+	return env.FlagPrefix + envFlagRegExp.ReplaceAllString(name, "_")
+}
+
+// EnvFlagArgs returns the flag arguments for env vars that provide a value
+// for a flag of the selected command, or a global flag, that wasn't set on the
+// command line.
+//
+// Providing the values as arguments, rather than as kingpin defaults, ensures
+// flag actions and validation behave as if the user had typed them.
+//
+// Boolean flags accept any value understood by strconv.ParseBool.
+func EnvFlagArgs(ctx *kingpin.ParseContext, globals []*kingpin.ClauseModel, vars map[string]string) ([]string, error) {
+	if len(vars) == 0 || ctx.SelectedCommand == nil {
+		return nil, nil
+	}
+
+	set := ctx.Elements.FlagMap()
+	command := ctx.SelectedCommand.FullCommand()
+
+	var args []string
+	add := func(command string, flags []*kingpin.ClauseModel) error {
+		for _, f := range flags {
+			if f.Hidden || set[f.Name] != nil {
+				continue
+			}
+			name := EnvFlagName(command, f.Name)
+			v, ok := vars[name]
+			if !ok {
+				continue
+			}
+			if !f.IsBoolFlag() {
+				args = append(args, fmt.Sprintf("--%s=%s", f.Name, v))
+				continue
+			}
+			b, err := strconv.ParseBool(v)
+			if err != nil {
+				return fmt.Errorf("invalid boolean value '%s' for %s", v, name)
+			}
+			switch {
+			case b:
+				args = append(args, "--"+f.Name)
+			case f.IsNegatable():
+				args = append(args, "--no-"+f.Name)
+			}
+		}
+		return nil
+	}
+
+	if err := add(command, ctx.SelectedCommand.Model(nil).Flags); err != nil {
+		return nil, err
+	}
+
+	var gf []*kingpin.ClauseModel
+	for _, f := range globals {
+		if !envFlagExclusions[f.Name] {
+			gf = append(gf, f)
+		}
+	}
+	if err := add("", gf); err != nil {
+		return nil, err
+	}
+
+	return args, nil
+}
+
+// SettingsArgs returns the global flag arguments for the project settings
+// whose flags weren't set on the command line or by the given env var flag
+// arguments. Disabled settings are omitted as the flags default to false.
+//
+// NOTE: The quiet setting is ignored when --verbose is set, as the flags can't
+// be combined. The autoclone setting is applied by AutoCloneArgs, and the
+// non_interactive setting isn't a flag (see manifest.Settings).
+func SettingsArgs(ctx *kingpin.ParseContext, envArgs []string, s manifest.Settings) []string {
+	if ctx.SelectedCommand == nil {
+		return nil
+	}
+	var args []string
+	if s.Profile != "" && !flagSet(ctx, envArgs, "profile") {
+		args = append(args, "--profile="+s.Profile)
+	}
+	if s.Quiet != nil && *s.Quiet && !flagSet(ctx, envArgs, "quiet") && !flagSet(ctx, envArgs, "verbose") {
+		args = append(args, "--quiet")
+	}
+	return args
+}
+
+// AutoCloneArgs returns the --autoclone flag argument when autoclone is
+// enabled by default and the selected command has an --autoclone flag that
+// wasn't set on the command line or by the given env var flag arguments.
+//
+// Autoclone is enabled by default via the FASTLY_AUTOCLONE env var, which
+// takes precedence, or the given default (from the project settings or the
+// profile's autoclone setting).
+func AutoCloneArgs(ctx *kingpin.ParseContext, envArgs []string, vars map[string]string, enabledByDefault bool) ([]string, error) {
+	if ctx.SelectedCommand == nil || ctx.SelectedCommand.GetFlag("autoclone") == nil {
+		return nil, nil
+	}
+	if flagSet(ctx, envArgs, "autoclone") {
+		return nil, nil
+	}
+
+	enabled := enabledByDefault
+	if v, ok := vars[env.AutoClone]; ok {
+		b, err := strconv.ParseBool(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid boolean value '%s' for %s", v, env.AutoClone)
+		}
+		enabled = b
+	}
+	if !enabled {
+		return nil, nil
+	}
+	return []string{"--autoclone"}, nil
+}
+
+// flagSet reports whether the flag was set on the command line or by the
+// given env var flag arguments.
+func flagSet(ctx *kingpin.ParseContext, envArgs []string, name string) bool {
+	if ctx.Elements.FlagMap()[name] != nil {
+		return true
+	}
+	for _, arg := range envArgs {
+		if arg == "--"+name || arg == "--no-"+name || strings.HasPrefix(arg, "--"+name+"=") {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,227 @@
+package cmd_test
+
+import (
+	"testing"
+
+	"github.com/fastly/cli/pkg/cmd"
+	"github.com/fastly/cli/pkg/manifest"
+	"github.com/fastly/cli/pkg/testutil"
+	"github.com/fastly/kingpin"
+)
+
+func TestEnvFlagName(t *testing.T) {
+	testutil.AssertString(t, "FASTLY_COMPUTE_DEPLOY_COMMENT", cmd.EnvFlagName("compute deploy", "comment"))
+	testutil.AssertString(t, "FASTLY_LOGGING_S3_CREATE_SERVICE_ID", cmd.EnvFlagName("logging s3 create", "service-id"))
+	testutil.AssertString(t, "FASTLY_NON_INTERACTIVE", cmd.EnvFlagName("", "non-interactive"))
+}
+
+func TestEnvFlagArgs(t *testing.T) {
+	for _, testcase := range []struct {
+		name      string
+		args      []string
+		vars      map[string]string
+		wantArgs  []string
+		wantError string
+	}{
+		{
+			name: "no vars",
+			args: []string{"compute", "deploy"},
+		},
+		{
+			name: "command and global flags",
+			args: []string{"compute", "deploy"},
+			vars: map[string]string{
+				"FASTLY_COMPUTE_DEPLOY_COMMENT": "-from CI",
+				"FASTLY_COMPUTE_DEPLOY_DRY_RUN": "true",
+				"FASTLY_VERBOSE":                "1",
+				"FASTLY_COMMENT":                "ignored",
+				"FASTLY_TOKEN":                  "ignored",
+			},
+			wantArgs: []string{"--comment=-from CI", "--dry-run", "--verbose"},
+		},
+		{
+			name: "command line takes precedence",
+			args: []string{"compute", "deploy", "--comment", "foo", "-v"},
+			vars: map[string]string{
+				"FASTLY_COMPUTE_DEPLOY_COMMENT": "bar",
+				"FASTLY_VERBOSE":                "true",
+			},
+		},
+		{
+			name: "false boolean keeps the default",
+			args: []string{"compute", "deploy"},
+			vars: map[string]string{
+				"FASTLY_COMPUTE_DEPLOY_DRY_RUN": "false",
+			},
+		},
+		{
+			name: "invalid boolean",
+			args: []string{"compute", "deploy"},
+			vars: map[string]string{
+				"FASTLY_COMPUTE_DEPLOY_DRY_RUN": "nope",
+			},
+			wantError: "invalid boolean value 'nope' for FASTLY_COMPUTE_DEPLOY_DRY_RUN",
+		},
+	} {
+		t.Run(testcase.name, func(t *testing.T) {
+			app := kingpin.New("fastly", "")
+			app.Flag("token", "").String()
+			app.Flag("verbose", "").Short('v').Bool()
+			deploy := app.Command("compute", "").Command("deploy", "")
+			deploy.Flag("comment", "").String()
+			deploy.Flag("dry-run", "").Bool()
+
+			ctx, err := app.ParseContext(testcase.args)
+			if err != nil {
+				t.Fatal(err)
+			}
+			args, err := cmd.EnvFlagArgs(ctx, app.Model().Flags, testcase.vars)
+			testutil.AssertErrorContains(t, err, testcase.wantError)
+			testutil.AssertEqual(t, testcase.wantArgs, args)
+		})
+	}
+}
+
+func TestAutoCloneArgs(t *testing.T) {
+	for _, testcase := range []struct {
+		name      string
+		args      []string
+		envArgs   []string
+		vars      map[string]string
+		profile   bool
+		wantArgs  []string
+		wantError string
+	}{
+		{
+			name: "disabled",
+			args: []string{"backend", "create"},
+		},
+		{
+			name:     "enabled by the profile",
+			args:     []string{"backend", "create"},
+			profile:  true,
+			wantArgs: []string{"--autoclone"},
+		},
+		{
+			name:     "enabled by the env var",
+			args:     []string{"backend", "create"},
+			vars:     map[string]string{"FASTLY_AUTOCLONE": "1"},
+			wantArgs: []string{"--autoclone"},
+		},
+		{
+			name:    "env var takes precedence",
+			args:    []string{"backend", "create"},
+			vars:    map[string]string{"FASTLY_AUTOCLONE": "false"},
+			profile: true,
+		},
+		{
+			name:    "command line takes precedence",
+			args:    []string{"backend", "create", "--no-autoclone"},
+			profile: true,
+		},
+		{
+			name:    "command env var takes precedence",
+			args:    []string{"backend", "create"},
+			envArgs: []string{"--no-autoclone"},
+			profile: true,
+		},
+		{
+			name:    "command without the flag",
+			args:    []string{"backend", "list"},
+			profile: true,
+		},
+		{
+			name:      "invalid boolean",
+			args:      []string{"backend", "create"},
+			vars:      map[string]string{"FASTLY_AUTOCLONE": "nope"},
+			wantError: "invalid boolean value 'nope' for FASTLY_AUTOCLONE",
+		},
+	} {
+		t.Run(testcase.name, func(t *testing.T) {
+			app := kingpin.New("fastly", "")
+			backend := app.Command("backend", "")
+			backend.Command("create", "").Flag("autoclone", "").NegatableBool()
+			backend.Command("list", "")
+
+			ctx, err := app.ParseContext(testcase.args)
+			if err != nil {
+				t.Fatal(err)
+			}
+			args, err := cmd.AutoCloneArgs(ctx, testcase.envArgs, testcase.vars, testcase.profile)
+			testutil.AssertErrorContains(t, err, testcase.wantError)
+			testutil.AssertEqual(t, testcase.wantArgs, args)
+		})
+	}
+}
+
+func TestSettingsArgs(t *testing.T) {
+	enabled, disabled := true, false
+	for _, testcase := range []struct {
+		name     string
+		args     []string
+		envArgs  []string
+		settings manifest.Settings
+		wantArgs []string
+	}{
+		{
+			name: "no settings",
+			args: []string{"service", "list"},
+		},
+		{
+			name: "settings",
+			args: []string{"service", "list"},
+			settings: manifest.Settings{
+				NonInteractive: &enabled,
+				Profile:        "staging",
+				Quiet:          &enabled,
+			},
+			wantArgs: []string{"--profile=staging", "--quiet"},
+		},
+		{
+			name:     "disabled settings",
+			args:     []string{"service", "list"},
+			settings: manifest.Settings{Quiet: &disabled},
+		},
+		{
+			name:     "command line takes precedence",
+			args:     []string{"service", "list", "--quiet"},
+			settings: manifest.Settings{Quiet: &enabled},
+		},
+		{
+			name:     "env var takes precedence",
+			args:     []string{"service", "list"},
+			envArgs:  []string{"--no-quiet"},
+			settings: manifest.Settings{Quiet: &enabled},
+		},
+		{
+			name:     "profile command line takes precedence",
+			args:     []string{"service", "list", "--profile", "production"},
+			settings: manifest.Settings{Profile: "staging"},
+		},
+		{
+			name:     "profile env var takes precedence",
+			args:     []string{"service", "list"},
+			envArgs:  []string{"--profile=production"},
+			settings: manifest.Settings{Profile: "staging"},
+		},
+		{
+			name:     "quiet ignored when verbose",
+			args:     []string{"service", "list", "--verbose"},
+			settings: manifest.Settings{Quiet: &enabled},
+		},
+	} {
+		t.Run(testcase.name, func(t *testing.T) {
+			app := kingpin.New("fastly", "")
+			app.Flag("profile", "").String()
+			app.Flag("quiet", "").Bool()
+			app.Flag("verbose", "").Bool()
+			app.Command("service", "").Command("list", "")
+
+			ctx, err := app.ParseContext(testcase.args)
+			if err != nil {
+				t.Fatal(err)
+			}
+			testutil.AssertEqual(t, testcase.wantArgs, cmd.SettingsArgs(ctx, testcase.envArgs, testcase.settings))
+		})
+	}
+}
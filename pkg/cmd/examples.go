@@ -0,0 +1,56 @@
+package cmd
+
+import (
+	"strings"
+	"sync"
+)
+
+// Example is a runnable example of a command, shown in the command's help
+// output.
+type Example struct {
+	Cmd         string `json:"cmd"`
+	Description string `json:"description,omitempty"`
+	Title       string `json:"title"`
+}
+
+// examples are the registered examples, keyed by the full command name.
+//
+// NOTE: The registry is shared by every run of the application, which can be
+// concurrent (e.g. in parallel tests), and so it's guarded by a mutex.
+var examples = struct {
+	sync.RWMutex
+	m map[string][]Example
+}{m: make(map[string][]Example)}
+
+// RegisterExamples registers runnable examples of the command, which are shown
+// in its help output. Each example is the full command line, starting with
+// `fastly` followed by the command, e.g. `fastly service list --per-page 10`.
+//
+// NOTE: Every run of the application defines its commands afresh, and so the
+// examples replace any previously registered for the command.
+func (b Base) RegisterExamples(e ...Example) {
+	examples.Lock()
+	defer examples.Unlock()
+	examples.m[commandName(b.CmdClause.FullCommand())] = e
+}
+
+// Examples returns the examples registered for the named command.
+func Examples(command string) []Example {
+	examples.RLock()
+	defer examples.RUnlock()
+	return examples.m[command]
+}
+
+// commandName strips the arguments (e.g. `<method>` or `[<name>]`) from the
+// full command of a kingpin.CmdClause, leaving the command name as reported by
+// kingpin.CmdModel.FullCommand.
+func commandName(fullCommand string) string {
+	var segs []string
+	for _, seg := range strings.Fields(fullCommand) {
+		if strings.HasPrefix(seg, "<") || strings.HasPrefix(seg, "[") || seg == "..." {
+			continue
+		}
+		segs = append(segs, seg)
+	}
+	return strings.Join(segs, " ")
+}
@@ -97,11 +97,21 @@ func (sv *OptionalServiceVersion) Parse(sid string, client api.Interface) (*fast
 
 	var v *fastly.Version
 
+	if name, ok := cutVersionTagPrefix(sv.Value); ok {
+		return GetTaggedVersion(vs, name)
+	}
+
+	if m := relativeVersionRegExp.FindStringSubmatch(strings.ToLower(sv.Value)); m != nil {
+		return getRelativeVersion(vs, m[1], m[2])
+	}
+
 	switch strings.ToLower(sv.Value) {
 	case "latest":
 		return vs[0], nil
 	case "active":
 		v, err = GetActiveVersion(vs)
+	case "draft":
+		v, err = GetDraftVersion(vs)
 	case "": // no --version flag provided
 		v, err = GetActiveVersion(vs)
 		if err != nil {
@@ -166,8 +176,11 @@ type AutoCloneFlagOpts struct {
 
 // RegisterAutoCloneFlag defines a --autoclone flag that will cause a clone of the
 // identified service version if it's found to be active or locked.
+//
+// The flag is negatable, so --no-autoclone overrides autoclone being enabled
+// by default (see AutoCloneArgs).
 func (b Base) RegisterAutoCloneFlag(opts AutoCloneFlagOpts) {
-	b.CmdClause.Flag("autoclone", "If the selected service version is not editable, clone it and use the clone.").Action(opts.Action).BoolVar(opts.Dst)
+	b.CmdClause.Flag("autoclone", "If the selected service version is not editable, clone it and use the clone.").Action(opts.Action).NegatableBoolVar(opts.Dst)
 }
 
 // OptionalAutoClone defines a method set for abstracting the logic required to
@@ -217,6 +230,45 @@ func GetActiveVersion(vs []*fastly.Version) (*fastly.Version, error) {
 	return nil, fmt.Errorf("no active service version found")
 }
 
+// relativeVersionRegExp matches a version relative to the latest or active
+// version, e.g. latest-1 is the version before the latest.
+var relativeVersionRegExp = regexp.MustCompile(`^(latest|active)-(\d+)$`)
+
+// getRelativeVersion returns the version the given number of versions before
+// the latest or active version. The versions must be in descending order.
+func getRelativeVersion(vs []*fastly.Version, base, offset string) (*fastly.Version, error) {
+	n, err := strconv.Atoi(offset)
+	if err != nil {
+		return nil, err
+	}
+	from := vs[0]
+	if base == "active" {
+		from, err = GetActiveVersion(vs)
+		if err != nil {
+			return nil, err
+		}
+	}
+	v, err := GetSpecifiedVersion(vs, strconv.Itoa(from.Number-n))
+	if err != nil {
+		return nil, fmt.Errorf("specified service version not found: %s-%d (version %d)", base, n, from.Number-n)
+	}
+	return v, nil
+}
+
+// GetDraftVersion returns the most recent version that can be edited (i.e.
+// it's neither active nor locked). The versions must be in descending order.
+func GetDraftVersion(vs []*fastly.Version) (*fastly.Version, error) {
+	for _, v := range vs {
+		if !v.Active && !v.Locked {
+			return v, nil
+		}
+	}
+	return nil, fsterr.RemediationError{
+		Inner:       fmt.Errorf("no draft service version found"),
+		Remediation: "Clone a version with `fastly service-version clone`, or use --autoclone.",
+	}
+}
+
 // GetSpecifiedVersion returns the specified service version.
 func GetSpecifiedVersion(vs []*fastly.Version, version string) (*fastly.Version, error) {
 	i, err := strconv.Atoi(version)
@@ -233,6 +285,127 @@ func GetSpecifiedVersion(vs []*fastly.Version, version string) (*fastly.Version,
 	return nil, fmt.Errorf("specified service version not found: %s", version)
 }
 
+// VersionTagPrefix is the prefix of a --version flag value that refers to a
+// version by its tag (e.g. tag:release-2024-06-01).
+const VersionTagPrefix = "tag:"
+
+// versionTagRegExp matches the tags recorded in a version comment, which use
+// the [tag:NAME] convention so a version can hold other comments and tags.
+var versionTagRegExp = regexp.MustCompile(`\[tag:([A-Za-z0-9._-]+)\]`)
+
+// VersionTagNameRegExp matches a valid tag name.
+var VersionTagNameRegExp = regexp.MustCompile(`^[A-Za-z0-9._-]+$`)
+
+// cutVersionTagPrefix returns the tag name from a --version flag value of the
+// form tag:NAME.
+func cutVersionTagPrefix(value string) (string, bool) {
+	if len(value) < len(VersionTagPrefix) || !strings.EqualFold(value[:len(VersionTagPrefix)], VersionTagPrefix) {
+		return "", false
+	}
+	return value[len(VersionTagPrefix):], true
+}
+
+// VersionTags returns the tags recorded in a version comment.
+func VersionTags(comment string) []string {
+	var tags []string
+	for _, m := range versionTagRegExp.FindAllStringSubmatch(comment, -1) {
+		tags = append(tags, m[1])
+	}
+	return tags
+}
+
+// AddVersionTag returns the version comment with the tag recorded, unless the
+// comment already has it.
+func AddVersionTag(comment, tag string) string {
+	for _, t := range VersionTags(comment) {
+		if t == tag {
+			return comment
+		}
+	}
+	marker := fmt.Sprintf("[%s%s]", VersionTagPrefix, tag)
+	if comment == "" {
+		return marker
+	}
+	return comment + " " + marker
+}
+
+// RemoveVersionTag returns the version comment without the tag.
+func RemoveVersionTag(comment, tag string) string {
+	marker := fmt.Sprintf("[%s%s]", VersionTagPrefix, tag)
+	return strings.Join(strings.Fields(strings.ReplaceAll(comment, marker, "")), " ")
+}
+
+// versionMetadataRegExp matches the metadata recorded in a version comment,
+// which uses the [meta:KEY=VALUE] convention alongside the tags.
+var versionMetadataRegExp = regexp.MustCompile(`\[meta:([A-Za-z0-9._-]+)=([^\]\n]*)\]`)
+
+// VersionMetadata returns the metadata recorded in a version comment.
+func VersionMetadata(comment string) map[string]string {
+	metadata := make(map[string]string)
+	for _, m := range versionMetadataRegExp.FindAllStringSubmatch(comment, -1) {
+		metadata[m[1]] = m[2]
+	}
+	return metadata
+}
+
+// ValidateVersionMetadata checks the metadata can be recorded in a version
+// comment.
+func ValidateVersionMetadata(metadata map[string]string) error {
+	for k, v := range metadata {
+		if !VersionTagNameRegExp.MatchString(k) || strings.ContainsAny(v, "]\n") {
+			return fsterr.RemediationError{
+				Inner:       fmt.Errorf("invalid metadata: %s=%s", k, v),
+				Remediation: "Use only letters, digits, '.', '_' and '-' in the metadata key, and no ']' or newline in the value.",
+			}
+		}
+	}
+	return nil
+}
+
+// SetVersionMetadata returns the version comment with the metadata recorded,
+// replacing any metadata already recorded (e.g. by the deploy of the version
+// it was cloned from).
+func SetVersionMetadata(comment string, metadata map[string]string) string {
+	comment = strings.Join(strings.Fields(versionMetadataRegExp.ReplaceAllString(comment, "")), " ")
+
+	keys := make([]string, 0, len(metadata))
+	for k := range metadata {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	markers := make([]string, 0, len(keys)+1)
+	if comment != "" {
+		markers = append(markers, comment)
+	}
+	for _, k := range keys {
+		markers = append(markers, fmt.Sprintf("[meta:%s=%s]", k, metadata[k]))
+	}
+	return strings.Join(markers, " ")
+}
+
+// GetTaggedVersion returns the most recent version with the given tag.
+//
+// NOTE: A tag can be moved to a newer version by tagging it, without removing
+// the tag from the older version, and so the most recent tagged version wins.
+func GetTaggedVersion(vs []*fastly.Version, tag string) (*fastly.Version, error) {
+	var tagged *fastly.Version
+	for _, v := range vs {
+		for _, t := range VersionTags(v.Comment) {
+			if t == tag && (tagged == nil || v.Number > tagged.Number) {
+				tagged = v
+			}
+		}
+	}
+	if tagged == nil {
+		return nil, fsterr.RemediationError{
+			Inner:       fmt.Errorf("no service version found with tag: %s", tag),
+			Remediation: "Tag a version with `fastly service-version tag --version <number> --name <tag>`.",
+		}
+	}
+	return tagged, nil
+}
+
 // Content determines if the given flag value is a file path, and if so read
 // the contents from disk, otherwise presume the given value is the content.
 func Content(flagval string) string {
@@ -47,6 +47,34 @@ func TestOptionalServiceVersionParse(t *testing.T) {
 			flagValue:   "4",
 			errExpected: true, // there is no version 4
 		},
+		"latest relative OK": {
+			flagValue:   "latest-1",
+			wantVersion: 2,
+		},
+		"latest relative ERR": {
+			flagValue:   "latest-3",
+			errExpected: true, // there is no version 0
+		},
+		"active relative OK": {
+			flagValue:   "active-0",
+			wantVersion: 1,
+		},
+		"active relative ERR": {
+			flagValue:   "active-1",
+			errExpected: true, // there is no version 0
+		},
+		"draft": {
+			flagValue:   "draft",
+			wantVersion: 3,
+		},
+		"tag OK": {
+			flagValue:   "tag:stable",
+			wantVersion: 2,
+		},
+		"tag ERR": {
+			flagValue:   "tag:missing",
+			errExpected: true,
+		},
 	}
 
 	for name, c := range cases {
@@ -99,6 +127,7 @@ func listVersions(i *fastly.ListVersionsInput) ([]*fastly.Version, error) {
 			Number:    2,
 			Active:    false,
 			Locked:    true,
+			Comment:   "Release [tag:stable]",
 			UpdatedAt: testutil.MustParseTimeRFC3339("2000-01-02T01:00:00Z"),
 		},
 		{
@@ -333,3 +362,36 @@ func cloneVersionResult(version int) func(i *fastly.CloneVersionInput) (*fastly.
 func errMatches(version int, err error) bool {
 	return err.Error() == fmt.Sprintf("service version %d is not editable", version)
 }
+
+func TestVersionTags(t *testing.T) {
+	comment := cmd.AddVersionTag("Release", "v1")
+	testutil.AssertString(t, "Release [tag:v1]", comment)
+	testutil.AssertString(t, comment, cmd.AddVersionTag(comment, "v1"))
+
+	comment = cmd.AddVersionTag(comment, "stable")
+	testutil.AssertEqual(t, []string{"v1", "stable"}, cmd.VersionTags(comment))
+
+	testutil.AssertString(t, "Release [tag:stable]", cmd.RemoveVersionTag(comment, "v1"))
+	testutil.AssertString(t, "[tag:v1]", cmd.AddVersionTag("", "v1"))
+}
+
+func TestVersionMetadata(t *testing.T) {
+	comment := cmd.SetVersionMetadata("Release [tag:v1]", map[string]string{
+		"git_sha":   "abc123",
+		"build_url": "https://ci.example.com/builds/1",
+	})
+	testutil.AssertString(t, "Release [tag:v1] [meta:build_url=https://ci.example.com/builds/1] [meta:git_sha=abc123]", comment)
+	testutil.AssertEqual(t, map[string]string{
+		"build_url": "https://ci.example.com/builds/1",
+		"git_sha":   "abc123",
+	}, cmd.VersionMetadata(comment))
+	testutil.AssertEqual(t, []string{"v1"}, cmd.VersionTags(comment))
+
+	// Setting the metadata replaces the metadata already recorded.
+	comment = cmd.SetVersionMetadata(comment, map[string]string{"git_sha": "def456"})
+	testutil.AssertString(t, "Release [tag:v1] [meta:git_sha=def456]", comment)
+
+	testutil.AssertNoError(t, cmd.ValidateVersionMetadata(map[string]string{"git_sha": "a b"}))
+	testutil.AssertErrorContains(t, cmd.ValidateVersionMetadata(map[string]string{"git sha": "abc"}), "invalid metadata: git sha=abc")
+	testutil.AssertErrorContains(t, cmd.ValidateVersionMetadata(map[string]string{"note": "[x]"}), "invalid metadata: note=[x]")
+}
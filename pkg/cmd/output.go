@@ -0,0 +1,234 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+
+	fsterr "github.com/fastly/cli/pkg/errors"
+)
+
+// The formats accepted by the --format flag.
+const (
+	FormatEnv  = "env"
+	FormatJSON = "json"
+	FormatText = "text"
+)
+
+// Output represents the flags that control how a describe command renders the
+// resource it fetched.
+//
+// The --json flag is kept as a shorthand for --format json, while --format env
+// renders each field as a KEY=value line that a shell can source, e.g.
+// FASTLY_BACKEND_ADDRESS=example.com.
+type Output struct {
+	File   string
+	Format string
+	JSON   bool
+
+	prefix string
+}
+
+// RegisterOutputFlags defines the --format and --output flags.
+//
+// NOTE: The --output flag has no short flag because -o is already taken by
+// the global --profile flag.
+func (b Base) RegisterOutputFlags(o *Output) {
+	o.prefix = EnvPrefix(b.CmdClause.FullCommand())
+	b.CmdClause.Flag("format", "Output format (text, json, env)").Default(FormatText).EnumVar(&o.Format, FormatText, FormatJSON, FormatEnv)
+	b.CmdClause.Flag("output", "Write the output to a file instead of stdout").StringVar(&o.File)
+}
+
+// Structured reports whether the output should be rendered as JSON or
+// environment variables rather than text.
+func (o Output) Structured() bool {
+	return o.JSON || o.Format != "" && o.Format != FormatText
+}
+
+// Writer validates the flags and returns where the output should be written,
+// which is either out or a temporary file that replaces the --output file once
+// the command succeeds. The returned function must be called with the error
+// of the command, which it returns, or otherwise the error of writing the
+// file.
+//
+// NOTE: Writing to a temporary file means that an existing --output file is
+// left as is when the command fails (e.g. the API request is unauthorized).
+func (o Output) Writer(out io.Writer) (io.Writer, func(error) error, error) {
+	if o.JSON && o.Format == FormatEnv {
+		return nil, nil, fsterr.RemediationError{
+			Inner:       fmt.Errorf("invalid flag combination, --json and --format env"),
+			Remediation: "Use either --json or --format, not both.",
+		}
+	}
+	if o.File == "" {
+		return out, func(err error) error { return err }, nil
+	}
+
+	// NOTE: The file is only readable by the current user as the output can
+	// contain credentials (e.g. when --show-secrets is set), which
+	// os.CreateTemp ensures.
+	path := filepath.Clean(o.File)
+	f, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*")
+	if err != nil {
+		return nil, nil, fmt.Errorf("error creating output file: %w", err)
+	}
+	finish := func(err error) error {
+		if err != nil {
+			_ = f.Close()
+			_ = os.Remove(f.Name())
+			return err
+		}
+		if err := f.Sync(); err != nil {
+			_ = f.Close()
+			_ = os.Remove(f.Name())
+			return fmt.Errorf("error writing output file: %w", err)
+		}
+		if err := f.Close(); err != nil {
+			_ = os.Remove(f.Name())
+			return fmt.Errorf("error writing output file: %w", err)
+		}
+		if err := os.Rename(f.Name(), path); err != nil {
+			_ = os.Remove(f.Name())
+			return fmt.Errorf("error writing output file: %w", err)
+		}
+		return nil
+	}
+	return f, finish, nil
+}
+
+// Write renders v in the structured format selected by the flags.
+func (o Output) Write(out io.Writer, v any) error {
+	if o.Format == FormatEnv {
+		return WriteEnv(out, o.prefix, v)
+	}
+
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	_, err = out.Write(data)
+	if err != nil {
+		return fmt.Errorf("error: unable to write data to stdout: %w", err)
+	}
+	return nil
+}
+
+// EnvPrefix returns the environment variable prefix for a command, e.g.
+// "logging s3 describe" becomes FASTLY_LOGGING_S3_.
+func EnvPrefix(command string) string {
+	var segments []string
+	for _, s := range strings.Fields(command) {
+		if !strings.HasPrefix(s, "<") && !strings.HasPrefix(s, "[") {
+			segments = append(segments, s)
+		}
+	}
+	if len(segments) > 1 {
+		segments = segments[:len(segments)-1]
+	}
+	return "FASTLY_" + EnvName(strings.Join(segments, "_")) + "_"
+}
+
+// envUnsafe matches the characters that aren't valid in a variable name.
+var envUnsafe = regexp.MustCompile(`[^A-Z0-9]+`)
+
+// EnvName converts a field name into an environment variable name, e.g.
+// ServiceID becomes SERVICE_ID and SSLClientKey becomes SSL_CLIENT_KEY.
+func EnvName(s string) string {
+	rs := []rune(s)
+	var b strings.Builder
+	for i, r := range rs {
+		if i > 0 && unicode.IsUpper(r) {
+			prev := rs[i-1]
+			nextLower := i+1 < len(rs) && unicode.IsLower(rs[i+1])
+			if unicode.IsLower(prev) || unicode.IsDigit(prev) || (unicode.IsUpper(prev) && nextLower) {
+				b.WriteRune('_')
+			}
+		}
+		b.WriteRune(unicode.ToUpper(r))
+	}
+	return strings.Trim(envUnsafe.ReplaceAllString(b.String(), "_"), "_")
+}
+
+// WriteEnv writes v as sorted KEY=value lines, one per field.
+//
+// Nested objects are flattened by joining the field names with an underscore,
+// lists of values are joined with a comma and lists of objects are indexed
+// (e.g. FASTLY_DICTIONARY_ITEMS_0_ITEM_KEY).
+func WriteEnv(out io.Writer, prefix string, v any) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	var decoded any
+	d := json.NewDecoder(strings.NewReader(string(data)))
+	d.UseNumber()
+	if err := d.Decode(&decoded); err != nil {
+		return err
+	}
+
+	vars := make(map[string]string)
+	flattenEnv(strings.TrimSuffix(prefix, "_"), decoded, vars)
+
+	keys := make([]string, 0, len(vars))
+	for k := range vars {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		if _, err := fmt.Fprintf(out, "%s=%s\n", k, shellQuote(vars[k])); err != nil {
+			return fmt.Errorf("error: unable to write data to stdout: %w", err)
+		}
+	}
+	return nil
+}
+
+// flattenEnv adds the decoded JSON value v to vars under the given key.
+func flattenEnv(key string, v any, vars map[string]string) {
+	switch t := v.(type) {
+	case map[string]any:
+		for k, v := range t {
+			flattenEnv(key+"_"+EnvName(k), v, vars)
+		}
+	case []any:
+		values := make([]string, 0, len(t))
+		for i, v := range t {
+			switch v.(type) {
+			case map[string]any, []any:
+				flattenEnv(fmt.Sprintf("%s_%d", key, i), v, vars)
+			default:
+				values = append(values, envValue(v))
+			}
+		}
+		if len(values) > 0 || len(t) == 0 {
+			vars[key] = strings.Join(values, ",")
+		}
+	default:
+		vars[key] = envValue(v)
+	}
+}
+
+// envValue formats a decoded JSON scalar.
+func envValue(v any) string {
+	if v == nil {
+		return ""
+	}
+	return fmt.Sprint(v)
+}
+
+// shellSafe matches values that don't need quoting in a shell.
+var shellSafe = regexp.MustCompile(`^[A-Za-z0-9_./:@%+,=-]*$`)
+
+// shellQuote single quotes s unless it only contains safe characters.
+func shellQuote(s string) string {
+	if shellSafe.MatchString(s) {
+		return s
+	}
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
@@ -0,0 +1,101 @@
+package cmd_test
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/fastly/cli/pkg/cmd"
+	"github.com/fastly/cli/pkg/testutil"
+)
+
+func TestEnvName(t *testing.T) {
+	for input, want := range map[string]string{
+		"Address":         "ADDRESS",
+		"ServiceID":       "SERVICE_ID",
+		"SSLClientKey":    "SSL_CLIENT_KEY",
+		"MinTLSVersion":   "MIN_TLS_VERSION",
+		"service_id":      "SERVICE_ID",
+		"logging_s3":      "LOGGING_S3",
+		"service-version": "SERVICE_VERSION",
+	} {
+		testutil.AssertEqual(t, want, cmd.EnvName(input))
+	}
+}
+
+func TestEnvPrefix(t *testing.T) {
+	testutil.AssertEqual(t, "FASTLY_BACKEND_", cmd.EnvPrefix("backend describe"))
+	testutil.AssertEqual(t, "FASTLY_LOGGING_S3_", cmd.EnvPrefix("logging s3 describe"))
+	testutil.AssertEqual(t, "FASTLY_TLS_CUSTOM_ACTIVATION_", cmd.EnvPrefix("tls-custom activation describe"))
+}
+
+func TestWriteEnv(t *testing.T) {
+	v := struct {
+		Name     string
+		Comment  string
+		Port     uint
+		UseSSL   bool
+		Shield   *string
+		Hosts    []string
+		Settings map[string]any
+		Items    []struct{ ItemKey string }
+	}{
+		Name:     "test.com",
+		Comment:  "it's a test",
+		Port:     443,
+		UseSSL:   true,
+		Hosts:    []string{"a.com", "b.com"},
+		Settings: map[string]any{"max_age": 60},
+		Items:    []struct{ ItemKey string }{{"foo"}},
+	}
+
+	var out bytes.Buffer
+	if err := cmd.WriteEnv(&out, "FASTLY_BACKEND_", v); err != nil {
+		t.Fatal(err)
+	}
+
+	want := `FASTLY_BACKEND_COMMENT='it'\''s a test'
+FASTLY_BACKEND_HOSTS=a.com,b.com
+FASTLY_BACKEND_ITEMS_0_ITEM_KEY=foo
+FASTLY_BACKEND_NAME=test.com
+FASTLY_BACKEND_PORT=443
+FASTLY_BACKEND_SETTINGS_MAX_AGE=60
+FASTLY_BACKEND_SHIELD=
+FASTLY_BACKEND_USE_SSL=true
+`
+	testutil.AssertString(t, want, out.String())
+}
+
+func TestOutputWriter(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "out.env")
+	if err := os.WriteFile(path, []byte("previous"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	o := cmd.Output{File: path}
+
+	// A failed command leaves the existing file as is.
+	w, finish, err := o.Writer(nil)
+	testutil.AssertNoError(t, err)
+	fmt.Fprint(w, "partial")
+	testutil.AssertErrorContains(t, finish(errors.New("unauthorized")), "unauthorized")
+	data, err := os.ReadFile(path)
+	testutil.AssertNoError(t, err)
+	testutil.AssertString(t, "previous", string(data))
+
+	w, finish, err = o.Writer(nil)
+	testutil.AssertNoError(t, err)
+	fmt.Fprint(w, "FOO=bar")
+	testutil.AssertNoError(t, finish(nil))
+	data, err = os.ReadFile(path)
+	testutil.AssertNoError(t, err)
+	testutil.AssertString(t, "FOO=bar", string(data))
+
+	// The temporary files are removed either way.
+	entries, err := os.ReadDir(dir)
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, 1, len(entries))
+}
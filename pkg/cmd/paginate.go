@@ -0,0 +1,130 @@
+package cmd
+
+import (
+	"fmt"
+
+	fsterr "github.com/fastly/cli/pkg/errors"
+)
+
+// MaxPerPage is the largest page size accepted by the Fastly API.
+const MaxPerPage = 100
+
+// Pagination represents the flags that control which pages of a paginated API
+// endpoint are fetched.
+//
+// Every page is fetched unless a specific --page is requested, in which case
+// only that page is returned. The --all flag spells out the default and
+// conflicts with --page.
+type Pagination struct {
+	All     bool
+	Page    int
+	PerPage int
+}
+
+// RegisterAllFlag defines the --all flag.
+//
+// NOTE: It's registered separately from the --page and --per-page flags so
+// that each flag can be kept in alphabetical order with a command's other
+// flags.
+func (b Base) RegisterAllFlag(p *Pagination) {
+	b.CmdClause.Flag("all", "Fetch every page of the data set (the default unless --page is set)").BoolVar(&p.All)
+}
+
+// RegisterPageFlags defines the --page and --per-page flags.
+func (b Base) RegisterPageFlags(p *Pagination) {
+	b.CmdClause.Flag("page", "Page number of data set to fetch (only that page is returned)").IntVar(&p.Page)
+	b.CmdClause.Flag("per-page", "Number of records per page").IntVar(&p.PerPage)
+}
+
+// Validate checks the pagination flags aren't in conflict.
+func (p Pagination) Validate() error {
+	if p.All && p.Page > 0 {
+		return fsterr.RemediationError{
+			Inner:       fmt.Errorf("invalid flag combination, --all and --page"),
+			Remediation: "Use either --all or --page, not both.",
+		}
+	}
+	return nil
+}
+
+// Paginator is the behaviour shared by the go-fastly list paginators (e.g.
+// fastly.PaginatorServices).
+type Paginator[T any] interface {
+	HasNext() bool
+	GetNext() ([]T, error)
+	Remaining() int
+}
+
+// Paginate collects the results from a go-fastly paginator.
+//
+// The paginator starts from the page set on its input, so a specific --page
+// stops iteration after that single page. Otherwise every page is fetched.
+// Results collected before an error occurred are returned alongside it.
+func Paginate[T any](p Paginator[T], opts Pagination) ([]T, error) {
+	var rs []T
+	for p.HasNext() {
+		data, err := p.GetNext()
+		if err != nil {
+			return rs, err
+		}
+		rs = append(rs, data...)
+		if opts.Page > 0 {
+			break
+		}
+	}
+	return rs, nil
+}
+
+// FetchPages collects the results from a page-numbered API endpoint.
+//
+// A specific --page is fetched on its own. Otherwise pages are requested from
+// the first onwards until one comes back with fewer records than requested.
+//
+// NOTE: The API silently caps the page size, so a larger --per-page would
+// make the first page look like the last. The page size is clamped to
+// MaxPerPage to avoid truncating the results.
+func FetchPages[T any](opts Pagination, fetch func(page, perPage int) ([]T, error)) ([]T, error) {
+	if opts.Page > 0 {
+		return fetch(opts.Page, opts.PerPage)
+	}
+
+	perPage := opts.PerPage
+	if perPage <= 0 || perPage > MaxPerPage {
+		perPage = MaxPerPage
+	}
+
+	var rs []T
+	for page := 1; ; page++ {
+		data, err := fetch(page, perPage)
+		if err != nil {
+			return rs, err
+		}
+		rs = append(rs, data...)
+		if len(data) < perPage {
+			return rs, nil
+		}
+	}
+}
+
+// FetchCursor collects the results from a cursor-based API endpoint.
+//
+// The fetch function is passed the cursor to request (empty for the first
+// page) and returns that page's records along with the cursor for the next
+// page, which is empty once there is nothing left to fetch.
+func FetchCursor[T any](fetch func(cursor string) ([]T, string, error)) ([]T, error) {
+	var (
+		cursor string
+		rs     []T
+	)
+	for {
+		data, next, err := fetch(cursor)
+		if err != nil {
+			return rs, err
+		}
+		rs = append(rs, data...)
+		if next == "" || next == cursor {
+			return rs, nil
+		}
+		cursor = next
+	}
+}
@@ -0,0 +1,113 @@
+package cmd_test
+
+import (
+	"testing"
+
+	"github.com/fastly/cli/pkg/cmd"
+	"github.com/fastly/cli/pkg/testutil"
+)
+
+func TestFetchPages(t *testing.T) {
+	records := []int{1, 2, 3, 4, 5}
+
+	fetch := func(calls *[]int) func(page, perPage int) ([]int, error) {
+		return func(page, perPage int) ([]int, error) {
+			*calls = append(*calls, page)
+			if page < 1 {
+				page = 1
+			}
+			if perPage < 1 {
+				perPage = len(records)
+			}
+			start := (page - 1) * perPage
+			if start >= len(records) {
+				return nil, nil
+			}
+			end := start + perPage
+			if end > len(records) {
+				end = len(records)
+			}
+			return records[start:end], nil
+		}
+	}
+
+	for name, tc := range map[string]struct {
+		opts      cmd.Pagination
+		wantCalls []int
+		want      []int
+	}{
+		"default": {
+			opts:      cmd.Pagination{PerPage: 2},
+			wantCalls: []int{1, 2, 3},
+			want:      records,
+		},
+		"page": {
+			opts:      cmd.Pagination{Page: 2, PerPage: 2},
+			wantCalls: []int{2},
+			want:      []int{3, 4},
+		},
+		"all": {
+			opts:      cmd.Pagination{All: true, PerPage: 2},
+			wantCalls: []int{1, 2, 3},
+			want:      records,
+		},
+		"all with exact final page": {
+			opts:      cmd.Pagination{All: true, PerPage: 5},
+			wantCalls: []int{1, 2},
+			want:      records,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			var calls []int
+			got, err := cmd.FetchPages(tc.opts, fetch(&calls))
+			testutil.AssertNoError(t, err)
+			testutil.AssertEqual(t, tc.want, got)
+			testutil.AssertEqual(t, tc.wantCalls, calls)
+		})
+	}
+}
+
+func TestFetchPagesClampsPerPage(t *testing.T) {
+	var sizes []int
+	_, err := cmd.FetchPages(cmd.Pagination{PerPage: 500}, func(_, perPage int) ([]int, error) {
+		sizes = append(sizes, perPage)
+		return nil, nil
+	})
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, []int{cmd.MaxPerPage}, sizes)
+}
+
+func TestFetchPagesError(t *testing.T) {
+	got, err := cmd.FetchPages(cmd.Pagination{All: true, PerPage: 1}, func(page, _ int) ([]int, error) {
+		if page == 2 {
+			return nil, testutil.Err
+		}
+		return []int{page}, nil
+	})
+	testutil.AssertErrorContains(t, err, testutil.Err.Error())
+	testutil.AssertEqual(t, []int{1}, got)
+}
+
+func TestFetchCursor(t *testing.T) {
+	pages := map[string]struct {
+		data []string
+		next string
+	}{
+		"":   {data: []string{"a", "b"}, next: "c1"},
+		"c1": {data: []string{"c"}, next: "c2"},
+		"c2": {data: []string{"d"}},
+	}
+
+	got, err := cmd.FetchCursor(func(cursor string) ([]string, string, error) {
+		p := pages[cursor]
+		return p.data, p.next, nil
+	})
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, []string{"a", "b", "c", "d"}, got)
+}
+
+func TestPaginationValidate(t *testing.T) {
+	testutil.AssertNoError(t, cmd.Pagination{All: true}.Validate())
+	testutil.AssertNoError(t, cmd.Pagination{Page: 2}.Validate())
+	testutil.AssertErrorContains(t, cmd.Pagination{All: true, Page: 2}.Validate(), "invalid flag combination, --all and --page")
+}
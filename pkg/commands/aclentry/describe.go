@@ -1,7 +1,6 @@
 package aclentry
 
 import (
-	"encoding/json"
 	"fmt"
 	"io"
 
@@ -27,9 +26,10 @@ func NewDescribeCommand(parent cmd.Registerer, globals *config.Data, data manife
 	c.RegisterFlagBool(cmd.BoolFlagOpts{
 		Name:        cmd.FlagJSONName,
 		Description: cmd.FlagJSONDesc,
-		Dst:         &c.json,
+		Dst:         &c.output.JSON,
 		Short:       'j',
 	})
+	c.RegisterOutputFlags(&c.output)
 	c.RegisterFlag(cmd.StringFlagOpts{
 		Name:        cmd.FlagServiceIDName,
 		Description: cmd.FlagServiceIDDesc,
@@ -52,17 +52,25 @@ type DescribeCommand struct {
 
 	aclID       string
 	id          string
-	json        bool
+	output      cmd.Output
 	manifest    manifest.Data
 	serviceName cmd.OptionalServiceNameID
 }
 
 // Exec invokes the application logic for the command.
-func (c *DescribeCommand) Exec(_ io.Reader, out io.Writer) error {
-	if c.Globals.Verbose() && c.json {
+func (c *DescribeCommand) Exec(_ io.Reader, out io.Writer) (err error) {
+	if c.Globals.Verbose() && c.output.Structured() {
 		return fsterr.ErrInvalidVerboseJSONCombo
 	}
 
+	out, closeOutput, err := c.output.Writer(out)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err = closeOutput(err)
+	}()
+
 	serviceID, source, flag, err := cmd.ServiceID(c.serviceName, c.manifest, c.Globals.APIClient, c.Globals.ErrLog)
 	if err != nil {
 		return err
@@ -101,15 +109,10 @@ func (c *DescribeCommand) constructInput(serviceID string) *fastly.GetACLEntryIn
 
 // print displays the information returned from the API.
 func (c *DescribeCommand) print(out io.Writer, a *fastly.ACLEntry) error {
-	if c.json {
-		data, err := json.Marshal(a)
-		if err != nil {
-			return err
-		}
-		_, err = out.Write(data)
-		if err != nil {
+	if c.output.Structured() {
+		if err := c.output.Write(out, a); err != nil {
 			c.Globals.ErrLog.Add(err)
-			return fmt.Errorf("error: unable to write data to stdout: %w", err)
+			return err
 		}
 		return nil
 	}
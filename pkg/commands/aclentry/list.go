@@ -24,6 +24,7 @@ func NewListCommand(parent cmd.Registerer, globals *config.Data, data manifest.D
 	c.CmdClause.Flag("acl-id", "Alphanumeric string identifying a ACL").Required().StringVar(&c.aclID)
 
 	// Optional Flags
+	c.RegisterAllFlag(&c.pagination)
 	c.CmdClause.Flag("direction", "Direction in which to sort results").Default(cmd.PaginationDirection[0]).HintOptions(cmd.PaginationDirection...).EnumVar(&c.direction, cmd.PaginationDirection...)
 	c.RegisterFlagBool(cmd.BoolFlagOpts{
 		Name:        cmd.FlagJSONName,
@@ -31,8 +32,7 @@ func NewListCommand(parent cmd.Registerer, globals *config.Data, data manifest.D
 		Dst:         &c.json,
 		Short:       'j',
 	})
-	c.CmdClause.Flag("page", "Page number of data set to fetch").IntVar(&c.page)
-	c.CmdClause.Flag("per-page", "Number of records per page").IntVar(&c.perPage)
+	c.RegisterPageFlags(&c.pagination)
 	c.RegisterFlag(cmd.StringFlagOpts{
 		Name:        cmd.FlagServiceIDName,
 		Description: cmd.FlagServiceIDDesc,
@@ -58,8 +58,7 @@ type ListCommand struct {
 	direction   string
 	json        bool
 	manifest    manifest.Data
-	page        int
-	perPage     int
+	pagination  cmd.Pagination
 	serviceName cmd.OptionalServiceNameID
 	sort        string
 }
@@ -69,6 +68,9 @@ func (c *ListCommand) Exec(_ io.Reader, out io.Writer) error {
 	if c.Globals.Verbose() && c.json {
 		return fsterr.ErrInvalidVerboseJSONCombo
 	}
+	if err := c.pagination.Validate(); err != nil {
+		return err
+	}
 
 	serviceID, source, flag, err := cmd.ServiceID(c.serviceName, c.manifest, c.Globals.APIClient, c.Globals.ErrLog)
 	if err != nil {
@@ -81,20 +83,14 @@ func (c *ListCommand) Exec(_ io.Reader, out io.Writer) error {
 	input := c.constructInput(serviceID)
 	paginator := c.Globals.APIClient.NewListACLEntriesPaginator(input)
 
-	// TODO: Use generics support in go 1.18 to replace this almost identical
-	// logic inside of 'dictionary-item list' and 'service list'.
-	var as []*fastly.ACLEntry
-	for paginator.HasNext() {
-		data, err := paginator.GetNext()
-		if err != nil {
-			c.Globals.ErrLog.AddWithContext(err, map[string]any{
-				"ACL ID":          c.aclID,
-				"Service ID":      serviceID,
-				"Remaining Pages": paginator.Remaining(),
-			})
-			return err
-		}
-		as = append(as, data...)
+	as, err := cmd.Paginate[*fastly.ACLEntry](paginator, c.pagination)
+	if err != nil {
+		c.Globals.ErrLog.AddWithContext(err, map[string]any{
+			"ACL ID":          c.aclID,
+			"Service ID":      serviceID,
+			"Remaining Pages": paginator.Remaining(),
+		})
+		return err
 	}
 
 	if c.Globals.Verbose() {
@@ -114,8 +110,8 @@ func (c *ListCommand) constructInput(serviceID string) *fastly.ListACLEntriesInp
 
 	input.ACLID = c.aclID
 	input.Direction = c.direction
-	input.Page = c.page
-	input.PerPage = c.perPage
+	input.Page = c.pagination.Page
+	input.PerPage = c.pagination.PerPage
 	input.ServiceID = serviceID
 	input.Sort = c.sort
 
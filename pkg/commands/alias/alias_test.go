@@ -0,0 +1,167 @@
+package alias_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/fastly/cli/pkg/app"
+	"github.com/fastly/cli/pkg/config"
+	"github.com/fastly/cli/pkg/testutil"
+	toml "github.com/pelletier/go-toml"
+)
+
+func TestAlias(t *testing.T) {
+	args := testutil.Args
+	scenarios := []struct {
+		testutil.TestScenario
+		aliases     config.Aliases
+		wantAliases config.Aliases
+	}{
+		{
+			TestScenario: testutil.TestScenario{
+				Name:       "list with no aliases",
+				Args:       args("alias list"),
+				WantOutput: "No aliases defined.",
+			},
+		},
+		{
+			TestScenario: testutil.TestScenario{
+				Name:       "list",
+				Args:       args("alias list"),
+				WantOutput: "NAME  COMMAND\ncdp   compute publish -i\n",
+			},
+			aliases: config.Aliases{"cdp": "compute publish -i"},
+		},
+		{
+			TestScenario: testutil.TestScenario{
+				Name:       "list as json",
+				Args:       args("alias list --json"),
+				WantOutput: `{"cdp":"compute publish -i"}`,
+			},
+			aliases: config.Aliases{"cdp": "compute publish -i"},
+		},
+		{
+			TestScenario: testutil.TestScenario{
+				Name:       "set",
+				Args:       args("alias set cdp compute"),
+				WantOutput: "Alias 'cdp' set to 'compute'",
+			},
+			wantAliases: config.Aliases{"cdp": "compute"},
+		},
+		{
+			TestScenario: testutil.TestScenario{
+				Name:       "set referring to another alias",
+				Args:       args("alias set x cdp"),
+				WantOutput: "Alias 'x' set to 'cdp'",
+			},
+			aliases:     config.Aliases{"cdp": "compute publish -i"},
+			wantAliases: config.Aliases{"cdp": "compute publish -i", "x": "cdp"},
+		},
+		{
+			TestScenario: testutil.TestScenario{
+				Name:      "set cannot shadow a command",
+				Args:      args("alias set compute service"),
+				WantError: "invalid alias name 'compute': it is the name of a command",
+			},
+		},
+		{
+			TestScenario: testutil.TestScenario{
+				Name:      "set with an invalid name",
+				Args:      args("alias set c.d service"),
+				WantError: "invalid alias name 'c.d'",
+			},
+		},
+		{
+			TestScenario: testutil.TestScenario{
+				Name:      "set with an unknown command",
+				Args:      args("alias set x nope"),
+				WantError: "invalid alias command: 'nope' is not a command or alias",
+			},
+		},
+		{
+			TestScenario: testutil.TestScenario{
+				Name:       "unset",
+				Args:       args("alias unset cdp"),
+				WantOutput: "Alias 'cdp' deleted",
+			},
+			aliases:     config.Aliases{"cdp": "compute publish -i", "x": "service"},
+			wantAliases: config.Aliases{"x": "service"},
+		},
+		{
+			TestScenario: testutil.TestScenario{
+				Name:      "unset unknown alias",
+				Args:      args("alias unset cdp"),
+				WantError: "the specified alias does not exist",
+			},
+		},
+		{
+			TestScenario: testutil.TestScenario{
+				Name:       "expand alias",
+				Args:       args("al"),
+				WantOutput: "NAME  COMMAND\nal    alias list\n",
+			},
+			aliases: config.Aliases{"al": "alias list"},
+		},
+		{
+			TestScenario: testutil.TestScenario{
+				Name:       "expand alias after global flags and before its own flags",
+				Args:       args("--columns name al --json"),
+				WantOutput: `{"al":"alias list"}`,
+			},
+			aliases: config.Aliases{"al": "alias list"},
+		},
+		{
+			TestScenario: testutil.TestScenario{
+				Name:       "expand chained aliases",
+				Args:       args("--columns name a"),
+				WantOutput: "NAME\na\nal\n",
+			},
+			aliases: config.Aliases{"a": "al", "al": "alias list"},
+		},
+		{
+			TestScenario: testutil.TestScenario{
+				Name:      "expand looping aliases",
+				Args:      args("a"),
+				WantError: "alias 'a' refers to itself",
+			},
+			aliases: config.Aliases{"a": "b", "b": "a"},
+		},
+		{
+			TestScenario: testutil.TestScenario{
+				Name:       "aliases do not shadow commands",
+				Args:       args("alias list --json"),
+				WantOutput: `{"alias":"version"}`,
+			},
+			aliases: config.Aliases{"alias": "version"},
+		},
+	}
+
+	for testcaseIdx := range scenarios {
+		testcase := &scenarios[testcaseIdx]
+		t.Run(testcase.Name, func(t *testing.T) {
+			configPath := filepath.Join(t.TempDir(), "config.toml")
+
+			var stdout bytes.Buffer
+			opts := testutil.NewRunOpts(testcase.Args, &stdout)
+			opts.ConfigPath = configPath
+			opts.ConfigFile = config.File{Aliases: testcase.aliases}
+			err := app.Run(opts)
+			testutil.AssertErrorContains(t, err, testcase.WantError)
+			testutil.AssertStringContains(t, stdout.String(), testcase.WantOutput)
+
+			if testcase.wantAliases != nil {
+				data, err := os.ReadFile(configPath)
+				if err != nil {
+					t.Fatal(err)
+				}
+				var f config.File
+				if err := toml.Unmarshal(data, &f); err != nil {
+					t.Fatal(err)
+				}
+				testutil.AssertEqual(t, testcase.wantAliases, f.Aliases)
+			}
+		})
+	}
+}
@@ -0,0 +1,2 @@
+// Package alias contains commands to manage user-defined command aliases.
+package alias
@@ -0,0 +1,72 @@
+package alias
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/fastly/cli/pkg/cmd"
+	"github.com/fastly/cli/pkg/config"
+	"github.com/fastly/cli/pkg/text"
+)
+
+// ListCommand represents a Kingpin command.
+type ListCommand struct {
+	cmd.Base
+	json bool
+}
+
+// NewListCommand returns a usable command registered under the parent.
+func NewListCommand(parent cmd.Registerer, globals *config.Data) *ListCommand {
+	var c ListCommand
+	c.Globals = globals
+	c.CmdClause = parent.Command("list", "List command aliases")
+	c.RegisterFlagBool(cmd.BoolFlagOpts{
+		Name:        cmd.FlagJSONName,
+		Description: cmd.FlagJSONDesc,
+		Dst:         &c.json,
+		Short:       'j',
+	})
+	return &c
+}
+
+// Exec invokes the application logic for the command.
+func (c *ListCommand) Exec(_ io.Reader, out io.Writer) error {
+	aliases := c.Globals.File.Aliases
+
+	if c.json {
+		if aliases == nil {
+			aliases = config.Aliases{}
+		}
+		data, err := json.Marshal(aliases)
+		if err != nil {
+			return err
+		}
+		_, err = out.Write(data)
+		if err != nil {
+			c.Globals.ErrLog.Add(err)
+			return fmt.Errorf("error: unable to write data to stdout: %w", err)
+		}
+		return nil
+	}
+
+	if len(aliases) == 0 {
+		text.Description(out, "No aliases defined. To create an alias, run", "fastly alias set <name> <command>")
+		return nil
+	}
+
+	names := make([]string, 0, len(aliases))
+	for name := range aliases {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	t := text.NewTable(out)
+	t.AddHeader("NAME", "COMMAND")
+	for _, name := range names {
+		t.AddLine(name, aliases[name])
+	}
+	t.Print()
+	return nil
+}
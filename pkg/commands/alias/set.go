@@ -0,0 +1,70 @@
+package alias
+
+import (
+	"fmt"
+	"io"
+	"regexp"
+
+	"github.com/fastly/cli/pkg/cmd"
+	"github.com/fastly/cli/pkg/config"
+	"github.com/fastly/cli/pkg/text"
+)
+
+var nameRegExp = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9_-]*$`)
+
+// SetCommand represents a Kingpin command.
+type SetCommand struct {
+	cmd.Base
+
+	builtin func(name string) bool
+	command string
+	name    string
+}
+
+// NewSetCommand returns a usable command registered under the parent.
+//
+// The builtin function reports whether a name is a top-level command, which
+// an alias isn't allowed to shadow.
+func NewSetCommand(parent cmd.Registerer, globals *config.Data, builtin func(name string) bool) *SetCommand {
+	var c SetCommand
+	c.Globals = globals
+	c.builtin = builtin
+	c.CmdClause = parent.Command("set", "Create or update a command alias (e.g. fastly alias set cdp \"compute publish -i\")")
+	c.CmdClause.Arg("name", "Alias name").Required().StringVar(&c.name)
+	c.CmdClause.Arg("command", "Command line the alias expands to (quote it to include flags)").Required().StringVar(&c.command)
+	return &c
+}
+
+// Exec invokes the application logic for the command.
+func (c *SetCommand) Exec(_ io.Reader, out io.Writer) error {
+	if !nameRegExp.MatchString(c.name) {
+		return fmt.Errorf("invalid alias name '%s': use letters, digits, '-' and '_'", c.name)
+	}
+	if c.builtin(c.name) {
+		return fmt.Errorf("invalid alias name '%s': it is the name of a command", c.name)
+	}
+
+	args, err := cmd.SplitArgs(c.command)
+	if err != nil {
+		return fmt.Errorf("invalid alias command: %w", err)
+	}
+	if len(args) == 0 {
+		return fmt.Errorf("invalid alias command: it must not be empty")
+	}
+	if _, ok := c.Globals.File.Aliases[args[0]]; !ok && !c.builtin(args[0]) {
+		return fmt.Errorf("invalid alias command: '%s' is not a command or alias", args[0])
+	}
+
+	if c.Globals.File.Aliases == nil {
+		c.Globals.File.Aliases = make(config.Aliases)
+	}
+	c.Globals.File.Aliases[c.name] = c.command
+
+	if err := c.Globals.File.Write(c.Globals.Path); err != nil {
+		c.Globals.ErrLog.Add(err)
+		return err
+	}
+
+	text.Success(out, "Alias '%s' set to '%s'", c.name, c.command)
+	return nil
+}
@@ -0,0 +1,42 @@
+package alias
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/fastly/cli/pkg/cmd"
+	"github.com/fastly/cli/pkg/config"
+	"github.com/fastly/cli/pkg/text"
+)
+
+// UnsetCommand represents a Kingpin command.
+type UnsetCommand struct {
+	cmd.Base
+
+	name string
+}
+
+// NewUnsetCommand returns a usable command registered under the parent.
+func NewUnsetCommand(parent cmd.Registerer, globals *config.Data) *UnsetCommand {
+	var c UnsetCommand
+	c.Globals = globals
+	c.CmdClause = parent.Command("unset", "Delete a command alias")
+	c.CmdClause.Arg("name", "Alias name").Required().StringVar(&c.name)
+	return &c
+}
+
+// Exec invokes the application logic for the command.
+func (c *UnsetCommand) Exec(_ io.Reader, out io.Writer) error {
+	if _, ok := c.Globals.File.Aliases[c.name]; !ok {
+		return fmt.Errorf("the specified alias does not exist")
+	}
+	delete(c.Globals.File.Aliases, c.name)
+
+	if err := c.Globals.File.Write(c.Globals.Path); err != nil {
+		c.Globals.ErrLog.Add(err)
+		return err
+	}
+
+	text.Success(out, "Alias '%s' deleted", c.name)
+	return nil
+}
@@ -0,0 +1,228 @@
+package apicall_test
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/fastly/cli/pkg/app"
+	"github.com/fastly/cli/pkg/commands/apicall"
+	"github.com/fastly/cli/pkg/mock"
+	"github.com/fastly/cli/pkg/testutil"
+)
+
+func TestAPI(t *testing.T) {
+	var slept []time.Duration
+	apicall.Sleep = func(d time.Duration) { slept = append(slept, d) }
+	defer func() { apicall.Sleep = time.Sleep }()
+
+	bodyFile := filepath.Join(t.TempDir(), "body.json")
+	if err := os.WriteFile(bodyFile, []byte(`{"name":"example"}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	args := testutil.Args
+	scenarios := []struct {
+		name            string
+		args            []string
+		stdin           string
+		responses       []response
+		wantError       string
+		wantOutput      string
+		wantMethod      string
+		wantPath        string
+		wantBody        string
+		wantContentType string
+		wantHeader      [2]string
+		wantRequests    int
+		wantSlept       []time.Duration
+	}{
+		{
+			name:      "validate missing token",
+			args:      args("api GET /service"),
+			wantError: "no token provided",
+		},
+		{
+			name:      "validate unsupported method",
+			args:      args("api --token x TRACE /service"),
+			wantError: "error parsing arguments: unsupported method 'TRACE'",
+		},
+		{
+			name:      "validate relative path",
+			args:      args("api --token x GET https://example.com/service"),
+			wantError: "the path 'https://example.com/service' must start with /",
+		},
+		{
+			name:      "validate missing service ID",
+			args:      args("api --token x GET /service/%s/version"),
+			wantError: "error reading service: no service ID found",
+		},
+		{
+			name:      "validate invalid header",
+			args:      args("api --token x GET /service -H nocolon"),
+			wantError: "error parsing arguments: invalid --header 'nocolon'",
+		},
+		{
+			name:         "validate GET with service ID and pretty-printed JSON",
+			args:         args("api --token x get /service/%s/version --service-id 123"),
+			responses:    []response{{body: `[{"number":1},{"number":2}]`}},
+			wantMethod:   http.MethodGet,
+			wantPath:     "/service/123/version",
+			wantOutput:   "[\n  {\n    \"number\": 1\n  },\n  {\n    \"number\": 2\n  }\n]\n",
+			wantRequests: 1,
+		},
+		{
+			name:         "validate curl alias and raw output",
+			args:         args("curl --token x GET /service --raw"),
+			responses:    []response{{body: `{"id":"123"}`}},
+			wantOutput:   "{\"id\":\"123\"}\n",
+			wantRequests: 1,
+		},
+		{
+			name:            "validate JSON body from file",
+			args:            args("api --token x POST /service --data @" + bodyFile + " -H X-Custom:yes"),
+			responses:       []response{{body: `{"id":"123"}`}},
+			wantMethod:      http.MethodPost,
+			wantBody:        `{"name":"example"}`,
+			wantContentType: "application/json",
+			wantHeader:      [2]string{"X-Custom", "yes"},
+			wantRequests:    1,
+		},
+		{
+			name:         "validate Accept header",
+			args:         args("api --token x GET /service -H Accept:text/plain"),
+			responses:    []response{{body: `ok`}},
+			wantHeader:   [2]string{"Accept", "text/plain"},
+			wantOutput:   "ok\n",
+			wantRequests: 1,
+		},
+		{
+			name:            "validate form body from stdin",
+			args:            args("api --token x PUT /service/123 --data @-"),
+			stdin:           "name=example",
+			responses:       []response{{body: `{"id":"123"}`}},
+			wantMethod:      http.MethodPut,
+			wantBody:        "name=example",
+			wantContentType: "application/x-www-form-urlencoded",
+			wantRequests:    1,
+		},
+		{
+			name:         "validate error response",
+			args:         args("api --token x GET /service/nope"),
+			responses:    []response{{code: http.StatusNotFound, body: `{"msg":"Record not found"}`}},
+			wantError:    "error from API: non-2xx response: 404 Not Found: Record not found",
+			wantOutput:   "\"msg\": \"Record not found\"",
+			wantRequests: 1,
+		},
+		{
+			name: "validate rate limited request is retried",
+			args: args("api --token x GET /service"),
+			responses: []response{
+				{code: http.StatusTooManyRequests, headers: map[string]string{"Retry-After": "5"}},
+				{code: http.StatusTooManyRequests},
+				{body: `{"id":"123"}`},
+			},
+			wantOutput:   "Rate limited by the API, retrying in 5s (attempt 1 of 3).",
+			wantRequests: 3,
+			wantSlept:    []time.Duration{5 * time.Second, time.Second},
+		},
+		{
+			name: "validate retries are limited",
+			args: args("api --token x GET /service --max-retries 1"),
+			responses: []response{
+				{code: http.StatusTooManyRequests, headers: map[string]string{"Retry-After": "600"}},
+				{code: http.StatusTooManyRequests},
+			},
+			wantError:    "error from API: non-2xx response: 429 Too Many Requests",
+			wantRequests: 2,
+			wantSlept:    []time.Duration{apicall.MaxRetryWait},
+		},
+		{
+			name:         "validate include",
+			args:         args("api --token x GET /service --include"),
+			responses:    []response{{body: `{}`, headers: map[string]string{"Fastly-RateLimit-Remaining": "999"}}},
+			wantOutput:   "HTTP/1.1 200 OK\nContent-Type: application/json\nFastly-Ratelimit-Remaining: 999\n\n{}\n",
+			wantRequests: 1,
+		},
+	}
+
+	for testcaseIdx := range scenarios {
+		testcase := &scenarios[testcaseIdx]
+		t.Run(testcase.name, func(t *testing.T) {
+			slept = nil
+			client := &sequenceClient{responses: testcase.responses}
+
+			var stdout bytes.Buffer
+			opts := testutil.NewRunOpts(testcase.args, &stdout)
+			opts.APIClient = mock.APIClient(mock.API{})
+			opts.HTTPClient = client
+			opts.Stdin = strings.NewReader(testcase.stdin)
+			err := app.Run(opts)
+			testutil.AssertErrorContains(t, err, testcase.wantError)
+			testutil.AssertStringContains(t, stdout.String(), testcase.wantOutput)
+			testutil.AssertEqual(t, testcase.wantRequests, len(client.requests))
+			testutil.AssertEqual(t, testcase.wantSlept, slept)
+
+			if len(client.requests) == 0 {
+				return
+			}
+			req := client.requests[0]
+			testutil.AssertString(t, "x", req.Header.Get("Fastly-Key"))
+			if testcase.wantMethod != "" {
+				testutil.AssertString(t, testcase.wantMethod, req.Method)
+			}
+			if testcase.wantPath != "" {
+				testutil.AssertString(t, testcase.wantPath, req.URL.Path)
+			}
+			testutil.AssertString(t, testcase.wantBody, client.bodies[0])
+			testutil.AssertString(t, testcase.wantContentType, req.Header.Get("Content-Type"))
+			if testcase.wantHeader[0] != "" {
+				testutil.AssertString(t, testcase.wantHeader[1], req.Header.Get(testcase.wantHeader[0]))
+			}
+		})
+	}
+}
+
+type response struct {
+	code    int
+	body    string
+	headers map[string]string
+}
+
+// sequenceClient records the requests it receives and responds with each of
+// the responses in turn.
+type sequenceClient struct {
+	responses []response
+	requests  []*http.Request
+	bodies    []string
+}
+
+func (c *sequenceClient) Do(req *http.Request) (*http.Response, error) {
+	var b []byte
+	if req.Body != nil {
+		b, _ = io.ReadAll(req.Body)
+	}
+	c.requests = append(c.requests, req)
+	c.bodies = append(c.bodies, string(b))
+
+	r := c.responses[0]
+	if len(c.responses) > 1 {
+		c.responses = c.responses[1:]
+	}
+	rec := httptest.NewRecorder()
+	rec.Header().Set("Content-Type", "application/json")
+	for k, v := range r.headers {
+		rec.Header().Set(k, v)
+	}
+	if r.code != 0 {
+		rec.WriteHeader(r.code)
+	}
+	rec.WriteString(r.body)
+	return rec.Result(), nil
+}
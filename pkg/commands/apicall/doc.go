@@ -0,0 +1,3 @@
+// Package apicall contains a command to send arbitrary requests to the Fastly
+// API.
+package apicall
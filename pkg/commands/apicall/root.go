@@ -0,0 +1,302 @@
+package apicall
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/fastly/cli/pkg/api/undocumented"
+	"github.com/fastly/cli/pkg/cmd"
+	"github.com/fastly/cli/pkg/config"
+	fsterr "github.com/fastly/cli/pkg/errors"
+	"github.com/fastly/cli/pkg/manifest"
+	"github.com/fastly/cli/pkg/text"
+)
+
+// Methods are the HTTP methods accepted by the command.
+var Methods = []string{
+	http.MethodGet,
+	http.MethodHead,
+	http.MethodPost,
+	http.MethodPut,
+	http.MethodPatch,
+	http.MethodDelete,
+}
+
+// MaxRetryWait is the longest the command waits before retrying a rate
+// limited request.
+const MaxRetryWait = time.Minute
+
+// Sleep pauses before a rate limited request is retried, and is replaced by
+// tests.
+var Sleep = time.Sleep
+
+// RootCommand sends an arbitrary request to the Fastly API, authenticated with
+// the configured token, for endpoints the CLI doesn't otherwise support.
+type RootCommand struct {
+	cmd.Base
+	manifest manifest.Data
+
+	data        string
+	headers     []string
+	include     bool
+	maxRetries  int
+	method      string
+	path        string
+	raw         bool
+	serviceName cmd.OptionalServiceNameID
+}
+
+// NewRootCommand returns a new command registered in the parent.
+func NewRootCommand(parent cmd.Registerer, globals *config.Data, data manifest.Data) *RootCommand {
+	var c RootCommand
+	c.Globals = globals
+	c.manifest = data
+	c.CmdClause = parent.Command("api", "Send an authenticated request to the Fastly API, e.g. fastly api GET /service/%s/version").Alias("curl")
+
+	// Required args
+	c.CmdClause.Arg("method", fmt.Sprintf("The HTTP method (%s)", strings.Join(Methods, ", "))).Required().StringVar(&c.method)
+	c.CmdClause.Arg("path", "The path of the API endpoint, in which %s is replaced with the service ID").Required().StringVar(&c.path)
+
+	// Optional flags
+	c.CmdClause.Flag("data", "The request body, or @FILE to read it from a file (@- for stdin). JSON bodies are sent as application/json, and others as form data").StringVar(&c.data)
+	c.CmdClause.Flag("header", "A request header in the form 'Name: value', which can be repeated").Short('H').StringsVar(&c.headers)
+	c.CmdClause.Flag("include", "Print the response status and headers").BoolVar(&c.include)
+	c.CmdClause.Flag("max-retries", "How many times to retry a rate limited (429) request").Default("3").IntVar(&c.maxRetries)
+	c.CmdClause.Flag("raw", "Print the response body as is, rather than pretty-printing JSON").BoolVar(&c.raw)
+	c.RegisterFlag(cmd.StringFlagOpts{
+		Name:        cmd.FlagServiceIDName,
+		Description: cmd.FlagServiceIDDesc,
+		Dst:         &c.manifest.Flag.ServiceID,
+		Short:       's',
+	})
+	c.RegisterFlag(cmd.StringFlagOpts{
+		Action:      c.serviceName.Set,
+		Name:        cmd.FlagServiceName,
+		Description: cmd.FlagServiceDesc,
+		Dst:         &c.serviceName.Value,
+	})
+	c.RegisterExamples(
+		cmd.Example{
+			Cmd:   "fastly api GET /service/%s/version --service-name example",
+			Title: "List the versions of a service, substituting its ID into the path",
+		},
+		cmd.Example{
+			Cmd:         "fastly api POST /service/%s/version/1/clone --include",
+			Description: "The service ID is read from the fastly.toml manifest unless --service-id or --service-name is set.",
+			Title:       "Clone a service version and print the response headers",
+		},
+	)
+	return &c
+}
+
+// Exec implements the command interface.
+func (c *RootCommand) Exec(in io.Reader, out io.Writer) error {
+	method := strings.ToUpper(c.method)
+	if !contains(Methods, method) {
+		return fmt.Errorf("error parsing arguments: unsupported method '%s' (expected one of %s)", c.method, strings.Join(Methods, ", "))
+	}
+	if !strings.HasPrefix(c.path, "/") {
+		return fsterr.RemediationError{
+			Inner:       fmt.Errorf("error parsing arguments: the path '%s' must start with /", c.path),
+			Remediation: "Provide the path relative to the API endpoint (e.g. /service), and use --endpoint to change the endpoint.",
+		}
+	}
+
+	path := c.path
+	if strings.Contains(path, "%s") {
+		serviceID, source, flag, err := cmd.ServiceID(c.serviceName, c.manifest, c.Globals.APIClient, c.Globals.ErrLog)
+		if err != nil {
+			return err
+		}
+		if c.Globals.Verbose() {
+			cmd.DisplayServiceID(serviceID, flag, source, out)
+		}
+		path = strings.ReplaceAll(path, "%s", serviceID)
+	}
+
+	token, source := c.Globals.Token()
+	if source == config.SourceUndefined {
+		return fsterr.ErrNoToken
+	}
+
+	body, err := c.body(in)
+	if err != nil {
+		return err
+	}
+	headers, err := c.requestHeaders(body)
+	if err != nil {
+		return err
+	}
+
+	endpoint, _ := c.Globals.Endpoint()
+	if c.Globals.Verbose() {
+		text.Info(out, "%s %s", method, strings.TrimSuffix(endpoint, "/")+path)
+	}
+	client := undocumented.NewClient(endpoint, token, c.Globals.HTTPClient)
+
+	// NOTE: Error responses are displayed like any other, and so the response
+	// is taken from the APIError, which is returned once it's displayed.
+	var (
+		resp    *http.Response
+		respErr error
+	)
+	for attempt := 0; ; attempt++ {
+		req := undocumented.Request{
+			Method: method,
+			Path:   path,
+			Header: headers,
+		}
+		if len(body) > 0 {
+			req.Body = bytes.NewReader(body)
+		}
+
+		var apiErr undocumented.APIError
+		resp, respErr = client.Send(req)
+		if errors.As(respErr, &apiErr) && apiErr.Response != nil {
+			resp = apiErr.Response
+		} else if respErr != nil {
+			c.Globals.ErrLog.Add(respErr)
+			return fmt.Errorf("error executing API request: %w", respErr)
+		}
+		if resp.StatusCode != http.StatusTooManyRequests || attempt >= c.maxRetries {
+			break
+		}
+		resp.Body.Close()
+
+		wait := retryWait(resp.Header, time.Now())
+		text.Warning(out, "Rate limited by the API, retrying in %s (attempt %d of %d).", wait, attempt+1, c.maxRetries)
+		Sleep(wait)
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		c.Globals.ErrLog.Add(err)
+		return fmt.Errorf("error reading API response: %w", err)
+	}
+
+	if c.include {
+		fmt.Fprintf(out, "%s %s\n", resp.Proto, resp.Status)
+		names := make([]string, 0, len(resp.Header))
+		for name := range resp.Header {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			for _, value := range resp.Header[name] {
+				fmt.Fprintf(out, "%s: %s\n", name, value)
+			}
+		}
+		fmt.Fprintln(out)
+	}
+	if len(data) > 0 {
+		if !c.raw && strings.Contains(resp.Header.Get("Content-Type"), "json") {
+			var pretty bytes.Buffer
+			if err := json.Indent(&pretty, data, "", "  "); err == nil {
+				data = pretty.Bytes()
+			}
+		}
+		_, err = out.Write(data)
+		if err != nil {
+			c.Globals.ErrLog.Add(err)
+			return fmt.Errorf("error: unable to write data to stdout: %w", err)
+		}
+		if !bytes.HasSuffix(data, []byte("\n")) {
+			fmt.Fprintln(out)
+		}
+	}
+
+	if c.Globals.Verbose() {
+		if remaining := resp.Header.Get("Fastly-RateLimit-Remaining"); remaining != "" {
+			text.Info(out, "Rate limit remaining: %s", remaining)
+		}
+	}
+
+	if respErr != nil {
+		c.Globals.ErrLog.Add(respErr)
+		return fmt.Errorf("error from API: %w", respErr)
+	}
+	return nil
+}
+
+// body returns the request body provided by the --data flag.
+func (c *RootCommand) body(in io.Reader) ([]byte, error) {
+	switch {
+	case c.data == "@-":
+		data, err := io.ReadAll(in)
+		if err != nil {
+			return nil, fmt.Errorf("error reading the request body from stdin: %w", err)
+		}
+		return data, nil
+	case strings.HasPrefix(c.data, "@"):
+		// gosec flagged this:
+		// G304 (CWE-22): Potential file inclusion via variable
+		// Disabling as we need to read the file provided by the user.
+		/* #nosec */
+		data, err := os.ReadFile(c.data[1:])
+		if err != nil {
+			return nil, fmt.Errorf("error reading the request body: %w", err)
+		}
+		return data, nil
+	}
+	return []byte(c.data), nil
+}
+
+// requestHeaders returns the headers provided by the --header flag, along with
+// a Content-Type for the body if one isn't provided.
+func (c *RootCommand) requestHeaders(body []byte) (http.Header, error) {
+	h := http.Header{}
+	for _, header := range c.headers {
+		name, value, ok := strings.Cut(header, ":")
+		if !ok || strings.TrimSpace(name) == "" {
+			return nil, fmt.Errorf("error parsing arguments: invalid --header '%s' (expected 'Name: value')", header)
+		}
+		h.Set(strings.TrimSpace(name), strings.TrimSpace(value))
+	}
+
+	if len(body) > 0 && h.Get("Content-Type") == "" {
+		trimmed := bytes.TrimSpace(body)
+		if json.Valid(trimmed) && (bytes.HasPrefix(trimmed, []byte("{")) || bytes.HasPrefix(trimmed, []byte("["))) {
+			h.Set("Content-Type", "application/json")
+		} else {
+			h.Set("Content-Type", "application/x-www-form-urlencoded")
+		}
+	}
+	return h, nil
+}
+
+// retryWait returns how long to wait before retrying a rate limited request,
+// as indicated by the Retry-After header, or the Fastly-RateLimit-Reset header
+// (a Unix timestamp), defaulting to a second and capped at MaxRetryWait.
+func retryWait(h http.Header, now time.Time) time.Duration {
+	wait := time.Second
+	if s, err := strconv.Atoi(h.Get("Retry-After")); err == nil && s > 0 {
+		wait = time.Duration(s) * time.Second
+	} else if reset, err := strconv.ParseInt(h.Get("Fastly-RateLimit-Reset"), 10, 64); err == nil {
+		if d := time.Unix(reset, 0).Sub(now); d > 0 {
+			wait = d.Round(time.Second)
+		}
+	}
+	if wait > MaxRetryWait {
+		wait = MaxRetryWait
+	}
+	return wait
+}
+
+func contains(values []string, v string) bool {
+	for _, value := range values {
+		if value == v {
+			return true
+		}
+	}
+	return false
+}
@@ -4,9 +4,13 @@ import (
 	"bytes"
 	"fmt"
 	"os"
+	"path/filepath"
 	"testing"
+	"time"
 
+	"github.com/fastly/cli/pkg/api"
 	"github.com/fastly/cli/pkg/app"
+	"github.com/fastly/cli/pkg/config"
 	"github.com/fastly/cli/pkg/errors"
 	"github.com/fastly/cli/pkg/mock"
 	"github.com/fastly/cli/pkg/testutil"
@@ -425,3 +429,209 @@ func listTokenOutputSummary(env bool) string {
 Foo   123       456      purge_all global:read  a, b
 Bar   456       789      global                 a, b`, msg)
 }
+
+func TestMint(t *testing.T) {
+	args := testutil.Args
+	output := filepath.Join(t.TempDir(), "token")
+
+	createToken := func(i *fastly.CreateTokenInput) (*fastly.Token, error) {
+		if i.ExpiresAt == nil || time.Until(*i.ExpiresAt) > 30*time.Minute || time.Until(*i.ExpiresAt) < 29*time.Minute {
+			return nil, fmt.Errorf("unexpected expiry: %v", i.ExpiresAt)
+		}
+		return &fastly.Token{
+			AccessToken: "abc123",
+			ExpiresAt:   &testutil.Date,
+			ID:          "123",
+			Name:        "ci",
+			Scope:       i.Scope,
+			Services:    i.Services,
+		}, nil
+	}
+
+	scenarios := []testutil.TestScenario{
+		{
+			Name:      "validate missing --services flag",
+			Args:      args("auth-token mint --password secure --token 123"),
+			WantError: "error parsing arguments: required flag --services not provided",
+		},
+		{
+			Name:      "validate invalid --ttl",
+			Args:      args("auth-token mint --password secure --services a --ttl 0s --token 123"),
+			WantError: "invalid --ttl value: 0s",
+		},
+		{
+			Name: "validate CreateToken API error",
+			API: mock.API{
+				CreateTokenFn: func(i *fastly.CreateTokenInput) (*fastly.Token, error) {
+					return nil, testutil.Err
+				},
+			},
+			Args:      args("auth-token mint --password secure --services a --token 123"),
+			WantError: testutil.Err.Error(),
+		},
+		{
+			Name: "validate token is written to stdout",
+			API: mock.API{
+				CreateTokenFn: createToken,
+			},
+			Args:       args("auth-token mint --password secure --services a,b --ttl 30m --token 123"),
+			WantOutput: "abc123\n",
+		},
+		{
+			Name: "validate token is written to --output",
+			API: mock.API{
+				CreateTokenFn: createToken,
+			},
+			Args:       args("auth-token mint --password secure --services a,b --scope purge_select --ttl 30m --output " + output + " --token 123"),
+			WantOutput: "Wrote token '********' to '" + output + "' (name: ci, id: 123, scope: purge_select, services: a, b, expires: 2021-06-15 23:00:00 +0000 UTC)",
+		},
+	}
+
+	for testcaseIdx := range scenarios {
+		testcase := &scenarios[testcaseIdx]
+		t.Run(testcase.Name, func(t *testing.T) {
+			var stdout bytes.Buffer
+			opts := testutil.NewRunOpts(testcase.Args, &stdout)
+			opts.APIClient = mock.APIClient(testcase.API)
+			err := app.Run(opts)
+			testutil.AssertErrorContains(t, err, testcase.WantError)
+			testutil.AssertStringContains(t, stdout.String(), testcase.WantOutput)
+		})
+	}
+
+	b, err := os.ReadFile(output) // #nosec G304 (CWE-22)
+	testutil.AssertNoError(t, err)
+	testutil.AssertString(t, "abc123\n", string(b))
+	fi, err := os.Stat(output)
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, os.FileMode(0o600), fi.Mode().Perm())
+}
+
+func TestRotate(t *testing.T) {
+	args := testutil.Args
+
+	var deleted []string
+	oldAPI := mock.API{
+		GetTokenSelfFn: func() (*fastly.Token, error) {
+			return &fastly.Token{ID: "old-id", Name: "ci", Scope: "purge_all", Services: []string{"a", "b"}}, nil
+		},
+		CreateTokenFn: func(i *fastly.CreateTokenInput) (*fastly.Token, error) {
+			if i.Name != "ci" || i.Scope != "purge_all" || len(i.Services) != 2 {
+				return nil, fmt.Errorf("unexpected input: %+v", i)
+			}
+			return &fastly.Token{ID: "new-id", AccessToken: "new"}, nil
+		},
+		DeleteTokenFn: func(i *fastly.DeleteTokenInput) error {
+			deleted = append(deleted, "old client: "+i.TokenID)
+			return nil
+		},
+	}
+	newAPI := mock.API{
+		GetTokenSelfFn: func() (*fastly.Token, error) {
+			return &fastly.Token{ID: "new-id"}, nil
+		},
+		DeleteTokenFn: func(i *fastly.DeleteTokenInput) error {
+			deleted = append(deleted, "new client: "+i.TokenID)
+			return nil
+		},
+	}
+
+	for _, testcase := range []struct {
+		name        string
+		args        string
+		newAPI      mock.API
+		wantError   string
+		wantOutput  string
+		wantToken   string
+		wantDeleted []string
+		configPath  string
+	}{
+		{
+			name:      "validate --token flag",
+			args:      "auth-token rotate --password secure --token 123",
+			wantError: "only a profile's token can be rotated",
+			wantToken: "old",
+		},
+		{
+			name: "validate replacement token fails",
+			args: "auth-token rotate --password secure",
+			newAPI: mock.API{
+				GetTokenSelfFn: func() (*fastly.Token, error) {
+					return nil, testutil.Err
+				},
+			},
+			wantError:   "error validating replacement token: test error",
+			wantToken:   "old",
+			wantDeleted: []string{"old client: new-id"},
+		},
+		{
+			name: "validate revoking old token fails",
+			args: "auth-token rotate --password secure",
+			newAPI: mock.API{
+				GetTokenSelfFn: newAPI.GetTokenSelfFn,
+				DeleteTokenFn: func(i *fastly.DeleteTokenInput) error {
+					return testutil.Err
+				},
+			},
+			wantError: "error revoking old token: test error",
+			wantToken: "new",
+		},
+		{
+			name:        "validate saving config file fails",
+			args:        "auth-token rotate --password secure",
+			newAPI:      newAPI,
+			configPath:  "missing/config.toml",
+			wantError:   "error saving config file",
+			wantToken:   "old",
+			wantDeleted: []string{"old client: new-id"},
+		},
+		{
+			name:        "validate missing --profile rotates the default profile",
+			args:        "auth-token rotate --password secure --profile missing --auto-yes",
+			newAPI:      newAPI,
+			wantOutput:  "Rotated the token of profile 'user'",
+			wantToken:   "new",
+			wantDeleted: []string{"new client: old-id"},
+		},
+		{
+			name:        "validate success",
+			args:        "auth-token rotate --password secure",
+			newAPI:      newAPI,
+			wantOutput:  "Rotated the token of profile 'user' (new token: ********, id: new-id, old token id: old-id)",
+			wantToken:   "new",
+			wantDeleted: []string{"new client: old-id"},
+		},
+	} {
+		t.Run(testcase.name, func(t *testing.T) {
+			deleted = nil
+			configPath := filepath.Join(t.TempDir(), "config.toml")
+			if testcase.configPath != "" {
+				configPath = filepath.Join(t.TempDir(), testcase.configPath)
+			}
+
+			var stdout bytes.Buffer
+			opts := testutil.NewRunOpts(args(testcase.args), &stdout)
+			opts.APIClient = func(token, _ string) (api.Interface, error) {
+				if token == "new" {
+					return testcase.newAPI, nil
+				}
+				return oldAPI, nil
+			}
+			opts.ConfigPath = configPath
+			opts.ConfigFile = config.File{
+				Profiles: config.Profiles{
+					"user": &config.Profile{
+						Default: true,
+						Email:   "foo@example.com",
+						Token:   "old",
+					},
+				},
+			}
+			err := app.Run(opts)
+			testutil.AssertErrorContains(t, err, testcase.wantError)
+			testutil.AssertStringContains(t, stdout.String(), testcase.wantOutput)
+			testutil.AssertEqual(t, testcase.wantDeleted, deleted)
+			testutil.AssertString(t, testcase.wantToken, opts.ConfigFile.Profiles["user"].Token)
+		})
+	}
+}
@@ -1,7 +1,6 @@
 package authtoken
 
 import (
-	"encoding/json"
 	"fmt"
 	"io"
 	"strings"
@@ -22,9 +21,10 @@ func NewDescribeCommand(parent cmd.Registerer, globals *config.Data, data manife
 	c.RegisterFlagBool(cmd.BoolFlagOpts{
 		Name:        cmd.FlagJSONName,
 		Description: cmd.FlagJSONDesc,
-		Dst:         &c.json,
+		Dst:         &c.output.JSON,
 		Short:       'j',
 	})
+	c.RegisterOutputFlags(&c.output)
 	return &c
 }
 
@@ -32,20 +32,28 @@ func NewDescribeCommand(parent cmd.Registerer, globals *config.Data, data manife
 type DescribeCommand struct {
 	cmd.Base
 
-	json     bool
+	output   cmd.Output
 	manifest manifest.Data
 }
 
 // Exec invokes the application logic for the command.
-func (c *DescribeCommand) Exec(_ io.Reader, out io.Writer) error {
+func (c *DescribeCommand) Exec(_ io.Reader, out io.Writer) (err error) {
 	_, s := c.Globals.Token()
 	if s == config.SourceUndefined {
 		return fsterr.ErrNoToken
 	}
-	if c.Globals.Verbose() && c.json {
+	if c.Globals.Verbose() && c.output.Structured() {
 		return fsterr.ErrInvalidVerboseJSONCombo
 	}
 
+	out, closeOutput, err := c.output.Writer(out)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err = closeOutput(err)
+	}()
+
 	r, err := c.Globals.APIClient.GetTokenSelf()
 	if err != nil {
 		c.Globals.ErrLog.Add(err)
@@ -61,15 +69,10 @@ func (c *DescribeCommand) Exec(_ io.Reader, out io.Writer) error {
 
 // print displays the information returned from the API.
 func (c *DescribeCommand) print(out io.Writer, r *fastly.Token) error {
-	if c.json {
-		data, err := json.Marshal(r)
-		if err != nil {
-			return err
-		}
-		_, err = out.Write(data)
-		if err != nil {
+	if c.output.Structured() {
+		if err := c.output.Write(out, r); err != nil {
 			c.Globals.ErrLog.Add(err)
-			return fmt.Errorf("error: unable to write data to stdout: %w", err)
+			return err
 		}
 		return nil
 	}
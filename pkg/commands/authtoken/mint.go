@@ -0,0 +1,127 @@
+package authtoken
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/fastly/cli/pkg/cmd"
+	"github.com/fastly/cli/pkg/config"
+	fsterr "github.com/fastly/cli/pkg/errors"
+	"github.com/fastly/cli/pkg/text"
+	"github.com/fastly/go-fastly/v6/fastly"
+	"github.com/fastly/kingpin"
+)
+
+// NewMintCommand returns a usable command registered under the parent.
+func NewMintCommand(parent cmd.Registerer, globals *config.Data) *MintCommand {
+	var c MintCommand
+	c.CmdClause = parent.Command("mint", "Create a short-lived API token limited to specific services, e.g. for a CI job, and print it")
+	c.Globals = globals
+
+	// Required flags
+	//
+	// NOTE: Creating a token requires the password of the user account (see
+	// the create command).
+	c.CmdClause.Flag("password", "User password corresponding with --token or $FASTLY_API_TOKEN").Required().StringVar(&c.password)
+	c.CmdClause.Flag("services", "A comma-separated list of alphanumeric strings identifying the services the token can access").Required().StringsVar(&c.services, kingpin.Separator(","))
+
+	// Optional flags
+	c.CmdClause.Flag("name", "Name of the token (default: a name including its expiry time)").StringVar(&c.name)
+	c.CmdClause.Flag("output", "Write the token to a file, readable only by the current user, instead of stdout").StringVar(&c.output)
+	c.CmdClause.Flag("scope", "Authorization scope (repeat flag per scope)").Default("global").HintOptions(Scopes...).EnumsVar(&c.scope, Scopes...)
+	c.CmdClause.Flag("ttl", "How long the token is valid for (e.g. 30m, 1h)").Default("1h").DurationVar(&c.ttl)
+	return &c
+}
+
+// MintCommand calls the Fastly API to create a short-lived token.
+type MintCommand struct {
+	cmd.Base
+
+	name     string
+	output   string
+	password string
+	scope    []string
+	services []string
+	ttl      time.Duration
+}
+
+// Exec invokes the application logic for the command.
+func (c *MintCommand) Exec(_ io.Reader, out io.Writer) error {
+	_, s := c.Globals.Token()
+	if s == config.SourceUndefined {
+		return fsterr.ErrNoToken
+	}
+	if c.ttl <= 0 {
+		return fsterr.RemediationError{
+			Inner:       fmt.Errorf("invalid --ttl value: %s", c.ttl),
+			Remediation: "Set --ttl to a positive duration (e.g. 30m, 1h).",
+		}
+	}
+
+	var services []string
+	for _, s := range c.services {
+		if s = strings.TrimSpace(s); s != "" {
+			services = append(services, s)
+		}
+	}
+	if len(services) == 0 {
+		return fsterr.RemediationError{
+			Inner:       fmt.Errorf("no services provided"),
+			Remediation: "Set --services to a comma-separated list of the service IDs the token needs to access.",
+		}
+	}
+
+	input := c.constructInput(services, time.Now())
+	r, err := c.Globals.APIClient.CreateToken(input)
+	if err != nil {
+		c.Globals.ErrLog.AddWithContext(err, map[string]any{
+			"Services": services,
+			"TTL":      c.ttl,
+		})
+		return err
+	}
+
+	// NOTE: Without --output only the token is written to stdout, so that it
+	// can be captured by a shell (e.g. FASTLY_API_TOKEN=$(fastly auth-token mint ...)).
+	if c.output == "" {
+		fmt.Fprintln(out, r.AccessToken)
+		return nil
+	}
+
+	path := filepath.Clean(c.output)
+	if err := os.WriteFile(path, []byte(r.AccessToken+"\n"), 0o600); err != nil {
+		c.Globals.ErrLog.Add(err)
+		return fsterr.RemediationError{
+			Inner:       fmt.Errorf("error writing token to '%s': %w", path, err),
+			Remediation: fmt.Sprintf("The token (id: %s) was created, revoke it with `fastly auth-token delete --id %s`.", r.ID, r.ID),
+		}
+	}
+
+	expires := "never"
+	if r.ExpiresAt != nil {
+		expires = r.ExpiresAt.String()
+	}
+	text.Success(out, "Wrote token '%s' to '%s' (name: %s, id: %s, scope: %s, services: %s, expires: %s)", text.Secret(r.AccessToken), path, r.Name, r.ID, r.Scope, strings.Join(r.Services, ", "), expires)
+	return nil
+}
+
+// constructInput transforms values parsed from CLI flags into an object to be used by the API client library.
+func (c *MintCommand) constructInput(services []string, now time.Time) *fastly.CreateTokenInput {
+	expires := now.Add(c.ttl).UTC().Truncate(time.Second)
+
+	input := fastly.CreateTokenInput{
+		ExpiresAt: &expires,
+		Name:      c.name,
+		Password:  c.password,
+		Scope:     fastly.TokenScope(strings.Join(c.scope, " ")),
+		Services:  services,
+	}
+	if input.Name == "" {
+		input.Name = fmt.Sprintf("fastly-cli-mint-%s", expires.Format("20060102T150405Z"))
+	}
+	return &input
+}
@@ -0,0 +1,173 @@
+package authtoken
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/fastly/cli/pkg/api"
+	"github.com/fastly/cli/pkg/cmd"
+	"github.com/fastly/cli/pkg/config"
+	fsterr "github.com/fastly/cli/pkg/errors"
+	"github.com/fastly/cli/pkg/profile"
+	"github.com/fastly/cli/pkg/text"
+	"github.com/fastly/go-fastly/v6/fastly"
+)
+
+// APIClientFactory allows the rotate command to construct a Fastly API client
+// for the replacement token, in order to validate that token.
+// It's a redeclaration of the app.APIClientFactory to avoid an import loop.
+type APIClientFactory func(token, endpoint string) (api.Interface, error)
+
+// NewRotateCommand returns a usable command registered under the parent.
+func NewRotateCommand(parent cmd.Registerer, cf APIClientFactory, globals *config.Data) *RotateCommand {
+	var c RotateCommand
+	c.CmdClause = parent.Command("rotate", "Replace the active profile's API token with a new token of the same scope, then revoke the old token")
+	c.Globals = globals
+	c.clientFactory = cf
+
+	// Required flags
+	//
+	// NOTE: Creating a token requires the password of the user account (see
+	// the create command).
+	c.CmdClause.Flag("password", "User password corresponding with the active profile's token").Required().StringVar(&c.password)
+
+	// Optional flags
+	c.CmdClause.Flag("expires", "Time-stamp (UTC) of when the new token will expire").HintOptions("2016-07-28T19:24:50+00:00").TimeVar(time.RFC3339, &c.expires)
+	c.CmdClause.Flag("name", "Name of the new token (default: the name of the old token)").StringVar(&c.name)
+	return &c
+}
+
+// RotateCommand calls the Fastly API to replace the active profile's token.
+type RotateCommand struct {
+	cmd.Base
+
+	clientFactory APIClientFactory
+	expires       time.Time
+	name          string
+	password      string
+}
+
+// Exec invokes the application logic for the command.
+func (c *RotateCommand) Exec(_ io.Reader, out io.Writer) (err error) {
+	token, s := c.Globals.Token()
+	switch s {
+	case config.SourceUndefined:
+		return fsterr.ErrNoToken
+	case config.SourceFlag, config.SourceEnvironment:
+		return fsterr.RemediationError{
+			Inner:       fmt.Errorf("only a profile's token can be rotated, the token was provided via --token or $FASTLY_API_TOKEN"),
+			Remediation: "Remove the --token flag and unset $FASTLY_API_TOKEN, or use `fastly auth-token create` and `fastly auth-token delete` to replace the token yourself.",
+		}
+	}
+	name := c.Globals.Profile()
+
+	progress := text.NewProgress(out, c.Globals.Verbose())
+	defer func() {
+		if err != nil {
+			progress.Fail() // progress.Done is handled inline
+		}
+	}()
+
+	progress.Step("Reading current token...")
+	old, err := c.Globals.APIClient.GetTokenSelf()
+	if err != nil {
+		c.Globals.ErrLog.Add(err)
+		return fmt.Errorf("error reading current token: %w", err)
+	}
+
+	progress.Step("Creating replacement token...")
+	input := &fastly.CreateTokenInput{
+		Name:     old.Name,
+		Password: c.password,
+		Scope:    old.Scope,
+		Services: old.Services,
+	}
+	if c.name != "" {
+		input.Name = c.name
+	}
+	if !c.expires.IsZero() {
+		input.ExpiresAt = &c.expires
+	}
+	t, err := c.Globals.APIClient.CreateToken(input)
+	if err != nil {
+		c.Globals.ErrLog.Add(err)
+		return fmt.Errorf("error creating replacement token: %w", err)
+	}
+
+	// NOTE: Until the profile is saved with the replacement token, any failure
+	// revokes it (with the old token) so that an unused token isn't left behind.
+	saved := false
+	defer func() {
+		if err != nil && !saved {
+			if derr := c.Globals.APIClient.DeleteToken(&fastly.DeleteTokenInput{TokenID: t.ID}); derr != nil {
+				c.Globals.ErrLog.Add(derr)
+			}
+		}
+	}()
+
+	progress.Step("Validating replacement token...")
+	client, err := c.validateToken(t)
+	if err != nil {
+		return err
+	}
+
+	progress.Step("Updating profile...")
+	ps, ok := profile.Edit(name, c.Globals.File.Profiles, func(p *config.Profile) {
+		p.Token = t.AccessToken
+	})
+	if !ok {
+		return fsterr.RemediationError{
+			Inner:       fmt.Errorf(profile.DoesNotExist, name),
+			Remediation: fsterr.ProfileRemediation,
+		}
+	}
+	c.Globals.File.Profiles = ps
+	if err = c.Globals.File.Write(c.Globals.Path); err != nil {
+		c.Globals.ErrLog.Add(err)
+		c.Globals.File.Profiles, _ = profile.Edit(name, ps, func(p *config.Profile) {
+			p.Token = token
+		})
+		return fmt.Errorf("error saving config file: %w", err)
+	}
+	saved = true
+
+	progress.Step("Revoking old token...")
+	if err = client.DeleteToken(&fastly.DeleteTokenInput{TokenID: old.ID}); err != nil {
+		c.Globals.ErrLog.Add(err)
+		return fsterr.RemediationError{
+			Inner:       fmt.Errorf("error revoking old token: %w", err),
+			Remediation: fmt.Sprintf("The profile '%s' now uses the new token. Revoke the old token with `fastly auth-token delete --id %s`.", name, old.ID),
+		}
+	}
+
+	progress.Done()
+
+	text.Success(out, "Rotated the token of profile '%s' (new token: %s, id: %s, old token id: %s)", name, text.Secret(t.AccessToken), t.ID, old.ID)
+	return nil
+}
+
+// validateToken ensures the replacement token can be used to call the API,
+// returning a client authenticated with it.
+func (c *RotateCommand) validateToken(t *fastly.Token) (api.Interface, error) {
+	endpoint, _ := c.Globals.Endpoint()
+	client, err := c.clientFactory(t.AccessToken, endpoint)
+	if err != nil {
+		c.Globals.ErrLog.AddWithContext(err, map[string]any{
+			"Endpoint": endpoint,
+		})
+		return nil, fmt.Errorf("error regenerating Fastly API client: %w", err)
+	}
+
+	self, err := client.GetTokenSelf()
+	if err != nil {
+		c.Globals.ErrLog.Add(err)
+		return nil, fmt.Errorf("error validating replacement token: %w", err)
+	}
+	if self.ID != t.ID {
+		err := fmt.Errorf("error validating replacement token: the API identified the token as '%s' rather than '%s'", self.ID, t.ID)
+		c.Globals.ErrLog.Add(err)
+		return nil, err
+	}
+	return client, nil
+}
@@ -3,6 +3,8 @@ package backend_test
 import (
 	"bytes"
 	"errors"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
@@ -549,3 +551,78 @@ func listServicesOK(i *fastly.ListServicesInput) ([]*fastly.Service, error) {
 		},
 	}, nil
 }
+
+func TestBackendDescribeExport(t *testing.T) {
+	args := testutil.Args
+	path := filepath.Join(t.TempDir(), "backend.env")
+	scenarios := []struct {
+		testutil.TestScenario
+		path string
+	}{
+		{
+			TestScenario: testutil.TestScenario{
+				Name: "env",
+				Args: args("backend describe --service-id 123 --version 1 --name www.test.com --format env"),
+				API: mock.API{
+					ListVersionsFn: testutil.ListVersions,
+					GetBackendFn:   getBackendOK,
+				},
+				WantOutput: "FASTLY_BACKEND_ADDRESS=www.test.com\n",
+			},
+		},
+		{
+			TestScenario: testutil.TestScenario{
+				Name: "env written to file",
+				Args: args("backend describe --service-id 123 --version 1 --name www.test.com --format env --output " + path),
+				API: mock.API{
+					ListVersionsFn: testutil.ListVersions,
+					GetBackendFn:   getBackendOK,
+				},
+				WantOutput: "FASTLY_BACKEND_SERVICE_ID=123\n",
+			},
+			path: path,
+		},
+		{
+			// NOTE: The file written by the previous scenario is kept.
+			TestScenario: testutil.TestScenario{
+				Name: "file kept on error",
+				Args: args("backend describe --service-id 123 --version 1 --name www.test.com --format env --output " + path),
+				API: mock.API{
+					ListVersionsFn: testutil.ListVersions,
+					GetBackendFn:   getBackendError,
+				},
+				WantError:  errTest.Error(),
+				WantOutput: "FASTLY_BACKEND_SERVICE_ID=123\n",
+			},
+			path: path,
+		},
+		{
+			TestScenario: testutil.TestScenario{
+				Name:      "json and env",
+				Args:      args("backend describe --service-id 123 --version 1 --name www.test.com --json --format env"),
+				WantError: "invalid flag combination, --json and --format env",
+			},
+		},
+	}
+	for testcaseIdx := range scenarios {
+		testcase := &scenarios[testcaseIdx]
+		t.Run(testcase.Name, func(t *testing.T) {
+			var stdout bytes.Buffer
+			opts := testutil.NewRunOpts(testcase.Args, &stdout)
+			opts.APIClient = mock.APIClient(testcase.API)
+			err := app.Run(opts)
+			testutil.AssertErrorContains(t, err, testcase.WantError)
+
+			output := stdout.String()
+			if testcase.path != "" {
+				testutil.AssertString(t, "", output)
+				data, err := os.ReadFile(testcase.path)
+				if err != nil {
+					t.Fatal(err)
+				}
+				output = string(data)
+			}
+			testutil.AssertStringContains(t, output, testcase.WantOutput)
+		})
+	}
+}
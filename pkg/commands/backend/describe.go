@@ -1,7 +1,6 @@
 package backend
 
 import (
-	"encoding/json"
 	"fmt"
 	"io"
 
@@ -9,6 +8,7 @@ import (
 	"github.com/fastly/cli/pkg/config"
 	fsterr "github.com/fastly/cli/pkg/errors"
 	"github.com/fastly/cli/pkg/manifest"
+	"github.com/fastly/cli/pkg/text"
 	"github.com/fastly/go-fastly/v6/fastly"
 )
 
@@ -17,7 +17,7 @@ type DescribeCommand struct {
 	cmd.Base
 	manifest       manifest.Data
 	Input          fastly.GetBackendInput
-	json           bool
+	output         cmd.Output
 	serviceName    cmd.OptionalServiceNameID
 	serviceVersion cmd.OptionalServiceVersion
 }
@@ -31,9 +31,10 @@ func NewDescribeCommand(parent cmd.Registerer, globals *config.Data, data manife
 	c.RegisterFlagBool(cmd.BoolFlagOpts{
 		Name:        cmd.FlagJSONName,
 		Description: cmd.FlagJSONDesc,
-		Dst:         &c.json,
+		Dst:         &c.output.JSON,
 		Short:       'j',
 	})
+	c.RegisterOutputFlags(&c.output)
 	c.RegisterFlag(cmd.StringFlagOpts{
 		Name:        cmd.FlagServiceIDName,
 		Description: cmd.FlagServiceIDDesc,
@@ -57,11 +58,19 @@ func NewDescribeCommand(parent cmd.Registerer, globals *config.Data, data manife
 }
 
 // Exec invokes the application logic for the command.
-func (c *DescribeCommand) Exec(_ io.Reader, out io.Writer) error {
-	if c.Globals.Verbose() && c.json {
+func (c *DescribeCommand) Exec(_ io.Reader, out io.Writer) (err error) {
+	if c.Globals.Verbose() && c.output.Structured() {
 		return fsterr.ErrInvalidVerboseJSONCombo
 	}
 
+	out, closeOutput, err := c.output.Writer(out)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err = closeOutput(err)
+	}()
+
 	serviceID, serviceVersion, err := cmd.ServiceDetails(cmd.ServiceDetailsOpts{
 		AllowActiveLocked:  true,
 		APIClient:          c.Globals.APIClient,
@@ -91,6 +100,8 @@ func (c *DescribeCommand) Exec(_ io.Reader, out io.Writer) error {
 		return err
 	}
 
+	backend.SSLClientKey = text.Secret(backend.SSLClientKey)
+
 	err = c.print(out, backend)
 	if err != nil {
 		return err
@@ -100,15 +111,10 @@ func (c *DescribeCommand) Exec(_ io.Reader, out io.Writer) error {
 
 // print displays the information returned from the API.
 func (c *DescribeCommand) print(out io.Writer, b *fastly.Backend) error {
-	if c.json {
-		data, err := json.Marshal(b)
-		if err != nil {
-			return err
-		}
-		_, err = out.Write(data)
-		if err != nil {
+	if c.output.Structured() {
+		if err := c.output.Write(out, b); err != nil {
 			c.Globals.ErrLog.Add(err)
-			return fmt.Errorf("error: unable to write data to stdout: %w", err)
+			return err
 		}
 		return nil
 	}
@@ -0,0 +1,129 @@
+package billing_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/fastly/cli/pkg/app"
+	"github.com/fastly/cli/pkg/commands/billing"
+	"github.com/fastly/cli/pkg/mock"
+	"github.com/fastly/cli/pkg/testutil"
+	"github.com/fastly/go-fastly/v6/fastly"
+)
+
+func TestUsage(t *testing.T) {
+	args := testutil.Args
+	scenarios := []testutil.TestScenario{
+		{
+			Name:      "validate invalid --month flag",
+			Args:      args("billing usage --month 05-2024"),
+			WantError: "invalid month '05-2024'",
+		},
+		{
+			Name: "validate API error",
+			API: mock.API{
+				GetStatsJSONFn: func(_ *fastly.GetStatsInput, _ any) error {
+					return testutil.Err
+				},
+			},
+			Args:      args("billing usage --month 2024-05"),
+			WantError: testutil.Err.Error(),
+		},
+		{
+			Name: "validate table output",
+			API: mock.API{
+				GetStatsJSONFn: getStatsJSON,
+				ListServicesFn: listServices,
+			},
+			Args:       args("billing usage --month 2024-05"),
+			WantOutput: tableOutput,
+		},
+		{
+			Name: "validate CSV output",
+			API: mock.API{
+				GetStatsJSONFn: getStatsJSON,
+				ListServicesFn: listServices,
+			},
+			Args:       args("billing usage --month 2024-05 --format csv"),
+			WantOutput: csvOutput,
+		},
+		{
+			Name: "validate JSON output ignores service name failures",
+			API: mock.API{
+				GetStatsJSONFn: getStatsJSON,
+				ListServicesFn: func(_ *fastly.ListServicesInput) ([]*fastly.Service, error) {
+					return nil, testutil.Err
+				},
+			},
+			Args:       args("billing usage --month 2024-05 --format json"),
+			WantOutput: jsonOutput,
+		},
+	}
+
+	for testcaseIdx := range scenarios {
+		testcase := &scenarios[testcaseIdx]
+		t.Run(testcase.Name, func(t *testing.T) {
+			var stdout bytes.Buffer
+			opts := testutil.NewRunOpts(testcase.Args, &stdout)
+			opts.APIClient = mock.APIClient(testcase.API)
+			err := app.Run(opts)
+			testutil.AssertErrorContains(t, err, testcase.WantError)
+			testutil.AssertString(t, testcase.WantOutput, stdout.String())
+		})
+	}
+}
+
+func TestParseMonth(t *testing.T) {
+	now := time.Date(2024, time.June, 15, 10, 0, 0, 0, time.UTC)
+
+	got, err := billing.ParseMonth("", now)
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, time.Date(2024, time.June, 1, 0, 0, 0, 0, time.UTC), got)
+
+	got, err = billing.ParseMonth("2023-12", now)
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, time.Date(2023, time.December, 1, 0, 0, 0, 0, time.UTC), got)
+}
+
+func getStatsJSON(i *fastly.GetStatsInput, dst any) error {
+	// 2024-05-01T00:00:00Z to 2024-06-01T00:00:00Z
+	if i.From != "1714521600" || i.To != "1717200000" || i.Service != "" {
+		return testutil.Err
+	}
+	return json.Unmarshal([]byte(`{
+		"status": "success",
+		"data": {
+			"456": [
+				{"requests": 10, "bandwidth": 100, "compute_requests": 5, "compute_execution_time_ms": 1.5},
+				{"requests": 20, "bandwidth": 200, "compute_requests": 5, "compute_execution_time_ms": 2}
+			],
+			"123": [
+				{"requests": 1, "bandwidth": 2}
+			]
+		}
+	}`), dst)
+}
+
+func listServices(_ *fastly.ListServicesInput) ([]*fastly.Service, error) {
+	return []*fastly.Service{
+		{ID: "123", Name: "Foo"},
+		{ID: "456", Name: "Bar"},
+	}, nil
+}
+
+var tableOutput = `Usage for May 2024
+
+SERVICE ID  NAME  REQUESTS  BANDWIDTH (BYTES)  COMPUTE REQUESTS  COMPUTE EXECUTION TIME (MS)
+123         Foo   1         2                  0                 0
+456         Bar   30        300                10                3.5
+TOTAL             31        302                10                3.5
+`
+
+var csvOutput = `service_id,service_name,requests,bandwidth,compute_requests,compute_execution_time_ms
+123,Foo,1,2,0,0
+456,Bar,30,300,10,3.5
+`
+
+var jsonOutput = `[{"service_id":"123","service_name":"","requests":1,"bandwidth":2,"compute_requests":0,"compute_execution_time_ms":0},{"service_id":"456","service_name":"","requests":30,"bandwidth":300,"compute_requests":10,"compute_execution_time_ms":3.5}]`
@@ -0,0 +1,3 @@
+// Package billing contains commands to report on Fastly account usage for
+// billing purposes.
+package billing
@@ -0,0 +1,212 @@
+package billing
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/fastly/cli/pkg/cmd"
+	"github.com/fastly/cli/pkg/config"
+	fsterr "github.com/fastly/cli/pkg/errors"
+	"github.com/fastly/cli/pkg/text"
+	"github.com/fastly/go-fastly/v6/fastly"
+)
+
+// monthLayout is the format expected by the --month flag.
+const monthLayout = "2006-01"
+
+// NewUsageCommand returns a usable command registered under the parent.
+func NewUsageCommand(parent cmd.Registerer, globals *config.Data) *UsageCommand {
+	var c UsageCommand
+	c.CmdClause = parent.Command("usage", "Summarise bandwidth, request and Compute@Edge usage per service for a calendar month")
+	c.Globals = globals
+
+	// Optional flags
+	c.CmdClause.Flag("format", "Output format (csv, json)").HintOptions("csv", "json").EnumVar(&c.format, "csv", "json")
+	c.CmdClause.Flag("month", "Month to report on in YYYY-MM format (defaults to the current month)").StringVar(&c.month)
+
+	return &c
+}
+
+// UsageCommand calls the Fastly API to summarise account usage.
+type UsageCommand struct {
+	cmd.Base
+
+	format string
+	month  string
+}
+
+// ServiceUsage is the usage of a single service over the reporting period.
+type ServiceUsage struct {
+	ServiceID            string  `json:"service_id"`
+	ServiceName          string  `json:"service_name"`
+	Requests             uint64  `json:"requests"`
+	Bandwidth            uint64  `json:"bandwidth"`
+	ComputeRequests      uint64  `json:"compute_requests"`
+	ComputeExecutionTime float64 `json:"compute_execution_time_ms"`
+}
+
+// usageResponse models the stats API response when querying all services.
+type usageResponse struct {
+	Status string                      `json:"status"`
+	Msg    string                      `json:"msg"`
+	Data   map[string][]map[string]any `json:"data"`
+}
+
+// Exec invokes the application logic for the command.
+func (c *UsageCommand) Exec(_ io.Reader, out io.Writer) error {
+	from, err := ParseMonth(c.month, time.Now())
+	if err != nil {
+		return fsterr.RemediationError{
+			Inner:       err,
+			Remediation: "Provide --month in YYYY-MM format, e.g. --month 2024-05",
+		}
+	}
+	to := from.AddDate(0, 1, 0)
+
+	var envelope usageResponse
+	err = c.Globals.APIClient.GetStatsJSON(&fastly.GetStatsInput{
+		From: strconv.FormatInt(from.Unix(), 10),
+		To:   strconv.FormatInt(to.Unix(), 10),
+		By:   "day",
+	}, &envelope)
+	if err != nil {
+		c.Globals.ErrLog.AddWithContext(err, map[string]any{
+			"Month": from.Format(monthLayout),
+		})
+		return err
+	}
+	if envelope.Status != "success" {
+		return fmt.Errorf("non-success response: %s", envelope.Msg)
+	}
+
+	// NOTE: Service names are a nicety, so a failure to fetch them shouldn't
+	// prevent the report from being produced.
+	names := make(map[string]string)
+	services, err := c.Globals.APIClient.ListServices(&fastly.ListServicesInput{})
+	if err != nil {
+		c.Globals.ErrLog.Add(err)
+	}
+	for _, s := range services {
+		names[s.ID] = s.Name
+	}
+
+	usage := Summarise(envelope.Data, names)
+
+	switch c.format {
+	case "csv":
+		return c.printCSV(out, usage)
+	case "json":
+		return c.printJSON(out, usage)
+	default:
+		c.printTable(out, from, usage)
+		return nil
+	}
+}
+
+// ParseMonth parses a YYYY-MM value into the first instant of that month (UTC).
+// An empty value returns the current month.
+func ParseMonth(value string, now time.Time) (time.Time, error) {
+	if value == "" {
+		now = now.UTC()
+		return time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC), nil
+	}
+	t, err := time.Parse(monthLayout, value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid month '%s'", value)
+	}
+	return t, nil
+}
+
+// Summarise totals the per-period stats blocks for each service, returning
+// the results ordered by service ID.
+func Summarise(data map[string][]map[string]any, names map[string]string) []ServiceUsage {
+	usage := make([]ServiceUsage, 0, len(data))
+	for serviceID, blocks := range data {
+		u := ServiceUsage{
+			ServiceID:   serviceID,
+			ServiceName: names[serviceID],
+		}
+		for _, block := range blocks {
+			u.Requests += uint64(number(block, "requests"))
+			u.Bandwidth += uint64(number(block, "bandwidth"))
+			u.ComputeRequests += uint64(number(block, "compute_requests"))
+			u.ComputeExecutionTime += number(block, "compute_execution_time_ms")
+		}
+		usage = append(usage, u)
+	}
+	sort.Slice(usage, func(i, j int) bool {
+		return usage[i].ServiceID < usage[j].ServiceID
+	})
+	return usage
+}
+
+// number returns the numeric value of the field, or zero if it is missing.
+func number(block map[string]any, field string) float64 {
+	if v, ok := block[field].(float64); ok {
+		return v
+	}
+	return 0
+}
+
+// printTable displays the usage as a table with a trailing total row.
+func (c *UsageCommand) printTable(out io.Writer, from time.Time, usage []ServiceUsage) {
+	text.Output(out, "Usage for %s", from.Format("January 2006"))
+	text.Break(out)
+
+	var total ServiceUsage
+	t := text.NewTable(out)
+	t.AddHeader("SERVICE ID", "NAME", "REQUESTS", "BANDWIDTH (BYTES)", "COMPUTE REQUESTS", "COMPUTE EXECUTION TIME (MS)")
+	for _, u := range usage {
+		t.AddLine(u.ServiceID, u.ServiceName, u.Requests, u.Bandwidth, u.ComputeRequests, formatMillis(u.ComputeExecutionTime))
+		total.Requests += u.Requests
+		total.Bandwidth += u.Bandwidth
+		total.ComputeRequests += u.ComputeRequests
+		total.ComputeExecutionTime += u.ComputeExecutionTime
+	}
+	t.AddLine("TOTAL", "", total.Requests, total.Bandwidth, total.ComputeRequests, formatMillis(total.ComputeExecutionTime))
+	t.Print()
+}
+
+// printCSV displays the usage as CSV, suitable for spreadsheets.
+func (c *UsageCommand) printCSV(out io.Writer, usage []ServiceUsage) error {
+	w := csv.NewWriter(out)
+	records := [][]string{{"service_id", "service_name", "requests", "bandwidth", "compute_requests", "compute_execution_time_ms"}}
+	for _, u := range usage {
+		records = append(records, []string{
+			u.ServiceID,
+			u.ServiceName,
+			strconv.FormatUint(u.Requests, 10),
+			strconv.FormatUint(u.Bandwidth, 10),
+			strconv.FormatUint(u.ComputeRequests, 10),
+			formatMillis(u.ComputeExecutionTime),
+		})
+	}
+	if err := w.WriteAll(records); err != nil {
+		c.Globals.ErrLog.Add(err)
+		return fmt.Errorf("error: unable to write data to stdout: %w", err)
+	}
+	return nil
+}
+
+// printJSON displays the usage as JSON.
+func (c *UsageCommand) printJSON(out io.Writer, usage []ServiceUsage) error {
+	data, err := json.Marshal(usage)
+	if err != nil {
+		return err
+	}
+	_, err = out.Write(data)
+	if err != nil {
+		c.Globals.ErrLog.Add(err)
+		return fmt.Errorf("error: unable to write data to stdout: %w", err)
+	}
+	return nil
+}
+
+func formatMillis(ms float64) string {
+	return strconv.FormatFloat(ms, 'f', -1, 64)
+}
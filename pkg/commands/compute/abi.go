@@ -0,0 +1,189 @@
+package compute
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/fastly/cli/pkg/config"
+)
+
+// hostcallModulePrefix is the prefix of the modules of Fastly's hostcalls.
+const hostcallModulePrefix = "fastly_"
+
+// WasmImport is a function imported by a Wasm module.
+type WasmImport struct {
+	Module string
+	Name   string
+}
+
+// String returns the import as MODULE.FUNCTION.
+func (i WasmImport) String() string {
+	return i.Module + "." + i.Name
+}
+
+// The sections and import kinds of the Wasm binary format.
+// https://webassembly.github.io/spec/core/binary/modules.html
+const (
+	wasmSectionImport = 2
+
+	wasmImportFunc   = 0
+	wasmImportTable  = 1
+	wasmImportMemory = 2
+	wasmImportGlobal = 3
+	wasmImportTag    = 4
+)
+
+// wasmVersion follows the wasmMagic preamble of a Wasm binary (version 1).
+var wasmVersion = []byte{0x01, 0x00, 0x00, 0x00}
+
+// ReadWasmImports reads the functions imported by the Wasm binary.
+func ReadWasmImports(r io.Reader) ([]WasmImport, error) {
+	br := bufio.NewReader(r)
+
+	preamble := make([]byte, len(wasmMagic)+len(wasmVersion))
+	if _, err := io.ReadFull(br, preamble); err != nil || !bytes.Equal(preamble[:len(wasmMagic)], wasmMagic) || !bytes.Equal(preamble[len(wasmMagic):], wasmVersion) {
+		return nil, errors.New("not a Wasm binary (version 1)")
+	}
+
+	for {
+		id, err := br.ReadByte()
+		if errors.Is(err, io.EOF) {
+			return nil, nil // no import section
+		}
+		if err != nil {
+			return nil, err
+		}
+		size, err := binary.ReadUvarint(br)
+		if err != nil {
+			return nil, fmt.Errorf("error reading section size: %w", err)
+		}
+		if id == wasmSectionImport {
+			return readImportSection(io.LimitReader(br, int64(size)))
+		}
+		if _, err := br.Discard(int(size)); err != nil {
+			return nil, fmt.Errorf("error reading section %d: %w", id, err)
+		}
+	}
+}
+
+// readImportSection reads the function imports of an import section.
+func readImportSection(r io.Reader) ([]WasmImport, error) {
+	wr := &wasmReader{r: bufio.NewReader(r)}
+
+	var imports []WasmImport
+	for n := wr.uvarint(); n > 0 && wr.err == nil; n-- {
+		i := WasmImport{Module: wr.name(), Name: wr.name()}
+		switch kind := wr.byte(); kind {
+		case wasmImportFunc:
+			wr.uvarint() // type index
+			imports = append(imports, i)
+		case wasmImportTable:
+			wr.byte() // reference type
+			wr.limits()
+		case wasmImportMemory:
+			wr.limits()
+		case wasmImportGlobal:
+			wr.byte() // value type
+			wr.byte() // mutability
+		case wasmImportTag:
+			wr.byte()    // attribute
+			wr.uvarint() // type index
+		default:
+			if wr.err == nil {
+				wr.err = fmt.Errorf("unknown import kind %#x", kind)
+			}
+		}
+	}
+	if wr.err != nil {
+		return nil, fmt.Errorf("error reading import section: %w", wr.err)
+	}
+	return imports, nil
+}
+
+// wasmReader reads the values of the Wasm binary format, recording the first
+// error so that a sequence of values can be read before it's checked.
+type wasmReader struct {
+	r   *bufio.Reader
+	err error
+}
+
+// maxWasmName is the length of the longest name read, to avoid allocating a
+// buffer for the length read from a malformed binary.
+const maxWasmName = 1 << 16
+
+func (w *wasmReader) byte() byte {
+	if w.err != nil {
+		return 0
+	}
+	var b byte
+	b, w.err = w.r.ReadByte()
+	return b
+}
+
+func (w *wasmReader) uvarint() uint64 {
+	if w.err != nil {
+		return 0
+	}
+	var v uint64
+	v, w.err = binary.ReadUvarint(w.r)
+	return v
+}
+
+func (w *wasmReader) name() string {
+	n := w.uvarint()
+	if w.err != nil {
+		return ""
+	}
+	if n > maxWasmName {
+		w.err = fmt.Errorf("name length %d exceeds %d bytes", n, maxWasmName)
+		return ""
+	}
+	b := make([]byte, n)
+	_, w.err = io.ReadFull(w.r, b)
+	return string(b)
+}
+
+// limits reads the limits of a table or memory, whose maximum is only present
+// when the lowest bit of the flags is set.
+func (w *wasmReader) limits() {
+	if flags := w.byte(); flags&0x01 != 0 {
+		w.uvarint()
+		w.uvarint()
+		return
+	}
+	w.uvarint()
+}
+
+// UnavailableHostcalls returns the hostcalls imported by a package that aren't
+// generally available at the edge, according to the ABI: those of unknown
+// fastly_* modules, and those in preview.
+func UnavailableHostcalls(imports []WasmImport, abi config.WasmABI) []WasmImport {
+	modules := make(map[string]bool, len(abi.Modules))
+	for _, m := range abi.Modules {
+		modules[m] = true
+	}
+	preview := make(map[string]bool, len(abi.Preview))
+	for _, p := range abi.Preview {
+		preview[p] = true
+	}
+
+	var unavailable []WasmImport
+	for _, i := range imports {
+		if !strings.HasPrefix(i.Module, hostcallModulePrefix) {
+			continue // e.g. wasi_snapshot_preview1
+		}
+		if !modules[i.Module] || preview[i.String()] {
+			unavailable = append(unavailable, i)
+		}
+	}
+	sort.Slice(unavailable, func(a, b int) bool {
+		return unavailable[a].String() < unavailable[b].String()
+	})
+	return unavailable
+}
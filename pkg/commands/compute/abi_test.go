@@ -0,0 +1,106 @@
+package compute_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/fastly/cli/pkg/commands/compute"
+	"github.com/fastly/cli/pkg/config"
+	"github.com/fastly/cli/pkg/testutil"
+)
+
+// wasmBinary returns a wasm binary with a type section, which is skipped, and
+// an import section of the given imports (which must total under 128 bytes,
+// as the section size is encoded as a single byte).
+func wasmBinary(imports ...[]byte) []byte {
+	section := []byte{byte(len(imports))}
+	for _, i := range imports {
+		section = append(section, i...)
+	}
+
+	b := []byte("\x00asm\x01\x00\x00\x00")
+	b = append(b, 0x01, 0x04, 0x01, 0x60, 0x00, 0x00) // type section: () -> ()
+	b = append(b, 0x02, byte(len(section)))
+	b = append(b, section...)
+	return b
+}
+
+// wasmImport encodes an import of the given kind and description.
+func wasmImport(module, name string, desc ...byte) []byte {
+	b := append([]byte{byte(len(module))}, module...)
+	b = append(b, byte(len(name)))
+	b = append(b, name...)
+	return append(b, desc...)
+}
+
+func TestReadWasmImports(t *testing.T) {
+	for _, testcase := range []struct {
+		name        string
+		binary      []byte
+		wantError   string
+		wantImports []string
+	}{
+		{
+			name: "function imports",
+			binary: wasmBinary(
+				wasmImport("fastly_http_req", "send", 0x00, 0x00),
+				wasmImport("env", "memory", 0x02, 0x01, 0x01, 0x02),
+				wasmImport("env", "table", 0x01, 0x70, 0x00, 0x01),
+				wasmImport("env", "global", 0x03, 0x7f, 0x00),
+				wasmImport("fastly_cache", "lookup", 0x00, 0x00),
+				wasmImport("wasi_snapshot_preview1", "fd_write", 0x00, 0x00),
+			),
+			wantImports: []string{"fastly_http_req.send", "fastly_cache.lookup", "wasi_snapshot_preview1.fd_write"},
+		},
+		{
+			name:   "no import section",
+			binary: []byte("\x00asm\x01\x00\x00\x00\x01\x04\x01\x60\x00\x00"),
+		},
+		{
+			name:      "not a wasm binary",
+			binary:    []byte("#!/bin/sh\n"),
+			wantError: "not a Wasm binary",
+		},
+		{
+			name:      "truncated import section",
+			binary:    wasmBinary(wasmImport("fastly_http_req", "send", 0x00, 0x00))[:20],
+			wantError: "error reading import section",
+		},
+		{
+			name:      "unknown import kind",
+			binary:    wasmBinary(wasmImport("fastly_http_req", "send", 0x09, 0x00)),
+			wantError: "unknown import kind 0x9",
+		},
+	} {
+		t.Run(testcase.name, func(t *testing.T) {
+			imports, err := compute.ReadWasmImports(bytes.NewReader(testcase.binary))
+			testutil.AssertErrorContains(t, err, testcase.wantError)
+
+			var names []string
+			for _, i := range imports {
+				names = append(names, i.String())
+			}
+			testutil.AssertEqual(t, testcase.wantImports, names)
+		})
+	}
+}
+
+func TestUnavailableHostcalls(t *testing.T) {
+	abi := config.WasmABI{
+		Modules: []string{"fastly_http_req", "fastly_log"},
+		Preview: []string{"fastly_http_req.send_v2"},
+	}
+	imports := []compute.WasmImport{
+		{Module: "fastly_log", Name: "write"},
+		{Module: "fastly_http_req", Name: "send"},
+		{Module: "fastly_http_req", Name: "send_v2"},
+		{Module: "fastly_cache", Name: "lookup"},
+		{Module: "wasi_snapshot_preview1", Name: "fd_write"},
+	}
+
+	var names []string
+	for _, i := range compute.UnavailableHostcalls(imports, abi) {
+		names = append(names, i.String())
+	}
+	testutil.AssertEqual(t, []string{"fastly_cache.lookup", "fastly_http_req.send_v2"}, names)
+}
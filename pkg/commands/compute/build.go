@@ -2,6 +2,7 @@ package compute
 
 import (
 	"bufio"
+	"bytes"
 	"crypto/rand"
 	"errors"
 	"fmt"
@@ -9,10 +10,12 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/fastly/cli/pkg/cmd"
 	"github.com/fastly/cli/pkg/config"
 	fsterr "github.com/fastly/cli/pkg/errors"
+	fstexec "github.com/fastly/cli/pkg/exec"
 	"github.com/fastly/cli/pkg/filesystem"
 	"github.com/fastly/cli/pkg/manifest"
 	"github.com/fastly/cli/pkg/text"
@@ -23,6 +26,15 @@ import (
 // IgnoreFilePath is the filepath name of the Fastly ignore file.
 const IgnoreFilePath = ".fastlyignore"
 
+// defaultIgnoreDirs are the directories never packaged, at any depth, as they
+// contain installed dependencies, build output or version control data rather
+// than package content.
+var defaultIgnoreDirs = map[string]bool{
+	".git":         true,
+	"node_modules": true,
+	"target":       true,
+}
+
 // CustomBuildScriptMessage is the message displayed to a user when there is a
 // custom build script.
 const CustomBuildScriptMessage = "This project has a custom build script defined in the fastly.toml manifest"
@@ -38,11 +50,29 @@ type Toolchain interface {
 	Build(out io.Writer, progress text.Progress, verbose bool, callback func() error) error
 }
 
+// Auditor is implemented by the toolchains that can check the dependencies for
+// security advisories before the package is created.
+type Auditor interface {
+	Audit(out io.Writer, force, verbose bool) error
+}
+
+// NativeTestBuilder is implemented by the toolchains that can compile a native
+// test binary for the host alongside the Wasm binary.
+type NativeTestBuilder interface {
+	BuildNativeTest(out io.Writer, dst string, verbose bool) error
+}
+
 // Flags represents the flags defined for the command.
 type Flags struct {
+	Audit            bool
+	BuildArgs        []string
+	BuildLog         string
+	EnvVars          []string
 	IncludeSrc       bool
 	Lang             string
+	NativeTest       bool
 	PackageName      string
+	Sandbox          cmd.OptionalBool
 	SkipVerification bool
 	Timeout          int
 }
@@ -66,9 +96,15 @@ func NewBuildCommand(parent cmd.Registerer, globals *config.Data, data manifest.
 
 	// NOTE: when updating these flags, be sure to update the composite commands:
 	// `compute publish` and `compute serve`.
+	c.CmdClause.Flag("audit", "Check the dependencies for security advisories before packaging (Rust only, requires cargo-audit)").BoolVar(&c.Flags.Audit)
+	c.CmdClause.Flag("build-arg", "An argument appended to the built-in build command, which can be repeated (ignored when [scripts.build] is set)").StringsVar(&c.Flags.BuildArgs)
+	c.CmdClause.Flag("build-log", "Also emit build events (stages, durations, artifact sizes) as JSON lines (json)").EnumVar(&c.Flags.BuildLog, BuildLogJSON)
+	c.CmdClause.Flag("env-var", "An environment variable (KEY=value) set for the build, in addition to [scripts.env_vars], which can be repeated").StringsVar(&c.Flags.EnvVars)
 	c.CmdClause.Flag("include-source", "Include source code in built package").BoolVar(&c.Flags.IncludeSrc)
 	c.CmdClause.Flag("language", "Language type").StringVar(&c.Flags.Lang)
 	c.CmdClause.Flag("name", "Package name").StringVar(&c.Flags.PackageName)
+	c.CmdClause.Flag("native-test", "Also compile a native test binary, in parallel with the Wasm binary (Go and Rust only)").BoolVar(&c.Flags.NativeTest)
+	c.CmdClause.Flag("sandbox", "Run custom build scripts in a sandbox, as configured in the [sandbox] config section (--no-sandbox to disable)").Action(c.Flags.Sandbox.Set).NegatableBoolVar(&c.Flags.Sandbox.Value)
 	c.CmdClause.Flag("skip-verification", "Skip verification steps and force build").BoolVar(&c.Flags.SkipVerification)
 	c.CmdClause.Flag("timeout", "Timeout, in seconds, for the build compilation step").IntVar(&c.Flags.Timeout)
 
@@ -128,6 +164,16 @@ func (c *BuildCommand) Exec(in io.Reader, out io.Writer) (err error) {
 
 	name = sanitize.BaseName(name)
 
+	if err := validateEnvVars(c.Flags.EnvVars); err != nil {
+		return err
+	}
+	if err := fstexec.ValidateShell(c.Manifest.File.Scripts.Shell); err != nil {
+		c.Globals.ErrLog.Add(err)
+		return err
+	}
+	scripts := c.Manifest.File.Scripts
+	scripts.EnvVars = append(append([]string{}, scripts.EnvVars...), c.Flags.EnvVars...)
+
 	var language *Language
 	switch toolchain {
 	case "assemblyscript":
@@ -137,19 +183,26 @@ func (c *BuildCommand) Exec(in io.Reader, out io.Writer) (err error) {
 			IncludeFiles:    []string{},
 			Toolchain: NewAssemblyScript(
 				name,
-				c.Manifest.File.Scripts,
+				scripts,
+				c.buildArgs(nil),
 				c.Globals.ErrLog,
 				c.Flags.Timeout,
+				c.Globals.File.Language.JavaScript,
 			),
 		})
 	case "go":
+		flags := c.Manifest.File.Build.Go.TinyGoFlags
+		if c.Globals.File.Language.Go.Compiler == config.GoCompilerGo {
+			flags = c.Manifest.File.Build.Go.GoFlags
+		}
 		language = NewLanguage(&LanguageOptions{
 			Name:            "go",
 			SourceDirectory: GoSourceDirectory,
 			IncludeFiles:    []string{},
 			Toolchain: NewGo(
 				name,
-				c.Manifest.File.Scripts,
+				scripts,
+				c.buildArgs(flags),
 				c.Globals.ErrLog,
 				c.Flags.Timeout,
 				c.Globals.File.Language.Go,
@@ -162,9 +215,11 @@ func (c *BuildCommand) Exec(in io.Reader, out io.Writer) (err error) {
 			IncludeFiles:    []string{},
 			Toolchain: NewJavaScript(
 				name,
-				c.Manifest.File.Scripts,
+				scripts,
+				c.buildArgs(nil),
 				c.Globals.ErrLog,
 				c.Flags.Timeout,
+				c.Globals.File.Language.JavaScript,
 			),
 		})
 	case "rust":
@@ -174,7 +229,8 @@ func (c *BuildCommand) Exec(in io.Reader, out io.Writer) (err error) {
 			IncludeFiles:    []string{},
 			Toolchain: NewRust(
 				name,
-				c.Manifest.File.Scripts,
+				scripts,
+				c.buildArgs(c.Manifest.File.Build.Rust.CargoFlags),
 				c.Globals.ErrLog,
 				c.Globals.HTTPClient,
 				c.Flags.Timeout,
@@ -185,7 +241,7 @@ func (c *BuildCommand) Exec(in io.Reader, out io.Writer) (err error) {
 		language = NewLanguage(&LanguageOptions{
 			Name: "other",
 			Toolchain: NewOther(
-				c.Manifest.File.Scripts,
+				scripts,
 				c.Globals.ErrLog,
 				c.Flags.Timeout,
 			),
@@ -194,6 +250,49 @@ func (c *BuildCommand) Exec(in io.Reader, out io.Writer) (err error) {
 		return fmt.Errorf("unsupported language %s", toolchain)
 	}
 
+	if sandbox := c.sandbox(); sandbox != nil {
+		if s, ok := language.Toolchain.(Sandboxer); ok {
+			s.SetSandbox(sandbox)
+		}
+	}
+	if s, ok := language.Toolchain.(Canceler); ok {
+		s.SetContext(c.Globals.Context())
+	}
+	if d, ok := language.Toolchain.(Director); ok {
+		d.SetDir(c.Globals.Dir)
+	}
+
+	blog := newBuildLog(c.Flags.BuildLog, c.Globals.Dir, out)
+	blog.emit(BuildEvent{Event: BuildEventBuildStart, Language: language.Name, Package: name})
+	started := time.Now()
+	defer func() {
+		e := BuildEvent{Event: BuildEventBuildEnd, DurationMS: since(started)}
+		if err != nil {
+			e.Error = err.Error()
+		}
+		blog.emit(e)
+	}()
+
+	auditor, ok := language.Toolchain.(Auditor)
+	if c.Flags.Audit && !ok {
+		return fsterr.RemediationError{
+			Inner:       fmt.Errorf("--audit isn't supported for the %s language", language.Name),
+			Remediation: "Remove the --audit flag, it's only supported for Rust.",
+		}
+	}
+
+	var nativeTest NativeTestBuilder
+	if c.Flags.NativeTest {
+		var ok bool
+		nativeTest, ok = language.Toolchain.(NativeTestBuilder)
+		if !ok {
+			return fsterr.RemediationError{
+				Inner:       fmt.Errorf("--native-test isn't supported for the %s language", language.Name),
+				Remediation: "Remove the --native-test flag, it's only supported for Go and Rust.",
+			}
+		}
+	}
+
 	// NOTE: If there is a custom build script defined, then we set the toolchain
 	// to be "custom" as it means the CLI is no longer responsible for verifying
 	// the user's environment and isn't directly executing its own build process.
@@ -206,8 +305,10 @@ func (c *BuildCommand) Exec(in io.Reader, out io.Writer) (err error) {
 	// necessary to run their custom build script).
 	if c.Manifest.File.Scripts.Build == "" && !c.Flags.SkipVerification {
 		progress.Step(fmt.Sprintf("Verifying local %s toolchain...", toolchain))
+		blog.start(BuildStageVerify)
 
 		err = language.Verify(progress)
+		blog.stop(BuildStageVerify, err)
 		if err != nil {
 			c.Globals.ErrLog.AddWithContext(err, map[string]any{
 				"Language": language.Name,
@@ -220,8 +321,20 @@ func (c *BuildCommand) Exec(in io.Reader, out io.Writer) (err error) {
 	// print doesn't get hidden by the progress status.
 	progress.Done()
 
+	// NOTE: A script approved by the user is recorded in the trust store, so
+	// they're only prompted again when the script changes.
+	trust := LoadTrustStore(c.Globals.Path)
+	projectDir := c.Globals.Dir
+	if projectDir == "" {
+		projectDir, err = os.Getwd()
+		if err != nil {
+			c.Globals.ErrLog.Add(err)
+			return fmt.Errorf("getting current working directory: %w", err)
+		}
+	}
+
 	if toolchain == "custom" {
-		if !c.Globals.Flag.AutoYes && !c.Globals.Flag.NonInteractive {
+		if !c.Globals.Flag.AutoYes && !c.Globals.Flag.NonInteractive && !trust.Trusted(projectDir, TrustScriptBuild, c.Manifest.File.Scripts.Build) {
 			// NOTE: A third-party could share a project with a build command for a
 			// language that wouldn't normally require one (e.g. Rust), and do evil
 			// things. So we should notify the user and confirm they would like to
@@ -230,6 +343,9 @@ func (c *BuildCommand) Exec(in io.Reader, out io.Writer) (err error) {
 			if err != nil {
 				return err
 			}
+			if err := trust.Trust(projectDir, TrustScriptBuild, c.Manifest.File.Scripts.Build); err != nil {
+				c.Globals.ErrLog.Add(err)
+			}
 		}
 	}
 
@@ -241,21 +357,73 @@ func (c *BuildCommand) Exec(in io.Reader, out io.Writer) (err error) {
 	progress.Step(fmt.Sprintf("Building package using %s toolchain...", toolchain))
 
 	postBuildCallback := func() error {
-		if !c.Globals.Flag.AutoYes && !c.Globals.Flag.NonInteractive {
+		if !c.Globals.Flag.AutoYes && !c.Globals.Flag.NonInteractive && !trust.Trusted(projectDir, TrustScriptPostBuild, c.Manifest.File.Scripts.PostBuild) {
 			err := promptForBuildContinue(CustomPostBuildScriptMessage, c.Manifest.File.Scripts.PostBuild, out, in, c.Globals.Verbose())
 			if err != nil {
 				return err
 			}
+			if err := trust.Trust(projectDir, TrustScriptPostBuild, c.Manifest.File.Scripts.PostBuild); err != nil {
+				c.Globals.ErrLog.Add(err)
+			}
 		}
 		return nil
 	}
 
+	testBin := filepath.Join("pkg", fmt.Sprintf("%s.test", name))
+	if nativeTest != nil {
+		blog.start(BuildStageNativeTest)
+	}
+	waitNativeTest := startNativeTest(nativeTest, filepath.Join(c.Globals.Dir, testBin), c.Globals.Verbose())
+	blog.start(BuildStageCompile)
+
 	if err := language.Build(out, progress, c.Globals.Flag.Verbose, postBuildCallback); err != nil {
+		blog.stop(BuildStageCompile, err)
+		_ = waitNativeTest(out)
 		c.Globals.ErrLog.AddWithContext(err, map[string]any{
 			"Language": language.Name,
 		})
 		return err
 	}
+	blog.stop(BuildStageCompile, nil)
+	blog.artifact(filepath.Join("bin", "main.wasm"))
+
+	modules, err := stageModules(c.Globals.Dir, c.Manifest.File.Modules)
+	if err != nil {
+		_ = waitNativeTest(out)
+		c.Globals.ErrLog.AddWithContext(err, map[string]any{
+			"Modules": c.Manifest.File.Modules,
+		})
+		return err
+	}
+	for _, m := range modules {
+		blog.artifact(m)
+	}
+
+	err = waitNativeTest(out)
+	if nativeTest != nil {
+		blog.stop(BuildStageNativeTest, err)
+	}
+	if err != nil {
+		c.Globals.ErrLog.AddWithContext(err, map[string]any{
+			"Language": language.Name,
+		})
+		return fmt.Errorf("error building native test binary: %w", err)
+	}
+	if nativeTest != nil {
+		blog.artifact(testBin)
+	}
+
+	if auditor != nil {
+		blog.start(BuildStageAudit)
+		err := auditor.Audit(out, c.Flags.Audit, c.Globals.Verbose())
+		blog.stop(BuildStageAudit, err)
+		if err != nil {
+			c.Globals.ErrLog.AddWithContext(err, map[string]any{
+				"Language": language.Name,
+			})
+			return err
+		}
+	}
 
 	if c.Globals.Verbose() {
 		text.Break(out)
@@ -263,6 +431,7 @@ func (c *BuildCommand) Exec(in io.Reader, out io.Writer) (err error) {
 
 	progress = text.ResetProgress(out, c.Globals.Verbose())
 	progress.Step("Creating package archive...")
+	blog.start(BuildStagePack)
 
 	dest := filepath.Join("pkg", fmt.Sprintf("%s.tar.gz", name))
 
@@ -271,13 +440,13 @@ func (c *BuildCommand) Exec(in io.Reader, out io.Writer) (err error) {
 	}
 	files = append(files, language.IncludeFiles...)
 
-	ignoreFiles, err := GetIgnoredFiles(IgnoreFilePath)
+	ignoreFiles, err := GetIgnoredFiles(c.Globals.Dir, IgnoreFilePath)
 	if err != nil {
 		c.Globals.ErrLog.Add(err)
 		return err
 	}
 
-	binFiles, err := GetNonIgnoredFiles("bin", ignoreFiles)
+	binFiles, err := GetNonIgnoredFiles(c.Globals.Dir, "bin", ignoreFiles)
 	if err != nil {
 		c.Globals.ErrLog.AddWithContext(err, map[string]any{
 			"Ignore files": ignoreFiles,
@@ -286,8 +455,19 @@ func (c *BuildCommand) Exec(in io.Reader, out io.Writer) (err error) {
 	}
 	files = append(files, binFiles...)
 
+	// Modules are packaged even when they're ignored.
+	packaged := make(map[string]bool, len(binFiles))
+	for _, f := range binFiles {
+		packaged[f] = true
+	}
+	for _, m := range modules {
+		if !packaged[m] {
+			files = append(files, m)
+		}
+	}
+
 	if c.Flags.IncludeSrc {
-		srcFiles, err := GetNonIgnoredFiles(language.SourceDirectory, ignoreFiles)
+		srcFiles, err := GetNonIgnoredFiles(c.Globals.Dir, language.SourceDirectory, ignoreFiles)
 		if err != nil {
 			c.Globals.ErrLog.AddWithContext(err, map[string]any{
 				"Source directory": language.SourceDirectory,
@@ -298,7 +478,15 @@ func (c *BuildCommand) Exec(in io.Reader, out io.Writer) (err error) {
 		files = append(files, srcFiles...)
 	}
 
-	err = CreatePackageArchive(files, dest)
+	if c.Globals.Verbose() {
+		text.Output(out, "Packaging %d files:", len(files))
+		for _, f := range files {
+			text.Indent(out, 4, "%s", f)
+		}
+	}
+
+	err = CreatePackageArchive(c.Globals.Dir, files, filepath.Join(c.Globals.Dir, dest))
+	blog.stop(BuildStagePack, err)
 	if err != nil {
 		c.Globals.ErrLog.AddWithContext(err, map[string]any{
 			"Files":       files,
@@ -308,11 +496,84 @@ func (c *BuildCommand) Exec(in io.Reader, out io.Writer) (err error) {
 	}
 
 	progress.Done()
+	blog.artifact(dest)
 
 	text.Success(out, "Built package '%s' (%s)", name, dest)
+	if nativeTest != nil {
+		text.Info(out, "Built native test binary (%s)", testBin)
+	}
 	return nil
 }
 
+// buildArgs returns the arguments appended to the built-in build command,
+// which are those set in the fastly.toml manifest followed by the --build-arg
+// flags.
+func (c *BuildCommand) buildArgs(manifestArgs []string) []string {
+	return append(append([]string{}, manifestArgs...), c.Flags.BuildArgs...)
+}
+
+// validateEnvVars checks each environment variable is in the KEY=value format.
+func validateEnvVars(vars []string) error {
+	for _, v := range vars {
+		if k, _, ok := strings.Cut(v, "="); !ok || k == "" {
+			return fsterr.RemediationError{
+				Inner:       fmt.Errorf("invalid --env-var value: %s", v),
+				Remediation: "Set each environment variable in the KEY=value format.",
+			}
+		}
+	}
+	return nil
+}
+
+// sandbox returns the sandbox that custom scripts run in, or nil when they
+// aren't sandboxed.
+func (c *BuildCommand) sandbox() *fstexec.Sandbox {
+	cfg := c.Globals.File.Sandbox
+	enabled := cfg.Enabled
+	if c.Flags.Sandbox.WasSet {
+		enabled = c.Flags.Sandbox.Value
+	}
+	if !enabled {
+		return nil
+	}
+	return &fstexec.Sandbox{
+		AllowEnv:    cfg.EnvVars,
+		DenyNetwork: cfg.DenyNetwork,
+	}
+}
+
+// startNativeTest compiles the native test binary in the background so that
+// it's built in parallel with the Wasm binary. The returned function waits for
+// the compilation to finish and returns any error.
+//
+// NOTE: The output is buffered, and only displayed in verbose mode, as it
+// would otherwise be interleaved with the output of the Wasm build.
+func startNativeTest(b NativeTestBuilder, dst string, verbose bool) func(out io.Writer) error {
+	if b == nil {
+		return func(io.Writer) error { return nil }
+	}
+
+	var buf bytes.Buffer
+	done := make(chan error, 1)
+	go func() {
+		if err := filesystem.MakeDirectoryIfNotExists(filepath.Dir(dst)); err != nil {
+			done <- fmt.Errorf("creating %s directory: %w", filepath.Dir(dst), err)
+			return
+		}
+		done <- b.BuildNativeTest(&buf, dst, verbose)
+	}()
+
+	return func(out io.Writer) error {
+		err := <-done
+		if verbose && buf.Len() > 0 {
+			text.Break(out)
+			text.Info(out, "Native test build output:")
+			_, _ = io.Copy(out, &buf)
+		}
+		return err
+	}
+}
+
 // promptForBuildContinue ensures the user is happy to continue with the build
 // when there is either a custom build or post build in the fastly.toml
 // manifest file.
@@ -345,11 +606,14 @@ func promptForBuildContinue(msg, script string, out io.Writer, in io.Reader, ver
 // CreatePackageArchive packages build artifacts as a Fastly package, which
 // must be a GZipped Tar archive such as: package-name.tar.gz.
 //
+// The files are relative to dir, or the current directory when dir is empty,
+// and keep their relative paths within the archive.
+//
 // Due to a behavior of archiver.Archive() which recursively writes all files in
 // a provided directory to the archive we first copy our input files to a
 // temporary directory to ensure only the specified files are included and not
 // any in the directory which may be ignored.
-func CreatePackageArchive(files []string, destination string) error {
+func CreatePackageArchive(dir string, files []string, destination string) error {
 	// Create temporary directory to copy files into.
 	p := make([]byte, 8)
 	n, err := rand.Read(p)
@@ -370,14 +634,14 @@ func CreatePackageArchive(files []string, destination string) error {
 	// Create implicit top-level directory within temp which will become the
 	// root of the archive. This replaces the `tar.ImplicitTopLevelFolder`
 	// behavior.
-	dir := filepath.Join(tmpDir, FileNameWithoutExtension(destination))
-	if err := os.Mkdir(dir, 0o700); err != nil {
+	root := filepath.Join(tmpDir, FileNameWithoutExtension(destination))
+	if err := os.Mkdir(root, 0o700); err != nil {
 		return fmt.Errorf("error creating temporary directory: %w", err)
 	}
 
 	for _, src := range files {
-		dst := filepath.Join(dir, src)
-		if err = filesystem.CopyFile(src, dst); err != nil {
+		dst := filepath.Join(root, src)
+		if err = filesystem.CopyFile(filepath.Join(dir, src), dst); err != nil {
 			return fmt.Errorf("error copying file: %w", err)
 		}
 	}
@@ -386,7 +650,7 @@ func CreatePackageArchive(files []string, destination string) error {
 	tar.OverwriteExisting = true //
 	tar.MkdirAll = true          // make destination directory if it doesn't exist
 
-	return tar.Archive([]string{dir}, destination)
+	return tar.Archive([]string{root}, destination)
 }
 
 // FileNameWithoutExtension returns a filename with its extension stripped.
@@ -400,10 +664,15 @@ func FileNameWithoutExtension(filename string) string {
 }
 
 // GetIgnoredFiles reads the .fastlyignore file line-by-line and expands the
-// glob pattern into a map containing all files it matches. If no ignore file
-// is present it returns an empty map.
-func GetIgnoredFiles(filePath string) (files map[string]bool, err error) {
+// glob pattern into a map containing all files it matches. Blank lines and
+// lines starting with # are skipped. If no ignore file is present it returns
+// an empty map.
+//
+// The file path, glob patterns and returned files are relative to dir, or the
+// current directory when dir is empty.
+func GetIgnoredFiles(dir, filePath string) (files map[string]bool, err error) {
 	files = make(map[string]bool)
+	filePath = filepath.Join(dir, filePath)
 
 	if !filesystem.FileExists(filePath) {
 		return files, nil
@@ -428,11 +697,19 @@ func GetIgnoredFiles(filePath string) (files map[string]bool, err error) {
 	scanner := bufio.NewScanner(file)
 	for scanner.Scan() {
 		glob := strings.TrimSpace(scanner.Text())
-		globFiles, err := filepath.Glob(glob)
+		if glob == "" || strings.HasPrefix(glob, "#") {
+			continue
+		}
+		globFiles, err := filepath.Glob(filepath.Join(dir, glob))
 		if err != nil {
 			return files, fmt.Errorf("parsing glob %s: %w", glob, err)
 		}
 		for _, f := range globFiles {
+			if dir != "" {
+				if f, err = filepath.Rel(dir, f); err != nil {
+					return files, err
+				}
+			}
 			files[f] = true
 		}
 	}
@@ -446,19 +723,35 @@ func GetIgnoredFiles(filePath string) (files map[string]bool, err error) {
 
 // GetNonIgnoredFiles walks a filepath and returns all files that don't exist in
 // the provided ignore files map.
-func GetNonIgnoredFiles(base string, ignoredFiles map[string]bool) ([]string, error) {
+//
+// The files within an ignored directory, or one of the defaultIgnoreDirs, are
+// also left out (other than those of the base directory itself).
+//
+// The base path and returned files are relative to dir, or the current
+// directory when dir is empty.
+func GetNonIgnoredFiles(dir, base string, ignoredFiles map[string]bool) ([]string, error) {
 	var files []string
-	err := filepath.Walk(base, func(path string, info os.FileInfo, err error) error {
+	root := filepath.Join(dir, base)
+	err := filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
 		if err != nil {
 			return err
 		}
+		rel := path
+		if dir != "" {
+			if rel, err = filepath.Rel(dir, path); err != nil {
+				return err
+			}
+		}
 		if info.IsDir() {
+			if path != root && (defaultIgnoreDirs[info.Name()] || ignoredFiles[rel]) {
+				return filepath.SkipDir
+			}
 			return nil
 		}
-		if ignoredFiles[path] {
+		if ignoredFiles[rel] {
 			return nil
 		}
-		files = append(files, path)
+		files = append(files, rel)
 		return nil
 	})
 
@@ -36,6 +36,8 @@ func TestBuildRust(t *testing.T) {
 		t.Skip("Set TEST_COMPUTE_BUILD to run this test")
 	}
 
+	t.Parallel()
+
 	args := testutil.Args
 
 	scenarios := []struct {
@@ -300,12 +302,6 @@ func TestBuildRust(t *testing.T) {
 	for testcaseIdx := range scenarios {
 		testcase := &scenarios[testcaseIdx]
 		t.Run(testcase.name, func(t *testing.T) {
-			// We're going to chdir to a build environment,
-			// so save the PWD to return to, afterwards.
-			pwd, err := os.Getwd()
-			if err != nil {
-				t.Fatal(err)
-			}
 
 			// Create test environment
 			rootdir := testutil.NewEnv(testutil.EnvOpts{
@@ -322,21 +318,13 @@ func TestBuildRust(t *testing.T) {
 					{Src: testcase.cargoLock, Dst: "Cargo.lock"},
 				},
 			})
-			defer os.RemoveAll(rootdir)
-
-			// Before running the test, chdir into the build environment.
-			// When we're done, chdir back to our original location.
-			// This is so we can reliably copy the testdata/ fixtures.
-			if err := os.Chdir(rootdir); err != nil {
-				t.Fatal(err)
-			}
-			defer os.Chdir(pwd)
 
 			var stdout bytes.Buffer
 			opts := testutil.NewRunOpts(testcase.args, &stdout)
+			opts.Dir = rootdir
 			opts.ConfigFile = testcase.applicationConfig
 			opts.HTTPClient = testcase.client
-			err = app.Run(opts)
+			err := app.Run(opts)
 			t.Log(stdout.String())
 			testutil.AssertErrorContains(t, err, testcase.wantError)
 			testutil.AssertRemediationErrorContains(t, err, testcase.wantRemediationError)
@@ -354,6 +342,8 @@ func TestBuildAssemblyScript(t *testing.T) {
 		t.Skip("Set TEST_COMPUTE_BUILD_ASSEMBLYSCRIPT or TEST_COMPUTE_BUILD to run this test")
 	}
 
+	t.Parallel()
+
 	for _, testcase := range []struct {
 		name                 string
 		args                 []string
@@ -404,12 +394,6 @@ func TestBuildAssemblyScript(t *testing.T) {
 		},
 	} {
 		t.Run(testcase.name, func(t *testing.T) {
-			// We're going to chdir to a build environment,
-			// so save the PWD to return to, afterwards.
-			pwd, err := os.Getwd()
-			if err != nil {
-				t.Fatal(err)
-			}
 
 			// Create test environment
 			rootdir := testutil.NewEnv(testutil.EnvOpts{
@@ -423,19 +407,11 @@ func TestBuildAssemblyScript(t *testing.T) {
 				},
 				Exec: []string{"npm", "install"},
 			})
-			defer os.RemoveAll(rootdir)
-
-			// Before running the test, chdir into the build environment.
-			// When we're done, chdir back to our original location.
-			// This is so we can reliably copy the testdata/ fixtures.
-			if err := os.Chdir(rootdir); err != nil {
-				t.Fatal(err)
-			}
-			defer os.Chdir(pwd)
 
 			var stdout bytes.Buffer
 			opts := testutil.NewRunOpts(testcase.args, &stdout)
-			err = app.Run(opts)
+			opts.Dir = rootdir
+			err := app.Run(opts)
 			testutil.AssertErrorContains(t, err, testcase.wantError)
 			testutil.AssertRemediationErrorContains(t, err, testcase.wantRemediationError)
 			if testcase.wantOutputContains != "" {
@@ -452,12 +428,7 @@ func TestBuildJavaScript(t *testing.T) {
 		t.Skip("Set TEST_COMPUTE_BUILD_JAVASCRIPT or TEST_COMPUTE_BUILD to run this test")
 	}
 
-	// We're going to chdir to a build environment,
-	// so save the PWD to return to, afterwards.
-	pwd, err := os.Getwd()
-	if err != nil {
-		t.Fatal(err)
-	}
+	t.Parallel()
 
 	// Create test environment
 	rootdir := testutil.NewEnv(testutil.EnvOpts{
@@ -469,15 +440,6 @@ func TestBuildJavaScript(t *testing.T) {
 		},
 		Exec: []string{"npm", "install"},
 	})
-	defer os.RemoveAll(rootdir)
-
-	// Before running the test, chdir into the build environment.
-	// When we're done, chdir back to our original location.
-	// This is so we can reliably copy the testdata/ fixtures.
-	if err := os.Chdir(rootdir); err != nil {
-		t.Fatal(err)
-	}
-	defer os.Chdir(pwd)
 
 	for _, testcase := range []struct {
 		name                 string
@@ -561,6 +523,7 @@ func TestBuildJavaScript(t *testing.T) {
 
 			var stdout threadsafe.Buffer
 			opts := testutil.NewRunOpts(testcase.args, &stdout)
+			opts.Dir = rootdir
 			err = app.Run(opts)
 
 			t.Log(stdout.String())
@@ -581,12 +544,7 @@ func TestBuildGo(t *testing.T) {
 		t.Skip("Set TEST_COMPUTE_BUILD_GO or TEST_COMPUTE_BUILD to run this test")
 	}
 
-	// We're going to chdir to a build environment,
-	// so save the PWD to return to, afterwards.
-	pwd, err := os.Getwd()
-	if err != nil {
-		t.Fatal(err)
-	}
+	t.Parallel()
 
 	// Create test environment
 	rootdir := testutil.NewEnv(testutil.EnvOpts{
@@ -596,19 +554,11 @@ func TestBuildGo(t *testing.T) {
 			{Src: filepath.Join("testdata", "build", "go", "main.go"), Dst: "main.go"},
 		},
 	})
-	defer os.RemoveAll(rootdir)
-
-	// Before running the test, chdir into the build environment.
-	// When we're done, chdir back to our original location.
-	// This is so we can reliably copy the testdata/ fixtures.
-	if err := os.Chdir(rootdir); err != nil {
-		t.Fatal(err)
-	}
-	defer os.Chdir(pwd)
 
 	for _, testcase := range []struct {
 		name                 string
 		args                 []string
+		compiler             string
 		fastlyManifest       string
 		sourceOverride       string
 		wantError            string
@@ -658,6 +608,29 @@ func TestBuildGo(t *testing.T) {
 			language = "go"`,
 			wantOutputContains: "Built package 'test'",
 		},
+		{
+			name:     "success with standard go compiler",
+			args:     args("compute build --verbose"),
+			compiler: "go",
+			fastlyManifest: `
+			manifest_version = 2
+			name = "test"
+			language = "go"`,
+			wantOutputContains: "Built package 'test'",
+		},
+		{
+			name:     "build arguments",
+			args:     args("compute build --verbose --build-arg=-ldflags=-s"),
+			compiler: "go",
+			fastlyManifest: `
+			manifest_version = 2
+			name = "test"
+			language = "go"
+
+			[build.go]
+			go_flags = ["-trimpath"]`,
+			wantOutputContains: "go build -o=bin/main.wasm -trimpath -ldflags=-s ./.",
+		},
 	} {
 		t.Run(testcase.name, func(t *testing.T) {
 			if testcase.fastlyManifest != "" {
@@ -688,11 +661,14 @@ func TestBuildGo(t *testing.T) {
 
 			var stdout threadsafe.Buffer
 			opts := testutil.NewRunOpts(testcase.args, &stdout)
+			opts.Dir = rootdir
 
 			// NOTE: The following constraints should be kept in-sync with
 			// ./pkg/config/config.toml
 			opts.ConfigFile.Language.Go.TinyGoConstraint = ">= 0.24.0-0" // NOTE: -0 is to allow prereleases.
 			opts.ConfigFile.Language.Go.ToolchainConstraint = ">= 1.17 < 1.19"
+			opts.ConfigFile.Language.Go.WasiP1Constraint = ">= 1.21"
+			opts.ConfigFile.Language.Go.Compiler = testcase.compiler
 
 			err = app.Run(opts)
 
@@ -714,12 +690,7 @@ func TestCustomBuild(t *testing.T) {
 		t.Skip("Set TEST_COMPUTE_BUILD to run this test")
 	}
 
-	// We're going to chdir to a build environment,
-	// so save the PWD to return to, afterwards.
-	pwd, err := os.Getwd()
-	if err != nil {
-		t.Fatal(err)
-	}
+	t.Parallel()
 
 	// Create test environment
 	//
@@ -732,20 +703,12 @@ func TestCustomBuild(t *testing.T) {
 			{Src: "mock content", Dst: "bin/testfile"},
 		},
 	})
-	defer os.RemoveAll(rootdir)
-
-	// Before running the test, chdir into the build environment.
-	// When we're done, chdir back to our original location.
-	// This is so we can reliably copy the testdata/ fixtures.
-	if err := os.Chdir(rootdir); err != nil {
-		t.Fatal(err)
-	}
-	defer os.Chdir(pwd)
 
 	for _, testcase := range []struct {
 		args                 []string
 		dontWantOutput       []string
 		fastlyManifest       string
+		fastlyrc             string
 		name                 string
 		stdin                string
 		wantError            string
@@ -782,6 +745,25 @@ func TestCustomBuild(t *testing.T) {
 			wantError:            "build process stopped by user",
 			wantRemediationError: "Remove or update the custom [scripts.build] in the fastly.toml manifest.",
 		},
+		{
+			name: "project settings disable the prompt without answering it",
+			args: args("compute build --language other"),
+			fastlyManifest: `
+			manifest_version = 2
+			name = "test"
+			language = "other"
+			[scripts]
+			build = "echo custom build"`,
+			fastlyrc: "non_interactive = true\n",
+			stdin:    "Y",
+			wantOutput: []string{
+				compute.CustomBuildScriptMessage,
+				"Are you sure you want to continue with the build step?",
+			},
+			dontWantOutput:       []string{"Building package using custom toolchain"},
+			wantError:            "user input required but prompts are disabled",
+			wantRemediationError: "the project's non_interactive setting",
+		},
 		{
 			name: "allow build process",
 			args: args("compute build --language other"),
@@ -836,6 +818,91 @@ func TestCustomBuild(t *testing.T) {
 			},
 			wantError: "error reading Cargo.toml manifest", // we expect this to error as we don't actually setup the relevant files for a rust build
 		},
+		{
+			name: "build log",
+			args: args("compute build --auto-yes --build-log json --language other"),
+			fastlyManifest: `
+			manifest_version = 2
+			name = "test"
+			language = "other"
+			[scripts]
+			build = "echo custom build"`,
+			wantOutput: []string{
+				`{"event":"build_start","language":"other","package":"test",`,
+				`"event":"stage_start","stage":"compile"`,
+				`"event":"stage_end","stage":"compile"`,
+				`"event":"artifact","path":"pkg/test.tar.gz"`,
+				`"event":"build_end"`,
+				"Built package 'test'",
+			},
+		},
+		{
+			name: "named modules",
+			args: args("compute build --auto-yes --build-log json --language other"),
+			fastlyManifest: `
+			manifest_version = 2
+			name = "test"
+			language = "other"
+			[modules.auxiliary]
+			path = "target/auxiliary.wasm"
+			[scripts]
+			build = "mkdir -p target && printf '\\000asm' > target/auxiliary.wasm"`,
+			wantOutput: []string{
+				`"event":"artifact","path":"bin/auxiliary.wasm"`,
+				"Built package 'test'",
+			},
+		},
+		{
+			name: "invalid named module",
+			args: args("compute build --auto-yes --language other"),
+			fastlyManifest: `
+			manifest_version = 2
+			name = "test"
+			language = "other"
+			[modules.auxiliary]
+			path = "target/invalid.wasm"
+			[scripts]
+			build = "mkdir -p target && echo invalid > target/invalid.wasm"`,
+			wantError: "error validating module 'auxiliary': target/invalid.wasm is not a wasm binary",
+		},
+		{
+			name: "missing named module",
+			args: args("compute build --auto-yes --language other"),
+			fastlyManifest: `
+			manifest_version = 2
+			name = "test"
+			language = "other"
+			[modules.auxiliary]
+			path = "target/missing.wasm"
+			[scripts]
+			build = "echo custom build"`,
+			wantError:            "error reading module 'auxiliary'",
+			wantRemediationError: "Ensure the build script writes the module to 'target/missing.wasm'",
+		},
+		{
+			name: "audit unsupported",
+			args: args("compute build --language other --audit"),
+			fastlyManifest: `
+			manifest_version = 2
+			name = "test"
+			language = "other"
+			[scripts]
+			build = "echo custom build"`,
+			wantError:            "--audit isn't supported for the other language",
+			wantRemediationError: "it's only supported for Rust",
+		},
+		{
+			name: "native test binary unsupported",
+			args: args("compute build --language other --native-test"),
+			fastlyManifest: `
+			manifest_version = 2
+			name = "test"
+			language = "other"
+			[scripts]
+			build = "echo custom build"`,
+			wantError:            "--native-test isn't supported for the other language",
+			wantRemediationError: "it's only supported for Go and Rust",
+		},
 		{
 			name: "avoid prompt confirmation",
 			args: args("compute build --auto-yes --language other"),
@@ -854,6 +921,61 @@ func TestCustomBuild(t *testing.T) {
 				"Are you sure you want to continue with the build step?",
 			},
 		},
+		{
+			name: "environment variables",
+			args: args("compute build --auto-yes --verbose --language other --env-var NAME=world"),
+			fastlyManifest: `
+			manifest_version = 2
+			name = "test"
+			language = "other"
+			[scripts]
+			build = "echo $GREETING $NAME"
+			env_vars = ["GREETING=hello"]`,
+			wantOutput: []string{
+				"hello world",
+				"Built package 'test'",
+			},
+		},
+		{
+			name: "invalid environment variable",
+			args: args("compute build --auto-yes --language other --env-var NAME"),
+			fastlyManifest: `
+			manifest_version = 2
+			name = "test"
+			language = "other"
+			[scripts]
+			build = "echo custom build"`,
+			wantError:            "invalid --env-var value: NAME",
+			wantRemediationError: "KEY=value",
+		},
+		{
+			name: "shell",
+			args: args("compute build --auto-yes --verbose --language other"),
+			fastlyManifest: `
+			manifest_version = 2
+			name = "test"
+			language = "other"
+			[scripts]
+			build = "echo $0"
+			shell = "bash"`,
+			wantOutput: []string{
+				"bash",
+				"Built package 'test'",
+			},
+		},
+		{
+			name: "unsupported shell",
+			args: args("compute build --auto-yes --language other"),
+			fastlyManifest: `
+			manifest_version = 2
+			name = "test"
+			language = "other"
+			[scripts]
+			build = "echo custom build"
+			shell = "fish"`,
+			wantError:            "unsupported shell: fish",
+			wantRemediationError: "bash, cmd, powershell, pwsh, sh",
+		},
 	} {
 		t.Run(testcase.name, func(t *testing.T) {
 			if testcase.fastlyManifest != "" {
@@ -861,11 +983,19 @@ func TestCustomBuild(t *testing.T) {
 					t.Fatal(err)
 				}
 			}
+			if testcase.fastlyrc != "" {
+				path := filepath.Join(rootdir, manifest.SettingsFilename)
+				if err := os.WriteFile(path, []byte(testcase.fastlyrc), 0o600); err != nil {
+					t.Fatal(err)
+				}
+				defer os.Remove(path)
+			}
 
 			var stdout bytes.Buffer
 			opts := testutil.NewRunOpts(testcase.args, &stdout)
+			opts.Dir = rootdir
 			opts.Stdin = strings.NewReader(testcase.stdin) // NOTE: build only has one prompt when dealing with a custom build
-			err = app.Run(opts)
+			err := app.Run(opts)
 
 			t.Log(stdout.String())
 
@@ -888,12 +1018,7 @@ func TestCustomPostBuild(t *testing.T) {
 		t.Skip("Set TEST_COMPUTE_BUILD to run this test")
 	}
 
-	// We're going to chdir to a build environment,
-	// so save the PWD to return to, afterwards.
-	pwd, err := os.Getwd()
-	if err != nil {
-		t.Fatal(err)
-	}
+	t.Parallel()
 
 	// Create test environment
 	rootdir := testutil.NewEnv(testutil.EnvOpts{
@@ -910,15 +1035,6 @@ func TestCustomPostBuild(t *testing.T) {
 			{Src: "mock content", Dst: "bin/testfile"},
 		},
 	})
-	defer os.RemoveAll(rootdir)
-
-	// Before running the test, chdir into the build environment.
-	// When we're done, chdir back to our original location.
-	// This is so we can reliably copy the testdata/ fixtures.
-	if err := os.Chdir(rootdir); err != nil {
-		t.Fatal(err)
-	}
-	defer os.Chdir(pwd)
 
 	scenarios := []struct {
 		applicationConfig    config.File
@@ -1044,9 +1160,10 @@ func TestCustomPostBuild(t *testing.T) {
 
 			var stdout bytes.Buffer
 			opts := testutil.NewRunOpts(testcase.args, &stdout)
+			opts.Dir = rootdir
 			opts.ConfigFile = testcase.applicationConfig
 			opts.Stdin = strings.NewReader(testcase.stdin) // NOTE: build only has one prompt when dealing with a custom build
-			err = app.Run(opts)
+			err := app.Run(opts)
 
 			t.Log(stdout.String())
 
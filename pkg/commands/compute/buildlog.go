@@ -0,0 +1,107 @@
+package compute
+
+import (
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// BuildLogJSON is the --build-log format that emits build events as JSON lines.
+const BuildLogJSON = "json"
+
+// The build stages reported in a build event.
+const (
+	BuildStageAudit      = "audit"
+	BuildStageCompile    = "compile"
+	BuildStageNativeTest = "native_test"
+	BuildStagePack       = "pack"
+	BuildStageVerify     = "verify"
+)
+
+// The types of build event.
+const (
+	BuildEventArtifact   = "artifact"
+	BuildEventBuildEnd   = "build_end"
+	BuildEventBuildStart = "build_start"
+	BuildEventStageEnd   = "stage_end"
+	BuildEventStageStart = "stage_start"
+)
+
+// BuildEvent is a build lifecycle event, written as a single line of JSON, so
+// that build observability tooling can follow the progress of a build.
+type BuildEvent struct {
+	DurationMS *int64    `json:"duration_ms,omitempty"`
+	Error      string    `json:"error,omitempty"`
+	Event      string    `json:"event"`
+	Language   string    `json:"language,omitempty"`
+	Package    string    `json:"package,omitempty"`
+	Path       string    `json:"path,omitempty"`
+	Size       int64     `json:"size,omitempty"`
+	Stage      string    `json:"stage,omitempty"`
+	Time       time.Time `json:"time"`
+}
+
+// buildLog emits the build events. The zero value discards them, so the build
+// logic doesn't need to check whether --build-log was set.
+type buildLog struct {
+	dir     string
+	out     io.Writer
+	started map[string]time.Time
+}
+
+// newBuildLog returns a buildLog for the given --build-log format. Artifact
+// paths are relative to dir.
+func newBuildLog(format, dir string, out io.Writer) *buildLog {
+	l := &buildLog{dir: dir, started: make(map[string]time.Time)}
+	if format == BuildLogJSON {
+		l.out = out
+	}
+	return l
+}
+
+// start records the start of a stage.
+func (l *buildLog) start(stage string) {
+	l.started[stage] = time.Now()
+	l.emit(BuildEvent{Event: BuildEventStageStart, Stage: stage})
+}
+
+// stop records the end of a stage, and whether it failed.
+func (l *buildLog) stop(stage string, err error) {
+	e := BuildEvent{Event: BuildEventStageEnd, Stage: stage}
+	if t, ok := l.started[stage]; ok {
+		e.DurationMS = since(t)
+	}
+	if err != nil {
+		e.Error = err.Error()
+	}
+	l.emit(e)
+}
+
+// artifact records a file produced by the build along with its size.
+func (l *buildLog) artifact(path string) {
+	e := BuildEvent{Event: BuildEventArtifact, Path: path}
+	if fi, err := os.Stat(filepath.Join(l.dir, path)); err == nil {
+		e.Size = fi.Size()
+	}
+	l.emit(e)
+}
+
+// emit writes the event as a line of JSON.
+func (l *buildLog) emit(e BuildEvent) {
+	if l.out == nil {
+		return
+	}
+	e.Time = time.Now().UTC()
+	// NOTE: A BuildEvent can always be marshalled, and a failure to write an
+	// event shouldn't fail the build.
+	data, _ := json.Marshal(e)
+	_, _ = l.out.Write(append(data, '\n'))
+}
+
+// since returns the milliseconds elapsed since t.
+func since(t time.Time) *int64 {
+	ms := time.Since(t).Milliseconds()
+	return &ms
+}
@@ -0,0 +1,132 @@
+package compute
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"strings"
+	"text/template"
+	"time"
+
+	fsterr "github.com/fastly/cli/pkg/errors"
+	"github.com/fastly/cli/pkg/revision"
+)
+
+// commentTemplateDesc describes the --comment-template flag.
+const commentTemplateDesc = "Template for the version comment when --comment isn't set, populated from git and the environment, e.g. '{{.GitShortSHA}} by {{.GitAuthor}} via CLI {{.Version}}'"
+
+// CommentData is the data available to a --comment-template, e.g.
+//
+//	{{.GitShortSHA}} by {{.GitAuthor}} via CLI {{.Version}}
+//
+// The git fields are read from the project's git repository, falling back to
+// the variables set by common CI providers, and are empty otherwise. The
+// template can also read environment variables with {{env "NAME"}}.
+type CommentData struct {
+	// GitAuthor is the author of the HEAD commit.
+	GitAuthor string
+	// GitBranch is the checked out branch.
+	GitBranch string
+	// GitDirty indicates the working tree has uncommitted changes.
+	GitDirty bool
+	// GitMessage is the subject line of the HEAD commit.
+	GitMessage string
+	// GitSHA is the full hash of the HEAD commit.
+	GitSHA string
+	// GitShortSHA is the abbreviated hash of the HEAD commit.
+	GitShortSHA string
+	// Time is when the comment was rendered (UTC, RFC3339).
+	Time string
+	// User is the name of the local user.
+	User string
+	// Version is the version of the CLI.
+	Version string
+}
+
+// ParseCommentTemplate parses a --comment-template.
+func ParseCommentTemplate(tmpl string) (*template.Template, error) {
+	t, err := template.New("comment").Option("missingkey=error").Funcs(template.FuncMap{
+		"env": os.Getenv,
+	}).Parse(tmpl)
+	if err != nil {
+		return nil, fsterr.RemediationError{
+			Inner:       fmt.Errorf("error parsing --comment-template: %w", err),
+			Remediation: "The template uses Go's text/template syntax, e.g. --comment-template '{{.GitShortSHA}} by {{.GitAuthor}} via CLI {{.Version}}'.",
+		}
+	}
+	return t, nil
+}
+
+// RenderComment renders the parsed --comment-template.
+func RenderComment(t *template.Template, data CommentData) (string, error) {
+	var buf bytes.Buffer
+	if err := t.Execute(&buf, data); err != nil {
+		return "", fmt.Errorf("error rendering --comment-template: %w", err)
+	}
+	return strings.Join(strings.Fields(buf.String()), " "), nil
+}
+
+// ReadCommentData reads the data for a --comment-template from the git
+// repository in dir and the environment.
+func ReadCommentData(dir string, environ func(string) string) CommentData {
+	data := CommentData{
+		Time:    time.Now().UTC().Format(time.RFC3339),
+		Version: revision.AppVersion,
+	}
+	for _, k := range []string{"USER", "USERNAME"} {
+		if v := environ(k); v != "" {
+			data.User = v
+			break
+		}
+	}
+
+	// NOTE: Each field is read separately, so that a repository without
+	// commits, or a detached HEAD, still provides the other fields.
+	if sha, err := gitOutput(dir, "rev-parse", "HEAD"); err == nil {
+		data.GitSHA = sha
+	}
+	if sha, err := gitOutput(dir, "rev-parse", "--short", "HEAD"); err == nil {
+		data.GitShortSHA = sha
+	}
+	if branch, err := gitOutput(dir, "rev-parse", "--abbrev-ref", "HEAD"); err == nil && branch != "HEAD" {
+		data.GitBranch = branch
+	}
+	if author, err := gitOutput(dir, "log", "-1", "--format=%an"); err == nil {
+		data.GitAuthor = author
+	}
+	if msg, err := gitOutput(dir, "log", "-1", "--format=%s"); err == nil {
+		data.GitMessage = msg
+	}
+	if status, err := gitOutput(dir, "status", "--porcelain"); err == nil {
+		data.GitDirty = status != ""
+	}
+
+	// CI providers commonly check out a detached HEAD, or a shallow clone
+	// without the repository, and describe the commit in the environment.
+	if data.GitSHA == "" {
+		data.GitSHA = firstEnv(environ, "GITHUB_SHA", "CI_COMMIT_SHA", "CIRCLE_SHA1", "BUILDKITE_COMMIT", "GIT_COMMIT")
+		if len(data.GitSHA) > 7 {
+			data.GitShortSHA = data.GitSHA[:7]
+		} else {
+			data.GitShortSHA = data.GitSHA
+		}
+	}
+	if data.GitBranch == "" {
+		data.GitBranch = firstEnv(environ, "GITHUB_HEAD_REF", "GITHUB_REF_NAME", "CI_COMMIT_REF_NAME", "CIRCLE_BRANCH", "BUILDKITE_BRANCH", "GIT_BRANCH")
+	}
+	if data.GitAuthor == "" {
+		data.GitAuthor = firstEnv(environ, "GITHUB_ACTOR", "GITLAB_USER_NAME", "CIRCLE_USERNAME", "BUILDKITE_BUILD_CREATOR")
+	}
+	return data
+}
+
+// firstEnv returns the value of the first of the environment variables that's
+// set.
+func firstEnv(environ func(string) string, keys ...string) string {
+	for _, k := range keys {
+		if v := environ(k); v != "" {
+			return v
+		}
+	}
+	return ""
+}
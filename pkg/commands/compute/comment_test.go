@@ -0,0 +1,69 @@
+package compute_test
+
+import (
+	"os/exec"
+	"strings"
+	"testing"
+
+	"github.com/fastly/cli/pkg/commands/compute"
+	"github.com/fastly/cli/pkg/testutil"
+)
+
+func TestReadCommentData(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not found in $PATH")
+	}
+
+	environ := func(vars map[string]string) func(string) string {
+		return func(k string) string { return vars[k] }
+	}
+
+	dir := t.TempDir()
+	git := func(args ...string) string {
+		t.Helper()
+		c := exec.Command("git", append([]string{"-C", dir, "-c", "user.name=Jane Doe", "-c", "user.email=jane@example.com"}, args...)...)
+		out, err := c.CombinedOutput()
+		if err != nil {
+			t.Fatalf("git %s: %s\n\n%s", strings.Join(args, " "), err, out)
+		}
+		return strings.TrimSpace(string(out))
+	}
+
+	// Outside of a repository the CI environment variables are used.
+	data := compute.ReadCommentData(dir, environ(map[string]string{
+		"GITHUB_SHA":      "0123456789abcdef",
+		"GITHUB_REF_NAME": "main",
+		"GITHUB_ACTOR":    "octocat",
+	}))
+	testutil.AssertString(t, "0123456789abcdef", data.GitSHA)
+	testutil.AssertString(t, "0123456", data.GitShortSHA)
+	testutil.AssertString(t, "main", data.GitBranch)
+	testutil.AssertString(t, "octocat", data.GitAuthor)
+
+	git("init", "--initial-branch", "feature")
+	git("commit", "--allow-empty", "-m", "Add a feature")
+	sha := git("rev-parse", "HEAD")
+
+	data = compute.ReadCommentData(dir, environ(map[string]string{
+		"GITHUB_SHA": "0123456789abcdef",
+		"USER":       "jdoe",
+	}))
+	testutil.AssertString(t, sha, data.GitSHA)
+	testutil.AssertString(t, "feature", data.GitBranch)
+	testutil.AssertString(t, "Jane Doe", data.GitAuthor)
+	testutil.AssertString(t, "Add a feature", data.GitMessage)
+	testutil.AssertBool(t, false, data.GitDirty)
+	testutil.AssertString(t, "jdoe", data.User)
+
+	tmpl, err := compute.ParseCommentTemplate(`{{.GitShortSHA}} by {{.GitAuthor}}{{if .GitDirty}} (dirty){{end}}
+via CLI {{.Version}}`)
+	testutil.AssertNoError(t, err)
+	comment, err := compute.RenderComment(tmpl, data)
+	testutil.AssertNoError(t, err)
+	testutil.AssertString(t, data.GitShortSHA+" by Jane Doe via CLI "+data.Version, comment)
+
+	tmpl, err = compute.ParseCommentTemplate("{{.Unknown}}")
+	testutil.AssertNoError(t, err)
+	_, err = compute.RenderComment(tmpl, data)
+	testutil.AssertErrorContains(t, err, "error rendering --comment-template")
+}
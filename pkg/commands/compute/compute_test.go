@@ -1,12 +1,15 @@
 package compute_test
 
 import (
+	"archive/tar"
+	"compress/gzip"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"os"
 	"path/filepath"
 	"reflect"
+	"sort"
 	"strings"
 	"testing"
 
@@ -55,9 +58,20 @@ func TestPublishFlagDivergence(t *testing.T) {
 		expect[iter.Key().String()] = 1
 	}
 
+	// Some flags on `compute publish` are unique to it.
+	ignorePublishFlags := []string{
+		"debounce",
+		"static-dir",
+		"static-store",
+		"watch",
+	}
+
 	iter = publishFlags.MapRange()
 	for iter.Next() {
-		have[iter.Key().String()] = 1
+		flag := iter.Key().String()
+		if !ignoreFlag(ignorePublishFlags, flag) {
+			have[flag] = 1
+		}
 	}
 
 	if !reflect.DeepEqual(expect, have) {
@@ -101,6 +115,7 @@ func TestServeFlagDivergence(t *testing.T) {
 	// We only want to be sure serve contains all build flags.
 	ignoreServeFlags := []string{
 		"addr",
+		"arch",
 		"debug",
 		"env",
 		"file",
@@ -164,7 +179,7 @@ func TestCreatePackageArchive(t *testing.T) {
 
 	destination := "cli.tar.gz"
 
-	err = compute.CreatePackageArchive([]string{"Cargo.toml", "Cargo.lock", "src/main.rs"}, destination)
+	err = compute.CreatePackageArchive("", []string{"Cargo.toml", "Cargo.lock", "src/main.rs"}, destination)
 	testutil.AssertNoError(t, err)
 
 	var files, directories []string
@@ -258,6 +273,13 @@ func TestGetIgnoredFiles(t *testing.T) {
 				"Cargo.toml": true,
 			},
 		},
+		{
+			name:         "skip comments and blank lines",
+			fastlyignore: "# Cargo files\n\nCargo.lock\n",
+			wantfiles: map[string]bool{
+				"Cargo.lock": true,
+			},
+		},
 		{
 			name:         "ignore all",
 			fastlyignore: "*",
@@ -273,7 +295,7 @@ func TestGetIgnoredFiles(t *testing.T) {
 			if err := os.WriteFile(filepath.Join(rootdir, compute.IgnoreFilePath), []byte(testcase.fastlyignore), 0o777); err != nil {
 				t.Fatal(err)
 			}
-			output, err := compute.GetIgnoredFiles(compute.IgnoreFilePath)
+			output, err := compute.GetIgnoredFiles("", compute.IgnoreFilePath)
 			testutil.AssertNoError(t, err)
 			testutil.AssertEqual(t, testcase.wantfiles, output)
 		})
@@ -296,6 +318,12 @@ func TestGetNonIgnoredFiles(t *testing.T) {
 			{Src: filepath.Join("testdata", "build", "rust", "Cargo.toml"), Dst: "Cargo.toml"},
 			{Src: filepath.Join("testdata", "build", "rust", "src", "main.rs"), Dst: filepath.Join("src", "main.rs")},
 		},
+		Write: []testutil.FileIO{
+			{Src: "static", Dst: filepath.Join("src", "static", "index.html")},
+			{Src: "module.exports = {}", Dst: filepath.Join("src", "node_modules", "dep", "index.js")},
+			{Src: "[core]", Dst: filepath.Join(".git", "config")},
+			{Src: "wasm", Dst: filepath.Join("target", "main.wasm")},
+		},
 	})
 	defer os.RemoveAll(rootdir)
 
@@ -321,6 +349,17 @@ func TestGetNonIgnoredFiles(t *testing.T) {
 				"Cargo.lock",
 				"Cargo.toml",
 				filepath.Join("src/main.rs"),
+				filepath.Join("src/static/index.html"),
+			},
+		},
+		{
+			name: "ignored directory",
+			path: "src",
+			ignoredFiles: map[string]bool{
+				filepath.Join("src/static"): true,
+			},
+			wantFiles: []string{
+				filepath.Join("src/main.rs"),
 			},
 		},
 		{
@@ -332,6 +371,7 @@ func TestGetNonIgnoredFiles(t *testing.T) {
 			wantFiles: []string{
 				"Cargo.lock",
 				"Cargo.toml",
+				filepath.Join("src/static/index.html"),
 			},
 		},
 		{
@@ -343,11 +383,12 @@ func TestGetNonIgnoredFiles(t *testing.T) {
 			},
 			wantFiles: []string{
 				filepath.Join("src/main.rs"),
+				filepath.Join("src/static/index.html"),
 			},
 		},
 	} {
 		t.Run(testcase.name, func(t *testing.T) {
-			output, err := compute.GetNonIgnoredFiles(testcase.path, testcase.ignoredFiles)
+			output, err := compute.GetNonIgnoredFiles("", testcase.path, testcase.ignoredFiles)
 			testutil.AssertNoError(t, err)
 			testutil.AssertEqual(t, testcase.wantFiles, output)
 		})
@@ -510,3 +551,341 @@ func (v httpClient) Do(*http.Request) (*http.Response, error) {
 	}
 	return rec.Result(), nil
 }
+
+func TestCargoTestExecutable(t *testing.T) {
+	for _, testcase := range []struct {
+		name      string
+		input     string
+		wantExe   string
+		wantError string
+	}{
+		{
+			name:      "no test executable",
+			input:     `{"reason":"compiler-artifact","profile":{"test":false},"executable":"target/release/app"}`,
+			wantError: "cargo didn't report a test executable",
+		},
+		{
+			name:      "invalid output",
+			input:     `Compiling app`,
+			wantError: "error parsing cargo output",
+		},
+		{
+			name: "success",
+			input: `{"reason":"compiler-artifact","profile":{"test":false},"executable":null}
+{"reason":"compiler-artifact","profile":{"test":true},"executable":"target/release/deps/app-1a2b3c"}
+{"reason":"build-finished","success":true}`,
+			wantExe: "target/release/deps/app-1a2b3c",
+		},
+	} {
+		t.Run(testcase.name, func(t *testing.T) {
+			exe, err := compute.CargoTestExecutable(strings.NewReader(testcase.input))
+			testutil.AssertErrorContains(t, err, testcase.wantError)
+			testutil.AssertString(t, testcase.wantExe, exe)
+		})
+	}
+}
+
+func TestParseCargoAudit(t *testing.T) {
+	for _, testcase := range []struct {
+		name           string
+		input          string
+		wantAdvisories []compute.CargoAdvisory
+		wantError      string
+	}{
+		{
+			name:      "invalid report",
+			input:     `error: not found: Couldn't load Cargo.lock`,
+			wantError: "error parsing cargo audit report",
+		},
+		{
+			name:           "no advisories",
+			input:          `{"vulnerabilities":{"found":false,"count":0,"list":[]}}`,
+			wantAdvisories: []compute.CargoAdvisory{},
+		},
+		{
+			name: "advisories",
+			input: `{"vulnerabilities":{"found":true,"count":1,"list":[{
+				"advisory":{"id":"RUSTSEC-2020-0071","title":"Potential segfault in the time crate"},
+				"package":{"name":"time","version":"0.1.44"}
+			}]}}`,
+			wantAdvisories: []compute.CargoAdvisory{
+				{
+					ID:      "RUSTSEC-2020-0071",
+					Package: "time",
+					Title:   "Potential segfault in the time crate",
+					Version: "0.1.44",
+				},
+			},
+		},
+	} {
+		t.Run(testcase.name, func(t *testing.T) {
+			advisories, err := compute.ParseCargoAudit(strings.NewReader(testcase.input))
+			testutil.AssertErrorContains(t, err, testcase.wantError)
+			if testcase.wantError == "" {
+				testutil.AssertEqual(t, testcase.wantAdvisories, advisories)
+			}
+		})
+	}
+}
+
+func TestJsPackageManager(t *testing.T) {
+	for _, testcase := range []struct {
+		name        string
+		configured  string
+		lockfiles   []string
+		wantError   string
+		wantManager string
+	}{
+		{
+			name:        "default",
+			wantManager: "npm",
+		},
+		{
+			name:        "npm lockfile",
+			lockfiles:   []string{"package-lock.json"},
+			wantManager: "npm",
+		},
+		{
+			name:        "yarn lockfile",
+			lockfiles:   []string{"yarn.lock"},
+			wantManager: "yarn",
+		},
+		{
+			name:        "pnpm lockfile in workspace root",
+			lockfiles:   []string{filepath.Join("..", "..", "pnpm-lock.yaml")},
+			wantManager: "pnpm",
+		},
+		{
+			name:        "manifest setting overrides lockfile",
+			configured:  "pnpm",
+			lockfiles:   []string{"yarn.lock"},
+			wantManager: "pnpm",
+		},
+		{
+			name:       "unsupported manifest setting",
+			configured: "bun",
+			wantError:  "unsupported package manager: bun",
+		},
+	} {
+		t.Run(testcase.name, func(t *testing.T) {
+			dir := filepath.Join(t.TempDir(), "packages", "app")
+			if err := os.MkdirAll(dir, 0o750); err != nil {
+				t.Fatal(err)
+			}
+			for _, f := range testcase.lockfiles {
+				if err := os.WriteFile(filepath.Join(dir, f), nil, 0o600); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			manager, err := compute.JsPackageManager(testcase.configured, dir)
+			testutil.AssertErrorContains(t, err, testcase.wantError)
+			testutil.AssertString(t, testcase.wantManager, manager)
+		})
+	}
+}
+
+func TestCheckNodeVersion(t *testing.T) {
+	for _, testcase := range []struct {
+		name                 string
+		output               string
+		constraint           string
+		wantError            string
+		wantRemediationError string
+	}{
+		{
+			name:       "meets constraint",
+			output:     "v18.12.1\n",
+			constraint: ">= 16.0.0",
+		},
+		{
+			name:                 "fails constraint",
+			output:               "v14.21.2\n",
+			constraint:           ">= 16.0.0",
+			wantError:            "node version 14.21.2 didn't meet the constraint >= 16.0.0",
+			wantRemediationError: "nvm install --lts",
+		},
+		{
+			name:       "invalid output",
+			output:     "command not found",
+			constraint: ">= 16.0.0",
+			wantError:  "error parsing version output",
+		},
+		{
+			name:       "invalid constraint",
+			output:     "v18.12.1",
+			constraint: "sixteen",
+			wantError:  "error parsing node constraint",
+		},
+	} {
+		t.Run(testcase.name, func(t *testing.T) {
+			err := compute.CheckNodeVersion(testcase.output, testcase.constraint)
+			testutil.AssertErrorContains(t, err, testcase.wantError)
+			testutil.AssertRemediationErrorContains(t, err, testcase.wantRemediationError)
+		})
+	}
+}
+
+func TestTrustStore(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config.toml")
+	if err := os.WriteFile(configPath, nil, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	trust := compute.LoadTrustStore(configPath)
+	if trust.Trusted("/project", compute.TrustScriptBuild, "make build") {
+		t.Fatal("want an unapproved script to be untrusted")
+	}
+	if err := trust.Trust("/project", compute.TrustScriptBuild, "make build"); err != nil {
+		t.Fatal(err)
+	}
+
+	trust = compute.LoadTrustStore(configPath)
+	for _, testcase := range []struct {
+		name        string
+		dir         string
+		kind        string
+		script      string
+		wantTrusted bool
+	}{
+		{
+			name:        "approved script",
+			dir:         "/project",
+			kind:        compute.TrustScriptBuild,
+			script:      "make build",
+			wantTrusted: true,
+		},
+		{
+			name:   "changed script",
+			dir:    "/project",
+			kind:   compute.TrustScriptBuild,
+			script: "make build && curl evil.example.com",
+		},
+		{
+			name:   "different kind of script",
+			dir:    "/project",
+			kind:   compute.TrustScriptPostBuild,
+			script: "make build",
+		},
+		{
+			name:   "different project",
+			dir:    "/other",
+			kind:   compute.TrustScriptBuild,
+			script: "make build",
+		},
+	} {
+		t.Run(testcase.name, func(t *testing.T) {
+			testutil.AssertBool(t, testcase.wantTrusted, trust.Trusted(testcase.dir, testcase.kind, testcase.script))
+		})
+	}
+
+	t.Run("no config file", func(t *testing.T) {
+		trust := compute.LoadTrustStore(os.DevNull)
+		if err := trust.Trust("/project", compute.TrustScriptBuild, "make build"); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := os.Stat(filepath.Join(filepath.Dir(os.DevNull), compute.TrustStoreName)); err == nil {
+			t.Error("want no trust store written alongside a placeholder config")
+		}
+	})
+}
+
+func TestRemoteBuild(t *testing.T) {
+	pwd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	rootdir := t.TempDir()
+	for path, content := range map[string]string{
+		manifest.Filename:                    `name = "test"`,
+		compute.IgnoreFilePath:               "secret.txt",
+		"secret.txt":                         "secret",
+		filepath.Join("src", "main.rs"):      "fn main() {}",
+		filepath.Join("pkg", "old.tar.gz"):   "old",
+		filepath.Join("target", "main.wasm"): "wasm",
+	} {
+		path = filepath.Join(rootdir, path)
+		if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.Chdir(rootdir); err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		_ = os.Chdir(pwd)
+	}()
+
+	for _, testcase := range []struct {
+		name      string
+		endpoint  bool
+		status    int
+		body      string
+		wantError string
+	}{
+		{
+			name:      "no endpoint",
+			wantError: "no remote build endpoint configured",
+		},
+		{
+			name:     "success",
+			endpoint: true,
+			status:   http.StatusOK,
+			body:     "built package",
+		},
+		{
+			name:      "build failure",
+			endpoint:  true,
+			status:    http.StatusUnprocessableEntity,
+			body:      "error[E0425]: cannot find value",
+			wantError: "error[E0425]: cannot find value",
+		},
+	} {
+		t.Run(testcase.name, func(t *testing.T) {
+			var files []string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				testutil.AssertString(t, "123", r.Header.Get("Fastly-Key"))
+				gz, err := gzip.NewReader(r.Body)
+				if err != nil {
+					t.Error(err)
+					return
+				}
+				tr := tar.NewReader(gz)
+				for {
+					h, err := tr.Next()
+					if err != nil {
+						break
+					}
+					if h.Typeflag == tar.TypeReg {
+						files = append(files, filepath.ToSlash(h.Name))
+					}
+				}
+				w.WriteHeader(testcase.status)
+				_, _ = w.Write([]byte(testcase.body))
+			}))
+			defer server.Close()
+
+			var endpoint string
+			if testcase.endpoint {
+				endpoint = server.URL
+			}
+
+			path, err := compute.RemoteBuild(endpoint, "123", "test", http.DefaultClient)
+			testutil.AssertErrorContains(t, err, testcase.wantError)
+			if err != nil {
+				return
+			}
+
+			sort.Strings(files)
+			testutil.AssertEqual(t, []string{"test/.fastlyignore", "test/fastly.toml", "test/src/main.rs"}, files)
+			data, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			testutil.AssertString(t, testcase.body, string(data))
+		})
+	}
+}
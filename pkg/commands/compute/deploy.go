@@ -12,14 +12,19 @@ import (
 	"path/filepath"
 	"sort"
 	"strings"
+	"text/template"
+	"time"
 
 	"github.com/fastly/cli/pkg/api"
 	"github.com/fastly/cli/pkg/api/undocumented"
 	"github.com/fastly/cli/pkg/cmd"
 	"github.com/fastly/cli/pkg/commands/compute/setup"
 	"github.com/fastly/cli/pkg/config"
+	"github.com/fastly/cli/pkg/env"
 	fsterr "github.com/fastly/cli/pkg/errors"
+	fstexec "github.com/fastly/cli/pkg/exec"
 	"github.com/fastly/cli/pkg/manifest"
+	"github.com/fastly/cli/pkg/notify"
 	"github.com/fastly/cli/pkg/text"
 	"github.com/fastly/cli/pkg/undo"
 	"github.com/fastly/go-fastly/v6/fastly"
@@ -32,6 +37,25 @@ const (
 	trialNotActivated    = "Valid values for 'type' are: 'vcl'"
 )
 
+// answerCreateService is the name of the prompt confirming a new service
+// should be created, for use with the --answer and --answers-file flags.
+const answerCreateService = "deploy.create_service"
+
+// answerPostDeploy is the name of the prompt confirming the post deploy script
+// should be run, for use with the --answer and --answers-file flags.
+const answerPostDeploy = "deploy.post_deploy"
+
+// The env vars describing the deployment to the post deploy script.
+const (
+	envServiceID      = env.ServiceID
+	envServiceVersion = "FASTLY_SERVICE_VERSION"
+	envDomains        = "FASTLY_DOMAINS"
+)
+
+// CustomPostDeployScriptMessage is the message displayed to a user when there
+// is a custom post deploy script.
+const CustomPostDeployScriptMessage = "This project has a custom post deploy script defined in the fastly.toml manifest"
+
 // PackageSizeLimit describes the package size limit in bytes (currently 50mb)
 // https://docs.fastly.com/products/compute-at-edge-billing-and-resource-limits#resource-limits
 var PackageSizeLimit int64 = 50000000
@@ -42,12 +66,38 @@ type DeployCommand struct {
 
 	// NOTE: these are public so that the "publish" composite command can set the
 	// values appropriately before calling the Exec() function.
-	Comment        cmd.OptionalString
-	Domain         string
-	Manifest       manifest.Data
-	Package        string
-	ServiceName    cmd.OptionalServiceNameID
-	ServiceVersion cmd.OptionalServiceVersion
+	Comment         cmd.OptionalString
+	CommentTemplate string
+	Domain          string
+	GitRemote       string
+	GitTag          bool
+	Lock            string
+	LockTTL         time.Duration
+	Manifest        manifest.Data
+	Metadata        map[string]string
+	Notify          cmd.OptionalBool
+	Package         string
+	Policy          string
+	RemoteBuild     bool
+	RequireCleanGit bool
+	ServiceName     cmd.OptionalServiceNameID
+	ServiceVersion  cmd.OptionalServiceVersion
+
+	// result describes the last successful deploy, for the "publish" composite
+	// command's --watch summary.
+	result deployResult
+	// staticStore is the KV store of the "publish" composite command's
+	// --static-dir, which is linked to the deployed version.
+	staticStore *undocumented.KVStore
+}
+
+// deployResult describes the outcome of a successful deploy.
+type deployResult struct {
+	serviceID string
+	// skipped indicates the service version already had an identical package,
+	// so nothing was uploaded or activated.
+	skipped bool
+	version int
 }
 
 // NewDeployCommand returns a usable command registered under the parent.
@@ -78,14 +128,93 @@ func NewDeployCommand(parent cmd.Registerer, globals *config.Data, data manifest
 		Name:        cmd.FlagVersionName,
 	})
 	c.CmdClause.Flag("comment", "Human-readable comment").Action(c.Comment.Set).StringVar(&c.Comment.Value)
+	c.CmdClause.Flag("comment-template", commentTemplateDesc).PlaceHolder("TEMPLATE").StringVar(&c.CommentTemplate)
 	c.CmdClause.Flag("domain", "The name of the domain associated to the package").StringVar(&c.Domain)
+	c.CmdClause.Flag("git-remote", "The git remote that --git-tag pushes the tag to").Default("origin").StringVar(&c.GitRemote)
+	c.CmdClause.Flag("git-tag", "Create an annotated git tag (fastly-deploy/<service>/<version>) of the deployed commit and push it to --git-remote").BoolVar(&c.GitTag)
+	c.CmdClause.Flag("lock", "Name of a dictionary that holds an advisory lock during the deploy, so a concurrent deploy to the service fails rather than tramples this one").StringVar(&c.Lock)
+	c.CmdClause.Flag("lock-ttl", "How long a --lock is held before it's presumed abandoned and replaced").Default(defaultDeployLockTTL.String()).DurationVar(&c.LockTTL)
+	c.CmdClause.Flag("metadata", "Metadata recorded in the version comment, e.g. --metadata git_sha=abc123 (can be repeated)").StringMapVar(&c.Metadata)
 	c.CmdClause.Flag("name", "Package name").StringVar(&c.Manifest.Flag.Name)
+	c.CmdClause.Flag("notify", notify.FlagDesc).Action(c.Notify.Set).NegatableBoolVar(&c.Notify.Value)
 	c.CmdClause.Flag("package", "Path to a package tar.gz").Short('p').StringVar(&c.Package)
+	c.CmdClause.Flag("policy", policyDesc).PlaceHolder("FILE").StringVar(&c.Policy)
+	c.CmdClause.Flag("remote-build", "Upload the package source to the [remote_build] endpoint to be built, instead of deploying a locally built package").BoolVar(&c.RemoteBuild)
+	c.CmdClause.Flag("require-clean-git", "Fail, rather than warn, when the git working tree has uncommitted changes").BoolVar(&c.RequireCleanGit)
 	return &c
 }
 
 // Exec implements the command interface.
-func (c *DeployCommand) Exec(in io.Reader, out io.Writer) (err error) {
+func (c *DeployCommand) Exec(in io.Reader, out io.Writer) error {
+	if err := c.deploy(in, out); err != nil {
+		return err
+	}
+	if c.result.serviceID == "" || c.result.skipped {
+		return nil
+	}
+	notify.Send(notify.Event{
+		Action:    notify.ActionDeploy,
+		ServiceID: c.result.serviceID,
+		Version:   c.result.version,
+	}, c.Notify, c.Globals, out)
+	if c.GitTag {
+		c.gitTag(out)
+	}
+	if c.Manifest.File.Scripts.PostDeploy == "" {
+		return nil
+	}
+	return c.postDeploy(in, out)
+}
+
+// gitTag tags the deployed commit.
+//
+// NOTE: The package is already active, so a failure is only a warning.
+func (c *DeployCommand) gitTag(out io.Writer) {
+	tag, pushed, err := gitTagDeploy(".", c.GitRemote, c.result.serviceID, c.result.version)
+	if err != nil {
+		c.Globals.ErrLog.Add(err)
+		text.Warning(out, "%s", err)
+		return
+	}
+	if !pushed {
+		text.Info(out, "Created git tag '%s' (not pushed, as there's no '%s' remote)", tag, c.GitRemote)
+		return
+	}
+	text.Info(out, "Created git tag '%s' and pushed it to '%s'", tag, c.GitRemote)
+}
+
+// evaluatePolicy fails the deploy when its plan violates the policy.
+func (c *DeployCommand) evaluatePolicy(policy *Policy, in policyPlanInput) error {
+	plan, err := buildPolicyPlan(in)
+	if err != nil {
+		errLogService(c.Globals.ErrLog, err, in.serviceID, in.version)
+		return err
+	}
+	violations, err := policy.evaluate(plan)
+	if err != nil {
+		c.Globals.ErrLog.AddWithContext(err, map[string]any{
+			"Policy": policy.Path,
+		})
+		return err
+	}
+	if len(violations) > 0 {
+		err := policyError(policy.Path, violations)
+		c.Globals.ErrLog.AddWithContext(err, map[string]any{
+			"Policy":     policy.Path,
+			"Service ID": in.serviceID,
+		})
+		return err
+	}
+	return nil
+}
+
+// deploy uploads and activates the package.
+//
+// NOTE: It's separate from the post deploy script so that a failing script
+// doesn't cause the deployment to be undone.
+func (c *DeployCommand) deploy(in io.Reader, out io.Writer) (err error) {
+	c.result = deployResult{}
+
 	token, s := c.Globals.Token()
 	if s == config.SourceUndefined {
 		return fsterr.ErrNoToken
@@ -101,9 +230,52 @@ func (c *DeployCommand) Exec(in io.Reader, out io.Writer) (err error) {
 	verbose := c.Globals.Verbose()
 	apiClient := c.Globals.APIClient
 
+	if err := cmd.ValidateVersionMetadata(c.Metadata); err != nil {
+		return err
+	}
+	// NOTE: The template is parsed before deploying, but only rendered once the
+	// version to comment on exists.
+	var commentTemplate *template.Template
+	if !c.Comment.WasSet && c.CommentTemplate != "" {
+		commentTemplate, err = ParseCommentTemplate(c.CommentTemplate)
+		if err != nil {
+			errLog.Add(err)
+			return err
+		}
+	}
+	// NOTE: The shell is validated before deploying, as the post deploy script
+	// runs once the package is already active.
+	if c.Manifest.File.Scripts.PostDeploy != "" {
+		if err := fstexec.ValidateShell(c.Manifest.File.Scripts.Shell); err != nil {
+			errLog.Add(err)
+			return err
+		}
+	}
+
+	// NOTE: The policy is read before deploying, so that an invalid rule fails
+	// the deploy before the service is changed.
+	policy, err := LoadPolicy(c.Policy, c.Globals.Dir)
+	if err != nil {
+		errLog.Add(err)
+		return err
+	}
+
+	if err := checkCleanGit(".", c.RequireCleanGit, out); err != nil {
+		errLog.Add(err)
+		return err
+	}
+
+	// REMOTE BUILD...
+
+	if c.RemoteBuild {
+		if err := c.remoteBuild(token, out); err != nil {
+			return err
+		}
+	}
+
 	// VALIDATE PACKAGE...
 
-	pkgName, pkgPath, hashSum, err := validatePackage(c.Manifest, c.Package, errLog, out)
+	pkgName, pkgPath, hashSum, err := validatePackage(c.Manifest, c.Globals.Dir, c.Package, errLog, out)
 	if err != nil {
 		return err
 	}
@@ -115,14 +287,30 @@ func (c *DeployCommand) Exec(in io.Reader, out io.Writer) (err error) {
 
 	// SERVICE MANAGEMENT...
 
+	// NOTE: The undo stack runs as a cleanup so that its requests are made even
+	// if the deploy failed because the user interrupted it.
+	undoStack := undo.NewStack()
+	defer func() {
+		c.Globals.Interrupt.Cleanup(func() {
+			undoStack.RunIfError(out, err)
+		})
+	}()
+
 	var (
 		newService     bool
 		serviceVersion *fastly.Version
+		snapshot       versionSnapshot
 	)
 
 	if source == manifest.SourceUndefined {
 		newService = true
-		serviceID, serviceVersion, err = manageNoServiceIDFlow(c.Globals.Flag, in, out, verbose, apiClient, pkgName, c.Package, errLog, &c.Manifest.File, activateTrial)
+		previousServiceID := c.Manifest.File.ServiceID
+		serviceID, serviceVersion, err = manageNoServiceIDFlow(c.Globals.Flag, c.Globals.Answers, in, out, verbose, apiClient, pkgName, c.Package, errLog, &c.Manifest.File, filepath.Join(c.Globals.Dir, manifest.Filename), activateTrial)
+		if serviceID != "" {
+			undoStack.Push(func() error {
+				return c.undoNewService(serviceID, previousServiceID, out)
+			})
+		}
 		if err != nil {
 			return err
 		}
@@ -131,6 +319,28 @@ func (c *DeployCommand) Exec(in io.Reader, out io.Writer) (err error) {
 			return nil
 		}
 	} else {
+		if c.Lock != "" {
+			lock, err := acquireDeployLock(apiClient, serviceID, c.Lock, c.LockTTL, out)
+			if err != nil {
+				errLog.AddWithContext(err, map[string]any{
+					"Service ID": serviceID,
+					"Lock":       c.Lock,
+				})
+				return err
+			}
+			defer lock.release(out)
+		}
+
+		// NOTE: The versions are recorded before the working version is cloned,
+		// so that a version created by a concurrent deploy can be detected.
+		snapshot, err = takeVersionSnapshot(apiClient, serviceID)
+		if err != nil {
+			errLog.AddWithContext(err, map[string]any{
+				"Service ID": serviceID,
+			})
+			return err
+		}
+
 		serviceVersion, err = manageExistingServiceFlow(serviceID, c.ServiceVersion, apiClient, verbose, out, errLog)
 		if err != nil {
 			return err
@@ -153,14 +363,20 @@ func (c *DeployCommand) Exec(in io.Reader, out io.Writer) (err error) {
 	// We check and allow the user to configure these settings before continuing.
 
 	domains := &setup.Domains{
-		APIClient:      apiClient,
-		AcceptDefaults: c.Globals.Flag.AcceptDefaults,
-		NonInteractive: c.Globals.Flag.NonInteractive,
-		PackageDomain:  c.Domain,
-		ServiceID:      serviceID,
-		ServiceVersion: serviceVersion.Number,
-		Stdin:          in,
-		Stdout:         out,
+		APIClient:        apiClient,
+		APIEndpoint:      endpoint,
+		APIToken:         token,
+		Answers:          c.Globals.Answers,
+		AcceptDefaults:   c.Globals.Flag.AcceptDefaults,
+		HTTPClient:       c.Globals.HTTPClient,
+		NamingConvention: c.Globals.File.Domain.Template,
+		NamingVars:       domainNamingVars(c.Globals, pkgName),
+		NonInteractive:   c.Globals.Flag.NonInteractive,
+		PackageDomain:    c.Domain,
+		ServiceID:        serviceID,
+		ServiceVersion:   serviceVersion.Number,
+		Stdin:            in,
+		Stdout:           out,
 	}
 
 	err = domains.Validate()
@@ -178,6 +394,7 @@ func (c *DeployCommand) Exec(in io.Reader, out io.Writer) (err error) {
 	if newService {
 		backends = &setup.Backends{
 			APIClient:      apiClient,
+			Answers:        c.Globals.Answers,
 			AcceptDefaults: c.Globals.Flag.AcceptDefaults,
 			NonInteractive: c.Globals.Flag.NonInteractive,
 			ServiceID:      serviceID,
@@ -189,6 +406,7 @@ func (c *DeployCommand) Exec(in io.Reader, out io.Writer) (err error) {
 
 		dictionaries = &setup.Dictionaries{
 			APIClient:      apiClient,
+			Answers:        c.Globals.Answers,
 			AcceptDefaults: c.Globals.Flag.AcceptDefaults,
 			NonInteractive: c.Globals.Flag.NonInteractive,
 			ServiceID:      serviceID,
@@ -247,70 +465,32 @@ func (c *DeployCommand) Exec(in io.Reader, out io.Writer) (err error) {
 
 	text.Break(out)
 
-	// RESOURCE CREATION...
-
-	progress := text.ResetProgress(out, c.Globals.Verbose())
-	undoStack := undo.NewStack()
-
-	defer func(errLog fsterr.LogInterface, progress text.Progress) {
-		if err != nil {
-			errLog.Add(err)
-			progress.Fail()
-		}
-		undoStack.RunIfError(out, err)
-	}(errLog, progress)
-
-	if domains.Missing() {
-		// NOTE: We can't pass a text.Progress instance to setup.Domains at the
-		// point of constructing the domains object, as the text.Progress instance
-		// prevents other stdout from being read.
-		domains.Progress = progress
-
-		if err := domains.Create(); err != nil {
-			errLog.AddWithContext(err, map[string]any{
-				"Accept defaults": c.Globals.Flag.AcceptDefaults,
-				"Auto-yes":        c.Globals.Flag.AutoYes,
-				"Non-interactive": c.Globals.Flag.NonInteractive,
-				"Service ID":      serviceID,
-				"Service Version": serviceVersion.Number,
-			})
-			return err
-		}
-	}
-
-	if newService {
-		// NOTE: We can't pass a text.Progress instance to setup.Backends or
-		// setup.Dictionaries at the point of constructing the setup objects,
-		// as the text.Progress instance prevents other stdout from being read.
-		backends.Progress = progress
-		dictionaries.Progress = progress
-
-		if err := backends.Create(); err != nil {
-			errLog.AddWithContext(err, map[string]any{
-				"Accept defaults": c.Globals.Flag.AcceptDefaults,
-				"Auto-yes":        c.Globals.Flag.AutoYes,
-				"Non-interactive": c.Globals.Flag.NonInteractive,
-				"Service ID":      serviceID,
-				"Service Version": serviceVersion.Number,
-			})
-			return err
-		}
-
-		if err := dictionaries.Create(); err != nil {
-			errLog.AddWithContext(err, map[string]any{
-				"Accept defaults": c.Globals.Flag.AcceptDefaults,
-				"Auto-yes":        c.Globals.Flag.AutoYes,
-				"Non-interactive": c.Globals.Flag.NonInteractive,
-				"Service ID":      serviceID,
-				"Service Version": serviceVersion.Number,
-			})
+	// POLICY EVALUATION...
+	//
+	// NOTE: The plan is only complete once the resources are configured, so
+	// the policy is evaluated before they're created and the package uploaded.
+	// A new service is removed by the undo stack if the policy is violated.
+
+	if policy != nil {
+		if err := c.evaluatePolicy(policy, policyPlanInput{
+			apiClient:    apiClient,
+			backends:     backends,
+			dictionaries: dictionaries,
+			domains:      domains,
+			hashSum:      hashSum,
+			newService:   newService,
+			pkgName:      pkgName,
+			pkgPath:      pkgPath,
+			serviceID:    serviceID,
+			version:      serviceVersion.Number,
+		}); err != nil {
 			return err
 		}
 	}
 
-	// PACKAGE PROCESSING...
+	// PACKAGE COMPARISON...
 
-	cont, err := pkgCompare(apiClient, serviceID, serviceVersion.Number, hashSum, progress, out)
+	cont, err := pkgCompare(apiClient, serviceID, serviceVersion.Number, hashSum, out)
 	if err != nil {
 		errLog.AddWithContext(err, map[string]any{
 			"Package path":    pkgPath,
@@ -320,12 +500,46 @@ func (c *DeployCommand) Exec(in io.Reader, out io.Writer) (err error) {
 		return err
 	}
 	if !cont {
+		c.result = deployResult{serviceID: serviceID, skipped: true, version: serviceVersion.Number}
 		return nil
 	}
 
-	err = pkgUpload(progress, apiClient, serviceID, serviceVersion.Number, pkgPath)
-	if err != nil {
+	// RESOURCE CREATION AND PACKAGE UPLOAD...
+	//
+	// NOTE: The resources are created, and the package uploaded, concurrently.
+	// We can't pass the text.Tasks instance to the setup objects at the point
+	// of constructing them, as the tasks' output prevents other stdout (i.e.
+	// the prompts of the Configure methods) from being read.
+
+	tasks := text.NewTasks(out, c.Globals.Verbose())
+
+	var creators []func() error
+	if domains.Missing() {
+		domains.Tasks = tasks
+		creators = append(creators, domains.Create)
+	}
+	if newService {
+		backends.Tasks = tasks
+		dictionaries.Tasks = tasks
+		creators = append(creators, backends.Create, dictionaries.Create)
+	}
+	for _, create := range creators {
+		if err := create(); err != nil {
+			errLog.Add(err)
+			return err
+		}
+	}
+
+	pkgUpload(tasks, apiClient, serviceID, serviceVersion.Number, pkgPath)
+	if c.staticStore != nil {
+		staticLink(tasks, undocumented.NewClient(endpoint, token, c.Globals.HTTPClient), c.staticStore, serviceID, serviceVersion.Number)
+	}
+
+	if err = tasks.Wait(); err != nil {
 		errLog.AddWithContext(err, map[string]any{
+			"Accept defaults": c.Globals.Flag.AcceptDefaults,
+			"Auto-yes":        c.Globals.Flag.AutoYes,
+			"Non-interactive": c.Globals.Flag.NonInteractive,
 			"Package path":    pkgPath,
 			"Service ID":      serviceID,
 			"Service Version": serviceVersion.Number,
@@ -333,13 +547,36 @@ func (c *DeployCommand) Exec(in io.Reader, out io.Writer) (err error) {
 		return err
 	}
 
+	progress := text.ResetProgress(out, c.Globals.Verbose())
+
+	defer func(errLog fsterr.LogInterface, progress text.Progress) {
+		if err != nil {
+			errLog.Add(err)
+			progress.Fail()
+		}
+	}(errLog, progress)
+
 	// SERVICE PROCESSING...
 
-	if c.Comment.WasSet {
+	if c.Comment.WasSet || commentTemplate != nil || len(c.Metadata) > 0 {
+		comment := serviceVersion.Comment
+		switch {
+		case c.Comment.WasSet:
+			comment = c.Comment.Value
+		case commentTemplate != nil:
+			comment, err = RenderComment(commentTemplate, ReadCommentData(".", os.Getenv))
+			if err != nil {
+				return err
+			}
+		}
+		if len(c.Metadata) > 0 {
+			comment = cmd.SetVersionMetadata(comment, c.Metadata)
+		}
+
 		_, err = apiClient.UpdateVersion(&fastly.UpdateVersionInput{
 			ServiceID:      serviceID,
 			ServiceVersion: serviceVersion.Number,
-			Comment:        &c.Comment.Value,
+			Comment:        &comment,
 		})
 
 		if err != nil {
@@ -347,6 +584,15 @@ func (c *DeployCommand) Exec(in io.Reader, out io.Writer) (err error) {
 		}
 	}
 
+	if !newService {
+		err = checkConcurrentDeploy(apiClient, serviceID, serviceVersion.Number, snapshot)
+		if err != nil {
+			progress.Fail()
+			errLogService(errLog, err, serviceID, serviceVersion.Number)
+			return err
+		}
+	}
+
 	progress.Step("Activating version...")
 
 	_, err = apiClient.ActivateVersion(&fastly.ActivateVersionInput{
@@ -370,15 +616,142 @@ func (c *DeployCommand) Exec(in io.Reader, out io.Writer) (err error) {
 	displayDomain(apiClient, serviceID, serviceVersion.Number, out)
 
 	text.Success(out, "Deployed package (service %s, version %v)", serviceID, serviceVersion.Number)
+	c.result = deployResult{serviceID: serviceID, version: serviceVersion.Number}
+	return nil
+}
+
+// postDeploy runs the custom post deploy script, after confirming the user
+// trusts it.
+func (c *DeployCommand) postDeploy(in io.Reader, out io.Writer) error {
+	script := c.Manifest.File.Scripts.PostDeploy
+
+	// NOTE: A third-party could share a project with a post deploy script that
+	// does evil things, so we confirm the user would like to run it, as we do
+	// for custom build scripts.
+	if c.Globals.Answers.Has(answerPostDeploy) || (!c.Globals.Flag.AutoYes && !c.Globals.Flag.NonInteractive) {
+		text.Info(out, "%s:\n", CustomPostDeployScriptMessage)
+		text.Break(out)
+		text.Indent(out, 4, "%s", script)
+
+		label := "\nAre you sure you want to run the post deploy script? [y/N] "
+		answer, err := c.Globals.Answers.AskYesNo(answerPostDeploy, out, label, in)
+		if err != nil {
+			return err
+		}
+		if !answer {
+			text.Info(out, "Skipping the post deploy script.")
+			return nil
+		}
+		text.Break(out)
+	}
+
+	env := append([]string{}, c.Manifest.File.Scripts.EnvVars...)
+	env = append(env,
+		fmt.Sprintf("%s=%s", envServiceID, c.result.serviceID),
+		fmt.Sprintf("%s=%d", envServiceVersion, c.result.version),
+		fmt.Sprintf("%s=%s", envDomains, strings.Join(c.domainNames(), ",")),
+	)
+
+	command, args := Shell{Name: c.Manifest.File.Scripts.Shell}.Build(script)
+	s := fstexec.Streaming{
+		Command: command,
+		Context: c.Globals.Context(),
+		Args:    args,
+		Env:     env,
+		Output:  out,
+		Verbose: c.Globals.Verbose(),
+	}
+	if err := s.Exec(); err != nil {
+		c.Globals.ErrLog.AddWithContext(err, map[string]any{
+			"Service ID":      c.result.serviceID,
+			"Service Version": c.result.version,
+		})
+		return fsterr.RemediationError{
+			Inner:       fmt.Errorf("error running post deploy script: %w", err),
+			Remediation: fmt.Sprintf("The package was deployed (service %s, version %d). Fix the post_deploy script in the fastly.toml [scripts] section, then run it manually.", c.result.serviceID, c.result.version),
+		}
+	}
+
+	text.Success(out, "Ran post deploy script")
+	return nil
+}
+
+// remoteBuild builds the package from its source using the remote build
+// endpoint, writing it to the pkg directory from which it's then deployed.
+func (c *DeployCommand) remoteBuild(token string, out io.Writer) error {
+	if c.Package != "" {
+		return fsterr.RemediationError{
+			Inner:       fmt.Errorf("invalid flag combination, --remote-build and --package"),
+			Remediation: "Use either --remote-build to build the package source, or --package to deploy a built package, not both.",
+		}
+	}
+	if err := c.Manifest.File.ReadError(); err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			err = fsterr.ErrReadingManifest
+		}
+		return err
+	}
+
+	name, _ := c.Manifest.Name()
+
+	progress := text.NewProgress(out, c.Globals.Verbose())
+	progress.Step("Building package remotely...")
+
+	path, err := RemoteBuild(c.Globals.File.RemoteBuild.Endpoint, token, sanitize.BaseName(name), c.Globals.HTTPClient)
+	if err != nil {
+		progress.Fail()
+		c.Globals.ErrLog.AddWithContext(err, map[string]any{
+			"Endpoint": c.Globals.File.RemoteBuild.Endpoint,
+		})
+		return err
+	}
+
+	progress.Done()
+	text.Success(out, "Built package '%s' remotely (%s)", name, path)
+	text.Break(out)
 	return nil
 }
 
+// domainNames returns the names of the deployed service version's domains.
+func (c *DeployCommand) domainNames() []string {
+	domains, err := c.Globals.APIClient.ListDomains(&fastly.ListDomainsInput{
+		ServiceID:      c.result.serviceID,
+		ServiceVersion: c.result.version,
+	})
+	if err != nil {
+		c.Globals.ErrLog.Add(err)
+		return nil
+	}
+	names := make([]string, 0, len(domains))
+	for _, d := range domains {
+		names = append(names, d.Name)
+	}
+	return names
+}
+
+// domainNamingVars returns the values of the [domain] naming convention
+// placeholders, where <name> is the package name and the others come from the
+// config [domain.vars] or a FASTLY_DOMAIN_VAR_<NAME> env var.
+func domainNamingVars(globals *config.Data, pkgName string) map[string]string {
+	vars := make(map[string]string)
+	for k, v := range globals.File.Domain.Vars {
+		vars[strings.ToLower(k)] = v
+	}
+	for k, v := range globals.Env.Flags {
+		if strings.HasPrefix(k, env.DomainVarPrefix) {
+			vars[strings.ToLower(strings.TrimPrefix(k, env.DomainVarPrefix))] = v
+		}
+	}
+	vars["name"] = pkgName
+	return vars
+}
+
 // validatePackage short-circuits the deploy command if the user hasn't first
-// built a package to be deployed.
+// built a package, within the pkg directory of dir, to be deployed.
 //
 // NOTE: It also validates if the package size exceeds limit:
 // https://docs.fastly.com/products/compute-at-edge-billing-and-resource-limits#resource-limits
-func validatePackage(data manifest.Data, packageFlag string, errLog fsterr.LogInterface, out io.Writer) (pkgName, pkgPath, hashSum string, err error) {
+func validatePackage(data manifest.Data, dir, packageFlag string, errLog fsterr.LogInterface, out io.Writer) (pkgName, pkgPath, hashSum string, err error) {
 	err = data.File.ReadError()
 	if err != nil {
 		if packageFlag == "" {
@@ -397,7 +770,7 @@ func validatePackage(data manifest.Data, packageFlag string, errLog fsterr.LogIn
 	}
 
 	pkgName, source := data.Name()
-	pkgPath, err = packagePath(packageFlag, pkgName, source)
+	pkgPath, err = packagePath(dir, packageFlag, pkgName, source)
 	if err != nil {
 		errLog.AddWithContext(err, map[string]any{
 			"Package path": packageFlag,
@@ -419,30 +792,40 @@ func validatePackage(data manifest.Data, packageFlag string, errLog fsterr.LogIn
 			Remediation: fsterr.PackageSizeRemediation,
 		}
 	}
+	hashSum, err = packageHashSum(pkgPath, moduleFilenames(data.File.Modules))
+	if err != nil {
+		errLog.AddWithContext(err, map[string]any{
+			"Package path": pkgPath,
+			"Package size": pkgSize,
+		})
+		return pkgName, pkgPath, hashSum, err
+	}
+	return pkgName, pkgPath, hashSum, nil
+}
+
+// packageHashSum validates the package and returns the hash of its contents,
+// which is comparable with the hash of a service version's package. The
+// package must contain the given module files, which are included in the hash.
+func packageHashSum(pkgPath string, modules []string) (string, error) {
 	contents := map[string]*bytes.Buffer{
 		"fastly.toml": {},
 		"main.wasm":   {},
 	}
-	if err := validate(pkgPath, func(f archiver.File) error {
-		switch fname := f.Name(); fname {
-		case "fastly.toml", "main.wasm":
-			if _, err := io.Copy(contents[fname], f); err != nil {
+	for _, m := range modules {
+		contents[m] = &bytes.Buffer{}
+	}
+	if err := validate(pkgPath, modules, func(f archiver.File) error {
+		fname := f.Name()
+		if buf, ok := contents[fname]; ok {
+			if _, err := io.Copy(buf, f); err != nil {
 				return fmt.Errorf("error reading %s: %w", fname, err)
 			}
 		}
 		return nil
 	}); err != nil {
-		errLog.AddWithContext(err, map[string]any{
-			"Package path": pkgPath,
-			"Package size": pkgSize,
-		})
-		return pkgName, pkgPath, hashSum, err
-	}
-	hashSum, err = getHashSum(contents)
-	if err != nil {
-		return pkgName, pkgPath, hashSum, err
+		return "", err
 	}
-	return pkgName, pkgPath, hashSum, nil
+	return getHashSum(contents)
 }
 
 // readManifestFromPackageArchive extracts the manifest file from the given
@@ -514,14 +897,14 @@ func locateManifest(path string) (string, error) {
 }
 
 // packagePath generates a path that points to a package tar inside the pkg
-// directory if the `path` flag was not set by the user.
-func packagePath(path string, name string, source manifest.Source) (string, error) {
+// directory of dir if the `path` flag was not set by the user.
+func packagePath(dir, path string, name string, source manifest.Source) (string, error) {
 	if path == "" {
 		if source == manifest.SourceUndefined {
 			return "", fsterr.ErrReadingManifest
 		}
 
-		path = filepath.Join("pkg", fmt.Sprintf("%s.tar.gz", sanitize.BaseName(name)))
+		path = filepath.Join(dir, "pkg", fmt.Sprintf("%s.tar.gz", sanitize.BaseName(name)))
 		return path, nil
 	}
 
@@ -549,26 +932,21 @@ type activator func(customerID string) error
 
 // preconfigureActivateTrial forms a closure around an activator.
 func preconfigureActivateTrial(endpoint, token string, httpClient api.HTTPClient) activator {
+	client := undocumented.NewClient(endpoint, token, httpClient)
 	return func(customerID string) error {
-		path := fmt.Sprintf(undocumented.EdgeComputeTrial, customerID)
-		_, err := undocumented.Get(endpoint, path, token, httpClient)
-		if err != nil {
-			apiErr, ok := err.(undocumented.APIError)
-			if !ok {
-				return err
-			}
-			// 409 Conflict == The Compute@Edge trial has already been created.
-			if apiErr.StatusCode != http.StatusConflict {
-				return fmt.Errorf("%w: %d %s", err, apiErr.StatusCode, http.StatusText(apiErr.StatusCode))
-			}
+		err := client.Post(fmt.Sprintf(undocumented.EdgeComputeTrial, customerID), nil, nil)
+		// 409 Conflict == The Compute@Edge trial has already been created.
+		if apiErr, ok := err.(undocumented.APIError); ok && apiErr.StatusCode == http.StatusConflict {
+			return nil
 		}
-		return nil
+		return err
 	}
 }
 
 // manageNoServiceIDFlow handles creating a new service when no Service ID is found.
 func manageNoServiceIDFlow(
 	globalFlags config.Flag,
+	answers text.Answers,
 	in io.Reader,
 	out io.Writer,
 	verbose bool,
@@ -576,16 +954,17 @@ func manageNoServiceIDFlow(
 	pkgName, packageFlag string,
 	errLog fsterr.LogInterface,
 	manifestFile *manifest.File,
+	manifestPath string,
 	activateTrial activator,
 ) (serviceID string, serviceVersion *fastly.Version, err error) {
-	if !globalFlags.AutoYes && !globalFlags.NonInteractive {
+	if answers.Has(answerCreateService) || (!globalFlags.AutoYes && !globalFlags.NonInteractive) {
 		text.Break(out)
 		text.Output(out, "There is no Fastly service associated with this package. To connect to an existing service add the Service ID to the fastly.toml file, otherwise follow the prompts to create a service now.")
 		text.Break(out)
 		text.Output(out, "Press ^C at any time to quit.")
 		text.Break(out)
 
-		answer, err := text.AskYesNo(out, text.BoldYellow("Create new service: [y/N] "), in)
+		answer, err := answers.AskYesNo(answerCreateService, out, text.BoldYellow("Create new service: [y/N] "), in)
 		if err != nil {
 			return serviceID, serviceVersion, err
 		}
@@ -617,7 +996,7 @@ func manageNoServiceIDFlow(
 	// directory and subsequently we're reading the manifest content from within
 	// a given .tar.gz package archive file.
 	if packageFlag == "" {
-		err = updateManifestServiceID(manifestFile, manifest.Filename, serviceID)
+		err = updateManifestServiceID(manifestFile, manifestPath, serviceID)
 		if err != nil {
 			errLog.AddWithContext(err, map[string]any{
 				"Service ID": serviceID,
@@ -630,6 +1009,31 @@ func manageNoServiceIDFlow(
 	return serviceID, serviceVersion, nil
 }
 
+// undoNewService deletes a service created by a deploy that failed, and
+// restores the Service ID the manifest held before the service was created.
+func (c *DeployCommand) undoNewService(serviceID, previousServiceID string, out io.Writer) error {
+	// NOTE: The manifest is only updated when the --package flag isn't set (see
+	// manageNoServiceIDFlow).
+	if c.Package == "" {
+		if err := updateManifestServiceID(&c.Manifest.File, filepath.Join(c.Globals.Dir, manifest.Filename), previousServiceID); err != nil {
+			return err
+		}
+	}
+
+	err := c.Globals.APIClient.DeleteService(&fastly.DeleteServiceInput{
+		ID: serviceID,
+	})
+	if err != nil {
+		c.Globals.ErrLog.AddWithContext(err, map[string]any{
+			"Service ID": serviceID,
+		})
+		return fmt.Errorf("error deleting service %s: %w", serviceID, err)
+	}
+
+	text.Info(out, "Deleted the service %s created by the failed deploy.", serviceID)
+	return nil
+}
+
 // createService creates a service to associate with the compute package.
 //
 // NOTE: If the creation of the service fails because the user has not
@@ -781,7 +1185,7 @@ func checkServiceID(serviceID string, client api.Interface) error {
 
 // pkgCompare compares the local package hashsum against the existing service
 // package version and exits early with message if identical.
-func pkgCompare(client api.Interface, serviceID string, version int, hashSum string, progress text.Progress, out io.Writer) (bool, error) {
+func pkgCompare(client api.Interface, serviceID string, version int, hashSum string, out io.Writer) (bool, error) {
 	p, err := client.GetPackage(&fastly.GetPackageInput{
 		ServiceID:      serviceID,
 		ServiceVersion: version,
@@ -789,7 +1193,6 @@ func pkgCompare(client api.Interface, serviceID string, version int, hashSum str
 
 	if err == nil {
 		if hashSum == p.Metadata.HashSum {
-			progress.Done()
 			text.Info(out, "Skipping package deployment, local and service version are identical. (service %v, version %v) ", serviceID, version)
 			return false, nil
 		}
@@ -814,20 +1217,20 @@ func getHashSum(contents map[string]*bytes.Buffer) (hash string, err error) {
 	return fmt.Sprintf("%x", h.Sum(nil)), nil
 }
 
-// pkgUpload uploads the package to the specified service and version.
-func pkgUpload(progress text.Progress, client api.Interface, serviceID string, version int, path string) error {
-	progress.Step("Uploading package...")
-
-	_, err := client.UpdatePackage(&fastly.UpdatePackageInput{
-		ServiceID:      serviceID,
-		ServiceVersion: version,
-		PackagePath:    path,
+// pkgUpload starts a task, with the tasks, that uploads the package to the
+// specified service and version.
+func pkgUpload(tasks *text.Tasks, client api.Interface, serviceID string, version int, path string) {
+	tasks.Go("Uploading package...", func(_ io.Writer) error {
+		_, err := client.UpdatePackage(&fastly.UpdatePackageInput{
+			ServiceID:      serviceID,
+			ServiceVersion: version,
+			PackagePath:    path,
+		})
+		if err != nil {
+			return fmt.Errorf("error uploading package: %w", err)
+		}
+		return nil
 	})
-	if err != nil {
-		return fmt.Errorf("error uploading package: %w", err)
-	}
-
-	return nil
 }
 
 // displayDomain displays a domain from those available in the service.
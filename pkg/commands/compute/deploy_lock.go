@@ -0,0 +1,284 @@
+package compute
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/fastly/cli/pkg/api"
+	fsterr "github.com/fastly/cli/pkg/errors"
+	"github.com/fastly/cli/pkg/text"
+	"github.com/fastly/go-fastly/v6/fastly"
+)
+
+// deployLockKey is the dictionary item key that holds the deploy lock.
+const deployLockKey = "deploy_lock"
+
+// defaultDeployLockTTL is how long a deploy lock is held before it's presumed
+// to have been abandoned (e.g. by a CI job that was cancelled).
+const defaultDeployLockTTL = 30 * time.Minute
+
+// versionSnapshot records the latest and active versions of a service when a
+// deploy starts, so that a concurrent deploy can be detected before
+// activating.
+type versionSnapshot struct {
+	active int
+	latest int
+}
+
+// takeVersionSnapshot returns the latest and active versions of the service.
+func takeVersionSnapshot(client api.Interface, serviceID string) (versionSnapshot, error) {
+	var s versionSnapshot
+	vs, err := client.ListVersions(&fastly.ListVersionsInput{
+		ServiceID: serviceID,
+	})
+	if err != nil {
+		return s, fmt.Errorf("error listing service versions: %w", err)
+	}
+	for _, v := range vs {
+		if v.Number > s.latest {
+			s.latest = v.Number
+		}
+		if v.Active {
+			s.active = v.Number
+		}
+	}
+	return s, nil
+}
+
+// checkConcurrentDeploy returns an error if a version other than the deploy's
+// own version was created, or a different version activated, since the
+// snapshot was taken.
+func checkConcurrentDeploy(client api.Interface, serviceID string, version int, snapshot versionSnapshot) error {
+	current, err := takeVersionSnapshot(client, serviceID)
+	if err != nil {
+		return err
+	}
+
+	var reason string
+	switch {
+	case current.active != snapshot.active:
+		reason = fmt.Sprintf("version %d was activated", current.active)
+	case current.latest > snapshot.latest && current.latest != version:
+		reason = fmt.Sprintf("version %d was created", current.latest)
+	case version > snapshot.latest+1:
+		// NOTE: A version created between the snapshot and the deploy's own
+		// version being cloned has a lower number than the deploy's version.
+		reason = fmt.Sprintf("version %d was created", snapshot.latest+1)
+	}
+	if reason == "" {
+		return nil
+	}
+
+	return fsterr.RemediationError{
+		Inner:       fmt.Errorf("service %s changed during the deploy: %s, so version %d wasn't activated", serviceID, reason, version),
+		Remediation: "Another deploy may be in progress. Check the service versions, then run the deploy again. Use --lock to prevent concurrent deploys.",
+	}
+}
+
+// deployLock is an advisory lock held in a dictionary item, which prevents
+// concurrent deploys to a service from trampling each other.
+//
+// NOTE: Dictionary items aren't versioned, so the lock is seen by every
+// deploy regardless of which version it's working on.
+type deployLock struct {
+	client       api.Interface
+	dictionaryID string
+	serviceID    string
+	// takeover is the key of the item that claimed a stale lock, if any.
+	takeover string
+	// token identifies the lock item as this deploy's.
+	token string
+}
+
+// acquireDeployLock creates the lock item in the named dictionary, failing if
+// another deploy holds it. A lock older than the ttl is presumed abandoned
+// and replaced.
+func acquireDeployLock(client api.Interface, serviceID, dictionary string, ttl time.Duration, out io.Writer) (*deployLock, error) {
+	snapshot, err := takeVersionSnapshot(client, serviceID)
+	if err != nil {
+		return nil, err
+	}
+	d, err := client.GetDictionary(&fastly.GetDictionaryInput{
+		ServiceID:      serviceID,
+		ServiceVersion: snapshot.latest,
+		Name:           dictionary,
+	})
+	if err != nil {
+		return nil, fsterr.RemediationError{
+			Inner:       fmt.Errorf("error fetching the deploy lock dictionary '%s': %w", dictionary, err),
+			Remediation: "The --lock flag requires an existing dictionary. Create one with `fastly dictionary create --name <name> --version latest --autoclone`, then activate the version.",
+		}
+	}
+
+	token := make([]byte, 8)
+	if _, err := rand.Read(token); err != nil {
+		return nil, fmt.Errorf("error generating the deploy lock token: %w", err)
+	}
+	l := &deployLock{
+		client:       client,
+		dictionaryID: d.ID,
+		serviceID:    serviceID,
+		token:        hex.EncodeToString(token),
+	}
+
+	existing, err := l.create()
+	if err == nil {
+		return l, nil
+	}
+	lockedAt, _, holder := parseDeployLock(existing)
+	if lockedAt.IsZero() || time.Since(lockedAt) < ttl {
+		if existing == "" {
+			return nil, fmt.Errorf("error acquiring the deploy lock: %w", err)
+		}
+		return nil, l.lockedErr(holder)
+	}
+
+	text.Warning(out, "Replacing the deploy lock held since %s (%s), as it's older than %s.", lockedAt.Format(time.RFC3339), holder, ttl)
+	text.Break(out)
+	if err := l.takeOver(existing); err != nil {
+		return nil, err
+	}
+	return l, nil
+}
+
+// takeOver replaces the stale lock with this deploy's lock.
+//
+// NOTE: Dictionary items can't be changed conditionally, so the deploys that
+// find the same stale lock race to create an item named after its token, and
+// only the one that creates it replaces the lock. The lock is also re-read
+// before it's deleted, in case its holder released it in the meantime.
+func (l *deployLock) takeOver(stale string) error {
+	_, staleToken, staleHolder := parseDeployLock(stale)
+	key := deployLockKey + "_takeover_" + staleToken
+	_, err := l.client.CreateDictionaryItem(&fastly.CreateDictionaryItemInput{
+		ServiceID:    l.serviceID,
+		DictionaryID: l.dictionaryID,
+		ItemKey:      key,
+		ItemValue:    l.token,
+	})
+	if err != nil {
+		return l.lockedErr(fmt.Sprintf("replacing the stale lock of %s", staleHolder))
+	}
+	l.takeover = key
+
+	current, err := l.client.GetDictionaryItem(&fastly.GetDictionaryItemInput{
+		ServiceID:    l.serviceID,
+		DictionaryID: l.dictionaryID,
+		ItemKey:      deployLockKey,
+	})
+	switch {
+	case err == nil && current.ItemValue != stale:
+		_, _, holder := parseDeployLock(current.ItemValue)
+		l.deleteTakeover()
+		return l.lockedErr(holder)
+	case err == nil:
+		if err := l.client.DeleteDictionaryItem(&fastly.DeleteDictionaryItemInput{
+			ServiceID:    l.serviceID,
+			DictionaryID: l.dictionaryID,
+			ItemKey:      deployLockKey,
+		}); err != nil {
+			l.deleteTakeover()
+			return fmt.Errorf("error replacing the deploy lock: %w", err)
+		}
+	}
+
+	if _, err := l.create(); err != nil {
+		l.deleteTakeover()
+		return fmt.Errorf("error acquiring the deploy lock: %w", err)
+	}
+	return nil
+}
+
+// create attempts to create the lock item. If the item already exists, it
+// returns the value of the existing lock.
+func (l *deployLock) create() (existing string, err error) {
+	hostname, _ := os.Hostname()
+	_, err = l.client.CreateDictionaryItem(&fastly.CreateDictionaryItemInput{
+		ServiceID:    l.serviceID,
+		DictionaryID: l.dictionaryID,
+		ItemKey:      deployLockKey,
+		ItemValue:    fmt.Sprintf("%s %s %s (pid %d)", time.Now().UTC().Format(time.RFC3339), l.token, hostname, os.Getpid()),
+	})
+	if err == nil {
+		return "", nil
+	}
+
+	item, getErr := l.client.GetDictionaryItem(&fastly.GetDictionaryItemInput{
+		ServiceID:    l.serviceID,
+		DictionaryID: l.dictionaryID,
+		ItemKey:      deployLockKey,
+	})
+	if getErr != nil {
+		return "", err
+	}
+	return item.ItemValue, err
+}
+
+// lockedErr reports that the lock is held by another deploy.
+func (l *deployLock) lockedErr(holder string) error {
+	return fsterr.RemediationError{
+		Inner:       fmt.Errorf("service %s is locked by another deploy (%s)", l.serviceID, holder),
+		Remediation: fmt.Sprintf("Wait for the other deploy to finish, or if it was abandoned, remove the lock with `fastly dictionary-item delete --service-id %s --dictionary-id %s --key %s`.", l.serviceID, l.dictionaryID, deployLockKey),
+	}
+}
+
+// release deletes the lock item, unless it's no longer this deploy's lock
+// (e.g. it was presumed abandoned and replaced by another deploy).
+//
+// NOTE: A failure to release the lock is only a warning, as the deploy itself
+// has finished, and the lock expires after its ttl.
+func (l *deployLock) release(out io.Writer) {
+	defer l.deleteTakeover()
+
+	item, err := l.client.GetDictionaryItem(&fastly.GetDictionaryItemInput{
+		ServiceID:    l.serviceID,
+		DictionaryID: l.dictionaryID,
+		ItemKey:      deployLockKey,
+	})
+	if err != nil {
+		text.Warning(out, "Failed to release the deploy lock: %s", err)
+		return
+	}
+	if _, token, holder := parseDeployLock(item.ItemValue); token != l.token {
+		text.Warning(out, "The deploy lock wasn't released, as it's now held by another deploy (%s).", holder)
+		return
+	}
+
+	err = l.client.DeleteDictionaryItem(&fastly.DeleteDictionaryItemInput{
+		ServiceID:    l.serviceID,
+		DictionaryID: l.dictionaryID,
+		ItemKey:      deployLockKey,
+	})
+	if err != nil {
+		text.Warning(out, "Failed to release the deploy lock: %s", err)
+	}
+}
+
+// deleteTakeover deletes the item that claimed a stale lock, if any.
+func (l *deployLock) deleteTakeover() {
+	if l.takeover == "" {
+		return
+	}
+	// NOTE: A leftover item only prevents the stale lock it was named after
+	// from being replaced again, and so the error is ignored.
+	_ = l.client.DeleteDictionaryItem(&fastly.DeleteDictionaryItemInput{
+		ServiceID:    l.serviceID,
+		DictionaryID: l.dictionaryID,
+		ItemKey:      l.takeover,
+	})
+	l.takeover = ""
+}
+
+// parseDeployLock returns the creation time, token and holder of the value of
+// a lock item.
+func parseDeployLock(value string) (lockedAt time.Time, token, holder string) {
+	at, rest, _ := strings.Cut(value, " ")
+	token, holder, _ = strings.Cut(rest, " ")
+	lockedAt, _ = time.Parse(time.RFC3339, at)
+	return lockedAt, token, holder
+}
@@ -8,16 +8,20 @@ import (
 	"net/http"
 	"net/url"
 	"os"
+	"os/exec"
 	"path/filepath"
+	"runtime"
 	"strings"
 	"testing"
 	"time"
 
 	"github.com/fastly/cli/pkg/app"
 	"github.com/fastly/cli/pkg/commands/compute"
+	"github.com/fastly/cli/pkg/config"
 	"github.com/fastly/cli/pkg/errors"
 	"github.com/fastly/cli/pkg/manifest"
 	"github.com/fastly/cli/pkg/mock"
+	"github.com/fastly/cli/pkg/revision"
 	"github.com/fastly/cli/pkg/testutil"
 	"github.com/fastly/go-fastly/v6/fastly"
 )
@@ -79,10 +83,14 @@ func TestDeploy(t *testing.T) {
 
 	originalPackageSizeLimit := compute.PackageSizeLimit
 	args := testutil.Args
+	listDomainsNamed, createDomainNamed := listDomainsCreated()
 	scenarios := []struct {
 		api                  mock.API
 		args                 []string
+		configFile           config.File
+		dontWantManifest     []string
 		dontWantOutput       []string
+		env                  map[string]string
 		httpClientRes        *http.Response
 		httpClientErr        error
 		manifest             string
@@ -106,6 +114,31 @@ func TestDeploy(t *testing.T) {
 			wantRemediationError: errors.ComputeInitRemediation,
 			noManifest:           true,
 		},
+		{
+			name:                 "remote build with package",
+			args:                 args("compute deploy --token 123 --remote-build --package pkg/package.tar.gz"),
+			wantError:            "invalid flag combination, --remote-build and --package",
+			wantRemediationError: "not both",
+		},
+		{
+			name:                 "remote build without endpoint",
+			args:                 args("compute deploy --token 123 --remote-build"),
+			wantError:            "no remote build endpoint configured",
+			wantRemediationError: "[remote_build]",
+		},
+		{
+			name: "package missing named module",
+			args: args("compute deploy --token 123"),
+			manifest: `
+			name = "package"
+			manifest_version = 2
+			language = "rust"
+
+			[modules.auxiliary]
+			path = "bin/auxiliary.wasm"
+			`,
+			wantError: "package must contain a auxiliary.wasm file",
+		},
 		{
 			// If no Service ID defined via flag or manifest, then the expectation is
 			// for the service to be created via the API and for the returned ID to
@@ -213,6 +246,10 @@ func TestDeploy(t *testing.T) {
 			wantError: fmt.Sprintf("error uploading package: %s", testutil.Err.Error()),
 			wantOutput: []string{
 				"Uploading package...",
+				"Deleted the service 12345 created by the failed deploy.",
+			},
+			dontWantManifest: []string{
+				"12345",
 			},
 		},
 		// The following test doesn't provide a Service ID by either a flag nor the
@@ -349,6 +386,68 @@ func TestDeploy(t *testing.T) {
 				"Creating domain '",
 			},
 		},
+		// The following test validates that a --domain that's already in use is
+		// rejected before attempting to create it.
+		{
+			name: "service domain in use",
+			args: args("compute deploy --token 123 --domain taken.edgecompute.app"),
+			api: mock.API{
+				CreateServiceFn: createServiceOK,
+				DeleteServiceFn: deleteServiceOK,
+				ListDomainsFn:   listDomainsNone,
+			},
+			httpClientRes: &http.Response{
+				Body:       io.NopCloser(strings.NewReader(`{"domain":"taken.edgecompute.app","status":"active"}`)),
+				Status:     http.StatusText(http.StatusOK),
+				StatusCode: http.StatusOK,
+			},
+			stdin: []string{
+				"Y", // when prompted to create a new service
+			},
+			wantError: "error configuring service domains: domain 'taken.edgecompute.app' is already in use",
+			dontWantOutput: []string{
+				"Creating domain '",
+			},
+		},
+		{
+			name: "service domain from naming convention",
+			args: args("compute deploy --non-interactive --token 123"),
+			api: mock.API{
+				ActivateVersionFn: activateVersionOk,
+				CreateBackendFn:   createBackendOK,
+				CreateDomainFn:    createDomainNamed,
+				CreateServiceFn:   createServiceOK,
+				GetPackageFn:      getPackageOk,
+				ListDomainsFn:     listDomainsNamed,
+				UpdatePackageFn:   updatePackageOk,
+			},
+			configFile: config.File{
+				Domain: config.Domain{
+					Template: "<team>-<name>-<env>.edgecompute.app",
+					Vars:     map[string]string{"team": "Web Team", "env": "dev"},
+				},
+			},
+			env: map[string]string{"FASTLY_DOMAIN_VAR_ENV": "prod"},
+			wantOutput: []string{
+				"View this service at:\n\thttps://web-team-package-prod.edgecompute.app",
+				"SUCCESS: Deployed package (service 12345, version 1)",
+			},
+		},
+		{
+			name: "service domain naming convention missing a value",
+			args: args("compute deploy --non-interactive --token 123"),
+			api: mock.API{
+				CreateServiceFn: createServiceOK,
+				DeleteServiceFn: deleteServiceOK,
+				ListDomainsFn:   listDomainsNone,
+			},
+			configFile: config.File{
+				Domain: config.Domain{
+					Template: "<name>-<env>.edgecompute.app",
+				},
+			},
+			wantError: "no value for the domain naming convention placeholder(s): env",
+		},
 		// The following test doesn't provide a Service ID by either a flag nor the
 		// manifest, so this will result in the deploy script attempting to create
 		// a new service. We mock the service creation to be successful while we
@@ -567,6 +666,78 @@ func TestDeploy(t *testing.T) {
 				"Deployed package (service 123, version 4)",
 			},
 		},
+		{
+			name: "success with comment and metadata",
+			args: args("compute deploy --service-id 123 --token 123 --package pkg/package.tar.gz --version 2 --comment foo --metadata git_sha=abc123"),
+			api: mock.API{
+				ActivateVersionFn:   activateVersionOk,
+				CloneVersionFn:      testutil.CloneVersionResult(4),
+				GetPackageFn:        getPackageOk,
+				GetServiceFn:        getServiceOK,
+				GetServiceDetailsFn: getServiceDetailsWasm,
+				ListDomainsFn:       listDomainsOk,
+				ListVersionsFn:      testutil.ListVersions,
+				UpdatePackageFn:     updatePackageOk,
+				UpdateVersionFn: func(i *fastly.UpdateVersionInput) (*fastly.Version, error) {
+					if want := "foo [meta:git_sha=abc123]"; *i.Comment != want {
+						return nil, fmt.Errorf("unexpected comment: %s", *i.Comment)
+					}
+					return updateVersionOk(i)
+				},
+			},
+			wantOutput: []string{
+				"Deployed package (service 123, version 4)",
+			},
+		},
+		{
+			name: "success with comment template",
+			args: args("compute deploy --service-id 123 --token 123 --package pkg/package.tar.gz --version 2 --comment-template via-CLI-{{.Version}} --metadata git_sha=abc123"),
+			api: mock.API{
+				ActivateVersionFn:   activateVersionOk,
+				CloneVersionFn:      testutil.CloneVersionResult(4),
+				GetPackageFn:        getPackageOk,
+				GetServiceFn:        getServiceOK,
+				GetServiceDetailsFn: getServiceDetailsWasm,
+				ListDomainsFn:       listDomainsOk,
+				ListVersionsFn:      testutil.ListVersions,
+				UpdatePackageFn:     updatePackageOk,
+				UpdateVersionFn: func(i *fastly.UpdateVersionInput) (*fastly.Version, error) {
+					if want := "via-CLI-" + revision.AppVersion + " [meta:git_sha=abc123]"; *i.Comment != want {
+						return nil, fmt.Errorf("unexpected comment: %s", *i.Comment)
+					}
+					return updateVersionOk(i)
+				},
+			},
+			wantOutput: []string{
+				"Deployed package (service 123, version 4)",
+			},
+		},
+		{
+			name:      "invalid comment template",
+			args:      args("compute deploy --service-id 123 --token 123 --package pkg/package.tar.gz --version 2 --comment-template {{.Version"),
+			wantError: "error parsing --comment-template",
+		},
+		{
+			name: "concurrent deploy",
+			args: args("compute deploy --service-id 123 --token 123 --package pkg/package.tar.gz --version 2"),
+			api: mock.API{
+				ActivateVersionFn:   activateVersionOk,
+				CloneVersionFn:      testutil.CloneVersionResult(4),
+				GetPackageFn:        getPackageOk,
+				GetServiceFn:        getServiceOK,
+				GetServiceDetailsFn: getServiceDetailsWasm,
+				ListDomainsFn:       listDomainsOk,
+				UpdatePackageFn:     updatePackageOk,
+				ListVersionsFn:      listVersionsConcurrent(),
+			},
+			wantError:            "service 123 changed during the deploy: version 5 was created, so version 4 wasn't activated",
+			wantRemediationError: "Use --lock to prevent concurrent deploys.",
+		},
+		{
+			name:      "invalid metadata",
+			args:      args("compute deploy --service-id 123 --token 123 --metadata note=[x]"),
+			wantError: "invalid metadata: note=[x]",
+		},
 		// The following test doesn't provide a Service ID by either a flag nor the
 		// manifest, so this will result in the deploy script attempting to create
 		// a new service. Our fastly.toml is configured with a [setup] section so
@@ -879,6 +1050,34 @@ func TestDeploy(t *testing.T) {
 				"Creating backend", // expect originless creation to be hidden
 			},
 		},
+		// The following test validates prompts answered ahead of time are used
+		// even when the --non-interactive flag would otherwise skip them.
+		{
+			name: "success with no setup.backends configuration and prompts answered with --answer",
+			args: args("compute deploy --non-interactive --token 123 --answer setup.backends.1.address=151.101.1.57 --answer setup.backends.1.port=443"),
+			api: mock.API{
+				ActivateVersionFn: activateVersionOk,
+				CreateBackendFn:   createBackendOK,
+				CreateDomainFn:    createDomainOK,
+				CreateServiceFn:   createServiceOK,
+				GetPackageFn:      getPackageOk,
+				ListDomainsFn:     listDomainsOk,
+				UpdatePackageFn:   updatePackageOk,
+			},
+			wantOutput: []string{
+				"Backend port number: [80] 443",
+				"Creating backend 'backend_1' (host: 151.101.1.57, port: 443)...",
+				"SUCCESS: Deployed package (service 12345, version 1)",
+			},
+			dontWantOutput: []string{
+				"Create new service",
+			},
+		},
+		{
+			name:      "invalid --answer for the create service prompt",
+			args:      args("compute deploy --token 123 --answer deploy.create_service=maybe"),
+			wantError: "invalid answer for 'deploy.create_service': must be yes or no",
+		},
 		// The following test validates that when dealing with an existing service,
 		// no [setup.backends] configuration is utilised.
 		//
@@ -1060,6 +1259,41 @@ func TestDeploy(t *testing.T) {
 				"SUCCESS: Deployed package (service 12345, version 1)",
 			},
 		},
+		{
+			name: "success with setup.dictionaries configuration and no existing service and --answer",
+			args: args("compute deploy --non-interactive --token 123 --answer setup.dictionaries.dict_a.foo=custom"),
+			api: mock.API{
+				ActivateVersionFn:      activateVersionOk,
+				CreateBackendFn:        createBackendOK,
+				CreateDictionaryFn:     createDictionaryOK,
+				CreateDictionaryItemFn: createDictionaryItemOK,
+				CreateDomainFn:         createDomainOK,
+				CreateServiceFn:        createServiceOK,
+				GetPackageFn:           getPackageOk,
+				ListDomainsFn:          listDomainsOk,
+				UpdatePackageFn:        updatePackageOk,
+			},
+			manifest: `
+			name = "package"
+			manifest_version = 2
+			language = "rust"
+
+			[setup.dictionaries.dict_a]
+			[setup.dictionaries.dict_a.items.foo]
+			value = "my default value for foo"
+			[setup.dictionaries.dict_a.items.bar]
+			value = "my default value for bar"
+			`,
+			wantOutput: []string{
+				"Value: [my default value for foo] custom",
+				"Creating dictionary item 'foo'...",
+				"Creating dictionary item 'bar'...",
+				"SUCCESS: Deployed package (service 12345, version 1)",
+			},
+			dontWantOutput: []string{
+				"Value: [my default value for bar]",
+			},
+		},
 		{
 			name: "success with setup.dictionaries configuration and no existing service and no predefined values",
 			args: args("compute deploy --token 123"),
@@ -1299,9 +1533,14 @@ func TestDeploy(t *testing.T) {
 			var stdout bytes.Buffer
 			opts := testutil.NewRunOpts(testcase.args, &stdout)
 			opts.APIClient = mock.APIClient(testcase.api)
+			opts.ConfigFile = testcase.configFile
+			opts.Env.Read(testcase.env)
 
 			if testcase.httpClientRes != nil || testcase.httpClientErr != nil {
 				opts.HTTPClient = mock.HTMLClient(testcase.httpClientRes, testcase.httpClientErr)
+			} else {
+				// Avoid calling the real API, e.g. to check a domain is available.
+				opts.HTTPClient = mock.HTMLClient(nil, testutil.Err)
 			}
 
 			if testcase.reduceSizeLimit {
@@ -1371,6 +1610,16 @@ func TestDeploy(t *testing.T) {
 			for _, s := range testcase.dontWantOutput {
 				testutil.AssertStringDoesntContain(t, stdout.String(), s)
 			}
+
+			if len(testcase.dontWantManifest) > 0 {
+				content, err := os.ReadFile(filepath.Join(rootdir, manifest.Filename))
+				if err != nil {
+					t.Fatal(err)
+				}
+				for _, s := range testcase.dontWantManifest {
+					testutil.AssertStringDoesntContain(t, string(content), s)
+				}
+			}
 		})
 	}
 }
@@ -1423,6 +1672,554 @@ func deleteBackendOK(i *fastly.DeleteBackendInput) error {
 	return nil
 }
 
+// TestDeployPostDeploy validates the post_deploy script runs after a package
+// is activated, once the user has confirmed they trust it.
+func TestDeployPostDeploy(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("test scripts require a POSIX shell")
+	}
+
+	pwd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rootdir := testutil.NewEnv(testutil.EnvOpts{
+		T: t,
+		Copy: []testutil.FileIO{
+			{
+				Src: filepath.Join("testdata", "deploy", "pkg", "package.tar.gz"),
+				Dst: filepath.Join("pkg", "package.tar.gz"),
+			},
+		},
+	})
+	defer os.RemoveAll(rootdir)
+
+	if err := os.Chdir(rootdir); err != nil {
+		t.Fatal(err)
+	}
+	defer os.Chdir(pwd)
+
+	args := testutil.Args
+	api := mock.API{
+		ActivateVersionFn:   activateVersionOk,
+		GetPackageFn:        getPackageOk,
+		GetServiceFn:        getServiceOK,
+		GetServiceDetailsFn: getServiceDetailsWasm,
+		ListDomainsFn:       listDomainsOk,
+		ListVersionsFn:      testutil.ListVersions,
+		UpdatePackageFn:     updatePackageOk,
+	}
+	postDeployManifest := `
+	name = "package"
+	manifest_version = 2
+
+	[scripts]
+	env_vars = ["GREETING=hello"]
+	post_deploy = "echo $GREETING $FASTLY_SERVICE_ID $FASTLY_SERVICE_VERSION $FASTLY_DOMAINS"
+	`
+	wantScriptOutput := "hello 123 3 https://directly-careful-coyote.edgecompute.app"
+
+	scenarios := []struct {
+		api                  mock.API
+		args                 []string
+		dontWantOutput       []string
+		manifest             string
+		name                 string
+		stdin                string
+		wantError            string
+		wantOutput           []string
+		wantRemediationError string
+	}{
+		{
+			name:     "confirm post_deploy script",
+			args:     args("compute deploy --service-id 123 --token 123 --package pkg/package.tar.gz --version latest"),
+			api:      api,
+			manifest: postDeployManifest,
+			stdin:    "Y",
+			wantOutput: []string{
+				"Deployed package (service 123, version 3)",
+				compute.CustomPostDeployScriptMessage,
+				"echo $GREETING $FASTLY_SERVICE_ID $FASTLY_SERVICE_VERSION $FASTLY_DOMAINS",
+				"Are you sure you want to run the post deploy script?",
+				wantScriptOutput,
+				"SUCCESS: Ran post deploy script",
+			},
+		},
+		{
+			name:     "decline post_deploy script",
+			args:     args("compute deploy --service-id 123 --token 123 --package pkg/package.tar.gz --version latest"),
+			api:      api,
+			manifest: postDeployManifest,
+			stdin:    "N",
+			wantOutput: []string{
+				"Deployed package (service 123, version 3)",
+				"Skipping the post deploy script.",
+			},
+			dontWantOutput: []string{wantScriptOutput},
+		},
+		{
+			name:     "avoid prompt confirmation",
+			args:     args("compute deploy --service-id 123 --token 123 --package pkg/package.tar.gz --version latest --auto-yes"),
+			api:      api,
+			manifest: postDeployManifest,
+			wantOutput: []string{
+				wantScriptOutput,
+				"SUCCESS: Ran post deploy script",
+			},
+			dontWantOutput: []string{compute.CustomPostDeployScriptMessage},
+		},
+		{
+			name:       "answer prompt confirmation",
+			args:       args("compute deploy --service-id 123 --token 123 --package pkg/package.tar.gz --version latest --answer deploy.post_deploy=yes"),
+			api:        api,
+			manifest:   postDeployManifest,
+			wantOutput: []string{wantScriptOutput},
+		},
+		{
+			name: "post_deploy script failure",
+			args: args("compute deploy --service-id 123 --token 123 --package pkg/package.tar.gz --version latest --auto-yes"),
+			api:  api,
+			manifest: `
+			name = "package"
+			manifest_version = 2
+
+			[scripts]
+			post_deploy = "exit 3"
+			`,
+			wantOutput:           []string{"Deployed package (service 123, version 3)"},
+			wantError:            "error running post deploy script",
+			wantRemediationError: "The package was deployed (service 123, version 3).",
+		},
+		{
+			name: "skip post_deploy script when package is unchanged",
+			args: args("compute deploy --service-id 123 --token 123 --package pkg/package.tar.gz --version latest --auto-yes"),
+			api: mock.API{
+				GetPackageFn:        getPackageIdentical,
+				GetServiceFn:        getServiceOK,
+				GetServiceDetailsFn: getServiceDetailsWasm,
+				ListDomainsFn:       listDomainsOk,
+				ListVersionsFn:      testutil.ListVersions,
+			},
+			manifest:       postDeployManifest,
+			wantOutput:     []string{"Skipping package deployment"},
+			dontWantOutput: []string{wantScriptOutput},
+		},
+	}
+
+	for _, testcase := range scenarios {
+		t.Run(testcase.name, func(t *testing.T) {
+			if err := os.WriteFile(filepath.Join(rootdir, manifest.Filename), []byte(testcase.manifest), 0o777); err != nil {
+				t.Fatal(err)
+			}
+
+			var stdout bytes.Buffer
+			opts := testutil.NewRunOpts(testcase.args, &stdout)
+			opts.APIClient = mock.APIClient(testcase.api)
+			opts.Stdin = strings.NewReader(testcase.stdin)
+			err := app.Run(opts)
+
+			testutil.AssertErrorContains(t, err, testcase.wantError)
+			testutil.AssertRemediationErrorContains(t, err, testcase.wantRemediationError)
+			for _, s := range testcase.wantOutput {
+				testutil.AssertStringContains(t, stdout.String(), s)
+			}
+			for _, s := range testcase.dontWantOutput {
+				testutil.AssertStringDoesntContain(t, stdout.String(), s)
+			}
+		})
+	}
+}
+
+// TestDeployLock validates the --lock flag, including when another deploy
+// replaces the lock.
+func TestDeployLock(t *testing.T) {
+	pwd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rootdir := testutil.NewEnv(testutil.EnvOpts{
+		T: t,
+		Copy: []testutil.FileIO{
+			{
+				Src: filepath.Join("testdata", "deploy", "pkg", "package.tar.gz"),
+				Dst: filepath.Join("pkg", "package.tar.gz"),
+			},
+		},
+		Write: []testutil.FileIO{
+			{Src: "manifest_version = 2\nname = \"package\"\n", Dst: manifest.Filename},
+		},
+	})
+	defer os.RemoveAll(rootdir)
+
+	if err := os.Chdir(rootdir); err != nil {
+		t.Fatal(err)
+	}
+	defer os.Chdir(pwd)
+
+	args := testutil.Args
+	fresh := deployLockValue(time.Now(), "abc123")
+	stale := deployLockValue(time.Now().Add(-2*time.Hour), "abc123")
+	other := deployLockValue(time.Now(), "def456")
+
+	scenarios := []struct {
+		args                 []string
+		name                 string
+		store                *dictionaryItemStore
+		wantError            string
+		wantItems            map[string]string
+		wantOutput           []string
+		wantRemediationError string
+	}{
+		{
+			name:       "lock released",
+			args:       args("compute deploy --service-id 123 --token 123 --package pkg/package.tar.gz --version 2 --lock deploy_locks"),
+			store:      &dictionaryItemStore{items: map[string]string{}},
+			wantItems:  map[string]string{},
+			wantOutput: []string{"Deployed package (service 123, version 4)"},
+		},
+		{
+			name:                 "lock held",
+			args:                 args("compute deploy --service-id 123 --token 123 --package pkg/package.tar.gz --version 2 --lock deploy_locks"),
+			store:                &dictionaryItemStore{items: map[string]string{"deploy_lock": fresh}},
+			wantError:            "service 123 is locked by another deploy (ci-host (pid 1))",
+			wantItems:            map[string]string{"deploy_lock": fresh},
+			wantRemediationError: "fastly dictionary-item delete --service-id 123 --dictionary-id 456 --key deploy_lock",
+		},
+		{
+			name:      "stale lock replaced",
+			args:      args("compute deploy --service-id 123 --token 123 --package pkg/package.tar.gz --version 2 --lock deploy_locks --lock-ttl 1h"),
+			store:     &dictionaryItemStore{items: map[string]string{"deploy_lock": stale}},
+			wantItems: map[string]string{},
+			wantOutput: []string{
+				"Replacing the deploy lock held since",
+				"Deployed package (service 123, version 4)",
+			},
+		},
+		{
+			name: "stale lock claimed by another deploy first",
+			args: args("compute deploy --service-id 123 --token 123 --package pkg/package.tar.gz --version 2 --lock deploy_locks --lock-ttl 1h"),
+			store: &dictionaryItemStore{
+				items: map[string]string{"deploy_lock": stale},
+				afterGet: func(items map[string]string) {
+					items["deploy_lock_takeover_abc123"] = "def456"
+					items["deploy_lock"] = other
+				},
+			},
+			wantError: "service 123 is locked by another deploy (replacing the stale lock of ci-host (pid 1))",
+			wantItems: map[string]string{"deploy_lock": other, "deploy_lock_takeover_abc123": "def456"},
+		},
+		{
+			name: "stale lock replaced by another deploy before it's deleted",
+			args: args("compute deploy --service-id 123 --token 123 --package pkg/package.tar.gz --version 2 --lock deploy_locks --lock-ttl 1h"),
+			store: &dictionaryItemStore{
+				items: map[string]string{"deploy_lock": stale},
+				afterGet: func(items map[string]string) {
+					items["deploy_lock"] = other
+				},
+			},
+			wantError: "service 123 is locked by another deploy (ci-host (pid 1))",
+			wantItems: map[string]string{"deploy_lock": other},
+		},
+		{
+			name: "lock replaced during the deploy isn't released",
+			args: args("compute deploy --service-id 123 --token 123 --package pkg/package.tar.gz --version 2 --lock deploy_locks"),
+			store: &dictionaryItemStore{
+				items: map[string]string{},
+				onActivate: func(items map[string]string) {
+					items["deploy_lock"] = other
+				},
+			},
+			wantItems: map[string]string{"deploy_lock": other},
+			wantOutput: []string{
+				"Deployed package (service 123, version 4)",
+				"The deploy lock wasn't released, as it's now held by another deploy (ci-host (pid 1))",
+			},
+		},
+	}
+
+	for _, testcase := range scenarios {
+		t.Run(testcase.name, func(t *testing.T) {
+			store := testcase.store
+			var stdout bytes.Buffer
+			opts := testutil.NewRunOpts(testcase.args, &stdout)
+			opts.APIClient = mock.APIClient(mock.API{
+				ActivateVersionFn: func(i *fastly.ActivateVersionInput) (*fastly.Version, error) {
+					if store.onActivate != nil {
+						store.onActivate(store.items)
+					}
+					return activateVersionOk(i)
+				},
+				CloneVersionFn:         testutil.CloneVersionResult(4),
+				CreateDictionaryItemFn: store.create,
+				DeleteDictionaryItemFn: store.delete,
+				GetDictionaryFn:        getDictionaryOK,
+				GetDictionaryItemFn:    store.get,
+				GetPackageFn:           getPackageOk,
+				GetServiceFn:           getServiceOK,
+				GetServiceDetailsFn:    getServiceDetailsWasm,
+				ListDomainsFn:          listDomainsOk,
+				ListVersionsFn:         testutil.ListVersions,
+				UpdatePackageFn:        updatePackageOk,
+			})
+			opts.HTTPClient = mock.HTMLClient(nil, testutil.Err)
+			err := app.Run(opts)
+
+			t.Log(stdout.String())
+
+			testutil.AssertErrorContains(t, err, testcase.wantError)
+			testutil.AssertRemediationErrorContains(t, err, testcase.wantRemediationError)
+			for _, s := range testcase.wantOutput {
+				testutil.AssertStringContains(t, stdout.String(), s)
+			}
+			testutil.AssertEqual(t, testcase.wantItems, store.items)
+		})
+	}
+}
+
+func TestDeployGit(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not found in $PATH")
+	}
+
+	pwd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rootdir := testutil.NewEnv(testutil.EnvOpts{
+		T: t,
+		Copy: []testutil.FileIO{
+			{
+				Src: filepath.Join("testdata", "deploy", "pkg", "package.tar.gz"),
+				Dst: filepath.Join("pkg", "package.tar.gz"),
+			},
+		},
+		Write: []testutil.FileIO{
+			{Src: "name = \"package\"\nmanifest_version = 2\n", Dst: manifest.Filename},
+		},
+	})
+	defer os.RemoveAll(rootdir)
+
+	if err := os.Chdir(rootdir); err != nil {
+		t.Fatal(err)
+	}
+	defer os.Chdir(pwd)
+
+	remote := t.TempDir()
+	git := func(dir string, args ...string) string {
+		t.Helper()
+		c := exec.Command("git", append([]string{"-C", dir, "-c", "user.name=test", "-c", "user.email=test@example.com"}, args...)...)
+		out, err := c.CombinedOutput()
+		if err != nil {
+			t.Fatalf("git %s: %s\n\n%s", strings.Join(args, " "), err, out)
+		}
+		return strings.TrimSpace(string(out))
+	}
+	git(remote, "init", "--bare")
+	git(rootdir, "init")
+	git(rootdir, "config", "user.name", "test")
+	git(rootdir, "config", "user.email", "test@example.com")
+	git(rootdir, "add", ".")
+	git(rootdir, "commit", "-m", "Initial commit")
+	git(rootdir, "remote", "add", "origin", remote)
+
+	args := testutil.Args
+	api := mock.API{
+		ActivateVersionFn:   activateVersionOk,
+		GetPackageFn:        getPackageOk,
+		GetServiceFn:        getServiceOK,
+		GetServiceDetailsFn: getServiceDetailsWasm,
+		ListDomainsFn:       listDomainsOk,
+		ListVersionsFn:      testutil.ListVersions,
+		UpdatePackageFn:     updatePackageOk,
+	}
+
+	scenarios := []struct {
+		args                 []string
+		dirty                bool
+		dontWantOutput       []string
+		name                 string
+		wantError            string
+		wantOutput           []string
+		wantRemediationError string
+	}{
+		{
+			name:       "warn on uncommitted changes",
+			args:       args("compute deploy --service-id 123 --token 123 --package pkg/package.tar.gz --version latest"),
+			dirty:      true,
+			wantOutput: []string{"The git working tree has uncommitted changes (dirty.txt)", "Deployed package (service 123, version 3)"},
+		},
+		{
+			name:                 "--require-clean-git fails on uncommitted changes",
+			args:                 args("compute deploy --service-id 123 --token 123 --package pkg/package.tar.gz --version latest --require-clean-git"),
+			dirty:                true,
+			wantError:            "the git working tree has uncommitted changes: dirty.txt",
+			wantRemediationError: "Commit or stash the changes before deploying",
+			dontWantOutput:       []string{"Deployed package"},
+		},
+		{
+			name:           "--git-tag tags the deployed commit",
+			args:           args("compute deploy --service-id 123 --token 123 --package pkg/package.tar.gz --version latest --require-clean-git --git-tag"),
+			wantOutput:     []string{"Deployed package (service 123, version 3)", "Created git tag 'fastly-deploy/123/3' and pushed it to 'origin'"},
+			dontWantOutput: []string{"uncommitted changes"},
+		},
+	}
+
+	for _, testcase := range scenarios {
+		t.Run(testcase.name, func(t *testing.T) {
+			dirty := filepath.Join(rootdir, "dirty.txt")
+			if testcase.dirty {
+				if err := os.WriteFile(dirty, []byte("dirty"), 0o600); err != nil {
+					t.Fatal(err)
+				}
+				defer os.Remove(dirty)
+			}
+
+			var stdout bytes.Buffer
+			opts := testutil.NewRunOpts(testcase.args, &stdout)
+			opts.APIClient = mock.APIClient(api)
+			err := app.Run(opts)
+
+			testutil.AssertErrorContains(t, err, testcase.wantError)
+			testutil.AssertRemediationErrorContains(t, err, testcase.wantRemediationError)
+			for _, s := range testcase.wantOutput {
+				testutil.AssertStringContains(t, stdout.String(), s)
+			}
+			for _, s := range testcase.dontWantOutput {
+				testutil.AssertStringDoesntContain(t, stdout.String(), s)
+			}
+		})
+	}
+
+	testutil.AssertString(t, git(rootdir, "rev-parse", "HEAD"), git(remote, "rev-parse", "fastly-deploy/123/3^{commit}"))
+}
+
+func TestDeployPolicy(t *testing.T) {
+	pwd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rootdir := testutil.NewEnv(testutil.EnvOpts{
+		T: t,
+		Copy: []testutil.FileIO{
+			{
+				Src: filepath.Join("testdata", "deploy", "pkg", "package.tar.gz"),
+				Dst: filepath.Join("pkg", "package.tar.gz"),
+			},
+		},
+		Write: []testutil.FileIO{
+			{Src: "name = \"package\"\nmanifest_version = 2\n", Dst: manifest.Filename},
+			{
+				Src: `protected_services = ["123"]
+
+[[rule]]
+name = "no-internal-backends"
+message = "Protected services can't have backends pointing at *.internal hosts"
+deny = '.service.protected and any(.backends[]; .address | endswith(".internal"))'
+
+[[rule]]
+name = "tls-backends"
+deny = '.backends[] | select(.port != 443) | "backend \(.name) uses port \(.port)"'
+`,
+				Dst: "violated.toml",
+			},
+			{
+				Src: `[[rule]]
+name = "package-size"
+deny = '.package.size > 50000000 or (.domains | length) == 0'
+
+[[rule]]
+name = "frozen"
+message = "Deploys are frozen"
+deny = '$ENV.FASTLY_TEST_DEPLOY_FREEZE == "1"'
+`,
+				Dst: compute.PolicyFilename,
+			},
+			{
+				Src: "[[rule]]\nname = \"invalid\"\ndeny = '.backends[] |'\n",
+				Dst: "invalid.toml",
+			},
+		},
+	})
+	defer os.RemoveAll(rootdir)
+
+	if err := os.Chdir(rootdir); err != nil {
+		t.Fatal(err)
+	}
+	defer os.Chdir(pwd)
+
+	args := testutil.Args
+	api := mock.API{
+		ActivateVersionFn:   activateVersionOk,
+		GetPackageFn:        getPackageOk,
+		GetServiceFn:        getServiceOK,
+		GetServiceDetailsFn: getServiceDetailsWasm,
+		ListBackendsFn: func(i *fastly.ListBackendsInput) ([]*fastly.Backend, error) {
+			return []*fastly.Backend{
+				{Name: "origin", Address: "origin.internal", Port: 443},
+				{Name: "legacy", Address: "legacy.example.com", Port: 80},
+			}, nil
+		},
+		ListDomainsFn:   listDomainsOk,
+		ListVersionsFn:  testutil.ListVersions,
+		UpdatePackageFn: updatePackageOk,
+	}
+
+	scenarios := []struct {
+		args                 []string
+		env                  string
+		name                 string
+		wantError            string
+		wantOutput           string
+		wantRemediationError string
+	}{
+		{
+			name:       "the default policy file is satisfied",
+			args:       args("compute deploy --service-id 123 --token 123 --package pkg/package.tar.gz --version latest"),
+			wantOutput: "Deployed package (service 123, version 3)",
+		},
+		{
+			name:                 "the default policy file is violated",
+			args:                 args("compute deploy --service-id 123 --token 123 --package pkg/package.tar.gz --version latest"),
+			env:                  "1",
+			wantError:            "the deploy violates policy rule 'frozen' (Deploys are frozen)",
+			wantRemediationError: fmt.Sprintf("satisfy the rules of the policy file '%s'", filepath.Join(rootdir, compute.PolicyFilename)),
+		},
+		{
+			name:      "--policy rules are violated",
+			args:      args("compute deploy --service-id 123 --token 123 --package pkg/package.tar.gz --version latest --policy violated.toml"),
+			wantError: "the deploy violates 2 policy rules: 'no-internal-backends' (Protected services can't have backends pointing at *.internal hosts), 'tls-backends' (backend legacy uses port 80)",
+		},
+		{
+			name:                 "--policy with an invalid rule",
+			args:                 args("compute deploy --service-id 123 --token 123 --package pkg/package.tar.gz --version latest --policy invalid.toml"),
+			wantError:            "invalid deny expression of policy rule 'invalid'",
+			wantRemediationError: "https://stedolan.github.io/jq/manual/",
+		},
+	}
+
+	for _, testcase := range scenarios {
+		t.Run(testcase.name, func(t *testing.T) {
+			t.Setenv("FASTLY_TEST_DEPLOY_FREEZE", testcase.env)
+
+			var stdout bytes.Buffer
+			opts := testutil.NewRunOpts(testcase.args, &stdout)
+			opts.APIClient = mock.APIClient(api)
+			err := app.Run(opts)
+
+			testutil.AssertErrorContains(t, err, testcase.wantError)
+			testutil.AssertRemediationErrorContains(t, err, testcase.wantRemediationError)
+			if testcase.wantError != "" {
+				testutil.AssertStringDoesntContain(t, stdout.String(), "Deployed package")
+			}
+			testutil.AssertStringContains(t, stdout.String(), testcase.wantOutput)
+		})
+	}
+}
+
 func getPackageIdentical(i *fastly.GetPackageInput) (*fastly.Package, error) {
 	return &fastly.Package{
 		ServiceID:      i.ServiceID,
@@ -1444,3 +2241,85 @@ func listDomainsError(i *fastly.ListDomainsInput) ([]*fastly.Domain, error) {
 func listDomainsNone(i *fastly.ListDomainsInput) ([]*fastly.Domain, error) {
 	return []*fastly.Domain{}, nil
 }
+
+// listDomainsCreated returns no domains until one is created, so that the
+// domain the deploy creates is then listed.
+func listDomainsCreated() (func(*fastly.ListDomainsInput) ([]*fastly.Domain, error), func(*fastly.CreateDomainInput) (*fastly.Domain, error)) {
+	var domains []*fastly.Domain
+	list := func(i *fastly.ListDomainsInput) ([]*fastly.Domain, error) {
+		return domains, nil
+	}
+	create := func(i *fastly.CreateDomainInput) (*fastly.Domain, error) {
+		d := &fastly.Domain{
+			ServiceID:      i.ServiceID,
+			ServiceVersion: i.ServiceVersion,
+			Name:           i.Name,
+		}
+		domains = append(domains, d)
+		return d, nil
+	}
+	return list, create
+}
+
+// listVersionsConcurrent returns the service versions, along with a version 5
+// created by a concurrent deploy once the deploy has cloned its own version.
+func listVersionsConcurrent() func(*fastly.ListVersionsInput) ([]*fastly.Version, error) {
+	var calls int
+	return func(i *fastly.ListVersionsInput) ([]*fastly.Version, error) {
+		calls++
+		vs, err := testutil.ListVersions(i)
+		if calls > 2 {
+			vs = append(vs, &fastly.Version{ServiceID: i.ServiceID, Number: 5})
+		}
+		return vs, err
+	}
+}
+
+func getDictionaryOK(i *fastly.GetDictionaryInput) (*fastly.Dictionary, error) {
+	return &fastly.Dictionary{
+		ID:             "456",
+		Name:           i.Name,
+		ServiceID:      i.ServiceID,
+		ServiceVersion: i.ServiceVersion,
+	}, nil
+}
+
+// dictionaryItemStore is an in-memory dictionary, whose hooks let a test play
+// the part of a concurrent deploy.
+type dictionaryItemStore struct {
+	items map[string]string
+	// afterGet runs after the first item is read.
+	afterGet func(items map[string]string)
+	// onActivate runs when a version is activated.
+	onActivate func(items map[string]string)
+}
+
+func (s *dictionaryItemStore) create(i *fastly.CreateDictionaryItemInput) (*fastly.DictionaryItem, error) {
+	if _, ok := s.items[i.ItemKey]; ok {
+		return nil, testutil.Err
+	}
+	s.items[i.ItemKey] = i.ItemValue
+	return &fastly.DictionaryItem{ItemKey: i.ItemKey, ItemValue: i.ItemValue}, nil
+}
+
+func (s *dictionaryItemStore) get(i *fastly.GetDictionaryItemInput) (*fastly.DictionaryItem, error) {
+	v, ok := s.items[i.ItemKey]
+	if hook := s.afterGet; hook != nil {
+		s.afterGet = nil
+		defer hook(s.items)
+	}
+	if !ok {
+		return nil, testutil.Err
+	}
+	return &fastly.DictionaryItem{ItemKey: i.ItemKey, ItemValue: v}, nil
+}
+
+func (s *dictionaryItemStore) delete(i *fastly.DeleteDictionaryItemInput) error {
+	delete(s.items, i.ItemKey)
+	return nil
+}
+
+// deployLockValue returns the value of a deploy lock held since the given time.
+func deployLockValue(at time.Time, token string) string {
+	return at.UTC().Format(time.RFC3339) + " " + token + " ci-host (pid 1)"
+}
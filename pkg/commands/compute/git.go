@@ -0,0 +1,104 @@
+package compute
+
+import (
+	"fmt"
+	"io"
+	"os/exec"
+	"strings"
+
+	fsterr "github.com/fastly/cli/pkg/errors"
+	"github.com/fastly/cli/pkg/revision"
+	"github.com/fastly/cli/pkg/text"
+)
+
+// GitTagPrefix is the prefix of the tags created by deploy --git-tag.
+const GitTagPrefix = "fastly-deploy"
+
+// GitTagName returns the name of the tag created by deploy --git-tag, e.g.
+// fastly-deploy/SERVICE_ID/3.
+func GitTagName(serviceID string, version int) string {
+	return fmt.Sprintf("%s/%s/%d", GitTagPrefix, serviceID, version)
+}
+
+// gitWorkTree reports whether dir is within a git working tree.
+func gitWorkTree(dir string) bool {
+	if _, err := exec.LookPath("git"); err != nil {
+		return false
+	}
+	out, err := gitOutput(dir, "rev-parse", "--is-inside-work-tree")
+	return err == nil && out == "true"
+}
+
+// gitUncommitted returns the paths with uncommitted changes (including
+// untracked files) in the git working tree of dir.
+func gitUncommitted(dir string) ([]string, error) {
+	out, err := gitOutput(dir, "status", "--porcelain")
+	if err != nil {
+		return nil, err
+	}
+	var paths []string
+	for _, line := range strings.Split(out, "\n") {
+		if len(line) > 3 {
+			paths = append(paths, strings.TrimSpace(line[3:]))
+		}
+	}
+	return paths, nil
+}
+
+// checkCleanGit warns when the git working tree of dir has uncommitted
+// changes, as the deployed package then doesn't match any commit. With require
+// set it fails instead. Nothing is checked outside of a git working tree.
+func checkCleanGit(dir string, require bool, out io.Writer) error {
+	if !gitWorkTree(dir) {
+		if require {
+			return fsterr.RemediationError{
+				Inner:       fmt.Errorf("--require-clean-git is set but the project isn't in a git repository"),
+				Remediation: "Run the command from a git repository, or remove --require-clean-git.",
+			}
+		}
+		return nil
+	}
+
+	paths, err := gitUncommitted(dir)
+	if err != nil {
+		return fmt.Errorf("error reading git status: %w", err)
+	}
+	if len(paths) == 0 {
+		return nil
+	}
+
+	list := paths
+	if len(list) > 5 {
+		list = append(list[:5:5], fmt.Sprintf("(and %d more)", len(paths)-5))
+	}
+	if require {
+		return fsterr.RemediationError{
+			Inner:       fmt.Errorf("the git working tree has uncommitted changes: %s", strings.Join(list, ", ")),
+			Remediation: "Commit or stash the changes before deploying, or remove --require-clean-git.",
+		}
+	}
+	text.Warning(out, "The git working tree has uncommitted changes (%s), so the deployed package won't match a commit. Set --require-clean-git to fail instead.", strings.Join(list, ", "))
+	return nil
+}
+
+// gitTagDeploy creates an annotated tag of HEAD for the deployed version, and
+// pushes it to the remote when one is configured.
+func gitTagDeploy(dir, remote, serviceID string, version int) (tag string, pushed bool, err error) {
+	if !gitWorkTree(dir) {
+		return "", false, fmt.Errorf("the project isn't in a git repository")
+	}
+
+	tag = GitTagName(serviceID, version)
+	msg := fmt.Sprintf("Deployed to service %s version %d (Fastly CLI %s)", serviceID, version, revision.AppVersion)
+	if err := git(dir, "tag", "--annotate", "--message", msg, tag); err != nil {
+		return tag, false, fmt.Errorf("error creating git tag '%s': %w", tag, err)
+	}
+
+	if _, err := gitOutput(dir, "remote", "get-url", remote); err != nil {
+		return tag, false, nil
+	}
+	if err := git(dir, "push", remote, "refs/tags/"+tag); err != nil {
+		return tag, false, fmt.Errorf("error pushing git tag '%s' to '%s': %w", tag, remote, err)
+	}
+	return tag, true, nil
+}
@@ -2,6 +2,8 @@ package compute
 
 import (
 	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"io"
@@ -27,7 +29,24 @@ import (
 	cp "github.com/otiai10/copy"
 )
 
+// The names of the prompts that can be answered ahead of time with the
+// --answer, --answers-file and `compute init --answers` flags.
+//
+// NOTE: The answers for init.backends.<name>.{address,port,description} don't
+// correspond to a prompt but are written to the [setup.backends] section of
+// the fastly.toml manifest, for the backends to be created on deploy.
+const (
+	answerAuthor      = "init.author"
+	answerBackends    = "init.backends."
+	answerContinue    = "init.continue"
+	answerDescription = "init.description"
+	answerLanguage    = "init.language"
+	answerName        = "init.name"
+	answerStarterKit  = "init.starter_kit"
+)
+
 var (
+	commitRegEx               = regexp.MustCompile(`^[0-9a-fA-F]{40}$`)
 	gitRepositoryRegEx        = regexp.MustCompile(`((git|ssh|http(s)?)|(git@[\w\.]+))(:(//)?)([\w\.@\:/\-~]+)(\.git)(/)?`)
 	fastlyOrgRegEx            = regexp.MustCompile(`^https:\/\/github\.com\/fastly`)
 	fastlyFileIgnoreListRegEx = regexp.MustCompile(`\.github|LICENSE|SECURITY\.md|CHANGELOG\.md|screenshot\.png`)
@@ -37,12 +56,15 @@ var (
 type InitCommand struct {
 	cmd.Base
 
+	answersFile      string
 	branch           string
+	commit           string
 	dir              string
 	from             string
 	language         string
 	manifest         manifest.Data
 	skipVerification bool
+	syncTemplate     bool
 	tag              string
 }
 
@@ -63,13 +85,36 @@ func NewInitCommand(parent cmd.Registerer, globals *config.Data, data manifest.D
 	c.CmdClause.Flag("from", "Local project directory, or Git repository URL, or URL referencing a .zip/.tar.gz file, containing a package template").Short('f').StringVar(&c.from)
 	c.CmdClause.Flag("branch", "Git branch name to clone from package template repository").Hidden().StringVar(&c.branch)
 	c.CmdClause.Flag("tag", "Git tag name to clone from package template repository").Hidden().StringVar(&c.tag)
+	c.CmdClause.Flag("commit", "Git commit to use from the package template repository (overrides the commit pinned in the starter kit configuration)").StringVar(&c.commit)
+	c.CmdClause.Flag("answers", "Path to a JSON file of answers for the init prompts, e.g. {\"init\": {\"name\": \"my-app\", \"author\": [\"me@example.com\"]}}").StringVar(&c.answersFile)
 	c.CmdClause.Flag("force", "Skip non-empty directory verification step and force new project creation").BoolVar(&c.skipVerification)
+	c.CmdClause.Flag("sync-template", "Merge updates from the package template into an existing project, instead of creating a new project").BoolVar(&c.syncTemplate)
 
 	return &c
 }
 
 // Exec implements the command interface.
 func (c *InitCommand) Exec(in io.Reader, out io.Writer) (err error) {
+	if c.syncTemplate {
+		return c.sync(out)
+	}
+
+	if c.answersFile != "" {
+		answers, err := text.ReadAnswers(c.answersFile)
+		if err != nil {
+			c.Globals.ErrLog.Add(err)
+			return err
+		}
+		// NOTE: The global --answer and --answers-file flags take precedence.
+		c.Globals.Answers = answers.Merge(c.Globals.Answers)
+	}
+
+	backends, err := setupBackends(c.Globals.Answers)
+	if err != nil {
+		c.Globals.ErrLog.Add(err)
+		return err
+	}
+
 	var introContext string
 	if c.from != "" {
 		introContext = " (using --from to locate package template)"
@@ -86,7 +131,8 @@ func (c *InitCommand) Exec(in io.Reader, out io.Writer) (err error) {
 
 	text.Break(out)
 
-	cont, err := verifyDirectory(c.dir, c.skipVerification, out, in)
+	autoYes := (c.Globals.Flag.AutoYes || c.Globals.Flag.NonInteractive) && !c.Globals.Answers.Has(answerContinue)
+	cont, err := verifyDirectory(c.dir, c.skipVerification || autoYes, c.Globals.Answers, out, in)
 	if err != nil {
 		c.Globals.ErrLog.Add(err)
 		return err
@@ -95,6 +141,7 @@ func (c *InitCommand) Exec(in io.Reader, out io.Writer) (err error) {
 		return fsterr.RemediationError{
 			Inner:       fmt.Errorf("project directory not empty"),
 			Remediation: fsterr.ExistingDirRemediation,
+			Code:        fsterr.ExitAborted,
 		}
 	}
 
@@ -138,7 +185,12 @@ func (c *InitCommand) Exec(in io.Reader, out io.Writer) (err error) {
 		email = p.Email
 	}
 
-	name, desc, authors, err := promptOrReturn(c.manifest, c.dir, email, in, out)
+	// NOTE: Every prompt below has a default which is used without prompting
+	// when either --accept-defaults or --non-interactive is set, unless the
+	// prompt was answered ahead of time with --answer or --answers-file.
+	acceptDefaults := c.Globals.Flag.AcceptDefaults || c.Globals.Flag.NonInteractive
+
+	name, desc, authors, err := promptOrReturn(c.manifest, c.dir, email, acceptDefaults, c.Globals.Answers, in, out)
 	if err != nil {
 		c.Globals.ErrLog.AddWithContext(err, map[string]any{
 			"Description": desc,
@@ -148,7 +200,12 @@ func (c *InitCommand) Exec(in io.Reader, out io.Writer) (err error) {
 	}
 
 	languages := NewLanguages(c.Globals.File.StarterKits, c.Globals, name, mf.Scripts)
-	language, err := selectLanguage(c.from, c.language, languages, mf, in, out)
+	for _, l := range languages {
+		if s, ok := l.Toolchain.(Canceler); ok {
+			s.SetContext(c.Globals.Context())
+		}
+	}
+	language, err := selectLanguage(c.from, c.language, languages, mf, acceptDefaults, c.Globals.Answers, in, out)
 	if err != nil {
 		c.Globals.ErrLog.AddWithContext(err, map[string]any{
 			"Language": c.language,
@@ -156,10 +213,14 @@ func (c *InitCommand) Exec(in io.Reader, out io.Writer) (err error) {
 		return err
 	}
 
-	var from, branch, tag string
+	kit := config.StarterKit{
+		Path:   c.from,
+		Branch: c.branch,
+		Tag:    c.tag,
+	}
 
 	if noProjectFiles(c.from, language, mf) {
-		from, branch, tag, err = promptForStarterKit(language.StarterKits, in, out)
+		kit, err = promptForStarterKit(language.StarterKits, acceptDefaults, c.Globals.Answers, in, out)
 		if err != nil {
 			c.Globals.ErrLog.AddWithContext(err, map[string]any{
 				"From":           c.from,
@@ -169,7 +230,36 @@ func (c *InitCommand) Exec(in io.Reader, out io.Writer) (err error) {
 			})
 			return err
 		}
-		c.from = from
+		c.from = kit.Path
+	}
+
+	// NOTE: A starter kit given with --from, or pasted as a URL at the prompt,
+	// is still checked against the pins of the matching configured kit.
+	if kit.Name == "" {
+		if known, ok := knownStarterKit(languages, language, kit.Path); ok {
+			if kit.Branch != "" || kit.Tag != "" {
+				known.Branch, known.Tag = kit.Branch, kit.Tag
+			}
+			kit = known
+			c.from = kit.Path
+			if kit.Commit != "" || kit.Checksum != "" {
+				text.Info(out, "Verifying the package template against the pins of the '%s' starter kit.", kit.Name)
+			}
+		}
+	}
+
+	// NOTE: --commit overrides the commit pinned in the starter kit config, so
+	// the user can deliberately select a different version of the template.
+	if c.commit != "" {
+		kit.Commit = c.commit
+	}
+	if kit.Commit != "" && !commitRegEx.MatchString(kit.Commit) {
+		err := fsterr.RemediationError{
+			Inner:       fmt.Errorf("invalid commit '%s' for the package template", kit.Commit),
+			Remediation: "Use the full 40 character SHA of the commit, in --commit or the starter kit configuration.",
+		}
+		c.Globals.ErrLog.Add(err)
+		return err
 	}
 
 	text.Break(out)
@@ -178,18 +268,31 @@ func (c *InitCommand) Exec(in io.Reader, out io.Writer) (err error) {
 	// whether --verbose was set or not.
 	progress = text.NewProgress(out, c.Globals.Verbose())
 
-	err = fetchPackageTemplate(language, c.from, branch, tag, c.dir, mf, file.Archives, progress, c.Globals.HTTPClient, out, c.Globals.ErrLog)
+	commit, err := fetchPackageTemplate(language, kit, c.dir, mf, file.Archives, progress, c.Globals.HTTPClient, out, c.Globals.ErrLog)
 	if err != nil {
 		c.Globals.ErrLog.AddWithContext(err, map[string]any{
-			"From":      from,
-			"Branch":    branch,
-			"Tag":       tag,
+			"From":      kit.Path,
+			"Branch":    kit.Branch,
+			"Tag":       kit.Tag,
+			"Commit":    kit.Commit,
 			"Directory": c.dir,
 		})
 		return err
 	}
 
-	mf, err = updateManifest(mf, progress, c.dir, name, desc, authors, language)
+	// NOTE: Only templates cloned from a git repository are tracked, as the
+	// commit is needed to merge later updates with --sync-template.
+	var tmpl manifest.Template
+	if commit != "" {
+		tmpl = manifest.Template{
+			Source: kit.Path,
+			Branch: kit.Branch,
+			Tag:    kit.Tag,
+			Commit: commit,
+		}
+	}
+
+	mf, err = updateManifest(mf, progress, c.dir, name, desc, authors, backends, language, tmpl)
 	if err != nil {
 		c.Globals.ErrLog.AddWithContext(err, map[string]any{
 			"Directory":   c.dir,
@@ -213,7 +316,7 @@ func (c *InitCommand) Exec(in io.Reader, out io.Writer) (err error) {
 // verifyDirectory indicates if the user wants to continue with the execution
 // flow when presented with a prompt that suggests the current directory isn't
 // empty.
-func verifyDirectory(dir string, skipVerification bool, out io.Writer, in io.Reader) (bool, error) {
+func verifyDirectory(dir string, skipVerification bool, answers text.Answers, out io.Writer, in io.Reader) (bool, error) {
 	if skipVerification {
 		return true, nil
 	}
@@ -233,7 +336,7 @@ func verifyDirectory(dir string, skipVerification bool, out io.Writer, in io.Rea
 
 	if len(files) > 0 {
 		label := fmt.Sprintf("The current directory isn't empty. Are you sure you want to initialize a Compute@Edge project in %s? [y/N] ", dir)
-		return text.AskYesNo(out, label, in)
+		return answers.AskYesNo(answerContinue, out, label, in)
 	}
 
 	return true, nil
@@ -305,21 +408,21 @@ func verifyDestination(path string, progress text.Progress) (dst string, err err
 // promptOrReturn will prompt the user for information missing from the
 // fastly.toml manifest file, otherwise if it already exists then the value is
 // returned as is.
-func promptOrReturn(m manifest.Data, path, email string, in io.Reader, out io.Writer) (name, description string, authors []string, err error) {
+func promptOrReturn(m manifest.Data, path, email string, acceptDefaults bool, answers text.Answers, in io.Reader, out io.Writer) (name, description string, authors []string, err error) {
 	name, _ = m.Name()
-	name, err = packageName(name, path, in, out)
+	name, err = packageName(name, path, acceptDefaults, answers, in, out)
 	if err != nil {
 		return name, description, authors, err
 	}
 
 	description, _ = m.Description()
-	description, err = packageDescription(description, in, out)
+	description, err = packageDescription(description, acceptDefaults, answers, in, out)
 	if err != nil {
 		return name, description, authors, err
 	}
 
 	authors, _ = m.Authors()
-	authors, err = packageAuthors(authors, email, in, out)
+	authors, err = packageAuthors(authors, email, acceptDefaults, answers, in, out)
 	if err != nil {
 		return name, description, authors, err
 	}
@@ -332,13 +435,17 @@ func promptOrReturn(m manifest.Data, path, email string, in io.Reader, out io.Wr
 //
 // It will use a default of the current directory path if no value provided by
 // the user via the prompt.
-func packageName(name string, dirPath string, in io.Reader, out io.Writer) (string, error) {
+func packageName(name string, dirPath string, acceptDefaults bool, answers text.Answers, in io.Reader, out io.Writer) (string, error) {
 	defaultName := filepath.Base(dirPath)
 
+	if name == "" && acceptDefaults && !answers.Has(answerName) {
+		return defaultName, nil
+	}
+
 	if name == "" {
 		var err error
 
-		name, err = text.Input(out, fmt.Sprintf("Name: [%s] ", defaultName), in)
+		name, err = answers.Input(answerName, out, fmt.Sprintf("Name: [%s] ", defaultName), in)
 		if err != nil {
 			return "", fmt.Errorf("error reading input: %w", err)
 		}
@@ -353,11 +460,11 @@ func packageName(name string, dirPath string, in io.Reader, out io.Writer) (stri
 
 // packageDescription prompts the user for a package description unless already
 // defined either via the corresponding CLI flag or the manifest file.
-func packageDescription(desc string, in io.Reader, out io.Writer) (string, error) {
-	if desc == "" {
+func packageDescription(desc string, acceptDefaults bool, answers text.Answers, in io.Reader, out io.Writer) (string, error) {
+	if desc == "" && (!acceptDefaults || answers.Has(answerDescription)) {
 		var err error
 
-		desc, err = text.Input(out, "Description: ", in)
+		desc, err = answers.Input(answerDescription, out, "Description: ", in)
 		if err != nil {
 			return "", fmt.Errorf("error reading input: %w", err)
 		}
@@ -371,7 +478,11 @@ func packageDescription(desc string, in io.Reader, out io.Writer) (string, error
 //
 // It will use a default of the user's email found within the manifest, if set
 // there, otherwise the value will be an empty slice.
-func packageAuthors(authors []string, manifestEmail string, in io.Reader, out io.Writer) ([]string, error) {
+func packageAuthors(authors []string, manifestEmail string, acceptDefaults bool, answers text.Answers, in io.Reader, out io.Writer) ([]string, error) {
+	if len(authors) == 0 && acceptDefaults && !answers.Has(answerAuthor) {
+		return []string{manifestEmail}, nil
+	}
+
 	if len(authors) == 0 {
 		label := "Author: "
 
@@ -379,13 +490,17 @@ func packageAuthors(authors []string, manifestEmail string, in io.Reader, out io
 			label = fmt.Sprintf("%s[%s] ", label, manifestEmail)
 		}
 
-		author, err := text.Input(out, label, in)
+		author, err := answers.Input(answerAuthor, out, label, in)
 		if err != nil {
 			return []string{}, fmt.Errorf("error reading input %w", err)
 		}
 
 		if author != "" {
-			authors = []string{author}
+			for _, a := range strings.Split(author, ",") {
+				if a = strings.TrimSpace(a); a != "" {
+					authors = append(authors, a)
+				}
+			}
 		} else {
 			authors = []string{manifestEmail}
 		}
@@ -396,11 +511,20 @@ func packageAuthors(authors []string, manifestEmail string, in io.Reader, out io
 
 // selectLanguage decides whether to prompt the user for a language if none
 // defined or try and match the --language flag against available languages.
-func selectLanguage(from string, langFlag string, ls []*Language, mf manifest.File, in io.Reader, out io.Writer) (*Language, error) {
+func selectLanguage(from string, langFlag string, ls []*Language, mf manifest.File, acceptDefaults bool, answers text.Answers, in io.Reader, out io.Writer) (*Language, error) {
 	if from != "" && langFlag == "" || mf.Exists() {
 		return nil, nil
 	}
 
+	// An answer to the language prompt is treated like the --language flag.
+	if langFlag == "" {
+		langFlag = answers[answerLanguage]
+	}
+
+	if langFlag == "" && acceptDefaults {
+		return ls[0], nil
+	}
+
 	if langFlag == "" {
 		return promptForLanguage(ls, in, out)
 	}
@@ -471,16 +595,21 @@ func noProjectFiles(from string, language *Language, mf manifest.File) bool {
 
 // promptForStarterKit prompts the user for a package starter kit.
 //
-// It returns the path to the starter kit, and the corresponding branch/tag,
-func promptForStarterKit(kits []config.StarterKit, in io.Reader, out io.Writer) (from string, branch string, tag string, err error) {
+// It returns the selected starter kit, or a starter kit with only the path set
+// when the user pasted a git URL.
+func promptForStarterKit(kits []config.StarterKit, acceptDefaults bool, answers text.Answers, in io.Reader, out io.Writer) (config.StarterKit, error) {
+	if acceptDefaults && !answers.Has(answerStarterKit) {
+		return kits[0], nil
+	}
+
 	text.Output(out, "%s", text.Bold("Starter kit:"))
 	for i, kit := range kits {
 		fmt.Fprintf(out, "[%d] %s\n", i+1, text.Bold(kit.Name))
 		text.Indent(out, 4, "%s\n%s", kit.Description, kit.Path)
 	}
-	option, err := text.Input(out, "Choose option or paste git URL: [1] ", in, validateTemplateOptionOrURL(kits))
+	option, err := answers.Input(answerStarterKit, out, "Choose option or paste git URL: [1] ", in, validateTemplateOptionOrURL(kits))
 	if err != nil {
-		return "", "", "", fmt.Errorf("error reading input: %w", err)
+		return config.StarterKit{}, fmt.Errorf("error reading input: %w", err)
 	}
 	if option == "" {
 		option = "1"
@@ -488,11 +617,10 @@ func promptForStarterKit(kits []config.StarterKit, in io.Reader, out io.Writer)
 
 	var i int
 	if i, err = strconv.Atoi(option); err == nil {
-		template := kits[i-1]
-		return template.Path, template.Branch, template.Tag, nil
+		return kits[i-1], nil
 	}
 
-	return option, "", "", nil
+	return config.StarterKit{Path: option}, nil
 }
 
 func validateTemplateOptionOrURL(templates []config.StarterKit) func(string) error {
@@ -514,43 +642,91 @@ func validateTemplateOptionOrURL(templates []config.StarterKit) func(string) err
 	}
 }
 
+// knownStarterKit returns the configured starter kit that from refers to,
+// either by its URL (ignoring a trailing .git) or, unless from is a local
+// directory, by its name. Names are looked up in the kits of the language,
+// or of every language when none is selected, and must be unambiguous.
+func knownStarterKit(languages []*Language, language *Language, from string) (config.StarterKit, bool) {
+	if from == "" {
+		return config.StarterKit{}, false
+	}
+	normalize := func(p string) string {
+		return strings.TrimSuffix(strings.TrimSuffix(strings.ToLower(p), "/"), ".git")
+	}
+	for _, l := range languages {
+		for _, kit := range l.StarterKits {
+			if normalize(kit.Path) == normalize(from) {
+				return kit, true
+			}
+		}
+	}
+
+	if fi, err := os.Stat(from); err == nil && fi.IsDir() {
+		return config.StarterKit{}, false
+	}
+	if language != nil {
+		languages = []*Language{language}
+	}
+	var matches []config.StarterKit
+	for _, l := range languages {
+		for _, kit := range l.StarterKits {
+			if strings.EqualFold(kit.Name, from) {
+				matches = append(matches, kit)
+			}
+		}
+	}
+	if len(matches) != 1 {
+		return config.StarterKit{}, false
+	}
+	return matches[0], true
+}
+
 // fetchPackageTemplate will determine if the package code should be fetched
 // from GitHub using the git binary to clone the source or a HTTP request that
 // uses content-negotiation to determine the type of archive format used.
+//
+// If the starter kit pins a commit or checksum, the fetched template must
+// match it, protecting the user from a template that was tampered with.
+//
+// The commit of the template is returned when it was cloned from a git
+// repository, otherwise it's empty.
 func fetchPackageTemplate(
 	language *Language,
-	from, branch, tag, dst string,
+	kit config.StarterKit,
+	dst string,
 	mf manifest.File,
 	archives []file.Archive,
 	progress text.Progress,
 	client api.HTTPClient,
 	out io.Writer,
 	errLog fsterr.LogInterface,
-) error {
+) (commit string, err error) {
 	// We don't try to fetch a package template if the user is bringing their own
 	// compiled Wasm binary (or if the directory currently already contains a
 	// fastly.toml manifest file).
 	if mf.Exists() || language != nil && language.Name == "other" {
-		return nil
+		return "", nil
 	}
 	progress.Step("Fetching package template...")
 
+	from := kit.Path
+
 	// If the user has provided a local file path, we'll recursively copy the
 	// directory to dst.
 	fi, err := os.Stat(from)
 	if err != nil {
 		errLog.Add(err)
 	} else if fi.IsDir() {
-		return cp.Copy(from, dst)
+		return "", cp.Copy(from, dst)
 	}
 
 	req, err := http.NewRequest("GET", from, nil)
 	if err != nil {
 		errLog.Add(err)
 		if gitRepositoryRegEx.MatchString(from) {
-			return clonePackageFromEndpoint(from, branch, tag, dst)
+			return clonePackageFromEndpoint(kit, dst)
 		}
-		return fmt.Errorf("failed to construct package request URL: %w", err)
+		return "", fmt.Errorf("failed to construct package request URL: %w", err)
 	}
 
 	for _, archive := range archives {
@@ -562,14 +738,14 @@ func fetchPackageTemplate(
 	res, err := client.Do(req)
 	if err != nil {
 		errLog.Add(err)
-		return fmt.Errorf("failed to get package: %w", err)
+		return "", fmt.Errorf("failed to get package: %w", err)
 	}
 	defer res.Body.Close()
 
 	if res.StatusCode != http.StatusOK {
 		err := fmt.Errorf("failed to get package: %s", res.Status)
 		errLog.Add(err)
-		return err
+		return "", err
 	}
 
 	filename := filepath.Base(from)
@@ -583,7 +759,7 @@ func fetchPackageTemplate(
 	f, err := os.Create(filename)
 	if err != nil {
 		errLog.Add(err)
-		return fmt.Errorf("failed to create local %s archive: %w", filename, err)
+		return "", fmt.Errorf("failed to create local %s archive: %w", filename, err)
 	}
 	defer func() {
 		// NOTE: Later on we rename the file to include an extension and the
@@ -597,10 +773,11 @@ func fetchPackageTemplate(
 		}
 	}()
 
-	_, err = io.Copy(f, res.Body)
+	h := sha256.New()
+	_, err = io.Copy(io.MultiWriter(f, h), res.Body)
 	if err != nil {
 		errLog.Add(err)
-		return fmt.Errorf("failed to write %s archive to disk: %w", filename, err)
+		return "", fmt.Errorf("failed to write %s archive to disk: %w", filename, err)
 	}
 
 	// NOTE: We used to `defer` the closing of the file after its creation but
@@ -636,6 +813,17 @@ mimes:
 	}
 
 	if archive != nil {
+		if kit.Commit != "" {
+			return "", fsterr.RemediationError{
+				Inner:       fmt.Errorf("a commit can't be selected from the %s archive", filename),
+				Remediation: "Remove the --commit flag, or use a git repository URL with --from.",
+			}
+		}
+		if err := verifyChecksum(kit, hex.EncodeToString(h.Sum(nil))); err != nil {
+			errLog.Add(err)
+			return "", err
+		}
+
 		// Ensure there is a file extension on our filename, otherwise we won't
 		// know what type of archive format we're dealing with when we come to call
 		// the archive.Extract() method.
@@ -644,7 +832,7 @@ mimes:
 			err := os.Rename(filename, filenameWithExt)
 			if err != nil {
 				errLog.Add(err)
-				return err
+				return "", err
 			}
 			filename = filenameWithExt
 		}
@@ -655,40 +843,66 @@ mimes:
 		err = archive.Extract()
 		if err != nil {
 			errLog.Add(err)
-			return fmt.Errorf("failed to extract %s archive content: %w", filename, err)
+			return "", fmt.Errorf("failed to extract %s archive content: %w", filename, err)
 		}
 
+		return "", nil
+	}
+
+	return clonePackageFromEndpoint(kit, dst)
+}
+
+// verifyChecksum returns an error if the starter kit pins a checksum that
+// doesn't match the SHA-256 checksum of the fetched archive.
+func verifyChecksum(kit config.StarterKit, checksum string) error {
+	if kit.Checksum == "" || strings.EqualFold(kit.Checksum, checksum) {
 		return nil
 	}
+	return fsterr.RemediationError{
+		Inner:       fmt.Errorf("the checksum of the package template %s doesn't match the starter kit configuration (want %s, got %s)", kit.Path, kit.Checksum, checksum),
+		Remediation: fsterr.StarterKitMismatchRemediation,
+	}
+}
 
-	return clonePackageFromEndpoint(from, branch, tag, dst)
+// verifyCommit returns an error if the starter kit pins a commit that doesn't
+// match the commit that was cloned.
+func verifyCommit(kit config.StarterKit, commit string) error {
+	if kit.Commit == "" || strings.EqualFold(commit, kit.Commit) {
+		return nil
+	}
+	return fsterr.RemediationError{
+		Inner:       fmt.Errorf("the commit of the package template %s doesn't match the starter kit configuration (want %s, got %s)", kit.Path, kit.Commit, commit),
+		Remediation: fsterr.StarterKitMismatchRemediation,
+	}
 }
 
-// clonePackageFromEndpoint clones the given repo (from) into a temp directory,
-// then copies specific files to the destination directory (path).
-func clonePackageFromEndpoint(from string, branch string, tag string, dst string) error {
-	_, err := exec.LookPath("git")
-	if err != nil {
-		return fsterr.RemediationError{
-			Inner:       fmt.Errorf("`git` not found in $PATH"),
-			Remediation: fmt.Sprintf("The Fastly CLI requires a local installation of git.  For installation instructions for your operating system see:\n\n\t$ %s", text.Bold("https://git-scm.com/book/en/v2/Getting-Started-Installing-Git")),
-		}
+// clonePackageFromEndpoint clones the given starter kit repo into a temp
+// directory, then copies specific files to the destination directory (path).
+//
+// When the starter kit pins a commit without a branch or tag, the full history
+// is cloned so the commit can be checked out, otherwise the commit is only
+// verified against the head of the shallow clone. The cloned commit is
+// returned.
+func clonePackageFromEndpoint(kit config.StarterKit, dst string) (commit string, err error) {
+	from, branch, tag := kit.Path, kit.Branch, kit.Tag
+
+	if err := lookPathGit(); err != nil {
+		return "", err
 	}
 
 	tempdir, err := tempDir("package-init")
 	if err != nil {
-		return fmt.Errorf("error creating temporary path for package template: %w", err)
+		return "", fmt.Errorf("error creating temporary path for package template: %w", err)
 	}
 	defer os.RemoveAll(tempdir)
 
 	if branch != "" && tag != "" {
-		return fmt.Errorf("cannot use both git branch and tag name")
+		return "", fmt.Errorf("cannot use both git branch and tag name")
 	}
 
-	args := []string{
-		"clone",
-		"--depth",
-		"1",
+	args := []string{"clone"}
+	if kit.Commit == "" || branch != "" || tag != "" {
+		args = append(args, "--depth", "1")
 	}
 	var ref string
 	if branch != "" {
@@ -700,7 +914,7 @@ func clonePackageFromEndpoint(from string, branch string, tag string, dst string
 	if ref != "" {
 		args = append(args, "--branch", ref)
 	}
-	args = append(args, from, tempdir)
+	args = append(args, "--", from, tempdir)
 
 	// gosec flagged this:
 	// G204 (CWE-78): Subprocess launched with variable
@@ -709,11 +923,32 @@ func clonePackageFromEndpoint(from string, branch string, tag string, dst string
 	c := exec.Command("git", args...)
 	stdoutStderr, err := c.CombinedOutput()
 	if err != nil {
-		return fmt.Errorf("error fetching package template: %w\n\n%s", err, stdoutStderr)
+		return "", fmt.Errorf("error fetching package template: %w\n\n%s", err, stdoutStderr)
+	}
+
+	// NOTE: The commit must be a full SHA so that it can't be mistaken for an
+	// option, and the -- ensures it isn't read as a path.
+	if kit.Commit != "" && ref == "" {
+		if !commitRegEx.MatchString(kit.Commit) {
+			return "", fmt.Errorf("invalid commit '%s' for the package template", kit.Commit)
+		}
+		if err := git(tempdir, "checkout", "--quiet", kit.Commit, "--"); err != nil {
+			return "", fsterr.RemediationError{
+				Inner:       fmt.Errorf("error checking out commit %s of the package template: %w", kit.Commit, err),
+				Remediation: "Check the commit exists in the package template repository.",
+			}
+		}
+	}
+	commit, err = gitOutput(tempdir, "rev-parse", "HEAD")
+	if err != nil {
+		return "", fmt.Errorf("error reading the commit of the package template: %w", err)
+	}
+	if err := verifyCommit(kit, commit); err != nil {
+		return "", err
 	}
 
 	if err := os.RemoveAll(filepath.Join(tempdir, ".git")); err != nil {
-		return fmt.Errorf("error removing git metadata from package template: %w", err)
+		return "", fmt.Errorf("error removing git metadata from package template: %w", err)
 	}
 
 	err = filepath.Walk(tempdir, func(path string, info os.FileInfo, err error) error {
@@ -730,8 +965,7 @@ func clonePackageFromEndpoint(from string, branch string, tag string, dst string
 			return err
 		}
 
-		// Filter any files we want to ignore in Fastly-owned templates.
-		if fastlyOrgRegEx.MatchString(from) && fastlyFileIgnoreListRegEx.MatchString(rel) {
+		if ignoreTemplateFile(from, rel) {
 			return nil
 		}
 
@@ -744,11 +978,49 @@ func clonePackageFromEndpoint(from string, branch string, tag string, dst string
 	})
 
 	if err != nil {
-		return fmt.Errorf("error copying files from package template: %w", err)
+		return "", fmt.Errorf("error copying files from package template: %w", err)
+	}
+	return commit, nil
+}
+
+// lookPathGit returns an error if git isn't installed.
+func lookPathGit() error {
+	if _, err := exec.LookPath("git"); err != nil {
+		return fsterr.RemediationError{
+			Inner:       fmt.Errorf("`git` not found in $PATH"),
+			Remediation: fmt.Sprintf("The Fastly CLI requires a local installation of git.  For installation instructions for your operating system see:\n\n\t$ %s", text.Bold("https://git-scm.com/book/en/v2/Getting-Started-Installing-Git")),
+		}
 	}
 	return nil
 }
 
+// ignoreTemplateFile reports whether the file at the relative path within the
+// package template from is left out of the project. We filter files that are
+// only relevant to the Fastly-owned template repositories.
+func ignoreTemplateFile(from, rel string) bool {
+	return fastlyOrgRegEx.MatchString(from) && fastlyFileIgnoreListRegEx.MatchString(rel)
+}
+
+// git runs a git command in the given directory.
+func git(dir string, args ...string) error {
+	_, err := gitOutput(dir, args...)
+	return err
+}
+
+// gitOutput runs a git command in the given directory and returns its output.
+func gitOutput(dir string, args ...string) (string, error) {
+	// gosec flagged this:
+	// G204 (CWE-78): Subprocess launched with variable
+	// Disabling as the arguments are controlled by the CLI.
+	/* #nosec */
+	c := exec.Command("git", append([]string{"-C", dir}, args...)...)
+	stdoutStderr, err := c.CombinedOutput()
+	if err != nil {
+		return "", fmt.Errorf("%w\n\n%s", err, stdoutStderr)
+	}
+	return strings.TrimSpace(string(stdoutStderr)), nil
+}
+
 func tempDir(prefix string) (abspath string, err error) {
 	abspath, err = filepath.Abs(filepath.Join(
 		os.TempDir(),
@@ -774,7 +1046,9 @@ func updateManifest(
 	progress text.Progress,
 	path, name, desc string,
 	authors []string,
+	backends map[string]*manifest.SetupBackend,
 	language *Language,
+	tmpl manifest.Template,
 ) (manifest.File, error) {
 	progress.Step("Updating package manifest...")
 
@@ -790,6 +1064,9 @@ func updateManifest(
 				m.Description = desc
 				m.Authors = authors
 				m.Language = language.Name
+				if len(backends) > 0 {
+					m.Setup.Backends = backends
+				}
 				if err := m.Write(mp); err != nil {
 					return m, fmt.Errorf("error saving package manifest: %w", err)
 				}
@@ -817,6 +1094,21 @@ func updateManifest(
 		m.Language = language.Name
 	}
 
+	if len(backends) > 0 {
+		if m.Setup.Backends == nil {
+			m.Setup.Backends = make(map[string]*manifest.SetupBackend)
+		}
+		for name, b := range backends {
+			fmt.Fprintf(progress, "Setting backend %s in manifest...\n", name)
+			m.Setup.Backends[name] = b
+		}
+	}
+
+	if tmpl.Commit != "" {
+		fmt.Fprintf(progress, "Setting package template in manifest to %s@%s...\n", tmpl.Source, tmpl.Commit)
+		m.Template = tmpl
+	}
+
 	if err := m.Write(mp); err != nil {
 		return m, fmt.Errorf("error saving package manifest: %w", err)
 	}
@@ -824,6 +1116,41 @@ func updateManifest(
 	return m, nil
 }
 
+// setupBackends returns the backends defined by the init.backends.<name>.*
+// answers, for the [setup.backends] section of the fastly.toml manifest.
+func setupBackends(answers text.Answers) (map[string]*manifest.SetupBackend, error) {
+	backends := make(map[string]*manifest.SetupBackend)
+	for k, v := range answers {
+		if !strings.HasPrefix(k, answerBackends) {
+			continue
+		}
+		name, field, ok := strings.Cut(strings.TrimPrefix(k, answerBackends), ".")
+		if !ok || name == "" {
+			return nil, fmt.Errorf("invalid answer '%s': expected %s<name>.<field>", k, answerBackends)
+		}
+		b, ok := backends[name]
+		if !ok {
+			b = &manifest.SetupBackend{}
+			backends[name] = b
+		}
+		switch field {
+		case "address":
+			b.Address = v
+		case "description":
+			b.Description = v
+		case "port":
+			port, err := strconv.ParseUint(v, 10, 16)
+			if err != nil {
+				return nil, fmt.Errorf("invalid answer for '%s': must be a port number", k)
+			}
+			b.Port = uint(port)
+		default:
+			return nil, fmt.Errorf("invalid answer '%s': a backend has an address, port and description", k)
+		}
+	}
+	return backends, nil
+}
+
 // initializeLanguage for newly cloned package.
 func initializeLanguage(progress text.Progress, language *Language, languages []*Language, name, wd, path, build string) (*Language, error) {
 	progress.Step("Initializing package...")
@@ -0,0 +1,325 @@
+package compute
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	fsterr "github.com/fastly/cli/pkg/errors"
+	"github.com/fastly/cli/pkg/manifest"
+	"github.com/fastly/cli/pkg/text"
+)
+
+// TemplateSyncRemediation explains which projects can be synced with their
+// package template.
+const TemplateSyncRemediation = "Only projects created with `fastly compute init` from a git repository template can be synced. Add a [template] section with the source and commit of the package template to the fastly.toml manifest."
+
+// templateFile is a file in a commit of the package template.
+type templateFile struct {
+	mode os.FileMode
+	data []byte
+}
+
+// templateSync describes the changes merged into a project.
+type templateSync struct {
+	added     []string
+	updated   []string
+	removed   []string
+	conflicts []string
+}
+
+// sync fetches the latest commit of the package template the project was
+// created from and three-way merges the changes since the recorded commit into
+// the project, leaving conflict markers where both changed the same lines.
+func (c *InitCommand) sync(out io.Writer) (err error) {
+	progress := text.NewProgress(out, c.Globals.Verbose())
+	defer func(errLog fsterr.LogInterface) {
+		if err != nil {
+			errLog.Add(err)
+			progress.Fail()
+		}
+	}(c.Globals.ErrLog)
+
+	dir := c.dir
+	if dir == "" {
+		dir, err = os.Getwd()
+		if err != nil {
+			return fmt.Errorf("error determining current directory: %w", err)
+		}
+	}
+
+	var mf manifest.File
+	mp := filepath.Join(dir, manifest.Filename)
+	if err := mf.Read(mp); err != nil {
+		return fsterr.ErrReadingManifest
+	}
+	tmpl := mf.Template
+	if tmpl.Source == "" || tmpl.Commit == "" {
+		return fsterr.RemediationError{
+			Inner:       fmt.Errorf("the package template of the project isn't tracked in the fastly.toml manifest"),
+			Remediation: TemplateSyncRemediation,
+		}
+	}
+	if tmpl.Branch != "" && tmpl.Tag != "" {
+		return fmt.Errorf("cannot use both git branch and tag name")
+	}
+	if err := lookPathGit(); err != nil {
+		return err
+	}
+
+	progress.Step("Fetching package template...")
+
+	tempdir, err := tempDir("package-sync")
+	if err != nil {
+		return fmt.Errorf("error creating temporary path for package template: %w", err)
+	}
+	defer os.RemoveAll(tempdir)
+
+	// NOTE: The full history is cloned as the recorded commit is needed as the
+	// base of the merge.
+	args := []string{"clone", "--quiet", "--no-checkout"}
+	if ref := tmpl.Branch + tmpl.Tag; ref != "" {
+		args = append(args, "--branch", ref)
+	}
+	args = append(args, tmpl.Source, tempdir)
+	// gosec flagged this:
+	// G204 (CWE-78): Subprocess launched with variable
+	// Disabling as there should be no vulnerability to cloning a remote repo.
+	/* #nosec */
+	if stdoutStderr, err := exec.Command("git", args...).CombinedOutput(); err != nil {
+		return fmt.Errorf("error fetching package template: %w\n\n%s", err, stdoutStderr)
+	}
+
+	head, err := gitOutput(tempdir, "rev-parse", "HEAD")
+	if err != nil {
+		return fmt.Errorf("error reading the commit of the package template: %w", err)
+	}
+	if strings.HasPrefix(head, strings.ToLower(tmpl.Commit)) {
+		progress.Done()
+		text.Info(out, "The project is up to date with the package template (%s).", shortCommit(head))
+		return nil
+	}
+
+	base, err := templateFiles(tempdir, tmpl.Commit)
+	if err != nil {
+		return fsterr.RemediationError{
+			Inner:       fmt.Errorf("error reading commit %s of the package template: %w", tmpl.Commit, err),
+			Remediation: "Check the commit in the [template] section of the fastly.toml manifest exists in the package template repository.",
+		}
+	}
+	latest, err := templateFiles(tempdir, head)
+	if err != nil {
+		return fmt.Errorf("error reading commit %s of the package template: %w", head, err)
+	}
+
+	progress.Step("Merging package template changes...")
+
+	result, err := mergeTemplate(dir, tmpl.Source, base, latest)
+	if err != nil {
+		return err
+	}
+
+	progress.Step("Updating package manifest...")
+
+	mf.Template.Commit = head
+	if err := mf.Write(mp); err != nil {
+		return fmt.Errorf("error saving package manifest: %w", err)
+	}
+
+	progress.Done()
+
+	text.Success(out, "Synced the package template from %s to %s", shortCommit(tmpl.Commit), shortCommit(head))
+	for _, section := range []struct {
+		name  string
+		files []string
+	}{
+		{"Added", result.added},
+		{"Updated", result.updated},
+		{"Removed", result.removed},
+	} {
+		if len(section.files) > 0 {
+			text.Break(out)
+			text.Output(out, "%s:", section.name)
+			for _, f := range section.files {
+				text.Indent(out, 4, "%s", f)
+			}
+		}
+	}
+	if len(result.conflicts) > 0 {
+		text.Warning(out, "The following files have conflicting changes. Resolve the conflict markers before building the project:")
+		for _, f := range result.conflicts {
+			text.Indent(out, 4, "%s", f)
+		}
+	}
+	return nil
+}
+
+// mergeTemplate merges the changes between the base and latest commits of the
+// package template into the project in dir.
+//
+// The fastly.toml manifest is never merged, as it describes the project rather
+// than the template. Files the project deleted stay deleted, and files removed
+// from the template are only deleted if the project didn't change them.
+func mergeTemplate(dir, from string, base, latest map[string]templateFile) (templateSync, error) {
+	var result templateSync
+
+	names := make([]string, 0, len(base)+len(latest))
+	for name := range base {
+		names = append(names, name)
+	}
+	for name := range latest {
+		if _, ok := base[name]; !ok {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		if name == manifest.Filename || ignoreTemplateFile(from, name) {
+			continue
+		}
+		b, inBase := base[name]
+		l, inLatest := latest[name]
+		if inBase && inLatest && bytes.Equal(b.data, l.data) {
+			continue // unchanged in the template
+		}
+
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		// gosec flagged this:
+		// G304 (CWE-22): Potential file inclusion via variable
+		// Disabling as the path is a file within the project.
+		/* #nosec */
+		local, err := os.ReadFile(path)
+		inLocal := err == nil
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			return result, fmt.Errorf("error reading %s: %w", name, err)
+		}
+
+		switch {
+		case !inLatest:
+			if inLocal && bytes.Equal(local, b.data) {
+				if err := os.Remove(path); err != nil {
+					return result, fmt.Errorf("error removing %s: %w", name, err)
+				}
+				result.removed = append(result.removed, name)
+			}
+		case !inLocal:
+			if inBase {
+				continue // deleted from the project
+			}
+			if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+				return result, fmt.Errorf("error creating directory for %s: %w", name, err)
+			}
+			if err := os.WriteFile(path, l.data, l.mode); err != nil {
+				return result, fmt.Errorf("error writing %s: %w", name, err)
+			}
+			result.added = append(result.added, name)
+		case bytes.Equal(local, l.data):
+			continue // already up to date
+		default:
+			merged, conflicts, err := mergeFile(local, b.data, l.data)
+			if err != nil {
+				return result, fmt.Errorf("error merging %s: %w", name, err)
+			}
+			if err := os.WriteFile(path, merged, l.mode); err != nil {
+				return result, fmt.Errorf("error writing %s: %w", name, err)
+			}
+			if conflicts {
+				result.conflicts = append(result.conflicts, name)
+			} else {
+				result.updated = append(result.updated, name)
+			}
+		}
+	}
+	return result, nil
+}
+
+// mergeFile three-way merges the changes between base and latest into local,
+// using `git merge-file`. It reports whether the result has conflict markers.
+func mergeFile(local, base, latest []byte) (merged []byte, conflicts bool, err error) {
+	tempdir, err := tempDir("package-merge")
+	if err != nil {
+		return nil, false, err
+	}
+	defer os.RemoveAll(tempdir)
+
+	paths := make([]string, 3)
+	for i, data := range [][]byte{local, base, latest} {
+		paths[i] = filepath.Join(tempdir, strconv.Itoa(i))
+		if err := os.WriteFile(paths[i], data, 0o600); err != nil {
+			return nil, false, err
+		}
+	}
+
+	// gosec flagged this:
+	// G204 (CWE-78): Subprocess launched with variable
+	// Disabling as the arguments are controlled by the CLI.
+	/* #nosec */
+	cmd := exec.Command("git", "merge-file", "--stdout", "-L", "project", "-L", "base", "-L", "template", paths[0], paths[1], paths[2])
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	merged, err = cmd.Output()
+
+	// NOTE: A positive exit code is the number of conflicts.
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) && exitErr.ExitCode() > 0 && exitErr.ExitCode() < 128 {
+		return merged, true, nil
+	}
+	if err != nil {
+		return nil, false, fmt.Errorf("%w\n\n%s", err, stderr.String())
+	}
+	return merged, false, nil
+}
+
+// templateFiles returns the files of the given commit of the package template
+// cloned into dir, keyed by their slash-separated path.
+func templateFiles(dir, commit string) (map[string]templateFile, error) {
+	tree, err := gitOutput(dir, "ls-tree", "-r", "-z", commit)
+	if err != nil {
+		return nil, err
+	}
+
+	files := make(map[string]templateFile)
+	for _, entry := range strings.Split(tree, "\x00") {
+		// Each entry is formatted as: <mode> SP <type> SP <object> TAB <path>
+		meta, name, ok := strings.Cut(entry, "\t")
+		if !ok {
+			continue
+		}
+		fields := strings.Fields(meta)
+		if len(fields) != 3 || fields[1] != "blob" {
+			continue // e.g. submodules
+		}
+
+		mode := os.FileMode(0o644)
+		if fields[0] == "100755" {
+			mode = 0o755
+		}
+
+		// gosec flagged this:
+		// G204 (CWE-78): Subprocess launched with variable
+		// Disabling as the arguments are controlled by the CLI.
+		/* #nosec */
+		data, err := exec.Command("git", "-C", dir, "cat-file", "blob", fields[2]).Output()
+		if err != nil {
+			return nil, fmt.Errorf("error reading %s: %w", name, err)
+		}
+		files[name] = templateFile{mode: mode, data: data}
+	}
+	return files, nil
+}
+
+// shortCommit abbreviates a commit hash for display.
+func shortCommit(commit string) string {
+	if len(commit) > 7 {
+		return commit[:7]
+	}
+	return commit
+}
@@ -3,12 +3,17 @@ package compute_test
 import (
 	"bytes"
 	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"os"
+	"os/exec"
 	"path/filepath"
 	"strings"
 	"testing"
 
 	"github.com/fastly/cli/pkg/app"
+	"github.com/fastly/cli/pkg/commands/compute"
 	"github.com/fastly/cli/pkg/config"
 	"github.com/fastly/cli/pkg/manifest"
 	"github.com/fastly/cli/pkg/testutil"
@@ -121,6 +126,21 @@ func TestInit(t *testing.T) {
 			},
 			manifestIncludes: `authors = ["test1@example.com", "test2@example.com"]`,
 		},
+		{
+			name: "with --non-interactive",
+			args: args("compute init --non-interactive"),
+			configFile: config.File{
+				StarterKits: config.StarterKitLanguages{
+					Rust: skRust,
+				},
+			},
+			wantOutput: []string{
+				"Fetching package template...",
+				"Updating package manifest...",
+				"SUCCESS: Initialized package",
+			},
+			manifestIncludes: `language = "rust"`,
+		},
 		{
 			name: "with --from set to starter kit repository",
 			args: args("compute init --from https://github.com/fastly/compute-starter-kit-rust-default"),
@@ -201,6 +221,23 @@ func TestInit(t *testing.T) {
 				"SUCCESS: Initialized package",
 			},
 		},
+		{
+			name: "with starter kit commit mismatch",
+			args: args("compute init --non-interactive"),
+			configFile: config.File{
+				StarterKits: config.StarterKitLanguages{
+					Rust: []config.StarterKit{
+						{
+							Name:   "Default",
+							Path:   "https://github.com/fastly/compute-starter-kit-rust-default",
+							Branch: "main",
+							Commit: "0000000000000000000000000000000000000000",
+						},
+					},
+				},
+			},
+			wantError: "doesn't match the starter kit configuration (want 0000000000000000000000000000000000000000",
+		},
 		{
 			name: "with existing package manifest",
 			args: args("compute init --force"), // --force will ignore a directory that isn't empty
@@ -429,3 +466,262 @@ func TestInit(t *testing.T) {
 		})
 	}
 }
+
+func TestInitStarterKitPin(t *testing.T) {
+	args := testutil.Args
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/zip")
+		_, _ = w.Write([]byte("not really a zip"))
+	}))
+	defer server.Close()
+
+	for _, testcase := range []struct {
+		name                 string
+		args                 []string
+		kit                  config.StarterKit
+		wantError            string
+		wantRemediationError string
+	}{
+		{
+			name: "checksum mismatch",
+			args: args("compute init --language rust --non-interactive"),
+			kit: config.StarterKit{
+				Name:     "Default",
+				Path:     server.URL + "/main.zip",
+				Checksum: "deadbeef",
+			},
+			wantError:            "the checksum of the package template " + server.URL + "/main.zip doesn't match the starter kit configuration (want deadbeef",
+			wantRemediationError: "may mean it was tampered with",
+		},
+		{
+			name: "commit with an archive",
+			args: args("compute init --language rust --non-interactive --commit 1a2b3c4d5e6f1a2b3c4d5e6f1a2b3c4d5e6f1a2b"),
+			kit: config.StarterKit{
+				Name: "Default",
+				Path: server.URL + "/main.zip",
+			},
+			wantError:            "a commit can't be selected from the main.zip archive",
+			wantRemediationError: "Remove the --commit flag",
+		},
+		{
+			name: "checksum mismatch with --from URL",
+			args: args("compute init --from " + server.URL + "/main.zip --language rust --non-interactive"),
+			kit: config.StarterKit{
+				Name:     "Default",
+				Path:     server.URL + "/main.zip",
+				Checksum: "deadbeef",
+			},
+			wantError: "doesn't match the starter kit configuration (want deadbeef",
+		},
+		{
+			name: "checksum mismatch with --from name",
+			args: args("compute init --from default --language rust --non-interactive"),
+			kit: config.StarterKit{
+				Name:     "Default",
+				Path:     server.URL + "/main.zip",
+				Checksum: "deadbeef",
+			},
+			wantError: "doesn't match the starter kit configuration (want deadbeef",
+		},
+		{
+			name: "commit that looks like an option",
+			args: args("compute init --language rust --non-interactive --commit=--upload-pack=touch"),
+			kit: config.StarterKit{
+				Name: "Default",
+				Path: "https://github.com/fastly/compute-starter-kit-rust-default.git",
+			},
+			wantError:            "invalid commit '--upload-pack=touch'",
+			wantRemediationError: "full 40 character SHA",
+		},
+		{
+			name: "abbreviated commit",
+			args: args("compute init --language rust --non-interactive"),
+			kit: config.StarterKit{
+				Name:   "Default",
+				Path:   "https://github.com/fastly/compute-starter-kit-rust-default.git",
+				Commit: "1a2b3c",
+			},
+			wantError: "invalid commit '1a2b3c'",
+		},
+	} {
+		t.Run(testcase.name, func(t *testing.T) {
+			pwd, err := os.Getwd()
+			if err != nil {
+				t.Fatal(err)
+			}
+			rootdir := testutil.NewEnv(testutil.EnvOpts{T: t})
+			defer os.RemoveAll(rootdir)
+			if err := os.Chdir(rootdir); err != nil {
+				t.Fatal(err)
+			}
+			defer os.Chdir(pwd)
+
+			var stdout bytes.Buffer
+			opts := testutil.NewRunOpts(testcase.args, &stdout)
+			opts.ConfigFile = config.File{
+				StarterKits: config.StarterKitLanguages{
+					Rust: []config.StarterKit{testcase.kit},
+				},
+			}
+			err = app.Run(opts)
+			testutil.AssertErrorContains(t, err, testcase.wantError)
+			testutil.AssertRemediationErrorContains(t, err, testcase.wantRemediationError)
+		})
+	}
+}
+
+func TestInitAnswers(t *testing.T) {
+	pwd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	rootdir := testutil.NewEnv(testutil.EnvOpts{
+		T: t,
+		Write: []testutil.FileIO{
+			{
+				Src: `{
+					"init": {
+						"name": "edge-app",
+						"description": "Stamped out by automation",
+						"author": ["a@example.com", "b@example.com"],
+						"backends": {
+							"origin": {"address": "origin.example.com", "port": 443}
+						}
+					}
+				}`,
+				Dst: "answers.json",
+			},
+		},
+	})
+	defer os.RemoveAll(rootdir)
+	if err := os.Chdir(rootdir); err != nil {
+		t.Fatal(err)
+	}
+	defer os.Chdir(pwd)
+
+	var stdout bytes.Buffer
+	opts := testutil.NewRunOpts(testutil.Args("compute init --language other --non-interactive --answers answers.json --directory app"), &stdout)
+	err = app.Run(opts)
+	t.Log(stdout.String())
+	testutil.AssertNoError(t, err)
+
+	var m manifest.File
+	if err := m.Read(filepath.Join(rootdir, "app", manifest.Filename)); err != nil {
+		t.Fatal(err)
+	}
+	testutil.AssertString(t, "edge-app", m.Name)
+	testutil.AssertString(t, "Stamped out by automation", m.Description)
+	testutil.AssertEqual(t, []string{"a@example.com", "b@example.com"}, m.Authors)
+	testutil.AssertEqual(t, map[string]*manifest.SetupBackend{
+		"origin": {Address: "origin.example.com", Port: 443},
+	}, m.Setup.Backends)
+}
+
+func TestInitSyncTemplate(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not found in $PATH")
+	}
+
+	template := t.TempDir()
+	git := func(args ...string) string {
+		t.Helper()
+		c := exec.Command("git", append([]string{"-C", template, "-c", "user.name=test", "-c", "user.email=test@example.com"}, args...)...)
+		out, err := c.CombinedOutput()
+		if err != nil {
+			t.Fatalf("git %s: %s\n\n%s", strings.Join(args, " "), err, out)
+		}
+		return strings.TrimSpace(string(out))
+	}
+	write := func(dir, name, content string) {
+		t.Helper()
+		if err := os.MkdirAll(filepath.Join(dir, filepath.Dir(name)), 0o750); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	git("init", "--quiet", "--initial-branch", "main")
+	write(template, manifest.Filename, "manifest_version = 2\nname = \"template\"\n")
+	write(template, "README.md", "# Template\n\nStart here.\n\nMore docs.\n")
+	write(template, "src/main.rs", "fn main() {}\n")
+	write(template, "obsolete.txt", "remove me\n")
+	write(template, "conflict.txt", "original\n")
+	git("add", "-A")
+	git("commit", "--quiet", "-m", "v1")
+	base := git("rev-parse", "HEAD")
+
+	project := t.TempDir()
+	write(project, manifest.Filename, fmt.Sprintf("manifest_version = 2\nname = \"app\"\nlanguage = \"rust\"\n\n[template]\nsource = %q\nbranch = \"main\"\ncommit = %q\n", template, base))
+	write(project, "README.md", "# My App\n\nStart here.\n\nMore docs.\n")
+	write(project, "src/main.rs", "fn main() {}\n")
+	write(project, "obsolete.txt", "remove me\n")
+	write(project, "conflict.txt", "changed by the project\n")
+
+	write(template, "README.md", "# Template\n\nStart here.\n\nMore docs, now updated.\n")
+	write(template, "src/lib.rs", "pub fn lib() {}\n")
+	write(template, "conflict.txt", "changed by the template\n")
+	git("rm", "--quiet", "obsolete.txt")
+	git("add", "-A")
+	git("commit", "--quiet", "-m", "v2")
+	head := git("rev-parse", "HEAD")
+
+	var stdout bytes.Buffer
+	opts := testutil.NewRunOpts(testutil.Args("compute init --sync-template --directory "+project), &stdout)
+	err := app.Run(opts)
+	t.Log(stdout.String())
+	testutil.AssertNoError(t, err)
+
+	for _, s := range []string{
+		"Synced the package template from " + base[:7] + " to " + head[:7],
+		"src/lib.rs",
+		"README.md",
+		"obsolete.txt",
+		"conflicting changes",
+		"conflict.txt",
+	} {
+		testutil.AssertStringContains(t, stdout.String(), s)
+	}
+
+	read := func(name string) string {
+		t.Helper()
+		b, err := os.ReadFile(filepath.Join(project, name))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return string(b)
+	}
+	testutil.AssertString(t, "# My App\n\nStart here.\n\nMore docs, now updated.\n", read("README.md"))
+	testutil.AssertString(t, "pub fn lib() {}\n", read("src/lib.rs"))
+	testutil.AssertStringContains(t, read("conflict.txt"), "<<<<<<< project")
+	if _, err := os.Stat(filepath.Join(project, "obsolete.txt")); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("want obsolete.txt removed, have %v", err)
+	}
+
+	var m manifest.File
+	if err := m.Read(filepath.Join(project, manifest.Filename)); err != nil {
+		t.Fatal(err)
+	}
+	testutil.AssertString(t, "app", m.Name)
+	testutil.AssertString(t, head, m.Template.Commit)
+
+	stdout.Reset()
+	opts = testutil.NewRunOpts(testutil.Args("compute init --sync-template --directory "+project), &stdout)
+	err = app.Run(opts)
+	testutil.AssertNoError(t, err)
+	testutil.AssertStringContains(t, stdout.String(), "The project is up to date with the package template")
+}
+
+func TestInitSyncTemplateUntracked(t *testing.T) {
+	project := t.TempDir()
+	if err := os.WriteFile(filepath.Join(project, manifest.Filename), []byte("manifest_version = 2\nname = \"app\"\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	var stdout bytes.Buffer
+	opts := testutil.NewRunOpts(testutil.Args("compute init --sync-template --directory "+project), &stdout)
+	err := app.Run(opts)
+	testutil.AssertErrorContains(t, err, "the package template of the project isn't tracked")
+	testutil.AssertRemediationErrorContains(t, err, compute.TemplateSyncRemediation)
+}
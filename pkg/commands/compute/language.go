@@ -1,12 +1,15 @@
 package compute
 
 import (
+	"context"
 	"fmt"
-	"runtime"
+	"os"
+	"path/filepath"
 	"sort"
 	"strings"
 
 	"github.com/fastly/cli/pkg/config"
+	fstexec "github.com/fastly/cli/pkg/exec"
 	"github.com/fastly/cli/pkg/manifest"
 )
 
@@ -24,6 +27,7 @@ func NewLanguages(kits config.StarterKitLanguages, d *config.Data, pkgName strin
 			Toolchain: NewRust(
 				pkgName,
 				scripts,
+				nil,
 				d.ErrLog,
 				d.HTTPClient,
 				0,
@@ -37,8 +41,10 @@ func NewLanguages(kits config.StarterKitLanguages, d *config.Data, pkgName strin
 			Toolchain: NewJavaScript(
 				pkgName,
 				scripts,
+				nil,
 				d.ErrLog,
 				0,
+				d.File.Language.JavaScript,
 			),
 		}),
 		NewLanguage(&LanguageOptions{
@@ -48,6 +54,7 @@ func NewLanguages(kits config.StarterKitLanguages, d *config.Data, pkgName strin
 			Toolchain: NewGo(
 				pkgName,
 				scripts,
+				nil,
 				d.ErrLog,
 				0,
 				d.File.Language.Go,
@@ -60,8 +67,10 @@ func NewLanguages(kits config.StarterKitLanguages, d *config.Data, pkgName strin
 			Toolchain: NewAssemblyScript(
 				pkgName,
 				scripts,
+				nil,
 				d.ErrLog,
 				0,
+				d.File.Language.JavaScript,
 			),
 		}),
 		NewLanguage(&LanguageOptions{
@@ -124,7 +133,66 @@ func NewLanguage(options *LanguageOptions) *Language {
 
 // Shell represents a subprocess shell used by `compute` environment where
 // `[scripts.build]` has been defined within fastly.toml manifest.
-type Shell struct{}
+//
+// Name is the shell that the custom scripts run in (see [scripts.shell]),
+// otherwise the platform's default shell. When Sandbox is set, the custom
+// scripts run within it. When Context is set, the toolchain's subprocesses are
+// killed once it's done. When Dir is set, the toolchain builds the project in
+// that directory rather than the current working directory.
+type Shell struct {
+	Context context.Context
+	Dir     string
+	Name    string
+	Sandbox *fstexec.Sandbox
+}
+
+// Director is implemented by a Toolchain that can build a project outside of
+// the current working directory.
+type Director interface {
+	SetDir(dir string)
+}
+
+// SetDir implements the Director interface.
+func (s *Shell) SetDir(dir string) {
+	s.Dir = dir
+}
+
+// projectDir returns the directory the toolchain builds the project in.
+func (s Shell) projectDir() (string, error) {
+	if s.Dir != "" {
+		return s.Dir, nil
+	}
+	return os.Getwd()
+}
+
+// path returns name resolved against the project directory. A relative path
+// is returned when Dir isn't set, which is resolved against the current
+// working directory.
+func (s Shell) path(name string) string {
+	return filepath.Join(s.Dir, name)
+}
+
+// Sandboxer is implemented by a Toolchain whose custom scripts can be run in
+// a sandbox.
+type Sandboxer interface {
+	SetSandbox(sandbox *fstexec.Sandbox)
+}
+
+// SetSandbox implements the Sandboxer interface.
+func (s *Shell) SetSandbox(sandbox *fstexec.Sandbox) {
+	s.Sandbox = sandbox
+}
+
+// Canceler is implemented by a Toolchain whose subprocesses can be cancelled,
+// e.g. when the user interrupts the CLI.
+type Canceler interface {
+	SetContext(ctx context.Context)
+}
+
+// SetContext implements the Canceler interface.
+func (s *Shell) SetContext(ctx context.Context) {
+	s.Context = ctx
+}
 
 // Build expects a command that can be prefixed with an appropriate subprocess
 // shell.
@@ -135,15 +203,5 @@ type Shell struct{}
 // Should be converted into a command such as (on unix):
 // sh -c "yarn install && yarn build"
 func (s Shell) Build(command string) (cmd string, args []string) {
-	cmd = "sh"
-	args = []string{"-c"}
-
-	if runtime.GOOS == "windows" {
-		cmd = "cmd.exe"
-		args = []string{"/C"}
-	}
-
-	args = append(args, command)
-
-	return cmd, args
+	return fstexec.ShellCommand(s.Name, command)
 }
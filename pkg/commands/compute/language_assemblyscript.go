@@ -3,10 +3,10 @@ package compute
 import (
 	"fmt"
 	"io"
-	"os"
 	"path/filepath"
 	"time"
 
+	"github.com/fastly/cli/pkg/config"
 	fsterr "github.com/fastly/cli/pkg/errors"
 	fstexec "github.com/fastly/cli/pkg/exec"
 	"github.com/fastly/cli/pkg/filesystem"
@@ -34,16 +34,20 @@ type AssemblyScript struct {
 }
 
 // NewAssemblyScript constructs a new AssemblyScript toolchain.
-func NewAssemblyScript(pkgName string, scripts manifest.Scripts, errlog fsterr.LogInterface, timeout int) *AssemblyScript {
+func NewAssemblyScript(pkgName string, scripts manifest.Scripts, args []string, errlog fsterr.LogInterface, timeout int, cfg config.JavaScript) *AssemblyScript {
 	return &AssemblyScript{
 		JavaScript: JavaScript{
+			Shell:             Shell{Name: scripts.Shell},
+			args:              args,
 			build:             scripts.Build,
+			config:            cfg,
+			env:               scripts.EnvVars,
 			errlog:            errlog,
 			packageDependency: "assemblyscript",
 			packageExecutable: "asc",
+			packageManager:    scripts.PackageManager,
 			pkgName:           pkgName,
 			timeout:           timeout,
-			toolchain:         JsToolchain,
 		},
 		build:     scripts.Build,
 		errlog:    errlog,
@@ -55,7 +59,7 @@ func NewAssemblyScript(pkgName string, scripts manifest.Scripts, errlog fsterr.L
 // AssemblyScript source to a Wasm binary.
 func (a AssemblyScript) Build(out io.Writer, progress text.Progress, verbose bool, callback func() error) error {
 	// Check if bin directory exists and create if not.
-	pwd, err := os.Getwd()
+	pwd, err := a.projectDir()
 	if err != nil {
 		a.errlog.Add(err)
 		return fmt.Errorf("getting current working directory: %w", err)
@@ -66,10 +70,14 @@ func (a AssemblyScript) Build(out io.Writer, progress text.Progress, verbose boo
 		return fmt.Errorf("making bin directory: %w", err)
 	}
 
-	toolchaindir, err := getJsToolchainBinPath(a.toolchain)
+	toolchain, err := a.toolchain()
+	if err != nil {
+		return err
+	}
+	toolchaindir, err := getJsToolchainBinPath(toolchain, a.packageExecutable, pwd)
 	if err != nil {
 		a.errlog.Add(err)
-		return fmt.Errorf("getting npm path: %w", err)
+		return fmt.Errorf("getting %s path: %w", toolchain, err)
 	}
 
 	cmd := filepath.Join(toolchaindir, "asc")
@@ -80,12 +88,15 @@ func (a AssemblyScript) Build(out io.Writer, progress text.Progress, verbose boo
 		"--optimize",
 		"--noAssert",
 	}
+	args = append(args, a.args...)
 
+	var sandbox *fstexec.Sandbox
 	if a.build != "" {
 		cmd, args = a.Shell.Build(a.build)
+		sandbox = a.Shell.Sandbox
 	}
 
-	err = a.execCommand(cmd, args, out, progress, verbose)
+	err = a.execCommand(cmd, args, sandbox, out, progress, verbose)
 	if err != nil {
 		return err
 	}
@@ -98,7 +109,7 @@ func (a AssemblyScript) Build(out io.Writer, progress text.Progress, verbose boo
 	if a.postBuild != "" {
 		if err = callback(); err == nil {
 			cmd, args := a.Shell.Build(a.postBuild)
-			err := a.execCommand(cmd, args, out, progress, verbose)
+			err := a.execCommand(cmd, args, a.Shell.Sandbox, out, progress, verbose)
 			if err != nil {
 				return err
 			}
@@ -108,13 +119,17 @@ func (a AssemblyScript) Build(out io.Writer, progress text.Progress, verbose boo
 	return nil
 }
 
-func (a AssemblyScript) execCommand(cmd string, args []string, out, progress io.Writer, verbose bool) error {
+func (a AssemblyScript) execCommand(cmd string, args []string, sandbox *fstexec.Sandbox, out, progress io.Writer, verbose bool) error {
 	s := fstexec.Streaming{
 		Command:  cmd,
+		Context:  a.Shell.Context,
 		Args:     args,
-		Env:      os.Environ(),
+		Dir:      a.Shell.Dir,
+		Env:      a.env,
 		Output:   out,
+		Prefix:   filepath.Base(cmd),
 		Progress: progress,
+		Sandbox:  sandbox,
 		Verbose:  verbose,
 	}
 	if a.timeout > 0 {
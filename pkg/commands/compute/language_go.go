@@ -28,12 +28,18 @@ const GoSourceDirectory = "."
 const GoManifestName = "go.mod"
 
 // NewGo constructs a new Go toolchain.
-func NewGo(pkgName string, scripts manifest.Scripts, errlog fsterr.LogInterface, timeout int, cfg config.Go) *Go {
+func NewGo(pkgName string, scripts manifest.Scripts, args []string, errlog fsterr.LogInterface, timeout int, cfg config.Go) *Go {
+	compiler := cfg.Compiler
+	if compiler == "" {
+		compiler = config.GoCompilerTinyGo
+	}
 	return &Go{
-		Shell:     Shell{},
+		Shell:     Shell{Name: scripts.Shell},
+		args:      args,
 		build:     scripts.Build,
-		compiler:  "tinygo",
+		compiler:  compiler,
 		config:    cfg,
+		env:       scripts.EnvVars,
 		errlog:    errlog,
 		pkgName:   pkgName,
 		postBuild: scripts.PostBuild,
@@ -48,15 +54,25 @@ func NewGo(pkgName string, scripts manifest.Scripts, errlog fsterr.LogInterface,
 //
 // 1. Go: for defining required packages in a go.mod project module.
 // 2. TinyGo: used to compile the go project.
+//
+// Alternatively, when [language.go] compiler = "go" is set in the CLI config,
+// the standard go compiler builds the project for its wasip1 target.
 type Go struct {
 	Shell
 
+	// args are extra arguments for the compiler, set using [build.go] in
+	// fastly.toml or the --build-arg flag.
+	args []string
 	// build is a custom build script defined in fastly.toml using [scripts.build].
 	build string
-	// compiler is a WASM/WASI capable compiler (i.e. not the standard go compiler)
+	// compiler is a WASM/WASI capable compiler (tinygo, or go when it supports
+	// the wasip1 target).
 	compiler string
 	// config is Go configuration such as toolchain constraints.
 	config config.Go
+	// env are environment variables (KEY=value) set for the build, defined in
+	// fastly.toml using [scripts.env_vars] or the --env-var flag.
+	env []string
 	// errlog is an abstraction for recording errors to disk.
 	errlog fsterr.LogInterface
 	// pkgName is the name of the package (also used as the module name).
@@ -100,51 +116,21 @@ func (g Go) Initialize(out io.Writer) error {
 	}
 
 	// 2. Check go version is correct.
+	//
+	// NOTE: When go is also the compiler, it must support the wasip1 target.
 	{
-		// gosec flagged this:
-		// G204 (CWE-78): Subprocess launched with function call as argument or cmd arguments
-		// Disabling as we trust the source of the variable.
-		/* #nosec */
-		cmd := exec.Command(bin, "version") // e.g. go version go1.18 darwin/amd64
-		stdoutStderr, err := cmd.CombinedOutput()
-		output := string(stdoutStderr)
-		if err != nil {
-			if len(stdoutStderr) > 0 {
-				err = fmt.Errorf("%w: %s", err, strings.TrimSpace(output))
-			}
-			g.errlog.Add(err)
-			return err
+		constraint := g.config.ToolchainConstraint
+		if g.compiler == config.GoCompilerGo {
+			constraint = g.config.WasiP1Constraint
 		}
-
-		segs := strings.Split(output, " ")
-		if len(segs) < 3 {
-			return errors.New("unexpected go version output")
-		}
-		version := segs[2][2:]
-
-		v, err := semver.NewVersion(version)
-		if err != nil {
-			return fmt.Errorf("error parsing version output %s into a semver: %w", version, err)
-		}
-
-		c, err := semver.NewConstraint(g.config.ToolchainConstraint)
-		if err != nil {
-			return fmt.Errorf("error parsing toolchain constraint %s into a semver: %w", g.config.ToolchainConstraint, err)
-		}
-
-		if !c.Check(v) {
-			err := fsterr.RemediationError{
-				Inner:       fmt.Errorf("version %s didn't meet the constraint %s", version, g.config.ToolchainConstraint),
-				Remediation: remediation,
-			}
-			g.errlog.Add(err)
+		if err := g.checkGoVersion(bin, constraint, remediation); err != nil {
 			return err
 		}
 	}
 
 	// 3. Set package name.
 	{
-		m, err := filepath.Abs(GoManifestName)
+		m, err := filepath.Abs(g.path(GoManifestName))
 		if err != nil {
 			g.errlog.Add(err)
 			return fmt.Errorf("getting %s path: %w", JSManifestName, err)
@@ -161,7 +147,7 @@ func (g Go) Initialize(out io.Writer) error {
 			return err
 		}
 
-		if err := g.setPackageName(GoManifestName); err != nil {
+		if err := g.setPackageName(m); err != nil {
 			g.errlog.Add(err)
 			return fmt.Errorf("error updating %s manifest: %w", GoManifestName, err)
 		}
@@ -174,7 +160,9 @@ func (g Go) Initialize(out io.Writer) error {
 		fmt.Fprintf(out, "Installing package dependencies...\n")
 		cmd := fstexec.Streaming{
 			Command: "go",
+			Context: g.Shell.Context,
 			Args:    []string{"mod", "download"},
+			Dir:     g.Shell.Dir,
 			Env:     os.Environ(),
 			Output:  out,
 		}
@@ -185,6 +173,49 @@ func (g Go) Initialize(out io.Writer) error {
 // Verify implements the Toolchain interface and verifies whether the Go
 // language toolchain is correctly configured on the host.
 func (g *Go) Verify(out io.Writer) error {
+	switch g.compiler {
+	case config.GoCompilerGo:
+		return g.verifyGo(out)
+	case config.GoCompilerTinyGo:
+		return g.verifyTinyGo(out)
+	default:
+		err := fsterr.RemediationError{
+			Inner:       fmt.Errorf("unsupported go compiler: %s", g.compiler),
+			Remediation: fmt.Sprintf("Set [language.go] compiler in the CLI config to either %s or %s.", config.GoCompilerTinyGo, config.GoCompilerGo),
+		}
+		g.errlog.Add(err)
+		return err
+	}
+}
+
+// verifyGo verifies the standard go compiler is installed and supports the
+// wasip1 target.
+func (g *Go) verifyGo(out io.Writer) error {
+	// Remediation used in variation sections.
+	goURL := "https://go.dev/"
+	remediation := fmt.Sprintf("To fix this error, install a version of %s that supports the wasip1 target by visiting:\n\n\t$ %s\n\nAlternatively, build with TinyGo by setting [language.go] compiler = %q in the CLI config.", g.compiler, text.Bold(goURL), config.GoCompilerTinyGo)
+
+	// 1. Check go command is on $PATH.
+	fmt.Fprintf(out, "Checking if %s is installed...\n", g.compiler)
+
+	bin, err := exec.LookPath(g.compiler)
+	if err != nil {
+		g.errlog.Add(err)
+
+		return fsterr.RemediationError{
+			Inner:       fmt.Errorf("`%s` not found in $PATH", g.compiler),
+			Remediation: remediation,
+		}
+	}
+
+	fmt.Fprintf(out, "Found %s at %s\n", g.compiler, bin)
+
+	// 2. Check go version supports wasip1.
+	return g.checkGoVersion(bin, g.config.WasiP1Constraint, remediation)
+}
+
+// verifyTinyGo verifies the tinygo compiler is installed.
+func (g *Go) verifyTinyGo(out io.Writer) error {
 	// Remediation used in variation sections.
 	tinygoURL := "https://tinygo.org"
 	remediation := fmt.Sprintf("To fix this error, install %s by visiting:\n\n\t$ %s", g.compiler, text.Bold(tinygoURL))
@@ -218,6 +249,7 @@ func (g *Go) Verify(out io.Writer) error {
 		// Disabling as we trust the source of the variable.
 		/* #nosec */
 		cmd := exec.Command(bin, "version") // e.g. tinygo version 0.24.0 darwin/amd64 (using go version go1.18 and LLVM version 14.0.0)
+		cmd.Dir = g.Shell.Dir
 		stdoutStderr, err := cmd.CombinedOutput()
 		output := string(stdoutStderr)
 		if err != nil {
@@ -268,9 +300,22 @@ func (g *Go) Build(out io.Writer, progress text.Progress, verbose bool, callback
 		"-o=bin/main.wasm",
 		fmt.Sprintf("./%s", GoSourceDirectory),
 	}
+	var env []string
+	if g.compiler == config.GoCompilerGo {
+		args = []string{
+			"build",
+			"-o=bin/main.wasm",
+			fmt.Sprintf("./%s", GoSourceDirectory),
+		}
+		env = []string{"GOOS=wasip1", "GOARCH=wasm"}
+	}
+
+	// NOTE: The extra arguments are flags, and so must precede the package.
+	pkg := args[len(args)-1]
+	args = append(append(args[:len(args)-1], g.args...), pkg)
 
 	// A bin directory is required.
-	dir, err := os.Getwd()
+	dir, err := g.projectDir()
 	if err != nil {
 		g.errlog.Add(err)
 		return fmt.Errorf("getting current working directory: %w", err)
@@ -281,11 +326,14 @@ func (g *Go) Build(out io.Writer, progress text.Progress, verbose bool, callback
 		return fmt.Errorf("creating bin directory: %w", err)
 	}
 
+	var sandbox *fstexec.Sandbox
 	if g.build != "" {
 		cmd, args = g.Shell.Build(g.build)
+		env = nil
+		sandbox = g.Shell.Sandbox
 	}
 
-	err = g.execCommand(cmd, args, out, progress, verbose)
+	err = g.execCommand(cmd, args, env, sandbox, out, progress, verbose)
 	if err != nil {
 		return err
 	}
@@ -298,7 +346,7 @@ func (g *Go) Build(out io.Writer, progress text.Progress, verbose bool, callback
 	if g.postBuild != "" {
 		if err = callback(); err == nil {
 			cmd, args := g.Shell.Build(g.postBuild)
-			err := g.execCommand(cmd, args, out, progress, verbose)
+			err := g.execCommand(cmd, args, nil, g.Shell.Sandbox, out, progress, verbose)
 			if err != nil {
 				return err
 			}
@@ -308,13 +356,34 @@ func (g *Go) Build(out io.Writer, progress text.Progress, verbose bool, callback
 	return nil
 }
 
-func (g Go) execCommand(cmd string, args []string, out, progress io.Writer, verbose bool) error {
+// BuildNativeTest implements the NativeTestBuilder interface and compiles the
+// package's tests into a native binary using the standard go toolchain.
+//
+// NOTE: The go toolchain shares its module cache with TinyGo, so the
+// dependencies resolved for the Wasm build aren't downloaded again.
+func (g *Go) BuildNativeTest(out io.Writer, dst string, verbose bool) error {
+	args := []string{
+		"test",
+		"-c",
+		fmt.Sprintf("-o=%s", dst),
+		fmt.Sprintf("./%s", GoSourceDirectory),
+	}
+	return g.execCommand(g.toolchain, args, nil, nil, out, nil, verbose)
+}
+
+// execCommand runs the command with the given environment variables added to
+// the current environment, along with those set for the build.
+func (g Go) execCommand(cmd string, args, env []string, sandbox *fstexec.Sandbox, out, progress io.Writer, verbose bool) error {
 	s := fstexec.Streaming{
 		Command:  cmd,
+		Context:  g.Shell.Context,
 		Args:     args,
-		Env:      os.Environ(),
+		Dir:      g.Shell.Dir,
+		Env:      append(append([]string{}, g.env...), env...),
 		Output:   out,
+		Prefix:   filepath.Base(cmd),
 		Progress: progress,
+		Sandbox:  sandbox,
 		Verbose:  verbose,
 	}
 	if g.timeout > 0 {
@@ -327,6 +396,51 @@ func (g Go) execCommand(cmd string, args []string, out, progress io.Writer, verb
 	return nil
 }
 
+// checkGoVersion checks the version of the go toolchain meets the constraint.
+func (g Go) checkGoVersion(bin, constraint, remediation string) error {
+	// gosec flagged this:
+	// G204 (CWE-78): Subprocess launched with function call as argument or cmd arguments
+	// Disabling as we trust the source of the variable.
+	/* #nosec */
+	cmd := exec.Command(bin, "version") // e.g. go version go1.18 darwin/amd64
+	cmd.Dir = g.Shell.Dir
+	stdoutStderr, err := cmd.CombinedOutput()
+	output := string(stdoutStderr)
+	if err != nil {
+		if len(stdoutStderr) > 0 {
+			err = fmt.Errorf("%w: %s", err, strings.TrimSpace(output))
+		}
+		g.errlog.Add(err)
+		return err
+	}
+
+	segs := strings.Split(output, " ")
+	if len(segs) < 3 {
+		return errors.New("unexpected go version output")
+	}
+	version := segs[2][2:]
+
+	v, err := semver.NewVersion(version)
+	if err != nil {
+		return fmt.Errorf("error parsing version output %s into a semver: %w", version, err)
+	}
+
+	c, err := semver.NewConstraint(constraint)
+	if err != nil {
+		return fmt.Errorf("error parsing toolchain constraint %s into a semver: %w", constraint, err)
+	}
+
+	if !c.Check(v) {
+		err := fsterr.RemediationError{
+			Inner:       fmt.Errorf("version %s didn't meet the constraint %s", version, constraint),
+			Remediation: remediation,
+		}
+		g.errlog.Add(err)
+		return err
+	}
+	return nil
+}
+
 // setPackageName into go.mod manifest.
 //
 // NOTE: The implementation scans the go.mod line-by-line looking for the
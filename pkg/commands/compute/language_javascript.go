@@ -10,6 +10,8 @@ import (
 	"strings"
 	"time"
 
+	"github.com/Masterminds/semver/v3"
+	"github.com/fastly/cli/pkg/config"
 	fsterr "github.com/fastly/cli/pkg/errors"
 	fstexec "github.com/fastly/cli/pkg/exec"
 	"github.com/fastly/cli/pkg/filesystem"
@@ -23,7 +25,8 @@ const JSSourceDirectory = "src"
 // JSManifestName represents the language file for configuring dependencies.
 const JSManifestName = "package.json"
 
-// JsToolchain represents the default JS toolchain.
+// JsToolchain represents the default JS toolchain, used when no lockfile or
+// [scripts.package_manager] setting selects another package manager.
 const JsToolchain = "npm"
 
 // SetPackageName into package.json manifest.
@@ -71,69 +74,97 @@ func SetPackageName(name, path string) (err error) {
 type JavaScript struct {
 	Shell
 
+	args                []string
 	build               string
+	config              config.JavaScript
+	env                 []string
 	errlog              fsterr.LogInterface
 	packageDependency   string
 	packageExecutable   string
+	packageManager      string
 	pkgName             string
 	postBuild           string
 	timeout             int
-	toolchain           string
 	validateScriptBuild bool
 }
 
 // NewJavaScript constructs a new JavaScript toolchain.
-func NewJavaScript(pkgName string, scripts manifest.Scripts, errlog fsterr.LogInterface, timeout int) *JavaScript {
+func NewJavaScript(pkgName string, scripts manifest.Scripts, args []string, errlog fsterr.LogInterface, timeout int, cfg config.JavaScript) *JavaScript {
 	return &JavaScript{
-		Shell:               Shell{},
+		Shell:               Shell{Name: scripts.Shell},
+		args:                args,
 		build:               scripts.Build,
+		config:              cfg,
+		env:                 scripts.EnvVars,
 		errlog:              errlog,
 		packageDependency:   "@fastly/js-compute",
 		packageExecutable:   "js-compute-runtime",
+		packageManager:      scripts.PackageManager,
 		pkgName:             pkgName,
 		postBuild:           scripts.PostBuild,
 		timeout:             timeout,
-		toolchain:           JsToolchain,
 		validateScriptBuild: true,
 	}
 }
 
+// toolchain returns the package manager used to install the package
+// dependencies and run its scripts.
+func (j JavaScript) toolchain() (string, error) {
+	pwd, err := j.projectDir()
+	if err != nil {
+		j.errlog.Add(err)
+		return "", fmt.Errorf("getting current working directory: %w", err)
+	}
+	toolchain, err := JsPackageManager(j.packageManager, pwd)
+	if err != nil {
+		j.errlog.Add(err)
+		return "", err
+	}
+	return toolchain, nil
+}
+
 // Initialize implements the Toolchain interface and initializes a newly cloned
 // package by installing required dependencies.
 func (j JavaScript) Initialize(out io.Writer) error {
-	// 1) Check a.toolchain is on $PATH
+	toolchain, err := j.toolchain()
+	if err != nil {
+		return err
+	}
+
+	// 1) Check toolchain is on $PATH
 	//
-	// npm, a Node/JavaScript toolchain installer/manager, is needed to install
-	// the package dependencies on initialization. We only check whether the
-	// binary exists on the users $PATH and error with installation help text.
-	fmt.Fprintf(out, "Checking if %s is installed...\n", j.toolchain)
+	// npm (or pnpm/yarn), a Node/JavaScript toolchain installer/manager, is
+	// needed to install the package dependencies on initialization. We only
+	// check whether the binary exists on the users $PATH and error with
+	// installation help text.
+	fmt.Fprintf(out, "Checking if %s is installed...\n", toolchain)
 
-	p, err := exec.LookPath(j.toolchain)
+	p, err := exec.LookPath(toolchain)
 	if err != nil {
 		j.errlog.Add(err)
 		nodejsURL := "https://nodejs.org/"
-		remediation := fmt.Sprintf("To fix this error, install Node.js and %s by visiting:\n\n\t$ %s\n\nThen execute:\n\n\t$ fastly compute init", j.toolchain, text.Bold(nodejsURL))
+		remediation := fmt.Sprintf("To fix this error, install Node.js and %s by visiting:\n\n\t$ %s\n\nThen execute:\n\n\t$ fastly compute init", toolchain, text.Bold(nodejsURL))
 
 		return fsterr.RemediationError{
-			Inner:       fmt.Errorf("`%s` not found in $PATH", j.toolchain),
+			Inner:       fmt.Errorf("`%s` not found in $PATH", toolchain),
 			Remediation: remediation,
 		}
 	}
 
-	fmt.Fprintf(out, "Found %s at %s\n", j.toolchain, p)
+	fmt.Fprintf(out, "Found %s at %s\n", toolchain, p)
 
 	// 2) Check package.json file exists in $PWD
 	//
 	// A valid npm package manifest file is needed for the install command to
 	// work. Therefore, we first assert whether one exists in the current $PWD.
-	m, err := filepath.Abs(JSManifestName)
+	m, err := filepath.Abs(j.path(JSManifestName))
 	if err != nil {
 		j.errlog.Add(err)
 		return fmt.Errorf("getting %s path: %w", JSManifestName, err)
 	}
 
 	if !filesystem.FileExists(m) {
-		msg := fmt.Sprintf(fsterr.FormatTemplate, text.Bold("npm init"))
+		msg := fmt.Sprintf(fsterr.FormatTemplate, text.Bold(toolchain+" init"))
 		remediation := fmt.Sprintf("%s\n\nThen execute\n\n\t$ fastly compute init", msg)
 		err := fsterr.RemediationError{
 			Inner:       fmt.Errorf("%s not found", JSManifestName),
@@ -152,8 +183,10 @@ func (j JavaScript) Initialize(out io.Writer) error {
 	fmt.Fprintf(out, "Installing package dependencies...\n")
 
 	cmd := fstexec.Streaming{
-		Command: j.toolchain,
+		Command: toolchain,
+		Context: j.Shell.Context,
 		Args:    []string{"install"},
+		Dir:     j.Shell.Dir,
 		Env:     []string{},
 		Output:  out,
 	}
@@ -163,41 +196,55 @@ func (j JavaScript) Initialize(out io.Writer) error {
 // Verify implements the Toolchain interface and verifies whether the
 // JavaScript language toolchain is correctly configured on the host.
 func (j JavaScript) Verify(out io.Writer) error {
-	// 1) Check a.toolchain is on $PATH
-	//
-	// npm, a popular Node/JavaScript toolchain installer/manager, which is needed
-	// to assert that the correct versions of the js-compute-runtime compiler and
-	// @fastly/js-compute package are installed.  We only check whether the binary
-	// exists on the users $PATH and error with installation help text.
-	fmt.Fprintf(out, "Checking if %s is installed...\n", j.toolchain)
+	toolchain, err := j.toolchain()
+	if err != nil {
+		return err
+	}
 
-	p, err := exec.LookPath(j.toolchain)
+	// 1) Check toolchain is on $PATH
+	//
+	// npm (or pnpm/yarn), a popular Node/JavaScript toolchain installer/manager,
+	// which is needed to assert that the correct versions of the
+	// js-compute-runtime compiler and @fastly/js-compute package are installed.
+	// We only check whether the binary exists on the users $PATH and error with
+	// installation help text.
+	fmt.Fprintf(out, "Checking if %s is installed...\n", toolchain)
+
+	p, err := exec.LookPath(toolchain)
 	if err != nil {
 		j.errlog.Add(err)
 		nodejsURL := "https://nodejs.org/"
-		remediation := fmt.Sprintf("To fix this error, install Node.js and %s by visiting:\n\n\t$ %s", j.toolchain, text.Bold(nodejsURL))
+		remediation := fmt.Sprintf("To fix this error, install Node.js and %s by visiting:\n\n\t$ %s", toolchain, text.Bold(nodejsURL))
 
 		return fsterr.RemediationError{
-			Inner:       fmt.Errorf("`%s` not found in $PATH", j.toolchain),
+			Inner:       fmt.Errorf("`%s` not found in $PATH", toolchain),
 			Remediation: remediation,
 		}
 	}
 
-	fmt.Fprintf(out, "Found %s at %s\n", j.toolchain, p)
+	fmt.Fprintf(out, "Found %s at %s\n", toolchain, p)
 
-	// 2) Check package.json file exists in $PWD
+	// 2) Check the node version is correct.
+	//
+	// An unsupported version of Node.js otherwise fails deep within the build
+	// script (e.g. webpack) with an error that doesn't identify the cause.
+	if err := j.verifyNodeVersion(out); err != nil {
+		return err
+	}
+
+	// 3) Check package.json file exists in $PWD
 	//
 	// A valid package is needed for compilation and to assert whether the
 	// required dependencies are installed locally. Therefore, we first assert
 	// whether one exists in the current $PWD.
-	pkg, err := filepath.Abs(JSManifestName)
+	pkg, err := filepath.Abs(j.path(JSManifestName))
 	if err != nil {
 		j.errlog.Add(err)
 		return fmt.Errorf("getting %s path: %w", JSManifestName, err)
 	}
 
 	if !filesystem.FileExists(pkg) {
-		remediation := "npm init"
+		remediation := toolchain + " init"
 		err := fsterr.RemediationError{
 			Inner:       fmt.Errorf("%s not found", JSManifestName),
 			Remediation: fmt.Sprintf(fsterr.FormatTemplate, text.Bold(remediation)),
@@ -208,14 +255,14 @@ func (j JavaScript) Verify(out io.Writer) error {
 
 	fmt.Fprintf(out, "Found %s at %s\n", JSManifestName, pkg)
 
-	// 3) Check if `js-compute-runtime` is installed.
+	// 4) Check if `js-compute-runtime` is installed.
 	//
 	// js-compute-runtime is the JavaScript compiler. We first check if the
 	// required dependency exists in the package.json and then whether the
 	// js-compute-runtime binary exists in the toolchain bin directory.
 	fmt.Fprintf(out, "Checking if %s is installed...\n", j.packageDependency)
-	if !checkJsPackageDependencyExists(j.toolchain, j.packageDependency) {
-		remediation := fmt.Sprintf("npm install --save-dev %s", j.packageDependency)
+	if !checkJsPackageDependencyExists(toolchain, j.packageDependency, filepath.Dir(pkg)) {
+		remediation := jsInstallDevDependency(toolchain, j.packageDependency)
 		err := fsterr.RemediationError{
 			Inner:       fmt.Errorf("`%s` not installed", j.packageDependency),
 			Remediation: fmt.Sprintf(fsterr.FormatTemplate, text.Bold(remediation)),
@@ -224,12 +271,15 @@ func (j JavaScript) Verify(out io.Writer) error {
 		return err
 	}
 
-	p, err = getJsToolchainBinPath(j.toolchain)
+	p, err = getJsToolchainBinPath(toolchain, j.packageExecutable, filepath.Dir(pkg))
 	if err != nil {
 		j.errlog.Add(err)
 		remediation := "npm install --global npm@latest"
+		if toolchain != JsToolchain {
+			remediation = jsInstallDevDependency(toolchain, j.packageDependency)
+		}
 		return fsterr.RemediationError{
-			Inner:       fmt.Errorf("could not determine %s bin path", j.toolchain),
+			Inner:       fmt.Errorf("could not determine %s bin path", toolchain),
 			Remediation: fmt.Sprintf(fsterr.FormatTemplate, text.Bold(remediation)),
 		}
 	}
@@ -240,7 +290,7 @@ func (j JavaScript) Verify(out io.Writer) error {
 		return fmt.Errorf("getting %s path: %w", j.packageExecutable, err)
 	}
 	if !filesystem.FileExists(path) {
-		remediation := fmt.Sprintf("npm install --save-dev %s", j.packageDependency)
+		remediation := jsInstallDevDependency(toolchain, j.packageDependency)
 		err := fsterr.RemediationError{
 			Inner:       fmt.Errorf("`%s` binary not found in %s", j.packageExecutable, p),
 			Remediation: fmt.Sprintf(fsterr.FormatTemplate, text.Bold(remediation)),
@@ -252,17 +302,14 @@ func (j JavaScript) Verify(out io.Writer) error {
 	fmt.Fprintf(out, "Found %s at %s\n", j.packageExecutable, path)
 
 	if j.validateScriptBuild {
-		remediation := "npm run"
+		remediation := toolchain + " run"
 		pkgErr := fmt.Sprintf("%s requires a `script` field with a `build` step defined that calls the `%s` binary", JSManifestName, j.packageExecutable)
 		remediation = fmt.Sprintf("Check your %s has a `script` field with a `build` step defined:\n\n\t$ %s", JSManifestName, text.Bold(remediation))
 
-		// gosec flagged this:
-		// G204 (CWE-78): Subprocess launched with variable
-		// Disabling as the variables come from trusted sources:
-		// The CLI parser enforces supported values via EnumVar.
-		/* #nosec */
-		cmd := exec.Command(j.toolchain, "run")
-		stdoutStderr, err := cmd.CombinedOutput()
+		// NOTE: The scripts are read from the package.json rather than listed
+		// with `<toolchain> run`, as the output (and whether it prompts for a
+		// script to run) differs between package managers.
+		ok, err := hasJsBuildScript(pkg)
 		if err != nil {
 			j.errlog.Add(err)
 			return fsterr.RemediationError{
@@ -270,10 +317,9 @@ func (j JavaScript) Verify(out io.Writer) error {
 				Remediation: remediation,
 			}
 		}
-
-		if !strings.Contains(string(stdoutStderr), " build\n") {
+		if !ok {
 			err := fsterr.RemediationError{
-				Inner:       fmt.Errorf("%s:\n\n%s", pkgErr, stdoutStderr),
+				Inner:       fmt.Errorf("%s", pkgErr),
 				Remediation: remediation,
 			}
 			j.errlog.Add(err)
@@ -284,17 +330,103 @@ func (j JavaScript) Verify(out io.Writer) error {
 	return nil
 }
 
+// verifyNodeVersion checks the installed Node.js version meets the configured
+// constraint, if there is one.
+func (j JavaScript) verifyNodeVersion(out io.Writer) error {
+	if j.config.NodeConstraint == "" {
+		return nil
+	}
+
+	fmt.Fprintf(out, "Checking if node meets the constraint %s...\n", j.config.NodeConstraint)
+
+	cmd := exec.Command("node", "--version") // e.g. v18.12.1
+	cmd.Dir = j.Shell.Dir
+	stdoutStderr, err := cmd.CombinedOutput()
+	if err != nil {
+		if len(stdoutStderr) > 0 {
+			err = fmt.Errorf("%w: %s", err, strings.TrimSpace(string(stdoutStderr)))
+		}
+		j.errlog.Add(err)
+		return fsterr.RemediationError{
+			Inner:       fmt.Errorf("error checking node version: %w", err),
+			Remediation: nodeVersionRemediation(j.config.NodeConstraint),
+		}
+	}
+
+	if err := CheckNodeVersion(string(stdoutStderr), j.config.NodeConstraint); err != nil {
+		j.errlog.Add(err)
+		return err
+	}
+
+	fmt.Fprintf(out, "Found node %s\n", strings.TrimSpace(string(stdoutStderr)))
+	return nil
+}
+
+// CheckNodeVersion validates the output of `node --version` against the given
+// semver constraint.
+func CheckNodeVersion(output, constraint string) error {
+	version := strings.TrimPrefix(strings.TrimSpace(output), "v")
+
+	v, err := semver.NewVersion(version)
+	if err != nil {
+		return fmt.Errorf("error parsing version output %s into a semver: %w", version, err)
+	}
+
+	c, err := semver.NewConstraint(constraint)
+	if err != nil {
+		return fmt.Errorf("error parsing node constraint %s into a semver: %w", constraint, err)
+	}
+
+	if !c.Check(v) {
+		return fsterr.RemediationError{
+			Inner:       fmt.Errorf("node version %s didn't meet the constraint %s", version, constraint),
+			Remediation: nodeVersionRemediation(constraint),
+		}
+	}
+	return nil
+}
+
+// nodeVersionRemediation suggests installing a supported Node.js version with
+// a version manager.
+func nodeVersionRemediation(constraint string) string {
+	return fmt.Sprintf("To fix this error, install a version of Node.js that meets the constraint %s. A version manager such as nvm (%s) or volta (%s) can install and switch between versions:\n\n\t$ %s\n\t$ %s",
+		constraint,
+		text.Bold("https://github.com/nvm-sh/nvm"),
+		text.Bold("https://volta.sh/"),
+		text.Bold("nvm install --lts"),
+		text.Bold("volta install node@lts"),
+	)
+}
+
 // Build implements the Toolchain interface and attempts to compile the package
 // JavaScript source to a Wasm binary.
 func (j JavaScript) Build(out io.Writer, progress text.Progress, verbose bool, callback func() error) error {
-	cmd := j.toolchain
-	args := []string{"run", "build"}
-
+	var (
+		cmd     string
+		args    []string
+		sandbox *fstexec.Sandbox
+	)
 	if j.build != "" {
 		cmd, args = j.Shell.Build(j.build)
+		sandbox = j.Shell.Sandbox
+	} else {
+		toolchain, err := j.toolchain()
+		if err != nil {
+			return err
+		}
+		cmd, args = toolchain, []string{"run", "build"}
+
+		// NOTE: npm requires a -- separator to pass arguments to the script,
+		// while pnpm and yarn pass any arguments after the script name.
+		if len(j.args) > 0 {
+			if toolchain == JsToolchain {
+				args = append(args, "--")
+			}
+			args = append(args, j.args...)
+		}
 	}
 
-	err := j.execCommand(cmd, args, out, progress, verbose)
+	err := j.execCommand(cmd, args, sandbox, out, progress, verbose)
 	if err != nil {
 		return err
 	}
@@ -307,7 +439,7 @@ func (j JavaScript) Build(out io.Writer, progress text.Progress, verbose bool, c
 	if j.postBuild != "" {
 		if err = callback(); err == nil {
 			cmd, args := j.Shell.Build(j.postBuild)
-			err := j.execCommand(cmd, args, out, progress, verbose)
+			err := j.execCommand(cmd, args, j.Shell.Sandbox, out, progress, verbose)
 			if err != nil {
 				return err
 			}
@@ -317,13 +449,17 @@ func (j JavaScript) Build(out io.Writer, progress text.Progress, verbose bool, c
 	return nil
 }
 
-func (j JavaScript) execCommand(cmd string, args []string, out, progress io.Writer, verbose bool) error {
+func (j JavaScript) execCommand(cmd string, args []string, sandbox *fstexec.Sandbox, out, progress io.Writer, verbose bool) error {
 	s := fstexec.Streaming{
 		Command:  cmd,
+		Context:  j.Shell.Context,
 		Args:     args,
-		Env:      os.Environ(),
+		Dir:      j.Shell.Dir,
+		Env:      j.env,
 		Output:   out,
+		Prefix:   filepath.Base(cmd),
 		Progress: progress,
+		Sandbox:  sandbox,
 		Verbose:  verbose,
 	}
 	if j.timeout > 0 {
@@ -335,3 +471,12 @@ func (j JavaScript) execCommand(cmd string, args []string, out, progress io.Writ
 	}
 	return nil
 }
+
+// jsInstallDevDependency returns the command that installs name as a
+// development dependency with the given package manager.
+func jsInstallDevDependency(toolchain, name string) string {
+	if toolchain == JsToolchain {
+		return fmt.Sprintf("npm install --save-dev %s", name)
+	}
+	return fmt.Sprintf("%s add -D %s", toolchain, name)
+}
@@ -3,7 +3,7 @@ package compute
 import (
 	"fmt"
 	"io"
-	"os"
+	"path/filepath"
 	"time"
 
 	fsterr "github.com/fastly/cli/pkg/errors"
@@ -17,6 +17,7 @@ type Other struct {
 	Shell
 
 	build     string
+	env       []string
 	errlog    fsterr.LogInterface
 	postBuild string
 	timeout   int
@@ -25,8 +26,9 @@ type Other struct {
 // NewOther constructs a new unsupported language instance.
 func NewOther(scripts manifest.Scripts, errlog fsterr.LogInterface, timeout int) *Other {
 	return &Other{
-		Shell:     Shell{},
+		Shell:     Shell{Name: scripts.Shell},
 		build:     scripts.Build,
+		env:       scripts.EnvVars,
 		errlog:    errlog,
 		postBuild: scripts.PostBuild,
 		timeout:   timeout,
@@ -56,7 +58,7 @@ func (o Other) Build(out io.Writer, progress text.Progress, verbose bool, callba
 	}
 	cmd, args := o.Shell.Build(o.build)
 
-	err := o.execCommand(cmd, args, out, progress, verbose)
+	err := o.execCommand(cmd, args, o.Shell.Sandbox, out, progress, verbose)
 	if err != nil {
 		return err
 	}
@@ -69,7 +71,7 @@ func (o Other) Build(out io.Writer, progress text.Progress, verbose bool, callba
 	if o.postBuild != "" {
 		if err = callback(); err == nil {
 			cmd, args := o.Shell.Build(o.postBuild)
-			err := o.execCommand(cmd, args, out, progress, verbose)
+			err := o.execCommand(cmd, args, o.Shell.Sandbox, out, progress, verbose)
 			if err != nil {
 				return err
 			}
@@ -79,13 +81,17 @@ func (o Other) Build(out io.Writer, progress text.Progress, verbose bool, callba
 	return nil
 }
 
-func (o Other) execCommand(cmd string, args []string, out, progress io.Writer, verbose bool) error {
+func (o Other) execCommand(cmd string, args []string, sandbox *fstexec.Sandbox, out, progress io.Writer, verbose bool) error {
 	s := fstexec.Streaming{
 		Command:  cmd,
+		Context:  o.Shell.Context,
 		Args:     args,
-		Env:      os.Environ(),
+		Dir:      o.Shell.Dir,
+		Env:      o.env,
 		Output:   out,
+		Prefix:   filepath.Base(cmd),
 		Progress: progress,
+		Sandbox:  sandbox,
 		Verbose:  verbose,
 	}
 	if o.timeout > 0 {
@@ -3,6 +3,7 @@ package compute
 import (
 	"bufio"
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -33,6 +34,9 @@ const RustSourceDirectory = "src"
 // RustManifestName represents the language file for configuring dependencies.
 const RustManifestName = "Cargo.toml"
 
+// RustLockName represents the language file recording the resolved dependencies.
+const RustLockName = "Cargo.lock"
+
 // CargoPackage models the package configuration properties of a Rust Cargo
 // package which we are interested in and is embedded within CargoManifest and
 // CargoLock.
@@ -63,9 +67,9 @@ func (m *CargoManifest) Read(path string) error {
 	return err
 }
 
-// SetPackageName into Cargo.toml manifest.
+// SetPackageName into the Cargo.toml manifest at path.
 func (m *CargoManifest) SetPackageName(name, path string) error {
-	if err := m.Read("Cargo.toml"); err != nil {
+	if err := m.Read(path); err != nil {
 		return fmt.Errorf("error reading Cargo.toml manifest: %w", err)
 	}
 	// gosec flagged this:
@@ -100,9 +104,11 @@ type CargoMetadata struct {
 	TargetDirectory string                 `json:"target_directory"`
 }
 
-// Read the contents of the Cargo.lock file from filename.
-func (m *CargoMetadata) Read(errlog fsterr.LogInterface) error {
+// Read the metadata of the package in dir, or the current directory when dir
+// is empty.
+func (m *CargoMetadata) Read(dir string, errlog fsterr.LogInterface) error {
 	cmd := exec.Command("cargo", "metadata", "--quiet", "--format-version", "1")
+	cmd.Dir = dir
 	stdoutStderr, err := cmd.CombinedOutput()
 	if err != nil {
 		if len(stdoutStderr) > 0 {
@@ -123,9 +129,11 @@ func (m *CargoMetadata) Read(errlog fsterr.LogInterface) error {
 type Rust struct {
 	Shell
 
+	args      []string
 	build     string
 	client    api.HTTPClient
 	config    config.Rust
+	env       []string
 	errlog    fsterr.LogInterface
 	pkgName   string
 	postBuild string
@@ -133,12 +141,14 @@ type Rust struct {
 }
 
 // NewRust constructs a new Rust toolchain.
-func NewRust(pkgName string, scripts manifest.Scripts, errlog fsterr.LogInterface, client api.HTTPClient, timeout int, cfg config.Rust) *Rust {
+func NewRust(pkgName string, scripts manifest.Scripts, args []string, errlog fsterr.LogInterface, client api.HTTPClient, timeout int, cfg config.Rust) *Rust {
 	return &Rust{
-		Shell:     Shell{},
+		Shell:     Shell{Name: scripts.Shell},
+		args:      args,
 		build:     scripts.Build,
 		client:    client,
 		config:    cfg,
+		env:       scripts.EnvVars,
 		errlog:    errlog,
 		pkgName:   pkgName,
 		postBuild: scripts.PostBuild,
@@ -168,14 +178,14 @@ func (r *Rust) Verify(out io.Writer) (err error) {
 
 	fmt.Fprintf(out, "Checking the `rustc` version...\n")
 
-	err = validateCompilerVersion(r.config.ToolchainConstraint, r.errlog)
+	err = validateCompilerVersion(r.config.ToolchainConstraint, r.Shell.Dir, r.errlog)
 	if err != nil {
 		return err
 	}
 
 	fmt.Fprintf(out, "Checking the `wasm32-wasi` target is installed...\n")
 
-	err = validateWasmTarget(r.config.WasmWasiTarget, r.errlog)
+	err = validateWasmTarget(r.config.WasmWasiTarget, r.Shell.Dir, r.errlog)
 	if err != nil {
 		return err
 	}
@@ -195,7 +205,7 @@ func (r *Rust) Verify(out io.Writer) (err error) {
 	}
 
 	var metadata CargoMetadata
-	if err := metadata.Read(r.errlog); err != nil {
+	if err := metadata.Read(r.Shell.Dir, r.errlog); err != nil {
 		return fmt.Errorf("error reading cargo metadata: %w", err)
 	}
 
@@ -226,8 +236,8 @@ func validateCompilerExists(errlog fsterr.LogInterface) error {
 }
 
 // validateCompilerVersion checks the `rustc` version meets our constraint.
-func validateCompilerVersion(constraint string, errlog fsterr.LogInterface) error {
-	version, err := rustcVersion(errlog)
+func validateCompilerVersion(constraint, dir string, errlog fsterr.LogInterface) error {
+	version, err := rustcVersion(dir, errlog)
 	if err != nil {
 		return err
 	}
@@ -257,9 +267,10 @@ func validateCompilerVersion(constraint string, errlog fsterr.LogInterface) erro
 }
 
 // rustcVersion returns the active rustc compiler version.
-func rustcVersion(errlog fsterr.LogInterface) (string, error) {
+func rustcVersion(dir string, errlog fsterr.LogInterface) (string, error) {
 	cmd := []string{"rustc", "--version"}
 	c := exec.Command(cmd[0], cmd[1:]...) // #nosec G204
+	c.Dir = dir
 	stdoutStderr, err := c.CombinedOutput()
 	if err != nil {
 		errlog.Add(err)
@@ -311,20 +322,21 @@ func rustcVersion(errlog fsterr.LogInterface) (string, error) {
 // If the user has `rustup` installed then we use it to identify if the target
 // is installed, otherwise we fallback to a low-level check of the target
 // directory using `rustc --print sysroot`.
-func validateWasmTarget(target string, errlog fsterr.LogInterface) error {
+func validateWasmTarget(target, dir string, errlog fsterr.LogInterface) error {
 	_, err := exec.LookPath("rustup")
 	if err != nil {
 		errlog.Add(err)
-		return rustcSysroot(target, errlog)
+		return rustcSysroot(target, dir, errlog)
 	}
 
-	toolchain, err := rustupToolchain(errlog)
+	toolchain, err := rustupToolchain(dir, errlog)
 	if err != nil {
 		return err
 	}
 
 	cmd := []string{"rustup", "target", "list", "--installed", "--toolchain", toolchain}
 	c := exec.Command(cmd[0], cmd[1:]...) // #nosec G204
+	c.Dir = dir
 	stdoutStderr, err := c.CombinedOutput()
 	if err != nil {
 		errlog.Add(err)
@@ -354,9 +366,10 @@ func validateWasmTarget(target string, errlog fsterr.LogInterface) error {
 }
 
 // rustupToolchain returns the active rustup toolchain.
-func rustupToolchain(errlog fsterr.LogInterface) (string, error) {
+func rustupToolchain(dir string, errlog fsterr.LogInterface) (string, error) {
 	cmd := []string{"rustup", "show", "active-toolchain"}
 	c := exec.Command(cmd[0], cmd[1:]...) // #nosec G204
+	c.Dir = dir
 	stdoutStderr, err := c.CombinedOutput()
 	if err != nil {
 		errlog.Add(err)
@@ -387,9 +400,10 @@ func rustupToolchain(errlog fsterr.LogInterface) (string, error) {
 // low-level rustc compiler `--print sysroot` flag.
 //
 // This is called only when the user doesn't have `rustup` installed.
-func rustcSysroot(target string, errlog fsterr.LogInterface) error {
+func rustcSysroot(target, dir string, errlog fsterr.LogInterface) error {
 	cmd := []string{"rustc", "--print", "sysroot"}
 	c := exec.Command(cmd[0], cmd[1:]...) // #nosec G204
+	c.Dir = dir
 	stdoutStderr, err := c.CombinedOutput()
 	if err != nil {
 		errlog.Add(err)
@@ -488,7 +502,7 @@ func validateFastlyCrate(metadata CargoMetadata, v *semver.Version, out io.Write
 // package. It is a noop for Rust as the Cargo toolchain handles these steps.
 func (r Rust) Initialize(_ io.Writer) error {
 	var m CargoManifest
-	if err := m.SetPackageName(r.pkgName, RustManifestName); err != nil {
+	if err := m.SetPackageName(r.pkgName, r.path(RustManifestName)); err != nil {
 		r.errlog.Add(err)
 		return fmt.Errorf("error updating %s manifest: %w", RustManifestName, err)
 	}
@@ -504,7 +518,7 @@ func (r Rust) Initialize(_ io.Writer) error {
 func (r *Rust) Build(out io.Writer, progress text.Progress, verbose bool, callback func() error) error {
 	// Get binary name from Cargo.toml.
 	var m CargoManifest
-	if err := m.Read(RustManifestName); err != nil {
+	if err := m.Read(r.path(RustManifestName)); err != nil {
 		r.errlog.Add(err)
 		return fmt.Errorf("error reading %s manifest: %w", RustManifestName, err)
 	}
@@ -524,26 +538,29 @@ func (r *Rust) Build(out io.Writer, progress text.Progress, verbose bool, callba
 	if verbose {
 		args = append(args, "--verbose")
 	}
+	args = append(args, r.args...)
 
+	var sandbox *fstexec.Sandbox
 	if r.build != "" {
 		cmd, args = r.Shell.Build(r.build)
+		sandbox = r.Shell.Sandbox
 	}
 
 	// Execute the `cargo build` commands with the Wasm WASI target, release
 	// flags and env vars.
-	err := r.execCommand(cmd, args, out, progress, verbose)
+	err := r.execCommand(cmd, args, sandbox, out, progress, verbose)
 	if err != nil {
 		return err
 	}
 
 	// Get working directory.
-	dir, err := os.Getwd()
+	dir, err := r.projectDir()
 	if err != nil {
 		r.errlog.Add(err)
 		return fmt.Errorf("getting current working directory: %w", err)
 	}
 	var metadata CargoMetadata
-	if err := metadata.Read(r.errlog); err != nil {
+	if err := metadata.Read(r.Shell.Dir, r.errlog); err != nil {
 		r.errlog.Add(err)
 		return fmt.Errorf("error reading cargo metadata: %w", err)
 	}
@@ -571,7 +588,7 @@ func (r *Rust) Build(out io.Writer, progress text.Progress, verbose bool, callba
 	if r.postBuild != "" {
 		if err = callback(); err == nil {
 			cmd, args := r.Shell.Build(r.postBuild)
-			err := r.execCommand(cmd, args, out, progress, verbose)
+			err := r.execCommand(cmd, args, r.Shell.Sandbox, out, progress, verbose)
 			if err != nil {
 				return err
 			}
@@ -581,14 +598,229 @@ func (r *Rust) Build(out io.Writer, progress text.Progress, verbose bool, callba
 	return nil
 }
 
+// BuildNativeTest implements the NativeTestBuilder interface and compiles the
+// package's tests into a native binary for the host.
+//
+// NOTE: Both builds share the Cargo.lock and target directory, so the
+// dependencies are only resolved and downloaded once. Cargo serialises access
+// to the target directory, so whichever build starts second waits on its lock.
+func (r *Rust) BuildNativeTest(out io.Writer, dst string, verbose bool) error {
+	var m CargoManifest
+	if err := m.Read(r.path(RustManifestName)); err != nil {
+		r.errlog.Add(err)
+		return fmt.Errorf("error reading %s manifest: %w", RustManifestName, err)
+	}
+
+	args := []string{
+		"test",
+		"--no-run",
+		"--bin",
+		m.Package.Name,
+		"--release",
+		"--message-format",
+		"json-render-diagnostics",
+	}
+
+	ctx := r.Shell.Context
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if r.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, time.Duration(r.timeout)*time.Second)
+		defer cancel()
+	}
+
+	if verbose {
+		text.Description(out, "Process command", fmt.Sprintf("cargo %s", strings.Join(args, " ")))
+	}
+
+	// NOTE: cargo writes the JSON messages to stdout and everything a user
+	// would expect to see (progress, diagnostics) to stderr.
+	var stdout, stderr bytes.Buffer
+	// gosec flagged this:
+	// G204 (CWE-78): Subprocess launched with variable
+	// Disabling as the arguments are controlled by the CLI.
+	/* #nosec */
+	cmd := exec.CommandContext(ctx, "cargo", args...)
+	cmd.Dir = r.Shell.Dir
+	cmd.Stdout = &stdout
+	cmd.Stderr = io.MultiWriter(out, &stderr)
+	if err := cmd.Run(); err != nil {
+		r.errlog.Add(err)
+		return fmt.Errorf("error during execution process: %w\n\n%s", err, strings.TrimSpace(stderr.String()))
+	}
+
+	src, err := CargoTestExecutable(&stdout)
+	if err != nil {
+		r.errlog.Add(err)
+		return err
+	}
+	if err := filesystem.CopyFile(src, dst); err != nil {
+		r.errlog.Add(err)
+		return fmt.Errorf("copying native test binary: %w", err)
+	}
+	return nil
+}
+
+// CargoTestExecutable returns the path to the test binary reported in the JSON
+// messages written by `cargo test --no-run --message-format json`.
+func CargoTestExecutable(r io.Reader) (string, error) {
+	var exe string
+	dec := json.NewDecoder(r)
+	for {
+		var msg struct {
+			Executable string `json:"executable"`
+			Profile    struct {
+				Test bool `json:"test"`
+			} `json:"profile"`
+			Reason string `json:"reason"`
+		}
+		err := dec.Decode(&msg)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return "", fmt.Errorf("error parsing cargo output: %w", err)
+		}
+		if msg.Reason == "compiler-artifact" && msg.Profile.Test && msg.Executable != "" {
+			exe = msg.Executable
+		}
+	}
+	if exe == "" {
+		return "", errors.New("cargo didn't report a test executable")
+	}
+	return exe, nil
+}
+
+// Audit implements the Auditor interface and checks the dependencies in the
+// Cargo.lock for RustSec advisories using `cargo audit`.
+//
+// The check runs when either the --audit flag (force) is set or the config's
+// audit_policy is set. Advisories fail the build unless the policy is "warn".
+func (r *Rust) Audit(out io.Writer, force, verbose bool) error {
+	policy := r.config.AuditPolicy
+	if policy == "" {
+		if !force {
+			return nil
+		}
+		policy = config.AuditPolicyFail
+	}
+	if policy != config.AuditPolicyFail && policy != config.AuditPolicyWarn {
+		return fsterr.RemediationError{
+			Inner:       fmt.Errorf("invalid Rust audit_policy: %s", policy),
+			Remediation: fmt.Sprintf("Set the audit_policy in the [language.rust] section of the CLI config to %q or %q.", config.AuditPolicyWarn, config.AuditPolicyFail),
+		}
+	}
+
+	if _, err := exec.LookPath("cargo-audit"); err != nil {
+		r.errlog.Add(err)
+		return fsterr.RemediationError{
+			Inner:       fmt.Errorf("`cargo audit` not found"),
+			Remediation: fmt.Sprintf("To check the dependencies for advisories, install cargo-audit:\n\n\t$ %s", text.Bold("cargo install cargo-audit --locked")),
+		}
+	}
+
+	args := []string{"audit", "--json"}
+	if verbose {
+		text.Description(out, "Process command", fmt.Sprintf("cargo %s", strings.Join(args, " ")))
+	}
+
+	// NOTE: cargo audit exits with a non-zero status when it finds advisories,
+	// so the error is only reported if the JSON report can't be parsed.
+	var stdout, stderr bytes.Buffer
+	// gosec flagged this:
+	// G204 (CWE-78): Subprocess launched with variable
+	// Disabling as the arguments are controlled by the CLI.
+	/* #nosec */
+	cmd := exec.Command("cargo", args...)
+	cmd.Dir = r.Shell.Dir
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	runErr := cmd.Run()
+
+	advisories, err := ParseCargoAudit(&stdout)
+	if err != nil {
+		if runErr != nil {
+			err = fmt.Errorf("%w\n\n%s", runErr, strings.TrimSpace(stderr.String()))
+		}
+		r.errlog.Add(err)
+		return fmt.Errorf("error checking dependencies for advisories: %w", err)
+	}
+	if len(advisories) == 0 {
+		text.Info(out, "No advisories found for the dependencies in %s", RustLockName)
+		return nil
+	}
+
+	text.Break(out)
+	text.Output(out, "%s", text.Bold("Dependencies with advisories:"))
+	for _, a := range advisories {
+		text.Output(out, "%s %s %s: %s", a.ID, a.Package, a.Version, a.Title)
+	}
+	text.Break(out)
+
+	if policy == config.AuditPolicyWarn {
+		text.Warning(out, "Found %d advisories for the dependencies in %s.", len(advisories), RustLockName)
+		return nil
+	}
+	return fsterr.RemediationError{
+		Inner:       fmt.Errorf("found %d advisories for the dependencies in %s", len(advisories), RustLockName),
+		Remediation: fmt.Sprintf("Update the affected crates (e.g. `cargo update`), or to only warn about advisories set audit_policy = %q in the [language.rust] section of the CLI config.", config.AuditPolicyWarn),
+	}
+}
+
+// CargoAdvisory is a RustSec advisory affecting a dependency.
+type CargoAdvisory struct {
+	ID      string
+	Package string
+	Title   string
+	Version string
+}
+
+// ParseCargoAudit returns the advisories from a `cargo audit --json` report.
+func ParseCargoAudit(r io.Reader) ([]CargoAdvisory, error) {
+	var report struct {
+		Vulnerabilities struct {
+			List []struct {
+				Advisory struct {
+					ID    string `json:"id"`
+					Title string `json:"title"`
+				} `json:"advisory"`
+				Package struct {
+					Name    string `json:"name"`
+					Version string `json:"version"`
+				} `json:"package"`
+			} `json:"list"`
+		} `json:"vulnerabilities"`
+	}
+	if err := json.NewDecoder(r).Decode(&report); err != nil {
+		return nil, fmt.Errorf("error parsing cargo audit report: %w", err)
+	}
+
+	advisories := make([]CargoAdvisory, 0, len(report.Vulnerabilities.List))
+	for _, v := range report.Vulnerabilities.List {
+		advisories = append(advisories, CargoAdvisory{
+			ID:      v.Advisory.ID,
+			Package: v.Package.Name,
+			Title:   v.Advisory.Title,
+			Version: v.Package.Version,
+		})
+	}
+	return advisories, nil
+}
+
 // TODO: Consider generics to avoid re-implementing this same logic.
-func (r Rust) execCommand(cmd string, args []string, out, progress io.Writer, verbose bool) error {
+func (r Rust) execCommand(cmd string, args []string, sandbox *fstexec.Sandbox, out, progress io.Writer, verbose bool) error {
 	s := fstexec.Streaming{
 		Command:  cmd,
+		Context:  r.Shell.Context,
 		Args:     args,
-		Env:      os.Environ(),
+		Dir:      r.Shell.Dir,
+		Env:      r.env,
 		Output:   out,
+		Prefix:   filepath.Base(cmd),
 		Progress: progress,
+		Sandbox:  sandbox,
 		Verbose:  verbose,
 	}
 	if r.timeout > 0 {
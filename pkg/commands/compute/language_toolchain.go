@@ -1,29 +1,146 @@
 package compute
 
 import (
+	"encoding/json"
+	"fmt"
+	"os"
 	"os/exec"
+	"path/filepath"
 	"strings"
+
+	fsterr "github.com/fastly/cli/pkg/errors"
+	"github.com/fastly/cli/pkg/filesystem"
 )
 
-func getJsToolchainBinPath(bin string) (string, error) {
+// JsPackageManagers are the supported JavaScript package managers.
+var JsPackageManagers = []string{"npm", "pnpm", "yarn"}
+
+// jsLockfiles maps a lockfile to the package manager that created it.
+//
+// NOTE: The lockfiles are checked in order, and so when a project contains
+// more than one the first match wins.
+var jsLockfiles = []struct {
+	name    string
+	manager string
+}{
+	{"pnpm-lock.yaml", "pnpm"},
+	{"yarn.lock", "yarn"},
+	{"package-lock.json", "npm"},
+}
+
+// JsPackageManager returns the package manager used to install dependencies
+// and run scripts for the JavaScript package in dir.
+//
+// The [scripts.package_manager] manifest setting takes precedence, otherwise
+// the package manager is detected from the lockfile found in dir or its parent
+// directories (so a package within a pnpm or yarn workspace uses the tool that
+// manages the workspace), falling back to npm.
+func JsPackageManager(configured, dir string) (string, error) {
+	if configured != "" {
+		for _, m := range JsPackageManagers {
+			if m == configured {
+				return configured, nil
+			}
+		}
+		return "", fsterr.RemediationError{
+			Inner:       fmt.Errorf("unsupported package manager: %s", configured),
+			Remediation: fmt.Sprintf("Set [scripts.package_manager] in the fastly.toml manifest to one of: %s.", strings.Join(JsPackageManagers, ", ")),
+		}
+	}
+
+	for {
+		for _, l := range jsLockfiles {
+			if filesystem.FileExists(filepath.Join(dir, l.name)) {
+				return l.manager, nil
+			}
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return JsToolchain, nil
+		}
+		dir = parent
+	}
+}
+
+// getJsToolchainBinPath returns the directory containing the executable
+// installed by the package manager for the JavaScript package in dir.
+//
+// NOTE: Only npm reports a single bin directory. pnpm and yarn workspaces can
+// hoist an executable to the node_modules of any parent directory, and so the
+// nearest node_modules/.bin containing the executable is used.
+func getJsToolchainBinPath(bin, executable, dir string) (string, error) {
+	if bin != JsToolchain {
+		dir, ok := findNodeModule(filepath.Join(".bin", executable), dir)
+		if !ok {
+			return "", fmt.Errorf("%s not found in node_modules/.bin", executable)
+		}
+		return filepath.Dir(dir), nil
+	}
+
 	// gosec flagged this:
 	// G204 (CWE-78): Subprocess launched with variable
 	// Disabling as the variables come from trusted sources:
 	// The CLI parser enforces supported values via EnumVar.
 	/* #nosec */
-	path, err := exec.Command(bin, "bin").Output()
+	cmd := exec.Command(bin, "bin")
+	cmd.Dir = dir
+	path, err := cmd.Output()
 	if err != nil {
 		return "", err
 	}
 	return strings.TrimSpace(string(path)), nil
 }
 
-func checkJsPackageDependencyExists(bin, name string) bool {
+func checkJsPackageDependencyExists(bin, name, dir string) bool {
+	if bin != JsToolchain {
+		_, ok := findNodeModule(name, dir)
+		return ok
+	}
+
 	// gosec flagged this:
 	// G204 (CWE-78): Subprocess launched with variable
 	// Disabling as the variables come from trusted sources:
 	// The CLI parser enforces supported values via EnumVar.
 	/* #nosec */
-	err := exec.Command(bin, "list", "--json", "--depth", "0", name).Run()
-	return err == nil
+	cmd := exec.Command(bin, "list", "--json", "--depth", "0", name)
+	cmd.Dir = dir
+	return cmd.Run() == nil
+}
+
+// findNodeModule returns the path of name within the nearest node_modules
+// directory, searching from dir up through its parents.
+func findNodeModule(name, dir string) (string, bool) {
+	for {
+		path := filepath.Join(dir, "node_modules", name)
+		if _, err := os.Stat(path); err == nil {
+			return path, true
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", false
+		}
+		dir = parent
+	}
+}
+
+// hasJsBuildScript reports whether the package.json manifest at path defines
+// a `build` script.
+func hasJsBuildScript(path string) (bool, error) {
+	// gosec flagged this:
+	// G304 (CWE-22): Potential file inclusion via variable
+	//
+	// Disabling as we require a user to configure their own environment.
+	/* #nosec */
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return false, err
+	}
+	var pkg struct {
+		Scripts map[string]string `json:"scripts"`
+	}
+	if err := json.Unmarshal(data, &pkg); err != nil {
+		return false, err
+	}
+	_, ok := pkg.Scripts["build"]
+	return ok, nil
 }
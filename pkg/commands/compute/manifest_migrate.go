@@ -0,0 +1,78 @@
+package compute
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/fastly/cli/pkg/cmd"
+	"github.com/fastly/cli/pkg/config"
+	"github.com/fastly/cli/pkg/manifest"
+	"github.com/fastly/cli/pkg/text"
+)
+
+// NewManifestMigrateCommand returns a usable command registered under the parent.
+func NewManifestMigrateCommand(parent cmd.Registerer, globals *config.Data) *ManifestMigrateCommand {
+	var c ManifestMigrateCommand
+	c.Globals = globals
+	c.CmdClause = parent.Command("migrate", fmt.Sprintf("Upgrade the %s to the latest manifest_version (%d)", manifest.Filename, manifest.ManifestLatestVersion))
+	c.CmdClause.Flag("dry-run", "Display the changes without modifying the manifest").BoolVar(&c.dryRun)
+	return &c
+}
+
+// ManifestMigrateCommand upgrades the manifest file to the latest schema.
+type ManifestMigrateCommand struct {
+	cmd.Base
+
+	dryRun bool
+}
+
+// Exec implements the command interface.
+func (c *ManifestMigrateCommand) Exec(_ io.Reader, out io.Writer) error {
+	path := manifest.Filename
+
+	// gosec flagged this:
+	// G304 (CWE-22): Potential file inclusion via variable
+	// Disabling as the path is a static constant from the 'manifest' package.
+	/* #nosec */
+	data, err := os.ReadFile(path)
+	if err != nil {
+		c.Globals.ErrLog.Add(err)
+		return fmt.Errorf("error reading %s: %w", path, err)
+	}
+
+	migrated, version, err := manifest.Migrate(data)
+	if err != nil {
+		c.Globals.ErrLog.AddWithContext(err, map[string]any{
+			"Manifest Version": version,
+		})
+		return err
+	}
+
+	if bytes.Equal(data, migrated) {
+		text.Info(out, "The %s is already using manifest_version %d.", path, manifest.ManifestLatestVersion)
+		return nil
+	}
+
+	text.Diff(out, string(data), string(migrated))
+	text.Break(out)
+
+	if c.dryRun {
+		text.Info(out, "Dry run: the %s was not modified.", path)
+		return nil
+	}
+
+	backup := fmt.Sprintf("%s.v%d.bak", path, version)
+	if err := os.WriteFile(backup, data, manifest.FilePermissions); err != nil {
+		c.Globals.ErrLog.Add(err)
+		return fmt.Errorf("error backing up %s: %w", path, err)
+	}
+	if err := os.WriteFile(path, migrated, manifest.FilePermissions); err != nil {
+		c.Globals.ErrLog.Add(err)
+		return fmt.Errorf("error writing %s: %w", path, err)
+	}
+
+	text.Success(out, "Migrated the %s from manifest_version %d to %d (original saved to %s).", path, version, manifest.ManifestLatestVersion, backup)
+	return nil
+}
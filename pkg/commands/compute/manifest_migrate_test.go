@@ -0,0 +1,109 @@
+package compute_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/fastly/cli/pkg/app"
+	"github.com/fastly/cli/pkg/manifest"
+	"github.com/fastly/cli/pkg/testutil"
+)
+
+func TestManifestMigrate(t *testing.T) {
+	args := testutil.Args
+	v1 := `manifest_version = 1
+name = "test"
+
+[[setup.backends]]
+name = "origin"
+address = "127.0.0.1"
+prompt = "Origin server"
+`
+	for _, testcase := range []struct {
+		name         string
+		args         []string
+		manifest     string
+		wantError    string
+		wantOutput   []string
+		wantBackup   bool
+		wantModified bool
+	}{
+		{
+			name:     "success",
+			args:     args("compute manifest migrate"),
+			manifest: v1,
+			wantOutput: []string{
+				"- [[setup.backends]]",
+				"+     [setup.backends.origin]",
+				"+       description = \"Origin server\"",
+				"Migrated the fastly.toml from manifest_version 1 to 2 (original saved to fastly.toml.v1.bak).",
+			},
+			wantBackup:   true,
+			wantModified: true,
+		},
+		{
+			name:     "dry run",
+			args:     args("compute manifest migrate --dry-run"),
+			manifest: v1,
+			wantOutput: []string{
+				"+ manifest_version = 2",
+				"Dry run: the fastly.toml was not modified.",
+			},
+		},
+		{
+			name: "already latest",
+			args: args("compute manifest migrate"),
+			manifest: `manifest_version = 2
+name = "test"
+`,
+			wantOutput: []string{"The fastly.toml is already using manifest_version 2."},
+		},
+		{
+			name:      "unrecognised version",
+			args:      args("compute manifest migrate"),
+			manifest:  `manifest_version = 99`,
+			wantError: "unrecognised manifest_version",
+		},
+	} {
+		t.Run(testcase.name, func(t *testing.T) {
+			// We're going to chdir to a temp environment,
+			// so save the PWD to return to, afterwards.
+			pwd, err := os.Getwd()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			rootdir := testutil.NewEnv(testutil.EnvOpts{
+				T: t,
+				Write: []testutil.FileIO{
+					{Src: testcase.manifest, Dst: manifest.Filename},
+				},
+			})
+			defer os.RemoveAll(rootdir)
+
+			if err := os.Chdir(rootdir); err != nil {
+				t.Fatal(err)
+			}
+			defer os.Chdir(pwd)
+
+			var stdout bytes.Buffer
+			opts := testutil.NewRunOpts(testcase.args, &stdout)
+			err = app.Run(opts)
+			testutil.AssertErrorContains(t, err, testcase.wantError)
+			for _, s := range testcase.wantOutput {
+				testutil.AssertStringContains(t, stdout.String(), s)
+			}
+
+			_, err = os.Stat(filepath.Join(rootdir, manifest.Filename+".v1.bak"))
+			testutil.AssertBool(t, testcase.wantBackup, err == nil)
+
+			data, err := os.ReadFile(filepath.Join(rootdir, manifest.Filename))
+			if err != nil {
+				t.Fatal(err)
+			}
+			testutil.AssertBool(t, testcase.wantModified, string(data) != testcase.manifest)
+		})
+	}
+}
@@ -0,0 +1,29 @@
+package compute
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/fastly/cli/pkg/cmd"
+	"github.com/fastly/cli/pkg/config"
+	"github.com/fastly/cli/pkg/manifest"
+)
+
+// ManifestRootCommand is the parent command for the manifest subcommands.
+type ManifestRootCommand struct {
+	cmd.Base
+	// no flags
+}
+
+// NewManifestRootCommand returns a new command registered in the parent.
+func NewManifestRootCommand(parent cmd.Registerer, globals *config.Data) *ManifestRootCommand {
+	var c ManifestRootCommand
+	c.Globals = globals
+	c.CmdClause = parent.Command("manifest", fmt.Sprintf("Manage the %s package manifest", manifest.Filename))
+	return &c
+}
+
+// Exec implements the command interface.
+func (c *ManifestRootCommand) Exec(_ io.Reader, _ io.Writer) error {
+	panic("unreachable")
+}
@@ -0,0 +1,38 @@
+package compute
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/fastly/cli/pkg/cmd"
+	"github.com/fastly/cli/pkg/config"
+	"github.com/fastly/cli/pkg/manifest"
+)
+
+// NewManifestSchemaCommand returns a usable command registered under the parent.
+func NewManifestSchemaCommand(parent cmd.Registerer, globals *config.Data) *ManifestSchemaCommand {
+	var c ManifestSchemaCommand
+	c.Globals = globals
+	c.CmdClause = parent.Command("manifest-schema", fmt.Sprintf("Display the JSON Schema for the %s manifest file (e.g. for IDE autocomplete and validation)", manifest.Filename))
+	return &c
+}
+
+// ManifestSchemaCommand displays the JSON Schema of the manifest file.
+type ManifestSchemaCommand struct {
+	cmd.Base
+}
+
+// Exec implements the command interface.
+func (c *ManifestSchemaCommand) Exec(_ io.Reader, out io.Writer) error {
+	data, err := manifest.MarshalSchema()
+	if err != nil {
+		c.Globals.ErrLog.Add(err)
+		return fmt.Errorf("error generating manifest schema: %w", err)
+	}
+	_, err = fmt.Fprintln(out, string(data))
+	if err != nil {
+		c.Globals.ErrLog.Add(err)
+		return fmt.Errorf("error: unable to write data to stdout: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,30 @@
+package compute_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/fastly/cli/pkg/app"
+	"github.com/fastly/cli/pkg/manifest"
+	"github.com/fastly/cli/pkg/testutil"
+)
+
+func TestManifestSchema(t *testing.T) {
+	var stdout bytes.Buffer
+	opts := testutil.NewRunOpts(testutil.Args("compute manifest-schema"), &stdout)
+	err := app.Run(opts)
+	testutil.AssertNoError(t, err)
+
+	var s manifest.Schema
+	if err := json.Unmarshal(stdout.Bytes(), &s); err != nil {
+		t.Fatalf("output is not valid JSON: %v", err)
+	}
+	testutil.AssertString(t, manifest.SchemaURI, s.Schema)
+	testutil.AssertString(t, manifest.Filename, s.Title)
+	for _, k := range []string{"manifest_version", "name", "language", "scripts", "setup", "local_server"} {
+		if _, ok := s.Properties[k]; !ok {
+			t.Errorf("schema missing property %q", k)
+		}
+	}
+}
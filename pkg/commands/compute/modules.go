@@ -0,0 +1,104 @@
+package compute
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+
+	fsterr "github.com/fastly/cli/pkg/errors"
+	"github.com/fastly/cli/pkg/filesystem"
+	"github.com/fastly/cli/pkg/manifest"
+)
+
+// moduleNamePattern matches the valid names of additional wasm modules.
+var moduleNamePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]*$`)
+
+// wasmMagic is the preamble of every wasm binary.
+var wasmMagic = []byte("\x00asm")
+
+// moduleFilename returns the filename of the named module within a package.
+func moduleFilename(name string) string {
+	return name + ".wasm"
+}
+
+// moduleFilenames returns the filenames of the modules within a package.
+func moduleFilenames(modules manifest.Modules) []string {
+	names := modules.Names()
+	files := make([]string, 0, len(names))
+	for _, name := range names {
+		files = append(files, moduleFilename(name))
+	}
+	return files
+}
+
+// validateModule checks the named module has a valid name and that the built
+// binary is a wasm binary within the package size limit.
+func validateModule(dir, name string, m manifest.Module) error {
+	if !moduleNamePattern.MatchString(name) || name == "main" {
+		return fsterr.RemediationError{
+			Inner:       fmt.Errorf("invalid module name '%s'", name),
+			Remediation: "Module names must be lowercase alphanumeric (with '-' or '_') and can't be 'main'. Rename the module in the [modules] section of the fastly.toml.",
+		}
+	}
+	if m.Path == "" {
+		return fsterr.RemediationError{
+			Inner:       fmt.Errorf("module '%s' has no path", name),
+			Remediation: fmt.Sprintf("Set [modules.%s].path in the fastly.toml to the path of the built wasm module.", name),
+		}
+	}
+
+	path := filepath.Join(dir, m.Path)
+	fi, err := os.Stat(path)
+	if err != nil {
+		return fsterr.RemediationError{
+			Inner:       fmt.Errorf("error reading module '%s': %w", name, err),
+			Remediation: fmt.Sprintf("Ensure the build script writes the module to '%s'.", m.Path),
+		}
+	}
+	if fi.Size() > PackageSizeLimit {
+		return fsterr.RemediationError{
+			Inner:       fmt.Errorf("module '%s' is too large (%d bytes)", name, fi.Size()),
+			Remediation: fsterr.PackageSizeRemediation,
+		}
+	}
+
+	// gosec flagged this:
+	// G304 (CWE-22): Potential file inclusion via variable
+	// Disabling as the path is set in the user's fastly.toml.
+	/* #nosec */
+	f, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("error reading module '%s': %w", name, err)
+	}
+	defer f.Close() // #nosec G307
+
+	magic := make([]byte, len(wasmMagic))
+	if _, err := io.ReadFull(f, magic); err != nil || !bytes.Equal(magic, wasmMagic) {
+		return fmt.Errorf("error validating module '%s': %s is not a wasm binary", name, m.Path)
+	}
+	return nil
+}
+
+// stageModules validates each module and copies it into the bin directory, so
+// that it's packaged alongside main.wasm as bin/<name>.wasm. It returns the
+// staged paths relative to dir.
+func stageModules(dir string, modules manifest.Modules) ([]string, error) {
+	staged := make([]string, 0, len(modules))
+	for _, name := range modules.Names() {
+		m := modules[name]
+		if err := validateModule(dir, name, m); err != nil {
+			return nil, err
+		}
+		dst := filepath.Join("bin", moduleFilename(name))
+		if filepath.Clean(m.Path) != dst {
+			if err := filesystem.CopyFile(filepath.Join(dir, m.Path), filepath.Join(dir, dst)); err != nil {
+				return nil, fmt.Errorf("error copying module '%s': %w", name, err)
+			}
+		}
+		staged = append(staged, dst)
+	}
+	return staged, nil
+}
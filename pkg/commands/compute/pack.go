@@ -101,6 +101,16 @@ func (c *PackCommand) Exec(_ io.Reader, out io.Writer) (err error) {
 	}
 
 	progress.Step("Creating .tar.gz file...")
+	if c.Globals.Verbose() {
+		files, err := GetNonIgnoredFiles(fmt.Sprintf("pkg/%s", name), ".", nil)
+		if err != nil {
+			return err
+		}
+		text.Output(out, "Packaging %d files:", len(files))
+		for _, f := range files {
+			text.Indent(out, 4, "%s", f)
+		}
+	}
 	tar := archiver.NewTarGz()
 	tar.OverwriteExisting = true
 	{
@@ -61,6 +61,20 @@ func TestPack(t *testing.T) {
 				{"pkg", "another-name.tar.gz"},
 			},
 		},
+		// The following test validates that the packaged files are listed in
+		// verbose mode.
+		{
+			name: "success with verbose output",
+			args: args("compute pack --wasm-binary ./main.wasm --verbose"),
+			manifest: `
+			manifest_version = 2
+			name = "mypackagename"`,
+			wantOutput: []string{
+				"Packaging 2 files:",
+				filepath.Join("bin", "main.wasm"),
+				"fastly.toml",
+			},
+		},
 		// The following tests validate that a valid path flag value should be
 		// provided.
 		{
@@ -0,0 +1,323 @@
+package compute
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/fastly/cli/pkg/api"
+	"github.com/fastly/cli/pkg/commands/compute/setup"
+	fsterr "github.com/fastly/cli/pkg/errors"
+	"github.com/fastly/go-fastly/v6/fastly"
+	"github.com/itchyny/gojq"
+	toml "github.com/pelletier/go-toml"
+)
+
+// PolicyFilename is the name of the policy file that deploy evaluates when
+// --policy isn't set, if it exists in the project directory.
+const PolicyFilename = "fastly.policy.toml"
+
+// policyDesc describes the --policy flag.
+const policyDesc = "Path to a policy file whose rules the deploy plan must satisfy before the service is changed (default: " + PolicyFilename + " if it exists)"
+
+// Policy is a set of team-defined rules that a deploy plan is checked against,
+// read from a TOML file, e.g.
+//
+//	protected_services = ["SERVICE_ID"]
+//
+//	[[rule]]
+//	name = "no-internal-backends"
+//	message = "Protected services can't gain backends pointing at *.internal hosts"
+//	deny = '.service.protected and any(.backends[]; .new and (.address | endswith(".internal")))'
+//
+// Each deny expression is a jq expression evaluated against the deploy plan
+// (see policyPlan), with the environment available as $ENV. The rule is
+// violated when the expression outputs a value other than false or null. A
+// string output describes the violation, e.g.
+//
+//	deny = '.backends[] | select(.port != 443) | "backend \(.name) uses port \(.port)"'
+type Policy struct {
+	// Path is the file the policy was read from.
+	Path string `toml:"-"`
+	// ProtectedServices are the IDs of the services whose plan is marked as
+	// protected, e.g. production services.
+	ProtectedServices []string `toml:"protected_services"`
+	// Rules are the rules the plan must satisfy.
+	Rules []PolicyRule `toml:"rule"`
+}
+
+// PolicyRule is a rule of a Policy.
+type PolicyRule struct {
+	// Deny is a jq expression that identifies a violation of the rule.
+	Deny string `toml:"deny"`
+	// Message explains the rule when it's violated.
+	Message string `toml:"message"`
+	// Name identifies the rule.
+	Name string `toml:"name"`
+
+	code *gojq.Code
+}
+
+// LoadPolicy reads the policy file at path, compiling each of its rules.
+//
+// When path is empty the PolicyFilename in dir is read, if it exists,
+// otherwise a nil Policy is returned.
+func LoadPolicy(path, dir string) (*Policy, error) {
+	if path == "" {
+		path = filepath.Join(dir, PolicyFilename)
+		if _, err := os.Stat(path); errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+	}
+
+	data, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return nil, fmt.Errorf("error reading policy file: %w", err)
+	}
+
+	p := Policy{Path: path}
+	if err := toml.Unmarshal(data, &p); err != nil {
+		return nil, fsterr.RemediationError{
+			Inner:       fmt.Errorf("error parsing policy file '%s': %w", path, err),
+			Remediation: "Check the policy file is valid TOML, with a [[rule]] table per rule.",
+		}
+	}
+
+	seen := make(map[string]bool)
+	for i := range p.Rules {
+		r := &p.Rules[i]
+		if r.Name == "" || r.Deny == "" {
+			return nil, fsterr.RemediationError{
+				Inner:       fmt.Errorf("rule %d of policy file '%s' has no name or deny expression", i+1, path),
+				Remediation: "Set the name and deny fields of each [[rule]].",
+			}
+		}
+		if seen[r.Name] {
+			return nil, fmt.Errorf("policy file '%s' has more than one rule named '%s'", path, r.Name)
+		}
+		seen[r.Name] = true
+
+		query, err := gojq.Parse(r.Deny)
+		if err == nil {
+			r.code, err = gojq.Compile(query, gojq.WithEnvironLoader(os.Environ))
+		}
+		if err != nil {
+			return nil, fsterr.RemediationError{
+				Inner:       fmt.Errorf("invalid deny expression of policy rule '%s': %w", r.Name, err),
+				Remediation: "See https://stedolan.github.io/jq/manual/ for the expression syntax.",
+			}
+		}
+	}
+	return &p, nil
+}
+
+// policyViolation describes a rule the deploy plan violates.
+type policyViolation struct {
+	Rule   string
+	Detail string
+}
+
+// evaluate checks the plan against each of the policy's rules, returning the
+// violations.
+func (p *Policy) evaluate(plan policyPlan) ([]policyViolation, error) {
+	for _, id := range p.ProtectedServices {
+		if id == plan.Service.ID {
+			plan.Service.Protected = true
+		}
+	}
+
+	// NOTE: gojq only accepts the generic JSON types, so the plan is converted
+	// with a round trip through JSON.
+	var input any
+	data, err := json.Marshal(plan)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, &input); err != nil {
+		return nil, err
+	}
+
+	var violations []policyViolation
+	for _, r := range p.Rules {
+		var details []string
+		violated := false
+		iter := r.code.Run(input)
+		for {
+			v, ok := iter.Next()
+			if !ok {
+				break
+			}
+			switch v := v.(type) {
+			case error:
+				return nil, fmt.Errorf("error evaluating policy rule '%s': %w", r.Name, v)
+			case nil:
+			case bool:
+				violated = violated || v
+			case string:
+				violated = true
+				details = append(details, v)
+			default:
+				violated = true
+			}
+		}
+		if !violated {
+			continue
+		}
+		detail := r.Message
+		if len(details) > 0 {
+			detail = strings.TrimPrefix(r.Message+": "+strings.Join(details, "; "), ": ")
+		}
+		violations = append(violations, policyViolation{Rule: r.Name, Detail: detail})
+	}
+	return violations, nil
+}
+
+// policyError describes the violations as an error naming the violated rules.
+func policyError(path string, violations []policyViolation) error {
+	list := make([]string, 0, len(violations))
+	for _, v := range violations {
+		s := fmt.Sprintf("'%s'", v.Rule)
+		if v.Detail != "" {
+			s += fmt.Sprintf(" (%s)", v.Detail)
+		}
+		list = append(list, s)
+	}
+	inner := fmt.Errorf("the deploy violates policy rule %s", list[0])
+	if len(list) > 1 {
+		inner = fmt.Errorf("the deploy violates %d policy rules: %s", len(list), strings.Join(list, ", "))
+	}
+	return fsterr.RemediationError{
+		Inner:       inner,
+		Remediation: fmt.Sprintf("Change the deploy to satisfy the rules of the policy file '%s'. Nothing was deployed.", path),
+		Code:        fsterr.ExitValidation,
+	}
+}
+
+// policyPlan describes what a deploy is about to change, for a Policy to
+// evaluate. The domains and backends that already exist on the service version
+// are included, marked as not new.
+type policyPlan struct {
+	Backends     []policyBackend    `json:"backends"`
+	Dictionaries []policyDictionary `json:"dictionaries"`
+	Domains      []policyDomain     `json:"domains"`
+	Git          policyGit          `json:"git"`
+	Package      policyPackage      `json:"package"`
+	Service      policyService      `json:"service"`
+}
+
+type policyBackend struct {
+	Address string `json:"address"`
+	Name    string `json:"name"`
+	New     bool   `json:"new"`
+	Port    uint   `json:"port"`
+}
+
+type policyDictionary struct {
+	Name string `json:"name"`
+	New  bool   `json:"new"`
+}
+
+type policyDomain struct {
+	Name string `json:"name"`
+	New  bool   `json:"new"`
+}
+
+type policyGit struct {
+	Author string `json:"author"`
+	Branch string `json:"branch"`
+	Dirty  bool   `json:"dirty"`
+	SHA    string `json:"sha"`
+}
+
+type policyPackage struct {
+	Hash string `json:"hash"`
+	Name string `json:"name"`
+	Path string `json:"path"`
+	Size int64  `json:"size"`
+}
+
+type policyService struct {
+	ID        string `json:"id"`
+	New       bool   `json:"new"`
+	Protected bool   `json:"protected"`
+	Version   int    `json:"version"`
+}
+
+// policyPlanInput is the state of a deploy that a policyPlan is built from.
+type policyPlanInput struct {
+	apiClient    api.Interface
+	backends     *setup.Backends
+	dictionaries *setup.Dictionaries
+	domains      *setup.Domains
+	hashSum      string
+	newService   bool
+	pkgName      string
+	pkgPath      string
+	serviceID    string
+	version      int
+}
+
+// buildPolicyPlan describes the deploy for a Policy to evaluate.
+//
+// NOTE: The backends of an existing service aren't otherwise read by deploy,
+// so they're only listed when there's a policy to evaluate.
+func buildPolicyPlan(in policyPlanInput) (policyPlan, error) {
+	git := ReadCommentData(".", os.Getenv)
+	plan := policyPlan{
+		Backends:     []policyBackend{},
+		Dictionaries: []policyDictionary{},
+		Domains:      []policyDomain{},
+		Git: policyGit{
+			Author: git.GitAuthor,
+			Branch: git.GitBranch,
+			Dirty:  git.GitDirty,
+			SHA:    git.GitSHA,
+		},
+		Package: policyPackage{
+			Hash: in.hashSum,
+			Name: in.pkgName,
+			Path: in.pkgPath,
+		},
+		Service: policyService{
+			ID:      in.serviceID,
+			New:     in.newService,
+			Version: in.version,
+		},
+	}
+
+	if fi, err := os.Stat(in.pkgPath); err == nil {
+		plan.Package.Size = fi.Size()
+	}
+
+	for _, d := range in.domains.Available() {
+		plan.Domains = append(plan.Domains, policyDomain{Name: d.Name})
+	}
+	for _, d := range in.domains.Required() {
+		plan.Domains = append(plan.Domains, policyDomain{Name: d.Name, New: true})
+	}
+
+	if in.newService {
+		for _, b := range in.backends.Required() {
+			plan.Backends = append(plan.Backends, policyBackend{Address: b.Address, Name: b.Name, New: true, Port: b.Port})
+		}
+		for _, d := range in.dictionaries.Required() {
+			plan.Dictionaries = append(plan.Dictionaries, policyDictionary{Name: d.Name, New: true})
+		}
+		return plan, nil
+	}
+
+	backends, err := in.apiClient.ListBackends(&fastly.ListBackendsInput{
+		ServiceID:      in.serviceID,
+		ServiceVersion: in.version,
+	})
+	if err != nil {
+		return plan, fmt.Errorf("error listing service backends: %w", err)
+	}
+	for _, b := range backends {
+		plan.Backends = append(plan.Backends, policyBackend{Address: b.Address, Name: b.Name, Port: b.Port})
+	}
+	return plan, nil
+}
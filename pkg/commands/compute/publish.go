@@ -1,12 +1,24 @@
 package compute
 
 import (
+	"fmt"
 	"io"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
 
+	"github.com/bep/debounce"
+	"github.com/fastly/cli/pkg/api/undocumented"
 	"github.com/fastly/cli/pkg/cmd"
 	"github.com/fastly/cli/pkg/config"
+	fsterr "github.com/fastly/cli/pkg/errors"
 	"github.com/fastly/cli/pkg/manifest"
+	"github.com/fastly/cli/pkg/notify"
 	"github.com/fastly/cli/pkg/text"
+	"github.com/fatih/color"
+	"github.com/fsnotify/fsnotify"
+	"github.com/kennygrant/sanitize"
 )
 
 // PublishCommand produces and deploys an artifact from files on the local disk.
@@ -17,18 +29,44 @@ type PublishCommand struct {
 	deploy   *DeployCommand
 
 	// Build fields
+	audit            cmd.OptionalBool
+	buildArgs        cmd.OptionalStringSlice
+	buildLog         cmd.OptionalString
+	envVars          cmd.OptionalStringSlice
 	includeSrc       cmd.OptionalBool
 	lang             cmd.OptionalString
 	name             cmd.OptionalString
+	nativeTest       cmd.OptionalBool
+	sandbox          cmd.OptionalBool
 	skipVerification cmd.OptionalBool
 	timeout          cmd.OptionalInt
 
 	// Deploy fields
-	comment        cmd.OptionalString
-	domain         cmd.OptionalString
-	pkg            cmd.OptionalString
-	serviceName    cmd.OptionalServiceNameID
-	serviceVersion cmd.OptionalServiceVersion
+	comment         cmd.OptionalString
+	commentTemplate cmd.OptionalString
+	domain          cmd.OptionalString
+	gitRemote       cmd.OptionalString
+	gitTag          cmd.OptionalBool
+	lock            cmd.OptionalString
+	lockTTL         time.Duration
+	metadata        map[string]string
+	notify          cmd.OptionalBool
+	pkg             cmd.OptionalString
+	policy          cmd.OptionalString
+	remoteBuild     cmd.OptionalBool
+	requireCleanGit cmd.OptionalBool
+	serviceName     cmd.OptionalServiceNameID
+	serviceVersion  cmd.OptionalServiceVersion
+
+	// Static content fields
+	staticDir   string
+	staticStore string
+
+	// Watch fields
+	debounce time.Duration
+	// lastHashSum is the hash of the package last deployed in watch mode.
+	lastHashSum string
+	watch       bool
 }
 
 // NewPublishCommand returns a usable command registered under the parent.
@@ -40,12 +78,28 @@ func NewPublishCommand(parent cmd.Registerer, globals *config.Data, build *Build
 	c.deploy = deploy
 	c.CmdClause = parent.Command("publish", "Build and deploy a Compute@Edge package to a Fastly service")
 
+	c.CmdClause.Flag("audit", "Check the dependencies for security advisories before packaging (Rust only, requires cargo-audit)").Action(c.audit.Set).BoolVar(&c.audit.Value)
+	c.CmdClause.Flag("build-arg", "An argument appended to the built-in build command, which can be repeated (ignored when [scripts.build] is set)").Action(c.buildArgs.Set).StringsVar(&c.buildArgs.Value)
+	c.CmdClause.Flag("build-log", "Also emit build events (stages, durations, artifact sizes) as JSON lines (json)").Action(c.buildLog.Set).EnumVar(&c.buildLog.Value, BuildLogJSON)
 	c.CmdClause.Flag("comment", "Human-readable comment").Action(c.comment.Set).StringVar(&c.comment.Value)
+	c.CmdClause.Flag("comment-template", commentTemplateDesc).PlaceHolder("TEMPLATE").Action(c.commentTemplate.Set).StringVar(&c.commentTemplate.Value)
+	c.CmdClause.Flag("debounce", "How long to wait for further file changes before publishing when using --watch").Default("1s").DurationVar(&c.debounce)
 	c.CmdClause.Flag("domain", "The name of the domain associated to the package").Action(c.domain.Set).StringVar(&c.domain.Value)
+	c.CmdClause.Flag("env-var", "An environment variable (KEY=value) set for the build, in addition to [scripts.env_vars], which can be repeated").Action(c.envVars.Set).StringsVar(&c.envVars.Value)
+	c.CmdClause.Flag("git-remote", "The git remote that --git-tag pushes the tag to").Default("origin").Action(c.gitRemote.Set).StringVar(&c.gitRemote.Value)
+	c.CmdClause.Flag("git-tag", "Create an annotated git tag (fastly-deploy/<service>/<version>) of the deployed commit and push it to --git-remote").Action(c.gitTag.Set).BoolVar(&c.gitTag.Value)
 	c.CmdClause.Flag("include-source", "Include source code in built package").Action(c.includeSrc.Set).BoolVar(&c.includeSrc.Value)
 	c.CmdClause.Flag("language", "Language type").Action(c.lang.Set).StringVar(&c.lang.Value)
+	c.CmdClause.Flag("lock", "Name of a dictionary that holds an advisory lock during the deploy, so a concurrent deploy to the service fails rather than tramples this one").Action(c.lock.Set).StringVar(&c.lock.Value)
+	c.CmdClause.Flag("lock-ttl", "How long a --lock is held before it's presumed abandoned and replaced").Default(defaultDeployLockTTL.String()).DurationVar(&c.lockTTL)
+	c.CmdClause.Flag("metadata", "Metadata recorded in the version comment, e.g. --metadata git_sha=abc123 (can be repeated)").StringMapVar(&c.metadata)
 	c.CmdClause.Flag("name", "Package name").Action(c.name.Set).StringVar(&c.name.Value)
+	c.CmdClause.Flag("native-test", "Also compile a native test binary, in parallel with the Wasm binary (Go and Rust only)").Action(c.nativeTest.Set).BoolVar(&c.nativeTest.Value)
+	c.CmdClause.Flag("notify", notify.FlagDesc).Action(c.notify.Set).NegatableBoolVar(&c.notify.Value)
 	c.CmdClause.Flag("package", "Path to a package tar.gz").Short('p').Action(c.pkg.Set).StringVar(&c.pkg.Value)
+	c.CmdClause.Flag("policy", policyDesc).PlaceHolder("FILE").Action(c.policy.Set).StringVar(&c.policy.Value)
+	c.CmdClause.Flag("remote-build", "Upload the package source to the [remote_build] endpoint to be built, instead of building locally").Action(c.remoteBuild.Set).BoolVar(&c.remoteBuild.Value)
+	c.CmdClause.Flag("require-clean-git", "Fail, rather than warn, when the git working tree has uncommitted changes").Action(c.requireCleanGit.Set).BoolVar(&c.requireCleanGit.Value)
 	c.RegisterFlag(cmd.StringFlagOpts{
 		Name:        cmd.FlagServiceIDName,
 		Description: cmd.FlagServiceIDDesc,
@@ -64,8 +118,12 @@ func NewPublishCommand(parent cmd.Registerer, globals *config.Data, build *Build
 		Dst:         &c.serviceVersion.Value,
 		Action:      c.serviceVersion.Set,
 	})
+	c.CmdClause.Flag("sandbox", "Run custom build scripts in a sandbox, as configured in the [sandbox] config section (--no-sandbox to disable)").Action(c.sandbox.Set).NegatableBoolVar(&c.sandbox.Value)
 	c.CmdClause.Flag("skip-verification", "Skip verification steps and force build").Action(c.skipVerification.Set).BoolVar(&c.skipVerification.Value)
+	c.CmdClause.Flag("static-dir", "Upload the files of a directory (e.g. ./public) to a KV store linked to the service, skipping the files that haven't changed").PlaceHolder("DIR").StringVar(&c.staticDir)
+	c.CmdClause.Flag("static-store", "Name of the KV store for --static-dir, which is created if it doesn't exist (default: <package name>-static)").StringVar(&c.staticStore)
 	c.CmdClause.Flag("timeout", "Timeout, in seconds, for the build compilation step").Action(c.timeout.Set).IntVar(&c.timeout.Value)
+	c.CmdClause.Flag("watch", "Watch for file changes, then rebuild and deploy the project").BoolVar(&c.watch)
 
 	return &c
 }
@@ -78,6 +136,30 @@ func NewPublishCommand(parent cmd.Registerer, globals *config.Data, build *Build
 // non-deterministic ways. It's best to leave those nested commands to handle
 // the progress indicator.
 func (c *PublishCommand) Exec(in io.Reader, out io.Writer) (err error) {
+	if c.watch {
+		if c.pkg.WasSet {
+			return fsterr.ErrIncompatiblePublishFlags
+		}
+		return c.watchFiles(in, out)
+	}
+
+	err = c.Build(in, out)
+	if err != nil {
+		return err
+	}
+
+	text.Break(out)
+
+	return c.Deploy(in, out)
+}
+
+// Build constructs and executes the build logic.
+func (c *PublishCommand) Build(in io.Reader, out io.Writer) error {
+	// NOTE: A remote build happens as part of the deploy.
+	if c.remoteBuild.Value {
+		return nil
+	}
+
 	// Reset the fields on the BuildCommand based on PublishCommand values.
 	if c.includeSrc.WasSet {
 		c.build.Flags.IncludeSrc = c.includeSrc.Value
@@ -88,6 +170,24 @@ func (c *PublishCommand) Exec(in io.Reader, out io.Writer) (err error) {
 	if c.name.WasSet {
 		c.build.Flags.PackageName = c.name.Value
 	}
+	if c.audit.WasSet {
+		c.build.Flags.Audit = c.audit.Value
+	}
+	if c.buildArgs.WasSet {
+		c.build.Flags.BuildArgs = c.buildArgs.Value
+	}
+	if c.buildLog.WasSet {
+		c.build.Flags.BuildLog = c.buildLog.Value
+	}
+	if c.envVars.WasSet {
+		c.build.Flags.EnvVars = c.envVars.Value
+	}
+	if c.nativeTest.WasSet {
+		c.build.Flags.NativeTest = c.nativeTest.Value
+	}
+	if c.sandbox.WasSet {
+		c.build.Flags.Sandbox = c.sandbox
+	}
 	if c.skipVerification.WasSet {
 		c.build.Flags.SkipVerification = c.skipVerification.Value
 	}
@@ -96,14 +196,16 @@ func (c *PublishCommand) Exec(in io.Reader, out io.Writer) (err error) {
 	}
 	c.build.Manifest = c.manifest
 
-	err = c.build.Exec(in, out)
+	err := c.build.Exec(in, out)
 	if err != nil {
 		c.Globals.ErrLog.Add(err)
 		return err
 	}
+	return nil
+}
 
-	text.Break(out)
-
+// Deploy constructs and executes the deploy logic.
+func (c *PublishCommand) Deploy(in io.Reader, out io.Writer) error {
 	// Reset the fields on the DeployCommand based on PublishCommand values.
 	if c.name.WasSet {
 		c.manifest.Flag.Name = c.name.Value
@@ -120,16 +222,199 @@ func (c *PublishCommand) Exec(in io.Reader, out io.Writer) (err error) {
 	if c.domain.WasSet {
 		c.deploy.Domain = c.domain.Value
 	}
+	c.deploy.GitRemote = c.gitRemote.Value
+	if c.gitTag.WasSet {
+		c.deploy.GitTag = c.gitTag.Value
+	}
 	if c.comment.WasSet {
 		c.deploy.Comment = c.comment
 	}
+	if c.commentTemplate.WasSet {
+		c.deploy.CommentTemplate = c.commentTemplate.Value
+	}
+	if c.lock.WasSet {
+		c.deploy.Lock = c.lock.Value
+	}
+	c.deploy.LockTTL = c.lockTTL
+	if len(c.metadata) > 0 {
+		c.deploy.Metadata = c.metadata
+	}
+	if c.notify.WasSet {
+		c.deploy.Notify = c.notify
+	}
+	if c.policy.WasSet {
+		c.deploy.Policy = c.policy.Value
+	}
+	if c.remoteBuild.WasSet {
+		c.deploy.RemoteBuild = c.remoteBuild.Value
+	}
+	if c.requireCleanGit.WasSet {
+		c.deploy.RequireCleanGit = c.requireCleanGit.Value
+	}
 	c.deploy.Manifest = c.manifest
 
-	err = c.deploy.Exec(in, out)
+	c.deploy.staticStore = nil
+	if c.staticDir != "" {
+		store, err := c.syncStatic(out)
+		if err != nil {
+			c.Globals.ErrLog.AddWithContext(err, map[string]any{
+				"Static directory": c.staticDir,
+				"Static store":     c.staticStore,
+			})
+			return err
+		}
+		c.deploy.staticStore = store
+	}
+
+	err := c.deploy.Exec(in, out)
+
+	// NOTE: Deploying to a new service records its ID in the manifest, which
+	// subsequent deploys in watch mode must use rather than creating another
+	// service.
+	c.manifest.File = c.deploy.Manifest.File
+
 	if err != nil {
 		c.Globals.ErrLog.Add(err)
 		return err
 	}
-
 	return nil
 }
+
+// syncStatic uploads the --static-dir files to the --static-store, returning
+// the store so that deploy links it to the service version.
+func (c *PublishCommand) syncStatic(out io.Writer) (*undocumented.KVStore, error) {
+	token, s := c.Globals.Token()
+	if s == config.SourceUndefined {
+		return nil, fsterr.ErrNoToken
+	}
+	endpoint, _ := c.Globals.Endpoint()
+	client := undocumented.NewClient(endpoint, token, c.Globals.HTTPClient)
+
+	name := c.staticStore
+	if name == "" {
+		name = sanitize.BaseName(c.manifest.File.Name) + "-static"
+	}
+
+	r, err := syncStatic(client, name, c.staticDir, out, c.Globals.Verbose())
+	if err != nil {
+		return nil, err
+	}
+	text.Info(out, "Synced '%s' to KV store '%s' (%d uploaded, %d unchanged)", c.staticDir, r.Store.Name, r.Added+r.Updated, r.Unchanged)
+	return r.Store, nil
+}
+
+// watchFiles publishes the project, then publishes it again each time the
+// language source directory changes, until interrupted.
+func (c *PublishCommand) watchFiles(in io.Reader, out io.Writer) error {
+	srcDir := sourceDirectory(c.lang, c.manifest.File.Language, c.watch, out)
+
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		c.Globals.ErrLog.Add(err)
+		return fmt.Errorf("error creating file watcher: %w", err)
+	}
+	defer watcher.Close()
+
+	if err := watchSource(srcDir, watcher, c.Globals.Verbose(), out); err != nil {
+		c.Globals.ErrLog.Add(err)
+		return err
+	}
+
+	// NOTE: An interrupt is the normal way to stop, so the command handles it
+	// rather than the CLI cleaning up after an interrupted command.
+	c.Globals.Interrupt.Release()
+	sigs := make(chan os.Signal, 1)
+	signal.Notify(sigs, syscall.SIGINT, syscall.SIGTERM)
+	defer signal.Stop(sigs)
+
+	c.publishChange(in, out, "")
+
+	// NOTE: Changes that arrive while publishing are coalesced into a single
+	// follow-up publish.
+	changes := make(chan string, 1)
+	debounced := debounce.New(c.debounce)
+
+	text.Info(out, "Watching %s for changes.", watchPattern(srcDir))
+	text.Break(out)
+
+	for {
+		select {
+		case <-sigs:
+			text.Info(out, "Stopped watching for changes")
+			return nil
+		case event, ok := <-watcher.Events:
+			if !ok {
+				return nil
+			}
+			if isBuildOutput(event.Name) {
+				continue
+			}
+			debounced(func() {
+				select {
+				case changes <- event.Name:
+				default:
+				}
+			})
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return nil
+			}
+			text.Output(out, "error event while watching files: %v", err)
+		case file := <-changes:
+			c.publishChange(in, out, file)
+		}
+	}
+}
+
+// publishChange rebuilds the project and deploys the package if it differs
+// from the package last deployed, then displays a one line summary.
+//
+// NOTE: Errors are displayed rather than returned so the user can fix the
+// issue and have the project published once they save their changes.
+func (c *PublishCommand) publishChange(in io.Reader, out io.Writer, file string) {
+	if file != "" {
+		text.Info(out, "Publishing project (%s)", file)
+		text.Break(out)
+	}
+	start := time.Now()
+
+	if err := c.Build(in, out); err != nil {
+		fsterr.Deduce(err).Print(color.Error)
+		return
+	}
+	text.Break(out)
+
+	_, _, hashSum, err := validatePackage(c.manifest, c.Globals.Dir, "", c.Globals.ErrLog, out)
+	if err != nil {
+		fsterr.Deduce(err).Print(color.Error)
+		return
+	}
+	if hashSum == c.lastHashSum {
+		text.Info(out, "Package unchanged, skipped deploy (%s)", elapsed(start))
+		text.Break(out)
+		return
+	}
+
+	if err := c.Deploy(in, out); err != nil {
+		fsterr.Deduce(err).Print(color.Error)
+		return
+	}
+
+	r := c.deploy.result
+	switch {
+	case r.serviceID == "":
+		// The user declined to create a service.
+		return
+	case r.skipped:
+		text.Info(out, "Service %s version %d already has this package, skipped deploy (%s)", r.serviceID, r.version, elapsed(start))
+	default:
+		text.Success(out, "Published service %s version %d (%s)", r.serviceID, r.version, elapsed(start))
+	}
+	text.Break(out)
+	c.lastHashSum = hashSum
+}
+
+// elapsed returns the time since start rounded for display.
+func elapsed(start time.Time) time.Duration {
+	return time.Since(start).Round(100 * time.Millisecond)
+}
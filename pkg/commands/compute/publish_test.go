@@ -0,0 +1,285 @@
+package compute_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/fastly/cli/pkg/app"
+	"github.com/fastly/cli/pkg/commands/kvstore"
+	"github.com/fastly/cli/pkg/manifest"
+	"github.com/fastly/cli/pkg/mock"
+	"github.com/fastly/cli/pkg/testutil"
+	"github.com/fastly/go-fastly/v6/fastly"
+)
+
+func TestPublishWatchFlags(t *testing.T) {
+	var stdout bytes.Buffer
+	opts := testutil.NewRunOpts(testutil.Args("compute publish --watch --package pkg/package.tar.gz --token 123"), &stdout)
+	err := app.Run(opts)
+	testutil.AssertErrorContains(t, err, "--package shouldn't be used with --watch")
+	testutil.AssertRemediationErrorContains(t, err, "Remove one of the flags based on the outcome you require.")
+}
+
+// TestPublishWatch validates the project is published on start up, and again
+// whenever the source changes, unless the built package is unchanged.
+func TestPublishWatch(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("test relies on a POSIX build script and signals")
+	}
+
+	pwd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rootdir := testutil.NewEnv(testutil.EnvOpts{
+		T: t,
+		Write: []testutil.FileIO{
+			{Src: "a", Dst: filepath.Join("src", "main.wasm")},
+			{Src: "mock content", Dst: filepath.Join("bin", "testfile")},
+			{Src: `manifest_version = 2
+name = "test"
+language = "other"
+[scripts]
+build = "cp src/main.wasm bin/main.wasm"
+`, Dst: manifest.Filename},
+		},
+	})
+	defer os.RemoveAll(rootdir)
+
+	if err := os.Chdir(rootdir); err != nil {
+		t.Fatal(err)
+	}
+	defer os.Chdir(pwd)
+
+	var uploads int32
+	var stdout syncBuffer
+	opts := testutil.NewRunOpts(testutil.Args("compute publish --watch --debounce 100ms --auto-yes --token 123 --service-id 123 --version latest"), &stdout)
+	opts.APIClient = mock.APIClient(mock.API{
+		ActivateVersionFn:   activateVersionOk,
+		GetPackageFn:        getPackageOk,
+		GetServiceFn:        getServiceOK,
+		GetServiceDetailsFn: getServiceDetailsWasm,
+		ListDomainsFn:       listDomainsOk,
+		ListVersionsFn:      testutil.ListVersions,
+		UpdatePackageFn: func(i *fastly.UpdatePackageInput) (*fastly.Package, error) {
+			atomic.AddInt32(&uploads, 1)
+			return updatePackageOk(i)
+		},
+	})
+
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- app.Run(opts)
+	}()
+
+	// waitFor blocks until s has been output n times.
+	waitFor := func(s string, n int) {
+		t.Helper()
+		for i := 0; i < 200; i++ {
+			if strings.Count(stdout.String(), s) >= n {
+				return
+			}
+			time.Sleep(50 * time.Millisecond)
+		}
+		t.Fatalf("timed out waiting for %q, output:\n%s", s, stdout.String())
+	}
+	writeSource := func(content string) {
+		t.Helper()
+		if err := os.WriteFile(filepath.Join(rootdir, "src", "main.wasm"), []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	waitFor("Watching ./src/**/* for changes.", 1)
+	testutil.AssertStringContains(t, stdout.String(), "Published service 123 version 3")
+
+	writeSource("a")
+	waitFor("Package unchanged, skipped deploy", 1)
+
+	writeSource("b")
+	waitFor("Published service 123 version 3", 2)
+
+	if n := atomic.LoadInt32(&uploads); n != 2 {
+		t.Errorf("want 2 package uploads, got %d", n)
+	}
+
+	p, err := os.FindProcess(os.Getpid())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := p.Signal(os.Interrupt); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case err := <-errCh:
+		testutil.AssertNoError(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("watch didn't stop")
+	}
+	testutil.AssertStringContains(t, stdout.String(), "Stopped watching for changes")
+}
+
+// syncBuffer is a bytes.Buffer that's safe to read while being written to.
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+// TestPublishStatic validates the --static-dir files are uploaded to a KV store
+// linked to the service, and that unchanged files aren't uploaded again.
+func TestPublishStatic(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("test relies on a POSIX build script")
+	}
+
+	pwd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rootdir := testutil.NewEnv(testutil.EnvOpts{
+		T: t,
+		Write: []testutil.FileIO{
+			{Src: "a", Dst: filepath.Join("src", "main.wasm")},
+			{Src: "<h1>home</h1>", Dst: filepath.Join("public", "index.html")},
+			{Src: "body {}", Dst: filepath.Join("public", "css", "site.css")},
+			{Src: `manifest_version = 2
+name = "test"
+language = "other"
+[scripts]
+build = "mkdir -p bin && cp src/main.wasm bin/main.wasm"
+`, Dst: manifest.Filename},
+		},
+	})
+	defer os.RemoveAll(rootdir)
+
+	if err := os.Chdir(rootdir); err != nil {
+		t.Fatal(err)
+	}
+	defer os.Chdir(pwd)
+
+	var (
+		mu      sync.Mutex
+		stores  []map[string]string
+		keys    = make(map[string]string)
+		links   []string
+		uploads []string
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/resources/stores/kv":
+			_ = json.NewEncoder(w).Encode(map[string]any{"data": stores})
+		case r.Method == http.MethodPost && r.URL.Path == "/resources/stores/kv":
+			var body map[string]string
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			store := map[string]string{"id": "store-1", "name": body["name"]}
+			stores = append(stores, store)
+			_ = json.NewEncoder(w).Encode(store)
+		case strings.HasPrefix(r.URL.Path, "/resources/stores/kv/store-1/keys/"):
+			key := strings.TrimPrefix(r.URL.Path, "/resources/stores/kv/store-1/keys/")
+			switch r.Method {
+			case http.MethodGet:
+				v, ok := keys[key]
+				if !ok {
+					w.WriteHeader(http.StatusNotFound)
+					return
+				}
+				_, _ = w.Write([]byte(v))
+			case http.MethodPut:
+				b, _ := io.ReadAll(r.Body)
+				keys[key] = string(b)
+				if key != kvstore.ManifestKey {
+					uploads = append(uploads, key)
+				}
+			}
+		case r.URL.Path == "/service/123/version/3/resource":
+			if r.Method == http.MethodPost {
+				_ = r.ParseForm()
+				links = append(links, r.PostForm.Get("resource_id")+":"+r.PostForm.Get("name"))
+				_ = json.NewEncoder(w).Encode(map[string]string{"resource_id": r.PostForm.Get("resource_id")})
+				return
+			}
+			data := []map[string]string{}
+			for _, l := range links {
+				data = append(data, map[string]string{"resource_id": strings.SplitN(l, ":", 2)[0]})
+			}
+			_ = json.NewEncoder(w).Encode(data)
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	api := mock.API{
+		ActivateVersionFn:   activateVersionOk,
+		GetPackageFn:        getPackageOk,
+		GetServiceFn:        getServiceOK,
+		GetServiceDetailsFn: getServiceDetailsWasm,
+		ListDomainsFn:       listDomainsOk,
+		ListVersionsFn:      testutil.ListVersions,
+		UpdatePackageFn:     updatePackageOk,
+	}
+	publish := func() string {
+		t.Helper()
+		var stdout bytes.Buffer
+		opts := testutil.NewRunOpts(testutil.Args("compute publish --static-dir public --auto-yes --token 123 --service-id 123 --version latest --endpoint "+server.URL), &stdout)
+		opts.APIClient = mock.APIClient(api)
+		if err := app.Run(opts); err != nil {
+			t.Fatalf("unexpected error: %s\n\n%s", err, stdout.String())
+		}
+		return stdout.String()
+	}
+
+	out := publish()
+	testutil.AssertStringContains(t, out, "Created KV store 'test-static' (id: store-1)")
+	testutil.AssertStringContains(t, out, "Synced 'public' to KV store 'test-static' (2 uploaded, 0 unchanged)")
+	testutil.AssertStringContains(t, out, "Deployed package (service 123, version 3)")
+	testutil.AssertEqual(t, []string{"css/site.css", "index.html"}, sortedCopy(uploads))
+	testutil.AssertEqual(t, []string{"store-1:test-static"}, links)
+	testutil.AssertString(t, "<h1>home</h1>", keys["index.html"])
+
+	if err := os.WriteFile(filepath.Join(rootdir, "public", "index.html"), []byte("<h1>updated</h1>"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	uploads = nil
+
+	out = publish()
+	testutil.AssertStringContains(t, out, "Synced 'public' to KV store 'test-static' (1 uploaded, 1 unchanged)")
+	testutil.AssertEqual(t, []string{"index.html"}, uploads)
+	testutil.AssertEqual(t, []string{"store-1:test-static"}, links)
+	testutil.AssertString(t, "<h1>updated</h1>", keys["index.html"])
+}
+
+func sortedCopy(s []string) []string {
+	c := append([]string{}, s...)
+	sort.Strings(c)
+	return c
+}
@@ -0,0 +1,138 @@
+package compute
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/fastly/cli/pkg/api"
+	fsterr "github.com/fastly/cli/pkg/errors"
+	"github.com/fastly/cli/pkg/useragent"
+)
+
+// remoteBuildSkipDirs are the top-level directories left out of the source
+// uploaded for a remote build, as they contain build output rather than package
+// source (see also defaultIgnoreDirs).
+var remoteBuildSkipDirs = map[string]bool{
+	"bin": true,
+	"pkg": true,
+}
+
+// RemoteBuild uploads the source of the package in the current directory to
+// the remote build endpoint, then writes the built package it responds with to
+// the pkg directory, returning its path.
+//
+// The source is sent as the body of a POST request, in the same .tar.gz format
+// as a package, with the user's API token in the Fastly-Key header. Files
+// matched by the .fastlyignore file are left out.
+func RemoteBuild(endpoint, token, name string, client api.HTTPClient) (string, error) {
+	if endpoint == "" {
+		return "", fsterr.RemediationError{
+			Inner:       fmt.Errorf("no remote build endpoint configured"),
+			Remediation: "Set the endpoint in the [remote_build] section of the CLI config file (see `fastly config --location`).",
+		}
+	}
+
+	ignoreFiles, err := GetIgnoredFiles("", IgnoreFilePath)
+	if err != nil {
+		return "", err
+	}
+	files, err := remoteBuildFiles(ignoreFiles)
+	if err != nil {
+		return "", fmt.Errorf("error reading package source: %w", err)
+	}
+
+	tmpDir, err := os.MkdirTemp("", "fastly-remote-build")
+	if err != nil {
+		return "", fmt.Errorf("error creating temporary directory: %w", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	src := filepath.Join(tmpDir, fmt.Sprintf("%s.tar.gz", name))
+	if err := CreatePackageArchive("", files, src); err != nil {
+		return "", fmt.Errorf("error creating source archive: %w", err)
+	}
+
+	// gosec flagged this:
+	// G304 (CWE-22): Potential file inclusion via variable
+	//
+	// Disabling as the path is the archive created above.
+	/* #nosec */
+	f, err := os.Open(src)
+	if err != nil {
+		return "", fmt.Errorf("error reading source archive: %w", err)
+	}
+	defer f.Close() // #nosec G307
+
+	req, err := http.NewRequest(http.MethodPost, endpoint, f)
+	if err != nil {
+		return "", fmt.Errorf("error creating remote build request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/gzip")
+	req.Header.Set("Fastly-Key", token)
+	req.Header.Set("User-Agent", useragent.Name)
+
+	res, err := client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("error requesting remote build: %w", err)
+	}
+	defer res.Body.Close() // #nosec G307
+
+	if res.StatusCode < http.StatusOK || res.StatusCode >= http.StatusMultipleChoices {
+		// NOTE: The endpoint is expected to describe a failed build (e.g. the
+		// compiler errors) in the response body.
+		body, _ := io.ReadAll(io.LimitReader(res.Body, 4096))
+		return "", fsterr.RemediationError{
+			Inner:       fmt.Errorf("remote build failed: %s\n\n%s", res.Status, strings.TrimSpace(string(body))),
+			Remediation: "Fix the errors reported by the remote build, or build the package locally with `fastly compute build`.",
+		}
+	}
+
+	dest := filepath.Join("pkg", fmt.Sprintf("%s.tar.gz", name))
+	if err := os.MkdirAll("pkg", 0o750); err != nil {
+		return "", fmt.Errorf("error creating package directory: %w", err)
+	}
+	// gosec flagged this:
+	// G304 (CWE-22): Potential file inclusion via variable
+	//
+	// Disabling as the package name is sanitized by the caller.
+	/* #nosec */
+	pkg, err := os.Create(dest)
+	if err != nil {
+		return "", fmt.Errorf("error writing package: %w", err)
+	}
+	if _, err := io.Copy(pkg, res.Body); err != nil {
+		_ = pkg.Close()
+		return "", fmt.Errorf("error writing package: %w", err)
+	}
+	if err := pkg.Close(); err != nil {
+		return "", fmt.Errorf("error writing package: %w", err)
+	}
+	return dest, nil
+}
+
+// remoteBuildFiles returns the files in the current directory that make up
+// the package source.
+func remoteBuildFiles(ignoreFiles map[string]bool) ([]string, error) {
+	var files []string
+	err := filepath.Walk(".", func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			if path != "." && (remoteBuildSkipDirs[path] || defaultIgnoreDirs[info.Name()] || ignoreFiles[path]) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if ignoreFiles[path] {
+			return nil
+		}
+		files = append(files, path)
+		return nil
+	})
+	return files, err
+}
@@ -4,12 +4,10 @@ import (
 	"context"
 	"fmt"
 	"io"
-	"io/fs"
 	"log"
 	"os"
 	"os/exec"
 	"path/filepath"
-	"runtime"
 	"strings"
 	"syscall"
 	"time"
@@ -25,6 +23,7 @@ import (
 	"github.com/fastly/cli/pkg/filesystem"
 	"github.com/fastly/cli/pkg/manifest"
 	"github.com/fastly/cli/pkg/text"
+	"github.com/fastly/cli/pkg/tools"
 	"github.com/fatih/color"
 	"github.com/fsnotify/fsnotify"
 	ignore "github.com/sabhiram/go-gitignore"
@@ -39,14 +38,21 @@ type ServeCommand struct {
 	viceroyVersioner update.Versioner
 
 	// Build fields
+	audit            cmd.OptionalBool
+	buildArgs        cmd.OptionalStringSlice
+	buildLog         cmd.OptionalString
+	envVars          cmd.OptionalStringSlice
 	includeSrc       cmd.OptionalBool
 	lang             cmd.OptionalString
 	name             cmd.OptionalString
+	nativeTest       cmd.OptionalBool
+	sandbox          cmd.OptionalBool
 	skipVerification cmd.OptionalBool
 	timeout          cmd.OptionalInt
 
 	// Serve fields
 	addr      string
+	arch      string
 	debug     bool
 	env       cmd.OptionalString
 	file      string
@@ -66,13 +72,20 @@ func NewServeCommand(parent cmd.Registerer, globals *config.Data, build *BuildCo
 	c.manifest = data
 
 	c.CmdClause.Flag("addr", "The IPv4 address and port to listen on").Default("127.0.0.1:7676").StringVar(&c.addr)
+	c.CmdClause.Flag("arch", "The architecture of the Viceroy binary to download, instead of the detected one (amd64, arm64)").StringVar(&c.arch)
+	c.CmdClause.Flag("audit", "Check the dependencies for security advisories before packaging (Rust only, requires cargo-audit)").Action(c.audit.Set).BoolVar(&c.audit.Value)
+	c.CmdClause.Flag("build-arg", "An argument appended to the built-in build command, which can be repeated (ignored when [scripts.build] is set)").Action(c.buildArgs.Set).StringsVar(&c.buildArgs.Value)
+	c.CmdClause.Flag("build-log", "Also emit build events (stages, durations, artifact sizes) as JSON lines (json)").Action(c.buildLog.Set).EnumVar(&c.buildLog.Value, BuildLogJSON)
 	c.CmdClause.Flag("debug", "Run the server in Debug Adapter mode").Hidden().BoolVar(&c.debug)
 	c.CmdClause.Flag("env", "The environment configuration to use (e.g. stage)").Action(c.env.Set).StringVar(&c.env.Value)
 	c.CmdClause.Flag("file", "The Wasm file to run").Default("bin/main.wasm").StringVar(&c.file)
+	c.CmdClause.Flag("env-var", "An environment variable (KEY=value) set for the build and the local server, in addition to [scripts.env_vars], which can be repeated").Action(c.envVars.Set).StringsVar(&c.envVars.Value)
 	c.CmdClause.Flag("include-source", "Include source code in built package").Action(c.includeSrc.Set).BoolVar(&c.includeSrc.Value)
 	c.CmdClause.Flag("language", "Language type").Action(c.lang.Set).StringVar(&c.lang.Value)
 	c.CmdClause.Flag("name", "Package name").Action(c.name.Set).StringVar(&c.name.Value)
+	c.CmdClause.Flag("native-test", "Also compile a native test binary, in parallel with the Wasm binary (Go and Rust only)").Action(c.nativeTest.Set).BoolVar(&c.nativeTest.Value)
 	c.CmdClause.Flag("skip-build", "Skip the build step").BoolVar(&c.skipBuild)
+	c.CmdClause.Flag("sandbox", "Run custom build scripts in a sandbox, as configured in the [sandbox] config section (--no-sandbox to disable)").Action(c.sandbox.Set).NegatableBoolVar(&c.sandbox.Value)
 	c.CmdClause.Flag("skip-verification", "Skip verification steps and force build").Action(c.skipVerification.Set).BoolVar(&c.skipVerification.Value)
 	c.CmdClause.Flag("timeout", "Timeout, in seconds, for the build compilation step").Action(c.timeout.Set).IntVar(&c.timeout.Value)
 	c.CmdClause.Flag("watch", "Watch for file changes, then rebuild project and restart local server").BoolVar(&c.watch)
@@ -85,6 +98,13 @@ func (c *ServeCommand) Exec(in io.Reader, out io.Writer) (err error) {
 	if c.skipBuild && c.watch {
 		return fsterr.ErrIncompatibleServeFlags
 	}
+	if err := validateEnvVars(c.envVars.Value); err != nil {
+		return err
+	}
+	platform, err := update.DetectPlatform(c.arch)
+	if err != nil {
+		return err
+	}
 
 	if !c.skipBuild {
 		err = c.Build(in, out)
@@ -95,7 +115,7 @@ func (c *ServeCommand) Exec(in io.Reader, out io.Writer) (err error) {
 
 	progress := text.ResetProgress(out, c.Globals.Verbose())
 
-	bin, err := GetViceroy(progress, out, c.viceroyVersioner, c.Globals)
+	bin, err := c.viceroy(progress, out, platform)
 	if err != nil {
 		return err
 	}
@@ -105,8 +125,14 @@ func (c *ServeCommand) Exec(in io.Reader, out io.Writer) (err error) {
 
 	srcDir := sourceDirectory(c.lang, c.manifest.File.Language, c.watch, out)
 
+	envVars := append(append([]string{}, c.manifest.File.Scripts.EnvVars...), c.envVars.Value...)
+
+	// NOTE: An interrupt is the normal way to stop the local server, which
+	// handles it itself (see local).
+	c.Globals.Interrupt.Release()
+
 	for {
-		err = local(bin, srcDir, c.file, c.addr, c.env.Value, c.debug, c.watch, c.Globals.Verbose(), out, c.Globals.ErrLog)
+		err = local(bin, srcDir, c.file, c.addr, c.env.Value, envVars, c.debug, c.watch, c.Globals.Verbose(), out, c.Globals.ErrLog)
 		if err != nil {
 			if err != fsterr.ErrViceroyRestart {
 				if err == fsterr.ErrSignalInterrupt || err == fsterr.ErrSignalKilled {
@@ -140,6 +166,24 @@ func (c *ServeCommand) Build(in io.Reader, out io.Writer) error {
 	if c.name.WasSet {
 		c.build.Flags.PackageName = c.name.Value
 	}
+	if c.audit.WasSet {
+		c.build.Flags.Audit = c.audit.Value
+	}
+	if c.buildArgs.WasSet {
+		c.build.Flags.BuildArgs = c.buildArgs.Value
+	}
+	if c.buildLog.WasSet {
+		c.build.Flags.BuildLog = c.buildLog.Value
+	}
+	if c.envVars.WasSet {
+		c.build.Flags.EnvVars = c.envVars.Value
+	}
+	if c.nativeTest.WasSet {
+		c.build.Flags.NativeTest = c.nativeTest.Value
+	}
+	if c.sandbox.WasSet {
+		c.build.Flags.Sandbox = c.sandbox
+	}
 	if c.skipVerification.WasSet {
 		c.build.Flags.SkipVerification = c.skipVerification.Value
 	}
@@ -157,6 +201,31 @@ func (c *ServeCommand) Build(in io.Reader, out io.Writer) error {
 	return nil
 }
 
+// viceroy returns the path to the Viceroy binary, which is the version pinned
+// by the project (see `fastly tools pin`), otherwise the latest version.
+func (c *ServeCommand) viceroy(progress text.Progress, out io.Writer, platform update.Platform) (string, error) {
+	pin, ok := c.manifest.File.Tools[tools.NameViceroy]
+	if !ok {
+		return GetViceroy(progress, out, c.viceroyVersioner, platform, c.Globals)
+	}
+
+	v, err := semver.Parse(pin.Version)
+	if err != nil {
+		return "", fmt.Errorf("error parsing the pinned Viceroy version '%s': %w", pin.Version, err)
+	}
+
+	progress.Step(fmt.Sprintf("Installing pinned Viceroy %s...", v))
+
+	m := tools.Manager{Dir: filepath.Join(InstallDir, tools.DirName), Platform: platform}
+	bin, _, err := m.Install(c.Globals.Context(), tools.Viceroy(c.viceroyVersioner), v, pin.Checksums[platform.String()])
+	if err != nil {
+		c.Globals.ErrLog.Add(err)
+		progress.Fail()
+		return bin, err
+	}
+	return bin, nil
+}
+
 // GetViceroy returns the path to the installed binary.
 //
 // NOTE: if Viceroy is installed then it is updated, otherwise download the
@@ -165,7 +234,9 @@ func (c *ServeCommand) Build(in io.Reader, out io.Writer) error {
 //
 // In the case of a network failure we fallback to the latest installed version of the
 // Viceroy binary as long as one is installed and has the correct permissions.
-func GetViceroy(progress text.Progress, out io.Writer, versioner update.Versioner, cfg *config.Data) (bin string, err error) {
+//
+// The release asset downloaded is the one built for the given platform.
+func GetViceroy(progress text.Progress, out io.Writer, versioner update.Versioner, platform update.Platform, cfg *config.Data) (bin string, err error) {
 	defer func() {
 		if err != nil {
 			progress.Fail()
@@ -244,19 +315,15 @@ func GetViceroy(progress text.Progress, out io.Writer, versioner update.Versione
 		checkUpdate = true
 	}
 
-	archiveFormat := ".tar.gz"
-	asset := fmt.Sprintf(update.DefaultAssetFormat, versioner.BinaryName(), latest, runtime.GOOS, runtime.GOARCH, archiveFormat)
-	versioner.SetAsset(asset)
-
 	if install {
-		err := installViceroy(progress, versioner, latest, bin)
+		err := installViceroy(progress, versioner, platform, latest, bin)
 		if err != nil {
 			cfg.ErrLog.Add(err)
 			return bin, err
 		}
 	} else if checkUpdate {
 		version := strings.TrimSpace(string(stdoutStderr))
-		err := updateViceroy(progress, version, out, versioner, latest, bin)
+		err := updateViceroy(progress, version, out, versioner, platform, latest, bin)
 		if err != nil {
 			cfg.ErrLog.Add(err)
 			return bin, err
@@ -288,29 +355,19 @@ var InstallDir = func() string {
 }()
 
 // installViceroy downloads the latest release from GitHub.
-func installViceroy(progress text.Progress, versioner update.Versioner, latest semver.Version, bin string) error {
+func installViceroy(progress text.Progress, versioner update.Versioner, platform update.Platform, latest semver.Version, bin string) error {
 	progress.Step("Fetching latest Viceroy release...")
 
-	tmp, err := versioner.Download(context.Background(), latest)
-	if err != nil {
+	if _, err := tools.Download(context.Background(), tools.Viceroy(versioner), platform, latest, "", bin); err != nil {
 		progress.Fail()
-		return fmt.Errorf("error downloading latest Viceroy release: %w", err)
-	}
-	defer os.RemoveAll(tmp)
-
-	if err := os.Rename(tmp, bin); err != nil {
-		if err := filesystem.CopyFile(tmp, bin); err != nil {
-			progress.Fail()
-			return fmt.Errorf("error moving latest Viceroy binary in place: %w", err)
-		}
+		return err
 	}
-
 	return nil
 }
 
 // updateViceroy checks if the currently installed version is out-of-date and
 // downloads the latest release from GitHub.
-func updateViceroy(progress text.Progress, version string, out io.Writer, versioner update.Versioner, latest semver.Version, bin string) error {
+func updateViceroy(progress text.Progress, version string, out io.Writer, versioner update.Versioner, platform update.Platform, latest semver.Version, bin string) error {
 	progress.Step("Checking installed Viceroy version...")
 
 	var installedViceroyVersion string
@@ -352,21 +409,11 @@ func updateViceroy(progress text.Progress, version string, out io.Writer, versio
 		text.Output(out, "Latest Viceroy version: %s", latest)
 		text.Break(out)
 
-		tmp, err := versioner.Download(context.Background(), latest)
 		progress.Step("Fetching latest Viceroy release...")
-		if err != nil {
-			progress.Fail()
-			return fmt.Errorf("error downloading latest Viceroy release: %w", err)
-		}
-		defer os.RemoveAll(tmp)
-
-		progress.Step("Replacing Viceroy binary...")
 
-		if err := os.Rename(tmp, bin); err != nil {
-			if err := filesystem.CopyFile(tmp, bin); err != nil {
-				progress.Fail()
-				return fmt.Errorf("error moving latest Viceroy binary in place: %w", err)
-			}
+		if _, err := tools.Download(context.Background(), tools.Viceroy(versioner), platform, latest, "", bin); err != nil {
+			progress.Fail()
+			return err
 		}
 	}
 
@@ -413,7 +460,7 @@ func sourceDirectory(flag cmd.OptionalString, lang string, watch bool, out io.Wr
 }
 
 // local spawns a subprocess that runs the compiled binary.
-func local(bin, srcDir, file, addr, env string, debug, watch, verbose bool, out io.Writer, errLog fsterr.LogInterface) error {
+func local(bin, srcDir, file, addr, env string, envVars []string, debug, watch, verbose bool, out io.Writer, errLog fsterr.LogInterface) error {
 	if env != "" {
 		env = "." + env
 	}
@@ -439,7 +486,7 @@ func local(bin, srcDir, file, addr, env string, debug, watch, verbose bool, out
 	s := &fstexec.Streaming{
 		Args:     args,
 		Command:  bin,
-		Env:      os.Environ(),
+		Env:      envVars,
 		Output:   out,
 		SignalCh: make(chan os.Signal, 1),
 	}
@@ -496,8 +543,6 @@ func local(bin, srcDir, file, addr, env string, debug, watch, verbose bool, out
 // watchFiles watches the language source directory and restarts the viceroy
 // executable when changes are detected.
 func watchFiles(verbose bool, dir string, s *fstexec.Streaming, out io.Writer, restart chan<- bool) {
-	gi := gitIgnore()
-
 	watcher, err := fsnotify.NewWatcher()
 	if err != nil {
 		log.Fatal(err)
@@ -576,36 +621,11 @@ func watchFiles(verbose bool, dir string, s *fstexec.Streaming, out io.Writer, r
 		}
 	}()
 
-	filepath.WalkDir(dir, func(path string, entry fs.DirEntry, err error) error {
-		if err != nil {
-			log.Fatal(fmt.Errorf("error walking directory tree '%s': %w", dir, err))
-		}
-		// If there's no ignore file, we'll default to watching all directories
-		// within the specified top-level directory.
-		//
-		// NOTE: Watching a directory implies watching all files within the root of
-		// the directory. This means we don't need to call Add(path) for each file.
-		if gi == nil && entry.IsDir() {
-			watchFile(path, watcher, verbose, out)
-		}
-		if gi != nil && !entry.IsDir() && !gi.MatchesPath(path) {
-			// If there is an ignore file, we avoid watching directories and instead
-			// will only add files that don't match the exclusion patterns defined.
-			watchFile(path, watcher, verbose, out)
-		}
-		return nil
-	})
-
-	// NOTE: A language might use the root directory rather than a subdirectory
-	// like the ./src directory (which most currently use).
-	if dir == "." {
-		dir = ""
-	}
-	if dir != "" {
-		dir = dir + "/"
+	if err := watchSource(dir, watcher, verbose, out); err != nil {
+		log.Fatal(err)
 	}
 
-	text.Info(out, "Watching ./%s**/* for changes.", dir)
+	text.Info(out, "Watching %s for changes.", watchPattern(dir))
 	text.Break(out)
 	<-done
 }
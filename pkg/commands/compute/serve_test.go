@@ -8,6 +8,7 @@ import (
 	"testing"
 
 	"github.com/fastly/cli/pkg/commands/compute"
+	"github.com/fastly/cli/pkg/commands/update"
 	"github.com/fastly/cli/pkg/config"
 	fsterr "github.com/fastly/cli/pkg/errors"
 	"github.com/fastly/cli/pkg/mock"
@@ -91,7 +92,12 @@ func TestGetViceroy(t *testing.T) {
 		ErrLog: fsterr.MockLog{},
 	}
 
-	_, err = compute.GetViceroy(progress, &out, versioner, &data)
+	platform, err := update.DetectPlatform("")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = compute.GetViceroy(progress, &out, versioner, platform, &data)
 	if err != nil {
 		t.Fatal(err)
 	}
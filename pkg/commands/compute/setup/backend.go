@@ -14,6 +14,16 @@ import (
 	"github.com/fastly/go-fastly/v6/fastly"
 )
 
+// The names of the backend prompts for use with the --answer and
+// --answers-file flags. The placeholder is the name of a backend defined in the
+// [setup.backends] configuration, otherwise the number of the backend being
+// added.
+const (
+	answerBackendAddress = "setup.backends.%s.address"
+	answerBackendName    = "setup.backends.%s.name"
+	answerBackendPort    = "setup.backends.%s.port"
+)
+
 // Backends represents the service state related to backends defined within the
 // fastly.toml [setup] configuration.
 //
@@ -22,13 +32,14 @@ type Backends struct {
 	// Public
 	APIClient      api.Interface
 	AcceptDefaults bool
+	Answers        text.Answers
 	NonInteractive bool
-	Progress       text.Progress
 	ServiceID      string
 	ServiceVersion int
 	Setup          map[string]*manifest.SetupBackend
 	Stdin          io.Reader
 	Stdout         io.Writer
+	Tasks          *text.Tasks
 
 	// Private
 	required []Backend
@@ -53,37 +64,42 @@ func (b *Backends) Configure() error {
 	return b.promptForBackend()
 }
 
-// Create calls the relevant API to create the service resource(s).
+// Create starts a task, with the Tasks, that calls the relevant API to create
+// each service resource. The error of a failed task is returned by Tasks.Wait.
 func (b *Backends) Create() error {
-	if b.Progress == nil {
+	if b.Tasks == nil {
 		return errors.RemediationError{
-			Inner:       fmt.Errorf("internal logic error: no text.Progress configured for setup.Backends"),
+			Inner:       fmt.Errorf("internal logic error: no text.Tasks configured for setup.Backends"),
 			Remediation: errors.BugRemediation,
 		}
 	}
 
 	for _, bk := range b.required {
-		if !b.isOriginless() {
-			b.Progress.Step(fmt.Sprintf("Creating backend '%s' (host: %s, port: %d)...", bk.Name, bk.Address, bk.Port))
+		bk := bk
+		title := fmt.Sprintf("Creating backend '%s' (host: %s, port: %d)...", bk.Name, bk.Address, bk.Port)
+		if b.isOriginless() {
+			title = "Configuring the service..."
 		}
 
-		_, err := b.APIClient.CreateBackend(&fastly.CreateBackendInput{
-			ServiceID:       b.ServiceID,
-			ServiceVersion:  b.ServiceVersion,
-			Name:            bk.Name,
-			Address:         bk.Address,
-			Port:            fastly.Uint(bk.Port),
-			OverrideHost:    bk.OverrideHost,
-			SSLCertHostname: bk.SSLCertHostname,
-			SSLSNIHostname:  bk.SSLSNIHostname,
-		})
-		if err != nil {
-			b.Progress.Fail()
-			if b.isOriginless() {
-				return fmt.Errorf("error configuring the service: %w", err)
+		b.Tasks.Go(title, func(_ io.Writer) error {
+			_, err := b.APIClient.CreateBackend(&fastly.CreateBackendInput{
+				ServiceID:       b.ServiceID,
+				ServiceVersion:  b.ServiceVersion,
+				Name:            bk.Name,
+				Address:         bk.Address,
+				Port:            fastly.Uint(bk.Port),
+				OverrideHost:    bk.OverrideHost,
+				SSLCertHostname: bk.SSLCertHostname,
+				SSLSNIHostname:  bk.SSLSNIHostname,
+			})
+			if err != nil {
+				if b.isOriginless() {
+					return fmt.Errorf("error configuring the service: %w", err)
+				}
+				return fmt.Errorf("error creating backend: %w", err)
 			}
-			return fmt.Errorf("error creating backend: %w", err)
-		}
+			return nil
+		})
 	}
 
 	return nil
@@ -95,6 +111,11 @@ func (b *Backends) Predefined() bool {
 	return len(b.Setup) > 0
 }
 
+// Required returns the backends that Create will create (see Configure).
+func (b *Backends) Required() []Backend {
+	return b.required
+}
+
 // isOriginless indicates if the required backend is originless.
 func (b *Backends) isOriginless() bool {
 	return len(b.required) == 1 && b.required[0].Name == "originless" && b.required[0].Address == "127.0.0.1"
@@ -129,8 +150,9 @@ func (b *Backends) checkPredefined() error {
 
 		prompt := text.BoldYellow(fmt.Sprintf("Hostname or IP address: [%s] ", defaultAddress))
 
-		if !b.AcceptDefaults && !b.NonInteractive {
-			addr, err = text.Input(b.Stdout, prompt, b.Stdin, b.validateAddress)
+		addrAnswer := fmt.Sprintf(answerBackendAddress, name)
+		if b.Answers.Has(addrAnswer) || (!b.AcceptDefaults && !b.NonInteractive) {
+			addr, err = b.Answers.Input(addrAnswer, b.Stdout, prompt, b.Stdin, b.validateAddress)
 			if err != nil {
 				return fmt.Errorf("error reading prompt input: %w", err)
 			}
@@ -143,8 +165,9 @@ func (b *Backends) checkPredefined() error {
 		if settings.Port > 0 {
 			port = settings.Port
 		}
-		if !b.AcceptDefaults && !b.NonInteractive {
-			input, err := text.Input(b.Stdout, text.BoldYellow(fmt.Sprintf("Port: [%d] ", port)), b.Stdin)
+		portAnswer := fmt.Sprintf(answerBackendPort, name)
+		if b.Answers.Has(portAnswer) || (!b.AcceptDefaults && !b.NonInteractive) {
+			input, err := b.Answers.Input(portAnswer, b.Stdout, text.BoldYellow(fmt.Sprintf("Port: [%d] ", port)), b.Stdin)
 			if err != nil {
 				return fmt.Errorf("error reading prompt input: %w", err)
 			}
@@ -173,8 +196,13 @@ func (b *Backends) checkPredefined() error {
 
 // promptForBackend issues a prompt requesting one or more Backends that will
 // be created within the user's service.
+//
+// NOTE: When the prompts have been answered ahead of time, the backends are
+// numbered from one (e.g. setup.backends.1.address) and the first number
+// without an address answer stops the loop.
 func (b *Backends) promptForBackend() error {
-	if b.AcceptDefaults || b.NonInteractive {
+	answered := b.Answers.HasPrefix("setup.backends.")
+	if !answered && (b.AcceptDefaults || b.NonInteractive) {
 		b.required = append(b.required, b.createOriginlessBackend())
 		return nil
 	}
@@ -186,9 +214,16 @@ func (b *Backends) promptForBackend() error {
 		}
 		i++
 
-		addr, err := text.Input(b.Stdout, text.BoldYellow("Backend (hostname or IP address, or leave blank to stop adding backends): "), b.Stdin, b.validateAddress)
-		if err != nil {
-			return fmt.Errorf("error reading prompt input %w", err)
+		var (
+			addr string
+			err  error
+		)
+		n := strconv.Itoa(i)
+		if !answered || b.Answers.Has(fmt.Sprintf(answerBackendAddress, n)) {
+			addr, err = b.Answers.Input(fmt.Sprintf(answerBackendAddress, n), b.Stdout, text.BoldYellow("Backend (hostname or IP address, or leave blank to stop adding backends): "), b.Stdin, b.validateAddress)
+			if err != nil {
+				return fmt.Errorf("error reading prompt input %w", err)
+			}
 		}
 
 		// This block short-circuits the endless prompt loop
@@ -200,9 +235,12 @@ func (b *Backends) promptForBackend() error {
 		}
 
 		port := uint(80)
-		input, err := text.Input(b.Stdout, text.BoldYellow(fmt.Sprintf("Backend port number: [%d] ", port)), b.Stdin)
-		if err != nil {
-			return fmt.Errorf("error reading prompt input: %w", err)
+		var input string
+		if !answered || b.Answers.Has(fmt.Sprintf(answerBackendPort, n)) {
+			input, err = b.Answers.Input(fmt.Sprintf(answerBackendPort, n), b.Stdout, text.BoldYellow(fmt.Sprintf("Backend port number: [%d] ", port)), b.Stdin)
+			if err != nil {
+				return fmt.Errorf("error reading prompt input: %w", err)
+			}
 		}
 		if input != "" {
 			if portnumber, err := strconv.Atoi(input); err != nil {
@@ -213,9 +251,12 @@ func (b *Backends) promptForBackend() error {
 		}
 
 		defaultName := fmt.Sprintf("backend_%d", i)
-		name, err := text.Input(b.Stdout, text.BoldYellow(fmt.Sprintf("Backend name: [%s] ", defaultName)), b.Stdin)
-		if err != nil {
-			return fmt.Errorf("error reading prompt input %w", err)
+		var name string
+		if !answered || b.Answers.Has(fmt.Sprintf(answerBackendName, n)) {
+			name, err = b.Answers.Input(fmt.Sprintf(answerBackendName, n), b.Stdout, text.BoldYellow(fmt.Sprintf("Backend name: [%s] ", defaultName)), b.Stdin)
+			if err != nil {
+				return fmt.Errorf("error reading prompt input %w", err)
+			}
 		}
 		if name == "" {
 			name = defaultName
@@ -11,6 +11,11 @@ import (
 	"github.com/fastly/go-fastly/v6/fastly"
 )
 
+// answerDictionaryItem is the name of a dictionary item's value prompt for use
+// with the --answer and --answers-file flags. The placeholders are the names of
+// the dictionary and item defined in the [setup.dictionaries] configuration.
+const answerDictionaryItem = "setup.dictionaries.%s.%s"
+
 // Dictionaries represents the service state related to dictionaries defined
 // within the fastly.toml [setup] configuration.
 //
@@ -19,13 +24,14 @@ type Dictionaries struct {
 	// Public
 	APIClient      api.Interface
 	AcceptDefaults bool
+	Answers        text.Answers
 	NonInteractive bool
-	Progress       text.Progress
 	ServiceID      string
 	ServiceVersion int
 	Setup          map[string]*manifest.SetupDictionary
 	Stdin          io.Reader
 	Stdout         io.Writer
+	Tasks          *text.Tasks
 
 	// Private
 	required []Dictionary
@@ -72,7 +78,8 @@ func (d *Dictionaries) Configure() error {
 				err   error
 			)
 
-			if !d.AcceptDefaults && !d.NonInteractive {
+			answer := fmt.Sprintf(answerDictionaryItem, name, key)
+			if d.Answers.Has(answer) || (!d.AcceptDefaults && !d.NonInteractive) {
 				text.Break(d.Stdout)
 				text.Output(d.Stdout, "Create a dictionary key called '%s'", key)
 				if item.Description != "" {
@@ -80,7 +87,7 @@ func (d *Dictionaries) Configure() error {
 				}
 				text.Break(d.Stdout)
 
-				value, err = text.Input(d.Stdout, prompt, d.Stdin)
+				value, err = d.Answers.Input(answer, d.Stdout, prompt, d.Stdin)
 				if err != nil {
 					return fmt.Errorf("error reading prompt input: %w", err)
 				}
@@ -105,44 +112,47 @@ func (d *Dictionaries) Configure() error {
 	return nil
 }
 
-// Create calls the relevant API to create the service resource(s).
+// Create starts a task, with the Tasks, that calls the relevant API to create
+// each service resource. The items of a dictionary are created by tasks of
+// their own, once the dictionary has been created. The error of a failed task
+// is returned by Tasks.Wait.
 func (d *Dictionaries) Create() error {
-	if d.Progress == nil {
+	if d.Tasks == nil {
 		return errors.RemediationError{
-			Inner:       fmt.Errorf("internal logic error: no text.Progress configured for setup.Dictionaries"),
+			Inner:       fmt.Errorf("internal logic error: no text.Tasks configured for setup.Dictionaries"),
 			Remediation: errors.BugRemediation,
 		}
 	}
 
 	for _, dictionary := range d.required {
-		d.Progress.Step(fmt.Sprintf("Creating dictionary '%s'...", dictionary.Name))
-
-		dict, err := d.APIClient.CreateDictionary(&fastly.CreateDictionaryInput{
-			ServiceID:      d.ServiceID,
-			ServiceVersion: d.ServiceVersion,
-			Name:           dictionary.Name,
-		})
-		if err != nil {
-			d.Progress.Fail()
-			return fmt.Errorf("error creating dictionary: %w", err)
-		}
+		dictionary := dictionary
+		d.Tasks.Go(fmt.Sprintf("Creating dictionary '%s'...", dictionary.Name), func(_ io.Writer) error {
+			dict, err := d.APIClient.CreateDictionary(&fastly.CreateDictionaryInput{
+				ServiceID:      d.ServiceID,
+				ServiceVersion: d.ServiceVersion,
+				Name:           dictionary.Name,
+			})
+			if err != nil {
+				return fmt.Errorf("error creating dictionary: %w", err)
+			}
 
-		if len(dictionary.Items) > 0 {
 			for _, item := range dictionary.Items {
-				d.Progress.Step(fmt.Sprintf("Creating dictionary item '%s'...", item.Key))
-
-				_, err := d.APIClient.CreateDictionaryItem(&fastly.CreateDictionaryItemInput{
-					ServiceID:    d.ServiceID,
-					DictionaryID: dict.ID,
-					ItemKey:      item.Key,
-					ItemValue:    item.Value,
+				item := item
+				d.Tasks.Go(fmt.Sprintf("Creating dictionary item '%s'...", item.Key), func(_ io.Writer) error {
+					_, err := d.APIClient.CreateDictionaryItem(&fastly.CreateDictionaryItemInput{
+						ServiceID:    d.ServiceID,
+						DictionaryID: dict.ID,
+						ItemKey:      item.Key,
+						ItemValue:    item.Value,
+					})
+					if err != nil {
+						return fmt.Errorf("error creating dictionary item: %w", err)
+					}
+					return nil
 				})
-				if err != nil {
-					d.Progress.Fail()
-					return fmt.Errorf("error creating dictionary item: %w", err)
-				}
 			}
-		}
+			return nil
+		})
 	}
 
 	return nil
@@ -153,3 +163,8 @@ func (d *Dictionaries) Create() error {
 func (d *Dictionaries) Predefined() bool {
 	return len(d.Setup) > 0
 }
+
+// Required returns the dictionaries that Create will create (see Configure).
+func (d *Dictionaries) Required() []Dictionary {
+	return d.required
+}
@@ -5,10 +5,12 @@ import (
 	"io"
 	"math/rand"
 	"regexp"
+	"strings"
 	"time"
 
 	petname "github.com/dustinkirkland/golang-petname"
 	"github.com/fastly/cli/pkg/api"
+	"github.com/fastly/cli/pkg/api/undocumented"
 	"github.com/fastly/cli/pkg/errors"
 	"github.com/fastly/cli/pkg/text"
 	"github.com/fastly/go-fastly/v6/fastly"
@@ -16,8 +18,26 @@ import (
 
 const defaultTopLevelDomain = "edgecompute.app"
 
+// defaultDomainAttempts is the number of random default domains generated in
+// search of one that's available.
+const defaultDomainAttempts = 5
+
+// answerDomain is the name of the domain prompt for use with the --answer and
+// --answers-file flags.
+const answerDomain = "setup.domain"
+
 var domainNameRegEx = regexp.MustCompile(`(?:[a-z0-9](?:[a-z0-9-]{0,61}[a-z0-9])?\.)+[a-z0-9][a-z0-9-]{0,61}[a-z0-9]`)
 
+// placeholderRegEx matches the <placeholder> values of a naming convention.
+var placeholderRegEx = regexp.MustCompile(`<([a-zA-Z0-9_]+)>`)
+
+// invalidDomainCharsRegEx matches the characters of a placeholder value that
+// aren't valid in a domain.
+var invalidDomainCharsRegEx = regexp.MustCompile(`[^a-z0-9.-]+`)
+
+// randomPlaceholder is the naming convention placeholder for a random name.
+const randomPlaceholder = "random"
+
 // Domains represents the service state related to domains defined within the
 // fastly.toml [setup] configuration.
 //
@@ -25,14 +45,24 @@ var domainNameRegEx = regexp.MustCompile(`(?:[a-z0-9](?:[a-z0-9-]{0,61}[a-z0-9])
 type Domains struct {
 	// Public
 	APIClient      api.Interface
+	APIEndpoint    string
+	APIToken       string
 	AcceptDefaults bool
+	Answers        text.Answers
+	// HTTPClient is used to check the domains are available (if it's set).
+	HTTPClient api.HTTPClient
+	// NamingConvention is the template of the default domain, e.g.
+	// <team>-<name>-<env>.edgecompute.app (see config.Domain).
+	NamingConvention string
+	// NamingVars are the values of the NamingConvention placeholders.
+	NamingVars     map[string]string
 	NonInteractive bool
 	PackageDomain  string
-	Progress       text.Progress
 	ServiceID      string
 	ServiceVersion int
 	Stdin          io.Reader
 	Stdout         io.Writer
+	Tasks          *text.Tasks
 
 	// Private
 	available []*fastly.Domain
@@ -52,6 +82,12 @@ type Domain struct {
 func (d *Domains) Configure() error {
 	// PackageDomain is the --domain flag value.
 	if d.PackageDomain != "" {
+		if !d.isAvailable(d.PackageDomain) {
+			return errors.RemediationError{
+				Inner:       fmt.Errorf("domain '%s' is already in use", d.PackageDomain),
+				Remediation: "Use --domain to specify a different domain, or run `fastly domain suggest` to find one that's available.",
+			}
+		}
 		d.required = append(d.required, Domain{
 			Name: d.PackageDomain,
 		})
@@ -59,14 +95,25 @@ func (d *Domains) Configure() error {
 	}
 
 	rand.Seed(time.Now().UnixNano())
-	defaultDomain := fmt.Sprintf("%s.%s", petname.Generate(3, "-"), defaultTopLevelDomain)
-
 	var (
-		domain string
-		err    error
+		defaultDomain string
+		err           error
 	)
-	if !d.AcceptDefaults && !d.NonInteractive {
-		domain, err = text.Input(d.Stdout, text.BoldYellow(fmt.Sprintf("Domain: [%s] ", defaultDomain)), d.Stdin, d.validateDomain)
+	for i := 0; i < defaultDomainAttempts; i++ {
+		defaultDomain, err = d.defaultDomain()
+		if err != nil {
+			return err
+		}
+		// A naming convention without a random name generates the same domain
+		// each time, so there's no point in retrying it.
+		if d.isAvailable(defaultDomain) || !d.randomDefault() {
+			break
+		}
+	}
+
+	var domain string
+	if d.Answers.Has(answerDomain) || (!d.AcceptDefaults && !d.NonInteractive) {
+		domain, err = d.Answers.Input(answerDomain, d.Stdout, text.BoldYellow(fmt.Sprintf("Domain: [%s] ", defaultDomain)), d.Stdin, d.validateDomain, d.validateAvailable)
 		if err != nil {
 			return fmt.Errorf("error reading input %w", err)
 		}
@@ -86,27 +133,29 @@ func (d *Domains) Configure() error {
 	return nil
 }
 
-// Create calls the relevant API to create the service resource(s).
+// Create starts a task, with the Tasks, that calls the relevant API to create
+// each service resource. The error of a failed task is returned by Tasks.Wait.
 func (d *Domains) Create() error {
-	if d.Progress == nil {
+	if d.Tasks == nil {
 		return errors.RemediationError{
-			Inner:       fmt.Errorf("internal logic error: no text.Progress configured for setup.Domains"),
+			Inner:       fmt.Errorf("internal logic error: no text.Tasks configured for setup.Domains"),
 			Remediation: errors.BugRemediation,
 		}
 	}
 
 	for _, domain := range d.required {
-		d.Progress.Step(fmt.Sprintf("Creating domain '%s'...", domain.Name))
-
-		_, err := d.APIClient.CreateDomain(&fastly.CreateDomainInput{
-			ServiceID:      d.ServiceID,
-			ServiceVersion: d.ServiceVersion,
-			Name:           domain.Name,
+		domain := domain
+		d.Tasks.Go(fmt.Sprintf("Creating domain '%s'...", domain.Name), func(_ io.Writer) error {
+			_, err := d.APIClient.CreateDomain(&fastly.CreateDomainInput{
+				ServiceID:      d.ServiceID,
+				ServiceVersion: d.ServiceVersion,
+				Name:           domain.Name,
+			})
+			if err != nil {
+				return fmt.Errorf("error creating domain: %w", err)
+			}
+			return nil
 		})
-		if err != nil {
-			d.Progress.Fail()
-			return fmt.Errorf("error creating domain: %w", err)
-		}
 	}
 
 	return nil
@@ -117,6 +166,17 @@ func (d *Domains) Missing() bool {
 	return d.missing || len(d.required) > 0
 }
 
+// Available returns the domains the service version already has (see
+// Validate).
+func (d *Domains) Available() []*fastly.Domain {
+	return d.available
+}
+
+// Required returns the domains that Create will create (see Configure).
+func (d *Domains) Required() []Domain {
+	return d.required
+}
+
 // Predefined indicates if the service resource has been specified within the
 // fastly.toml file using a [setup] configuration block.
 //
@@ -146,6 +206,55 @@ func (d *Domains) Validate() error {
 	return nil
 }
 
+// defaultDomain returns a domain following the naming convention, or a random
+// domain if there isn't one.
+func (d *Domains) defaultDomain() (string, error) {
+	if d.NamingConvention == "" {
+		return fmt.Sprintf("%s.%s", petname.Generate(3, "-"), defaultTopLevelDomain), nil
+	}
+
+	var missing []string
+	domain := placeholderRegEx.ReplaceAllStringFunc(d.NamingConvention, func(placeholder string) string {
+		name := strings.ToLower(placeholderRegEx.FindStringSubmatch(placeholder)[1])
+		if name == randomPlaceholder {
+			return petname.Generate(2, "-")
+		}
+		value := strings.Trim(invalidDomainCharsRegEx.ReplaceAllString(strings.ToLower(d.NamingVars[name]), "-"), "-.")
+		if value == "" {
+			missing = append(missing, name)
+		}
+		return value
+	})
+	if len(missing) > 0 {
+		return "", errors.RemediationError{
+			Inner:       fmt.Errorf("no value for the domain naming convention placeholder(s): %s", strings.Join(missing, ", ")),
+			Remediation: "Set the value in the [domain.vars] of the CLI config, or with a FASTLY_DOMAIN_VAR_<NAME> environment variable.",
+		}
+	}
+
+	domain = strings.ToLower(domain)
+	if domainNameRegEx.FindString(domain) != domain {
+		return "", errors.RemediationError{
+			Inner:       fmt.Errorf("the domain naming convention '%s' generated an invalid domain name '%s'", d.NamingConvention, domain),
+			Remediation: "Check the [domain] template of the CLI config.",
+		}
+	}
+	return domain, nil
+}
+
+// randomDefault indicates whether the default domain includes a random name.
+func (d *Domains) randomDefault() bool {
+	if d.NamingConvention == "" {
+		return true
+	}
+	for _, m := range placeholderRegEx.FindAllStringSubmatch(d.NamingConvention, -1) {
+		if strings.ToLower(m[1]) == randomPlaceholder {
+			return true
+		}
+	}
+	return false
+}
+
 // validateDomain checks the user entered domain is valid.
 //
 // NOTE: An empty value is allowed so that a default domain can be utilised.
@@ -158,3 +267,29 @@ func (d *Domains) validateDomain(input string) error {
 	}
 	return nil
 }
+
+// validateAvailable checks the user entered domain isn't already in use, so
+// the user can pick another rather than the domain creation failing.
+func (d *Domains) validateAvailable(input string) error {
+	if input == "" || d.isAvailable(input) {
+		return nil
+	}
+	return fmt.Errorf("domain '%s' is already in use", input)
+}
+
+// isAvailable indicates whether the domain is free to use.
+//
+// NOTE: A domain is assumed to be available unless its status shows it's in
+// use, so that a problem with the check doesn't prevent a deploy. The domain
+// creation will still fail if it's in use.
+func (d *Domains) isAvailable(name string) bool {
+	if d.HTTPClient == nil {
+		return true
+	}
+	client := undocumented.NewClient(d.APIEndpoint, d.APIToken, d.HTTPClient)
+	status, err := client.GetDomainStatus(name)
+	if err != nil {
+		return true
+	}
+	return !status.InUse()
+}
@@ -5,7 +5,8 @@ type Interface interface {
 	// Configure prompts the user for specific values related to the service resource.
 	Configure() error
 
-	// Create calls the relevant API to create the service resource(s).
+	// Create starts the tasks that call the relevant API to create the service
+	// resource(s), which can run concurrently (see text.Tasks).
 	Create() error
 
 	// Missing indicates if there are missing resources that need to be
@@ -0,0 +1,57 @@
+package compute
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/fastly/cli/pkg/api/undocumented"
+	"github.com/fastly/cli/pkg/commands/kvstore"
+	"github.com/fastly/cli/pkg/text"
+)
+
+// syncStatic uploads the files of dir to the named KV store (see
+// kvstore.Sync), skipping the files that a .fastlyignore or the default ignored
+// directories (e.g. node_modules) exclude from a package.
+func syncStatic(client *undocumented.Client, storeName, dir string, out io.Writer, verbose bool) (*kvstore.SyncResult, error) {
+	if _, err := os.Stat(dir); err != nil {
+		return nil, fmt.Errorf("error reading --static-dir: %w", err)
+	}
+	files, err := GetNonIgnoredFiles(dir, ".", nil)
+	if err != nil {
+		return nil, fmt.Errorf("error reading --static-dir: %w", err)
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("error reading --static-dir: %s has no files", dir)
+	}
+	return kvstore.Sync(client, kvstore.SyncInput{
+		Dir:     dir,
+		Files:   files,
+		Store:   storeName,
+		Verbose: verbose,
+	}, out)
+}
+
+// staticLink starts a task, with the tasks, that links the KV store to the
+// service version, unless it's already linked.
+func staticLink(tasks *text.Tasks, client *undocumented.Client, store *undocumented.KVStore, serviceID string, version int) {
+	tasks.Go(fmt.Sprintf("Linking KV store '%s'...", store.Name), func(_ io.Writer) error {
+		input := undocumented.ResourceLinkInput{
+			ServiceID:      serviceID,
+			ServiceVersion: version,
+		}
+		links, err := client.ListResourceLinks(input)
+		if err != nil {
+			return fmt.Errorf("error listing resource links: %w", err)
+		}
+		for _, l := range links {
+			if l.ResourceID == store.ID {
+				return nil
+			}
+		}
+		if _, err := client.CreateResourceLink(input, store.ID, store.Name); err != nil {
+			return fmt.Errorf("error linking KV store '%s': %w", store.Name, err)
+		}
+		return nil
+	})
+}
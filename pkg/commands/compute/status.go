@@ -0,0 +1,270 @@
+package compute
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/fastly/cli/pkg/cmd"
+	"github.com/fastly/cli/pkg/config"
+	fsterr "github.com/fastly/cli/pkg/errors"
+	"github.com/fastly/cli/pkg/manifest"
+	"github.com/fastly/cli/pkg/text"
+	fsttime "github.com/fastly/cli/pkg/time"
+	"github.com/fastly/go-fastly/v6/fastly"
+)
+
+// NewStatusCommand returns a usable command registered under the parent.
+func NewStatusCommand(parent cmd.Registerer, globals *config.Data, data manifest.Data) *StatusCommand {
+	var c StatusCommand
+	c.Globals = globals
+	c.manifest = data
+	c.CmdClause = parent.Command("status", "Show the Fastly service a Compute@Edge project is linked to, and whether its local package has been deployed")
+	c.CmdClause.Flag("exit-code", "Exit with status 7 if the local package isn't deployed to the active version (see `fastly help exit-codes`)").BoolVar(&c.exitCode)
+	c.RegisterFlagBool(cmd.BoolFlagOpts{
+		Name:        cmd.FlagJSONName,
+		Description: cmd.FlagJSONDesc,
+		Dst:         &c.json,
+		Short:       'j',
+	})
+	c.CmdClause.Flag("package", "Path to a package tar.gz").Short('p').StringVar(&c.pkg)
+	c.RegisterFlag(cmd.StringFlagOpts{
+		Name:        cmd.FlagServiceIDName,
+		Description: cmd.FlagServiceIDDesc,
+		Dst:         &c.manifest.Flag.ServiceID,
+		Short:       's',
+	})
+	c.RegisterFlag(cmd.StringFlagOpts{
+		Action:      c.serviceName.Set,
+		Name:        cmd.FlagServiceName,
+		Description: cmd.FlagServiceDesc,
+		Dst:         &c.serviceName.Value,
+	})
+	return &c
+}
+
+// StatusCommand summarises the linkage of a project to its Fastly service.
+type StatusCommand struct {
+	cmd.Base
+
+	exitCode    bool
+	json        bool
+	manifest    manifest.Data
+	pkg         string
+	serviceName cmd.OptionalServiceNameID
+}
+
+// Status describes a project, its local package and the service it's linked
+// to.
+type Status struct {
+	Name    string         `json:"name"`
+	Package *StatusPackage `json:"package,omitempty"`
+	Service *StatusService `json:"service,omitempty"`
+	// Deployed indicates whether the local package is identical to the package
+	// of the service's active version.
+	Deployed bool `json:"deployed"`
+}
+
+// StatusPackage describes the last locally built package.
+type StatusPackage struct {
+	BuiltAt time.Time `json:"built_at"`
+	HashSum string    `json:"hash_sum"`
+	Path    string    `json:"path"`
+	Size    int64     `json:"size"`
+}
+
+// StatusService describes the service a project is linked to.
+type StatusService struct {
+	ActiveVersion int      `json:"active_version,omitempty"`
+	Domains       []string `json:"domains,omitempty"`
+	HashSum       string   `json:"hash_sum,omitempty"`
+	ID            string   `json:"id"`
+	Name          string   `json:"name"`
+}
+
+// Exec implements the command interface.
+func (c *StatusCommand) Exec(_ io.Reader, out io.Writer) error {
+	if c.Globals.Verbose() && c.json {
+		return fsterr.ErrInvalidVerboseJSONCombo
+	}
+
+	if err := c.manifest.File.ReadError(); err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			err = fsterr.ErrReadingManifest
+		}
+		c.Globals.ErrLog.Add(err)
+		return err
+	}
+
+	name, source := c.manifest.Name()
+	status := Status{Name: name}
+
+	pkgPath, err := packagePath("", c.pkg, name, source)
+	if err != nil {
+		c.Globals.ErrLog.Add(err)
+		return err
+	}
+	status.Package, err = localPackage(pkgPath, moduleFilenames(c.manifest.File.Modules))
+	if err != nil {
+		c.Globals.ErrLog.AddWithContext(err, map[string]any{
+			"Package path": pkgPath,
+		})
+		return err
+	}
+
+	// NOTE: A project that hasn't been deployed isn't linked to a service, which
+	// isn't an error.
+	serviceID, source, flag, err := cmd.ServiceID(c.serviceName, c.manifest, c.Globals.APIClient, nil)
+	if source != manifest.SourceUndefined {
+		if err != nil {
+			c.Globals.ErrLog.Add(err)
+			return err
+		}
+		if c.Globals.Verbose() {
+			cmd.DisplayServiceID(serviceID, flag, source, out)
+		}
+		status.Service, err = c.linkedService(serviceID)
+		if err != nil {
+			return err
+		}
+	}
+
+	status.Deployed = status.Package != nil && status.Service != nil && status.Package.HashSum == status.Service.HashSum
+
+	if c.json {
+		data, err := json.Marshal(status)
+		if err != nil {
+			return err
+		}
+		_, err = out.Write(data)
+		if err != nil {
+			c.Globals.ErrLog.Add(err)
+			return fmt.Errorf("error: unable to write data to stdout: %w", err)
+		}
+	} else {
+		c.print(status, out)
+	}
+
+	if c.exitCode && !status.Deployed {
+		return fsterr.RemediationError{
+			Inner: fmt.Errorf("the local package isn't deployed to the active version"),
+			Code:  fsterr.ExitDrift,
+		}
+	}
+	return nil
+}
+
+// linkedService returns the service's active version, along with its domains
+// and the hash of its package.
+func (c *StatusCommand) linkedService(serviceID string) (*StatusService, error) {
+	service, err := c.Globals.APIClient.GetServiceDetails(&fastly.GetServiceInput{
+		ID: serviceID,
+	})
+	if err != nil {
+		c.Globals.ErrLog.AddWithContext(err, map[string]any{
+			"Service ID": serviceID,
+		})
+		return nil, fmt.Errorf("error fetching service details: %w", err)
+	}
+
+	s := &StatusService{
+		ID:   serviceID,
+		Name: service.Name,
+	}
+	if !service.ActiveVersion.Active {
+		return s, nil
+	}
+	s.ActiveVersion = service.ActiveVersion.Number
+
+	domains, err := c.Globals.APIClient.ListDomains(&fastly.ListDomainsInput{
+		ServiceID:      serviceID,
+		ServiceVersion: s.ActiveVersion,
+	})
+	if err != nil {
+		c.Globals.ErrLog.AddWithContext(err, map[string]any{
+			"Service ID":      serviceID,
+			"Service Version": s.ActiveVersion,
+		})
+		return nil, fmt.Errorf("error fetching service domains: %w", err)
+	}
+	for _, d := range domains {
+		s.Domains = append(s.Domains, d.Name)
+	}
+
+	// NOTE: A version without a package returns an error, which isn't a
+	// problem for the status of the service.
+	p, err := c.Globals.APIClient.GetPackage(&fastly.GetPackageInput{
+		ServiceID:      serviceID,
+		ServiceVersion: s.ActiveVersion,
+	})
+	if err == nil {
+		s.HashSum = p.Metadata.HashSum
+	}
+	return s, nil
+}
+
+// print displays the status as text.
+func (c *StatusCommand) print(s Status, out io.Writer) {
+	fmt.Fprintf(out, "Package name: %s\n", s.Name)
+
+	if s.Service == nil {
+		fmt.Fprintln(out, "Service: none")
+	} else {
+		fmt.Fprintf(out, "Service: %s (%s)\n", s.Service.Name, s.Service.ID)
+		if s.Service.ActiveVersion == 0 {
+			fmt.Fprintln(out, "Active version: none")
+		} else {
+			fmt.Fprintf(out, "Active version: %d\n", s.Service.ActiveVersion)
+			fmt.Fprintln(out, "Domains:")
+			for _, d := range s.Service.Domains {
+				fmt.Fprintf(out, "\t%s\n", d)
+			}
+		}
+	}
+
+	if s.Package == nil {
+		fmt.Fprintln(out, "Local package: none")
+	} else {
+		fmt.Fprintf(out, "Local package: %s\n", s.Package.Path)
+		fmt.Fprintf(out, "\tHash: %s\n", s.Package.HashSum)
+		fmt.Fprintf(out, "\tSize: %d bytes\n", s.Package.Size)
+		fmt.Fprintf(out, "\tBuilt (UTC): %s (%s ago)\n", s.Package.BuiltAt.UTC().Format(fsttime.Format), time.Since(s.Package.BuiltAt).Round(time.Second))
+	}
+	text.Break(out)
+
+	switch {
+	case s.Package == nil:
+		text.Info(out, "No local package found. Run `fastly compute build` to build one.")
+	case s.Service == nil:
+		text.Info(out, "The project isn't linked to a service. Run `fastly compute deploy` to create one.")
+	case s.Deployed:
+		text.Success(out, "The local package is deployed to the active version.")
+	default:
+		text.Warning(out, "The local package differs from the active version. Run `fastly compute deploy` to deploy it.")
+	}
+}
+
+// localPackage returns the details of the package, or nil if it hasn't been
+// built.
+func localPackage(path string, modules []string) (*StatusPackage, error) {
+	fi, err := os.Stat(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("error reading package: %w", err)
+	}
+	hashSum, err := packageHashSum(path, modules)
+	if err != nil {
+		return nil, err
+	}
+	return &StatusPackage{
+		BuiltAt: fi.ModTime(),
+		HashSum: hashSum,
+		Path:    path,
+		Size:    fi.Size(),
+	}, nil
+}
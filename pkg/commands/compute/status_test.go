@@ -0,0 +1,181 @@
+package compute_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/fastly/cli/pkg/app"
+	fsterr "github.com/fastly/cli/pkg/errors"
+	"github.com/fastly/cli/pkg/manifest"
+	"github.com/fastly/cli/pkg/mock"
+	"github.com/fastly/cli/pkg/testutil"
+	"github.com/fastly/go-fastly/v6/fastly"
+)
+
+func TestStatus(t *testing.T) {
+	args := testutil.Args
+	scenarios := []struct {
+		api            mock.API
+		args           []string
+		dontWantOutput []string
+		manifest       string
+		name           string
+		noPackage      bool
+		wantError      string
+		wantExitCode   fsterr.ExitCode
+		wantOutput     []string
+	}{
+		{
+			name:       "validate not linked to a service",
+			args:       args("compute status"),
+			wantOutput: []string{"Package name: package\nService: none\nLocal package: pkg/package.tar.gz\n", "The project isn't linked to a service"},
+		},
+		{
+			name:       "validate package not built",
+			args:       args("compute status"),
+			noPackage:  true,
+			wantOutput: []string{"Local package: none", "No local package found"},
+		},
+		{
+			name: "validate deployed",
+			args: args("compute status"),
+			api: mock.API{
+				GetServiceDetailsFn: getServiceDetailsActive,
+				ListDomainsFn:       listDomainsOk,
+				GetPackageFn:        getPackageIdentical,
+			},
+			manifest: "service_id = \"123\"\n",
+			wantOutput: []string{
+				"Service: example (123)\nActive version: 2\nDomains:\n\thttps://directly-careful-coyote.edgecompute.app\n",
+				"\tSize: ",
+				"The local package is deployed to the active version.",
+			},
+		},
+		{
+			name: "validate differs",
+			args: args("compute status --service-id 456"),
+			api: mock.API{
+				GetServiceDetailsFn: getServiceDetailsActive,
+				ListDomainsFn:       listDomainsOk,
+				GetPackageFn:        getPackageOk,
+			},
+			wantOutput: []string{"Service: example (456)", "The local package differs from the active version."},
+		},
+		{
+			name: "validate differs with --exit-code",
+			args: args("compute status --service-id 456 --exit-code"),
+			api: mock.API{
+				GetServiceDetailsFn: getServiceDetailsActive,
+				ListDomainsFn:       listDomainsOk,
+				GetPackageFn:        getPackageOk,
+			},
+			wantOutput:   []string{"The local package differs from the active version."},
+			wantError:    "the local package isn't deployed to the active version",
+			wantExitCode: fsterr.ExitDrift,
+		},
+		{
+			name: "validate deployed with --exit-code",
+			args: args("compute status --service-id 123 --exit-code"),
+			api: mock.API{
+				GetServiceDetailsFn: getServiceDetailsActive,
+				ListDomainsFn:       listDomainsOk,
+				GetPackageFn:        getPackageIdentical,
+			},
+			wantOutput: []string{"The local package is deployed to the active version."},
+		},
+		{
+			name: "validate no active version",
+			args: args("compute status --service-id 123"),
+			api: mock.API{
+				GetServiceDetailsFn: getServiceDetailsWasm,
+			},
+			wantOutput:     []string{"Active version: none"},
+			dontWantOutput: []string{"Domains:"},
+		},
+		{
+			name: "validate --json",
+			args: args("compute status --service-id 123 --json"),
+			api: mock.API{
+				GetServiceDetailsFn: getServiceDetailsActive,
+				ListDomainsFn:       listDomainsOk,
+				GetPackageFn:        getPackageIdentical,
+			},
+			wantOutput: []string{`"service":{"active_version":2,"domains":["https://directly-careful-coyote.edgecompute.app"],"hash_sum":"bf634ccf`, `"deployed":true}`},
+		},
+		{
+			name: "validate API error",
+			args: args("compute status --service-id 123"),
+			api: mock.API{
+				GetServiceDetailsFn: func(i *fastly.GetServiceInput) (*fastly.ServiceDetail, error) {
+					return nil, testutil.Err
+				},
+			},
+			wantError:    "error fetching service details: test error",
+			wantExitCode: fsterr.ExitError,
+		},
+	}
+
+	for testcaseIdx := range scenarios {
+		testcase := &scenarios[testcaseIdx]
+		t.Run(testcase.name, func(t *testing.T) {
+			// We're going to chdir to a project environment,
+			// so save the PWD to return to, afterwards.
+			pwd, err := os.Getwd()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			opts := testutil.EnvOpts{
+				T: t,
+				Write: []testutil.FileIO{
+					{
+						Src: "manifest_version = 2\nname = \"package\"\n" + testcase.manifest,
+						Dst: manifest.Filename,
+					},
+				},
+			}
+			if !testcase.noPackage {
+				opts.Copy = []testutil.FileIO{
+					{
+						Src: filepath.Join("testdata", "deploy", "pkg", "package.tar.gz"),
+						Dst: filepath.Join("pkg", "package.tar.gz"),
+					},
+				}
+			}
+			rootdir := testutil.NewEnv(opts)
+			defer os.RemoveAll(rootdir)
+
+			if err := os.Chdir(rootdir); err != nil {
+				t.Fatal(err)
+			}
+			defer os.Chdir(pwd)
+
+			var stdout bytes.Buffer
+			runOpts := testutil.NewRunOpts(testcase.args, &stdout)
+			runOpts.APIClient = mock.APIClient(testcase.api)
+			err = app.Run(runOpts)
+			testutil.AssertErrorContains(t, err, testcase.wantError)
+			testutil.AssertEqual(t, testcase.wantExitCode, fsterr.Exit(err))
+			for _, s := range testcase.wantOutput {
+				testutil.AssertStringContains(t, stdout.String(), s)
+			}
+			for _, s := range testcase.dontWantOutput {
+				testutil.AssertStringDoesntContain(t, stdout.String(), s)
+			}
+		})
+	}
+}
+
+func getServiceDetailsActive(i *fastly.GetServiceInput) (*fastly.ServiceDetail, error) {
+	return &fastly.ServiceDetail{
+		ID:   i.ID,
+		Name: "example",
+		Type: "wasm",
+		ActiveVersion: fastly.Version{
+			Active: true,
+			Number: 2,
+		},
+	}, nil
+}
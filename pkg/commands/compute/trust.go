@@ -0,0 +1,87 @@
+package compute
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+)
+
+// TrustStoreName is the name of the file, kept alongside the CLI config file,
+// that records the custom scripts a user has approved.
+const TrustStoreName = "trusted_scripts.json"
+
+// The kinds of custom script recorded in the trust store.
+const (
+	TrustScriptBuild     = "build"
+	TrustScriptPostBuild = "post_build"
+)
+
+// TrustStore records a hash of each custom script approved by the user, keyed
+// by the project directory and kind of script, so that a script that was
+// previously approved runs without prompting while a changed script prompts
+// again.
+//
+// NOTE: The store is only available when the CLI config file exists, and so
+// approvals aren't recorded when running with a placeholder config (e.g. the
+// /dev/null path used by the tests).
+type TrustStore struct {
+	Projects map[string]map[string]string `json:"projects"`
+
+	path string
+}
+
+// LoadTrustStore reads the trust store kept alongside the CLI config file. A
+// missing or unreadable store is treated as empty.
+func LoadTrustStore(configPath string) *TrustStore {
+	t := &TrustStore{Projects: make(map[string]map[string]string)}
+
+	fi, err := os.Stat(configPath)
+	if err != nil || !fi.Mode().IsRegular() {
+		return t
+	}
+	t.path = filepath.Join(filepath.Dir(configPath), TrustStoreName)
+
+	// gosec flagged this:
+	// G304 (CWE-22): Potential file inclusion via variable
+	//
+	// Disabling as the path is derived from the CLI config file location.
+	/* #nosec */
+	data, err := os.ReadFile(t.path)
+	if err != nil {
+		return t
+	}
+	if err := json.Unmarshal(data, t); err != nil || t.Projects == nil {
+		t.Projects = make(map[string]map[string]string)
+	}
+	return t
+}
+
+// Trusted reports whether the script was previously approved for the project.
+func (t *TrustStore) Trusted(dir, kind, script string) bool {
+	return t.Projects[dir][kind] == hashScript(script)
+}
+
+// Trust records the approval of the script for the project.
+func (t *TrustStore) Trust(dir, kind, script string) error {
+	if t.path == "" {
+		return nil
+	}
+	if t.Projects[dir] == nil {
+		t.Projects[dir] = make(map[string]string)
+	}
+	t.Projects[dir][kind] = hashScript(script)
+
+	data, err := json.MarshalIndent(t, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(t.path, data, 0o600)
+}
+
+// hashScript returns the hex encoded SHA-256 of the script.
+func hashScript(script string) string {
+	sum := sha256.Sum256([]byte(script))
+	return hex.EncodeToString(sum[:])
+}
@@ -5,6 +5,7 @@ import (
 	"io"
 	"os"
 	"path/filepath"
+	"strings"
 
 	"github.com/fastly/cli/pkg/cmd"
 	"github.com/fastly/cli/pkg/config"
@@ -31,17 +32,61 @@ func (c *ValidateCommand) Exec(_ io.Reader, out io.Writer) error {
 		return fmt.Errorf("error reading file path: %w", err)
 	}
 
-	if err := validate(p, nil); err != nil {
+	// NOTE: The imports of each wasm binary in the package (i.e. main.wasm and
+	// any additional modules) are read as the package is validated.
+	var imports []WasmImport
+	readImports := func(f archiver.File) error {
+		if filepath.Ext(f.Name()) != ".wasm" {
+			return nil
+		}
+		i, err := ReadWasmImports(f)
+		if err != nil {
+			return fmt.Errorf("error reading imports of %s: %w", f.Name(), err)
+		}
+		imports = append(imports, i...)
+		return nil
+	}
+
+	if err := validate(p, nil, readImports); err != nil {
 		c.Globals.ErrLog.AddWithContext(err, map[string]any{
 			"Path": c.path,
 		})
 		return err
 	}
 
+	c.checkHostcalls(imports, out)
+
 	text.Success(out, "Validated package %s", p)
 	return nil
 }
 
+// checkHostcalls warns when the package imports hostcalls that aren't generally
+// available at the edge, as the package then works locally (with a Viceroy
+// that supports them) but fails once deployed.
+//
+// NOTE: A config without a [wasm_abi] section (i.e. one written by an older
+// CLI version) can't be checked against.
+func (c *ValidateCommand) checkHostcalls(imports []WasmImport, out io.Writer) {
+	abi := c.Globals.File.WasmABI
+	if len(abi.Modules) == 0 {
+		return
+	}
+
+	unavailable := UnavailableHostcalls(imports, abi)
+	if len(unavailable) == 0 {
+		return
+	}
+	seen := make(map[string]bool)
+	list := make([]string, 0, len(unavailable))
+	for _, i := range unavailable {
+		if !seen[i.String()] {
+			seen[i.String()] = true
+			list = append(list, i.String())
+		}
+	}
+	text.Warning(out, "The package uses hostcalls that aren't generally available at the edge, so it may work locally but fail once deployed: %s. Check the SDK version the package is built with.", strings.Join(list, ", "))
+}
+
 // ValidateCommand validates a package archive.
 type ValidateCommand struct {
 	cmd.Base
@@ -54,13 +99,13 @@ type FileValidator func(archiver.File) error
 // validate is a utility function to determine whether a package is valid.
 // It attemptes to unarchive and read a tar.gz file from a specfic path,
 // if successful, it then iterates through (streams) each file in the archive
-// checking the filename against a list of required files. If one of the files
-// doesn't exist it returns an error.
+// checking the filename against a list of required files, along with the given
+// module files. If one of the files doesn't exist it returns an error.
 // validate also call fileValidator, if not nil, passing the file obtained from
 // tar.Read().
 //
 // NOTE: This function is also called by the `deploy` command.
-func validate(path string, fileValidator FileValidator) error {
+func validate(path string, modules []string, fileValidator FileValidator) error {
 	file, err := os.Open(filepath.Clean(path))
 	if err != nil {
 		return fmt.Errorf("error reading package: %w", err)
@@ -78,6 +123,9 @@ func validate(path string, fileValidator FileValidator) error {
 		"fastly.toml": false,
 		"main.wasm":   false,
 	}
+	for _, m := range modules {
+		files[m] = false
+	}
 
 	for {
 		f, err := tar.Read()
@@ -0,0 +1,62 @@
+package compute
+
+import (
+	"fmt"
+	"io"
+	"io/fs"
+	"path/filepath"
+	"strings"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// watchSource adds the files within dir to the watcher, excluding any that
+// match the rules returned by gitIgnore().
+func watchSource(dir string, watcher *fsnotify.Watcher, verbose bool, out io.Writer) error {
+	gi := gitIgnore()
+
+	return filepath.WalkDir(dir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return fmt.Errorf("error walking directory tree '%s': %w", dir, err)
+		}
+		// If there's no ignore file, we'll default to watching all directories
+		// within the specified top-level directory.
+		//
+		// NOTE: Watching a directory implies watching all files within the root of
+		// the directory. This means we don't need to call Add(path) for each file.
+		if gi == nil && entry.IsDir() {
+			watchFile(path, watcher, verbose, out)
+		}
+		if gi != nil && !entry.IsDir() && !gi.MatchesPath(path) {
+			// If there is an ignore file, we avoid watching directories and instead
+			// will only add files that don't match the exclusion patterns defined.
+			watchFile(path, watcher, verbose, out)
+		}
+		return nil
+	})
+}
+
+// watchPattern describes the files watched within dir, e.g. ./src/**/*
+func watchPattern(dir string) string {
+	// NOTE: A language might use the root directory rather than a subdirectory
+	// like the ./src directory (which most currently use).
+	if dir == "." {
+		dir = ""
+	}
+	if dir != "" {
+		dir = dir + "/"
+	}
+	return fmt.Sprintf("./%s**/*", dir)
+}
+
+// isBuildOutput reports whether path is within one of the directories the
+// build writes to, so changes to it shouldn't trigger another build.
+func isBuildOutput(path string) bool {
+	path = filepath.ToSlash(filepath.Clean(path))
+	for _, dir := range []string{"bin", "pkg"} {
+		if path == dir || strings.HasPrefix(path, dir+"/") {
+			return true
+		}
+	}
+	return false
+}
@@ -4,9 +4,11 @@ import (
 	"bytes"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/fastly/cli/pkg/app"
+	"github.com/fastly/cli/pkg/config"
 	"github.com/fastly/cli/pkg/mock"
 	"github.com/fastly/cli/pkg/testutil"
 )
@@ -53,8 +55,13 @@ func TestConfig(t *testing.T) {
 	args := testutil.Args
 	scenarios := []testutil.TestScenario{
 		{
-			Name:       "validate config file content is displayed",
+			Name:       "validate config file content is displayed with secrets masked",
 			Args:       args("config"),
+			WantOutput: strings.Replace(string(data), `token = "abc"`, `token = "********"`, 1),
+		},
+		{
+			Name:       "validate config file content is displayed with --show-secrets",
+			Args:       args("config --show-secrets"),
 			WantOutput: string(data),
 		},
 		{
@@ -77,3 +84,84 @@ func TestConfig(t *testing.T) {
 		})
 	}
 }
+
+func TestConfigPin(t *testing.T) {
+	args := testutil.Args
+	scenarios := []struct {
+		testutil.TestScenario
+		file          config.File
+		wantFile      []string
+		wantNotInFile []string
+	}{
+		{
+			TestScenario: testutil.TestScenario{
+				Name:       "pin",
+				Args:       args("config pin"),
+				WantOutput: "Pinned the language constraints and starter kits to those of CLI version 1.2.3",
+			},
+			file: config.File{
+				CLI:      config.CLI{Version: "1.2.3"},
+				Language: config.Language{Go: config.Go{TinyGoConstraint: ">= 0.1.0"}},
+			},
+			wantFile: []string{`pinned_version = "1.2.3"`, `tinygo_constraint = ">= 0.1.0"`},
+		},
+		{
+			TestScenario: testutil.TestScenario{
+				Name:       "already pinned",
+				Args:       args("config pin"),
+				WantOutput: "already pinned",
+			},
+			file: config.File{
+				CLI: config.CLI{PinnedVersion: "1.0.0", Version: "1.2.3"},
+			},
+		},
+		{
+			TestScenario: testutil.TestScenario{
+				Name:       "unpin",
+				Args:       args("config unpin"),
+				WantOutput: "Restored the language constraints and starter kits",
+			},
+			file: config.File{
+				CLI:      config.CLI{PinnedVersion: "1.0.0", Version: "1.2.3"},
+				Language: config.Language{Go: config.Go{TinyGoConstraint: ">= 0.1.0"}},
+			},
+			wantNotInFile: []string{"pinned_version", `tinygo_constraint = ">= 0.1.0"`},
+		},
+		{
+			TestScenario: testutil.TestScenario{
+				Name:       "not pinned",
+				Args:       args("config unpin"),
+				WantOutput: "aren't pinned",
+			},
+		},
+	}
+
+	for testcaseIdx := range scenarios {
+		testcase := &scenarios[testcaseIdx]
+		t.Run(testcase.Name, func(t *testing.T) {
+			configPath := filepath.Join(t.TempDir(), "config.toml")
+
+			var stdout bytes.Buffer
+			opts := testutil.NewRunOpts(testcase.Args, &stdout)
+			opts.ConfigFile = testcase.file
+			opts.ConfigPath = configPath
+			err := app.Run(opts)
+			testutil.AssertErrorContains(t, err, testcase.WantError)
+			testutil.AssertStringContains(t, stdout.String(), testcase.WantOutput)
+
+			if len(testcase.wantFile) == 0 && len(testcase.wantNotInFile) == 0 {
+				return
+			}
+			data, err := os.ReadFile(configPath)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, s := range testcase.wantFile {
+				testutil.AssertStringContains(t, string(data), s)
+			}
+			for _, s := range testcase.wantNotInFile {
+				testutil.AssertStringDoesntContain(t, string(data), s)
+			}
+		})
+	}
+}
@@ -0,0 +1,46 @@
+package config
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/fastly/cli/pkg/cmd"
+	"github.com/fastly/cli/pkg/config"
+	"github.com/fastly/cli/pkg/revision"
+	"github.com/fastly/cli/pkg/text"
+)
+
+// PinCommand pins the language constraints and starter kits.
+type PinCommand struct {
+	cmd.Base
+}
+
+// NewPinCommand returns a usable command registered under the parent.
+func NewPinCommand(parent cmd.Registerer, globals *config.Data) *PinCommand {
+	var c PinCommand
+	c.Globals = globals
+	c.CmdClause = parent.Command("pin", "Keep the current language constraints and starter kits when the CLI is updated")
+	return &c
+}
+
+// Exec invokes the application logic for the command.
+func (c *PinCommand) Exec(_ io.Reader, out io.Writer) error {
+	if v := c.Globals.File.CLI.PinnedVersion; v != "" {
+		text.Info(out, "The language constraints and starter kits are already pinned to those of CLI version %s.", v)
+		return nil
+	}
+
+	v := c.Globals.File.CLI.Version
+	if v == "" {
+		v = revision.SemVer(revision.AppVersion)
+	}
+	c.Globals.File.CLI.PinnedVersion = v
+
+	if err := c.Globals.File.Write(c.Globals.Path); err != nil {
+		c.Globals.ErrLog.Add(err)
+		return fmt.Errorf("error saving config file: %w", err)
+	}
+
+	text.Success(out, "Pinned the language constraints and starter kits to those of CLI version %s. Run `fastly config unpin` to use the ones shipped with the CLI.", v)
+	return nil
+}
@@ -0,0 +1,64 @@
+package config
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+
+	"github.com/fastly/cli/pkg/cmd"
+	"github.com/fastly/cli/pkg/config"
+	"github.com/fastly/cli/pkg/text"
+)
+
+// ShowCommand displays the CLI configuration.
+type ShowCommand struct {
+	cmd.Base
+
+	filePath string
+	location bool
+}
+
+// NewShowCommand returns a usable command registered under the parent.
+//
+// NOTE: It's the default subcommand so `fastly config` continues to display
+// the configuration.
+func NewShowCommand(parent cmd.Registerer, globals *config.Data) *ShowCommand {
+	var c ShowCommand
+	c.Globals = globals
+	c.CmdClause = parent.Command("show", "Display the Fastly CLI configuration").Default()
+	c.CmdClause.Flag("location", "Print the location of the CLI configuration file").Short('l').BoolVar(&c.location)
+	c.filePath = globals.Path
+	return &c
+}
+
+// Exec invokes the application logic for the command.
+func (c *ShowCommand) Exec(_ io.Reader, out io.Writer) (err error) {
+	if c.location {
+		if c.Globals.Flag.Verbose {
+			text.Break(out)
+		}
+		fmt.Fprintln(out, c.filePath)
+		return nil
+	}
+
+	data, err := os.ReadFile(c.filePath)
+	if err != nil {
+		c.Globals.ErrLog.Add(err)
+		return err
+	}
+	fmt.Fprintln(out, maskSecrets(string(data)))
+	return nil
+}
+
+// secretRegEx matches the config keys whose values are secrets.
+var secretRegEx = regexp.MustCompile(`(?m)^(\s*(?:token|slack_webhook|webhook)\s*=\s*)"([^"]*)"`)
+
+// maskSecrets masks the values of secret config keys, unless secrets are being
+// shown.
+func maskSecrets(data string) string {
+	return secretRegEx.ReplaceAllStringFunc(data, func(m string) string {
+		parts := secretRegEx.FindStringSubmatch(m)
+		return parts[1] + `"` + text.Secret(parts[2]) + `"`
+	})
+}
@@ -0,0 +1,42 @@
+package config
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/fastly/cli/pkg/cmd"
+	"github.com/fastly/cli/pkg/config"
+	"github.com/fastly/cli/pkg/revision"
+	"github.com/fastly/cli/pkg/text"
+)
+
+// UnpinCommand restores the language constraints and starter kits shipped
+// with the CLI.
+type UnpinCommand struct {
+	cmd.Base
+}
+
+// NewUnpinCommand returns a usable command registered under the parent.
+func NewUnpinCommand(parent cmd.Registerer, globals *config.Data) *UnpinCommand {
+	var c UnpinCommand
+	c.Globals = globals
+	c.CmdClause = parent.Command("unpin", "Use the language constraints and starter kits shipped with the CLI")
+	return &c
+}
+
+// Exec invokes the application logic for the command.
+func (c *UnpinCommand) Exec(_ io.Reader, out io.Writer) error {
+	if c.Globals.File.CLI.PinnedVersion == "" {
+		text.Info(out, "The language constraints and starter kits aren't pinned.")
+		return nil
+	}
+
+	c.Globals.File.CLI.PinnedVersion = ""
+	if err := c.Globals.File.UseStatic(c.Globals.Path); err != nil {
+		c.Globals.ErrLog.Add(err)
+		return fmt.Errorf("error saving config file: %w", err)
+	}
+
+	text.Success(out, "Restored the language constraints and starter kits shipped with CLI version %s.", revision.SemVer(revision.AppVersion))
+	return nil
+}
@@ -1,8 +1,6 @@
 package dictionary
 
 import (
-	"encoding/json"
-	"fmt"
 	"io"
 
 	"github.com/fastly/cli/pkg/cmd"
@@ -18,7 +16,7 @@ type DescribeCommand struct {
 	cmd.Base
 	manifest       manifest.Data
 	Input          fastly.GetDictionaryInput
-	json           bool
+	output         cmd.Output
 	serviceName    cmd.OptionalServiceNameID
 	serviceVersion cmd.OptionalServiceVersion
 }
@@ -32,9 +30,10 @@ func NewDescribeCommand(parent cmd.Registerer, globals *config.Data, data manife
 	c.RegisterFlagBool(cmd.BoolFlagOpts{
 		Name:        cmd.FlagJSONName,
 		Description: cmd.FlagJSONDesc,
-		Dst:         &c.json,
+		Dst:         &c.output.JSON,
 		Short:       'j',
 	})
+	c.RegisterOutputFlags(&c.output)
 	c.RegisterFlag(cmd.StringFlagOpts{
 		Name:        cmd.FlagServiceIDName,
 		Description: cmd.FlagServiceIDDesc,
@@ -58,11 +57,19 @@ func NewDescribeCommand(parent cmd.Registerer, globals *config.Data, data manife
 }
 
 // Exec invokes the application logic for the command.
-func (c *DescribeCommand) Exec(_ io.Reader, out io.Writer) error {
-	if c.Globals.Verbose() && c.json {
+func (c *DescribeCommand) Exec(_ io.Reader, out io.Writer) (err error) {
+	if c.Globals.Verbose() && c.output.Structured() {
 		return fsterr.ErrInvalidVerboseJSONCombo
 	}
 
+	out, closeOutput, err := c.output.Writer(out)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err = closeOutput(err)
+	}()
+
 	serviceID, serviceVersion, err := cmd.ServiceDetails(cmd.ServiceDetailsOpts{
 		AllowActiveLocked:  true,
 		APIClient:          c.Globals.APIClient,
@@ -96,7 +103,7 @@ func (c *DescribeCommand) Exec(_ io.Reader, out io.Writer) error {
 		info  *fastly.DictionaryInfo
 		items []*fastly.DictionaryItem
 	)
-	if c.Globals.Verbose() || c.json {
+	if c.Globals.Verbose() || c.output.Structured() {
 		infoInput := fastly.GetDictionaryInfoInput{
 			ServiceID:      c.Input.ServiceID,
 			ServiceVersion: c.Input.ServiceVersion,
@@ -124,23 +131,19 @@ func (c *DescribeCommand) Exec(_ io.Reader, out io.Writer) error {
 		}
 	}
 
-	if c.json {
+	if c.output.Structured() {
 		// NOTE: When not using JSON you have to provide the --verbose flag to get
-		// some extra information about the dictionary. When using --json we go
-		// ahead and acquire that info and combine it into the JSON output.
+		// some extra information about the dictionary. When using --json (or
+		// --format) we go ahead and acquire that info and combine it into the
+		// output.
 		type container struct {
 			*fastly.Dictionary
 			*fastly.DictionaryInfo
 			Items []*fastly.DictionaryItem
 		}
-		data, err := json.Marshal(&container{Dictionary: dictionary, DictionaryInfo: info, Items: items})
-		if err != nil {
-			return err
-		}
-		_, err = out.Write(data)
-		if err != nil {
+		if err := c.output.Write(out, &container{Dictionary: dictionary, DictionaryInfo: info, Items: items}); err != nil {
 			c.Globals.ErrLog.Add(err)
-			return fmt.Errorf("error: unable to write data to stdout: %w", err)
+			return err
 		}
 		return nil
 	}
@@ -3,6 +3,8 @@ package dictionary_test
 import (
 	"bytes"
 	"errors"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
@@ -298,6 +300,176 @@ func TestUpdateDictionary(t *testing.T) {
 	}
 }
 
+func TestDiffDictionary(t *testing.T) {
+	args := testutil.Args
+
+	dir := t.TempDir()
+	file := filepath.Join(dir, "items.json")
+	if err := os.WriteFile(file, []byte(`{"foo": "updated", "new": "value"}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	same := filepath.Join(dir, "same.json")
+	if err := os.WriteFile(same, []byte(`{"foo": "bar", "old": "value"}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	invalid := filepath.Join(dir, "invalid.json")
+	if err := os.WriteFile(invalid, []byte(`{"foo": 1}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	// NOTE: The "old" item is on the second page.
+	listItems := func(i *fastly.ListDictionaryItemsInput) fastly.PaginatorDictionaryItems {
+		items, _ := listDictionaryItemsOK(i)
+		return &mockDictionaryItemPages{pages: [][]*fastly.DictionaryItem{
+			items,
+			{{ItemKey: "old", ItemValue: "value"}},
+		}}
+	}
+	describeWriteOnly := func(i *fastly.GetDictionaryInput) (*fastly.Dictionary, error) {
+		d, err := describeDictionaryOK(i)
+		d.WriteOnly = true
+		return d, err
+	}
+	var batches []*fastly.BatchModifyDictionaryItemsInput
+	batchModify := func(i *fastly.BatchModifyDictionaryItemsInput) error {
+		batches = append(batches, i)
+		return nil
+	}
+
+	scenarios := []struct {
+		args        []string
+		api         mock.API
+		wantBatches [][]*fastly.BatchDictionaryItem
+		wantError   string
+		wantOutput  []string
+	}{
+		{
+			args:      args("dictionary diff --version 1 --service-id 123 --name dict-1"),
+			wantError: "error parsing arguments: required flag --file not provided",
+		},
+		{
+			args:      args("dictionary diff --version 1 --service-id 123 --name dict-1 --file " + invalid),
+			wantError: "error parsing --file",
+		},
+		{
+			args: args("dictionary diff --version 1 --service-id 123 --name dict-1 --file " + same),
+			api: mock.API{
+				ListVersionsFn:                    testutil.ListVersions,
+				GetDictionaryFn:                   describeDictionaryOK,
+				NewListDictionaryItemsPaginatorFn: listItems,
+			},
+			wantOutput: []string{"The items of dictionary 'dict-1' match " + same},
+		},
+		{
+			args: args("dictionary diff --version 1 --service-id 123 --name dict-1 --file " + file),
+			api: mock.API{
+				ListVersionsFn:                    testutil.ListVersions,
+				GetDictionaryFn:                   describeDictionaryOK,
+				NewListDictionaryItemsPaginatorFn: listItems,
+			},
+			wantOutput: []string{
+				`~ foo: "bar" => "updated"` + "\n" + `+ new: "value"` + "\n" + `- old: "value"`,
+				"1 to add, 1 to update, 1 to delete. Set --apply",
+			},
+		},
+		{
+			args: args("dictionary diff --version 1 --service-id 123 --name dict-1 --file " + file + " --apply"),
+			api: mock.API{
+				ListVersionsFn:                    testutil.ListVersions,
+				GetDictionaryFn:                   describeDictionaryOK,
+				NewListDictionaryItemsPaginatorFn: listItems,
+				BatchModifyDictionaryItemsFn:      batchModify,
+			},
+			wantBatches: [][]*fastly.BatchDictionaryItem{{
+				{Operation: fastly.UpdateBatchOperation, ItemKey: "foo", ItemValue: "updated"},
+				{Operation: fastly.CreateBatchOperation, ItemKey: "new", ItemValue: "value"},
+				{Operation: fastly.DeleteBatchOperation, ItemKey: "old"},
+			}},
+			wantOutput: []string{"Updated dictionary 'dict-1' (service 123): 1 added, 1 updated, 1 deleted"},
+		},
+		{
+			args: args("dictionary diff --version 1 --service-id 123 --name dict-1 --file " + same),
+			api: mock.API{
+				ListVersionsFn:                    testutil.ListVersions,
+				GetDictionaryFn:                   describeWriteOnly,
+				NewListDictionaryItemsPaginatorFn: listItems,
+			},
+			wantOutput: []string{
+				"The values of the write-only dictionary 'dict-1' can't be read",
+				"The keys of dictionary 'dict-1' match " + same,
+			},
+		},
+		{
+			args: args("dictionary diff --version 1 --service-id 123 --name dict-1 --file " + same + " --apply"),
+			api: mock.API{
+				ListVersionsFn:                    testutil.ListVersions,
+				GetDictionaryFn:                   describeWriteOnly,
+				NewListDictionaryItemsPaginatorFn: listItems,
+				BatchModifyDictionaryItemsFn:      batchModify,
+			},
+			wantBatches: [][]*fastly.BatchDictionaryItem{{
+				{Operation: fastly.UpsertBatchOperation, ItemKey: "foo", ItemValue: "bar"},
+				{Operation: fastly.UpsertBatchOperation, ItemKey: "old", ItemValue: "value"},
+			}},
+			wantOutput: []string{"Updated dictionary 'dict-1' (service 123): 0 added, 2 updated, 0 deleted"},
+		},
+		{
+			args: args("dictionary diff --version 1 --service-id 123 --name dict-1 --file " + file + " --apply"),
+			api: mock.API{
+				ListVersionsFn:                    testutil.ListVersions,
+				GetDictionaryFn:                   describeWriteOnly,
+				NewListDictionaryItemsPaginatorFn: listItems,
+				BatchModifyDictionaryItemsFn:      batchModify,
+			},
+			wantBatches: [][]*fastly.BatchDictionaryItem{{
+				{Operation: fastly.CreateBatchOperation, ItemKey: "new", ItemValue: "value"},
+				{Operation: fastly.DeleteBatchOperation, ItemKey: "old"},
+				{Operation: fastly.UpsertBatchOperation, ItemKey: "foo", ItemValue: "updated"},
+			}},
+			wantOutput: []string{"Updated dictionary 'dict-1' (service 123): 1 added, 1 updated, 1 deleted"},
+		},
+	}
+	for testcaseIdx := range scenarios {
+		testcase := &scenarios[testcaseIdx]
+		t.Run(strings.Join(testcase.args, " "), func(t *testing.T) {
+			batches = nil
+			var stdout bytes.Buffer
+			opts := testutil.NewRunOpts(testcase.args, &stdout)
+			opts.APIClient = mock.APIClient(testcase.api)
+			err := app.Run(opts)
+			testutil.AssertErrorContains(t, err, testcase.wantError)
+			for _, s := range testcase.wantOutput {
+				testutil.AssertStringContains(t, stdout.String(), s)
+			}
+			var items [][]*fastly.BatchDictionaryItem
+			for _, b := range batches {
+				items = append(items, b.Items)
+			}
+			testutil.AssertEqual(t, testcase.wantBatches, items)
+		})
+	}
+}
+
+// mockDictionaryItemPages is a dictionary items paginator that returns each of
+// its pages in turn.
+type mockDictionaryItemPages struct {
+	pages [][]*fastly.DictionaryItem
+}
+
+func (p *mockDictionaryItemPages) HasNext() bool {
+	return len(p.pages) > 0
+}
+
+func (p *mockDictionaryItemPages) Remaining() int {
+	return len(p.pages)
+}
+
+func (p *mockDictionaryItemPages) GetNext() ([]*fastly.DictionaryItem, error) {
+	page := p.pages[0]
+	p.pages = p.pages[1:]
+	return page, nil
+}
+
 func describeDictionaryOK(i *fastly.GetDictionaryInput) (*fastly.Dictionary, error) {
 	return &fastly.Dictionary{
 		ServiceID:      i.ServiceID,
@@ -0,0 +1,248 @@
+package dictionary
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/fastly/cli/pkg/cmd"
+	"github.com/fastly/cli/pkg/commands/dictionaryitem"
+	"github.com/fastly/cli/pkg/config"
+	fsterr "github.com/fastly/cli/pkg/errors"
+	"github.com/fastly/cli/pkg/manifest"
+	"github.com/fastly/cli/pkg/text"
+	"github.com/fastly/go-fastly/v6/fastly"
+)
+
+// DiffCommand compares a local file of items with the items of a dictionary.
+type DiffCommand struct {
+	cmd.Base
+	apply          bool
+	file           string
+	manifest       manifest.Data
+	Input          fastly.GetDictionaryInput
+	revert         bool
+	serviceName    cmd.OptionalServiceNameID
+	serviceVersion cmd.OptionalServiceVersion
+}
+
+// NewDiffCommand returns a usable command registered under the parent.
+func NewDiffCommand(parent cmd.Registerer, globals *config.Data, data manifest.Data) *DiffCommand {
+	var c DiffCommand
+	c.Globals = globals
+	c.manifest = data
+	c.CmdClause = parent.Command("diff", "Compare a local file of items with the items of a Fastly edge dictionary")
+	c.CmdClause.Flag("apply", "Change the items of the dictionary to match the file").BoolVar(&c.apply)
+	c.CmdClause.Flag("file", `Path to a JSON file of the items' keys and values, e.g. {"key": "value"}`).Required().StringVar(&c.file)
+	c.CmdClause.Flag("name", "Name of Dictionary").Short('n').Required().StringVar(&c.Input.Name)
+	c.CmdClause.Flag(dictionaryitem.RevertFlagName, dictionaryitem.RevertFlagDesc+" (with --apply)").BoolVar(&c.revert)
+	c.RegisterFlag(cmd.StringFlagOpts{
+		Name:        cmd.FlagServiceIDName,
+		Description: cmd.FlagServiceIDDesc,
+		Dst:         &c.manifest.Flag.ServiceID,
+		Short:       's',
+	})
+	c.RegisterFlag(cmd.StringFlagOpts{
+		Action:      c.serviceName.Set,
+		Name:        cmd.FlagServiceName,
+		Description: cmd.FlagServiceDesc,
+		Dst:         &c.serviceName.Value,
+	})
+	c.RegisterFlag(cmd.StringFlagOpts{
+		Name:        cmd.FlagVersionName,
+		Description: cmd.FlagVersionDesc,
+		Dst:         &c.serviceVersion.Value,
+		Required:    true,
+	})
+	return &c
+}
+
+// Exec invokes the application logic for the command.
+func (c *DiffCommand) Exec(_ io.Reader, out io.Writer) error {
+	local, err := readItemsFile(c.file)
+	if err != nil {
+		c.Globals.ErrLog.Add(err)
+		return err
+	}
+
+	serviceID, serviceVersion, err := cmd.ServiceDetails(cmd.ServiceDetailsOpts{
+		AllowActiveLocked:  true,
+		APIClient:          c.Globals.APIClient,
+		Manifest:           c.manifest,
+		Out:                out,
+		ServiceNameFlag:    c.serviceName,
+		ServiceVersionFlag: c.serviceVersion,
+		VerboseMode:        c.Globals.Flag.Verbose,
+	})
+	if err != nil {
+		c.Globals.ErrLog.AddWithContext(err, map[string]any{
+			"Service ID":      serviceID,
+			"Service Version": fsterr.ServiceVersion(serviceVersion),
+		})
+		return err
+	}
+
+	c.Input.ServiceID = serviceID
+	c.Input.ServiceVersion = serviceVersion.Number
+
+	dictionary, err := c.Globals.APIClient.GetDictionary(&c.Input)
+	if err != nil {
+		c.Globals.ErrLog.AddWithContext(err, map[string]any{
+			"Service ID":      serviceID,
+			"Service Version": serviceVersion.Number,
+		})
+		return err
+	}
+
+	remote, err := dictionaryitem.ListItems(c.Globals.APIClient, serviceID, dictionary.ID)
+	if err != nil {
+		c.Globals.ErrLog.AddWithContext(err, map[string]any{
+			"Service ID":    serviceID,
+			"Dictionary ID": dictionary.ID,
+		})
+		return err
+	}
+
+	// NOTE: The values of a write-only dictionary aren't returned by the API, so
+	// only its keys can be compared. The values of the keys in both the file and
+	// the dictionary are instead set by --apply.
+	var unchecked []*fastly.BatchDictionaryItem
+	if dictionary.WriteOnly {
+		text.Warning(out, "The values of the write-only dictionary '%s' can't be read, so only its keys are compared. --apply also sets the values of the keys that are already in the dictionary.", dictionary.Name)
+		text.Break(out)
+		unchecked = upsertShared(local, remote)
+	}
+	changes := diffItems(local, remote, dictionary.WriteOnly)
+
+	if len(changes) == 0 && (!c.apply || len(unchecked) == 0) {
+		compared := "items"
+		if dictionary.WriteOnly {
+			compared = "keys"
+		}
+		text.Success(out, "The %s of dictionary '%s' match %s", compared, dictionary.Name, c.file)
+		return nil
+	}
+
+	var adds, updates, deletes int
+	for _, ch := range changes {
+		switch ch.Operation {
+		case fastly.CreateBatchOperation:
+			adds++
+			fmt.Fprintln(out, text.BoldGreen(fmt.Sprintf("+ %s: %q", ch.ItemKey, ch.ItemValue)))
+		case fastly.UpdateBatchOperation:
+			updates++
+			fmt.Fprintln(out, text.BoldYellow(fmt.Sprintf("~ %s: %q => %q", ch.ItemKey, remote[ch.ItemKey], ch.ItemValue)))
+		case fastly.DeleteBatchOperation:
+			deletes++
+			if dictionary.WriteOnly {
+				fmt.Fprintln(out, text.BoldRed("- "+ch.ItemKey))
+				continue
+			}
+			fmt.Fprintln(out, text.BoldRed(fmt.Sprintf("- %s: %q", ch.ItemKey, remote[ch.ItemKey])))
+		}
+	}
+	text.Break(out)
+	summary := fmt.Sprintf("%d to add, %d to update, %d to delete", adds, updates, deletes)
+
+	if !c.apply {
+		text.Info(out, "%s. Set --apply to change the items of dictionary '%s' to match %s.", summary, dictionary.Name, c.file)
+		return nil
+	}
+
+	if c.revert && dictionary.WriteOnly {
+		return fsterr.RemediationError{
+			Inner:       fmt.Errorf("--%s can't be used with the write-only dictionary '%s', as its values can't be read to revert to", dictionaryitem.RevertFlagName, dictionary.Name),
+			Remediation: fmt.Sprintf("Remove --%s.", dictionaryitem.RevertFlagName),
+		}
+	}
+
+	changes = append(changes, unchecked...)
+	updates += len(unchecked)
+
+	opts := dictionaryitem.BatchOptions{Revert: c.revert}
+	if c.Globals.Verbose() {
+		opts.Progress = out
+	}
+	err = dictionaryitem.BatchModify(c.Globals.APIClient, &fastly.BatchModifyDictionaryItemsInput{
+		ServiceID:    serviceID,
+		DictionaryID: dictionary.ID,
+		Items:        changes,
+	}, opts)
+	if err != nil {
+		c.Globals.ErrLog.AddWithContext(err, map[string]any{
+			"Service ID":    serviceID,
+			"Dictionary ID": dictionary.ID,
+		})
+		var batchErr *dictionaryitem.BatchError
+		if errors.As(err, &batchErr) && !batchErr.Reverted {
+			return fsterr.RemediationError{
+				Inner:       err,
+				Remediation: fmt.Sprintf("Run the command again to apply the remaining changes, or set --%s to leave the dictionary unchanged when a change fails.", dictionaryitem.RevertFlagName),
+			}
+		}
+		return err
+	}
+
+	text.Success(out, "Updated dictionary '%s' (service %s): %d added, %d updated, %d deleted", dictionary.Name, serviceID, adds, updates, deletes)
+	return nil
+}
+
+// upsertShared returns the batch operations that set the values of the local
+// items whose keys are also remote, ordered by key.
+func upsertShared(local, remote map[string]string) []*fastly.BatchDictionaryItem {
+	var ops []*fastly.BatchDictionaryItem
+	for k, v := range local {
+		if _, ok := remote[k]; ok {
+			ops = append(ops, &fastly.BatchDictionaryItem{Operation: fastly.UpsertBatchOperation, ItemKey: k, ItemValue: v})
+		}
+	}
+	sort.Slice(ops, func(i, j int) bool {
+		return ops[i].ItemKey < ops[j].ItemKey
+	})
+	return ops
+}
+
+// readItemsFile reads a JSON file of the keys and values of dictionary items.
+func readItemsFile(path string) (map[string]string, error) {
+	data, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return nil, fmt.Errorf("error reading --file: %w", err)
+	}
+	var items map[string]string
+	if err := json.Unmarshal(data, &items); err != nil {
+		return nil, fsterr.RemediationError{
+			Inner:       fmt.Errorf("error parsing --file '%s': %w", path, err),
+			Remediation: `The file must be a JSON object of the items' keys and string values, e.g. {"key": "value"}.`,
+		}
+	}
+	return items, nil
+}
+
+// diffItems returns the batch operations that change the remote items to
+// match the local items, ordered by key. Values aren't compared when
+// keysOnly is set.
+func diffItems(local, remote map[string]string, keysOnly bool) []*fastly.BatchDictionaryItem {
+	var changes []*fastly.BatchDictionaryItem
+	for k, v := range local {
+		rv, ok := remote[k]
+		switch {
+		case !ok:
+			changes = append(changes, &fastly.BatchDictionaryItem{Operation: fastly.CreateBatchOperation, ItemKey: k, ItemValue: v})
+		case rv != v && !keysOnly:
+			changes = append(changes, &fastly.BatchDictionaryItem{Operation: fastly.UpdateBatchOperation, ItemKey: k, ItemValue: v})
+		}
+	}
+	for k := range remote {
+		if _, ok := local[k]; !ok {
+			changes = append(changes, &fastly.BatchDictionaryItem{Operation: fastly.DeleteBatchOperation, ItemKey: k})
+		}
+	}
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].ItemKey < changes[j].ItemKey
+	})
+	return changes
+}
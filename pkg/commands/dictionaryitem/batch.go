@@ -0,0 +1,163 @@
+package dictionaryitem
+
+import (
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/fastly/cli/pkg/api"
+	"github.com/fastly/cli/pkg/text"
+	"github.com/fastly/go-fastly/v6/fastly"
+)
+
+// RevertFlagDesc describes the flag that sets BatchOptions.Revert.
+const RevertFlagDesc = "When a chunk of operations fails, revert the chunks already applied so the dictionary is left unchanged"
+
+// RevertFlagName is the name of the flag that sets BatchOptions.Revert.
+const RevertFlagName = "revert-on-failure"
+
+// BatchOptions configures BatchModify.
+type BatchOptions struct {
+	// ChunkSize is the most operations sent in one request. It defaults to, and
+	// can't exceed, the API's limit.
+	ChunkSize int
+	// Progress receives a line for each chunk applied, if set.
+	Progress io.Writer
+	// Revert undoes the chunks already applied when a chunk fails.
+	//
+	// NOTE: The items are read before any chunk is applied so that they can be
+	// restored, which isn't possible for a write-only dictionary as the API
+	// doesn't return its values.
+	Revert bool
+}
+
+// BatchError describes a BatchModify that failed after some of its chunks were
+// applied.
+type BatchError struct {
+	// Applied is the number of operations applied before the failure.
+	Applied int
+	// Err is the error of the chunk that failed.
+	Err error
+	// RevertErr is the error of reverting the applied operations, if any.
+	RevertErr error
+	// Reverted reports whether the applied operations were reverted.
+	Reverted bool
+	// Total is the number of operations.
+	Total int
+
+	failed int // operations of the chunk that failed
+}
+
+// Error implements the error interface.
+func (e *BatchError) Error() string {
+	msg := fmt.Sprintf("error applying operations %d-%d of %d: %s", e.Applied+1, e.Applied+e.failed, e.Total, e.Err)
+	switch {
+	case e.Reverted:
+		return msg + fmt.Sprintf(" (the %d operations applied before the failure were reverted)", e.Applied)
+	case e.RevertErr != nil:
+		return msg + fmt.Sprintf(" (the %d operations applied before the failure couldn't be reverted: %s)", e.Applied, e.RevertErr)
+	default:
+		return msg + fmt.Sprintf(" (the %d operations before them were applied)", e.Applied)
+	}
+}
+
+// Unwrap returns the error of the chunk that failed.
+func (e *BatchError) Unwrap() error {
+	return e.Err
+}
+
+// BatchModify applies the operations of the input in chunks, as the API limits
+// the number of operations of a request.
+//
+// The API applies each chunk atomically, but not the chunks as a whole: when
+// the first chunk fails its error is returned, and when a later one fails a
+// *BatchError reports how many operations were applied (or reverted, with
+// BatchOptions.Revert).
+func BatchModify(client api.Interface, input *fastly.BatchModifyDictionaryItemsInput, opts BatchOptions) error {
+	size := opts.ChunkSize
+	if size <= 0 || size > fastly.BatchModifyMaximumOperations {
+		size = fastly.BatchModifyMaximumOperations
+	}
+
+	var previous map[string]string
+	if opts.Revert && len(input.Items) > size {
+		var err error
+		previous, err = ListItems(client, input.ServiceID, input.DictionaryID)
+		if err != nil {
+			return fmt.Errorf("error reading the dictionary items to revert to: %w", err)
+		}
+	}
+
+	total := len(input.Items)
+	for applied := 0; applied < total; applied += size {
+		end := applied + size
+		if end > total {
+			end = total
+		}
+		err := client.BatchModifyDictionaryItems(&fastly.BatchModifyDictionaryItemsInput{
+			ServiceID:    input.ServiceID,
+			DictionaryID: input.DictionaryID,
+			Items:        input.Items[applied:end],
+		})
+		if err != nil {
+			if applied == 0 {
+				return err
+			}
+			e := &BatchError{Applied: applied, Err: err, Total: total, failed: end - applied}
+			if opts.Revert {
+				revert := revertOperations(previous, input.Items[:applied])
+				if e.RevertErr = BatchModify(client, &fastly.BatchModifyDictionaryItemsInput{
+					ServiceID:    input.ServiceID,
+					DictionaryID: input.DictionaryID,
+					Items:        revert,
+				}, BatchOptions{ChunkSize: size}); e.RevertErr == nil {
+					e.Reverted = true
+				}
+			}
+			return e
+		}
+		if opts.Progress != nil && total > size {
+			text.Output(opts.Progress, "Applied operations %d-%d of %d", applied+1, end, total)
+		}
+	}
+	return nil
+}
+
+// revertOperations returns the operations that restore the previous items
+// after the applied operations.
+func revertOperations(previous map[string]string, applied []*fastly.BatchDictionaryItem) []*fastly.BatchDictionaryItem {
+	current := make(map[string]string, len(previous))
+	for k, v := range previous {
+		current[k] = v
+	}
+	touched := make(map[string]bool)
+	for _, op := range applied {
+		touched[op.ItemKey] = true
+		if op.Operation == fastly.DeleteBatchOperation {
+			delete(current, op.ItemKey)
+			continue
+		}
+		current[op.ItemKey] = op.ItemValue
+	}
+
+	keys := make([]string, 0, len(touched))
+	for k := range touched {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var ops []*fastly.BatchDictionaryItem
+	for _, k := range keys {
+		pv, existed := previous[k]
+		cv, exists := current[k]
+		switch {
+		case existed && !exists:
+			ops = append(ops, &fastly.BatchDictionaryItem{Operation: fastly.CreateBatchOperation, ItemKey: k, ItemValue: pv})
+		case existed && cv != pv:
+			ops = append(ops, &fastly.BatchDictionaryItem{Operation: fastly.UpdateBatchOperation, ItemKey: k, ItemValue: pv})
+		case !existed && exists:
+			ops = append(ops, &fastly.BatchDictionaryItem{Operation: fastly.DeleteBatchOperation, ItemKey: k})
+		}
+	}
+	return ops
+}
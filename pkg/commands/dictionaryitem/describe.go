@@ -1,7 +1,6 @@
 package dictionaryitem
 
 import (
-	"encoding/json"
 	"fmt"
 	"io"
 
@@ -18,7 +17,7 @@ type DescribeCommand struct {
 	cmd.Base
 	manifest    manifest.Data
 	Input       fastly.GetDictionaryItemInput
-	json        bool
+	output      cmd.Output
 	serviceName cmd.OptionalServiceNameID
 }
 
@@ -31,9 +30,10 @@ func NewDescribeCommand(parent cmd.Registerer, globals *config.Data, data manife
 	c.RegisterFlagBool(cmd.BoolFlagOpts{
 		Name:        cmd.FlagJSONName,
 		Description: cmd.FlagJSONDesc,
-		Dst:         &c.json,
+		Dst:         &c.output.JSON,
 		Short:       'j',
 	})
+	c.RegisterOutputFlags(&c.output)
 	c.RegisterFlag(cmd.StringFlagOpts{
 		Name:        cmd.FlagServiceIDName,
 		Description: cmd.FlagServiceIDDesc,
@@ -52,11 +52,19 @@ func NewDescribeCommand(parent cmd.Registerer, globals *config.Data, data manife
 }
 
 // Exec invokes the application logic for the command.
-func (c *DescribeCommand) Exec(_ io.Reader, out io.Writer) error {
-	if c.Globals.Verbose() && c.json {
+func (c *DescribeCommand) Exec(_ io.Reader, out io.Writer) (err error) {
+	if c.Globals.Verbose() && c.output.Structured() {
 		return fsterr.ErrInvalidVerboseJSONCombo
 	}
 
+	out, closeOutput, err := c.output.Writer(out)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err = closeOutput(err)
+	}()
+
 	serviceID, source, flag, err := cmd.ServiceID(c.serviceName, c.manifest, c.Globals.APIClient, c.Globals.ErrLog)
 	if err != nil {
 		return err
@@ -75,15 +83,10 @@ func (c *DescribeCommand) Exec(_ io.Reader, out io.Writer) error {
 		return err
 	}
 
-	if c.json {
-		data, err := json.Marshal(item)
-		if err != nil {
-			return err
-		}
-		_, err = out.Write(data)
-		if err != nil {
+	if c.output.Structured() {
+		if err := c.output.Write(out, item); err != nil {
 			c.Globals.ErrLog.Add(err)
-			return fmt.Errorf("error: unable to write data to stdout: %w", err)
+			return err
 		}
 		return nil
 	}
@@ -8,6 +8,8 @@ import (
 	"testing"
 
 	"github.com/fastly/cli/pkg/app"
+	"github.com/fastly/cli/pkg/cmd"
+	"github.com/fastly/cli/pkg/commands/dictionaryitem"
 	"github.com/fastly/cli/pkg/mock"
 	"github.com/fastly/cli/pkg/testutil"
 	"github.com/fastly/go-fastly/v6/fastly"
@@ -293,6 +295,115 @@ func TestDictionaryItemUpdate(t *testing.T) {
 	}
 }
 
+func TestBatchModify(t *testing.T) {
+	op := func(o fastly.BatchOperation, k, v string) *fastly.BatchDictionaryItem {
+		return &fastly.BatchDictionaryItem{Operation: o, ItemKey: k, ItemValue: v}
+	}
+	ops := []*fastly.BatchDictionaryItem{
+		op(fastly.CreateBatchOperation, "a", "1"),
+		op(fastly.UpdateBatchOperation, "foo", "new"),
+		op(fastly.DeleteBatchOperation, "baz", ""),
+		op(fastly.UpsertBatchOperation, "b", "2"),
+		op(fastly.CreateBatchOperation, "c", "3"),
+	}
+	// NOTE: The items are split across pages, so reverting relies on every page
+	// being read.
+	var listInputs []*fastly.ListDictionaryItemsInput
+	listItems := func(i *fastly.ListDictionaryItemsInput) fastly.PaginatorDictionaryItems {
+		listInputs = append(listInputs, i)
+		return &mockDictionaryItemPages{pages: [][]*fastly.DictionaryItem{
+			{{ItemKey: "foo", ItemValue: "old"}},
+			{{ItemKey: "baz", ItemValue: "bear"}},
+			{{ItemKey: "qux", ItemValue: "gone", DeletedAt: testutil.MustParseTimeRFC3339("2001-02-03T04:06:08Z")}},
+		}}
+	}
+
+	for _, testcase := range []struct {
+		name       string
+		failChunk  int
+		revert     bool
+		wantChunks [][]*fastly.BatchDictionaryItem
+		wantError  string
+	}{
+		{
+			name:       "applies every chunk",
+			wantChunks: [][]*fastly.BatchDictionaryItem{ops[:2], ops[2:4], ops[4:]},
+		},
+		{
+			name:       "first chunk fails",
+			failChunk:  1,
+			revert:     true,
+			wantChunks: [][]*fastly.BatchDictionaryItem{ops[:2]},
+			wantError:  testutil.Err.Error(),
+		},
+		{
+			name:       "later chunk fails",
+			failChunk:  3,
+			wantChunks: [][]*fastly.BatchDictionaryItem{ops[:2], ops[2:4], ops[4:]},
+			wantError:  "error applying operations 5-5 of 5: test error (the 4 operations before them were applied)",
+		},
+		{
+			name:      "later chunk fails and is reverted",
+			failChunk: 3,
+			revert:    true,
+			wantChunks: [][]*fastly.BatchDictionaryItem{ops[:2], ops[2:4], ops[4:], {
+				op(fastly.DeleteBatchOperation, "a", ""),
+				op(fastly.DeleteBatchOperation, "b", ""),
+			}, {
+				op(fastly.CreateBatchOperation, "baz", "bear"),
+				op(fastly.UpdateBatchOperation, "foo", "old"),
+			}},
+			wantError: "error applying operations 5-5 of 5: test error (the 4 operations applied before the failure were reverted)",
+		},
+	} {
+		t.Run(testcase.name, func(t *testing.T) {
+			listInputs = nil
+			var chunks [][]*fastly.BatchDictionaryItem
+			client := mock.API{
+				NewListDictionaryItemsPaginatorFn: listItems,
+				BatchModifyDictionaryItemsFn: func(i *fastly.BatchModifyDictionaryItemsInput) error {
+					chunks = append(chunks, i.Items)
+					if len(chunks) == testcase.failChunk {
+						return testutil.Err
+					}
+					return nil
+				},
+			}
+			err := dictionaryitem.BatchModify(client, &fastly.BatchModifyDictionaryItemsInput{
+				ServiceID:    "123",
+				DictionaryID: "456",
+				Items:        ops,
+			}, dictionaryitem.BatchOptions{ChunkSize: 2, Revert: testcase.revert})
+			testutil.AssertErrorContains(t, err, testcase.wantError)
+			testutil.AssertEqual(t, testcase.wantChunks, chunks)
+			if testcase.revert {
+				testutil.AssertEqual(t, 1, len(listInputs))
+				testutil.AssertEqual(t, cmd.MaxPerPage, listInputs[0].PerPage)
+			}
+		})
+	}
+}
+
+// mockDictionaryItemPages is a dictionary items paginator that returns each of
+// its pages in turn.
+type mockDictionaryItemPages struct {
+	pages [][]*fastly.DictionaryItem
+}
+
+func (p *mockDictionaryItemPages) HasNext() bool {
+	return len(p.pages) > 0
+}
+
+func (p *mockDictionaryItemPages) Remaining() int {
+	return len(p.pages)
+}
+
+func (p *mockDictionaryItemPages) GetNext() ([]*fastly.DictionaryItem, error) {
+	page := p.pages[0]
+	p.pages = p.pages[1:]
+	return page, nil
+}
+
 func TestDictionaryItemDelete(t *testing.T) {
 	args := testutil.Args
 	scenarios := []struct {
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"io"
 
+	"github.com/fastly/cli/pkg/api"
 	"github.com/fastly/cli/pkg/cmd"
 	"github.com/fastly/cli/pkg/config"
 	fsterr "github.com/fastly/cli/pkg/errors"
@@ -19,6 +20,7 @@ type ListCommand struct {
 	manifest    manifest.Data
 	input       fastly.ListDictionaryItemsInput
 	json        bool
+	pagination  cmd.Pagination
 	serviceName cmd.OptionalServiceNameID
 }
 
@@ -28,6 +30,7 @@ func NewListCommand(parent cmd.Registerer, globals *config.Data, data manifest.D
 	c.Globals = globals
 	c.manifest = data
 	c.CmdClause = parent.Command("list", "List items in a Fastly edge dictionary")
+	c.RegisterAllFlag(&c.pagination)
 	c.CmdClause.Flag("dictionary-id", "Dictionary ID").Required().StringVar(&c.input.DictionaryID)
 	c.CmdClause.Flag("direction", "Direction in which to sort results").Default(cmd.PaginationDirection[0]).HintOptions(cmd.PaginationDirection...).EnumVar(&c.input.Direction, cmd.PaginationDirection...)
 	c.RegisterFlagBool(cmd.BoolFlagOpts{
@@ -36,8 +39,7 @@ func NewListCommand(parent cmd.Registerer, globals *config.Data, data manifest.D
 		Dst:         &c.json,
 		Short:       'j',
 	})
-	c.CmdClause.Flag("page", "Page number of data set to fetch").IntVar(&c.input.Page)
-	c.CmdClause.Flag("per-page", "Number of records per page").IntVar(&c.input.PerPage)
+	c.RegisterPageFlags(&c.pagination)
 	c.RegisterFlag(cmd.StringFlagOpts{
 		Name:        cmd.FlagServiceIDName,
 		Description: cmd.FlagServiceIDDesc,
@@ -59,6 +61,9 @@ func (c *ListCommand) Exec(_ io.Reader, out io.Writer) error {
 	if c.Globals.Verbose() && c.json {
 		return fsterr.ErrInvalidVerboseJSONCombo
 	}
+	if err := c.pagination.Validate(); err != nil {
+		return err
+	}
 
 	serviceID, source, flag, err := cmd.ServiceID(c.serviceName, c.manifest, c.Globals.APIClient, c.Globals.ErrLog)
 	if err != nil {
@@ -68,21 +73,19 @@ func (c *ListCommand) Exec(_ io.Reader, out io.Writer) error {
 		cmd.DisplayServiceID(serviceID, flag, source, out)
 	}
 
+	c.input.Page = c.pagination.Page
+	c.input.PerPage = c.pagination.PerPage
 	c.input.ServiceID = serviceID
 	paginator := c.Globals.APIClient.NewListDictionaryItemsPaginator(&c.input)
 
-	var ds []*fastly.DictionaryItem
-	for paginator.HasNext() {
-		data, err := paginator.GetNext()
-		if err != nil {
-			c.Globals.ErrLog.AddWithContext(err, map[string]any{
-				"Dictionary ID":   c.input.DictionaryID,
-				"Service ID":      serviceID,
-				"Remaining Pages": paginator.Remaining(),
-			})
-			return err
-		}
-		ds = append(ds, data...)
+	ds, err := cmd.Paginate[*fastly.DictionaryItem](paginator, c.pagination)
+	if err != nil {
+		c.Globals.ErrLog.AddWithContext(err, map[string]any{
+			"Dictionary ID":   c.input.DictionaryID,
+			"Service ID":      serviceID,
+			"Remaining Pages": paginator.Remaining(),
+		})
+		return err
 	}
 
 	if c.json {
@@ -109,3 +112,23 @@ func (c *ListCommand) Exec(_ io.Reader, out io.Writer) error {
 
 	return nil
 }
+
+// ListItems returns the keys and values of the items of a dictionary, from
+// every page of them. Deleted items are omitted.
+func ListItems(client api.Interface, serviceID, dictionaryID string) (map[string]string, error) {
+	items, err := cmd.Paginate[*fastly.DictionaryItem](client.NewListDictionaryItemsPaginator(&fastly.ListDictionaryItemsInput{
+		ServiceID:    serviceID,
+		DictionaryID: dictionaryID,
+		PerPage:      cmd.MaxPerPage,
+	}), cmd.Pagination{})
+	if err != nil {
+		return nil, err
+	}
+	m := make(map[string]string, len(items))
+	for _, i := range items {
+		if i.DeletedAt == nil {
+			m[i.ItemKey] = i.ItemValue
+		}
+	}
+	return m, nil
+}
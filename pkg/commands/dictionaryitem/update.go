@@ -2,12 +2,14 @@ package dictionaryitem
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"os"
 
 	"github.com/fastly/cli/pkg/cmd"
 	"github.com/fastly/cli/pkg/config"
+	fsterr "github.com/fastly/cli/pkg/errors"
 	"github.com/fastly/cli/pkg/manifest"
 	"github.com/fastly/cli/pkg/text"
 	"github.com/fastly/go-fastly/v6/fastly"
@@ -21,6 +23,7 @@ type UpdateCommand struct {
 	InputBatch  fastly.BatchModifyDictionaryItemsInput
 	file        cmd.OptionalString
 	manifest    manifest.Data
+	revert      bool
 	serviceName cmd.OptionalServiceNameID
 }
 
@@ -33,6 +36,7 @@ func NewUpdateCommand(parent cmd.Registerer, globals *config.Data, data manifest
 	c.CmdClause.Flag("dictionary-id", "Dictionary ID").Required().StringVar(&c.Input.DictionaryID)
 	c.CmdClause.Flag("file", "Batch update json file").Action(c.file.Set).StringVar(&c.file.Value)
 	c.CmdClause.Flag("key", "Dictionary item key").StringVar(&c.Input.ItemKey)
+	c.CmdClause.Flag(RevertFlagName, RevertFlagDesc+" (with --file)").BoolVar(&c.revert)
 	c.RegisterFlag(cmd.StringFlagOpts{
 		Name:        cmd.FlagServiceIDName,
 		Description: cmd.FlagServiceIDDesc,
@@ -111,9 +115,20 @@ func (c *UpdateCommand) batchModify(out io.Writer) error {
 		return fmt.Errorf("item key not found in file %s", c.file.Value)
 	}
 
-	err = c.Globals.APIClient.BatchModifyDictionaryItems(&c.InputBatch)
+	opts := BatchOptions{Revert: c.revert}
+	if c.Globals.Verbose() {
+		opts.Progress = out
+	}
+	err = BatchModify(c.Globals.APIClient, &c.InputBatch, opts)
 	if err != nil {
 		c.Globals.ErrLog.Add(err)
+		var batchErr *BatchError
+		if errors.As(err, &batchErr) && !batchErr.Reverted {
+			return fsterr.RemediationError{
+				Inner:       err,
+				Remediation: fmt.Sprintf("Remove the applied operations from %s before retrying, or set --%s to leave the dictionary unchanged when an operation fails.", c.file.Value, RevertFlagName),
+			}
+		}
 		return err
 	}
 
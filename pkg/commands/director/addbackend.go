@@ -0,0 +1,130 @@
+package director
+
+import (
+	"io"
+
+	"github.com/fastly/cli/pkg/cmd"
+	"github.com/fastly/cli/pkg/config"
+	"github.com/fastly/cli/pkg/errors"
+	"github.com/fastly/cli/pkg/manifest"
+	"github.com/fastly/cli/pkg/text"
+	"github.com/fastly/go-fastly/v6/fastly"
+)
+
+// NewAddBackendCommand returns a usable command registered under the parent.
+func NewAddBackendCommand(parent cmd.Registerer, globals *config.Data, data manifest.Data) *AddBackendCommand {
+	var c AddBackendCommand
+	c.CmdClause = parent.Command("add-backend", "Add a backend to a director on a Fastly service version")
+	c.Globals = globals
+	c.manifest = data
+
+	// Required flags
+	c.CmdClause.Flag("backend", "Name of the backend to add").Required().StringVar(&c.backend)
+	c.CmdClause.Flag("name", "Name of the director").Short('n').Required().StringVar(&c.name)
+	c.RegisterFlag(cmd.StringFlagOpts{
+		Name:        cmd.FlagVersionName,
+		Description: cmd.FlagVersionDesc,
+		Dst:         &c.serviceVersion.Value,
+		Required:    true,
+	})
+
+	// Optional flags
+	c.RegisterAutoCloneFlag(cmd.AutoCloneFlagOpts{
+		Action: c.autoClone.Set,
+		Dst:    &c.autoClone.Value,
+	})
+	c.RegisterFlag(cmd.StringFlagOpts{
+		Name:        cmd.FlagServiceIDName,
+		Description: cmd.FlagServiceIDDesc,
+		Dst:         &c.manifest.Flag.ServiceID,
+		Short:       's',
+	})
+	c.RegisterFlag(cmd.StringFlagOpts{
+		Action:      c.serviceName.Set,
+		Name:        cmd.FlagServiceName,
+		Description: cmd.FlagServiceDesc,
+		Dst:         &c.serviceName.Value,
+	})
+	c.CmdClause.Flag("weight", "Weight used by a random director to load balance this backend against others").Action(c.weight.Set).UintVar(&c.weight.Value)
+
+	return &c
+}
+
+// AddBackendCommand calls the Fastly API to associate a backend with a
+// director.
+type AddBackendCommand struct {
+	cmd.Base
+
+	autoClone      cmd.OptionalAutoClone
+	backend        string
+	manifest       manifest.Data
+	name           string
+	serviceName    cmd.OptionalServiceNameID
+	serviceVersion cmd.OptionalServiceVersion
+	weight         cmd.OptionalUint
+}
+
+// Exec invokes the application logic for the command.
+func (c *AddBackendCommand) Exec(_ io.Reader, out io.Writer) error {
+	serviceID, serviceVersion, err := cmd.ServiceDetails(cmd.ServiceDetailsOpts{
+		AutoCloneFlag:      c.autoClone,
+		APIClient:          c.Globals.APIClient,
+		Manifest:           c.manifest,
+		Out:                out,
+		ServiceNameFlag:    c.serviceName,
+		ServiceVersionFlag: c.serviceVersion,
+		VerboseMode:        c.Globals.Flag.Verbose,
+	})
+	if err != nil {
+		c.Globals.ErrLog.AddWithContext(err, map[string]any{
+			"Service ID":      serviceID,
+			"Service Version": errors.ServiceVersion(serviceVersion),
+		})
+		return err
+	}
+
+	_, err = c.Globals.APIClient.CreateDirectorBackend(&fastly.CreateDirectorBackendInput{
+		Backend:        c.backend,
+		Director:       c.name,
+		ServiceID:      serviceID,
+		ServiceVersion: serviceVersion.Number,
+	})
+	if err != nil {
+		c.Globals.ErrLog.AddWithContext(err, map[string]any{
+			"Backend Name":    c.backend,
+			"Director Name":   c.name,
+			"Service ID":      serviceID,
+			"Service Version": serviceVersion.Number,
+		})
+		return err
+	}
+
+	if c.weight.WasSet {
+		err = setWeight(c.Globals, serviceID, serviceVersion.Number, c.backend, c.weight.Value)
+		if err != nil {
+			return err
+		}
+	}
+
+	text.Success(out, "Added backend '%s' to director '%s' (service: %s, version: %d)", c.backend, c.name, serviceID, serviceVersion.Number)
+	return nil
+}
+
+// setWeight updates the load balancing weight of a backend.
+func setWeight(globals *config.Data, serviceID string, serviceVersion int, backend string, weight uint) error {
+	_, err := globals.APIClient.UpdateBackend(&fastly.UpdateBackendInput{
+		Name:           backend,
+		ServiceID:      serviceID,
+		ServiceVersion: serviceVersion,
+		Weight:         fastly.Uint(weight),
+	})
+	if err != nil {
+		globals.ErrLog.AddWithContext(err, map[string]any{
+			"Backend Name":    backend,
+			"Service ID":      serviceID,
+			"Service Version": serviceVersion,
+			"Weight":          weight,
+		})
+	}
+	return err
+}
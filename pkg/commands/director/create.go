@@ -0,0 +1,130 @@
+package director
+
+import (
+	"io"
+
+	"github.com/fastly/cli/pkg/cmd"
+	"github.com/fastly/cli/pkg/config"
+	"github.com/fastly/cli/pkg/errors"
+	"github.com/fastly/cli/pkg/manifest"
+	"github.com/fastly/cli/pkg/text"
+	"github.com/fastly/go-fastly/v6/fastly"
+)
+
+// NewCreateCommand returns a usable command registered under the parent.
+func NewCreateCommand(parent cmd.Registerer, globals *config.Data, data manifest.Data) *CreateCommand {
+	var c CreateCommand
+	c.CmdClause = parent.Command("create", "Create a director on a Fastly service version").Alias("add")
+	c.Globals = globals
+	c.manifest = data
+
+	// Required flags
+	c.CmdClause.Flag("name", "Name of the director").Short('n').Required().StringVar(&c.name)
+	c.RegisterFlag(cmd.StringFlagOpts{
+		Name:        cmd.FlagVersionName,
+		Description: cmd.FlagVersionDesc,
+		Dst:         &c.serviceVersion.Value,
+		Required:    true,
+	})
+
+	// Optional flags
+	c.RegisterAutoCloneFlag(cmd.AutoCloneFlagOpts{
+		Action: c.autoClone.Set,
+		Dst:    &c.autoClone.Value,
+	})
+	c.CmdClause.Flag("comment", "A descriptive note").Action(c.comment.Set).StringVar(&c.comment.Value)
+	c.CmdClause.Flag("quorum", "The percentage of capacity that needs to be up for the director to be considered up (0-100)").Action(c.quorum.Set).UintVar(&c.quorum.Value)
+	c.CmdClause.Flag("retries", "How many backends to search if it fails").Action(c.retries.Set).UintVar(&c.retries.Value)
+	c.RegisterFlag(cmd.StringFlagOpts{
+		Name:        cmd.FlagServiceIDName,
+		Description: cmd.FlagServiceIDDesc,
+		Dst:         &c.manifest.Flag.ServiceID,
+		Short:       's',
+	})
+	c.RegisterFlag(cmd.StringFlagOpts{
+		Action:      c.serviceName.Set,
+		Name:        cmd.FlagServiceName,
+		Description: cmd.FlagServiceDesc,
+		Dst:         &c.serviceName.Value,
+	})
+	c.CmdClause.Flag("shield", "Selected POP to serve as a shield for the backends").Action(c.shield.Set).StringVar(&c.shield.Value)
+	c.CmdClause.Flag("type", "How the director selects a backend (only 'random' honours backend weights)").Default("random").HintOptions(Types...).EnumVar(&c.directorType, Types...)
+
+	return &c
+}
+
+// CreateCommand calls the Fastly API to create an appropriate resource.
+type CreateCommand struct {
+	cmd.Base
+
+	autoClone      cmd.OptionalAutoClone
+	comment        cmd.OptionalString
+	directorType   string
+	manifest       manifest.Data
+	name           string
+	quorum         cmd.OptionalUint
+	retries        cmd.OptionalUint
+	serviceName    cmd.OptionalServiceNameID
+	serviceVersion cmd.OptionalServiceVersion
+	shield         cmd.OptionalString
+}
+
+// Exec invokes the application logic for the command.
+func (c *CreateCommand) Exec(_ io.Reader, out io.Writer) error {
+	serviceID, serviceVersion, err := cmd.ServiceDetails(cmd.ServiceDetailsOpts{
+		AutoCloneFlag:      c.autoClone,
+		APIClient:          c.Globals.APIClient,
+		Manifest:           c.manifest,
+		Out:                out,
+		ServiceNameFlag:    c.serviceName,
+		ServiceVersionFlag: c.serviceVersion,
+		VerboseMode:        c.Globals.Flag.Verbose,
+	})
+	if err != nil {
+		c.Globals.ErrLog.AddWithContext(err, map[string]any{
+			"Service ID":      serviceID,
+			"Service Version": errors.ServiceVersion(serviceVersion),
+		})
+		return err
+	}
+
+	input := c.constructInput(serviceID, serviceVersion.Number)
+
+	d, err := c.Globals.APIClient.CreateDirector(input)
+	if err != nil {
+		c.Globals.ErrLog.AddWithContext(err, map[string]any{
+			"Director Name":   c.name,
+			"Service ID":      serviceID,
+			"Service Version": serviceVersion.Number,
+		})
+		return err
+	}
+
+	text.Success(out, "Created director '%s' (service: %s, version: %d)", d.Name, d.ServiceID, d.ServiceVersion)
+	return nil
+}
+
+// constructInput transforms values parsed from CLI flags into an object to be used by the API client library.
+func (c *CreateCommand) constructInput(serviceID string, serviceVersion int) *fastly.CreateDirectorInput {
+	var input fastly.CreateDirectorInput
+
+	input.ServiceID = serviceID
+	input.ServiceVersion = serviceVersion
+	input.Name = c.name
+	input.Type = directorTypes[c.directorType]
+
+	if c.comment.WasSet {
+		input.Comment = c.comment.Value
+	}
+	if c.quorum.WasSet {
+		input.Quorum = fastly.Uint(c.quorum.Value)
+	}
+	if c.retries.WasSet {
+		input.Retries = fastly.Uint(c.retries.Value)
+	}
+	if c.shield.WasSet {
+		input.Shield = c.shield.Value
+	}
+
+	return &input
+}
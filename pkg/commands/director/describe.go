@@ -0,0 +1,202 @@
+package director
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/fastly/cli/pkg/cmd"
+	"github.com/fastly/cli/pkg/config"
+	fsterr "github.com/fastly/cli/pkg/errors"
+	"github.com/fastly/cli/pkg/manifest"
+	"github.com/fastly/go-fastly/v6/fastly"
+)
+
+// NewDescribeCommand returns a usable command registered under the parent.
+func NewDescribeCommand(parent cmd.Registerer, globals *config.Data, data manifest.Data) *DescribeCommand {
+	var c DescribeCommand
+	c.CmdClause = parent.Command("describe", "Show detailed information about a director on a Fastly service version").Alias("get")
+	c.Globals = globals
+	c.manifest = data
+
+	// Required flags
+	c.CmdClause.Flag("name", "Name of the director").Short('n').Required().StringVar(&c.name)
+	c.RegisterFlag(cmd.StringFlagOpts{
+		Name:        cmd.FlagVersionName,
+		Description: cmd.FlagVersionDesc,
+		Dst:         &c.serviceVersion.Value,
+		Required:    true,
+	})
+
+	// Optional flags
+	c.RegisterFlagBool(cmd.BoolFlagOpts{
+		Name:        cmd.FlagJSONName,
+		Description: cmd.FlagJSONDesc,
+		Dst:         &c.output.JSON,
+		Short:       'j',
+	})
+	c.RegisterOutputFlags(&c.output)
+	c.RegisterFlag(cmd.StringFlagOpts{
+		Name:        cmd.FlagServiceIDName,
+		Description: cmd.FlagServiceIDDesc,
+		Dst:         &c.manifest.Flag.ServiceID,
+		Short:       's',
+	})
+	c.RegisterFlag(cmd.StringFlagOpts{
+		Action:      c.serviceName.Set,
+		Name:        cmd.FlagServiceName,
+		Description: cmd.FlagServiceDesc,
+		Dst:         &c.serviceName.Value,
+	})
+
+	return &c
+}
+
+// DescribeCommand calls the Fastly API to describe an appropriate resource.
+type DescribeCommand struct {
+	cmd.Base
+
+	output         cmd.Output
+	manifest       manifest.Data
+	name           string
+	serviceName    cmd.OptionalServiceNameID
+	serviceVersion cmd.OptionalServiceVersion
+}
+
+// Exec invokes the application logic for the command.
+func (c *DescribeCommand) Exec(_ io.Reader, out io.Writer) (err error) {
+	if c.Globals.Verbose() && c.output.Structured() {
+		return fsterr.ErrInvalidVerboseJSONCombo
+	}
+
+	out, closeOutput, err := c.output.Writer(out)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err = closeOutput(err)
+	}()
+
+	serviceID, serviceVersion, err := cmd.ServiceDetails(cmd.ServiceDetailsOpts{
+		AllowActiveLocked:  true,
+		APIClient:          c.Globals.APIClient,
+		Manifest:           c.manifest,
+		Out:                out,
+		ServiceNameFlag:    c.serviceName,
+		ServiceVersionFlag: c.serviceVersion,
+		VerboseMode:        c.Globals.Flag.Verbose,
+	})
+	if err != nil {
+		c.Globals.ErrLog.AddWithContext(err, map[string]any{
+			"Service ID":      serviceID,
+			"Service Version": fsterr.ServiceVersion(serviceVersion),
+		})
+		return err
+	}
+
+	input := c.constructInput(serviceID, serviceVersion.Number)
+
+	d, err := c.Globals.APIClient.GetDirector(input)
+	if err != nil {
+		c.Globals.ErrLog.AddWithContext(err, map[string]any{
+			"Director Name":   c.name,
+			"Service ID":      serviceID,
+			"Service Version": serviceVersion.Number,
+		})
+		return err
+	}
+
+	// NOTE: A backend's weight is a property of the backend rather than the
+	// director, so it's looked up separately.
+	bs, err := c.Globals.APIClient.ListBackends(&fastly.ListBackendsInput{
+		ServiceID:      serviceID,
+		ServiceVersion: serviceVersion.Number,
+	})
+	if err != nil {
+		c.Globals.ErrLog.AddWithContext(err, map[string]any{
+			"Service ID":      serviceID,
+			"Service Version": serviceVersion.Number,
+		})
+		return err
+	}
+
+	return c.print(out, d, backendWeights(d, bs))
+}
+
+// constructInput transforms values parsed from CLI flags into an object to be used by the API client library.
+func (c *DescribeCommand) constructInput(serviceID string, serviceVersion int) *fastly.GetDirectorInput {
+	var input fastly.GetDirectorInput
+
+	input.Name = c.name
+	input.ServiceID = serviceID
+	input.ServiceVersion = serviceVersion
+
+	return &input
+}
+
+// print displays the information returned from the API.
+func (c *DescribeCommand) print(out io.Writer, d *fastly.Director, weights map[string]uint) error {
+	if c.output.Structured() {
+		err := c.output.Write(out, struct {
+			*fastly.Director
+			BackendWeights map[string]uint
+		}{d, weights})
+		if err != nil {
+			c.Globals.ErrLog.Add(err)
+			return err
+		}
+		return nil
+	}
+
+	fmt.Fprintln(out)
+	printDirector(out, "", d, weights)
+	return nil
+}
+
+// backendWeights returns the weight of each of the director's backends.
+func backendWeights(d *fastly.Director, bs []*fastly.Backend) map[string]uint {
+	weights := make(map[string]uint, len(d.Backends))
+	for _, b := range bs {
+		for _, name := range d.Backends {
+			if b.Name == name {
+				weights[name] = b.Weight
+			}
+		}
+	}
+	return weights
+}
+
+// printDirector displays all the available information for a director.
+//
+// The backends are listed with their weight when weights are provided.
+func printDirector(out io.Writer, indent string, d *fastly.Director, weights map[string]uint) {
+	fmt.Fprintf(out, "%sName: %s\n", indent, d.Name)
+	fmt.Fprintf(out, "%sService ID: %s\n", indent, d.ServiceID)
+	fmt.Fprintf(out, "%sVersion: %d\n", indent, d.ServiceVersion)
+	fmt.Fprintf(out, "%sType: %s\n", indent, typeName(d.Type))
+	fmt.Fprintf(out, "%sComment: %s\n", indent, d.Comment)
+	fmt.Fprintf(out, "%sQuorum: %d\n", indent, d.Quorum)
+	fmt.Fprintf(out, "%sRetries: %d\n", indent, d.Retries)
+	fmt.Fprintf(out, "%sShield: %s\n", indent, d.Shield)
+	if weights == nil {
+		fmt.Fprintf(out, "%sBackends: %s\n", indent, strings.Join(d.Backends, ", "))
+	} else {
+		fmt.Fprintf(out, "%sBackends:\n", indent)
+		for _, name := range d.Backends {
+			weight := "n/a"
+			if w, ok := weights[name]; ok {
+				weight = fmt.Sprint(w)
+			}
+			fmt.Fprintf(out, "%s\t%s (weight: %s)\n", indent, name, weight)
+		}
+	}
+	if d.CreatedAt != nil {
+		fmt.Fprintf(out, "%sCreated at: %s\n", indent, d.CreatedAt)
+	}
+	if d.UpdatedAt != nil {
+		fmt.Fprintf(out, "%sUpdated at: %s\n", indent, d.UpdatedAt)
+	}
+	if d.DeletedAt != nil {
+		fmt.Fprintf(out, "%sDeleted at: %s\n", indent, d.DeletedAt)
+	}
+}
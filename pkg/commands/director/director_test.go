@@ -0,0 +1,459 @@
+package director_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/fastly/cli/pkg/app"
+	"github.com/fastly/cli/pkg/commands/director"
+	"github.com/fastly/cli/pkg/mock"
+	"github.com/fastly/cli/pkg/testutil"
+	"github.com/fastly/go-fastly/v6/fastly"
+)
+
+const (
+	validateAPIError        = "validate API error"
+	validateAPISuccess      = "validate API success"
+	validateMissingNameFlag = "validate missing --name flag"
+)
+
+func TestCreate(t *testing.T) {
+	args := testutil.Args
+	scenarios := []testutil.TestScenario{
+		{
+			Name:      validateMissingNameFlag,
+			Args:      args("director create --service-id 123 --version 1"),
+			WantError: "error parsing arguments: required flag --name not provided",
+		},
+		{
+			Name: validateAPIError,
+			API: mock.API{
+				ListVersionsFn: testutil.ListVersions,
+				CloneVersionFn: testutil.CloneVersionResult(4),
+				CreateDirectorFn: func(_ *fastly.CreateDirectorInput) (*fastly.Director, error) {
+					return nil, testutil.Err
+				},
+			},
+			Args:      args("director create --service-id 123 --version 1 --autoclone --name example"),
+			WantError: testutil.Err.Error(),
+		},
+		{
+			Name: validateAPISuccess,
+			API: mock.API{
+				ListVersionsFn: testutil.ListVersions,
+				CloneVersionFn: testutil.CloneVersionResult(4),
+				CreateDirectorFn: func(i *fastly.CreateDirectorInput) (*fastly.Director, error) {
+					if i.Type != fastly.DirectorTypeHash || i.Quorum == nil || *i.Quorum != 50 {
+						return nil, testutil.Err
+					}
+					return &fastly.Director{
+						Name:           i.Name,
+						ServiceID:      i.ServiceID,
+						ServiceVersion: i.ServiceVersion,
+					}, nil
+				},
+			},
+			Args:       args("director create --service-id 123 --version 1 --autoclone --name example --type hash --quorum 50"),
+			WantOutput: "Created director 'example' (service: 123, version: 4)",
+		},
+	}
+
+	for testcaseIdx := range scenarios {
+		testcase := &scenarios[testcaseIdx]
+		t.Run(testcase.Name, func(t *testing.T) {
+			var stdout bytes.Buffer
+			opts := testutil.NewRunOpts(testcase.Args, &stdout)
+			opts.APIClient = mock.APIClient(testcase.API)
+			err := app.Run(opts)
+			testutil.AssertErrorContains(t, err, testcase.WantError)
+			testutil.AssertStringContains(t, stdout.String(), testcase.WantOutput)
+		})
+	}
+}
+
+func TestDelete(t *testing.T) {
+	args := testutil.Args
+	scenarios := []testutil.TestScenario{
+		{
+			Name:      validateMissingNameFlag,
+			Args:      args("director delete --service-id 123 --version 1"),
+			WantError: "error parsing arguments: required flag --name not provided",
+		},
+		{
+			Name: validateAPIError,
+			API: mock.API{
+				ListVersionsFn: testutil.ListVersions,
+				CloneVersionFn: testutil.CloneVersionResult(4),
+				DeleteDirectorFn: func(_ *fastly.DeleteDirectorInput) error {
+					return testutil.Err
+				},
+			},
+			Args:      args("director delete --service-id 123 --version 1 --autoclone --name example"),
+			WantError: testutil.Err.Error(),
+		},
+		{
+			Name: validateAPISuccess,
+			API: mock.API{
+				ListVersionsFn: testutil.ListVersions,
+				CloneVersionFn: testutil.CloneVersionResult(4),
+				DeleteDirectorFn: func(_ *fastly.DeleteDirectorInput) error {
+					return nil
+				},
+			},
+			Args:       args("director delete --service-id 123 --version 1 --autoclone --name example"),
+			WantOutput: "Deleted director 'example' (service: 123, version: 4)",
+		},
+	}
+
+	for testcaseIdx := range scenarios {
+		testcase := &scenarios[testcaseIdx]
+		t.Run(testcase.Name, func(t *testing.T) {
+			var stdout bytes.Buffer
+			opts := testutil.NewRunOpts(testcase.Args, &stdout)
+			opts.APIClient = mock.APIClient(testcase.API)
+			err := app.Run(opts)
+			testutil.AssertErrorContains(t, err, testcase.WantError)
+			testutil.AssertStringContains(t, stdout.String(), testcase.WantOutput)
+		})
+	}
+}
+
+func TestDescribe(t *testing.T) {
+	args := testutil.Args
+	scenarios := []testutil.TestScenario{
+		{
+			Name:      validateMissingNameFlag,
+			Args:      args("director describe --service-id 123 --version 1"),
+			WantError: "error parsing arguments: required flag --name not provided",
+		},
+		{
+			Name: validateAPIError,
+			API: mock.API{
+				ListVersionsFn: testutil.ListVersions,
+				GetDirectorFn: func(_ *fastly.GetDirectorInput) (*fastly.Director, error) {
+					return nil, testutil.Err
+				},
+			},
+			Args:      args("director describe --service-id 123 --version 1 --name example"),
+			WantError: testutil.Err.Error(),
+		},
+		{
+			Name: "validate ListBackends API error",
+			API: mock.API{
+				ListVersionsFn: testutil.ListVersions,
+				GetDirectorFn:  getDirector,
+				ListBackendsFn: func(_ *fastly.ListBackendsInput) ([]*fastly.Backend, error) {
+					return nil, testutil.Err
+				},
+			},
+			Args:      args("director describe --service-id 123 --version 1 --name example"),
+			WantError: testutil.Err.Error(),
+		},
+		{
+			Name: validateAPISuccess,
+			API: mock.API{
+				ListVersionsFn: testutil.ListVersions,
+				GetDirectorFn:  getDirector,
+				ListBackendsFn: func(i *fastly.ListBackendsInput) ([]*fastly.Backend, error) {
+					return []*fastly.Backend{
+						{Name: "origin-a", ServiceID: i.ServiceID, ServiceVersion: i.ServiceVersion, Weight: 70},
+						{Name: "origin-c", ServiceID: i.ServiceID, ServiceVersion: i.ServiceVersion, Weight: 100},
+					}, nil
+				},
+			},
+			Args:       args("director describe --service-id 123 --version 1 --name example"),
+			WantOutput: describeOutput,
+		},
+	}
+
+	for testcaseIdx := range scenarios {
+		testcase := &scenarios[testcaseIdx]
+		t.Run(testcase.Name, func(t *testing.T) {
+			var stdout bytes.Buffer
+			opts := testutil.NewRunOpts(testcase.Args, &stdout)
+			opts.APIClient = mock.APIClient(testcase.API)
+			err := app.Run(opts)
+			testutil.AssertErrorContains(t, err, testcase.WantError)
+			testutil.AssertString(t, testcase.WantOutput, stdout.String())
+		})
+	}
+}
+
+func TestList(t *testing.T) {
+	args := testutil.Args
+	scenarios := []testutil.TestScenario{
+		{
+			Name: validateAPIError,
+			API: mock.API{
+				ListVersionsFn: testutil.ListVersions,
+				ListDirectorsFn: func(_ *fastly.ListDirectorsInput) ([]*fastly.Director, error) {
+					return nil, testutil.Err
+				},
+			},
+			Args:      args("director list --service-id 123 --version 1"),
+			WantError: testutil.Err.Error(),
+		},
+		{
+			Name: validateAPISuccess,
+			API: mock.API{
+				ListVersionsFn: testutil.ListVersions,
+				ListDirectorsFn: func(i *fastly.ListDirectorsInput) ([]*fastly.Director, error) {
+					d, _ := getDirector(&fastly.GetDirectorInput{Name: "example", ServiceID: i.ServiceID, ServiceVersion: i.ServiceVersion})
+					return []*fastly.Director{d}, nil
+				},
+			},
+			Args:       args("director list --service-id 123 --version 1"),
+			WantOutput: listOutput,
+		},
+	}
+
+	for testcaseIdx := range scenarios {
+		testcase := &scenarios[testcaseIdx]
+		t.Run(testcase.Name, func(t *testing.T) {
+			var stdout bytes.Buffer
+			opts := testutil.NewRunOpts(testcase.Args, &stdout)
+			opts.APIClient = mock.APIClient(testcase.API)
+			err := app.Run(opts)
+			testutil.AssertErrorContains(t, err, testcase.WantError)
+			testutil.AssertString(t, testcase.WantOutput, stdout.String())
+		})
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	args := testutil.Args
+	scenarios := []testutil.TestScenario{
+		{
+			Name:      validateMissingNameFlag,
+			Args:      args("director update --service-id 123 --version 1"),
+			WantError: "error parsing arguments: required flag --name not provided",
+		},
+		{
+			Name: validateAPIError,
+			API: mock.API{
+				ListVersionsFn: testutil.ListVersions,
+				CloneVersionFn: testutil.CloneVersionResult(4),
+				UpdateDirectorFn: func(_ *fastly.UpdateDirectorInput) (*fastly.Director, error) {
+					return nil, testutil.Err
+				},
+			},
+			Args:      args("director update --service-id 123 --version 1 --autoclone --name example --new-name renamed"),
+			WantError: testutil.Err.Error(),
+		},
+		{
+			Name: validateAPISuccess,
+			API: mock.API{
+				ListVersionsFn: testutil.ListVersions,
+				CloneVersionFn: testutil.CloneVersionResult(4),
+				UpdateDirectorFn: func(i *fastly.UpdateDirectorInput) (*fastly.Director, error) {
+					if i.NewName == nil || i.Type != 0 || i.Quorum != nil {
+						return nil, testutil.Err
+					}
+					return &fastly.Director{
+						Name:           *i.NewName,
+						ServiceID:      i.ServiceID,
+						ServiceVersion: i.ServiceVersion,
+					}, nil
+				},
+			},
+			Args:       args("director update --service-id 123 --version 1 --autoclone --name example --new-name renamed"),
+			WantOutput: "Updated director 'renamed' (service: 123, version: 4)",
+		},
+	}
+
+	for testcaseIdx := range scenarios {
+		testcase := &scenarios[testcaseIdx]
+		t.Run(testcase.Name, func(t *testing.T) {
+			var stdout bytes.Buffer
+			opts := testutil.NewRunOpts(testcase.Args, &stdout)
+			opts.APIClient = mock.APIClient(testcase.API)
+			err := app.Run(opts)
+			testutil.AssertErrorContains(t, err, testcase.WantError)
+			testutil.AssertStringContains(t, stdout.String(), testcase.WantOutput)
+		})
+	}
+}
+
+func TestAddBackend(t *testing.T) {
+	args := testutil.Args
+	scenarios := []testutil.TestScenario{
+		{
+			Name:      "validate missing --backend flag",
+			Args:      args("director add-backend --service-id 123 --version 1 --name example"),
+			WantError: "error parsing arguments: required flag --backend not provided",
+		},
+		{
+			Name: validateAPIError,
+			API: mock.API{
+				ListVersionsFn: testutil.ListVersions,
+				CloneVersionFn: testutil.CloneVersionResult(4),
+				CreateDirectorBackendFn: func(_ *fastly.CreateDirectorBackendInput) (*fastly.DirectorBackend, error) {
+					return nil, testutil.Err
+				},
+			},
+			Args:      args("director add-backend --service-id 123 --version 1 --autoclone --name example --backend origin-a"),
+			WantError: testutil.Err.Error(),
+		},
+		{
+			Name: "validate --weight updates the backend",
+			API: mock.API{
+				ListVersionsFn: testutil.ListVersions,
+				CloneVersionFn: testutil.CloneVersionResult(4),
+				CreateDirectorBackendFn: func(i *fastly.CreateDirectorBackendInput) (*fastly.DirectorBackend, error) {
+					return &fastly.DirectorBackend{Backend: i.Backend, Director: i.Director}, nil
+				},
+				UpdateBackendFn: func(i *fastly.UpdateBackendInput) (*fastly.Backend, error) {
+					if i.Name != "origin-a" || i.Weight == nil || *i.Weight != 70 {
+						return nil, testutil.Err
+					}
+					return &fastly.Backend{Name: i.Name}, nil
+				},
+			},
+			Args:       args("director add-backend --service-id 123 --version 1 --autoclone --name example --backend origin-a --weight 70"),
+			WantOutput: "Added backend 'origin-a' to director 'example' (service: 123, version: 4)",
+		},
+	}
+
+	for testcaseIdx := range scenarios {
+		testcase := &scenarios[testcaseIdx]
+		t.Run(testcase.Name, func(t *testing.T) {
+			var stdout bytes.Buffer
+			opts := testutil.NewRunOpts(testcase.Args, &stdout)
+			opts.APIClient = mock.APIClient(testcase.API)
+			err := app.Run(opts)
+			testutil.AssertErrorContains(t, err, testcase.WantError)
+			testutil.AssertStringContains(t, stdout.String(), testcase.WantOutput)
+		})
+	}
+}
+
+func TestRebalance(t *testing.T) {
+	args := testutil.Args
+	scenarios := []testutil.TestScenario{
+		{
+			Name:      "validate missing --weights flag",
+			Args:      args("director rebalance --service-id 123 --version 1 --name example"),
+			WantError: "error parsing arguments: required flag --weights not provided",
+		},
+		{
+			Name:      "validate malformed --weights flag",
+			Args:      args("director rebalance --service-id 123 --version 1 --name example --weights origin-a"),
+			WantError: "invalid weight 'origin-a': expected backend=weight",
+		},
+		{
+			Name: "validate unknown backend",
+			API: mock.API{
+				ListVersionsFn: testutil.ListVersions,
+				CloneVersionFn: testutil.CloneVersionResult(4),
+				GetDirectorFn:  getDirector,
+			},
+			Args:      args("director rebalance --service-id 123 --version 1 --autoclone --name example --weights origin-a=70,origin-c=30"),
+			WantError: "backend 'origin-c' is not associated with director 'example'",
+		},
+		{
+			Name: validateAPIError,
+			API: mock.API{
+				ListVersionsFn: testutil.ListVersions,
+				CloneVersionFn: testutil.CloneVersionResult(4),
+				GetDirectorFn:  getDirector,
+				UpdateBackendFn: func(_ *fastly.UpdateBackendInput) (*fastly.Backend, error) {
+					return nil, testutil.Err
+				},
+			},
+			Args:      args("director rebalance --service-id 123 --version 1 --autoclone --name example --weights origin-a=70,origin-b=30"),
+			WantError: testutil.Err.Error(),
+		},
+		{
+			Name: validateAPISuccess,
+			API: mock.API{
+				ListVersionsFn: testutil.ListVersions,
+				CloneVersionFn: testutil.CloneVersionResult(4),
+				GetDirectorFn:  getDirector,
+				UpdateBackendFn: func(i *fastly.UpdateBackendInput) (*fastly.Backend, error) {
+					if i.ServiceVersion != 4 || i.Weight == nil {
+						return nil, testutil.Err
+					}
+					return &fastly.Backend{Name: i.Name, Weight: *i.Weight}, nil
+				},
+			},
+			Args:       args("director rebalance --service-id 123 --version 1 --autoclone --name example --weights origin-a=70,origin-b=30"),
+			WantOutput: "Rebalanced director 'example' (service: 123, version: 4): origin-a=70, origin-b=30",
+		},
+	}
+
+	for testcaseIdx := range scenarios {
+		testcase := &scenarios[testcaseIdx]
+		t.Run(testcase.Name, func(t *testing.T) {
+			var stdout bytes.Buffer
+			opts := testutil.NewRunOpts(testcase.Args, &stdout)
+			opts.APIClient = mock.APIClient(testcase.API)
+			err := app.Run(opts)
+			testutil.AssertErrorContains(t, err, testcase.WantError)
+			testutil.AssertStringContains(t, stdout.String(), testcase.WantOutput)
+		})
+	}
+}
+
+func TestParseWeights(t *testing.T) {
+	for _, testcase := range []struct {
+		name      string
+		input     []string
+		want      []director.Weight
+		wantError string
+	}{
+		{
+			name:  "valid pairs",
+			input: []string{"origin-a=70", " origin-b = 30"},
+			want:  []director.Weight{{Backend: "origin-a", Value: 70}, {Backend: "origin-b", Value: 30}},
+		},
+		{
+			name:      "negative weight",
+			input:     []string{"origin-a=-1"},
+			wantError: "weight must be a non-negative integer",
+		},
+		{
+			name:      "duplicate backend",
+			input:     []string{"origin-a=1", "origin-a=2"},
+			wantError: "backend 'origin-a' was specified more than once",
+		},
+	} {
+		t.Run(testcase.name, func(t *testing.T) {
+			got, err := director.ParseWeights(testcase.input)
+			testutil.AssertErrorContains(t, err, testcase.wantError)
+			if testcase.wantError == "" {
+				testutil.AssertEqual(t, testcase.want, got)
+			}
+		})
+	}
+}
+
+func getDirector(i *fastly.GetDirectorInput) (*fastly.Director, error) {
+	return &fastly.Director{
+		Backends:       []string{"origin-a", "origin-b"},
+		Comment:        "example director",
+		Name:           i.Name,
+		Quorum:         75,
+		Retries:        5,
+		ServiceID:      i.ServiceID,
+		ServiceVersion: i.ServiceVersion,
+		Type:           fastly.DirectorTypeRandom,
+	}, nil
+}
+
+var describeOutput = `
+Name: example
+Service ID: 123
+Version: 1
+Type: random
+Comment: example director
+Quorum: 75
+Retries: 5
+Shield: 
+Backends:
+	origin-a (weight: 70)
+	origin-b (weight: n/a)
+`
+
+var listOutput = `SERVICE  VERSION  NAME     TYPE    QUORUM  BACKENDS
+123      1        example  random  75      2
+`
@@ -0,0 +1,3 @@
+// Package director contains commands to inspect and manipulate Fastly service
+// directors and the weighting of their backends.
+package director
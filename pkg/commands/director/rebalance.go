@@ -0,0 +1,180 @@
+package director
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/fastly/cli/pkg/cmd"
+	"github.com/fastly/cli/pkg/config"
+	"github.com/fastly/cli/pkg/errors"
+	"github.com/fastly/cli/pkg/manifest"
+	"github.com/fastly/cli/pkg/text"
+	"github.com/fastly/go-fastly/v6/fastly"
+	"github.com/fastly/kingpin"
+)
+
+// NewRebalanceCommand returns a usable command registered under the parent.
+func NewRebalanceCommand(parent cmd.Registerer, globals *config.Data, data manifest.Data) *RebalanceCommand {
+	var c RebalanceCommand
+	c.CmdClause = parent.Command("rebalance", "Set the weights of a director's backends in one call (backends not listed keep their current weight)")
+	c.Globals = globals
+	c.manifest = data
+
+	// Required flags
+	c.CmdClause.Flag("name", "Name of the director").Short('n').Required().StringVar(&c.name)
+	c.RegisterFlag(cmd.StringFlagOpts{
+		Name:        cmd.FlagVersionName,
+		Description: cmd.FlagVersionDesc,
+		Dst:         &c.serviceVersion.Value,
+		Required:    true,
+	})
+	c.CmdClause.Flag("weights", "A comma-separated list of backend=weight pairs (e.g. origin-a=70,origin-b=30)").Required().StringsVar(&c.weights, kingpin.Separator(","))
+
+	// Optional flags
+	c.RegisterAutoCloneFlag(cmd.AutoCloneFlagOpts{
+		Action: c.autoClone.Set,
+		Dst:    &c.autoClone.Value,
+	})
+	c.RegisterFlag(cmd.StringFlagOpts{
+		Name:        cmd.FlagServiceIDName,
+		Description: cmd.FlagServiceIDDesc,
+		Dst:         &c.manifest.Flag.ServiceID,
+		Short:       's',
+	})
+	c.RegisterFlag(cmd.StringFlagOpts{
+		Action:      c.serviceName.Set,
+		Name:        cmd.FlagServiceName,
+		Description: cmd.FlagServiceDesc,
+		Dst:         &c.serviceName.Value,
+	})
+
+	return &c
+}
+
+// RebalanceCommand calls the Fastly API to update the weights of the backends
+// associated with a director.
+type RebalanceCommand struct {
+	cmd.Base
+
+	autoClone      cmd.OptionalAutoClone
+	manifest       manifest.Data
+	name           string
+	serviceName    cmd.OptionalServiceNameID
+	serviceVersion cmd.OptionalServiceVersion
+	weights        []string
+}
+
+// Weight is the desired load balancing weight for a backend.
+type Weight struct {
+	Backend string
+	Value   uint
+}
+
+// Exec invokes the application logic for the command.
+func (c *RebalanceCommand) Exec(_ io.Reader, out io.Writer) error {
+	weights, err := ParseWeights(c.weights)
+	if err != nil {
+		return errors.RemediationError{
+			Inner:       err,
+			Remediation: "Provide --weights as a comma-separated list of backend=weight pairs, e.g. --weights origin-a=70,origin-b=30",
+		}
+	}
+
+	serviceID, serviceVersion, err := cmd.ServiceDetails(cmd.ServiceDetailsOpts{
+		AutoCloneFlag:      c.autoClone,
+		APIClient:          c.Globals.APIClient,
+		Manifest:           c.manifest,
+		Out:                out,
+		ServiceNameFlag:    c.serviceName,
+		ServiceVersionFlag: c.serviceVersion,
+		VerboseMode:        c.Globals.Flag.Verbose,
+	})
+	if err != nil {
+		c.Globals.ErrLog.AddWithContext(err, map[string]any{
+			"Service ID":      serviceID,
+			"Service Version": errors.ServiceVersion(serviceVersion),
+		})
+		return err
+	}
+
+	d, err := c.Globals.APIClient.GetDirector(&fastly.GetDirectorInput{
+		Name:           c.name,
+		ServiceID:      serviceID,
+		ServiceVersion: serviceVersion.Number,
+	})
+	if err != nil {
+		c.Globals.ErrLog.AddWithContext(err, map[string]any{
+			"Director Name":   c.name,
+			"Service ID":      serviceID,
+			"Service Version": serviceVersion.Number,
+		})
+		return err
+	}
+
+	// Validate every backend before making any changes so a typo doesn't leave
+	// the director partially rebalanced.
+	for _, w := range weights {
+		if !contains(d.Backends, w.Backend) {
+			return errors.RemediationError{
+				Inner:       fmt.Errorf("backend '%s' is not associated with director '%s'", w.Backend, c.name),
+				Remediation: fmt.Sprintf("Add it first with `fastly director add-backend --name %s --backend %s`, or choose from: %s", c.name, w.Backend, strings.Join(d.Backends, ", ")),
+			}
+		}
+	}
+
+	if d.Type != fastly.DirectorTypeRandom {
+		text.Warning(out, "Director '%s' is of type '%s' which ignores backend weights.", c.name, typeName(d.Type))
+		text.Break(out)
+	}
+
+	for _, w := range weights {
+		err = setWeight(c.Globals, serviceID, serviceVersion.Number, w.Backend, w.Value)
+		if err != nil {
+			return err
+		}
+		if c.Globals.Verbose() {
+			text.Output(out, "Set weight of backend '%s' to %d", w.Backend, w.Value)
+		}
+	}
+
+	pairs := make([]string, len(weights))
+	for i, w := range weights {
+		pairs[i] = fmt.Sprintf("%s=%d", w.Backend, w.Value)
+	}
+	text.Success(out, "Rebalanced director '%s' (service: %s, version: %d): %s", c.name, serviceID, serviceVersion.Number, strings.Join(pairs, ", "))
+	return nil
+}
+
+// ParseWeights parses a list of backend=weight pairs.
+func ParseWeights(pairs []string) ([]Weight, error) {
+	seen := make(map[string]bool)
+	weights := make([]Weight, 0, len(pairs))
+	for _, pair := range pairs {
+		backend, value, ok := strings.Cut(pair, "=")
+		backend = strings.TrimSpace(backend)
+		if !ok || backend == "" {
+			return nil, fmt.Errorf("invalid weight '%s': expected backend=weight", pair)
+		}
+		n, err := strconv.ParseUint(strings.TrimSpace(value), 10, 0)
+		if err != nil {
+			return nil, fmt.Errorf("invalid weight '%s': weight must be a non-negative integer", pair)
+		}
+		if seen[backend] {
+			return nil, fmt.Errorf("backend '%s' was specified more than once", backend)
+		}
+		seen[backend] = true
+		weights = append(weights, Weight{Backend: backend, Value: uint(n)})
+	}
+	return weights, nil
+}
+
+func contains(items []string, s string) bool {
+	for _, item := range items {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
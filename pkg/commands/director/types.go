@@ -0,0 +1,28 @@
+package director
+
+import (
+	"fmt"
+
+	"github.com/fastly/go-fastly/v6/fastly"
+)
+
+// Types is a list of supported director types.
+var Types = []string{"random", "round-robin", "hash", "client"}
+
+// directorTypes maps the human readable director type to the API value.
+var directorTypes = map[string]fastly.DirectorType{
+	"random":      fastly.DirectorTypeRandom,
+	"round-robin": fastly.DirectorTypeRoundRobin,
+	"hash":        fastly.DirectorTypeHash,
+	"client":      fastly.DirectorTypeClient,
+}
+
+// typeName returns the human readable name for a director type.
+func typeName(t fastly.DirectorType) string {
+	for name, v := range directorTypes {
+		if v == t {
+			return name
+		}
+	}
+	return fmt.Sprintf("unknown (%d)", t)
+}
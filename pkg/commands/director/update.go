@@ -0,0 +1,137 @@
+package director
+
+import (
+	"io"
+
+	"github.com/fastly/cli/pkg/cmd"
+	"github.com/fastly/cli/pkg/config"
+	"github.com/fastly/cli/pkg/errors"
+	"github.com/fastly/cli/pkg/manifest"
+	"github.com/fastly/cli/pkg/text"
+	"github.com/fastly/go-fastly/v6/fastly"
+)
+
+// NewUpdateCommand returns a usable command registered under the parent.
+func NewUpdateCommand(parent cmd.Registerer, globals *config.Data, data manifest.Data) *UpdateCommand {
+	var c UpdateCommand
+	c.CmdClause = parent.Command("update", "Update a director on a Fastly service version")
+	c.Globals = globals
+	c.manifest = data
+
+	// Required flags
+	c.CmdClause.Flag("name", "Name of the director").Short('n').Required().StringVar(&c.name)
+	c.RegisterFlag(cmd.StringFlagOpts{
+		Name:        cmd.FlagVersionName,
+		Description: cmd.FlagVersionDesc,
+		Dst:         &c.serviceVersion.Value,
+		Required:    true,
+	})
+
+	// Optional flags
+	c.RegisterAutoCloneFlag(cmd.AutoCloneFlagOpts{
+		Action: c.autoClone.Set,
+		Dst:    &c.autoClone.Value,
+	})
+	c.CmdClause.Flag("comment", "A descriptive note").Action(c.comment.Set).StringVar(&c.comment.Value)
+	c.CmdClause.Flag("new-name", "New name for the director").Action(c.newName.Set).StringVar(&c.newName.Value)
+	c.CmdClause.Flag("quorum", "The percentage of capacity that needs to be up for the director to be considered up (0-100)").Action(c.quorum.Set).UintVar(&c.quorum.Value)
+	c.CmdClause.Flag("retries", "How many backends to search if it fails").Action(c.retries.Set).UintVar(&c.retries.Value)
+	c.RegisterFlag(cmd.StringFlagOpts{
+		Name:        cmd.FlagServiceIDName,
+		Description: cmd.FlagServiceIDDesc,
+		Dst:         &c.manifest.Flag.ServiceID,
+		Short:       's',
+	})
+	c.RegisterFlag(cmd.StringFlagOpts{
+		Action:      c.serviceName.Set,
+		Name:        cmd.FlagServiceName,
+		Description: cmd.FlagServiceDesc,
+		Dst:         &c.serviceName.Value,
+	})
+	c.CmdClause.Flag("shield", "Selected POP to serve as a shield for the backends").Action(c.shield.Set).StringVar(&c.shield.Value)
+	c.CmdClause.Flag("type", "How the director selects a backend (only 'random' honours backend weights)").Action(c.directorType.Set).HintOptions(Types...).EnumVar(&c.directorType.Value, Types...)
+
+	return &c
+}
+
+// UpdateCommand calls the Fastly API to update an appropriate resource.
+type UpdateCommand struct {
+	cmd.Base
+
+	autoClone      cmd.OptionalAutoClone
+	comment        cmd.OptionalString
+	directorType   cmd.OptionalString
+	manifest       manifest.Data
+	name           string
+	newName        cmd.OptionalString
+	quorum         cmd.OptionalUint
+	retries        cmd.OptionalUint
+	serviceName    cmd.OptionalServiceNameID
+	serviceVersion cmd.OptionalServiceVersion
+	shield         cmd.OptionalString
+}
+
+// Exec invokes the application logic for the command.
+func (c *UpdateCommand) Exec(_ io.Reader, out io.Writer) error {
+	serviceID, serviceVersion, err := cmd.ServiceDetails(cmd.ServiceDetailsOpts{
+		AutoCloneFlag:      c.autoClone,
+		APIClient:          c.Globals.APIClient,
+		Manifest:           c.manifest,
+		Out:                out,
+		ServiceNameFlag:    c.serviceName,
+		ServiceVersionFlag: c.serviceVersion,
+		VerboseMode:        c.Globals.Flag.Verbose,
+	})
+	if err != nil {
+		c.Globals.ErrLog.AddWithContext(err, map[string]any{
+			"Service ID":      serviceID,
+			"Service Version": errors.ServiceVersion(serviceVersion),
+		})
+		return err
+	}
+
+	input := c.constructInput(serviceID, serviceVersion.Number)
+
+	d, err := c.Globals.APIClient.UpdateDirector(input)
+	if err != nil {
+		c.Globals.ErrLog.AddWithContext(err, map[string]any{
+			"Director Name":   c.name,
+			"Service ID":      serviceID,
+			"Service Version": serviceVersion.Number,
+		})
+		return err
+	}
+
+	text.Success(out, "Updated director '%s' (service: %s, version: %d)", d.Name, d.ServiceID, d.ServiceVersion)
+	return nil
+}
+
+// constructInput transforms values parsed from CLI flags into an object to be used by the API client library.
+func (c *UpdateCommand) constructInput(serviceID string, serviceVersion int) *fastly.UpdateDirectorInput {
+	var input fastly.UpdateDirectorInput
+
+	input.Name = c.name
+	input.ServiceID = serviceID
+	input.ServiceVersion = serviceVersion
+
+	if c.comment.WasSet {
+		input.Comment = fastly.String(c.comment.Value)
+	}
+	if c.directorType.WasSet {
+		input.Type = directorTypes[c.directorType.Value]
+	}
+	if c.newName.WasSet {
+		input.NewName = fastly.String(c.newName.Value)
+	}
+	if c.quorum.WasSet {
+		input.Quorum = fastly.Uint(c.quorum.Value)
+	}
+	if c.retries.WasSet {
+		input.Retries = fastly.Uint(c.retries.Value)
+	}
+	if c.shield.WasSet {
+		input.Shield = fastly.String(c.shield.Value)
+	}
+
+	return &input
+}
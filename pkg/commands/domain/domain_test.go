@@ -4,6 +4,8 @@ import (
 	"bytes"
 	"errors"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"strings"
 	"testing"
 
@@ -334,6 +336,70 @@ func TestDomainValidate(t *testing.T) {
 
 var errTest = errors.New("fixture error")
 
+func TestDomainSuggest(t *testing.T) {
+	args := testutil.Args
+	responses := map[string]string{
+		"/domain-management/v1/tools/suggest?defaults=com&query=myshop": `{"results":[{"domain":"myshop.com","subdomain":"myshop.","zone":"com"},{"domain":"myshop.dev","subdomain":"myshop.","zone":"dev"}]}`,
+		"/domain-management/v1/tools/status?domain=myshop.com":          `{"domain":"myshop.com","zone":"com","status":"active"}`,
+		"/domain-management/v1/tools/status?domain=myshop.dev":          `{"domain":"myshop.dev","zone":"dev","status":"undelegated inactive"}`,
+	}
+	scenarios := []struct {
+		args       []string
+		code       int
+		wantError  string
+		wantOutput string
+	}{
+		{
+			args:      args("domain suggest"),
+			wantError: "error parsing arguments: required argument 'query' not provided",
+		},
+		{
+			args:      args("domain suggest myshop --defaults com --token 123"),
+			code:      http.StatusForbidden,
+			wantError: "error suggesting domain names: non-2xx response",
+		},
+		{
+			args:       args("domain suggest myshop --defaults com --token 123"),
+			wantOutput: "DOMAIN      AVAILABLE  STATUS\nmyshop.com  false      active\nmyshop.dev  true       undelegated inactive\n",
+		},
+		{
+			args:       args("domain suggest myshop --defaults com --json --token 123"),
+			wantOutput: `[{"available":false,"domain":"myshop.com","status":"active"},{"available":true,"domain":"myshop.dev","status":"undelegated inactive"}]`,
+		},
+	}
+	for testcaseIdx := range scenarios {
+		testcase := &scenarios[testcaseIdx]
+		t.Run(strings.Join(testcase.args, " "), func(t *testing.T) {
+			var stdout bytes.Buffer
+			opts := testutil.NewRunOpts(testcase.args, &stdout)
+			opts.HTTPClient = domainToolsClient{code: testcase.code, responses: responses}
+			err := app.Run(opts)
+			testutil.AssertErrorContains(t, err, testcase.wantError)
+			testutil.AssertString(t, testcase.wantOutput, stdout.String())
+		})
+	}
+}
+
+// domainToolsClient responds to each request with the response for its path
+// and query, or the configured status code.
+type domainToolsClient struct {
+	code      int
+	responses map[string]string
+}
+
+func (c domainToolsClient) Do(req *http.Request) (*http.Response, error) {
+	rec := httptest.NewRecorder()
+	body, ok := c.responses[req.URL.RequestURI()]
+	switch {
+	case c.code != 0:
+		rec.WriteHeader(c.code)
+	case !ok:
+		rec.WriteHeader(http.StatusNotFound)
+	}
+	rec.WriteString(body)
+	return rec.Result(), nil
+}
+
 func createDomainOK(i *fastly.CreateDomainInput) (*fastly.Domain, error) {
 	return &fastly.Domain{
 		ServiceID:      i.ServiceID,
@@ -0,0 +1,111 @@
+package domain
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/fastly/cli/pkg/api/undocumented"
+	"github.com/fastly/cli/pkg/cmd"
+	"github.com/fastly/cli/pkg/config"
+	fsterr "github.com/fastly/cli/pkg/errors"
+	"github.com/fastly/cli/pkg/text"
+)
+
+// SuggestCommand calls the Fastly API to suggest available domain names.
+type SuggestCommand struct {
+	cmd.Base
+
+	defaults []string
+	json     bool
+	keywords []string
+	query    string
+}
+
+// SuggestResult is a suggested domain name and whether it's available.
+type SuggestResult struct {
+	Available bool   `json:"available"`
+	Domain    string `json:"domain"`
+	Status    string `json:"status"`
+}
+
+// NewSuggestCommand returns a usable command registered under the parent.
+func NewSuggestCommand(parent cmd.Registerer, globals *config.Data) *SuggestCommand {
+	var c SuggestCommand
+	c.Globals = globals
+	c.CmdClause = parent.Command("suggest", "Suggest domain names for a query, along with whether they're available")
+	c.CmdClause.Arg("query", "Words to suggest domain names for, e.g. 'my shop'").Required().StringVar(&c.query)
+	c.CmdClause.Flag("defaults", "Zone to always suggest a domain name in, e.g. com (can be repeated)").StringsVar(&c.defaults)
+	c.RegisterFlagBool(cmd.BoolFlagOpts{
+		Name:        cmd.FlagJSONName,
+		Description: cmd.FlagJSONDesc,
+		Dst:         &c.json,
+		Short:       'j',
+	})
+	c.CmdClause.Flag("keyword", "Keyword to suggest related domain names for (can be repeated)").StringsVar(&c.keywords)
+	return &c
+}
+
+// Exec invokes the application logic for the command.
+func (c *SuggestCommand) Exec(_ io.Reader, out io.Writer) error {
+	token, source := c.Globals.Token()
+	if source == config.SourceUndefined {
+		return fsterr.ErrNoToken
+	}
+	endpoint, _ := c.Globals.Endpoint()
+	client := undocumented.NewClient(endpoint, token, c.Globals.HTTPClient)
+
+	suggestions, err := client.SuggestDomains(undocumented.DomainSuggestInput{
+		Defaults: c.defaults,
+		Keywords: c.keywords,
+		Query:    c.query,
+	})
+	if err != nil {
+		c.Globals.ErrLog.AddWithContext(err, map[string]any{
+			"Query": c.query,
+		})
+		return fmt.Errorf("error suggesting domain names: %w", err)
+	}
+
+	results := make([]SuggestResult, 0, len(suggestions))
+	for _, s := range suggestions {
+		status, err := client.GetDomainStatus(s.Domain)
+		if err != nil {
+			c.Globals.ErrLog.AddWithContext(err, map[string]any{
+				"Domain": s.Domain,
+			})
+			return fmt.Errorf("error checking the status of domain name '%s': %w", s.Domain, err)
+		}
+		results = append(results, SuggestResult{
+			Available: status.Available(),
+			Domain:    s.Domain,
+			Status:    status.Status,
+		})
+	}
+
+	if c.json {
+		data, err := json.Marshal(results)
+		if err != nil {
+			return err
+		}
+		_, err = out.Write(data)
+		if err != nil {
+			c.Globals.ErrLog.Add(err)
+			return fmt.Errorf("error: unable to write data to stdout: %w", err)
+		}
+		return nil
+	}
+
+	if len(results) == 0 {
+		text.Info(out, "No domain names found for '%s'", c.query)
+		return nil
+	}
+
+	tw := text.NewTable(out)
+	tw.AddHeader("DOMAIN", "AVAILABLE", "STATUS")
+	for _, r := range results {
+		tw.AddLine(r.Domain, r.Available, r.Status)
+	}
+	tw.Print()
+	return nil
+}
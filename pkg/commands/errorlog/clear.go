@@ -0,0 +1,41 @@
+package errorlog
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+
+	"github.com/fastly/cli/pkg/cmd"
+	"github.com/fastly/cli/pkg/config"
+	fsterr "github.com/fastly/cli/pkg/errors"
+	"github.com/fastly/cli/pkg/text"
+)
+
+// ClearCommand represents a Kingpin command.
+type ClearCommand struct {
+	cmd.Base
+	path string
+}
+
+// NewClearCommand returns a usable command registered under the parent.
+func NewClearCommand(parent cmd.Registerer, globals *config.Data, path string) *ClearCommand {
+	var c ClearCommand
+	c.Globals = globals
+	c.path = path
+	c.CmdClause = parent.Command("clear", "Delete all entries in the error log")
+	return &c
+}
+
+// Exec invokes the application logic for the command.
+func (c *ClearCommand) Exec(_ io.Reader, out io.Writer) error {
+	for _, path := range []string{c.path, fsterr.RotatedLogPath(c.path)} {
+		if err := os.Remove(path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			c.Globals.ErrLog.Add(err)
+			return fmt.Errorf("error clearing the error log: %w", err)
+		}
+	}
+	text.Success(out, "Cleared the error log (%s)", c.path)
+	return nil
+}
@@ -0,0 +1,2 @@
+// Package errorlog contains commands to inspect and clear the CLI's error log.
+package errorlog
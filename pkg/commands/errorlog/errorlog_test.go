@@ -0,0 +1,119 @@
+package errorlog_test
+
+import (
+	"bytes"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/fastly/cli/pkg/app"
+	fsterr "github.com/fastly/cli/pkg/errors"
+	"github.com/fastly/cli/pkg/testutil"
+)
+
+func session(cmd string) string {
+	return "\nCOMMAND:\n" + cmd + "\n\nTIMESTAMP:\n0001-01-01 00:00:00 +0000 UTC\n\nERROR:\nboom\n" + fsterr.LogSeparator
+}
+
+func TestErrors(t *testing.T) {
+	args := testutil.Args
+	scenarios := []struct {
+		testutil.TestScenario
+		log        string
+		rotated    string
+		wantAbsent []string
+		wantClear  bool
+	}{
+		{
+			TestScenario: testutil.TestScenario{
+				Name:       "show with no log",
+				Args:       args("errors show"),
+				WantOutput: "No errors have been logged.",
+			},
+		},
+		{
+			TestScenario: testutil.TestScenario{
+				Name:       "show",
+				Args:       args("errors show"),
+				WantOutput: "fastly service list",
+			},
+			log: session("fastly service list"),
+		},
+		{
+			TestScenario: testutil.TestScenario{
+				Name:       "show includes rotated log",
+				Args:       args("errors show"),
+				WantOutput: "fastly version",
+			},
+			log:     session("fastly service list"),
+			rotated: session("fastly version"),
+		},
+		{
+			TestScenario: testutil.TestScenario{
+				Name:       "show with limit",
+				Args:       args("errors show --limit 1"),
+				WantOutput: "fastly whoami",
+			},
+			log:        session("fastly service list") + session("fastly whoami"),
+			rotated:    session("fastly version"),
+			wantAbsent: []string{"fastly service list", "fastly version"},
+		},
+		{
+			TestScenario: testutil.TestScenario{
+				Name:       "clear",
+				Args:       args("errors clear"),
+				WantOutput: "Cleared the error log",
+			},
+			log:       session("fastly service list"),
+			rotated:   session("fastly version"),
+			wantClear: true,
+		},
+		{
+			TestScenario: testutil.TestScenario{
+				Name:       "clear with no log",
+				Args:       args("errors clear"),
+				WantOutput: "Cleared the error log",
+			},
+			wantClear: true,
+		},
+	}
+
+	for testcaseIdx := range scenarios {
+		testcase := &scenarios[testcaseIdx]
+		t.Run(testcase.Name, func(t *testing.T) {
+			logPath := filepath.Join(t.TempDir(), "errors.log")
+			if testcase.log != "" {
+				if err := os.WriteFile(logPath, []byte(testcase.log), 0o600); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if testcase.rotated != "" {
+				if err := os.WriteFile(fsterr.RotatedLogPath(logPath), []byte(testcase.rotated), 0o600); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			defer func(path string) { fsterr.LogPath = path }(fsterr.LogPath)
+			fsterr.LogPath = logPath
+
+			var stdout bytes.Buffer
+			opts := testutil.NewRunOpts(testcase.Args, &stdout)
+			err := app.Run(opts)
+			testutil.AssertErrorContains(t, err, testcase.WantError)
+			testutil.AssertStringContains(t, stdout.String(), testcase.WantOutput)
+			for _, s := range testcase.wantAbsent {
+				testutil.AssertStringDoesntContain(t, stdout.String(), s)
+			}
+
+			if testcase.wantClear {
+				for _, path := range []string{logPath, fsterr.RotatedLogPath(logPath)} {
+					if _, err := os.Stat(path); !errors.Is(err, fs.ErrNotExist) {
+						t.Errorf("want %s to be removed, got: %v", path, err)
+					}
+				}
+			}
+		})
+	}
+}
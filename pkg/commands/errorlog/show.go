@@ -0,0 +1,88 @@
+package errorlog
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"strings"
+
+	"github.com/fastly/cli/pkg/cmd"
+	"github.com/fastly/cli/pkg/config"
+	fsterr "github.com/fastly/cli/pkg/errors"
+	"github.com/fastly/cli/pkg/text"
+)
+
+// ShowCommand represents a Kingpin command.
+type ShowCommand struct {
+	cmd.Base
+	limit int
+	path  string
+}
+
+// NewShowCommand returns a usable command registered under the parent.
+func NewShowCommand(parent cmd.Registerer, globals *config.Data, path string) *ShowCommand {
+	var c ShowCommand
+	c.Globals = globals
+	c.path = path
+	c.CmdClause = parent.Command("show", "Show the most recent entries in the error log")
+	c.CmdClause.Flag("limit", "The number of most recent CLI invocations to show").Short('n').Default("5").IntVar(&c.limit)
+	return &c
+}
+
+// Exec invokes the application logic for the command.
+func (c *ShowCommand) Exec(_ io.Reader, out io.Writer) error {
+	var sessions []string
+	for _, path := range []string{fsterr.RotatedLogPath(c.path), c.path} {
+		s, err := readSessions(path)
+		if err != nil {
+			c.Globals.ErrLog.Add(err)
+			return fmt.Errorf("error reading the error log: %w", err)
+		}
+		sessions = append(sessions, s...)
+	}
+
+	if len(sessions) == 0 {
+		text.Info(out, "No errors have been logged.")
+		return nil
+	}
+
+	if c.limit > 0 && len(sessions) > c.limit {
+		sessions = sessions[len(sessions)-c.limit:]
+	}
+
+	text.Info(out, "Showing the %d most recent entries from %s", len(sessions), c.path)
+	for _, s := range sessions {
+		text.Break(out)
+		fmt.Fprintln(out, s)
+		fmt.Fprint(out, fsterr.LogSeparator)
+	}
+	return nil
+}
+
+// readSessions returns the entries persisted for each CLI invocation, oldest
+// first, treating a missing log file as empty.
+func readSessions(path string) ([]string, error) {
+	// gosec flagged this:
+	// G304 (CWE-22): Potential file inclusion via variable
+	//
+	// Disabling as the input is determined from our own package.
+	/* #nosec */
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var sessions []string
+	for _, s := range strings.Split(string(data), fsterr.LogSeparator) {
+		s = strings.TrimSpace(s)
+		if s != "" {
+			sessions = append(sessions, s)
+		}
+	}
+	return sessions, nil
+}
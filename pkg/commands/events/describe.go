@@ -0,0 +1,77 @@
+package events
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/fastly/cli/pkg/cmd"
+	"github.com/fastly/cli/pkg/config"
+	fsterr "github.com/fastly/cli/pkg/errors"
+	"github.com/fastly/go-fastly/v6/fastly"
+)
+
+// NewDescribeCommand returns a usable command registered under the parent.
+func NewDescribeCommand(parent cmd.Registerer, globals *config.Data) *DescribeCommand {
+	var c DescribeCommand
+	c.CmdClause = parent.Command("describe", "Show detailed information about an event").Alias("get")
+	c.Globals = globals
+
+	// Required flags
+	c.CmdClause.Flag("id", "Alphanumeric string identifying an event").Required().StringVar(&c.id)
+
+	// Optional Flags
+	c.RegisterFlagBool(cmd.BoolFlagOpts{
+		Name:        cmd.FlagJSONName,
+		Description: cmd.FlagJSONDesc,
+		Dst:         &c.output.JSON,
+		Short:       'j',
+	})
+	c.RegisterOutputFlags(&c.output)
+
+	return &c
+}
+
+// DescribeCommand calls the Fastly API to describe an appropriate resource.
+type DescribeCommand struct {
+	cmd.Base
+
+	id     string
+	output cmd.Output
+}
+
+// Exec invokes the application logic for the command.
+func (c *DescribeCommand) Exec(_ io.Reader, out io.Writer) (err error) {
+	if c.Globals.Verbose() && c.output.Structured() {
+		return fsterr.ErrInvalidVerboseJSONCombo
+	}
+
+	out, closeOutput, err := c.output.Writer(out)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err = closeOutput(err)
+	}()
+
+	r, err := c.Globals.APIClient.GetAPIEvent(&fastly.GetAPIEventInput{
+		EventID: c.id,
+	})
+	if err != nil {
+		c.Globals.ErrLog.AddWithContext(err, map[string]any{
+			"Event ID": c.id,
+		})
+		return err
+	}
+
+	if c.output.Structured() {
+		if err := c.output.Write(out, r); err != nil {
+			c.Globals.ErrLog.Add(err)
+			return err
+		}
+		return nil
+	}
+
+	fmt.Fprintln(out)
+	printEvent(out, "", r)
+	return nil
+}
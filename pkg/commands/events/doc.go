@@ -0,0 +1,2 @@
+// Package events contains commands to inspect Fastly account event logs.
+package events
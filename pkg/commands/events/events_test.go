@@ -0,0 +1,297 @@
+package events_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/fastly/cli/pkg/app"
+	"github.com/fastly/cli/pkg/commands/events"
+	"github.com/fastly/cli/pkg/mock"
+	"github.com/fastly/cli/pkg/testutil"
+	"github.com/fastly/go-fastly/v6/fastly"
+)
+
+func TestList(t *testing.T) {
+	args := testutil.Args
+	scenarios := []testutil.TestScenario{
+		{
+			Name: "validate API error",
+			API: mock.API{
+				GetAPIEventsFn: func(_ *fastly.GetAPIEventsFilterInput) (fastly.GetAPIEventsResponse, error) {
+					return fastly.GetAPIEventsResponse{}, testutil.Err
+				},
+			},
+			Args:      args("events list"),
+			WantError: testutil.Err.Error(),
+		},
+		{
+			Name:      "validate invalid --since value",
+			Args:      args("events list --since yesterday"),
+			WantError: "error parsing --since value: yesterday",
+		},
+		{
+			Name:      "validate --follow and --page are mutually exclusive",
+			Args:      args("events list --follow --page 2"),
+			WantError: "invalid flag combination, --follow and --page",
+		},
+		{
+			Name:      "validate --interval must be positive",
+			Args:      args("events list --follow --interval 0s"),
+			WantError: "invalid --interval value: 0s",
+		},
+		{
+			Name: "validate API success",
+			API: mock.API{
+				GetAPIEventsFn: getAPIEvents,
+			},
+			Args:       args("events list"),
+			WantOutput: listEventsOutput,
+		},
+		{
+			Name: "validate --since filters older events",
+			API: mock.API{
+				GetAPIEventsFn: getAPIEvents,
+			},
+			Args:       args("events list --since 2021-06-15T00:00:00Z"),
+			WantOutput: listEventsSinceOutput,
+		},
+		{
+			Name: "validate --verbose output",
+			API: mock.API{
+				GetAPIEventsFn: getAPIEvents,
+			},
+			Args:       args("events list --verbose"),
+			WantOutput: listEventsVerboseOutput,
+		},
+	}
+
+	for testcaseIdx := range scenarios {
+		testcase := &scenarios[testcaseIdx]
+		t.Run(testcase.Name, func(t *testing.T) {
+			var stdout bytes.Buffer
+			opts := testutil.NewRunOpts(testcase.Args, &stdout)
+			opts.APIClient = mock.APIClient(testcase.API)
+			err := app.Run(opts)
+			testutil.AssertErrorContains(t, err, testcase.WantError)
+			testutil.AssertString(t, testcase.WantOutput, stdout.String())
+		})
+	}
+}
+
+func TestListFilters(t *testing.T) {
+	var got fastly.GetAPIEventsFilterInput
+
+	var stdout bytes.Buffer
+	opts := testutil.NewRunOpts(testutil.Args("events list --event-type version.activate --service-id 123 --user abc --per-page 5"), &stdout)
+	opts.APIClient = mock.APIClient(mock.API{
+		GetAPIEventsFn: func(i *fastly.GetAPIEventsFilterInput) (fastly.GetAPIEventsResponse, error) {
+			got = *i
+			return fastly.GetAPIEventsResponse{}, nil
+		},
+	})
+	err := app.Run(opts)
+	testutil.AssertNoError(t, err)
+
+	want := fastly.GetAPIEventsFilterInput{
+		EventType:  "version.activate",
+		ServiceID:  "123",
+		UserID:     "abc",
+		PageNumber: 1,
+		MaxResults: 5,
+	}
+	testutil.AssertEqual(t, want, got)
+}
+
+func TestListFollow(t *testing.T) {
+	a := newEvent("abc", "version.activate", time.Date(2021, 6, 1, 10, 0, 0, 0, time.UTC))
+	b := newEvent("def", "service.update", time.Date(2021, 6, 1, 11, 0, 0, 0, time.UTC))
+	c := newEvent("ghi", "service.update", time.Date(2021, 6, 1, 11, 0, 0, 0, time.UTC))
+	d := newEvent("jkl", "version.activate", time.Date(2021, 6, 1, 12, 0, 0, 0, time.UTC))
+
+	// Each poll returns the newest page of events, which overlaps with the
+	// events already displayed.
+	pages := [][]*fastly.Event{
+		{b, a},
+		{c, b, a},
+		{d, c, b},
+	}
+
+	var calls int
+	var inputs []fastly.GetAPIEventsFilterInput
+
+	var stdout bytes.Buffer
+	opts := testutil.NewRunOpts(testutil.Args("events list --follow --interval 10ms --json"), &stdout)
+	opts.APIClient = mock.APIClient(mock.API{
+		GetAPIEventsFn: func(i *fastly.GetAPIEventsFilterInput) (fastly.GetAPIEventsResponse, error) {
+			inputs = append(inputs, *i)
+			if calls == len(pages) {
+				return fastly.GetAPIEventsResponse{Events: pages[calls-1]}, nil
+			}
+			page := pages[calls]
+			calls++
+			if calls == len(pages) {
+				// NOTE: The command stops polling once interrupted.
+				testutil.AssertNoError(t, syscall.Kill(syscall.Getpid(), syscall.SIGINT))
+			}
+			return fastly.GetAPIEventsResponse{Events: page}, nil
+		},
+	})
+	err := app.Run(opts)
+	testutil.AssertNoError(t, err)
+
+	for _, i := range inputs {
+		testutil.AssertEqual(t, fastly.GetAPIEventsFilterInput{PageNumber: 1, MaxResults: 20}, i)
+	}
+
+	var ids []string
+	for _, line := range strings.Split(strings.TrimSpace(stdout.String()), "\n") {
+		var e fastly.Event
+		testutil.AssertNoError(t, json.Unmarshal([]byte(line), &e))
+		ids = append(ids, e.ID)
+	}
+	testutil.AssertEqual(t, []string{"abc", "def", "ghi", "jkl"}, ids)
+}
+
+func TestDescribe(t *testing.T) {
+	args := testutil.Args
+	scenarios := []testutil.TestScenario{
+		{
+			Name:      "validate missing --id flag",
+			Args:      args("events describe"),
+			WantError: "error parsing arguments: required flag --id not provided",
+		},
+		{
+			Name: "validate API error",
+			API: mock.API{
+				GetAPIEventFn: func(_ *fastly.GetAPIEventInput) (*fastly.Event, error) {
+					return nil, testutil.Err
+				},
+			},
+			Args:      args("events describe --id abc"),
+			WantError: testutil.Err.Error(),
+		},
+		{
+			Name: "validate API success",
+			API: mock.API{
+				GetAPIEventFn: func(i *fastly.GetAPIEventInput) (*fastly.Event, error) {
+					return newEvent(i.EventID, "version.activate", time.Date(2021, 6, 15, 23, 0, 0, 0, time.UTC)), nil
+				},
+			},
+			Args:       args("events describe --id abc"),
+			WantOutput: describeEventOutput,
+		},
+	}
+
+	for testcaseIdx := range scenarios {
+		testcase := &scenarios[testcaseIdx]
+		t.Run(testcase.Name, func(t *testing.T) {
+			var stdout bytes.Buffer
+			opts := testutil.NewRunOpts(testcase.Args, &stdout)
+			opts.APIClient = mock.APIClient(testcase.API)
+			err := app.Run(opts)
+			testutil.AssertErrorContains(t, err, testcase.WantError)
+			testutil.AssertString(t, testcase.WantOutput, stdout.String())
+		})
+	}
+}
+
+func TestParseSince(t *testing.T) {
+	now := time.Date(2021, 6, 15, 12, 0, 0, 0, time.UTC)
+
+	got, err := events.ParseSince("", now)
+	testutil.AssertNoError(t, err)
+	testutil.AssertBool(t, true, got.IsZero())
+
+	got, err = events.ParseSince("2h", now)
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, now.Add(-2*time.Hour), got)
+
+	got, err = events.ParseSince("2021-06-01T00:00:00Z", now)
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, time.Date(2021, 6, 1, 0, 0, 0, 0, time.UTC), got)
+
+	_, err = events.ParseSince("last week", now)
+	testutil.AssertErrorContains(t, err, "error parsing --since value")
+}
+
+func newEvent(id, eventType string, created time.Time) *fastly.Event {
+	return &fastly.Event{
+		ID:          id,
+		CustomerID:  "customer",
+		Description: "Version 2 was activated",
+		EventType:   eventType,
+		IP:          "127.0.0.1",
+		Metadata: map[string]any{
+			"version": 2,
+		},
+		ServiceID: "123",
+		UserID:    "user",
+		CreatedAt: &created,
+	}
+}
+
+func getAPIEvents(_ *fastly.GetAPIEventsFilterInput) (fastly.GetAPIEventsResponse, error) {
+	return fastly.GetAPIEventsResponse{
+		Events: []*fastly.Event{
+			newEvent("abc", "version.activate", time.Date(2021, 6, 15, 23, 0, 0, 0, time.UTC)),
+			newEvent("def", "service.update", time.Date(2021, 6, 1, 10, 30, 0, 0, time.UTC)),
+		},
+	}, nil
+}
+
+var listEventsOutput = `CREATED (UTC)     ID   EVENT TYPE        USER ID  SERVICE ID  DESCRIPTION
+2021-06-15 23:00  abc  version.activate  user     123         Version 2 was activated
+2021-06-01 10:30  def  service.update    user     123         Version 2 was activated
+`
+
+var listEventsSinceOutput = `CREATED (UTC)     ID   EVENT TYPE        USER ID  SERVICE ID  DESCRIPTION
+2021-06-15 23:00  abc  version.activate  user     123         Version 2 was activated
+`
+
+var listEventsVerboseOutput = `Fastly API token not provided
+Fastly API endpoint: https://api.fastly.com
+Event 1/2
+	ID: abc
+	Event Type: version.activate
+	Description: Version 2 was activated
+	Customer ID: customer
+	Service ID: 123
+	User ID: user
+	IP: 127.0.0.1
+	Admin: false
+	Created at: 2021-06-15 23:00:00 +0000 UTC
+	Metadata:
+		version: 2
+
+Event 2/2
+	ID: def
+	Event Type: service.update
+	Description: Version 2 was activated
+	Customer ID: customer
+	Service ID: 123
+	User ID: user
+	IP: 127.0.0.1
+	Admin: false
+	Created at: 2021-06-01 10:30:00 +0000 UTC
+	Metadata:
+		version: 2
+
+`
+
+var describeEventOutput = `
+ID: abc
+Event Type: version.activate
+Description: Version 2 was activated
+Customer ID: customer
+Service ID: 123
+User ID: user
+IP: 127.0.0.1
+Admin: false
+Created at: 2021-06-15 23:00:00 +0000 UTC
+Metadata:
+	version: 2
+`
@@ -0,0 +1,410 @@
+package events
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"sort"
+	"syscall"
+	"time"
+
+	"github.com/fastly/cli/pkg/cmd"
+	"github.com/fastly/cli/pkg/config"
+	fsterr "github.com/fastly/cli/pkg/errors"
+	"github.com/fastly/cli/pkg/text"
+	fsttime "github.com/fastly/cli/pkg/time"
+	"github.com/fastly/go-fastly/v6/fastly"
+)
+
+// followPageSize is the number of events requested on each poll when
+// following the event log.
+const followPageSize = 20
+
+// columns is the registry of table columns used by the --columns and
+// --sort-by flags.
+var columns = []text.Column{
+	{Key: "created_at", Header: "CREATED (UTC)"},
+	{Key: "id", Header: "ID"},
+	{Key: "event_type", Header: "EVENT TYPE"},
+	{Key: "user_id", Header: "USER ID"},
+	{Key: "service_id", Header: "SERVICE ID"},
+	{Key: "description", Header: "DESCRIPTION"},
+}
+
+// NewListCommand returns a usable command registered under the parent.
+func NewListCommand(parent cmd.Registerer, globals *config.Data) *ListCommand {
+	var c ListCommand
+	c.CmdClause = parent.Command("list", "List events from the audit log of your Fastly account")
+	c.Globals = globals
+
+	// Optional Flags
+	c.RegisterAllFlag(&c.pagination)
+	c.CmdClause.Flag("event-type", "Limit the returned events to a specific event type (e.g. version.activate)").StringVar(&c.eventType)
+	c.CmdClause.Flag("follow", "Poll for new events until interrupted").Short('f').BoolVar(&c.follow)
+	c.CmdClause.Flag("interval", "How often to poll for new events when using --follow").Default("10s").DurationVar(&c.interval)
+	c.RegisterFlagBool(cmd.BoolFlagOpts{
+		Name:        cmd.FlagJSONName,
+		Description: cmd.FlagJSONDesc,
+		Dst:         &c.json,
+		Short:       'j',
+	})
+	c.RegisterPageFlags(&c.pagination)
+	c.CmdClause.Flag("service-id", "Limit the returned events to a specific service").Short('s').StringVar(&c.serviceID)
+	c.CmdClause.Flag("since", "Only show events created after this point in time (a duration such as 24h, or an RFC3339 timestamp)").StringVar(&c.since)
+	c.CmdClause.Flag("user", "Limit the returned events to those made by a specific user ID").StringVar(&c.userID)
+
+	return &c
+}
+
+// ListCommand calls the Fastly API to list appropriate resources.
+type ListCommand struct {
+	cmd.Base
+
+	eventType  string
+	follow     bool
+	interval   time.Duration
+	json       bool
+	pagination cmd.Pagination
+	serviceID  string
+	since      string
+	userID     string
+}
+
+// Exec invokes the application logic for the command.
+func (c *ListCommand) Exec(_ io.Reader, out io.Writer) error {
+	if c.Globals.Verbose() && c.json {
+		return fsterr.ErrInvalidVerboseJSONCombo
+	}
+	if err := c.pagination.Validate(); err != nil {
+		return err
+	}
+	if c.follow && c.pagination.Page > 0 {
+		return fsterr.RemediationError{
+			Inner:       fmt.Errorf("invalid flag combination, --follow and --page"),
+			Remediation: "Use either --follow or --page, not both.",
+		}
+	}
+	if c.follow && c.pagination.All {
+		return fsterr.RemediationError{
+			Inner:       fmt.Errorf("invalid flag combination, --follow and --all"),
+			Remediation: "Use either --follow or --all, not both.",
+		}
+	}
+
+	if c.follow && c.interval <= 0 {
+		return fsterr.RemediationError{
+			Inner:       fmt.Errorf("invalid --interval value: %s", c.interval),
+			Remediation: "Provide a positive duration (e.g. 10s).",
+		}
+	}
+
+	since, err := ParseSince(c.since, time.Now())
+	if err != nil {
+		c.Globals.ErrLog.Add(err)
+		return err
+	}
+
+	if c.follow {
+		return c.poll(out, since)
+	}
+
+	rs, err := c.fetch(since)
+	if err != nil {
+		return err
+	}
+
+	if c.Globals.Verbose() {
+		c.printVerbose(out, rs)
+		return nil
+	}
+	return c.printSummary(out, rs)
+}
+
+// fetch acquires the events matching the user's filters.
+//
+// NOTE: The API doesn't support filtering by creation date, so the --since
+// filter is applied to the returned results.
+func (c *ListCommand) fetch(since time.Time) ([]*fastly.Event, error) {
+	input := c.constructInput()
+
+	es, err := cmd.FetchPages(c.pagination, func(page, perPage int) ([]*fastly.Event, error) {
+		input.PageNumber = page
+		input.MaxResults = perPage
+		r, err := c.Globals.APIClient.GetAPIEvents(input)
+		return r.Events, err
+	})
+	if err != nil {
+		c.Globals.ErrLog.AddWithContext(err, map[string]any{
+			"Event Type":  c.eventType,
+			"Page Number": c.pagination.Page,
+			"Page Size":   c.pagination.PerPage,
+			"Service ID":  c.serviceID,
+			"User ID":     c.userID,
+		})
+		return nil, err
+	}
+
+	if since.IsZero() {
+		return es, nil
+	}
+
+	var rs []*fastly.Event
+	for _, e := range es {
+		if e.CreatedAt != nil && !e.CreatedAt.Before(since) {
+			rs = append(rs, e)
+		}
+	}
+	return rs, nil
+}
+
+// poll displays the most recent events and then periodically checks for new
+// events until the user interrupts the process.
+//
+// NOTE: Only the newest page of events is requested on each tick. Events are
+// considered new if they were created after the newest event displayed so far,
+// which means only the events sharing that creation time need remembering.
+func (c *ListCommand) poll(out io.Writer, since time.Time) error {
+	var (
+		latest = since
+		seen   = make(map[string]bool)
+		t      = text.NewTable(out)
+	)
+
+	// NOTE: The same table is reused for every tick so the header is only
+	// printed once while the column registry still applies to later rows.
+	t.AddColumns(columns...)
+
+	display := func(rs []*fastly.Event) error {
+		var unseen []*fastly.Event
+		for _, e := range rs {
+			if e.CreatedAt == nil || e.CreatedAt.Before(latest) || (e.CreatedAt.Equal(latest) && seen[e.ID]) {
+				continue
+			}
+			unseen = append(unseen, e)
+		}
+		if len(unseen) == 0 {
+			return nil
+		}
+		sortByCreated(unseen)
+
+		newest := *unseen[len(unseen)-1].CreatedAt
+		if newest.After(latest) {
+			latest = newest
+			seen = make(map[string]bool)
+		}
+		for _, e := range unseen {
+			if e.CreatedAt.Equal(latest) {
+				seen[e.ID] = true
+			}
+		}
+		return c.printStream(out, unseen, t)
+	}
+
+	rs, err := c.fetchLatest()
+	if err != nil {
+		return err
+	}
+	if err := display(rs); err != nil {
+		return err
+	}
+
+	// NOTE: An interrupt is the normal way to stop, so the command handles it
+	// rather than the CLI cleaning up after an interrupted command.
+	c.Globals.Interrupt.Release()
+	sigs := make(chan os.Signal, 1)
+	signal.Notify(sigs, syscall.SIGINT, syscall.SIGTERM)
+	defer signal.Stop(sigs)
+
+	ticker := time.NewTicker(c.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-sigs:
+			return nil
+		case <-ticker.C:
+			rs, err := c.fetchLatest()
+			if err != nil {
+				// NOTE: The error is kept out of the JSON stream so it remains
+				// parseable. It's still recorded in the error log.
+				if !c.json {
+					text.Error(out, "fetching events: %v", err)
+				}
+				continue
+			}
+			if err := display(rs); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// fetchLatest acquires the newest page of events matching the user's filters.
+func (c *ListCommand) fetchLatest() ([]*fastly.Event, error) {
+	input := c.constructInput()
+	input.PageNumber = 1
+	input.MaxResults = followPageSize
+
+	r, err := c.Globals.APIClient.GetAPIEvents(input)
+	if err != nil {
+		c.Globals.ErrLog.AddWithContext(err, map[string]any{
+			"Event Type": c.eventType,
+			"Service ID": c.serviceID,
+			"User ID":    c.userID,
+		})
+		return nil, err
+	}
+	return r.Events, nil
+}
+
+// constructInput transforms values parsed from CLI flags into an object to be used by the API client library.
+func (c *ListCommand) constructInput() *fastly.GetAPIEventsFilterInput {
+	var input fastly.GetAPIEventsFilterInput
+
+	if c.eventType != "" {
+		input.EventType = c.eventType
+	}
+	if c.serviceID != "" {
+		input.ServiceID = c.serviceID
+	}
+	if c.userID != "" {
+		input.UserID = c.userID
+	}
+
+	return &input
+}
+
+// printVerbose displays the information returned from the API in a verbose
+// format.
+func (c *ListCommand) printVerbose(out io.Writer, rs []*fastly.Event) {
+	for i, r := range rs {
+		fmt.Fprintf(out, "Event %d/%d\n", i+1, len(rs))
+		printEvent(out, "\t", r)
+		fmt.Fprintln(out)
+	}
+}
+
+// printSummary displays the information returned from the API in a summarised
+// format.
+func (c *ListCommand) printSummary(out io.Writer, rs []*fastly.Event) error {
+	if c.json {
+		data, err := json.Marshal(rs)
+		if err != nil {
+			return err
+		}
+		_, err = out.Write(data)
+		if err != nil {
+			c.Globals.ErrLog.Add(err)
+			return fmt.Errorf("error: unable to write data to stdout: %w", err)
+		}
+		return nil
+	}
+
+	t := text.NewTable(out)
+	t.AddColumns(columns...)
+	for _, r := range rs {
+		t.AddLine(createdAt(r), r.ID, r.EventType, r.UserID, r.ServiceID, r.Description)
+	}
+	t.Print()
+	return nil
+}
+
+// printStream displays events as they arrive when following the event log.
+//
+// NOTE: JSON output is rendered as one event object per line so the stream
+// can be consumed incrementally by tools such as jq.
+func (c *ListCommand) printStream(out io.Writer, rs []*fastly.Event, t *text.Table) error {
+	switch {
+	case c.json:
+		for _, r := range rs {
+			data, err := json.Marshal(r)
+			if err != nil {
+				return err
+			}
+			_, err = out.Write(data)
+			if err != nil {
+				c.Globals.ErrLog.Add(err)
+				return fmt.Errorf("error: unable to write data to stdout: %w", err)
+			}
+			text.Break(out)
+		}
+	case c.Globals.Verbose():
+		for _, r := range rs {
+			printEvent(out, "", r)
+			fmt.Fprintln(out)
+		}
+	default:
+		for _, r := range rs {
+			t.AddLine(createdAt(r), r.ID, r.EventType, r.UserID, r.ServiceID, r.Description)
+		}
+		t.Print()
+	}
+	return nil
+}
+
+// printEvent displays all the available information for an event.
+func printEvent(out io.Writer, indent string, r *fastly.Event) {
+	fmt.Fprintf(out, "%sID: %s\n", indent, r.ID)
+	fmt.Fprintf(out, "%sEvent Type: %s\n", indent, r.EventType)
+	fmt.Fprintf(out, "%sDescription: %s\n", indent, r.Description)
+	fmt.Fprintf(out, "%sCustomer ID: %s\n", indent, r.CustomerID)
+	fmt.Fprintf(out, "%sService ID: %s\n", indent, r.ServiceID)
+	fmt.Fprintf(out, "%sUser ID: %s\n", indent, r.UserID)
+	fmt.Fprintf(out, "%sIP: %s\n", indent, r.IP)
+	fmt.Fprintf(out, "%sAdmin: %t\n", indent, r.Admin)
+	if r.CreatedAt != nil {
+		fmt.Fprintf(out, "%sCreated at: %s\n", indent, r.CreatedAt)
+	}
+	if len(r.Metadata) > 0 {
+		keys := make([]string, 0, len(r.Metadata))
+		for k := range r.Metadata {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		fmt.Fprintf(out, "%sMetadata:\n", indent)
+		for _, k := range keys {
+			fmt.Fprintf(out, "%s\t%s: %v\n", indent, k, r.Metadata[k])
+		}
+	}
+}
+
+// createdAt returns a table friendly representation of the event's creation
+// date.
+func createdAt(r *fastly.Event) string {
+	if r.CreatedAt == nil {
+		return "n/a"
+	}
+	return r.CreatedAt.UTC().Format(fsttime.Format)
+}
+
+// sortByCreated sorts events into ascending creation order.
+func sortByCreated(rs []*fastly.Event) {
+	sort.SliceStable(rs, func(i, j int) bool {
+		if rs[i].CreatedAt == nil || rs[j].CreatedAt == nil {
+			return rs[j].CreatedAt != nil
+		}
+		return rs[i].CreatedAt.Before(*rs[j].CreatedAt)
+	})
+}
+
+// ParseSince converts the --since flag value into a point in time.
+//
+// The value can either be a duration relative to now (e.g. 24h) or an RFC3339
+// formatted timestamp. An empty value returns the zero time.
+func ParseSince(value string, now time.Time) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	if d, err := time.ParseDuration(value); err == nil {
+		return now.Add(-d), nil
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	return time.Time{}, fsterr.RemediationError{
+		Inner:       fmt.Errorf("error parsing --since value: %s", value),
+		Remediation: "Provide either a duration (e.g. 30m, 24h) or an RFC3339 timestamp (e.g. 2022-09-01T00:00:00Z).",
+	}
+}
@@ -28,3 +28,13 @@ func TestAllIPs(t *testing.T) {
 	testutil.AssertNoError(t, err)
 	testutil.AssertString(t, "\nIPv4\n\t00.123.45.6/78\n\nIPv6\n\t0a12:3b45::/67\n", stdout.String())
 }
+
+func TestAllIPsReplay(t *testing.T) {
+	var stdout bytes.Buffer
+	args := testutil.Args("ip-list --token 123")
+	opts := testutil.NewRunOpts(args, &stdout)
+	opts.APIClient = testutil.ReplayAPIClient(t, "testdata/ip-list.json")
+	err := app.Run(opts)
+	testutil.AssertNoError(t, err)
+	testutil.AssertString(t, "\nIPv4\n\t00.123.45.6/78\n\nIPv6\n\t0a12:3b45::/67\n", stdout.String())
+}
@@ -0,0 +1,2 @@
+// Package kvstore contains commands to inspect and manipulate Fastly KV stores.
+package kvstore
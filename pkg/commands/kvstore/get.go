@@ -0,0 +1,139 @@
+package kvstore
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/fastly/cli/pkg/api/undocumented"
+	"github.com/fastly/cli/pkg/cmd"
+	"github.com/fastly/cli/pkg/config"
+	fsterr "github.com/fastly/cli/pkg/errors"
+	"github.com/fastly/cli/pkg/text"
+)
+
+// NewGetCommand returns a usable command registered under the parent.
+func NewGetCommand(parent cmd.Registerer, globals *config.Data) *GetCommand {
+	var c GetCommand
+	c.CmdClause = parent.Command("get", "Stream the value of a key of a KV store to stdout or a file")
+	c.Globals = globals
+
+	// Required flags
+	c.CmdClause.Flag("key", "Key to read").Required().StringVar(&c.key)
+	c.CmdClause.Flag("store", "Name of the KV store").Required().StringVar(&c.store)
+
+	// Optional flags
+	c.CmdClause.Flag("file", "Path to write the value to, reporting progress (default: stdout)").PlaceHolder("PATH").StringVar(&c.file)
+	c.CmdClause.Flag("range", "Only read the bytes in the range: FIRST-LAST, FIRST- (to the end) or -N (the last N bytes)").StringVar(&c.rng)
+
+	return &c
+}
+
+// GetCommand calls the Fastly API to read the value of a key.
+type GetCommand struct {
+	cmd.Base
+
+	file  string
+	key   string
+	rng   string
+	store string
+}
+
+// Exec invokes the application logic for the command.
+func (c *GetCommand) Exec(_ io.Reader, out io.Writer) (err error) {
+	defer func() {
+		if err != nil {
+			c.Globals.ErrLog.AddWithContext(err, map[string]any{
+				"Key":   c.key,
+				"Range": c.rng,
+				"Store": c.store,
+			})
+		}
+	}()
+
+	var rng *undocumented.ByteRange
+	if c.rng != "" {
+		if rng, err = parseRange(c.rng); err != nil {
+			return err
+		}
+	}
+
+	client, err := newClient(c.Globals)
+	if err != nil {
+		return err
+	}
+	store, err := findStore(client, c.store)
+	if err != nil {
+		return err
+	}
+
+	value, err := client.OpenKVStoreKey(store.ID, c.key, rng)
+	if err != nil {
+		return fmt.Errorf("error reading key '%s': %w", c.key, err)
+	}
+	defer value.Close()
+
+	// NOTE: The value is the only output when it's written to stdout, so that
+	// it can be piped to another command.
+	if c.file == "" {
+		if _, err := io.Copy(out, value); err != nil {
+			return fmt.Errorf("error reading key '%s': %w", c.key, err)
+		}
+		return nil
+	}
+
+	f, err := os.Create(c.file)
+	if err != nil {
+		return fmt.Errorf("error creating --file: %w", err)
+	}
+	defer f.Close() // #nosec G307
+
+	progress := text.NewProgress(out, c.Globals.Verbose())
+	progress.Step(fmt.Sprintf("Downloading '%s'...", c.key))
+	n, err := io.Copy(f, &progressReader{r: value, progress: progress, size: value.Size})
+	if err == nil {
+		err = f.Close()
+	}
+	if err != nil {
+		progress.Fail()
+		return fmt.Errorf("error reading key '%s': %w", c.key, err)
+	}
+	progress.Done()
+
+	text.Success(out, "Wrote key '%s' of KV store '%s' to %s (%s)", c.key, store.Name, c.file, formatBytes(n))
+	return nil
+}
+
+// parseRange parses the --range flag.
+func parseRange(s string) (*undocumented.ByteRange, error) {
+	invalid := fsterr.RemediationError{
+		Inner:       fmt.Errorf("invalid --range '%s'", s),
+		Remediation: "Set --range to FIRST-LAST (e.g. 0-1023), FIRST- (e.g. 1024-) or -N (e.g. -1024), counting bytes from 0.",
+	}
+
+	first, last, ok := strings.Cut(s, "-")
+	if !ok {
+		return nil, invalid
+	}
+	if first == "" {
+		n, err := strconv.ParseInt(last, 10, 64)
+		if err != nil || n <= 0 {
+			return nil, invalid
+		}
+		return &undocumented.ByteRange{First: -n, Last: -1}, nil
+	}
+
+	var err error
+	r := undocumented.ByteRange{Last: -1}
+	if r.First, err = strconv.ParseInt(first, 10, 64); err != nil || r.First < 0 {
+		return nil, invalid
+	}
+	if last != "" {
+		if r.Last, err = strconv.ParseInt(last, 10, 64); err != nil || r.Last < r.First {
+			return nil, invalid
+		}
+	}
+	return &r, nil
+}
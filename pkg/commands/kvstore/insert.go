@@ -0,0 +1,86 @@
+package kvstore
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/fastly/cli/pkg/cmd"
+	"github.com/fastly/cli/pkg/config"
+	"github.com/fastly/cli/pkg/text"
+)
+
+// NewInsertCommand returns a usable command registered under the parent.
+func NewInsertCommand(parent cmd.Registerer, globals *config.Data) *InsertCommand {
+	var c InsertCommand
+	c.CmdClause = parent.Command("insert", "Set the value of a key of a KV store, streaming it from a file or stdin")
+	c.Globals = globals
+
+	// Required flags
+	c.CmdClause.Flag("key", "Key to set").Required().StringVar(&c.key)
+	c.CmdClause.Flag("store", "Name of the KV store").Required().StringVar(&c.store)
+
+	// Optional flags
+	c.CmdClause.Flag("file", "Path to a file whose content is the value (default: stdin)").PlaceHolder("PATH").StringVar(&c.file)
+	c.CmdClause.Flag("metadata", "Metadata (e.g. JSON) to store alongside the value").StringVar(&c.metadata)
+
+	return &c
+}
+
+// InsertCommand calls the Fastly API to set the value of a key.
+type InsertCommand struct {
+	cmd.Base
+
+	file     string
+	key      string
+	metadata string
+	store    string
+}
+
+// Exec invokes the application logic for the command.
+func (c *InsertCommand) Exec(in io.Reader, out io.Writer) (err error) {
+	defer func() {
+		if err != nil {
+			c.Globals.ErrLog.AddWithContext(err, map[string]any{
+				"File":  c.file,
+				"Key":   c.key,
+				"Store": c.store,
+			})
+		}
+	}()
+
+	client, err := newClient(c.Globals)
+	if err != nil {
+		return err
+	}
+	store, err := findStore(client, c.store)
+	if err != nil {
+		return err
+	}
+
+	value, size := in, int64(-1)
+	if c.file != "" {
+		f, err := os.Open(filepath.Clean(c.file))
+		if err != nil {
+			return fmt.Errorf("error reading --file: %w", err)
+		}
+		defer f.Close() // #nosec G307
+		if fi, err := f.Stat(); err == nil {
+			size = fi.Size()
+		}
+		value = f
+	}
+
+	progress := text.NewProgress(out, c.Globals.Verbose())
+	progress.Step(fmt.Sprintf("Uploading '%s'...", c.key))
+	r := &progressReader{r: value, progress: progress, size: size}
+	if err := client.InsertKVStoreKey(store.ID, c.key, r, c.metadata); err != nil {
+		progress.Fail()
+		return fmt.Errorf("error inserting key '%s': %w", c.key, err)
+	}
+	progress.Done()
+
+	text.Success(out, "Inserted key '%s' into KV store '%s' (%s)", c.key, store.Name, formatBytes(r.n))
+	return nil
+}
@@ -0,0 +1,344 @@
+package kvstore
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"mime"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/fastly/cli/pkg/api/undocumented"
+	"github.com/fastly/cli/pkg/config"
+	fsterr "github.com/fastly/cli/pkg/errors"
+	"github.com/fastly/cli/pkg/text"
+)
+
+// ManifestKey is the key of the KV store entry that records the content hash
+// of each key synced from a directory, so that only the files that changed are
+// uploaded.
+const ManifestKey = "_fastly_static_manifest.json"
+
+// newClient returns a client for the KV store API.
+//
+// NOTE: The timeout of the HTTP client is removed, as it applies to the whole
+// request and so would cut short the transfer of a large value.
+func newClient(globals *config.Data) (*undocumented.Client, error) {
+	token, source := globals.Token()
+	if source == config.SourceUndefined {
+		return nil, fsterr.ErrNoToken
+	}
+	host, _ := globals.Endpoint()
+
+	httpClient := globals.HTTPClient
+	if c, ok := httpClient.(*http.Client); ok && c.Timeout > 0 {
+		clone := *c
+		clone.Timeout = 0
+		httpClient = &clone
+	}
+	return undocumented.NewClient(host, token, httpClient), nil
+}
+
+// findStore returns the named KV store.
+func findStore(client *undocumented.Client, name string) (*undocumented.KVStore, error) {
+	stores, err := client.ListKVStores()
+	if err != nil {
+		return nil, fmt.Errorf("error listing KV stores: %w", err)
+	}
+	for _, s := range stores {
+		if s.Name == name {
+			return s, nil
+		}
+	}
+	return nil, fsterr.RemediationError{
+		Inner:       fmt.Errorf("there's no KV store named '%s'", name),
+		Remediation: "Check the --store name, or create the store with `fastly kv-store sync`.",
+	}
+}
+
+// SyncInput describes a directory to mirror into a KV store.
+type SyncInput struct {
+	// Dir is the directory to mirror.
+	Dir string
+	// Files are the paths, relative to Dir, of the files to mirror. Every file
+	// of Dir is mirrored when it's empty.
+	Files []string
+	// MetadataFromExt sets the metadata of each key to its Metadata.
+	MetadataFromExt bool
+	// Prune deletes the keys of the store that aren't files of Dir.
+	Prune bool
+	// Store is the name of the KV store, which is created if it doesn't exist.
+	Store string
+	// Verbose lists each upload and deletion as it happens.
+	Verbose bool
+}
+
+// SyncResult describes the changes made to a KV store by Sync.
+type SyncResult struct {
+	Store *undocumented.KVStore
+
+	Added     int
+	Deleted   int
+	Unchanged int
+	Updated   int
+}
+
+// Changed returns the number of keys that were uploaded or deleted.
+func (r *SyncResult) Changed() int {
+	return r.Added + r.Updated + r.Deleted
+}
+
+// String summarises the changes, e.g. "2 added, 1 updated, 0 deleted, 5
+// unchanged".
+func (r *SyncResult) String() string {
+	return fmt.Sprintf("%d added, %d updated, %d deleted, %d unchanged", r.Added, r.Updated, r.Deleted, r.Unchanged)
+}
+
+// manifest maps each key to the hash of the content and metadata it was
+// uploaded with.
+type manifest map[string]string
+
+// Sync mirrors a directory into a KV store. Each file is stored under its path
+// relative to the directory, with forward slashes (e.g. css/site.css), and is
+// only uploaded when its content or metadata differs from that recorded in the
+// store's ManifestKey entry.
+//
+// NOTE: Keys that aren't files of the directory are left in the store, unless
+// Prune is set, as the active service version may still serve them.
+func Sync(client *undocumented.Client, in SyncInput, out io.Writer) (*SyncResult, error) {
+	files, err := readDir(in.Dir, in.Files)
+	if err != nil {
+		return nil, fmt.Errorf("error reading '%s': %w", in.Dir, err)
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("error reading '%s': the directory has no files", in.Dir)
+	}
+
+	local := make(manifest, len(files))
+	metadata := make(map[string]string, len(files))
+	for k, path := range files {
+		if in.MetadataFromExt {
+			metadata[k] = Metadata(k)
+		}
+		h, err := fileHash(path, metadata[k])
+		if err != nil {
+			return nil, fmt.Errorf("error reading '%s': %w", in.Dir, err)
+		}
+		local[k] = h
+	}
+
+	store, err := findOrCreate(client, in.Store, out)
+	if err != nil {
+		return nil, err
+	}
+
+	remote, err := readManifest(client, store.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	r := &SyncResult{Store: store}
+	var uploads, deletes []string
+	for k, h := range local {
+		switch remote[k] {
+		case h:
+			r.Unchanged++
+			continue
+		case "":
+			r.Added++
+		default:
+			r.Updated++
+		}
+		uploads = append(uploads, k)
+	}
+	sort.Strings(uploads)
+
+	if in.Prune {
+		keys, err := client.ListKVStoreKeys(store.ID, "")
+		if err != nil {
+			return nil, fmt.Errorf("error listing the keys of KV store '%s': %w", store.Name, err)
+		}
+		for _, k := range keys {
+			if _, ok := local[k]; !ok && k != ManifestKey {
+				deletes = append(deletes, k)
+			}
+		}
+		sort.Strings(deletes)
+		r.Deleted = len(deletes)
+	}
+
+	tasks := text.NewTasks(out, in.Verbose)
+	for _, k := range uploads {
+		k := k
+		tasks.Go(fmt.Sprintf("Uploading '%s'...", k), func(_ io.Writer) error {
+			f, err := os.Open(files[k])
+			if err != nil {
+				return err
+			}
+			defer f.Close() // #nosec G307
+			if err := client.InsertKVStoreKey(store.ID, k, f, metadata[k]); err != nil {
+				return fmt.Errorf("error uploading '%s': %w", k, err)
+			}
+			return nil
+		})
+	}
+	for _, k := range deletes {
+		k := k
+		tasks.Go(fmt.Sprintf("Deleting '%s'...", k), func(_ io.Writer) error {
+			if err := client.DeleteKVStoreKey(store.ID, k); err != nil {
+				return fmt.Errorf("error deleting '%s': %w", k, err)
+			}
+			return nil
+		})
+	}
+	if err := tasks.Wait(); err != nil {
+		return nil, err
+	}
+
+	// NOTE: The manifest is only written once every change is made, so that a
+	// failed upload is retried by the next sync.
+	if len(uploads) > 0 || len(deletes) > 0 {
+		for k, h := range local {
+			remote[k] = h
+		}
+		for _, k := range deletes {
+			delete(remote, k)
+		}
+		data, err := json.Marshal(remote)
+		if err != nil {
+			return nil, err
+		}
+		if err := client.InsertKVStoreKey(store.ID, ManifestKey, bytes.NewReader(data), ""); err != nil {
+			return nil, fmt.Errorf("error writing the manifest of KV store '%s': %w", store.Name, err)
+		}
+	}
+
+	return r, nil
+}
+
+// readDir returns the path of each file to mirror by its key.
+func readDir(dir string, files []string) (map[string]string, error) {
+	fi, err := os.Stat(dir)
+	if err != nil {
+		return nil, err
+	}
+	if !fi.IsDir() {
+		return nil, errors.New("not a directory")
+	}
+
+	if len(files) == 0 {
+		err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+			if err != nil || !d.Type().IsRegular() {
+				return err
+			}
+			rel, err := filepath.Rel(dir, path)
+			if err != nil {
+				return err
+			}
+			files = append(files, rel)
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	m := make(map[string]string, len(files))
+	for _, f := range files {
+		m[filepath.ToSlash(f)] = filepath.Join(dir, f)
+	}
+	return m, nil
+}
+
+// fileHash returns the SHA-256 hash of the file's content, followed by the
+// metadata when it's set.
+func fileHash(path, metadata string) (string, error) {
+	f, err := os.Open(filepath.Clean(path))
+	if err != nil {
+		return "", err
+	}
+	defer f.Close() // #nosec G307
+
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", err
+	}
+	if metadata != "" {
+		h.Write([]byte{0})
+		h.Write([]byte(metadata))
+	}
+	return fmt.Sprintf("%x", h.Sum(nil)), nil
+}
+
+// findOrCreate returns the named KV store, creating it if it doesn't exist.
+func findOrCreate(client *undocumented.Client, name string, out io.Writer) (*undocumented.KVStore, error) {
+	stores, err := client.ListKVStores()
+	if err != nil {
+		return nil, fmt.Errorf("error listing KV stores: %w", err)
+	}
+	for _, s := range stores {
+		if s.Name == name {
+			return s, nil
+		}
+	}
+
+	store, err := client.CreateKVStore(name)
+	if err != nil {
+		return nil, fmt.Errorf("error creating KV store '%s': %w", name, err)
+	}
+	text.Info(out, "Created KV store '%s' (id: %s)", store.Name, store.ID)
+	return store, nil
+}
+
+// readManifest returns the hashes recorded in the store, which are empty when
+// nothing has been synced yet.
+func readManifest(client *undocumented.Client, storeID string) (manifest, error) {
+	m := make(manifest)
+	data, err := client.GetKVStoreKey(storeID, ManifestKey)
+	if err != nil {
+		var apiErr undocumented.APIError
+		if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound {
+			return m, nil
+		}
+		return nil, fmt.Errorf("error reading the manifest of the KV store: %w", err)
+	}
+	// NOTE: A manifest that can't be decoded causes every file to be uploaded,
+	// which then replaces it.
+	if err := json.Unmarshal(data, &m); err != nil || m == nil {
+		return make(manifest), nil
+	}
+	return m, nil
+}
+
+// Metadata returns the metadata that --metadata-from-ext sets for the key, as
+// JSON with the content-type and cache-control inferred from its extension.
+//
+// HTML and JSON must be revalidated, as their URLs don't change when they do,
+// while other assets (e.g. fingerprinted scripts and images) are cached for a
+// day.
+func Metadata(key string) string {
+	ext := strings.ToLower(filepath.Ext(key))
+
+	contentType := mime.TypeByExtension(ext)
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+	cacheControl := "public, max-age=86400"
+	switch ext {
+	case ".htm", ".html", ".json", "":
+		cacheControl = "public, max-age=0, must-revalidate"
+	}
+
+	data, _ := json.Marshal(struct {
+		CacheControl string `json:"cache-control"`
+		ContentType  string `json:"content-type"`
+	}{cacheControl, contentType})
+	return string(data)
+}
@@ -0,0 +1,250 @@
+package kvstore_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/fastly/cli/pkg/app"
+	"github.com/fastly/cli/pkg/commands/kvstore"
+	"github.com/fastly/cli/pkg/testutil"
+)
+
+func TestSync(t *testing.T) {
+	args := testutil.Args
+
+	dir := t.TempDir()
+	write := func(name, content string) {
+		t.Helper()
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write("index.html", "<h1>home</h1>")
+	write("css/site.css", "body {}")
+
+	kv := newKVServer(t)
+	defer kv.Close()
+	kv.values["stale.txt"] = "old"
+
+	run := func(a string) (string, error) {
+		var stdout bytes.Buffer
+		opts := testutil.NewRunOpts(args(a), &stdout)
+		err := app.Run(opts)
+		return stdout.String(), err
+	}
+	base := "kv-store sync " + dir + " --store assets --token 123 --endpoint " + kv.URL
+
+	_, err := run("kv-store sync " + dir + " --token 123")
+	testutil.AssertErrorContains(t, err, "required flag --store not provided")
+
+	out, err := run(base + " --metadata-from-ext")
+	testutil.AssertNoError(t, err)
+	testutil.AssertStringContains(t, out, "Created KV store 'assets' (id: store-1)")
+	testutil.AssertStringContains(t, out, "Synced '"+dir+"' to KV store 'assets' (2 added, 0 updated, 0 deleted, 0 unchanged)")
+	testutil.AssertEqual(t, []string{"css/site.css", "index.html"}, kv.takeUploads())
+	testutil.AssertString(t, `{"cache-control":"public, max-age=0, must-revalidate","content-type":"text/html; charset=utf-8"}`, kv.metadata["index.html"])
+	testutil.AssertString(t, "old", kv.values["stale.txt"])
+
+	out, err = run(base + " --metadata-from-ext")
+	testutil.AssertNoError(t, err)
+	testutil.AssertStringContains(t, out, "KV store 'assets' is up to date with '"+dir+"' (2 unchanged)")
+	testutil.AssertEqual(t, []string(nil), kv.takeUploads())
+
+	// Syncing without --metadata-from-ext changes the hash of every file, so
+	// that the metadata is cleared.
+	write("index.html", "<h1>updated</h1>")
+	out, err = run(base + " --prune")
+	testutil.AssertNoError(t, err)
+	testutil.AssertStringContains(t, out, "Synced '"+dir+"' to KV store 'assets' (0 added, 2 updated, 1 deleted, 0 unchanged)")
+	testutil.AssertEqual(t, []string{"css/site.css", "index.html"}, kv.takeUploads())
+	testutil.AssertString(t, "<h1>updated</h1>", kv.values["index.html"])
+	testutil.AssertString(t, "", kv.metadata["index.html"])
+	if _, ok := kv.values["stale.txt"]; ok {
+		t.Error("want stale.txt to be pruned")
+	}
+	if _, ok := kv.values[kvstore.ManifestKey]; !ok {
+		t.Error("want the manifest to be kept")
+	}
+}
+
+func TestInsertAndGet(t *testing.T) {
+	args := testutil.Args
+
+	// NOTE: The value spans several progress reports.
+	value := strings.Repeat("0123456789", 300000)
+	file := filepath.Join(t.TempDir(), "value.bin")
+	if err := os.WriteFile(file, []byte(value), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	kv := newKVServer(t)
+	defer kv.Close()
+	kv.stores = append(kv.stores, map[string]string{"id": "store-1", "name": "assets"})
+
+	run := func(a string, stdin io.Reader) (string, error) {
+		var stdout bytes.Buffer
+		opts := testutil.NewRunOpts(args(a+" --token 123 --endpoint "+kv.URL), &stdout)
+		opts.Stdin = stdin
+		err := app.Run(opts)
+		return stdout.String(), err
+	}
+
+	_, err := run("kv-store insert --store missing --key a", strings.NewReader("a"))
+	testutil.AssertErrorContains(t, err, "there's no KV store named 'missing'")
+
+	out, err := run("kv-store insert --store assets --key big --file "+file+" --verbose", nil)
+	testutil.AssertNoError(t, err)
+	testutil.AssertStringContains(t, out, "Uploading 'big'...")
+	testutil.AssertStringContains(t, out, "1.0 MB of 3.0 MB")
+	testutil.AssertStringContains(t, out, "3.0 MB of 3.0 MB")
+	testutil.AssertStringContains(t, out, "Inserted key 'big' into KV store 'assets' (3.0 MB)")
+	testutil.AssertString(t, value, kv.values["big"])
+
+	out, err = run("kv-store insert --store assets --key small --metadata {}", strings.NewReader("from stdin"))
+	testutil.AssertNoError(t, err)
+	testutil.AssertStringContains(t, out, "Inserted key 'small' into KV store 'assets' (10 B)")
+	testutil.AssertString(t, "from stdin", kv.values["small"])
+	testutil.AssertString(t, "{}", kv.metadata["small"])
+
+	out, err = run("kv-store get --store assets --key small", nil)
+	testutil.AssertNoError(t, err)
+	testutil.AssertString(t, "from stdin", out)
+
+	for _, ranges := range []bool{true, false} {
+		kv.mu.Lock()
+		kv.ranges = ranges
+		kv.mu.Unlock()
+		for rng, want := range map[string]string{
+			"5-8":      "stdi",
+			"5-":       "stdin",
+			"-2":       "in",
+			"-20":      "from stdin",
+			"0-100000": "from stdin",
+		} {
+			out, err = run("kv-store get --store assets --key small --range="+rng, nil)
+			testutil.AssertNoError(t, err)
+			if out != want {
+				t.Errorf("range %s (served by API: %t): want %q, have %q", rng, ranges, want, out)
+			}
+		}
+	}
+
+	_, err = run("kv-store get --store assets --key small --range 8-5", nil)
+	testutil.AssertErrorContains(t, err, "invalid --range '8-5'")
+
+	dst := filepath.Join(t.TempDir(), "out.bin")
+	out, err = run("kv-store get --store assets --key big --file "+dst, nil)
+	testutil.AssertNoError(t, err)
+	testutil.AssertStringContains(t, out, "Wrote key 'big' of KV store 'assets' to "+dst+" (3.0 MB)")
+	data, err := os.ReadFile(dst)
+	testutil.AssertNoError(t, err)
+	testutil.AssertString(t, value, string(data))
+}
+
+func TestMetadata(t *testing.T) {
+	for key, want := range map[string]string{
+		"index.html":     `{"cache-control":"public, max-age=0, must-revalidate","content-type":"text/html; charset=utf-8"}`,
+		"css/site.CSS":   `{"cache-control":"public, max-age=86400","content-type":"text/css; charset=utf-8"}`,
+		"LICENSE":        `{"cache-control":"public, max-age=0, must-revalidate","content-type":"application/octet-stream"}`,
+		"font.unknown42": `{"cache-control":"public, max-age=86400","content-type":"application/octet-stream"}`,
+	} {
+		testutil.AssertString(t, want, kvstore.Metadata(key))
+	}
+}
+
+// kvServer is an in-memory KV store API.
+type kvServer struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	metadata map[string]string
+	// ranges serves the Range header of a GET, which is otherwise ignored.
+	ranges  bool
+	stores  []map[string]string
+	uploads []string
+	values  map[string]string
+}
+
+func newKVServer(t *testing.T) *kvServer {
+	s := &kvServer{
+		metadata: make(map[string]string),
+		values:   make(map[string]string),
+	}
+	const keysPath = "/resources/stores/kv/store-1/keys"
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/resources/stores/kv":
+			_ = json.NewEncoder(w).Encode(map[string]any{"data": s.stores})
+		case r.Method == http.MethodPost && r.URL.Path == "/resources/stores/kv":
+			var body map[string]string
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			store := map[string]string{"id": "store-1", "name": body["name"]}
+			s.stores = append(s.stores, store)
+			_ = json.NewEncoder(w).Encode(store)
+		case r.Method == http.MethodGet && r.URL.Path == keysPath:
+			keys := []string{}
+			for k := range s.values {
+				keys = append(keys, k)
+			}
+			_ = json.NewEncoder(w).Encode(map[string]any{"data": keys})
+		case strings.HasPrefix(r.URL.Path, keysPath+"/"):
+			key := strings.TrimPrefix(r.URL.Path, keysPath+"/")
+			switch r.Method {
+			case http.MethodGet:
+				v, ok := s.values[key]
+				if !ok {
+					w.WriteHeader(http.StatusNotFound)
+					return
+				}
+				if s.ranges {
+					http.ServeContent(w, r, "", time.Time{}, strings.NewReader(v))
+					return
+				}
+				w.Header().Set("Content-Length", strconv.Itoa(len(v)))
+				_, _ = w.Write([]byte(v))
+			case http.MethodPut:
+				b, _ := io.ReadAll(r.Body)
+				s.values[key] = string(b)
+				s.metadata[key] = r.Header.Get("Metadata")
+				if key != kvstore.ManifestKey {
+					s.uploads = append(s.uploads, key)
+				}
+			case http.MethodDelete:
+				delete(s.values, key)
+				delete(s.metadata, key)
+				w.WriteHeader(http.StatusNoContent)
+			}
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	return s
+}
+
+// takeUploads returns the keys uploaded since it was last called, sorted.
+func (s *kvServer) takeUploads() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	uploads := s.uploads
+	s.uploads = nil
+	sort.Strings(uploads)
+	return uploads
+}
@@ -0,0 +1,54 @@
+package kvstore
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/fastly/cli/pkg/text"
+)
+
+// progressInterval is the number of bytes transferred between each report of
+// a progressReader.
+const progressInterval = 1 << 20
+
+// progressReader reports the bytes read from the reader to the progress, so
+// that the transfer of a large value can be followed.
+type progressReader struct {
+	r        io.Reader
+	progress text.Progress
+	// size is the number of bytes to read, or -1 when it's unknown.
+	size int64
+
+	n        int64
+	reported int64
+}
+
+// Read implements the io.Reader interface.
+func (p *progressReader) Read(b []byte) (int, error) {
+	n, err := p.r.Read(b)
+	p.n += int64(n)
+	if p.n-p.reported >= progressInterval || (err == io.EOF && p.n != p.reported) {
+		p.reported = p.n
+		if p.size < 0 {
+			fmt.Fprintf(p.progress, "%s\n", formatBytes(p.n))
+		} else {
+			fmt.Fprintf(p.progress, "%s of %s\n", formatBytes(p.n), formatBytes(p.size))
+		}
+	}
+	return n, err
+}
+
+// formatBytes returns the number of bytes in the largest unit that's at least
+// one, e.g. 12.5 MB.
+func formatBytes(n int64) string {
+	const unit = 1000
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := int64(unit), 0
+	for m := n / unit; m >= unit && exp < 4; m /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %cB", float64(n)/float64(div), "kMGTP"[exp])
+}
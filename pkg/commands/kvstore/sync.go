@@ -0,0 +1,67 @@
+package kvstore
+
+import (
+	"io"
+
+	"github.com/fastly/cli/pkg/cmd"
+	"github.com/fastly/cli/pkg/config"
+	"github.com/fastly/cli/pkg/text"
+)
+
+// NewSyncCommand returns a usable command registered under the parent.
+func NewSyncCommand(parent cmd.Registerer, globals *config.Data) *SyncCommand {
+	var c SyncCommand
+	c.CmdClause = parent.Command("sync", "Mirror a local directory into a KV store, uploading only the files that changed")
+	c.Globals = globals
+
+	c.CmdClause.Arg("dir", "Directory to mirror (e.g. ./public)").Required().StringVar(&c.dir)
+
+	// Required flags
+	c.CmdClause.Flag("store", "Name of the KV store, which is created if it doesn't exist").Required().StringVar(&c.store)
+
+	// Optional flags
+	c.CmdClause.Flag("metadata-from-ext", "Set the content-type and cache-control metadata of each key from its file extension").BoolVar(&c.metadataFromExt)
+	c.CmdClause.Flag("prune", "Delete the keys of the store that aren't files of the directory").BoolVar(&c.prune)
+
+	return &c
+}
+
+// SyncCommand calls the Fastly API to mirror a directory into a KV store.
+type SyncCommand struct {
+	cmd.Base
+
+	dir             string
+	metadataFromExt bool
+	prune           bool
+	store           string
+}
+
+// Exec invokes the application logic for the command.
+func (c *SyncCommand) Exec(_ io.Reader, out io.Writer) error {
+	client, err := newClient(c.Globals)
+	if err != nil {
+		return err
+	}
+
+	r, err := Sync(client, SyncInput{
+		Dir:             c.dir,
+		MetadataFromExt: c.metadataFromExt,
+		Prune:           c.prune,
+		Store:           c.store,
+		Verbose:         c.Globals.Verbose(),
+	}, out)
+	if err != nil {
+		c.Globals.ErrLog.AddWithContext(err, map[string]any{
+			"Directory": c.dir,
+			"Store":     c.store,
+		})
+		return err
+	}
+
+	if r.Changed() == 0 {
+		text.Success(out, "KV store '%s' is up to date with '%s' (%d unchanged)", r.Store.Name, c.dir, r.Unchanged)
+		return nil
+	}
+	text.Success(out, "Synced '%s' to KV store '%s' (%s)", c.dir, r.Store.Name, r)
+	return nil
+}
@@ -7,6 +7,7 @@ import (
 	"testing"
 
 	"github.com/fastly/cli/pkg/app"
+	"github.com/fastly/cli/pkg/commands/logging/azureblob"
 	"github.com/fastly/cli/pkg/mock"
 	"github.com/fastly/cli/pkg/testutil"
 	"github.com/fastly/go-fastly/v6/fastly"
@@ -78,6 +79,48 @@ func TestBlobStorageCreate(t *testing.T) {
 	}
 }
 
+func TestBlobStorageGenerateSAS(t *testing.T) {
+	t.Setenv(azureblob.AccountKeyEnv, "")
+
+	args := testutil.Args
+	scenarios := []struct {
+		args       []string
+		wantError  string
+		wantOutput string
+	}{
+		{
+			args:      args("logging azureblob generate-sas --account-name account"),
+			wantError: "error parsing arguments: required flag --container not provided",
+		},
+		{
+			args:      args("logging azureblob generate-sas --account-name account --container log"),
+			wantError: "no storage account key provided",
+		},
+		{
+			args:      args("logging azureblob generate-sas --account-name account --container log --account-key c2VjcmV0 --expires-in 0s"),
+			wantError: "--expires-in must be positive",
+		},
+		{
+			args:       args("logging azureblob generate-sas --account-name account --container log --account-key c2VjcmV0 --expires-in 72h"),
+			wantOutput: "The SAS token expires at",
+		},
+		{
+			args:       args("logging azureblob generate-sas --account-name account --container log --account-key c2VjcmV0 --json"),
+			wantOutput: `{"sas_token":"se=`,
+		},
+	}
+	for testcaseIdx := range scenarios {
+		testcase := &scenarios[testcaseIdx]
+		t.Run(strings.Join(testcase.args, " "), func(t *testing.T) {
+			var stdout bytes.Buffer
+			opts := testutil.NewRunOpts(testcase.args, &stdout)
+			err := app.Run(opts)
+			testutil.AssertErrorContains(t, err, testcase.wantError)
+			testutil.AssertStringContains(t, stdout.String(), testcase.wantOutput)
+		})
+	}
+}
+
 func TestBlobStorageList(t *testing.T) {
 	args := testutil.Args
 	scenarios := []struct {
@@ -176,6 +219,14 @@ func TestBlobStorageDescribe(t *testing.T) {
 			},
 			wantOutput: describeBlobStorageOutput,
 		},
+		{
+			args: args("logging azureblob describe --service-id 123 --version 1 --name logs --show-secrets"),
+			api: mock.API{
+				ListVersionsFn:   testutil.ListVersions,
+				GetBlobStorageFn: getBlobStorageOK,
+			},
+			wantOutput: strings.Replace(describeBlobStorageOutput, "SAS token: ********", "SAS token: token", 1),
+		},
 	}
 	for testcaseIdx := range scenarios {
 		testcase := &scenarios[testcaseIdx]
@@ -370,7 +421,7 @@ Version: 1
 		Name: logs
 		Container: container
 		Account name: account
-		SAS token: token
+		SAS token: ********
 		Path: /logs
 		Period: 3600
 		GZip level: 0
@@ -389,7 +440,7 @@ Version: 1
 		Name: analytics
 		Container: analytics
 		Account name: account
-		SAS token: token
+		SAS token: ********
 		Path: /logs
 		Period: 86400
 		GZip level: 0
@@ -436,7 +487,7 @@ Version: 1
 Name: logs
 Container: container
 Account name: account
-SAS token: token
+SAS token: ********
 Path: /logs
 Period: 3600
 GZip level: 0
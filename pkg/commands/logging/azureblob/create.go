@@ -3,6 +3,7 @@ package azureblob
 import (
 	"fmt"
 	"io"
+	"time"
 
 	"github.com/fastly/cli/pkg/cmd"
 	"github.com/fastly/cli/pkg/config"
@@ -184,6 +185,10 @@ func (c *CreateCommand) Exec(_ io.Reader, out io.Writer) error {
 		return err
 	}
 
+	for _, w := range CheckSASToken(input.SASToken, time.Now()) {
+		text.Warning(out, "%s", w)
+	}
+
 	d, err := c.Globals.APIClient.CreateBlobStorage(input)
 	if err != nil {
 		c.Globals.ErrLog.AddWithContext(err, map[string]any{
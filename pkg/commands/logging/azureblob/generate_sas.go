@@ -0,0 +1,102 @@
+package azureblob
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/fastly/cli/pkg/cmd"
+	"github.com/fastly/cli/pkg/config"
+	fsterr "github.com/fastly/cli/pkg/errors"
+	"github.com/fastly/cli/pkg/text"
+)
+
+// AccountKeyEnv is the env var we look in for the storage account key.
+const AccountKeyEnv = "AZURE_STORAGE_KEY"
+
+// GenerateSASCommand generates a SAS token for an Azure Blob Storage logging
+// endpoint.
+type GenerateSASCommand struct {
+	cmd.Base
+
+	accountKey  string
+	accountName string
+	container   string
+	expiresIn   time.Duration
+	json        bool
+}
+
+// NewGenerateSASCommand returns a usable command registered under the parent.
+func NewGenerateSASCommand(parent cmd.Registerer, globals *config.Data) *GenerateSASCommand {
+	var c GenerateSASCommand
+	c.Globals = globals
+	c.CmdClause = parent.Command("generate-sas", "Generate a SAS token scoped to writing logs to an Azure Blob Storage container")
+	c.CmdClause.Flag("account-name", "The unique Azure Blob Storage namespace in which your data objects are stored").Required().StringVar(&c.accountName)
+	c.CmdClause.Flag("container", "The name of the Azure Blob Storage container in which to store logs").Required().StringVar(&c.container)
+	c.CmdClause.Flag("account-key", fmt.Sprintf("The storage account access key used to sign the token (falls back to %s)", AccountKeyEnv)).StringVar(&c.accountKey)
+	c.CmdClause.Flag("expires-in", "How long the token is valid for, e.g. 720h").Default("2160h").DurationVar(&c.expiresIn)
+	c.RegisterFlagBool(cmd.BoolFlagOpts{
+		Name:        cmd.FlagJSONName,
+		Description: cmd.FlagJSONDesc,
+		Dst:         &c.json,
+		Short:       'j',
+	})
+	return &c
+}
+
+// Exec invokes the application logic for the command.
+func (c *GenerateSASCommand) Exec(_ io.Reader, out io.Writer) error {
+	if c.accountKey == "" {
+		c.accountKey = os.Getenv(AccountKeyEnv)
+	}
+	if c.accountKey == "" {
+		return fsterr.RemediationError{
+			Inner:       fmt.Errorf("no storage account key provided"),
+			Remediation: fmt.Sprintf("Provide the access key of the storage account with --account-key or the %s environment variable.", AccountKeyEnv),
+		}
+	}
+	if c.expiresIn <= 0 {
+		return fmt.Errorf("error parsing arguments: --expires-in must be positive")
+	}
+
+	now := time.Now()
+	sas := SAS{
+		AccountName: c.accountName,
+		AccountKey:  c.accountKey,
+		Container:   c.container,
+		Start:       now.Add(-sasClockSkew),
+		Expiry:      now.Add(c.expiresIn),
+	}
+	token, err := sas.Token()
+	if err != nil {
+		c.Globals.ErrLog.Add(err)
+		return err
+	}
+
+	if c.json {
+		data, err := json.Marshal(struct {
+			SASToken string    `json:"sas_token"`
+			Expiry   time.Time `json:"expiry"`
+		}{token, sas.Expiry.UTC().Truncate(time.Second)})
+		if err != nil {
+			return err
+		}
+		_, err = out.Write(data)
+		if err != nil {
+			c.Globals.ErrLog.Add(err)
+			return fmt.Errorf("error: unable to write data to stdout: %w", err)
+		}
+		return nil
+	}
+
+	for _, w := range CheckSASToken(token, now) {
+		text.Warning(out, "%s", w)
+	}
+	// NOTE: The token isn't wrapped, so it can be copied as is.
+	fmt.Fprintln(out, token)
+	text.Break(out)
+	text.Info(out, "The token expires at %s. Pass it to the --sas-token flag of the create and update commands.", sas.Expiry.UTC().Format(time.RFC3339))
+	return nil
+}
@@ -91,6 +91,10 @@ func (c *ListCommand) Exec(_ io.Reader, out io.Writer) error {
 		return err
 	}
 
+	for _, azureblob := range azureblobs {
+		azureblob.SASToken = text.Secret(azureblob.SASToken)
+	}
+
 	if !c.Globals.Verbose() {
 		if c.json {
 			data, err := json.Marshal(azureblobs)
@@ -0,0 +1,120 @@
+package azureblob
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// SASVersion is the version of the storage service the SAS tokens are signed
+// for.
+const SASVersion = "2020-12-06"
+
+// SASPermissions are the permissions of the generated SAS tokens, which allow
+// blobs to be created and written to, but not read, listed or deleted.
+const SASPermissions = "cw"
+
+// SASExpiryWarning is how soon a SAS token has to expire for a warning to be
+// printed.
+const SASExpiryWarning = 30 * 24 * time.Hour
+
+// SASMaxLifetime is the lifetime beyond which a SAS token is considered too
+// long lived.
+const SASMaxLifetime = 366 * 24 * time.Hour
+
+// sasClockSkew is how far in the past the generated tokens start, so they're
+// valid despite the clocks of the CLI and the storage service differing.
+const sasClockSkew = 15 * time.Minute
+
+// SAS is the input used to generate a container service SAS token.
+type SAS struct {
+	AccountName string
+	AccountKey  string
+	Container   string
+	Start       time.Time
+	Expiry      time.Time
+}
+
+// Token returns a service SAS token scoped to the container, allowing blobs to
+// be created and written to over HTTPS.
+//
+// https://learn.microsoft.com/en-us/rest/api/storageservices/create-service-sas
+func (s SAS) Token() (string, error) {
+	key, err := base64.StdEncoding.DecodeString(s.AccountKey)
+	if err != nil {
+		return "", fmt.Errorf("error decoding the account key (expected base64): %w", err)
+	}
+
+	start := s.Start.UTC().Format(time.RFC3339)
+	expiry := s.Expiry.UTC().Format(time.RFC3339)
+	stringToSign := strings.Join([]string{
+		SASPermissions,
+		start,
+		expiry,
+		fmt.Sprintf("/blob/%s/%s", s.AccountName, s.Container),
+		"",      // signed identifier
+		"",      // signed IP
+		"https", // signed protocol
+		SASVersion,
+		"c", // signed resource (container)
+		"",  // signed snapshot time
+		"",  // signed encryption scope
+		"",  // Cache-Control
+		"",  // Content-Disposition
+		"",  // Content-Encoding
+		"",  // Content-Language
+		"",  // Content-Type
+	}, "\n")
+
+	h := hmac.New(sha256.New, key)
+	h.Write([]byte(stringToSign))
+
+	q := url.Values{
+		"sv":  {SASVersion},
+		"sr":  {"c"},
+		"sp":  {SASPermissions},
+		"st":  {start},
+		"se":  {expiry},
+		"spr": {"https"},
+		"sig": {base64.StdEncoding.EncodeToString(h.Sum(nil))},
+	}
+	return q.Encode(), nil
+}
+
+// CheckSASToken returns warnings about a SAS token that is malformed, expires
+// soon, or grants more access than writing logs requires.
+func CheckSASToken(token string, now time.Time) []string {
+	q, err := url.ParseQuery(strings.TrimPrefix(token, "?"))
+	if err != nil || q.Get("sig") == "" || q.Get("se") == "" {
+		return []string{"The SAS token is malformed: it should be the query string of a SAS URL, including the sig and se parameters."}
+	}
+
+	var warnings []string
+	if expiry, err := time.Parse(time.RFC3339, q.Get("se")); err == nil {
+		switch {
+		case !expiry.After(now):
+			warnings = append(warnings, fmt.Sprintf("The SAS token expired at %s, and so logs won't be delivered.", expiry.UTC().Format(time.RFC3339)))
+		case expiry.Sub(now) < SASExpiryWarning:
+			warnings = append(warnings, fmt.Sprintf("The SAS token expires at %s, after which logs won't be delivered. Be sure to update the token before then.", expiry.UTC().Format(time.RFC3339)))
+		case expiry.Sub(now) > SASMaxLifetime:
+			warnings = append(warnings, fmt.Sprintf("The SAS token doesn't expire until %s. Consider a shorter lived token, and rotating it.", expiry.UTC().Format(time.RFC3339)))
+		}
+	}
+	if q.Get("srt") != "" || q.Get("ss") != "" {
+		warnings = append(warnings, "The SAS token is an account SAS. A service SAS scoped to the container grants less access.")
+	}
+	extra := strings.Map(func(r rune) rune {
+		if strings.ContainsRune("acw", r) {
+			return -1
+		}
+		return r
+	}, q.Get("sp"))
+	if extra != "" {
+		warnings = append(warnings, fmt.Sprintf("The SAS token grants permissions (%s) beyond the add, create and write permissions logging requires.", extra))
+	}
+	return warnings
+}
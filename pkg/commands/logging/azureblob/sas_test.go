@@ -0,0 +1,93 @@
+package azureblob_test
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/fastly/cli/pkg/commands/logging/azureblob"
+	"github.com/fastly/cli/pkg/testutil"
+)
+
+func TestSASToken(t *testing.T) {
+	key := base64.StdEncoding.EncodeToString([]byte("secret"))
+	sas := azureblob.SAS{
+		AccountName: "account",
+		AccountKey:  key,
+		Container:   "logs",
+		Start:       time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
+		Expiry:      time.Date(2023, 4, 1, 0, 0, 0, 0, time.UTC),
+	}
+	token, err := sas.Token()
+	testutil.AssertNoError(t, err)
+
+	q, err := url.ParseQuery(token)
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, "c", q.Get("sr"))
+	testutil.AssertEqual(t, "cw", q.Get("sp"))
+	testutil.AssertEqual(t, "https", q.Get("spr"))
+	testutil.AssertEqual(t, "2023-01-01T00:00:00Z", q.Get("st"))
+	testutil.AssertEqual(t, "2023-04-01T00:00:00Z", q.Get("se"))
+
+	stringToSign := "cw\n2023-01-01T00:00:00Z\n2023-04-01T00:00:00Z\n/blob/account/logs\n\n\nhttps\n" + azureblob.SASVersion + "\nc" + strings.Repeat("\n", 7)
+	h := hmac.New(sha256.New, []byte("secret"))
+	h.Write([]byte(stringToSign))
+	testutil.AssertEqual(t, base64.StdEncoding.EncodeToString(h.Sum(nil)), q.Get("sig"))
+
+	sas.AccountKey = "not base64!"
+	_, err = sas.Token()
+	testutil.AssertErrorContains(t, err, "error decoding the account key")
+}
+
+func TestCheckSASToken(t *testing.T) {
+	now := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	scenarios := []struct {
+		name  string
+		token string
+		want  []string
+	}{
+		{
+			name:  "scoped",
+			token: "sv=2020-12-06&sr=c&sp=cw&se=2023-03-01T00:00:00Z&sig=abc",
+		},
+		{
+			name:  "malformed",
+			token: "abc",
+			want:  []string{"The SAS token is malformed"},
+		},
+		{
+			name:  "expired",
+			token: "?sp=w&se=2022-12-01T00:00:00Z&sig=abc",
+			want:  []string{"The SAS token expired at 2022-12-01T00:00:00Z"},
+		},
+		{
+			name:  "expires soon",
+			token: "sp=w&se=2023-01-08T00:00:00Z&sig=abc",
+			want:  []string{"The SAS token expires at 2023-01-08T00:00:00Z"},
+		},
+		{
+			name:  "over-scoped account SAS",
+			token: "ss=b&srt=sco&sp=rwdlac&se=2025-01-01T00:00:00Z&sig=abc",
+			want: []string{
+				"The SAS token doesn't expire until 2025-01-01T00:00:00Z",
+				"The SAS token is an account SAS",
+				"The SAS token grants permissions (rdl)",
+			},
+		},
+	}
+	for _, testcase := range scenarios {
+		t.Run(testcase.name, func(t *testing.T) {
+			have := azureblob.CheckSASToken(testcase.token, now)
+			if len(have) != len(testcase.want) {
+				t.Fatalf("want %d warnings, have %d: %v", len(testcase.want), len(have), have)
+			}
+			for i, w := range testcase.want {
+				testutil.AssertStringContains(t, have[i], w)
+			}
+		})
+	}
+}
@@ -2,6 +2,7 @@ package azureblob
 
 import (
 	"io"
+	"time"
 
 	"github.com/fastly/cli/pkg/cmd"
 	"github.com/fastly/cli/pkg/config"
@@ -193,6 +194,12 @@ func (c *UpdateCommand) Exec(_ io.Reader, out io.Writer) error {
 		return err
 	}
 
+	if c.SASToken.WasSet {
+		for _, w := range CheckSASToken(c.SASToken.Value, time.Now()) {
+			text.Warning(out, "%s", w)
+		}
+	}
+
 	azureblob, err := c.Globals.APIClient.UpdateBlobStorage(input)
 	if err != nil {
 		c.Globals.ErrLog.AddWithContext(err, map[string]any{
@@ -91,6 +91,10 @@ func (c *ListCommand) Exec(_ io.Reader, out io.Writer) error {
 		return err
 	}
 
+	for _, bq := range bqs {
+		bq.SecretKey = text.Secret(bq.SecretKey)
+	}
+
 	if !c.Globals.Verbose() {
 		if c.json {
 			data, err := json.Marshal(bqs)
@@ -355,7 +355,7 @@ Version: 1
 		Version: 1
 		Name: logs
 		User: username
-		Access key: 1234
+		Access key: ********
 		Bucket: my-logs
 		Path: logs/
 		Region: ORD
@@ -373,7 +373,7 @@ Version: 1
 		Version: 1
 		Name: analytics
 		User: username
-		Access key: 1234
+		Access key: ********
 		Bucket: analytics
 		Path: logs/
 		Region: ORD
@@ -419,7 +419,7 @@ Service ID: 123
 Version: 1
 Name: logs
 User: username
-Access key: 1234
+Access key: ********
 Bucket: my-logs
 Path: logs/
 Region: ORD
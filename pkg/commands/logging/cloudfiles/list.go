@@ -91,6 +91,10 @@ func (c *ListCommand) Exec(_ io.Reader, out io.Writer) error {
 		return err
 	}
 
+	for _, cloudfile := range cloudfiles {
+		cloudfile.AccessKey = text.Secret(cloudfile.AccessKey)
+	}
+
 	if !c.Globals.Verbose() {
 		if c.json {
 			data, err := json.Marshal(cloudfiles)
@@ -0,0 +1,131 @@
+package logging
+
+import (
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"os"
+
+	"github.com/fastly/cli/pkg/cmd"
+	fsterr "github.com/fastly/cli/pkg/errors"
+)
+
+// FlagCredentialsFileName is the name of the flag providing the key file of a
+// Google Cloud service account.
+const FlagCredentialsFileName = "credentials-file"
+
+// FlagCredentialsFileDesc is the description of the flag providing the key
+// file of a Google Cloud service account.
+const FlagCredentialsFileDesc = "Path to the JSON key file of a Google Cloud service account. Its client_email and private_key fields are used unless --user and --secret-key are set"
+
+// FlagCredentialsFileProjectDesc is the description of the flag providing the
+// key file of a Google Cloud service account, for endpoints with a project.
+const FlagCredentialsFileProjectDesc = "Path to the JSON key file of a Google Cloud service account. Its client_email, private_key and project_id fields are used unless --user, --secret-key and --project-id are set"
+
+// GoogleCredentials are the fields of a Google Cloud service account key file
+// used by the logging endpoints.
+type GoogleCredentials struct {
+	Type        string `json:"type"`
+	ProjectID   string `json:"project_id"`
+	PrivateKey  string `json:"private_key"`
+	ClientEmail string `json:"client_email"`
+}
+
+// ReadGoogleCredentials reads and validates the key file of a Google Cloud
+// service account.
+func ReadGoogleCredentials(path string) (GoogleCredentials, error) {
+	var creds GoogleCredentials
+
+	// gosec flagged this:
+	// G304 (CWE-22): Potential file inclusion via variable
+	// Disabling as we need to read the key file provided by the user.
+	/* #nosec */
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return creds, fmt.Errorf("error reading credentials file: %w", err)
+	}
+
+	remediation := "Download a JSON key for the service account from the Google Cloud console (IAM & Admin > Service Accounts > Keys)."
+	if err := json.Unmarshal(data, &creds); err != nil {
+		return creds, fsterr.RemediationError{
+			Inner:       fmt.Errorf("error parsing credentials file %s: %w", path, err),
+			Remediation: remediation,
+		}
+	}
+
+	var problem string
+	switch {
+	case creds.Type != "service_account":
+		problem = fmt.Sprintf("the type is '%s' rather than 'service_account'", creds.Type)
+	case creds.ClientEmail == "":
+		problem = "the client_email field is missing"
+	case creds.PrivateKey == "":
+		problem = "the private_key field is missing"
+	}
+	if problem == "" {
+		if block, _ := pem.Decode([]byte(creds.PrivateKey)); block == nil {
+			problem = "the private_key field isn't a PEM encoded key"
+		}
+	}
+	if problem != "" {
+		return creds, fsterr.RemediationError{
+			Inner:       fmt.Errorf("invalid credentials file %s: %s", path, problem),
+			Remediation: remediation,
+		}
+	}
+	return creds, nil
+}
+
+// ApplyGoogleCredentials reads the key file of a Google Cloud service account,
+// unless path is empty, into whichever of the values aren't already set by
+// flags, and then checks they are all set. A nil projectID is ignored.
+func ApplyGoogleCredentials(path string, user, secretKey, projectID *string) error {
+	if path != "" {
+		creds, err := ReadGoogleCredentials(path)
+		if err != nil {
+			return err
+		}
+		if *user == "" {
+			*user = creds.ClientEmail
+		}
+		if *secretKey == "" {
+			*secretKey = creds.PrivateKey
+		}
+		if projectID != nil && *projectID == "" {
+			*projectID = creds.ProjectID
+		}
+	}
+
+	required := []struct {
+		flag  string
+		value *string
+	}{{"user", user}, {"secret-key", secretKey}, {"project-id", projectID}}
+	for _, r := range required {
+		if r.value != nil && *r.value == "" {
+			return fmt.Errorf("error parsing arguments: required flag --%s not provided (or provide it with --%s)", r.flag, FlagCredentialsFileName)
+		}
+	}
+	return nil
+}
+
+// SetGoogleCredentials reads the key file of a Google Cloud service account,
+// unless path is empty, into whichever of the optional values aren't already
+// set by flags.
+func SetGoogleCredentials(path string, user, secretKey *cmd.OptionalString) error {
+	if path == "" {
+		return nil
+	}
+	creds, err := ReadGoogleCredentials(path)
+	if err != nil {
+		return err
+	}
+	if !user.WasSet {
+		user.WasSet = true
+		user.Value = creds.ClientEmail
+	}
+	if !secretKey.WasSet {
+		secretKey.WasSet = true
+		secretKey.Value = creds.PrivateKey
+	}
+	return nil
+}
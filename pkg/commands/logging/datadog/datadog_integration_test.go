@@ -42,6 +42,15 @@ func TestDatadogCreate(t *testing.T) {
 			},
 			wantError: errTest.Error(),
 		},
+		{
+			args: args("logging datadog create --service-id 123 --version 1 --name log --auth-token abc --autoclone --preset nope"),
+			api: mock.API{
+				ListVersionsFn:  testutil.ListVersions,
+				CloneVersionFn:  testutil.CloneVersionResult(4),
+				CreateDatadogFn: createDatadogOK,
+			},
+			wantError: "unknown logging preset 'nope'",
+		},
 	}
 	for testcaseIdx := range scenarios {
 		testcase := &scenarios[testcaseIdx]
@@ -322,7 +331,7 @@ Version: 1
 		Service ID: 123
 		Version: 1
 		Name: logs
-		Token: abc
+		Token: ********
 		Region: US
 		Format: %h %l %u %t "%r" %>s %b
 		Format version: 2
@@ -332,7 +341,7 @@ Version: 1
 		Service ID: 123
 		Version: 1
 		Name: analytics
-		Token: abc
+		Token: ********
 		Region: US
 		Format: %h %l %u %t "%r" %>s %b
 		Format version: 2
@@ -362,7 +371,7 @@ var describeDatadogOutput = "\n" + strings.TrimSpace(`
 Service ID: 123
 Version: 1
 Name: logs
-Token: abc
+Token: ********
 Region: US
 Format: %h %l %u %t "%r" %>s %b
 Format version: 2
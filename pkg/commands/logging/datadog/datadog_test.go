@@ -46,6 +46,18 @@ func TestCreateDatadogInput(t *testing.T) {
 				Placement:         "none",
 			},
 		},
+		{
+			name: "preset overridden by format version flag",
+			cmd:  createCommandPreset(),
+			want: &fastly.CreateDatadogInput{
+				ServiceID:      "123",
+				ServiceVersion: 4,
+				Name:           "log",
+				Format:         `{"status":"%>s"}`,
+				FormatVersion:  1,
+				Token:          "tkn",
+			},
+		},
 		{
 			name:      "error missing serviceID",
 			cmd:       createCommandMissingServiceID(),
@@ -252,6 +264,16 @@ func createCommandRequired() *datadog.CreateCommand {
 	}
 }
 
+func createCommandPreset() *datadog.CreateCommand {
+	res := createCommandRequired()
+	res.Globals.File.LoggingPresets = config.LoggingPresets{
+		"datadog": {Format: `{"status":"%>s"}`, FormatVersion: 2},
+	}
+	res.Preset = cmd.OptionalString{Optional: cmd.Optional{WasSet: true}, Value: "datadog"}
+	res.FormatVersion = cmd.OptionalUint{Optional: cmd.Optional{WasSet: true}, Value: 1}
+	return res
+}
+
 func createCommandMissingServiceID() *datadog.CreateCommand {
 	res := createCommandOK()
 	res.Manifest = manifest.Data{}
@@ -88,6 +88,10 @@ func (c *ListCommand) Exec(_ io.Reader, out io.Writer) error {
 		return err
 	}
 
+	for _, datadog := range datadogs {
+		datadog.Token = text.Secret(datadog.Token)
+	}
+
 	if !c.Globals.Verbose() {
 		if c.json {
 			data, err := json.Marshal(datadogs)
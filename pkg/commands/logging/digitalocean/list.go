@@ -88,6 +88,11 @@ func (c *ListCommand) Exec(_ io.Reader, out io.Writer) error {
 		return err
 	}
 
+	for _, digitalocean := range digitaloceans {
+		digitalocean.AccessKey = text.Secret(digitalocean.AccessKey)
+		digitalocean.SecretKey = text.Secret(digitalocean.SecretKey)
+	}
+
 	if !c.Globals.Verbose() {
 		if c.json {
 			data, err := json.Marshal(digitaloceans)
@@ -88,6 +88,11 @@ func (c *ListCommand) Exec(_ io.Reader, out io.Writer) error {
 		return err
 	}
 
+	for _, elasticsearch := range elasticsearchs {
+		elasticsearch.TLSClientKey = text.Secret(elasticsearch.TLSClientKey)
+		elasticsearch.Password = text.Secret(elasticsearch.Password)
+	}
+
 	if !c.Globals.Verbose() {
 		if c.json {
 			data, err := json.Marshal(elasticsearchs)
@@ -365,7 +365,7 @@ Version: 1
 		Address: example.com
 		Port: 123
 		Username: anonymous
-		Password: foo@example.com
+		Password: ********
 		Public key: `+pgpPublicKey()+`
 		Path: logs/
 		Period: 3600
@@ -383,7 +383,7 @@ Version: 1
 		Address: 127.0.0.1
 		Port: 456
 		Username: foo
-		Password: password
+		Password: ********
 		Public key: `+pgpPublicKey()+`
 		Path: logs/
 		Period: 86400
@@ -429,7 +429,7 @@ Name: logs
 Address: example.com
 Port: 123
 Username: anonymous
-Password: foo@example.com
+Password: ********
 Public key: `+pgpPublicKey()+`
 Path: logs/
 Period: 3600
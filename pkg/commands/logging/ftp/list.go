@@ -88,6 +88,10 @@ func (c *ListCommand) Exec(_ io.Reader, out io.Writer) error {
 		return err
 	}
 
+	for _, ftp := range ftps {
+		ftp.Password = text.Secret(ftp.Password)
+	}
+
 	if !c.Globals.Verbose() {
 		if c.json {
 			data, err := json.Marshal(ftps)
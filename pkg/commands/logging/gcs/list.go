@@ -88,6 +88,10 @@ func (c *ListCommand) Exec(_ io.Reader, out io.Writer) error {
 		return err
 	}
 
+	for _, gcs := range gcss {
+		gcs.SecretKey = text.Secret(gcs.SecretKey)
+	}
+
 	if !c.Globals.Verbose() {
 		if c.json {
 			data, err := json.Marshal(gcss)
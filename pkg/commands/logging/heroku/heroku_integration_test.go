@@ -335,7 +335,7 @@ Version: 1
 		Version: 1
 		Name: logs
 		URL: example.com
-		Token: abc
+		Token: ********
 		Format: %h %l %u %t "%r" %>s %b
 		Format version: 2
 		Response condition: Prevent default logging
@@ -345,7 +345,7 @@ Version: 1
 		Version: 1
 		Name: analytics
 		URL: bar.com
-		Token: abc
+		Token: ********
 		Format: %h %l %u %t "%r" %>s %b
 		Format version: 2
 		Response condition: Prevent default logging
@@ -375,7 +375,7 @@ Service ID: 123
 Version: 1
 Name: logs
 URL: example.com
-Token: abc
+Token: ********
 Format: %h %l %u %t "%r" %>s %b
 Format version: 2
 Response condition: Prevent default logging
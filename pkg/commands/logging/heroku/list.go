@@ -88,6 +88,10 @@ func (c *ListCommand) Exec(_ io.Reader, out io.Writer) error {
 		return err
 	}
 
+	for _, heroku := range herokus {
+		heroku.Token = text.Secret(heroku.Token)
+	}
+
 	if !c.Globals.Verbose() {
 		if c.json {
 			data, err := json.Marshal(herokus)
@@ -4,6 +4,7 @@ import (
 	"io"
 
 	"github.com/fastly/cli/pkg/cmd"
+	"github.com/fastly/cli/pkg/commands/logging"
 	"github.com/fastly/cli/pkg/config"
 	"github.com/fastly/cli/pkg/errors"
 	"github.com/fastly/cli/pkg/manifest"
@@ -29,6 +30,7 @@ type CreateCommand struct {
 	FormatVersion     cmd.OptionalUint
 	ResponseCondition cmd.OptionalString
 	Placement         cmd.OptionalString
+	Preset            cmd.OptionalString
 }
 
 // NewCreateCommand returns a usable command registered under the parent.
@@ -66,6 +68,7 @@ func NewCreateCommand(parent cmd.Registerer, globals *config.Data, data manifest
 	c.CmdClause.Flag("format-version", "The version of the custom logging format used for the configured endpoint. Can be either 2 (default) or 1").Action(c.FormatVersion.Set).UintVar(&c.FormatVersion.Value)
 	c.CmdClause.Flag("response-condition", "The name of an existing condition in the configured endpoint, or leave blank to always execute").Action(c.ResponseCondition.Set).StringVar(&c.ResponseCondition.Value)
 	c.CmdClause.Flag("placement", "Where in the generated VCL the logging call should be placed, overriding any format_version default. Can be none or waf_debug").Action(c.Placement.Set).StringVar(&c.Placement.Value)
+	c.CmdClause.Flag(logging.FlagPresetName, logging.FlagPresetDesc).Action(c.Preset.Set).StringVar(&c.Preset.Value)
 	return &c
 }
 
@@ -79,6 +82,15 @@ func (c *CreateCommand) ConstructInput(serviceID string, serviceVersion int) (*f
 	input.Token = c.Token
 	input.Dataset = c.Dataset
 
+	if c.Preset.WasSet {
+		p, err := c.Globals.File.LoggingPreset(c.Preset.Value)
+		if err != nil {
+			return nil, err
+		}
+		input.Format = p.Format
+		input.FormatVersion = p.FormatVersion
+	}
+
 	if c.Format.WasSet {
 		input.Format = c.Format.Value
 	}
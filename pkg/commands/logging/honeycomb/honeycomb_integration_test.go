@@ -335,7 +335,7 @@ Version: 1
 		Version: 1
 		Name: logs
 		Dataset: log
-		Token: tkn
+		Token: ********
 		Format: %h %l %u %t "%r" %>s %b
 		Format version: 2
 		Response condition: Prevent default logging
@@ -345,7 +345,7 @@ Version: 1
 		Version: 1
 		Name: analytics
 		Dataset: log
-		Token: tkn
+		Token: ********
 		Format: %h %l %u %t "%r" %>s %b
 		Format version: 2
 		Response condition: Prevent default logging
@@ -375,7 +375,7 @@ Service ID: 123
 Version: 1
 Name: logs
 Dataset: log
-Token: tkn
+Token: ********
 Format: %h %l %u %t "%r" %>s %b
 Format version: 2
 Response condition: Prevent default logging
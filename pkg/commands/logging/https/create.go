@@ -4,6 +4,7 @@ import (
 	"io"
 
 	"github.com/fastly/cli/pkg/cmd"
+	"github.com/fastly/cli/pkg/commands/logging"
 	"github.com/fastly/cli/pkg/config"
 	"github.com/fastly/cli/pkg/errors"
 	"github.com/fastly/cli/pkg/manifest"
@@ -39,7 +40,9 @@ type CreateCommand struct {
 	Format            cmd.OptionalString
 	FormatVersion     cmd.OptionalUint
 	Placement         cmd.OptionalString
+	Preset            cmd.OptionalString
 	ResponseCondition cmd.OptionalString
+	Preview           bool
 }
 
 // NewCreateCommand returns a usable command registered under the parent.
@@ -85,6 +88,8 @@ func NewCreateCommand(parent cmd.Registerer, globals *config.Data, data manifest
 	c.CmdClause.Flag("format", "Apache style log formatting. Your log must produce valid JSON that HTTPS can ingest").Action(c.Format.Set).StringVar(&c.Format.Value)
 	c.CmdClause.Flag("format-version", "The version of the custom logging format used for the configured endpoint. Can be either 2 (default) or 1").Action(c.FormatVersion.Set).UintVar(&c.FormatVersion.Value)
 	c.CmdClause.Flag("placement", "Where in the generated VCL the logging call should be placed, overriding any format_version default. Can be none or waf_debug").Action(c.Placement.Set).StringVar(&c.Placement.Value)
+	c.CmdClause.Flag(logging.FlagPresetName, logging.FlagPresetDesc).Action(c.Preset.Set).StringVar(&c.Preset.Value)
+	c.CmdClause.Flag("preview", "Print an example of the requests the endpoint would send, with the format rendered using a sample request, rather than creating it").BoolVar(&c.Preview)
 	c.CmdClause.Flag("response-condition", "The name of an existing condition in the configured endpoint, or leave blank to always execute").Action(c.ResponseCondition.Set).StringVar(&c.ResponseCondition.Value)
 	c.CmdClause.Flag("request-max-entries", "Maximum number of logs to append to a batch, if non-zero. Defaults to 10k").Action(c.RequestMaxEntries.Set).UintVar(&c.RequestMaxEntries.Value)
 	c.CmdClause.Flag("request-max-bytes", "Maximum size of log batch, if non-zero. Defaults to 100MB").Action(c.RequestMaxBytes.Set).UintVar(&c.RequestMaxBytes.Value)
@@ -100,6 +105,18 @@ func (c *CreateCommand) ConstructInput(serviceID string, serviceVersion int) (*f
 	input.URL = c.URL
 	input.ServiceVersion = serviceVersion
 
+	if c.Preset.WasSet {
+		p, err := c.Globals.File.LoggingPreset(c.Preset.Value)
+		if err != nil {
+			return nil, err
+		}
+		input.Format = p.Format
+		input.FormatVersion = p.FormatVersion
+		input.ContentType = p.ContentType
+		input.JSONFormat = p.JSONFormat
+		input.MessageType = p.MessageType
+	}
+
 	if c.ContentType.WasSet {
 		input.ContentType = c.ContentType.Value
 	}
@@ -169,6 +186,16 @@ func (c *CreateCommand) ConstructInput(serviceID string, serviceVersion int) (*f
 
 // Exec invokes the application logic for the command.
 func (c *CreateCommand) Exec(_ io.Reader, out io.Writer) error {
+	if c.Preview {
+		input, err := c.ConstructInput("", 0)
+		if err != nil {
+			c.Globals.ErrLog.Add(err)
+			return err
+		}
+		NewPreviewRequest(input).Print(out)
+		return nil
+	}
+
 	serviceID, serviceVersion, err := cmd.ServiceDetails(cmd.ServiceDetailsOpts{
 		AutoCloneFlag:      c.AutoClone,
 		APIClient:          c.Globals.APIClient,
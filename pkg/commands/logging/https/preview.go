@@ -0,0 +1,95 @@
+package https
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/fastly/cli/pkg/commands/logging"
+	"github.com/fastly/cli/pkg/text"
+	"github.com/fastly/go-fastly/v6/fastly"
+)
+
+// PreviewRequest is an example of the requests an HTTPS logging endpoint
+// sends, for a batch containing a single log line of a sample request.
+type PreviewRequest struct {
+	Method  string
+	URL     string
+	Headers [][2]string
+	Body    string
+	// JSON is whether the body is expected to be JSON.
+	JSON bool
+}
+
+// NewPreviewRequest returns an example of the requests the endpoint sends.
+func NewPreviewRequest(input *fastly.CreateHTTPSInput) PreviewRequest {
+	r := PreviewRequest{
+		Method: input.Method,
+		URL:    input.URL,
+	}
+	if r.Method == "" {
+		r.Method = http.MethodPost
+	}
+	if input.ContentType != "" {
+		r.Headers = append(r.Headers, [2]string{"Content-Type", input.ContentType})
+	}
+	if input.HeaderName != "" {
+		r.Headers = append(r.Headers, [2]string{input.HeaderName, input.HeaderValue})
+	}
+
+	format := input.Format
+	if format == "" {
+		format = logging.DefaultFormat
+	}
+	line := logging.SampleMessagePrefix(input.MessageType, input.Name) + logging.SampleFormat(format)
+
+	switch input.JSONFormat {
+	case "1":
+		r.Body = "[" + line + "]"
+	default:
+		r.Body = line + "\n"
+	}
+	r.JSON = input.JSONFormat == "1" || input.JSONFormat == "2" || strings.Contains(input.ContentType, "json")
+	return r
+}
+
+// Validate returns an error if the body is expected to be JSON but isn't.
+//
+// NOTE: A body of newline delimited JSON is validated line by line.
+func (r PreviewRequest) Validate() error {
+	if !r.JSON {
+		return nil
+	}
+	lines := []string{r.Body}
+	if !strings.HasPrefix(r.Body, "[") {
+		lines = strings.Split(strings.TrimSuffix(r.Body, "\n"), "\n")
+	}
+	for _, l := range lines {
+		var v any
+		if err := json.Unmarshal([]byte(l), &v); err != nil {
+			return fmt.Errorf("the body isn't valid JSON: %w", err)
+		}
+	}
+	return nil
+}
+
+// Print writes the request in the HTTP/1.1 wire format, followed by a warning
+// if the body isn't valid JSON when it's expected to be.
+func (r PreviewRequest) Print(out io.Writer) {
+	fmt.Fprintf(out, "%s %s\n", r.Method, r.URL)
+	for _, h := range r.Headers {
+		fmt.Fprintf(out, "%s: %s\n", h[0], h[1])
+	}
+	fmt.Fprintf(out, "\n%s", r.Body)
+	if !strings.HasSuffix(r.Body, "\n") {
+		fmt.Fprintln(out)
+	}
+
+	text.Break(out)
+	text.Info(out, "The log format was rendered with the values of a sample request. Unset headers are rendered as (null), and VCL expressions without a sample value as <expression>.")
+	if err := r.Validate(); err != nil {
+		text.Warning(out, "%s. Check the --format and --json-format flags match the expectations of the receiving system.", err)
+	}
+}
@@ -43,6 +43,15 @@ type CreateCommand struct {
 	AuthMethod        cmd.OptionalString
 	User              cmd.OptionalString
 	Password          cmd.OptionalString
+
+	// schema registry
+	SchemaRegistryURL      string
+	SchemaRegistryUsername string
+	SchemaRegistryPassword string
+	SchemaFile             string
+	SchemaType             string
+	SchemaSubject          string
+	SchemaValidateOnly     bool
 }
 
 // NewCreateCommand returns a usable command registered under the parent.
@@ -90,9 +99,16 @@ func NewCreateCommand(parent cmd.Registerer, globals *config.Data, data manifest
 	c.CmdClause.Flag("parse-log-keyvals", "Parse key-value pairs within the log format").Action(c.ParseLogKeyvals.Set).BoolVar(&c.ParseLogKeyvals.Value)
 	c.CmdClause.Flag("max-batch-size", "The maximum size of the log batch in bytes").Action(c.RequestMaxBytes.Set).UintVar(&c.RequestMaxBytes.Value)
 	c.CmdClause.Flag("use-sasl", "Enable SASL authentication. Requires --auth-method, --username, and --password to be specified").Action(c.UseSASL.Set).BoolVar(&c.UseSASL.Value)
-	c.CmdClause.Flag("auth-method", "SASL authentication method. Valid values are: plain, scram-sha-256, scram-sha-512").Action(c.AuthMethod.Set).HintOptions("plain", "scram-sha-256", "scram-sha-512").EnumVar(&c.AuthMethod.Value, "plain", "scram-sha-256", "scram-sha-512")
-	c.CmdClause.Flag("username", "SASL authentication username. Required if --auth-method is specified").Action(c.User.Set).StringVar(&c.User.Value)
-	c.CmdClause.Flag("password", "SASL authentication password. Required if --auth-method is specified").Action(c.Password.Set).StringVar(&c.Password.Value)
+	c.CmdClause.Flag("auth-method", "SASL authentication method. Valid values are: plain, scram-sha-256, scram-sha-512, oauthbearer").Action(c.AuthMethod.Set).HintOptions(AuthMethods...).EnumVar(&c.AuthMethod.Value, AuthMethods...)
+	c.CmdClause.Flag("username", "SASL authentication username. Required if --auth-method is specified, unless it's oauthbearer").Action(c.User.Set).StringVar(&c.User.Value)
+	c.CmdClause.Flag("password", "SASL authentication password, or the OAuth bearer token when --auth-method is oauthbearer. Required if --auth-method is specified").Action(c.Password.Set).StringVar(&c.Password.Value)
+	c.CmdClause.Flag("schema-registry-url", "The URL of a Confluent schema registry to register the schema of the logs with before creating the endpoint. Requires --schema-file").StringVar(&c.SchemaRegistryURL)
+	c.CmdClause.Flag("schema-registry-username", "The username (or API key) used to authenticate with the schema registry").StringVar(&c.SchemaRegistryUsername)
+	c.CmdClause.Flag("schema-registry-password", "The password (or API secret) used to authenticate with the schema registry").StringVar(&c.SchemaRegistryPassword)
+	c.CmdClause.Flag("schema-file", "Path to the schema of the logs produced by --format. Requires --schema-registry-url").StringVar(&c.SchemaFile)
+	c.CmdClause.Flag("schema-type", "The type of the schema. Valid values are: AVRO (default), JSON, PROTOBUF").Default(SchemaTypeAvro).HintOptions(SchemaTypeAvro, SchemaTypeJSON, SchemaTypeProtobuf).EnumVar(&c.SchemaType, SchemaTypeAvro, SchemaTypeJSON, SchemaTypeProtobuf)
+	c.CmdClause.Flag("schema-subject", "The subject the schema is registered under (default: <topic>-value)").StringVar(&c.SchemaSubject)
+	c.CmdClause.Flag("schema-validate-only", "Only validate the schema is compatible with the latest version registered under the subject, rather than registering it").BoolVar(&c.SchemaValidateOnly)
 	return &c
 }
 
@@ -100,12 +116,8 @@ func NewCreateCommand(parent cmd.Registerer, globals *config.Data, data manifest
 func (c *CreateCommand) ConstructInput(serviceID string, serviceVersion int) (*fastly.CreateKafkaInput, error) {
 	var input fastly.CreateKafkaInput
 
-	if c.UseSASL.WasSet && c.UseSASL.Value && (c.AuthMethod.Value == "" || c.User.Value == "" || c.Password.Value == "") {
-		return nil, fmt.Errorf("the --auth-method, --username, and --password flags must be present when using the --use-sasl flag")
-	}
-
-	if !c.UseSASL.Value && (c.AuthMethod.Value != "" || c.User.Value != "" || c.Password.Value != "") {
-		return nil, fmt.Errorf("the --auth-method, --username, and --password options are only valid when the --use-sasl flag is specified")
+	if err := validateSASL(c.UseSASL, c.AuthMethod.Value, c.User.Value, c.Password.Value); err != nil {
+		return nil, err
 	}
 
 	input.ServiceID = serviceID
@@ -206,6 +218,14 @@ func (c *CreateCommand) Exec(_ io.Reader, out io.Writer) error {
 		return err
 	}
 
+	if err := c.registerSchema(out); err != nil {
+		c.Globals.ErrLog.AddWithContext(err, map[string]any{
+			"Schema registry": c.SchemaRegistryURL,
+			"Schema file":     c.SchemaFile,
+		})
+		return err
+	}
+
 	d, err := c.Globals.APIClient.CreateKafka(input)
 	if err != nil {
 		c.Globals.ErrLog.Add(err)
@@ -215,3 +235,47 @@ func (c *CreateCommand) Exec(_ io.Reader, out io.Writer) error {
 	text.Success(out, "Created Kafka logging endpoint %s (service %s version %d)", d.Name, d.ServiceID, d.ServiceVersion)
 	return nil
 }
+
+// registerSchema registers the schema of the logs with the schema registry,
+// or only validates it's compatible when --schema-validate-only is set.
+func (c *CreateCommand) registerSchema(out io.Writer) error {
+	if c.SchemaRegistryURL == "" && c.SchemaFile == "" {
+		return nil
+	}
+	if c.SchemaRegistryURL == "" || c.SchemaFile == "" {
+		return errors.RemediationError{
+			Inner:       fmt.Errorf("the --schema-registry-url and --schema-file flags must be used together"),
+			Remediation: "Provide both flags to register the schema of the logs, or neither to skip the schema registry.",
+		}
+	}
+
+	schema, err := ReadSchema(c.SchemaFile, c.SchemaType)
+	if err != nil {
+		return err
+	}
+	subject := c.SchemaSubject
+	if subject == "" {
+		subject = SubjectName(c.Topic)
+	}
+	registry := SchemaRegistry{
+		URL:      c.SchemaRegistryURL,
+		Username: c.SchemaRegistryUsername,
+		Password: c.SchemaRegistryPassword,
+		Client:   c.Globals.HTTPClient,
+	}
+
+	if err := registry.Validate(subject, schema); err != nil {
+		return err
+	}
+	if c.SchemaValidateOnly {
+		text.Info(out, "Validated schema against subject %s", subject)
+		return nil
+	}
+
+	id, err := registry.Register(subject, schema)
+	if err != nil {
+		return err
+	}
+	text.Info(out, "Registered schema %d under subject %s", id, subject)
+	return nil
+}
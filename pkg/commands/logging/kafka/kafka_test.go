@@ -75,6 +75,21 @@ func TestCreateKafkaInput(t *testing.T) {
 				Password:        "12345",
 			},
 		},
+		{
+			name: "verify SASL OAUTHBEARER fields",
+			cmd:  createCommandSASL("oauthbearer", "", "token"),
+			want: &fastly.CreateKafkaInput{
+				ServiceID:       "123",
+				ServiceVersion:  4,
+				Name:            "log",
+				Topic:           "logs",
+				Brokers:         "127.0.0.1,127.0.0.2",
+				ParseLogKeyvals: true,
+				RequestMaxBytes: 11111,
+				AuthMethod:      "oauthbearer",
+				Password:        "token",
+			},
+		},
 	} {
 		t.Run(testcase.name, func(t *testing.T) {
 			var bs []byte
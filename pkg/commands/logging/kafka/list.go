@@ -88,6 +88,11 @@ func (c *ListCommand) Exec(_ io.Reader, out io.Writer) error {
 		return err
 	}
 
+	for _, kafka := range kafkas {
+		kafka.TLSClientKey = text.Secret(kafka.TLSClientKey)
+		kafka.Password = text.Secret(kafka.Password)
+	}
+
 	if !c.Globals.Verbose() {
 		if c.json {
 			data, err := json.Marshal(kafkas)
@@ -0,0 +1,37 @@
+package kafka
+
+import (
+	"fmt"
+
+	"github.com/fastly/cli/pkg/cmd"
+)
+
+// AuthMethodOAuthBearer is the SASL authentication method using an OAuth
+// bearer token, which is sent as the password without a username.
+const AuthMethodOAuthBearer = "oauthbearer"
+
+// AuthMethods are the supported SASL authentication methods.
+var AuthMethods = []string{"plain", "scram-sha-256", "scram-sha-512", AuthMethodOAuthBearer}
+
+// validateSASL returns an error if the SASL flags are inconsistent.
+func validateSASL(useSASL cmd.OptionalBool, authMethod, user, password string) error {
+	if useSASL.WasSet && useSASL.Value {
+		if authMethod == AuthMethodOAuthBearer {
+			if password == "" {
+				return fmt.Errorf("the --password flag must be set to the OAuth bearer token when using the %s authentication method", AuthMethodOAuthBearer)
+			}
+			if user != "" {
+				return fmt.Errorf("the --username flag isn't valid when using the %s authentication method", AuthMethodOAuthBearer)
+			}
+			return nil
+		}
+		if authMethod == "" || user == "" || password == "" {
+			return fmt.Errorf("the --auth-method, --username, and --password flags must be present when using the --use-sasl flag")
+		}
+	}
+
+	if !useSASL.Value && (authMethod != "" || user != "" || password != "") {
+		return fmt.Errorf("the --auth-method, --username, and --password options are only valid when the --use-sasl flag is specified")
+	}
+	return nil
+}
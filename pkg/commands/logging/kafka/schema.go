@@ -0,0 +1,153 @@
+package kafka
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/fastly/cli/pkg/api"
+	fsterr "github.com/fastly/cli/pkg/errors"
+	"github.com/fastly/cli/pkg/useragent"
+)
+
+// The schema types supported by a Confluent schema registry.
+const (
+	SchemaTypeAvro     = "AVRO"
+	SchemaTypeJSON     = "JSON"
+	SchemaTypeProtobuf = "PROTOBUF"
+)
+
+// schemaRegistryContentType is the media type of schema registry requests.
+const schemaRegistryContentType = "application/vnd.schemaregistry.v1+json"
+
+// errSubjectNotFound is the error code returned by a schema registry when
+// the subject has no registered versions.
+const errSubjectNotFound = 40401
+
+// SchemaRegistry is a client for the parts of the Confluent schema registry
+// API used to register and validate the schema of the logs.
+type SchemaRegistry struct {
+	URL      string
+	Username string
+	Password string
+	Client   api.HTTPClient
+}
+
+// Schema is a schema of the logs sent to a topic.
+type Schema struct {
+	Schema string `json:"schema"`
+	// Type is omitted for Avro, which is the registry's default.
+	Type string `json:"schemaType,omitempty"`
+}
+
+// ReadSchema reads a schema of the given type from a file.
+func ReadSchema(path, schemaType string) (Schema, error) {
+	// gosec flagged this:
+	// G304 (CWE-22): Potential file inclusion via variable
+	// Disabling as we need to read the schema file provided by the user.
+	/* #nosec */
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Schema{}, fmt.Errorf("error reading schema file: %w", err)
+	}
+	s := Schema{Schema: string(data)}
+	if t := strings.ToUpper(schemaType); t != SchemaTypeAvro {
+		s.Type = t
+	}
+	return s, nil
+}
+
+// SubjectName returns the default subject of the schema of a topic's values.
+func SubjectName(topic string) string {
+	return topic + "-value"
+}
+
+// Register registers the schema under the subject and returns its ID. The
+// registry returns the ID of the existing schema if it's already registered.
+func (r SchemaRegistry) Register(subject string, s Schema) (int, error) {
+	var result struct {
+		ID int `json:"id"`
+	}
+	path := fmt.Sprintf("/subjects/%s/versions", url.PathEscape(subject))
+	if err := r.do(path, s, &result); err != nil {
+		return 0, fmt.Errorf("error registering schema for subject '%s': %w", subject, err)
+	}
+	return result.ID, nil
+}
+
+// Validate returns an error if the schema isn't compatible with the latest
+// version registered under the subject. A subject without any registered
+// versions is compatible with any schema.
+func (r SchemaRegistry) Validate(subject string, s Schema) error {
+	var result struct {
+		IsCompatible bool     `json:"is_compatible"`
+		Messages     []string `json:"messages"`
+	}
+	path := fmt.Sprintf("/compatibility/subjects/%s/versions/latest?verbose=true", url.PathEscape(subject))
+	if err := r.do(path, s, &result); err != nil {
+		if e, ok := err.(schemaRegistryError); ok && e.Code == errSubjectNotFound {
+			return nil
+		}
+		return fmt.Errorf("error validating schema for subject '%s': %w", subject, err)
+	}
+	if !result.IsCompatible {
+		return fsterr.RemediationError{
+			Inner:       fmt.Errorf("the schema isn't compatible with the latest version of subject '%s': %s", subject, strings.Join(result.Messages, "; ")),
+			Remediation: "Update the schema file so it's compatible with the registered schema, or change the compatibility level of the subject.",
+		}
+	}
+	return nil
+}
+
+// schemaRegistryError is an error returned by a schema registry.
+type schemaRegistryError struct {
+	Code    int    `json:"error_code"`
+	Message string `json:"message"`
+	Status  string `json:"-"`
+}
+
+// Error implements the error interface.
+func (e schemaRegistryError) Error() string {
+	if e.Message == "" {
+		return fmt.Sprintf("unexpected response status: %s", e.Status)
+	}
+	return fmt.Sprintf("unexpected response status: %s: %s", e.Status, e.Message)
+}
+
+// do sends a POST request with a JSON body to the registry, decoding a
+// successful JSON response into result.
+func (r SchemaRegistry) do(path string, body, result any) error {
+	data, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, strings.TrimSuffix(r.URL, "/")+path, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", schemaRegistryContentType)
+	req.Header.Set("Content-Type", schemaRegistryContentType)
+	req.Header.Set("User-Agent", useragent.Name)
+	if r.Username != "" || r.Password != "" {
+		req.SetBasicAuth(r.Username, r.Password)
+	}
+
+	res, err := r.Client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close() // #nosec G307
+
+	if res.StatusCode < http.StatusOK || res.StatusCode >= http.StatusMultipleChoices {
+		e := schemaRegistryError{Status: res.Status}
+		_ = json.NewDecoder(io.LimitReader(res.Body, 4096)).Decode(&e)
+		return e
+	}
+	return json.NewDecoder(res.Body).Decode(result)
+}
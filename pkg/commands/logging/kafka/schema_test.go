@@ -0,0 +1,79 @@
+package kafka_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/fastly/cli/pkg/commands/logging/kafka"
+	"github.com/fastly/cli/pkg/testutil"
+)
+
+func TestSchemaRegistry(t *testing.T) {
+	var registered kafka.Schema
+	compatible := true
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if user, pass, ok := r.BasicAuth(); !ok || user != "key" || pass != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		switch r.URL.Path {
+		case "/compatibility/subjects/logs-value/versions/latest":
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"is_compatible": compatible,
+				"messages":      []string{"reader field 'status' has no default"},
+			})
+		case "/compatibility/subjects/new-value/versions/latest":
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"error_code":40401,"message":"Subject 'new-value' not found."}`))
+		case "/subjects/logs-value/versions":
+			_ = json.NewDecoder(r.Body).Decode(&registered)
+			_, _ = w.Write([]byte(`{"id":7}`))
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	defer server.Close()
+
+	path := filepath.Join(t.TempDir(), "schema.json")
+	if err := os.WriteFile(path, []byte(`{"type":"object"}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	schema, err := kafka.ReadSchema(path, "json")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	registry := kafka.SchemaRegistry{
+		URL:      server.URL + "/",
+		Username: "key",
+		Password: "secret",
+		Client:   http.DefaultClient,
+	}
+
+	if err := registry.Validate(kafka.SubjectName("logs"), schema); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if err := registry.Validate(kafka.SubjectName("new"), schema); err != nil {
+		t.Fatalf("unexpected error for a new subject: %s", err)
+	}
+
+	id, err := registry.Register(kafka.SubjectName("logs"), schema)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	testutil.AssertEqual(t, 7, id)
+	testutil.AssertEqual(t, schema, registered)
+	testutil.AssertEqual(t, kafka.SchemaTypeJSON, registered.Type)
+
+	compatible = false
+	err = registry.Validate(kafka.SubjectName("logs"), schema)
+	testutil.AssertErrorContains(t, err, "reader field 'status' has no default")
+
+	registry.Password = "wrong"
+	_, err = registry.Register(kafka.SubjectName("logs"), schema)
+	testutil.AssertErrorContains(t, err, "401 Unauthorized")
+}
@@ -1,7 +1,6 @@
 package kafka
 
 import (
-	"fmt"
 	"io"
 
 	"github.com/fastly/cli/pkg/cmd"
@@ -93,20 +92,16 @@ func NewUpdateCommand(parent cmd.Registerer, globals *config.Data, data manifest
 	c.CmdClause.Flag("parse-log-keyvals", "Parse key-value pairs within the log format").Action(c.ParseLogKeyvals.Set).NegatableBoolVar(&c.ParseLogKeyvals.Value)
 	c.CmdClause.Flag("max-batch-size", "The maximum size of the log batch in bytes").Action(c.RequestMaxBytes.Set).UintVar(&c.RequestMaxBytes.Value)
 	c.CmdClause.Flag("use-sasl", "Enable SASL authentication. Requires --auth-method, --username, and --password to be specified").Action(c.UseSASL.Set).BoolVar(&c.UseSASL.Value)
-	c.CmdClause.Flag("auth-method", "SASL authentication method. Valid values are: plain, scram-sha-256, scram-sha-512").Action(c.AuthMethod.Set).HintOptions("plain", "scram-sha-256", "scram-sha-512").EnumVar(&c.AuthMethod.Value, "plain", "scram-sha-256", "scram-sha-512")
-	c.CmdClause.Flag("username", "SASL authentication username. Required if --auth-method is specified").Action(c.User.Set).StringVar(&c.User.Value)
-	c.CmdClause.Flag("password", "SASL authentication password. Required if --auth-method is specified").Action(c.Password.Set).StringVar(&c.Password.Value)
+	c.CmdClause.Flag("auth-method", "SASL authentication method. Valid values are: plain, scram-sha-256, scram-sha-512, oauthbearer").Action(c.AuthMethod.Set).HintOptions(AuthMethods...).EnumVar(&c.AuthMethod.Value, AuthMethods...)
+	c.CmdClause.Flag("username", "SASL authentication username. Required if --auth-method is specified, unless it's oauthbearer").Action(c.User.Set).StringVar(&c.User.Value)
+	c.CmdClause.Flag("password", "SASL authentication password, or the OAuth bearer token when --auth-method is oauthbearer. Required if --auth-method is specified").Action(c.Password.Set).StringVar(&c.Password.Value)
 	return &c
 }
 
 // ConstructInput transforms values parsed from CLI flags into an object to be used by the API client library.
 func (c *UpdateCommand) ConstructInput(serviceID string, serviceVersion int) (*fastly.UpdateKafkaInput, error) {
-	if c.UseSASL.WasSet && c.UseSASL.Value && (c.AuthMethod.Value == "" || c.User.Value == "" || c.Password.Value == "") {
-		return nil, fmt.Errorf("the --auth-method, --username, and --password flags must be present when using the --use-sasl flag")
-	}
-
-	if !c.UseSASL.Value && (c.AuthMethod.Value != "" || c.User.Value != "" || c.Password.Value != "") {
-		return nil, fmt.Errorf("the --auth-method, --username, and --password options are only valid when the --use-sasl flag is specified")
+	if err := validateSASL(c.UseSASL, c.AuthMethod.Value, c.User.Value, c.Password.Value); err != nil {
+		return nil, err
 	}
 
 	input := fastly.UpdateKafkaInput{
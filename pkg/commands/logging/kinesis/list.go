@@ -88,6 +88,11 @@ func (c *ListCommand) Exec(_ io.Reader, out io.Writer) error {
 		return err
 	}
 
+	for _, kinesis := range kineses {
+		kinesis.AccessKey = text.Secret(kinesis.AccessKey)
+		kinesis.SecretKey = text.Secret(kinesis.SecretKey)
+	}
+
 	if !c.Globals.Verbose() {
 		if c.json {
 			data, err := json.Marshal(kineses)
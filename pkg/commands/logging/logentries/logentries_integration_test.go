@@ -319,7 +319,7 @@ Version: 1
 		Name: logs
 		Port: 20000
 		Use TLS: true
-		Token: tkn
+		Token: ********
 		Format: %h %l %u %t "%r" %>s %b
 		Format version: 2
 		Response condition: Prevent default logging
@@ -331,7 +331,7 @@ Version: 1
 		Name: analytics
 		Port: 20001
 		Use TLS: false
-		Token: tkn1
+		Token: ********
 		Format: %h %l %u %t "%r" %>s %b
 		Format version: 2
 		Response condition: Prevent default logging
@@ -365,7 +365,7 @@ Version: 1
 Name: logs
 Port: 20000
 Use TLS: true
-Token: tkn
+Token: ********
 Format: %h %l %u %t "%r" %>s %b
 Format version: 2
 Response condition: Prevent default logging
@@ -324,7 +324,7 @@ Version: 1
 		Service ID: 123
 		Version: 1
 		Name: logs
-		Token: abc
+		Token: ********
 		Format: %h %l %u %t "%r" %>s %b
 		Format version: 2
 		Response condition: Prevent default logging
@@ -333,7 +333,7 @@ Version: 1
 		Service ID: 123
 		Version: 1
 		Name: analytics
-		Token: abc
+		Token: ********
 		Format: %h %l %u %t "%r" %>s %b
 		Format version: 2
 		Response condition: Prevent default logging
@@ -361,7 +361,7 @@ var describeLogglyOutput = "\n" + strings.TrimSpace(`
 Service ID: 123
 Version: 1
 Name: logs
-Token: abc
+Token: ********
 Format: %h %l %u %t "%r" %>s %b
 Format version: 2
 Response condition: Prevent default logging
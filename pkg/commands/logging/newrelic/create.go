@@ -4,6 +4,7 @@ import (
 	"io"
 
 	"github.com/fastly/cli/pkg/cmd"
+	"github.com/fastly/cli/pkg/commands/logging"
 	"github.com/fastly/cli/pkg/config"
 	"github.com/fastly/cli/pkg/errors"
 	"github.com/fastly/cli/pkg/manifest"
@@ -36,6 +37,7 @@ func NewCreateCommand(parent cmd.Registerer, globals *config.Data, data manifest
 	c.CmdClause.Flag("format", "A Fastly log format string. Must produce valid JSON that New Relic Logs can ingest").StringVar(&c.format)
 	c.CmdClause.Flag("format-version", "The version of the custom logging format used for the configured endpoint").UintVar(&c.formatVersion)
 	c.CmdClause.Flag("placement", "Where in the generated VCL the logging call should be placed").StringVar(&c.placement)
+	c.CmdClause.Flag(logging.FlagPresetName, logging.FlagPresetDesc).Action(c.preset.Set).StringVar(&c.preset.Value)
 	c.CmdClause.Flag("region", "The region to which to stream logs").StringVar(&c.region)
 	c.CmdClause.Flag("response-condition", "The name of an existing condition in the configured endpoint").Action(c.responseCondition.Set).StringVar(&c.responseCondition.Value)
 	c.RegisterFlag(cmd.StringFlagOpts{
@@ -65,6 +67,7 @@ type CreateCommand struct {
 	manifest          manifest.Data
 	name              string
 	placement         string
+	preset            cmd.OptionalString
 	region            string
 	responseCondition cmd.OptionalString
 	serviceName       cmd.OptionalServiceNameID
@@ -90,7 +93,11 @@ func (c *CreateCommand) Exec(_ io.Reader, out io.Writer) error {
 		return err
 	}
 
-	input := c.constructInput(serviceID, serviceVersion.Number)
+	input, err := c.constructInput(serviceID, serviceVersion.Number)
+	if err != nil {
+		c.Globals.ErrLog.Add(err)
+		return err
+	}
 
 	l, err := c.Globals.APIClient.CreateNewRelic(input)
 	if err != nil {
@@ -106,7 +113,7 @@ func (c *CreateCommand) Exec(_ io.Reader, out io.Writer) error {
 }
 
 // constructInput transforms values parsed from CLI flags into an object to be used by the API client library.
-func (c *CreateCommand) constructInput(serviceID string, serviceVersion int) *fastly.CreateNewRelicInput {
+func (c *CreateCommand) constructInput(serviceID string, serviceVersion int) (*fastly.CreateNewRelicInput, error) {
 	var input fastly.CreateNewRelicInput
 
 	input.Name = c.name
@@ -114,6 +121,14 @@ func (c *CreateCommand) constructInput(serviceID string, serviceVersion int) *fa
 	input.ServiceVersion = serviceVersion
 	input.Token = c.key
 
+	if c.preset.WasSet {
+		p, err := c.Globals.File.LoggingPreset(c.preset.Value)
+		if err != nil {
+			return nil, err
+		}
+		input.Format = p.Format
+		input.FormatVersion = p.FormatVersion
+	}
 	if c.format != "" {
 		input.Format = c.format
 	}
@@ -130,5 +145,5 @@ func (c *CreateCommand) constructInput(serviceID string, serviceVersion int) *fa
 		input.ResponseCondition = c.responseCondition.Value
 	}
 
-	return &input
+	return &input, nil
 }
@@ -95,6 +95,10 @@ func (c *ListCommand) Exec(_ io.Reader, out io.Writer) error {
 		return err
 	}
 
+	for _, nr := range l {
+		nr.Token = text.Secret(nr.Token)
+	}
+
 	if c.Globals.Verbose() {
 		c.printVerbose(out, serviceVersion.Number, l)
 	} else {
@@ -377,7 +377,7 @@ Version: 1
 		Version: 1
 		Name: logs
 		Bucket: my-logs
-		Access key: 1234
+		Access key: ********
 		User: user
 		URL: https://example.com
 		Path: logs/
@@ -396,7 +396,7 @@ Version: 1
 		Version: 1
 		Name: analytics
 		Bucket: analytics
-		Access key: 1234
+		Access key: ********
 		User: user2
 		URL: https://two.example.com
 		Path: logs/
@@ -444,7 +444,7 @@ Service ID: 123
 Version: 1
 Name: logs
 Bucket: my-logs
-Access key: 1234
+Access key: ********
 User: user
 URL: https://example.com
 Path: logs/
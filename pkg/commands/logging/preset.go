@@ -0,0 +1,90 @@
+package logging
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/fastly/cli/pkg/cmd"
+	"github.com/fastly/cli/pkg/config"
+	"github.com/fastly/cli/pkg/text"
+)
+
+// FlagPresetName is the name of the flag selecting a logging preset.
+const FlagPresetName = "preset"
+
+// FlagPresetDesc is the description of the flag selecting a logging preset.
+const FlagPresetDesc = "Apply the recommended log format of an observability vendor (e.g. datadog, honeycomb, newrelic, splunk) as listed by the logging presets command. Other flags override the preset"
+
+// PresetsCommand lists the logging presets.
+type PresetsCommand struct {
+	cmd.Base
+	json bool
+}
+
+// NewPresetsCommand returns a usable command registered under the parent.
+func NewPresetsCommand(parent cmd.Registerer, globals *config.Data) *PresetsCommand {
+	var c PresetsCommand
+	c.Globals = globals
+	c.CmdClause = parent.Command("presets", "List the log format presets applied by the --preset flag of the create commands")
+	c.RegisterFlagBool(cmd.BoolFlagOpts{
+		Name:        cmd.FlagJSONName,
+		Description: cmd.FlagJSONDesc,
+		Dst:         &c.json,
+		Short:       'j',
+	})
+	return &c
+}
+
+// Exec invokes the application logic for the command.
+func (c *PresetsCommand) Exec(_ io.Reader, out io.Writer) error {
+	presets := c.Globals.File.LoggingPresets
+
+	if c.json {
+		if presets == nil {
+			presets = config.LoggingPresets{}
+		}
+		data, err := json.Marshal(presets)
+		if err != nil {
+			return err
+		}
+		_, err = out.Write(data)
+		if err != nil {
+			c.Globals.ErrLog.Add(err)
+			return fmt.Errorf("error: unable to write data to stdout: %w", err)
+		}
+		return nil
+	}
+
+	if len(presets) == 0 {
+		text.Info(out, "No logging presets are defined in the [logging_presets] section of the CLI config.")
+		return nil
+	}
+
+	names := make([]string, 0, len(presets))
+	for name := range presets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	if c.Globals.Verbose() {
+		for _, name := range names {
+			p := presets[name]
+			fmt.Fprintf(out, "Name: %s\n", name)
+			fmt.Fprintf(out, "Description: %s\n", p.Description)
+			fmt.Fprintf(out, "Format: %s\n", p.Format)
+			fmt.Fprintf(out, "Format version: %d\n", p.FormatVersion)
+			fmt.Fprintf(out, "\n")
+		}
+		return nil
+	}
+
+	t := text.NewTable(out)
+	t.AddHeader("NAME", "DESCRIPTION")
+	for _, name := range names {
+		t.AddLine(name, presets[name].Description)
+	}
+	t.Print()
+	return nil
+}
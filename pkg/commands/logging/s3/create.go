@@ -3,11 +3,13 @@ package s3
 import (
 	"fmt"
 	"io"
+	"time"
 
 	"github.com/fastly/cli/pkg/cmd"
 	"github.com/fastly/cli/pkg/config"
 	"github.com/fastly/cli/pkg/errors"
 	"github.com/fastly/cli/pkg/manifest"
+	"github.com/fastly/cli/pkg/sigv4"
 	"github.com/fastly/cli/pkg/text"
 	"github.com/fastly/go-fastly/v6/fastly"
 )
@@ -46,6 +48,7 @@ type CreateCommand struct {
 	ServerSideEncryption         cmd.OptionalString
 	ServerSideEncryptionKMSKeyID cmd.OptionalString
 	CompressionCodec             cmd.OptionalString
+	Validate                     bool
 }
 
 // NewCreateCommand returns a usable command registered under the parent.
@@ -96,6 +99,7 @@ func NewCreateCommand(parent cmd.Registerer, globals *config.Data, data manifest
 	c.CmdClause.Flag("server-side-encryption", "Set to enable S3 Server Side Encryption. Can be either AES256 or aws:kms").Action(c.ServerSideEncryption.Set).EnumVar(&c.ServerSideEncryption.Value, string(fastly.S3ServerSideEncryptionAES), string(fastly.S3ServerSideEncryptionKMS))
 	c.CmdClause.Flag("server-side-encryption-kms-key-id", "Server-side KMS Key ID. Must be set if server-side-encryption is set to aws:kms").Action(c.ServerSideEncryptionKMSKeyID.Set).StringVar(&c.ServerSideEncryptionKMSKeyID.Value)
 	c.CmdClause.Flag("compression-codec", `The codec used for compression of your logs. Valid values are zstd, snappy, and gzip. If the specified codec is "gzip", gzip_level will default to 3. To specify a different level, leave compression_codec blank and explicitly set the level using gzip_level. Specifying both compression_codec and gzip_level in the same API request will result in an error.`).Action(c.CompressionCodec.Set).StringVar(&c.CompressionCodec.Value)
+	c.CmdClause.Flag("validate", "Validate the access key can write to the bucket by uploading a small object before creating the endpoint. With --iam-role, print the IAM policies the role needs instead").BoolVar(&c.Validate)
 	return &c
 }
 
@@ -241,6 +245,17 @@ func (c *CreateCommand) Exec(_ io.Reader, out io.Writer) error {
 		return err
 	}
 
+	if c.Validate {
+		if err := c.validate(input, out); err != nil {
+			c.Globals.ErrLog.AddWithContext(err, map[string]any{
+				"Bucket": input.BucketName,
+				"Domain": input.Domain,
+				"Path":   input.Path,
+			})
+			return err
+		}
+	}
+
 	d, err := c.Globals.APIClient.CreateS3(input)
 	if err != nil {
 		c.Globals.ErrLog.Add(err)
@@ -250,3 +265,46 @@ func (c *CreateCommand) Exec(_ io.Reader, out io.Writer) error {
 	text.Success(out, "Created S3 logging endpoint %s (service %s version %d)", d.Name, d.ServiceID, d.ServiceVersion)
 	return nil
 }
+
+// validate checks the access key can write to the bucket.
+//
+// NOTE: Only Fastly can assume the IAM role, and so rather than validating
+// it, the policies the role needs are printed.
+func (c *CreateCommand) validate(input *fastly.CreateS3Input, out io.Writer) error {
+	if input.IAMRole != "" {
+		customerID, err := lookupCustomerID(cmd.OptionalCustomerID{}, c.Globals.APIClient)
+		if err != nil {
+			return err
+		}
+		text.Warning(out, "The IAM role can't be validated as only Fastly can assume it. Ensure the role has the following policies:")
+		text.Break(out)
+		err = printPolicies(out, Policies{
+			Permissions: Policy(input.BucketName, input.Path, input.ServerSideEncryptionKMSKeyID),
+			Trust:       TrustPolicy(customerID),
+		})
+		text.Break(out)
+		return err
+	}
+
+	b := Bucket{
+		Name:                 input.BucketName,
+		Domain:               input.Domain,
+		Path:                 input.Path,
+		ServerSideEncryption: string(input.ServerSideEncryption),
+		KMSKeyID:             input.ServerSideEncryptionKMSKeyID,
+		Credentials: sigv4.Credentials{
+			AccessKeyID:     input.AccessKey,
+			SecretAccessKey: input.SecretKey,
+		},
+		Client: c.Globals.HTTPClient,
+	}
+	key, err := b.Validate(time.Now())
+	if err != nil {
+		return errors.RemediationError{
+			Inner:       err,
+			Remediation: fmt.Sprintf("Ensure the access key is allowed to write to the bucket path. Run `fastly logging s3 policy --bucket %s` to print the policy it needs.", input.BucketName),
+		}
+	}
+	text.Info(out, "Validated the bucket is writable (uploaded %s)", key)
+	return nil
+}
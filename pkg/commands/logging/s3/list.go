@@ -88,6 +88,11 @@ func (c *ListCommand) Exec(_ io.Reader, out io.Writer) error {
 		return err
 	}
 
+	for _, s3 := range s3s {
+		s3.AccessKey = text.Secret(s3.AccessKey)
+		s3.SecretKey = text.Secret(s3.SecretKey)
+	}
+
 	if !c.Globals.Verbose() {
 		if c.json {
 			data, err := json.Marshal(s3s)
@@ -0,0 +1,115 @@
+package s3
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/fastly/cli/pkg/api"
+	"github.com/fastly/cli/pkg/cmd"
+	"github.com/fastly/cli/pkg/config"
+	"github.com/fastly/cli/pkg/text"
+)
+
+// PolicyCommand prints the IAM policies needed by an S3 logging endpoint.
+type PolicyCommand struct {
+	cmd.Base
+
+	bucket     string
+	customerID cmd.OptionalCustomerID
+	json       bool
+	kmsKeyID   string
+	path       string
+}
+
+// NewPolicyCommand returns a usable command registered under the parent.
+func NewPolicyCommand(parent cmd.Registerer, globals *config.Data) *PolicyCommand {
+	var c PolicyCommand
+	c.Globals = globals
+	c.CmdClause = parent.Command("policy", "Print the IAM policies an S3 logging endpoint needs, including the trust policy of a role assumed with --iam-role")
+	c.CmdClause.Flag("bucket", "Your S3 bucket name").Required().StringVar(&c.bucket)
+	c.CmdClause.Flag("path", "The path to upload logs to").StringVar(&c.path)
+	c.CmdClause.Flag("server-side-encryption-kms-key-id", "Server-side KMS Key ID, if the logs are encrypted with aws:kms").StringVar(&c.kmsKeyID)
+	c.RegisterFlag(cmd.StringFlagOpts{
+		Name:        cmd.FlagCustomerIDName,
+		Description: "Alphanumeric string identifying the customer, used as the external ID of the trust policy (falls back to FASTLY_CUSTOMER_ID, then the customer of the current user)",
+		Dst:         &c.customerID.Value,
+		Action:      c.customerID.Set,
+	})
+	c.RegisterFlagBool(cmd.BoolFlagOpts{
+		Name:        cmd.FlagJSONName,
+		Description: cmd.FlagJSONDesc,
+		Dst:         &c.json,
+		Short:       'j',
+	})
+	return &c
+}
+
+// Policies are the IAM policies needed by an S3 logging endpoint.
+type Policies struct {
+	Permissions PolicyDocument `json:"permissions_policy"`
+	Trust       PolicyDocument `json:"trust_policy"`
+}
+
+// Exec invokes the application logic for the command.
+func (c *PolicyCommand) Exec(_ io.Reader, out io.Writer) error {
+	customerID, err := lookupCustomerID(c.customerID, c.Globals.APIClient)
+	if err != nil {
+		c.Globals.ErrLog.Add(err)
+		return err
+	}
+
+	p := Policies{
+		Permissions: Policy(c.bucket, c.path, c.kmsKeyID),
+		Trust:       TrustPolicy(customerID),
+	}
+
+	if c.json {
+		data, err := json.Marshal(p)
+		if err != nil {
+			return err
+		}
+		_, err = out.Write(data)
+		if err != nil {
+			c.Globals.ErrLog.Add(err)
+			return fmt.Errorf("error: unable to write data to stdout: %w", err)
+		}
+		return nil
+	}
+
+	return printPolicies(out, p)
+}
+
+// printPolicies writes the policies as indented JSON documents.
+func printPolicies(out io.Writer, p Policies) error {
+	permissions, err := json.MarshalIndent(p.Permissions, "", "  ")
+	if err != nil {
+		return err
+	}
+	trust, err := json.MarshalIndent(p.Trust, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	text.Output(out, "Permissions policy (attach to the IAM role or user):")
+	text.Break(out)
+	fmt.Fprintf(out, "%s\n", permissions)
+	text.Break(out)
+	text.Output(out, "Trust policy (for a role assumed with --iam-role):")
+	text.Break(out)
+	fmt.Fprintf(out, "%s\n", trust)
+	return nil
+}
+
+// lookupCustomerID returns the customer ID from the flag or environment,
+// falling back to the customer of the current user.
+func lookupCustomerID(flag cmd.OptionalCustomerID, client api.Interface) (string, error) {
+	if err := flag.Parse(); err == nil {
+		return flag.Value, nil
+	}
+	user, err := client.GetCurrentUser()
+	if err != nil {
+		return "", fmt.Errorf("error fetching the current user: %w", err)
+	}
+	return user.CustomerID, nil
+}
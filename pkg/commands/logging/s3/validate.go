@@ -0,0 +1,210 @@
+package s3
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/fastly/cli/pkg/api"
+	"github.com/fastly/cli/pkg/sigv4"
+	"github.com/fastly/cli/pkg/useragent"
+)
+
+// FastlyAWSAccountID is the ID of the AWS account Fastly assumes IAM roles
+// from when delivering logs.
+const FastlyAWSAccountID = "717331877981"
+
+// DefaultDomain is the domain of the S3 endpoint used when none is set.
+const DefaultDomain = "s3.amazonaws.com"
+
+// defaultRegion is the region used to sign requests when the domain doesn't
+// contain one.
+const defaultRegion = "us-east-1"
+
+// PolicyDocument is an AWS IAM policy document.
+type PolicyDocument struct {
+	Version   string            `json:"Version"`
+	Statement []PolicyStatement `json:"Statement"`
+}
+
+// PolicyStatement is a statement of an AWS IAM policy document.
+type PolicyStatement struct {
+	Effect    string         `json:"Effect"`
+	Principal map[string]any `json:"Principal,omitempty"`
+	Action    []string       `json:"Action"`
+	Resource  []string       `json:"Resource,omitempty"`
+	Condition map[string]any `json:"Condition,omitempty"`
+}
+
+// Policy returns the permissions policy an IAM role or user needs for logs to
+// be written to the path within the bucket.
+func Policy(bucket, path, kmsKeyID string) PolicyDocument {
+	p := PolicyDocument{
+		Version: "2012-10-17",
+		Statement: []PolicyStatement{{
+			Effect:   "Allow",
+			Action:   []string{"s3:PutObject"},
+			Resource: []string{fmt.Sprintf("arn:aws:s3:::%s/%s*", bucket, keyPrefix(path))},
+		}},
+	}
+	if kmsKeyID != "" {
+		p.Statement = append(p.Statement, PolicyStatement{
+			Effect:   "Allow",
+			Action:   []string{"kms:GenerateDataKey"},
+			Resource: []string{kmsKeyID},
+		})
+	}
+	return p
+}
+
+// TrustPolicy returns the trust policy allowing Fastly to assume an IAM role
+// on behalf of the customer.
+func TrustPolicy(customerID string) PolicyDocument {
+	return PolicyDocument{
+		Version: "2012-10-17",
+		Statement: []PolicyStatement{{
+			Effect:    "Allow",
+			Principal: map[string]any{"AWS": fmt.Sprintf("arn:aws:iam::%s:root", FastlyAWSAccountID)},
+			Action:    []string{"sts:AssumeRole"},
+			Condition: map[string]any{"StringEquals": map[string]string{"sts:ExternalId": customerID}},
+		}},
+	}
+}
+
+// Bucket is the destination of the logs of an S3 logging endpoint.
+type Bucket struct {
+	Name                 string
+	Domain               string
+	Path                 string
+	ServerSideEncryption string
+	KMSKeyID             string
+	Credentials          sigv4.Credentials
+	Client               api.HTTPClient
+	// Endpoint overrides the URL of the bucket (e.g. https://bucket.domain).
+	Endpoint string
+}
+
+// Validate writes a small object to the path within the bucket, the same way
+// Fastly writes the logs, and returns its key.
+func (b Bucket) Validate(now time.Time) (string, error) {
+	key := fmt.Sprintf("%sfastly-cli-validation-%d.txt", keyPrefix(b.Path), now.Unix())
+	body := []byte("This object was written by the Fastly CLI to validate the bucket is writable, and can be deleted.\n")
+
+	region := b.region()
+	err := b.put(key, body, region, now)
+	// NOTE: Buckets outside of the region the domain implies return the
+	// region the request should be signed for, and so we retry once.
+	if e, ok := err.(s3Error); ok && e.Region != "" && e.Region != region {
+		err = b.put(key, body, e.Region, now)
+	}
+	if err != nil {
+		return key, fmt.Errorf("error writing to bucket '%s': %w", b.Name, err)
+	}
+	return key, nil
+}
+
+// put sends a signed PutObject request.
+func (b Bucket) put(key string, body []byte, region string, now time.Time) error {
+	req, err := http.NewRequest(http.MethodPut, b.url()+"/"+escapeKey(key), bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "text/plain")
+	req.Header.Set("User-Agent", useragent.Name)
+	if b.ServerSideEncryption != "" {
+		req.Header.Set("X-Amz-Server-Side-Encryption", b.ServerSideEncryption)
+	}
+	if b.KMSKeyID != "" {
+		req.Header.Set("X-Amz-Server-Side-Encryption-Aws-Kms-Key-Id", b.KMSKeyID)
+	}
+	sigv4.Sign(req, body, b.Credentials, region, sigv4.ServiceS3, now.UTC())
+
+	res, err := b.Client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close() // #nosec G307
+
+	if res.StatusCode < http.StatusOK || res.StatusCode >= http.StatusMultipleChoices {
+		e := s3Error{Status: res.Status}
+		_ = xml.NewDecoder(io.LimitReader(res.Body, 4096)).Decode(&e)
+		return e
+	}
+	return nil
+}
+
+// url returns the URL of the bucket.
+//
+// NOTE: Bucket names containing dots don't match the wildcard certificate of
+// virtual-hosted style URLs, and so they use path style URLs.
+func (b Bucket) url() string {
+	if b.Endpoint != "" {
+		return strings.TrimSuffix(b.Endpoint, "/")
+	}
+	domain := b.Domain
+	if domain == "" {
+		domain = DefaultDomain
+	}
+	if strings.Contains(b.Name, ".") {
+		return fmt.Sprintf("https://%s/%s", domain, b.Name)
+	}
+	return fmt.Sprintf("https://%s.%s", b.Name, domain)
+}
+
+// region returns the region implied by the domain, e.g. s3.eu-west-1.amazonaws.com
+// or s3-eu-west-1.amazonaws.com.
+func (b Bucket) region() string {
+	labels := strings.Split(b.Domain, ".")
+	if len(labels) < 3 || !strings.HasSuffix(b.Domain, ".amazonaws.com") {
+		return defaultRegion
+	}
+	if r := strings.TrimPrefix(labels[0], "s3-"); r != labels[0] {
+		return r
+	}
+	for _, l := range labels[1 : len(labels)-2] {
+		if l != "dualstack" {
+			return l
+		}
+	}
+	return defaultRegion
+}
+
+// s3Error is an error returned by the S3 API.
+type s3Error struct {
+	Code    string `xml:"Code"`
+	Message string `xml:"Message"`
+	Region  string `xml:"Region"`
+	Status  string `xml:"-"`
+}
+
+// Error implements the error interface.
+func (e s3Error) Error() string {
+	if e.Code == "" {
+		return fmt.Sprintf("unexpected response status: %s", e.Status)
+	}
+	return fmt.Sprintf("unexpected response status: %s: %s: %s", e.Status, e.Code, e.Message)
+}
+
+// keyPrefix returns the prefix of the keys of the objects written to the path,
+// which is relative to the root of the bucket.
+func keyPrefix(path string) string {
+	p := strings.Trim(path, "/")
+	if p == "" {
+		return ""
+	}
+	return p + "/"
+}
+
+// escapeKey escapes each segment of an object key.
+func escapeKey(key string) string {
+	segments := strings.Split(key, "/")
+	for i, s := range segments {
+		segments[i] = url.PathEscape(s)
+	}
+	return strings.Join(segments, "/")
+}
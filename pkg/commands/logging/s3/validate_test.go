@@ -0,0 +1,55 @@
+package s3_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/fastly/cli/pkg/commands/logging/s3"
+	"github.com/fastly/cli/pkg/sigv4"
+	"github.com/fastly/cli/pkg/testutil"
+)
+
+func TestBucketValidate(t *testing.T) {
+	var requests, auth []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		auth = append(auth, r.Header.Get("Authorization"))
+		if !strings.Contains(r.Header.Get("Authorization"), "/eu-west-1/s3/aws4_request") {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`<Error><Code>AuthorizationHeaderMalformed</Code><Message>wrong region</Message><Region>eu-west-1</Region></Error>`))
+			return
+		}
+		if r.Header.Get("X-Amz-Server-Side-Encryption") != "AES256" {
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte(`<Error><Code>AccessDenied</Code><Message>Access Denied</Message></Error>`))
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	b := s3.Bucket{
+		Name:                 "log",
+		Path:                 "/logs",
+		ServerSideEncryption: "AES256",
+		Credentials:          sigv4.Credentials{AccessKeyID: "AKID", SecretAccessKey: "secret"},
+		Client:               http.DefaultClient,
+		Endpoint:             server.URL,
+	}
+
+	key, err := b.Validate(time.Unix(1700000000, 0))
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, "logs/fastly-cli-validation-1700000000.txt", key)
+	testutil.AssertEqual(t, []string{
+		"PUT /logs/fastly-cli-validation-1700000000.txt",
+		"PUT /logs/fastly-cli-validation-1700000000.txt",
+	}, requests)
+	testutil.AssertStringContains(t, auth[1], "SignedHeaders=host;x-amz-content-sha256;x-amz-date;x-amz-server-side-encryption,")
+
+	b.ServerSideEncryption = ""
+	_, err = b.Validate(time.Unix(1700000000, 0))
+	testutil.AssertErrorContains(t, err, "AccessDenied: Access Denied")
+}
@@ -0,0 +1,216 @@
+package logging
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// DefaultFormat is the log format used by endpoints without one.
+const DefaultFormat = `%h %l %u %t "%r" %>s %b`
+
+// SampleTime is the time of the sample request used to render log formats.
+var SampleTime = time.Date(2024, time.January, 1, 12, 0, 0, 0, time.UTC)
+
+// sampleHostname is the name of the cache node in sample log lines.
+const sampleHostname = "cache-sjc10000-SJC"
+
+// sampleDirectives are the values of the Apache style directives for the
+// sample request.
+var sampleDirectives = map[string]string{
+	"a": "192.0.2.1",
+	"A": "198.51.100.1",
+	"b": "1024",
+	"B": "1024",
+	"D": "1234",
+	"h": "192.0.2.1",
+	"H": "HTTP/1.1",
+	"l": "-",
+	"m": "GET",
+	"p": "443",
+	"q": "?a=1",
+	"r": "GET /index.html?a=1 HTTP/1.1",
+	"s": "200",
+	"t": SampleTime.Format("[02/Jan/2006:15:04:05 -0700]"),
+	"T": "0",
+	"u": "-",
+	"U": "/index.html",
+	"v": "www.example.com",
+	"V": "www.example.com",
+}
+
+// sampleRequestHeaders are the request headers of the sample request.
+var sampleRequestHeaders = map[string]string{
+	"accept":     "*/*",
+	"host":       "www.example.com",
+	"user-agent": "curl/8.0.1",
+}
+
+// sampleResponseHeaders are the response headers of the sample request.
+var sampleResponseHeaders = map[string]string{
+	"content-length": "1024",
+	"content-type":   "text/html",
+}
+
+// sampleVariables are the values of VCL variables for the sample request.
+var sampleVariables = map[string]string{
+	"client.geo.city":         "san francisco",
+	"client.geo.country_code": "US",
+	"client.ip":               "192.0.2.1",
+	"fastly_info.state":       "HIT",
+	"now":                     SampleTime.Format(time.RFC1123),
+	"req.body_bytes_read":     "0",
+	"req.bytes_read":          "120",
+	"req.header_bytes_read":   "120",
+	"req.method":              "GET",
+	"req.proto":               "HTTP/1.1",
+	"req.protocol":            "https",
+	"req.service_id":          "SU1Z0isxPaozGVKXdv0eY",
+	"req.url":                 "/index.html?a=1",
+	"req.url.path":            "/index.html",
+	"req.url.qs":              "a=1",
+	"req.xid":                 "1234567890",
+	"resp.body_bytes_written": "1024",
+	"resp.bytes_written":      "1300",
+	"resp.response":           "OK",
+	"resp.status":             "200",
+	"server.datacenter":       "SJC",
+	"server.hostname":         sampleHostname,
+	"server.region":           "US-West",
+	"time.elapsed.msec":       "1",
+	"time.elapsed.usec":       "1234",
+	"time.start":              SampleTime.Format(time.RFC1123),
+	"time.to_first_byte":      "0.001",
+	"tls.client.protocol":     "TLSv1.3",
+}
+
+// sampleFunction matches VCL functions whose sample value is that of their
+// argument.
+var sampleFunction = regexp.MustCompile(`^(?:json\.escape|cstr_escape|urlencode|std\.tolower|std\.toupper)\((.*)\)$`)
+
+// SampleFormat renders a log format with the values of a sample request.
+//
+// NOTE: Unset headers are rendered as (null), as Fastly does, and VCL
+// expressions without a sample value are rendered as <expression>.
+func SampleFormat(format string) string {
+	var b strings.Builder
+	for i := 0; i < len(format); i++ {
+		if format[i] != '%' {
+			b.WriteByte(format[i])
+			continue
+		}
+
+		// A directive is of the form %[{arg}][<>]verb, where the argument
+		// might contain braces (e.g. %{strftime({"%Y"}, time.start)}V).
+		j := i + 1
+		var arg string
+		if j < len(format) && format[j] == '{' {
+			end := closingBrace(format, j)
+			if end < 0 {
+				b.WriteString(format[i:])
+				break
+			}
+			arg = format[j+1 : end]
+			j = end + 1
+		}
+		for j < len(format) && (format[j] == '<' || format[j] == '>') {
+			j++
+		}
+		if j >= len(format) {
+			b.WriteString(format[i:])
+			break
+		}
+		b.WriteString(sampleDirective(format[i:j+1], arg, format[j]))
+		i = j
+	}
+	return b.String()
+}
+
+// closingBrace returns the index of the brace closing the one at index open,
+// or -1 if it isn't closed.
+func closingBrace(s string, open int) int {
+	depth := 0
+	for i := open; i < len(s); i++ {
+		switch s[i] {
+		case '{':
+			depth++
+		case '}':
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+// sampleDirective returns the sample value of a directive, or the directive
+// itself if it isn't supported.
+func sampleDirective(directive, arg string, verb byte) string {
+	switch {
+	case verb == '%':
+		return "%"
+	case verb == 'i':
+		return sampleHeader(sampleRequestHeaders, arg)
+	case verb == 'o':
+		return sampleHeader(sampleResponseHeaders, arg)
+	case verb == 'V':
+		return sampleExpression(arg)
+	case verb == 't' && arg != "":
+		return SampleTime.Format(time.RFC3339)
+	}
+	if v, ok := sampleDirectives[string(verb)]; ok {
+		return v
+	}
+	return directive
+}
+
+// sampleHeader returns the sample value of a header.
+func sampleHeader(headers map[string]string, name string) string {
+	if v, ok := headers[strings.ToLower(name)]; ok {
+		return v
+	}
+	return "(null)"
+}
+
+// sampleExpression returns the sample value of a VCL expression.
+func sampleExpression(expr string) string {
+	expr = strings.TrimSpace(expr)
+	if m := sampleFunction.FindStringSubmatch(expr); m != nil {
+		return sampleExpression(m[1])
+	}
+	if strings.HasPrefix(expr, "strftime(") {
+		return SampleTime.Format(time.RFC3339)
+	}
+	if v, ok := sampleVariables[expr]; ok {
+		return v
+	}
+	lower := strings.ToLower(expr)
+	for prefix, headers := range map[string]map[string]string{
+		"req.http.":    sampleRequestHeaders,
+		"bereq.http.":  sampleRequestHeaders,
+		"resp.http.":   sampleResponseHeaders,
+		"beresp.http.": sampleResponseHeaders,
+	} {
+		if strings.HasPrefix(lower, prefix) {
+			return sampleHeader(headers, expr[len(prefix):])
+		}
+	}
+	return "<" + expr + ">"
+}
+
+// SampleMessagePrefix returns the prefix the message type adds to the sample
+// log lines of the named endpoint.
+func SampleMessagePrefix(messageType, name string) string {
+	ts := SampleTime.Format(time.RFC3339)
+	switch messageType {
+	case "classic":
+		return fmt.Sprintf("<134>%s %s %s[1]: ", ts, sampleHostname, name)
+	case "loggly":
+		return fmt.Sprintf("<134>1 %s %s %s - - - ", ts, sampleHostname, name)
+	case "logplex":
+		return fmt.Sprintf("<134>1 %s %s %s - - ", ts, sampleHostname, name)
+	}
+	return ""
+}
@@ -0,0 +1,33 @@
+package logging_test
+
+import (
+	"testing"
+
+	"github.com/fastly/cli/pkg/commands/logging"
+	"github.com/fastly/cli/pkg/testutil"
+)
+
+func TestSampleFormat(t *testing.T) {
+	scenarios := []struct {
+		format string
+		want   string
+	}{
+		{
+			format: logging.DefaultFormat,
+			want:   `192.0.2.1 - - [01/Jan/2024:12:00:00 +0000] "GET /index.html?a=1 HTTP/1.1" 200 1024`,
+		},
+		{
+			format: `{"host":"%{req.http.Host}V","ua":"%{json.escape(req.http.User-Agent)}V","time":"%{strftime({"%Y-%m-%dT%H:%M:%SZ"}, time.start)}V","status":%{resp.status}V}`,
+			want:   `{"host":"www.example.com","ua":"curl/8.0.1","time":"2024-01-01T12:00:00Z","status":200}`,
+		},
+		{
+			format: `%{Referer}i %{Content-Type}o %{req.http.x-custom}V %{var.unknown}V 100%%`,
+			want:   `(null) text/html (null) <var.unknown> 100%`,
+		},
+	}
+	for _, testcase := range scenarios {
+		t.Run(testcase.format, func(t *testing.T) {
+			testutil.AssertEqual(t, testcase.want, logging.SampleFormat(testcase.format))
+		})
+	}
+}
@@ -365,7 +365,7 @@ Version: 1
 		Service ID: 123
 		Version: 1
 		Name: logs
-		Token: abc
+		Token: ********
 		Region: US
 		Format: %h %l %u %t "%r" %>s %b
 		Format version: 2
@@ -375,7 +375,7 @@ Version: 1
 		Service ID: 123
 		Version: 1
 		Name: analytics
-		Token: abc
+		Token: ********
 		Region: US
 		Format: %h %l %u %t "%r" %>s %b
 		Format version: 2
@@ -405,7 +405,7 @@ var describeScalyrOutput = "\n" + strings.TrimSpace(`
 Service ID: 123
 Version: 1
 Name: logs
-Token: abc
+Token: ********
 Region: US
 Format: %h %l %u %t "%r" %>s %b
 Format version: 2
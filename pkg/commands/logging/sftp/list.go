@@ -88,6 +88,11 @@ func (c *ListCommand) Exec(_ io.Reader, out io.Writer) error {
 		return err
 	}
 
+	for _, sftp := range sftps {
+		sftp.Password = text.Secret(sftp.Password)
+		sftp.SecretKey = text.Secret(sftp.SecretKey)
+	}
+
 	if !c.Globals.Verbose() {
 		if c.json {
 			data, err := json.Marshal(sftps)
@@ -374,9 +374,9 @@ Version: 1
 		Address: 127.0.0.1
 		Port: 514
 		User: user
-		Password: password
+		Password: ********
 		Public key: `+pgpPublicKey()+`
-		Secret key: `+sshPrivateKey()+`
+		Secret key: ********
 		SSH known hosts: `+knownHosts()+`
 		Path: /logs
 		Period: 3600
@@ -395,9 +395,9 @@ Version: 1
 		Address: example.com
 		Port: 123
 		User: user
-		Password: password
+		Password: ********
 		Public key: `+pgpPublicKey()+`
-		Secret key: `+sshPrivateKey()+`
+		Secret key: ********
 		SSH known hosts: `+knownHosts()+`
 		Path: /analytics
 		Period: 3600
@@ -447,9 +447,9 @@ Name: logs
 Address: example.com
 Port: 514
 User: user
-Password: password
+Password: ********
 Public key: `+pgpPublicKey()+`
-Secret key: `+sshPrivateKey()+`
+Secret key: ********
 SSH known hosts: `+knownHosts()+`
 Path: /logs
 Period: 3600
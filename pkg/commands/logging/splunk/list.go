@@ -88,6 +88,11 @@ func (c *ListCommand) Exec(_ io.Reader, out io.Writer) error {
 		return err
 	}
 
+	for _, splunk := range splunks {
+		splunk.Token = text.Secret(splunk.Token)
+		splunk.TLSClientKey = text.Secret(splunk.TLSClientKey)
+	}
+
 	if !c.Globals.Verbose() {
 		if c.json {
 			data, err := json.Marshal(splunks)
@@ -0,0 +1,261 @@
+package logging
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/fastly/cli/pkg/api/undocumented"
+	"github.com/fastly/cli/pkg/cmd"
+	"github.com/fastly/cli/pkg/config"
+	fsterr "github.com/fastly/cli/pkg/errors"
+	"github.com/fastly/cli/pkg/manifest"
+	"github.com/fastly/cli/pkg/text"
+	"github.com/fastly/go-fastly/v6/fastly"
+)
+
+// StatusCommand reports the logging endpoints of a service version, along
+// with how many log lines the service has recently sent and anything about
+// the endpoints likely to stop them receiving logs.
+type StatusCommand struct {
+	cmd.Base
+
+	from           string
+	json           bool
+	manifest       manifest.Data
+	serviceName    cmd.OptionalServiceNameID
+	serviceVersion cmd.OptionalServiceVersion
+}
+
+// NewStatusCommand returns a usable command registered under the parent.
+func NewStatusCommand(parent cmd.Registerer, globals *config.Data, data manifest.Data) *StatusCommand {
+	var c StatusCommand
+	c.Globals = globals
+	c.manifest = data
+	c.CmdClause = parent.Command("status", "Show the logging endpoints of a service version and how many log lines the service has recently sent")
+
+	// Optional flags
+	c.CmdClause.Flag("from", "Count the log lines sent since this time, accepted formats at https://fastly.dev/reference/api/metrics-stats/historical-stats").Default("1 day ago").StringVar(&c.from)
+	c.RegisterFlagBool(cmd.BoolFlagOpts{
+		Name:        cmd.FlagJSONName,
+		Description: cmd.FlagJSONDesc,
+		Dst:         &c.json,
+		Short:       'j',
+	})
+	c.RegisterFlag(cmd.StringFlagOpts{
+		Name:        cmd.FlagServiceIDName,
+		Description: cmd.FlagServiceIDDesc,
+		Dst:         &c.manifest.Flag.ServiceID,
+		Short:       's',
+	})
+	c.RegisterFlag(cmd.StringFlagOpts{
+		Action:      c.serviceName.Set,
+		Name:        cmd.FlagServiceName,
+		Description: cmd.FlagServiceDesc,
+		Dst:         &c.serviceName.Value,
+	})
+	c.RegisterFlag(cmd.StringFlagOpts{
+		Name:        cmd.FlagVersionName,
+		Description: cmd.FlagVersionDesc + " (defaults to the active version)",
+		Dst:         &c.serviceVersion.Value,
+	})
+	return &c
+}
+
+// EndpointStatus is the status of a logging endpoint.
+type EndpointStatus struct {
+	*undocumented.LoggingEndpoint
+	Issues []string `json:"issues"`
+}
+
+// Status is the logging status of a service version.
+type Status struct {
+	ServiceID      string           `json:"service_id"`
+	ServiceVersion int              `json:"service_version"`
+	From           string           `json:"from"`
+	LogLines       *uint64          `json:"log_lines"`
+	Endpoints      []EndpointStatus `json:"endpoints"`
+}
+
+// Exec invokes the application logic for the command.
+func (c *StatusCommand) Exec(_ io.Reader, out io.Writer) error {
+	if c.Globals.Verbose() && c.json {
+		return fsterr.ErrInvalidVerboseJSONCombo
+	}
+
+	serviceID, serviceVersion, err := cmd.ServiceDetails(cmd.ServiceDetailsOpts{
+		AllowActiveLocked:  true,
+		APIClient:          c.Globals.APIClient,
+		Manifest:           c.manifest,
+		Out:                out,
+		ServiceNameFlag:    c.serviceName,
+		ServiceVersionFlag: c.serviceVersion,
+		VerboseMode:        c.Globals.Flag.Verbose,
+	})
+	if err != nil {
+		c.Globals.ErrLog.AddWithContext(err, map[string]any{
+			"Service ID":      serviceID,
+			"Service Version": fsterr.ServiceVersion(serviceVersion),
+		})
+		return err
+	}
+
+	token, source := c.Globals.Token()
+	if source == config.SourceUndefined {
+		return fsterr.ErrNoToken
+	}
+	host, _ := c.Globals.Endpoint()
+	client := undocumented.NewClient(host, token, c.Globals.HTTPClient)
+	input := undocumented.LoggingEndpointsInput{
+		ServiceID:      serviceID,
+		ServiceVersion: serviceVersion.Number,
+	}
+
+	status := Status{
+		ServiceID:      serviceID,
+		ServiceVersion: serviceVersion.Number,
+		From:           c.from,
+		Endpoints:      []EndpointStatus{},
+	}
+
+	providers := make([]string, 0, len(undocumented.LoggingProviders))
+	for p := range undocumented.LoggingProviders {
+		providers = append(providers, p)
+	}
+	sort.Strings(providers)
+	for _, p := range providers {
+		es, err := client.ListLoggingEndpoints(input, p)
+		if err != nil {
+			c.Globals.ErrLog.AddWithContext(err, map[string]any{
+				"Service ID":      serviceID,
+				"Service Version": serviceVersion.Number,
+				"Provider":        p,
+			})
+			return fmt.Errorf("error listing %s logging endpoints: %w", p, err)
+		}
+		for _, e := range es {
+			status.Endpoints = append(status.Endpoints, EndpointStatus{
+				LoggingEndpoint: e,
+				Issues:          endpointIssues(e),
+			})
+		}
+	}
+
+	lines, err := c.logLines(serviceID)
+	if err != nil {
+		c.Globals.ErrLog.AddWithContext(err, map[string]any{
+			"Service ID": serviceID,
+		})
+		if !c.json {
+			text.Warning(out, "Unable to count the log lines sent: %s", err)
+			text.Break(out)
+		}
+	} else {
+		status.LogLines = &lines
+	}
+
+	if c.json {
+		data, err := json.Marshal(status)
+		if err != nil {
+			return err
+		}
+		_, err = out.Write(data)
+		if err != nil {
+			c.Globals.ErrLog.Add(err)
+			return fmt.Errorf("error: unable to write data to stdout: %w", err)
+		}
+		return nil
+	}
+
+	c.print(out, status)
+	return nil
+}
+
+// logLines returns how many log lines the service has sent to all of its
+// logging endpoints since the --from time.
+//
+// NOTE: The stats API only counts log lines per service, not per endpoint.
+func (c *StatusCommand) logLines(serviceID string) (uint64, error) {
+	var envelope struct {
+		Status string `json:"status"`
+		Msg    string `json:"msg"`
+		Data   []struct {
+			Log uint64 `json:"log"`
+		} `json:"data"`
+	}
+	err := c.Globals.APIClient.GetStatsJSON(&fastly.GetStatsInput{
+		Service: serviceID,
+		From:    c.from,
+		By:      "day",
+	}, &envelope)
+	if err != nil {
+		return 0, err
+	}
+	if envelope.Status != "success" {
+		return 0, fmt.Errorf("non-success response: %s", envelope.Msg)
+	}
+
+	var total uint64
+	for _, d := range envelope.Data {
+		total += d.Log
+	}
+	return total, nil
+}
+
+// endpointIssues returns the reasons the endpoint might not receive the log
+// lines it's expected to.
+func endpointIssues(e *undocumented.LoggingEndpoint) []string {
+	issues := []string{}
+	switch e.Placement {
+	case "none":
+		issues = append(issues, "placement is none, so only custom VCL logs to it")
+	case "waf_debug":
+		issues = append(issues, "placement is waf_debug, so only WAF debug logs are sent")
+	}
+	if e.ResponseCondition != "" {
+		issues = append(issues, fmt.Sprintf("only responses matching the condition '%s' are logged", e.ResponseCondition))
+	}
+	if e.FormatVersion.String() == "1" {
+		issues = append(issues, "log format version 1 is deprecated")
+	}
+	return issues
+}
+
+// print displays the status in a table.
+func (c *StatusCommand) print(out io.Writer, status Status) {
+	fmt.Fprintf(out, "Service ID: %s\n", status.ServiceID)
+	fmt.Fprintf(out, "Service Version: %d\n", status.ServiceVersion)
+	if status.LogLines != nil {
+		fmt.Fprintf(out, "Log lines sent since %s: %d\n", status.From, *status.LogLines)
+	}
+	text.Break(out)
+
+	if len(status.Endpoints) == 0 {
+		text.Warning(out, "Service version %d has no logging endpoints.", status.ServiceVersion)
+		return
+	}
+
+	t := text.NewTable(out)
+	t.AddHeader("NAME", "TYPE", "PLACEMENT", "ISSUES")
+	for _, e := range status.Endpoints {
+		placement := e.Placement
+		if placement == "" {
+			placement = "default"
+		}
+		issues := strings.Join(e.Issues, "; ")
+		if issues == "" {
+			issues = "-"
+		}
+		t.AddLine(e.Name, e.Type, placement, issues)
+	}
+	t.Print()
+	text.Break(out)
+
+	if status.LogLines != nil && *status.LogLines == 0 {
+		text.Warning(out, "The service hasn't sent any log lines since %s. Check the service version is active and receiving traffic, and the issues listed above.", status.From)
+		text.Break(out)
+	}
+	text.Info(out, "The Fastly API doesn't expose delivery metrics or errors per endpoint. If the service is sending log lines an endpoint isn't receiving, check its destination and credentials with 'fastly logging <type> describe'.")
+}
@@ -0,0 +1,157 @@
+package logging_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/fastly/cli/pkg/app"
+	"github.com/fastly/cli/pkg/mock"
+	"github.com/fastly/cli/pkg/testutil"
+	"github.com/fastly/go-fastly/v6/fastly"
+)
+
+func TestLoggingStatus(t *testing.T) {
+	args := testutil.Args
+	scenarios := []struct {
+		name       string
+		args       []string
+		endpoints  map[string]string
+		code       int
+		stats      func(*fastly.GetStatsInput, any) error
+		wantError  string
+		wantOutput []string
+	}{
+		{
+			name:      "validate missing token",
+			args:      args("logging status --service-id 123"),
+			wantError: "no token provided",
+		},
+		{
+			name:      "validate API error",
+			args:      args("logging status --token x --service-id 123"),
+			code:      http.StatusForbidden,
+			stats:     logStats(0),
+			wantError: "error listing azureblob logging endpoints: non-2xx response: 403 Forbidden",
+		},
+		{
+			name: "validate endpoints and issues",
+			args: args("logging status --token x --service-id 123"),
+			endpoints: map[string]string{
+				"/service/123/version/1/logging/s3":     `[{"name":"archive","placement":null,"format_version":2}]`,
+				"/service/123/version/1/logging/pubsub": `[{"name":"events","placement":"none","response_condition":"errors only","format_version":"1"}]`,
+			},
+			stats: logStats(10, 32),
+			wantOutput: []string{
+				"Service ID: 123",
+				"Service Version: 1",
+				"Log lines sent since 1 day ago: 42",
+				"NAME     TYPE          PLACEMENT  ISSUES",
+				"events   googlepubsub  none       placement is none, so only custom VCL logs to it; only responses matching the condition 'errors only' are logged; log format version 1 is deprecated",
+				"archive  s3            default    -",
+			},
+		},
+		{
+			name:  "validate no endpoints",
+			args:  args("logging status --token x --service-id 123 --version 2"),
+			stats: logStats(0),
+			wantOutput: []string{
+				"Service Version: 2",
+				"Service version 2 has no logging endpoints.",
+			},
+		},
+		{
+			name: "validate no log lines sent",
+			args: args("logging status --token x --service-id 123"),
+			endpoints: map[string]string{
+				"/service/123/version/1/logging/syslog": `[{"name":"remote"}]`,
+			},
+			stats: logStats(0),
+			wantOutput: []string{
+				"Log lines sent since 1 day ago: 0",
+				"The service hasn't sent any log lines since 1 day ago.",
+			},
+		},
+		{
+			name: "validate stats error is a warning",
+			args: args("logging status --token x --service-id 123"),
+			endpoints: map[string]string{
+				"/service/123/version/1/logging/syslog": `[{"name":"remote"}]`,
+			},
+			stats: func(*fastly.GetStatsInput, any) error {
+				return errors.New("unauthorised")
+			},
+			wantOutput: []string{
+				"Unable to count the log lines sent: unauthorised",
+				"remote  syslog  default    -",
+			},
+		},
+		{
+			name: "validate JSON",
+			args: args("logging status --token x --service-id 123 --json"),
+			endpoints: map[string]string{
+				"/service/123/version/1/logging/syslog": `[{"name":"remote","response_condition":"slow"}]`,
+			},
+			stats: logStats(7),
+			wantOutput: []string{
+				`{"service_id":"123","service_version":1,"from":"1 day ago","log_lines":7,"endpoints":[{"name":"remote","type":"syslog","placement":"","response_condition":"slow","created_at":null,"updated_at":null,"issues":["only responses matching the condition 'slow' are logged"]}]}`,
+			},
+		},
+	}
+
+	for testcaseIdx := range scenarios {
+		testcase := &scenarios[testcaseIdx]
+		t.Run(testcase.name, func(t *testing.T) {
+			var stdout bytes.Buffer
+			opts := testutil.NewRunOpts(testcase.args, &stdout)
+			opts.APIClient = mock.APIClient(mock.API{
+				ListVersionsFn: testutil.ListVersions,
+				GetStatsJSONFn: testcase.stats,
+			})
+			opts.HTTPClient = endpointsClient{endpoints: testcase.endpoints, code: testcase.code}
+			err := app.Run(opts)
+			testutil.AssertErrorContains(t, err, testcase.wantError)
+			for _, s := range testcase.wantOutput {
+				testutil.AssertStringContains(t, stdout.String(), s)
+			}
+		})
+	}
+}
+
+// endpointsClient responds with the logging endpoints for each request path,
+// or an empty list for paths without any.
+type endpointsClient struct {
+	endpoints map[string]string
+	code      int
+}
+
+func (c endpointsClient) Do(req *http.Request) (*http.Response, error) {
+	rec := httptest.NewRecorder()
+	if c.code != 0 {
+		rec.WriteHeader(c.code)
+		return rec.Result(), nil
+	}
+	body, ok := c.endpoints[req.URL.Path]
+	if !ok {
+		body = "[]"
+	}
+	rec.WriteString(body)
+	return rec.Result(), nil
+}
+
+// logStats returns a stats response with a day of data for each count of log
+// lines.
+func logStats(lines ...int) func(*fastly.GetStatsInput, any) error {
+	return func(i *fastly.GetStatsInput, dst any) error {
+		data := make([]string, len(lines))
+		for n, l := range lines {
+			data[n] = fmt.Sprintf(`{"log":%d}`, l)
+		}
+		return json.Unmarshal([]byte(`{"status":"success","data":[`+strings.Join(data, ",")+`]}`), dst)
+	}
+}
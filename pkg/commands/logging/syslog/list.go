@@ -88,6 +88,11 @@ func (c *ListCommand) Exec(_ io.Reader, out io.Writer) error {
 		return err
 	}
 
+	for _, syslog := range syslogs {
+		syslog.TLSClientKey = text.Secret(syslog.TLSClientKey)
+		syslog.Token = text.Secret(syslog.Token)
+	}
+
 	if !c.Globals.Verbose() {
 		if c.json {
 			data, err := json.Marshal(syslogs)
@@ -100,6 +100,9 @@ func (c *RootCommand) Exec(_ io.Reader, out io.Writer) error {
 		return err
 	}
 
+	// NOTE: An interrupt is the normal way to stop, so the command handles it
+	// rather than the CLI cleaning up after an interrupted command.
+	c.Globals.Interrupt.Release()
 	sigs := make(chan os.Signal, 2)
 	signal.Notify(sigs, syscall.SIGINT, syscall.SIGTERM, syscall.SIGHUP)
 
@@ -0,0 +1,3 @@
+// Package mcp contains the command that serves Fastly operations as Model
+// Context Protocol (MCP) tools, for use by AI coding assistants.
+package mcp
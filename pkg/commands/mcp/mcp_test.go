@@ -0,0 +1,171 @@
+package mcp_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/fastly/cli/pkg/app"
+	"github.com/fastly/cli/pkg/mock"
+	"github.com/fastly/cli/pkg/testutil"
+	"github.com/fastly/go-fastly/v6/fastly"
+)
+
+const initialize = `{"jsonrpc":"2.0","id":1,"method":"initialize","params":{"protocolVersion":"2024-11-05","capabilities":{},"clientInfo":{"name":"test","version":"1"}}}
+{"jsonrpc":"2.0","method":"notifications/initialized"}
+`
+
+func TestMCP(t *testing.T) {
+	args := testutil.Args
+	api := mock.API{
+		GetServiceDetailsFn: func(i *fastly.GetServiceInput) (*fastly.ServiceDetail, error) {
+			return &fastly.ServiceDetail{ID: i.ID, Name: "Foo"}, nil
+		},
+		PurgeKeyFn: func(i *fastly.PurgeKeyInput) (*fastly.Purge, error) {
+			return &fastly.Purge{Status: "ok", ID: "123"}, nil
+		},
+	}
+
+	scenarios := []struct {
+		testutil.TestScenario
+		Input string
+		// WantLines are substrings of each expected response, in order.
+		WantLines []string
+		// DontWant are substrings that mustn't appear in the output.
+		DontWant []string
+	}{
+		{
+			TestScenario: testutil.TestScenario{
+				Name: "initialize and list read-only tools",
+				Args: args("mcp --token 123"),
+			},
+			Input: initialize + `{"jsonrpc":"2.0","id":2,"method":"tools/list"}` + "\n",
+			WantLines: []string{
+				`{"jsonrpc":"2.0","id":1,"result":{"capabilities":{"tools":{}},"protocolVersion":"2024-11-05","serverInfo":{"name":"fastly"`,
+				`{"jsonrpc":"2.0","id":2,"result":{"tools":[{"annotations":{"readOnlyHint":true},"description":"List the Fastly services the token has access to","inputSchema":{"additionalProperties":false,"properties":{},"required":[],"type":"object"},"name":"service_list"}`,
+			},
+			DontWant: []string{"compute_deploy", "purge"},
+		},
+		{
+			TestScenario: testutil.TestScenario{
+				Name: "list allowed mutating tools",
+				Args: args("mcp --allow purge --token 123"),
+			},
+			Input: `{"jsonrpc":"2.0","id":1,"method":"tools/list"}` + "\n",
+			WantLines: []string{
+				`{"annotations":{"readOnlyHint":false},"description":"Invalidate objects in the Fastly cache","inputSchema":{"additionalProperties":false,"properties":{"all":{"description":"Purge everything from the service","type":"boolean"}`,
+			},
+			DontWant: []string{"compute_deploy"},
+		},
+		{
+			TestScenario: testutil.TestScenario{
+				Name: "call read-only tool",
+				Args: args("mcp --token 123"),
+			},
+			Input: `{"jsonrpc":"2.0","id":1,"method":"tools/call","params":{"name":"service_describe","arguments":{"service_id":"abc"}}}` + "\n",
+			WantLines: []string{
+				`{"jsonrpc":"2.0","id":1,"result":{"content":[{"type":"text","text":"{\"ID\":\"abc\",\"Name\":\"Foo\"`,
+			},
+		},
+		{
+			TestScenario: testutil.TestScenario{
+				Name: "call allowed mutating tool",
+				Args: args("mcp --allow purge --token 123"),
+			},
+			Input: `{"jsonrpc":"2.0","id":1,"method":"tools/call","params":{"name":"purge","arguments":{"service_id":"abc","key":"foo"}}}` + "\n",
+			WantLines: []string{
+				`{"jsonrpc":"2.0","id":1,"result":{"content":[{"type":"text","text":"\nSUCCESS: Purged key: foo (soft: false). Status: ok, ID: 123\n"}]}}`,
+			},
+		},
+		{
+			TestScenario: testutil.TestScenario{
+				Name: "validate mutating tool isn't allowed by default",
+				Args: args("mcp --token 123"),
+			},
+			Input: `{"jsonrpc":"2.0","id":1,"method":"tools/call","params":{"name":"purge","arguments":{"all":true}}}` + "\n",
+			WantLines: []string{
+				`{"jsonrpc":"2.0","id":1,"error":{"code":-32602,"message":"tool 'purge' isn't allowed by the server's policy (see: fastly mcp --allow)"}}`,
+			},
+		},
+		{
+			TestScenario: testutil.TestScenario{
+				Name: "validate tool arguments",
+				Args: args("mcp --token 123"),
+			},
+			Input: `{"jsonrpc":"2.0","id":1,"method":"tools/call","params":{"name":"domain_list","arguments":{"service_id":"abc"}}}
+{"jsonrpc":"2.0","id":2,"method":"tools/call","params":{"name":"service_describe","arguments":{"service_id":1}}}
+{"jsonrpc":"2.0","id":3,"method":"tools/call","params":{"name":"service_list","arguments":{"foo":"bar"}}}
+{"jsonrpc":"2.0","id":4,"method":"tools/call","params":{"name":"nope"}}
+`,
+			WantLines: []string{
+				`{"jsonrpc":"2.0","id":1,"error":{"code":-32602,"message":"missing required argument 'version'"}}`,
+				`{"jsonrpc":"2.0","id":2,"error":{"code":-32602,"message":"argument 'service_id' must be a string"}}`,
+				`{"jsonrpc":"2.0","id":3,"error":{"code":-32602,"message":"unknown arguments: foo"}}`,
+				`{"jsonrpc":"2.0","id":4,"error":{"code":-32602,"message":"unknown tool: nope"}}`,
+			},
+		},
+		{
+			TestScenario: testutil.TestScenario{
+				Name: "command errors are tool results",
+				Args: args("mcp --token 123"),
+			},
+			Input: `{"jsonrpc":"2.0","id":1,"method":"tools/call","params":{"name":"service_version_list","arguments":{}}}` + "\n",
+			WantLines: []string{
+				`{"jsonrpc":"2.0","id":1,"result":{"content":[{"type":"text","text":"error reading service: no service ID found\n\nPlease provide one via the --service-id or --service-name flag`,
+			},
+		},
+		{
+			TestScenario: testutil.TestScenario{
+				Name: "unknown method",
+				Args: args("mcp --token 123"),
+			},
+			Input: `{"jsonrpc":"2.0","id":"a","method":"resources/list"}` + "\n",
+			WantLines: []string{
+				`{"jsonrpc":"2.0","id":"a","error":{"code":-32601,"message":"method not found: resources/list"}}`,
+			},
+		},
+		{
+			TestScenario: testutil.TestScenario{
+				Name:      "malformed request",
+				Args:      args("mcp --token 123"),
+				WantError: "error parsing MCP request",
+			},
+			Input: "{nope\n",
+			WantLines: []string{
+				`{"jsonrpc":"2.0","id":null,"error":{"code":-32700,"message":"invalid character 'n' looking for beginning of object key string"}}`,
+			},
+		},
+		{
+			TestScenario: testutil.TestScenario{
+				Name:      "validate --allow",
+				Args:      args("mcp --allow service_list --token 123"),
+				WantError: "unrecognised tool 'service_list' for --allow",
+			},
+		},
+	}
+	for testcaseIdx := range scenarios {
+		testcase := &scenarios[testcaseIdx]
+		t.Run(testcase.Name, func(t *testing.T) {
+			var stdout bytes.Buffer
+			opts := testutil.NewRunOpts(testcase.Args, &stdout)
+			opts.APIClient = mock.APIClient(api)
+			opts.Stdin = strings.NewReader(testcase.Input)
+			err := app.Run(opts)
+			testutil.AssertErrorContains(t, err, testcase.WantError)
+
+			lines := strings.Split(strings.TrimSuffix(stdout.String(), "\n"), "\n")
+			if stdout.Len() == 0 {
+				lines = nil
+			}
+			if len(lines) != len(testcase.WantLines) {
+				t.Fatalf("want %d responses, got %d:\n%s", len(testcase.WantLines), len(lines), stdout.String())
+			}
+			for i, want := range testcase.WantLines {
+				testutil.AssertStringContains(t, lines[i], want)
+			}
+			for _, s := range testcase.DontWant {
+				testutil.AssertStringDoesntContain(t, stdout.String(), `"name":"`+s+`"`)
+			}
+		})
+	}
+}
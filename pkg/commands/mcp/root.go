@@ -0,0 +1,53 @@
+package mcp
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/fastly/cli/pkg/cmd"
+	"github.com/fastly/cli/pkg/commands/serveapi"
+	"github.com/fastly/cli/pkg/config"
+	fsterr "github.com/fastly/cli/pkg/errors"
+)
+
+// RootCommand serves Fastly operations as MCP tools over stdio.
+type RootCommand struct {
+	cmd.Base
+
+	allow []string
+	run   serveapi.Runner
+}
+
+// NewRootCommand returns a new command registered in the parent.
+func NewRootCommand(parent cmd.Registerer, globals *config.Data, run serveapi.Runner) *RootCommand {
+	var c RootCommand
+	c.Globals = globals
+	c.run = run
+	c.CmdClause = parent.Command("mcp", "Serve Fastly operations as Model Context Protocol (MCP) tools over stdio, for AI assistants")
+	c.CmdClause.Flag("allow", fmt.Sprintf("Expose a tool that changes state, may be repeated (%s)", strings.Join(mutatingTools(), ", "))).StringsVar(&c.allow)
+	return &c
+}
+
+// Exec implements the command interface.
+func (c *RootCommand) Exec(in io.Reader, out io.Writer) error {
+	valid := make(map[string]bool)
+	for _, name := range mutatingTools() {
+		valid[name] = true
+	}
+	for _, name := range c.allow {
+		if !valid[name] {
+			return fsterr.RemediationError{
+				Inner:       fmt.Errorf("unrecognised tool '%s' for --allow", name),
+				Remediation: fmt.Sprintf("Tools that can be allowed: %s.", strings.Join(mutatingTools(), ", ")),
+			}
+		}
+	}
+
+	// NOTE: The interrupt warning mustn't be written to stdout, as it's reserved
+	// for protocol messages.
+	c.Globals.Interrupt.Release()
+
+	s := newServer(c.run, serveapi.GlobalArgs(c.Globals), c.allow)
+	return s.serve(in, out)
+}
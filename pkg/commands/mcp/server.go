@@ -0,0 +1,199 @@
+package mcp
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/fastly/cli/pkg/commands/serveapi"
+	fsterr "github.com/fastly/cli/pkg/errors"
+	"github.com/fastly/cli/pkg/revision"
+)
+
+// ProtocolVersion is the MCP revision implemented by the server.
+const ProtocolVersion = "2024-11-05"
+
+// JSON-RPC error codes.
+const (
+	codeParseError     = -32700
+	codeMethodNotFound = -32601
+	codeInvalidParams  = -32602
+)
+
+type request struct {
+	ID     json.RawMessage `json:"id,omitempty"`
+	Method string          `json:"method"`
+	Params json.RawMessage `json:"params,omitempty"`
+}
+
+type response struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Result  any             `json:"result,omitempty"`
+	Error   *rpcError       `json:"error,omitempty"`
+}
+
+type rpcError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+type content struct {
+	Type string `json:"type"`
+	Text string `json:"text"`
+}
+
+type callResult struct {
+	Content []content `json:"content"`
+	IsError bool      `json:"isError,omitempty"`
+}
+
+// server handles MCP requests, running the CLI for each tool call.
+type server struct {
+	globalArgs []string
+	run        serveapi.Runner
+	tools      map[string]tool
+}
+
+// newServer returns a server exposing the read-only tools and the mutating
+// tools named in allow.
+func newServer(run serveapi.Runner, globalArgs, allow []string) *server {
+	allowed := make(map[string]bool)
+	for _, name := range allow {
+		allowed[name] = true
+	}
+	s := &server{
+		globalArgs: globalArgs,
+		run:        run,
+		tools:      make(map[string]tool),
+	}
+	for _, t := range tools {
+		if !t.mutating || allowed[t.name] {
+			s.tools[t.name] = t
+		}
+	}
+	return s
+}
+
+// serve reads newline-delimited JSON-RPC messages from in until it's closed,
+// writing responses to out.
+func (s *server) serve(in io.Reader, out io.Writer) error {
+	dec := json.NewDecoder(in)
+	enc := json.NewEncoder(out)
+	for {
+		var req request
+		if err := dec.Decode(&req); err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			// NOTE: The stream can't be resynchronised after malformed input, so
+			// the client is told why before the server exits.
+			_ = enc.Encode(response{
+				JSONRPC: "2.0",
+				ID:      json.RawMessage("null"),
+				Error:   &rpcError{Code: codeParseError, Message: err.Error()},
+			})
+			return fmt.Errorf("error parsing MCP request: %w", err)
+		}
+
+		result, rerr := s.handle(req)
+
+		// Notifications have no ID and receive no response.
+		if len(req.ID) == 0 {
+			continue
+		}
+		resp := response{JSONRPC: "2.0", ID: req.ID, Result: result, Error: rerr}
+		if err := enc.Encode(resp); err != nil {
+			return fmt.Errorf("error writing MCP response: %w", err)
+		}
+	}
+}
+
+// handle dispatches a request to the method it names.
+func (s *server) handle(req request) (any, *rpcError) {
+	switch req.Method {
+	case "initialize":
+		return map[string]any{
+			"protocolVersion": ProtocolVersion,
+			"capabilities": map[string]any{
+				"tools": map[string]any{},
+			},
+			"serverInfo": map[string]any{
+				"name":    "fastly",
+				"version": revision.AppVersion,
+			},
+		}, nil
+	case "ping":
+		return map[string]any{}, nil
+	case "tools/list":
+		return map[string]any{"tools": s.list()}, nil
+	case "tools/call":
+		return s.call(req.Params)
+	}
+	if strings.HasPrefix(req.Method, "notifications/") {
+		return nil, nil
+	}
+	return nil, &rpcError{Code: codeMethodNotFound, Message: fmt.Sprintf("method not found: %s", req.Method)}
+}
+
+// list returns the tool definitions in the order they're declared.
+func (s *server) list() []map[string]any {
+	var list []map[string]any
+	for _, t := range tools {
+		if _, ok := s.tools[t.name]; !ok {
+			continue
+		}
+		list = append(list, map[string]any{
+			"name":        t.name,
+			"description": t.description,
+			"inputSchema": t.schema(),
+			"annotations": map[string]any{
+				"readOnlyHint": !t.mutating,
+			},
+		})
+	}
+	return list
+}
+
+// call runs a tool.
+//
+// Errors from the command are reported in the tool result, rather than as a
+// protocol error, so the assistant can see and act on them.
+func (s *server) call(params json.RawMessage) (any, *rpcError) {
+	var p struct {
+		Name      string         `json:"name"`
+		Arguments map[string]any `json:"arguments"`
+	}
+	if err := json.Unmarshal(params, &p); err != nil {
+		return nil, &rpcError{Code: codeInvalidParams, Message: err.Error()}
+	}
+
+	t, ok := s.tools[p.Name]
+	if !ok {
+		msg := fmt.Sprintf("unknown tool: %s", p.Name)
+		for _, m := range tools {
+			if m.name == p.Name {
+				msg = fmt.Sprintf("tool '%s' isn't allowed by the server's policy (see: fastly mcp --allow)", p.Name)
+			}
+		}
+		return nil, &rpcError{Code: codeInvalidParams, Message: msg}
+	}
+
+	args, dir, err := t.args(p.Arguments)
+	if err != nil {
+		return nil, &rpcError{Code: codeInvalidParams, Message: err.Error()}
+	}
+
+	output, err := serveapi.RunIn(s.run, dir, append(append([]string{}, s.globalArgs...), args...))
+	if err != nil {
+		msg := err.Error()
+		var re fsterr.RemediationError
+		if errors.As(err, &re) && re.Remediation != "" {
+			msg += "\n\n" + re.Remediation
+		}
+		return callResult{Content: []content{{Type: "text", Text: msg}}, IsError: true}, nil
+	}
+	return callResult{Content: []content{{Type: "text", Text: string(output)}}}, nil
+}
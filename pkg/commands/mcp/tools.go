@@ -0,0 +1,223 @@
+package mcp
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/fastly/cli/pkg/cmd"
+)
+
+// param is a tool argument that maps to a command flag.
+type param struct {
+	description string
+	flag        string
+	name        string
+	required    bool
+	// typ is the JSON Schema type of the argument, either "string" or
+	// "boolean".
+	typ string
+}
+
+// tool is an MCP tool backed by a CLI command.
+type tool struct {
+	command     []string
+	description string
+	// dir indicates the tool accepts a project directory to run the command
+	// from.
+	dir bool
+	// json indicates the command supports --json, so its output is returned
+	// as JSON.
+	json bool
+	// mutating tools change local or remote state and must be allow-listed by
+	// the operator.
+	mutating bool
+	name     string
+	params   []param
+}
+
+var (
+	serviceIDParam = param{
+		name:        "service_id",
+		flag:        cmd.FlagServiceIDName,
+		typ:         "string",
+		description: "Service ID (defaults to the service_id in the project's fastly.toml)",
+	}
+	versionParam = param{
+		name:        "version",
+		flag:        cmd.FlagVersionName,
+		typ:         "string",
+		description: "'latest', 'active', 'draft', relative to latest or active (e.g. 'latest-1'), 'tag:NAME', or the number of a specific version",
+		required:    true,
+	}
+)
+
+// tools are all the tools the server can expose.
+var tools = []tool{
+	{
+		name:        "service_list",
+		description: "List the Fastly services the token has access to",
+		command:     []string{"service", "list"},
+		json:        true,
+	},
+	{
+		name:        "service_describe",
+		description: "Show detailed information about a Fastly service",
+		command:     []string{"service", "describe"},
+		json:        true,
+		params:      []param{serviceIDParam},
+	},
+	{
+		name:        "service_version_list",
+		description: "List the versions of a Fastly service",
+		command:     []string{"service-version", "list"},
+		json:        true,
+		params:      []param{serviceIDParam},
+	},
+	{
+		name:        "domain_list",
+		description: "List the domains of a Fastly service version",
+		command:     []string{"domain", "list"},
+		json:        true,
+		params:      []param{serviceIDParam, versionParam},
+	},
+	{
+		name:        "backend_list",
+		description: "List the backends of a Fastly service version",
+		command:     []string{"backend", "list"},
+		json:        true,
+		params:      []param{serviceIDParam, versionParam},
+	},
+	{
+		name:        "compute_build",
+		description: "Build a Compute@Edge package locally",
+		command:     []string{"compute", "build"},
+		dir:         true,
+		mutating:    true,
+		params: []param{
+			{name: "include_source", flag: "include-source", typ: "boolean", description: "Include source code in the built package"},
+			{name: "language", flag: "language", typ: "string", description: "Language type"},
+		},
+	},
+	{
+		name:        "compute_deploy",
+		description: "Deploy a Compute@Edge package to a Fastly service",
+		command:     []string{"compute", "deploy"},
+		dir:         true,
+		mutating:    true,
+		params: []param{
+			serviceIDParam,
+			{name: "comment", flag: "comment", typ: "string", description: "Human-readable comment for the service version"},
+			{name: "package", flag: "package", typ: "string", description: "Path to a package tar.gz (defaults to the package built in the project directory)"},
+		},
+	},
+	{
+		name:        "purge",
+		description: "Invalidate objects in the Fastly cache",
+		command:     []string{"purge"},
+		mutating:    true,
+		params: []param{
+			serviceIDParam,
+			{name: "all", flag: "all", typ: "boolean", description: "Purge everything from the service"},
+			{name: "key", flag: "key", typ: "string", description: "Purge a single surrogate key"},
+			{name: "soft", flag: "soft", typ: "boolean", description: "Mark content as stale rather than removing it"},
+			{name: "url", flag: "url", typ: "string", description: "Purge an individual URL"},
+		},
+	},
+}
+
+// mutatingTools returns the names of the tools that must be allow-listed.
+func mutatingTools() []string {
+	var names []string
+	for _, t := range tools {
+		if t.mutating {
+			names = append(names, t.name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// schema returns the JSON Schema of the tool's arguments.
+func (t tool) schema() map[string]any {
+	properties := make(map[string]any)
+	required := []string{}
+	if t.dir {
+		properties["dir"] = map[string]any{
+			"type":        "string",
+			"description": "Project directory to run the command from (defaults to the server's working directory)",
+		}
+	}
+	for _, p := range t.params {
+		properties[p.name] = map[string]any{
+			"type":        p.typ,
+			"description": p.description,
+		}
+		if p.required {
+			required = append(required, p.name)
+		}
+	}
+	return map[string]any{
+		"type":                 "object",
+		"properties":           properties,
+		"required":             required,
+		"additionalProperties": false,
+	}
+}
+
+// args returns the command line for the given tool arguments, along with the
+// directory to run it from.
+func (t tool) args(arguments map[string]any) (args []string, dir string, err error) {
+	known := map[string]bool{}
+	if t.dir {
+		known["dir"] = true
+		if v, ok := arguments["dir"]; ok {
+			if dir, ok = v.(string); !ok {
+				return nil, "", fmt.Errorf("argument 'dir' must be a string")
+			}
+		}
+	}
+
+	args = append(args, t.command...)
+	if t.json {
+		args = append(args, "--"+cmd.FlagJSONName)
+	}
+	for _, p := range t.params {
+		known[p.name] = true
+		v, ok := arguments[p.name]
+		if !ok {
+			if p.required {
+				return nil, "", fmt.Errorf("missing required argument '%s'", p.name)
+			}
+			continue
+		}
+		switch p.typ {
+		case "boolean":
+			b, ok := v.(bool)
+			if !ok {
+				return nil, "", fmt.Errorf("argument '%s' must be a boolean", p.name)
+			}
+			if b {
+				args = append(args, "--"+p.flag)
+			}
+		default:
+			s, ok := v.(string)
+			if !ok {
+				return nil, "", fmt.Errorf("argument '%s' must be a string", p.name)
+			}
+			args = append(args, "--"+p.flag, s)
+		}
+	}
+
+	var unknown []string
+	for k := range arguments {
+		if !known[k] {
+			unknown = append(unknown, k)
+		}
+	}
+	if len(unknown) > 0 {
+		sort.Strings(unknown)
+		return nil, "", fmt.Errorf("unknown arguments: %s", strings.Join(unknown, ", "))
+	}
+	return args, dir, nil
+}
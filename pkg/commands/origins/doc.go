@@ -0,0 +1,3 @@
+// Package origins contains commands to inspect the origins (backends) of a
+// Fastly service.
+package origins
@@ -0,0 +1,332 @@
+package origins
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"sort"
+	"strconv"
+	"syscall"
+	"time"
+
+	"github.com/fastly/cli/pkg/cmd"
+	"github.com/fastly/cli/pkg/config"
+	fsterr "github.com/fastly/cli/pkg/errors"
+	"github.com/fastly/cli/pkg/manifest"
+	"github.com/fastly/cli/pkg/text"
+	"github.com/fastly/go-fastly/v6/fastly"
+)
+
+// Statuses reported for an origin.
+const (
+	StatusHealthy   = "healthy"
+	StatusNoTraffic = "no traffic"
+	StatusUnhealthy = "unhealthy"
+)
+
+// NewHealthCommand returns a usable command registered under the parent.
+func NewHealthCommand(parent cmd.Registerer, globals *config.Data, data manifest.Data) *HealthCommand {
+	var c HealthCommand
+	c.CmdClause = parent.Command("health", "Show the healthchecks and recent error rates of a service's origins")
+	c.Globals = globals
+	c.manifest = data
+
+	// Required flags
+	c.RegisterFlag(cmd.StringFlagOpts{
+		Name:        cmd.FlagVersionName,
+		Description: cmd.FlagVersionDesc,
+		Dst:         &c.serviceVersion.Value,
+		Required:    true,
+	})
+
+	// Optional flags
+	c.CmdClause.Flag("error-threshold", "Percentage of 5xx origin responses at which an origin is reported as unhealthy").Default("5").Float64Var(&c.errorThreshold)
+	c.CmdClause.Flag("interval", "How often to refresh when using --watch").Default("10s").DurationVar(&c.interval)
+	c.RegisterFlagBool(cmd.BoolFlagOpts{
+		Name:        cmd.FlagJSONName,
+		Description: cmd.FlagJSONDesc,
+		Dst:         &c.json,
+		Short:       'j',
+	})
+	c.RegisterFlag(cmd.StringFlagOpts{
+		Name:        cmd.FlagServiceIDName,
+		Description: cmd.FlagServiceIDDesc,
+		Dst:         &c.manifest.Flag.ServiceID,
+		Short:       's',
+	})
+	c.RegisterFlag(cmd.StringFlagOpts{
+		Action:      c.serviceName.Set,
+		Name:        cmd.FlagServiceName,
+		Description: cmd.FlagServiceDesc,
+		Dst:         &c.serviceName.Value,
+	})
+	c.CmdClause.Flag("watch", "Refresh the report until interrupted").Short('w').BoolVar(&c.watch)
+	c.CmdClause.Flag("window", "How far back to look for origin responses").Default("5m").DurationVar(&c.window)
+
+	return &c
+}
+
+// HealthCommand calls the Fastly API to report on origin health.
+type HealthCommand struct {
+	cmd.Base
+
+	errorThreshold float64
+	interval       time.Duration
+	json           bool
+	manifest       manifest.Data
+	serviceName    cmd.OptionalServiceNameID
+	serviceVersion cmd.OptionalServiceVersion
+	watch          bool
+	window         time.Duration
+}
+
+// OriginHealth summarises the configuration and recent traffic of an origin.
+type OriginHealth struct {
+	Backend     string  `json:"backend"`
+	Address     string  `json:"address"`
+	Healthcheck string  `json:"healthcheck,omitempty"`
+	CheckPath   string  `json:"check_path,omitempty"`
+	Responses   uint64  `json:"responses"`
+	Errors      uint64  `json:"errors"`
+	ErrorRate   float64 `json:"error_rate"`
+	Status      string  `json:"status"`
+}
+
+// Exec invokes the application logic for the command.
+func (c *HealthCommand) Exec(_ io.Reader, out io.Writer) error {
+	if c.Globals.Verbose() && c.json {
+		return fsterr.ErrInvalidVerboseJSONCombo
+	}
+	if err := c.validate(); err != nil {
+		return err
+	}
+
+	serviceID, serviceVersion, err := cmd.ServiceDetails(cmd.ServiceDetailsOpts{
+		AllowActiveLocked:  true,
+		APIClient:          c.Globals.APIClient,
+		Manifest:           c.manifest,
+		Out:                out,
+		ServiceNameFlag:    c.serviceName,
+		ServiceVersionFlag: c.serviceVersion,
+		VerboseMode:        c.Globals.Flag.Verbose,
+	})
+	if err != nil {
+		c.Globals.ErrLog.AddWithContext(err, map[string]any{
+			"Service ID":      serviceID,
+			"Service Version": fsterr.ServiceVersion(serviceVersion),
+		})
+		return err
+	}
+
+	report := func() error {
+		hs, err := c.fetch(serviceID, serviceVersion.Number, time.Now())
+		if err != nil {
+			c.Globals.ErrLog.AddWithContext(err, map[string]any{
+				"Service ID":      serviceID,
+				"Service Version": serviceVersion.Number,
+			})
+			return err
+		}
+		return c.print(out, hs)
+	}
+
+	if err := report(); err != nil || !c.watch {
+		return err
+	}
+
+	// NOTE: An interrupt is the normal way to stop, so the command handles it
+	// rather than the CLI cleaning up after an interrupted command.
+	c.Globals.Interrupt.Release()
+	sigs := make(chan os.Signal, 1)
+	signal.Notify(sigs, syscall.SIGINT, syscall.SIGTERM)
+	defer signal.Stop(sigs)
+
+	ticker := time.NewTicker(c.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-sigs:
+			return nil
+		case <-ticker.C:
+			text.Break(out)
+			// NOTE: The error is kept out of the JSON stream so it remains
+			// parseable. It's still recorded in the error log by report().
+			if err := report(); err != nil && !c.json {
+				text.Error(out, "fetching origin health: %v", err)
+			}
+		}
+	}
+}
+
+// validate checks the flag values are usable.
+func (c *HealthCommand) validate() error {
+	if c.errorThreshold < 0 || c.errorThreshold > 100 {
+		return fsterr.RemediationError{
+			Inner:       fmt.Errorf("invalid --error-threshold value: %v", c.errorThreshold),
+			Remediation: "Provide a percentage between 0 and 100.",
+		}
+	}
+	if c.window <= 0 {
+		return fsterr.RemediationError{
+			Inner:       fmt.Errorf("invalid --window value: %s", c.window),
+			Remediation: "Provide a positive duration (e.g. 5m).",
+		}
+	}
+	if c.watch && c.interval <= 0 {
+		return fsterr.RemediationError{
+			Inner:       fmt.Errorf("invalid --interval value: %s", c.interval),
+			Remediation: "Provide a positive duration (e.g. 10s).",
+		}
+	}
+	return nil
+}
+
+// fetch combines the backend and healthcheck definitions with the origin
+// metrics recorded during the window leading up to now.
+func (c *HealthCommand) fetch(serviceID string, serviceVersion int, now time.Time) ([]OriginHealth, error) {
+	backends, err := c.Globals.APIClient.ListBackends(&fastly.ListBackendsInput{
+		ServiceID:      serviceID,
+		ServiceVersion: serviceVersion,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	healthchecks, err := c.Globals.APIClient.ListHealthChecks(&fastly.ListHealthChecksInput{
+		ServiceID:      serviceID,
+		ServiceVersion: serviceVersion,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// NOTE: Each response holds a limited number of series, so services with
+	// many origins require following the cursor to see every host.
+	data, err := cmd.FetchCursor(func(cursor string) ([]fastly.OriginData, string, error) {
+		r, err := c.Globals.APIClient.GetOriginMetricsForService(&fastly.GetOriginMetricsInput{
+			ServiceID:  serviceID,
+			Start:      now.Add(-c.window),
+			End:        now,
+			Metrics:    []string{"responses", "status_5xx"},
+			GroupBy:    []string{"host"},
+			Downsample: "minute",
+			Cursor:     cursor,
+		})
+		if err != nil {
+			return nil, "", err
+		}
+		return r.Data, r.Meta.NextCursor, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return Summarise(backends, healthchecks, &fastly.OriginInspector{Data: data}, c.errorThreshold), nil
+}
+
+// Summarise merges backend, healthcheck and origin metric data into a health
+// report ordered by backend name.
+//
+// NOTE: The API doesn't expose the result of healthcheck probes, so the status
+// is derived from the proportion of 5xx responses returned by the origin.
+func Summarise(backends []*fastly.Backend, healthchecks []*fastly.HealthCheck, metrics *fastly.OriginInspector, threshold float64) []OriginHealth {
+	checks := make(map[string]*fastly.HealthCheck, len(healthchecks))
+	for _, h := range healthchecks {
+		checks[h.Name] = h
+	}
+
+	type totals struct{ responses, errors uint64 }
+	byHost := make(map[string]totals)
+	if metrics != nil {
+		for _, d := range metrics.Data {
+			t := byHost[d.Dimensions["host"]]
+			for _, v := range d.Values {
+				t.responses += v.Responses
+				t.errors += v.Status5xx
+			}
+			byHost[d.Dimensions["host"]] = t
+		}
+	}
+
+	hs := make([]OriginHealth, 0, len(backends))
+	for _, b := range backends {
+		h := OriginHealth{
+			Backend:     b.Name,
+			Address:     b.Address,
+			Healthcheck: b.HealthCheck,
+		}
+		if hc, ok := checks[b.HealthCheck]; ok {
+			h.CheckPath = hc.Path
+		}
+
+		t := byHost[b.Address]
+		h.Responses = t.responses
+		h.Errors = t.errors
+
+		switch {
+		case t.responses == 0:
+			h.Status = StatusNoTraffic
+		default:
+			h.ErrorRate = float64(t.errors) / float64(t.responses) * 100
+			h.Status = StatusHealthy
+			if h.ErrorRate >= threshold {
+				h.Status = StatusUnhealthy
+			}
+		}
+		hs = append(hs, h)
+	}
+
+	sort.Slice(hs, func(i, j int) bool {
+		return hs[i].Backend < hs[j].Backend
+	})
+	return hs
+}
+
+// print displays the health report.
+func (c *HealthCommand) print(out io.Writer, hs []OriginHealth) error {
+	if c.json {
+		data, err := json.Marshal(hs)
+		if err != nil {
+			return err
+		}
+		_, err = out.Write(data)
+		if err != nil {
+			c.Globals.ErrLog.Add(err)
+			return fmt.Errorf("error: unable to write data to stdout: %w", err)
+		}
+		if c.watch {
+			text.Break(out)
+		}
+		return nil
+	}
+
+	t := text.NewTable(out)
+	t.AddHeader("BACKEND", "ADDRESS", "HEALTHCHECK", "CHECK PATH", "RESPONSES", "5XX", "ERROR RATE", "STATUS")
+	for _, h := range hs {
+		t.AddLine(h.Backend, h.Address, orDash(h.Healthcheck), orDash(h.CheckPath), h.Responses, h.Errors, strconv.FormatFloat(h.ErrorRate, 'f', 2, 64)+"%", colourStatus(h.Status))
+	}
+	t.Print()
+	return nil
+}
+
+// colourStatus highlights the status so problems stand out.
+func colourStatus(status string) string {
+	switch status {
+	case StatusHealthy:
+		return text.BoldGreen(status)
+	case StatusUnhealthy:
+		return text.BoldRed(status)
+	default:
+		return text.BoldYellow(status)
+	}
+}
+
+func orDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
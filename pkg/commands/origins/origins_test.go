@@ -0,0 +1,177 @@
+package origins_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"syscall"
+	"testing"
+
+	"github.com/fastly/cli/pkg/app"
+	"github.com/fastly/cli/pkg/commands/origins"
+	"github.com/fastly/cli/pkg/mock"
+	"github.com/fastly/cli/pkg/testutil"
+	"github.com/fastly/go-fastly/v6/fastly"
+)
+
+func TestHealth(t *testing.T) {
+	args := testutil.Args
+	scenarios := []testutil.TestScenario{
+		{
+			Name:      "validate missing --version flag",
+			Args:      args("origins health --service-id 123"),
+			WantError: "error parsing arguments: required flag --version not provided",
+		},
+		{
+			Name: "validate API error",
+			API: mock.API{
+				ListVersionsFn:     testutil.ListVersions,
+				ListBackendsFn:     listBackends,
+				ListHealthChecksFn: listHealthChecks,
+				GetOriginMetricsForServiceFn: func(_ *fastly.GetOriginMetricsInput) (*fastly.OriginInspector, error) {
+					return nil, testutil.Err
+				},
+			},
+			Args:      args("origins health --service-id 123 --version 1"),
+			WantError: testutil.Err.Error(),
+		},
+		{
+			Name:      "validate --error-threshold range",
+			Args:      args("origins health --service-id 123 --version 1 --error-threshold 101"),
+			WantError: "invalid --error-threshold value: 101",
+		},
+		{
+			Name:      "validate --window must be positive",
+			Args:      args("origins health --service-id 123 --version 1 --window 0s"),
+			WantError: "invalid --window value: 0s",
+		},
+		{
+			Name:      "validate --interval must be positive",
+			Args:      args("origins health --service-id 123 --version 1 --watch --interval 0s"),
+			WantError: "invalid --interval value: 0s",
+		},
+		{
+			Name: "validate API success",
+			API: mock.API{
+				ListVersionsFn:               testutil.ListVersions,
+				ListBackendsFn:               listBackends,
+				ListHealthChecksFn:           listHealthChecks,
+				GetOriginMetricsForServiceFn: getOriginMetrics,
+			},
+			Args:       args("origins health --service-id 123 --version 1"),
+			WantOutput: healthOutput,
+		},
+		{
+			Name: "validate --error-threshold",
+			API: mock.API{
+				ListVersionsFn:               testutil.ListVersions,
+				ListBackendsFn:               listBackends,
+				ListHealthChecksFn:           listHealthChecks,
+				GetOriginMetricsForServiceFn: getOriginMetrics,
+			},
+			Args:       args("origins health --service-id 123 --version 1 --error-threshold 1 --json"),
+			WantOutput: healthJSONOutput,
+		},
+	}
+
+	for testcaseIdx := range scenarios {
+		testcase := &scenarios[testcaseIdx]
+		t.Run(testcase.Name, func(t *testing.T) {
+			var stdout bytes.Buffer
+			opts := testutil.NewRunOpts(testcase.Args, &stdout)
+			opts.APIClient = mock.APIClient(testcase.API)
+			err := app.Run(opts)
+			testutil.AssertErrorContains(t, err, testcase.WantError)
+			testutil.AssertString(t, testcase.WantOutput, stdout.String())
+		})
+	}
+}
+
+func TestHealthWatchJSON(t *testing.T) {
+	var calls int
+
+	var stdout bytes.Buffer
+	opts := testutil.NewRunOpts(testutil.Args("origins health --service-id 123 --version 1 --watch --interval 10ms --json"), &stdout)
+	opts.APIClient = mock.APIClient(mock.API{
+		ListVersionsFn:     testutil.ListVersions,
+		ListHealthChecksFn: listHealthChecks,
+		ListBackendsFn: func(i *fastly.ListBackendsInput) ([]*fastly.Backend, error) {
+			calls++
+			switch calls {
+			case 2:
+				return nil, testutil.Err
+			case 3:
+				// NOTE: The command stops refreshing once interrupted.
+				testutil.AssertNoError(t, syscall.Kill(syscall.Getpid(), syscall.SIGINT))
+			}
+			return listBackends(i)
+		},
+		GetOriginMetricsForServiceFn: getOriginMetrics,
+	})
+	err := app.Run(opts)
+	testutil.AssertNoError(t, err)
+
+	// The failed refresh mustn't leak into the JSON stream.
+	for _, line := range strings.Split(strings.TrimSpace(stdout.String()), "\n") {
+		if line == "" {
+			continue
+		}
+		var hs []origins.OriginHealth
+		testutil.AssertNoError(t, json.Unmarshal([]byte(line), &hs))
+		testutil.AssertEqual(t, 3, len(hs))
+	}
+}
+
+func TestSummarise(t *testing.T) {
+	backends, _ := listBackends(nil)
+	healthchecks, _ := listHealthChecks(nil)
+	metrics, _ := getOriginMetrics(nil)
+
+	hs := origins.Summarise(backends, healthchecks, metrics, 10)
+	testutil.AssertEqual(t, 3, len(hs))
+	testutil.AssertString(t, origins.StatusHealthy, hs[0].Status)
+	testutil.AssertString(t, origins.StatusUnhealthy, hs[1].Status)
+	testutil.AssertString(t, origins.StatusNoTraffic, hs[2].Status)
+}
+
+func listBackends(_ *fastly.ListBackendsInput) ([]*fastly.Backend, error) {
+	return []*fastly.Backend{
+		{Name: "origin-b", Address: "b.example.com"},
+		{Name: "origin-a", Address: "a.example.com", HealthCheck: "check-a"},
+		{Name: "origin-c", Address: "c.example.com"},
+	}, nil
+}
+
+func listHealthChecks(_ *fastly.ListHealthChecksInput) ([]*fastly.HealthCheck, error) {
+	return []*fastly.HealthCheck{
+		{Name: "check-a", Path: "/status"},
+	}, nil
+}
+
+func getOriginMetrics(_ *fastly.GetOriginMetricsInput) (*fastly.OriginInspector, error) {
+	return &fastly.OriginInspector{
+		Data: []fastly.OriginData{
+			{
+				Dimensions: map[string]string{"host": "a.example.com"},
+				Values: []fastly.OriginMetrics{
+					{Responses: 100, Status5xx: 1},
+					{Responses: 100, Status5xx: 3},
+				},
+			},
+			{
+				Dimensions: map[string]string{"host": "b.example.com"},
+				Values: []fastly.OriginMetrics{
+					{Responses: 10, Status5xx: 5},
+				},
+			},
+		},
+	}, nil
+}
+
+var healthOutput = `BACKEND   ADDRESS        HEALTHCHECK  CHECK PATH  RESPONSES  5XX  ERROR RATE  STATUS
+origin-a  a.example.com  check-a      /status     200        4    2.00%       healthy
+origin-b  b.example.com  -            -           10         5    50.00%      unhealthy
+origin-c  c.example.com  -            -           0          0    0.00%       no traffic
+`
+
+var healthJSONOutput = `[{"backend":"origin-a","address":"a.example.com","healthcheck":"check-a","check_path":"/status","responses":200,"errors":4,"error_rate":2,"status":"unhealthy"},{"backend":"origin-b","address":"b.example.com","responses":10,"errors":5,"error_rate":50,"status":"unhealthy"},{"backend":"origin-c","address":"c.example.com","responses":0,"errors":0,"error_rate":0,"status":"no traffic"}]`
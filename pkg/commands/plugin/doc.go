@@ -0,0 +1,5 @@
+// Package plugin contains commands to discover and run CLI plugins.
+//
+// A plugin is any executable on the user's PATH named with the 'fastly-'
+// prefix, e.g. a 'fastly-foo' executable is run by `fastly foo ...`.
+package plugin
@@ -0,0 +1,100 @@
+package plugin
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+
+	"github.com/fastly/cli/pkg/cmd"
+	"github.com/fastly/cli/pkg/config"
+	"github.com/fastly/cli/pkg/env"
+	"github.com/fastly/cli/pkg/text"
+)
+
+// ExecCommand runs a plugin executable.
+//
+// NOTE: It's hidden as users run plugins as `fastly <name>`, which the app
+// rewrites to `fastly plugin exec <name> -- <args>`.
+type ExecCommand struct {
+	cmd.Base
+
+	args []string
+	name string
+}
+
+// NewExecCommand returns a usable command registered under the parent.
+func NewExecCommand(parent cmd.Registerer, globals *config.Data) *ExecCommand {
+	var c ExecCommand
+	c.Globals = globals
+	c.CmdClause = parent.Command("exec", "Run a plugin").Hidden()
+	c.CmdClause.Arg("name", "Plugin name").Required().StringVar(&c.name)
+	c.CmdClause.Arg("args", "Plugin arguments").StringsVar(&c.args)
+	return &c
+}
+
+// Exec invokes the application logic for the command.
+func (c *ExecCommand) Exec(in io.Reader, out io.Writer) error {
+	path, ok := Find(c.name)
+	if !ok {
+		return fmt.Errorf("plugin '%s' not found: no %s%s executable on your PATH", c.name, Prefix, c.name)
+	}
+
+	// A plugin must see end of input rather than an error when prompts are
+	// disabled.
+	if _, ok := in.(text.NonInteractiveReader); ok {
+		in = nil
+	}
+
+	// gosec flagged this:
+	// G204 (CWE-78): Subprocess launched with variable
+	// Disabling as the user installed the plugin executable on their PATH.
+	/* #nosec */
+	command := exec.CommandContext(c.Globals.Context(), path, c.args...)
+	command.Env = append(os.Environ(), c.environ()...)
+	command.Stdin = in
+	command.Stdout = out
+	command.Stderr = os.Stderr
+
+	if err := command.Run(); err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			return fmt.Errorf("plugin '%s' exited with code %d", c.name, exitErr.ExitCode())
+		}
+		c.Globals.ErrLog.Add(err)
+		return fmt.Errorf("error running plugin '%s': %w", c.name, err)
+	}
+	return nil
+}
+
+// environ returns the env vars that give a plugin the context of the current
+// invocation. Any `fastly` commands run by the plugin inherit the same
+// context, as the CLI reads these same env vars.
+func (c *ExecCommand) environ() []string {
+	var vars []string
+	if token, _ := c.Globals.Token(); token != "" {
+		vars = append(vars, env.Token+"="+token)
+	}
+	if endpoint, _ := c.Globals.Endpoint(); endpoint != "" {
+		vars = append(vars, env.Endpoint+"="+endpoint)
+	}
+
+	if profile := c.Globals.Profile(); profile != "" {
+		vars = append(vars, cmd.EnvFlagName("", "profile")+"="+profile)
+	}
+
+	for flag, set := range map[string]bool{
+		"accept-defaults": c.Globals.Flag.AcceptDefaults,
+		"auto-yes":        c.Globals.Flag.AutoYes,
+		"non-interactive": c.Globals.Flag.NonInteractive,
+		"quiet":           c.Globals.Flag.Quiet,
+		"show-secrets":    c.Globals.Flag.ShowSecrets,
+		"verbose":         c.Globals.Flag.Verbose,
+	} {
+		if set {
+			vars = append(vars, cmd.EnvFlagName("", flag)+"=true")
+		}
+	}
+	return vars
+}
@@ -0,0 +1,66 @@
+package plugin
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/fastly/cli/pkg/cmd"
+	"github.com/fastly/cli/pkg/config"
+	"github.com/fastly/cli/pkg/text"
+)
+
+// ListCommand represents a Kingpin command.
+type ListCommand struct {
+	cmd.Base
+	json bool
+}
+
+// NewListCommand returns a usable command registered under the parent.
+func NewListCommand(parent cmd.Registerer, globals *config.Data) *ListCommand {
+	var c ListCommand
+	c.Globals = globals
+	c.CmdClause = parent.Command("list", "List the plugins found on your PATH")
+	c.RegisterFlagBool(cmd.BoolFlagOpts{
+		Name:        cmd.FlagJSONName,
+		Description: cmd.FlagJSONDesc,
+		Dst:         &c.json,
+		Short:       'j',
+	})
+	return &c
+}
+
+// Exec invokes the application logic for the command.
+func (c *ListCommand) Exec(_ io.Reader, out io.Writer) error {
+	plugins := Discover(os.Getenv("PATH"))
+
+	if c.json {
+		if plugins == nil {
+			plugins = []Plugin{}
+		}
+		data, err := json.Marshal(plugins)
+		if err != nil {
+			return err
+		}
+		_, err = out.Write(data)
+		if err != nil {
+			c.Globals.ErrLog.Add(err)
+			return fmt.Errorf("error: unable to write data to stdout: %w", err)
+		}
+		return nil
+	}
+
+	if len(plugins) == 0 {
+		text.Description(out, "No plugins found. To add a plugin, install an executable on your PATH named", Prefix+"<name>")
+		return nil
+	}
+
+	t := text.NewTable(out)
+	t.AddHeader("NAME", "PATH")
+	for _, p := range plugins {
+		t.AddLine(p.Name, p.Path)
+	}
+	t.Print()
+	return nil
+}
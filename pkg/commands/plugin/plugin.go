@@ -0,0 +1,84 @@
+package plugin
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+)
+
+// Prefix is the file name prefix of a plugin executable.
+const Prefix = "fastly-"
+
+// Plugin represents a plugin executable found on the PATH.
+type Plugin struct {
+	Name string `json:"name"`
+	Path string `json:"path"`
+}
+
+// Find returns the path of the executable for the named plugin.
+func Find(name string) (string, bool) {
+	if name == "" || strings.ContainsAny(name, `/\`) {
+		return "", false
+	}
+	path, err := exec.LookPath(Prefix + name)
+	if err != nil {
+		return "", false
+	}
+	return path, true
+}
+
+// Discover returns the plugins found in the directories of the path list
+// (e.g. the PATH environment variable), sorted by name.
+//
+// NOTE: As with command lookup, a plugin in an earlier directory shadows any
+// plugin of the same name in a later directory.
+func Discover(pathList string) []Plugin {
+	seen := make(map[string]bool)
+	var plugins []Plugin
+	for _, dir := range filepath.SplitList(pathList) {
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			continue
+		}
+		for _, e := range entries {
+			name, ok := pluginName(e.Name())
+			if !ok || seen[name] {
+				continue
+			}
+			path := filepath.Join(dir, e.Name())
+			if !isExecutable(path) {
+				continue
+			}
+			seen[name] = true
+			plugins = append(plugins, Plugin{Name: name, Path: path})
+		}
+	}
+	sort.Slice(plugins, func(i, j int) bool {
+		return plugins[i].Name < plugins[j].Name
+	})
+	return plugins
+}
+
+// pluginName returns the plugin name for an executable file name.
+func pluginName(file string) (string, bool) {
+	if runtime.GOOS == "windows" {
+		file = strings.TrimSuffix(file, filepath.Ext(file))
+	}
+	name := strings.TrimPrefix(file, Prefix)
+	return name, name != file && name != ""
+}
+
+// isExecutable reports whether path is an executable file.
+func isExecutable(path string) bool {
+	fi, err := os.Stat(path)
+	if err != nil || fi.IsDir() {
+		return false
+	}
+	if runtime.GOOS == "windows" {
+		return strings.EqualFold(filepath.Ext(path), ".exe")
+	}
+	return fi.Mode().Perm()&0o111 != 0
+}
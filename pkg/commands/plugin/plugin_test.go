@@ -0,0 +1,101 @@
+package plugin_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/fastly/cli/pkg/app"
+	"github.com/fastly/cli/pkg/commands/plugin"
+	"github.com/fastly/cli/pkg/config"
+	"github.com/fastly/cli/pkg/testutil"
+)
+
+const helloScript = `#!/bin/sh
+echo "hello $* token=$FASTLY_API_TOKEN profile=$FASTLY_PROFILE non_interactive=$FASTLY_NON_INTERACTIVE"
+`
+
+func TestPlugin(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("plugin test scripts require a POSIX shell")
+	}
+
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "fastly-hello"), helloScript, 0o755)
+	writeFile(t, filepath.Join(dir, "fastly-fail"), "#!/bin/sh\nexit 3\n", 0o755)
+	t.Setenv("PATH", dir)
+
+	args := testutil.Args
+	scenarios := []testutil.TestScenario{
+		{
+			Name:       "run plugin",
+			Args:       args("hello world --verbose"),
+			WantOutput: "hello world --verbose token= profile= non_interactive=\n",
+		},
+		{
+			Name:       "run plugin with global flags",
+			Args:       args("--token 123 -o user --non-interactive hello"),
+			WantOutput: "hello  token=123 profile=user non_interactive=true\n",
+		},
+		{
+			Name:      "plugin failure",
+			Args:      args("fail"),
+			WantError: "plugin 'fail' exited with code 3",
+		},
+		{
+			Name:      "unknown command",
+			Args:      args("nope"),
+			WantError: "expected command but got nope",
+		},
+		{
+			Name:       "list plugins",
+			Args:       args("plugin list"),
+			WantOutput: "NAME   PATH\nfail   " + filepath.Join(dir, "fastly-fail") + "\nhello  " + filepath.Join(dir, "fastly-hello") + "\n",
+		},
+	}
+	for testcaseIdx := range scenarios {
+		testcase := &scenarios[testcaseIdx]
+		t.Run(testcase.Name, func(t *testing.T) {
+			var stdout bytes.Buffer
+			opts := testutil.NewRunOpts(testcase.Args, &stdout)
+			opts.ConfigFile = config.File{
+				Profiles: config.Profiles{
+					"user": &config.Profile{Token: "456"},
+				},
+			}
+			err := app.Run(opts)
+			testutil.AssertErrorContains(t, err, testcase.WantError)
+			testutil.AssertString(t, testcase.WantOutput, stdout.String())
+		})
+	}
+}
+
+func TestDiscover(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("plugin discovery test relies on file permissions")
+	}
+
+	a := t.TempDir()
+	b := t.TempDir()
+	writeFile(t, filepath.Join(a, "fastly-foo"), "", 0o755)
+	writeFile(t, filepath.Join(a, "fastly-noexec"), "", 0o644)
+	writeFile(t, filepath.Join(a, "fastly-"), "", 0o755)
+	writeFile(t, filepath.Join(a, "other"), "", 0o755)
+	writeFile(t, filepath.Join(b, "fastly-foo"), "", 0o755)
+	writeFile(t, filepath.Join(b, "fastly-bar"), "", 0o755)
+
+	got := plugin.Discover(a + string(os.PathListSeparator) + b + string(os.PathListSeparator) + filepath.Join(a, "missing"))
+	testutil.AssertEqual(t, []plugin.Plugin{
+		{Name: "bar", Path: filepath.Join(b, "fastly-bar")},
+		{Name: "foo", Path: filepath.Join(a, "fastly-foo")},
+	}, got)
+}
+
+func writeFile(t *testing.T, path, content string, perm os.FileMode) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(content), perm); err != nil {
+		t.Fatal(err)
+	}
+}
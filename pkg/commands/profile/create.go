@@ -218,6 +218,10 @@ func displayCfgPath(path string, out io.Writer) {
 }
 
 func (c *CreateCommand) promptForDefault(in io.Reader, out io.Writer) (bool, error) {
+	if c.Globals.Flag.AutoYes || c.Globals.Flag.NonInteractive {
+		return true, nil
+	}
+
 	text.Break(out)
 	cont, err := text.AskYesNo(out, "Set this profile to be your default? [y/N] ", in)
 	if err != nil {
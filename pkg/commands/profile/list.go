@@ -40,7 +40,7 @@ func (c *ListCommand) Exec(_ io.Reader, out io.Writer) error {
 
 	if !c.Globals.Verbose() {
 		if c.json {
-			data, err := json.Marshal(c.Globals.File.Profiles)
+			data, err := json.Marshal(maskTokens(c.Globals.File.Profiles))
 			if err != nil {
 				return err
 			}
@@ -89,5 +89,23 @@ func display(k string, v *config.Profile, out io.Writer, style func(a ...any) st
 	text.Break(out)
 	text.Output(out, "%s: %t", style("Default"), v.Default)
 	text.Output(out, "%s: %s", style("Email"), v.Email)
-	text.Output(out, "%s: %s", style("Token"), v.Token)
+	text.Output(out, "%s: %s", style("Token"), text.Secret(v.Token))
+	if v.AutoClone {
+		text.Output(out, "%s: %t", style("Autoclone"), v.AutoClone)
+	}
+}
+
+// maskTokens returns a copy of the profiles with their tokens masked, unless
+// secrets are being shown.
+func maskTokens(p config.Profiles) config.Profiles {
+	if p == nil {
+		return nil
+	}
+	masked := make(config.Profiles, len(p))
+	for k, v := range p {
+		profile := *v
+		profile.Token = text.Secret(profile.Token)
+		masked[k] = &profile
+	}
+	return masked
 }
@@ -100,6 +100,40 @@ func TestCreate(t *testing.T) {
 				},
 			},
 		},
+		{
+			TestScenario: testutil.TestScenario{
+				Name:      "validate --non-interactive fails rather than prompting for a token",
+				Args:      args("profile create bar --non-interactive"),
+				WantError: "user input required but prompts are disabled",
+			},
+		},
+		{
+			TestScenario: testutil.TestScenario{
+				Name: "validate --non-interactive makes the new profile the default without prompting",
+				Args: args("profile create bar --non-interactive --token 123"),
+				API: mock.API{
+					GetTokenSelfFn: getToken,
+					GetUserFn:      getUser,
+				},
+				WantOutput: "Profile 'bar' created",
+			},
+			ConfigFile: config.File{
+				Profiles: config.Profiles{
+					"foo": &config.Profile{
+						Default: true,
+						Email:   "foo@example.com",
+						Token:   "123",
+					},
+				},
+			},
+		},
+		{
+			TestScenario: testutil.TestScenario{
+				Name:      "validate --verbose and --quiet are mutually exclusive",
+				Args:      args("profile create bar --verbose --quiet"),
+				WantError: "invalid flag combination, --verbose and --quiet",
+			},
+		},
 	}
 
 	for testcaseIdx := range scenarios {
@@ -322,8 +356,8 @@ func TestList(t *testing.T) {
 				Args: args("profile list"),
 				WantOutputs: []string{
 					"Default profile highlighted in red.",
-					"foo\n\nDefault: true\nEmail: foo@example.com\nToken: 123",
-					"bar\n\nDefault: false\nEmail: bar@example.com\nToken: 456",
+					"foo\n\nDefault: true\nEmail: foo@example.com\nToken: ********",
+					"bar\n\nDefault: false\nEmail: bar@example.com\nToken: ********",
 				},
 			},
 			ConfigFile: config.File{
@@ -371,8 +405,8 @@ func TestList(t *testing.T) {
 				Args: args("profile list"),
 				WantOutputs: []string{
 					"At least one account profile should be set as the 'default'. Run `fastly profile update <NAME>`.",
-					"foo\n\nDefault: false\nEmail: foo@example.com\nToken: 123",
-					"bar\n\nDefault: false\nEmail: bar@example.com\nToken: 456",
+					"foo\n\nDefault: false\nEmail: foo@example.com\nToken: ********",
+					"bar\n\nDefault: false\nEmail: bar@example.com\nToken: ********",
 				},
 			},
 			ConfigFile: config.File{
@@ -415,6 +449,27 @@ func TestList(t *testing.T) {
 			TestScenario: testutil.TestScenario{
 				Name:       "validate listing profiles with --json displays data correctly",
 				Args:       args("profile list --json"),
+				WantOutput: `{"bar":{"default":false,"email":"bar@example.com","token":"********"},"foo":{"default":false,"email":"foo@example.com","token":"********"}}`,
+			},
+			ConfigFile: config.File{
+				Profiles: config.Profiles{
+					"foo": &config.Profile{
+						Default: false,
+						Email:   "foo@example.com",
+						Token:   "123",
+					},
+					"bar": &config.Profile{
+						Default: false,
+						Email:   "bar@example.com",
+						Token:   "456",
+					},
+				},
+			},
+		},
+		{
+			TestScenario: testutil.TestScenario{
+				Name:       "validate listing profiles with --json and --show-secrets displays tokens",
+				Args:       args("profile list --json --show-secrets"),
 				WantOutput: `{"bar":{"default":false,"email":"bar@example.com","token":"456"},"foo":{"default":false,"email":"foo@example.com","token":"123"}}`,
 			},
 			ConfigFile: config.File{
@@ -51,6 +51,18 @@ func TestPurgeAll(t *testing.T) {
 			Args:       args("purge --all --service-id 123 --token 456"),
 			WantOutput: "Purge all status: ok",
 		},
+		{
+			Name: "validate --no-notify flag",
+			API: mock.API{
+				PurgeAllFn: func(i *fastly.PurgeAllInput) (*fastly.Purge, error) {
+					return &fastly.Purge{
+						Status: "ok",
+					}, nil
+				},
+			},
+			Args:       args("purge --all --no-notify --service-id 123 --token 456"),
+			WantOutput: "Purge all status: ok",
+		},
 	}
 
 	for testcaseIdx := range scenarios {
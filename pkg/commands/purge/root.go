@@ -12,6 +12,7 @@ import (
 	"github.com/fastly/cli/pkg/config"
 	"github.com/fastly/cli/pkg/errors"
 	"github.com/fastly/cli/pkg/manifest"
+	"github.com/fastly/cli/pkg/notify"
 	"github.com/fastly/cli/pkg/text"
 	"github.com/fastly/go-fastly/v6/fastly"
 )
@@ -27,6 +28,7 @@ func NewRootCommand(parent cmd.Registerer, globals *config.Data, data manifest.D
 	c.CmdClause.Flag("all", "Purge everything from a service").BoolVar(&c.all)
 	c.CmdClause.Flag("file", "Purge a service of a newline delimited list of Surrogate Keys").StringVar(&c.file)
 	c.CmdClause.Flag("key", "Purge a service of objects tagged with a Surrogate Key").StringVar(&c.key)
+	c.CmdClause.Flag("notify", notify.FlagDesc+" when using --all").Action(c.notify.Set).NegatableBoolVar(&c.notify.Value)
 	c.RegisterFlag(cmd.StringFlagOpts{
 		Name:        cmd.FlagServiceIDName,
 		Description: cmd.FlagServiceIDDesc,
@@ -54,6 +56,7 @@ type RootCommand struct {
 	file        string
 	key         string
 	manifest    manifest.Data
+	notify      cmd.OptionalBool
 	serviceName cmd.OptionalServiceNameID
 	soft        bool
 	url         string
@@ -148,6 +151,11 @@ func (c *RootCommand) purgeAll(serviceID string, out io.Writer) error {
 		return err
 	}
 	text.Success(out, "Purge all status: %s", p.Status)
+
+	notify.Send(notify.Event{
+		Action:    notify.ActionPurgeAll,
+		ServiceID: serviceID,
+	}, c.notify, c.Globals, out)
 	return nil
 }
 
@@ -0,0 +1,165 @@
+package ratelimit
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/fastly/cli/pkg/cmd"
+	"github.com/fastly/cli/pkg/config"
+	"github.com/fastly/cli/pkg/errors"
+	"github.com/fastly/cli/pkg/manifest"
+	"github.com/fastly/cli/pkg/text"
+	"github.com/fastly/go-fastly/v6/fastly"
+	"github.com/fastly/kingpin"
+)
+
+// Actions is a list of supported actions for when a rate limiter violation is
+// detected.
+var Actions = []string{
+	string(fastly.ERLActionLogOnly),
+	string(fastly.ERLActionResponse),
+}
+
+// WindowSizes is a list of supported time windows (in seconds) used to
+// calculate the client's request rate.
+var WindowSizes = []string{"1", "10", "60"}
+
+// NewCreateCommand returns a usable command registered under the parent.
+func NewCreateCommand(parent cmd.Registerer, globals *config.Data, data manifest.Data) *CreateCommand {
+	var c CreateCommand
+	c.CmdClause = parent.Command("create", "Create a rate limiter on a Fastly service version").Alias("add")
+	c.Globals = globals
+	c.manifest = data
+
+	// Required flags
+	c.CmdClause.Flag("action", "The action to take when a rate limiter violation is detected").Required().HintOptions(Actions...).EnumVar(&c.action, Actions...)
+	c.CmdClause.Flag("client-key", "A comma-separated list of VCL variables used to generate a counter key to identify a client (e.g. req.http.Fastly-Client-IP)").Required().StringsVar(&c.clientKey, kingpin.Separator(","))
+	c.CmdClause.Flag("http-methods", "A comma-separated list of HTTP methods to apply rate limiting to (e.g. GET,POST)").Required().StringsVar(&c.httpMethods, kingpin.Separator(","))
+	c.CmdClause.Flag("name", "A human readable name for the rate limiting rule").Short('n').Required().StringVar(&c.name)
+	c.CmdClause.Flag("penalty-box-duration", "Length of time in minutes that the rate limiter is in effect after the initial violation is detected (1-60)").Required().IntVar(&c.penaltyBoxDuration)
+	c.CmdClause.Flag("rps-limit", "Upper limit of requests per second allowed by the rate limiter (10-10000)").Required().IntVar(&c.rpsLimit)
+	c.RegisterFlag(cmd.StringFlagOpts{
+		Name:        cmd.FlagVersionName,
+		Description: cmd.FlagVersionDesc,
+		Dst:         &c.serviceVersion.Value,
+		Required:    true,
+	})
+	c.CmdClause.Flag("window-size", "Number of seconds during which the RPS limit must be exceeded in order to trigger a violation").Required().HintOptions(WindowSizes...).EnumVar(&c.windowSize, WindowSizes...)
+
+	// Optional flags
+	c.RegisterAutoCloneFlag(cmd.AutoCloneFlagOpts{
+		Action: c.autoClone.Set,
+		Dst:    &c.autoClone.Value,
+	})
+	c.CmdClause.Flag("response-content", "HTTP response body to send when --action=response").StringVar(&c.responseContent)
+	c.CmdClause.Flag("response-content-type", "HTTP Content-Type to send when --action=response (e.g. application/json)").StringVar(&c.responseContentType)
+	c.CmdClause.Flag("response-status", "HTTP status code to send when --action=response (e.g. 429)").IntVar(&c.responseStatus)
+	c.RegisterFlag(cmd.StringFlagOpts{
+		Name:        cmd.FlagServiceIDName,
+		Description: cmd.FlagServiceIDDesc,
+		Dst:         &c.manifest.Flag.ServiceID,
+		Short:       's',
+	})
+	c.RegisterFlag(cmd.StringFlagOpts{
+		Action:      c.serviceName.Set,
+		Name:        cmd.FlagServiceName,
+		Description: cmd.FlagServiceDesc,
+		Dst:         &c.serviceName.Value,
+	})
+	c.RegisterExamples(cmd.Example{
+		Cmd:   "fastly rate-limit create --name api --action log_only --client-key req.http.Fastly-Client-IP --http-methods GET,POST --penalty-box-duration 5 --rps-limit 100 --window-size 10 --version latest --autoclone",
+		Title: "Log clients that send more than 100 GET or POST requests per second",
+	})
+
+	return &c
+}
+
+// CreateCommand calls the Fastly API to create an appropriate resource.
+type CreateCommand struct {
+	cmd.Base
+
+	action              string
+	autoClone           cmd.OptionalAutoClone
+	clientKey           []string
+	httpMethods         []string
+	manifest            manifest.Data
+	name                string
+	penaltyBoxDuration  int
+	responseContent     string
+	responseContentType string
+	responseStatus      int
+	rpsLimit            int
+	serviceName         cmd.OptionalServiceNameID
+	serviceVersion      cmd.OptionalServiceVersion
+	windowSize          string
+}
+
+// Exec invokes the application logic for the command.
+func (c *CreateCommand) Exec(_ io.Reader, out io.Writer) error {
+	if c.action == string(fastly.ERLActionResponse) && c.responseStatus == 0 {
+		return errors.RemediationError{
+			Inner:       fmt.Errorf("--response-status is required when --action=%s", c.action),
+			Remediation: "Provide the HTTP response details to send to rate limited clients (e.g. --response-status 429 --response-content-type text/plain --response-content 'Too many requests').",
+		}
+	}
+
+	serviceID, serviceVersion, err := cmd.ServiceDetails(cmd.ServiceDetailsOpts{
+		AutoCloneFlag:      c.autoClone,
+		APIClient:          c.Globals.APIClient,
+		Manifest:           c.manifest,
+		Out:                out,
+		ServiceNameFlag:    c.serviceName,
+		ServiceVersionFlag: c.serviceVersion,
+		VerboseMode:        c.Globals.Flag.Verbose,
+	})
+	if err != nil {
+		c.Globals.ErrLog.AddWithContext(err, map[string]any{
+			"Service ID":      serviceID,
+			"Service Version": errors.ServiceVersion(serviceVersion),
+		})
+		return err
+	}
+
+	input := c.constructInput(serviceID, serviceVersion.Number)
+
+	r, err := c.Globals.APIClient.CreateERL(input)
+	if err != nil {
+		c.Globals.ErrLog.AddWithContext(err, map[string]any{
+			"Service ID":      serviceID,
+			"Service Version": serviceVersion.Number,
+		})
+		return err
+	}
+
+	text.Success(out, "Created rate limiter '%s' (id: %s, service: %s, version: %d)", r.Name, r.ID, r.ServiceId, r.Version)
+	return nil
+}
+
+// constructInput transforms values parsed from CLI flags into an object to be used by the API client library.
+func (c *CreateCommand) constructInput(serviceID string, serviceVersion int) *fastly.CreateERLInput {
+	var input fastly.CreateERLInput
+
+	input.Action = fastly.ERLAction(c.action)
+	input.ClientKey = c.clientKey
+	input.HttpMethods = c.httpMethods
+	input.Name = c.name
+	input.PenaltyBoxDuration = c.penaltyBoxDuration
+	input.RpsLimit = c.rpsLimit
+	input.ServiceID = serviceID
+	input.ServiceVersion = serviceVersion
+
+	// NOTE: The enum flag guarantees the value is a valid integer.
+	ws, _ := strconv.Atoi(c.windowSize)
+	input.WindowSize = fastly.ERLWindowSize(ws)
+
+	if c.responseStatus > 0 || c.responseContentType != "" || c.responseContent != "" {
+		input.Response = &fastly.ERLResponseType{
+			ERLStatus:      c.responseStatus,
+			ERLContentType: c.responseContentType,
+			ERLContent:     c.responseContent,
+		}
+	}
+
+	return &input
+}
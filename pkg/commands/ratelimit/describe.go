@@ -0,0 +1,171 @@
+package ratelimit
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/fastly/cli/pkg/cmd"
+	"github.com/fastly/cli/pkg/config"
+	fsterr "github.com/fastly/cli/pkg/errors"
+	"github.com/fastly/cli/pkg/manifest"
+	"github.com/fastly/go-fastly/v6/fastly"
+)
+
+// NewDescribeCommand returns a usable command registered under the parent.
+func NewDescribeCommand(parent cmd.Registerer, globals *config.Data, data manifest.Data) *DescribeCommand {
+	var c DescribeCommand
+	c.CmdClause = parent.Command("describe", "Show detailed information about a rate limiter on a Fastly service version").Alias("get")
+	c.Globals = globals
+	c.manifest = data
+
+	// Required flags
+	c.CmdClause.Flag("id", "Alphanumeric string identifying the rate limiter").Required().StringVar(&c.id)
+	c.RegisterFlag(cmd.StringFlagOpts{
+		Name:        cmd.FlagVersionName,
+		Description: cmd.FlagVersionDesc,
+		Dst:         &c.serviceVersion.Value,
+		Required:    true,
+	})
+
+	// Optional flags
+	c.RegisterFlagBool(cmd.BoolFlagOpts{
+		Name:        cmd.FlagJSONName,
+		Description: cmd.FlagJSONDesc,
+		Dst:         &c.output.JSON,
+		Short:       'j',
+	})
+	c.RegisterOutputFlags(&c.output)
+	c.RegisterFlag(cmd.StringFlagOpts{
+		Name:        cmd.FlagServiceIDName,
+		Description: cmd.FlagServiceIDDesc,
+		Dst:         &c.manifest.Flag.ServiceID,
+		Short:       's',
+	})
+	c.RegisterFlag(cmd.StringFlagOpts{
+		Action:      c.serviceName.Set,
+		Name:        cmd.FlagServiceName,
+		Description: cmd.FlagServiceDesc,
+		Dst:         &c.serviceName.Value,
+	})
+
+	return &c
+}
+
+// DescribeCommand calls the Fastly API to describe an appropriate resource.
+type DescribeCommand struct {
+	cmd.Base
+
+	id             string
+	output         cmd.Output
+	manifest       manifest.Data
+	serviceName    cmd.OptionalServiceNameID
+	serviceVersion cmd.OptionalServiceVersion
+}
+
+// Exec invokes the application logic for the command.
+func (c *DescribeCommand) Exec(_ io.Reader, out io.Writer) (err error) {
+	if c.Globals.Verbose() && c.output.Structured() {
+		return fsterr.ErrInvalidVerboseJSONCombo
+	}
+
+	out, closeOutput, err := c.output.Writer(out)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err = closeOutput(err)
+	}()
+
+	serviceID, serviceVersion, err := cmd.ServiceDetails(cmd.ServiceDetailsOpts{
+		AllowActiveLocked:  true,
+		APIClient:          c.Globals.APIClient,
+		Manifest:           c.manifest,
+		Out:                out,
+		ServiceNameFlag:    c.serviceName,
+		ServiceVersionFlag: c.serviceVersion,
+		VerboseMode:        c.Globals.Flag.Verbose,
+	})
+	if err != nil {
+		c.Globals.ErrLog.AddWithContext(err, map[string]any{
+			"Service ID":      serviceID,
+			"Service Version": fsterr.ServiceVersion(serviceVersion),
+		})
+		return err
+	}
+
+	input := c.constructInput(serviceID, serviceVersion.Number)
+
+	r, err := c.Globals.APIClient.GetERL(input)
+	if err != nil {
+		c.Globals.ErrLog.AddWithContext(err, map[string]any{
+			"Rate Limiter ID": c.id,
+			"Service ID":      serviceID,
+			"Service Version": serviceVersion.Number,
+		})
+		return err
+	}
+
+	return c.print(out, r)
+}
+
+// constructInput transforms values parsed from CLI flags into an object to be used by the API client library.
+func (c *DescribeCommand) constructInput(serviceID string, serviceVersion int) *fastly.GetERLInput {
+	var input fastly.GetERLInput
+
+	input.ERLID = c.id
+	input.ServiceID = serviceID
+	input.ServiceVersion = serviceVersion
+
+	return &input
+}
+
+// print displays the information returned from the API.
+func (c *DescribeCommand) print(out io.Writer, r *fastly.ERL) error {
+	if c.output.Structured() {
+		if err := c.output.Write(out, r); err != nil {
+			c.Globals.ErrLog.Add(err)
+			return err
+		}
+		return nil
+	}
+
+	fmt.Fprintln(out)
+	printERL(out, "", r)
+	return nil
+}
+
+// printERL displays all the available information for a rate limiter.
+func printERL(out io.Writer, indent string, r *fastly.ERL) {
+	fmt.Fprintf(out, "%sID: %s\n", indent, r.ID)
+	fmt.Fprintf(out, "%sName: %s\n", indent, r.Name)
+	fmt.Fprintf(out, "%sService ID: %s\n", indent, r.ServiceId)
+	fmt.Fprintf(out, "%sVersion: %d\n", indent, r.Version)
+	fmt.Fprintf(out, "%sAction: %s\n", indent, r.Action)
+	fmt.Fprintf(out, "%sClient Key: %s\n", indent, strings.Join(r.ClientKey, ", "))
+	fmt.Fprintf(out, "%sHTTP Methods: %s\n", indent, strings.Join(r.HttpMethods, ", "))
+	fmt.Fprintf(out, "%sRPS Limit: %d\n", indent, r.RpsLimit)
+	fmt.Fprintf(out, "%sWindow Size: %d\n", indent, r.WindowSize)
+	fmt.Fprintf(out, "%sPenalty Box Duration: %d\n", indent, r.PenaltyBoxDuration)
+	if r.Response != nil {
+		fmt.Fprintf(out, "%sResponse:\n", indent)
+		fmt.Fprintf(out, "%s\tStatus: %d\n", indent, r.Response.ERLStatus)
+		fmt.Fprintf(out, "%s\tContent Type: %s\n", indent, r.Response.ERLContentType)
+		fmt.Fprintf(out, "%s\tContent: %s\n", indent, r.Response.ERLContent)
+	}
+	if r.ResponseObjectName != "" {
+		fmt.Fprintf(out, "%sResponse Object Name: %s\n", indent, r.ResponseObjectName)
+	}
+	if r.LoggerType != "" {
+		fmt.Fprintf(out, "%sLogger Type: %s\n", indent, r.LoggerType)
+	}
+	if r.CreatedAt != nil {
+		fmt.Fprintf(out, "%sCreated at: %s\n", indent, r.CreatedAt)
+	}
+	if r.UpdatedAt != nil {
+		fmt.Fprintf(out, "%sUpdated at: %s\n", indent, r.UpdatedAt)
+	}
+	if r.DeletedAt != nil {
+		fmt.Fprintf(out, "%sDeleted at: %s\n", indent, r.DeletedAt)
+	}
+}
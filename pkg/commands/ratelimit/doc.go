@@ -0,0 +1,3 @@
+// Package ratelimit contains commands to inspect and manipulate Fastly edge
+// rate limiters.
+package ratelimit
@@ -0,0 +1,291 @@
+package ratelimit_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/fastly/cli/pkg/app"
+	"github.com/fastly/cli/pkg/mock"
+	"github.com/fastly/cli/pkg/testutil"
+	"github.com/fastly/go-fastly/v6/fastly"
+)
+
+const (
+	validateAPIError      = "validate API error"
+	validateAPISuccess    = "validate API success"
+	validateMissingIDFlag = "validate missing --id flag"
+)
+
+const createFlags = "--action log_only --client-key req.http.Fastly-Client-IP --http-methods GET,POST --name example --penalty-box-duration 5 --rps-limit 100 --window-size 10"
+
+func TestCreate(t *testing.T) {
+	args := testutil.Args
+	scenarios := []testutil.TestScenario{
+		{
+			Name:      "validate missing --name flag",
+			Args:      args("rate-limit create --service-id 123 --version 1 --action log_only --client-key req.http.Fastly-Client-IP --http-methods GET --penalty-box-duration 5 --rps-limit 100 --window-size 10"),
+			WantError: "error parsing arguments: required flag --name not provided",
+		},
+		{
+			Name:      "validate invalid --window-size flag",
+			Args:      args("rate-limit create --service-id 123 --version 1 --action log_only --client-key req.http.Fastly-Client-IP --http-methods GET --name example --penalty-box-duration 5 --rps-limit 100 --window-size 5"),
+			WantError: "enum value must be one of 1,10,60, got '5'",
+		},
+		{
+			Name:      "validate invalid --action flag",
+			Args:      args("rate-limit create --service-id 123 --version 1 --action response_object --client-key req.http.Fastly-Client-IP --http-methods GET --name example --penalty-box-duration 5 --rps-limit 100 --window-size 10"),
+			WantError: "enum value must be one of log_only,response, got 'response_object'",
+		},
+		{
+			Name:      "validate --response-status required for response action",
+			Args:      args("rate-limit create --service-id 123 --version 1 --action response --client-key req.http.Fastly-Client-IP --http-methods GET --name example --penalty-box-duration 5 --rps-limit 100 --window-size 10"),
+			WantError: "--response-status is required when --action=response",
+		},
+		{
+			Name: validateAPIError,
+			API: mock.API{
+				ListVersionsFn: testutil.ListVersions,
+				CloneVersionFn: testutil.CloneVersionResult(4),
+				CreateERLFn: func(_ *fastly.CreateERLInput) (*fastly.ERL, error) {
+					return nil, testutil.Err
+				},
+			},
+			Args:      args("rate-limit create --service-id 123 --version 1 --autoclone " + createFlags),
+			WantError: testutil.Err.Error(),
+		},
+		{
+			Name: validateAPISuccess,
+			API: mock.API{
+				ListVersionsFn: testutil.ListVersions,
+				CloneVersionFn: testutil.CloneVersionResult(4),
+				CreateERLFn: func(i *fastly.CreateERLInput) (*fastly.ERL, error) {
+					if i.WindowSize != fastly.ERLSize10 || len(i.HttpMethods) != 2 {
+						return nil, testutil.Err
+					}
+					return &fastly.ERL{
+						ID:        "abc",
+						Name:      i.Name,
+						ServiceId: i.ServiceID,
+						Version:   i.ServiceVersion,
+					}, nil
+				},
+			},
+			Args:       args("rate-limit create --service-id 123 --version 1 --autoclone " + createFlags),
+			WantOutput: "Created rate limiter 'example' (id: abc, service: 123, version: 4)",
+		},
+	}
+
+	testutil.RunScenarios(t, scenarios)
+}
+
+func TestDelete(t *testing.T) {
+	args := testutil.Args
+	scenarios := []testutil.TestScenario{
+		{
+			Name:      validateMissingIDFlag,
+			Args:      args("rate-limit delete --service-id 123 --version 1"),
+			WantError: "error parsing arguments: required flag --id not provided",
+		},
+		{
+			Name: validateAPIError,
+			API: mock.API{
+				ListVersionsFn: testutil.ListVersions,
+				CloneVersionFn: testutil.CloneVersionResult(4),
+				DeleteERLFn: func(_ *fastly.DeleteERLInput) error {
+					return testutil.Err
+				},
+			},
+			Args:      args("rate-limit delete --service-id 123 --version 1 --autoclone --id abc"),
+			WantError: testutil.Err.Error(),
+		},
+		{
+			Name: validateAPISuccess,
+			API: mock.API{
+				ListVersionsFn: testutil.ListVersions,
+				CloneVersionFn: testutil.CloneVersionResult(4),
+				DeleteERLFn: func(_ *fastly.DeleteERLInput) error {
+					return nil
+				},
+			},
+			Args:       args("rate-limit delete --service-id 123 --version 1 --autoclone --id abc"),
+			WantOutput: "Deleted rate limiter 'abc' (service: 123, version: 4)",
+		},
+	}
+
+	testutil.RunScenarios(t, scenarios)
+}
+
+func TestDescribe(t *testing.T) {
+	args := testutil.Args
+	scenarios := []testutil.TestScenario{
+		{
+			Name:      validateMissingIDFlag,
+			Args:      args("rate-limit describe --service-id 123 --version 1"),
+			WantError: "error parsing arguments: required flag --id not provided",
+		},
+		{
+			Name: validateAPIError,
+			API: mock.API{
+				ListVersionsFn: testutil.ListVersions,
+				GetERLFn: func(_ *fastly.GetERLInput) (*fastly.ERL, error) {
+					return nil, testutil.Err
+				},
+			},
+			Args:      args("rate-limit describe --service-id 123 --version 1 --id abc"),
+			WantError: testutil.Err.Error(),
+		},
+		{
+			Name: validateAPISuccess,
+			API: mock.API{
+				ListVersionsFn: testutil.ListVersions,
+				GetERLFn:       getERL,
+			},
+			Args:       args("rate-limit describe --service-id 123 --version 1 --id abc"),
+			WantOutput: describeOutput,
+		},
+	}
+
+	for testcaseIdx := range scenarios {
+		testcase := &scenarios[testcaseIdx]
+		t.Run(testcase.Name, func(t *testing.T) {
+			var stdout bytes.Buffer
+			opts := testutil.NewRunOpts(testcase.Args, &stdout)
+			opts.APIClient = mock.APIClient(testcase.API)
+			err := app.Run(opts)
+			testutil.AssertErrorContains(t, err, testcase.WantError)
+			testutil.AssertString(t, testcase.WantOutput, stdout.String())
+		})
+	}
+}
+
+func TestList(t *testing.T) {
+	args := testutil.Args
+	scenarios := []testutil.TestScenario{
+		{
+			Name: validateAPIError,
+			API: mock.API{
+				ListVersionsFn: testutil.ListVersions,
+				ListERLsFn: func(_ *fastly.ListERLsInput) ([]*fastly.ERL, error) {
+					return nil, testutil.Err
+				},
+			},
+			Args:      args("rate-limit list --service-id 123 --version 1"),
+			WantError: testutil.Err.Error(),
+		},
+		{
+			Name: validateAPISuccess,
+			API: mock.API{
+				ListVersionsFn: testutil.ListVersions,
+				ListERLsFn: func(i *fastly.ListERLsInput) ([]*fastly.ERL, error) {
+					r, _ := getERL(&fastly.GetERLInput{ERLID: "abc", ServiceID: i.ServiceID, ServiceVersion: i.ServiceVersion})
+					return []*fastly.ERL{r}, nil
+				},
+			},
+			Args:       args("rate-limit list --service-id 123 --version 1"),
+			WantOutput: listOutput,
+		},
+	}
+
+	for testcaseIdx := range scenarios {
+		testcase := &scenarios[testcaseIdx]
+		t.Run(testcase.Name, func(t *testing.T) {
+			var stdout bytes.Buffer
+			opts := testutil.NewRunOpts(testcase.Args, &stdout)
+			opts.APIClient = mock.APIClient(testcase.API)
+			err := app.Run(opts)
+			testutil.AssertErrorContains(t, err, testcase.WantError)
+			testutil.AssertString(t, testcase.WantOutput, stdout.String())
+		})
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	args := testutil.Args
+	scenarios := []testutil.TestScenario{
+		{
+			Name:      validateMissingIDFlag,
+			Args:      args("rate-limit update --service-id 123 --version 1"),
+			WantError: "error parsing arguments: required flag --id not provided",
+		},
+		{
+			Name:      "validate --response-status required for response action",
+			Args:      args("rate-limit update --service-id 123 --version 1 --id abc --action response"),
+			WantError: "--response-status is required when --action=response",
+		},
+		{
+			Name: validateAPIError,
+			API: mock.API{
+				ListVersionsFn: testutil.ListVersions,
+				CloneVersionFn: testutil.CloneVersionResult(4),
+				UpdateERLFn: func(_ *fastly.UpdateERLInput) (*fastly.ERL, error) {
+					return nil, testutil.Err
+				},
+			},
+			Args:      args("rate-limit update --service-id 123 --version 1 --autoclone --id abc --rps-limit 200"),
+			WantError: testutil.Err.Error(),
+		},
+		{
+			Name: validateAPISuccess,
+			API: mock.API{
+				ListVersionsFn: testutil.ListVersions,
+				CloneVersionFn: testutil.CloneVersionResult(4),
+				UpdateERLFn: func(i *fastly.UpdateERLInput) (*fastly.ERL, error) {
+					if i.RpsLimit != 200 || i.Name != "" {
+						return nil, testutil.Err
+					}
+					return &fastly.ERL{
+						ID:        i.ID,
+						Name:      "example",
+						ServiceId: i.ServiceID,
+						Version:   i.ServiceVersion,
+					}, nil
+				},
+			},
+			Args:       args("rate-limit update --service-id 123 --version 1 --autoclone --id abc --rps-limit 200"),
+			WantOutput: "Updated rate limiter 'example' (id: abc, service: 123, version: 4)",
+		},
+	}
+
+	testutil.RunScenarios(t, scenarios)
+}
+
+func getERL(i *fastly.GetERLInput) (*fastly.ERL, error) {
+	return &fastly.ERL{
+		Action:             fastly.ERLActionResponse,
+		ClientKey:          []string{"req.http.Fastly-Client-IP"},
+		HttpMethods:        []string{"GET", "POST"},
+		ID:                 i.ERLID,
+		Name:               "example",
+		PenaltyBoxDuration: 5,
+		Response: &fastly.ERLResponseType{
+			ERLStatus:      429,
+			ERLContentType: "text/plain",
+			ERLContent:     "Too many requests",
+		},
+		RpsLimit:   100,
+		ServiceId:  i.ServiceID,
+		Version:    i.ServiceVersion,
+		WindowSize: fastly.ERLSize10,
+	}, nil
+}
+
+var describeOutput = `
+ID: abc
+Name: example
+Service ID: 123
+Version: 1
+Action: response
+Client Key: req.http.Fastly-Client-IP
+HTTP Methods: GET, POST
+RPS Limit: 100
+Window Size: 10
+Penalty Box Duration: 5
+Response:
+	Status: 429
+	Content Type: text/plain
+	Content: Too many requests
+`
+
+var listOutput = `ID   NAME     ACTION    RPS LIMIT  WINDOW SIZE  PENALTY BOX DURATION
+abc  example  response  100        10           5
+`
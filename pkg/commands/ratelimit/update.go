@@ -0,0 +1,167 @@
+package ratelimit
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/fastly/cli/pkg/cmd"
+	"github.com/fastly/cli/pkg/config"
+	"github.com/fastly/cli/pkg/errors"
+	"github.com/fastly/cli/pkg/manifest"
+	"github.com/fastly/cli/pkg/text"
+	"github.com/fastly/go-fastly/v6/fastly"
+	"github.com/fastly/kingpin"
+)
+
+// NewUpdateCommand returns a usable command registered under the parent.
+func NewUpdateCommand(parent cmd.Registerer, globals *config.Data, data manifest.Data) *UpdateCommand {
+	var c UpdateCommand
+	c.CmdClause = parent.Command("update", "Update a rate limiter on a Fastly service version")
+	c.Globals = globals
+	c.manifest = data
+
+	// Required flags
+	c.CmdClause.Flag("id", "Alphanumeric string identifying the rate limiter").Required().StringVar(&c.id)
+	c.RegisterFlag(cmd.StringFlagOpts{
+		Name:        cmd.FlagVersionName,
+		Description: cmd.FlagVersionDesc,
+		Dst:         &c.serviceVersion.Value,
+		Required:    true,
+	})
+
+	// Optional flags
+	c.CmdClause.Flag("action", "The action to take when a rate limiter violation is detected").Action(c.action.Set).HintOptions(Actions...).EnumVar(&c.action.Value, Actions...)
+	c.RegisterAutoCloneFlag(cmd.AutoCloneFlagOpts{
+		Action: c.autoClone.Set,
+		Dst:    &c.autoClone.Value,
+	})
+	c.CmdClause.Flag("client-key", "A comma-separated list of VCL variables used to generate a counter key to identify a client (e.g. req.http.Fastly-Client-IP)").Action(c.clientKey.Set).StringsVar(&c.clientKey.Value, kingpin.Separator(","))
+	c.CmdClause.Flag("http-methods", "A comma-separated list of HTTP methods to apply rate limiting to (e.g. GET,POST)").Action(c.httpMethods.Set).StringsVar(&c.httpMethods.Value, kingpin.Separator(","))
+	c.CmdClause.Flag("name", "A human readable name for the rate limiting rule").Short('n').Action(c.name.Set).StringVar(&c.name.Value)
+	c.CmdClause.Flag("penalty-box-duration", "Length of time in minutes that the rate limiter is in effect after the initial violation is detected (1-60)").Action(c.penaltyBoxDuration.Set).IntVar(&c.penaltyBoxDuration.Value)
+	c.CmdClause.Flag("response-content", "HTTP response body to send when --action=response").Action(c.responseContent.Set).StringVar(&c.responseContent.Value)
+	c.CmdClause.Flag("response-content-type", "HTTP Content-Type to send when --action=response (e.g. application/json)").Action(c.responseContentType.Set).StringVar(&c.responseContentType.Value)
+	c.CmdClause.Flag("response-status", "HTTP status code to send when --action=response (e.g. 429)").Action(c.responseStatus.Set).IntVar(&c.responseStatus.Value)
+	c.CmdClause.Flag("rps-limit", "Upper limit of requests per second allowed by the rate limiter (10-10000)").Action(c.rpsLimit.Set).IntVar(&c.rpsLimit.Value)
+	c.RegisterFlag(cmd.StringFlagOpts{
+		Name:        cmd.FlagServiceIDName,
+		Description: cmd.FlagServiceIDDesc,
+		Dst:         &c.manifest.Flag.ServiceID,
+		Short:       's',
+	})
+	c.RegisterFlag(cmd.StringFlagOpts{
+		Action:      c.serviceName.Set,
+		Name:        cmd.FlagServiceName,
+		Description: cmd.FlagServiceDesc,
+		Dst:         &c.serviceName.Value,
+	})
+	c.CmdClause.Flag("window-size", "Number of seconds during which the RPS limit must be exceeded in order to trigger a violation").Action(c.windowSize.Set).HintOptions(WindowSizes...).EnumVar(&c.windowSize.Value, WindowSizes...)
+
+	return &c
+}
+
+// UpdateCommand calls the Fastly API to update an appropriate resource.
+type UpdateCommand struct {
+	cmd.Base
+
+	action              cmd.OptionalString
+	autoClone           cmd.OptionalAutoClone
+	clientKey           cmd.OptionalStringSlice
+	httpMethods         cmd.OptionalStringSlice
+	id                  string
+	manifest            manifest.Data
+	name                cmd.OptionalString
+	penaltyBoxDuration  cmd.OptionalInt
+	responseContent     cmd.OptionalString
+	responseContentType cmd.OptionalString
+	responseStatus      cmd.OptionalInt
+	rpsLimit            cmd.OptionalInt
+	serviceName         cmd.OptionalServiceNameID
+	serviceVersion      cmd.OptionalServiceVersion
+	windowSize          cmd.OptionalString
+}
+
+// Exec invokes the application logic for the command.
+func (c *UpdateCommand) Exec(_ io.Reader, out io.Writer) error {
+	if c.action.WasSet && c.action.Value == string(fastly.ERLActionResponse) && !c.responseStatus.WasSet {
+		return errors.RemediationError{
+			Inner:       fmt.Errorf("--response-status is required when --action=%s", c.action.Value),
+			Remediation: "Provide the HTTP response details to send to rate limited clients (e.g. --response-status 429 --response-content-type text/plain --response-content 'Too many requests').",
+		}
+	}
+
+	serviceID, serviceVersion, err := cmd.ServiceDetails(cmd.ServiceDetailsOpts{
+		AutoCloneFlag:      c.autoClone,
+		APIClient:          c.Globals.APIClient,
+		Manifest:           c.manifest,
+		Out:                out,
+		ServiceNameFlag:    c.serviceName,
+		ServiceVersionFlag: c.serviceVersion,
+		VerboseMode:        c.Globals.Flag.Verbose,
+	})
+	if err != nil {
+		c.Globals.ErrLog.AddWithContext(err, map[string]any{
+			"Service ID":      serviceID,
+			"Service Version": errors.ServiceVersion(serviceVersion),
+		})
+		return err
+	}
+
+	input := c.constructInput(serviceID, serviceVersion.Number)
+
+	r, err := c.Globals.APIClient.UpdateERL(input)
+	if err != nil {
+		c.Globals.ErrLog.AddWithContext(err, map[string]any{
+			"Rate Limiter ID": c.id,
+			"Service ID":      serviceID,
+			"Service Version": serviceVersion.Number,
+		})
+		return err
+	}
+
+	text.Success(out, "Updated rate limiter '%s' (id: %s, service: %s, version: %d)", r.Name, r.ID, r.ServiceId, r.Version)
+	return nil
+}
+
+// constructInput transforms values parsed from CLI flags into an object to be used by the API client library.
+func (c *UpdateCommand) constructInput(serviceID string, serviceVersion int) *fastly.UpdateERLInput {
+	var input fastly.UpdateERLInput
+
+	input.ID = c.id
+	input.ServiceID = serviceID
+	input.ServiceVersion = serviceVersion
+
+	if c.action.WasSet {
+		input.Action = fastly.ERLAction(c.action.Value)
+	}
+	if c.clientKey.WasSet {
+		input.ClientKey = c.clientKey.Value
+	}
+	if c.httpMethods.WasSet {
+		input.HttpMethods = c.httpMethods.Value
+	}
+	if c.name.WasSet {
+		input.Name = c.name.Value
+	}
+	if c.penaltyBoxDuration.WasSet {
+		input.PenaltyBoxDuration = c.penaltyBoxDuration.Value
+	}
+	if c.rpsLimit.WasSet {
+		input.RpsLimit = c.rpsLimit.Value
+	}
+	if c.windowSize.WasSet {
+		// NOTE: The enum flag guarantees the value is a valid integer.
+		ws, _ := strconv.Atoi(c.windowSize.Value)
+		input.WindowSize = fastly.ERLWindowSize(ws)
+	}
+	if c.responseStatus.WasSet || c.responseContentType.WasSet || c.responseContent.WasSet {
+		input.Response = &fastly.ERLResponseType{
+			ERLStatus:      c.responseStatus.Value,
+			ERLContentType: c.responseContentType.Value,
+			ERLContent:     c.responseContent.Value,
+		}
+	}
+
+	return &input
+}
@@ -0,0 +1,3 @@
+// Package resourcelink contains commands to inspect and manipulate the
+// resources (e.g. KV, config and secret stores) linked to a Fastly service.
+package resourcelink
@@ -0,0 +1,158 @@
+package resourcelink
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/fastly/cli/pkg/api/undocumented"
+	"github.com/fastly/cli/pkg/cmd"
+	"github.com/fastly/cli/pkg/config"
+	fsterr "github.com/fastly/cli/pkg/errors"
+	"github.com/fastly/cli/pkg/manifest"
+	"github.com/fastly/cli/pkg/text"
+)
+
+// NewListCommand returns a usable command registered under the parent.
+func NewListCommand(parent cmd.Registerer, globals *config.Data, data manifest.Data) *ListCommand {
+	var c ListCommand
+	c.CmdClause = parent.Command("list", "List the resources linked to a Fastly service version")
+	c.Globals = globals
+	c.manifest = data
+
+	// Required flags
+	c.RegisterFlag(cmd.StringFlagOpts{
+		Name:        cmd.FlagVersionName,
+		Description: cmd.FlagVersionDesc,
+		Dst:         &c.serviceVersion.Value,
+		Required:    true,
+	})
+
+	// Optional Flags
+	c.RegisterFlagBool(cmd.BoolFlagOpts{
+		Name:        cmd.FlagJSONName,
+		Description: cmd.FlagJSONDesc,
+		Dst:         &c.json,
+		Short:       'j',
+	})
+	c.RegisterFlag(cmd.StringFlagOpts{
+		Name:        cmd.FlagServiceIDName,
+		Description: cmd.FlagServiceIDDesc,
+		Dst:         &c.manifest.Flag.ServiceID,
+		Short:       's',
+	})
+	c.RegisterFlag(cmd.StringFlagOpts{
+		Action:      c.serviceName.Set,
+		Name:        cmd.FlagServiceName,
+		Description: cmd.FlagServiceDesc,
+		Dst:         &c.serviceName.Value,
+	})
+
+	return &c
+}
+
+// ListCommand calls the Fastly API to list appropriate resources.
+type ListCommand struct {
+	cmd.Base
+
+	json           bool
+	manifest       manifest.Data
+	serviceName    cmd.OptionalServiceNameID
+	serviceVersion cmd.OptionalServiceVersion
+}
+
+// Exec invokes the application logic for the command.
+func (c *ListCommand) Exec(_ io.Reader, out io.Writer) error {
+	if c.Globals.Verbose() && c.json {
+		return fsterr.ErrInvalidVerboseJSONCombo
+	}
+
+	serviceID, serviceVersion, err := cmd.ServiceDetails(cmd.ServiceDetailsOpts{
+		AllowActiveLocked:  true,
+		APIClient:          c.Globals.APIClient,
+		Manifest:           c.manifest,
+		Out:                out,
+		ServiceNameFlag:    c.serviceName,
+		ServiceVersionFlag: c.serviceVersion,
+		VerboseMode:        c.Globals.Flag.Verbose,
+	})
+	if err != nil {
+		c.Globals.ErrLog.AddWithContext(err, map[string]any{
+			"Service ID":      serviceID,
+			"Service Version": fsterr.ServiceVersion(serviceVersion),
+		})
+		return err
+	}
+
+	client, err := newClient(c.Globals)
+	if err != nil {
+		return err
+	}
+	input := undocumented.ResourceLinkInput{
+		ServiceID:      serviceID,
+		ServiceVersion: serviceVersion.Number,
+	}
+
+	rs, err := client.ListResourceLinks(input)
+	if err != nil {
+		c.Globals.ErrLog.AddWithContext(err, map[string]any{
+			"Service ID":      serviceID,
+			"Service Version": serviceVersion.Number,
+		})
+		return err
+	}
+
+	if c.Globals.Verbose() {
+		c.printVerbose(out, serviceVersion.Number, rs)
+		return nil
+	}
+	return c.printSummary(out, rs)
+}
+
+// printVerbose displays the information returned from the API in a verbose
+// format.
+func (c *ListCommand) printVerbose(out io.Writer, serviceVersion int, rs []*undocumented.ResourceLink) {
+	fmt.Fprintf(out, "Version: %d\n", serviceVersion)
+	for i, r := range rs {
+		fmt.Fprintf(out, "\tResource Link %d/%d\n", i+1, len(rs))
+		fmt.Fprintf(out, "\t\tID: %s\n", r.ID)
+		fmt.Fprintf(out, "\t\tName: %s\n", r.Name)
+		fmt.Fprintf(out, "\t\tResource ID: %s\n", r.ResourceID)
+		fmt.Fprintf(out, "\t\tResource Type: %s\n", r.ResourceType)
+		if r.CreatedAt != nil {
+			fmt.Fprintf(out, "\t\tCreated at: %s\n", r.CreatedAt)
+		}
+		if r.UpdatedAt != nil {
+			fmt.Fprintf(out, "\t\tUpdated at: %s\n", r.UpdatedAt)
+		}
+		if r.DeletedAt != nil {
+			fmt.Fprintf(out, "\t\tDeleted at: %s\n", r.DeletedAt)
+		}
+	}
+	fmt.Fprintln(out)
+}
+
+// printSummary displays the information returned from the API in a summarised
+// format.
+func (c *ListCommand) printSummary(out io.Writer, rs []*undocumented.ResourceLink) error {
+	if c.json {
+		data, err := json.Marshal(rs)
+		if err != nil {
+			return err
+		}
+		_, err = out.Write(data)
+		if err != nil {
+			c.Globals.ErrLog.Add(err)
+			return fmt.Errorf("error: unable to write data to stdout: %w", err)
+		}
+		return nil
+	}
+
+	t := text.NewTable(out)
+	t.AddHeader("ID", "NAME", "RESOURCE ID", "RESOURCE TYPE")
+	for _, r := range rs {
+		t.AddLine(r.ID, r.Name, r.ResourceID, r.ResourceType)
+	}
+	t.Print()
+	return nil
+}
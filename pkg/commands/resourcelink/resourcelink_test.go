@@ -0,0 +1,142 @@
+package resourcelink_test
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/fastly/cli/pkg/api"
+	"github.com/fastly/cli/pkg/app"
+	"github.com/fastly/cli/pkg/mock"
+	"github.com/fastly/cli/pkg/testutil"
+)
+
+func TestResourceLink(t *testing.T) {
+	args := testutil.Args
+	scenarios := []struct {
+		name       string
+		args       []string
+		client     api.HTTPClient
+		wantMethod string
+		wantPath   string
+		wantBody   string
+		wantError  string
+		wantOutput string
+	}{
+		{
+			name:      "validate missing --resource-id flag",
+			args:      args("resource-link create --service-id 123 --version 1"),
+			wantError: "error parsing arguments: required flag --resource-id not provided",
+		},
+		{
+			name:      "validate missing token",
+			args:      args("resource-link list --service-id 123 --version 1"),
+			client:    &recordingClient{},
+			wantError: "no token provided",
+		},
+		{
+			name:      "validate API error",
+			args:      args("resource-link create --token x --service-id 123 --version 1 --autoclone --resource-id abc"),
+			client:    &recordingClient{code: http.StatusNotFound},
+			wantError: "non-2xx response: 404 Not Found",
+		},
+		{
+			name:       "validate create",
+			args:       args("resource-link create --token x --service-id 123 --version 1 --autoclone --resource-id abc --name my_store"),
+			client:     &recordingClient{body: `{"id":"link1","name":"my_store","resource_id":"abc","service_id":"123","version":4}`},
+			wantMethod: http.MethodPost,
+			wantPath:   "/service/123/version/4/resource",
+			wantBody:   "name=my_store&resource_id=abc",
+			wantOutput: "Linked resource 'abc' as 'my_store' (id: link1, service: 123, version: 4)",
+		},
+		{
+			name:       "validate delete",
+			args:       args("resource-link delete --token x --service-id 123 --version 1 --autoclone --id link1"),
+			client:     &recordingClient{body: `{"status":"ok"}`},
+			wantMethod: http.MethodDelete,
+			wantPath:   "/service/123/version/4/resource/link1",
+			wantOutput: "Deleted resource link 'link1' (service: 123, version: 4)",
+		},
+		{
+			name:       "validate create with 201 Created and no body",
+			args:       args("resource-link create --token x --service-id 123 --version 1 --autoclone --resource-id abc --name my_store"),
+			client:     &recordingClient{code: http.StatusCreated},
+			wantMethod: http.MethodPost,
+			wantPath:   "/service/123/version/4/resource",
+			wantBody:   "name=my_store&resource_id=abc",
+			wantOutput: "Linked resource 'abc' as 'my_store' (service: 123, version: 4)",
+		},
+		{
+			name:       "validate delete with 204 No Content",
+			args:       args("resource-link delete --token x --service-id 123 --version 1 --autoclone --id link1"),
+			client:     &recordingClient{code: http.StatusNoContent},
+			wantMethod: http.MethodDelete,
+			wantPath:   "/service/123/version/4/resource/link1",
+			wantOutput: "Deleted resource link 'link1' (service: 123, version: 4)",
+		},
+		{
+			name:       "validate list",
+			args:       args("resource-link list --token x --service-id 123 --version 1"),
+			client:     &recordingClient{body: `[{"id":"link1","name":"my_store","resource_id":"abc","resource_type":"kv-store"}]`},
+			wantMethod: http.MethodGet,
+			wantPath:   "/service/123/version/1/resource",
+			wantOutput: listOutput,
+		},
+	}
+
+	for testcaseIdx := range scenarios {
+		testcase := &scenarios[testcaseIdx]
+		t.Run(testcase.name, func(t *testing.T) {
+			var stdout bytes.Buffer
+			opts := testutil.NewRunOpts(testcase.args, &stdout)
+			opts.APIClient = mock.APIClient(mock.API{
+				ListVersionsFn: testutil.ListVersions,
+				CloneVersionFn: testutil.CloneVersionResult(4),
+			})
+			if testcase.client != nil {
+				opts.HTTPClient = testcase.client
+			}
+			err := app.Run(opts)
+			testutil.AssertErrorContains(t, err, testcase.wantError)
+			testutil.AssertStringContains(t, stdout.String(), testcase.wantOutput)
+			if c, ok := testcase.client.(*recordingClient); ok && testcase.wantMethod != "" {
+				testutil.AssertString(t, testcase.wantMethod, c.method)
+				testutil.AssertString(t, testcase.wantPath, c.path)
+				testutil.AssertString(t, testcase.wantBody, c.reqBody)
+			}
+		})
+	}
+}
+
+// recordingClient records the request it receives and responds with the
+// configured status code and body.
+type recordingClient struct {
+	body string
+	code int
+
+	method  string
+	path    string
+	reqBody string
+}
+
+func (c *recordingClient) Do(req *http.Request) (*http.Response, error) {
+	c.method = req.Method
+	c.path = req.URL.Path
+	if req.Body != nil {
+		b, _ := io.ReadAll(req.Body)
+		c.reqBody = string(b)
+	}
+
+	rec := httptest.NewRecorder()
+	if c.code != 0 {
+		rec.WriteHeader(c.code)
+	}
+	rec.WriteString(c.body)
+	return rec.Result(), nil
+}
+
+var listOutput = `ID     NAME      RESOURCE ID  RESOURCE TYPE
+link1  my_store  abc          kv-store
+`
@@ -0,0 +1,3 @@
+// Package serveapi contains the command that exposes CLI operations over a
+// local JSON-RPC socket, for use by IDE extensions and other tooling.
+package serveapi
@@ -0,0 +1,27 @@
+//go:build !windows
+
+package serveapi
+
+import (
+	"errors"
+	"os"
+	"syscall"
+)
+
+// ownedByUser reports whether the file is owned by the current user.
+func ownedByUser(fi os.FileInfo) bool {
+	st, ok := fi.Sys().(*syscall.Stat_t)
+	return ok && int(st.Uid) == os.Getuid()
+}
+
+// checkPrivate returns an error unless the directory is owned by the current
+// user and inaccessible to anyone else.
+func checkPrivate(fi os.FileInfo) error {
+	if !ownedByUser(fi) {
+		return errors.New("isn't owned by the current user")
+	}
+	if fi.Mode().Perm()&0o077 != 0 {
+		return errors.New("is accessible by other users (its mode must be 0700)")
+	}
+	return nil
+}
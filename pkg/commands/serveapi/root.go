@@ -0,0 +1,172 @@
+package serveapi
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/rpc"
+	"net/rpc/jsonrpc"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"syscall"
+
+	"github.com/fastly/cli/pkg/cmd"
+	"github.com/fastly/cli/pkg/config"
+	fsterr "github.com/fastly/cli/pkg/errors"
+	"github.com/fastly/cli/pkg/text"
+)
+
+// DefaultSocket returns the socket the server listens on when --socket isn't
+// set, creating its directory if needed.
+//
+// NOTE: Requests made over the socket use the caller's token, so it's kept in
+// a directory that only the user can access: $XDG_RUNTIME_DIR when it's set,
+// and otherwise a directory next to the CLI config file.
+func DefaultSocket() (string, error) {
+	dir := os.Getenv("XDG_RUNTIME_DIR")
+	if dir == "" {
+		dir = filepath.Join(filepath.Dir(config.FilePath), "run")
+	}
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return "", err
+	}
+	fi, err := os.Stat(dir)
+	if err != nil {
+		return "", err
+	}
+	if err := checkPrivate(fi); err != nil {
+		return "", fmt.Errorf("'%s' %w", dir, err)
+	}
+	return filepath.Join(dir, "fastly-api.sock"), nil
+}
+
+// RootCommand serves the CLI's operations over a local JSON-RPC socket.
+type RootCommand struct {
+	cmd.Base
+
+	run    Runner
+	socket string
+}
+
+// NewRootCommand returns a new command registered in the parent.
+func NewRootCommand(parent cmd.Registerer, globals *config.Data, run Runner) *RootCommand {
+	var c RootCommand
+	c.Globals = globals
+	c.run = run
+	c.CmdClause = parent.Command("serve-api", "Serve CLI operations (build, deploy, purge, service describe) over a local JSON-RPC socket")
+	c.CmdClause.Flag("socket", "Path of the unix socket to listen on (defaults to fastly-api.sock in $XDG_RUNTIME_DIR, or in a private directory next to the CLI config file)").StringVar(&c.socket)
+	return &c
+}
+
+// Exec implements the command interface.
+func (c *RootCommand) Exec(_ io.Reader, out io.Writer) error {
+	// NOTE: An interrupt is the normal way to stop, so the command handles it
+	// rather than the CLI cleaning up after an interrupted command.
+	c.Globals.Interrupt.Release()
+	sigs := make(chan os.Signal, 1)
+	signal.Notify(sigs, syscall.SIGINT, syscall.SIGTERM)
+	defer signal.Stop(sigs)
+
+	if c.socket == "" {
+		socket, err := DefaultSocket()
+		if err != nil {
+			c.Globals.ErrLog.Add(err)
+			return fsterr.RemediationError{
+				Inner:       fmt.Errorf("error creating the socket directory: %w", err),
+				Remediation: "Set --socket to a path in a directory that only you can access.",
+			}
+		}
+		c.socket = socket
+	}
+
+	if err := removeStaleSocket(c.socket); err != nil {
+		return err
+	}
+
+	l, err := net.Listen("unix", c.socket)
+	if err != nil {
+		c.Globals.ErrLog.Add(err)
+		return fsterr.RemediationError{
+			Inner:       fmt.Errorf("error listening on socket '%s': %w", c.socket, err),
+			Remediation: "Check the --socket directory exists and is writable.",
+		}
+	}
+	defer os.Remove(c.socket)
+
+	// NOTE: The socket's mode otherwise depends on the umask, and anyone who can
+	// connect to it can make requests with the caller's token.
+	if err := os.Chmod(c.socket, 0o600); err != nil {
+		l.Close()
+		c.Globals.ErrLog.Add(err)
+		return fmt.Errorf("error restricting access to socket '%s': %w", c.socket, err)
+	}
+
+	server := rpc.NewServer()
+	if err := server.RegisterName("Fastly", NewService(c.run, GlobalArgs(c.Globals)...)); err != nil {
+		return err
+	}
+
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		select {
+		case <-sigs:
+			l.Close()
+		case <-done:
+		}
+	}()
+
+	text.Info(out, "Listening for JSON-RPC requests on %s (press Ctrl-C to stop)", c.socket)
+
+	for {
+		conn, err := l.Accept()
+		if err != nil {
+			if errors.Is(err, net.ErrClosed) {
+				return nil
+			}
+			c.Globals.ErrLog.Add(err)
+			return fmt.Errorf("error accepting connection: %w", err)
+		}
+		go server.ServeCodec(jsonrpc.NewServerCodec(conn))
+	}
+}
+
+// GlobalArgs returns the global flags that commands run on behalf of a client
+// should inherit from the server's own invocation.
+//
+// NOTE: --verbose isn't inherited as it can't be combined with --json.
+func GlobalArgs(g *config.Data) []string {
+	args := []string{"--non-interactive"}
+	if g.Flag.Profile != "" {
+		args = append(args, "--profile", g.Flag.Profile)
+	}
+	if g.Flag.Token != "" {
+		args = append(args, "--token", g.Flag.Token)
+	}
+	if g.Flag.Endpoint != "" {
+		args = append(args, "--endpoint", g.Flag.Endpoint)
+	}
+	return args
+}
+
+// removeStaleSocket removes a socket left behind by a server that didn't shut
+// down cleanly. Anything other than a socket owned by the user is left alone.
+func removeStaleSocket(path string) error {
+	fi, err := os.Lstat(path)
+	if err != nil {
+		return nil
+	}
+	if fi.Mode()&os.ModeSocket == 0 {
+		return fmt.Errorf("'%s' already exists and isn't a socket", path)
+	}
+	if !ownedByUser(fi) {
+		return fmt.Errorf("'%s' already exists and isn't owned by the current user", path)
+	}
+	if conn, err := net.Dial("unix", path); err == nil {
+		conn.Close()
+		return fmt.Errorf("a server is already listening on '%s'", path)
+	}
+	return os.Remove(path)
+}
@@ -0,0 +1,275 @@
+package serveapi_test
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/rpc"
+	"net/rpc/jsonrpc"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/fastly/cli/pkg/app"
+	"github.com/fastly/cli/pkg/commands/serveapi"
+	"github.com/fastly/cli/pkg/mock"
+	"github.com/fastly/cli/pkg/testutil"
+	"github.com/fastly/go-fastly/v6/fastly"
+)
+
+func TestService(t *testing.T) {
+	// NOTE: The directory is resolved as os.Getwd returns the real path, e.g.
+	// on macOS the temp dir is a symlink.
+	dir, err := filepath.EvalSymlinks(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var (
+		gotArgs []string
+		gotDir  string
+	)
+	run := func(args []string, out io.Writer) error {
+		gotArgs = args
+		gotDir, _ = os.Getwd()
+		switch args[len(args)-1] {
+		case "fail":
+			return errors.New("boom")
+		case "bad-json":
+			fmt.Fprint(out, "not json")
+			return nil
+		}
+		if args[3] == "describe" {
+			fmt.Fprint(out, `{"ID":"123"}`)
+			return nil
+		}
+		fmt.Fprint(out, "done")
+		return nil
+	}
+
+	client := newClient(t, serveapi.NewService(run, "--token", "abc"))
+
+	for _, testcase := range []struct {
+		name       string
+		method     string
+		args       any
+		wantArgs   string
+		wantDir    string
+		wantOutput string
+		wantData   string
+		wantError  string
+	}{
+		{
+			name:       "build",
+			method:     "Fastly.Build",
+			args:       serveapi.BuildArgs{Dir: dir, IncludeSource: true, Language: "rust"},
+			wantArgs:   "--token abc compute build --include-source --language rust",
+			wantDir:    dir,
+			wantOutput: "done",
+		},
+		{
+			name:       "deploy",
+			method:     "Fastly.Deploy",
+			args:       serveapi.DeployArgs{Comment: "hello world", ServiceID: "123"},
+			wantArgs:   "--token abc compute deploy --comment hello world --service-id 123",
+			wantDir:    wd,
+			wantOutput: "done",
+		},
+		{
+			name:       "purge",
+			method:     "Fastly.Purge",
+			args:       serveapi.PurgeArgs{Key: "foo", ServiceID: "123", Soft: true},
+			wantArgs:   "--token abc purge --key foo --service-id 123 --soft",
+			wantDir:    wd,
+			wantOutput: "done",
+		},
+		{
+			name:     "service describe",
+			method:   "Fastly.ServiceDescribe",
+			args:     serveapi.ServiceArgs{ServiceID: "123"},
+			wantArgs: "--token abc service describe --json --service-id 123",
+			wantDir:  wd,
+			wantData: `{"ID":"123"}`,
+		},
+		{
+			name:      "command error",
+			method:    "Fastly.ServiceDescribe",
+			args:      serveapi.ServiceArgs{ServiceID: "fail"},
+			wantArgs:  "--token abc service describe --json --service-id fail",
+			wantDir:   wd,
+			wantError: "boom",
+		},
+		{
+			name:      "invalid json",
+			method:    "Fastly.ServiceDescribe",
+			args:      serveapi.ServiceArgs{ServiceID: "bad-json"},
+			wantArgs:  "--token abc service describe --json --service-id bad-json",
+			wantDir:   wd,
+			wantError: "command output was not valid JSON",
+		},
+	} {
+		t.Run(testcase.name, func(t *testing.T) {
+			var reply serveapi.Result
+			err := client.Call(testcase.method, testcase.args, &reply)
+			testutil.AssertErrorContains(t, err, testcase.wantError)
+			testutil.AssertString(t, testcase.wantArgs, strings.Join(gotArgs, " "))
+			testutil.AssertString(t, testcase.wantDir, gotDir)
+			testutil.AssertString(t, testcase.wantOutput, reply.Output)
+			testutil.AssertString(t, testcase.wantData, string(reply.Data))
+
+			// The working directory must be restored after each request.
+			got, _ := os.Getwd()
+			testutil.AssertString(t, wd, got)
+		})
+	}
+}
+
+func TestServeAPI(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("test relies on unix sockets and signals")
+	}
+
+	socket := filepath.Join(t.TempDir(), "api.sock")
+
+	var stdout bytes.Buffer
+	opts := testutil.NewRunOpts(testutil.Args("serve-api --token 123 --socket "+socket), &stdout)
+	opts.APIClient = mock.APIClient(mock.API{
+		GetServiceDetailsFn: func(i *fastly.GetServiceInput) (*fastly.ServiceDetail, error) {
+			return &fastly.ServiceDetail{ID: i.ID, Name: "Foo"}, nil
+		},
+	})
+
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- app.Run(opts)
+	}()
+
+	var conn net.Conn
+	for i := 0; i < 100; i++ {
+		var err error
+		if conn, err = net.Dial("unix", socket); err == nil {
+			break
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+	if conn == nil {
+		t.Fatal("server didn't start listening")
+	}
+	client := jsonrpc.NewClient(conn)
+	defer client.Close()
+
+	fi, err := os.Stat(socket)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if perm := fi.Mode().Perm(); perm != 0o600 {
+		t.Errorf("want socket mode 0600, got %#o", perm)
+	}
+
+	var reply serveapi.Result
+	if err := client.Call("Fastly.ServiceDescribe", serveapi.ServiceArgs{ServiceID: "abc"}, &reply); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(reply.Data), `"ID":"abc"`) {
+		t.Errorf("unexpected data: %s", reply.Data)
+	}
+
+	p, err := os.FindProcess(os.Getpid())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := p.Signal(os.Interrupt); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case err := <-errCh:
+		testutil.AssertNoError(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("server didn't stop")
+	}
+
+	if _, err := os.Stat(socket); !os.IsNotExist(err) {
+		t.Errorf("want socket removed, got: %v", err)
+	}
+	testutil.AssertStringContains(t, stdout.String(), "Listening for JSON-RPC requests on "+socket)
+}
+
+func TestDefaultSocket(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("test relies on unix file modes")
+	}
+
+	dir := filepath.Join(t.TempDir(), "run")
+	t.Setenv("XDG_RUNTIME_DIR", dir)
+
+	socket, err := serveapi.DefaultSocket()
+	testutil.AssertNoError(t, err)
+	testutil.AssertString(t, filepath.Join(dir, "fastly-api.sock"), socket)
+	fi, err := os.Stat(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if perm := fi.Mode().Perm(); perm != 0o700 {
+		t.Errorf("want directory mode 0700, got %#o", perm)
+	}
+
+	if err := os.Chmod(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	_, err = serveapi.DefaultSocket()
+	testutil.AssertErrorContains(t, err, "is accessible by other users")
+}
+
+func TestServeAPIErrors(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "file")
+	if err := os.WriteFile(file, nil, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	args := testutil.Args
+	scenarios := []testutil.TestScenario{
+		{
+			Name:      "validate missing socket directory",
+			Args:      args("serve-api --socket " + filepath.Join(dir, "missing", "api.sock")),
+			WantError: "error listening on socket",
+		},
+		{
+			Name:      "validate existing file isn't replaced",
+			Args:      args("serve-api --socket " + file),
+			WantError: "already exists and isn't a socket",
+		},
+	}
+	for testcaseIdx := range scenarios {
+		testcase := &scenarios[testcaseIdx]
+		t.Run(testcase.Name, func(t *testing.T) {
+			var stdout bytes.Buffer
+			opts := testutil.NewRunOpts(testcase.Args, &stdout)
+			err := app.Run(opts)
+			testutil.AssertErrorContains(t, err, testcase.WantError)
+		})
+	}
+}
+
+// newClient returns an RPC client connected to a server for svc.
+func newClient(t *testing.T, svc *serveapi.Service) *rpc.Client {
+	t.Helper()
+	server := rpc.NewServer()
+	if err := server.RegisterName("Fastly", svc); err != nil {
+		t.Fatal(err)
+	}
+	c, s := net.Pipe()
+	go server.ServeCodec(jsonrpc.NewServerCodec(s))
+	client := jsonrpc.NewClient(c)
+	t.Cleanup(func() { client.Close() })
+	return client
+}
@@ -0,0 +1,172 @@
+package serveapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+)
+
+// Runner runs the CLI with the given arguments, writing command output to out.
+type Runner func(args []string, out io.Writer) error
+
+// Result is the reply to every RPC method.
+type Result struct {
+	// Output is the text the command wrote to stdout.
+	Output string `json:"output"`
+	// Data is the structured output of commands that support --json.
+	Data json.RawMessage `json:"data,omitempty"`
+}
+
+// BuildArgs are the parameters of the Fastly.Build method.
+type BuildArgs struct {
+	// Dir is the Compute@Edge project directory.
+	Dir           string `json:"dir"`
+	IncludeSource bool   `json:"include_source"`
+	Language      string `json:"language"`
+}
+
+// DeployArgs are the parameters of the Fastly.Deploy method.
+type DeployArgs struct {
+	// Dir is the Compute@Edge project directory.
+	Dir       string `json:"dir"`
+	Comment   string `json:"comment"`
+	Package   string `json:"package"`
+	ServiceID string `json:"service_id"`
+}
+
+// PurgeArgs are the parameters of the Fastly.Purge method.
+type PurgeArgs struct {
+	All       bool   `json:"all"`
+	Key       string `json:"key"`
+	ServiceID string `json:"service_id"`
+	Soft      bool   `json:"soft"`
+	URL       string `json:"url"`
+}
+
+// ServiceArgs are the parameters of the Fastly.ServiceDescribe method.
+type ServiceArgs struct {
+	ServiceID string `json:"service_id"`
+}
+
+// Service implements the RPC methods, each of which runs the equivalent CLI
+// command.
+type Service struct {
+	globalArgs []string
+	mu         sync.Mutex
+	run        Runner
+}
+
+// NewService returns a Service that runs commands with run, passing
+// globalArgs (e.g. --token) to every command.
+func NewService(run Runner, globalArgs ...string) *Service {
+	return &Service{
+		globalArgs: globalArgs,
+		run:        run,
+	}
+}
+
+// Build runs `compute build`.
+func (s *Service) Build(args BuildArgs, reply *Result) error {
+	a := []string{"compute", "build"}
+	if args.IncludeSource {
+		a = append(a, "--include-source")
+	}
+	if args.Language != "" {
+		a = append(a, "--language", args.Language)
+	}
+	return s.exec(args.Dir, a, false, reply)
+}
+
+// Deploy runs `compute deploy`.
+func (s *Service) Deploy(args DeployArgs, reply *Result) error {
+	a := []string{"compute", "deploy"}
+	if args.Comment != "" {
+		a = append(a, "--comment", args.Comment)
+	}
+	if args.Package != "" {
+		a = append(a, "--package", args.Package)
+	}
+	if args.ServiceID != "" {
+		a = append(a, "--service-id", args.ServiceID)
+	}
+	return s.exec(args.Dir, a, false, reply)
+}
+
+// Purge runs `purge`.
+func (s *Service) Purge(args PurgeArgs, reply *Result) error {
+	a := []string{"purge"}
+	if args.All {
+		a = append(a, "--all")
+	}
+	if args.Key != "" {
+		a = append(a, "--key", args.Key)
+	}
+	if args.ServiceID != "" {
+		a = append(a, "--service-id", args.ServiceID)
+	}
+	if args.Soft {
+		a = append(a, "--soft")
+	}
+	if args.URL != "" {
+		a = append(a, "--url", args.URL)
+	}
+	return s.exec("", a, false, reply)
+}
+
+// ServiceDescribe runs `service describe --json`.
+func (s *Service) ServiceDescribe(args ServiceArgs, reply *Result) error {
+	a := []string{"service", "describe", "--json"}
+	if args.ServiceID != "" {
+		a = append(a, "--service-id", args.ServiceID)
+	}
+	return s.exec("", a, true, reply)
+}
+
+// exec runs the command on behalf of an RPC request.
+//
+// NOTE: Commands are run one at a time as the CLI reads the working directory
+// and holds some process-wide state (e.g. the --quiet setting).
+func (s *Service) exec(dir string, args []string, decode bool, reply *Result) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	output, err := RunIn(s.run, dir, append(append([]string{}, s.globalArgs...), args...))
+	if err != nil {
+		return err
+	}
+
+	if decode {
+		if !json.Valid(output) {
+			return errors.New("command output was not valid JSON")
+		}
+		reply.Data = json.RawMessage(output)
+		return nil
+	}
+	reply.Output = string(output)
+	return nil
+}
+
+// RunIn runs the command from within dir, if set, and returns its output.
+// The working directory is restored afterwards.
+func RunIn(run Runner, dir string, args []string) ([]byte, error) {
+	if dir != "" {
+		wd, err := os.Getwd()
+		if err != nil {
+			return nil, fmt.Errorf("error determining working directory: %w", err)
+		}
+		if err := os.Chdir(dir); err != nil {
+			return nil, fmt.Errorf("error changing to project directory: %w", err)
+		}
+		defer func() {
+			_ = os.Chdir(wd)
+		}()
+	}
+
+	var buf bytes.Buffer
+	err := run(args, &buf)
+	return buf.Bytes(), err
+}
@@ -13,11 +13,22 @@ import (
 	"github.com/fastly/go-fastly/v6/fastly"
 )
 
+// columns is the registry of table columns used by the --columns and
+// --sort-by flags.
+var columns = []text.Column{
+	{Key: "name", Header: "NAME"},
+	{Key: "id", Header: "ID"},
+	{Key: "type", Header: "TYPE"},
+	{Key: "active_version", Header: "ACTIVE VERSION"},
+	{Key: "updated_at", Header: "LAST EDITED (UTC)"},
+}
+
 // ListCommand calls the Fastly API to list services.
 type ListCommand struct {
 	cmd.Base
-	input fastly.ListServicesInput
-	json  bool
+	input      fastly.ListServicesInput
+	json       bool
+	pagination cmd.Pagination
 }
 
 // NewListCommand returns a usable command registered under the parent.
@@ -25,6 +36,7 @@ func NewListCommand(parent cmd.Registerer, globals *config.Data) *ListCommand {
 	var c ListCommand
 	c.Globals = globals
 	c.CmdClause = parent.Command("list", "List Fastly services")
+	c.RegisterAllFlag(&c.pagination)
 	c.CmdClause.Flag("direction", "Direction in which to sort results").Default(cmd.PaginationDirection[0]).HintOptions(cmd.PaginationDirection...).EnumVar(&c.input.Direction, cmd.PaginationDirection...)
 	c.RegisterFlagBool(cmd.BoolFlagOpts{
 		Name:        cmd.FlagJSONName,
@@ -32,9 +44,18 @@ func NewListCommand(parent cmd.Registerer, globals *config.Data) *ListCommand {
 		Dst:         &c.json,
 		Short:       'j',
 	})
-	c.CmdClause.Flag("page", "Page number of data set to fetch").IntVar(&c.input.Page)
-	c.CmdClause.Flag("per-page", "Number of records per page").IntVar(&c.input.PerPage)
+	c.RegisterPageFlags(&c.pagination)
 	c.CmdClause.Flag("sort", "Field on which to sort").Default("created").StringVar(&c.input.Sort)
+	c.RegisterExamples(
+		cmd.Example{
+			Cmd:   "fastly service list --sort name --json",
+			Title: "List all services sorted by name as JSON",
+		},
+		cmd.Example{
+			Cmd:   "fastly service list --page 2 --per-page 10",
+			Title: "List only the second page of ten services",
+		},
+	)
 	return &c
 }
 
@@ -43,19 +64,20 @@ func (c *ListCommand) Exec(_ io.Reader, out io.Writer) error {
 	if c.Globals.Verbose() && c.json {
 		return fsterr.ErrInvalidVerboseJSONCombo
 	}
+	if err := c.pagination.Validate(); err != nil {
+		return err
+	}
 
+	c.input.Page = c.pagination.Page
+	c.input.PerPage = c.pagination.PerPage
 	paginator := c.Globals.APIClient.NewListServicesPaginator(&c.input)
 
-	var ss []*fastly.Service
-	for paginator.HasNext() {
-		data, err := paginator.GetNext()
-		if err != nil {
-			c.Globals.ErrLog.AddWithContext(err, map[string]any{
-				"Remaining Pages": paginator.Remaining(),
-			})
-			return err
-		}
-		ss = append(ss, data...)
+	ss, err := cmd.Paginate[*fastly.Service](paginator, c.pagination)
+	if err != nil {
+		c.Globals.ErrLog.AddWithContext(err, map[string]any{
+			"Remaining Pages": paginator.Remaining(),
+		})
+		return err
 	}
 
 	if !c.Globals.Verbose() {
@@ -73,7 +95,7 @@ func (c *ListCommand) Exec(_ io.Reader, out io.Writer) error {
 		}
 
 		tw := text.NewTable(out)
-		tw.AddHeader("NAME", "ID", "TYPE", "ACTIVE VERSION", "LAST EDITED (UTC)")
+		tw.AddColumns(columns...)
 		for _, service := range ss {
 			updatedAt := "n/a"
 			if service.UpdatedAt != nil {
@@ -7,6 +7,7 @@ import (
 	"path/filepath"
 	"regexp"
 	"strings"
+	"syscall"
 	"testing"
 
 	"github.com/fastly/cli/pkg/app"
@@ -204,6 +205,48 @@ func TestServiceList(t *testing.T) {
 			args:       args("service list --page 2 --per-page 1"),
 			wantOutput: listServicesShortOutputPageTwo,
 		},
+		// In the following test, the paginator would happily continue onto the
+		// third page but only the requested --page should be fetched.
+		{
+			api: mock.API{
+				NewListServicesPaginatorFn: func(i *fastly.ListServicesInput) fastly.PaginatorServices {
+					return &mockServicesPaginator{count: i.Page - 1, maxPages: 3}
+				},
+			},
+			args:       args("service list --page 2"),
+			wantOutput: listServicesShortOutputPageTwo,
+		},
+		{
+			api: mock.API{
+				NewListServicesPaginatorFn: func(i *fastly.ListServicesInput) fastly.PaginatorServices {
+					return &mockServicesPaginator{numOfPages: i.PerPage, maxPages: 3}
+				},
+			},
+			args:       args("service list --all --per-page 1"),
+			wantOutput: listServicesShortOutput,
+		},
+		{
+			api: mock.API{
+				NewListServicesPaginatorFn: func(i *fastly.ListServicesInput) fastly.PaginatorServices {
+					return &mockServicesPaginator{numOfPages: i.PerPage, maxPages: 3}
+				},
+			},
+			args:       args("service list --per-page 1 --columns name,updated_at --sort-by=-updated_at"),
+			wantOutput: listServicesColumnsOutput,
+		},
+		{
+			api: mock.API{
+				NewListServicesPaginatorFn: func(i *fastly.ListServicesInput) fastly.PaginatorServices {
+					return &mockServicesPaginator{numOfPages: i.PerPage, maxPages: 3}
+				},
+			},
+			args:       args("service list --per-page 1 --sort-by name"),
+			wantOutput: listServicesSortedOutput,
+		},
+		{
+			args:      args("service list --all --page 2"),
+			wantError: "invalid flag combination, --all and --page",
+		},
 		{
 			api: mock.API{
 				NewListServicesPaginatorFn: func(i *fastly.ListServicesInput) fastly.PaginatorServices {
@@ -232,6 +275,7 @@ func TestServiceDescribe(t *testing.T) {
 	scenarios := []struct {
 		args       []string
 		api        mock.API
+		env        map[string]string
 		wantError  string
 		wantOutput string
 	}{
@@ -270,6 +314,30 @@ func TestServiceDescribe(t *testing.T) {
 			api:       mock.API{GetServiceDetailsFn: describeServiceError},
 			wantError: errTest.Error(),
 		},
+		{
+			args:       args("service describe"),
+			api:        mock.API{GetServiceDetailsFn: describeServiceID("123")},
+			env:        map[string]string{"FASTLY_SERVICE_DESCRIBE_SERVICE_ID": "123"},
+			wantOutput: describeServiceShortOutput,
+		},
+		{
+			args:       args("service describe --service-id 123"),
+			api:        mock.API{GetServiceDetailsFn: describeServiceID("123")},
+			env:        map[string]string{"FASTLY_SERVICE_DESCRIBE_SERVICE_ID": "456"},
+			wantOutput: describeServiceShortOutput,
+		},
+		{
+			args:       args("service describe --service-id 123"),
+			api:        mock.API{GetServiceDetailsFn: describeServiceOK},
+			env:        map[string]string{"FASTLY_VERBOSE": "1"},
+			wantOutput: describeServiceVerboseOutput,
+		},
+		{
+			args:      args("service describe --service-id 123"),
+			api:       mock.API{GetServiceDetailsFn: describeServiceOK},
+			env:       map[string]string{"FASTLY_VERBOSE": "maybe"},
+			wantError: "invalid boolean value 'maybe' for FASTLY_VERBOSE",
+		},
 	}
 	for testcaseIdx := range scenarios {
 		testcase := &scenarios[testcaseIdx]
@@ -277,6 +345,7 @@ func TestServiceDescribe(t *testing.T) {
 			var stdout bytes.Buffer
 			opts := testutil.NewRunOpts(testcase.args, &stdout)
 			opts.APIClient = mock.APIClient(testcase.api)
+			opts.Env.Flags = testcase.env
 			err := app.Run(opts)
 			testutil.AssertErrorContains(t, err, testcase.wantError)
 			testutil.AssertString(t, testcase.wantOutput, stdout.String())
@@ -289,6 +358,7 @@ func TestServiceSearch(t *testing.T) {
 	scenarios := []struct {
 		args       []string
 		api        mock.API
+		env        map[string]string
 		wantError  string
 		wantOutput string
 	}{
@@ -311,6 +381,12 @@ func TestServiceSearch(t *testing.T) {
 			api:       mock.API{SearchServiceFn: searchServiceOK},
 			wantError: "error parsing arguments: expected argument for flag '--name'",
 		},
+		{
+			args:       args("service search"),
+			api:        mock.API{SearchServiceFn: searchServiceOK},
+			env:        map[string]string{"FASTLY_SERVICE_SEARCH_NAME": "Foo"},
+			wantOutput: searchServiceShortOutput,
+		},
 	}
 	for testcaseIdx := range scenarios {
 		testcase := &scenarios[testcaseIdx]
@@ -318,6 +394,7 @@ func TestServiceSearch(t *testing.T) {
 			var stdout bytes.Buffer
 			opts := testutil.NewRunOpts(testcase.args, &stdout)
 			opts.APIClient = mock.APIClient(testcase.api)
+			opts.Env.Flags = testcase.env
 			err := app.Run(opts)
 			testutil.AssertErrorContains(t, err, testcase.wantError)
 			testutil.AssertString(t, testcase.wantOutput, stdout.String())
@@ -497,6 +574,91 @@ func TestServiceDelete(t *testing.T) {
 	}
 }
 
+func TestServiceWatch(t *testing.T) {
+	type state struct {
+		versions []*fastly.Version
+		domains  []string
+		hash     string
+		err      error
+	}
+	v := func(n int, active bool) *fastly.Version {
+		return &fastly.Version{Number: n, Active: active}
+	}
+	// NOTE: The second poll fails, which is reported without stopping the
+	// command.
+	states := []state{
+		{versions: []*fastly.Version{v(1, true), v(2, false)}, domains: []string{"a.example.com"}, hash: "aaaaaaaaaaaaaaaa"},
+		{err: testutil.Err},
+		{versions: []*fastly.Version{v(1, true), v(2, false), v(3, false)}, domains: []string{"a.example.com", "b.example.com"}, hash: "aaaaaaaaaaaaaaaa"},
+		{versions: []*fastly.Version{v(1, false), v(2, false), v(3, true)}, domains: []string{"b.example.com"}, hash: "bbbbbbbbbbbbbbbb"},
+	}
+
+	var calls, current int
+	var packages []int
+	var stdout bytes.Buffer
+	opts := testutil.NewRunOpts(testutil.Args("service watch --service-id 123 --interval 10ms"), &stdout)
+	opts.APIClient = mock.APIClient(mock.API{
+		GetServiceDetailsFn: func(i *fastly.GetServiceInput) (*fastly.ServiceDetail, error) {
+			testutil.AssertString(t, "123", i.ID)
+			current = calls
+			if calls < len(states)-1 {
+				calls++
+			} else if calls == len(states)-1 {
+				calls++
+				// NOTE: The command stops polling once interrupted.
+				testutil.AssertNoError(t, syscall.Kill(syscall.Getpid(), syscall.SIGINT))
+			} else {
+				current = len(states) - 1
+			}
+			s := states[current]
+			if s.err != nil {
+				return nil, s.err
+			}
+			return &fastly.ServiceDetail{ID: "123", Name: "Foo", Type: "wasm", Versions: s.versions}, nil
+		},
+		ListDomainsFn: func(i *fastly.ListDomainsInput) ([]*fastly.Domain, error) {
+			var ds []*fastly.Domain
+			for _, d := range states[current].domains {
+				ds = append(ds, &fastly.Domain{Name: d})
+			}
+			return ds, nil
+		},
+		GetPackageFn: func(i *fastly.GetPackageInput) (*fastly.Package, error) {
+			packages = append(packages, i.ServiceVersion)
+			return &fastly.Package{Metadata: fastly.PackageMetadata{HashSum: states[current].hash}}, nil
+		},
+	})
+	err := app.Run(opts)
+	testutil.AssertNoError(t, err)
+
+	// The package is only fetched when the active version changes.
+	testutil.AssertEqual(t, []int{1, 3}, packages)
+
+	out := stdout.String()
+	testutil.AssertStringContains(t, out, "Watching service 'Foo'")
+	testutil.AssertStringContains(t, out, "fetching service: test error")
+
+	// NOTE: Each change is prefixed by the time it was seen.
+	var changes []string
+	for _, line := range strings.Split(out, "\n") {
+		if i := strings.Index(line, "  "); i > 0 && strings.HasPrefix(line, "20") {
+			changes = append(changes, line[i+2:])
+		}
+	}
+	testutil.AssertEqual(t, []string{
+		"Version 3 created",
+		"Domain 'b.example.com' added (version 3)",
+		"Version 3 activated",
+		"Domain 'a.example.com' removed (version 3)",
+		"Active package changed: aaaaaaaaaaaa => bbbbbbbbbbbb (version 3)",
+	}, changes)
+
+	var stdout2 bytes.Buffer
+	opts = testutil.NewRunOpts(testutil.Args("service watch --service-id 123 --interval 0s"), &stdout2)
+	err = app.Run(opts)
+	testutil.AssertErrorContains(t, err, "invalid --interval value: 0s")
+}
+
 var errTest = errors.New("fixture error")
 
 func createServiceOK(i *fastly.CreateServiceInput) (*fastly.Service, error) {
@@ -519,6 +681,20 @@ Bar   456  wasm  1               2015-03-14 12:59
 Baz   789  vcl   1               n/a
 `) + "\n"
 
+var listServicesColumnsOutput = strings.TrimSpace(`
+NAME  LAST EDITED (UTC)
+Baz   n/a
+Bar   2015-03-14 12:59
+Foo   2010-11-15 19:01
+`) + "\n"
+
+var listServicesSortedOutput = strings.TrimSpace(`
+NAME  ID   TYPE  ACTIVE VERSION  LAST EDITED (UTC)
+Bar   456  wasm  1               2015-03-14 12:59
+Baz   789  vcl   1               n/a
+Foo   123  wasm  2               2010-11-15 19:01
+`) + "\n"
+
 var listServicesShortOutputPageOne = strings.TrimSpace(`
 NAME  ID   TYPE  ACTIVE VERSION  LAST EDITED (UTC)
 Foo   123  wasm  2               2010-11-15 19:01
@@ -590,6 +766,16 @@ func getServiceOK(i *fastly.GetServiceInput) (*fastly.Service, error) {
 	}, nil
 }
 
+// describeServiceID returns describeServiceOK data only for the given ID.
+func describeServiceID(id string) func(i *fastly.GetServiceInput) (*fastly.ServiceDetail, error) {
+	return func(i *fastly.GetServiceInput) (*fastly.ServiceDetail, error) {
+		if i.ID != id {
+			return nil, errTest
+		}
+		return describeServiceOK(i)
+	}
+}
+
 func describeServiceOK(i *fastly.GetServiceInput) (*fastly.ServiceDetail, error) {
 	return &fastly.ServiceDetail{
 		ID:         "123",
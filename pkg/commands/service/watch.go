@@ -0,0 +1,237 @@
+package service
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"sort"
+	"syscall"
+	"time"
+
+	"github.com/fastly/cli/pkg/cmd"
+	"github.com/fastly/cli/pkg/config"
+	fsterr "github.com/fastly/cli/pkg/errors"
+	"github.com/fastly/cli/pkg/manifest"
+	"github.com/fastly/cli/pkg/text"
+	"github.com/fastly/go-fastly/v6/fastly"
+)
+
+// WatchCommand polls a service and reports the changes made to it.
+type WatchCommand struct {
+	cmd.Base
+	interval    time.Duration
+	manifest    manifest.Data
+	serviceName cmd.OptionalServiceNameID
+}
+
+// NewWatchCommand returns a usable command registered under the parent.
+func NewWatchCommand(parent cmd.Registerer, globals *config.Data, data manifest.Data) *WatchCommand {
+	var c WatchCommand
+	c.Globals = globals
+	c.manifest = data
+	c.CmdClause = parent.Command("watch", "Poll a Fastly service and report its new versions, activations, domain changes and package changes until interrupted")
+	c.CmdClause.Flag("interval", "How often to poll the service").Default("10s").DurationVar(&c.interval)
+	c.RegisterFlag(cmd.StringFlagOpts{
+		Name:        cmd.FlagServiceIDName,
+		Description: cmd.FlagServiceIDDesc,
+		Dst:         &c.manifest.Flag.ServiceID,
+		Short:       's',
+	})
+	c.RegisterFlag(cmd.StringFlagOpts{
+		Action:      c.serviceName.Set,
+		Name:        cmd.FlagServiceName,
+		Description: cmd.FlagServiceDesc,
+		Dst:         &c.serviceName.Value,
+	})
+	return &c
+}
+
+// Exec invokes the application logic for the command.
+func (c *WatchCommand) Exec(_ io.Reader, out io.Writer) error {
+	if c.interval <= 0 {
+		return fsterr.RemediationError{
+			Inner:       fmt.Errorf("invalid --interval value: %s", c.interval),
+			Remediation: "Provide a positive duration (e.g. 10s).",
+		}
+	}
+
+	serviceID, source, flag, err := cmd.ServiceID(c.serviceName, c.manifest, c.Globals.APIClient, c.Globals.ErrLog)
+	if err != nil {
+		return err
+	}
+	if c.Globals.Verbose() {
+		cmd.DisplayServiceID(serviceID, flag, source, out)
+	}
+	if source == manifest.SourceUndefined && !c.serviceName.WasSet {
+		err := fsterr.ErrNoServiceID
+		c.Globals.ErrLog.Add(err)
+		return err
+	}
+
+	s, err := c.fetch(serviceID, nil)
+	if err != nil {
+		c.Globals.ErrLog.AddWithContext(err, map[string]any{
+			"Service ID": serviceID,
+		})
+		return err
+	}
+	active := "none"
+	if s.active > 0 {
+		active = fmt.Sprintf("%d", s.active)
+	}
+	text.Info(out, "Watching service '%s' (active version: %s, latest version: %d). Press Ctrl-C to stop.", s.name, active, s.latest)
+
+	// NOTE: An interrupt is the normal way to stop, so the command handles it
+	// rather than the CLI cleaning up after an interrupted command.
+	c.Globals.Interrupt.Release()
+	sigs := make(chan os.Signal, 1)
+	signal.Notify(sigs, syscall.SIGINT, syscall.SIGTERM)
+	defer signal.Stop(sigs)
+
+	ticker := time.NewTicker(c.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-sigs:
+			return nil
+		case <-ticker.C:
+			next, err := c.fetch(serviceID, s)
+			if err != nil {
+				text.Error(out, "fetching service: %v", err)
+				continue
+			}
+			now := time.Now().UTC().Format("2006-01-02 15:04:05")
+			for _, change := range s.changes(next) {
+				fmt.Fprintf(out, "%s  %s\n", now, change)
+			}
+			s = next
+		}
+	}
+}
+
+// serviceState is what the watch command compares between polls.
+type serviceState struct {
+	name     string
+	wasm     bool
+	versions map[int]bool // whether each version is active
+	active   int          // 0 when no version is active
+	latest   int
+	domains  []string // of the latest version, sorted
+	hash     string   // of the active version's package
+}
+
+// fetch returns the state of the service. The package hash of the previous
+// state is reused when the active version hasn't changed, as the package of
+// an activated version is locked.
+func (c *WatchCommand) fetch(serviceID string, prev *serviceState) (*serviceState, error) {
+	detail, err := c.Globals.APIClient.GetServiceDetails(&fastly.GetServiceInput{ID: serviceID})
+	if err != nil {
+		return nil, err
+	}
+
+	s := &serviceState{
+		name:     detail.Name,
+		wasm:     detail.Type == "wasm",
+		versions: make(map[int]bool, len(detail.Versions)),
+	}
+	for _, v := range detail.Versions {
+		s.versions[v.Number] = v.Active
+		if v.Active {
+			s.active = v.Number
+		}
+		if v.Number > s.latest {
+			s.latest = v.Number
+		}
+	}
+
+	if s.latest > 0 {
+		domains, err := c.Globals.APIClient.ListDomains(&fastly.ListDomainsInput{
+			ServiceID:      serviceID,
+			ServiceVersion: s.latest,
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, d := range domains {
+			s.domains = append(s.domains, d.Name)
+		}
+		sort.Strings(s.domains)
+	}
+
+	switch {
+	case !s.wasm || s.active == 0:
+	case prev != nil && prev.active == s.active:
+		s.hash = prev.hash
+	default:
+		pkg, err := c.Globals.APIClient.GetPackage(&fastly.GetPackageInput{
+			ServiceID:      serviceID,
+			ServiceVersion: s.active,
+		})
+		if err != nil {
+			return nil, err
+		}
+		s.hash = pkg.Metadata.HashSum
+	}
+	return s, nil
+}
+
+// changes describes how the next state differs from the state.
+func (s *serviceState) changes(next *serviceState) []string {
+	var changes []string
+
+	var created []int
+	for n := range next.versions {
+		if _, ok := s.versions[n]; !ok {
+			created = append(created, n)
+		}
+	}
+	sort.Ints(created)
+	for _, n := range created {
+		changes = append(changes, fmt.Sprintf("Version %d created", n))
+	}
+
+	switch {
+	case next.active == s.active:
+	case next.active == 0:
+		changes = append(changes, fmt.Sprintf("Version %d deactivated", s.active))
+	default:
+		changes = append(changes, fmt.Sprintf("Version %d activated", next.active))
+	}
+
+	// NOTE: A new version starts with the domains of the version it was cloned
+	// from, so domains are compared across versions rather than reported again.
+	old := make(map[string]bool, len(s.domains))
+	for _, d := range s.domains {
+		old[d] = true
+	}
+	for _, d := range next.domains {
+		if !old[d] {
+			changes = append(changes, fmt.Sprintf("Domain '%s' added (version %d)", d, next.latest))
+		}
+		delete(old, d)
+	}
+	for _, d := range s.domains {
+		if old[d] {
+			changes = append(changes, fmt.Sprintf("Domain '%s' removed (version %d)", d, next.latest))
+		}
+	}
+
+	if next.hash != s.hash && next.hash != "" {
+		changes = append(changes, fmt.Sprintf("Active package changed: %s => %s (version %d)", shortHash(s.hash), shortHash(next.hash), next.active))
+	}
+	return changes
+}
+
+// shortHash abbreviates a package hash for display.
+func shortHash(h string) string {
+	switch {
+	case h == "":
+		return "none"
+	case len(h) > 12:
+		return h[:12]
+	default:
+		return h
+	}
+}
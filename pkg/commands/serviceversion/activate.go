@@ -7,6 +7,7 @@ import (
 	"github.com/fastly/cli/pkg/config"
 	"github.com/fastly/cli/pkg/errors"
 	"github.com/fastly/cli/pkg/manifest"
+	"github.com/fastly/cli/pkg/notify"
 	"github.com/fastly/cli/pkg/text"
 	"github.com/fastly/go-fastly/v6/fastly"
 )
@@ -19,6 +20,7 @@ type ActivateCommand struct {
 	serviceName    cmd.OptionalServiceNameID
 	serviceVersion cmd.OptionalServiceVersion
 	autoClone      cmd.OptionalAutoClone
+	notify         cmd.OptionalBool
 }
 
 // NewActivateCommand returns a usable command registered under the parent.
@@ -49,6 +51,7 @@ func NewActivateCommand(parent cmd.Registerer, globals *config.Data, data manife
 		Action: c.autoClone.Set,
 		Dst:    &c.autoClone.Value,
 	})
+	c.CmdClause.Flag("notify", notify.FlagDesc).Action(c.notify.Set).NegatableBoolVar(&c.notify.Value)
 	return &c
 }
 
@@ -84,5 +87,11 @@ func (c *ActivateCommand) Exec(_ io.Reader, out io.Writer) error {
 	}
 
 	text.Success(out, "Activated service %s version %d", ver.ServiceID, c.Input.ServiceVersion)
+
+	notify.Send(notify.Event{
+		Action:    notify.ActionActivate,
+		ServiceID: serviceID,
+		Version:   c.Input.ServiceVersion,
+	}, c.notify, c.Globals, out)
 	return nil
 }
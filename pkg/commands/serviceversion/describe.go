@@ -0,0 +1,135 @@
+package serviceversion
+
+import (
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/fastly/cli/pkg/cmd"
+	"github.com/fastly/cli/pkg/config"
+	fsterr "github.com/fastly/cli/pkg/errors"
+	"github.com/fastly/cli/pkg/manifest"
+	"github.com/fastly/cli/pkg/text"
+	"github.com/fastly/go-fastly/v6/fastly"
+)
+
+// DescribeCommand calls the Fastly API to describe a service version.
+type DescribeCommand struct {
+	cmd.Base
+	manifest       manifest.Data
+	output         cmd.Output
+	serviceName    cmd.OptionalServiceNameID
+	serviceVersion cmd.OptionalServiceVersion
+}
+
+// VersionDescription is a service version along with the tags and metadata
+// recorded in its comment.
+type VersionDescription struct {
+	*fastly.Version
+	Metadata map[string]string `json:"Metadata"`
+	Tags     []string          `json:"Tags"`
+}
+
+// NewDescribeCommand returns a usable command registered under the parent.
+func NewDescribeCommand(parent cmd.Registerer, globals *config.Data, data manifest.Data) *DescribeCommand {
+	var c DescribeCommand
+	c.Globals = globals
+	c.manifest = data
+	c.CmdClause = parent.Command("describe", "Show detailed information about a Fastly service version, including its tags and deploy metadata").Alias("get")
+	c.RegisterFlagBool(cmd.BoolFlagOpts{
+		Name:        cmd.FlagJSONName,
+		Description: cmd.FlagJSONDesc,
+		Dst:         &c.output.JSON,
+		Short:       'j',
+	})
+	c.RegisterOutputFlags(&c.output)
+	c.RegisterFlag(cmd.StringFlagOpts{
+		Name:        cmd.FlagServiceIDName,
+		Description: cmd.FlagServiceIDDesc,
+		Dst:         &c.manifest.Flag.ServiceID,
+		Short:       's',
+	})
+	c.RegisterFlag(cmd.StringFlagOpts{
+		Action:      c.serviceName.Set,
+		Name:        cmd.FlagServiceName,
+		Description: cmd.FlagServiceDesc,
+		Dst:         &c.serviceName.Value,
+	})
+	c.RegisterFlag(cmd.StringFlagOpts{
+		Name:        cmd.FlagVersionName,
+		Description: cmd.FlagVersionDesc,
+		Dst:         &c.serviceVersion.Value,
+		Required:    true,
+	})
+	return &c
+}
+
+// Exec invokes the application logic for the command.
+func (c *DescribeCommand) Exec(_ io.Reader, out io.Writer) (err error) {
+	if c.Globals.Verbose() && c.output.Structured() {
+		return fsterr.ErrInvalidVerboseJSONCombo
+	}
+
+	out, closeOutput, err := c.output.Writer(out)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err = closeOutput(err)
+	}()
+
+	serviceID, serviceVersion, err := cmd.ServiceDetails(cmd.ServiceDetailsOpts{
+		AllowActiveLocked:  true,
+		APIClient:          c.Globals.APIClient,
+		Manifest:           c.manifest,
+		Out:                out,
+		ServiceNameFlag:    c.serviceName,
+		ServiceVersionFlag: c.serviceVersion,
+		VerboseMode:        c.Globals.Flag.Verbose,
+	})
+	if err != nil {
+		c.Globals.ErrLog.AddWithContext(err, map[string]any{
+			"Service ID":      serviceID,
+			"Service Version": fsterr.ServiceVersion(serviceVersion),
+		})
+		return err
+	}
+
+	v := VersionDescription{
+		Version:  serviceVersion,
+		Metadata: cmd.VersionMetadata(serviceVersion.Comment),
+		Tags:     cmd.VersionTags(serviceVersion.Comment),
+	}
+	if v.Tags == nil {
+		v.Tags = []string{}
+	}
+
+	if c.output.Structured() {
+		if err := c.output.Write(out, v); err != nil {
+			c.Globals.ErrLog.Add(err)
+			return err
+		}
+		return nil
+	}
+
+	text.PrintVersion(out, "", v.Version)
+	if len(v.Tags) > 0 {
+		fmt.Fprintln(out, "Tags:")
+		for _, t := range v.Tags {
+			fmt.Fprintf(out, "\t%s\n", t)
+		}
+	}
+	if len(v.Metadata) > 0 {
+		keys := make([]string, 0, len(v.Metadata))
+		for k := range v.Metadata {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+
+		fmt.Fprintln(out, "Metadata:")
+		for _, k := range keys {
+			fmt.Fprintf(out, "\t%s: %s\n", k, v.Metadata[k])
+		}
+	}
+	return nil
+}
@@ -6,6 +6,7 @@ import (
 	"testing"
 
 	"github.com/fastly/cli/pkg/app"
+	"github.com/fastly/cli/pkg/config"
 	"github.com/fastly/cli/pkg/mock"
 	"github.com/fastly/cli/pkg/testutil"
 	"github.com/fastly/go-fastly/v6/fastly"
@@ -157,6 +158,70 @@ func TestVersionUpdate(t *testing.T) {
 	}
 }
 
+func TestVersionUpdateAutoCloneDefault(t *testing.T) {
+	args := testutil.Args
+	api := mock.API{
+		ListVersionsFn:  testutil.ListVersions,
+		CloneVersionFn:  testutil.CloneVersionResult(4),
+		UpdateVersionFn: updateVersionOK,
+	}
+	scenarios := []struct {
+		name       string
+		args       []string
+		env        map[string]string
+		profile    bool
+		wantError  string
+		wantOutput string
+	}{
+		{
+			name:       "enabled by the env var",
+			args:       args("service-version update --service-id 123 --version 1 --comment foo --verbose"),
+			env:        map[string]string{"FASTLY_AUTOCLONE": "true"},
+			wantOutput: "Service version 1 is not editable, so it was automatically cloned",
+		},
+		{
+			name:       "enabled by the profile",
+			args:       args("service-version update --service-id 123 --version 1 --comment foo"),
+			profile:    true,
+			wantOutput: "Updated service 123 version 4",
+		},
+		{
+			name:      "disabled by the flag",
+			args:      args("service-version update --service-id 123 --version 1 --comment foo --no-autoclone"),
+			profile:   true,
+			wantError: "service version 1 is not editable",
+		},
+		{
+			name:      "disabled by the env var",
+			args:      args("service-version update --service-id 123 --version 1 --comment foo"),
+			env:       map[string]string{"FASTLY_AUTOCLONE": "0"},
+			profile:   true,
+			wantError: "service version 1 is not editable",
+		},
+		{
+			name:      "invalid env var",
+			args:      args("service-version update --service-id 123 --version 1 --comment foo"),
+			env:       map[string]string{"FASTLY_AUTOCLONE": "maybe"},
+			wantError: "invalid boolean value 'maybe' for FASTLY_AUTOCLONE",
+		},
+	}
+	for testcaseIdx := range scenarios {
+		testcase := &scenarios[testcaseIdx]
+		t.Run(testcase.name, func(t *testing.T) {
+			var stdout bytes.Buffer
+			opts := testutil.NewRunOpts(testcase.args, &stdout)
+			opts.APIClient = mock.APIClient(api)
+			opts.Env.Flags = testcase.env
+			opts.ConfigFile.Profiles = config.Profiles{
+				"user": &config.Profile{AutoClone: testcase.profile, Default: true},
+			}
+			err := app.Run(opts)
+			testutil.AssertErrorContains(t, err, testcase.wantError)
+			testutil.AssertStringContains(t, stdout.String(), testcase.wantOutput)
+		})
+	}
+}
+
 func TestVersionActivate(t *testing.T) {
 	args := testutil.Args
 	scenarios := []struct {
@@ -388,6 +453,124 @@ func deactivateVersionError(i *fastly.DeactivateVersionInput) (*fastly.Version,
 	return nil, testutil.Err
 }
 
+func TestVersionTag(t *testing.T) {
+	args := testutil.Args
+	scenarios := []struct {
+		args        []string
+		api         mock.API
+		wantComment string
+		wantError   string
+		wantOutput  string
+	}{
+		{
+			args:      args("service-version tag --service-id 123 --version 1"),
+			wantError: "error parsing arguments: required flag --name not provided",
+		},
+		{
+			args:      args("service-version tag --service-id 123 --version 1 --name release/1"),
+			wantError: "invalid tag name: release/1",
+		},
+		{
+			args: args("service-version tag --service-id 123 --version 3 --name release-1"),
+			api: mock.API{
+				ListVersionsFn: listTaggedVersions,
+			},
+			wantComment: "Add backend [tag:release-1]",
+			wantOutput:  "Tagged service 123 version 3 as release-1",
+		},
+		{
+			args: args("service-version tag --service-id 123 --version tag:stable --name stable --delete"),
+			api: mock.API{
+				ListVersionsFn: listTaggedVersions,
+			},
+			wantComment: "Initial [meta:git_sha=abc123]",
+			wantOutput:  "Removed tag stable from service 123 version 2",
+		},
+		{
+			args: args("service-version tag --service-id 123 --version 2 --name stable"),
+			api: mock.API{
+				ListVersionsFn: listTaggedVersions,
+			},
+			wantOutput: "Service 123 version 2 is already tagged stable",
+		},
+		{
+			args: args("service-version tag --service-id 123 --version tag:missing --name stable"),
+			api: mock.API{
+				ListVersionsFn: listTaggedVersions,
+			},
+			wantError: "no service version found with tag: missing",
+		},
+	}
+	for testcaseIdx := range scenarios {
+		testcase := &scenarios[testcaseIdx]
+		t.Run(strings.Join(testcase.args, " "), func(t *testing.T) {
+			var comment string
+			testcase.api.UpdateVersionFn = func(i *fastly.UpdateVersionInput) (*fastly.Version, error) {
+				comment = *i.Comment
+				return &fastly.Version{ServiceID: i.ServiceID, Number: i.ServiceVersion, Comment: *i.Comment}, nil
+			}
+
+			var stdout bytes.Buffer
+			opts := testutil.NewRunOpts(testcase.args, &stdout)
+			opts.APIClient = mock.APIClient(testcase.api)
+			err := app.Run(opts)
+			testutil.AssertErrorContains(t, err, testcase.wantError)
+			testutil.AssertStringContains(t, stdout.String(), testcase.wantOutput)
+			testutil.AssertString(t, testcase.wantComment, comment)
+		})
+	}
+}
+
+func TestVersionDescribe(t *testing.T) {
+	args := testutil.Args
+	scenarios := []testutil.TestScenario{
+		{
+			Name:      "validate missing --version flag",
+			Args:      args("service-version describe --service-id 123"),
+			WantError: "error parsing arguments: required flag --version not provided",
+		},
+		{
+			Name: "validate describe",
+			Args: args("service-version describe --service-id 123 --version tag:stable"),
+			API: mock.API{
+				ListVersionsFn: listTaggedVersions,
+			},
+			WantOutput: "Number: 2\nComment: Initial [tag:stable] [meta:git_sha=abc123]\nService ID: 123\nActive: false\nLocked: true\nDeployed: false\nStaging: false\nTesting: false\nLast edited (UTC): 2000-01-02 01:00\nTags:\n\tstable\nMetadata:\n\tgit_sha: abc123\n",
+		},
+		{
+			Name: "validate describe --json",
+			Args: args("service-version describe --service-id 123 --version 3 --json"),
+			API: mock.API{
+				ListVersionsFn: listTaggedVersions,
+			},
+			WantOutput: `"Metadata":{},"Tags":[]`,
+		},
+	}
+
+	for testcaseIdx := range scenarios {
+		testcase := &scenarios[testcaseIdx]
+		t.Run(testcase.Name, func(t *testing.T) {
+			var stdout bytes.Buffer
+			opts := testutil.NewRunOpts(testcase.Args, &stdout)
+			opts.APIClient = mock.APIClient(testcase.API)
+			err := app.Run(opts)
+			testutil.AssertErrorContains(t, err, testcase.WantError)
+			testutil.AssertStringContains(t, stdout.String(), testcase.WantOutput)
+		})
+	}
+}
+
+// listTaggedVersions returns service versions where version 2 is tagged.
+func listTaggedVersions(i *fastly.ListVersionsInput) ([]*fastly.Version, error) {
+	vs, err := testutil.ListVersions(i)
+	if err != nil {
+		return nil, err
+	}
+	vs[1].Comment = "Initial [tag:stable] [meta:git_sha=abc123]"
+	vs[2].Comment = "Add backend"
+	return vs, nil
+}
+
 func lockVersionOK(i *fastly.LockVersionInput) (*fastly.Version, error) {
 	return &fastly.Version{
 		Number:    i.ServiceVersion,
@@ -0,0 +1,117 @@
+package serviceversion
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/fastly/cli/pkg/cmd"
+	"github.com/fastly/cli/pkg/config"
+	"github.com/fastly/cli/pkg/errors"
+	"github.com/fastly/cli/pkg/manifest"
+	"github.com/fastly/cli/pkg/text"
+	"github.com/fastly/go-fastly/v6/fastly"
+)
+
+// TagCommand calls the Fastly API to tag a service version.
+type TagCommand struct {
+	cmd.Base
+	manifest       manifest.Data
+	serviceName    cmd.OptionalServiceNameID
+	serviceVersion cmd.OptionalServiceVersion
+
+	delete bool
+	name   string
+}
+
+// NewTagCommand returns a usable command registered under the parent.
+func NewTagCommand(parent cmd.Registerer, globals *config.Data, data manifest.Data) *TagCommand {
+	var c TagCommand
+	c.Globals = globals
+	c.manifest = data
+	c.CmdClause = parent.Command("tag", "Tag a Fastly service version, so it can be referred to with --version tag:NAME")
+	c.RegisterFlag(cmd.StringFlagOpts{
+		Name:        cmd.FlagServiceIDName,
+		Description: cmd.FlagServiceIDDesc,
+		Dst:         &c.manifest.Flag.ServiceID,
+		Short:       's',
+	})
+	c.RegisterFlag(cmd.StringFlagOpts{
+		Action:      c.serviceName.Set,
+		Name:        cmd.FlagServiceName,
+		Description: cmd.FlagServiceDesc,
+		Dst:         &c.serviceName.Value,
+	})
+	c.RegisterFlag(cmd.StringFlagOpts{
+		Name:        cmd.FlagVersionName,
+		Description: cmd.FlagVersionDesc,
+		Dst:         &c.serviceVersion.Value,
+		Required:    true,
+	})
+	c.CmdClause.Flag("delete", "Remove the tag from the version").BoolVar(&c.delete)
+	c.CmdClause.Flag("name", "Name of the tag (letters, digits, '.', '_' and '-')").Required().StringVar(&c.name)
+	return &c
+}
+
+// Exec invokes the application logic for the command.
+//
+// NOTE: The tags are recorded in the version comment, as the API has no other
+// means of attaching metadata to a version.
+func (c *TagCommand) Exec(_ io.Reader, out io.Writer) error {
+	if !cmd.VersionTagNameRegExp.MatchString(c.name) {
+		return errors.RemediationError{
+			Inner:       fmt.Errorf("invalid tag name: %s", c.name),
+			Remediation: "Use only letters, digits, '.', '_' and '-' in the tag name.",
+		}
+	}
+
+	serviceID, serviceVersion, err := cmd.ServiceDetails(cmd.ServiceDetailsOpts{
+		AllowActiveLocked:  true,
+		APIClient:          c.Globals.APIClient,
+		Manifest:           c.manifest,
+		Out:                out,
+		ServiceNameFlag:    c.serviceName,
+		ServiceVersionFlag: c.serviceVersion,
+		VerboseMode:        c.Globals.Flag.Verbose,
+	})
+	if err != nil {
+		c.Globals.ErrLog.AddWithContext(err, map[string]any{
+			"Service ID":      serviceID,
+			"Service Version": errors.ServiceVersion(serviceVersion),
+		})
+		return err
+	}
+
+	comment := cmd.AddVersionTag(serviceVersion.Comment, c.name)
+	if c.delete {
+		comment = cmd.RemoveVersionTag(serviceVersion.Comment, c.name)
+	}
+	if comment == serviceVersion.Comment {
+		if c.delete {
+			text.Info(out, "Service %s version %d isn't tagged %s", serviceID, serviceVersion.Number, c.name)
+		} else {
+			text.Info(out, "Service %s version %d is already tagged %s", serviceID, serviceVersion.Number, c.name)
+		}
+		return nil
+	}
+
+	_, err = c.Globals.APIClient.UpdateVersion(&fastly.UpdateVersionInput{
+		ServiceID:      serviceID,
+		ServiceVersion: serviceVersion.Number,
+		Comment:        fastly.String(comment),
+	})
+	if err != nil {
+		c.Globals.ErrLog.AddWithContext(err, map[string]any{
+			"Service ID":      serviceID,
+			"Service Version": serviceVersion.Number,
+			"Tag":             c.name,
+		})
+		return err
+	}
+
+	if c.delete {
+		text.Success(out, "Removed tag %s from service %s version %d", c.name, serviceID, serviceVersion.Number)
+	} else {
+		text.Success(out, "Tagged service %s version %d as %s", serviceID, serviceVersion.Number, c.name)
+	}
+	return nil
+}
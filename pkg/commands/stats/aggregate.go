@@ -0,0 +1,169 @@
+package stats
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/fastly/cli/pkg/api"
+	"github.com/fastly/cli/pkg/text"
+	"github.com/fastly/go-fastly/v6/fastly"
+	"github.com/mattn/go-isatty"
+	"github.com/mitchellh/mapstructure"
+)
+
+// clearScreen moves the cursor to the top left of the terminal and clears it.
+const clearScreen = "\033[H\033[2J"
+
+// aggregateRetryDelay is how long to wait before polling again when a service
+// failed to respond, so the API isn't polled in a tight loop.
+var aggregateRetryDelay = time.Second
+
+// aggregateService is a service included in the aggregated realtime stats.
+type aggregateService struct {
+	ID   string
+	Name string
+}
+
+// aggregateRow is a row of the aggregated realtime stats.
+type aggregateRow struct {
+	Name      string
+	Requests  uint64
+	Hits      uint64
+	Miss      uint64
+	Errors    uint64
+	Status5xx uint64
+	Bytes     uint64
+	// Err is the error fetching the stats of the service, if any.
+	Err error
+}
+
+// add adds the stats of a realtime block to the row.
+func (r *aggregateRow) add(block statsResponseData) error {
+	var s fastly.Stats
+	if err := mapstructure.Decode(block, &s); err != nil {
+		return err
+	}
+	r.Requests += s.Requests
+	r.Hits += s.Hits
+	r.Miss += s.Miss
+	r.Errors += s.Errors
+	r.Status5xx += s.Status5xx
+	r.Bytes += s.ResponseHeaderBytes + s.ResponseBodyBytes
+	return nil
+}
+
+// hitRatio returns the ratio of hits to cacheable requests, as a percentage.
+func (r aggregateRow) hitRatio() string {
+	if r.Hits+r.Miss == 0 {
+		return "-"
+	}
+	return fmt.Sprintf("%.2f%%", float64(r.Hits)/float64(r.Hits+r.Miss)*100)
+}
+
+// aggregator polls the realtime stats of several services, rendering a row
+// per service and a row of their totals each time every service responds.
+type aggregator struct {
+	client     api.RealtimeStatsInterface
+	services   []aggregateService
+	timestamps []uint64
+}
+
+// newAggregator returns an aggregator for the given services.
+func newAggregator(client api.RealtimeStatsInterface, services []aggregateService) *aggregator {
+	return &aggregator{
+		client:     client,
+		services:   services,
+		timestamps: make([]uint64, len(services)),
+	}
+}
+
+// poll fetches the stats each service recorded since the last poll.
+//
+// NOTE: The realtime API holds each request open until it has new data, so
+// the services are polled concurrently.
+func (a *aggregator) poll() []aggregateRow {
+	rows := make([]aggregateRow, len(a.services))
+	var wg sync.WaitGroup
+	for i, s := range a.services {
+		wg.Add(1)
+		go func(i int, s aggregateService) {
+			defer wg.Done()
+			rows[i].Name = s.Name
+			var envelope realtimeResponse
+			err := a.client.GetRealtimeStatsJSON(&fastly.GetRealtimeStatsInput{
+				ServiceID: s.ID,
+				Timestamp: a.timestamps[i],
+			}, &envelope)
+			if err != nil {
+				rows[i].Err = err
+				return
+			}
+			a.timestamps[i] = envelope.Timestamp
+			for _, block := range envelope.Data {
+				if err := rows[i].add(block.Aggregated); err != nil {
+					rows[i].Err = err
+					return
+				}
+			}
+		}(i, s)
+	}
+	wg.Wait()
+	return rows
+}
+
+// render writes a table of the rows followed by their totals.
+func render(out io.Writer, rows []aggregateRow, now time.Time) {
+	fmt.Fprintf(out, "Updated: %s\n\n", now.UTC().Format(time.RFC3339))
+
+	total := aggregateRow{Name: "TOTAL"}
+	t := text.NewTable(out)
+	t.AddHeader("SERVICE", "REQUESTS", "HIT RATIO", "ERRORS", "5XX", "BYTES")
+	for _, r := range rows {
+		if r.Err != nil {
+			t.AddLine(r.Name, "-", "-", "-", "-", fmt.Sprintf("error: %s", r.Err))
+			continue
+		}
+		t.AddLine(r.Name, r.Requests, r.hitRatio(), r.Errors, r.Status5xx, r.Bytes)
+		total.Requests += r.Requests
+		total.Hits += r.Hits
+		total.Miss += r.Miss
+		total.Errors += r.Errors
+		total.Status5xx += r.Status5xx
+		total.Bytes += r.Bytes
+	}
+	t.AddLine(total.Name, total.Requests, total.hitRatio(), total.Errors, total.Status5xx, total.Bytes)
+	t.Print()
+}
+
+// loopAggregate renders the aggregated stats of the services each time they're
+// polled, stopping after the given number of rounds if it's positive.
+//
+// NOTE: A terminal is cleared between rounds so the table refreshes in place,
+// otherwise each round is appended to the output.
+func loopAggregate(client api.RealtimeStatsInterface, services []aggregateService, out io.Writer, rounds int) {
+	live := false
+	if f, ok := out.(*os.File); ok {
+		live = isatty.IsTerminal(f.Fd()) || isatty.IsCygwinTerminal(f.Fd())
+	}
+
+	a := newAggregator(client, services)
+	for round := 1; rounds <= 0 || round <= rounds; round++ {
+		rows := a.poll()
+		if live {
+			fmt.Fprint(out, clearScreen)
+		} else if round > 1 {
+			text.Break(out)
+		}
+		render(out, rows, time.Now())
+
+		for _, r := range rows {
+			if r.Err != nil {
+				time.Sleep(aggregateRetryDelay)
+				break
+			}
+		}
+	}
+}
@@ -0,0 +1,85 @@
+package stats
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/fastly/go-fastly/v6/fastly"
+)
+
+func TestLoopAggregate(t *testing.T) {
+	aggregateRetryDelay = 0
+
+	client := &realtimeClient{
+		responses: map[string][]string{
+			"a": {
+				`{"timestamp":1,"data":[{"recorded":1,"aggregated":{"requests":10,"hits":8,"miss":2,"errors":1,"status_5xx":1,"resp_header_bytes":100,"resp_body_bytes":900}}]}`,
+				`{"timestamp":2,"data":[{"recorded":2,"aggregated":{"requests":5,"hits":5}},{"recorded":3,"aggregated":{"requests":5,"hits":4,"miss":1}}]}`,
+			},
+			"b": {
+				`{"timestamp":7,"data":[{"recorded":1,"aggregated":{"requests":30,"hits":10,"miss":10,"resp_body_bytes":3000}}]}`,
+				"",
+			},
+		},
+	}
+	services := []aggregateService{{ID: "a", Name: "alpha"}, {ID: "b", Name: "beta"}}
+
+	var out bytes.Buffer
+	loopAggregate(client, services, &out, 2)
+
+	for _, want := range []string{
+		"SERVICE  REQUESTS  HIT RATIO  ERRORS  5XX  BYTES",
+		"alpha    10        80.00%     1       1    1000",
+		"beta     30        50.00%     0       0    3000",
+		"TOTAL    40        60.00%     1       1    4000",
+		"alpha    10        90.00%     0       0    0",
+		"beta     -         -          -       -    error: no stats",
+		"TOTAL    10        90.00%     0       0    0",
+	} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("%q doesn't contain %q", out.String(), want)
+		}
+	}
+	if want, have := []uint64{0, 1, 0, 7}, client.timestamps(); !reflect.DeepEqual(want, have) {
+		t.Errorf("want timestamps %v, have %v", want, have)
+	}
+}
+
+// realtimeClient responds to each request for a service with the next of its
+// responses, an empty response being an error.
+type realtimeClient struct {
+	mu        sync.Mutex
+	responses map[string][]string
+	requested map[string][]uint64
+}
+
+func (c *realtimeClient) GetRealtimeStatsJSON(i *fastly.GetRealtimeStatsInput, dst any) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.requested == nil {
+		c.requested = map[string][]uint64{}
+	}
+	c.requested[i.ServiceID] = append(c.requested[i.ServiceID], i.Timestamp)
+
+	rs := c.responses[i.ServiceID]
+	if len(rs) == 0 {
+		return fmt.Errorf("unexpected request for %s", i.ServiceID)
+	}
+	r := rs[0]
+	c.responses[i.ServiceID] = rs[1:]
+	if r == "" {
+		return errors.New("no stats")
+	}
+	return json.Unmarshal([]byte(r), dst)
+}
+
+// timestamps returns the timestamps requested for service a, then service b.
+func (c *realtimeClient) timestamps() []uint64 {
+	return append(append([]uint64{}, c.requested["a"]...), c.requested["b"]...)
+}
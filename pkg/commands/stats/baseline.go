@@ -0,0 +1,164 @@
+package stats
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/fastly/cli/pkg/text"
+	"github.com/fastly/go-fastly/v6/fastly"
+	"github.com/mitchellh/mapstructure"
+)
+
+// parseBaseline parses how far back the baseline period is, either as a
+// number of days (e.g. 7d) or weeks (e.g. 2w), or as a Go duration (e.g. 12h).
+func parseBaseline(s string) (time.Duration, error) {
+	var d time.Duration
+	var err error
+	switch {
+	case strings.HasSuffix(s, "d"), strings.HasSuffix(s, "w"):
+		var n int
+		n, err = strconv.Atoi(s[:len(s)-1])
+		d = time.Duration(n) * 24 * time.Hour
+		if strings.HasSuffix(s, "w") {
+			d *= 7
+		}
+	default:
+		d, err = time.ParseDuration(s)
+	}
+	if err != nil || d <= 0 {
+		return 0, fmt.Errorf("error parsing arguments: invalid --baseline '%s' (expected a positive duration such as 7d, 2w or 12h)", s)
+	}
+	return d, nil
+}
+
+// statsTotals are the totals of the metrics compared against the baseline.
+type statsTotals struct {
+	Requests  uint64
+	Hits      uint64
+	Miss      uint64
+	Pass      uint64
+	Errors    uint64
+	Status4xx uint64
+	Status5xx uint64
+	Bandwidth uint64
+}
+
+// totalStats sums the metrics of the blocks.
+func totalStats(blocks []statsResponseData) (statsTotals, error) {
+	var t statsTotals
+	for _, block := range blocks {
+		var s fastly.Stats
+		if err := mapstructure.Decode(block, &s); err != nil {
+			return t, err
+		}
+		t.Requests += s.Requests
+		t.Hits += s.Hits
+		t.Miss += s.Miss
+		t.Pass += s.Pass
+		t.Errors += s.Errors
+		t.Status4xx += s.Status4xx
+		t.Status5xx += s.Status5xx
+		t.Bandwidth += s.Bandwidth
+	}
+	return t, nil
+}
+
+// hitRatio returns the ratio of hits to cacheable requests, as a percentage.
+func (t statsTotals) hitRatio() float64 {
+	if t.Hits+t.Miss == 0 {
+		return 0
+	}
+	return float64(t.Hits) / float64(t.Hits+t.Miss) * 100
+}
+
+// Comparison is a metric of the requested period compared with the baseline
+// period.
+type Comparison struct {
+	Metric   string  `json:"metric"`
+	Current  float64 `json:"current"`
+	Baseline float64 `json:"baseline"`
+	// Change is the percentage change from the baseline, which is +Inf when
+	// the baseline is zero (see MarshalJSON).
+	Change  float64 `json:"-"`
+	Anomaly bool    `json:"anomaly"`
+}
+
+// MarshalJSON implements json.Marshaler, representing an infinite change as
+// null.
+func (c Comparison) MarshalJSON() ([]byte, error) {
+	type alias Comparison
+	var change *float64
+	if !math.IsInf(c.Change, 0) {
+		change = &c.Change
+	}
+	return json.Marshal(struct {
+		alias
+		Change *float64 `json:"change"`
+	}{alias(c), change})
+}
+
+// compareStats compares the totals of the requested period with those of the
+// baseline period, flagging changes greater than the threshold percentage.
+func compareStats(current, baseline statsTotals, threshold float64) []Comparison {
+	metrics := []struct {
+		name              string
+		current, baseline float64
+	}{
+		{"Requests", float64(current.Requests), float64(baseline.Requests)},
+		{"Hit ratio (%)", current.hitRatio(), baseline.hitRatio()},
+		{"Pass", float64(current.Pass), float64(baseline.Pass)},
+		{"Errors", float64(current.Errors), float64(baseline.Errors)},
+		{"4xx responses", float64(current.Status4xx), float64(baseline.Status4xx)},
+		{"5xx responses", float64(current.Status5xx), float64(baseline.Status5xx)},
+		{"Bandwidth (bytes)", float64(current.Bandwidth), float64(baseline.Bandwidth)},
+	}
+
+	cs := make([]Comparison, 0, len(metrics))
+	for _, m := range metrics {
+		c := Comparison{Metric: m.name, Current: m.current, Baseline: m.baseline}
+		switch {
+		case m.baseline != 0:
+			c.Change = (m.current - m.baseline) / m.baseline * 100
+		case m.current != 0:
+			c.Change = math.Inf(1)
+		}
+		c.Anomaly = math.Abs(c.Change) > threshold
+		cs = append(cs, c)
+	}
+	return cs
+}
+
+// writeComparison displays the comparisons in a table.
+func writeComparison(out io.Writer, current, baseline statsResponseMeta, cs []Comparison) {
+	fmt.Fprintf(out, "Current:  %s to %s\n", current.From, current.To)
+	fmt.Fprintf(out, "Baseline: %s to %s\n", baseline.From, baseline.To)
+	text.Break(out)
+
+	t := text.NewTable(out)
+	t.AddHeader("METRIC", "CURRENT", "BASELINE", "CHANGE", "ANOMALY")
+	for _, c := range cs {
+		change := fmt.Sprintf("%+.1f%%", c.Change)
+		if math.IsInf(c.Change, 0) {
+			change = "new"
+		}
+		anomaly := "no"
+		if c.Anomaly {
+			anomaly = "yes"
+		}
+		t.AddLine(c.Metric, formatMetric(c.Current), formatMetric(c.Baseline), change, anomaly)
+	}
+	t.Print()
+}
+
+// formatMetric formats a metric without decimals unless it has a fraction.
+func formatMetric(v float64) string {
+	if v == math.Trunc(v) {
+		return strconv.FormatFloat(v, 'f', 0, 64)
+	}
+	return strconv.FormatFloat(v, 'f', 2, 64)
+}
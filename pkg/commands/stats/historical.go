@@ -4,9 +4,13 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/fastly/cli/pkg/cmd"
 	"github.com/fastly/cli/pkg/config"
+	fsterr "github.com/fastly/cli/pkg/errors"
 	"github.com/fastly/cli/pkg/manifest"
 	"github.com/fastly/go-fastly/v6/fastly"
 )
@@ -19,8 +23,13 @@ type HistoricalCommand struct {
 	manifest manifest.Data
 
 	Input       fastly.GetStatsInput
+	baseline    string
 	formatFlag  string
 	serviceName cmd.OptionalServiceNameID
+	threshold   float64
+
+	// offset is how far before the requested period the baseline period is.
+	offset time.Duration
 }
 
 // NewHistoricalCommand is the "stats historical" subcommand.
@@ -49,12 +58,25 @@ func NewHistoricalCommand(parent cmd.Registerer, globals *config.Data, data mani
 	c.CmdClause.Flag("region", "Filter by region ('stats regions' to list)").StringVar(&c.Input.Region)
 
 	c.CmdClause.Flag("format", "Output format (json)").EnumVar(&c.formatFlag, "json")
+	c.CmdClause.Flag("baseline", "Compare the totals with the same period this long before (e.g. 7d, 2w, 12h), exiting with an error if a metric changed by more than --threshold").StringVar(&c.baseline)
+	c.CmdClause.Flag("threshold", "The percentage change from the --baseline period considered an anomaly").Default("20").Float64Var(&c.threshold)
 
 	return &c
 }
 
 // Exec implements the command interface.
 func (c *HistoricalCommand) Exec(_ io.Reader, out io.Writer) error {
+	if c.baseline != "" {
+		offset, err := parseBaseline(c.baseline)
+		if err != nil {
+			return err
+		}
+		if c.threshold <= 0 {
+			return fmt.Errorf("error parsing arguments: --threshold must be positive")
+		}
+		c.offset = offset
+	}
+
 	serviceID, source, flag, err := cmd.ServiceID(c.serviceName, c.manifest, c.Globals.APIClient, c.Globals.ErrLog)
 	if err != nil {
 		return err
@@ -78,6 +100,10 @@ func (c *HistoricalCommand) Exec(_ io.Reader, out io.Writer) error {
 		return fmt.Errorf("non-success response: %s", envelope.Msg)
 	}
 
+	if c.baseline != "" {
+		return c.compareBaseline(out, serviceID, envelope)
+	}
+
 	switch c.formatFlag {
 	case "json":
 		err := writeBlocksJSON(out, serviceID, envelope.Data)
@@ -100,6 +126,71 @@ func (c *HistoricalCommand) Exec(_ io.Reader, out io.Writer) error {
 	return nil
 }
 
+// compareBaseline fetches the stats of the period the --baseline before the
+// requested period, and compares the totals of the two.
+func (c *HistoricalCommand) compareBaseline(out io.Writer, serviceID string, current statsResponse) error {
+	// NOTE: The period is taken from the response rather than the flags, as
+	// the API accepts relative times (e.g. "1 day ago") we can't parse.
+	from, errFrom := time.Parse(time.UnixDate, current.Meta.From)
+	to, errTo := time.Parse(time.UnixDate, current.Meta.To)
+	if errFrom != nil || errTo != nil {
+		err := fmt.Errorf("error parsing the period of the stats: %s to %s", current.Meta.From, current.Meta.To)
+		c.Globals.ErrLog.Add(err)
+		return err
+	}
+
+	input := c.Input
+	input.From = strconv.FormatInt(from.Add(-c.offset).Unix(), 10)
+	input.To = strconv.FormatInt(to.Add(-c.offset).Unix(), 10)
+
+	var baseline statsResponse
+	err := c.Globals.APIClient.GetStatsJSON(&input, &baseline)
+	if err != nil {
+		c.Globals.ErrLog.AddWithContext(err, map[string]any{
+			"Service ID": serviceID,
+			"From":       input.From,
+			"To":         input.To,
+		})
+		return err
+	}
+	if baseline.Status != statusSuccess {
+		return fmt.Errorf("non-success response: %s", baseline.Msg)
+	}
+
+	currentTotals, err := totalStats(current.Data)
+	if err != nil {
+		return err
+	}
+	baselineTotals, err := totalStats(baseline.Data)
+	if err != nil {
+		return err
+	}
+	cs := compareStats(currentTotals, baselineTotals, c.threshold)
+
+	if c.formatFlag == "json" {
+		if err := json.NewEncoder(out).Encode(cs); err != nil {
+			c.Globals.ErrLog.Add(err)
+			return fmt.Errorf("error: unable to write data to stdout: %w", err)
+		}
+	} else {
+		writeComparison(out, current.Meta, baseline.Meta, cs)
+	}
+
+	var anomalies []string
+	for _, c := range cs {
+		if c.Anomaly {
+			anomalies = append(anomalies, c.Metric)
+		}
+	}
+	if len(anomalies) > 0 {
+		return fsterr.RemediationError{
+			Inner:       fmt.Errorf("%d metric(s) changed by more than %g%% from the baseline: %s", len(anomalies), c.threshold, strings.Join(anomalies, ", ")),
+			Remediation: "Investigate the changes, or raise the --threshold if they're expected.",
+		}
+	}
+	return nil
+}
+
 func writeHeader(out io.Writer, meta statsResponseMeta) {
 	fmt.Fprintf(out, "From: %s\n", meta.From)
 	fmt.Fprintf(out, "To: %s\n", meta.To)
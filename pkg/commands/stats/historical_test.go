@@ -3,6 +3,7 @@ package stats_test
 import (
 	"bytes"
 	"encoding/json"
+	"fmt"
 	"strings"
 	"testing"
 
@@ -101,3 +102,93 @@ func getStatsJSONOK(i *fastly.GetStatsInput, o any) error {
 func getStatsJSONError(i *fastly.GetStatsInput, o any) error {
 	return errTest
 }
+
+func TestHistoricalBaseline(t *testing.T) {
+	args := testutil.Args
+	scenarios := []struct {
+		args       []string
+		api        mock.API
+		wantError  string
+		wantOutput string
+	}{
+		{
+			args:      args("stats historical --service-id=123 --baseline 7x"),
+			wantError: "error parsing arguments: invalid --baseline '7x'",
+		},
+		{
+			args:      args("stats historical --service-id=123 --baseline 7d --threshold 0"),
+			wantError: "error parsing arguments: --threshold must be positive",
+		},
+		{
+			args:       args("stats historical --service-id=123 --baseline 7d"),
+			api:        mock.API{GetStatsJSONFn: getStatsJSONBaseline(`{"requests":1050,"hits":900,"miss":100,"bandwidth":10000}`)},
+			wantOutput: baselineOK,
+		},
+		{
+			args:       args("stats historical --service-id=123 --baseline 1w --threshold 10"),
+			api:        mock.API{GetStatsJSONFn: getStatsJSONBaseline(`{"requests":1300,"hits":900,"miss":100,"status_5xx":25,"bandwidth":10000}`)},
+			wantError:  "2 metric(s) changed by more than 10% from the baseline: Requests, 5xx responses",
+			wantOutput: "5xx responses      25       0         new     yes",
+		},
+		{
+			args:       args("stats historical --service-id=123 --baseline 7d --format json"),
+			api:        mock.API{GetStatsJSONFn: getStatsJSONBaseline(`{"requests":1000,"hits":900,"miss":100,"bandwidth":10000}`)},
+			wantOutput: `{"metric":"Requests","current":1000,"baseline":1000,"anomaly":false,"change":0}`,
+		},
+		{
+			args:      args("stats historical --service-id=123 --baseline 7d"),
+			api:       mock.API{GetStatsJSONFn: getStatsJSONBaselineError},
+			wantError: errTest.Error(),
+		},
+	}
+	for testcaseIdx := range scenarios {
+		testcase := &scenarios[testcaseIdx]
+		t.Run(strings.Join(testcase.args, " "), func(t *testing.T) {
+			var stdout bytes.Buffer
+			opts := testutil.NewRunOpts(testcase.args, &stdout)
+			opts.APIClient = mock.APIClient(testcase.api)
+			err := app.Run(opts)
+			testutil.AssertErrorContains(t, err, testcase.wantError)
+			testutil.AssertStringContains(t, stdout.String(), testcase.wantOutput)
+		})
+	}
+}
+
+var baselineOK = `Current:  Wed May 15 20:08:35 UTC 2013 to Thu May 16 20:08:35 UTC 2013
+Baseline: Wed May 08 20:08:35 UTC 2013 to Thu May 09 20:08:35 UTC 2013
+
+METRIC             CURRENT  BASELINE  CHANGE  ANOMALY
+Requests           1050     1000      +5.0%   no
+Hit ratio (%)      90       90        +0.0%   no
+Pass               0        0         +0.0%   no
+Errors             0        0         +0.0%   no
+4xx responses      0        0         +0.0%   no
+5xx responses      0        0         +0.0%   no
+Bandwidth (bytes)  10000    10000     +0.0%   no
+`
+
+// getStatsJSONBaseline returns the given stats for the requested period, and
+// those of baselineStats for the period a week before.
+func getStatsJSONBaseline(current string) func(i *fastly.GetStatsInput, o any) error {
+	return func(i *fastly.GetStatsInput, o any) error {
+		meta := `{"from":"Wed May 15 20:08:35 UTC 2013","to":"Thu May 16 20:08:35 UTC 2013","by":"day","region":"all"}`
+		data := current
+		if i.From != "" {
+			if i.From != "1368043715" || i.To != "1368130115" {
+				return fmt.Errorf("unexpected baseline period: %s to %s", i.From, i.To)
+			}
+			meta = `{"from":"Wed May 08 20:08:35 UTC 2013","to":"Thu May 09 20:08:35 UTC 2013","by":"day","region":"all"}`
+			data = baselineStats
+		}
+		return json.Unmarshal([]byte(`{"status":"success","meta":`+meta+`,"data":[`+data+`]}`), o)
+	}
+}
+
+var baselineStats = `{"requests":1000,"hits":900,"miss":100,"bandwidth":10000}`
+
+func getStatsJSONBaselineError(i *fastly.GetStatsInput, o any) error {
+	if i.From != "" {
+		return errTest
+	}
+	return getStatsJSONOK(i, o)
+}
@@ -4,10 +4,13 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"path"
+	"strings"
 
 	"github.com/fastly/cli/pkg/api"
 	"github.com/fastly/cli/pkg/cmd"
 	"github.com/fastly/cli/pkg/config"
+	fsterr "github.com/fastly/cli/pkg/errors"
 	"github.com/fastly/cli/pkg/manifest"
 	"github.com/fastly/cli/pkg/text"
 	"github.com/fastly/go-fastly/v6/fastly"
@@ -18,8 +21,11 @@ type RealtimeCommand struct {
 	cmd.Base
 	manifest manifest.Data
 
+	aggregate   bool
+	filter      string
 	formatFlag  string
 	serviceName cmd.OptionalServiceNameID
+	services    string
 }
 
 // NewRealtimeCommand is the "stats realtime" subcommand.
@@ -43,12 +49,25 @@ func NewRealtimeCommand(parent cmd.Registerer, globals *config.Data, data manife
 	})
 
 	c.CmdClause.Flag("format", "Output format (json)").EnumVar(&c.formatFlag, "json")
+	c.CmdClause.Flag("aggregate", "Show a live table of several services, with a row per service and a row of their totals").BoolVar(&c.aggregate)
+	c.CmdClause.Flag("services", "Comma-separated IDs of the services to aggregate (requires --aggregate)").StringVar(&c.services)
+	c.CmdClause.Flag("filter", "Aggregate the services whose name matches this glob pattern, e.g. 'prod-*' (requires --aggregate)").StringVar(&c.filter)
 
 	return &c
 }
 
 // Exec implements the command interface.
 func (c *RealtimeCommand) Exec(_ io.Reader, out io.Writer) error {
+	if !c.aggregate && (c.services != "" || c.filter != "") {
+		return fsterr.RemediationError{
+			Inner:       fmt.Errorf("invalid flag combination, --services and --filter require --aggregate"),
+			Remediation: "Add the --aggregate flag, or use --service-id to view a single service.",
+		}
+	}
+	if c.aggregate {
+		return c.execAggregate(out)
+	}
+
 	serviceID, source, flag, err := cmd.ServiceID(c.serviceName, c.manifest, c.Globals.APIClient, c.Globals.ErrLog)
 	if err != nil {
 		return err
@@ -78,6 +97,53 @@ func (c *RealtimeCommand) Exec(_ io.Reader, out io.Writer) error {
 	return nil
 }
 
+// execAggregate renders the realtime stats of several services.
+func (c *RealtimeCommand) execAggregate(out io.Writer) error {
+	if c.formatFlag == "json" {
+		return fsterr.RemediationError{
+			Inner:       fmt.Errorf("invalid flag combination, --aggregate and --format=json"),
+			Remediation: "Use --format=json with a single service.",
+		}
+	}
+
+	var services []aggregateService
+	for _, id := range strings.Split(c.services, ",") {
+		if id = strings.TrimSpace(id); id != "" {
+			services = append(services, aggregateService{ID: id, Name: id})
+		}
+	}
+	if serviceID, source := c.manifest.ServiceID(); source == manifest.SourceFlag {
+		services = append(services, aggregateService{ID: serviceID, Name: serviceID})
+	}
+
+	if c.filter != "" {
+		if _, err := path.Match(c.filter, ""); err != nil {
+			return fmt.Errorf("error parsing arguments: invalid --filter pattern: %w", err)
+		}
+		paginator := c.Globals.APIClient.NewListServicesPaginator(&fastly.ListServicesInput{})
+		ss, err := cmd.Paginate[*fastly.Service](paginator, cmd.Pagination{})
+		if err != nil {
+			c.Globals.ErrLog.Add(err)
+			return err
+		}
+		for _, s := range ss {
+			if ok, _ := path.Match(c.filter, s.Name); ok {
+				services = append(services, aggregateService{ID: s.ID, Name: s.Name})
+			}
+		}
+	}
+
+	if len(services) == 0 {
+		return fsterr.RemediationError{
+			Inner:       fmt.Errorf("no services to aggregate"),
+			Remediation: "Provide the IDs of the services with --services, or a pattern matching their names with --filter.",
+		}
+	}
+
+	loopAggregate(c.Globals.RTSClient, services, out, 0)
+	return nil
+}
+
 func loopJSON(client api.RealtimeStatsInterface, service string, out io.Writer) error {
 	var timestamp uint64
 	for {
@@ -0,0 +1,50 @@
+package stats_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/fastly/cli/pkg/app"
+	"github.com/fastly/cli/pkg/mock"
+	"github.com/fastly/cli/pkg/testutil"
+)
+
+func TestRealtimeAggregate(t *testing.T) {
+	args := testutil.Args
+	scenarios := []struct {
+		args      []string
+		wantError string
+	}{
+		{
+			args:      args("stats realtime --services 123,456"),
+			wantError: "invalid flag combination, --services and --filter require --aggregate",
+		},
+		{
+			args:      args("stats realtime --filter prod-*"),
+			wantError: "invalid flag combination, --services and --filter require --aggregate",
+		},
+		{
+			args:      args("stats realtime --aggregate --services 123 --format json"),
+			wantError: "invalid flag combination, --aggregate and --format=json",
+		},
+		{
+			args:      args("stats realtime --aggregate"),
+			wantError: "no services to aggregate",
+		},
+		{
+			args:      args("stats realtime --aggregate --filter [prod"),
+			wantError: "error parsing arguments: invalid --filter pattern",
+		},
+	}
+	for testcaseIdx := range scenarios {
+		testcase := &scenarios[testcaseIdx]
+		t.Run(strings.Join(testcase.args, " "), func(t *testing.T) {
+			var stdout bytes.Buffer
+			opts := testutil.NewRunOpts(testcase.args, &stdout)
+			opts.APIClient = mock.APIClient(mock.API{})
+			err := app.Run(opts)
+			testutil.AssertErrorContains(t, err, testcase.wantError)
+		})
+	}
+}
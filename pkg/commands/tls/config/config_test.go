@@ -134,6 +134,43 @@ func TestList(t *testing.T) {
 	}
 }
 
+func TestListPagination(t *testing.T) {
+	list := func(pages *[]int) func(i *fastly.ListCustomTLSConfigurationsInput) ([]*fastly.CustomTLSConfiguration, error) {
+		return func(i *fastly.ListCustomTLSConfigurationsInput) ([]*fastly.CustomTLSConfiguration, error) {
+			*pages = append(*pages, i.PageNumber)
+			if i.PageNumber > 2 {
+				return nil, nil
+			}
+			var rs []*fastly.CustomTLSConfiguration
+			for n := 0; n < i.PageSize; n++ {
+				rs = append(rs, &fastly.CustomTLSConfiguration{ID: fmt.Sprintf("%d-%d", i.PageNumber, n)})
+			}
+			return rs, nil
+		}
+	}
+
+	for _, tc := range []struct {
+		args      string
+		wantPages []int
+	}{
+		{args: "tls-config list --per-page 2", wantPages: []int{1, 2, 3}},
+		{args: "tls-config list --all --per-page 2", wantPages: []int{1, 2, 3}},
+		{args: "tls-config list --page 2 --per-page 2", wantPages: []int{2}},
+	} {
+		t.Run(tc.args, func(t *testing.T) {
+			var pages []int
+			var stdout bytes.Buffer
+			opts := testutil.NewRunOpts(testutil.Args(tc.args), &stdout)
+			opts.APIClient = mock.APIClient(mock.API{
+				ListCustomTLSConfigurationsFn: list(&pages),
+			})
+			err := app.Run(opts)
+			testutil.AssertNoError(t, err)
+			testutil.AssertEqual(t, tc.wantPages, pages)
+		})
+	}
+}
+
 func TestUpdate(t *testing.T) {
 	args := testutil.Args
 	scenarios := []testutil.TestScenario{
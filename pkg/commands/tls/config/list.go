@@ -22,6 +22,7 @@ func NewListCommand(parent cmd.Registerer, globals *config.Data, data manifest.D
 	c.manifest = data
 
 	// Optional Flags
+	c.RegisterAllFlag(&c.pagination)
 	c.CmdClause.Flag("filter-bulk", "Optionally filter by the bulk attribute").Action(c.filterBulk.Set).BoolVar(&c.filterBulk.Value)
 	c.CmdClause.Flag("include", "Include related objects (comma-separated values)").HintOptions(include).EnumVar(&c.include, include)
 	c.RegisterFlagBool(cmd.BoolFlagOpts{
@@ -30,8 +31,7 @@ func NewListCommand(parent cmd.Registerer, globals *config.Data, data manifest.D
 		Dst:         &c.json,
 		Short:       'j',
 	})
-	c.CmdClause.Flag("page", "Page number of data set to fetch").IntVar(&c.pageNumber)
-	c.CmdClause.Flag("per-page", "Number of records per page").IntVar(&c.pageSize)
+	c.RegisterPageFlags(&c.pagination)
 
 	return &c
 }
@@ -44,8 +44,7 @@ type ListCommand struct {
 	include    string
 	json       bool
 	manifest   manifest.Data
-	pageNumber int
-	pageSize   int
+	pagination cmd.Pagination
 }
 
 // Exec invokes the application logic for the command.
@@ -53,16 +52,23 @@ func (c *ListCommand) Exec(_ io.Reader, out io.Writer) error {
 	if c.Globals.Verbose() && c.json {
 		return fsterr.ErrInvalidVerboseJSONCombo
 	}
+	if err := c.pagination.Validate(); err != nil {
+		return err
+	}
 
 	input := c.constructInput()
 
-	rs, err := c.Globals.APIClient.ListCustomTLSConfigurations(input)
+	rs, err := cmd.FetchPages(c.pagination, func(page, perPage int) ([]*fastly.CustomTLSConfiguration, error) {
+		input.PageNumber = page
+		input.PageSize = perPage
+		return c.Globals.APIClient.ListCustomTLSConfigurations(input)
+	})
 	if err != nil {
 		c.Globals.ErrLog.AddWithContext(err, map[string]any{
 			"Filter Bulk": c.filterBulk,
 			"Include":     c.include,
-			"Page Number": c.pageNumber,
-			"Page Size":   c.pageSize,
+			"Page Number": c.pagination.Page,
+			"Page Size":   c.pagination.PerPage,
 		})
 		return err
 	}
@@ -88,12 +94,6 @@ func (c *ListCommand) constructInput() *fastly.ListCustomTLSConfigurationsInput
 	if c.include != "" {
 		input.Include = c.include
 	}
-	if c.pageNumber > 0 {
-		input.PageNumber = c.pageNumber
-	}
-	if c.pageSize > 0 {
-		input.PageSize = c.pageSize
-	}
 
 	return &input
 }
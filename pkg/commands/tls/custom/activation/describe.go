@@ -1,7 +1,6 @@
 package activation
 
 import (
-	"encoding/json"
 	"fmt"
 	"io"
 
@@ -29,9 +28,10 @@ func NewDescribeCommand(parent cmd.Registerer, globals *config.Data, data manife
 	c.RegisterFlagBool(cmd.BoolFlagOpts{
 		Name:        cmd.FlagJSONName,
 		Description: cmd.FlagJSONDesc,
-		Dst:         &c.json,
+		Dst:         &c.output.JSON,
 		Short:       'j',
 	})
+	c.RegisterOutputFlags(&c.output)
 
 	return &c
 }
@@ -42,16 +42,24 @@ type DescribeCommand struct {
 
 	id       string
 	include  string
-	json     bool
+	output   cmd.Output
 	manifest manifest.Data
 }
 
 // Exec invokes the application logic for the command.
-func (c *DescribeCommand) Exec(_ io.Reader, out io.Writer) error {
-	if c.Globals.Verbose() && c.json {
+func (c *DescribeCommand) Exec(_ io.Reader, out io.Writer) (err error) {
+	if c.Globals.Verbose() && c.output.Structured() {
 		return fsterr.ErrInvalidVerboseJSONCombo
 	}
 
+	out, closeOutput, err := c.output.Writer(out)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err = closeOutput(err)
+	}()
+
 	input := c.constructInput()
 
 	r, err := c.Globals.APIClient.GetTLSActivation(input)
@@ -84,15 +92,10 @@ func (c *DescribeCommand) constructInput() *fastly.GetTLSActivationInput {
 
 // print displays the information returned from the API.
 func (c *DescribeCommand) print(out io.Writer, r *fastly.TLSActivation) error {
-	if c.json {
-		data, err := json.Marshal(r)
-		if err != nil {
-			return err
-		}
-		_, err = out.Write(data)
-		if err != nil {
+	if c.output.Structured() {
+		if err := c.output.Write(out, r); err != nil {
 			c.Globals.ErrLog.Add(err)
-			return fmt.Errorf("error: unable to write data to stdout: %w", err)
+			return err
 		}
 		return nil
 	}
@@ -21,6 +21,7 @@ func NewListCommand(parent cmd.Registerer, globals *config.Data, data manifest.D
 	c.manifest = data
 
 	// Optional Flags
+	c.RegisterAllFlag(&c.pagination)
 	c.CmdClause.Flag("filter-cert", "Limit the returned activations to a specific certificate").StringVar(&c.filterTLSCertID)
 	c.CmdClause.Flag("filter-config", "Limit the returned activations to a specific TLS configuration").StringVar(&c.filterTLSConfigID)
 	c.CmdClause.Flag("filter-domain", "Limit the returned rules to a specific domain name").StringVar(&c.filterTLSDomainID)
@@ -31,8 +32,7 @@ func NewListCommand(parent cmd.Registerer, globals *config.Data, data manifest.D
 		Dst:         &c.json,
 		Short:       'j',
 	})
-	c.CmdClause.Flag("page", "Page number of data set to fetch").IntVar(&c.pageNumber)
-	c.CmdClause.Flag("per-page", "Number of records per page").IntVar(&c.pageSize)
+	c.RegisterPageFlags(&c.pagination)
 
 	return &c
 }
@@ -47,8 +47,7 @@ type ListCommand struct {
 	include           string
 	json              bool
 	manifest          manifest.Data
-	pageNumber        int
-	pageSize          int
+	pagination        cmd.Pagination
 }
 
 // Exec invokes the application logic for the command.
@@ -56,18 +55,25 @@ func (c *ListCommand) Exec(_ io.Reader, out io.Writer) error {
 	if c.Globals.Verbose() && c.json {
 		return fsterr.ErrInvalidVerboseJSONCombo
 	}
+	if err := c.pagination.Validate(); err != nil {
+		return err
+	}
 
 	input := c.constructInput()
 
-	rs, err := c.Globals.APIClient.ListTLSActivations(input)
+	rs, err := cmd.FetchPages(c.pagination, func(page, perPage int) ([]*fastly.TLSActivation, error) {
+		input.PageNumber = page
+		input.PageSize = perPage
+		return c.Globals.APIClient.ListTLSActivations(input)
+	})
 	if err != nil {
 		c.Globals.ErrLog.AddWithContext(err, map[string]any{
 			"Filter TLS Certificate ID":   c.filterTLSCertID,
 			"Filter TLS Configuration ID": c.filterTLSConfigID,
 			"Filter TLS Domain ID":        c.filterTLSDomainID,
 			"Include":                     c.include,
-			"Page Number":                 c.pageNumber,
-			"Page Size":                   c.pageSize,
+			"Page Number":                 c.pagination.Page,
+			"Page Size":                   c.pagination.PerPage,
 		})
 		return err
 	}
@@ -99,12 +105,6 @@ func (c *ListCommand) constructInput() *fastly.ListTLSActivationsInput {
 	if c.include != "" {
 		input.Include = c.include
 	}
-	if c.pageNumber > 0 {
-		input.PageNumber = c.pageNumber
-	}
-	if c.pageSize > 0 {
-		input.PageSize = c.pageSize
-	}
 
 	return &input
 }
@@ -23,6 +23,7 @@ func NewListCommand(parent cmd.Registerer, globals *config.Data, data manifest.D
 	c.manifest = data
 
 	// Optional Flags
+	c.RegisterAllFlag(&c.pagination)
 	c.CmdClause.Flag("filter-not-after", "Limit the returned certificates to those that expire prior to the specified date in UTC").StringVar(&c.filterNotAfter)
 	c.CmdClause.Flag("filter-domain", "Limit the returned certificates to those that include the specific domain").StringVar(&c.filterTLSDomainID)
 	c.CmdClause.Flag("include", "Include related objects (comma-separated values)").HintOptions("tls_activations").EnumVar(&c.include, "tls_activations")
@@ -32,8 +33,7 @@ func NewListCommand(parent cmd.Registerer, globals *config.Data, data manifest.D
 		Dst:         &c.json,
 		Short:       'j',
 	})
-	c.CmdClause.Flag("page", "Page number of data set to fetch").IntVar(&c.pageNumber)
-	c.CmdClause.Flag("per-page", "Number of records per page").IntVar(&c.pageSize)
+	c.RegisterPageFlags(&c.pagination)
 	c.CmdClause.Flag("sort", "The order in which to list the results by creation date").StringVar(&c.sort)
 
 	return &c
@@ -48,8 +48,7 @@ type ListCommand struct {
 	include           string
 	json              bool
 	manifest          manifest.Data
-	pageNumber        int
-	pageSize          int
+	pagination        cmd.Pagination
 	sort              string
 }
 
@@ -58,17 +57,24 @@ func (c *ListCommand) Exec(_ io.Reader, out io.Writer) error {
 	if c.Globals.Verbose() && c.json {
 		return fsterr.ErrInvalidVerboseJSONCombo
 	}
+	if err := c.pagination.Validate(); err != nil {
+		return err
+	}
 
 	input := c.constructInput()
 
-	rs, err := c.Globals.APIClient.ListCustomTLSCertificates(input)
+	rs, err := cmd.FetchPages(c.pagination, func(page, perPage int) ([]*fastly.CustomTLSCertificate, error) {
+		input.PageNumber = page
+		input.PageSize = perPage
+		return c.Globals.APIClient.ListCustomTLSCertificates(input)
+	})
 	if err != nil {
 		c.Globals.ErrLog.AddWithContext(err, map[string]any{
 			"Filter Not After":     c.filterNotAfter,
 			"Filter TLS Domain ID": c.filterTLSDomainID,
 			"Include":              c.include,
-			"Page Number":          c.pageNumber,
-			"Page Size":            c.pageSize,
+			"Page Number":          c.pagination.Page,
+			"Page Size":            c.pagination.PerPage,
 			"Sort":                 c.sort,
 		})
 		return err
@@ -98,12 +104,6 @@ func (c *ListCommand) constructInput() *fastly.ListCustomTLSCertificatesInput {
 	if c.include != emptyString {
 		input.Include = c.include
 	}
-	if c.pageNumber > 0 {
-		input.PageNumber = c.pageNumber
-	}
-	if c.pageSize > 0 {
-		input.PageSize = c.pageSize
-	}
 	if c.sort != "" {
 		input.Sort = c.sort
 	}
@@ -23,6 +23,7 @@ func NewListCommand(parent cmd.Registerer, globals *config.Data, data manifest.D
 	c.manifest = data
 
 	// Optional Flags
+	c.RegisterAllFlag(&c.pagination)
 	c.CmdClause.Flag("filter-cert", "Limit the returned domains to those listed in the given TLS certificate's SAN list").StringVar(&c.filterTLSCertsID)
 	c.CmdClause.Flag("filter-in-use", "Limit the returned domains to those currently using Fastly to terminate TLS with SNI").Action(c.filterInUse.Set).BoolVar(&c.filterInUse.Value)
 	c.CmdClause.Flag("filter-subscription", "Limit the returned domains to those for a given TLS subscription").StringVar(&c.filterTLSSubsID)
@@ -33,8 +34,7 @@ func NewListCommand(parent cmd.Registerer, globals *config.Data, data manifest.D
 		Dst:         &c.json,
 		Short:       'j',
 	})
-	c.CmdClause.Flag("page", "Page number of data set to fetch").IntVar(&c.pageNumber)
-	c.CmdClause.Flag("per-page", "Number of records per page").IntVar(&c.pageSize)
+	c.RegisterPageFlags(&c.pagination)
 	c.CmdClause.Flag("sort", "The order in which to list the results by creation date").StringVar(&c.sort)
 
 	return &c
@@ -50,8 +50,7 @@ type ListCommand struct {
 	include          string
 	json             bool
 	manifest         manifest.Data
-	pageNumber       int
-	pageSize         int
+	pagination       cmd.Pagination
 	sort             string
 }
 
@@ -60,18 +59,25 @@ func (c *ListCommand) Exec(_ io.Reader, out io.Writer) error {
 	if c.Globals.Verbose() && c.json {
 		return fsterr.ErrInvalidVerboseJSONCombo
 	}
+	if err := c.pagination.Validate(); err != nil {
+		return err
+	}
 
 	input := c.constructInput()
 
-	rs, err := c.Globals.APIClient.ListTLSDomains(input)
+	rs, err := cmd.FetchPages(c.pagination, func(page, perPage int) ([]*fastly.TLSDomain, error) {
+		input.PageNumber = page
+		input.PageSize = perPage
+		return c.Globals.APIClient.ListTLSDomains(input)
+	})
 	if err != nil {
 		c.Globals.ErrLog.AddWithContext(err, map[string]any{
 			"Filter In Use":            c.filterInUse,
 			"Filter TLS Certificates":  c.filterTLSCertsID,
 			"Filter TLS Subscriptions": c.filterTLSSubsID,
 			"Include":                  c.include,
-			"Page Number":              c.pageNumber,
-			"Page Size":                c.pageSize,
+			"Page Number":              c.pagination.Page,
+			"Page Size":                c.pagination.PerPage,
 			"Sort":                     c.sort,
 		})
 		return err
@@ -104,12 +110,6 @@ func (c *ListCommand) constructInput() *fastly.ListTLSDomainsInput {
 	if c.include != emptyString {
 		input.Include = c.include
 	}
-	if c.pageNumber > 0 {
-		input.PageNumber = c.pageNumber
-	}
-	if c.pageSize > 0 {
-		input.PageSize = c.pageSize
-	}
 	if c.sort != "" {
 		input.Sort = c.sort
 	}
@@ -21,6 +21,7 @@ func NewListCommand(parent cmd.Registerer, globals *config.Data, data manifest.D
 	c.manifest = data
 
 	// Optional Flags
+	c.RegisterAllFlag(&c.pagination)
 	c.CmdClause.Flag("filter-in-use", "Limit the returned keys to those without any matching TLS certificates").HintOptions("false").EnumVar(&c.filterInUse, "false")
 	c.RegisterFlagBool(cmd.BoolFlagOpts{
 		Name:        cmd.FlagJSONName,
@@ -28,8 +29,7 @@ func NewListCommand(parent cmd.Registerer, globals *config.Data, data manifest.D
 		Dst:         &c.json,
 		Short:       'j',
 	})
-	c.CmdClause.Flag("page", "Page number of data set to fetch").IntVar(&c.pageNumber)
-	c.CmdClause.Flag("per-page", "Number of records per page").IntVar(&c.pageSize)
+	c.RegisterPageFlags(&c.pagination)
 
 	return &c
 }
@@ -41,8 +41,7 @@ type ListCommand struct {
 	filterInUse string
 	json        bool
 	manifest    manifest.Data
-	pageNumber  int
-	pageSize    int
+	pagination  cmd.Pagination
 }
 
 // Exec invokes the application logic for the command.
@@ -50,15 +49,22 @@ func (c *ListCommand) Exec(_ io.Reader, out io.Writer) error {
 	if c.Globals.Verbose() && c.json {
 		return fsterr.ErrInvalidVerboseJSONCombo
 	}
+	if err := c.pagination.Validate(); err != nil {
+		return err
+	}
 
 	input := c.constructInput()
 
-	rs, err := c.Globals.APIClient.ListPrivateKeys(input)
+	rs, err := cmd.FetchPages(c.pagination, func(page, perPage int) ([]*fastly.PrivateKey, error) {
+		input.PageNumber = page
+		input.PageSize = perPage
+		return c.Globals.APIClient.ListPrivateKeys(input)
+	})
 	if err != nil {
 		c.Globals.ErrLog.AddWithContext(err, map[string]any{
 			"Filter In Use": c.filterInUse,
-			"Page Number":   c.pageNumber,
-			"Page Size":     c.pageSize,
+			"Page Number":   c.pagination.Page,
+			"Page Size":     c.pagination.PerPage,
 		})
 		return err
 	}
@@ -81,12 +87,6 @@ func (c *ListCommand) constructInput() *fastly.ListPrivateKeysInput {
 	if c.filterInUse != "" {
 		input.FilterInUse = c.filterInUse
 	}
-	if c.pageNumber > 0 {
-		input.PageNumber = c.pageNumber
-	}
-	if c.pageSize > 0 {
-		input.PageSize = c.pageSize
-	}
 
 	return &input
 }
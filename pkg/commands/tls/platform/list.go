@@ -21,6 +21,7 @@ func NewListCommand(parent cmd.Registerer, globals *config.Data, data manifest.D
 	c.manifest = data
 
 	// Optional Flags
+	c.RegisterAllFlag(&c.pagination)
 	c.CmdClause.Flag("filter-domain", "Optionally filter by the bulk attribute").StringVar(&c.filterTLSDomainID)
 	c.RegisterFlagBool(cmd.BoolFlagOpts{
 		Name:        cmd.FlagJSONName,
@@ -28,8 +29,7 @@ func NewListCommand(parent cmd.Registerer, globals *config.Data, data manifest.D
 		Dst:         &c.json,
 		Short:       'j',
 	})
-	c.CmdClause.Flag("page", "Page number of data set to fetch").IntVar(&c.pageNumber)
-	c.CmdClause.Flag("per-page", "Number of records per page").IntVar(&c.pageSize)
+	c.RegisterPageFlags(&c.pagination)
 	c.CmdClause.Flag("sort", "The order in which to list the results by creation date").StringVar(&c.sort)
 
 	return &c
@@ -42,8 +42,7 @@ type ListCommand struct {
 	filterTLSDomainID string
 	json              bool
 	manifest          manifest.Data
-	pageNumber        int
-	pageSize          int
+	pagination        cmd.Pagination
 	sort              string
 }
 
@@ -52,15 +51,22 @@ func (c *ListCommand) Exec(_ io.Reader, out io.Writer) error {
 	if c.Globals.Verbose() && c.json {
 		return fsterr.ErrInvalidVerboseJSONCombo
 	}
+	if err := c.pagination.Validate(); err != nil {
+		return err
+	}
 
 	input := c.constructInput()
 
-	rs, err := c.Globals.APIClient.ListBulkCertificates(input)
+	rs, err := cmd.FetchPages(c.pagination, func(page, perPage int) ([]*fastly.BulkCertificate, error) {
+		input.PageNumber = page
+		input.PageSize = perPage
+		return c.Globals.APIClient.ListBulkCertificates(input)
+	})
 	if err != nil {
 		c.Globals.ErrLog.AddWithContext(err, map[string]any{
 			"Filter TLS Domain ID": c.filterTLSDomainID,
-			"Page Number":          c.pageNumber,
-			"Page Size":            c.pageSize,
+			"Page Number":          c.pagination.Page,
+			"Page Size":            c.pagination.PerPage,
 			"Sort":                 c.sort,
 		})
 		return err
@@ -84,12 +90,6 @@ func (c *ListCommand) constructInput() *fastly.ListBulkCertificatesInput {
 	if c.filterTLSDomainID != "" {
 		input.FilterTLSDomainsIDMatch = c.filterTLSDomainID
 	}
-	if c.pageNumber > 0 {
-		input.PageNumber = c.pageNumber
-	}
-	if c.pageSize > 0 {
-		input.PageSize = c.pageSize
-	}
 	if c.sort != "" {
 		input.Sort = c.sort
 	}
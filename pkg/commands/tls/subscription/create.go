@@ -1,10 +1,18 @@
 package subscription
 
 import (
+	"encoding/json"
+	"fmt"
 	"io"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
 
 	"github.com/fastly/cli/pkg/cmd"
 	"github.com/fastly/cli/pkg/config"
+	"github.com/fastly/cli/pkg/dnsprovider"
+	fsterr "github.com/fastly/cli/pkg/errors"
 	"github.com/fastly/cli/pkg/manifest"
 	"github.com/fastly/cli/pkg/text"
 	"github.com/fastly/go-fastly/v6/fastly"
@@ -28,6 +36,16 @@ func NewCreateCommand(parent cmd.Registerer, globals *config.Data, data manifest
 	c.CmdClause.Flag("cert-auth", "The entity that issues and certifies the TLS certificates for your subscription. Valid values are lets-encrypt or globalsign").HintOptions(certAuth...).EnumVar(&c.certAuth, certAuth...)
 	c.CmdClause.Flag("common-name", "The domain name associated with the subscription. Default to the first domain specified by --domain").StringVar(&c.commonName)
 	c.CmdClause.Flag("config", "Alphanumeric string identifying a TLS configuration").StringVar(&c.config)
+	c.CmdClause.Flag("dns-provider", "Create the ACME challenge DNS records with a DNS provider, then wait for the certificate to be issued (credentials are read from the provider's usual env vars)").HintOptions(dnsprovider.Names...).EnumVar(&c.dnsProvider, dnsprovider.Names...)
+	c.CmdClause.Flag("interval", "How often to check the subscription state when using --wait").Default("30s").DurationVar(&c.interval)
+	c.RegisterFlagBool(cmd.BoolFlagOpts{
+		Name:        cmd.FlagJSONName,
+		Description: cmd.FlagJSONDesc,
+		Dst:         &c.json,
+		Short:       'j',
+	})
+	c.CmdClause.Flag("wait", "Wait until the domains are validated and the certificate issued").BoolVar(&c.wait)
+	c.CmdClause.Flag("wait-timeout", "How long to wait for the certificate to be issued when using --wait").Default("1h").DurationVar(&c.waitTimeout)
 
 	return &c
 }
@@ -36,15 +54,49 @@ func NewCreateCommand(parent cmd.Registerer, globals *config.Data, data manifest
 type CreateCommand struct {
 	cmd.Base
 
-	certAuth   string
-	commonName string
-	config     string
-	domains    []string
-	manifest   manifest.Data
+	certAuth    string
+	commonName  string
+	config      string
+	dnsProvider string
+	domains     []string
+	interval    time.Duration
+	json        bool
+	manifest    manifest.Data
+	wait        bool
+	waitTimeout time.Duration
+}
+
+// CreateResult describes a created subscription and the DNS records required
+// to validate its domains, for the --json output.
+type CreateResult struct {
+	CertificateAuthority string      `json:"certificate_authority"`
+	CommonName           string      `json:"common_name"`
+	DNSRecords           []DNSRecord `json:"dns_records"`
+	ID                   string      `json:"id"`
+	State                string      `json:"state"`
 }
 
 // Exec invokes the application logic for the command.
 func (c *CreateCommand) Exec(_ io.Reader, out io.Writer) error {
+	if c.Globals.Verbose() && c.json {
+		return fsterr.ErrInvalidVerboseJSONCombo
+	}
+	var provider dnsprovider.Provider
+	if c.dnsProvider != "" {
+		p, err := dnsprovider.New(c.dnsProvider, c.Globals.HTTPClient)
+		if err != nil {
+			return err
+		}
+		provider = p
+		c.wait = true
+	}
+	if c.wait && (c.interval <= 0 || c.waitTimeout <= 0) {
+		return fsterr.RemediationError{
+			Inner:       fmt.Errorf("invalid --interval or --wait-timeout value"),
+			Remediation: "Provide a positive duration (e.g. 30s).",
+		}
+	}
+
 	input := c.constructInput()
 
 	r, err := c.Globals.APIClient.CreateTLSSubscription(input)
@@ -58,10 +110,153 @@ func (c *CreateCommand) Exec(_ io.Reader, out io.Writer) error {
 		return err
 	}
 
-	text.Success(out, "Created TLS Subscription '%s' (Authority: %s, Common Name: %s)", r.ID, r.CertificateAuthority, r.CommonName.ID)
+	if !c.json {
+		text.Success(out, "Created TLS Subscription '%s' (Authority: %s, Common Name: %s)", r.ID, r.CertificateAuthority, r.CommonName.ID)
+	}
+
+	// NOTE: The DNS records are only described by the subscription's
+	// authorizations, which the create response doesn't include.
+	s, err := c.get(r.ID)
+	if err != nil {
+		return err
+	}
+
+	if !c.json {
+		text.Break(out)
+		text.Output(out, "Create the following DNS records to validate the domains (one challenge per domain is sufficient):")
+		text.Break(out)
+		printDNSRecords(out, DNSRecords(s))
+	}
+
+	if provider != nil {
+		if err := c.createDNSRecords(provider, DNSRecords(s), out); err != nil {
+			return err
+		}
+	}
+
+	if c.wait && s.State != StateIssued {
+		s, err = c.waitForIssued(s, out)
+		if err != nil {
+			return err
+		}
+	}
+
+	if c.json {
+		var commonName string
+		if r.CommonName != nil {
+			commonName = r.CommonName.ID
+		}
+		data, err := json.Marshal(CreateResult{
+			CertificateAuthority: r.CertificateAuthority,
+			CommonName:           commonName,
+			DNSRecords:           DNSRecords(s),
+			ID:                   s.ID,
+			State:                s.State,
+		})
+		if err != nil {
+			return err
+		}
+		_, err = out.Write(data)
+		if err != nil {
+			c.Globals.ErrLog.Add(err)
+			return fmt.Errorf("error: unable to write data to stdout: %w", err)
+		}
+	}
+	return nil
+}
+
+// createDNSRecords creates the ACME challenge records with the DNS provider.
+func (c *CreateCommand) createDNSRecords(provider dnsprovider.Provider, records []DNSRecord, out io.Writer) error {
+	for _, r := range records {
+		if r.Challenge != ChallengeManagedDNS {
+			continue
+		}
+		err := provider.UpsertRecord(dnsprovider.Record{
+			Name:   r.Name,
+			Type:   r.RecordType,
+			Values: r.Values,
+		})
+		if err != nil {
+			c.Globals.ErrLog.AddWithContext(err, map[string]any{
+				"DNS Provider": c.dnsProvider,
+				"Record Name":  r.Name,
+			})
+			return fsterr.RemediationError{
+				Inner:       fmt.Errorf("error creating DNS record %s: %w", r.Name, err),
+				Remediation: "Check the DNS provider credentials can edit the zone, or create the DNS records manually.",
+			}
+		}
+		if !c.json {
+			text.Info(out, "Created %s record %s with %s", r.RecordType, r.Name, c.dnsProvider)
+		}
+	}
 	return nil
 }
 
+// get fetches the subscription along with its authorizations.
+func (c *CreateCommand) get(id string) (*fastly.TLSSubscription, error) {
+	include := includeAuthorizations
+	s, err := c.Globals.APIClient.GetTLSSubscription(&fastly.GetTLSSubscriptionInput{
+		ID:      id,
+		Include: &include,
+	})
+	if err != nil {
+		c.Globals.ErrLog.AddWithContext(err, map[string]any{
+			"TLS Subscription ID": id,
+			"Include":             include,
+		})
+		return nil, err
+	}
+	return s, nil
+}
+
+// waitForIssued polls the subscription until its certificate is issued, the
+// --wait-timeout elapses or the user interrupts the command.
+func (c *CreateCommand) waitForIssued(s *fastly.TLSSubscription, out io.Writer) (*fastly.TLSSubscription, error) {
+	if !c.json {
+		text.Break(out)
+		text.Info(out, "Waiting for the certificate to be issued (state: %s)...", s.State)
+	}
+
+	// NOTE: An interrupt is the normal way to stop, so the command handles it
+	// rather than the CLI cleaning up after an interrupted command.
+	c.Globals.Interrupt.Release()
+	sigs := make(chan os.Signal, 1)
+	signal.Notify(sigs, syscall.SIGINT, syscall.SIGTERM)
+	defer signal.Stop(sigs)
+
+	ticker := time.NewTicker(c.interval)
+	defer ticker.Stop()
+	timeout := time.After(c.waitTimeout)
+
+	for {
+		select {
+		case <-sigs:
+			return s, fmt.Errorf("stopped waiting for TLS Subscription '%s' (state: %s)", s.ID, s.State)
+		case <-timeout:
+			return s, fsterr.RemediationError{
+				Inner:       fmt.Errorf("timed out waiting for TLS Subscription '%s' to be issued (state: %s)", s.ID, s.State),
+				Remediation: fmt.Sprintf("Check the DNS records have been created, then run `fastly tls-subscription describe --id %s` to follow its progress.", s.ID),
+			}
+		case <-ticker.C:
+			latest, err := c.get(s.ID)
+			if err != nil {
+				return s, err
+			}
+			if latest.State != s.State && !c.json {
+				text.Info(out, "State changed to %s", latest.State)
+			}
+			s = latest
+			if s.State == StateIssued {
+				if !c.json {
+					text.Success(out, "Issued TLS Subscription '%s'", s.ID)
+				}
+				return s, nil
+			}
+		}
+	}
+}
+
 // constructInput transforms values parsed from CLI flags into an object to be used by the API client library.
 func (c *CreateCommand) constructInput() *fastly.CreateTLSSubscriptionInput {
 	var input fastly.CreateTLSSubscriptionInput
@@ -0,0 +1,71 @@
+package subscription
+
+import (
+	"io"
+	"strings"
+
+	"github.com/fastly/cli/pkg/text"
+	"github.com/fastly/go-fastly/v6/fastly"
+)
+
+// includeAuthorizations is the related object that describes the DNS records
+// required to validate the domains of a subscription.
+const includeAuthorizations = "tls_authorizations"
+
+// StateIssued is the state of a TLS subscription once its domains have been
+// validated and the certificate issued.
+const StateIssued = "issued"
+
+// ChallengeManagedDNS is the challenge that validates a domain with an ACME
+// challenge CNAME record, which unlike the other challenges doesn't change
+// where the domain's traffic is sent.
+const ChallengeManagedDNS = "managed-dns"
+
+// DNSRecord is a DNS record required to validate a domain of a subscription.
+//
+// NOTE: A domain can be validated by any one of its challenges, e.g. either
+// the ACME challenge CNAME (managed-dns) or by pointing the domain itself at
+// Fastly with a CNAME (managed-http-cname) or A records (managed-http-a).
+type DNSRecord struct {
+	Challenge  string   `json:"challenge"`
+	Name       string   `json:"name"`
+	RecordType string   `json:"record_type"`
+	State      string   `json:"state,omitempty"`
+	Values     []string `json:"values"`
+}
+
+// DNSRecords returns the DNS records described by the subscription's
+// authorizations, which are only present when the subscription was fetched
+// with the tls_authorizations related object included.
+func DNSRecords(s *fastly.TLSSubscription) []DNSRecord {
+	var records []DNSRecord
+	for _, a := range s.Authorizations {
+		if a == nil {
+			continue
+		}
+		for _, ch := range a.Challenges {
+			records = append(records, DNSRecord{
+				Challenge:  ch.Type,
+				Name:       ch.RecordName,
+				RecordType: ch.RecordType,
+				State:      a.State,
+				Values:     ch.Values,
+			})
+		}
+	}
+	return records
+}
+
+// printDNSRecords displays the DNS records as a table.
+func printDNSRecords(out io.Writer, records []DNSRecord) {
+	if len(records) == 0 {
+		text.Info(out, "No DNS records are required to validate the subscription's domains.")
+		return
+	}
+	t := text.NewTable(out)
+	t.AddHeader("CHALLENGE", "NAME", "TYPE", "VALUES")
+	for _, r := range records {
+		t.AddLine(r.Challenge, r.Name, r.RecordType, strings.Join(r.Values, ", "))
+	}
+	t.Print()
+}
@@ -20,6 +20,8 @@ const (
 )
 
 func TestCreate(t *testing.T) {
+	t.Setenv("NS1_APIKEY", "")
+
 	args := testutil.Args
 	scenarios := []testutil.TestScenario{
 		{
@@ -49,10 +51,62 @@ func TestCreate(t *testing.T) {
 						},
 					}, nil
 				},
+				GetTLSSubscriptionFn: getTLSSubscriptionWithAuthorizations("pending"),
 			},
 			Args:       args("tls-subscription create --domain example.com"),
 			WantOutput: fmt.Sprintf("Created TLS Subscription '%s' (Authority: %s, Common Name: example.com)", mockResponseID, certificateAuthority),
 		},
+		{
+			Name: "validate DNS records are displayed",
+			API: mock.API{
+				CreateTLSSubscriptionFn: createTLSSubscriptionOK,
+				GetTLSSubscriptionFn:    getTLSSubscriptionWithAuthorizations("pending"),
+			},
+			Args:       args("tls-subscription create --domain example.com"),
+			WantOutput: "managed-dns     _acme-challenge.example.com  CNAME  abc123.fastly-validations.com\nmanaged-http-a  example.com                  A      151.101.2.132, 151.101.66.132\n",
+		},
+		{
+			Name: "validate --json",
+			API: mock.API{
+				CreateTLSSubscriptionFn: createTLSSubscriptionOK,
+				GetTLSSubscriptionFn:    getTLSSubscriptionWithAuthorizations("pending"),
+			},
+			Args:       args("tls-subscription create --domain example.com --json"),
+			WantOutput: `"dns_records":[{"challenge":"managed-dns","name":"_acme-challenge.example.com","record_type":"CNAME","state":"pending","values":["abc123.fastly-validations.com"]}`,
+		},
+		{
+			Name: "validate --wait",
+			API: mock.API{
+				CreateTLSSubscriptionFn: createTLSSubscriptionOK,
+				GetTLSSubscriptionFn: func() func(*fastly.GetTLSSubscriptionInput) (*fastly.TLSSubscription, error) {
+					var calls int
+					return func(i *fastly.GetTLSSubscriptionInput) (*fastly.TLSSubscription, error) {
+						calls++
+						state := "pending"
+						if calls > 2 {
+							state = "issued"
+						}
+						return getTLSSubscriptionWithAuthorizations(state)(i)
+					}
+				}(),
+			},
+			Args:       args("tls-subscription create --domain example.com --wait --interval 1ms"),
+			WantOutput: fmt.Sprintf("Issued TLS Subscription '%s'", mockResponseID),
+		},
+		{
+			Name:      "validate --dns-provider without credentials",
+			Args:      args("tls-subscription create --domain example.com --dns-provider ns1"),
+			WantError: "no ns1 credentials found",
+		},
+		{
+			Name: "validate --wait timeout",
+			API: mock.API{
+				CreateTLSSubscriptionFn: createTLSSubscriptionOK,
+				GetTLSSubscriptionFn:    getTLSSubscriptionWithAuthorizations("pending"),
+			},
+			Args:      args("tls-subscription create --domain example.com --wait --interval 1ms --wait-timeout 10ms"),
+			WantError: fmt.Sprintf("timed out waiting for TLS Subscription '%s' to be issued (state: pending)", mockResponseID),
+		},
 	}
 
 	for testcaseIdx := range scenarios {
@@ -256,3 +310,45 @@ func TestUpdate(t *testing.T) {
 		})
 	}
 }
+
+func createTLSSubscriptionOK(_ *fastly.CreateTLSSubscriptionInput) (*fastly.TLSSubscription, error) {
+	return &fastly.TLSSubscription{
+		ID:                   mockResponseID,
+		CertificateAuthority: certificateAuthority,
+		CommonName: &fastly.TLSDomain{
+			ID: "example.com",
+		},
+	}, nil
+}
+
+func getTLSSubscriptionWithAuthorizations(state string) func(*fastly.GetTLSSubscriptionInput) (*fastly.TLSSubscription, error) {
+	return func(i *fastly.GetTLSSubscriptionInput) (*fastly.TLSSubscription, error) {
+		if i.Include == nil || *i.Include != "tls_authorizations" {
+			return nil, fmt.Errorf("want tls_authorizations to be included")
+		}
+		return &fastly.TLSSubscription{
+			ID:                   mockResponseID,
+			CertificateAuthority: certificateAuthority,
+			State:                state,
+			Authorizations: []*fastly.TLSAuthorizations{
+				{
+					State: state,
+					Challenges: []fastly.TLSChallenge{
+						{
+							Type:       "managed-dns",
+							RecordType: "CNAME",
+							RecordName: "_acme-challenge.example.com",
+							Values:     []string{"abc123.fastly-validations.com"},
+						},
+						{
+							Type:       "managed-http-a",
+							RecordType: "A",
+							RecordName: "example.com",
+							Values:     []string{"151.101.2.132", "151.101.66.132"},
+						},
+					},
+				},
+			},
+		}, nil
+	}
+}
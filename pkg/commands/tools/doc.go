@@ -0,0 +1,3 @@
+// Package tools contains commands to manage the auxiliary binaries (e.g.
+// Viceroy) used by the CLI.
+package tools
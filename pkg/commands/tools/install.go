@@ -0,0 +1,88 @@
+package tools
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/blang/semver"
+	"github.com/fastly/cli/pkg/cmd"
+	"github.com/fastly/cli/pkg/config"
+	"github.com/fastly/cli/pkg/manifest"
+	"github.com/fastly/cli/pkg/text"
+	fsttools "github.com/fastly/cli/pkg/tools"
+)
+
+// InstallCommand installs a version of a tool.
+type InstallCommand struct {
+	cmd.Base
+	dir      string
+	manifest manifest.Data
+	tools    []fsttools.Tool
+
+	arch    string
+	name    string
+	version string
+}
+
+// NewInstallCommand returns a usable command registered under the parent.
+func NewInstallCommand(parent cmd.Registerer, globals *config.Data, data manifest.Data, dir string, tools []fsttools.Tool) *InstallCommand {
+	var c InstallCommand
+	c.Globals = globals
+	c.manifest = data
+	c.dir = dir
+	c.tools = tools
+	c.CmdClause = parent.Command("install", "Install a tool, at the version pinned by the project or otherwise the latest version")
+	c.CmdClause.Arg("name", argToolDesc).Required().StringVar(&c.name)
+	c.CmdClause.Flag("arch", flagArchDesc).StringVar(&c.arch)
+	c.CmdClause.Flag("version", "The version to install, instead of the pinned or latest version").StringVar(&c.version)
+	return &c
+}
+
+// Exec invokes the application logic for the command.
+func (c *InstallCommand) Exec(_ io.Reader, out io.Writer) error {
+	m, err := newManager(c.dir, c.tools, c.arch)
+	if err != nil {
+		return err
+	}
+	t, err := m.Tool(c.name)
+	if err != nil {
+		return err
+	}
+
+	v, checksum, ok, err := pinned(m, c.manifest, t.Name)
+	if err != nil {
+		return err
+	}
+	switch {
+	case c.version != "":
+		v, err = semver.ParseTolerant(c.version)
+		if err != nil {
+			return fmt.Errorf("error parsing version '%s': %w", c.version, err)
+		}
+		checksum = ""
+	case !ok:
+		v, err = m.Latest(c.Globals.Context(), t)
+		if err != nil {
+			c.Globals.ErrLog.Add(err)
+			return err
+		}
+	}
+
+	progress := text.NewProgress(out, c.Globals.Verbose())
+	progress.Step(fmt.Sprintf("Installing %s %s...", t.Name, v))
+
+	bin, _, err := m.Install(c.Globals.Context(), t, v, checksum)
+	if err != nil {
+		c.Globals.ErrLog.AddWithContext(err, map[string]any{
+			"Tool":     t.Name,
+			"Version":  v,
+			"Platform": m.Platform,
+		})
+		progress.Fail()
+		return err
+	}
+	progress.Done()
+
+	text.Success(out, "Installed %s %s to %s", t.Name, v, bin)
+	return nil
+}
@@ -0,0 +1,105 @@
+package tools
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/fastly/cli/pkg/cmd"
+	"github.com/fastly/cli/pkg/config"
+	"github.com/fastly/cli/pkg/manifest"
+	"github.com/fastly/cli/pkg/text"
+	fsttools "github.com/fastly/cli/pkg/tools"
+)
+
+// ListCommand lists the tools along with their installed and pinned versions.
+type ListCommand struct {
+	cmd.Base
+	dir      string
+	manifest manifest.Data
+	tools    []fsttools.Tool
+
+	json bool
+}
+
+// NewListCommand returns a usable command registered under the parent.
+func NewListCommand(parent cmd.Registerer, globals *config.Data, data manifest.Data, dir string, tools []fsttools.Tool) *ListCommand {
+	var c ListCommand
+	c.Globals = globals
+	c.manifest = data
+	c.dir = dir
+	c.tools = tools
+	c.CmdClause = parent.Command("list", "List the tools along with their installed versions and the version pinned by the project")
+	c.RegisterFlagBool(cmd.BoolFlagOpts{
+		Name:        cmd.FlagJSONName,
+		Description: cmd.FlagJSONDesc,
+		Dst:         &c.json,
+		Short:       'j',
+	})
+	return &c
+}
+
+// toolStatus is the JSON representation of a tool.
+type toolStatus struct {
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	Installed   []string `json:"installed"`
+	Pinned      string   `json:"pinned,omitempty"`
+}
+
+// Exec invokes the application logic for the command.
+func (c *ListCommand) Exec(_ io.Reader, out io.Writer) error {
+	m, err := newManager(c.dir, c.tools, "")
+	if err != nil {
+		return err
+	}
+
+	statuses := make([]toolStatus, 0, len(m.Tools))
+	for _, t := range m.Tools {
+		versions, err := m.Installed(t)
+		if err != nil {
+			c.Globals.ErrLog.Add(err)
+			return fmt.Errorf("error reading the installed %s versions: %w", t.Name, err)
+		}
+		installed := make([]string, 0, len(versions))
+		for _, v := range versions {
+			installed = append(installed, v.String())
+		}
+		statuses = append(statuses, toolStatus{
+			Name:        t.Name,
+			Description: t.Description,
+			Installed:   installed,
+			Pinned:      c.manifest.File.Tools[t.Name].Version,
+		})
+	}
+
+	if c.json {
+		data, err := json.Marshal(statuses)
+		if err != nil {
+			return err
+		}
+		_, err = out.Write(data)
+		if err != nil {
+			c.Globals.ErrLog.Add(err)
+			return fmt.Errorf("error: unable to write data to stdout: %w", err)
+		}
+		return nil
+	}
+
+	t := text.NewTable(out)
+	t.AddHeader("NAME", "INSTALLED", "PINNED", "DESCRIPTION")
+	for _, s := range statuses {
+		installed := strings.Join(s.Installed, ", ")
+		if installed == "" {
+			installed = "-"
+		}
+		pinned := s.Pinned
+		if pinned == "" {
+			pinned = "-"
+		}
+		t.AddLine(s.Name, installed, pinned, s.Description)
+	}
+	t.Print()
+	return nil
+}
@@ -0,0 +1,111 @@
+package tools
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/blang/semver"
+	"github.com/fastly/cli/pkg/cmd"
+	"github.com/fastly/cli/pkg/config"
+	"github.com/fastly/cli/pkg/manifest"
+	"github.com/fastly/cli/pkg/text"
+	fsttools "github.com/fastly/cli/pkg/tools"
+)
+
+// PinCommand pins a version of a tool in the project's fastly.toml.
+type PinCommand struct {
+	cmd.Base
+	dir      string
+	manifest manifest.Data
+	tools    []fsttools.Tool
+
+	arch    string
+	name    string
+	version string
+}
+
+// NewPinCommand returns a usable command registered under the parent.
+func NewPinCommand(parent cmd.Registerer, globals *config.Data, data manifest.Data, dir string, tools []fsttools.Tool) *PinCommand {
+	var c PinCommand
+	c.Globals = globals
+	c.manifest = data
+	c.dir = dir
+	c.tools = tools
+	c.CmdClause = parent.Command("pin", "Pin a version of a tool, along with the checksum of its binary, in the fastly.toml [tools] section")
+	c.CmdClause.Arg("name", argToolDesc).Required().StringVar(&c.name)
+	c.CmdClause.Flag("arch", flagArchDesc).StringVar(&c.arch)
+	c.CmdClause.Flag("version", "The version to pin, instead of the latest version").StringVar(&c.version)
+	return &c
+}
+
+// Exec invokes the application logic for the command.
+func (c *PinCommand) Exec(_ io.Reader, out io.Writer) error {
+	if !c.manifest.File.Exists() {
+		return fmt.Errorf("error reading package manifest: %s not found", manifest.Filename)
+	}
+
+	m, err := newManager(c.dir, c.tools, c.arch)
+	if err != nil {
+		return err
+	}
+	t, err := m.Tool(c.name)
+	if err != nil {
+		return err
+	}
+
+	var v semver.Version
+	if c.version != "" {
+		v, err = semver.ParseTolerant(c.version)
+		if err != nil {
+			return fmt.Errorf("error parsing version '%s': %w", c.version, err)
+		}
+	} else {
+		v, err = m.Latest(c.Globals.Context(), t)
+		if err != nil {
+			c.Globals.ErrLog.Add(err)
+			return err
+		}
+	}
+
+	// The checksums pinned for other platforms are kept when re-pinning the same
+	// version, so that each platform can add its own checksum.
+	pin := c.manifest.File.Tools[t.Name]
+	if pin.Version != v.String() {
+		pin = manifest.ToolPin{Version: v.String()}
+	}
+
+	progress := text.NewProgress(out, c.Globals.Verbose())
+	progress.Step(fmt.Sprintf("Installing %s %s...", t.Name, v))
+
+	_, sum, err := m.Install(c.Globals.Context(), t, v, pin.Checksums[m.Platform.String()])
+	if err != nil {
+		c.Globals.ErrLog.AddWithContext(err, map[string]any{
+			"Tool":     t.Name,
+			"Version":  v,
+			"Platform": m.Platform,
+		})
+		progress.Fail()
+		return err
+	}
+
+	progress.Step(fmt.Sprintf("Updating %s...", manifest.Filename))
+
+	if pin.Checksums == nil {
+		pin.Checksums = make(map[string]string)
+	}
+	pin.Checksums[m.Platform.String()] = sum
+	if c.manifest.File.Tools == nil {
+		c.manifest.File.Tools = make(manifest.Tools)
+	}
+	c.manifest.File.Tools[t.Name] = pin
+
+	if err := c.manifest.File.Write(manifest.Filename); err != nil {
+		c.Globals.ErrLog.Add(err)
+		progress.Fail()
+		return fmt.Errorf("error saving package manifest: %w", err)
+	}
+	progress.Done()
+
+	text.Success(out, "Pinned %s %s (%s checksum %s)", t.Name, v, m.Platform, sum)
+	return nil
+}
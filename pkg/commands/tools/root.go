@@ -0,0 +1,63 @@
+package tools
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/blang/semver"
+	"github.com/fastly/cli/pkg/cmd"
+	"github.com/fastly/cli/pkg/commands/update"
+	"github.com/fastly/cli/pkg/config"
+	"github.com/fastly/cli/pkg/manifest"
+	fsttools "github.com/fastly/cli/pkg/tools"
+)
+
+// RootCommand is the parent command for all subcommands in this package.
+// It should be installed under the primary root command.
+type RootCommand struct {
+	cmd.Base
+	// no flags
+}
+
+// NewRootCommand returns a new command registered in the parent.
+func NewRootCommand(parent cmd.Registerer, globals *config.Data) *RootCommand {
+	var c RootCommand
+	c.Globals = globals
+	c.CmdClause = parent.Command("tools", "Manage the auxiliary binaries (e.g. Viceroy) used by the CLI")
+	return &c
+}
+
+// Exec implements the command interface.
+func (c *RootCommand) Exec(_ io.Reader, _ io.Writer) error {
+	panic("unreachable")
+}
+
+// argToolDesc is the description of the tool name argument.
+const argToolDesc = "The name of the tool (js-compute-runtime, viceroy or wasm-opt)"
+
+// flagArchDesc is the description of the --arch flag.
+const flagArchDesc = "The architecture of the binary to download, instead of the detected one (amd64, arm64)"
+
+// newManager returns a manager of the tools installed in dir, which downloads
+// the binaries built for the given architecture, otherwise the detected one.
+func newManager(dir string, tools []fsttools.Tool, arch string) (fsttools.Manager, error) {
+	p, err := update.DetectPlatform(arch)
+	if err != nil {
+		return fsttools.Manager{}, err
+	}
+	return fsttools.Manager{Dir: dir, Platform: p, Tools: tools}, nil
+}
+
+// pinned returns the version and checksum of the tool pinned by the project,
+// if any.
+func pinned(m fsttools.Manager, data manifest.Data, name string) (v semver.Version, checksum string, ok bool, err error) {
+	pin, ok := data.File.Tools[name]
+	if !ok {
+		return v, checksum, false, nil
+	}
+	v, err = semver.Parse(pin.Version)
+	if err != nil {
+		return v, checksum, true, fmt.Errorf("error parsing the pinned %s version '%s': %w", name, pin.Version, err)
+	}
+	return v, pin.Checksums[m.Platform.String()], true, nil
+}
@@ -0,0 +1,168 @@
+package tools_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/fastly/cli/pkg/app"
+	"github.com/fastly/cli/pkg/commands/compute"
+	"github.com/fastly/cli/pkg/manifest"
+	"github.com/fastly/cli/pkg/mock"
+	"github.com/fastly/cli/pkg/testutil"
+	toml "github.com/pelletier/go-toml"
+)
+
+func TestTools(t *testing.T) {
+	args := testutil.Args
+	scenarios := []struct {
+		testutil.TestScenario
+		manifest  string
+		wantPin   manifest.ToolPin
+		installed bool
+	}{
+		{
+			TestScenario: testutil.TestScenario{
+				Name:       "list",
+				Args:       args("tools list"),
+				WantOutput: "viceroy  -          0.3.0   The local testing server",
+			},
+			manifest: "[tools.viceroy]\nversion = \"0.3.0\"\n",
+		},
+		{
+			TestScenario: testutil.TestScenario{
+				Name:      "install unknown tool",
+				Args:      args("tools install nope"),
+				WantError: "unknown tool 'nope'",
+			},
+		},
+		{
+			TestScenario: testutil.TestScenario{
+				Name:       "install latest",
+				Args:       args("tools install viceroy"),
+				WantOutput: "Installed viceroy 1.2.3",
+			},
+			installed: true,
+		},
+		{
+			TestScenario: testutil.TestScenario{
+				Name:       "install pinned",
+				Args:       args("tools install viceroy"),
+				WantOutput: "Installed viceroy 0.3.0",
+			},
+			manifest: "[tools.viceroy]\nversion = \"0.3.0\"\n",
+		},
+		{
+			TestScenario: testutil.TestScenario{
+				Name:      "install pinned with a mismatched checksum",
+				Args:      args("tools install viceroy --arch arm64"),
+				WantError: "doesn't match the pinned checksum",
+			},
+			manifest: "[tools.viceroy]\nversion = \"0.3.0\"\n[tools.viceroy.checksums]\nlinux-arm64 = \"abc\"\n",
+		},
+		{
+			TestScenario: testutil.TestScenario{
+				Name:      "install with an unsupported arch",
+				Args:      args("tools install viceroy --arch mips"),
+				WantError: "unsupported architecture 'mips'",
+			},
+		},
+		{
+			TestScenario: testutil.TestScenario{
+				Name:       "pin",
+				Args:       args("tools pin viceroy --version 0.4.0 --arch arm64"),
+				WantOutput: "Pinned viceroy 0.4.0",
+			},
+			wantPin: manifest.ToolPin{
+				Version: "0.4.0",
+				Checksums: map[string]string{
+					"darwin-amd64": "def",
+					"linux-arm64":  "2c26b46b68ffc68ff99b453c1d30413413422d706483bfa0f98a5e886266e7ae",
+				},
+			},
+			manifest: "[tools.viceroy]\nversion = \"0.4.0\"\n[tools.viceroy.checksums]\ndarwin-amd64 = \"def\"\n",
+		},
+		{
+			TestScenario: testutil.TestScenario{
+				Name:       "pin a new version",
+				Args:       args("tools pin viceroy --arch arm64"),
+				WantOutput: "Pinned viceroy 1.2.3",
+			},
+			wantPin: manifest.ToolPin{
+				Version: "1.2.3",
+				Checksums: map[string]string{
+					"linux-arm64": "2c26b46b68ffc68ff99b453c1d30413413422d706483bfa0f98a5e886266e7ae",
+				},
+			},
+			manifest: "[tools.viceroy]\nversion = \"0.4.0\"\n[tools.viceroy.checksums]\ndarwin-amd64 = \"def\"\n",
+		},
+		{
+			TestScenario: testutil.TestScenario{
+				Name:       "upgrade with nothing installed",
+				Args:       args("tools upgrade"),
+				WantOutput: "No tools are installed.",
+			},
+		},
+	}
+
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Chdir(wd)
+
+	installDir := compute.InstallDir
+	defer func() { compute.InstallDir = installDir }()
+
+	for testcaseIdx := range scenarios {
+		testcase := &scenarios[testcaseIdx]
+		t.Run(testcase.Name, func(t *testing.T) {
+			rootdir := t.TempDir()
+			compute.InstallDir = filepath.Join(rootdir, "install")
+
+			downloaded := filepath.Join(rootdir, "download")
+			if err := os.WriteFile(downloaded, []byte("foo"), 0o600); err != nil {
+				t.Fatal(err)
+			}
+			manifestPath := filepath.Join(rootdir, manifest.Filename)
+			if err := os.WriteFile(manifestPath, []byte("manifest_version = 2\nname = \"test\"\n"+testcase.manifest), 0o600); err != nil {
+				t.Fatal(err)
+			}
+			if err := os.Chdir(rootdir); err != nil {
+				t.Fatal(err)
+			}
+
+			var stdout bytes.Buffer
+			opts := testutil.NewRunOpts(testcase.Args, &stdout)
+			opts.Versioners.Viceroy = mock.Versioner{
+				Version:        "v1.2.3",
+				BinaryFilename: "viceroy",
+				DownloadOK:     true,
+				DownloadedFile: downloaded,
+			}
+			err := app.Run(opts)
+			testutil.AssertErrorContains(t, err, testcase.WantError)
+			testutil.AssertStringContains(t, stdout.String(), testcase.WantOutput)
+
+			if testcase.installed {
+				bin := filepath.Join(compute.InstallDir, "tools", "viceroy", "1.2.3", "viceroy")
+				if _, err := os.Stat(bin); err != nil {
+					t.Fatalf("expected viceroy to be installed: %s", err)
+				}
+			}
+
+			if testcase.wantPin.Version != "" {
+				data, err := os.ReadFile(manifestPath)
+				if err != nil {
+					t.Fatal(err)
+				}
+				var f manifest.File
+				if err := toml.Unmarshal(data, &f); err != nil {
+					t.Fatal(err)
+				}
+				testutil.AssertEqual(t, testcase.wantPin, f.Tools["viceroy"])
+			}
+		})
+	}
+}
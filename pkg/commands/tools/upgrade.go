@@ -0,0 +1,105 @@
+package tools
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/fastly/cli/pkg/cmd"
+	"github.com/fastly/cli/pkg/config"
+	"github.com/fastly/cli/pkg/manifest"
+	"github.com/fastly/cli/pkg/text"
+	fsttools "github.com/fastly/cli/pkg/tools"
+)
+
+// UpgradeCommand installs the latest version of the installed tools.
+type UpgradeCommand struct {
+	cmd.Base
+	dir      string
+	manifest manifest.Data
+	tools    []fsttools.Tool
+
+	arch string
+	name string
+}
+
+// NewUpgradeCommand returns a usable command registered under the parent.
+func NewUpgradeCommand(parent cmd.Registerer, globals *config.Data, data manifest.Data, dir string, tools []fsttools.Tool) *UpgradeCommand {
+	var c UpgradeCommand
+	c.Globals = globals
+	c.manifest = data
+	c.dir = dir
+	c.tools = tools
+	c.CmdClause = parent.Command("upgrade", "Install the latest version of a tool, otherwise of every installed tool")
+	c.CmdClause.Arg("name", argToolDesc).StringVar(&c.name)
+	c.CmdClause.Flag("arch", flagArchDesc).StringVar(&c.arch)
+	return &c
+}
+
+// Exec invokes the application logic for the command.
+func (c *UpgradeCommand) Exec(_ io.Reader, out io.Writer) error {
+	m, err := newManager(c.dir, c.tools, c.arch)
+	if err != nil {
+		return err
+	}
+
+	var tools []fsttools.Tool
+	if c.name != "" {
+		t, err := m.Tool(c.name)
+		if err != nil {
+			return err
+		}
+		tools = append(tools, t)
+	} else {
+		for _, t := range m.Tools {
+			versions, err := m.Installed(t)
+			if err != nil {
+				c.Globals.ErrLog.Add(err)
+				return fmt.Errorf("error reading the installed %s versions: %w", t.Name, err)
+			}
+			if len(versions) > 0 {
+				tools = append(tools, t)
+			}
+		}
+	}
+	if len(tools) == 0 {
+		text.Info(out, "No tools are installed. To install a tool, run `fastly tools install <name>`.")
+		return nil
+	}
+
+	for _, t := range tools {
+		latest, err := m.Latest(c.Globals.Context(), t)
+		if err != nil {
+			c.Globals.ErrLog.Add(err)
+			return err
+		}
+
+		versions, err := m.Installed(t)
+		if err != nil {
+			c.Globals.ErrLog.Add(err)
+			return fmt.Errorf("error reading the installed %s versions: %w", t.Name, err)
+		}
+		if n := len(versions); n > 0 && versions[n-1].GTE(latest) {
+			text.Info(out, "%s is up to date (%s).", t.Name, versions[n-1])
+			continue
+		}
+
+		progress := text.NewProgress(out, c.Globals.Verbose())
+		progress.Step(fmt.Sprintf("Installing %s %s...", t.Name, latest))
+		if _, _, err := m.Install(c.Globals.Context(), t, latest, ""); err != nil {
+			c.Globals.ErrLog.AddWithContext(err, map[string]any{
+				"Tool":     t.Name,
+				"Version":  latest,
+				"Platform": m.Platform,
+			})
+			progress.Fail()
+			return err
+		}
+		progress.Done()
+		text.Success(out, "Upgraded %s to %s", t.Name, latest)
+
+		if pin, ok := c.manifest.File.Tools[t.Name]; ok && pin.Version != latest.String() {
+			text.Warning(out, "The project pins %s %s. To use %s in the project, run `fastly tools pin %s`.", t.Name, pin.Version, latest, t.Name)
+		}
+	}
+	return nil
+}
@@ -4,7 +4,6 @@ import (
 	"context"
 	"fmt"
 	"io"
-	"runtime"
 	"strings"
 
 	"github.com/blang/semver"
@@ -23,15 +22,20 @@ func Check(ctx context.Context, currentVersion string, cliVersioner Versioner) (
 		return current, latest, false
 	}
 
+	// NOTE: The platform can't fail to be detected without an arch override.
+	platform, _ := DetectPlatform("")
+	SetPlatformAsset(cliVersioner, platform, latest, cliArchiveFormat())
+
+	return current, latest, latest.GT(current)
+}
+
+// cliArchiveFormat returns the file extension of the CLI release assets.
+func cliArchiveFormat() string {
 	// TODO: change goreleaser to produce .tar.gz for CLI on Windows
-	archiveFormat := ".tar.gz"
 	if fstruntime.Windows {
-		archiveFormat = ".zip"
+		return ".zip"
 	}
-	asset := fmt.Sprintf(DefaultAssetFormat, cliVersioner.BinaryName(), latest, runtime.GOOS, runtime.GOARCH, archiveFormat)
-	cliVersioner.SetAsset(asset)
-
-	return current, latest, latest.GT(current)
+	return ".tar.gz"
 }
 
 type checkResult struct {
@@ -0,0 +1,101 @@
+package update
+
+import (
+	"fmt"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/blang/semver"
+)
+
+// MuslAssetFormat represents the GitHub release asset name format of a binary
+// built against musl libc (e.g. for Alpine Linux).
+//
+// Interpolation placeholders are the same as DefaultAssetFormat.
+const MuslAssetFormat = "%s_v%s_%s-%s-musl%s"
+
+// Platform describes the operating system, architecture and C library a
+// release asset is built for.
+type Platform struct {
+	OS   string
+	Arch string
+	Musl bool
+}
+
+// String returns the platform in the format used by the release assets.
+func (p Platform) String() string {
+	s := p.OS + "-" + p.Arch
+	if p.Musl {
+		s += "-musl"
+	}
+	return s
+}
+
+// Assets returns the names of the release assets that can be used on the
+// platform, in order of preference.
+//
+// NOTE: On musl systems we prefer a musl specific asset but fallback to the
+// standard asset, as not every project publishes musl builds and statically
+// linked binaries work regardless of the C library.
+func (p Platform) Assets(binary string, version semver.Version, ext string) []string {
+	var assets []string
+	if p.Musl {
+		assets = append(assets, fmt.Sprintf(MuslAssetFormat, binary, version, p.OS, p.Arch, ext))
+	}
+	return append(assets, fmt.Sprintf(DefaultAssetFormat, binary, version, p.OS, p.Arch, ext))
+}
+
+// archAliases maps the architecture names reported by other tools (e.g.
+// `uname -m`) to the names used by the release assets.
+var archAliases = map[string]string{
+	"aarch64": "arm64",
+	"amd64":   "amd64",
+	"arm64":   "arm64",
+	"armv8":   "arm64",
+	"x64":     "amd64",
+	"x86_64":  "amd64",
+}
+
+// DetectPlatform returns the platform of the host.
+//
+// The arch parameter overrides the detected architecture, for example to
+// download an arm64 binary from an emulated amd64 process, and accepts the
+// common aliases such as aarch64 and x86_64.
+func DetectPlatform(arch string) (Platform, error) {
+	p := Platform{
+		OS:   runtime.GOOS,
+		Arch: hostArch(),
+	}
+	if arch != "" {
+		a, ok := archAliases[strings.ToLower(arch)]
+		if !ok {
+			return p, fmt.Errorf("unsupported architecture '%s' (supported: amd64, arm64)", arch)
+		}
+		p.Arch = a
+	}
+	if p.OS == "linux" {
+		p.Musl = isMusl()
+	}
+	return p, nil
+}
+
+// MuslLoaderGlob is the pattern of the dynamic loader found on musl systems.
+//
+// NOTE: This is a package level variable as it makes testing the behaviour of
+// the package easier because the test code can replace the value when running
+// the test suite.
+var MuslLoaderGlob = "/lib/ld-musl-*.so.1"
+
+// isMusl reports whether the host uses musl libc, which ships its own dynamic
+// loader instead of the glibc ld-linux loader.
+func isMusl() bool {
+	matches, err := filepath.Glob(MuslLoaderGlob)
+	return err == nil && len(matches) > 0
+}
+
+// SetPlatformAsset configures the versioner to download the release asset of
+// the given version for the platform.
+func SetPlatformAsset(v Versioner, p Platform, version semver.Version, ext string) {
+	v.SetAsset(p.Assets(v.BinaryName(), version, ext)...)
+}
@@ -0,0 +1,21 @@
+package update
+
+import (
+	"runtime"
+
+	"golang.org/x/sys/unix"
+)
+
+// hostArch returns the architecture of the host.
+//
+// NOTE: An amd64 build of the CLI running under Rosetta on Apple silicon
+// reports amd64, so we check whether the process is translated to download
+// native arm64 binaries instead.
+func hostArch() string {
+	if runtime.GOARCH == "amd64" {
+		if v, err := unix.SysctlUint32("sysctl.proc_translated"); err == nil && v == 1 {
+			return "arm64"
+		}
+	}
+	return runtime.GOARCH
+}
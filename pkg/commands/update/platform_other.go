@@ -0,0 +1,10 @@
+//go:build !darwin
+
+package update
+
+import "runtime"
+
+// hostArch returns the architecture of the host.
+func hostArch() string {
+	return runtime.GOARCH
+}
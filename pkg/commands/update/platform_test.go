@@ -0,0 +1,111 @@
+package update
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/blang/semver"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestDetectPlatform(t *testing.T) {
+	scenarios := []struct {
+		name     string
+		arch     string
+		wantArch string
+		wantErr  bool
+	}{
+		{
+			name:     "alias aarch64",
+			arch:     "aarch64",
+			wantArch: "arm64",
+		},
+		{
+			name:     "alias x86_64",
+			arch:     "X86_64",
+			wantArch: "amd64",
+		},
+		{
+			name:     "arm64",
+			arch:     "arm64",
+			wantArch: "arm64",
+		},
+		{
+			name:    "unsupported",
+			arch:    "mips",
+			wantErr: true,
+		},
+	}
+
+	for _, testcase := range scenarios {
+		t.Run(testcase.name, func(t *testing.T) {
+			p, err := DetectPlatform(testcase.arch)
+			if testcase.wantErr {
+				if err == nil {
+					t.Fatal("expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if p.OS != runtime.GOOS {
+				t.Fatalf("want OS %s, have %s", runtime.GOOS, p.OS)
+			}
+			if p.Arch != testcase.wantArch {
+				t.Fatalf("want arch %s, have %s", testcase.wantArch, p.Arch)
+			}
+		})
+	}
+}
+
+func TestDetectPlatformMusl(t *testing.T) {
+	if runtime.GOOS != "linux" {
+		t.Skip()
+	}
+
+	dir := t.TempDir()
+	original := MuslLoaderGlob
+	defer func() { MuslLoaderGlob = original }()
+	MuslLoaderGlob = filepath.Join(dir, "ld-musl-*.so.1")
+
+	p, err := DetectPlatform("")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if p.Musl {
+		t.Fatal("expected glibc without a musl loader")
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, "ld-musl-aarch64.so.1"), nil, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	p, err = DetectPlatform("")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if !p.Musl {
+		t.Fatal("expected musl with a musl loader")
+	}
+}
+
+func TestPlatformAssets(t *testing.T) {
+	version := semver.MustParse("0.4.0")
+
+	p := Platform{OS: "linux", Arch: "arm64", Musl: true}
+	want := []string{
+		"viceroy_v0.4.0_linux-arm64-musl.tar.gz",
+		"viceroy_v0.4.0_linux-arm64.tar.gz",
+	}
+	if diff := cmp.Diff(want, p.Assets("viceroy", version, ".tar.gz")); diff != "" {
+		t.Fatalf("unexpected assets (-want +have):\n%s", diff)
+	}
+
+	p = Platform{OS: "darwin", Arch: "arm64"}
+	want = []string{"viceroy_v0.4.0_darwin-arm64.tar.gz"}
+	if diff := cmp.Diff(want, p.Assets("viceroy", version, ".tar.gz")); diff != "" {
+		t.Fatalf("unexpected assets (-want +have):\n%s", diff)
+	}
+}
@@ -19,6 +19,7 @@ import (
 // It should be installed under the primary root command.
 type RootCommand struct {
 	cmd.Base
+	arch           string
 	cliVersioner   Versioner
 	configFilePath string
 }
@@ -30,12 +31,19 @@ func NewRootCommand(parent cmd.Registerer, configFilePath string, cliVersioner V
 	c.CmdClause = parent.Command("update", "Update the CLI to the latest version")
 	c.cliVersioner = cliVersioner
 	c.configFilePath = configFilePath
+	c.CmdClause.Flag("arch", "The architecture of the binary to download, instead of the detected one (amd64, arm64)").StringVar(&c.arch)
 	return &c
 }
 
 // Exec implements the command interface.
 func (c *RootCommand) Exec(_ io.Reader, out io.Writer) error {
+	platform, err := DetectPlatform(c.arch)
+	if err != nil {
+		return err
+	}
+
 	current, latest, shouldUpdate := Check(context.Background(), revision.AppVersion, c.cliVersioner)
+	SetPlatformAsset(c.cliVersioner, platform, latest, cliArchiveFormat())
 
 	text.Break(out)
 	text.Output(out, "Current version: %s", current)
@@ -7,7 +7,6 @@ import (
 	"net/http"
 	"os"
 	"path/filepath"
-	"runtime"
 	"strings"
 
 	"github.com/blang/semver"
@@ -32,7 +31,7 @@ type Versioner interface {
 	BinaryName() string
 	Download(context.Context, semver.Version) (filename string, err error)
 	LatestVersion(context.Context) (semver.Version, error)
-	SetAsset(name string)
+	SetAsset(names ...string)
 }
 
 // GitHubRepoClient describes the GitHub client behaviours we need.
@@ -45,11 +44,13 @@ type GitHubRepoClient interface {
 
 // GitHub is a versioner that uses GitHub releases.
 type GitHub struct {
-	client       GitHubRepoClient
-	org          string
-	repo         string
-	binary       string // name of compiled binary
-	releaseAsset string // name of the release asset file to download
+	client        GitHubRepoClient
+	org           string
+	repo          string
+	binary        string   // name of compiled binary
+	releaseAssets []string // names of the release asset files to download, in order of preference
+	releasePrefix string   // prefix of the release names before the version
+	archivePath   func(semver.Version) string
 }
 
 // GitHubOpts represents options to be passed to NewGitHub.
@@ -57,6 +58,13 @@ type GitHubOpts struct {
 	Org    string
 	Repo   string
 	Binary string
+
+	// ReleasePrefix is the prefix of the release names before the version
+	// (default "v"), e.g. "version_" for binaryen's version_116 releases.
+	ReleasePrefix string
+	// ArchivePath returns the path of the binary within the release asset of a
+	// version, when it isn't at the root of the archive.
+	ArchivePath func(semver.Version) string
 }
 
 // NewGitHub returns a usable GitHub versioner utilizing the provided token.
@@ -66,11 +74,18 @@ func NewGitHub(opts GitHubOpts) *GitHub {
 		binary = binary + ".exe"
 	}
 
+	prefix := opts.ReleasePrefix
+	if prefix == "" {
+		prefix = "v"
+	}
+
 	return &GitHub{
-		client: github.NewClient(nil).Repositories,
-		org:    opts.Org,
-		repo:   opts.Repo,
-		binary: binary,
+		client:        github.NewClient(nil).Repositories,
+		org:           opts.Org,
+		repo:          opts.Repo,
+		binary:        binary,
+		releasePrefix: prefix,
+		archivePath:   opts.ArchivePath,
 	}
 }
 
@@ -89,13 +104,16 @@ func (g *GitHub) BinaryName() string {
 
 // SetAsset allows configuring the release asset format.
 //
+// When multiple names are given the first one published with the release is
+// downloaded (e.g. a musl build with a fallback to the standard build).
+//
 // NOTE: This existed because the CLI project was originally using a different
 // release asset name format to the Viceroy project. Although the two projects
 // are now aligned we've kept this feature in case there are any changes
 // between the two projects in the future, or if we have to call out to more
 // external binaries from within the CLI.
-func (g *GitHub) SetAsset(name string) {
-	g.releaseAsset = name
+func (g *GitHub) SetAsset(names ...string) {
+	g.releaseAssets = names
 }
 
 // LatestVersion calls the GitHub API to return the latest release as a semver.
@@ -104,7 +122,19 @@ func (g GitHub) LatestVersion(ctx context.Context) (semver.Version, error) {
 	if err != nil {
 		return semver.Version{}, err
 	}
-	return semver.Parse(strings.TrimPrefix(release.GetName(), "v"))
+	return g.parseRelease(release.GetName())
+}
+
+// parseRelease returns the version of a release from its name.
+//
+// NOTE: Versions missing a minor or patch number (e.g. binaryen's version_116)
+// are accepted, with the missing numbers set to zero.
+func (g GitHub) parseRelease(name string) (semver.Version, error) {
+	prefix := g.releasePrefix
+	if prefix == "" {
+		prefix = "v"
+	}
+	return semver.ParseTolerant(strings.TrimPrefix(name, prefix))
 }
 
 // Download implements the Versioner interface.
@@ -124,7 +154,7 @@ func (g GitHub) Download(ctx context.Context, version semver.Version) (string, e
 		return "", fmt.Errorf("error fetching release: %w", err)
 	}
 
-	assetID, err := g.GetAssetID(release.Assets)
+	assetID, assetName, err := g.GetAssetID(release.Assets)
 	if err != nil {
 		return "", err
 	}
@@ -146,7 +176,7 @@ func (g GitHub) Download(ctx context.Context, version semver.Version) (string, e
 	//
 	// Disabling as the inputs need to be dynamically determined.
 	/* #nosec */
-	archive, err := os.Create(filepath.Join(dir, g.releaseAsset))
+	archive, err := os.Create(filepath.Join(dir, assetName))
 	if err != nil {
 		return "", fmt.Errorf("error creating release asset file: %w", err)
 	}
@@ -160,10 +190,14 @@ func (g GitHub) Download(ctx context.Context, version semver.Version) (string, e
 		return "", fmt.Errorf("error closing release asset file: %w", err)
 	}
 
-	if err := archiver.Extract(archive.Name(), g.binary, dir); err != nil {
+	archivePath := g.binary
+	if g.archivePath != nil {
+		archivePath = g.archivePath(version)
+	}
+	if err := archiver.Extract(archive.Name(), archivePath, dir); err != nil {
 		return "", fmt.Errorf("error extracting binary: %w", err)
 	}
-	extractedBinary := filepath.Join(dir, g.binary)
+	extractedBinary := filepath.Join(dir, filepath.FromSlash(archivePath))
 
 	// G302 (CWE-276): Expect file permissions to be 0600 or less
 	// gosec flagged this:
@@ -199,11 +233,7 @@ func (g GitHub) Download(ctx context.Context, version semver.Version) (string, e
 
 // GetReleaseID returns the release ID.
 func (g GitHub) GetReleaseID(ctx context.Context, version semver.Version) (id int64, err error) {
-	var (
-		page        int
-		versionStr  = version.String()
-		vVersionStr = "v" + versionStr
-	)
+	var page int
 	for {
 		releases, resp, err := g.client.ListReleases(ctx, g.org, g.repo, &github.ListOptions{
 			Page:    page,
@@ -213,7 +243,7 @@ func (g GitHub) GetReleaseID(ctx context.Context, version semver.Version) (id in
 			return id, err
 		}
 		for _, release := range releases {
-			if name := release.GetName(); name == versionStr || name == vVersionStr {
+			if v, err := g.parseRelease(release.GetName()); err == nil && v.Equals(version) {
 				return release.GetID(), nil
 			}
 		}
@@ -225,15 +255,18 @@ func (g GitHub) GetReleaseID(ctx context.Context, version semver.Version) (id in
 	return id, fmt.Errorf("no matching release found")
 }
 
-// GetAssetID returns the asset ID.
-func (g GitHub) GetAssetID(assets []*github.ReleaseAsset) (id int64, err error) {
-	if g.releaseAsset == "" {
-		return id, fmt.Errorf("no release asset specified")
+// GetAssetID returns the ID and name of the first configured release asset
+// found in assets.
+func (g GitHub) GetAssetID(assets []*github.ReleaseAsset) (id int64, name string, err error) {
+	if len(g.releaseAssets) == 0 {
+		return id, name, fmt.Errorf("no release asset specified")
 	}
-	for _, asset := range assets {
-		if asset.GetName() == g.releaseAsset {
-			return asset.GetID(), nil
+	for _, name := range g.releaseAssets {
+		for _, asset := range assets {
+			if asset.GetName() == name {
+				return asset.GetID(), name, nil
+			}
 		}
 	}
-	return id, fmt.Errorf("no asset found for your OS (%s) and architecture (%s)", runtime.GOOS, runtime.GOARCH)
+	return id, name, fmt.Errorf("no asset found for your platform (tried: %s), use --arch to select a different architecture", strings.Join(g.releaseAssets, ", "))
 }
@@ -22,7 +22,7 @@ func TestName(t *testing.T) {
 		want = want + ".exe"
 	}
 
-	gh := NewGitHub(GitHubOpts{"org", "repo", "fastly"})
+	gh := NewGitHub(GitHubOpts{Org: "org", Repo: "repo", Binary: "fastly"})
 
 	if have := gh.Binary(); have != want {
 		t.Fatalf("want: %s, have: %s", want, have)
@@ -49,8 +49,8 @@ func (c mockClient) DownloadReleaseAsset(_ context.Context, _, _ string, _ int64
 	return asset, redirectURL, err
 }
 
-func (c mockClient) ListReleases(_ context.Context, _, _ string, _ *github.ListOptions) (releases []*github.RepositoryRelease, response *github.Response, err error) {
-	return []*github.RepositoryRelease{c.release}, response, err
+func (c mockClient) ListReleases(_ context.Context, _, _ string, _ *github.ListOptions) ([]*github.RepositoryRelease, *github.Response, error) {
+	return []*github.RepositoryRelease{c.release}, &github.Response{}, nil
 }
 
 // Mock some functions called by Download() method...
@@ -133,3 +133,26 @@ func TestDownloadArchiveExtract(t *testing.T) {
 		})
 	}
 }
+
+// TestGetReleaseIDPrefix validates releases are matched by version regardless
+// of the prefix of their name.
+func TestGetReleaseIDPrefix(t *testing.T) {
+	name := "version_116"
+	id := int64(123)
+	gh := NewGitHub(GitHubOpts{Org: "WebAssembly", Repo: "binaryen", Binary: "wasm-opt", ReleasePrefix: "version_"})
+	gh.client = mockClient{
+		release: &github.RepositoryRelease{Name: &name, ID: &id},
+	}
+
+	have, err := gh.GetReleaseID(context.Background(), semver.MustParse("116.0.0"))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if have != id {
+		t.Fatalf("want: %d, have: %d", id, have)
+	}
+
+	if _, err := gh.GetReleaseID(context.Background(), semver.MustParse("115.0.0")); err == nil {
+		t.Fatal("expected an error for a missing release")
+	}
+}
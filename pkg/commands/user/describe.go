@@ -22,9 +22,10 @@ func NewDescribeCommand(parent cmd.Registerer, globals *config.Data, data manife
 	c.RegisterFlagBool(cmd.BoolFlagOpts{
 		Name:        cmd.FlagJSONName,
 		Description: cmd.FlagJSONDesc,
-		Dst:         &c.json,
+		Dst:         &c.output.JSON,
 		Short:       'j',
 	})
+	c.RegisterOutputFlags(&c.output)
 	return &c
 }
 
@@ -34,16 +35,27 @@ type DescribeCommand struct {
 
 	current  bool
 	id       string
-	json     bool
 	manifest manifest.Data
+	output   cmd.Output
 }
 
 // Exec invokes the application logic for the command.
-func (c *DescribeCommand) Exec(_ io.Reader, out io.Writer) error {
+func (c *DescribeCommand) Exec(_ io.Reader, out io.Writer) (err error) {
 	_, s := c.Globals.Token()
 	if s == config.SourceUndefined {
 		return errors.ErrNoToken
 	}
+	if c.Globals.Verbose() && c.output.Structured() {
+		return errors.ErrInvalidVerboseJSONCombo
+	}
+
+	out, closeOutput, err := c.output.Writer(out)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err = closeOutput(err)
+	}()
 
 	if c.current {
 		r, err := c.Globals.APIClient.GetCurrentUser()
@@ -52,8 +64,7 @@ func (c *DescribeCommand) Exec(_ io.Reader, out io.Writer) error {
 			return err
 		}
 
-		c.print(out, r)
-		return nil
+		return c.print(out, r)
 	}
 
 	input, err := c.constructInput()
@@ -67,8 +78,7 @@ func (c *DescribeCommand) Exec(_ io.Reader, out io.Writer) error {
 		return err
 	}
 
-	c.print(out, r)
-	return nil
+	return c.print(out, r)
 }
 
 // constructInput transforms values parsed from CLI flags into an object to be used by the API client library.
@@ -87,7 +97,15 @@ func (c *DescribeCommand) constructInput() (*fastly.GetUserInput, error) {
 }
 
 // print displays the information returned from the API.
-func (c *DescribeCommand) print(out io.Writer, r *fastly.User) {
+func (c *DescribeCommand) print(out io.Writer, r *fastly.User) error {
+	if c.output.Structured() {
+		if err := c.output.Write(out, r); err != nil {
+			c.Globals.ErrLog.Add(err)
+			return err
+		}
+		return nil
+	}
+
 	fmt.Fprintf(out, "\nID: %s\n", r.ID)
 	fmt.Fprintf(out, "Login: %s\n", r.Login)
 	fmt.Fprintf(out, "Name: %s\n", r.Name)
@@ -109,4 +127,5 @@ func (c *DescribeCommand) print(out io.Writer, r *fastly.User) {
 	if r.DeletedAt != nil {
 		fmt.Fprintf(out, "Deleted at: %s\n", r.DeletedAt)
 	}
+	return nil
 }
@@ -0,0 +1,282 @@
+package user
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/fastly/cli/pkg/cmd"
+	"github.com/fastly/cli/pkg/config"
+	fsterr "github.com/fastly/cli/pkg/errors"
+	"github.com/fastly/cli/pkg/manifest"
+	"github.com/fastly/cli/pkg/text"
+	"github.com/fastly/go-fastly/v6/fastly"
+)
+
+// Roles are the permissions roles that can be assigned to a user.
+var Roles = []string{"user", "billing", "engineer", "superuser"}
+
+// Permissions are the levels of permission a service authorization grants.
+var Permissions = []string{"full", "read_only", "purge_select", "purge_all"}
+
+// NewInviteCommand returns a usable command registered under the parent.
+func NewInviteCommand(parent cmd.Registerer, globals *config.Data, data manifest.Data) *InviteCommand {
+	var c InviteCommand
+	c.CmdClause = parent.Command("invite", "Invite users in bulk from a CSV file, and authorize them to access services")
+	c.Globals = globals
+	c.manifest = data
+
+	// Required flags
+	c.CmdClause.Flag("file", "Path to a CSV file with a header row and the columns login, name, and optionally role and services (e.g. SERVICE_ID:read_only;SERVICE_ID)").Short('f').Required().StringVar(&c.file)
+
+	// Optional flags
+	c.CmdClause.Flag("permission", "The permission granted for services listed without one: full, read_only, purge_select or purge_all").Default("full").EnumVar(&c.permission, Permissions...)
+	c.CmdClause.Flag("role", "The permissions role assigned to users without one: user, billing, engineer or superuser").EnumVar(&c.role, Roles...)
+
+	return &c
+}
+
+// InviteCommand calls the Fastly API to create users and their service
+// authorizations in bulk.
+type InviteCommand struct {
+	cmd.Base
+
+	file       string
+	manifest   manifest.Data
+	permission string
+	role       string
+}
+
+// Invite is a user to invite, read from a row of the CSV file.
+type Invite struct {
+	Line     int
+	Login    string
+	Name     string
+	Role     string
+	Services []ServicePermission
+}
+
+// ServicePermission is a service the invited user is authorized to access.
+type ServicePermission struct {
+	ServiceID  string
+	Permission string
+}
+
+// inviteResult is the outcome of inviting a user.
+type inviteResult struct {
+	invite     Invite
+	user       *fastly.User
+	authorized int
+	err        error
+}
+
+// Exec invokes the application logic for the command.
+func (c *InviteCommand) Exec(_ io.Reader, out io.Writer) error {
+	_, s := c.Globals.Token()
+	if s == config.SourceUndefined {
+		return fsterr.ErrNoToken
+	}
+
+	f, err := os.Open(filepath.Clean(c.file))
+	if err != nil {
+		c.Globals.ErrLog.Add(err)
+		return fmt.Errorf("error reading --file: %w", err)
+	}
+	defer f.Close() // #nosec G307
+
+	invites, err := ParseInvites(f, c.role, c.permission)
+	if err != nil {
+		c.Globals.ErrLog.Add(err)
+		return fsterr.RemediationError{
+			Inner:       fmt.Errorf("error parsing --file: %w", err),
+			Remediation: "Check the CSV file has a header row with the columns login and name, and optionally role and services.",
+		}
+	}
+
+	results := make([]inviteResult, 0, len(invites))
+	var failed int
+	for _, inv := range invites {
+		r := c.invite(inv)
+		if r.err != nil {
+			failed++
+		}
+		results = append(results, r)
+	}
+
+	t := text.NewTable(out)
+	t.AddHeader("LOGIN", "ROLE", "SERVICES", "RESULT")
+	for _, r := range results {
+		role := r.invite.Role
+		if r.user != nil {
+			role = r.user.Role
+		}
+		result := "OK"
+		if r.err != nil {
+			result = "ERROR: " + r.err.Error()
+		}
+		t.AddLine(r.invite.Login, role, fmt.Sprintf("%d/%d", r.authorized, len(r.invite.Services)), result)
+	}
+	t.Print()
+
+	if failed > 0 {
+		return fmt.Errorf("failed to invite %d of %d users", failed, len(results))
+	}
+	text.Success(out, "Invited %d users", len(results))
+	return nil
+}
+
+// invite creates the user, then authorizes them to access each service.
+//
+// NOTE: The services are authorized even when one of them fails, so that the
+// summary reports every service the user can't access.
+func (c *InviteCommand) invite(inv Invite) inviteResult {
+	r := inviteResult{invite: inv}
+
+	user, err := c.Globals.APIClient.CreateUser(&fastly.CreateUserInput{
+		Login: inv.Login,
+		Name:  inv.Name,
+		Role:  inv.Role,
+	})
+	if err != nil {
+		c.Globals.ErrLog.AddWithContext(err, map[string]any{
+			"User Login": inv.Login,
+			"User Name":  inv.Name,
+		})
+		r.err = fmt.Errorf("error creating user: %w", err)
+		return r
+	}
+	r.user = user
+
+	var failed []string
+	for _, sp := range inv.Services {
+		_, err := c.Globals.APIClient.CreateServiceAuthorization(&fastly.CreateServiceAuthorizationInput{
+			Permission: sp.Permission,
+			Service:    &fastly.SAService{ID: sp.ServiceID},
+			User:       &fastly.SAUser{ID: user.ID},
+		})
+		if err != nil {
+			c.Globals.ErrLog.AddWithContext(err, map[string]any{
+				"User Login": inv.Login,
+				"Service ID": sp.ServiceID,
+				"Permission": sp.Permission,
+			})
+			failed = append(failed, sp.ServiceID)
+			continue
+		}
+		r.authorized++
+	}
+	if len(failed) > 0 {
+		r.err = fmt.Errorf("error authorizing services: %s", strings.Join(failed, ", "))
+	}
+	return r
+}
+
+// ParseInvites reads the users to invite from CSV, whose header row names the
+// columns login, name, role (optional) and services (optional). Each services
+// value is a list of service IDs separated by semicolons, each optionally
+// followed by :PERMISSION. The role and permission fall back to the given
+// defaults.
+//
+// Every row is validated before any user is invited.
+func ParseInvites(r io.Reader, role, permission string) ([]Invite, error) {
+	cr := csv.NewReader(r)
+	cr.TrimLeadingSpace = true
+	cr.FieldsPerRecord = -1
+
+	header, err := cr.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, errors.New("the file is empty")
+		}
+		return nil, err
+	}
+	columns := make(map[string]int)
+	for i, h := range header {
+		columns[strings.ToLower(strings.TrimSpace(h))] = i
+	}
+	for _, required := range []string{"login", "name"} {
+		if _, ok := columns[required]; !ok {
+			return nil, fmt.Errorf("the header row has no '%s' column", required)
+		}
+	}
+
+	var (
+		invites []Invite
+		seen    = make(map[string]int)
+	)
+	for {
+		record, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		line, _ := cr.FieldPos(0)
+		field := func(name string) string {
+			if i, ok := columns[name]; ok && i < len(record) {
+				return strings.TrimSpace(record[i])
+			}
+			return ""
+		}
+
+		inv := Invite{
+			Line:  line,
+			Login: field("login"),
+			Name:  field("name"),
+			Role:  field("role"),
+		}
+		if inv.Login == "" && inv.Name == "" {
+			continue
+		}
+		if inv.Login == "" || inv.Name == "" {
+			return nil, fmt.Errorf("line %d: a login and name are required", line)
+		}
+		if prev, ok := seen[strings.ToLower(inv.Login)]; ok {
+			return nil, fmt.Errorf("line %d: '%s' is already invited on line %d", line, inv.Login, prev)
+		}
+		seen[strings.ToLower(inv.Login)] = line
+
+		if inv.Role == "" {
+			inv.Role = role
+		}
+		if inv.Role != "" && !contains(Roles, inv.Role) {
+			return nil, fmt.Errorf("line %d: invalid role '%s' (valid roles: %s)", line, inv.Role, strings.Join(Roles, ", "))
+		}
+
+		for _, s := range strings.Split(field("services"), ";") {
+			s = strings.TrimSpace(s)
+			if s == "" {
+				continue
+			}
+			sp := ServicePermission{ServiceID: s, Permission: permission}
+			if id, p, ok := strings.Cut(s, ":"); ok {
+				sp.ServiceID, sp.Permission = strings.TrimSpace(id), strings.TrimSpace(p)
+			}
+			if !contains(Permissions, sp.Permission) {
+				return nil, fmt.Errorf("line %d: invalid permission '%s' (valid permissions: %s)", line, sp.Permission, strings.Join(Permissions, ", "))
+			}
+			inv.Services = append(inv.Services, sp)
+		}
+
+		invites = append(invites, inv)
+	}
+
+	if len(invites) == 0 {
+		return nil, errors.New("the file has no users")
+	}
+	return invites, nil
+}
+
+func contains(values []string, v string) bool {
+	for _, s := range values {
+		if s == v {
+			return true
+		}
+	}
+	return false
+}
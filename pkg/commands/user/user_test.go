@@ -3,9 +3,13 @@ package user_test
 import (
 	"bytes"
 	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/fastly/cli/pkg/app"
+	"github.com/fastly/cli/pkg/commands/user"
 	"github.com/fastly/cli/pkg/errors"
 	"github.com/fastly/cli/pkg/mock"
 	"github.com/fastly/cli/pkg/testutil"
@@ -180,6 +184,152 @@ func TestDescribe(t *testing.T) {
 	}
 }
 
+func TestInvite(t *testing.T) {
+	args := testutil.Args
+	csv := `login,name,role,services
+foo@example.com,foo,,123;456:read_only
+bar@example.com,bar,superuser,
+`
+	path := filepath.Join(t.TempDir(), "users.csv")
+	if err := os.WriteFile(path, []byte(csv), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	invalid := filepath.Join(t.TempDir(), "users.csv")
+	if err := os.WriteFile(invalid, []byte("login,name\nfoo@example.com,\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	var authorized []string
+	createUser := func(i *fastly.CreateUserInput) (*fastly.User, error) {
+		return &fastly.User{ID: strings.Split(i.Login, "@")[0] + "-id", Login: i.Login, Name: i.Name, Role: i.Role}, nil
+	}
+
+	scenarios := []testutil.TestScenario{
+		{
+			Name:      "validate missing --file flag",
+			Args:      args("user invite --token 123"),
+			WantError: "error parsing arguments: required flag --file not provided",
+		},
+		{
+			Name:      "validate invalid row",
+			Args:      args("user invite --file " + invalid + " --token 123"),
+			WantError: "error parsing --file: line 2: a login and name are required",
+		},
+		{
+			Name: "validate CreateUser API error",
+			API: mock.API{
+				CreateUserFn: func(i *fastly.CreateUserInput) (*fastly.User, error) {
+					if i.Login == "bar@example.com" {
+						return nil, testutil.Err
+					}
+					return createUser(i)
+				},
+				CreateServiceAuthorizationFn: func(i *fastly.CreateServiceAuthorizationInput) (*fastly.ServiceAuthorization, error) {
+					return &fastly.ServiceAuthorization{}, nil
+				},
+			},
+			Args:       args("user invite --file " + path + " --role engineer --token 123"),
+			WantError:  "failed to invite 1 of 2 users",
+			WantOutput: "bar@example.com  superuser  0/0       ERROR: error creating user: test error",
+		},
+		{
+			Name: "validate CreateServiceAuthorization API error",
+			API: mock.API{
+				CreateUserFn: createUser,
+				CreateServiceAuthorizationFn: func(i *fastly.CreateServiceAuthorizationInput) (*fastly.ServiceAuthorization, error) {
+					if i.Service.ID == "123" {
+						return nil, testutil.Err
+					}
+					return &fastly.ServiceAuthorization{}, nil
+				},
+			},
+			Args:       args("user invite --file " + path + " --role engineer --token 123"),
+			WantError:  "failed to invite 1 of 2 users",
+			WantOutput: "foo@example.com  engineer   1/2       ERROR: error authorizing services: 123",
+		},
+		{
+			Name: "validate success",
+			API: mock.API{
+				CreateUserFn: createUser,
+				CreateServiceAuthorizationFn: func(i *fastly.CreateServiceAuthorizationInput) (*fastly.ServiceAuthorization, error) {
+					authorized = append(authorized, fmt.Sprintf("%s:%s:%s", i.User.ID, i.Service.ID, i.Permission))
+					return &fastly.ServiceAuthorization{}, nil
+				},
+			},
+			Args:       args("user invite --file " + path + " --role engineer --token 123"),
+			WantOutput: inviteOutput(),
+		},
+	}
+
+	for testcaseIdx := range scenarios {
+		testcase := &scenarios[testcaseIdx]
+		t.Run(testcase.Name, func(t *testing.T) {
+			var stdout bytes.Buffer
+			opts := testutil.NewRunOpts(testcase.Args, &stdout)
+			opts.APIClient = mock.APIClient(testcase.API)
+			err := app.Run(opts)
+			testutil.AssertErrorContains(t, err, testcase.WantError)
+			testutil.AssertStringContains(t, stdout.String(), testcase.WantOutput)
+		})
+	}
+
+	testutil.AssertEqual(t, []string{"foo-id:123:full", "foo-id:456:read_only"}, authorized)
+}
+
+func TestParseInvites(t *testing.T) {
+	for _, testcase := range []struct {
+		name      string
+		csv       string
+		want      []user.Invite
+		wantError string
+	}{
+		{
+			name: "defaults",
+			csv:  "Name, Login, Services\nfoo, foo@example.com, 123\n\n",
+			want: []user.Invite{
+				{
+					Line:     2,
+					Login:    "foo@example.com",
+					Name:     "foo",
+					Role:     "engineer",
+					Services: []user.ServicePermission{{ServiceID: "123", Permission: "purge_all"}},
+				},
+			},
+		},
+		{
+			name:      "missing column",
+			csv:       "login\nfoo@example.com\n",
+			wantError: "the header row has no 'name' column",
+		},
+		{
+			name:      "duplicate login",
+			csv:       "login,name\nfoo@example.com,foo\nFOO@example.com,foo\n",
+			wantError: "line 3: 'FOO@example.com' is already invited on line 2",
+		},
+		{
+			name:      "invalid role",
+			csv:       "login,name,role\nfoo@example.com,foo,admin\n",
+			wantError: "line 2: invalid role 'admin'",
+		},
+		{
+			name:      "invalid permission",
+			csv:       "login,name,services\nfoo@example.com,foo,123:write\n",
+			wantError: "line 2: invalid permission 'write'",
+		},
+		{
+			name:      "no users",
+			csv:       "login,name\n",
+			wantError: "the file has no users",
+		},
+	} {
+		t.Run(testcase.name, func(t *testing.T) {
+			invites, err := user.ParseInvites(strings.NewReader(testcase.csv), "engineer", "purge_all")
+			testutil.AssertErrorContains(t, err, testcase.wantError)
+			testutil.AssertEqual(t, testcase.want, invites)
+		})
+	}
+}
+
 func TestList(t *testing.T) {
 	args := testutil.Args
 	scenarios := []testutil.TestScenario{
@@ -413,6 +563,15 @@ Deleted at: 2021-06-15 23:00:00 +0000 UTC
 `
 }
 
+func inviteOutput() string {
+	return `LOGIN            ROLE       SERVICES  RESULT
+foo@example.com  engineer   2/2       OK
+bar@example.com  superuser  0/0       OK
+
+SUCCESS: Invited 2 users
+`
+}
+
 func listOutput() string {
 	return `LOGIN            NAME  ROLE       LOCKED  ID
 foo@example.com  foo   user       true    123
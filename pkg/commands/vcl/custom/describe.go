@@ -1,7 +1,6 @@
 package custom
 
 import (
-	"encoding/json"
 	"fmt"
 	"io"
 
@@ -32,9 +31,10 @@ func NewDescribeCommand(parent cmd.Registerer, globals *config.Data, data manife
 	c.RegisterFlagBool(cmd.BoolFlagOpts{
 		Name:        cmd.FlagJSONName,
 		Description: cmd.FlagJSONDesc,
-		Dst:         &c.json,
+		Dst:         &c.output.JSON,
 		Short:       'j',
 	})
+	c.RegisterOutputFlags(&c.output)
 	c.RegisterFlag(cmd.StringFlagOpts{
 		Name:        cmd.FlagServiceIDName,
 		Description: cmd.FlagServiceIDDesc,
@@ -55,7 +55,7 @@ func NewDescribeCommand(parent cmd.Registerer, globals *config.Data, data manife
 type DescribeCommand struct {
 	cmd.Base
 
-	json           bool
+	output         cmd.Output
 	manifest       manifest.Data
 	name           string
 	serviceName    cmd.OptionalServiceNameID
@@ -63,11 +63,19 @@ type DescribeCommand struct {
 }
 
 // Exec invokes the application logic for the command.
-func (c *DescribeCommand) Exec(_ io.Reader, out io.Writer) error {
-	if c.Globals.Verbose() && c.json {
+func (c *DescribeCommand) Exec(_ io.Reader, out io.Writer) (err error) {
+	if c.Globals.Verbose() && c.output.Structured() {
 		return fsterr.ErrInvalidVerboseJSONCombo
 	}
 
+	out, closeOutput, err := c.output.Writer(out)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err = closeOutput(err)
+	}()
+
 	serviceID, serviceVersion, err := cmd.ServiceDetails(cmd.ServiceDetailsOpts{
 		AllowActiveLocked:  true,
 		APIClient:          c.Globals.APIClient,
@@ -116,15 +124,10 @@ func (c *DescribeCommand) constructInput(serviceID string, serviceVersion int) *
 
 // print displays the information returned from the API.
 func (c *DescribeCommand) print(out io.Writer, v *fastly.VCL) error {
-	if c.json {
-		data, err := json.Marshal(v)
-		if err != nil {
-			return err
-		}
-		_, err = out.Write(data)
-		if err != nil {
+	if c.output.Structured() {
+		if err := c.output.Write(out, v); err != nil {
 			c.Globals.ErrLog.Add(err)
-			return fmt.Errorf("error: unable to write data to stdout: %w", err)
+			return err
 		}
 		return nil
 	}
@@ -0,0 +1,95 @@
+package generated
+
+import (
+	"io"
+
+	"github.com/fastly/cli/pkg/cmd"
+	"github.com/fastly/cli/pkg/config"
+	fsterr "github.com/fastly/cli/pkg/errors"
+	"github.com/fastly/cli/pkg/manifest"
+	"github.com/fastly/cli/pkg/text"
+)
+
+// NewDiffCommand returns a usable command registered under the parent.
+func NewDiffCommand(parent cmd.Registerer, globals *config.Data, data manifest.Data) *DiffCommand {
+	var c DiffCommand
+	c.CmdClause = parent.Command("diff", "Compare the VCL generated by Fastly for two versions of a service")
+	c.Globals = globals
+	c.manifest = data
+
+	// Required flags
+	c.CmdClause.Flag("from", "The version to compare from: "+cmd.FlagVersionDesc).Required().StringVar(&c.from.Value)
+	c.CmdClause.Flag("to", "The version to compare to: "+cmd.FlagVersionDesc).Required().StringVar(&c.to.Value)
+
+	// Optional Flags
+	c.RegisterFlag(cmd.StringFlagOpts{
+		Name:        cmd.FlagServiceIDName,
+		Description: cmd.FlagServiceIDDesc,
+		Dst:         &c.manifest.Flag.ServiceID,
+		Short:       's',
+	})
+	c.RegisterFlag(cmd.StringFlagOpts{
+		Action:      c.serviceName.Set,
+		Name:        cmd.FlagServiceName,
+		Description: cmd.FlagServiceDesc,
+		Dst:         &c.serviceName.Value,
+	})
+
+	return &c
+}
+
+// DiffCommand calls the Fastly API to compare the generated VCL of two
+// service versions.
+type DiffCommand struct {
+	cmd.Base
+
+	from        cmd.OptionalServiceVersion
+	manifest    manifest.Data
+	serviceName cmd.OptionalServiceNameID
+	to          cmd.OptionalServiceVersion
+}
+
+// Exec invokes the application logic for the command.
+func (c *DiffCommand) Exec(_ io.Reader, out io.Writer) error {
+	serviceID, from, err := cmd.ServiceDetails(cmd.ServiceDetailsOpts{
+		AllowActiveLocked:  true,
+		APIClient:          c.Globals.APIClient,
+		Manifest:           c.manifest,
+		Out:                out,
+		ServiceNameFlag:    c.serviceName,
+		ServiceVersionFlag: c.from,
+		VerboseMode:        c.Globals.Flag.Verbose,
+	})
+	if err != nil {
+		c.Globals.ErrLog.AddWithContext(err, map[string]any{
+			"Service ID":      serviceID,
+			"Service Version": fsterr.ServiceVersion(from),
+		})
+		return err
+	}
+
+	to, err := c.to.Parse(serviceID, c.Globals.APIClient)
+	if err != nil {
+		c.Globals.ErrLog.AddWithContext(err, map[string]any{
+			"Service ID":      serviceID,
+			"Service Version": c.to.Value,
+		})
+		return err
+	}
+
+	a, err := getGeneratedVCL(c.Globals, serviceID, from.Number)
+	if err != nil {
+		return err
+	}
+	b, err := getGeneratedVCL(c.Globals, serviceID, to.Number)
+	if err != nil {
+		return err
+	}
+
+	if a.Content == b.Content {
+		text.Info(out, "The generated VCL for service %s is the same in versions %d and %d", serviceID, from.Number, to.Number)
+		return nil
+	}
+	text.Diff(out, a.Content, b.Content)
+	return nil
+}
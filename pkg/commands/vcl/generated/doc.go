@@ -0,0 +1,3 @@
+// Package generated contains commands for inspecting the VCL generated by
+// Fastly for a service version.
+package generated
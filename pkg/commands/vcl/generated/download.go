@@ -0,0 +1,119 @@
+package generated
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/fastly/cli/pkg/cmd"
+	"github.com/fastly/cli/pkg/config"
+	fsterr "github.com/fastly/cli/pkg/errors"
+	"github.com/fastly/cli/pkg/manifest"
+	"github.com/fastly/cli/pkg/text"
+	"github.com/fastly/go-fastly/v6/fastly"
+)
+
+// NewDownloadCommand returns a usable command registered under the parent.
+func NewDownloadCommand(parent cmd.Registerer, globals *config.Data, data manifest.Data) *DownloadCommand {
+	var c DownloadCommand
+	c.CmdClause = parent.Command("download", "Download the VCL generated by Fastly for a particular service and version")
+	c.Globals = globals
+	c.manifest = data
+
+	// Required flags
+	c.RegisterFlag(cmd.StringFlagOpts{
+		Name:        cmd.FlagVersionName,
+		Description: cmd.FlagVersionDesc,
+		Dst:         &c.serviceVersion.Value,
+		Required:    true,
+	})
+
+	// Optional Flags
+	c.CmdClause.Flag("output", "Path of the file to write the VCL to (otherwise the VCL is written to stdout)").StringVar(&c.output)
+	c.RegisterFlag(cmd.StringFlagOpts{
+		Name:        cmd.FlagServiceIDName,
+		Description: cmd.FlagServiceIDDesc,
+		Dst:         &c.manifest.Flag.ServiceID,
+		Short:       's',
+	})
+	c.RegisterFlag(cmd.StringFlagOpts{
+		Action:      c.serviceName.Set,
+		Name:        cmd.FlagServiceName,
+		Description: cmd.FlagServiceDesc,
+		Dst:         &c.serviceName.Value,
+	})
+
+	return &c
+}
+
+// DownloadCommand calls the Fastly API to download the generated VCL.
+type DownloadCommand struct {
+	cmd.Base
+
+	manifest       manifest.Data
+	output         string
+	serviceName    cmd.OptionalServiceNameID
+	serviceVersion cmd.OptionalServiceVersion
+}
+
+// Exec invokes the application logic for the command.
+func (c *DownloadCommand) Exec(_ io.Reader, out io.Writer) error {
+	serviceID, serviceVersion, err := cmd.ServiceDetails(cmd.ServiceDetailsOpts{
+		AllowActiveLocked:  true,
+		APIClient:          c.Globals.APIClient,
+		Manifest:           c.manifest,
+		Out:                out,
+		ServiceNameFlag:    c.serviceName,
+		ServiceVersionFlag: c.serviceVersion,
+		VerboseMode:        c.Globals.Flag.Verbose,
+	})
+	if err != nil {
+		c.Globals.ErrLog.AddWithContext(err, map[string]any{
+			"Service ID":      serviceID,
+			"Service Version": fsterr.ServiceVersion(serviceVersion),
+		})
+		return err
+	}
+
+	v, err := getGeneratedVCL(c.Globals, serviceID, serviceVersion.Number)
+	if err != nil {
+		return err
+	}
+
+	if c.output == "" {
+		fmt.Fprint(out, v.Content)
+		return nil
+	}
+
+	dst, err := filepath.Abs(c.output)
+	if err != nil {
+		c.Globals.ErrLog.Add(err)
+		return fmt.Errorf("error resolving the output path: %w", err)
+	}
+	if err := os.WriteFile(dst, []byte(v.Content), 0o600); err != nil {
+		c.Globals.ErrLog.AddWithContext(err, map[string]any{
+			"Output": dst,
+		})
+		return fmt.Errorf("error writing the generated VCL: %w", err)
+	}
+
+	text.Success(out, "Downloaded the generated VCL for service %s version %d to %s", serviceID, serviceVersion.Number, dst)
+	return nil
+}
+
+// getGeneratedVCL returns the VCL generated for the service version.
+func getGeneratedVCL(globals *config.Data, serviceID string, serviceVersion int) (*fastly.VCL, error) {
+	v, err := globals.APIClient.GetGeneratedVCL(&fastly.GetGeneratedVCLInput{
+		ServiceID:      serviceID,
+		ServiceVersion: serviceVersion,
+	})
+	if err != nil {
+		globals.ErrLog.AddWithContext(err, map[string]any{
+			"Service ID":      serviceID,
+			"Service Version": serviceVersion,
+		})
+		return nil, err
+	}
+	return v, nil
+}
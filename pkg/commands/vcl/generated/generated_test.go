@@ -0,0 +1,149 @@
+package generated_test
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/fastly/cli/pkg/app"
+	"github.com/fastly/cli/pkg/mock"
+	"github.com/fastly/cli/pkg/testutil"
+	"github.com/fastly/go-fastly/v6/fastly"
+)
+
+func TestVCLGeneratedDownload(t *testing.T) {
+	args := testutil.Args
+	scenarios := []testutil.TestScenario{
+		{
+			Name:      "validate missing --version flag",
+			Args:      args("vcl generated download --service-id 123"),
+			WantError: "error parsing arguments: required flag --version not provided",
+		},
+		{
+			Name:      "validate missing --service-id flag",
+			Args:      args("vcl generated download --version 3"),
+			WantError: "error reading service: no service ID found",
+		},
+		{
+			Name: "validate GetGeneratedVCL API error",
+			API: mock.API{
+				ListVersionsFn: testutil.ListVersions,
+				GetGeneratedVCLFn: func(i *fastly.GetGeneratedVCLInput) (*fastly.VCL, error) {
+					return nil, testutil.Err
+				},
+			},
+			Args:      args("vcl generated download --service-id 123 --version 3"),
+			WantError: testutil.Err.Error(),
+		},
+		{
+			Name: "validate GetGeneratedVCL API success",
+			API: mock.API{
+				ListVersionsFn:    testutil.ListVersions,
+				GetGeneratedVCLFn: getGeneratedVCL,
+			},
+			Args:       args("vcl generated download --service-id 123 --version 1"),
+			WantOutput: "# version 1\nsub vcl_recv {\n}\n",
+		},
+	}
+
+	for testcaseIdx := range scenarios {
+		testcase := &scenarios[testcaseIdx]
+		t.Run(testcase.Name, func(t *testing.T) {
+			var stdout bytes.Buffer
+			opts := testutil.NewRunOpts(testcase.Args, &stdout)
+			opts.APIClient = mock.APIClient(testcase.API)
+			err := app.Run(opts)
+			testutil.AssertErrorContains(t, err, testcase.WantError)
+			testutil.AssertString(t, testcase.WantOutput, stdout.String())
+		})
+	}
+}
+
+func TestVCLGeneratedDownloadOutput(t *testing.T) {
+	output := filepath.Join(t.TempDir(), "out.vcl")
+
+	var stdout bytes.Buffer
+	opts := testutil.NewRunOpts(testutil.Args("vcl generated download --service-id 123 --version 2 --output "+output), &stdout)
+	opts.APIClient = mock.APIClient(mock.API{
+		ListVersionsFn:    testutil.ListVersions,
+		GetGeneratedVCLFn: getGeneratedVCL,
+	})
+	err := app.Run(opts)
+	testutil.AssertNoError(t, err)
+	testutil.AssertStringContains(t, stdout.String(), "Downloaded the generated VCL for service 123 version 2 to "+output)
+
+	data, err := os.ReadFile(output)
+	testutil.AssertNoError(t, err)
+	testutil.AssertString(t, "# version 2\nsub vcl_recv {\n}\n", string(data))
+}
+
+func TestVCLGeneratedDiff(t *testing.T) {
+	args := testutil.Args
+	scenarios := []testutil.TestScenario{
+		{
+			Name:      "validate missing --to flag",
+			Args:      args("vcl generated diff --service-id 123 --from 1"),
+			WantError: "error parsing arguments: required flag --to not provided",
+		},
+		{
+			Name: "validate invalid --to flag",
+			API: mock.API{
+				ListVersionsFn: testutil.ListVersions,
+			},
+			Args:      args("vcl generated diff --service-id 123 --from 1 --to 9"),
+			WantError: "specified service version not found: 9",
+		},
+		{
+			Name: "validate GetGeneratedVCL API error",
+			API: mock.API{
+				ListVersionsFn: testutil.ListVersions,
+				GetGeneratedVCLFn: func(i *fastly.GetGeneratedVCLInput) (*fastly.VCL, error) {
+					return nil, testutil.Err
+				},
+			},
+			Args:      args("vcl generated diff --service-id 123 --from 1 --to 2"),
+			WantError: testutil.Err.Error(),
+		},
+		{
+			Name: "validate diff",
+			API: mock.API{
+				ListVersionsFn:    testutil.ListVersions,
+				GetGeneratedVCLFn: getGeneratedVCL,
+			},
+			Args:       args("vcl generated diff --service-id 123 --from active --to latest"),
+			WantOutput: "- # version 1\n+ # version 3\n  sub vcl_recv {\n  }\n",
+		},
+		{
+			Name: "validate no differences",
+			API: mock.API{
+				ListVersionsFn:    testutil.ListVersions,
+				GetGeneratedVCLFn: getGeneratedVCL,
+			},
+			Args:       args("vcl generated diff --service-id 123 --from 2 --to 2"),
+			WantOutput: "\nINFO: The generated VCL for service 123 is the same in versions 2 and 2\n",
+		},
+	}
+
+	for testcaseIdx := range scenarios {
+		testcase := &scenarios[testcaseIdx]
+		t.Run(testcase.Name, func(t *testing.T) {
+			var stdout bytes.Buffer
+			opts := testutil.NewRunOpts(testcase.Args, &stdout)
+			opts.APIClient = mock.APIClient(testcase.API)
+			err := app.Run(opts)
+			testutil.AssertErrorContains(t, err, testcase.WantError)
+			testutil.AssertString(t, testcase.WantOutput, stdout.String())
+		})
+	}
+}
+
+func getGeneratedVCL(i *fastly.GetGeneratedVCLInput) (*fastly.VCL, error) {
+	return &fastly.VCL{
+		Content:        fmt.Sprintf("# version %d\nsub vcl_recv {\n}\n", i.ServiceVersion),
+		Main:           true,
+		ServiceID:      i.ServiceID,
+		ServiceVersion: i.ServiceVersion,
+	}, nil
+}
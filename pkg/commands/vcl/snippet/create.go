@@ -39,6 +39,7 @@ func NewCreateCommand(parent cmd.Registerer, globals *config.Data, data manifest
 	})
 	c.CmdClause.Flag("dynamic", "Whether the VCL snippet is dynamic or versioned").Action(c.dynamic.Set).BoolVar(&c.dynamic.Value)
 	c.CmdClause.Flag("priority", "Priority determines execution order. Lower numbers execute first").Short('p').Action(c.priority.Set).IntVar(&c.priority.Value)
+	c.template.register(c.CmdClause)
 
 	c.RegisterFlag(cmd.StringFlagOpts{
 		Name:        cmd.FlagServiceIDName,
@@ -69,6 +70,7 @@ type CreateCommand struct {
 	priority       cmd.OptionalInt
 	serviceName    cmd.OptionalServiceNameID
 	serviceVersion cmd.OptionalServiceVersion
+	template       templateOpts
 }
 
 // Exec invokes the application logic for the command.
@@ -90,7 +92,16 @@ func (c *CreateCommand) Exec(_ io.Reader, out io.Writer) error {
 		return err
 	}
 
-	input := c.constructInput(serviceID, serviceVersion.Number)
+	content := cmd.Content(c.content)
+	if c.template.enabled() {
+		content, err = c.template.render(content)
+		if err != nil {
+			c.Globals.ErrLog.Add(err)
+			return err
+		}
+	}
+
+	input := c.constructInput(serviceID, serviceVersion.Number, content)
 
 	v, err := c.Globals.APIClient.CreateSnippet(input)
 	if err != nil {
@@ -106,10 +117,10 @@ func (c *CreateCommand) Exec(_ io.Reader, out io.Writer) error {
 }
 
 // constructInput transforms values parsed from CLI flags into an object to be used by the API client library.
-func (c *CreateCommand) constructInput(serviceID string, serviceVersion int) *fastly.CreateSnippetInput {
+func (c *CreateCommand) constructInput(serviceID string, serviceVersion int, content string) *fastly.CreateSnippetInput {
 	var input fastly.CreateSnippetInput
 
-	input.Content = cmd.Content(c.content)
+	input.Content = content
 	input.Name = c.name
 	input.ServiceID = serviceID
 	input.ServiceVersion = serviceVersion
@@ -0,0 +1,43 @@
+package snippet
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/fastly/cli/pkg/cmd"
+	"github.com/fastly/cli/pkg/config"
+)
+
+// NewRenderCommand returns a usable command registered under the parent.
+func NewRenderCommand(parent cmd.Registerer, globals *config.Data) *RenderCommand {
+	var c RenderCommand
+	c.CmdClause = parent.Command("render", "Render a VCL snippet template, as the create and update commands do when given template variables")
+	c.Globals = globals
+
+	// Required flags
+	c.CmdClause.Flag("content", "VCL snippet template passed as file path or content, e.g. $(< snippet.vcl)").Required().StringVar(&c.content)
+
+	// Optional flags
+	c.template.register(c.CmdClause)
+
+	return &c
+}
+
+// RenderCommand renders a VCL snippet template without calling the Fastly API.
+type RenderCommand struct {
+	cmd.Base
+
+	content  string
+	template templateOpts
+}
+
+// Exec invokes the application logic for the command.
+func (c *RenderCommand) Exec(_ io.Reader, out io.Writer) error {
+	content, err := c.template.render(cmd.Content(c.content))
+	if err != nil {
+		c.Globals.ErrLog.Add(err)
+		return err
+	}
+	fmt.Fprint(out, content)
+	return nil
+}
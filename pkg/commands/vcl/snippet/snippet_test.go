@@ -612,3 +612,86 @@ func listSnippets(i *fastly.ListSnippetsInput) ([]*fastly.Snippet, error) {
 	}
 	return vs, nil
 }
+
+func TestVCLSnippetTemplate(t *testing.T) {
+	var content string
+	args := testutil.Args
+	createSnippet := func(i *fastly.CreateSnippetInput) (*fastly.Snippet, error) {
+		content = i.Content
+		return &fastly.Snippet{
+			Name:           i.Name,
+			ServiceID:      i.ServiceID,
+			ServiceVersion: i.ServiceVersion,
+			ID:             "123",
+		}, nil
+	}
+	scenarios := []struct {
+		testutil.TestScenario
+		wantContent string
+	}{
+		{
+			TestScenario: testutil.TestScenario{
+				Name:       "validate render with TOML values",
+				Args:       args("vcl snippet render --content ./testdata/template.vcl --values ./testdata/values.toml --include ./testdata/common.vcl"),
+				WantOutput: "set req.http.X-Origin = \"example.com\";\nset req.http.X-Env = \"production\";\n",
+			},
+		},
+		{
+			TestScenario: testutil.TestScenario{
+				Name:       "validate render with JSON values and --set",
+				Args:       args("vcl snippet render --content ./testdata/template.vcl --values ./testdata/values.json --include ./testdata/common.vcl --set env=dev"),
+				WantOutput: "set req.http.X-Origin = \"example.org\";\nset req.http.X-Env = \"dev\";\n",
+			},
+		},
+		{
+			TestScenario: testutil.TestScenario{
+				Name:      "validate render with a missing variable",
+				Args:      args("vcl snippet render --content ./testdata/template.vcl --include ./testdata/common.vcl --set origin=example.com"),
+				WantError: "error rendering snippet template",
+			},
+		},
+		{
+			TestScenario: testutil.TestScenario{
+				Name:      "validate render with a missing include",
+				Args:      args("vcl snippet render --content ./testdata/template.vcl --values ./testdata/values.toml"),
+				WantError: "error rendering snippet template",
+			},
+		},
+		{
+			TestScenario: testutil.TestScenario{
+				Name: "validate create renders the template",
+				API: mock.API{
+					ListVersionsFn:  testutil.ListVersions,
+					CreateSnippetFn: createSnippet,
+				},
+				Args:       args("vcl snippet create --content ./testdata/template.vcl --values ./testdata/values.toml --include ./testdata/common.vcl --name foo --service-id 123 --type recv --version 3"),
+				WantOutput: "Created VCL snippet 'foo'",
+			},
+			wantContent: "set req.http.X-Origin = \"example.com\";\nset req.http.X-Env = \"production\";\n",
+		},
+		{
+			TestScenario: testutil.TestScenario{
+				Name: "validate update requires --content to render",
+				API: mock.API{
+					ListVersionsFn: testutil.ListVersions,
+				},
+				Args:      args("vcl snippet update --values ./testdata/values.toml --name foo --service-id 123 --version 3"),
+				WantError: "must provide --content to render as a template",
+			},
+		},
+	}
+
+	for testcaseIdx := range scenarios {
+		testcase := &scenarios[testcaseIdx]
+		t.Run(testcase.Name, func(t *testing.T) {
+			content = ""
+			var stdout bytes.Buffer
+			opts := testutil.NewRunOpts(testcase.Args, &stdout)
+			opts.APIClient = mock.APIClient(testcase.API)
+			err := app.Run(opts)
+			testutil.AssertErrorContains(t, err, testcase.WantError)
+			testutil.AssertStringContains(t, stdout.String(), testcase.WantOutput)
+			testutil.AssertString(t, testcase.wantContent, content)
+		})
+	}
+}
@@ -0,0 +1,105 @@
+package snippet
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+
+	"github.com/fastly/cli/pkg/errors"
+	"github.com/fastly/kingpin"
+	toml "github.com/pelletier/go-toml"
+)
+
+// templateOpts are the flags that render a snippet's content as a Go
+// template, so the same snippet source can be deployed to many services.
+type templateOpts struct {
+	includes []string
+	sets     map[string]string
+	values   string
+}
+
+// register defines the template flags on the command.
+func (t *templateOpts) register(c *kingpin.CmdClause) {
+	c.Flag("include", "Path of a template the snippet can include by file name, e.g. {{ template \"acl.vcl\" . }} (can be repeated)").StringsVar(&t.includes)
+	c.Flag("set", "Set a template variable, overriding the --values file, e.g. --set origin=example.com (can be repeated)").StringMapVar(&t.sets)
+	c.Flag("values", "Path of a TOML (or .json) file of template variables, rendering the content as a Go template").StringVar(&t.values)
+}
+
+// enabled indicates whether the content should be rendered as a template.
+func (t *templateOpts) enabled() bool {
+	return t.values != "" || len(t.sets) > 0 || len(t.includes) > 0
+}
+
+// render executes the content as a Go template with the variables from the
+// --values file and --set flags.
+//
+// NOTE: A variable missing from the values is an error, as otherwise the
+// snippet would be rendered with '<no value>' in its place.
+func (t *templateOpts) render(content string) (string, error) {
+	values, err := t.readValues()
+	if err != nil {
+		return "", err
+	}
+	for k, v := range t.sets {
+		values[k] = v
+	}
+
+	tmpl := template.New("snippet").Option("missingkey=error")
+	for _, path := range t.includes {
+		// gosec flagged this:
+		// G304 (CWE-22): Potential file inclusion via variable
+		// Disabling as we require a user to configure their own environment.
+		/* #nosec */
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return "", fmt.Errorf("error reading template include: %w", err)
+		}
+		if _, err := tmpl.New(filepath.Base(path)).Parse(string(data)); err != nil {
+			return "", fmt.Errorf("error parsing template include: %w", err)
+		}
+	}
+	if _, err := tmpl.Parse(content); err != nil {
+		return "", fmt.Errorf("error parsing snippet template: %w", err)
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, values); err != nil {
+		return "", errors.RemediationError{
+			Inner:       fmt.Errorf("error rendering snippet template: %w", err),
+			Remediation: "Check the template variables are defined in the --values file or with --set.",
+		}
+	}
+	return buf.String(), nil
+}
+
+// readValues returns the variables from the --values file, which is decoded
+// as JSON if it has a .json extension and otherwise as TOML.
+func (t *templateOpts) readValues() (map[string]any, error) {
+	values := make(map[string]any)
+	if t.values == "" {
+		return values, nil
+	}
+
+	// gosec flagged this:
+	// G304 (CWE-22): Potential file inclusion via variable
+	// Disabling as we require a user to configure their own environment.
+	/* #nosec */
+	data, err := os.ReadFile(t.values)
+	if err != nil {
+		return nil, fmt.Errorf("error reading template values: %w", err)
+	}
+
+	if strings.EqualFold(filepath.Ext(t.values), ".json") {
+		err = json.Unmarshal(data, &values)
+	} else {
+		err = toml.Unmarshal(data, &values)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error decoding template values: %w", err)
+	}
+	return values, nil
+}
@@ -50,6 +50,7 @@ func NewUpdateCommand(parent cmd.Registerer, globals *config.Data, data manifest
 		Dst:         &c.serviceName.Value,
 	})
 	c.CmdClause.Flag("snippet-id", "Alphanumeric string identifying a VCL Snippet").StringVar(&c.snippetID)
+	c.template.register(c.CmdClause)
 
 	// NOTE: Locations is defined in the same snippet package inside create.go
 	c.CmdClause.Flag("type", "The location in generated VCL where the snippet should be placed").HintOptions(Locations...).Action(c.location.Set).EnumVar(&c.location.Value, Locations...)
@@ -72,6 +73,7 @@ type UpdateCommand struct {
 	serviceName    cmd.OptionalServiceNameID
 	serviceVersion cmd.OptionalServiceVersion
 	snippetID      string
+	template       templateOpts
 }
 
 // Exec invokes the application logic for the command.
@@ -148,8 +150,12 @@ func (c *UpdateCommand) constructDynamicInput(serviceID string, _ int) (*fastly.
 	if c.snippetID == "" {
 		return nil, fmt.Errorf("error parsing arguments: must provide --snippet-id to update a dynamic VCL snippet")
 	}
-	if c.content.WasSet {
-		input.Content = fastly.String(cmd.Content(c.content.Value))
+	if c.content.WasSet || c.template.enabled() {
+		content, err := c.readContent()
+		if err != nil {
+			return nil, err
+		}
+		input.Content = fastly.String(content)
 	}
 
 	return &input, nil
@@ -175,8 +181,12 @@ func (c *UpdateCommand) constructInput(serviceID string, serviceVersion int) (*f
 	if c.priority.WasSet {
 		input.Priority = fastly.Int(c.priority.Value)
 	}
-	if c.content.WasSet {
-		input.Content = fastly.String(cmd.Content(c.content.Value))
+	if c.content.WasSet || c.template.enabled() {
+		content, err := c.readContent()
+		if err != nil {
+			return nil, err
+		}
+		input.Content = fastly.String(content)
 	}
 	if c.location.WasSet {
 		location := fastly.SnippetType(c.location.Value)
@@ -185,3 +195,16 @@ func (c *UpdateCommand) constructInput(serviceID string, serviceVersion int) (*f
 
 	return &input, nil
 }
+
+// readContent returns the snippet content, rendered as a template if any
+// template variables were provided.
+func (c *UpdateCommand) readContent() (string, error) {
+	if !c.content.WasSet {
+		return "", fmt.Errorf("error parsing arguments: must provide --content to render as a template")
+	}
+	content := cmd.Content(c.content.Value)
+	if c.template.enabled() {
+		return c.template.render(content)
+	}
+	return content, nil
+}
@@ -0,0 +1,380 @@
+package vcl
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/fastly/cli/pkg/cmd"
+	"github.com/fastly/cli/pkg/config"
+	fsterr "github.com/fastly/cli/pkg/errors"
+	"github.com/fastly/cli/pkg/manifest"
+	"github.com/fastly/cli/pkg/notify"
+	"github.com/fastly/cli/pkg/text"
+	"github.com/fastly/go-fastly/v6/fastly"
+)
+
+// defaultSyncPath is the directory of VCL files synced by default.
+const defaultSyncPath = "vcl"
+
+// NewSyncCommand returns a usable command registered under the parent.
+func NewSyncCommand(parent cmd.Registerer, globals *config.Data, data manifest.Data) *SyncCommand {
+	var c SyncCommand
+	c.CmdClause = parent.Command("sync", "Make the custom VCL of a service version match a local directory of VCL files")
+	c.Globals = globals
+	c.manifest = data
+
+	// Required flags
+	c.RegisterFlag(cmd.StringFlagOpts{
+		Name:        cmd.FlagVersionName,
+		Description: cmd.FlagVersionDesc,
+		Dst:         &c.serviceVersion.Value,
+		Required:    true,
+	})
+
+	// Optional Flags
+	c.CmdClause.Flag("activate", "Activate the service version once the VCL is synced").BoolVar(&c.activate)
+	c.RegisterAutoCloneFlag(cmd.AutoCloneFlagOpts{
+		Action: c.autoClone.Set,
+		Dst:    &c.autoClone.Value,
+	})
+	c.CmdClause.Flag("dry-run", "Display the changes without modifying the service version").BoolVar(&c.dryRun)
+	c.CmdClause.Flag("exit-code", "With --dry-run, exit with status 7 if there are changes to sync (see `fastly help exit-codes`)").BoolVar(&c.exitCode)
+	c.CmdClause.Flag("main", "The name of the main VCL (defaults to the only file, or 'main' if there are several)").StringVar(&c.main)
+	c.CmdClause.Flag("notify", notify.FlagDesc).Action(c.notify.Set).NegatableBoolVar(&c.notify.Value)
+	c.CmdClause.Flag("path", "Directory of .vcl files, each uploaded as the VCL named after the file").Default(defaultSyncPath).StringVar(&c.path)
+	c.RegisterFlag(cmd.StringFlagOpts{
+		Name:        cmd.FlagServiceIDName,
+		Description: cmd.FlagServiceIDDesc,
+		Dst:         &c.manifest.Flag.ServiceID,
+		Short:       's',
+	})
+	c.RegisterFlag(cmd.StringFlagOpts{
+		Action:      c.serviceName.Set,
+		Name:        cmd.FlagServiceName,
+		Description: cmd.FlagServiceDesc,
+		Dst:         &c.serviceName.Value,
+	})
+
+	return &c
+}
+
+// SyncCommand calls the Fastly API to sync a directory of VCL files.
+type SyncCommand struct {
+	cmd.Base
+
+	activate       bool
+	autoClone      cmd.OptionalAutoClone
+	dryRun         bool
+	exitCode       bool
+	main           string
+	manifest       manifest.Data
+	notify         cmd.OptionalBool
+	path           string
+	serviceName    cmd.OptionalServiceNameID
+	serviceVersion cmd.OptionalServiceVersion
+}
+
+// syncAction is the kind of change made to a VCL.
+type syncAction string
+
+// The changes made to the VCL of a service version.
+const (
+	syncCreate  syncAction = "create"
+	syncUpdate  syncAction = "update"
+	syncDelete  syncAction = "delete"
+	syncSetMain syncAction = "set main"
+)
+
+// syncChange is a change required to sync the VCL.
+type syncChange struct {
+	Action  syncAction
+	Content string
+	Main    bool
+	Name    string
+}
+
+// Exec invokes the application logic for the command.
+func (c *SyncCommand) Exec(_ io.Reader, out io.Writer) error {
+	files, err := readVCLFiles(c.path)
+	if err != nil {
+		c.Globals.ErrLog.AddWithContext(err, map[string]any{
+			"Path": c.path,
+		})
+		return err
+	}
+
+	main, err := mainVCL(files, c.main)
+	if err != nil {
+		c.Globals.ErrLog.AddWithContext(err, map[string]any{
+			"Main": c.main,
+		})
+		return err
+	}
+
+	// NOTE: The version is only cloned (and so required to be editable) if
+	// there are changes to make, so a sync is idempotent.
+	serviceID, serviceVersion, err := cmd.ServiceDetails(cmd.ServiceDetailsOpts{
+		AllowActiveLocked:  true,
+		APIClient:          c.Globals.APIClient,
+		Manifest:           c.manifest,
+		Out:                out,
+		ServiceNameFlag:    c.serviceName,
+		ServiceVersionFlag: c.serviceVersion,
+		VerboseMode:        c.Globals.Flag.Verbose,
+	})
+	if err != nil {
+		c.Globals.ErrLog.AddWithContext(err, map[string]any{
+			"Service ID":      serviceID,
+			"Service Version": fsterr.ServiceVersion(serviceVersion),
+		})
+		return err
+	}
+
+	remote, err := c.Globals.APIClient.ListVCLs(&fastly.ListVCLsInput{
+		ServiceID:      serviceID,
+		ServiceVersion: serviceVersion.Number,
+	})
+	if err != nil {
+		c.Globals.ErrLog.AddWithContext(err, map[string]any{
+			"Service ID":      serviceID,
+			"Service Version": serviceVersion.Number,
+		})
+		return err
+	}
+
+	changes := syncChanges(files, main, remote)
+	if len(changes) == 0 {
+		text.Info(out, "The VCL of service %s version %d already matches %s", serviceID, serviceVersion.Number, c.path)
+		if c.activate && !serviceVersion.Active && !c.dryRun {
+			return c.activateVersion(serviceID, serviceVersion.Number, out)
+		}
+		return nil
+	}
+
+	printChanges(out, changes)
+
+	if c.dryRun {
+		text.Break(out)
+		text.Info(out, "Dry run: service %s version %d was not modified.", serviceID, serviceVersion.Number)
+		if c.exitCode {
+			return fsterr.RemediationError{
+				Inner: fmt.Errorf("the VCL of service %s version %d doesn't match %s", serviceID, serviceVersion.Number, c.path),
+				Code:  fsterr.ExitDrift,
+			}
+		}
+		return nil
+	}
+
+	serviceVersion, err = c.autoClone.Parse(serviceVersion, serviceID, c.Globals.Verbose(), out, c.Globals.APIClient)
+	if err != nil {
+		c.Globals.ErrLog.AddWithContext(err, map[string]any{
+			"Service ID": serviceID,
+		})
+		return err
+	}
+
+	for _, change := range changes {
+		if err := c.apply(serviceID, serviceVersion.Number, change); err != nil {
+			c.Globals.ErrLog.AddWithContext(err, map[string]any{
+				"Service ID":      serviceID,
+				"Service Version": serviceVersion.Number,
+				"Action":          change.Action,
+				"Name":            change.Name,
+			})
+			return fmt.Errorf("error syncing VCL '%s': %w", change.Name, err)
+		}
+	}
+
+	text.Success(out, "Synced %d VCL change(s) to service %s version %d", len(changes), serviceID, serviceVersion.Number)
+
+	if c.activate {
+		return c.activateVersion(serviceID, serviceVersion.Number, out)
+	}
+	return nil
+}
+
+// apply makes the change to the service version.
+func (c *SyncCommand) apply(serviceID string, serviceVersion int, change syncChange) error {
+	var err error
+	switch change.Action {
+	case syncCreate:
+		_, err = c.Globals.APIClient.CreateVCL(&fastly.CreateVCLInput{
+			ServiceID:      serviceID,
+			ServiceVersion: serviceVersion,
+			Name:           change.Name,
+			Content:        change.Content,
+			Main:           change.Main,
+		})
+	case syncUpdate:
+		_, err = c.Globals.APIClient.UpdateVCL(&fastly.UpdateVCLInput{
+			ServiceID:      serviceID,
+			ServiceVersion: serviceVersion,
+			Name:           change.Name,
+			Content:        fastly.String(change.Content),
+		})
+	case syncDelete:
+		err = c.Globals.APIClient.DeleteVCL(&fastly.DeleteVCLInput{
+			ServiceID:      serviceID,
+			ServiceVersion: serviceVersion,
+			Name:           change.Name,
+		})
+	case syncSetMain:
+		_, err = c.Globals.APIClient.ActivateVCL(&fastly.ActivateVCLInput{
+			ServiceID:      serviceID,
+			ServiceVersion: serviceVersion,
+			Name:           change.Name,
+		})
+	}
+	return err
+}
+
+// activateVersion activates the synced service version.
+func (c *SyncCommand) activateVersion(serviceID string, serviceVersion int, out io.Writer) error {
+	_, err := c.Globals.APIClient.ActivateVersion(&fastly.ActivateVersionInput{
+		ServiceID:      serviceID,
+		ServiceVersion: serviceVersion,
+	})
+	if err != nil {
+		c.Globals.ErrLog.AddWithContext(err, map[string]any{
+			"Service ID":      serviceID,
+			"Service Version": serviceVersion,
+		})
+		return err
+	}
+
+	text.Success(out, "Activated service %s version %d", serviceID, serviceVersion)
+
+	notify.Send(notify.Event{
+		Action:    notify.ActionActivate,
+		ServiceID: serviceID,
+		Version:   serviceVersion,
+	}, c.notify, c.Globals, out)
+	return nil
+}
+
+// syncChanges returns the changes required for the remote VCL to match the
+// local files (keyed by name), with the named VCL as the main VCL.
+//
+// The VCL is created and updated before the main VCL is set and the removed
+// VCL is deleted, so the service version always has a main VCL.
+func syncChanges(files map[string]string, main string, remote []*fastly.VCL) []syncChange {
+	existing := make(map[string]*fastly.VCL, len(remote))
+	for _, v := range remote {
+		existing[v.Name] = v
+	}
+
+	var changes []syncChange
+	for _, name := range sortedNames(files) {
+		v, ok := existing[name]
+		switch {
+		case !ok:
+			changes = append(changes, syncChange{Action: syncCreate, Content: files[name], Main: name == main, Name: name})
+		case v.Content != files[name]:
+			changes = append(changes, syncChange{Action: syncUpdate, Content: files[name], Name: name})
+		}
+	}
+
+	if v, ok := existing[main]; ok && !v.Main {
+		changes = append(changes, syncChange{Action: syncSetMain, Name: main})
+	}
+
+	removed := make(map[string]string)
+	for name := range existing {
+		if _, ok := files[name]; !ok {
+			removed[name] = ""
+		}
+	}
+	for _, name := range sortedNames(removed) {
+		changes = append(changes, syncChange{Action: syncDelete, Name: name})
+	}
+	return changes
+}
+
+// printChanges displays the changes as a plan.
+func printChanges(out io.Writer, changes []syncChange) {
+	for _, change := range changes {
+		switch change.Action {
+		case syncCreate:
+			line := fmt.Sprintf("+ create %s", change.Name)
+			if change.Main {
+				line += " (main)"
+			}
+			text.Output(out, text.BoldGreen(line))
+		case syncUpdate:
+			text.Output(out, text.BoldYellow(fmt.Sprintf("~ update %s", change.Name)))
+		case syncSetMain:
+			text.Output(out, text.BoldYellow(fmt.Sprintf("~ set %s as the main VCL", change.Name)))
+		case syncDelete:
+			text.Output(out, text.BoldRed(fmt.Sprintf("- delete %s", change.Name)))
+		}
+	}
+}
+
+// readVCLFiles returns the content of the .vcl files in the directory, keyed
+// by the file name without the extension.
+func readVCLFiles(path string) (map[string]string, error) {
+	entries, err := os.ReadDir(path)
+	if err != nil {
+		return nil, fsterr.RemediationError{
+			Inner:       fmt.Errorf("error reading VCL directory: %w", err),
+			Remediation: fmt.Sprintf("Create a '%s' directory of .vcl files, or use --path to specify a different directory.", defaultSyncPath),
+		}
+	}
+
+	files := make(map[string]string)
+	for _, e := range entries {
+		if e.IsDir() || filepath.Ext(e.Name()) != ".vcl" {
+			continue
+		}
+		// gosec flagged this:
+		// G304 (CWE-22): Potential file inclusion via variable
+		// Disabling as we require a user to configure their own VCL directory.
+		/* #nosec */
+		data, err := os.ReadFile(filepath.Join(path, e.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("error reading VCL file: %w", err)
+		}
+		files[strings.TrimSuffix(e.Name(), ".vcl")] = string(data)
+	}
+
+	if len(files) == 0 {
+		return nil, fsterr.RemediationError{
+			Inner:       fmt.Errorf("no .vcl files found in %s", path),
+			Remediation: "Syncing would delete all of the service version's VCL. Add the .vcl files to the directory, or use --path to specify a different directory.",
+		}
+	}
+	return files, nil
+}
+
+// mainVCL returns the name of the main VCL, which defaults to the only file or
+// otherwise to 'main'.
+func mainVCL(files map[string]string, name string) (string, error) {
+	if name == "" && len(files) == 1 {
+		for k := range files {
+			return k, nil
+		}
+	}
+	if name == "" {
+		name = "main"
+	}
+	if _, ok := files[name]; !ok {
+		return "", fsterr.RemediationError{
+			Inner:       fmt.Errorf("no main VCL file found: %s.vcl", name),
+			Remediation: "Use --main to specify the name of the main VCL file (without the .vcl extension).",
+		}
+	}
+	return name, nil
+}
+
+func sortedNames(files map[string]string) []string {
+	names := make([]string, 0, len(files))
+	for k := range files {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+	return names
+}
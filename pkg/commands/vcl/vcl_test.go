@@ -0,0 +1,182 @@
+package vcl_test
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/fastly/cli/pkg/app"
+	fsterr "github.com/fastly/cli/pkg/errors"
+	"github.com/fastly/cli/pkg/mock"
+	"github.com/fastly/cli/pkg/testutil"
+	"github.com/fastly/go-fastly/v6/fastly"
+)
+
+func TestVCLSync(t *testing.T) {
+	scenarios := []struct {
+		name         string
+		args         string
+		files        map[string]string
+		remote       []*fastly.VCL
+		wantError    string
+		wantExitCode fsterr.ExitCode
+		wantOutputs  []string
+		wantCalls    []string
+	}{
+		{
+			name:      "validate no .vcl files",
+			args:      "--service-id 123 --version 3",
+			files:     map[string]string{"README.md": "docs"},
+			wantError: "no .vcl files found in",
+		},
+		{
+			name:      "validate missing main VCL",
+			args:      "--service-id 123 --version 3",
+			files:     map[string]string{"a.vcl": "a", "b.vcl": "b"},
+			wantError: "no main VCL file found: main.vcl",
+		},
+		{
+			name:  "validate dry run",
+			args:  "--service-id 123 --version 1 --dry-run",
+			files: map[string]string{"main.vcl": "main v2", "new.vcl": "new", "same.vcl": "same"},
+			remote: []*fastly.VCL{
+				{Name: "main", Content: "main v1", Main: true},
+				{Name: "old", Content: "old"},
+				{Name: "same", Content: "same"},
+			},
+			wantOutputs: []string{
+				"~ update main\n+ create new\n- delete old\n",
+				"Dry run: service 123 version 1 was not modified.",
+			},
+		},
+		{
+			name:  "validate dry run with --exit-code",
+			args:  "--service-id 123 --version 1 --dry-run --exit-code",
+			files: map[string]string{"main.vcl": "main v2"},
+			remote: []*fastly.VCL{
+				{Name: "main", Content: "main v1", Main: true},
+			},
+			wantError:    "the VCL of service 123 version 1 doesn't match",
+			wantExitCode: fsterr.ExitDrift,
+			wantOutputs:  []string{"~ update main\n"},
+		},
+		{
+			name:  "validate changes to a locked version require --autoclone",
+			args:  "--service-id 123 --version 1",
+			files: map[string]string{"main.vcl": "main v2"},
+			remote: []*fastly.VCL{
+				{Name: "main", Content: "main v1", Main: true},
+			},
+			wantError: "service version 1 is not editable",
+		},
+		{
+			name:  "validate sync",
+			args:  "--service-id 123 --version 1 --autoclone --main new",
+			files: map[string]string{"main.vcl": "main v2", "new.vcl": "new", "same.vcl": "same"},
+			remote: []*fastly.VCL{
+				{Name: "main", Content: "main v1", Main: true},
+				{Name: "old", Content: "old"},
+				{Name: "same", Content: "same"},
+			},
+			wantOutputs: []string{
+				"~ update main\n+ create new (main)\n- delete old\n",
+				"Synced 3 VCL change(s) to service 123 version 4",
+			},
+			wantCalls: []string{"update main 4", "create new 4 main", "delete old 4"},
+		},
+		{
+			name:  "validate setting the main VCL and activating",
+			args:  "--service-id 123 --version 3 --activate",
+			files: map[string]string{"main.vcl": "main", "other.vcl": "other"},
+			remote: []*fastly.VCL{
+				{Name: "main", Content: "main"},
+				{Name: "other", Content: "other", Main: true},
+			},
+			wantOutputs: []string{
+				"~ set main as the main VCL\n",
+				"Synced 1 VCL change(s) to service 123 version 3",
+				"Activated service 123 version 3",
+			},
+			wantCalls: []string{"set main main 3", "activate 3"},
+		},
+		{
+			name:  "validate sync is idempotent",
+			args:  "--service-id 123 --version 1",
+			files: map[string]string{"only.vcl": "only"},
+			remote: []*fastly.VCL{
+				{Name: "only", Content: "only", Main: true},
+			},
+			wantOutputs: []string{"The VCL of service 123 version 1 already matches"},
+		},
+		{
+			name:  "validate dry run with --exit-code and no changes",
+			args:  "--service-id 123 --version 1 --dry-run --exit-code",
+			files: map[string]string{"only.vcl": "only"},
+			remote: []*fastly.VCL{
+				{Name: "only", Content: "only", Main: true},
+			},
+			wantOutputs: []string{"The VCL of service 123 version 1 already matches"},
+		},
+	}
+
+	for testcaseIdx := range scenarios {
+		testcase := &scenarios[testcaseIdx]
+		t.Run(testcase.name, func(t *testing.T) {
+			dir := t.TempDir()
+			for name, content := range testcase.files {
+				if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o600); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			var calls []string
+			api := mock.API{
+				ListVersionsFn: testutil.ListVersions,
+				CloneVersionFn: testutil.CloneVersionResult(4),
+				ListVCLsFn: func(i *fastly.ListVCLsInput) ([]*fastly.VCL, error) {
+					return testcase.remote, nil
+				},
+				CreateVCLFn: func(i *fastly.CreateVCLInput) (*fastly.VCL, error) {
+					call := fmt.Sprintf("create %s %d", i.Name, i.ServiceVersion)
+					if i.Main {
+						call += " main"
+					}
+					calls = append(calls, call)
+					return &fastly.VCL{}, nil
+				},
+				UpdateVCLFn: func(i *fastly.UpdateVCLInput) (*fastly.VCL, error) {
+					calls = append(calls, fmt.Sprintf("update %s %d", i.Name, i.ServiceVersion))
+					return &fastly.VCL{}, nil
+				},
+				DeleteVCLFn: func(i *fastly.DeleteVCLInput) error {
+					calls = append(calls, fmt.Sprintf("delete %s %d", i.Name, i.ServiceVersion))
+					return nil
+				},
+				ActivateVCLFn: func(i *fastly.ActivateVCLInput) (*fastly.VCL, error) {
+					calls = append(calls, fmt.Sprintf("set main %s %d", i.Name, i.ServiceVersion))
+					return &fastly.VCL{}, nil
+				},
+				ActivateVersionFn: func(i *fastly.ActivateVersionInput) (*fastly.Version, error) {
+					calls = append(calls, fmt.Sprintf("activate %d", i.ServiceVersion))
+					return &fastly.Version{ServiceID: i.ServiceID, Number: i.ServiceVersion}, nil
+				},
+			}
+
+			var stdout bytes.Buffer
+			args := testutil.Args(fmt.Sprintf("vcl sync --path %s %s", dir, testcase.args))
+			opts := testutil.NewRunOpts(args, &stdout)
+			opts.APIClient = mock.APIClient(api)
+			err := app.Run(opts)
+			testutil.AssertErrorContains(t, err, testcase.wantError)
+			if testcase.wantExitCode != fsterr.ExitOK {
+				testutil.AssertEqual(t, testcase.wantExitCode, fsterr.Exit(err))
+			}
+			for _, s := range testcase.wantOutputs {
+				testutil.AssertStringContains(t, stdout.String(), s)
+			}
+			testutil.AssertEqual(t, testcase.wantCalls, calls)
+		})
+	}
+}
@@ -0,0 +1,6 @@
+// Package waf contains commands to inspect and manipulate Fastly Next-Gen WAF
+// workspaces.
+//
+// NOTE: go-fastly only supports the legacy WAF API, and so these commands call
+// the Next-Gen WAF API directly (see the undocumented package).
+package waf
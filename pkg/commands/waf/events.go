@@ -0,0 +1,181 @@
+package waf
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"time"
+
+	"github.com/fastly/cli/pkg/api/undocumented"
+	"github.com/fastly/cli/pkg/cmd"
+	"github.com/fastly/cli/pkg/config"
+	fsterr "github.com/fastly/cli/pkg/errors"
+	"github.com/fastly/cli/pkg/manifest"
+	"github.com/fastly/cli/pkg/text"
+	fsttime "github.com/fastly/cli/pkg/time"
+)
+
+// NewEventsCommand returns a usable command registered under the parent.
+func NewEventsCommand(parent cmd.Registerer, globals *config.Data, data manifest.Data) *EventsCommand {
+	var c EventsCommand
+	c.CmdClause = parent.Command("events", "List recent events recorded by the Next-Gen WAF workspace protecting a service")
+	c.Globals = globals
+	c.manifest = data
+
+	// Optional flags
+	c.RegisterFlagBool(cmd.BoolFlagOpts{
+		Name:        cmd.FlagJSONName,
+		Description: cmd.FlagJSONDesc,
+		Dst:         &c.json,
+		Short:       'j',
+	})
+	c.CmdClause.Flag("limit", "Maximum number of events to return").Default("20").IntVar(&c.limit)
+	c.RegisterFlag(cmd.StringFlagOpts{
+		Name:        cmd.FlagServiceIDName,
+		Description: cmd.FlagServiceIDDesc,
+		Dst:         &c.manifest.Flag.ServiceID,
+		Short:       's',
+	})
+	c.RegisterFlag(cmd.StringFlagOpts{
+		Action:      c.serviceName.Set,
+		Name:        cmd.FlagServiceName,
+		Description: cmd.FlagServiceDesc,
+		Dst:         &c.serviceName.Value,
+	})
+	c.CmdClause.Flag("since", "Only show events from within this duration (e.g. 30m, 24h)").Default("1h").DurationVar(&c.since)
+
+	return &c
+}
+
+// EventsCommand calls the Next-Gen WAF API to list recent events.
+type EventsCommand struct {
+	cmd.Base
+
+	json        bool
+	limit       int
+	manifest    manifest.Data
+	serviceName cmd.OptionalServiceNameID
+	since       time.Duration
+}
+
+// Exec invokes the application logic for the command.
+func (c *EventsCommand) Exec(_ io.Reader, out io.Writer) error {
+	if c.Globals.Verbose() && c.json {
+		return fsterr.ErrInvalidVerboseJSONCombo
+	}
+	if c.since <= 0 {
+		return fsterr.RemediationError{
+			Inner:       fmt.Errorf("invalid --since value: %s", c.since),
+			Remediation: "Provide a positive duration (e.g. 1h).",
+		}
+	}
+
+	client, err := newClient(c.Globals)
+	if err != nil {
+		return err
+	}
+
+	serviceID, source, flag, err := cmd.ServiceID(c.serviceName, c.manifest, c.Globals.APIClient, c.Globals.ErrLog)
+	if err != nil {
+		return err
+	}
+	if c.Globals.Verbose() {
+		cmd.DisplayServiceID(serviceID, flag, source, out)
+	}
+
+	workspaceID, err := client.ServiceWAFWorkspace(serviceID)
+	if err != nil {
+		c.Globals.ErrLog.AddWithContext(err, map[string]any{
+			"Service ID": serviceID,
+		})
+		return fmt.Errorf("error reading the Next-Gen WAF workspace of service '%s': %w", serviceID, err)
+	}
+	if workspaceID == "" {
+		return fsterr.RemediationError{
+			Inner:       fmt.Errorf("service '%s' isn't protected by a Next-Gen WAF workspace", serviceID),
+			Remediation: "Enable Next-Gen WAF for the service, or check the --service-id. Run `fastly waf list` to view the available workspaces.",
+		}
+	}
+
+	rs, err := client.ListWAFEvents(undocumented.WAFEventsInput{
+		From:        time.Now().Add(-c.since),
+		Limit:       c.limit,
+		WorkspaceID: workspaceID,
+	})
+	if err != nil {
+		c.Globals.ErrLog.AddWithContext(err, map[string]any{
+			"Service ID":   serviceID,
+			"Workspace ID": workspaceID,
+			"Since":        c.since,
+		})
+		return err
+	}
+
+	if c.Globals.Verbose() {
+		c.printVerbose(out, rs)
+		return nil
+	}
+	return c.printSummary(out, rs)
+}
+
+// printVerbose displays the information returned from the API in a verbose
+// format.
+func (c *EventsCommand) printVerbose(out io.Writer, rs []*undocumented.WAFEvent) {
+	for i, r := range rs {
+		fmt.Fprintf(out, "Event %d/%d\n", i+1, len(rs))
+		fmt.Fprintf(out, "\tID: %s\n", r.ID)
+		fmt.Fprintf(out, "\tTimestamp: %s\n", eventTimestamp(r))
+		fmt.Fprintf(out, "\tAction: %s\n", r.Action)
+		fmt.Fprintf(out, "\tType: %s\n", r.Type)
+		fmt.Fprintf(out, "\tSource: %s\n", r.Source)
+		fmt.Fprintf(out, "\tCountry: %s\n", r.Country)
+		fmt.Fprintf(out, "\tRequests: %d\n", r.RequestCount)
+		if len(r.Reasons) > 0 {
+			keys := make([]string, 0, len(r.Reasons))
+			for k := range r.Reasons {
+				keys = append(keys, k)
+			}
+			sort.Strings(keys)
+			fmt.Fprintf(out, "\tReasons:\n")
+			for _, k := range keys {
+				fmt.Fprintf(out, "\t\t%s: %d\n", k, r.Reasons[k])
+			}
+		}
+		fmt.Fprintln(out)
+	}
+}
+
+// printSummary displays the information returned from the API in a summarised
+// format.
+func (c *EventsCommand) printSummary(out io.Writer, rs []*undocumented.WAFEvent) error {
+	if c.json {
+		data, err := json.Marshal(rs)
+		if err != nil {
+			return err
+		}
+		_, err = out.Write(data)
+		if err != nil {
+			c.Globals.ErrLog.Add(err)
+			return fmt.Errorf("error: unable to write data to stdout: %w", err)
+		}
+		return nil
+	}
+
+	t := text.NewTable(out)
+	t.AddHeader("TIMESTAMP (UTC)", "ID", "ACTION", "TYPE", "SOURCE", "COUNTRY", "REQUESTS")
+	for _, r := range rs {
+		t.AddLine(eventTimestamp(r), r.ID, r.Action, r.Type, r.Source, r.Country, r.RequestCount)
+	}
+	t.Print()
+	return nil
+}
+
+// eventTimestamp returns a table friendly representation of when the event
+// occurred.
+func eventTimestamp(r *undocumented.WAFEvent) string {
+	if r.Timestamp == nil {
+		return "n/a"
+	}
+	return r.Timestamp.UTC().Format(fsttime.Format)
+}
@@ -0,0 +1,74 @@
+package exclusion
+
+import (
+	"io"
+	"strings"
+
+	"github.com/fastly/cli/pkg/api/undocumented"
+	"github.com/fastly/cli/pkg/cmd"
+	"github.com/fastly/cli/pkg/config"
+	"github.com/fastly/cli/pkg/text"
+	"github.com/fastly/kingpin"
+)
+
+// NewCreateCommand returns a usable command registered under the parent.
+func NewCreateCommand(parent cmd.Registerer, globals *config.Data) *CreateCommand {
+	var c CreateCommand
+	c.CmdClause = parent.Command("create", "Create a rule exclusion on a Next-Gen WAF workspace").Alias("add")
+	c.Globals = globals
+
+	// Required flags
+	c.CmdClause.Flag("path", "Request path the exclusion applies to (e.g. /search)").Required().StringVar(&c.path)
+	c.CmdClause.Flag("signal", "A comma-separated list of signals to exclude (e.g. SQLI,XSS)").Required().StringsVar(&c.signals, kingpin.Separator(","))
+	c.CmdClause.Flag("workspace-id", "Alphanumeric string identifying the Next-Gen WAF workspace").Required().StringVar(&c.workspaceID)
+
+	// Optional flags
+	c.CmdClause.Flag("description", "Description of the rule exclusion").StringVar(&c.description)
+
+	return &c
+}
+
+// CreateCommand calls the Next-Gen WAF API to create a rule exclusion.
+type CreateCommand struct {
+	cmd.Base
+
+	description string
+	path        string
+	signals     []string
+	workspaceID string
+}
+
+// Exec invokes the application logic for the command.
+func (c *CreateCommand) Exec(_ io.Reader, out io.Writer) error {
+	client, err := newClient(c.Globals)
+	if err != nil {
+		return err
+	}
+
+	input := c.constructInput()
+
+	r, err := client.CreateWAFExclusion(c.workspaceID, input)
+	if err != nil {
+		c.Globals.ErrLog.AddWithContext(err, map[string]any{
+			"Workspace ID": c.workspaceID,
+			"Path":         c.path,
+		})
+		return err
+	}
+
+	text.Success(out, "Created rule exclusion '%s' excluding %s from '%s' (workspace: %s)", r.ID, strings.Join(c.signals, ", "), c.path, c.workspaceID)
+	return nil
+}
+
+// constructInput transforms values parsed from CLI flags into an object to be used by the API client.
+func (c *CreateCommand) constructInput() undocumented.WAFExclusionInput {
+	var input undocumented.WAFExclusionInput
+
+	input.Path = &c.path
+	input.Signals = c.signals
+	if c.description != "" {
+		input.Description = &c.description
+	}
+
+	return input
+}
@@ -0,0 +1,49 @@
+package exclusion
+
+import (
+	"io"
+
+	"github.com/fastly/cli/pkg/cmd"
+	"github.com/fastly/cli/pkg/config"
+	"github.com/fastly/cli/pkg/text"
+)
+
+// NewDeleteCommand returns a usable command registered under the parent.
+func NewDeleteCommand(parent cmd.Registerer, globals *config.Data) *DeleteCommand {
+	var c DeleteCommand
+	c.CmdClause = parent.Command("delete", "Delete a rule exclusion from a Next-Gen WAF workspace").Alias("remove")
+	c.Globals = globals
+
+	// Required flags
+	c.CmdClause.Flag("id", "Alphanumeric string identifying the rule exclusion").Required().StringVar(&c.id)
+	c.CmdClause.Flag("workspace-id", "Alphanumeric string identifying the Next-Gen WAF workspace").Required().StringVar(&c.workspaceID)
+
+	return &c
+}
+
+// DeleteCommand calls the Next-Gen WAF API to delete a rule exclusion.
+type DeleteCommand struct {
+	cmd.Base
+
+	id          string
+	workspaceID string
+}
+
+// Exec invokes the application logic for the command.
+func (c *DeleteCommand) Exec(_ io.Reader, out io.Writer) error {
+	client, err := newClient(c.Globals)
+	if err != nil {
+		return err
+	}
+
+	if err := client.DeleteWAFExclusion(c.workspaceID, c.id); err != nil {
+		c.Globals.ErrLog.AddWithContext(err, map[string]any{
+			"Workspace ID":      c.workspaceID,
+			"Rule Exclusion ID": c.id,
+		})
+		return err
+	}
+
+	text.Success(out, "Deleted rule exclusion '%s' (workspace: %s)", c.id, c.workspaceID)
+	return nil
+}
@@ -0,0 +1,3 @@
+// Package exclusion contains commands to inspect and manipulate the rule
+// exclusions of Fastly Next-Gen WAF workspaces.
+package exclusion
@@ -0,0 +1,133 @@
+package exclusion_test
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/fastly/cli/pkg/app"
+	"github.com/fastly/cli/pkg/testutil"
+)
+
+func TestExclusion(t *testing.T) {
+	args := testutil.Args
+	scenarios := []struct {
+		name       string
+		args       []string
+		client     *recordingClient
+		wantMethod string
+		wantPath   string
+		wantBody   string
+		wantError  string
+		wantOutput string
+	}{
+		{
+			name:      "validate missing --signal flag",
+			args:      args("waf rule-exclusion create --token x --workspace-id ws1 --path /search"),
+			wantError: "error parsing arguments: required flag --signal not provided",
+		},
+		{
+			name:      "validate missing --workspace-id flag",
+			args:      args("waf rule-exclusion list --token x"),
+			wantError: "error parsing arguments: required flag --workspace-id not provided",
+		},
+		{
+			name:      "validate missing token",
+			args:      args("waf rule-exclusion list --workspace-id ws1"),
+			client:    &recordingClient{},
+			wantError: "no token provided",
+		},
+		{
+			name:      "validate API error",
+			args:      args("waf rule-exclusion create --token x --workspace-id ws1 --path /search --signal SQLI"),
+			client:    &recordingClient{code: http.StatusBadRequest},
+			wantError: "non-2xx response: 400 Bad Request",
+		},
+		{
+			name:       "validate create",
+			args:       args("waf rule-exclusion create --token x --workspace-id ws1 --path /search --signal SQLI,XSS --description example"),
+			client:     &recordingClient{body: `{"id":"ex1","description":"example","path":"/search","signals":["SQLI","XSS"]}`},
+			wantMethod: http.MethodPost,
+			wantPath:   "/ngwaf/v1/workspaces/ws1/exclusions",
+			wantBody:   `{"description":"example","path":"/search","signals":["SQLI","XSS"]}`,
+			wantOutput: "Created rule exclusion 'ex1' excluding SQLI, XSS from '/search' (workspace: ws1)",
+		},
+		{
+			name:       "validate update",
+			args:       args("waf rule-exclusion update --token x --workspace-id ws1 --id ex1 --signal XSS"),
+			client:     &recordingClient{code: http.StatusNoContent},
+			wantMethod: http.MethodPatch,
+			wantPath:   "/ngwaf/v1/workspaces/ws1/exclusions/ex1",
+			wantBody:   `{"signals":["XSS"]}`,
+			wantOutput: "Updated rule exclusion 'ex1' (workspace: ws1)",
+		},
+		{
+			name:       "validate delete",
+			args:       args("waf rule-exclusion delete --token x --workspace-id ws1 --id ex1"),
+			client:     &recordingClient{code: http.StatusNoContent},
+			wantMethod: http.MethodDelete,
+			wantPath:   "/ngwaf/v1/workspaces/ws1/exclusions/ex1",
+			wantOutput: "Deleted rule exclusion 'ex1' (workspace: ws1)",
+		},
+		{
+			name:       "validate list",
+			args:       args("waf rule-exclusion list --token x --workspace-id ws1"),
+			client:     &recordingClient{body: `{"data":[{"id":"ex1","description":"example","path":"/search","signals":["SQLI","XSS"]}]}`},
+			wantMethod: http.MethodGet,
+			wantPath:   "/ngwaf/v1/workspaces/ws1/exclusions",
+			wantOutput: listOutput,
+		},
+	}
+
+	for testcaseIdx := range scenarios {
+		testcase := &scenarios[testcaseIdx]
+		t.Run(testcase.name, func(t *testing.T) {
+			var stdout bytes.Buffer
+			opts := testutil.NewRunOpts(testcase.args, &stdout)
+			if testcase.client != nil {
+				opts.HTTPClient = testcase.client
+			}
+			err := app.Run(opts)
+			testutil.AssertErrorContains(t, err, testcase.wantError)
+			testutil.AssertStringContains(t, stdout.String(), testcase.wantOutput)
+			if testcase.wantMethod != "" {
+				testutil.AssertString(t, testcase.wantMethod, testcase.client.method)
+				testutil.AssertString(t, testcase.wantPath, testcase.client.path)
+				testutil.AssertString(t, testcase.wantBody, testcase.client.reqBody)
+			}
+		})
+	}
+}
+
+// recordingClient records the request it receives and responds with the
+// configured status code and body.
+type recordingClient struct {
+	body string
+	code int
+
+	method  string
+	path    string
+	reqBody string
+}
+
+func (c *recordingClient) Do(req *http.Request) (*http.Response, error) {
+	c.method = req.Method
+	c.path = req.URL.Path
+	if req.Body != nil {
+		b, _ := io.ReadAll(req.Body)
+		c.reqBody = string(b)
+	}
+
+	rec := httptest.NewRecorder()
+	if c.code != 0 {
+		rec.WriteHeader(c.code)
+	}
+	rec.WriteString(c.body)
+	return rec.Result(), nil
+}
+
+var listOutput = `ID   PATH     SIGNALS    DESCRIPTION
+ex1  /search  SQLI, XSS  example
+`
@@ -0,0 +1,114 @@
+package exclusion
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/fastly/cli/pkg/api/undocumented"
+	"github.com/fastly/cli/pkg/cmd"
+	"github.com/fastly/cli/pkg/config"
+	fsterr "github.com/fastly/cli/pkg/errors"
+	"github.com/fastly/cli/pkg/text"
+	fsttime "github.com/fastly/cli/pkg/time"
+)
+
+// NewListCommand returns a usable command registered under the parent.
+func NewListCommand(parent cmd.Registerer, globals *config.Data) *ListCommand {
+	var c ListCommand
+	c.CmdClause = parent.Command("list", "List the rule exclusions on a Next-Gen WAF workspace")
+	c.Globals = globals
+
+	// Required flags
+	c.CmdClause.Flag("workspace-id", "Alphanumeric string identifying the Next-Gen WAF workspace").Required().StringVar(&c.workspaceID)
+
+	// Optional Flags
+	c.RegisterFlagBool(cmd.BoolFlagOpts{
+		Name:        cmd.FlagJSONName,
+		Description: cmd.FlagJSONDesc,
+		Dst:         &c.json,
+		Short:       'j',
+	})
+
+	return &c
+}
+
+// ListCommand calls the Next-Gen WAF API to list rule exclusions.
+type ListCommand struct {
+	cmd.Base
+
+	json        bool
+	workspaceID string
+}
+
+// Exec invokes the application logic for the command.
+func (c *ListCommand) Exec(_ io.Reader, out io.Writer) error {
+	if c.Globals.Verbose() && c.json {
+		return fsterr.ErrInvalidVerboseJSONCombo
+	}
+
+	client, err := newClient(c.Globals)
+	if err != nil {
+		return err
+	}
+
+	es, err := client.ListWAFExclusions(c.workspaceID)
+	if err != nil {
+		c.Globals.ErrLog.AddWithContext(err, map[string]any{
+			"Workspace ID": c.workspaceID,
+		})
+		return err
+	}
+
+	if c.Globals.Verbose() {
+		c.printVerbose(out, es)
+		return nil
+	}
+	return c.printSummary(out, es)
+}
+
+// printVerbose displays the information returned from the API in a verbose
+// format.
+func (c *ListCommand) printVerbose(out io.Writer, es []*undocumented.WAFExclusion) {
+	fmt.Fprintf(out, "Workspace ID: %s\n", c.workspaceID)
+	for i, e := range es {
+		fmt.Fprintf(out, "\tRule Exclusion %d/%d\n", i+1, len(es))
+		fmt.Fprintf(out, "\t\tID: %s\n", e.ID)
+		fmt.Fprintf(out, "\t\tDescription: %s\n", e.Description)
+		fmt.Fprintf(out, "\t\tPath: %s\n", e.Path)
+		fmt.Fprintf(out, "\t\tSignals: %s\n", strings.Join(e.Signals, ", "))
+		if e.CreatedAt != nil {
+			fmt.Fprintf(out, "\t\tCreated at: %s\n", e.CreatedAt.UTC().Format(fsttime.Format))
+		}
+		if e.UpdatedAt != nil {
+			fmt.Fprintf(out, "\t\tUpdated at: %s\n", e.UpdatedAt.UTC().Format(fsttime.Format))
+		}
+	}
+	fmt.Fprintln(out)
+}
+
+// printSummary displays the information returned from the API in a summarised
+// format.
+func (c *ListCommand) printSummary(out io.Writer, es []*undocumented.WAFExclusion) error {
+	if c.json {
+		data, err := json.Marshal(es)
+		if err != nil {
+			return err
+		}
+		_, err = out.Write(data)
+		if err != nil {
+			c.Globals.ErrLog.Add(err)
+			return fmt.Errorf("error: unable to write data to stdout: %w", err)
+		}
+		return nil
+	}
+
+	t := text.NewTable(out)
+	t.AddHeader("ID", "PATH", "SIGNALS", "DESCRIPTION")
+	for _, e := range es {
+		t.AddLine(e.ID, e.Path, strings.Join(e.Signals, ", "), e.Description)
+	}
+	t.Print()
+	return nil
+}
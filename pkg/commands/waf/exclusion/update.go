@@ -0,0 +1,79 @@
+package exclusion
+
+import (
+	"io"
+
+	"github.com/fastly/cli/pkg/api/undocumented"
+	"github.com/fastly/cli/pkg/cmd"
+	"github.com/fastly/cli/pkg/config"
+	"github.com/fastly/cli/pkg/text"
+	"github.com/fastly/kingpin"
+)
+
+// NewUpdateCommand returns a usable command registered under the parent.
+func NewUpdateCommand(parent cmd.Registerer, globals *config.Data) *UpdateCommand {
+	var c UpdateCommand
+	c.CmdClause = parent.Command("update", "Update a rule exclusion on a Next-Gen WAF workspace")
+	c.Globals = globals
+
+	// Required flags
+	c.CmdClause.Flag("id", "Alphanumeric string identifying the rule exclusion").Required().StringVar(&c.id)
+	c.CmdClause.Flag("workspace-id", "Alphanumeric string identifying the Next-Gen WAF workspace").Required().StringVar(&c.workspaceID)
+
+	// Optional flags
+	c.CmdClause.Flag("description", "Description of the rule exclusion").Action(c.description.Set).StringVar(&c.description.Value)
+	c.CmdClause.Flag("path", "Request path the exclusion applies to (e.g. /search)").Action(c.path.Set).StringVar(&c.path.Value)
+	c.CmdClause.Flag("signal", "A comma-separated list of signals to exclude (e.g. SQLI,XSS)").Action(c.signals.Set).StringsVar(&c.signals.Value, kingpin.Separator(","))
+
+	return &c
+}
+
+// UpdateCommand calls the Next-Gen WAF API to update a rule exclusion.
+type UpdateCommand struct {
+	cmd.Base
+
+	description cmd.OptionalString
+	id          string
+	path        cmd.OptionalString
+	signals     cmd.OptionalStringSlice
+	workspaceID string
+}
+
+// Exec invokes the application logic for the command.
+func (c *UpdateCommand) Exec(_ io.Reader, out io.Writer) error {
+	client, err := newClient(c.Globals)
+	if err != nil {
+		return err
+	}
+
+	input := c.constructInput()
+
+	r, err := client.UpdateWAFExclusion(c.workspaceID, c.id, input)
+	if err != nil {
+		c.Globals.ErrLog.AddWithContext(err, map[string]any{
+			"Workspace ID":      c.workspaceID,
+			"Rule Exclusion ID": c.id,
+		})
+		return err
+	}
+
+	text.Success(out, "Updated rule exclusion '%s' (workspace: %s)", r.ID, c.workspaceID)
+	return nil
+}
+
+// constructInput transforms values parsed from CLI flags into an object to be used by the API client.
+func (c *UpdateCommand) constructInput() undocumented.WAFExclusionInput {
+	var input undocumented.WAFExclusionInput
+
+	if c.description.WasSet {
+		input.Description = &c.description.Value
+	}
+	if c.path.WasSet {
+		input.Path = &c.path.Value
+	}
+	if c.signals.WasSet {
+		input.Signals = c.signals.Value
+	}
+
+	return input
+}
@@ -0,0 +1,103 @@
+package waf
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/fastly/cli/pkg/api/undocumented"
+	"github.com/fastly/cli/pkg/cmd"
+	"github.com/fastly/cli/pkg/config"
+	fsterr "github.com/fastly/cli/pkg/errors"
+	"github.com/fastly/cli/pkg/text"
+	fsttime "github.com/fastly/cli/pkg/time"
+)
+
+// NewListCommand returns a usable command registered under the parent.
+func NewListCommand(parent cmd.Registerer, globals *config.Data) *ListCommand {
+	var c ListCommand
+	c.CmdClause = parent.Command("list", "List the Next-Gen WAF workspaces of the account")
+	c.Globals = globals
+
+	// Optional Flags
+	c.RegisterFlagBool(cmd.BoolFlagOpts{
+		Name:        cmd.FlagJSONName,
+		Description: cmd.FlagJSONDesc,
+		Dst:         &c.json,
+		Short:       'j',
+	})
+
+	return &c
+}
+
+// ListCommand calls the Next-Gen WAF API to list workspaces.
+type ListCommand struct {
+	cmd.Base
+
+	json bool
+}
+
+// Exec invokes the application logic for the command.
+func (c *ListCommand) Exec(_ io.Reader, out io.Writer) error {
+	if c.Globals.Verbose() && c.json {
+		return fsterr.ErrInvalidVerboseJSONCombo
+	}
+
+	client, err := newClient(c.Globals)
+	if err != nil {
+		return err
+	}
+
+	ws, err := client.ListWAFWorkspaces()
+	if err != nil {
+		c.Globals.ErrLog.Add(err)
+		return err
+	}
+
+	if c.Globals.Verbose() {
+		c.printVerbose(out, ws)
+		return nil
+	}
+	return c.printSummary(out, ws)
+}
+
+// printVerbose displays the information returned from the API in a verbose
+// format.
+func (c *ListCommand) printVerbose(out io.Writer, ws []*undocumented.WAFWorkspace) {
+	for i, w := range ws {
+		fmt.Fprintf(out, "Workspace %d/%d\n", i+1, len(ws))
+		fmt.Fprintf(out, "\tID: %s\n", w.ID)
+		fmt.Fprintf(out, "\tName: %s\n", w.Name)
+		fmt.Fprintf(out, "\tDescription: %s\n", w.Description)
+		fmt.Fprintf(out, "\tMode: %s\n", w.Mode)
+		if w.CreatedAt != nil {
+			fmt.Fprintf(out, "\tCreated at: %s\n", w.CreatedAt.UTC().Format(fsttime.Format))
+		}
+		fmt.Fprintln(out)
+	}
+}
+
+// printSummary displays the information returned from the API in a summarised
+// format.
+func (c *ListCommand) printSummary(out io.Writer, ws []*undocumented.WAFWorkspace) error {
+	if c.json {
+		data, err := json.Marshal(ws)
+		if err != nil {
+			return err
+		}
+		_, err = out.Write(data)
+		if err != nil {
+			c.Globals.ErrLog.Add(err)
+			return fmt.Errorf("error: unable to write data to stdout: %w", err)
+		}
+		return nil
+	}
+
+	t := text.NewTable(out)
+	t.AddHeader("ID", "NAME", "MODE")
+	for _, w := range ws {
+		t.AddLine(w.ID, w.Name, w.Mode)
+	}
+	t.Print()
+	return nil
+}
@@ -0,0 +1,51 @@
+package waf
+
+import (
+	"io"
+
+	"github.com/fastly/cli/pkg/api/undocumented"
+	"github.com/fastly/cli/pkg/cmd"
+	"github.com/fastly/cli/pkg/config"
+	"github.com/fastly/cli/pkg/text"
+)
+
+// NewModeCommand returns a usable command registered under the parent.
+func NewModeCommand(parent cmd.Registerer, globals *config.Data) *ModeCommand {
+	var c ModeCommand
+	c.CmdClause = parent.Command("mode", "Switch a Next-Gen WAF workspace between blocking, logging and being disabled")
+	c.Globals = globals
+
+	// Required flags
+	c.CmdClause.Flag("mode", "Whether the workspace blocks attacks, only logs them, or is disabled").Required().HintOptions(undocumented.WAFWorkspaceModes...).EnumVar(&c.mode, undocumented.WAFWorkspaceModes...)
+	c.CmdClause.Flag("workspace-id", "Alphanumeric string identifying the Next-Gen WAF workspace").Required().StringVar(&c.workspaceID)
+
+	return &c
+}
+
+// ModeCommand calls the Next-Gen WAF API to switch the mode of a workspace.
+type ModeCommand struct {
+	cmd.Base
+
+	mode        string
+	workspaceID string
+}
+
+// Exec invokes the application logic for the command.
+func (c *ModeCommand) Exec(_ io.Reader, out io.Writer) error {
+	client, err := newClient(c.Globals)
+	if err != nil {
+		return err
+	}
+
+	w, err := client.UpdateWAFWorkspaceMode(c.workspaceID, c.mode)
+	if err != nil {
+		c.Globals.ErrLog.AddWithContext(err, map[string]any{
+			"Workspace ID": c.workspaceID,
+			"Mode":         c.mode,
+		})
+		return err
+	}
+
+	text.Success(out, "Set workspace '%s' to %s mode", w.ID, w.Mode)
+	return nil
+}
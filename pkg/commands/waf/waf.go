@@ -0,0 +1,17 @@
+package waf
+
+import (
+	"github.com/fastly/cli/pkg/api/undocumented"
+	"github.com/fastly/cli/pkg/config"
+	"github.com/fastly/cli/pkg/errors"
+)
+
+// newClient returns a client for the Next-Gen WAF API.
+func newClient(globals *config.Data) (*undocumented.Client, error) {
+	token, source := globals.Token()
+	if source == config.SourceUndefined {
+		return nil, errors.ErrNoToken
+	}
+	host, _ := globals.Endpoint()
+	return undocumented.NewClient(host, token, globals.HTTPClient), nil
+}
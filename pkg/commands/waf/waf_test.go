@@ -0,0 +1,227 @@
+package waf_test
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/fastly/cli/pkg/app"
+	"github.com/fastly/cli/pkg/testutil"
+)
+
+func TestList(t *testing.T) {
+	args := testutil.Args
+	scenarios := []struct {
+		name       string
+		args       []string
+		client     *wafClient
+		wantError  string
+		wantOutput string
+	}{
+		{
+			name:      "validate missing token",
+			args:      args("waf list"),
+			client:    &wafClient{},
+			wantError: "no token provided",
+		},
+		{
+			name:      "validate API error",
+			args:      args("waf list --token x"),
+			client:    &wafClient{code: http.StatusForbidden},
+			wantError: "non-2xx response: 403 Forbidden",
+		},
+		{
+			name: "validate API success",
+			args: args("waf list --token x"),
+			client: &wafClient{responses: map[string]string{
+				"/ngwaf/v1/workspaces": `{"data":[{"id":"ws1","name":"production","mode":"block"},{"id":"ws2","name":"staging","mode":"log"}]}`,
+			}},
+			wantOutput: listOutput,
+		},
+	}
+
+	for testcaseIdx := range scenarios {
+		testcase := &scenarios[testcaseIdx]
+		t.Run(testcase.name, func(t *testing.T) {
+			var stdout bytes.Buffer
+			opts := testutil.NewRunOpts(testcase.args, &stdout)
+			opts.HTTPClient = testcase.client
+			err := app.Run(opts)
+			testutil.AssertErrorContains(t, err, testcase.wantError)
+			testutil.AssertString(t, testcase.wantOutput, stdout.String())
+		})
+	}
+}
+
+func TestMode(t *testing.T) {
+	args := testutil.Args
+	scenarios := []struct {
+		name       string
+		args       []string
+		client     *wafClient
+		wantError  string
+		wantOutput string
+	}{
+		{
+			name:      "validate missing --workspace-id flag",
+			args:      args("waf mode --token x --mode block"),
+			wantError: "error parsing arguments: required flag --workspace-id not provided",
+		},
+		{
+			name:      "validate invalid --mode flag",
+			args:      args("waf mode --token x --workspace-id ws1 --mode score"),
+			wantError: "enum value must be one of block,log,off, got 'score'",
+		},
+		{
+			name:      "validate API error",
+			args:      args("waf mode --token x --workspace-id ws1 --mode block"),
+			client:    &wafClient{code: http.StatusNotFound},
+			wantError: "non-2xx response: 404 Not Found",
+		},
+		{
+			name: "validate API success",
+			args: args("waf mode --token x --workspace-id ws1 --mode block"),
+			client: &wafClient{responses: map[string]string{
+				"/ngwaf/v1/workspaces/ws1": `{"id":"ws1","name":"production","mode":"block"}`,
+			}},
+			wantOutput: "Set workspace 'ws1' to block mode",
+		},
+	}
+
+	for testcaseIdx := range scenarios {
+		testcase := &scenarios[testcaseIdx]
+		t.Run(testcase.name, func(t *testing.T) {
+			var stdout bytes.Buffer
+			opts := testutil.NewRunOpts(testcase.args, &stdout)
+			if testcase.client != nil {
+				opts.HTTPClient = testcase.client
+			}
+			err := app.Run(opts)
+			testutil.AssertErrorContains(t, err, testcase.wantError)
+			testutil.AssertStringContains(t, stdout.String(), testcase.wantOutput)
+			if testcase.client != nil && testcase.wantOutput != "" {
+				testutil.AssertEqual(t, []string{"PATCH /ngwaf/v1/workspaces/ws1"}, testcase.client.reqs)
+				testutil.AssertString(t, `{"mode":"block"}`, testcase.client.reqBody)
+			}
+		})
+	}
+}
+
+func TestEvents(t *testing.T) {
+	args := testutil.Args
+	scenarios := []struct {
+		name       string
+		args       []string
+		client     *wafClient
+		wantError  string
+		wantOutput string
+		wantReqs   []string
+	}{
+		{
+			name:      "validate missing --service-id flag",
+			args:      args("waf events --token x"),
+			client:    &wafClient{},
+			wantError: "error reading service: no service ID found",
+		},
+		{
+			name:      "validate invalid --since flag",
+			args:      args("waf events --token x --service-id 123 --since 0s"),
+			wantError: "invalid --since value: 0s",
+		},
+		{
+			name:      "validate missing token",
+			args:      args("waf events --service-id 123"),
+			client:    &wafClient{},
+			wantError: "no token provided",
+		},
+		{
+			name:      "validate service without a workspace",
+			args:      args("waf events --token x --service-id 123"),
+			client:    &wafClient{responses: map[string]string{}},
+			wantError: "service '123' isn't protected by a Next-Gen WAF workspace",
+			wantReqs:  []string{"GET /enabled-products/v1/ngwaf/services/123/configuration"},
+		},
+		{
+			name:      "validate API error",
+			args:      args("waf events --token x --service-id 123"),
+			client:    &wafClient{code: http.StatusForbidden},
+			wantError: "error reading the Next-Gen WAF workspace of service '123': non-2xx response: 403 Forbidden",
+		},
+		{
+			name: "validate API success",
+			args: args("waf events --token x --service-id 123 --limit 5"),
+			client: &wafClient{responses: map[string]string{
+				"/enabled-products/v1/ngwaf/services/123/configuration": `{"workspace_id":"ws1"}`,
+				"/ngwaf/v1/workspaces/ws1/events":                       `{"data":[{"id":"ev1","action":"flagged","type":"attack","source":"192.0.2.1","remote_country_code":"GB","request_count":12,"timestamp":"2022-09-01T10:00:00Z"}]}`,
+			}},
+			wantOutput: eventsOutput,
+			wantReqs: []string{
+				"GET /enabled-products/v1/ngwaf/services/123/configuration",
+				"GET /ngwaf/v1/workspaces/ws1/events",
+			},
+		},
+	}
+
+	for testcaseIdx := range scenarios {
+		testcase := &scenarios[testcaseIdx]
+		t.Run(testcase.name, func(t *testing.T) {
+			var stdout bytes.Buffer
+			opts := testutil.NewRunOpts(testcase.args, &stdout)
+			if testcase.client != nil {
+				opts.HTTPClient = testcase.client
+			}
+			err := app.Run(opts)
+			testutil.AssertErrorContains(t, err, testcase.wantError)
+			testutil.AssertString(t, testcase.wantOutput, stdout.String())
+			if testcase.wantReqs != nil {
+				testutil.AssertEqual(t, testcase.wantReqs, testcase.client.reqs)
+			}
+			if testcase.wantOutput != "" {
+				testutil.AssertString(t, "5", testcase.client.req.URL.Query().Get("limit"))
+				testutil.AssertBool(t, true, testcase.client.req.URL.Query().Get("from") != "")
+			}
+		})
+	}
+}
+
+// wafClient records the requests it receives and responds with the body
+// configured for the request path, or the configured status code.
+type wafClient struct {
+	code      int
+	responses map[string]string
+
+	req     *http.Request
+	reqBody string
+	reqs    []string
+}
+
+func (c *wafClient) Do(req *http.Request) (*http.Response, error) {
+	c.req = req
+	c.reqs = append(c.reqs, req.Method+" "+req.URL.Path)
+	if req.Body != nil {
+		b, _ := io.ReadAll(req.Body)
+		c.reqBody = string(b)
+	}
+
+	rec := httptest.NewRecorder()
+	switch body, ok := c.responses[req.URL.Path]; {
+	case c.code != 0:
+		rec.WriteHeader(c.code)
+	case ok:
+		rec.WriteString(body)
+	default:
+		rec.WriteHeader(http.StatusNoContent)
+	}
+	return rec.Result(), nil
+}
+
+var eventsOutput = `TIMESTAMP (UTC)   ID   ACTION   TYPE    SOURCE     COUNTRY  REQUESTS
+2022-09-01 10:00  ev1  flagged  attack  192.0.2.1  GB       12
+`
+
+var listOutput = `ID   NAME        MODE
+ws1  production  block
+ws2  staging     log
+`
@@ -1,17 +1,22 @@
 package config
 
 import (
+	"context"
 	_ "embed"
 	"errors"
 	"fmt"
 	"io"
 	"os"
 	"path/filepath"
+	"sort"
+	"strings"
+	"time"
 
 	"github.com/fastly/cli/pkg/api"
 	"github.com/fastly/cli/pkg/env"
 	fsterr "github.com/fastly/cli/pkg/errors"
 	"github.com/fastly/cli/pkg/filesystem"
+	"github.com/fastly/cli/pkg/interrupt"
 	"github.com/fastly/cli/pkg/manifest"
 	"github.com/fastly/cli/pkg/revision"
 	"github.com/fastly/cli/pkg/text"
@@ -45,6 +50,21 @@ const (
 	FilePermissions = 0o600
 )
 
+// String implements the fmt.Stringer interface.
+func (s Source) String() string {
+	switch s {
+	case SourceFile:
+		return "file"
+	case SourceEnvironment:
+		return "environment"
+	case SourceFlag:
+		return "flag"
+	case SourceDefault:
+		return "default"
+	}
+	return "undefined"
+}
+
 var (
 	// CurrentConfigVersion indicates the present config version.
 	CurrentConfigVersion int
@@ -76,6 +96,7 @@ var (
 // (e.g. an email address). Otherwise, parameters should be defined in specific
 // command structs, and parsed as flags.
 type Data struct {
+	Answers  text.Answers
 	Env      Environment
 	File     File
 	Flag     Flag
@@ -83,11 +104,30 @@ type Data struct {
 	Output   io.Writer
 	Path     string
 
+	// Dir is the absolute path of the directory the command runs in, which
+	// local files (e.g. the fastly.toml manifest) are resolved against.
+	Dir string
+
+	// Settings are the project's default global flags, from the .fastlyrc file
+	// and the [cli] section of the manifest. They're applied as flag arguments
+	// and so rank between env vars and the config file.
+	Settings manifest.Settings
+
 	// Custom interfaces
 	ErrLog     fsterr.LogInterface
 	APIClient  api.Interface
 	HTTPClient api.HTTPClient
 	RTSClient  api.RealtimeStatsInterface
+
+	// Interrupt cancels in-flight requests when the user interrupts the CLI.
+	Interrupt *interrupt.Handler
+}
+
+// Context is cancelled when the user interrupts the CLI or the command times
+// out. API requests are cancelled regardless, but the context allows commands
+// to also cancel other long running operations (e.g. subprocesses).
+func (d *Data) Context() context.Context {
+	return d.Interrupt.Context()
 }
 
 // Token yields the Fastly API token.
@@ -100,29 +140,30 @@ func (d *Data) Token() (string, Source) {
 		return d.Env.Token, SourceEnvironment
 	}
 
-	if d.Manifest.File.Profile != "" {
-		for k, v := range d.File.Profiles {
-			if k == d.Manifest.File.Profile {
-				return v.Token, SourceFile
-			}
-		}
+	if name := d.Profile(); name != "" {
+		return d.File.Profiles[name].Token, SourceFile
 	}
 
-	if d.Flag.Profile != "" {
-		for k, v := range d.File.Profiles {
-			if k == d.Flag.Profile {
-				return v.Token, SourceFile
-			}
+	return "", SourceUndefined
+}
+
+// Profile yields the name of the profile in use, i.e. the profile named by the
+// fastly.toml manifest or the --profile flag (whichever exists, in that order),
+// otherwise the default profile. It's empty if there's no such profile.
+func (d *Data) Profile() string {
+	for _, name := range []string{d.Manifest.File.Profile, d.Flag.Profile} {
+		if _, ok := d.File.Profiles[name]; ok && name != "" {
+			return name
 		}
 	}
 
-	for _, v := range d.File.Profiles {
+	for k, v := range d.File.Profiles {
 		if v.Default {
-			return v.Token, SourceFile
+			return k
 		}
 	}
 
-	return "", SourceUndefined
+	return ""
 }
 
 // Verbose yields the verbose flag, which can only be set via flags.
@@ -186,6 +227,11 @@ type Fastly struct {
 
 // CLI represents CLI specific configuration.
 type CLI struct {
+	// PinnedVersion is the CLI version whose language constraints and starter
+	// kits are pinned (see `fastly config pin`), which prevents them from being
+	// replaced by the ones embedded in a different CLI binary.
+	PinnedVersion string `toml:"pinned_version,omitempty"`
+
 	Version string `toml:"version"`
 }
 
@@ -202,14 +248,38 @@ type Viceroy struct {
 	TTL           string `toml:"ttl"`
 }
 
+// WasmABI represents the hostcall ABI generally available to Compute@Edge
+// packages at the edge, which a package's main.wasm imports are checked
+// against.
+//
+// NOTE: Hostcalls are added to the ABI before they're generally available, so
+// a package can work locally (with a recent Viceroy) but fail at the edge.
+type WasmABI struct {
+	// Modules are the generally available fastly_* hostcall modules.
+	Modules []string `toml:"modules"`
+
+	// Preview are the hostcalls, as MODULE.FUNCTION, within those modules that
+	// aren't yet generally available.
+	Preview []string `toml:"preview"`
+}
+
 // Language represents C@E language specific configuration.
 type Language struct {
-	Go   Go   `toml:"go"`
-	Rust Rust `toml:"rust"`
+	Go         Go         `toml:"go"`
+	JavaScript JavaScript `toml:"javascript"`
+	Rust       Rust       `toml:"rust"`
 }
 
 // Go represents Go C@E language specific configuration.
 type Go struct {
+	// Compiler is the compiler used to build the package: "tinygo" (the
+	// default) or "go", which uses the wasip1 target of the standard go
+	// compiler.
+	//
+	// NOTE: This is a user setting, so it's deliberately absent from the static
+	// config embedded into the CLI binary (and isn't reset by an update).
+	Compiler string `toml:"compiler,omitempty"`
+
 	// TinyGoConstraint is the `tinygo` version that we support.
 	TinyGoConstraint string `toml:"tinygo_constraint"`
 
@@ -218,6 +288,23 @@ type Go struct {
 	// We aim for go versions that support go modules by default.
 	// https://go.dev/blog/using-go-modules
 	ToolchainConstraint string `toml:"toolchain_constraint"`
+
+	// WasiP1Constraint is the `go` version that we support when the standard go
+	// compiler is used, as it must support the wasip1 target.
+	WasiP1Constraint string `toml:"wasip1_constraint"`
+}
+
+// The values of the Go Compiler.
+const (
+	GoCompilerGo     = "go"
+	GoCompilerTinyGo = "tinygo"
+)
+
+// JavaScript represents JavaScript C@E language specific configuration, which
+// also applies to AssemblyScript as it's built with the same toolchain.
+type JavaScript struct {
+	// NodeConstraint is the `node` version that we support.
+	NodeConstraint string `toml:"node_constraint"`
 }
 
 // Rust represents Rust C@E language specific configuration.
@@ -242,6 +329,124 @@ type Rust struct {
 	// RustupConstraint is a free-form semver constraint for the rustup version
 	// that should be installed.
 	RustupConstraint string `toml:"rustup_constraint"`
+
+	// AuditPolicy is whether a build checks the dependencies in Cargo.lock for
+	// RustSec advisories, and what happens if any are found: "warn" or "fail".
+	// When empty, the check only runs when the --audit flag is set.
+	//
+	// NOTE: This is a user setting, so it's deliberately absent from the static
+	// config embedded into the CLI binary (and isn't reset by an update).
+	AuditPolicy string `toml:"audit_policy,omitempty"`
+}
+
+// The values of the Rust AuditPolicy.
+const (
+	AuditPolicyFail = "fail"
+	AuditPolicyWarn = "warn"
+)
+
+// Aliases represents user-defined command shortcuts, mapping an alias name to
+// the command line it expands to (e.g. cdp = "compute publish -i").
+type Aliases map[string]string
+
+// Domain represents the naming convention of the domain proposed for a new
+// service by `compute deploy`, so that accepting the defaults (e.g. in CI)
+// produces a compliant domain.
+//
+// NOTE: These are user settings, so they're deliberately absent from the
+// static config embedded into the CLI binary (and aren't reset by an update).
+type Domain struct {
+	// Template is the domain with <placeholder> values, e.g.
+	// <team>-<name>-<env>.edgecompute.app, where <name> is the package name and
+	// <random> is a random name.
+	Template string `toml:"template,omitempty"`
+
+	// Vars are the values of the other placeholders, which a
+	// FASTLY_DOMAIN_VAR_<NAME> env var overrides.
+	Vars map[string]string `toml:"vars,omitempty"`
+}
+
+// LoggingPresets represents the recommended log formats of observability
+// vendors, keyed by preset name, which the --preset flag of the logging create
+// commands applies.
+type LoggingPresets map[string]LoggingPreset
+
+// LoggingPreset represents the settings of a logging endpoint recommended by
+// an observability vendor.
+type LoggingPreset struct {
+	// Description is displayed when listing the presets.
+	Description string `toml:"description"`
+
+	// Format is the log format, which must produce JSON the vendor can ingest.
+	Format string `toml:"format"`
+
+	// FormatVersion is the version of the custom logging format.
+	FormatVersion uint `toml:"format_version"`
+
+	// ContentType, JSONFormat and MessageType only apply to HTTPS endpoints,
+	// which send logs to the vendor's HTTP ingestion API.
+	ContentType string `toml:"content_type,omitempty"`
+	JSONFormat  string `toml:"json_format,omitempty"`
+	MessageType string `toml:"message_type,omitempty"`
+}
+
+// LoggingPreset returns the named logging preset.
+func (f *File) LoggingPreset(name string) (LoggingPreset, error) {
+	if p, ok := f.LoggingPresets[name]; ok {
+		return p, nil
+	}
+	names := make([]string, 0, len(f.LoggingPresets))
+	for n := range f.LoggingPresets {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+	return LoggingPreset{}, fsterr.RemediationError{
+		Inner:       fmt.Errorf("unknown logging preset '%s'", name),
+		Remediation: fmt.Sprintf("Use one of: %s. Presets are defined in the [logging_presets] section of the CLI config file (run `fastly config show --location` to view the path).", strings.Join(names, ", ")),
+	}
+}
+
+// Notify represents the endpoints notified after a service is changed (e.g. a
+// package is deployed or a version activated).
+type Notify struct {
+	// Enabled indicates whether notifications are sent by default, which the
+	// --notify/--no-notify flags override.
+	Enabled bool `toml:"enabled"`
+
+	// SlackWebhook is a Slack incoming webhook URL.
+	SlackWebhook string `toml:"slack_webhook,omitempty"`
+
+	// Webhook is a generic HTTP endpoint that is sent a JSON payload.
+	Webhook string `toml:"webhook,omitempty"`
+}
+
+// RemoteBuild represents the endpoint that builds a Compute@Edge package from
+// its source, for use with the `compute deploy --remote-build` flag.
+//
+// NOTE: This is a user setting, so it's deliberately absent from the static
+// config embedded into the CLI binary (and isn't reset by an update).
+type RemoteBuild struct {
+	// Endpoint is sent the package source as a .tar.gz archive, and responds
+	// with the built package.
+	Endpoint string `toml:"endpoint,omitempty"`
+}
+
+// Sandbox represents how the custom [scripts.build] and [scripts.post_build]
+// scripts of a Compute@Edge package are run.
+//
+// NOTE: These are user settings, so they're deliberately absent from the
+// static config embedded into the CLI binary (and aren't reset by an update).
+type Sandbox struct {
+	// Enabled indicates whether custom scripts run in a sandbox by default,
+	// which the --sandbox/--no-sandbox flags override.
+	Enabled bool `toml:"enabled,omitempty"`
+
+	// DenyNetwork runs sandboxed scripts without network access.
+	DenyNetwork bool `toml:"deny_network,omitempty"`
+
+	// EnvVars are the names of the environment variables passed to a sandboxed
+	// script, in addition to those a toolchain needs (e.g. PATH and HOME).
+	EnvVars []string `toml:"env_vars,omitempty"`
 }
 
 // Profiles represents multiple profile accounts.
@@ -249,9 +454,12 @@ type Profiles map[string]*Profile
 
 // Profile represents a specific profile account.
 type Profile struct {
-	Default bool   `toml:"default" json:"default"`
-	Email   string `toml:"email" json:"email"`
-	Token   string `toml:"token" json:"token"`
+	// AutoClone enables --autoclone by default for commands that modify a
+	// service version.
+	AutoClone bool   `toml:"autoclone,omitempty" json:"autoclone,omitempty"`
+	Default   bool   `toml:"default" json:"default"`
+	Email     string `toml:"email" json:"email"`
+	Token     string `toml:"token" json:"token"`
 }
 
 // StarterKitLanguages represents language specific starter kits.
@@ -263,12 +471,18 @@ type StarterKitLanguages struct {
 }
 
 // StarterKit represents starter kit specific configuration.
+//
+// Commit and Checksum pin the starter kit's content, so a template that has
+// been changed upstream is rejected. Commit is the expected git commit, and
+// Checksum the expected SHA-256 of a starter kit fetched as an archive.
 type StarterKit struct {
 	Name        string `toml:"name"`
 	Description string `toml:"description"`
 	Path        string `toml:"path"`
 	Tag         string `toml:"tag"`
 	Branch      string `toml:"branch"`
+	Commit      string `toml:"commit,omitempty"`
+	Checksum    string `toml:"checksum,omitempty"`
 }
 
 // createConfigDir creates the application configuration directory if it
@@ -284,13 +498,20 @@ func createConfigDir(path string) error {
 
 // File represents our dynamic application toml configuration.
 type File struct {
-	CLI           CLI                 `toml:"cli"`
-	ConfigVersion int                 `toml:"config_version"`
-	Fastly        Fastly              `toml:"fastly"`
-	Language      Language            `toml:"language"`
-	Profiles      Profiles            `toml:"profile"`
-	StarterKits   StarterKitLanguages `toml:"starter-kits"`
-	Viceroy       Viceroy             `toml:"viceroy"`
+	Aliases        Aliases             `toml:"alias,omitempty"`
+	CLI            CLI                 `toml:"cli"`
+	ConfigVersion  int                 `toml:"config_version"`
+	Domain         Domain              `toml:"domain,omitempty"`
+	Fastly         Fastly              `toml:"fastly"`
+	Language       Language            `toml:"language"`
+	LoggingPresets LoggingPresets      `toml:"logging_presets,omitempty"`
+	Notify         Notify              `toml:"notify,omitempty"`
+	Profiles       Profiles            `toml:"profile"`
+	RemoteBuild    RemoteBuild         `toml:"remote_build,omitempty"`
+	Sandbox        Sandbox             `toml:"sandbox,omitempty"`
+	StarterKits    StarterKitLanguages `toml:"starter-kits"`
+	Viceroy        Viceroy             `toml:"viceroy"`
+	WasmABI        WasmABI             `toml:"wasm_abi"`
 
 	// We store off a possible legacy configuration so that we can later extract
 	// the relevant email and token values that may pre-exist.
@@ -375,11 +596,28 @@ func (f *File) Read(
 
 		text.Break(out)
 
-		replacement := "Replace it with a valid version? (any existing email/token data will be lost) [y/N] "
-		label := fmt.Sprintf("Your configuration file (%s) is invalid. %s", path, replacement)
-		cont, err := text.AskYesNo(out, label, in)
-		if err != nil {
-			return fmt.Errorf("error reading input: %w", err)
+		// NOTE: Replacing the config loses the user's email/token data, and so
+		// it's only done without asking when --auto-yes is set. A prompt that
+		// can't be answered (e.g. --non-interactive) is an error.
+		cont, answered := f.autoYes, false
+		if cont {
+			text.Warning(out, "Your configuration file (%s) is invalid and has been replaced with a valid version.", path)
+		} else if !f.nonInteractive {
+			replacement := "Replace it with a valid version? (any existing email/token data will be lost) [y/N] "
+			label := fmt.Sprintf("Your configuration file (%s) is invalid. %s", path, replacement)
+			cont, err = text.AskYesNo(out, label, in)
+			if err != nil && !errors.Is(err, text.ErrNonInteractive) {
+				return fmt.Errorf("error reading input: %w", err)
+			}
+			answered = err == nil
+		}
+		if !cont && !answered {
+			err := fsterr.RemediationError{
+				Inner:       fmt.Errorf("%v (%s): %v", ErrInvalidConfig, path, unmarshalErr),
+				Remediation: fmt.Sprintf("%s Fix %s, or set --auto-yes to replace it with a valid version (any existing email/token data will be lost).", RemediationManualFix, path),
+			}
+			errLog.Add(err)
+			return err
 		}
 		if !cont {
 			err := fsterr.RemediationError{
@@ -389,7 +627,8 @@ func (f *File) Read(
 			errLog.Add(err)
 			return err
 		}
-		f = &staticConfig
+		*f = staticConfig
+		return f.UseStatic(path)
 	}
 
 	err = createConfigDir(path)
@@ -482,13 +721,22 @@ func (f *File) NeedsUpdating(data []byte, out io.Writer, errLog fsterr.LogInterf
 // UseStatic switches the in-memory configuration with the static version
 // embedded into the CLI binary and writes it back to disk.
 //
-// NOTE: We will attempt to migrate the profile data.
+// NOTE: We will attempt to migrate the profile data. The language constraints
+// and starter kits are kept if they've been pinned.
 func (f *File) UseStatic(path string) error {
+	pinned, language, kits := f.CLI.PinnedVersion, f.Language, f.StarterKits
+
 	err := toml.Unmarshal(Static, f)
 	if err != nil {
 		return invalidStaticConfigErr(err)
 	}
 
+	if pinned != "" {
+		f.CLI.PinnedVersion = pinned
+		f.Language = language
+		f.StarterKits = kits
+	}
+
 	f.CLI.Version = revision.SemVer(revision.AppVersion)
 	f.MigrateLegacy()
 
@@ -528,27 +776,57 @@ func (f *File) Write(path string) error {
 // Environment represents all of the configuration parameters that can come
 // from environment variables.
 type Environment struct {
-	Token    string
-	Endpoint string
+	Token      string
+	Endpoint   string
+	NoColor    string
+	ForceColor string
+	// Flags holds the env vars that may provide flag values, see
+	// cmd.EnvFlagArgs.
+	Flags map[string]string
 }
 
 // Read populates the fields from the provided environment.
 func (e *Environment) Read(state map[string]string) {
 	e.Token = state[env.Token]
 	e.Endpoint = state[env.Endpoint]
+	e.NoColor = state[env.NoColor]
+	e.ForceColor = state[env.ForceColor]
+	e.Flags = make(map[string]string)
+	for k, v := range state {
+		if strings.HasPrefix(k, env.FlagPrefix) {
+			e.Flags[k] = v
+		}
+	}
 }
 
 // Flag represents all of the configuration parameters that can be set with
 // explicit flags. Consumers should bind their flag values to these fields
 // directly.
 type Flag struct {
-	AcceptDefaults bool
-	AutoYes        bool
-	Endpoint       string
-	NonInteractive bool
-	Profile        string
-	Token          string
-	Verbose        bool
+	AcceptDefaults     bool
+	Answers            map[string]string
+	AnswersFile        string
+	AutoYes            bool
+	Color              string
+	Columns            []string
+	CommandTimeout     time.Duration
+	Concurrency        int
+	Dir                string
+	Endpoint           string
+	Interactive        bool
+	JQ                 string
+	LogFile            string
+	LogLevel           string
+	NonInteractive     bool
+	Profile            string
+	Quiet              bool
+	ServiceIDFile      string
+	ShowSecrets        bool
+	SortBy             string
+	StrictDeprecations bool
+	Theme              string
+	Token              string
+	Verbose            bool
 }
 
 // invalidStaticConfigErr generates an error to alert the user to an issue with
@@ -3,6 +3,7 @@ package config_test
 import (
 	"bytes"
 	_ "embed"
+	"io"
 	"os"
 	"path/filepath"
 	"strings"
@@ -11,6 +12,7 @@ import (
 	"github.com/fastly/cli/pkg/config"
 	fsterr "github.com/fastly/cli/pkg/errors"
 	"github.com/fastly/cli/pkg/testutil"
+	"github.com/fastly/cli/pkg/text"
 	toml "github.com/pelletier/go-toml"
 )
 
@@ -21,12 +23,16 @@ var staticConfig []byte
 var staticConfigInvalid []byte
 
 type testReadScenario struct {
+	autoYes              bool
 	name                 string
+	nonInteractive       bool
 	remediation          bool
 	staticConfig         []byte
+	unanswerable         bool
 	userConfigFilename   string
 	userResponseToPrompt string
 	wantError            string
+	wantRemediation      string
 }
 
 // TestConfigRead validates all logic flows within config.File.Read()
@@ -58,6 +64,29 @@ func TestConfigRead(t *testing.T) {
 			userResponseToPrompt: "no",
 			wantError:            config.RemediationManualFix,
 		},
+		{
+			name:               "when user config is invalid and --non-interactive is set, it should return a remediation error",
+			nonInteractive:     true,
+			staticConfig:       staticConfig,
+			userConfigFilename: "config-invalid.toml",
+			wantError:          config.ErrInvalidConfig.Error(),
+			wantRemediation:    "set --auto-yes to replace it",
+		},
+		{
+			name:               "when user config is invalid and the prompt can't be answered, it should return a remediation error",
+			staticConfig:       staticConfig,
+			unanswerable:       true,
+			userConfigFilename: "config-invalid.toml",
+			wantError:          config.ErrInvalidConfig.Error(),
+			wantRemediation:    "set --auto-yes to replace it",
+		},
+		{
+			name:               "when user config is invalid and --auto-yes is set, it should use static config",
+			autoYes:            true,
+			nonInteractive:     true,
+			staticConfig:       staticConfig,
+			userConfigFilename: "config-invalid.toml",
+		},
 		{
 			name:                 "when user config is in the legacy format, it should use static config",
 			staticConfig:         staticConfig,
@@ -119,11 +148,16 @@ func TestConfigRead(t *testing.T) {
 			}
 
 			var out bytes.Buffer
-			in := strings.NewReader(testcase.userResponseToPrompt)
+			var in io.Reader = strings.NewReader(testcase.userResponseToPrompt)
+			if testcase.unanswerable {
+				in = text.NonInteractiveReader{}
+			}
 
 			mockLog := fsterr.MockLog{}
 
 			var f config.File
+			f.SetAutoYes(testcase.autoYes)
+			f.SetNonInteractive(testcase.nonInteractive)
 			err = f.Read(configPath, in, &out, mockLog, false)
 
 			if testcase.remediation {
@@ -136,6 +170,7 @@ func TestConfigRead(t *testing.T) {
 				}
 			} else {
 				testutil.AssertErrorContains(t, err, testcase.wantError)
+				testutil.AssertRemediationErrorContains(t, err, testcase.wantRemediation)
 			}
 
 			if testcase.wantError == "" {
@@ -245,6 +280,31 @@ func TestUseStatic(t *testing.T) {
 	}
 }
 
+// TestUseStaticPinned validates pinned language constraints and starter kits
+// aren't replaced by the static config.
+func TestUseStaticPinned(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.toml")
+
+	f := config.File{
+		CLI:         config.CLI{PinnedVersion: "1.0.0", Version: "1.0.0"},
+		Language:    config.Language{Go: config.Go{TinyGoConstraint: ">= 0.1.0"}},
+		StarterKits: config.StarterKitLanguages{Go: []config.StarterKit{{Name: "pinned"}}},
+	}
+	if err := f.UseStatic(path); err != nil {
+		t.Fatal(err)
+	}
+
+	testutil.AssertEqual(t, "1.0.0", f.CLI.PinnedVersion)
+	testutil.AssertEqual(t, ">= 0.1.0", f.Language.Go.TinyGoConstraint)
+	testutil.AssertEqual(t, "pinned", f.StarterKits.Go[0].Name)
+	if f.CLI.Version == "1.0.0" {
+		t.Errorf("expected CLI.Version to be updated")
+	}
+	if f.Viceroy.TTL == "" {
+		t.Errorf("expected the other sections to be replaced with the static config")
+	}
+}
+
 type testInvalidConfigScenario struct {
 	testutil.TestScenario
 
@@ -372,3 +432,74 @@ func TestNeedsUpdating(t *testing.T) {
 		})
 	}
 }
+
+func TestProfile(t *testing.T) {
+	profiles := config.Profiles{
+		"user":  &config.Profile{Default: true, Token: "123"},
+		"other": &config.Profile{Token: "456"},
+	}
+
+	tests := []struct {
+		name      string
+		manifest  string
+		flag      string
+		profiles  config.Profiles
+		wantName  string
+		wantToken string
+	}{
+		{
+			name:      "default profile",
+			profiles:  profiles,
+			wantName:  "user",
+			wantToken: "123",
+		},
+		{
+			name:      "manifest profile",
+			manifest:  "other",
+			flag:      "user",
+			profiles:  profiles,
+			wantName:  "other",
+			wantToken: "456",
+		},
+		{
+			name:      "flag profile",
+			flag:      "other",
+			profiles:  profiles,
+			wantName:  "other",
+			wantToken: "456",
+		},
+		{
+			name:      "missing manifest profile falls back to the flag",
+			manifest:  "missing",
+			flag:      "other",
+			profiles:  profiles,
+			wantName:  "other",
+			wantToken: "456",
+		},
+		{
+			name:      "missing profiles fall back to the default",
+			manifest:  "missing",
+			flag:      "missing",
+			profiles:  profiles,
+			wantName:  "user",
+			wantToken: "123",
+		},
+		{
+			name: "no profiles",
+			flag: "other",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var d config.Data
+			d.Manifest.File.Profile = tt.manifest
+			d.Flag.Profile = tt.flag
+			d.File.Profiles = tt.profiles
+
+			testutil.AssertString(t, tt.wantName, d.Profile())
+			token, _ := d.Token()
+			testutil.AssertString(t, tt.wantToken, token)
+		})
+	}
+}
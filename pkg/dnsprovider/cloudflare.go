@@ -0,0 +1,87 @@
+package dnsprovider
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/fastly/cli/pkg/api"
+)
+
+// CloudflareEndpoint is the Cloudflare API endpoint.
+const CloudflareEndpoint = "https://api.cloudflare.com/client/v4"
+
+// Cloudflare creates records using the Cloudflare API, authenticated with an
+// API token that can edit the zone's DNS.
+type Cloudflare struct {
+	Client   api.HTTPClient
+	Endpoint string
+	Token    string
+}
+
+// cloudflareResponse is the envelope of a Cloudflare API response.
+type cloudflareResponse struct {
+	Result []struct {
+		ID   string `json:"id"`
+		Name string `json:"name"`
+	} `json:"result"`
+}
+
+// UpsertRecord implements the Provider interface.
+//
+// NOTE: Cloudflare holds each value as a separate record, and so any existing
+// records are deleted before a record is created for each value.
+func (c *Cloudflare) UpsertRecord(r Record) error {
+	zoneID, err := c.zone(r.Name)
+	if err != nil {
+		return err
+	}
+
+	records := fmt.Sprintf("%s/zones/%s/dns_records", c.Endpoint, zoneID)
+
+	var existing cloudflareResponse
+	query := url.Values{"name": {r.Name}, "type": {r.Type}}
+	if err := c.do(http.MethodGet, records+"?"+query.Encode(), nil, &existing); err != nil {
+		return fmt.Errorf("error listing Cloudflare records: %w", err)
+	}
+	for _, e := range existing.Result {
+		if err := c.do(http.MethodDelete, records+"/"+e.ID, nil, nil); err != nil {
+			return fmt.Errorf("error deleting Cloudflare record: %w", err)
+		}
+	}
+
+	for _, v := range r.Values {
+		body := map[string]any{
+			"content": v,
+			"name":    r.Name,
+			"ttl":     TTL,
+			"type":    r.Type,
+		}
+		if err := c.do(http.MethodPost, records, body, nil); err != nil {
+			return fmt.Errorf("error creating Cloudflare record: %w", err)
+		}
+	}
+	return nil
+}
+
+// zone returns the ID of the zone containing the record.
+func (c *Cloudflare) zone(name string) (string, error) {
+	for _, z := range zoneCandidates(name) {
+		var zones cloudflareResponse
+		query := url.Values{"name": {z}}
+		if err := c.do(http.MethodGet, c.Endpoint+"/zones?"+query.Encode(), nil, &zones); err != nil {
+			return "", fmt.Errorf("error listing Cloudflare zones: %w", err)
+		}
+		if len(zones.Result) > 0 {
+			return zones.Result[0].ID, nil
+		}
+	}
+	return "", fmt.Errorf("no Cloudflare zone found for %s", name)
+}
+
+// do sends an authenticated request to the Cloudflare API.
+func (c *Cloudflare) do(method, url string, body, result any) error {
+	return doJSON(c.Client, method, url, map[string]string{
+		"Authorization": "Bearer " + c.Token,
+	}, body, result)
+}
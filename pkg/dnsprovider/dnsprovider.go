@@ -0,0 +1,141 @@
+package dnsprovider
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/fastly/cli/pkg/api"
+	fsterr "github.com/fastly/cli/pkg/errors"
+	"github.com/fastly/cli/pkg/useragent"
+)
+
+// The supported DNS providers.
+const (
+	NameCloudflare = "cloudflare"
+	NameNS1        = "ns1"
+	NameRoute53    = "route53"
+)
+
+// Names are the supported DNS providers.
+var Names = []string{NameCloudflare, NameNS1, NameRoute53}
+
+// TTL is the time to live, in seconds, of the records created.
+const TTL = 60
+
+// Record is a DNS record.
+type Record struct {
+	Name   string
+	Type   string
+	Values []string
+}
+
+// Provider creates DNS records within the zones managed by a DNS provider.
+type Provider interface {
+	// UpsertRecord creates the record, replacing any existing record of the
+	// same name and type.
+	UpsertRecord(r Record) error
+}
+
+// New returns the named provider, configured with the credentials found in the
+// provider's usual environment variables.
+func New(name string, client api.HTTPClient) (Provider, error) {
+	switch name {
+	case NameCloudflare:
+		token := os.Getenv("CLOUDFLARE_API_TOKEN")
+		if token == "" {
+			return nil, errMissingCredentials(name, "CLOUDFLARE_API_TOKEN")
+		}
+		return &Cloudflare{Client: client, Endpoint: CloudflareEndpoint, Token: token}, nil
+	case NameNS1:
+		key := os.Getenv("NS1_APIKEY")
+		if key == "" {
+			return nil, errMissingCredentials(name, "NS1_APIKEY")
+		}
+		return &NS1{Client: client, Endpoint: NS1Endpoint, Key: key}, nil
+	case NameRoute53:
+		id, secret := os.Getenv("AWS_ACCESS_KEY_ID"), os.Getenv("AWS_SECRET_ACCESS_KEY")
+		if id == "" || secret == "" {
+			return nil, errMissingCredentials(name, "AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY")
+		}
+		return &Route53{
+			AccessKeyID:     id,
+			Client:          client,
+			Endpoint:        Route53Endpoint,
+			SecretAccessKey: secret,
+			SessionToken:    os.Getenv("AWS_SESSION_TOKEN"),
+		}, nil
+	}
+	return nil, fsterr.RemediationError{
+		Inner:       fmt.Errorf("unsupported DNS provider: %s", name),
+		Remediation: fmt.Sprintf("Use one of: %s.", strings.Join(Names, ", ")),
+	}
+}
+
+// errMissingCredentials describes the environment variables a provider needs.
+func errMissingCredentials(name, vars string) error {
+	return fsterr.RemediationError{
+		Inner:       fmt.Errorf("no %s credentials found", name),
+		Remediation: fmt.Sprintf("Set %s in the environment.", vars),
+	}
+}
+
+// zoneCandidates returns the names of the zones that might contain the record,
+// from the most to the least specific, e.g. _acme-challenge.www.example.com
+// might be within the www.example.com or example.com zone.
+func zoneCandidates(name string) []string {
+	labels := strings.Split(strings.TrimSuffix(name, "."), ".")
+	var zones []string
+	for i := 0; i < len(labels)-1; i++ {
+		zones = append(zones, strings.Join(labels[i:], "."))
+	}
+	return zones
+}
+
+// errNotFound is returned by doJSON when the resource doesn't exist.
+var errNotFound = fmt.Errorf("not found")
+
+// doJSON sends a request with an optional JSON body, decoding a successful
+// JSON response into result (if it isn't nil).
+func doJSON(client api.HTTPClient, method, url string, headers map[string]string, body, result any) error {
+	var r io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		r = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequest(method, url, r)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", useragent.Name)
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+
+	res, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close() // #nosec G307
+
+	if res.StatusCode == http.StatusNotFound {
+		return errNotFound
+	}
+	if res.StatusCode < http.StatusOK || res.StatusCode >= http.StatusMultipleChoices {
+		msg, _ := io.ReadAll(io.LimitReader(res.Body, 1024))
+		return fmt.Errorf("unexpected response status: %s: %s", res.Status, strings.TrimSpace(string(msg)))
+	}
+	if result == nil {
+		return nil
+	}
+	return json.NewDecoder(res.Body).Decode(result)
+}
@@ -0,0 +1,137 @@
+package dnsprovider_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/fastly/cli/pkg/dnsprovider"
+	"github.com/fastly/cli/pkg/testutil"
+)
+
+var record = dnsprovider.Record{
+	Name:   "_acme-challenge.www.example.com",
+	Type:   "CNAME",
+	Values: []string{"abc123.fastly-validations.com"},
+}
+
+func TestNew(t *testing.T) {
+	t.Setenv("CLOUDFLARE_API_TOKEN", "")
+
+	_, err := dnsprovider.New(dnsprovider.NameCloudflare, http.DefaultClient)
+	testutil.AssertErrorContains(t, err, "no cloudflare credentials found")
+	testutil.AssertRemediationErrorContains(t, err, "CLOUDFLARE_API_TOKEN")
+
+	_, err = dnsprovider.New("bind", http.DefaultClient)
+	testutil.AssertErrorContains(t, err, "unsupported DNS provider: bind")
+
+	t.Setenv("CLOUDFLARE_API_TOKEN", "123")
+	p, err := dnsprovider.New(dnsprovider.NameCloudflare, http.DefaultClient)
+	testutil.AssertNoError(t, err)
+	if _, ok := p.(*dnsprovider.Cloudflare); !ok {
+		t.Fatalf("want a Cloudflare provider, have: %T", p)
+	}
+}
+
+// fakeAPI records the requests it receives and responds to each with the
+// first matching response, or a 404 if none match.
+type fakeAPI struct {
+	requests  []string
+	responses map[string]string
+	body      string
+}
+
+func (f *fakeAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	req := r.Method + " " + r.URL.RequestURI()
+	f.requests = append(f.requests, req)
+	if r.Method == http.MethodPost || r.Method == http.MethodPut {
+		data, _ := io.ReadAll(r.Body)
+		f.body = string(data)
+	}
+	if resp, ok := f.responses[req]; ok {
+		_, _ = w.Write([]byte(resp))
+		return
+	}
+	w.WriteHeader(http.StatusNotFound)
+}
+
+func TestCloudflare(t *testing.T) {
+	f := &fakeAPI{responses: map[string]string{
+		"GET /zones?name=_acme-challenge.www.example.com":                           `{"result":[]}`,
+		"GET /zones?name=www.example.com":                                           `{"result":[]}`,
+		"GET /zones?name=example.com":                                               `{"result":[{"id":"z1","name":"example.com"}]}`,
+		"GET /zones/z1/dns_records?name=_acme-challenge.www.example.com&type=CNAME": `{"result":[{"id":"r1"}]}`,
+		"DELETE /zones/z1/dns_records/r1":                                           `{}`,
+		"POST /zones/z1/dns_records":                                                `{}`,
+	}}
+	server := httptest.NewServer(f)
+	defer server.Close()
+
+	p := &dnsprovider.Cloudflare{Client: http.DefaultClient, Endpoint: server.URL, Token: "123"}
+	testutil.AssertNoError(t, p.UpsertRecord(record))
+	testutil.AssertEqual(t, []string{
+		"GET /zones?name=_acme-challenge.www.example.com",
+		"GET /zones?name=www.example.com",
+		"GET /zones?name=example.com",
+		"GET /zones/z1/dns_records?name=_acme-challenge.www.example.com&type=CNAME",
+		"DELETE /zones/z1/dns_records/r1",
+		"POST /zones/z1/dns_records",
+	}, f.requests)
+	testutil.AssertString(t, `{"content":"abc123.fastly-validations.com","name":"_acme-challenge.www.example.com","ttl":60,"type":"CNAME"}`, f.body)
+}
+
+func TestNS1(t *testing.T) {
+	f := &fakeAPI{responses: map[string]string{
+		"GET /zones/example.com": `{}`,
+		"PUT /zones/example.com/_acme-challenge.www.example.com/CNAME": `{}`,
+	}}
+	server := httptest.NewServer(f)
+	defer server.Close()
+
+	p := &dnsprovider.NS1{Client: http.DefaultClient, Endpoint: server.URL, Key: "123"}
+	testutil.AssertNoError(t, p.UpsertRecord(record))
+	testutil.AssertEqual(t, []string{
+		"GET /zones/_acme-challenge.www.example.com",
+		"GET /zones/www.example.com",
+		"GET /zones/example.com",
+		"GET /zones/example.com/_acme-challenge.www.example.com/CNAME",
+		"PUT /zones/example.com/_acme-challenge.www.example.com/CNAME",
+	}, f.requests)
+	testutil.AssertString(t, `{"answers":[{"answer":["abc123.fastly-validations.com"]}],"domain":"_acme-challenge.www.example.com","ttl":60,"type":"CNAME","zone":"example.com"}`, f.body)
+}
+
+func TestRoute53(t *testing.T) {
+	zones := `<ListHostedZonesByNameResponse><HostedZones><HostedZone><Id>/hostedzone/Z1</Id><Name>example.com.</Name></HostedZone></HostedZones></ListHostedZonesByNameResponse>`
+	f := &fakeAPI{responses: map[string]string{
+		"GET /2013-04-01/hostedzonesbyname?dnsname=_acme-challenge.www.example.com&maxitems=1": zones,
+		"GET /2013-04-01/hostedzonesbyname?dnsname=www.example.com&maxitems=1":                 zones,
+		"GET /2013-04-01/hostedzonesbyname?dnsname=example.com&maxitems=1":                     zones,
+		"POST /2013-04-01/hostedzone/Z1/rrset/":                                                `<ChangeResourceRecordSetsResponse/>`,
+	}}
+	var auth string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auth = r.Header.Get("Authorization")
+		f.ServeHTTP(w, r)
+	}))
+	defer server.Close()
+
+	p := &dnsprovider.Route53{
+		AccessKeyID:     "AKID",
+		Client:          http.DefaultClient,
+		Endpoint:        server.URL,
+		SecretAccessKey: "secret",
+	}
+	testutil.AssertNoError(t, p.UpsertRecord(record))
+	testutil.AssertEqual(t, []string{
+		"GET /2013-04-01/hostedzonesbyname?dnsname=_acme-challenge.www.example.com&maxitems=1",
+		"GET /2013-04-01/hostedzonesbyname?dnsname=www.example.com&maxitems=1",
+		"GET /2013-04-01/hostedzonesbyname?dnsname=example.com&maxitems=1",
+		"POST /2013-04-01/hostedzone/Z1/rrset/",
+	}, f.requests)
+	testutil.AssertStringContains(t, f.body, "<Change><Action>UPSERT</Action><ResourceRecordSet><Name>_acme-challenge.www.example.com</Name><Type>CNAME</Type><TTL>60</TTL><ResourceRecords><ResourceRecord><Value>abc123.fastly-validations.com</Value></ResourceRecord></ResourceRecords></ResourceRecordSet></Change>")
+	if !strings.HasPrefix(auth, "AWS4-HMAC-SHA256 Credential=AKID/") || !strings.Contains(auth, "/us-east-1/route53/aws4_request, SignedHeaders=host;x-amz-date, Signature=") {
+		t.Errorf("want a signed request, have Authorization: %s", auth)
+	}
+}
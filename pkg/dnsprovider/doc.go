@@ -0,0 +1,3 @@
+// Package dnsprovider contains abstractions for creating DNS records with a
+// third-party DNS provider (e.g. the records that validate a TLS subscription).
+package dnsprovider
@@ -0,0 +1,81 @@
+package dnsprovider
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/fastly/cli/pkg/api"
+)
+
+// NS1Endpoint is the NS1 API endpoint.
+const NS1Endpoint = "https://api.nsone.net/v1"
+
+// NS1 creates records using the NS1 API, authenticated with an API key.
+type NS1 struct {
+	Client   api.HTTPClient
+	Endpoint string
+	Key      string
+}
+
+// ns1Answer is a value of an NS1 record.
+type ns1Answer struct {
+	Answer []string `json:"answer"`
+}
+
+// UpsertRecord implements the Provider interface.
+func (n *NS1) UpsertRecord(r Record) error {
+	zone, err := n.zone(r.Name)
+	if err != nil {
+		return err
+	}
+
+	answers := make([]ns1Answer, len(r.Values))
+	for i, v := range r.Values {
+		answers[i] = ns1Answer{Answer: []string{v}}
+	}
+	body := map[string]any{
+		"answers": answers,
+		"domain":  r.Name,
+		"ttl":     TTL,
+		"type":    r.Type,
+		"zone":    zone,
+	}
+
+	// NOTE: NS1 creates a record with a PUT and updates an existing record
+	// with a POST.
+	record := fmt.Sprintf("%s/zones/%s/%s/%s", n.Endpoint, zone, r.Name, r.Type)
+	method := http.MethodPost
+	err = n.do(http.MethodGet, record, nil)
+	if errors.Is(err, errNotFound) {
+		method = http.MethodPut
+	} else if err != nil {
+		return fmt.Errorf("error reading NS1 record: %w", err)
+	}
+	if err := n.do(method, record, body); err != nil {
+		return fmt.Errorf("error saving NS1 record: %w", err)
+	}
+	return nil
+}
+
+// zone returns the name of the zone containing the record.
+func (n *NS1) zone(name string) (string, error) {
+	for _, z := range zoneCandidates(name) {
+		err := n.do(http.MethodGet, n.Endpoint+"/zones/"+z, nil)
+		if err == nil {
+			return z, nil
+		}
+		if !errors.Is(err, errNotFound) {
+			return "", fmt.Errorf("error reading NS1 zone: %w", err)
+		}
+	}
+	return "", fmt.Errorf("no NS1 zone found for %s", strings.TrimSuffix(name, "."))
+}
+
+// do sends an authenticated request to the NS1 API.
+func (n *NS1) do(method, url string, body any) error {
+	return doJSON(n.Client, method, url, map[string]string{
+		"X-NSONE-Key": n.Key,
+	}, body, nil)
+}
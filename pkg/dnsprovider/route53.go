@@ -0,0 +1,140 @@
+package dnsprovider
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/fastly/cli/pkg/api"
+	"github.com/fastly/cli/pkg/sigv4"
+	"github.com/fastly/cli/pkg/useragent"
+)
+
+// Route53Endpoint is the Amazon Route 53 API endpoint.
+const Route53Endpoint = "https://route53.amazonaws.com"
+
+// route53Region is the region used to sign Route 53 requests, which is a
+// global service.
+const route53Region = "us-east-1"
+
+// Route53 creates records using the Amazon Route 53 API, authenticated with
+// AWS credentials allowed to change the hosted zone's record sets.
+type Route53 struct {
+	AccessKeyID     string
+	Client          api.HTTPClient
+	Endpoint        string
+	SecretAccessKey string
+	SessionToken    string
+}
+
+type route53HostedZones struct {
+	HostedZones []struct {
+		ID   string `xml:"Id"`
+		Name string `xml:"Name"`
+	} `xml:"HostedZones>HostedZone"`
+}
+
+type route53ChangeRequest struct {
+	XMLName xml.Name        `xml:"https://route53.amazonaws.com/doc/2013-04-01/ ChangeResourceRecordSetsRequest"`
+	Changes []route53Change `xml:"ChangeBatch>Changes>Change"`
+}
+
+type route53Change struct {
+	Action string   `xml:"Action"`
+	Name   string   `xml:"ResourceRecordSet>Name"`
+	Type   string   `xml:"ResourceRecordSet>Type"`
+	TTL    int      `xml:"ResourceRecordSet>TTL"`
+	Values []string `xml:"ResourceRecordSet>ResourceRecords>ResourceRecord>Value"`
+}
+
+// UpsertRecord implements the Provider interface.
+func (r53 *Route53) UpsertRecord(r Record) error {
+	zoneID, err := r53.zone(r.Name)
+	if err != nil {
+		return err
+	}
+
+	body, err := xml.Marshal(route53ChangeRequest{
+		Changes: []route53Change{{
+			Action: "UPSERT",
+			Name:   r.Name,
+			Type:   r.Type,
+			TTL:    TTL,
+			Values: r.Values,
+		}},
+	})
+	if err != nil {
+		return err
+	}
+	body = append([]byte(xml.Header), body...)
+
+	path := fmt.Sprintf("/2013-04-01/hostedzone/%s/rrset/", zoneID)
+	if err := r53.do(http.MethodPost, path, nil, body, nil); err != nil {
+		return fmt.Errorf("error changing Route 53 record sets: %w", err)
+	}
+	return nil
+}
+
+// zone returns the ID of the hosted zone containing the record.
+func (r53 *Route53) zone(name string) (string, error) {
+	for _, z := range zoneCandidates(name) {
+		var zones route53HostedZones
+		query := url.Values{"dnsname": {z}, "maxitems": {"1"}}
+		if err := r53.do(http.MethodGet, "/2013-04-01/hostedzonesbyname", query, nil, &zones); err != nil {
+			return "", fmt.Errorf("error listing Route 53 hosted zones: %w", err)
+		}
+		// NOTE: The zones are listed from the given name onwards, and so the
+		// first zone is only a match if it has the same name.
+		if len(zones.HostedZones) > 0 && strings.TrimSuffix(zones.HostedZones[0].Name, ".") == z {
+			return strings.TrimPrefix(zones.HostedZones[0].ID, "/hostedzone/"), nil
+		}
+	}
+	return "", fmt.Errorf("no Route 53 hosted zone found for %s", name)
+}
+
+// do sends a signed request to the Route 53 API, decoding a successful XML
+// response into result (if it isn't nil).
+func (r53 *Route53) do(method, path string, query url.Values, body []byte, result any) error {
+	u := r53.Endpoint + path
+	if len(query) > 0 {
+		u += "?" + query.Encode()
+	}
+	req, err := http.NewRequest(method, u, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("User-Agent", useragent.Name)
+	if body != nil {
+		req.Header.Set("Content-Type", "application/xml")
+	}
+	r53.sign(req, body, time.Now().UTC())
+
+	res, err := r53.Client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close() // #nosec G307
+
+	if res.StatusCode < http.StatusOK || res.StatusCode >= http.StatusMultipleChoices {
+		msg, _ := io.ReadAll(io.LimitReader(res.Body, 1024))
+		return fmt.Errorf("unexpected response status: %s: %s", res.Status, strings.TrimSpace(string(msg)))
+	}
+	if result == nil {
+		return nil
+	}
+	return xml.NewDecoder(res.Body).Decode(result)
+}
+
+// sign adds an AWS Signature Version 4 Authorization header to the request.
+func (r53 *Route53) sign(req *http.Request, body []byte, now time.Time) {
+	sigv4.Sign(req, body, sigv4.Credentials{
+		AccessKeyID:     r53.AccessKeyID,
+		SecretAccessKey: r53.SecretAccessKey,
+		SessionToken:    r53.SessionToken,
+	}, route53Region, "route53", now)
+}
@@ -16,4 +16,31 @@ const (
 
 	// CustomerID is the env var we look in for a Customer ID.
 	CustomerID = "FASTLY_CUSTOMER_ID"
+
+	// AutoClone is the env var we look in to enable --autoclone by default.
+	AutoClone = "FASTLY_AUTOCLONE"
+
+	// DomainVarPrefix is the prefix of the env vars we look in for the values
+	// of the [domain] template placeholders, e.g. FASTLY_DOMAIN_VAR_ENV for <env>.
+	DomainVarPrefix = "FASTLY_DOMAIN_VAR_"
+
+	// Recorder is the env var we look in for whether to record or replay API
+	// interactions, see the recorder package.
+	Recorder = "FASTLY_RECORDER"
+
+	// Cassette is the env var we look in for the file that API interactions
+	// are recorded to or replayed from.
+	Cassette = "FASTLY_RECORDER_CASSETTE"
+
+	// NoColor is the env var we look in for whether to disable coloured output
+	// (see https://no-color.org).
+	NoColor = "NO_COLOR"
+
+	// ForceColor is the env var we look in for whether to colour output even
+	// when it isn't written to a terminal.
+	ForceColor = "FORCE_COLOR"
+
+	// FlagPrefix is the prefix of the env vars we look in for flag values,
+	// e.g. FASTLY_COMPUTE_DEPLOY_COMMENT for `compute deploy --comment`.
+	FlagPrefix = "FASTLY_"
 )
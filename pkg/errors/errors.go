@@ -22,12 +22,22 @@ var ErrViceroyRestart = fmt.Errorf("a RESTART was initiated")
 var ErrIncompatibleServeFlags = RemediationError{
 	Inner:       fmt.Errorf("--skip-build shouldn't be used with --watch"),
 	Remediation: ComputeServeRemediation,
+	Code:        ExitValidation,
+}
+
+// ErrIncompatiblePublishFlags means --package can't be used with --watch
+// because the package wouldn't be rebuilt when changes are detected.
+var ErrIncompatiblePublishFlags = RemediationError{
+	Inner:       fmt.Errorf("--package shouldn't be used with --watch"),
+	Remediation: ComputePublishRemediation,
+	Code:        ExitValidation,
 }
 
 // ErrNoToken means no --token has been provided.
 var ErrNoToken = RemediationError{
 	Inner:       fmt.Errorf("no token provided"),
 	Remediation: AuthRemediation,
+	Code:        ExitAuth,
 }
 
 // ErrNoServiceID means no --service-id or service_id package manifest value has
@@ -35,6 +45,7 @@ var ErrNoToken = RemediationError{
 var ErrNoServiceID = RemediationError{
 	Inner:       fmt.Errorf("error reading service: no service ID found"),
 	Remediation: ServiceIDRemediation,
+	Code:        ExitValidation,
 }
 
 // ErrNoCustomerID means no --customer-id or FASTLY_CUSTOMER_ID environment
@@ -42,6 +53,7 @@ var ErrNoServiceID = RemediationError{
 var ErrNoCustomerID = RemediationError{
 	Inner:       fmt.Errorf("error reading customer ID: no customer ID found"),
 	Remediation: CustomerIDRemediation,
+	Code:        ExitValidation,
 }
 
 // ErrMissingManifestVersion means an invalid manifest (fastly.toml) has been used.
@@ -68,13 +80,14 @@ var ErrInvalidManifestVersion = RemediationError{
 // longer compatible with the current CLI version.
 var ErrIncompatibleManifestVersion = RemediationError{
 	Inner:       fmt.Errorf("the fastly.toml contains an incompatible manifest_version number"),
-	Remediation: "Update the `manifest_version` in the fastly.toml and refer to https://github.com/fastly/cli/releases/tag/v0.39.3 for changes to the manifest structure",
+	Remediation: "Run `fastly compute manifest migrate` to upgrade the fastly.toml, or refer to https://github.com/fastly/cli/releases/tag/v0.39.3 for changes to the manifest structure",
 }
 
 // ErrNoID means no --id value has been provided.
 var ErrNoID = RemediationError{
 	Inner:       fmt.Errorf("no ID found"),
 	Remediation: IDRemediation,
+	Code:        ExitValidation,
 }
 
 // ErrReadingManifest means there was a problem reading the package manifest.
@@ -105,6 +118,7 @@ var ErrInvalidArchive = RemediationError{
 var ErrBuildStopped = RemediationError{
 	Inner:       fmt.Errorf("build process stopped by user"),
 	Remediation: "Remove or update the custom [scripts.build] in the fastly.toml manifest.",
+	Code:        ExitAborted,
 }
 
 // ErrInvalidVerboseJSONCombo means the user provided both a --verbose and
@@ -112,4 +126,21 @@ var ErrBuildStopped = RemediationError{
 var ErrInvalidVerboseJSONCombo = RemediationError{
 	Inner:       fmt.Errorf("invalid flag combination, --verbose and --json"),
 	Remediation: "Use either --verbose or --json, not both.",
+	Code:        ExitValidation,
+}
+
+// ErrInvalidVerboseJQCombo means the user provided both a --verbose and --jq
+// flag, and verbose output would corrupt the JSON to be filtered.
+var ErrInvalidVerboseJQCombo = RemediationError{
+	Inner:       fmt.Errorf("invalid flag combination, --verbose and --jq"),
+	Remediation: "Use either --verbose or --jq, not both.",
+	Code:        ExitValidation,
+}
+
+// ErrInvalidVerboseQuietCombo means the user provided both a --verbose and
+// --quiet flag which are mutally exclusive behaviours.
+var ErrInvalidVerboseQuietCombo = RemediationError{
+	Inner:       fmt.Errorf("invalid flag combination, --verbose and --quiet"),
+	Remediation: "Use either --verbose or --quiet, not both.",
+	Code:        ExitValidation,
 }
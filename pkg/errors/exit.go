@@ -0,0 +1,90 @@
+package errors
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/fastly/go-fastly/v6/fastly"
+)
+
+// ExitCode is the exit status of the CLI, which lets scripts branch on the
+// type of failure. The codes are documented by `fastly help exit-codes`.
+type ExitCode int
+
+// The exit codes of the CLI.
+//
+// NOTE: The codes are part of the CLI's interface, so existing codes must
+// never be renumbered.
+const (
+	// ExitOK means the command succeeded.
+	ExitOK ExitCode = 0
+	// ExitError means the command failed for a reason without a more specific
+	// exit code.
+	ExitError ExitCode = 1
+	// ExitValidation means the arguments, flags or configuration are invalid.
+	ExitValidation ExitCode = 2
+	// ExitAuth means the API token is missing, invalid or lacks permission.
+	ExitAuth ExitCode = 3
+	// ExitAPIClient means the Fastly API rejected the request (a 4xx status).
+	ExitAPIClient ExitCode = 4
+	// ExitAPIServer means the Fastly API failed to handle the request (a 5xx
+	// status).
+	ExitAPIServer ExitCode = 5
+	// ExitAborted means the user declined to continue at a prompt.
+	ExitAborted ExitCode = 6
+	// ExitDrift means a check found differences, e.g. between local files and
+	// a service (see the --exit-code flags).
+	ExitDrift ExitCode = 7
+	// ExitInterrupted means the user interrupted the command (e.g. ^C).
+	ExitInterrupted ExitCode = 130
+)
+
+// ExitCodes describes each exit code, in the order they're documented.
+var ExitCodes = []struct {
+	Code        ExitCode
+	Description string
+}{
+	{ExitOK, "Success"},
+	{ExitError, "An error without a more specific exit code"},
+	{ExitValidation, "Invalid arguments, flags or configuration"},
+	{ExitAuth, "The API token is missing, invalid or lacks permission"},
+	{ExitAPIClient, "The Fastly API rejected the request (4xx status)"},
+	{ExitAPIServer, "The Fastly API failed to handle the request (5xx status)"},
+	{ExitAborted, "The command was stopped at a confirmation prompt"},
+	{ExitDrift, "Differences were detected (e.g. `vcl sync --dry-run --exit-code`)"},
+	{ExitInterrupted, "The command was interrupted (e.g. ^C)"},
+}
+
+// Exit returns the exit code for the error.
+//
+// A RemediationError with a Code set determines the exit code, otherwise a
+// Fastly API error is classified by its status code. Any other error is
+// ExitError.
+func Exit(err error) ExitCode {
+	if err == nil {
+		return ExitOK
+	}
+
+	// NOTE: The outermost RemediationError with a Code wins, so a command can
+	// reclassify an error it wraps.
+	var re RemediationError
+	for e := err; errors.As(e, &re); e = re.Inner {
+		if re.Code != ExitOK {
+			return re.Code
+		}
+	}
+
+	var httpError *fastly.HTTPError
+	if errors.As(err, &httpError) {
+		switch {
+		case httpError.StatusCode == http.StatusUnauthorized || httpError.StatusCode == http.StatusForbidden:
+			return ExitAuth
+		case httpError.StatusCode >= 500:
+			return ExitAPIServer
+		case httpError.StatusCode >= 400:
+			return ExitAPIClient
+		}
+	}
+
+	return ExitError
+}
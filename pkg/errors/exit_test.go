@@ -0,0 +1,78 @@
+package errors_test
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/fastly/cli/pkg/errors"
+	"github.com/fastly/cli/pkg/testutil"
+	"github.com/fastly/go-fastly/v6/fastly"
+)
+
+func TestExit(t *testing.T) {
+	for _, testcase := range []struct {
+		name  string
+		input error
+		want  errors.ExitCode
+	}{
+		{
+			name: "nil",
+			want: errors.ExitOK,
+		},
+		{
+			name:  "generic error",
+			input: fmt.Errorf("foo"),
+			want:  errors.ExitError,
+		},
+		{
+			name:  "RemediationError without a code",
+			input: errors.RemediationError{Inner: fmt.Errorf("foo")},
+			want:  errors.ExitError,
+		},
+		{
+			name:  "RemediationError with a code",
+			input: errors.ErrNoServiceID,
+			want:  errors.ExitValidation,
+		},
+		{
+			name:  "wrapped RemediationError with a code",
+			input: fmt.Errorf("error deploying: %w", errors.ErrNoToken),
+			want:  errors.ExitAuth,
+		},
+		{
+			name:  "RemediationError wrapping a RemediationError with a code",
+			input: errors.RemediationError{Inner: errors.ErrBuildStopped, Remediation: "Try again."},
+			want:  errors.ExitAborted,
+		},
+		{
+			name:  "RemediationError reclassifying a fastly.HTTPError",
+			input: errors.RemediationError{Inner: &fastly.HTTPError{StatusCode: http.StatusNotFound}, Code: errors.ExitDrift},
+			want:  errors.ExitDrift,
+		},
+		{
+			name:  "fastly.HTTPError 401",
+			input: &fastly.HTTPError{StatusCode: http.StatusUnauthorized},
+			want:  errors.ExitAuth,
+		},
+		{
+			name:  "fastly.HTTPError 403",
+			input: fmt.Errorf("error listing services: %w", &fastly.HTTPError{StatusCode: http.StatusForbidden}),
+			want:  errors.ExitAuth,
+		},
+		{
+			name:  "fastly.HTTPError 404",
+			input: &fastly.HTTPError{StatusCode: http.StatusNotFound},
+			want:  errors.ExitAPIClient,
+		},
+		{
+			name:  "fastly.HTTPError 503",
+			input: errors.RemediationError{Inner: &fastly.HTTPError{StatusCode: http.StatusServiceUnavailable}},
+			want:  errors.ExitAPIServer,
+		},
+	} {
+		t.Run(testcase.name, func(t *testing.T) {
+			testutil.AssertEqual(t, testcase.want, errors.Exit(testcase.input))
+		})
+	}
+}
@@ -1,6 +1,7 @@
 package errors
 
 import (
+	"bytes"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -61,9 +62,12 @@ func (l *LogEntries) Add(err error) {
 }
 
 // AddWithContext adds a new log entry with extra contextual data.
+//
+// NOTE: The context is redacted as it's captured, so that neither the log file
+// nor the error analysis platform receive its secrets.
 func (l *LogEntries) AddWithContext(err error, ctx map[string]any) {
 	le := createLogEntry(err)
-	le.Context = ctx
+	le.Context = redactContext(ctx)
 
 	logMutex.Lock()
 	*l = append(*l, le)
@@ -71,15 +75,32 @@ func (l *LogEntries) AddWithContext(err error, ctx map[string]any) {
 }
 
 // Persist persists recorded log entries to disk.
-func (l LogEntries) Persist(logPath string, args []string) error {
-	if len(l) == 0 {
+//
+// NOTE: Each session is written with a single append so that concurrent CLI
+// processes sharing the log file don't interleave their records.
+func (l *LogEntries) Persist(logPath string, args []string) error {
+	logMutex.Lock()
+	entries := make(LogEntries, len(*l))
+	copy(entries, *l)
+	logMutex.Unlock()
+
+	if len(entries) == 0 {
 		return nil
 	}
-	cmd := "fastly " + strings.Join(args, " ")
-	instrument(l, cmd)
+	cmd := FilterToken("fastly " + strings.Join(args, " "))
+	instrument(entries, cmd)
+
+	persistMutex.Lock()
+	defer persistMutex.Unlock()
 
 	errMsg := "error accessing audit log file: %w"
 
+	if fi, err := os.Stat(logPath); err == nil && fi.Size() >= FileRotationSize {
+		if err := os.Rename(logPath, RotatedLogPath(logPath)); err != nil {
+			return fmt.Errorf(errMsg, err)
+		}
+	}
+
 	// gosec flagged this:
 	// G304 (CWE-22): Potential file inclusion via variable
 	//
@@ -90,25 +111,6 @@ func (l LogEntries) Persist(logPath string, args []string) error {
 		return fmt.Errorf(errMsg, err)
 	}
 
-	if fi, err := f.Stat(); err == nil {
-		if fi.Size() >= FileRotationSize {
-			err = f.Close()
-			if err != nil {
-				return err
-			}
-
-			// gosec flagged this:
-			// G304 (CWE-22): Potential file inclusion via variable
-			//
-			// Disabling as the input is determined from our own package.
-			/* #nosec */
-			f, err = os.Create(logPath)
-			if err != nil {
-				return fmt.Errorf(errMsg, err)
-			}
-		}
-	}
-
 	// G307 (CWE-): Deferring unsafe method "*os.File" on type "Close".
 	// gosec flagged this:
 	// Disabling because this file isn't critical to the functioning of the CLI
@@ -116,10 +118,8 @@ func (l LogEntries) Persist(logPath string, args []string) error {
 	/* #nosec */
 	defer f.Close()
 
-	cmd = "\nCOMMAND:\n" + cmd + "\n\n"
-	if _, err := f.Write([]byte(cmd)); err != nil {
-		return err
-	}
+	var buf bytes.Buffer
+	buf.WriteString("\nCOMMAND:\n" + cmd + "\n\n")
 
 	record := `TIMESTAMP:
 {{.Time}}
@@ -135,25 +135,63 @@ ERROR:
 {{ end }}
 `
 	t := template.Must(template.New("record").Parse(record))
-	for _, entry := range l {
-		err := t.Execute(f, entry)
-		if err != nil {
+	for _, entry := range entries {
+		var b bytes.Buffer
+		if err := t.Execute(&b, entry); err != nil {
 			return err
 		}
+		buf.WriteString(FilterToken(b.String()))
 	}
+	buf.WriteString(LogSeparator)
 
-	if _, err := f.Write([]byte("------------------------------\n\n")); err != nil {
-		return err
+	_, err = f.Write(buf.Bytes())
+	return err
+}
+
+// secretContextKeys are the words that mark a context key as holding a secret,
+// e.g. "Password", "Secret Key", "Access Key" and "SAS Token".
+var secretContextKeys = []string{"credentials", "key", "password", "secret", "token"}
+
+// redactContext returns a copy of ctx with the values of any keys that look
+// like they hold a secret replaced with "REDACTED".
+func redactContext(ctx map[string]any) map[string]any {
+	if ctx == nil {
+		return nil
 	}
+	redacted := make(map[string]any, len(ctx))
+	for k, v := range ctx {
+		lower := strings.ToLower(k)
+		for _, s := range secretContextKeys {
+			if strings.Contains(lower, s) {
+				v = "REDACTED"
+				break
+			}
+		}
+		redacted[k] = v
+	}
+	return redacted
+}
 
-	return nil
+// RotatedLogPath returns the location the log file at logPath is moved to
+// once it exceeds FileRotationSize.
+func RotatedLogPath(logPath string) string {
+	return logPath + ".1"
 }
 
+// LogSeparator marks the end of the entries persisted for a CLI session.
+const LogSeparator = "------------------------------\n\n"
+
 var (
 	// TokenRegEx matches a Token as part of the error output (https://regex101.com/r/ulIw1m/1)
 	TokenRegEx = regexp.MustCompile(`Token ([\w-]+)`)
-	// TokenFlagRegEx matches the token flag (https://regex101.com/r/YNr78Q/1)
-	TokenFlagRegEx = regexp.MustCompile(`(-t|--token)(\s*=?\s*['"]?)([\w-]+)(['"]?)`)
+	// TokenFlagRegEx matches the token flag, but not flags that merely contain
+	// "-t" such as --sas-token.
+	TokenFlagRegEx = regexp.MustCompile(`(^|\s)(-t|--token)(\s*=?\s*['"]?)([\w-]+)(['"]?)`)
+	// SecretFlagRegEx matches the flags whose values are secrets, such as
+	// --password, --secret-key and the Azure Blob Storage --sas-token.
+	SecretFlagRegEx = regexp.MustCompile(`(^|\s)(--(?:access-key|account-key|auth-token|client-key|password|sas-token|schema-registry-password|secret-key|ssl-client-key|tls-client-key))(\s*=\s*['"]?|\s+['"]?)([^\s'"]+)(['"]?)`)
+	// SignatureRegEx matches the signature of a SAS token or pre-signed URL.
+	SignatureRegEx = regexp.MustCompile(`([?&](?:sig|X-Amz-Signature)=)([^&\s'"]+)`)
 )
 
 // FilterToken replaces any matched patterns with "REDACTED".
@@ -161,7 +199,9 @@ var (
 // EXAMPLE: https://go.dev/play/p/cT4BwIh9Asa
 func FilterToken(input string) (inputFiltered string) {
 	inputFiltered = TokenRegEx.ReplaceAllString(input, "Token REDACTED")
-	inputFiltered = TokenFlagRegEx.ReplaceAllString(inputFiltered, "${1}${2}REDACTED${4}")
+	inputFiltered = TokenFlagRegEx.ReplaceAllString(inputFiltered, "${1}${2}${3}REDACTED${5}")
+	inputFiltered = SecretFlagRegEx.ReplaceAllString(inputFiltered, "${1}${2}${3}REDACTED${5}")
+	inputFiltered = SignatureRegEx.ReplaceAllString(inputFiltered, "${1}REDACTED")
 	return inputFiltered
 }
 
@@ -186,7 +226,7 @@ func instrument(l LogEntries, cmd string) {
 		// https://docs.sentry.io/product/issues/issue-details/breadcrumbs/
 		b := sentry.Breadcrumb{
 			Data:      entry.Context,
-			Message:   fmt.Sprintf("%s (file: %s, line: %d)", entry.Err.Error(), file, line),
+			Message:   FilterToken(fmt.Sprintf("%s (file: %s, line: %d)", entry.Err.Error(), file, line)),
 			Timestamp: entry.Time,
 			Type:      "error",
 		}
@@ -242,6 +282,10 @@ type Caller struct {
 // a lock before updating the LogEntries.
 var logMutex sync.Mutex
 
+// persistMutex prevents concurrent calls to Persist from racing to rotate the
+// log file.
+var persistMutex sync.Mutex
+
 // Now is exposed so that we may mock it from our test file.
 //
 // NOTE: The ideal way to deal with time is to inject it as a dependency and
@@ -251,7 +295,7 @@ var logMutex sync.Mutex
 var Now = time.Now
 
 // FileRotationSize represents the size the log file needs to be before we
-// truncate it.
+// rotate it, replacing any previously rotated file.
 //
 // NOTE: To enable easier testing of the log rotation logic, we don't define
 // this as a constant but as a variable so the test file can mutate the value
@@ -205,4 +205,103 @@ func TestLogPersistLogRotation(t *testing.T) {
 	havetrim := r.Replace(string(have))
 
 	testutil.AssertEqual(t, wanttrim, havetrim)
+
+	rotated, err := os.Stat(errors.RotatedLogPath(path))
+	if err != nil {
+		t.Fatalf("want rotated log file: %v", err)
+	}
+	testutil.AssertEqual(t, fi.Size(), rotated.Size())
+}
+
+func TestFilterToken(t *testing.T) {
+	for _, testcase := range []struct {
+		input string
+		want  string
+	}{
+		{
+			input: "fastly service list --token abc123",
+			want:  "fastly service list --token REDACTED",
+		},
+		{
+			input: "fastly service list -t=abc123",
+			want:  "fastly service list -t=REDACTED",
+		},
+		{
+			input: "fastly logging azureblob create --sas-token 'sv=2018-04-05&ss=b&sig=abc%2F123'",
+			want:  "fastly logging azureblob create --sas-token 'REDACTED'",
+		},
+		{
+			input: "fastly auth-token create --password=abc123 --name test",
+			want:  "fastly auth-token create --password=REDACTED --name test",
+		},
+		{
+			input: "fastly auth-token rotate --password abc123",
+			want:  "fastly auth-token rotate --password REDACTED",
+		},
+		{
+			input: `fastly logging s3 create --access-key abc123 --secret-key "abc/123"`,
+			want:  `fastly logging s3 create --access-key REDACTED --secret-key "REDACTED"`,
+		},
+		{
+			input: "fastly logging kafka create --schema-registry-password abc123 --tls-client-key abc123",
+			want:  "fastly logging kafka create --schema-registry-password REDACTED --tls-client-key REDACTED",
+		},
+		{
+			input: "fastly user update --password-reset --id abc123",
+			want:  "fastly user update --password-reset --id abc123",
+		},
+		{
+			input: "fastly logging sftp create --public-key abc123",
+			want:  "fastly logging sftp create --public-key abc123",
+		},
+		{
+			input: "GET https://example.blob.core.windows.net/logs?sv=2018-04-05&sig=abc%2F123&se=2022",
+			want:  "GET https://example.blob.core.windows.net/logs?sv=2018-04-05&sig=REDACTED&se=2022",
+		},
+		{
+			input: "401 - Unauthorized: Token abc123 is invalid",
+			want:  "401 - Unauthorized: Token REDACTED is invalid",
+		},
+		{
+			input: "fastly compute build --timeout 60",
+			want:  "fastly compute build --timeout 60",
+		},
+	} {
+		testutil.AssertString(t, testcase.want, errors.FilterToken(testcase.input))
+	}
+}
+
+func TestLogPersistRedactsContext(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "errors.log")
+
+	le := new(errors.LogEntries)
+	le.AddWithContext(fmt.Errorf("foo"), map[string]any{
+		"SAS Token":   "sv=2018-04-05&sig=abc",
+		"Name":        "logs",
+		"Password":    "abc-password",
+		"secret":      "abc-secret",
+		"Access Key":  "abc-access",
+		"SECRET_KEY":  "abc-secret-key",
+		"API Key":     "abc-api",
+		"Credentials": "abc-credentials",
+	})
+
+	testutil.AssertEqual(t, "REDACTED", (*le)[0].Context["Password"])
+
+	err := le.Persist(path, []string{"logging", "azureblob", "create", "--token", "abc123", "--sas-token", "abc123"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	have, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	testutil.AssertStringContains(t, string(have), "--token REDACTED --sas-token REDACTED")
+	testutil.AssertStringContains(t, string(have), "SAS Token: REDACTED")
+	for _, k := range []string{"Password", "secret", "Access Key", "SECRET_KEY", "API Key", "Credentials"} {
+		testutil.AssertStringContains(t, string(have), k+": REDACTED")
+	}
+	testutil.AssertStringContains(t, string(have), "Name: logs")
+	testutil.AssertStringDoesntContain(t, string(have), "abc")
 }
@@ -14,6 +14,8 @@ type RemediationError struct {
 	Prefix      string
 	Inner       error
 	Remediation string
+	// Code is the exit code of the CLI, see Exit.
+	Code ExitCode
 }
 
 // Unwrap returns the inner error.
@@ -134,6 +136,13 @@ var ComputeInitRemediation = strings.Join([]string{
 	"See more at https://developer.fastly.com/reference/fastly-toml/",
 }, " ")
 
+// ComputePublishRemediation suggests re-running `compute publish` with one of
+// the incompatible flags removed.
+var ComputePublishRemediation = strings.Join([]string{
+	"The --watch flag rebuilds and deploys your project whenever its source changes, and subsequently conflicts with the --package flag which deploys an existing package.",
+	"Remove one of the flags based on the outcome you require.",
+}, " ")
+
 // ComputeServeRemediation suggests re-running `compute serve` with one of the
 // incompatible flags removed.
 var ComputeServeRemediation = strings.Join([]string{
@@ -148,6 +157,14 @@ var ComputeBuildRemediation = strings.Join([]string{
 	"See more at https://developer.fastly.com/reference/fastly-toml/",
 }, " ")
 
+// StarterKitMismatchRemediation explains that a package template didn't match
+// the commit or checksum pinned in the starter kit configuration.
+var StarterKitMismatchRemediation = strings.Join([]string{
+	"The package template has changed since it was pinned, which may mean it was tampered with.",
+	"Check the starter kit's source before using it, or select a specific version with the --commit flag.",
+	"If you trust the new version, update the pin in the [starter-kits] section of the CLI config (see `fastly config --location`).",
+}, " ")
+
 // ComputeTrialRemediation suggests contacting customer manager to enable the
 // free trial feature flag.
 var ComputeTrialRemediation = "For more help with this error see fastly.help/cli/ecp-feature"
@@ -155,6 +172,21 @@ var ComputeTrialRemediation = "For more help with this error see fastly.help/cli
 // ProfileRemediation suggests no profiles exist.
 var ProfileRemediation = "Run `fastly profile create <NAME>` to create a profile, or `fastly profile list` to view available profiles (at least one profile should be set as 'default')."
 
+// NonInteractiveRemediation suggests how to provide input that would otherwise
+// have been prompted for.
+var NonInteractiveRemediation = strings.Join([]string{
+	"A prompt was reached that requires user input.",
+	"Provide the value using the command's flags (see --help for details),",
+	"or remove --non-interactive to be prompted for it",
+	"(prompts are also disabled when stdin isn't a terminal, or by the project's non_interactive setting, unless --interactive is set).",
+}, " ")
+
+// JQRemediation suggests how to produce JSON output for the --jq flag.
+var JQRemediation = strings.Join([]string{
+	"The --jq flag filters JSON output.",
+	"Add the --json flag if the command supports it (see --help for details).",
+}, " ")
+
 // InvalidStaticConfigRemediation indicates an unexpected error occurred when
 // deserialising the CLI's internal configuration.
 var InvalidStaticConfigRemediation = strings.Join([]string{
@@ -1,13 +1,16 @@
 package exec
 
 import (
+	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"os"
 	"os/exec"
 	"os/signal"
 	"strings"
+	"sync"
 	"syscall"
 	"time"
 
@@ -15,17 +18,39 @@ import (
 	"github.com/fastly/cli/pkg/threadsafe"
 )
 
+// ErrorTailLines is the maximum number of lines of the command's final output
+// that are included in the error returned when the command fails.
+const ErrorTailLines = 30
+
 // Streaming models a generic command execution that consumers can use to
 // execute commands and stream their output to an io.Writer. For example
 // compute commands can use this to standardize the flow control for each
 // compiler toolchain.
+//
+// When Prefix is set, each line of the command's output is displayed with the
+// prefix in verbose mode (e.g. "cargo | Compiling ...") so it can be told
+// apart from the CLI's own output.
+//
+// Env is added to the current environment. When Sandbox is set, the command
+// runs within the restrictions it describes, although the variables in Env are
+// always passed to the command.
+//
+// When Context is set, the command is killed once the context is done (e.g.
+// when the user interrupts the CLI).
+//
+// Dir is the working directory of the command, defaulting to the current
+// working directory.
 type Streaming struct {
 	Args     []string
 	Command  string
+	Context  context.Context
+	Dir      string
 	Env      []string
 	Output   io.Writer
+	Prefix   string
 	Process  *os.Process
 	Progress io.Writer
+	Sandbox  *Sandbox
 	SignalCh chan os.Signal
 	Timeout  time.Duration
 	Verbose  bool
@@ -61,29 +86,42 @@ func (s *Streaming) MonitorSignalsAsync() {
 // stderr output to the supplied io.Writer, it waits for the command to exit
 // cleanly or returns an error.
 func (s *Streaming) Exec() error {
+	command, args := s.Command, s.Args
+	if s.Sandbox != nil && s.Sandbox.DenyNetwork {
+		var err error
+		command, args, err = denyNetwork(command, args)
+		if err != nil {
+			return err
+		}
+	}
+
 	if s.Verbose {
 		text.Break(s.Output)
-		text.Description(s.Output, "Process command", fmt.Sprintf("%s %s", s.Command, strings.Join(s.Args, " ")))
+		text.Description(s.Output, "Process command", fmt.Sprintf("%s %s", command, strings.Join(args, " ")))
 	}
 
 	// Construct the command with given arguments and environment.
-	var cmd *exec.Cmd
+	ctx := s.Context
+	if ctx == nil {
+		ctx = context.Background()
+	}
 	if s.Timeout > 0 {
-		ctx, cancel := context.WithTimeout(context.Background(), s.Timeout)
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, s.Timeout)
 		defer cancel()
-		// gosec flagged this:
-		// G204 (CWE-78): Subprocess launched with variable
-		// Disabling as the variables come from trusted sources.
-		/* #nosec */
-		cmd = exec.CommandContext(ctx, s.Command, s.Args...)
-	} else {
-		// gosec flagged this:
-		// G204 (CWE-78): Subprocess launched with variable
-		// Disabling as the variables come from trusted sources.
-		/* #nosec */
-		cmd = exec.Command(s.Command, s.Args...)
 	}
+	// gosec flagged this:
+	// G204 (CWE-78): Subprocess launched with variable
+	// Disabling as the variables come from trusted sources.
+	/* #nosec */
+	cmd := exec.CommandContext(ctx, command, args...)
+	cmd.Dir = s.Dir
 	cmd.Env = append(os.Environ(), s.Env...)
+	if s.Sandbox != nil {
+		cmd.Env = append(s.Sandbox.filterEnv(os.Environ()), s.Env...)
+		setProcessGroup(cmd)
+	}
+	setCmdLine(cmd)
 
 	// Pipe the child process stdout and stderr to our own output writer.
 	var stdoutBuf, stderrBuf threadsafe.Buffer
@@ -107,12 +145,18 @@ func (s *Streaming) Exec() error {
 	}
 	if s.Verbose {
 		output = s.Output
+		if s.Prefix != "" {
+			output = &prefixWriter{out: s.Output, prefix: []byte(s.Prefix + " | ")}
+		}
 	}
 
 	cmd.Stdout = io.MultiWriter(output, &stdoutBuf)
 	cmd.Stderr = io.MultiWriter(output, &stderrBuf)
 
 	if err := cmd.Start(); err != nil {
+		if errors.Is(err, exec.ErrNotFound) {
+			return shellNotFoundErr(command, err)
+		}
 		return err
 	}
 
@@ -120,30 +164,39 @@ func (s *Streaming) Exec() error {
 	//
 	// NOTE: cmd.Process is nil until exec.Start() returns successfully.
 	s.Process = cmd.Process
+	if s.Sandbox != nil {
+		defer killProcessGroup(cmd.Process)
+	}
 
 	if err := cmd.Wait(); err != nil {
-		var ctx string
-		if stderrBuf.Len() > 0 {
-			if !s.Verbose {
-				ctx = fmt.Sprintf(":\n\n%s", strings.TrimSpace(stderrBuf.String()))
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return fmt.Errorf("error during execution process: %w", ctxErr)
+		}
+
+		// NOTE: The output was streamed as it was produced, but we also include
+		// the final lines in the error so that a failure can be diagnosed without
+		// scrolling back through (or re-running with --verbose) a long build.
+		var detail string
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			if desc := ExitCodeDescription(command, exitErr.ExitCode()); desc != "" {
+				detail = fmt.Sprintf(" (exit code %d: %s)", exitErr.ExitCode(), desc)
 			}
+		}
+		if stderrBuf.Len() > 0 {
+			detail += fmt.Sprintf(":\n\n%s", tail(stderrBuf.String(), ErrorTailLines))
 		} else {
 			// NOTE: Viceroy doesn't send errors to stderr but to stdout.
 			//
 			// We want to ensure the compilation errors sent to stdout are displayed
 			// regardless of whether the user has --verbose set.
-			//
-			// If --verbose is set, then all errors will be seen anyway.
-			// If --verbose isn't set, then we use text.Progress to constrain output.
-			//
-			// Meaning: only display stdoutBuf if NOT in verbose mode already.
 			var cmdOutput string
-			if !s.Verbose {
-				cmdOutput = "\n" + text.WrapIndent(stdoutBuf.String(), text.DefaultTextWidth, 5)
+			if stdoutBuf.Len() > 0 {
+				cmdOutput = "\n" + text.WrapIndent(tail(stdoutBuf.String(), ErrorTailLines), text.DefaultTextWidth, 5)
 			}
-			ctx = fmt.Sprintf(":%s\n\n%s", cmdOutput, err)
+			detail += fmt.Sprintf(":%s\n\n%s", cmdOutput, err)
 		}
-		return fmt.Errorf("error during execution process%s", ctx)
+		return fmt.Errorf("error during execution process%s", detail)
 	}
 
 	return nil
@@ -159,3 +212,46 @@ func (s *Streaming) Signal(sig os.Signal) error {
 	}
 	return nil
 }
+
+// tail returns the last n lines of output, noting how many lines were omitted.
+func tail(output string, n int) string {
+	lines := strings.Split(strings.TrimSpace(output), "\n")
+	if len(lines) <= n {
+		return strings.Join(lines, "\n")
+	}
+	omitted := len(lines) - n
+	return fmt.Sprintf("... (%d earlier lines omitted)\n%s", omitted, strings.Join(lines[omitted:], "\n"))
+}
+
+// prefixWriter writes a prefix at the start of every line it's given.
+//
+// NOTE: The writer is shared by the command's stdout and stderr, which are
+// copied to it concurrently, so writes are serialised.
+type prefixWriter struct {
+	mu      sync.Mutex
+	out     io.Writer
+	prefix  []byte
+	midLine bool
+}
+
+// Write implements the io.Writer interface.
+func (w *prefixWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	var buf bytes.Buffer
+	for _, line := range bytes.SplitAfter(p, []byte("\n")) {
+		if len(line) == 0 {
+			continue
+		}
+		if !w.midLine {
+			buf.Write(w.prefix)
+		}
+		buf.Write(line)
+		w.midLine = line[len(line)-1] != '\n'
+	}
+	if _, err := w.out.Write(buf.Bytes()); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
@@ -0,0 +1,89 @@
+package exec_test
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	fstexec "github.com/fastly/cli/pkg/exec"
+	"github.com/fastly/cli/pkg/testutil"
+)
+
+func TestStreamingPrefix(t *testing.T) {
+	var out bytes.Buffer
+	s := fstexec.Streaming{
+		Command: "sh",
+		Args:    []string{"-c", "echo one; echo two"},
+		Output:  &out,
+		Prefix:  "sh",
+		Verbose: true,
+	}
+	if err := s.Exec(); err != nil {
+		t.Fatal(err)
+	}
+	testutil.AssertStringContains(t, out.String(), "sh | one\nsh | two\n")
+}
+
+func TestStreamingErrorTail(t *testing.T) {
+	lines := fstexec.ErrorTailLines + 5
+	script := fmt.Sprintf("for i in $(seq 1 %d); do echo line $i >&2; done; exit 1", lines)
+
+	for _, verbose := range []bool{false, true} {
+		t.Run(fmt.Sprintf("verbose=%t", verbose), func(t *testing.T) {
+			var out bytes.Buffer
+			s := fstexec.Streaming{
+				Command: "sh",
+				Args:    []string{"-c", script},
+				Output:  &out,
+				Verbose: verbose,
+			}
+			err := s.Exec()
+			testutil.AssertErrorContains(t, err, "error during execution process")
+			testutil.AssertStringContains(t, err.Error(), "... (5 earlier lines omitted)")
+			testutil.AssertStringContains(t, err.Error(), fmt.Sprintf("line %d", lines))
+			if strings.Contains(err.Error(), "line 5\n") {
+				t.Errorf("want the earlier lines to be omitted, got: %s", err)
+			}
+		})
+	}
+}
+
+func TestStreamingContext(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	var out bytes.Buffer
+	s := fstexec.Streaming{
+		Command: "sleep",
+		Args:    []string{"10"},
+		Context: ctx,
+		Output:  &out,
+	}
+	started := time.Now()
+	err := s.Exec()
+	testutil.AssertErrorContains(t, err, "error during execution process: context deadline exceeded")
+	if time.Since(started) > 5*time.Second {
+		t.Fatal("want the command to be killed once the context is done")
+	}
+}
+
+func TestStreamingSandbox(t *testing.T) {
+	t.Setenv("FASTLY_API_TOKEN", "secret")
+	t.Setenv("FASTLY_TEST_ALLOWED", "allowed")
+
+	var out bytes.Buffer
+	s := fstexec.Streaming{
+		Command: "sh",
+		Args:    []string{"-c", `echo "token=$FASTLY_API_TOKEN allowed=$FASTLY_TEST_ALLOWED path=${PATH:+set}"`},
+		Output:  &out,
+		Sandbox: &fstexec.Sandbox{AllowEnv: []string{"FASTLY_TEST_ALLOWED"}},
+		Verbose: true,
+	}
+	if err := s.Exec(); err != nil {
+		t.Fatal(err)
+	}
+	testutil.AssertStringContains(t, out.String(), "token= allowed=allowed path=set\n")
+}
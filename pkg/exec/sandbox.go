@@ -0,0 +1,69 @@
+package exec
+
+import (
+	"strings"
+)
+
+// SandboxEnv are the environment variables passed to a sandboxed command, in
+// addition to those allowed by Sandbox.AllowEnv. They're needed for the shell
+// and the language toolchains to run.
+var SandboxEnv = []string{
+	"CARGO_HOME",
+	"COMSPEC",
+	"GOCACHE",
+	"GOMODCACHE",
+	"GOPATH",
+	"GOROOT",
+	"HOME",
+	"LANG",
+	"LC_ALL",
+	"PATH",
+	"PATHEXT",
+	"RUSTUP_HOME",
+	"RUSTUP_TOOLCHAIN",
+	"SHELL",
+	"SYSTEMROOT",
+	"TEMP",
+	"TERM",
+	"TMP",
+	"TMPDIR",
+	"USER",
+	"USERPROFILE",
+}
+
+// Sandbox restricts the environment a command runs in, to reduce the risk of
+// running a third-party script (e.g. the custom build script of a starter
+// kit).
+//
+// A sandboxed command runs in its own process group, which is killed once the
+// command exits so no background processes are left behind, and only receives
+// the allowed environment variables (so credentials such as FASTLY_API_TOKEN
+// aren't exposed).
+type Sandbox struct {
+	// AllowEnv are the names of additional environment variables passed to the
+	// command.
+	AllowEnv []string
+	// DenyNetwork runs the command without network access, using unshare on
+	// Linux and sandbox-exec on macOS.
+	DenyNetwork bool
+}
+
+// filterEnv returns the allowed environment variables.
+//
+// NOTE: Names are compared case-insensitively as Windows environment variable
+// names aren't case sensitive (e.g. Path).
+func (s *Sandbox) filterEnv(env []string) []string {
+	allowed := make(map[string]bool)
+	for _, name := range append(append([]string{}, SandboxEnv...), s.AllowEnv...) {
+		allowed[strings.ToUpper(name)] = true
+	}
+
+	var filtered []string
+	for _, kv := range env {
+		name, _, _ := strings.Cut(kv, "=")
+		if allowed[strings.ToUpper(name)] {
+			filtered = append(filtered, kv)
+		}
+	}
+	return filtered
+}
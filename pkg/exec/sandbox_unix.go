@@ -0,0 +1,40 @@
+//go:build !windows
+
+package exec
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"syscall"
+)
+
+// darwinDenyNetworkProfile is the sandbox-exec profile that denies network
+// access while allowing everything else.
+const darwinDenyNetworkProfile = "(version 1)(allow default)(deny network*)"
+
+// setProcessGroup runs the command in its own process group.
+func setProcessGroup(cmd *exec.Cmd) {
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+}
+
+// killProcessGroup kills any processes remaining in the process group.
+func killProcessGroup(p *os.Process) {
+	// NOTE: The group is usually empty by now, and so the error is ignored.
+	_ = syscall.Kill(-p.Pid, syscall.SIGKILL)
+}
+
+// denyNetwork wraps the command so that it runs without network access.
+func denyNetwork(command string, args []string) (string, []string, error) {
+	switch runtime.GOOS {
+	case "darwin":
+		return "sandbox-exec", append([]string{"-p", darwinDenyNetworkProfile, command}, args...), nil
+	case "linux":
+		// NOTE: A new network namespace only contains a loopback interface, and
+		// mapping the current user to root allows an unprivileged user to create
+		// one (where unprivileged user namespaces are enabled).
+		return "unshare", append([]string{"--net", "--map-root-user", "--", command}, args...), nil
+	}
+	return "", nil, fmt.Errorf("denying network access to a sandboxed command isn't supported on %s", runtime.GOOS)
+}
@@ -0,0 +1,22 @@
+package exec
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"syscall"
+)
+
+// setProcessGroup runs the command in its own process group.
+func setProcessGroup(cmd *exec.Cmd) {
+	cmd.SysProcAttr = &syscall.SysProcAttr{CreationFlags: syscall.CREATE_NEW_PROCESS_GROUP}
+}
+
+// killProcessGroup is a no-op as Windows doesn't support signalling a process
+// group.
+func killProcessGroup(_ *os.Process) {}
+
+// denyNetwork isn't supported on Windows.
+func denyNetwork(_ string, _ []string) (string, []string, error) {
+	return "", nil, errors.New("denying network access to a sandboxed command isn't supported on windows")
+}
@@ -0,0 +1,138 @@
+package exec
+
+import (
+	"encoding/base64"
+	"encoding/binary"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"unicode/utf16"
+
+	fsterr "github.com/fastly/cli/pkg/errors"
+	fstruntime "github.com/fastly/cli/pkg/runtime"
+)
+
+// Shells are the shells that custom scripts can be run in.
+var Shells = []string{"bash", "cmd", "powershell", "pwsh", "sh"}
+
+// DefaultShell returns the shell that custom scripts run in when one isn't
+// configured, which is cmd on Windows and sh everywhere else.
+func DefaultShell() string {
+	if fstruntime.Windows {
+		return "cmd"
+	}
+	return "sh"
+}
+
+// ValidateShell returns an error if the named shell isn't supported. An empty
+// name is valid, and selects the default shell.
+func ValidateShell(name string) error {
+	if name == "" {
+		return nil
+	}
+	for _, s := range Shells {
+		if s == name {
+			return nil
+		}
+	}
+	return fsterr.RemediationError{
+		Inner:       fmt.Errorf("unsupported shell: %s", name),
+		Remediation: fmt.Sprintf("Set [scripts.shell] in the fastly.toml manifest to one of: %s.", strings.Join(Shells, ", ")),
+	}
+}
+
+// ShellCommand returns the command and arguments that run the script in the
+// named shell. An empty (or unsupported) name selects the default shell.
+//
+// NOTE: The script is passed to PowerShell encoded, which avoids its
+// inconsistent handling of quotes within command line arguments, while cmd's
+// command line is passed as is when the command is run (see setCmdLine).
+func ShellCommand(name, script string) (command string, args []string) {
+	if ValidateShell(name) != nil || name == "" {
+		name = DefaultShell()
+	}
+
+	switch name {
+	case "cmd":
+		return "cmd.exe", []string{"/d", "/s", "/c", script}
+	case "powershell", "pwsh":
+		return name, []string{"-NoLogo", "-NoProfile", "-NonInteractive", "-EncodedCommand", encodePowerShell(script)}
+	default:
+		return name, []string{"-c", script}
+	}
+}
+
+// encodePowerShell encodes the script as PowerShell's -EncodedCommand flag
+// expects, i.e. base64 encoded UTF-16LE.
+func encodePowerShell(script string) string {
+	u := utf16.Encode([]rune(script))
+	b := make([]byte, len(u)*2)
+	for i, c := range u {
+		binary.LittleEndian.PutUint16(b[i*2:], c)
+	}
+	return base64.StdEncoding.EncodeToString(b)
+}
+
+// shellName returns the name of the shell that command is, or an empty string
+// if it isn't a supported shell.
+//
+// NOTE: Both path separators are handled, rather than using filepath.Base, so
+// that the result doesn't depend on the platform.
+func shellName(command string) string {
+	if i := strings.LastIndexAny(command, `/\`); i >= 0 {
+		command = command[i+1:]
+	}
+	name := strings.ToLower(strings.TrimSuffix(command, filepath.Ext(command)))
+	for _, s := range Shells {
+		if s == name {
+			return s
+		}
+	}
+	return ""
+}
+
+// ExitCodeDescription explains the exit code of the command, if it has a
+// well-known meaning, otherwise it returns an empty string.
+//
+// NOTE: Windows reports a process that crashed with an NTSTATUS code, which
+// isn't specific to the command.
+func ExitCodeDescription(command string, code int) string {
+	switch uint32(code) {
+	case 0xC0000005:
+		return "the process crashed with an access violation"
+	case 0xC000013A:
+		return "the process was interrupted (e.g. with Ctrl-C)"
+	case 0xC0000135:
+		return "a DLL required by the process wasn't found"
+	case 0xC0000409:
+		return "the process crashed with a stack buffer overrun"
+	}
+
+	switch shellName(command) {
+	case "sh", "bash":
+		switch code {
+		case 126:
+			return "a command in the script isn't executable"
+		case 127:
+			return "a command in the script wasn't found, check it's installed and on your PATH"
+		}
+	case "cmd":
+		switch code {
+		case 9009:
+			return "a command in the script wasn't recognised, check it's installed and on your PATH (cmd doesn't support POSIX shell syntax, see [scripts.shell] in the fastly.toml manifest)"
+		}
+	}
+	return ""
+}
+
+// shellNotFoundErr explains how to fix a shell that isn't installed.
+func shellNotFoundErr(command string, err error) error {
+	name := shellName(command)
+	if name == "" {
+		return err
+	}
+	return fsterr.RemediationError{
+		Inner:       err,
+		Remediation: fmt.Sprintf("Custom scripts run in %s, which wasn't found. Install it, or set [scripts.shell] in the fastly.toml manifest to one of: %s.", name, strings.Join(Shells, ", ")),
+	}
+}
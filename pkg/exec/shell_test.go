@@ -0,0 +1,135 @@
+package exec_test
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/binary"
+	"testing"
+	"unicode/utf16"
+
+	fstexec "github.com/fastly/cli/pkg/exec"
+	fstruntime "github.com/fastly/cli/pkg/runtime"
+	"github.com/fastly/cli/pkg/testutil"
+)
+
+func TestShellCommand(t *testing.T) {
+	script := `echo "hello world" && exit 1`
+
+	defaultCommand := "sh"
+	if fstruntime.Windows {
+		defaultCommand = "cmd.exe"
+	}
+
+	for _, testcase := range []struct {
+		name        string
+		shell       string
+		wantCommand string
+		wantArgs    []string
+	}{
+		{
+			name:        "default",
+			wantCommand: defaultCommand,
+		},
+		{
+			name:        "sh",
+			shell:       "sh",
+			wantCommand: "sh",
+			wantArgs:    []string{"-c", script},
+		},
+		{
+			name:        "bash",
+			shell:       "bash",
+			wantCommand: "bash",
+			wantArgs:    []string{"-c", script},
+		},
+		{
+			name:        "cmd",
+			shell:       "cmd",
+			wantCommand: "cmd.exe",
+			wantArgs:    []string{"/d", "/s", "/c", script},
+		},
+		{
+			name:        "unsupported",
+			shell:       "fish",
+			wantCommand: defaultCommand,
+		},
+	} {
+		t.Run(testcase.name, func(t *testing.T) {
+			command, args := fstexec.ShellCommand(testcase.shell, script)
+			testutil.AssertString(t, testcase.wantCommand, command)
+			if testcase.wantArgs != nil {
+				testutil.AssertEqual(t, testcase.wantArgs, args)
+			}
+			testutil.AssertString(t, script, args[len(args)-1])
+		})
+	}
+}
+
+func TestShellCommandPowerShell(t *testing.T) {
+	script := `Write-Output "héllo"; exit 1`
+
+	for _, shell := range []string{"powershell", "pwsh"} {
+		t.Run(shell, func(t *testing.T) {
+			command, args := fstexec.ShellCommand(shell, script)
+			testutil.AssertString(t, shell, command)
+			testutil.AssertEqual(t, []string{"-NoLogo", "-NoProfile", "-NonInteractive", "-EncodedCommand"}, args[:4])
+
+			b, err := base64.StdEncoding.DecodeString(args[4])
+			testutil.AssertNoError(t, err)
+			u := make([]uint16, len(b)/2)
+			for i := range u {
+				u[i] = binary.LittleEndian.Uint16(b[i*2:])
+			}
+			testutil.AssertString(t, script, string(utf16.Decode(u)))
+		})
+	}
+}
+
+func TestValidateShell(t *testing.T) {
+	for _, shell := range append([]string{""}, fstexec.Shells...) {
+		testutil.AssertNoError(t, fstexec.ValidateShell(shell))
+	}
+
+	err := fstexec.ValidateShell("fish")
+	testutil.AssertErrorContains(t, err, "unsupported shell: fish")
+	testutil.AssertRemediationErrorContains(t, err, "bash, cmd, powershell, pwsh, sh")
+}
+
+func TestExitCodeDescription(t *testing.T) {
+	for _, testcase := range []struct {
+		command string
+		code    int
+		want    string
+	}{
+		{command: "sh", code: 127, want: "wasn't found"},
+		{command: "/bin/bash", code: 126, want: "isn't executable"},
+		{command: `C:\Windows\System32\cmd.exe`, code: 9009, want: "wasn't recognised"},
+		{command: "cmd.exe", code: 127},
+		{command: "sh", code: 9009},
+		{command: "cargo", code: int(int32(-1073741515)), want: "DLL"},
+		{command: "sh", code: 1},
+	} {
+		got := fstexec.ExitCodeDescription(testcase.command, testcase.code)
+		if testcase.want == "" {
+			testutil.AssertString(t, "", got)
+			continue
+		}
+		testutil.AssertStringContains(t, got, testcase.want)
+	}
+}
+
+func TestStreamingExitCode(t *testing.T) {
+	if fstruntime.Windows {
+		t.Skip("requires a POSIX shell")
+	}
+
+	var out bytes.Buffer
+	command, args := fstexec.ShellCommand("sh", "fastly-test-nonexistent-command")
+	s := fstexec.Streaming{
+		Command: command,
+		Args:    args,
+		Output:  &out,
+	}
+	err := s.Exec()
+	testutil.AssertErrorContains(t, err, "exit code 127: a command in the script wasn't found")
+}
@@ -0,0 +1,9 @@
+//go:build !windows
+
+package exec
+
+import "os/exec"
+
+// setCmdLine is a no-op as the arguments of a command are passed as is on
+// unix.
+func setCmdLine(_ *exec.Cmd) {}
@@ -0,0 +1,26 @@
+package exec
+
+import (
+	"os/exec"
+	"strings"
+	"syscall"
+)
+
+// setCmdLine passes the command line of cmd.exe as is, as it doesn't follow
+// the quoting rules applied to the arguments of other commands, which would
+// otherwise break a script containing quotes (e.g. a quoted path).
+//
+// NOTE: The script is the last argument (see ShellCommand), which the /s flag
+// tells cmd.exe to take from within the outer quotes unchanged.
+func setCmdLine(cmd *exec.Cmd) {
+	if shellName(cmd.Path) != "cmd" || len(cmd.Args) < 2 {
+		return
+	}
+	if cmd.SysProcAttr == nil {
+		cmd.SysProcAttr = &syscall.SysProcAttr{}
+	}
+	args := cmd.Args[1:]
+	line := append([]string{syscall.EscapeArg(cmd.Path)}, args[:len(args)-1]...)
+	line = append(line, `"`+args[len(args)-1]+`"`)
+	cmd.SysProcAttr.CmdLine = strings.Join(line, " ")
+}
@@ -0,0 +1,3 @@
+// Package interrupt contains abstractions for handling a user interrupting
+// the CLI (e.g. with Ctrl-C) so that a command can clean up after itself.
+package interrupt
@@ -0,0 +1,379 @@
+package interrupt
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/fastly/cli/pkg/api"
+	fsterr "github.com/fastly/cli/pkg/errors"
+	"github.com/fastly/cli/pkg/text"
+)
+
+// ErrInterrupted means the user interrupted the command.
+var ErrInterrupted = errors.New("interrupted")
+
+// ErrTimeout means the command ran for longer than its timeout.
+var ErrTimeout = errors.New("timed out")
+
+// ExitCode is the conventional exit code for a process terminated by SIGINT.
+const ExitCode = int(fsterr.ExitInterrupted)
+
+// Handler cancels in-flight operations when the user interrupts the CLI, or
+// the command times out, while allowing a command to clean up any state it has
+// already changed.
+//
+// A nil Handler is valid and never interrupts anything.
+type Handler struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	mu       sync.Mutex
+	cleaning int
+	err      error
+	sigs     chan os.Signal
+}
+
+// New returns a Handler that hasn't been interrupted.
+func New() *Handler {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Handler{
+		ctx:    ctx,
+		cancel: cancel,
+	}
+}
+
+// Notify interrupts the handler when a SIGINT or SIGTERM is received. A
+// second signal exits the process immediately, in case the cleanup hangs.
+//
+// The returned function stops the handler from catching signals.
+func (h *Handler) Notify(out io.Writer) (stop func()) {
+	sigs := make(chan os.Signal, 1)
+	done := make(chan struct{})
+
+	h.mu.Lock()
+	h.sigs = sigs
+	h.mu.Unlock()
+
+	signal.Notify(sigs, syscall.SIGINT, syscall.SIGTERM)
+	go func() {
+		for {
+			select {
+			case <-sigs:
+				if h.interrupted() {
+					os.Exit(ExitCode)
+				}
+				text.Break(out)
+				text.Warning(out, "Interrupted, cleaning up (press Ctrl-C again to quit immediately).")
+				h.stop(ErrInterrupted)
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			h.Release()
+			close(done)
+		})
+	}
+}
+
+// Release stops the handler from catching signals, for commands that handle
+// an interrupt themselves as the normal way to stop (e.g. following a log
+// stream).
+func (h *Handler) Release() {
+	if h == nil {
+		return
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.sigs != nil {
+		signal.Stop(h.sigs)
+	}
+}
+
+// Interrupt cancels any in-flight operations, and causes new ones to fail.
+func (h *Handler) Interrupt() {
+	if h == nil {
+		return
+	}
+	h.stop(ErrInterrupted)
+}
+
+// Timeout interrupts the handler with ErrTimeout once d has elapsed, unless
+// the returned function is called first.
+func (h *Handler) Timeout(d time.Duration, out io.Writer) (stop func()) {
+	if h == nil || d <= 0 {
+		return func() {}
+	}
+	t := time.AfterFunc(d, func() {
+		if h.interrupted() {
+			return
+		}
+		text.Break(out)
+		text.Warning(out, "Timed out after %s, cleaning up.", d)
+		h.stop(ErrTimeout)
+	})
+	return func() {
+		t.Stop()
+	}
+}
+
+// stop records why the handler was interrupted and cancels its context.
+func (h *Handler) stop(err error) {
+	h.mu.Lock()
+	if h.err == nil {
+		h.err = err
+	}
+	h.mu.Unlock()
+	h.cancel()
+}
+
+// Context returns a context that's cancelled when the handler is interrupted,
+// e.g. for running a subprocess that should be killed on interrupt.
+func (h *Handler) Context() context.Context {
+	if h == nil {
+		return context.Background()
+	}
+	return h.ctx
+}
+
+// Done returns a channel that's closed when the handler is interrupted.
+func (h *Handler) Done() <-chan struct{} {
+	if h == nil {
+		return nil
+	}
+	return h.ctx.Done()
+}
+
+// Err returns ErrInterrupted or ErrTimeout if the handler has been
+// interrupted, unless it's running a cleanup function.
+func (h *Handler) Err() error {
+	if h == nil {
+		return nil
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.cleaning == 0 {
+		return h.err
+	}
+	return nil
+}
+
+// reason returns why the handler was interrupted, even while it's running a
+// cleanup function.
+func (h *Handler) reason() error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.err
+}
+
+// Cleanup runs fn, allowing it to make requests even if the handler has been
+// interrupted, e.g. to undo the changes made by an interrupted command.
+func (h *Handler) Cleanup(fn func()) {
+	if h == nil {
+		fn()
+		return
+	}
+	h.mu.Lock()
+	h.cleaning++
+	h.mu.Unlock()
+
+	defer func() {
+		h.mu.Lock()
+		h.cleaning--
+		h.mu.Unlock()
+	}()
+
+	fn()
+}
+
+// interrupted indicates whether the handler has been interrupted.
+func (h *Handler) interrupted() bool {
+	return h.ctx.Err() != nil
+}
+
+// Transport wraps rt so that its requests are cancelled when the handler is
+// interrupted. A nil rt wraps http.DefaultTransport.
+func (h *Handler) Transport(rt http.RoundTripper) http.RoundTripper {
+	if h == nil {
+		return rt
+	}
+	if rt == nil {
+		rt = http.DefaultTransport
+	}
+	return &transport{h: h, rt: rt}
+}
+
+// Client wraps c so that its requests are cancelled when the handler is
+// interrupted.
+func (h *Handler) Client(c api.HTTPClient) api.HTTPClient {
+	if h == nil || c == nil {
+		return c
+	}
+	return &client{h: h, c: c}
+}
+
+// Reader wraps r so that a blocked read (e.g. waiting on a prompt) returns an
+// error when the handler is interrupted.
+func (h *Handler) Reader(r io.Reader) io.Reader {
+	if h == nil || r == nil {
+		return r
+	}
+	rd := &reader{h: h, r: r}
+	if f, ok := r.(*os.File); ok {
+		return &fileReader{reader: rd, f: f}
+	}
+	return rd
+}
+
+// transport is a http.RoundTripper that cancels requests on interrupt.
+type transport struct {
+	h  *Handler
+	rt http.RoundTripper
+}
+
+// RoundTrip implements the http.RoundTripper interface.
+func (t *transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	return t.h.do(req, t.rt.RoundTrip)
+}
+
+// client is an api.HTTPClient that cancels requests on interrupt.
+type client struct {
+	h *Handler
+	c api.HTTPClient
+}
+
+// Do implements the api.HTTPClient interface.
+func (c *client) Do(req *http.Request) (*http.Response, error) {
+	return c.h.do(req, c.c.Do)
+}
+
+// do makes the request with a context that's cancelled on interrupt.
+//
+// NOTE: Requests made by a cleanup function aren't cancelled, as the handler
+// has already been interrupted by the time they're made.
+func (h *Handler) do(req *http.Request, fn func(*http.Request) (*http.Response, error)) (*http.Response, error) {
+	h.mu.Lock()
+	cleaning := h.cleaning > 0
+	h.mu.Unlock()
+	if cleaning {
+		return fn(req)
+	}
+	if h.interrupted() {
+		return nil, h.reason()
+	}
+
+	ctx, cancel := context.WithCancel(req.Context())
+	stop := make(chan struct{})
+	go func() {
+		select {
+		case <-h.ctx.Done():
+			cancel()
+		case <-stop:
+		}
+	}()
+
+	var once sync.Once
+	release := func() {
+		once.Do(func() {
+			close(stop)
+			cancel()
+		})
+	}
+
+	resp, err := fn(req.WithContext(ctx))
+	if err != nil {
+		release()
+		if h.interrupted() {
+			return nil, h.reason()
+		}
+		return nil, err
+	}
+	resp.Body = &body{ReadCloser: resp.Body, release: release}
+	return resp, nil
+}
+
+// body releases the request's context once the response body is closed.
+type body struct {
+	io.ReadCloser
+	release func()
+}
+
+// Close implements the io.Closer interface.
+func (b *body) Close() error {
+	err := b.ReadCloser.Close()
+	b.release()
+	return err
+}
+
+// reader is an io.Reader whose reads return early on interrupt.
+//
+// NOTE: The underlying read can't be cancelled, so it's made by a goroutine
+// into a buffer owned by the reader, and a read that returns early leaves the
+// goroutine blocked until input arrives (or the process exits).
+type reader struct {
+	h       *Handler
+	r       io.Reader
+	buf     []byte
+	pending chan readResult
+}
+
+// readResult is the outcome of a read of the underlying reader.
+type readResult struct {
+	b   []byte
+	err error
+}
+
+// Read implements the io.Reader interface.
+func (r *reader) Read(p []byte) (int, error) {
+	if len(r.buf) > 0 {
+		n := copy(p, r.buf)
+		r.buf = r.buf[n:]
+		return n, nil
+	}
+	if err := r.h.Err(); err != nil {
+		return 0, err
+	}
+
+	if r.pending == nil {
+		ch := make(chan readResult, 1)
+		b := make([]byte, len(p))
+		go func() {
+			n, err := r.r.Read(b)
+			ch <- readResult{b: b[:n], err: err}
+		}()
+		r.pending = ch
+	}
+
+	select {
+	case res := <-r.pending:
+		r.pending = nil
+		n := copy(p, res.b)
+		r.buf = res.b[n:]
+		return n, res.err
+	case <-r.h.Done():
+		return 0, r.h.reason()
+	}
+}
+
+// fileReader is a reader of a file, which exposes the file descriptor so that
+// a terminal can still be detected (e.g. to read a password without echo).
+type fileReader struct {
+	*reader
+	f *os.File
+}
+
+// Fd returns the file descriptor of the underlying file.
+func (r *fileReader) Fd() uintptr {
+	return r.f.Fd()
+}
@@ -0,0 +1,147 @@
+package interrupt_test
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/fastly/cli/pkg/interrupt"
+	"github.com/fastly/cli/pkg/testutil"
+)
+
+func TestTransport(t *testing.T) {
+	started := make(chan struct{}, 1)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/block" {
+			started <- struct{}{}
+			<-r.Context().Done()
+			return
+		}
+		_, _ = io.WriteString(w, "ok")
+	}))
+	defer srv.Close()
+
+	h := interrupt.New()
+	c := &http.Client{Transport: h.Transport(nil)}
+
+	resp, err := c.Get(srv.URL)
+	testutil.AssertNoError(t, err)
+	body, err := io.ReadAll(resp.Body)
+	testutil.AssertNoError(t, err)
+	testutil.AssertNoError(t, resp.Body.Close())
+	testutil.AssertString(t, "ok", string(body))
+
+	errc := make(chan error, 1)
+	go func() {
+		_, err := c.Get(srv.URL + "/block")
+		errc <- err
+	}()
+	<-started
+	h.Interrupt()
+
+	select {
+	case err := <-errc:
+		if !errors.Is(err, interrupt.ErrInterrupted) {
+			t.Fatalf("want %v, have %v", interrupt.ErrInterrupted, err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("in-flight request wasn't cancelled")
+	}
+
+	_, err = c.Get(srv.URL)
+	if !errors.Is(err, interrupt.ErrInterrupted) {
+		t.Fatalf("want %v, have %v", interrupt.ErrInterrupted, err)
+	}
+
+	h.Cleanup(func() {
+		resp, err := c.Get(srv.URL)
+		testutil.AssertNoError(t, err)
+		testutil.AssertNoError(t, resp.Body.Close())
+		testutil.AssertNoError(t, h.Err())
+	})
+	if !errors.Is(h.Err(), interrupt.ErrInterrupted) {
+		t.Fatalf("want %v, have %v", interrupt.ErrInterrupted, h.Err())
+	}
+}
+
+func TestReader(t *testing.T) {
+	h := interrupt.New()
+	pr, pw := io.Pipe()
+	r := h.Reader(pr)
+
+	go func() {
+		_, _ = io.WriteString(pw, "hello")
+	}()
+	b := make([]byte, 3)
+	n, err := r.Read(b)
+	testutil.AssertNoError(t, err)
+	testutil.AssertString(t, "hel", string(b[:n]))
+	n, err = r.Read(b)
+	testutil.AssertNoError(t, err)
+	testutil.AssertString(t, "lo", string(b[:n]))
+
+	errc := make(chan error, 1)
+	go func() {
+		_, err := r.Read(b)
+		errc <- err
+	}()
+	h.Interrupt()
+
+	select {
+	case err := <-errc:
+		if !errors.Is(err, interrupt.ErrInterrupted) {
+			t.Fatalf("want %v, have %v", interrupt.ErrInterrupted, err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("blocked read wasn't interrupted")
+	}
+}
+
+func TestNilHandler(t *testing.T) {
+	var h *interrupt.Handler
+
+	r := strings.NewReader("")
+	if h.Reader(r) != io.Reader(r) {
+		t.Fatal("want the reader to be unwrapped")
+	}
+	if h.Transport(http.DefaultTransport) != http.DefaultTransport {
+		t.Fatal("want the transport to be unwrapped")
+	}
+
+	var ran bool
+	h.Cleanup(func() { ran = true })
+	testutil.AssertBool(t, true, ran)
+	h.Interrupt()
+	h.Release()
+	testutil.AssertNoError(t, h.Err())
+}
+
+func TestTimeout(t *testing.T) {
+	var out bytes.Buffer
+	h := interrupt.New()
+	stop := h.Timeout(10*time.Millisecond, &out)
+	defer stop()
+
+	select {
+	case <-h.Done():
+	case <-time.After(5 * time.Second):
+		t.Fatal("handler didn't time out")
+	}
+	if !errors.Is(h.Err(), interrupt.ErrTimeout) {
+		t.Fatalf("want %v, have %v", interrupt.ErrTimeout, h.Err())
+	}
+	testutil.AssertStringContains(t, out.String(), "Timed out after 10ms")
+	if h.Context().Err() == nil {
+		t.Fatal("want the context to be cancelled")
+	}
+
+	// NOTE: A timeout that's stopped doesn't interrupt the handler.
+	h = interrupt.New()
+	h.Timeout(time.Hour, &out)()
+	testutil.AssertNoError(t, h.Err())
+}
@@ -0,0 +1,3 @@
+// Package logger contains the CLI's internal logger, which records diagnostic
+// messages to a file or stderr, separately from the output of a command.
+package logger
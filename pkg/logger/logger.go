@@ -0,0 +1,226 @@
+package logger
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Level is the severity of a log message.
+type Level int
+
+// The levels of log message, in increasing severity.
+const (
+	LevelDebug Level = iota
+	LevelInfo
+	LevelWarn
+	// LevelOff disables logging.
+	LevelOff
+)
+
+// LevelNames are the values accepted by the --log-level flag, in increasing
+// severity.
+var LevelNames = []string{"debug", "info", "warn", "off"}
+
+// String implements the fmt.Stringer interface.
+func (l Level) String() string {
+	if l < LevelDebug || l > LevelOff {
+		return fmt.Sprintf("level(%d)", int(l))
+	}
+	return LevelNames[l]
+}
+
+// ParseLevel returns the Level of the given name (see LevelNames).
+func ParseLevel(name string) (Level, error) {
+	for i, n := range LevelNames {
+		if strings.EqualFold(n, name) {
+			return Level(i), nil
+		}
+	}
+	return LevelOff, fmt.Errorf("invalid log level '%s' (valid levels: %s)", name, strings.Join(LevelNames, ", "))
+}
+
+// Logger writes log messages at or above its level as logfmt lines, e.g.
+//
+//	time=2023-01-02T15:04:05.000Z level=debug msg="api request" method=GET status=200
+//
+// A Logger is safe for concurrent use.
+type Logger struct {
+	mtx    sync.Mutex
+	w      io.Writer
+	level  Level
+	closer io.Closer
+}
+
+// New returns a Logger that writes the messages at or above level to w.
+func New(w io.Writer, level Level) *Logger {
+	return &Logger{w: w, level: level}
+}
+
+// Open returns a Logger that appends the messages at or above level to the
+// file at path, which is created if necessary. An empty path writes to
+// stderr. The returned Logger must be closed.
+func Open(path string, level Level) (*Logger, error) {
+	if level == LevelOff {
+		return New(io.Discard, level), nil
+	}
+	if path == "" {
+		return New(os.Stderr, level), nil
+	}
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600) // #nosec G304 (CWE-22)
+	if err != nil {
+		return nil, fmt.Errorf("error opening log file: %w", err)
+	}
+	l := New(f, level)
+	l.closer = f
+	return l, nil
+}
+
+// Close closes the log file opened by Open.
+func (l *Logger) Close() error {
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+	if l.closer == nil {
+		return nil
+	}
+	err := l.closer.Close()
+	l.closer = nil
+	l.w = io.Discard
+	return err
+}
+
+// Enabled reports whether messages of the given level are written.
+func (l *Logger) Enabled(level Level) bool {
+	return level >= l.level && l.level != LevelOff
+}
+
+// Debug logs a message for diagnosing problems, with alternating key/value
+// pairs of contextual data.
+func (l *Logger) Debug(msg string, kv ...any) {
+	l.log(LevelDebug, msg, kv)
+}
+
+// Info logs a message about the normal operation of the CLI, with alternating
+// key/value pairs of contextual data.
+func (l *Logger) Info(msg string, kv ...any) {
+	l.log(LevelInfo, msg, kv)
+}
+
+// Warn logs a message about an unexpected but handled problem, with
+// alternating key/value pairs of contextual data.
+func (l *Logger) Warn(msg string, kv ...any) {
+	l.log(LevelWarn, msg, kv)
+}
+
+func (l *Logger) log(level Level, msg string, kv []any) {
+	if !l.Enabled(level) {
+		return
+	}
+
+	var b strings.Builder
+	b.WriteString("time=")
+	b.WriteString(time.Now().UTC().Format("2006-01-02T15:04:05.000Z07:00"))
+	b.WriteString(" level=")
+	b.WriteString(level.String())
+	b.WriteString(" msg=")
+	b.WriteString(quote(msg))
+	for i := 0; i < len(kv); i += 2 {
+		key := fmt.Sprint(kv[i])
+		var value any = "MISSING"
+		if i+1 < len(kv) {
+			value = kv[i+1]
+		}
+		b.WriteByte(' ')
+		b.WriteString(key)
+		b.WriteByte('=')
+		b.WriteString(quote(fmt.Sprint(value)))
+	}
+	b.WriteByte('\n')
+
+	// NOTE: Each message is written with a single call, so that the messages
+	// of concurrent goroutines aren't interleaved.
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+	_, _ = io.WriteString(l.w, b.String())
+}
+
+// quote quotes the value of a logfmt key when it's empty or contains spaces,
+// quotes or equals signs.
+func quote(s string) string {
+	if s == "" || strings.ContainsAny(s, " \t\r\n\"=") {
+		return strconv.Quote(s)
+	}
+	return s
+}
+
+// Transport wraps rt so that its requests are logged at the debug level, and
+// failed requests at the warn level. A nil rt wraps http.DefaultTransport.
+//
+// NOTE: Request headers, which include the API token, aren't logged.
+func (l *Logger) Transport(rt http.RoundTripper) http.RoundTripper {
+	if rt == nil {
+		rt = http.DefaultTransport
+	}
+	return &transport{l: l, rt: rt}
+}
+
+type transport struct {
+	l  *Logger
+	rt http.RoundTripper
+}
+
+// RoundTrip implements the http.RoundTripper interface.
+func (t *transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	start := time.Now()
+	resp, err := t.rt.RoundTrip(req)
+	elapsed := time.Since(start).Round(time.Millisecond)
+	if err != nil {
+		t.l.Warn("api request failed", "method", req.Method, "url", req.URL.Redacted(), "duration", elapsed, "error", err)
+		return resp, err
+	}
+	t.l.Debug("api request", "method", req.Method, "url", req.URL.Redacted(), "status", resp.StatusCode, "duration", elapsed)
+	return resp, nil
+}
+
+// Discard is a Logger that discards every message.
+var Discard = New(io.Discard, LevelOff)
+
+var std atomic.Pointer[Logger]
+
+func init() {
+	std.Store(Discard)
+}
+
+// Default returns the Logger used by the package-level functions.
+func Default() *Logger {
+	return std.Load()
+}
+
+// SetDefault sets the Logger used by the package-level functions, returning
+// the previous Logger.
+//
+// NOTE: This is set from the global --log-level and --log-file flags.
+func SetDefault(l *Logger) *Logger {
+	return std.Swap(l)
+}
+
+// Debug logs a message to the default Logger (see Logger.Debug).
+func Debug(msg string, kv ...any) {
+	Default().Debug(msg, kv...)
+}
+
+// Info logs a message to the default Logger (see Logger.Info).
+func Info(msg string, kv ...any) {
+	Default().Info(msg, kv...)
+}
+
+// Warn logs a message to the default Logger (see Logger.Warn).
+func Warn(msg string, kv ...any) {
+	Default().Warn(msg, kv...)
+}
@@ -0,0 +1,89 @@
+package logger_test
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/fastly/cli/pkg/logger"
+	"github.com/fastly/cli/pkg/testutil"
+)
+
+func TestLogger(t *testing.T) {
+	for _, testcase := range []struct {
+		name     string
+		level    logger.Level
+		wantLogs []string
+	}{
+		{
+			name:  "debug",
+			level: logger.LevelDebug,
+			wantLogs: []string{
+				`level=debug msg=starting command=deploy`,
+				`level=info msg="uploading package" path="pkg/my app.tar.gz" size=1024`,
+				`level=warn msg=retrying error="connection reset" key=MISSING`,
+			},
+		},
+		{
+			name:  "warn",
+			level: logger.LevelWarn,
+			wantLogs: []string{
+				`level=warn msg=retrying error="connection reset" key=MISSING`,
+			},
+		},
+		{
+			name:  "off",
+			level: logger.LevelOff,
+		},
+	} {
+		t.Run(testcase.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			l := logger.New(&buf, testcase.level)
+			l.Debug("starting", "command", "deploy")
+			l.Info("uploading package", "path", "pkg/my app.tar.gz", "size", 1024)
+			l.Warn("retrying", "error", errors.New("connection reset"), "key")
+
+			lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+			if buf.Len() == 0 {
+				lines = nil
+			}
+			testutil.AssertEqual(t, len(testcase.wantLogs), len(lines))
+			for i, s := range testcase.wantLogs {
+				testutil.AssertStringContains(t, lines[i], s)
+			}
+		})
+	}
+}
+
+func TestParseLevel(t *testing.T) {
+	level, err := logger.ParseLevel("INFO")
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, logger.LevelInfo, level)
+
+	_, err = logger.ParseLevel("trace")
+	testutil.AssertErrorContains(t, err, "invalid log level 'trace'")
+}
+
+func TestTransport(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer srv.Close()
+
+	var buf bytes.Buffer
+	l := logger.New(&buf, logger.LevelDebug)
+	c := &http.Client{Transport: l.Transport(nil)}
+
+	req, err := http.NewRequest(http.MethodGet, srv.URL+"/service", nil)
+	testutil.AssertNoError(t, err)
+	req.Header.Set("Fastly-Key", "123")
+	resp, err := c.Do(req)
+	testutil.AssertNoError(t, err)
+	testutil.AssertNoError(t, resp.Body.Close())
+
+	testutil.AssertStringContains(t, buf.String(), `msg="api request" method=GET url=`+srv.URL+`/service status=404`)
+	testutil.AssertStringDoesntContain(t, buf.String(), "123")
+}
@@ -6,6 +6,8 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"path/filepath"
+	"sort"
 	"strconv"
 	"strings"
 
@@ -200,16 +202,21 @@ func (v *Version) UnmarshalText(txt []byte) error {
 // File represents all of the configuration parameters in the fastly.toml
 // manifest file schema.
 type File struct {
-	Authors         []string    `toml:"authors"`
-	Description     string      `toml:"description"`
-	Language        string      `toml:"language"`
-	Profile         string      `toml:"profile,omitempty"`
-	LocalServer     LocalServer `toml:"local_server,omitempty"`
-	ManifestVersion Version     `toml:"manifest_version"`
-	Name            string      `toml:"name"`
-	Scripts         Scripts     `toml:"scripts,omitempty"`
-	ServiceID       string      `toml:"service_id"`
-	Setup           Setup       `toml:"setup,omitempty"`
+	Authors         []string    `toml:"authors" description:"The package authors"`
+	Build           Build       `toml:"build,omitempty" description:"Arguments appended to the built-in build of each language"`
+	CLI             Settings    `toml:"cli,omitempty" description:"Default global flags for commands run within the project, overridden by the .fastlyrc file"`
+	Description     string      `toml:"description" description:"A description of the package"`
+	Language        string      `toml:"language" description:"The programming language of the package (e.g. rust, javascript)"`
+	Profile         string      `toml:"profile,omitempty" description:"The CLI account profile to use for this package"`
+	LocalServer     LocalServer `toml:"local_server,omitempty" description:"Resources mocked by the local testing server"`
+	ManifestVersion Version     `toml:"manifest_version" description:"The fastly.toml schema version"`
+	Modules         Modules     `toml:"modules,omitempty" description:"Additional wasm modules packaged alongside main.wasm, keyed by module name"`
+	Name            string      `toml:"name" description:"The package name"`
+	Scripts         Scripts     `toml:"scripts,omitempty" description:"Custom build and deploy operations"`
+	ServiceID       string      `toml:"service_id" description:"The ID of the Fastly service the package is deployed to"`
+	Setup           Setup       `toml:"setup,omitempty" description:"Resources created with a new service"`
+	Template        Template    `toml:"template,omitempty" description:"The package template the project was created from"`
+	Tools           Tools       `toml:"tools,omitempty" description:"Versions of the auxiliary tools (e.g. viceroy) pinned by the project, keyed by tool name"`
 
 	errLog    fsterr.LogInterface
 	exists    bool
@@ -219,59 +226,124 @@ type File struct {
 
 // Scripts represents custom operations.
 type Scripts struct {
-	Build     string `toml:"build,omitempty"`
-	PostBuild string `toml:"post_build,omitempty"`
+	Build          string   `toml:"build,omitempty" description:"A command that builds the package"`
+	EnvVars        []string `toml:"env_vars,omitempty" description:"Environment variables (KEY=value) set for the build, the local server and the post_deploy command"`
+	PackageManager string   `toml:"package_manager,omitempty" description:"The JavaScript package manager (npm, pnpm or yarn), otherwise detected from the lockfile"`
+	PostBuild      string   `toml:"post_build,omitempty" description:"A command run after the package is built"`
+	PostDeploy     string   `toml:"post_deploy,omitempty" description:"A command run after the package is deployed and activated, with FASTLY_SERVICE_ID, FASTLY_SERVICE_VERSION and FASTLY_DOMAINS set"`
+	Shell          string   `toml:"shell,omitempty" description:"The shell the build, post_build and post_deploy commands run in (bash, cmd, powershell, pwsh or sh), otherwise cmd on Windows and sh elsewhere"`
+}
+
+// Template represents the package template a project was created from, which
+// `compute init --sync-template` merges updates from.
+type Template struct {
+	Source string `toml:"source,omitempty" description:"The git repository URL of the package template"`
+	Branch string `toml:"branch,omitempty" description:"The git branch the package template is synced from"`
+	Tag    string `toml:"tag,omitempty" description:"The git tag the package template is synced from"`
+	Commit string `toml:"commit,omitempty" description:"The commit of the package template the project was last synced with"`
+}
+
+// Modules represents the additional named wasm modules of a package.
+type Modules map[string]Module
+
+// Module represents an additional wasm module built by the project.
+type Module struct {
+	Path string `toml:"path" description:"The path of the built wasm module, relative to the project directory"`
+}
+
+// Names returns the module names in sorted order.
+func (m Modules) Names() []string {
+	names := make([]string, 0, len(m))
+	for name := range m {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Tools represents the versions of the auxiliary tools pinned by the project
+// (see `fastly tools pin`), keyed by tool name.
+type Tools map[string]ToolPin
+
+// ToolPin represents a pinned version of a tool.
+//
+// NOTE: The checksums are keyed by platform (e.g. linux-amd64) as each
+// platform downloads a different binary.
+type ToolPin struct {
+	Version   string            `toml:"version" description:"The pinned version of the tool"`
+	Checksums map[string]string `toml:"checksums,omitempty" description:"The SHA-256 checksums of the tool binary, keyed by platform (e.g. linux-amd64), verified when the tool is installed"`
+}
+
+// Build represents the arguments appended to the built-in build command of
+// each language, which are ignored when [scripts.build] is set.
+//
+// NOTE: The tables are named after the language, e.g. [build.rust], as the
+// top-level language key is already used for the language name.
+type Build struct {
+	Go   BuildGo   `toml:"go,omitempty" description:"Arguments for the Go build"`
+	Rust BuildRust `toml:"rust,omitempty" description:"Arguments for the Rust build"`
+}
+
+// BuildGo represents the '[build.go]' arguments.
+type BuildGo struct {
+	GoFlags     []string `toml:"go_flags,omitempty" description:"Arguments appended to the go build command, when go is the configured compiler"`
+	TinyGoFlags []string `toml:"tinygo_flags,omitempty" description:"Arguments appended to the tinygo build command"`
+}
+
+// BuildRust represents the '[build.rust]' arguments.
+type BuildRust struct {
+	CargoFlags []string `toml:"cargo_flags,omitempty" description:"Arguments appended to the cargo build command (e.g. --features)"`
 }
 
 // Setup represents a set of service configuration that works with the code in
 // the package. See https://developer.fastly.com/reference/fastly-toml/.
 type Setup struct {
-	Backends     map[string]*SetupBackend    `toml:"backends,omitempty"`
-	Dictionaries map[string]*SetupDictionary `toml:"dictionaries,omitempty"`
-	Loggers      map[string]*SetupLogger     `toml:"log_endpoints,omitempty"`
+	Backends     map[string]*SetupBackend    `toml:"backends,omitempty" description:"Backends to create, keyed by name"`
+	Dictionaries map[string]*SetupDictionary `toml:"dictionaries,omitempty" description:"Dictionaries to create, keyed by name"`
+	Loggers      map[string]*SetupLogger     `toml:"log_endpoints,omitempty" description:"Logging endpoints to create, keyed by name"`
 }
 
 // SetupBackend represents a '[setup.backends.<T>]' instance.
 type SetupBackend struct {
-	Address     string `toml:"address,omitempty"`
-	Port        uint   `toml:"port,omitempty"`
-	Description string `toml:"description,omitempty"`
+	Address     string `toml:"address,omitempty" description:"The default hostname or IP address of the backend"`
+	Port        uint   `toml:"port,omitempty" description:"The default port of the backend"`
+	Description string `toml:"description,omitempty" description:"Displayed when prompting for the backend"`
 }
 
 // SetupDictionary represents a '[setup.dictionaries.<T>]' instance.
 type SetupDictionary struct {
-	Items       map[string]SetupDictionaryItems `toml:"items,omitempty"`
-	Description string                          `toml:"description,omitempty"`
+	Items       map[string]SetupDictionaryItems `toml:"items,omitempty" description:"Dictionary items to create, keyed by item key"`
+	Description string                          `toml:"description,omitempty" description:"Displayed when prompting for the dictionary"`
 }
 
 // SetupDictionaryItems represents a '[setup.dictionaries.<T>.items]' instance.
 type SetupDictionaryItems struct {
-	Value       string `toml:"value,omitempty"`
-	Description string `toml:"description,omitempty"`
+	Value       string `toml:"value,omitempty" description:"The default value of the item"`
+	Description string `toml:"description,omitempty" description:"Displayed when prompting for the item value"`
 }
 
 // SetupLogger represents a '[setup.log_endpoints.<T>]' instance.
 type SetupLogger struct {
-	Provider string `toml:"provider,omitempty"`
+	Provider string `toml:"provider,omitempty" description:"The logging provider (e.g. BigQuery)"`
 }
 
 // LocalServer represents a list of mocked Viceroy resources.
 type LocalServer struct {
-	Backends     map[string]LocalBackend    `toml:"backends"`
-	Dictionaries map[string]LocalDictionary `toml:"dictionaries,omitempty"`
+	Backends     map[string]LocalBackend    `toml:"backends" description:"Backends to mock, keyed by name"`
+	Dictionaries map[string]LocalDictionary `toml:"dictionaries,omitempty" description:"Dictionaries to mock, keyed by name"`
 }
 
 // LocalBackend represents a backend to be mocked by the local testing server.
 type LocalBackend struct {
-	URL          string `toml:"url"`
-	OverrideHost string `toml:"override_host,omitempty"`
+	URL          string `toml:"url" description:"The URL requests to the backend are sent to"`
+	OverrideHost string `toml:"override_host,omitempty" description:"The Host header to send to the backend"`
 }
 
 // LocalDictionary represents a dictionary to be mocked by the local testing server.
 type LocalDictionary struct {
-	File     string            `toml:"file,omitempty"`
-	Format   string            `toml:"format"`
-	Contents map[string]string `toml:"contents,omitempty"`
+	File     string            `toml:"file,omitempty" description:"A file containing the dictionary contents"`
+	Format   string            `toml:"format" description:"The format of the dictionary (e.g. inline-toml, json)"`
+	Contents map[string]string `toml:"contents,omitempty" description:"The dictionary contents when the format is inline-toml"`
 }
 
 // Exists yields whether the manifest exists.
@@ -295,6 +367,26 @@ func (f *File) ReadError() error {
 	return f.readError
 }
 
+// Find returns the directory of the nearest manifest file, searching dir and
+// then each of its parents in turn (like git does for a repository), or an
+// empty string if there is no manifest.
+func Find(dir string) (string, error) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return "", err
+	}
+	for {
+		if fi, err := os.Stat(filepath.Join(dir, Filename)); err == nil && !fi.IsDir() {
+			return dir, nil
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", nil
+		}
+		dir = parent
+	}
+}
+
 // SetErrLog sets an instance of errors.LogInterface.
 func (f *File) SetErrLog(errLog fsterr.LogInterface) {
 	f.errLog = errLog
@@ -387,8 +479,6 @@ func (f *File) Read(path string) (err error) {
 // supported and only if there is no [setup] configuration defined.
 //
 // NOTE: It contains similar conversions to the custom Version.UnmarshalText().
-// Specifically, it type switches the any into various types before
-// attempting to convert the underlying value into an integer.
 func (f *File) AutoMigrateVersion(data []byte, path string) ([]byte, error) {
 	tree, err := toml.LoadBytes(data)
 	if err != nil {
@@ -408,26 +498,9 @@ func (f *File) AutoMigrateVersion(data []byte, path string) ([]byte, error) {
 
 	setup := tree.GetArray("setup")
 
-	var version int
-	switch v := i.(type) {
-	case int64:
-		version = int(v)
-	case float64:
-		version = int(v)
-	case string:
-		if strings.Contains(v, ".") {
-			// Presumes semver value (e.g. 1.0.0, 0.1.0 or 0.1)
-			// Major is converted to integer if != zero.
-			// Otherwise if Major == zero, then ignore Minor/Patch and set to latest version.
-			segs := strings.Split(v, ".")
-			v = segs[0]
-		}
-		version, err = strconv.Atoi(v)
-		if err != nil {
-			return data, fmt.Errorf("error parsing manifest_version: %w", err)
-		}
-	default:
-		return data, fmt.Errorf("error parsing manifest_version: unrecognised type")
+	version, err := parseVersion(i)
+	if err != nil {
+		return data, err
 	}
 
 	// User is on the latest version supported by the CLI, so we'll return the
@@ -474,6 +547,34 @@ func (f *File) AutoMigrateVersion(data []byte, path string) ([]byte, error) {
 	return data, fsterr.ErrIncompatibleManifestVersion
 }
 
+// parseVersion converts a manifest_version value decoded from toml into an
+// integer.
+//
+// NOTE: It type switches the any into various types before attempting to
+// convert the underlying value into an integer.
+func parseVersion(i any) (int, error) {
+	switch v := i.(type) {
+	case int64:
+		return int(v), nil
+	case float64:
+		return int(v), nil
+	case string:
+		if strings.Contains(v, ".") {
+			// Presumes semver value (e.g. 1.0.0, 0.1.0 or 0.1)
+			// Major is converted to integer if != zero.
+			// Otherwise if Major == zero, then ignore Minor/Patch and set to latest version.
+			segs := strings.Split(v, ".")
+			v = segs[0]
+		}
+		version, err := strconv.Atoi(v)
+		if err != nil {
+			return 0, fmt.Errorf("error parsing manifest_version: %w", err)
+		}
+		return version, nil
+	}
+	return 0, fmt.Errorf("error parsing manifest_version: unrecognised type")
+}
+
 // containsManifestSection loads the slice of bytes into a toml tree structure
 // before checking if the manifest_version is defined as a toml section block.
 func containsManifestSection(data []byte) (bool, error) {
@@ -499,6 +600,22 @@ func containsManifestSection(data []byte) (bool, error) {
 // manifest_version would cause all keys below it to be deleted as they would
 // all be considered part of that section block.
 func stripManifestSection(r io.Reader, path string) (*bytes.Buffer, error) {
+	buf, err := removeManifestSection(r)
+	if err != nil {
+		return buf, err
+	}
+
+	err = os.WriteFile(path, buf.Bytes(), FilePermissions)
+	if err != nil {
+		return buf, err
+	}
+
+	return buf, nil
+}
+
+// removeManifestSection is like stripManifestSection but doesn't persist the
+// result to disk.
+func removeManifestSection(r io.Reader) (*bytes.Buffer, error) {
 	var data []byte
 	buf := bytes.NewBuffer(data)
 
@@ -515,16 +632,7 @@ func stripManifestSection(r io.Reader, path string) (*bytes.Buffer, error) {
 			}
 		}
 	}
-	if err := scanner.Err(); err != nil {
-		return buf, err
-	}
-
-	err := os.WriteFile(path, buf.Bytes(), FilePermissions)
-	if err != nil {
-		return buf, err
-	}
-
-	return buf, nil
+	return buf, scanner.Err()
 }
 
 // Write persists the manifest content to disk.
@@ -541,11 +649,7 @@ func (f *File) Write(path string) error {
 		return err
 	}
 
-	if err := appendSpecRef(fp); err != nil {
-		return err
-	}
-
-	if err := toml.NewEncoder(fp).Encode(f); err != nil {
+	if err := f.encode(fp); err != nil {
 		return err
 	}
 
@@ -556,6 +660,14 @@ func (f *File) Write(path string) error {
 	return fp.Close()
 }
 
+// encode writes the manifest content, preceded by the specification reference.
+func (f *File) encode(w io.Writer) error {
+	if err := appendSpecRef(w); err != nil {
+		return err
+	}
+	return toml.NewEncoder(w).Encode(f)
+}
+
 // appendSpecRef appends the fastly.toml specification URL to the manifest.
 func appendSpecRef(w io.Writer) error {
 	s := fmt.Sprintf("# %s\n# %s\n\n", SpecIntro, SpecURL)
@@ -0,0 +1,117 @@
+package manifest
+
+import (
+	"bytes"
+	"fmt"
+
+	fsterr "github.com/fastly/cli/pkg/errors"
+	toml "github.com/pelletier/go-toml"
+)
+
+// migrations upgrade a manifest from the keyed manifest_version to the next
+// version. Versions that only bumped the manifest_version number don't need
+// an entry.
+var migrations = map[int]func(*toml.Tree) error{
+	1: migrateSetupSections,
+}
+
+// Migrate upgrades the manifest data to the ManifestLatestVersion schema.
+//
+// It returns the upgraded data along with the manifest_version the data was
+// upgraded from. If there is nothing to upgrade, the data is returned
+// unmodified.
+func Migrate(data []byte) ([]byte, int, error) {
+	section, err := containsManifestSection(data)
+	if err != nil {
+		return data, 0, fmt.Errorf("failed to parse the fastly.toml manifest: %w", err)
+	}
+	if section {
+		buf, err := removeManifestSection(bytes.NewReader(data))
+		if err != nil {
+			return data, 0, fsterr.ErrInvalidManifestVersion
+		}
+		data = buf.Bytes()
+	}
+
+	tree, err := toml.LoadBytes(data)
+	if err != nil {
+		return data, 0, fmt.Errorf("failed to parse the fastly.toml manifest: %w", err)
+	}
+
+	var version int
+	if i := tree.Get("manifest_version"); i != nil {
+		version, err = parseVersion(i)
+		if err != nil {
+			return data, 0, err
+		}
+	}
+	if version > ManifestLatestVersion {
+		return data, version, fsterr.ErrUnrecognisedManifestVersion
+	}
+	if version == ManifestLatestVersion && !section {
+		return data, version, nil
+	}
+
+	// NOTE: A zero major semver manifest_version (e.g. 0.1.0) predates version 1
+	// but shares its schema.
+	for v := 1; v < ManifestLatestVersion; v++ {
+		if v < version {
+			continue
+		}
+		if m, ok := migrations[v]; ok {
+			if err := m(tree); err != nil {
+				return data, version, fmt.Errorf("error migrating manifest_version %d to %d: %w", v, v+1, err)
+			}
+		}
+	}
+	tree.Set("manifest_version", int64(ManifestLatestVersion))
+
+	var f File
+	if err := tree.Unmarshal(&f); err != nil {
+		return data, version, fmt.Errorf("error unmarshalling migrated fastly.toml: %w", err)
+	}
+	var buf bytes.Buffer
+	if err := f.encode(&buf); err != nil {
+		return data, version, fmt.Errorf("error marshalling migrated fastly.toml: %w", err)
+	}
+	return buf.Bytes(), version, nil
+}
+
+// migrateSetupSections converts the version 1 [[setup.<T>]] arrays of tables
+// into the version 2 [setup.<T>.<name>] tables, renaming the 'prompt' key to
+// 'description'.
+func migrateSetupSections(tree *toml.Tree) error {
+	setup, ok := tree.Get("setup").(*toml.Tree)
+	if !ok {
+		return nil
+	}
+	for _, key := range setup.Keys() {
+		entries, ok := setup.Get(key).([]*toml.Tree)
+		if !ok {
+			continue
+		}
+		m := make(map[string]any, len(entries))
+		for i, entry := range entries {
+			name, ok := entry.Get("name").(string)
+			if !ok || name == "" {
+				return fmt.Errorf("[[setup.%s]] entry %d is missing a name", key, i+1)
+			}
+			if _, ok := m[name]; ok {
+				return fmt.Errorf("[[setup.%s]] entry '%s' is defined more than once", key, name)
+			}
+			values := entry.ToMap()
+			delete(values, "name")
+			if prompt, ok := values["prompt"]; ok {
+				values["description"] = prompt
+				delete(values, "prompt")
+			}
+			m[name] = values
+		}
+		section, err := toml.TreeFromMap(m)
+		if err != nil {
+			return err
+		}
+		setup.Set(key, section)
+	}
+	return nil
+}
@@ -0,0 +1,87 @@
+package manifest_test
+
+import (
+	"errors"
+	"testing"
+
+	fsterr "github.com/fastly/cli/pkg/errors"
+	"github.com/fastly/cli/pkg/manifest"
+	"github.com/fastly/cli/pkg/testutil"
+	toml "github.com/pelletier/go-toml"
+)
+
+func TestMigrate(t *testing.T) {
+	data := []byte(`manifest_version = 1
+name = "test"
+language = "rust"
+
+[[setup.backends]]
+name = "origin"
+address = "example.com"
+port = 443
+prompt = "Origin server"
+
+[[setup.backends]]
+name = "other"
+address = "127.0.0.1"
+`)
+	migrated, version, err := manifest.Migrate(data)
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, 1, version)
+
+	var f manifest.File
+	if err := toml.Unmarshal(migrated, &f); err != nil {
+		t.Fatal(err)
+	}
+	testutil.AssertEqual(t, manifest.Version(manifest.ManifestLatestVersion), f.ManifestVersion)
+	testutil.AssertString(t, "test", f.Name)
+	testutil.AssertEqual(t, map[string]*manifest.SetupBackend{
+		"origin": {Address: "example.com", Port: 443, Description: "Origin server"},
+		"other":  {Address: "127.0.0.1"},
+	}, f.Setup.Backends)
+
+	// Migrating again is a no-op.
+	again, version, err := manifest.Migrate(migrated)
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, manifest.ManifestLatestVersion, version)
+	testutil.AssertString(t, string(migrated), string(again))
+}
+
+func TestMigrateErrors(t *testing.T) {
+	for _, testcase := range []struct {
+		name      string
+		data      string
+		wantError string
+	}{
+		{
+			name:      "unrecognised version",
+			data:      `manifest_version = 99`,
+			wantError: fsterr.ErrUnrecognisedManifestVersion.Inner.Error(),
+		},
+		{
+			name: "missing name",
+			data: `manifest_version = 1
+[[setup.backends]]
+address = "example.com"`,
+			wantError: "error migrating manifest_version 1 to 2: [[setup.backends]] entry 1 is missing a name",
+		},
+		{
+			name: "duplicate name",
+			data: `manifest_version = 1
+[[setup.backends]]
+name = "origin"
+[[setup.backends]]
+name = "origin"`,
+			wantError: "[[setup.backends]] entry 'origin' is defined more than once",
+		},
+	} {
+		t.Run(testcase.name, func(t *testing.T) {
+			_, _, err := manifest.Migrate([]byte(testcase.data))
+			var re fsterr.RemediationError
+			if errors.As(err, &re) {
+				err = re.Inner
+			}
+			testutil.AssertErrorContains(t, err, testcase.wantError)
+		})
+	}
+}
@@ -0,0 +1,121 @@
+package manifest
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// SchemaURI identifies the JSON Schema draft the manifest schema conforms to.
+const SchemaURI = "http://json-schema.org/draft-07/schema#"
+
+// Schema is a JSON Schema document node.
+type Schema struct {
+	Schema               string             `json:"$schema,omitempty"`
+	Title                string             `json:"title,omitempty"`
+	Description          string             `json:"description,omitempty"`
+	Type                 string             `json:"type,omitempty"`
+	Const                any                `json:"const,omitempty"`
+	Minimum              *int               `json:"minimum,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	AdditionalProperties any                `json:"additionalProperties,omitempty"`
+}
+
+// GenerateSchema returns the JSON Schema for the ManifestLatestVersion of the
+// fastly.toml manifest file.
+//
+// NOTE: The schema is generated from the File struct (using the toml and
+// description struct tags) so it can't drift from what the CLI decodes.
+func GenerateSchema() (*Schema, error) {
+	s, err := schemaFor(reflect.TypeOf(File{}))
+	if err != nil {
+		return nil, err
+	}
+	s.Schema = SchemaURI
+	s.Title = Filename
+	s.Description = fmt.Sprintf("Compute@Edge package manifest (manifest_version %d).", ManifestLatestVersion)
+	s.Required = []string{"manifest_version", "name"}
+	return s, nil
+}
+
+// MarshalSchema returns the indented JSON encoding of the manifest schema.
+func MarshalSchema() ([]byte, error) {
+	s, err := GenerateSchema()
+	if err != nil {
+		return nil, err
+	}
+	return json.MarshalIndent(s, "", "  ")
+}
+
+// schemaFor maps a Go type to its JSON Schema equivalent.
+func schemaFor(t reflect.Type) (*Schema, error) {
+	if t == reflect.TypeOf(Version(0)) {
+		return &Schema{Type: "integer", Const: ManifestLatestVersion}, nil
+	}
+
+	switch t.Kind() {
+	case reflect.Pointer:
+		return schemaFor(t.Elem())
+	case reflect.String:
+		return &Schema{Type: "string"}, nil
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}, nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return &Schema{Type: "integer"}, nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		min := 0
+		return &Schema{Type: "integer", Minimum: &min}, nil
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: "number"}, nil
+	case reflect.Slice, reflect.Array:
+		items, err := schemaFor(t.Elem())
+		if err != nil {
+			return nil, err
+		}
+		return &Schema{Type: "array", Items: items}, nil
+	case reflect.Map:
+		if t.Key().Kind() != reflect.String {
+			return nil, fmt.Errorf("unsupported manifest map key type: %s", t.Key())
+		}
+		values, err := schemaFor(t.Elem())
+		if err != nil {
+			return nil, err
+		}
+		return &Schema{Type: "object", AdditionalProperties: values}, nil
+	case reflect.Struct:
+		return structSchema(t)
+	}
+	return nil, fmt.Errorf("unsupported manifest field type: %s", t)
+}
+
+// structSchema maps the exported toml fields of a struct to an object schema.
+func structSchema(t reflect.Type) (*Schema, error) {
+	s := &Schema{
+		Type:                 "object",
+		Properties:           make(map[string]*Schema),
+		AdditionalProperties: false,
+	}
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		name, _, _ := strings.Cut(f.Tag.Get("toml"), ",")
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		fs, err := schemaFor(f.Type)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", name, err)
+		}
+		fs.Description = f.Tag.Get("description")
+		s.Properties[name] = fs
+	}
+	return s, nil
+}
@@ -0,0 +1,152 @@
+package manifest_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/fastly/cli/pkg/manifest"
+	"github.com/fastly/cli/pkg/testutil"
+	toml "github.com/pelletier/go-toml"
+)
+
+func TestGenerateSchema(t *testing.T) {
+	s, err := manifest.GenerateSchema()
+	testutil.AssertNoError(t, err)
+
+	testutil.AssertString(t, manifest.SchemaURI, s.Schema)
+	testutil.AssertEqual(t, []string{"manifest_version", "name"}, s.Required)
+	testutil.AssertEqual(t, false, s.AdditionalProperties)
+
+	v := s.Properties["manifest_version"]
+	testutil.AssertString(t, "integer", v.Type)
+	testutil.AssertEqual(t, manifest.ManifestLatestVersion, v.Const)
+
+	port := s.Properties["setup"].Properties["backends"].AdditionalProperties.(*manifest.Schema).Properties["port"]
+	testutil.AssertString(t, "integer", port.Type)
+	testutil.AssertEqual(t, 0, *port.Minimum)
+
+	// Every field must be documented for IDE tooltips.
+	var undocumented []string
+	walkSchema("", s, func(path string, n *manifest.Schema) {
+		if path != "" && n.Description == "" {
+			undocumented = append(undocumented, path)
+		}
+	})
+	if len(undocumented) > 0 {
+		t.Errorf("fields missing a description tag: %v", undocumented)
+	}
+
+	if _, err := manifest.MarshalSchema(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// TestSchemaMatchesManifests ensures the valid test manifests are accepted by
+// the schema, which guards against the schema drifting from the File struct.
+func TestSchemaMatchesManifests(t *testing.T) {
+	data, err := manifest.MarshalSchema()
+	testutil.AssertNoError(t, err)
+
+	// Round trip the schema through JSON so we validate what users receive.
+	var s manifest.Schema
+	if err := json.Unmarshal(data, &s); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, fpath := range []string{
+		"fastly-valid-integer.toml",
+		"fastly-viceroy-update.toml",
+	} {
+		t.Run(fpath, func(t *testing.T) {
+			tree, err := toml.LoadFile(filepath.Join("testdata", fpath))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := validate("", &s, tree.ToMap()); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+
+	// An unknown key should be rejected.
+	err = validate("", &s, map[string]any{"nmae": "typo"})
+	testutil.AssertErrorContains(t, err, ".nmae: unknown property")
+
+	// An unexpected type should be rejected.
+	err = validate("", &s, map[string]any{"authors": "not a list"})
+	testutil.AssertErrorContains(t, err, ".authors: want array")
+}
+
+// walkSchema calls fn for each property schema nested within s.
+func walkSchema(path string, s *manifest.Schema, fn func(string, *manifest.Schema)) {
+	fn(path, s)
+	for name, p := range s.Properties {
+		walkSchema(path+"."+name, p, fn)
+	}
+	if s.Items != nil {
+		walkSchema(path+"[]", s.Items, func(p string, n *manifest.Schema) {
+			if p != path+"[]" {
+				fn(p, n)
+			}
+		})
+	}
+	if ap, ok := s.AdditionalProperties.(*manifest.Schema); ok {
+		walkSchema(path+".*", ap, func(p string, n *manifest.Schema) {
+			if p != path+".*" {
+				fn(p, n)
+			}
+		})
+	}
+}
+
+// validate is a minimal JSON Schema validator supporting the subset of
+// keywords used by the manifest schema.
+func validate(path string, s *manifest.Schema, v any) error {
+	switch s.Type {
+	case "object":
+		m, ok := v.(map[string]any)
+		if !ok {
+			return fmt.Errorf("%s: want object, have %T", path, v)
+		}
+		for k, vv := range m {
+			p, ok := s.Properties[k]
+			if !ok {
+				switch ap := s.AdditionalProperties.(type) {
+				case map[string]any:
+					// Round tripped through JSON.
+					data, _ := json.Marshal(ap)
+					p = &manifest.Schema{}
+					_ = json.Unmarshal(data, p)
+				case *manifest.Schema:
+					p = ap
+				default:
+					return fmt.Errorf("%s.%s: unknown property", path, k)
+				}
+			}
+			if err := validate(path+"."+k, p, vv); err != nil {
+				return err
+			}
+		}
+	case "array":
+		a, ok := v.([]any)
+		if !ok {
+			return fmt.Errorf("%s: want array, have %T", path, v)
+		}
+		for i, vv := range a {
+			if err := validate(fmt.Sprintf("%s[%d]", path, i), s.Items, vv); err != nil {
+				return err
+			}
+		}
+	case "string":
+		if _, ok := v.(string); !ok {
+			return fmt.Errorf("%s: want string, have %T", path, v)
+		}
+	case "integer":
+		if _, ok := v.(int64); !ok {
+			return fmt.Errorf("%s: want integer, have %T", path, v)
+		}
+	}
+	return nil
+}
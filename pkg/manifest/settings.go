@@ -0,0 +1,78 @@
+package manifest
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	fsterr "github.com/fastly/cli/pkg/errors"
+	toml "github.com/pelletier/go-toml"
+)
+
+// SettingsFilename is the name of the project-level CLI settings file, which
+// sits alongside the fastly.toml manifest.
+const SettingsFilename = ".fastlyrc"
+
+// Settings represents the default global flags for commands run within a
+// project. They're read from the .fastlyrc file and the [cli] section of the
+// fastly.toml manifest.
+//
+// A setting is only applied when the flag isn't set on the command line or by
+// a FASTLY_ environment variable, and it takes precedence over the global
+// configuration (e.g. the default profile and its autoclone setting).
+//
+// NOTE: Unlike the --non-interactive flag, the non_interactive setting only
+// makes prompts fail rather than implying their answers, as the settings are
+// controlled by whoever wrote the project, who mustn't be able to confirm a
+// prompt (e.g. to run a custom build script) on the user's behalf.
+type Settings struct {
+	AutoClone      *bool  `toml:"autoclone,omitempty" description:"Clone an active or locked service version before changing it (the --autoclone flag)"`
+	NonInteractive *bool  `toml:"non_interactive,omitempty" description:"Fail rather than prompt for user input (unlike the --non-interactive flag, prompts aren't answered)"`
+	Profile        string `toml:"profile,omitempty" description:"The account profile to use (the --profile flag)"`
+	Quiet          *bool  `toml:"quiet,omitempty" description:"Suppress informational and progress output (the --quiet flag)"`
+}
+
+// Merge returns the settings with those set in o taking precedence.
+func (s Settings) Merge(o Settings) Settings {
+	if o.AutoClone != nil {
+		s.AutoClone = o.AutoClone
+	}
+	if o.NonInteractive != nil {
+		s.NonInteractive = o.NonInteractive
+	}
+	if o.Profile != "" {
+		s.Profile = o.Profile
+	}
+	if o.Quiet != nil {
+		s.Quiet = o.Quiet
+	}
+	return s
+}
+
+// ReadSettings returns the project settings for the project in dir, where the
+// .fastlyrc file takes precedence over the [cli] section of the manifest.
+func ReadSettings(dir string, m File) (Settings, error) {
+	path := filepath.Join(dir, SettingsFilename)
+
+	// gosec flagged this:
+	// G304 (CWE-22): Potential file inclusion via variable.
+	// Disabling as we need to load the .fastlyrc from the user's file system.
+	/* #nosec */
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return m.CLI, nil
+		}
+		return m.CLI, fmt.Errorf("error reading %s: %w", SettingsFilename, err)
+	}
+
+	var s Settings
+	if err := toml.Unmarshal(data, &s); err != nil {
+		return m.CLI, fsterr.RemediationError{
+			Inner:       fmt.Errorf("error parsing %s: %w", path, err),
+			Remediation: fmt.Sprintf("Fix the syntax of the %s file, which accepts the same settings as the [cli] section of the fastly.toml manifest.", SettingsFilename),
+		}
+	}
+	return m.CLI.Merge(s), nil
+}
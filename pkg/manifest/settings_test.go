@@ -0,0 +1,75 @@
+package manifest_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/fastly/cli/pkg/manifest"
+	"github.com/fastly/cli/pkg/testutil"
+)
+
+func TestReadSettings(t *testing.T) {
+	enabled, disabled := true, false
+	for _, testcase := range []struct {
+		name                 string
+		manifest             string
+		settings             string
+		want                 manifest.Settings
+		wantError            string
+		wantRemediationError string
+	}{
+		{
+			name:     "no settings",
+			manifest: "manifest_version = 2\n",
+		},
+		{
+			name:     "manifest section",
+			manifest: "manifest_version = 2\n[cli]\nprofile = \"staging\"\nquiet = true\n",
+			want:     manifest.Settings{Profile: "staging", Quiet: &enabled},
+		},
+		{
+			name:     "settings file",
+			manifest: "manifest_version = 2\n",
+			settings: "autoclone = true\nnon_interactive = true\n",
+			want:     manifest.Settings{AutoClone: &enabled, NonInteractive: &enabled},
+		},
+		{
+			name:     "settings file takes precedence",
+			manifest: "manifest_version = 2\n[cli]\nautoclone = true\nprofile = \"staging\"\nquiet = true\n",
+			settings: "profile = \"production\"\nquiet = false\n",
+			want:     manifest.Settings{AutoClone: &enabled, Profile: "production", Quiet: &disabled},
+		},
+		{
+			name:                 "invalid settings file",
+			manifest:             "manifest_version = 2\n",
+			settings:             "quiet = ",
+			wantError:            "error parsing",
+			wantRemediationError: "the [cli] section of the fastly.toml manifest",
+		},
+	} {
+		t.Run(testcase.name, func(t *testing.T) {
+			dir := t.TempDir()
+			mp := filepath.Join(dir, manifest.Filename)
+			if err := os.WriteFile(mp, []byte(testcase.manifest), 0o600); err != nil {
+				t.Fatal(err)
+			}
+			if testcase.settings != "" {
+				if err := os.WriteFile(filepath.Join(dir, manifest.SettingsFilename), []byte(testcase.settings), 0o600); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			var m manifest.File
+			if err := m.Read(mp); err != nil {
+				t.Fatal(err)
+			}
+			s, err := manifest.ReadSettings(dir, m)
+			testutil.AssertErrorContains(t, err, testcase.wantError)
+			testutil.AssertRemediationErrorContains(t, err, testcase.wantRemediationError)
+			if testcase.wantError == "" {
+				testutil.AssertEqual(t, testcase.want, s)
+			}
+		})
+	}
+}
@@ -221,11 +221,13 @@ type API struct {
 
 	CreateManagedLoggingFn func(*fastly.CreateManagedLoggingInput) (*fastly.ManagedLogging, error)
 
-	CreateVCLFn func(*fastly.CreateVCLInput) (*fastly.VCL, error)
-	ListVCLsFn  func(*fastly.ListVCLsInput) ([]*fastly.VCL, error)
-	GetVCLFn    func(*fastly.GetVCLInput) (*fastly.VCL, error)
-	UpdateVCLFn func(*fastly.UpdateVCLInput) (*fastly.VCL, error)
-	DeleteVCLFn func(*fastly.DeleteVCLInput) error
+	CreateVCLFn       func(*fastly.CreateVCLInput) (*fastly.VCL, error)
+	ListVCLsFn        func(*fastly.ListVCLsInput) ([]*fastly.VCL, error)
+	GetVCLFn          func(*fastly.GetVCLInput) (*fastly.VCL, error)
+	GetGeneratedVCLFn func(*fastly.GetGeneratedVCLInput) (*fastly.VCL, error)
+	UpdateVCLFn       func(*fastly.UpdateVCLInput) (*fastly.VCL, error)
+	ActivateVCLFn     func(*fastly.ActivateVCLInput) (*fastly.VCL, error)
+	DeleteVCLFn       func(*fastly.DeleteVCLInput) error
 
 	CreateSnippetFn        func(i *fastly.CreateSnippetInput) (*fastly.Snippet, error)
 	ListSnippetsFn         func(i *fastly.ListSnippetsInput) ([]*fastly.Snippet, error)
@@ -267,6 +269,8 @@ type API struct {
 	UpdateUserFn        func(i *fastly.UpdateUserInput) (*fastly.User, error)
 	ResetUserPasswordFn func(i *fastly.ResetUserPasswordInput) error
 
+	CreateServiceAuthorizationFn func(i *fastly.CreateServiceAuthorizationInput) (*fastly.ServiceAuthorization, error)
+
 	BatchDeleteTokensFn  func(i *fastly.BatchDeleteTokensInput) error
 	CreateTokenFn        func(i *fastly.CreateTokenInput) (*fastly.Token, error)
 	DeleteTokenFn        func(i *fastly.DeleteTokenInput) error
@@ -312,6 +316,25 @@ type API struct {
 	GetTLSSubscriptionFn    func(i *fastly.GetTLSSubscriptionInput) (*fastly.TLSSubscription, error)
 	ListTLSSubscriptionsFn  func(i *fastly.ListTLSSubscriptionsInput) ([]*fastly.TLSSubscription, error)
 	UpdateTLSSubscriptionFn func(i *fastly.UpdateTLSSubscriptionInput) (*fastly.TLSSubscription, error)
+
+	GetAPIEventFn  func(i *fastly.GetAPIEventInput) (*fastly.Event, error)
+	GetAPIEventsFn func(i *fastly.GetAPIEventsFilterInput) (fastly.GetAPIEventsResponse, error)
+
+	CreateERLFn func(i *fastly.CreateERLInput) (*fastly.ERL, error)
+	DeleteERLFn func(i *fastly.DeleteERLInput) error
+	GetERLFn    func(i *fastly.GetERLInput) (*fastly.ERL, error)
+	ListERLsFn  func(i *fastly.ListERLsInput) ([]*fastly.ERL, error)
+	UpdateERLFn func(i *fastly.UpdateERLInput) (*fastly.ERL, error)
+
+	CreateDirectorFn        func(i *fastly.CreateDirectorInput) (*fastly.Director, error)
+	DeleteDirectorFn        func(i *fastly.DeleteDirectorInput) error
+	GetDirectorFn           func(i *fastly.GetDirectorInput) (*fastly.Director, error)
+	ListDirectorsFn         func(i *fastly.ListDirectorsInput) ([]*fastly.Director, error)
+	UpdateDirectorFn        func(i *fastly.UpdateDirectorInput) (*fastly.Director, error)
+	CreateDirectorBackendFn func(i *fastly.CreateDirectorBackendInput) (*fastly.DirectorBackend, error)
+	DeleteDirectorBackendFn func(i *fastly.DeleteDirectorBackendInput) error
+
+	GetOriginMetricsForServiceFn func(i *fastly.GetOriginMetricsInput) (*fastly.OriginInspector, error)
 }
 
 // AllDatacenters implements Interface.
@@ -1209,11 +1232,21 @@ func (m API) GetVCL(i *fastly.GetVCLInput) (*fastly.VCL, error) {
 	return m.GetVCLFn(i)
 }
 
+// GetGeneratedVCL implements Interface.
+func (m API) GetGeneratedVCL(i *fastly.GetGeneratedVCLInput) (*fastly.VCL, error) {
+	return m.GetGeneratedVCLFn(i)
+}
+
 // UpdateVCL implements Interface.
 func (m API) UpdateVCL(i *fastly.UpdateVCLInput) (*fastly.VCL, error) {
 	return m.UpdateVCLFn(i)
 }
 
+// ActivateVCL implements Interface.
+func (m API) ActivateVCL(i *fastly.ActivateVCLInput) (*fastly.VCL, error) {
+	return m.ActivateVCLFn(i)
+}
+
 // DeleteVCL implements Interface.
 func (m API) DeleteVCL(i *fastly.DeleteVCLInput) error {
 	return m.DeleteVCLFn(i)
@@ -1389,6 +1422,11 @@ func (m API) ResetUserPassword(i *fastly.ResetUserPasswordInput) error {
 	return m.ResetUserPasswordFn(i)
 }
 
+// CreateServiceAuthorization implements Interface.
+func (m API) CreateServiceAuthorization(i *fastly.CreateServiceAuthorizationInput) (*fastly.ServiceAuthorization, error) {
+	return m.CreateServiceAuthorizationFn(i)
+}
+
 // BatchDeleteTokens implements Interface.
 func (m API) BatchDeleteTokens(i *fastly.BatchDeleteTokensInput) error {
 	return m.BatchDeleteTokensFn(i)
@@ -1578,3 +1616,78 @@ func (m API) ListTLSSubscriptions(i *fastly.ListTLSSubscriptionsInput) ([]*fastl
 func (m API) UpdateTLSSubscription(i *fastly.UpdateTLSSubscriptionInput) (*fastly.TLSSubscription, error) {
 	return m.UpdateTLSSubscriptionFn(i)
 }
+
+// GetAPIEvent implements Interface.
+func (m API) GetAPIEvent(i *fastly.GetAPIEventInput) (*fastly.Event, error) {
+	return m.GetAPIEventFn(i)
+}
+
+// GetAPIEvents implements Interface.
+func (m API) GetAPIEvents(i *fastly.GetAPIEventsFilterInput) (fastly.GetAPIEventsResponse, error) {
+	return m.GetAPIEventsFn(i)
+}
+
+// CreateERL implements Interface.
+func (m API) CreateERL(i *fastly.CreateERLInput) (*fastly.ERL, error) {
+	return m.CreateERLFn(i)
+}
+
+// DeleteERL implements Interface.
+func (m API) DeleteERL(i *fastly.DeleteERLInput) error {
+	return m.DeleteERLFn(i)
+}
+
+// GetERL implements Interface.
+func (m API) GetERL(i *fastly.GetERLInput) (*fastly.ERL, error) {
+	return m.GetERLFn(i)
+}
+
+// ListERLs implements Interface.
+func (m API) ListERLs(i *fastly.ListERLsInput) ([]*fastly.ERL, error) {
+	return m.ListERLsFn(i)
+}
+
+// UpdateERL implements Interface.
+func (m API) UpdateERL(i *fastly.UpdateERLInput) (*fastly.ERL, error) {
+	return m.UpdateERLFn(i)
+}
+
+// CreateDirector implements Interface.
+func (m API) CreateDirector(i *fastly.CreateDirectorInput) (*fastly.Director, error) {
+	return m.CreateDirectorFn(i)
+}
+
+// DeleteDirector implements Interface.
+func (m API) DeleteDirector(i *fastly.DeleteDirectorInput) error {
+	return m.DeleteDirectorFn(i)
+}
+
+// GetDirector implements Interface.
+func (m API) GetDirector(i *fastly.GetDirectorInput) (*fastly.Director, error) {
+	return m.GetDirectorFn(i)
+}
+
+// ListDirectors implements Interface.
+func (m API) ListDirectors(i *fastly.ListDirectorsInput) ([]*fastly.Director, error) {
+	return m.ListDirectorsFn(i)
+}
+
+// UpdateDirector implements Interface.
+func (m API) UpdateDirector(i *fastly.UpdateDirectorInput) (*fastly.Director, error) {
+	return m.UpdateDirectorFn(i)
+}
+
+// CreateDirectorBackend implements Interface.
+func (m API) CreateDirectorBackend(i *fastly.CreateDirectorBackendInput) (*fastly.DirectorBackend, error) {
+	return m.CreateDirectorBackendFn(i)
+}
+
+// DeleteDirectorBackend implements Interface.
+func (m API) DeleteDirectorBackend(i *fastly.DeleteDirectorBackendInput) error {
+	return m.DeleteDirectorBackendFn(i)
+}
+
+// GetOriginMetricsForService implements Interface.
+func (m API) GetOriginMetricsForService(i *fastly.GetOriginMetricsInput) (*fastly.OriginInspector, error) {
+	return m.GetOriginMetricsForServiceFn(i)
+}
@@ -0,0 +1,3 @@
+// Package notify contains abstractions for notifying Slack or a generic HTTP
+// endpoint after a service has been changed.
+package notify
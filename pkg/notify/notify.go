@@ -0,0 +1,157 @@
+package notify
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/fastly/cli/pkg/api"
+	"github.com/fastly/cli/pkg/cmd"
+	"github.com/fastly/cli/pkg/config"
+	"github.com/fastly/cli/pkg/text"
+	"github.com/fastly/cli/pkg/useragent"
+)
+
+// DiffURL is the Fastly web interface page comparing two service versions.
+const DiffURL = "https://manage.fastly.com/configure/services/%s/diff/%d,%d"
+
+// FlagDesc is the description of the --notify flag.
+const FlagDesc = "Notify the endpoints in the [notify] config section (--no-notify to skip)"
+
+// The actions that send a notification.
+const (
+	ActionActivate = "activate"
+	ActionDeploy   = "deploy"
+	ActionPurgeAll = "purge_all"
+)
+
+// Event describes a change made to a service.
+type Event struct {
+	Action    string `json:"action"`
+	DiffURL   string `json:"diff_url,omitempty"`
+	ServiceID string `json:"service_id"`
+	User      string `json:"user,omitempty"`
+	Version   int    `json:"version,omitempty"`
+}
+
+// Message returns a human readable summary of the event.
+func (e Event) Message() string {
+	var msg string
+	switch e.Action {
+	case ActionActivate:
+		msg = fmt.Sprintf("Activated service %s version %d", e.ServiceID, e.Version)
+	case ActionDeploy:
+		msg = fmt.Sprintf("Deployed service %s version %d", e.ServiceID, e.Version)
+	case ActionPurgeAll:
+		msg = fmt.Sprintf("Purged all content from service %s", e.ServiceID)
+	default:
+		msg = fmt.Sprintf("Changed service %s", e.ServiceID)
+	}
+	if e.User != "" {
+		msg = fmt.Sprintf("%s (by %s)", msg, e.User)
+	}
+	if e.DiffURL != "" {
+		msg = fmt.Sprintf("%s\n%s", msg, e.DiffURL)
+	}
+	return msg
+}
+
+// webhookPayload is the JSON sent to a generic webhook.
+type webhookPayload struct {
+	Event
+	Message string `json:"message"`
+}
+
+// slackPayload is the JSON sent to a Slack incoming webhook.
+type slackPayload struct {
+	Text string `json:"text"`
+}
+
+// Send notifies the endpoints in the [notify] config of the event, if
+// notifications are enabled by the config or the --notify flag.
+//
+// NOTE: A failed notification is displayed as a warning rather than returned
+// as an error, because the change it describes has already been made.
+func Send(e Event, flag cmd.OptionalBool, globals *config.Data, out io.Writer) {
+	cfg := globals.File.Notify
+	enabled := cfg.Enabled
+	if flag.WasSet {
+		enabled = flag.Value
+	}
+	if !enabled {
+		return
+	}
+
+	if cfg.SlackWebhook == "" && cfg.Webhook == "" {
+		text.Warning(out, "No notification was sent as the [notify] section of %s has no slack_webhook or webhook.", globals.Path)
+		return
+	}
+
+	if e.User == "" {
+		e.User = user(globals)
+	}
+	if e.DiffURL == "" && e.Version > 1 {
+		e.DiffURL = fmt.Sprintf(DiffURL, e.ServiceID, e.Version-1, e.Version)
+	}
+
+	endpoints := []struct {
+		name    string
+		url     string
+		payload any
+	}{
+		{"Slack", cfg.SlackWebhook, slackPayload{Text: e.Message()}},
+		{"webhook", cfg.Webhook, webhookPayload{Event: e, Message: e.Message()}},
+	}
+	for _, ep := range endpoints {
+		if ep.url == "" {
+			continue
+		}
+		if err := post(ep.url, ep.payload, globals.HTTPClient); err != nil {
+			globals.ErrLog.AddWithContext(err, map[string]any{
+				"Service ID": e.ServiceID,
+				"Action":     e.Action,
+			})
+			text.Warning(out, "Failed to send %s notification: %s", ep.name, err)
+			continue
+		}
+		if globals.Verbose() {
+			text.Info(out, "Sent %s notification", ep.name)
+		}
+	}
+}
+
+// user returns the email address of the profile in use.
+func user(globals *config.Data) string {
+	if name := globals.Profile(); name != "" {
+		return globals.File.Profiles[name].Email
+	}
+	return ""
+}
+
+// post sends the payload as JSON to the endpoint.
+func post(endpoint string, payload any, c api.HTTPClient) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", useragent.Name)
+
+	res, err := c.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode < http.StatusOK || res.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("unexpected response status: %s", res.Status)
+	}
+	return nil
+}
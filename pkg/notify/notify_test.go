@@ -0,0 +1,119 @@
+package notify_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/fastly/cli/pkg/cmd"
+	"github.com/fastly/cli/pkg/config"
+	fsterr "github.com/fastly/cli/pkg/errors"
+	"github.com/fastly/cli/pkg/notify"
+	"github.com/fastly/cli/pkg/testutil"
+)
+
+func TestSend(t *testing.T) {
+	for _, testcase := range []struct {
+		name         string
+		enabled      bool
+		flag         cmd.OptionalBool
+		status       int
+		wantRequests int
+		wantOutput   string
+	}{
+		{
+			name:         "disabled by config",
+			wantRequests: 0,
+		},
+		{
+			name:         "enabled by config",
+			enabled:      true,
+			status:       http.StatusOK,
+			wantRequests: 2,
+		},
+		{
+			name:         "enabled by --notify",
+			flag:         cmd.OptionalBool{Optional: cmd.Optional{WasSet: true}, Value: true},
+			status:       http.StatusOK,
+			wantRequests: 2,
+		},
+		{
+			name:         "disabled by --no-notify",
+			enabled:      true,
+			flag:         cmd.OptionalBool{Optional: cmd.Optional{WasSet: true}, Value: false},
+			wantRequests: 0,
+		},
+		{
+			name:         "endpoint error",
+			enabled:      true,
+			status:       http.StatusInternalServerError,
+			wantRequests: 2,
+			wantOutput:   "Failed to send Slack notification: unexpected response status: 500 Internal Server Error",
+		},
+	} {
+		t.Run(testcase.name, func(t *testing.T) {
+			var (
+				mu       sync.Mutex
+				payloads []map[string]any
+			)
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				var p map[string]any
+				if err := json.NewDecoder(r.Body).Decode(&p); err != nil {
+					t.Errorf("error decoding payload: %v", err)
+				}
+				mu.Lock()
+				payloads = append(payloads, p)
+				mu.Unlock()
+				w.WriteHeader(testcase.status)
+			}))
+			defer server.Close()
+
+			globals := &config.Data{
+				ErrLog:     fsterr.MockLog{},
+				HTTPClient: http.DefaultClient,
+			}
+			globals.File.Notify = config.Notify{
+				Enabled:      testcase.enabled,
+				SlackWebhook: server.URL + "/slack",
+				Webhook:      server.URL + "/webhook",
+			}
+			globals.File.Profiles = config.Profiles{
+				"user": &config.Profile{Default: true, Email: "user@example.com"},
+			}
+
+			var out bytes.Buffer
+			notify.Send(notify.Event{
+				Action:    notify.ActionDeploy,
+				ServiceID: "123",
+				Version:   3,
+			}, testcase.flag, globals, &out)
+
+			mu.Lock()
+			defer mu.Unlock()
+			testutil.AssertEqual(t, testcase.wantRequests, len(payloads))
+			if testcase.wantOutput != "" {
+				testutil.AssertStringContains(t, out.String(), testcase.wantOutput)
+			}
+			if testcase.wantRequests == 0 {
+				return
+			}
+
+			testutil.AssertEqual(t, "Deployed service 123 version 3 (by user@example.com)\nhttps://manage.fastly.com/configure/services/123/diff/2,3", payloads[0]["text"])
+			testutil.AssertEqual(t, "123", payloads[1]["service_id"])
+			testutil.AssertEqual(t, "user@example.com", payloads[1]["user"])
+			testutil.AssertEqual(t, float64(3), payloads[1]["version"])
+		})
+	}
+}
+
+func TestSendNoEndpoints(t *testing.T) {
+	globals := &config.Data{Path: "config.toml"}
+	globals.File.Notify.Enabled = true
+
+	var out bytes.Buffer
+	notify.Send(notify.Event{Action: notify.ActionPurgeAll, ServiceID: "123"}, cmd.OptionalBool{}, globals, &out)
+	testutil.AssertStringContains(t, out.String(), "No notification was sent")
+}
@@ -141,6 +141,10 @@ func Init(token string, data *manifest.Data, globals *config.Data, in io.Reader,
 	msg = fmt.Sprintf("%sThe default profile '%s' (%s) will be used.", msg, name, p.Email)
 	text.Warning(out, msg)
 
+	if globals.Flag.AutoYes || globals.Flag.NonInteractive {
+		return p.Token, nil
+	}
+
 	label := "\nWould you like to continue? [y/N] "
 	cont, err := text.AskYesNo(out, label, in)
 	if err != nil {
@@ -0,0 +1,17 @@
+// Package sdk runs Fastly CLI commands from other Go programs.
+//
+// Commands are executed exactly as the `fastly` binary would execute them,
+// but with the input, output, API client and configuration provided by the
+// caller rather than taken from the user's terminal and environment. For
+// example:
+//
+//	var out bytes.Buffer
+//	err := sdk.Run(sdk.Options{
+//		Args:   []string{"service", "list", "--json"},
+//		Token:  token,
+//		Stdout: &out,
+//	})
+//
+// Run is not safe for concurrent use, as some output settings (e.g. --quiet)
+// are process wide.
+package sdk
@@ -0,0 +1,120 @@
+package sdk
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/fastly/cli/pkg/api"
+	"github.com/fastly/cli/pkg/app"
+	"github.com/fastly/cli/pkg/config"
+	"github.com/fastly/cli/pkg/env"
+	fsterr "github.com/fastly/cli/pkg/errors"
+	"github.com/fastly/cli/pkg/interrupt"
+	"github.com/fastly/cli/pkg/text"
+	toml "github.com/pelletier/go-toml"
+)
+
+// Options configures the execution of a command.
+//
+// Only Args is required, the zero value of every other field has a safe
+// default.
+type Options struct {
+	// Args are the command line arguments, excluding the program name, e.g.
+	// []string{"service", "describe", "--service-id", "123"}.
+	Args []string
+
+	// Token is the Fastly API token, equivalent to FASTLY_API_TOKEN.
+	Token string
+	// Endpoint is the Fastly API endpoint, equivalent to FASTLY_API_ENDPOINT.
+	Endpoint string
+	// Env holds environment variables for the command, e.g. FASTLY_ prefixed
+	// flag values. The environment of the calling process isn't used.
+	Env map[string]string
+
+	// ConfigPath is the application configuration file to read and persist
+	// changes to (e.g. from `fastly profile create`). When empty, the default
+	// configuration embedded in the CLI is used and isn't persisted.
+	ConfigPath string
+
+	// APIClient creates the Fastly API client, defaulting to a real client.
+	// Tests can provide a mock (see the mock package).
+	APIClient func(token, endpoint string) (api.Interface, error)
+	// HTTPClient is used for requests that don't go via the APIClient.
+	HTTPClient api.HTTPClient
+	// ErrLog records errors, defaulting to an in-memory log.
+	ErrLog fsterr.LogInterface
+
+	// Stdin provides input to interactive prompts. When nil, prompts return
+	// an error rather than blocking.
+	Stdin io.Reader
+	// Stdout receives the command output. When nil, output is discarded.
+	Stdout io.Writer
+}
+
+// Run executes the command described by the options.
+//
+// NOTE: Commands that work with a fastly.toml package manifest read it from
+// the current working directory.
+func Run(opts Options) error {
+	in := opts.Stdin
+	if in == nil {
+		in = text.NonInteractiveReader{}
+	}
+	out := opts.Stdout
+	if out == nil {
+		out = io.Discard
+	}
+	errLog := opts.ErrLog
+	if errLog == nil {
+		errLog = new(fsterr.LogEntries)
+	}
+	clientFactory := app.FastlyAPIClient
+	if opts.APIClient != nil {
+		clientFactory = opts.APIClient
+	}
+	httpClient := opts.HTTPClient
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: time.Second * 5}
+	}
+
+	var e config.Environment
+	vars := make(map[string]string, len(opts.Env)+2)
+	for k, v := range opts.Env {
+		vars[k] = v
+	}
+	if opts.Token != "" {
+		vars[env.Token] = opts.Token
+	}
+	if opts.Endpoint != "" {
+		vars[env.Endpoint] = opts.Endpoint
+	}
+	e.Read(vars)
+
+	var file config.File
+	file.SetNonInteractive(true)
+	if opts.ConfigPath != "" {
+		if err := file.Read(opts.ConfigPath, in, out, errLog, false); err != nil {
+			return err
+		}
+	} else if err := toml.Unmarshal(config.Static, &file); err != nil {
+		errLog.Add(err)
+		return fmt.Errorf("error reading the default configuration: %w", err)
+	}
+
+	return app.Run(app.RunOpts{
+		APIClient:  clientFactory,
+		Args:       opts.Args,
+		ConfigFile: file,
+		ConfigPath: opts.ConfigPath,
+		Env:        e,
+		ErrLog:     errLog,
+		HTTPClient: httpClient,
+		// NOTE: The handler doesn't catch signals, which are left to the
+		// embedding program.
+		Interrupt: interrupt.New(),
+		Stdin:     in,
+		Stdout:    out,
+	})
+}
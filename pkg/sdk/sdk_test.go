@@ -0,0 +1,81 @@
+package sdk_test
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/fastly/cli/pkg/api"
+	"github.com/fastly/cli/pkg/config"
+	"github.com/fastly/cli/pkg/mock"
+	"github.com/fastly/cli/pkg/sdk"
+	"github.com/fastly/cli/pkg/testutil"
+	"github.com/fastly/cli/pkg/text"
+	"github.com/fastly/go-fastly/v6/fastly"
+	toml "github.com/pelletier/go-toml"
+)
+
+func TestRun(t *testing.T) {
+	var gotToken, gotEndpoint string
+	client := func(token, endpoint string) (api.Interface, error) {
+		gotToken, gotEndpoint = token, endpoint
+		return mock.API{
+			GetServiceDetailsFn: func(i *fastly.GetServiceInput) (*fastly.ServiceDetail, error) {
+				return &fastly.ServiceDetail{ID: i.ID, Name: "Foo"}, nil
+			},
+		}, nil
+	}
+
+	var out bytes.Buffer
+	err := sdk.Run(sdk.Options{
+		Args:      []string{"service", "describe"},
+		Token:     "123",
+		Endpoint:  "http://localhost",
+		Env:       map[string]string{"FASTLY_SERVICE_DESCRIBE_SERVICE_ID": "abc"},
+		APIClient: client,
+		Stdout:    &out,
+	})
+	testutil.AssertNoError(t, err)
+	testutil.AssertString(t, "123", gotToken)
+	testutil.AssertString(t, "http://localhost", gotEndpoint)
+	testutil.AssertStringContains(t, out.String(), "ID: abc\nName: Foo\n")
+}
+
+func TestRunPromptWithoutStdin(t *testing.T) {
+	err := sdk.Run(sdk.Options{
+		Args:      []string{"profile", "create", "foo"},
+		APIClient: mock.APIClient(mock.API{}),
+	})
+	if !errors.Is(err, text.ErrNonInteractive) {
+		t.Fatalf("want %v, have %v", text.ErrNonInteractive, err)
+	}
+}
+
+func TestRunConfigPath(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.toml")
+	if err := os.WriteFile(path, config.Static, config.FilePermissions); err != nil {
+		t.Fatal(err)
+	}
+
+	err := sdk.Run(sdk.Options{
+		Args:       []string{"alias", "set", "sl", "service list"},
+		ConfigPath: path,
+	})
+	testutil.AssertNoError(t, err)
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var f config.File
+	if err := toml.Unmarshal(data, &f); err != nil {
+		t.Fatal(err)
+	}
+	testutil.AssertEqual(t, config.Aliases{"sl": "service list"}, f.Aliases)
+
+	// Without a config path the change can't be persisted.
+	err = sdk.Run(sdk.Options{Args: []string{"alias", "set", "sl", "service list"}})
+	testutil.AssertErrorContains(t, err, "error creating config file")
+}
@@ -0,0 +1,2 @@
+// Package sigv4 signs requests to AWS APIs with AWS Signature Version 4.
+package sigv4
@@ -1,2 +1,24 @@
 // Package testutil provides helpers for unit tests.
+//
+// The helpers are used by the CLI's own command tests and are also intended
+// for authors of code embedding the CLI (see the sdk package), so exported
+// identifiers follow the same compatibility guarantees as the rest of the
+// CLI's Go API.
+//
+// A typical command test is a table of scenarios run against a mock API:
+//
+//	func TestDescribe(t *testing.T) {
+//		testutil.RunScenarios(t, []testutil.TestScenario{
+//			{
+//				Name:      "validate missing --id flag",
+//				Args:      testutil.Args("example describe"),
+//				WantError: "required flag --id not provided",
+//			},
+//		})
+//	}
+//
+// Commands that read files relative to the working directory (e.g. the
+// fastly.toml package manifest) can be run inside a temporary environment
+// created by NewEnv and entered with Chdir, both of which are undone when the
+// test completes.
 package testutil
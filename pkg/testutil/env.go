@@ -23,12 +23,16 @@ type EnvOpts struct {
 	Exec  []string // e.g. []string{"npm", "install"}
 }
 
-// NewEnv creates a new test environment and returns the root directory.
+// NewEnv creates a new test environment and returns the root directory, which
+// is removed when the test completes.
 func NewEnv(opts EnvOpts) (rootdir string) {
 	rootdir, err := os.MkdirTemp("", "fastly-temp-*")
 	if err != nil {
 		opts.T.Fatal(err)
 	}
+	opts.T.Cleanup(func() {
+		_ = os.RemoveAll(rootdir)
+	})
 
 	if err := os.MkdirAll(rootdir, 0o750); err != nil {
 		opts.T.Fatal(err)
@@ -81,6 +85,27 @@ func NewEnv(opts EnvOpts) (rootdir string) {
 	return rootdir
 }
 
+// Chdir changes the working directory to dir, restoring the original working
+// directory when the test completes.
+//
+// NOTE: The working directory is process wide, so a test calling Chdir must
+// not run in parallel with other tests.
+func Chdir(t *testing.T, dir string) {
+	t.Helper()
+	pwd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chdir(dir); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		if err := os.Chdir(pwd); err != nil {
+			t.Fatal(err)
+		}
+	})
+}
+
 // createIntermediaryDirectories strips the filename from the given path and
 // appends it to the rootdir so that we can use MkdirAll to create the
 // directory and all its intermediary directories.
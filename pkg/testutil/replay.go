@@ -0,0 +1,38 @@
+package testutil
+
+import (
+	"testing"
+
+	"github.com/fastly/cli/pkg/api"
+	"github.com/fastly/cli/pkg/api/recorder"
+	"github.com/fastly/cli/pkg/app"
+	"github.com/fastly/go-fastly/v6/fastly"
+)
+
+// ReplayAPIClient returns an app.APIClientFactory whose requests are answered
+// from the interactions recorded in the cassette at path (usually a file
+// inside ./testdata/). The test fails if a recorded interaction is never
+// replayed.
+//
+// Cassettes are recorded by running the CLI against the real API with the
+// FASTLY_RECORDER=record and FASTLY_RECORDER_CASSETTE=<path> env vars set.
+func ReplayAPIClient(t *testing.T, path string) app.APIClientFactory {
+	t.Helper()
+	rec, err := recorder.New(recorder.ModeReplay, path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		for _, in := range rec.Unused() {
+			t.Errorf("recorded interaction not replayed: %s %s", in.Request.Method, in.Request.URL)
+		}
+	})
+	return func(token, endpoint string) (api.Interface, error) {
+		client, err := fastly.NewClientForEndpoint(token, endpoint)
+		if err != nil {
+			return nil, err
+		}
+		client.HTTPClient.Transport = rec.Transport(client.HTTPClient.Transport)
+		return client, nil
+	}
+}
@@ -1,6 +1,12 @@
 package testutil
 
-import "github.com/fastly/cli/pkg/mock"
+import (
+	"bytes"
+	"testing"
+
+	"github.com/fastly/cli/pkg/app"
+	"github.com/fastly/cli/pkg/mock"
+)
 
 // TestScenario represents a standard test case to be validated.
 type TestScenario struct {
@@ -11,3 +17,25 @@ type TestScenario struct {
 	WantOutput  string
 	WantOutputs []string
 }
+
+// RunScenarios runs each scenario as a subtest, executing its Args against
+// its mock API and asserting the returned error and command output.
+//
+// An empty WantError asserts the command succeeded.
+func RunScenarios(t *testing.T, scenarios []TestScenario) {
+	t.Helper()
+	for i := range scenarios {
+		s := &scenarios[i]
+		t.Run(s.Name, func(t *testing.T) {
+			var stdout bytes.Buffer
+			opts := NewRunOpts(s.Args, &stdout)
+			opts.APIClient = mock.APIClient(s.API)
+			err := app.Run(opts)
+			AssertErrorContains(t, err, s.WantError)
+			AssertStringContains(t, stdout.String(), s.WantOutput)
+			for _, want := range s.WantOutputs {
+				AssertStringContains(t, stdout.String(), want)
+			}
+		})
+	}
+}
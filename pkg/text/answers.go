@@ -0,0 +1,157 @@
+package text
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// Answers holds values for interactive prompts that were provided ahead of
+// time (via the --answer and --answers-file flags), keyed by prompt name.
+//
+// Prompt names are dot-separated and scoped to the command flow that displays
+// them, e.g. 'init.name' or 'setup.backends.<name>.address'.
+//
+// NOTE: The zero value is usable and simply has no answers, so each prompt
+// falls back to interactive input.
+type Answers map[string]string
+
+// ReadAnswers reads a JSON object of prompt names to answers from path.
+//
+// Objects may be nested, in which case the keys are joined with a dot, so
+// {"init": {"name": "foo"}} is the same as {"init.name": "foo"}. A list of
+// values is joined with a comma, e.g. for multiple authors.
+func ReadAnswers(path string) (Answers, error) {
+	// gosec flagged this:
+	// G304 (CWE-22): Potential file inclusion via variable
+	// Disabling as we need to load the answers file provided by the user.
+	/* #nosec */
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading answers file: %w", err)
+	}
+	var raw map[string]any
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("error parsing answers file '%s': %w", path, err)
+	}
+	a := make(Answers, len(raw))
+	if err := a.add("", raw); err != nil {
+		return nil, fmt.Errorf("error parsing answers file '%s': %w", path, err)
+	}
+	return a, nil
+}
+
+// add sets the answer for the prompt name from a decoded JSON value.
+func (a Answers) add(name string, v any) error {
+	switch v := v.(type) {
+	case map[string]any:
+		for k, v := range v {
+			if name != "" {
+				k = name + "." + k
+			}
+			if err := a.add(k, v); err != nil {
+				return err
+			}
+		}
+	case []any:
+		values := make([]string, 0, len(v))
+		for _, v := range v {
+			s, ok := answerValue(v)
+			if !ok {
+				return fmt.Errorf("answer for '%s' must be a list of strings, numbers or booleans", name)
+			}
+			values = append(values, s)
+		}
+		a[name] = strings.Join(values, ",")
+	default:
+		s, ok := answerValue(v)
+		if !ok {
+			return fmt.Errorf("answer for '%s' must be a string, number or boolean", name)
+		}
+		a[name] = s
+	}
+	return nil
+}
+
+// answerValue formats a decoded JSON scalar as an answer.
+func answerValue(v any) (string, bool) {
+	switch v := v.(type) {
+	case string:
+		return v, true
+	case bool, float64:
+		return fmt.Sprint(v), true
+	}
+	return "", false
+}
+
+// Merge returns the answers combined with the overrides, where the overrides
+// take precedence.
+func (a Answers) Merge(overrides map[string]string) Answers {
+	m := make(Answers, len(a)+len(overrides))
+	for k, v := range a {
+		m[k] = v
+	}
+	for k, v := range overrides {
+		m[k] = v
+	}
+	return m
+}
+
+// Has indicates if an answer was provided for the named prompt.
+func (a Answers) Has(name string) bool {
+	_, ok := a[name]
+	return ok
+}
+
+// HasPrefix indicates if an answer was provided for any prompt whose name
+// starts with the prefix.
+func (a Answers) HasPrefix(prefix string) bool {
+	for k := range a {
+		if strings.HasPrefix(k, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// Input is like the package level Input function but returns the provided
+// answer for the named prompt, if there is one, instead of reading from r.
+//
+// The answer is checked by the validators and an invalid answer is an error,
+// as there is nobody to re-prompt.
+func (a Answers) Input(name string, w io.Writer, prefix string, r io.Reader, validators ...func(string) error) (string, error) {
+	answer, ok := a[name]
+	if !ok {
+		return Input(w, prefix, r, validators...)
+	}
+
+	answer = strings.TrimSpace(answer)
+	for _, validate := range validators {
+		if err := validate(answer); err != nil {
+			return "", fmt.Errorf("invalid answer for '%s': %w", name, err)
+		}
+	}
+	fmt.Fprintf(w, "%s%s\n", Bold(prefix), answer)
+	return answer, nil
+}
+
+// AskYesNo is like the package level AskYesNo function but returns the
+// provided answer for the named prompt, if there is one, instead of reading
+// from r.
+func (a Answers) AskYesNo(name string, w io.Writer, prompt string, r io.Reader) (bool, error) {
+	answer, ok := a[name]
+	if !ok {
+		return AskYesNo(w, prompt, r)
+	}
+
+	fmt.Fprintf(w, "%s%s\n", Bold(prompt), answer)
+	switch strings.ToLower(strings.TrimSpace(answer)) {
+	case "y", "yes", "true":
+		return true, nil
+	case "n", "no", "false":
+		return false, nil
+	}
+	return false, fmt.Errorf("invalid answer for '%s': must be yes or no", name)
+}
@@ -0,0 +1,113 @@
+package text_test
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/fastly/cli/pkg/testutil"
+	"github.com/fastly/cli/pkg/text"
+)
+
+func TestReadAnswers(t *testing.T) {
+	dir := t.TempDir()
+
+	path := filepath.Join(dir, "answers.json")
+	err := os.WriteFile(path, []byte(`{"init.name": "foo", "deploy.create_service": true, "setup.backends.1.port": 443}`), 0o600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	a, err := text.ReadAnswers(path)
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, text.Answers{
+		"init.name":             "foo",
+		"deploy.create_service": "true",
+		"setup.backends.1.port": "443",
+	}, a)
+
+	nested := filepath.Join(dir, "nested.json")
+	err = os.WriteFile(nested, []byte(`{"init": {"author": ["a", "b"], "backends": {"origin": {"port": 443}}}}`), 0o600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	a, err = text.ReadAnswers(nested)
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, text.Answers{
+		"init.author":               "a,b",
+		"init.backends.origin.port": "443",
+	}, a)
+
+	invalid := filepath.Join(dir, "invalid.json")
+	err = os.WriteFile(invalid, []byte(`{"init.author": null}`), 0o600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = text.ReadAnswers(invalid)
+	testutil.AssertErrorContains(t, err, "answer for 'init.author' must be a string, number or boolean")
+
+	err = os.WriteFile(invalid, []byte(`{"init.author": [{"a": "b"}]}`), 0o600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = text.ReadAnswers(invalid)
+	testutil.AssertErrorContains(t, err, "answer for 'init.author' must be a list of strings, numbers or booleans")
+
+	_, err = text.ReadAnswers(filepath.Join(dir, "missing.json"))
+	testutil.AssertErrorContains(t, err, "error reading answers file")
+}
+
+func TestAnswersMerge(t *testing.T) {
+	var a text.Answers
+	m := a.Merge(map[string]string{"init.name": "bar"})
+	testutil.AssertEqual(t, text.Answers{"init.name": "bar"}, m)
+
+	a = text.Answers{"init.name": "foo", "init.description": "baz"}
+	m = a.Merge(map[string]string{"init.name": "bar"})
+	testutil.AssertEqual(t, text.Answers{"init.name": "bar", "init.description": "baz"}, m)
+	testutil.AssertString(t, "foo", a["init.name"])
+}
+
+func TestAnswersInput(t *testing.T) {
+	a := text.Answers{"init.name": " foo "}
+
+	var buf bytes.Buffer
+	answer, err := a.Input("init.name", &buf, "Name: ", strings.NewReader("ignored\n"))
+	testutil.AssertNoError(t, err)
+	testutil.AssertString(t, "foo", answer)
+	testutil.AssertString(t, "Name: foo\n", buf.String())
+
+	// Unanswered prompts read from the reader.
+	answer, err = a.Input("init.description", &buf, "Description: ", strings.NewReader("bar\n"))
+	testutil.AssertNoError(t, err)
+	testutil.AssertString(t, "bar", answer)
+
+	// Answers are validated but, unlike user input, never re-prompted.
+	validate := func(string) error { return errors.New("must be bar") }
+	_, err = a.Input("init.name", &buf, "Name: ", strings.NewReader("bar\n"), validate)
+	testutil.AssertErrorContains(t, err, "invalid answer for 'init.name': must be bar")
+}
+
+func TestAnswersAskYesNo(t *testing.T) {
+	for answer, want := range map[string]bool{
+		"y":     true,
+		"YES":   true,
+		"true":  true,
+		"n":     false,
+		"No":    false,
+		"false": false,
+	} {
+		var buf bytes.Buffer
+		a := text.Answers{"deploy.create_service": answer}
+		got, err := a.AskYesNo("deploy.create_service", &buf, "Create new service: [y/N] ", strings.NewReader(""))
+		testutil.AssertNoError(t, err)
+		testutil.AssertBool(t, want, got)
+	}
+
+	var buf bytes.Buffer
+	a := text.Answers{"deploy.create_service": "maybe"}
+	_, err := a.AskYesNo("deploy.create_service", &buf, "Create new service: [y/N] ", strings.NewReader("y\n"))
+	testutil.AssertErrorContains(t, err, "invalid answer for 'deploy.create_service': must be yes or no")
+}
@@ -1,18 +1,143 @@
 package text
 
-import "github.com/fatih/color"
+import (
+	"strings"
 
-// Bold is a Sprint-class function that makes the arguments bold.
-var Bold = color.New(color.Bold).SprintFunc()
+	"github.com/fatih/color"
+)
 
-// BoldRed is a Sprint-class function that makes the arguments bold and red.
-var BoldRed = color.New(color.Bold, color.FgRed).SprintFunc()
+// The --color flag values.
+const (
+	// ColorAuto colours the output when it's written to a terminal.
+	ColorAuto = "auto"
+	// ColorAlways colours the output, even when it isn't written to a terminal.
+	ColorAlways = "always"
+	// ColorNever never colours the output.
+	ColorNever = "never"
+)
 
-// BoldYellow is a Sprint-class function that makes the arguments bold and yellow.
-var BoldYellow = color.New(color.Bold, color.FgYellow).SprintFunc()
+// ColorModes are the values accepted by the --color flag.
+var ColorModes = []string{ColorAuto, ColorAlways, ColorNever}
 
-// BoldGreen is a Sprint-class function that makes the arguments bold and green.
-var BoldGreen = color.New(color.Bold, color.FgGreen).SprintFunc()
+// ColorOptions determine whether the output is coloured.
+type ColorOptions struct {
+	// Mode is the --color flag value, which defaults to ColorAuto.
+	Mode string
+	// NoColor is the value of the NO_COLOR env var (see https://no-color.org).
+	NoColor string
+	// ForceColor is the value of the FORCE_COLOR env var.
+	ForceColor string
+}
+
+// Enabled reports whether the output written to the terminal is coloured.
+//
+// The --color flag takes precedence, followed by NO_COLOR and then
+// FORCE_COLOR. Otherwise the output is only coloured when it's written to a
+// terminal.
+func (o ColorOptions) Enabled(t Terminal) bool {
+	switch o.Mode {
+	case ColorAlways:
+		return true
+	case ColorNever:
+		return false
+	}
+	if o.NoColor != "" {
+		return false
+	}
+	switch strings.ToLower(o.ForceColor) {
+	case "", "0", "false":
+	default:
+		return true
+	}
+	return t.Color()
+}
+
+// SetColor enables or disables the colouring of the output.
+//
+// NOTE: This is set from the global --color flag.
+func SetColor(enabled bool) {
+	// NOTE: The fatih/color setting is global, so it's only written when it
+	// changes (e.g. not for every run of the application in tests).
+	if color.NoColor == enabled {
+		color.NoColor = !enabled
+	}
+}
+
+// Theme is the set of styles used by the output helpers.
+type Theme struct {
+	// Emphasis styles prompts, headings and highlighted values.
+	Emphasis *color.Color
+	// Info styles the prefix of informational messages.
+	Info *color.Color
+	// Success styles the prefix of success messages and additions.
+	Success *color.Color
+	// Warning styles the prefix of warnings, and changes.
+	Warning *color.Color
+	// Error styles the prefix of errors, and removals.
+	Error *color.Color
+}
+
+// DefaultTheme is the theme used unless another is set by the --theme flag.
+var DefaultTheme = Theme{
+	Emphasis: color.New(color.Bold),
+	Info:     color.New(color.Bold),
+	Success:  color.New(color.Bold, color.FgGreen),
+	Warning:  color.New(color.Bold, color.FgYellow),
+	Error:    color.New(color.Bold, color.FgRed),
+}
+
+// HighContrastTheme uses bright foreground and background colours, which
+// remain legible on both light and dark terminal backgrounds.
+var HighContrastTheme = Theme{
+	Emphasis: color.New(color.Bold, color.Underline),
+	Info:     color.New(color.Bold, color.FgHiWhite, color.BgBlue),
+	Success:  color.New(color.Bold, color.FgBlack, color.BgHiGreen),
+	Warning:  color.New(color.Bold, color.FgBlack, color.BgHiYellow),
+	Error:    color.New(color.Bold, color.FgHiWhite, color.BgRed),
+}
+
+// Themes are the themes accepted by the --theme flag, keyed by name.
+var Themes = map[string]Theme{
+	"default":       DefaultTheme,
+	"high-contrast": HighContrastTheme,
+}
+
+// ThemeNames are the names of the Themes, in the order they're documented.
+var ThemeNames = []string{"default", "high-contrast"}
+
+// theme is the theme used by the output helpers (see SetTheme).
+var theme = DefaultTheme
+
+// SetTheme sets the theme used by the output helpers.
+//
+// NOTE: This is set from the global --theme flag.
+func SetTheme(t Theme) {
+	theme = t
+}
+
+// Bold is a Sprint-class function that emphasises the arguments (bold in the
+// default theme).
+func Bold(a ...any) string {
+	return theme.Emphasis.Sprint(a...)
+}
+
+// BoldRed is a Sprint-class function that styles the arguments as an error
+// (bold and red in the default theme).
+func BoldRed(a ...any) string {
+	return theme.Error.Sprint(a...)
+}
+
+// BoldYellow is a Sprint-class function that styles the arguments as a warning
+// (bold and yellow in the default theme).
+func BoldYellow(a ...any) string {
+	return theme.Warning.Sprint(a...)
+}
+
+// BoldGreen is a Sprint-class function that styles the arguments as a success
+// (bold and green in the default theme).
+func BoldGreen(a ...any) string {
+	return theme.Success.Sprint(a...)
+}
 
 // Reset is a Sprint-class function that resets the color for the arguments.
 var Reset = color.New(color.Reset).SprintFunc()
@@ -0,0 +1,86 @@
+package text_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/fastly/cli/pkg/testutil"
+	"github.com/fastly/cli/pkg/text"
+)
+
+func TestColorOptions(t *testing.T) {
+	for _, testcase := range []struct {
+		name     string
+		opts     text.ColorOptions
+		terminal text.Terminal
+		want     bool
+	}{
+		{
+			name:     "auto with a terminal",
+			opts:     text.ColorOptions{Mode: text.ColorAuto},
+			terminal: text.Terminal{Stdout: true},
+			want:     true,
+		},
+		{
+			name: "auto without a terminal",
+			opts: text.ColorOptions{Mode: text.ColorAuto},
+			want: false,
+		},
+		{
+			name:     "NO_COLOR",
+			opts:     text.ColorOptions{NoColor: "1"},
+			terminal: text.Terminal{Stdout: true},
+			want:     false,
+		},
+		{
+			name: "FORCE_COLOR",
+			opts: text.ColorOptions{ForceColor: "1"},
+			want: true,
+		},
+		{
+			name: "FORCE_COLOR disabled",
+			opts: text.ColorOptions{ForceColor: "0"},
+			want: false,
+		},
+		{
+			name: "NO_COLOR takes precedence over FORCE_COLOR",
+			opts: text.ColorOptions{NoColor: "1", ForceColor: "1"},
+			want: false,
+		},
+		{
+			name: "--color always takes precedence over NO_COLOR",
+			opts: text.ColorOptions{Mode: text.ColorAlways, NoColor: "1"},
+			want: true,
+		},
+		{
+			name:     "--color never takes precedence over FORCE_COLOR",
+			opts:     text.ColorOptions{Mode: text.ColorNever, ForceColor: "1"},
+			terminal: text.Terminal{Stdout: true},
+			want:     false,
+		},
+	} {
+		t.Run(testcase.name, func(t *testing.T) {
+			testutil.AssertBool(t, testcase.want, testcase.opts.Enabled(testcase.terminal))
+		})
+	}
+}
+
+func TestTheme(t *testing.T) {
+	text.SetColor(true)
+	defer text.SetColor(false)
+	defer text.SetTheme(text.DefaultTheme)
+
+	var buf bytes.Buffer
+	text.Warning(&buf, "Deprecated.")
+	testutil.AssertStringContains(t, buf.String(), "\x1b[1;33mWARNING: \x1b[0m")
+
+	buf.Reset()
+	text.SetTheme(text.Themes["high-contrast"])
+	text.Warning(&buf, "Deprecated.")
+	testutil.AssertStringContains(t, buf.String(), "\x1b[1;30;103mWARNING: \x1b[0m")
+
+	text.SetColor(false)
+	buf.Reset()
+	text.Warning(&buf, "Deprecated.")
+	testutil.AssertString(t, "\nWARNING: Deprecated.\n", buf.String())
+}
@@ -0,0 +1,75 @@
+package text
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Diff writes a line based diff of a and b to w.
+//
+// Removed lines are prefixed with '-', added lines with '+' and unchanged
+// lines with a space. Nothing is written when a and b are identical.
+func Diff(w io.Writer, a, b string) {
+	if a == b {
+		return
+	}
+	x := splitLines(a)
+	y := splitLines(b)
+
+	// lcs[i][j] is the length of the longest common subsequence of x[i:] and y[j:].
+	lcs := make([][]int, len(x)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(y)+1)
+	}
+	for i := len(x) - 1; i >= 0; i-- {
+		for j := len(y) - 1; j >= 0; j-- {
+			if x[i] == y[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	i, j := 0, 0
+	for i < len(x) || j < len(y) {
+		switch {
+		case i < len(x) && j < len(y) && x[i] == y[j]:
+			fmt.Fprintln(w, diffLine(" ", x[i]))
+			i++
+			j++
+		case i < len(x) && (j == len(y) || lcs[i+1][j] >= lcs[i][j+1]):
+			fmt.Fprintln(w, BoldRed(diffLine("-", x[i])))
+			i++
+		default:
+			fmt.Fprintln(w, BoldGreen(diffLine("+", y[j])))
+			j++
+		}
+	}
+}
+
+// diffLine prefixes a line with its diff marker, avoiding trailing whitespace
+// for empty lines.
+func diffLine(marker, line string) string {
+	if line == "" {
+		return strings.TrimSpace(marker)
+	}
+	return marker + " " + line
+}
+
+// splitLines splits s into lines, ignoring a trailing newline.
+func splitLines(s string) []string {
+	s = strings.TrimSuffix(s, "\n")
+	if s == "" {
+		return nil
+	}
+	return strings.Split(s, "\n")
+}
+
+func max(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
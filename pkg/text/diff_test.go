@@ -0,0 +1,19 @@
+package text_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/fastly/cli/pkg/testutil"
+	"github.com/fastly/cli/pkg/text"
+)
+
+func TestDiff(t *testing.T) {
+	var buf bytes.Buffer
+	text.Diff(&buf, "a\nb\n\nc\n", "a\nx\n\nc\nd\n")
+	testutil.AssertString(t, "  a\n- b\n+ x\n\n  c\n+ d\n", buf.String())
+
+	buf.Reset()
+	text.Diff(&buf, "same\n", "same\n")
+	testutil.AssertString(t, "", buf.String())
+}
@@ -6,15 +6,11 @@ import (
 	"encoding/hex"
 	"fmt"
 	"io"
-	"os"
 	"runtime"
 	"strings"
 	"sync"
 	"time"
 	"unicode/utf8"
-
-	fstruntime "github.com/fastly/cli/pkg/runtime"
-	"github.com/mattn/go-isatty"
 )
 
 // Progress is a producer contract, abstracting over the quiet and verbose
@@ -23,6 +19,9 @@ import (
 // call Step for each new major step of their procedural code, and Write with
 // the verbose or detailed output of those steps. Callers must eventually call
 // either Done or Fail, to signal success or failure respectively.
+//
+// NOTE: A Progress displays one step at a time, use Tasks for steps that run
+// concurrently.
 type Progress interface {
 	io.Writer
 	Tick(rune)
@@ -41,12 +40,16 @@ type ProgressOptions struct {
 type Option func(*ProgressOptions)
 
 // NewProgress returns a Progress based on the given verbosity level or whether
-// the current process is running in a terminal environment.
+// the current process is running in a terminal environment. No progress is
+// displayed at all when quiet output has been requested.
 func NewProgress(output io.Writer, verbose bool, options ...Option) Progress {
 	var progress Progress
+	if quiet {
+		return NewNullProgress()
+	}
 	if verbose {
 		progress = NewVerboseProgress(output)
-	} else if isTerminal() {
+	} else if DetectTerminal(nil).Spinner() {
 		progress = NewInteractiveProgress(output, options...)
 	} else {
 		progress = NewQuietProgress(output)
@@ -73,17 +76,6 @@ func WithReset() Option {
 	}
 }
 
-// isTerminal indicates if the consumer is a modern terminal.
-//
-// EXAMPLE: If the user is on a standard Windows 'command prompt' the spinner
-// output doesn't work, nor does any colour output, so we avoid both features.
-func isTerminal() bool {
-	if isatty.IsTerminal(os.Stdout.Fd()) && !fstruntime.Windows || isatty.IsCygwinTerminal(os.Stdout.Fd()) {
-		return true
-	}
-	return false
-}
-
 // Ticker is a small consumer contract for the Spin function,
 // capturing part of the Progress interface.
 type Ticker interface {
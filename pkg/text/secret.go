@@ -0,0 +1,26 @@
+package text
+
+// SecretMask replaces the value of a secret in command output.
+const SecretMask = "********"
+
+// showSecrets disables the masking of secrets (see SetShowSecrets).
+var showSecrets bool
+
+// SetShowSecrets toggles whether secrets such as tokens, passwords and keys
+// are displayed in command output rather than masked.
+//
+// NOTE: This is set from the global --show-secrets flag.
+func SetShowSecrets(v bool) {
+	showSecrets = v
+}
+
+// Secret returns s masked with SecretMask, unless secrets are being shown.
+//
+// NOTE: An empty value isn't masked so users can still tell whether a secret
+// has been set.
+func Secret(s string) string {
+	if showSecrets || s == "" {
+		return s
+	}
+	return SecretMask
+}
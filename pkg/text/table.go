@@ -3,8 +3,11 @@ package text
 import (
 	"fmt"
 	"io"
+	"sort"
+	"strconv"
 	"strings"
 	"text/tabwriter"
+	"unicode"
 )
 
 var (
@@ -12,46 +15,232 @@ var (
 	headerStyle = Bold
 )
 
+// TableOptions customise how every table is rendered.
+//
+// NOTE: These are set from the global --columns and --sort-by flags so users
+// can reshape list output without piping it through other tools.
+type TableOptions struct {
+	// Columns is the ordered list of column keys to display (all when empty).
+	Columns []string
+	// SortBy is the column key to sort rows by, prefixed with a hyphen for
+	// descending order.
+	SortBy string
+}
+
+var tableOptions TableOptions
+
+// SetTableOptions configures the rendering of all subsequently printed tables.
+func SetTableOptions(o TableOptions) {
+	tableOptions = o
+}
+
+// Column is an entry in a table's column registry.
+type Column struct {
+	// Key identifies the column in the --columns and --sort-by flags.
+	Key string
+	// Header is the column's display name.
+	Header string
+}
+
 // Table wraps an instance of a tabwriter and provides helper methods to easily
 // create a table, add a header, add rows and print to the writer.
+//
+// Rows are buffered until Print is called so the user's column selection and
+// sort order can be applied to the whole table.
 type Table struct {
-	writer *tabwriter.Writer
+	columns []Column
+	header  bool
+	out     io.Writer
+	rows    [][]string
+	writer  *tabwriter.Writer
 }
 
 // NewTable contructs a new Table.
 func NewTable(w io.Writer) *Table {
 	return &Table{
+		out:    w,
 		writer: tabwriter.NewWriter(w, 0, 2, 2, ' ', 0),
 	}
 }
 
 // AddLine writes a new row to the table.
 func (t *Table) AddLine(args ...any) {
-	var b strings.Builder
-	for i := range args {
-		b.WriteString(lineStyle(`%v`))
-		if i+1 != len(args) {
-			b.WriteString("\t")
-		}
+	row := make([]string, len(args))
+	for i, a := range args {
+		row[i] = fmt.Sprint(a)
 	}
-	b.WriteString("\n")
-	fmt.Fprintf(t.writer, b.String(), args...)
+	t.rows = append(t.rows, row)
 }
 
 // AddHeader writes a table header line.
+//
+// Each column's key is derived from its header, e.g. "LAST EDITED (UTC)" is
+// selected with --columns last_edited_utc. Use AddColumns for explicit keys.
 func (t *Table) AddHeader(args ...any) {
-	var b strings.Builder
-	for i := range args {
-		b.WriteString(headerStyle(`%s`))
-		if i+1 != len(args) {
-			b.WriteString("\t")
-		}
+	cs := make([]Column, len(args))
+	for i, a := range args {
+		h := fmt.Sprint(a)
+		cs[i] = Column{Key: ColumnKey(h), Header: h}
 	}
-	b.WriteString("\n")
-	fmt.Fprintf(t.writer, b.String(), args...)
+	t.AddColumns(cs...)
+}
+
+// AddColumns registers the table's columns and writes their header line.
+func (t *Table) AddColumns(cs ...Column) {
+	t.columns = cs
+	t.header = true
 }
 
 // Print writes the table to the writer.
 func (t *Table) Print() {
+	indexes := t.selectColumns()
+	t.sortRows()
+
+	if t.header {
+		cells := make([]string, 0, len(t.columns))
+		if indexes == nil {
+			for _, c := range t.columns {
+				cells = append(cells, c.Header)
+			}
+		}
+		for _, idx := range indexes {
+			cells = append(cells, t.columns[idx].Header)
+		}
+		writeRow(t.writer, headerStyle, cells)
+	}
+	for _, row := range t.rows {
+		cells := row
+		if indexes != nil {
+			cells = make([]string, 0, len(indexes))
+			for _, idx := range indexes {
+				if idx < len(row) {
+					cells = append(cells, row[idx])
+				}
+			}
+		}
+		writeRow(t.writer, lineStyle, cells)
+	}
 	t.writer.Flush()
+
+	t.header = false
+	t.rows = nil
+}
+
+// selectColumns returns the indexes of the columns to display.
+//
+// A nil slice means every column of each row is displayed. Unknown keys are
+// reported and otherwise ignored.
+func (t *Table) selectColumns() []int {
+	if len(t.columns) == 0 || len(tableOptions.Columns) == 0 {
+		return nil
+	}
+
+	var indexes []int
+	for _, k := range tableOptions.Columns {
+		k = strings.TrimSpace(k)
+		if k == "" {
+			continue
+		}
+		idx := t.columnIndex(k)
+		if idx < 0 {
+			t.unknownColumn("--columns", k)
+			continue
+		}
+		indexes = append(indexes, idx)
+	}
+	return indexes
+}
+
+// sortRows orders the buffered rows by the --sort-by column.
+func (t *Table) sortRows() {
+	key := strings.TrimSpace(tableOptions.SortBy)
+	if key == "" || len(t.columns) == 0 {
+		return
+	}
+	desc := strings.HasPrefix(key, "-")
+	key = strings.TrimPrefix(key, "-")
+
+	idx := t.columnIndex(key)
+	if idx < 0 {
+		t.unknownColumn("--sort-by", key)
+		return
+	}
+
+	sort.SliceStable(t.rows, func(i, j int) bool {
+		a, b := cell(t.rows[i], idx), cell(t.rows[j], idx)
+		if desc {
+			return less(b, a)
+		}
+		return less(a, b)
+	})
+}
+
+// columnIndex returns the position of the column identified by key, or -1.
+func (t *Table) columnIndex(key string) int {
+	for i, c := range t.columns {
+		if strings.EqualFold(c.Key, key) {
+			return i
+		}
+	}
+	return -1
+}
+
+// unknownColumn warns about a column key missing from the table's registry.
+func (t *Table) unknownColumn(flag, key string) {
+	keys := make([]string, len(t.columns))
+	for i, c := range t.columns {
+		keys[i] = c.Key
+	}
+	Warning(t.out, "Unknown %s value '%s' (available: %s).", flag, key, strings.Join(keys, ", "))
+}
+
+// ColumnKey derives a column key from a table header by lowercasing it and
+// replacing each run of non-alphanumeric characters with an underscore.
+func ColumnKey(header string) string {
+	var b strings.Builder
+	sep := false
+	for _, r := range strings.ToLower(header) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			if sep && b.Len() > 0 {
+				b.WriteByte('_')
+			}
+			b.WriteRune(r)
+			sep = false
+			continue
+		}
+		sep = true
+	}
+	return b.String()
+}
+
+// cell returns the value of a row's column, allowing for short rows.
+func cell(row []string, idx int) string {
+	if idx < len(row) {
+		return row[idx]
+	}
+	return ""
+}
+
+// less compares two cells numerically when both are numbers and lexically
+// otherwise.
+func less(a, b string) bool {
+	x, errA := strconv.ParseFloat(a, 64)
+	y, errB := strconv.ParseFloat(b, 64)
+	if errA == nil && errB == nil {
+		return x < y
+	}
+	return a < b
+}
+
+// writeRow writes a tab separated line of styled cells.
+func writeRow(w io.Writer, style func(...any) string, cells []string) {
+	var b strings.Builder
+	for i, c := range cells {
+		b.WriteString(style(c))
+		if i+1 != len(cells) {
+			b.WriteString("\t")
+		}
+	}
+	b.WriteString("\n")
+	fmt.Fprint(w, b.String())
 }
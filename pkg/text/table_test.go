@@ -0,0 +1,104 @@
+package text_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/fastly/cli/pkg/testutil"
+	"github.com/fastly/cli/pkg/text"
+)
+
+func TestTable(t *testing.T) {
+	for _, testcase := range []struct {
+		name       string
+		opts       text.TableOptions
+		wantOutput string
+	}{
+		{
+			name: "defaults",
+			wantOutput: "NAME  COUNT  LAST EDITED (UTC)\n" +
+				"b     10     2021-06-02\n" +
+				"a     9      2021-06-01\n" +
+				"c     100    2021-06-03\n",
+		},
+		{
+			name: "columns",
+			opts: text.TableOptions{Columns: []string{"last_edited_utc", "name"}},
+			wantOutput: "LAST EDITED (UTC)  NAME\n" +
+				"2021-06-02         b\n" +
+				"2021-06-01         a\n" +
+				"2021-06-03         c\n",
+		},
+		{
+			name: "sort ascending",
+			opts: text.TableOptions{SortBy: "name"},
+			wantOutput: "NAME  COUNT  LAST EDITED (UTC)\n" +
+				"a     9      2021-06-01\n" +
+				"b     10     2021-06-02\n" +
+				"c     100    2021-06-03\n",
+		},
+		{
+			name: "sort descending numerically",
+			opts: text.TableOptions{Columns: []string{"count"}, SortBy: "-COUNT"},
+			wantOutput: "COUNT\n" +
+				"100\n" +
+				"10\n" +
+				"9\n",
+		},
+		{
+			name: "unknown keys",
+			opts: text.TableOptions{Columns: []string{"name", "nope"}, SortBy: "missing"},
+			wantOutput: "\nWARNING: Unknown --columns value 'nope' (available: name, count, last_edited_utc).\n\n" +
+				"WARNING: Unknown --sort-by value 'missing' (available: name, count, last_edited_utc).\n" +
+				"NAME\n" +
+				"b\n" +
+				"a\n" +
+				"c\n",
+		},
+	} {
+		t.Run(testcase.name, func(t *testing.T) {
+			text.SetTableOptions(testcase.opts)
+			defer text.SetTableOptions(text.TableOptions{})
+
+			var buf bytes.Buffer
+			tw := text.NewTable(&buf)
+			tw.AddHeader("NAME", "COUNT", "LAST EDITED (UTC)")
+			tw.AddLine("b", 10, "2021-06-02")
+			tw.AddLine("a", 9, "2021-06-01")
+			tw.AddLine("c", 100, "2021-06-03")
+			tw.Print()
+			testutil.AssertString(t, testcase.wantOutput, buf.String())
+		})
+	}
+}
+
+func TestTableColumns(t *testing.T) {
+	text.SetTableOptions(text.TableOptions{Columns: []string{"updated_at"}})
+	defer text.SetTableOptions(text.TableOptions{})
+
+	var buf bytes.Buffer
+	tw := text.NewTable(&buf)
+	tw.AddColumns(
+		text.Column{Key: "name", Header: "NAME"},
+		text.Column{Key: "updated_at", Header: "LAST EDITED (UTC)"},
+	)
+	tw.AddLine("a", "2021-06-01")
+	tw.Print()
+
+	// Later rows keep the column selection but don't repeat the header.
+	tw.AddLine("b", "2021-06-02")
+	tw.Print()
+
+	testutil.AssertString(t, "LAST EDITED (UTC)\n2021-06-01\n2021-06-02\n", buf.String())
+}
+
+func TestColumnKey(t *testing.T) {
+	for header, want := range map[string]string{
+		"NAME":              "name",
+		"ACTIVE VERSION":    "active_version",
+		"LAST EDITED (UTC)": "last_edited_utc",
+		"TLS-ID":            "tls_id",
+	} {
+		testutil.AssertString(t, want, text.ColumnKey(header))
+	}
+}
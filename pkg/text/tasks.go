@@ -0,0 +1,316 @@
+package text
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+)
+
+// MaxConcurrentTasks is the number of tasks started by Tasks.Go that run at
+// once.
+const MaxConcurrentTasks = 4
+
+// tasksMode determines how Tasks are displayed.
+type tasksMode int
+
+const (
+	tasksQuiet tasksMode = iota
+	tasksPlain
+	tasksVerbose
+	tasksInteractive
+)
+
+// Tasks displays the progress of a group of tasks, which can run concurrently
+// (see Go). Unlike a Progress, which displays a single step at a time, each
+// running task is displayed along with its elapsed time, and the tasks are
+// summarised once they've completed (see Wait).
+//
+// How the tasks are displayed depends on the output:
+//
+//   - In a terminal, each running task has a spinner line of its own, which is
+//     replaced by a ✓ or ✗ line once the task completes.
+//   - Otherwise (e.g. in CI), a line is written as each task completes, so the
+//     output of concurrent tasks is never interleaved.
+//   - With --verbose, each line written by a task is prefixed by its title.
+//   - With --quiet, nothing is displayed.
+type Tasks struct {
+	mtx    sync.Mutex
+	output io.Writer
+	mode   tasksMode
+	start  time.Time
+
+	tasks   []*Task // every task started, in start order
+	running []*Task // the tasks displayed with a spinner
+	drawn   int     // the number of spinner lines displayed
+	frame   int     // the current spinner frame
+
+	errs []*error // the errors of the tasks started by Go, in call order
+	wg   sync.WaitGroup
+	sem  chan struct{}
+
+	cancel func()          // tell Spin to stop
+	done   <-chan struct{} // wait for Spin to stop
+}
+
+// NewTasks returns Tasks displayed to the output, based on the given verbosity
+// level or whether the current process is running in a terminal environment.
+// Nothing is displayed when quiet output has been requested.
+func NewTasks(output io.Writer, verbose bool) *Tasks {
+	mode := tasksPlain
+	switch {
+	case quiet:
+		mode = tasksQuiet
+	case verbose:
+		mode = tasksVerbose
+	case DetectTerminal(nil).Spinner():
+		mode = tasksInteractive
+	}
+	return newTasks(output, mode)
+}
+
+func newTasks(output io.Writer, mode tasksMode) *Tasks {
+	t := &Tasks{
+		output: output,
+		mode:   mode,
+		start:  time.Now(),
+		sem:    make(chan struct{}, MaxConcurrentTasks),
+	}
+	if mode == tasksInteractive {
+		var (
+			ctx, cancel = context.WithCancel(context.Background())
+			done        = make(chan struct{})
+		)
+		go func() {
+			Spin(ctx, spinnerFrames, 100*time.Millisecond, t)
+			close(done)
+		}()
+		t.cancel = cancel
+		t.done = done
+	}
+	return t
+}
+
+// spinnerFrames are the frames of the spinner displayed for a running task.
+var spinnerFrames = []rune{'-', '\\', '|', '/'}
+
+// Start starts a task, which must eventually be marked as Done or Fail.
+func (t *Tasks) Start(title string) *Task {
+	task := &Task{
+		tasks: t,
+		title: strings.TrimSpace(title),
+		start: time.Now(),
+	}
+
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+	t.tasks = append(t.tasks, task)
+	switch t.mode {
+	case tasksVerbose:
+		fmt.Fprintln(t.output, task.title)
+	case tasksInteractive:
+		t.running = append(t.running, task)
+		t.redraw()
+	}
+	return task
+}
+
+// Go runs fn as a task in its own goroutine, passing it a writer for the
+// task's detailed output. The task fails if fn returns an error, which is then
+// returned by Wait. At most MaxConcurrentTasks tasks run at once.
+//
+// NOTE: fn can itself call Go, e.g. to start the tasks that depend on it.
+func (t *Tasks) Go(title string, fn func(w io.Writer) error) {
+	var result error
+	t.mtx.Lock()
+	t.errs = append(t.errs, &result)
+	t.mtx.Unlock()
+
+	t.wg.Add(1)
+	go func() {
+		defer t.wg.Done()
+		t.sem <- struct{}{}
+		defer func() { <-t.sem }()
+
+		task := t.Start(title)
+		if err := fn(task); err != nil {
+			result = err
+			task.Fail()
+			return
+		}
+		task.Done()
+	}()
+}
+
+// Wait waits for the tasks started by Go to complete, then summarises the
+// tasks. It returns the error of the first task (in the order Go was called)
+// that failed.
+func (t *Tasks) Wait() error {
+	t.wg.Wait()
+	if t.cancel != nil {
+		t.cancel()
+		<-t.done
+	}
+
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+
+	var err error
+	for _, e := range t.errs {
+		if *e != nil {
+			err = *e
+			break
+		}
+	}
+	var failed int
+	for _, task := range t.tasks {
+		if task.failed {
+			failed++
+		}
+	}
+
+	if t.mode != tasksQuiet && len(t.tasks) > 1 {
+		elapsed := formatElapsed(time.Since(t.start))
+		switch {
+		case failed > 0:
+			fmt.Fprintf(t.output, "%d of %d tasks failed (%s)\n", failed, len(t.tasks), elapsed)
+		default:
+			fmt.Fprintf(t.output, "Completed %d tasks (%s)\n", len(t.tasks), elapsed)
+		}
+	}
+	return err
+}
+
+// Tick implements the Ticker interface, redrawing the spinner lines.
+func (t *Tasks) Tick(_ rune) {
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+	t.frame = (t.frame + 1) % len(spinnerFrames)
+	t.redraw()
+}
+
+// finish displays the completed task.
+//
+// NOTE: The caller must hold the lock.
+func (t *Tasks) finish(task *Task, failed bool) {
+	if task.finished {
+		return
+	}
+	task.finished = true
+	task.failed = failed
+
+	mark := Bold("✓")
+	if failed {
+		mark = Bold("✗")
+	}
+	line := fmt.Sprintf("%s %s (%s)", mark, task.title, formatElapsed(time.Since(task.start)))
+
+	switch t.mode {
+	case tasksQuiet:
+		return
+	case tasksInteractive:
+		for i, r := range t.running {
+			if r == task {
+				t.running = append(t.running[:i], t.running[i+1:]...)
+				break
+			}
+		}
+		t.clear()
+		fmt.Fprintln(t.output, line)
+		t.redraw()
+	default:
+		fmt.Fprintln(t.output, line)
+	}
+}
+
+// clear removes the spinner lines, leaving the cursor at the start of the
+// first of them.
+//
+// NOTE: The caller must hold the lock.
+func (t *Tasks) clear() {
+	for ; t.drawn > 0; t.drawn-- {
+		fmt.Fprint(t.output, "\033[1A\r\033[K")
+	}
+}
+
+// redraw replaces the spinner lines with those of the running tasks.
+//
+// NOTE: The caller must hold the lock.
+func (t *Tasks) redraw() {
+	if t.mode != tasksInteractive {
+		return
+	}
+	t.clear()
+	for _, task := range t.running {
+		status := task.title
+		if task.last != "" {
+			status = fmt.Sprintf("%s %s", task.title, task.last)
+		}
+		fmt.Fprintf(t.output, "%s %s (%s)\n", string(spinnerFrames[t.frame]), status, formatElapsed(time.Since(task.start)))
+		t.drawn++
+	}
+}
+
+// formatElapsed formats the elapsed time of a task, e.g. 1.5s.
+func formatElapsed(d time.Duration) string {
+	return fmt.Sprintf("%.1fs", d.Seconds())
+}
+
+// Task is a task displayed by Tasks. The detailed output of the task is
+// written to it, which is displayed alongside the task's spinner in a
+// terminal, or prefixed by the task's title with --verbose.
+type Task struct {
+	tasks *Tasks
+	title string
+	start time.Time
+
+	buf  bytes.Buffer // receives the Write calls of the current line
+	last string       // the last full line written
+
+	failed   bool
+	finished bool
+}
+
+// Write implements the io.Writer interface.
+func (task *Task) Write(p []byte) (int, error) {
+	t := task.tasks
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+
+	task.buf.Write(p)
+	for {
+		line, err := task.buf.ReadString('\n')
+		if err != nil {
+			// NOTE: The partial line is kept until it's completed.
+			task.buf.Reset()
+			task.buf.WriteString(line)
+			break
+		}
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		task.last = line
+		if t.mode == tasksVerbose {
+			fmt.Fprintf(t.output, "%s: %s\n", strings.TrimSuffix(task.title, "..."), line)
+		}
+	}
+	return len(p), nil
+}
+
+// Done marks the task as successfully completed.
+func (task *Task) Done() {
+	task.tasks.mtx.Lock()
+	defer task.tasks.mtx.Unlock()
+	task.tasks.finish(task, false)
+}
+
+// Fail marks the task as failed.
+func (task *Task) Fail() {
+	task.tasks.mtx.Lock()
+	defer task.tasks.mtx.Unlock()
+	task.tasks.finish(task, true)
+}
@@ -0,0 +1,124 @@
+package text_test
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/fastly/cli/pkg/testutil"
+	"github.com/fastly/cli/pkg/text"
+)
+
+func TestTasks(t *testing.T) {
+	errFirst := errors.New("first")
+	errSecond := errors.New("second")
+
+	for _, testcase := range []struct {
+		name           string
+		verbose        bool
+		tasks          map[string]error
+		wantError      error
+		wantOutput     []string
+		dontWantOutput []string
+	}{
+		{
+			name: "success",
+			tasks: map[string]error{
+				"Creating domain...":  nil,
+				"Creating backend...": nil,
+			},
+			wantOutput: []string{
+				"✓ Creating domain... (",
+				"✓ Creating backend... (",
+				"Completed 3 tasks (",
+			},
+			dontWantOutput: []string{"detail"},
+		},
+		{
+			name:    "verbose",
+			verbose: true,
+			tasks: map[string]error{
+				"Creating domain...": nil,
+			},
+			wantOutput: []string{
+				"Creating domain...\n",
+				"Creating domain: detail for Creating domain...\n",
+				"✓ Creating domain... (",
+			},
+		},
+		{
+			name: "failure",
+			tasks: map[string]error{
+				"Creating domain...":  errFirst,
+				"Creating backend...": errSecond,
+			},
+			wantError: errFirst,
+			wantOutput: []string{
+				"✗ Creating domain... (",
+				"✗ Creating backend... (",
+				"2 of 3 tasks failed (",
+			},
+		},
+	} {
+		t.Run(testcase.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			tasks := text.NewTasks(&buf, testcase.verbose)
+
+			// NOTE: The tasks are started in a consistent order, so that the
+			// error of the first is returned.
+			for _, title := range []string{"Creating domain...", "Creating backend..."} {
+				err, ok := testcase.tasks[title]
+				if !ok {
+					continue
+				}
+				title := title
+				tasks.Go(title, func(w io.Writer) error {
+					fmt.Fprintf(w, "detail for %s\n", title)
+					return err
+				})
+			}
+			tasks.Go("Uploading package...", func(w io.Writer) error {
+				return nil
+			})
+
+			err := tasks.Wait()
+			if err != testcase.wantError {
+				t.Fatalf("want error %v, have %v", testcase.wantError, err)
+			}
+			for _, s := range testcase.wantOutput {
+				testutil.AssertStringContains(t, buf.String(), s)
+			}
+			for _, s := range testcase.dontWantOutput {
+				testutil.AssertStringDoesntContain(t, buf.String(), s)
+			}
+
+			// The line of each task is written in one piece, so the output of
+			// concurrent tasks isn't interleaved.
+			for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+				if strings.Count(line, "(") > 1 {
+					t.Errorf("interleaved line: %q", line)
+				}
+			}
+		})
+	}
+}
+
+func TestTasksNested(t *testing.T) {
+	var buf bytes.Buffer
+	tasks := text.NewTasks(&buf, false)
+	tasks.Go("Creating dictionary 'a'...", func(_ io.Writer) error {
+		for _, key := range []string{"foo", "bar"} {
+			tasks.Go(fmt.Sprintf("Creating dictionary item '%s'...", key), func(_ io.Writer) error {
+				return nil
+			})
+		}
+		return nil
+	})
+	testutil.AssertNoError(t, tasks.Wait())
+	testutil.AssertStringContains(t, buf.String(), "✓ Creating dictionary item 'foo'...")
+	testutil.AssertStringContains(t, buf.String(), "✓ Creating dictionary item 'bar'...")
+	testutil.AssertStringContains(t, buf.String(), "Completed 3 tasks (")
+}
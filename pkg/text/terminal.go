@@ -0,0 +1,63 @@
+package text
+
+import (
+	"io"
+	"os"
+
+	fstruntime "github.com/fastly/cli/pkg/runtime"
+	"github.com/mattn/go-isatty"
+)
+
+// Terminal describes the capabilities of the terminal the CLI is running in.
+// It's the single place that decides whether the CLI prompts for input, and
+// whether it displays colour and the progress spinner.
+type Terminal struct {
+	// Stdin indicates if the input is read from a terminal, so a user is able
+	// to answer prompts.
+	Stdin bool
+	// Stdout indicates if the output is written to a terminal.
+	Stdout bool
+	// Cygwin indicates if the output is written to a Cygwin/MSYS2 terminal.
+	Cygwin bool
+}
+
+// DetectTerminal returns the capabilities of the terminal, where in is the
+// reader user input is read from.
+//
+// NOTE: Only a file (i.e. os.Stdin, or a reader wrapping it that exposes its
+// file descriptor) can be detected as not being a terminal, e.g. when
+// redirected from /dev/null or a pipe in a CI environment. Any other reader is
+// assumed to be answering prompts, as is the case in tests.
+func DetectTerminal(in io.Reader) Terminal {
+	t := Terminal{
+		Stdin:  true,
+		Stdout: isatty.IsTerminal(os.Stdout.Fd()),
+		Cygwin: isatty.IsCygwinTerminal(os.Stdout.Fd()),
+	}
+	switch r := in.(type) {
+	case NonInteractiveReader:
+		t.Stdin = false
+	case interface{ Fd() uintptr }:
+		t.Stdin = isatty.IsTerminal(r.Fd()) || isatty.IsCygwinTerminal(r.Fd())
+	}
+	return t
+}
+
+// Interactive indicates if the user is able to answer prompts.
+func (t Terminal) Interactive() bool {
+	return t.Stdin
+}
+
+// Color indicates if the output can be coloured.
+func (t Terminal) Color() bool {
+	return t.Stdout || t.Cygwin
+}
+
+// Spinner indicates if progress can be displayed with a spinner, which
+// overwrites the current line of output.
+//
+// EXAMPLE: If the user is on a standard Windows 'command prompt' the spinner
+// output doesn't work, so we avoid it.
+func (t Terminal) Spinner() bool {
+	return t.Stdout && !fstruntime.Windows || t.Cygwin
+}
@@ -0,0 +1,45 @@
+package text_test
+
+import (
+	"io"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/fastly/cli/pkg/testutil"
+	"github.com/fastly/cli/pkg/text"
+)
+
+func TestDetectTerminal(t *testing.T) {
+	f, err := os.CreateTemp(t.TempDir(), "stdin")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	for _, testcase := range []struct {
+		name string
+		in   io.Reader
+		want bool
+	}{
+		{
+			name: "reader",
+			in:   strings.NewReader("y\n"),
+			want: true,
+		},
+		{
+			name: "file",
+			in:   f,
+			want: false,
+		},
+		{
+			name: "non-interactive reader",
+			in:   text.NonInteractiveReader{},
+			want: false,
+		},
+	} {
+		t.Run(testcase.name, func(t *testing.T) {
+			testutil.AssertBool(t, testcase.want, text.DetectTerminal(testcase.in).Interactive())
+		})
+	}
+}
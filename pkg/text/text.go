@@ -2,9 +2,9 @@ package text
 
 import (
 	"bufio"
+	"errors"
 	"fmt"
 	"io"
-	"os"
 	"strings"
 	"syscall"
 
@@ -16,6 +16,17 @@ import (
 // general-purpose blocks of text intended for the user.
 const DefaultTextWidth = 90
 
+// quiet suppresses informational output (see SetQuiet).
+var quiet bool
+
+// SetQuiet toggles the suppression of informational and progress output, so
+// that only errors and the data requested by the user are written.
+//
+// NOTE: This is set from the global --quiet flag.
+func SetQuiet(v bool) {
+	quiet = v
+}
+
 // Wrap a string at word boundaries with a maximum line length of width. Each
 // newline-delimited line in the text is trimmed of whitespace before being
 // added to the block for wrapping, which means strings can be declared in the
@@ -70,6 +81,21 @@ func Output(w io.Writer, format string, args ...any) {
 	fmt.Fprintf(w, "%s\n", Wrap(text, DefaultTextWidth))
 }
 
+// ErrNonInteractive is returned when reading user input has been disabled by
+// the --non-interactive flag, the project settings, or because stdin isn't a
+// terminal.
+var ErrNonInteractive = errors.New("user input required but prompts are disabled")
+
+// NonInteractiveReader is an io.Reader that fails every read with
+// ErrNonInteractive. It's used in place of stdin so prompts fail fast instead
+// of waiting on input that will never arrive (e.g. in a CI environment).
+type NonInteractiveReader struct{}
+
+// Read implements the io.Reader interface.
+func (NonInteractiveReader) Read(_ []byte) (int, error) {
+	return 0, ErrNonInteractive
+}
+
 // Input prints the prefix to the writer, and then reads a single line from the
 // reader, trimming writespace. The received line is passed to the validators,
 // and if any of them return a non-nil error, the error is printed to the
@@ -100,11 +126,16 @@ outer:
 }
 
 // InputSecure is like Input but doesn't echo input back to the terminal,
-// if and only if r is os.Stdin.
+// if and only if r is os.Stdin (or a reader wrapping it that exposes its file
+// descriptor).
 func InputSecure(w io.Writer, prefix string, r io.Reader, validators ...func(string) error) (string, error) {
+	if _, ok := r.(NonInteractiveReader); ok {
+		return "", ErrNonInteractive
+	}
+
 	var (
-		f, ok   = r.(*os.File)
-		isStdin = ok && uintptr(f.Fd()) == uintptr(syscall.Stdin)
+		f, ok   = r.(interface{ Fd() uintptr })
+		isStdin = ok && f.Fd() == uintptr(syscall.Stdin)
 	)
 	if !isStdin {
 		return Input(w, prefix, r, validators...)
@@ -167,18 +198,27 @@ func Error(w io.Writer, format string, args ...any) {
 
 // Warning is a wrapper for fmt.Fprintf with a bold yellow "WARNING: " prefix.
 func Warning(w io.Writer, format string, args ...any) {
+	if quiet {
+		return
+	}
 	format = strings.TrimRight(format, "\r\n") + "\n"
 	fmt.Fprintf(w, "\n"+Wrap(BoldYellow("WARNING: ")+format, DefaultTextWidth)+"\n", args...)
 }
 
 // Info is a wrapper for fmt.Fprintf with a bold "INFO: " prefix.
 func Info(w io.Writer, format string, args ...any) {
+	if quiet {
+		return
+	}
 	format = strings.TrimRight(format, "\r\n") + "\n"
-	fmt.Fprintf(w, "\n"+Wrap(Bold("INFO: ")+format, DefaultTextWidth)+"\n", args...)
+	fmt.Fprintf(w, "\n"+Wrap(theme.Info.Sprint("INFO: ")+format, DefaultTextWidth)+"\n", args...)
 }
 
 // Success is a wrapper for fmt.Fprintf with a bold green "SUCCESS: " prefix.
 func Success(w io.Writer, format string, args ...any) {
+	if quiet {
+		return
+	}
 	format = strings.TrimRight(format, "\r\n") + "\n"
 	fmt.Fprintf(w, "\n"+Wrap(BoldGreen("SUCCESS: ")+format, DefaultTextWidth)+"\n", args...)
 }
@@ -190,6 +230,9 @@ func Success(w io.Writer, format string, args ...any) {
 //	To compile the package, run:
 //	    fastly compute build
 func Description(w io.Writer, intro, description string) {
+	if quiet {
+		return
+	}
 	fmt.Fprintf(w, "%s:\n\t%s\n\n", intro, Bold(description))
 }
 
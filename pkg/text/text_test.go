@@ -152,3 +152,45 @@ func TestPrefixes(t *testing.T) {
 		})
 	}
 }
+
+func TestQuiet(t *testing.T) {
+	text.SetQuiet(true)
+	defer text.SetQuiet(false)
+
+	var buf bytes.Buffer
+	text.Info(&buf, "info")
+	text.Success(&buf, "success")
+	text.Warning(&buf, "warning")
+	text.Description(&buf, "intro", "description")
+	progress := text.NewProgress(&buf, false)
+	progress.Step("step")
+	progress.Done()
+	testutil.AssertString(t, "", buf.String())
+
+	text.Error(&buf, "error")
+	testutil.AssertString(t, "\nERROR: error\n", buf.String())
+}
+
+func TestSecret(t *testing.T) {
+	testutil.AssertString(t, text.SecretMask, text.Secret("abc"))
+	testutil.AssertString(t, "", text.Secret(""))
+
+	text.SetShowSecrets(true)
+	defer text.SetShowSecrets(false)
+	testutil.AssertString(t, "abc", text.Secret("abc"))
+}
+
+func TestNonInteractiveReader(t *testing.T) {
+	var buf bytes.Buffer
+
+	_, err := text.Input(&buf, "Name: ", text.NonInteractiveReader{})
+	testutil.AssertErrorContains(t, err, text.ErrNonInteractive.Error())
+
+	_, err = text.InputSecure(&buf, "Token: ", text.NonInteractiveReader{})
+	testutil.AssertErrorContains(t, err, text.ErrNonInteractive.Error())
+
+	_, err = text.AskYesNo(&buf, "Continue? [y/N] ", text.NonInteractiveReader{})
+	if !errors.Is(err, text.ErrNonInteractive) {
+		t.Errorf("want %v, have %v", text.ErrNonInteractive, err)
+	}
+}
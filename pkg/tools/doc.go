@@ -0,0 +1,3 @@
+// Package tools contains abstractions for installing the auxiliary binaries
+// (e.g. Viceroy) used by the CLI, with multiple versions side by side.
+package tools
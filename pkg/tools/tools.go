@@ -0,0 +1,269 @@
+package tools
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/blang/semver"
+	"github.com/fastly/cli/pkg/commands/update"
+	fsterr "github.com/fastly/cli/pkg/errors"
+	"github.com/fastly/cli/pkg/filesystem"
+)
+
+// The supported tools.
+const (
+	NameJSComputeRuntime = "js-compute-runtime"
+	NameViceroy          = "viceroy"
+	NameWasmOpt          = "wasm-opt"
+)
+
+// DirName is the name of the directory, within the directory of the
+// application configuration, the tools are installed in.
+const DirName = "tools"
+
+// Tool is an auxiliary binary downloaded from the releases of a project.
+type Tool struct {
+	Name        string
+	Description string
+	Versioner   update.Versioner
+	// Assets returns the names of the release assets of a version that can be
+	// used on the platform, in order of preference.
+	Assets func(v semver.Version, p update.Platform) []string
+}
+
+// JSComputeRuntime returns the tool for the JavaScript compiler.
+func JSComputeRuntime(v update.Versioner) Tool {
+	return Tool{
+		Name:        NameJSComputeRuntime,
+		Description: "The JavaScript to Wasm compiler",
+		Versioner:   v,
+		Assets: func(version semver.Version, p update.Platform) []string {
+			return []string{fmt.Sprintf("js-compute-runtime-%s-%s-%s.tar.gz", version, assetOS(p.OS), assetArch(p.Arch))}
+		},
+	}
+}
+
+// JSComputeRuntimeVersioner returns the versioner of the JavaScript compiler.
+func JSComputeRuntimeVersioner() *update.GitHub {
+	return update.NewGitHub(update.GitHubOpts{
+		Org:    "fastly",
+		Repo:   "js-compute-runtime",
+		Binary: NameJSComputeRuntime,
+	})
+}
+
+// Viceroy returns the tool for the local testing server.
+func Viceroy(v update.Versioner) Tool {
+	return Tool{
+		Name:        NameViceroy,
+		Description: "The local testing server used by `compute serve`",
+		Versioner:   v,
+		Assets: func(version semver.Version, p update.Platform) []string {
+			return p.Assets(NameViceroy, version, ".tar.gz")
+		},
+	}
+}
+
+// WasmOpt returns the tool for binaryen's Wasm optimizer.
+func WasmOpt(v update.Versioner) Tool {
+	return Tool{
+		Name:        NameWasmOpt,
+		Description: "The Wasm optimizer from binaryen",
+		Versioner:   v,
+		Assets: func(version semver.Version, p update.Platform) []string {
+			return []string{fmt.Sprintf("binaryen-version_%d-%s-%s.tar.gz", version.Major, assetArch(p.Arch), assetOS(p.OS))}
+		},
+	}
+}
+
+// WasmOptVersioner returns the versioner of binaryen's Wasm optimizer.
+//
+// NOTE: binaryen releases are named by a single number (e.g. version_116) and
+// the binary is nested within the archive.
+func WasmOptVersioner() *update.GitHub {
+	return update.NewGitHub(update.GitHubOpts{
+		Org:           "WebAssembly",
+		Repo:          "binaryen",
+		Binary:        NameWasmOpt,
+		ReleasePrefix: "version_",
+		ArchivePath: func(v semver.Version) string {
+			return fmt.Sprintf("binaryen-version_%d/bin/%s", v.Major, NameWasmOpt)
+		},
+	})
+}
+
+// assetOS returns the operating system name used by the release assets of
+// projects other than the CLI and Viceroy.
+func assetOS(goos string) string {
+	if goos == "darwin" {
+		return "macos"
+	}
+	return goos
+}
+
+// assetArch returns the architecture name used by the release assets of
+// projects other than the CLI and Viceroy.
+func assetArch(goarch string) string {
+	if goarch == "amd64" {
+		return "x86_64"
+	}
+	return goarch
+}
+
+// Manager installs tools within a directory, where each version of a tool is
+// installed side by side (e.g. <dir>/viceroy/0.4.0/viceroy).
+type Manager struct {
+	Dir      string
+	Platform update.Platform
+	Tools    []Tool
+}
+
+// Tool returns the named tool.
+func (m Manager) Tool(name string) (Tool, error) {
+	var names []string
+	for _, t := range m.Tools {
+		if t.Name == name {
+			return t, nil
+		}
+		names = append(names, t.Name)
+	}
+	return Tool{}, fsterr.RemediationError{
+		Inner:       fmt.Errorf("unknown tool '%s'", name),
+		Remediation: fmt.Sprintf("Use one of: %s.", strings.Join(names, ", ")),
+	}
+}
+
+// Path returns the path of a version of the tool.
+func (m Manager) Path(t Tool, v semver.Version) string {
+	return filepath.Join(m.Dir, t.Name, v.String(), t.Versioner.Binary())
+}
+
+// Installed returns the installed versions of the tool, in ascending order.
+func (m Manager) Installed(t Tool) ([]semver.Version, error) {
+	entries, err := os.ReadDir(filepath.Join(m.Dir, t.Name))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var versions []semver.Version
+	for _, e := range entries {
+		v, err := semver.Parse(e.Name())
+		if err != nil || !filesystem.FileExists(m.Path(t, v)) {
+			continue
+		}
+		versions = append(versions, v)
+	}
+	sort.Slice(versions, func(i, j int) bool { return versions[i].LT(versions[j]) })
+	return versions, nil
+}
+
+// Latest returns the latest released version of the tool.
+func (m Manager) Latest(ctx context.Context, t Tool) (semver.Version, error) {
+	v, err := t.Versioner.LatestVersion(ctx)
+	if err != nil {
+		return v, fsterr.RemediationError{
+			Inner:       fmt.Errorf("error fetching the latest %s version: %w", t.Name, err),
+			Remediation: fsterr.NetworkRemediation,
+		}
+	}
+	return v, nil
+}
+
+// Install downloads a version of the tool, unless it's already installed, and
+// returns the path and checksum of the binary.
+//
+// A non-empty checksum is verified against the SHA-256 checksum of the binary.
+func (m Manager) Install(ctx context.Context, t Tool, v semver.Version, checksum string) (bin, sum string, err error) {
+	bin = m.Path(t, v)
+	if filesystem.FileExists(bin) {
+		sum, err = Checksum(bin)
+		if err != nil {
+			return bin, sum, err
+		}
+		return bin, sum, verifyChecksum(t, v, checksum, sum)
+	}
+
+	if err := filesystem.MakeDirectoryIfNotExists(filepath.Dir(bin)); err != nil {
+		return bin, sum, fmt.Errorf("error creating %s directory: %w", t.Name, err)
+	}
+	sum, err = Download(ctx, t, m.Platform, v, checksum, bin)
+	return bin, sum, err
+}
+
+// Download downloads a version of the tool built for the platform to dst,
+// replacing any existing file, and returns the checksum of the binary.
+//
+// A non-empty checksum is verified against the SHA-256 checksum of the binary
+// before it's moved to dst.
+func Download(ctx context.Context, t Tool, p update.Platform, v semver.Version, checksum, dst string) (sum string, err error) {
+	t.Versioner.SetAsset(t.Assets(v, p)...)
+	tmp, err := t.Versioner.Download(ctx, v)
+	if err != nil {
+		return sum, fmt.Errorf("error downloading %s %s: %w", t.Name, v, err)
+	}
+	defer os.RemoveAll(tmp)
+
+	sum, err = Checksum(tmp)
+	if err != nil {
+		return sum, err
+	}
+	if err := verifyChecksum(t, v, checksum, sum); err != nil {
+		return sum, err
+	}
+
+	if err := os.Rename(tmp, dst); err != nil {
+		if err := filesystem.CopyFile(tmp, dst); err != nil {
+			return sum, fmt.Errorf("error moving %s binary in place: %w", t.Name, err)
+		}
+	}
+
+	// G302 (CWE-276): Expect file permissions to be 0600 or less
+	// gosec flagged this:
+	// Disabling as the file was not executable without it and we need all users
+	// to be able to execute the binary.
+	/* #nosec */
+	if err := os.Chmod(dst, 0o777); err != nil {
+		return sum, fmt.Errorf("error setting executable permissions on %s binary: %w", t.Name, err)
+	}
+	return sum, nil
+}
+
+// Checksum returns the hex encoded SHA-256 checksum of a file.
+func Checksum(path string) (string, error) {
+	// gosec flagged this:
+	// G304 (CWE-22): Potential file inclusion via variable
+	// Disabling as the path is within the tools directory.
+	/* #nosec */
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// verifyChecksum returns an error if the expected checksum isn't empty and
+// doesn't match the checksum of the binary.
+func verifyChecksum(t Tool, v semver.Version, want, have string) error {
+	if want == "" || strings.EqualFold(want, have) {
+		return nil
+	}
+	return fsterr.RemediationError{
+		Inner:       fmt.Errorf("the checksum of %s %s doesn't match the pinned checksum (want %s, got %s)", t.Name, v, want, have),
+		Remediation: "Check the origin of the binary, or re-pin the tool with `fastly tools pin` if the release was intentionally replaced.",
+	}
+}
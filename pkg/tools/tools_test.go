@@ -0,0 +1,129 @@
+package tools_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/blang/semver"
+	"github.com/fastly/cli/pkg/commands/update"
+	"github.com/fastly/cli/pkg/mock"
+	"github.com/fastly/cli/pkg/tools"
+)
+
+// newManager returns a manager of a Viceroy tool whose download is a file with
+// the given contents.
+func newManager(t *testing.T, contents string) tools.Manager {
+	dir := t.TempDir()
+	downloaded := filepath.Join(dir, "download")
+	if err := os.WriteFile(downloaded, []byte(contents), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	v := mock.Versioner{
+		BinaryFilename: "viceroy",
+		DownloadOK:     true,
+		DownloadedFile: downloaded,
+	}
+	return tools.Manager{
+		Dir:      filepath.Join(dir, tools.DirName),
+		Platform: update.Platform{OS: "linux", Arch: "arm64"},
+		Tools:    []tools.Tool{tools.Viceroy(v)},
+	}
+}
+
+// sumOfFoo is the SHA-256 checksum of "foo".
+const sumOfFoo = "2c26b46b68ffc68ff99b453c1d30413413422d706483bfa0f98a5e886266e7ae"
+
+func TestManagerInstall(t *testing.T) {
+	m := newManager(t, "foo")
+	viceroy, err := m.Tool("viceroy")
+	if err != nil {
+		t.Fatal(err)
+	}
+	v := semver.MustParse("0.4.0")
+
+	bin, sum, err := m.Install(context.Background(), viceroy, v, strings.ToUpper(sumOfFoo))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if want := filepath.Join(m.Dir, "viceroy", "0.4.0", "viceroy"); bin != want {
+		t.Fatalf("want %s, have %s", want, bin)
+	}
+	if sum != sumOfFoo {
+		t.Fatalf("want checksum %s, have %s", sumOfFoo, sum)
+	}
+
+	installed, err := m.Installed(viceroy)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(installed) != 1 || !installed[0].Equals(v) {
+		t.Fatalf("unexpected installed versions: %v", installed)
+	}
+
+	// An installed binary is verified against the pinned checksum too.
+	_, _, err = m.Install(context.Background(), viceroy, v, "abc")
+	if err == nil || !strings.Contains(err.Error(), "doesn't match the pinned checksum") {
+		t.Fatalf("expected a checksum error, have %v", err)
+	}
+}
+
+func TestManagerInstallChecksumMismatch(t *testing.T) {
+	m := newManager(t, "bar")
+	viceroy, err := m.Tool("viceroy")
+	if err != nil {
+		t.Fatal(err)
+	}
+	v := semver.MustParse("0.4.0")
+
+	_, _, err = m.Install(context.Background(), viceroy, v, sumOfFoo)
+	if err == nil || !strings.Contains(err.Error(), "doesn't match the pinned checksum") {
+		t.Fatalf("expected a checksum error, have %v", err)
+	}
+	if _, err := os.Stat(m.Path(viceroy, v)); !os.IsNotExist(err) {
+		t.Fatalf("expected the binary not to be installed, have %v", err)
+	}
+}
+
+func TestManagerTool(t *testing.T) {
+	m := newManager(t, "foo")
+	_, err := m.Tool("nope")
+	if err == nil || !strings.Contains(err.Error(), "unknown tool 'nope'") {
+		t.Fatalf("expected an unknown tool error, have %v", err)
+	}
+}
+
+func TestAssets(t *testing.T) {
+	p := update.Platform{OS: "darwin", Arch: "amd64"}
+
+	for _, testcase := range []struct {
+		tool    tools.Tool
+		version string
+		want    string
+	}{
+		{
+			tool:    tools.JSComputeRuntime(mock.Versioner{}),
+			version: "3.0.0",
+			want:    "js-compute-runtime-3.0.0-macos-x86_64.tar.gz",
+		},
+		{
+			tool:    tools.Viceroy(mock.Versioner{}),
+			version: "0.4.0",
+			want:    "viceroy_v0.4.0_darwin-amd64.tar.gz",
+		},
+		{
+			tool:    tools.WasmOpt(mock.Versioner{}),
+			version: "116.0.0",
+			want:    "binaryen-version_116-x86_64-macos.tar.gz",
+		},
+	} {
+		t.Run(testcase.tool.Name, func(t *testing.T) {
+			assets := testcase.tool.Assets(semver.MustParse(testcase.version), p)
+			if len(assets) != 1 || assets[0] != testcase.want {
+				t.Fatalf("want %s, have %v", testcase.want, assets)
+			}
+		})
+	}
+}